@@ -187,6 +187,17 @@ func (a *Agent) ForceLeaveWithOptions(node string, opts ForceLeaveOpts) error {
 	return err
 }
 
+// Reload reloads the agent configuration from its configuration files, as on
+// SIGHUP, and returns the sections of the configuration which changed.
+func (a *Agent) Reload() (*AgentReloadResponse, error) {
+	var resp AgentReloadResponse
+	_, err := a.client.put("/v1/agent/reload", nil, &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // Servers is used to query the list of servers on a client node.
 func (a *Agent) Servers() ([]string, error) {
 	var resp []string
@@ -495,6 +506,13 @@ func (a AgentMembersNameSort) Less(i, j int) bool {
 
 }
 
+// AgentReloadResponse is the response of reloading the agent configuration.
+type AgentReloadResponse struct {
+	// Changed is the sections of the configuration which changed, such as
+	// "telemetry" or "client.artifact".
+	Changed []string `json:"changed"`
+}
+
 // AgentHealthResponse is the response from the Health endpoint describing an
 // agent's health.
 type AgentHealthResponse struct {
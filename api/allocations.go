@@ -4,6 +4,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -85,6 +86,8 @@ func (a *Allocations) Info(allocID string, q *QueryOptions) (*Allocation, *Query
 //   - terminalSizeCh: A channel to send new tty terminal sizes
 //
 // The call blocks until command terminates (or an error occurs), and returns the exit code.
+// Heartbeats are sent while the command runs, and Nomad clients terminate commands whose
+// session has received neither input nor heartbeats for a minute.
 //
 // Note: for cluster topologies where API consumers don't have network access to
 // Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
@@ -112,6 +115,59 @@ func (a *Allocations) Exec(ctx context.Context,
 	return s.run(ctx)
 }
 
+// DefaultExecCaptureMaxBytes is the maximum size of each of the stdout and
+// stderr output captured by ExecCapture when no maximum is given.
+const DefaultExecCaptureMaxBytes = 1024 * 1024
+
+// ExecResult is the result of a command run with ExecCapture.
+type ExecResult struct {
+	// Stdout and Stderr are the output of the command, each limited to the
+	// maximum size given to ExecCapture.
+	Stdout []byte
+	Stderr []byte
+
+	// ExitCode is the exit code of the command.
+	ExitCode int
+
+	// Truncated is true if the output of the command on stdout or stderr
+	// exceeded the maximum size, in which case the excess was discarded.
+	Truncated bool
+}
+
+// ExecCapture runs a command inside a running task non-interactively, and
+// returns its exit code and output once it terminates. It is a variant of Exec
+// for callers which do not need to stream the input or output of the command:
+// the command runs without a tty and with an empty stdin, and its stdout and
+// stderr are captured separately.
+//
+// The output kept from each of stdout and stderr is limited to maxBytes, or to
+// DefaultExecCaptureMaxBytes if maxBytes is 0, and the result is marked as
+// truncated if the command wrote more. An error is returned if the command
+// could not be run, in which case it has no exit code.
+func (a *Allocations) ExecCapture(ctx context.Context,
+	alloc *Allocation, task string, command []string, maxBytes int,
+	q *QueryOptions) (*ExecResult, error) {
+
+	if maxBytes <= 0 {
+		maxBytes = DefaultExecCaptureMaxBytes
+	}
+	stdout := &cappedBuffer{max: maxBytes}
+	stderr := &cappedBuffer{max: maxBytes}
+
+	exitCode, err := a.Exec(ctx, alloc, task, false, command,
+		bytes.NewReader(nil), stdout, stderr, nil, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecResult{
+		Stdout:    stdout.buf.Bytes(),
+		Stderr:    stderr.buf.Bytes(),
+		ExitCode:  exitCode,
+		Truncated: stdout.truncated || stderr.truncated,
+	}, nil
+}
+
 // Stats gets allocation resource usage statistics about an allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -123,6 +179,29 @@ func (a *Allocations) Stats(alloc *Allocation, q *QueryOptions) (*AllocResourceU
 	return &resp, err
 }
 
+// StatsHistory gets the recent resource usage history of the tasks of an
+// allocation, including tasks which exited recently, keyed by task name. If
+// task is set, only the history of that task is returned.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) StatsHistory(alloc *Allocation, task string, q *QueryOptions) (map[string]*TaskResourceUsageHistory, error) {
+	if task != "" {
+		if q == nil {
+			q = &QueryOptions{}
+		}
+		if q.Params == nil {
+			q.Params = make(map[string]string)
+		}
+		q.Params["task"] = task
+	}
+
+	var resp map[string]*TaskResourceUsageHistory
+	_, err := a.client.query("/v1/client/allocation/"+alloc.ID+"/stats/history", &resp, q)
+	return resp, err
+}
+
 // Checks gets status information for nomad service checks that exist in the allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -134,6 +213,22 @@ func (a *Allocations) Checks(allocID string, q *QueryOptions) (AllocCheckStatuse
 	return resp, err
 }
 
+// Networking gets the network namespace details of an allocation: the ports
+// allocated to it on the host, the ports they are mapped to in its network
+// namespace, and the result of the CNI plugins which configured it.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) Networking(allocID string, q *QueryOptions) (*AllocNetworking, error) {
+	var resp AllocNetworking
+	_, err := a.client.query("/v1/client/allocation/"+allocID+"/networking", &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GC forces a garbage collection of client state for an allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -307,6 +402,19 @@ type AllocationMetric struct {
 	AllocationTime    time.Duration
 	CoalescedFailures int
 	ScoreMetaData     []*NodeScoreMeta
+	PortCollisions    []*PortCollision
+}
+
+// PortCollision describes a static port which could not be reserved on a
+// node because it was already in use. AllocID and JobID are empty if the
+// port is reserved by the node itself.
+type PortCollision struct {
+	NodeID  string
+	HostIP  string
+	Port    int
+	Label   string
+	AllocID string
+	JobID   string
 }
 
 // NodeScoreMeta is used to serialize node scoring metadata
@@ -426,6 +534,63 @@ type AllocNetworkStatus struct {
 	DNS           *DNSConfig
 }
 
+// AllocNetworking describes the network of an allocation, as reported by the
+// client running it.
+type AllocNetworking struct {
+	// Mode is the network mode of the allocation, such as "host", "bridge",
+	// or "cni/<network>".
+	Mode string
+
+	// Ports are the ports allocated to the allocation, sorted by label.
+	Ports []*AllocNetworkingPort
+
+	InterfaceName string
+	Address       string
+	AddressIPv6   string
+	DNS           *DNSConfig
+
+	// CNI is the result of the CNI plugins which configured the network
+	// namespace, or nil if it was not configured with CNI.
+	CNI *CNIResult
+}
+
+// AllocNetworkingPort is a port allocated on the host and the port it is
+// mapped to in the network namespace of the allocation.
+type AllocNetworkingPort struct {
+	Label    string
+	HostIP   string
+	HostPort int
+	To       int
+}
+
+// CNIResult is the result of the CNI plugins which configured the network
+// namespace of an allocation.
+type CNIResult struct {
+	Interfaces []*CNIInterface
+	Routes     []*CNIRoute
+	DNS        *DNSConfig
+}
+
+// CNIInterface is an interface created by a CNI plugin.
+type CNIInterface struct {
+	Name      string
+	MAC       string
+	Sandbox   string
+	Addresses []*CNIAddress
+}
+
+// CNIAddress is an address of a CNI interface.
+type CNIAddress struct {
+	Address string
+	Gateway string
+}
+
+// CNIRoute is a route set by a CNI plugin.
+type CNIRoute struct {
+	Destination string
+	Gateway     string
+}
+
 type AllocatedResources struct {
 	Tasks  map[string]*AllocatedTaskResources
 	Shared AllocatedSharedResources
@@ -563,6 +728,17 @@ type RescheduleEvent struct {
 
 	// PrevNodeID is the node ID of the previous allocation
 	PrevNodeID string
+
+	// Delay is the reschedule delay associated with the attempt
+	Delay time.Duration
+
+	// Jitter is the duration added to Delay to spread out reschedule attempts
+	Jitter time.Duration
+
+	// DelaySource is the reschedule policy the delay was computed from, either
+	// "job" or "node_pool:" followed by the name of the node pool whose
+	// reschedule overrides were applied.
+	DelaySource string
 }
 
 // DesiredTransition is used to mark an allocation as having a desired state
@@ -4,6 +4,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -256,3 +257,23 @@ func (s *execSession) startReceiving(ctx context.Context, conn *websocket.Conn)
 
 	return exitCodeCh, errCh
 }
+
+// cappedBuffer is a buffer which keeps up to max bytes of what is written to
+// it and discards the rest, without failing writes so that the command whose
+// output it captures is not interrupted.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); len(p) > room {
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocations_cappedBuffer(t *testing.T) {
+	testutil.Parallel(t)
+
+	b := &cappedBuffer{max: 8}
+
+	n, err := b.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.Eq(t, 5, n)
+	must.False(t, b.truncated)
+
+	// writes beyond the maximum are accepted but discarded
+	n, err = b.Write([]byte(" world"))
+	must.NoError(t, err)
+	must.Eq(t, 6, n)
+	must.True(t, b.truncated)
+
+	n, err = b.Write([]byte("!"))
+	must.NoError(t, err)
+	must.Eq(t, 1, n)
+	must.Eq(t, "hello wo", b.buf.String())
+}
@@ -59,6 +59,12 @@ type QueryOptions struct {
 	// a read. This allows for lower latency and higher throughput
 	AllowStale bool
 
+	// MaxStale bounds how stale the results of a read may be. A non-leader
+	// server which is staler than this forwards the read to the leader
+	// instead. Setting MaxStale implies AllowStale. The staleness of the
+	// server which serviced the read is returned in QueryMeta.LastContact.
+	MaxStale time.Duration
+
 	// WaitIndex is used to enable a blocking query. Waits
 	// until the timeout or the next index is reached
 	WaitIndex uint64
@@ -98,6 +104,14 @@ type QueryOptions struct {
 	// Currently only supported by specific endpoints.
 	Reverse bool
 
+	// Fields is the list of JSON paths, such as "TaskStates.web.State", to
+	// project each object of a list response onto. The fields which are not
+	// listed are left unset in the decoded list.
+	//
+	// Currently only supported by the allocation, evaluation, job, and node
+	// list endpoints.
+	Fields []string
+
 	// ctx is an optional context pass through to the underlying HTTP
 	// request layer. Use Context() and WithContext() to manage this.
 	ctx context.Context
@@ -194,6 +208,12 @@ type Config struct {
 	// the agent default values will be used.
 	WaitTime time.Duration
 
+	// AllowStale and MaxStale are the defaults of the options of the same
+	// name for all queries. Queries can set AllowStale or MaxStale but not
+	// unset these defaults.
+	AllowStale bool
+	MaxStale   time.Duration
+
 	// TLSConfig provides the various TLS related configurations for the http
 	// client.
 	//
@@ -695,6 +715,9 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.AllowStale {
 		r.params.Set("stale", "")
 	}
+	if q.MaxStale != 0 {
+		r.params.Set("max_stale", durToMsec(q.MaxStale))
+	}
 	if q.WaitIndex != 0 {
 		r.params.Set("index", strconv.FormatUint(q.WaitIndex, 10))
 	}
@@ -716,6 +739,9 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.Reverse {
 		r.params.Set("reverse", "true")
 	}
+	if len(q.Fields) != 0 {
+		r.params.Set("fields", strings.Join(q.Fields, ","))
+	}
 	for k, v := range q.Params {
 		r.params.Set(k, v)
 	}
@@ -842,6 +868,12 @@ func (c *Client) newRequest(method, path string) (*request, error) {
 	if c.config.WaitTime != 0 {
 		r.params.Set("wait", durToMsec(r.config.WaitTime))
 	}
+	if c.config.AllowStale {
+		r.params.Set("stale", "")
+	}
+	if c.config.MaxStale != 0 {
+		r.params.Set("max_stale", durToMsec(r.config.MaxStale))
+	}
 	if c.config.SecretID != "" {
 		r.token = r.config.SecretID
 	}
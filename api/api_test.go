@@ -166,10 +166,12 @@ func TestSetQueryOptions(t *testing.T) {
 		Region:     "foo",
 		Namespace:  "bar",
 		AllowStale: true,
+		MaxStale:   5 * time.Second,
 		WaitIndex:  1000,
 		WaitTime:   100 * time.Second,
 		AuthToken:  "foobar",
 		Reverse:    true,
+		Fields:     []string{"ID", "TaskStates.web.State"},
 	}
 	r.setQueryOptions(q)
 
@@ -185,12 +187,34 @@ func TestSetQueryOptions(t *testing.T) {
 	try("region", "foo")
 	try("namespace", "bar")
 	try("stale", "") // should not be present
+	try("max_stale", "5000ms")
 	try("index", "1000")
 	try("wait", "100000ms")
 	try("reverse", "true")
+	try("fields", "ID,TaskStates.web.State")
 	try("format", "baz")
 }
 
+func TestConfig_StaleDefaults(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, err := NewClient(&Config{
+		Address:    "http://127.0.0.1:4646",
+		AllowStale: true,
+		MaxStale:   2 * time.Second,
+	})
+	must.NoError(t, err)
+
+	r, err := c.newRequest("GET", "/v1/jobs")
+	must.NoError(t, err)
+	must.MapContainsKey(t, r.params, "stale")
+	must.Eq(t, "2000ms", r.params.Get("max_stale"))
+
+	// queries override the max stale duration of the config
+	r.setQueryOptions(&QueryOptions{MaxStale: time.Second})
+	must.Eq(t, "1000ms", r.params.Get("max_stale"))
+}
+
 func TestQueryOptionsContext(t *testing.T) {
 	testutil.Parallel(t)
 	ctx, cancel := context.WithCancel(context.Background())
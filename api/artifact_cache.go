@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+// ArtifactPrefetchRequest contains the artifact to download into the artifact
+// cache of a Node.
+type ArtifactPrefetchRequest struct {
+	NodeID string
+
+	// Artifact must declare a checksum in its options, which is verified
+	// during the download and used to address the artifact in the cache.
+	Artifact *TaskArtifact
+}
+
+// ArtifactPrefetch downloads an artifact into the artifact cache of a Node, so
+// that allocations using the same artifact later are not delayed by the
+// download. If NodeID is unset then the Node receiving the request is
+// targeted. The Node must have the artifact cache enabled.
+func (n *Nodes) ArtifactPrefetch(req *ArtifactPrefetchRequest, qo *QueryOptions) error {
+	if req.Artifact != nil {
+		req.Artifact.Canonicalize()
+	}
+	var out struct{}
+	_, err := n.client.postQuery("/v1/client/artifact/prefetch", req, &out, qo)
+	return err
+}
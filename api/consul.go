@@ -246,6 +246,14 @@ type ConsulUpstream struct {
 	LocalBindSocketMode  string             `mapstructure:"local_bind_socket_mode" hcl:"local_bind_socket_mode,optional"`
 	MeshGateway          *ConsulMeshGateway `mapstructure:"mesh_gateway" hcl:"mesh_gateway,block"`
 	Config               map[string]any     `mapstructure:"config" hcl:"config,block"`
+
+	// ConnectTimeout is the timeout for new network connections from the
+	// proxy to the upstream.
+	ConnectTimeout *time.Duration `mapstructure:"connect_timeout" hcl:"connect_timeout,optional"`
+
+	// PassiveHealthCheck configures outlier detection of the upstream's
+	// instances by the proxy.
+	PassiveHealthCheck *ConsulPassiveHealthCheck `mapstructure:"passive_health_check" hcl:"passive_health_check,block"`
 }
 
 func (cu *ConsulUpstream) Copy() *ConsulUpstream {
@@ -256,6 +264,8 @@ func (cu *ConsulUpstream) Copy() *ConsulUpstream {
 	*up = *cu
 	up.MeshGateway = cu.MeshGateway.Copy()
 	up.Config = maps.Clone(cu.Config)
+	up.ConnectTimeout = pointerCopy(cu.ConnectTimeout)
+	up.PassiveHealthCheck = cu.PassiveHealthCheck.Copy()
 	return up
 }
 
@@ -269,6 +279,27 @@ func (cu *ConsulUpstream) Canonicalize() {
 	}
 }
 
+// ConsulPassiveHealthCheck represents a Consul Connect upstream
+// passive_health_check block.
+type ConsulPassiveHealthCheck struct {
+	// MaxFailures is the number of consecutive failures after which an
+	// upstream instance is ejected from the load balancing pool.
+	MaxFailures int `mapstructure:"max_failures" hcl:"max_failures,optional"`
+
+	// Interval is the time between ejection sweeps of the upstream instances.
+	Interval *time.Duration `mapstructure:"interval" hcl:"interval,optional"`
+}
+
+func (c *ConsulPassiveHealthCheck) Copy() *ConsulPassiveHealthCheck {
+	if c == nil {
+		return nil
+	}
+	return &ConsulPassiveHealthCheck{
+		MaxFailures: c.MaxFailures,
+		Interval:    pointerCopy(c.Interval),
+	}
+}
+
 // ConsulTransparentProxy is used to configure the Envoy sidecar for
 // "transparent proxying", which creates IP tables rules inside the network
 // namespace to ensure traffic flows thru the Envoy proxy
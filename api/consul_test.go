@@ -186,10 +186,16 @@ func TestConsulUpstream_Copy(t *testing.T) {
 			LocalBindSocketPath:  "/var/run/testsocket.sock",
 			LocalBindSocketMode:  "0666",
 			MeshGateway:          &ConsulMeshGateway{Mode: "remote"},
-			Config:               map[string]any{"connect_timeout_ms": 5000},
+			Config:               map[string]any{"protocol": "http"},
+			ConnectTimeout:       pointerOf(5 * time.Second),
+			PassiveHealthCheck: &ConsulPassiveHealthCheck{
+				MaxFailures: 3,
+				Interval:    pointerOf(10 * time.Second),
+			},
 		}
 		result := cu.Copy()
 		must.Eq(t, cu, result)
+		must.NotEqOp(t, cu.PassiveHealthCheck, result.PassiveHealthCheck)
 	})
 }
 
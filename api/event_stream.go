@@ -100,6 +100,17 @@ func (e *Event) Node() (*Node, error) {
 	return out.Node, nil
 }
 
+// NodeEvent returns a NodeEvent struct and the ID of the Node it was added to
+// from a given event payload. If the Event Topic is Node and the Type is
+// NodeEventAdded this will return a valid NodeEvent.
+func (e *Event) NodeEvent() (string, *NodeEvent, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return "", nil, err
+	}
+	return out.NodeID, out.NodeEvent, nil
+}
+
 // NodePool returns a NodePool struct from a given event payload. If the Event
 // Topic is NodePool this will return a valid NodePool.
 func (e *Event) NodePool() (*NodePool, error) {
@@ -126,6 +137,8 @@ type eventPayload struct {
 	Evaluation *Evaluation          `mapstructure:"Evaluation"`
 	Job        *Job                 `mapstructure:"Job"`
 	Node       *Node                `mapstructure:"Node"`
+	NodeEvent  *NodeEvent           `mapstructure:"NodeEvent"`
+	NodeID     string               `mapstructure:"NodeID"`
 	NodePool   *NodePool            `mapstructure:"NodePool"`
 	Service    *ServiceRegistration `mapstructure:"Service"`
 }
@@ -325,6 +325,23 @@ func TestEventStream_PayloadValueHelpers(t *testing.T) {
 				}, n)
 			},
 		},
+		{
+			desc:  "node_event",
+			input: []byte(`{"Topic": "Node", "Type": "NodeEventAdded", "Payload": {"NodeID": "some-id", "NodeEvent": {"Message": "Node marked as ineligible", "Subsystem": "Cluster", "Actor": "operator", "AccessorID": "some-accessor-id", "CreateIndex": 10}}}`),
+			expectFn: func(t *testing.T, event Event) {
+				must.Eq(t, TopicNode, event.Topic)
+				nodeID, ne, err := event.NodeEvent()
+				must.NoError(t, err)
+				must.Eq(t, "some-id", nodeID)
+				must.Eq(t, &NodeEvent{
+					Message:     "Node marked as ineligible",
+					Subsystem:   "Cluster",
+					Actor:       "operator",
+					AccessorID:  "some-accessor-id",
+					CreateIndex: 10,
+				}, ne)
+			},
+		},
 		{
 			desc:  "node_pool",
 			input: []byte(`{"Topic":"NodePool","Payload":{"NodePool":{"Description":"prod pool","Name":"prod"}}}`),
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
 	"strconv"
 	"sync"
 	"time"
@@ -137,6 +138,25 @@ func (a *AllocFS) Cat(alloc *Allocation, path string, q *QueryOptions) (io.ReadC
 		})
 }
 
+// Write is used to write the content of r to a file at the given path in an
+// allocation directory, creating the file and its parent directories if
+// necessary. Files can only be written within the directory of a task, as in
+// "web/local/config.json", outside of its secrets and private directories.
+// The permission bits of the file are those of mode, and its content may not
+// exceed 64 MiB.
+func (a *AllocFS) Write(alloc *Allocation, path string, r io.Reader, mode os.FileMode, q *QueryOptions) (*QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	q.Params["path"] = path
+	q.Params["mode"] = strconv.FormatUint(uint64(mode.Perm()), 8)
+
+	return a.client.putQuery(fmt.Sprintf("/v1/client/fs/write/%s", alloc.ID), r, nil, q)
+}
+
 // Stream streams the content of a file blocking on EOF.
 // The parameters are:
 // * path: path to file to stream.
@@ -37,6 +37,17 @@ const (
 	// PeriodicSpecCron is used for a cron spec.
 	PeriodicSpecCron = "cron"
 
+	// PeriodicMissedLaunchSkip, PeriodicMissedLaunchRunOne, and
+	// PeriodicMissedLaunchRunAll are the missed launch policies of periodic
+	// jobs.
+	PeriodicMissedLaunchSkip   = "skip"
+	PeriodicMissedLaunchRunOne = "run_one"
+	PeriodicMissedLaunchRunAll = "run_all"
+
+	// DefaultPeriodicMaxMissedLaunches is the default maximum number of missed
+	// launches of a periodic job made up for with the "run_all" policy.
+	DefaultPeriodicMaxMissedLaunches = 10
+
 	// DefaultNamespace is the default namespace.
 	DefaultNamespace = "default"
 
@@ -240,6 +251,23 @@ func (j *Jobs) Scale(jobID, group string, count *int, message string, error bool
 	return &resp, qm, nil
 }
 
+// ScaleMultiple is used to scale several groups of a job at once, producing a
+// single job version and evaluation. The counts map group names to their new
+// counts.
+func (j *Jobs) ScaleMultiple(jobID string, counts map[string]int64, message string, meta map[string]interface{},
+	q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+
+	req := &ScalingRequest{
+		Counts: counts,
+		Target: map[string]string{
+			"Job": jobID,
+		},
+		Message: message,
+		Meta:    meta,
+	}
+	return j.ScaleWithRequest(jobID, req, q)
+}
+
 // ScaleWithRequest is used to scale a job, giving the caller complete control
 // over the ScalingRequest
 func (j *Jobs) ScaleWithRequest(jobID string, req *ScalingRequest, q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
@@ -486,6 +514,33 @@ func (j *Jobs) PeriodicForce(jobID string, q *WriteOptions) (string, *WriteMeta,
 	return resp.EvalID, wm, nil
 }
 
+// PeriodicStatus returns the latest and upcoming launches of the periodic job.
+// The upcoming launches skip the dates the job excludes.
+func (j *Jobs) PeriodicStatus(jobID string, q *QueryOptions) (*PeriodicStatus, *QueryMeta, error) {
+	var resp PeriodicStatus
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/periodic/status", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// PeriodicStatus is the status of the launches of a periodic job.
+type PeriodicStatus struct {
+	// LastLaunch is the time of the latest launch of the job, or of its
+	// registration if it was never launched.
+	LastLaunch time.Time
+
+	// NextLaunches are the upcoming launch times of the job. It is empty if
+	// the job is stopped or its periodic configuration is disabled.
+	NextLaunches []time.Time
+
+	// ExclusionCalendarError is the error reading the exclusion calendar of
+	// the job, if any, in which case the job is launched as if the calendar
+	// excluded no dates.
+	ExclusionCalendarError string
+}
+
 // PlanOptions is used to pass through job planning parameters
 type PlanOptions struct {
 	Diff           bool
@@ -902,6 +957,22 @@ type PeriodicConfig struct {
 	SpecType        *string
 	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
 	TimeZone        *string `mapstructure:"time_zone" hcl:"time_zone,optional"`
+
+	// Exclusions are the dates on which the job is not launched, in the
+	// YYYY-MM-DD format, any part of which may be "*".
+	Exclusions []string `hcl:"exclusions,optional"`
+
+	// ExclusionCalendar is the path of a variable holding a calendar of
+	// further dates on which the job is not launched.
+	ExclusionCalendar *string `mapstructure:"exclusion_calendar" hcl:"exclusion_calendar,optional"`
+
+	// MissedLaunchPolicy determines how the launches missed while no leader
+	// was running are made up for: "skip", "run_one", or "run_all".
+	MissedLaunchPolicy *string `mapstructure:"missed_launch_policy" hcl:"missed_launch_policy,optional"`
+
+	// MaxMissedLaunches is the maximum number of missed launches made up for
+	// with the "run_all" policy.
+	MaxMissedLaunches *int `mapstructure:"max_missed_launches" hcl:"max_missed_launches,optional"`
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -923,6 +994,12 @@ func (p *PeriodicConfig) Canonicalize() {
 	if p.TimeZone == nil || *p.TimeZone == "" {
 		p.TimeZone = pointerOf("UTC")
 	}
+	if p.MissedLaunchPolicy == nil || *p.MissedLaunchPolicy == "" {
+		p.MissedLaunchPolicy = pointerOf(PeriodicMissedLaunchRunOne)
+	}
+	if p.MaxMissedLaunches == nil {
+		p.MaxMissedLaunches = pointerOf(DefaultPeriodicMaxMissedLaunches)
+	}
 }
 
 // Next returns the closest time instant matching the spec that is after the
@@ -944,7 +1021,8 @@ func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 		if err != nil {
 			return time.Time{}, fmt.Errorf("failed parsing cron expression %s: %v", spec, err)
 		}
-		if nextTime.IsZero() || t.Before(nextTime) {
+		// a spec without further matches does not hide the others
+		if !t.IsZero() && (nextTime.IsZero() || t.Before(nextTime)) {
 			nextTime = t
 		}
 	}
@@ -1126,6 +1204,7 @@ type Job struct {
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
 	UI               *JobUIConfig            `hcl:"ui,block"`
+	VersionRetention *int                    `mapstructure:"version_retention" hcl:"version_retention,optional"`
 
 	/* Fields set by server, not sourced from job config file */
 
@@ -1188,6 +1267,9 @@ func (j *Job) Canonicalize() {
 	if j.NodePool == nil {
 		j.NodePool = pointerOf("")
 	}
+	if j.VersionRetention == nil {
+		j.VersionRetention = pointerOf(0)
+	}
 	if j.Type == nil {
 		j.Type = pointerOf("service")
 	}
@@ -1541,11 +1623,41 @@ type JobPlanResponse struct {
 	FailedTGAllocs     map[string]*AllocationMetric
 	NextPeriodicLaunch time.Time
 
+	// ChangeClass summarizes the effect of the plan on the running job, and is
+	// one of the PlanChangeClass constants.
+	ChangeClass string
+
 	// Warnings contains any warnings about the given job. These may include
 	// deprecation warnings.
 	Warnings string
 }
 
+const (
+	// PlanChangeClassNone is the change class of a plan which does not
+	// change the job.
+	PlanChangeClassNone = "none"
+
+	// PlanChangeClassInPlace is the change class of a plan which changes the
+	// job without replacing any of its allocations, or registers a new job.
+	PlanChangeClassInPlace = "in-place"
+
+	// PlanChangeClassDestructive is the change class of a plan which replaces
+	// or stops allocations of the job other than to scale it.
+	PlanChangeClassDestructive = "destructive"
+
+	// PlanChangeClassScaleOnly is the change class of a plan which only
+	// changes the count of task groups of the job.
+	PlanChangeClassScaleOnly = "scale-only"
+)
+
+const (
+	// The types of the diffs of a job and of its fields and objects.
+	DiffTypeNone    = "None"
+	DiffTypeAdded   = "Added"
+	DiffTypeDeleted = "Deleted"
+	DiffTypeEdited  = "Edited"
+)
+
 type JobDiff struct {
 	Type       string
 	ID         string
@@ -403,6 +403,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				NomadTokenID:      pointerOf(""),
 				Status:            pointerOf(""),
 				StatusDescription: pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -495,6 +496,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				NomadTokenID:      pointerOf(""),
 				Status:            pointerOf(""),
 				StatusDescription: pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -568,6 +570,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ConsulNamespace:   pointerOf(""),
 				VaultNamespace:    pointerOf(""),
 				NomadTokenID:      pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -736,6 +739,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ConsulNamespace:   pointerOf(""),
 				VaultNamespace:    pointerOf(""),
 				NomadTokenID:      pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -861,6 +865,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 										Envvars:       pointerOf(false),
 										VaultGrace:    pointerOf(time.Duration(0)),
 										ErrMissingKey: pointerOf(false),
+										VaultCluster:  pointerOf(""),
 										Once:          pointerOf(false),
 									},
 									{
@@ -876,6 +881,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 										Envvars:       pointerOf(true),
 										VaultGrace:    pointerOf(time.Duration(0)),
 										ErrMissingKey: pointerOf(false),
+										VaultCluster:  pointerOf(""),
 										Once:          pointerOf(false),
 									},
 								},
@@ -904,6 +910,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ConsulNamespace:   pointerOf(""),
 				VaultNamespace:    pointerOf(""),
 				NomadTokenID:      pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -924,12 +931,14 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoPromote:      pointerOf(false),
 				},
 				Periodic: &PeriodicConfig{
-					Enabled:         pointerOf(true),
-					Spec:            pointerOf(""),
-					Specs:           []string{},
-					SpecType:        pointerOf(PeriodicSpecCron),
-					ProhibitOverlap: pointerOf(false),
-					TimeZone:        pointerOf("UTC"),
+					Enabled:            pointerOf(true),
+					Spec:               pointerOf(""),
+					Specs:              []string{},
+					SpecType:           pointerOf(PeriodicSpecCron),
+					ProhibitOverlap:    pointerOf(false),
+					TimeZone:           pointerOf("UTC"),
+					MissedLaunchPolicy: pointerOf(PeriodicMissedLaunchRunOne),
+					MaxMissedLaunches:  pointerOf(DefaultPeriodicMaxMissedLaunches),
 				},
 			},
 		},
@@ -994,6 +1003,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ConsulNamespace:   pointerOf(""),
 				VaultNamespace:    pointerOf(""),
 				NomadTokenID:      pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -1142,6 +1152,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ConsulNamespace:   pointerOf(""),
 				VaultNamespace:    pointerOf(""),
 				NomadTokenID:      pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -1233,6 +1244,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ConsulNamespace:   pointerOf(""),
 				VaultNamespace:    pointerOf(""),
 				NomadTokenID:      pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -1404,6 +1416,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ConsulNamespace:   pointerOf(""),
 				VaultNamespace:    pointerOf(""),
 				NomadTokenID:      pointerOf(""),
+				VersionRetention:  pointerOf(0),
 				Stop:              pointerOf(false),
 				Stable:            pointerOf(false),
 				Version:           pointerOf(uint64(0)),
@@ -2248,6 +2261,7 @@ func TestJobs_Plan(t *testing.T) {
 	must.NotNil(t, planResp.Diff)
 	must.NotNil(t, planResp.Annotations)
 	must.SliceNotEmpty(t, planResp.CreatedEvals)
+	must.Eq(t, PlanChangeClassNone, planResp.ChangeClass)
 	assertWriteMeta(t, wm)
 
 	// Make a plan request w/o the diff
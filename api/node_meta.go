@@ -7,6 +7,10 @@ package api
 type NodeMetaApplyRequest struct {
 	NodeID string
 	Meta   map[string]*string
+
+	// CheckIndex, if set, only applies the update if the Node metadata's
+	// ModifyIndex is equal to it.
+	CheckIndex *uint64
 }
 
 // NodeMetaResponse contains the merged Node metadata.
@@ -19,6 +23,10 @@ type NodeMetaResponse struct {
 
 	// Static is the static Node metadata (set via agent configuration)
 	Static map[string]string
+
+	// ModifyIndex is the index of the Node metadata. It is local to the Node
+	// and may be passed to MetaCAS or used as the WaitIndex of a blocking Read.
+	ModifyIndex uint64
 }
 
 // NodeMeta is a client for manipulating dynamic Node metadata.
@@ -42,12 +50,22 @@ func (n *NodeMeta) Apply(meta *NodeMetaApplyRequest, qo *QueryOptions) (*NodeMet
 	return &out, nil
 }
 
+// MetaCAS applies dynamic Node metadata updates to a Node only if the Node
+// metadata's ModifyIndex is equal to index. If the metadata has been modified
+// since then an error with a 409 status code is returned.
+func (n *Nodes) MetaCAS(meta *NodeMetaApplyRequest, index uint64, qo *QueryOptions) (*NodeMetaResponse, error) {
+	req := *meta
+	req.CheckIndex = &index
+	return n.Meta().Apply(&req, qo)
+}
+
 // Read Node metadata (dynamic and static merged) from a Node directly. May
 // differ from Node.Info as dynamic Node metadata updates are batched and may
 // be delayed up to 10 seconds.
 //
 // If nodeID is empty then the metadata for the Node receiving the request is
-// returned.
+// returned. Setting WaitIndex blocks until the Node metadata's ModifyIndex is
+// greater than it.
 func (n *NodeMeta) Read(nodeID string, qo *QueryOptions) (*NodeMetaResponse, error) {
 	if qo == nil {
 		qo = &QueryOptions{}
@@ -122,6 +122,7 @@ type NodePool struct {
 	Meta                   map[string]string               `hcl:"meta,block"`
 	NodeIdentityTTL        time.Duration                   `hcl:"node_identity_ttl,optional"`
 	SchedulerConfiguration *NodePoolSchedulerConfiguration `hcl:"scheduler_config,block"`
+	RescheduleOverrides    *NodePoolRescheduleOverrides    `hcl:"reschedule_overrides,block"`
 	CreateIndex            uint64
 	ModifyIndex            uint64
 }
@@ -179,3 +180,12 @@ type NodePoolSchedulerConfiguration struct {
 	SchedulerAlgorithm            SchedulerAlgorithm `hcl:"scheduler_algorithm,optional"`
 	MemoryOversubscriptionEnabled *bool              `hcl:"memory_oversubscription_enabled,optional"`
 }
+
+// NodePoolRescheduleOverrides is used to serialize the overrides applied to
+// the reschedule policy of allocations which failed on nodes in a node pool.
+type NodePoolRescheduleOverrides struct {
+	Delay         time.Duration `hcl:"delay,optional"`
+	DelayFunction string        `hcl:"delay_function,optional"`
+	MaxDelay      time.Duration `hcl:"max_delay,optional"`
+	Jitter        time.Duration `hcl:"jitter,optional"`
+}
@@ -472,6 +472,19 @@ func (n *Nodes) Stats(nodeID string, q *QueryOptions) (*HostStats, error) {
 	return &resp, nil
 }
 
+// Debug returns the state of the subsystems of the client nodeID for
+// debugging. Subsystems which could not be collected are listed in the Errors
+// of the result.
+func (n *Nodes) Debug(nodeID string, q *QueryOptions) (*ClientDebug, error) {
+	var resp ClientDebug
+	path := fmt.Sprintf("/v1/client/debug?node_id=%s", nodeID)
+	if _, err := n.client.query(path, &resp, q); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 func (n *Nodes) GC(nodeID string, q *QueryOptions) error {
 	path := fmt.Sprintf("/v1/client/gc?node_id=%s", nodeID)
 	_, err := n.client.query(path, nil, q)
@@ -785,6 +798,15 @@ type NodeEvent struct {
 	Details     map[string]string
 	Timestamp   time.Time
 	CreateIndex uint64
+
+	// Actor identifies who initiated the event: the ACL token name for API
+	// initiated changes, or the server subsystem such as "drainer" or
+	// "heartbeat". Events emitted by the node itself leave it empty.
+	Actor string
+
+	// AccessorID is the accessor ID of the ACL token which initiated the
+	// event, if any.
+	AccessorID string
 }
 
 // HostStats represents resource usage stats of the host running a Nomad client
@@ -798,6 +820,50 @@ type HostStats struct {
 	CPUTicksConsumed float64
 }
 
+// ClientDebug is the state of the subsystems of a client for debugging.
+type ClientDebug struct {
+	Artifacts []*ArtifactDownload
+	Templates map[string]map[string][]*TemplateDependencyState
+	Cgroups   *CgroupLayout
+	GC        *ClientGCStats
+	Errors    map[string]string
+}
+
+// ArtifactDownload is an artifact download in progress on a client.
+type ArtifactDownload struct {
+	Source      string
+	Destination string
+	StartedAt   time.Time
+	Duration    time.Duration
+}
+
+// TemplateDependencyState is the state of the dependencies of a template.
+type TemplateDependencyState struct {
+	DestPath     string
+	Rendered     bool
+	LastRendered time.Time
+	MissingDeps  []string
+	UsedDeps     []string
+}
+
+// CgroupLayout is the layout of the cgroups managed by a client.
+type CgroupLayout struct {
+	Mode    string
+	Root    string
+	Cgroups []string
+}
+
+// ClientGCStats is the state of the allocation garbage collector of a client.
+type ClientGCStats struct {
+	TrackedAllocs       int
+	NumAllocs           int
+	MaxAllocs           int
+	DiskUsageThreshold  float64
+	InodeUsageThreshold float64
+	Collected           uint64
+	LastCollected       time.Time
+}
+
 type HostMemoryStats struct {
 	Total     uint64
 	Available uint64
@@ -80,6 +80,83 @@ func (op *Operator) RaftGetConfiguration(q *QueryOptions) (*RaftConfiguration, e
 	return &out, nil
 }
 
+// RaftPeerStatus is the replication status of a server in the Raft
+// configuration, as known by the leader.
+type RaftPeerStatus struct {
+	// ID is the unique ID for the server.
+	ID string
+
+	// Node is the node name of the server, as known by Nomad, or this
+	// will be set to "(unknown)" otherwise.
+	Node string
+
+	// Address is the IP:port of the server, used for Raft communications.
+	Address string
+
+	// Leader is true if this server is the current cluster leader.
+	Leader bool
+
+	// Voter is true if this server has a vote in the cluster.
+	Voter bool
+
+	// State is the replication state of the server: "leader" for the
+	// leader, "failing" while the leader fails to heartbeat to it,
+	// "replicating" otherwise, or "unknown" if the leader has not fetched
+	// its stats yet.
+	State string
+
+	// LastContact is the time since the server last heard from the leader.
+	LastContact time.Duration
+
+	// MatchIndex is the index of the last log stored by the server.
+	MatchIndex uint64
+
+	// TrailingLogs is the number of logs the server trails the leader by.
+	TrailingLogs uint64
+
+	// ReplicationFailures is the number of consecutive heartbeats from the
+	// leader to the server which have failed.
+	ReplicationFailures uint64
+}
+
+// RaftStatus is returned when querying for the replication status of the
+// servers in the Raft configuration.
+type RaftStatus struct {
+	// Servers has the replication status of each server in the Raft
+	// configuration.
+	Servers []*RaftPeerStatus
+
+	// Term is the current Raft term of the leader.
+	Term uint64
+
+	// LastIndex is the index of the last log stored by the leader.
+	LastIndex uint64
+
+	// CommitIndex is the index of the last log committed by the cluster.
+	CommitIndex uint64
+}
+
+// RaftGetStatus is used to query the replication status of the Raft peer set
+// from the leader.
+func (op *Operator) RaftGetStatus(q *QueryOptions) (*RaftStatus, error) {
+	r, err := op.c.newRequest("GET", "/v1/operator/raft/status")
+	if err != nil {
+		return nil, err
+	}
+	r.setQueryOptions(q)
+	_, resp, err := requireOK(op.c.doRequest(r)) //nolint:bodyclose
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out RaftStatus
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // RaftRemovePeerByAddress is used to kick a stale peer (one that it in the Raft
 // quorum but no longer known to Serf or the catalog) by address in the form of
 // "IP:port".
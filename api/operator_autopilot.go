@@ -34,6 +34,21 @@ type AutopilotConfiguration struct {
 	// applicable with Raft protocol version 3 or higher.
 	ServerStabilizationTime time.Duration
 
+	// MinDiskFree is the minimum free space, in bytes, of the volume holding a
+	// server's Raft data before the server is considered unhealthy. Zero
+	// disables the check.
+	MinDiskFree uint64
+
+	// MaxLastLogAge is how far behind the leader's, in time, the last log
+	// applied by a server can be before the server is considered unhealthy.
+	// Zero disables the check.
+	MaxLastLogAge time.Duration
+
+	// MaxAppliedIndexLag is the number of logs a server can trail the
+	// leader's applied index by before being considered unhealthy. Zero
+	// disables the check.
+	MaxAppliedIndexLag uint64
+
 	// (Enterprise-only) EnableRedundancyZones specifies whether to enable redundancy zones.
 	EnableRedundancyZones bool
 
@@ -62,10 +77,12 @@ func (u *AutopilotConfiguration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		LastContactThreshold    string
 		ServerStabilizationTime string
+		MaxLastLogAge           string
 		*Alias
 	}{
 		LastContactThreshold:    u.LastContactThreshold.String(),
 		ServerStabilizationTime: u.ServerStabilizationTime.String(),
+		MaxLastLogAge:           u.MaxLastLogAge.String(),
 		Alias:                   (*Alias)(u),
 	})
 }
@@ -75,6 +92,7 @@ func (u *AutopilotConfiguration) UnmarshalJSON(data []byte) error {
 	aux := &struct {
 		LastContactThreshold    string
 		ServerStabilizationTime string
+		MaxLastLogAge           string
 		*Alias
 	}{
 		Alias: (*Alias)(u),
@@ -93,6 +111,11 @@ func (u *AutopilotConfiguration) UnmarshalJSON(data []byte) error {
 			return err
 		}
 	}
+	if aux.MaxLastLogAge != "" {
+		if u.MaxLastLogAge, err = time.ParseDuration(aux.MaxLastLogAge); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -134,6 +157,10 @@ type ServerHealth struct {
 
 	// StableSince is the last time this server's Healthy value changed.
 	StableSince time.Time
+
+	// FailedChecks holds the autopilot health check thresholds the server
+	// fails, if any.
+	FailedChecks []string `json:",omitempty"`
 }
 
 func (u *ServerHealth) MarshalJSON() ([]byte, error) {
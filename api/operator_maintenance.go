@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// MaintenanceWindow is a period of planned maintenance during which the nodes
+// it selects are given a longer grace before their disconnected allocations
+// are considered lost and replaced.
+type MaintenanceWindow struct {
+	Name        string
+	Description string
+
+	// Start is the time at which the maintenance window begins.
+	Start time.Time
+
+	// Duration is how long the maintenance window lasts.
+	Duration time.Duration
+
+	// Grace is added to the disconnect lost_after of allocations on selected
+	// nodes while the window is active.
+	Grace time.Duration
+
+	// NodePool and Datacenter select the nodes in the maintenance window. If
+	// both are set nodes must match both.
+	NodePool   string
+	Datacenter string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// End returns the time at which the maintenance window ends.
+func (w *MaintenanceWindow) End() time.Time {
+	return w.Start.Add(w.Duration)
+}
+
+// Active returns true if now is within the maintenance window.
+func (w *MaintenanceWindow) Active(now time.Time) bool {
+	return !now.Before(w.Start) && now.Before(w.End())
+}
+
+// MaintenanceWindows is used to list the maintenance windows.
+func (op *Operator) MaintenanceWindows(q *QueryOptions) ([]*MaintenanceWindow, *QueryMeta, error) {
+	var resp []*MaintenanceWindow
+	qm, err := op.c.query("/v1/operator/maintenance-windows", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// MaintenanceWindow is used to read a maintenance window.
+func (op *Operator) MaintenanceWindow(name string, q *QueryOptions) (*MaintenanceWindow, *QueryMeta, error) {
+	if name == "" {
+		return nil, nil, errors.New("missing maintenance window name")
+	}
+
+	var resp MaintenanceWindow
+	qm, err := op.c.query("/v1/operator/maintenance-window/"+url.PathEscape(name), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// MaintenanceWindowRegister is used to create or update a maintenance window.
+func (op *Operator) MaintenanceWindowRegister(window *MaintenanceWindow, w *WriteOptions) (*WriteMeta, error) {
+	if window == nil {
+		return nil, errors.New("missing maintenance window")
+	}
+	if window.Name == "" {
+		return nil, errors.New("missing maintenance window name")
+	}
+
+	wm, err := op.c.put("/v1/operator/maintenance-window", window, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// MaintenanceWindowDelete is used to delete a maintenance window.
+func (op *Operator) MaintenanceWindowDelete(name string, w *WriteOptions) (*WriteMeta, error) {
+	if name == "" {
+		return nil, errors.New("missing maintenance window name")
+	}
+
+	wm, err := op.c.delete("/v1/operator/maintenance-window/"+url.PathEscape(name), nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
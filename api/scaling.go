@@ -58,6 +58,11 @@ type ScalingRequest struct {
 	Meta    map[string]interface{}
 	WriteRequest
 
+	// Counts maps task group names to their new counts, to scale several
+	// groups at once. It is mutually exclusive with Count and a group in
+	// Target.
+	Counts map[string]int64
+
 	// this is effectively a job update, so we need the ability to override policy.
 	PolicyOverride bool
 
@@ -124,4 +129,8 @@ type ScalingEvent struct {
 	EvalID        *string
 	Time          uint64
 	CreateIndex   uint64
+
+	// Groups are the new counts of every task group scaled by the same
+	// request, when several groups are scaled at once
+	Groups map[string]int64
 }
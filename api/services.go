@@ -59,6 +59,11 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Weights are determined from either Service.Weights or
+	// Service.CanaryWeights and allow consumers to implement weighted
+	// selection of the instances of a service, based on their health.
+	Weights *ServiceWeights
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -247,6 +252,7 @@ type Service struct {
 	OnUpdate          string            `mapstructure:"on_update" hcl:"on_update,optional"`
 	Identity          *WorkloadIdentity `hcl:"identity,block"`
 	Weights           *ServiceWeights   `mapstructure:"weights" hcl:"weights,block"`
+	CanaryWeights     *ServiceWeights   `mapstructure:"canary_weights" hcl:"canary_weights,block"`
 
 	// Provider defines which backend system provides the service registration,
 	// either "consul" (default) or "nomad".
@@ -255,6 +261,10 @@ type Service struct {
 	// Cluster is valid only for Nomad Enterprise with provider: consul
 	Cluster string `hcl:"cluster,optional"`
 
+	// Consul overrides the namespace and admin partition of the group or task
+	// consul block for this service, valid only when provider: consul
+	Consul *Consul `hcl:"consul,block"`
+
 	// Kind defines the consul service kind, valid only when provider: consul
 	Kind string `hcl:"kind,optional"`
 }
@@ -312,6 +322,7 @@ func (s *Service) Canonicalize(t *Task, tg *TaskGroup, job *Job) {
 
 	s.Connect.Canonicalize()
 	s.Weights.Canonicalize()
+	s.CanaryWeights.Canonicalize()
 
 	// Canonicalize CheckRestart on Checks and merge Service.CheckRestart
 	// into each check.
@@ -3,6 +3,12 @@
 
 package api
 
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
 // Status is used to query the status-related endpoints.
 type System struct {
 	client *Client
@@ -19,6 +25,71 @@ func (s *System) GarbageCollect() error {
 	return err
 }
 
+// GarbageCollectOptions is used to target a garbage collection at the given
+// types of objects, or to only report what it would collect.
+type GarbageCollectOptions struct {
+	// Types restricts the garbage collection to any of "jobs", "evals",
+	// "deployments", "volumes", "nodes", and "plugins". All objects are
+	// collected if empty.
+	Types []string
+
+	// DryRun reports the number of objects of each type eligible for garbage
+	// collection without collecting any of them.
+	DryRun bool
+}
+
+// GarbageCollectResponse is the response of a garbage collection.
+type GarbageCollectResponse struct {
+	// Classes is the number of objects of each type eligible for garbage
+	// collection, only set for a dry run.
+	Classes map[string]*GarbageCollectClassStats
+}
+
+// GarbageCollectClassStats reports the objects of a type eligible for garbage
+// collection.
+type GarbageCollectClassStats struct {
+	// Eligible is the number of objects the periodic garbage collection
+	// would collect with the configured Thresholds.
+	Eligible int
+
+	// Forced is the number of objects a forced garbage collection would
+	// collect, as it ignores the thresholds.
+	Forced int
+
+	// Thresholds are the server configured GC thresholds of the type, keyed
+	// by their configuration name.
+	Thresholds map[string]time.Duration
+}
+
+// GarbageCollectOpts is used to garbage collect the objects targeted by opts,
+// or to report the objects eligible for garbage collection for a dry run.
+func (s *System) GarbageCollectOpts(opts *GarbageCollectOptions, q *WriteOptions) (*GarbageCollectResponse, *WriteMeta, error) {
+	endpoint := "/v1/system/gc"
+	if opts != nil {
+		v := url.Values{}
+		if len(opts.Types) > 0 {
+			v.Set("type", strings.Join(opts.Types, ","))
+		}
+		if opts.DryRun {
+			v.Set("dry_run", "true")
+		}
+		if len(v) > 0 {
+			endpoint += "?" + v.Encode()
+		}
+	}
+
+	var resp GarbageCollectResponse
+	var out any
+	if opts != nil && opts.DryRun {
+		out = &resp
+	}
+	wm, err := s.client.put(endpoint, struct{}{}, out, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 func (s *System) ReconcileSummaries() error {
 	var req struct{}
 	_, err := s.client.put("/v1/system/reconcile/summaries", &req, nil, nil)
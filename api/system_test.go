@@ -19,3 +19,25 @@ func TestSystem_GarbageCollect(t *testing.T) {
 	err := e.GarbageCollect()
 	must.NoError(t, err)
 }
+
+func TestSystem_GarbageCollectOpts(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	e := c.System()
+
+	resp, _, err := e.GarbageCollectOpts(&GarbageCollectOptions{
+		Types:  []string{"evals", "deployments"},
+		DryRun: true,
+	}, nil)
+	must.NoError(t, err)
+	must.MapLen(t, 2, resp.Classes)
+	must.MapContainsKey(t, resp.Classes["evals"].Thresholds, "eval_gc_threshold")
+
+	_, _, err = e.GarbageCollectOpts(&GarbageCollectOptions{Types: []string{"evals"}}, nil)
+	must.NoError(t, err)
+
+	_, _, err = e.GarbageCollectOpts(&GarbageCollectOptions{Types: []string{"allocs"}}, nil)
+	must.ErrorContains(t, err, "invalid garbage collection type")
+}
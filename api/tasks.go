@@ -29,6 +29,12 @@ const (
 	ReconcileOptionKeepReplacement = "keep_replacement"
 	ReconcileOptionBestScore       = "best_score"
 	ReconcileOptionLongestRunning  = "longest_running"
+
+	// TaskGroupOnTimeoutFail and TaskGroupOnTimeoutComplete mark the
+	// allocations killed for exceeding the max run time of their task group
+	// as failed or as complete.
+	TaskGroupOnTimeoutFail     = "fail"
+	TaskGroupOnTimeoutComplete = "complete"
 )
 
 // MemoryStats holds memory usage related stats
@@ -77,6 +83,19 @@ type AllocResourceUsage struct {
 	Timestamp     int64
 }
 
+// TaskResourceUsageHistory holds the recent CPU and memory usage of a task,
+// which is kept for a while after the task exits.
+type TaskResourceUsageHistory struct {
+	// Samples are the resource usage of the task, oldest first.
+	Samples []*TaskResourceUsage
+
+	// Resolution is the minimum interval between samples.
+	Resolution time.Duration
+
+	// ExitedAt is when the task exited (UnixNano), or zero while it runs.
+	ExitedAt int64
+}
+
 // AllocCheckStatus contains the current status of a nomad service discovery check.
 type AllocCheckStatus struct {
 	ID         string
@@ -175,6 +194,11 @@ type ReschedulePolicy struct {
 
 	// Unlimited allows rescheduling attempts until they succeed
 	Unlimited *bool `mapstructure:"unlimited" hcl:"unlimited,optional"`
+
+	// Jitter is the upper bound of a duration added to the delay of each
+	// reschedule attempt, so that allocations which failed together are not
+	// all rescheduled at the same time.
+	Jitter *time.Duration `mapstructure:"jitter" hcl:"jitter,optional"`
 }
 
 func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
@@ -199,6 +223,9 @@ func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
 	if rp.Unlimited != nil {
 		r.Unlimited = rp.Unlimited
 	}
+	if rp.Jitter != nil {
+		r.Jitter = rp.Jitter
+	}
 }
 
 func (r *ReschedulePolicy) Canonicalize(jobType string) {
@@ -361,6 +388,10 @@ type EphemeralDisk struct {
 	Sticky  *bool `hcl:"sticky,optional"`
 	Migrate *bool `hcl:"migrate,optional"`
 	SizeMB  *int  `mapstructure:"size" hcl:"size,optional"`
+
+	// Class is the name of the client host disk the ephemeral disk is placed
+	// on. It is unset by default, placing the disk in the client's data_dir.
+	Class *string `hcl:"class,optional"`
 }
 
 func DefaultEphemeralDisk() *EphemeralDisk {
@@ -492,6 +523,7 @@ func (vm *VolumeMount) Canonicalize() {
 type TaskGroup struct {
 	Name             *string                   `hcl:"name,label"`
 	Count            *int                      `hcl:"count,optional"`
+	ReplicatePer     *string                   `mapstructure:"replicate_per" hcl:"replicate_per,optional"`
 	Constraints      []*Constraint             `hcl:"constraint,block"`
 	Affinities       []*Affinity               `hcl:"affinity,block"`
 	Tasks            []*Task                   `hcl:"task,block"`
@@ -506,7 +538,10 @@ type TaskGroup struct {
 	Networks         []*NetworkResource        `hcl:"network,block"`
 	Meta             map[string]string         `hcl:"meta,block"`
 	Services         []*Service                `hcl:"service,block"`
+	AdvertiseMode    *string                   `mapstructure:"advertise_mode" hcl:"advertise_mode,optional"`
 	ShutdownDelay    *time.Duration            `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
+	MaxRunTime       *time.Duration            `mapstructure:"max_run_time" hcl:"max_run_time,optional"`
+	OnTimeout        *string                   `mapstructure:"on_timeout" hcl:"on_timeout,optional"`
 	// Deprecated: StopAfterClientDisconnect is deprecated in Nomad 1.8 and ignored in Nomad 1.10. Use Disconnect.StopOnClientAfter.
 	StopAfterClientDisconnect *time.Duration `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	// Deprecated: MaxClientDisconnect is deprecated in Nomad 1.8.0 and ignored in Nomad 1.10. Use Disconnect.LostAfter.
@@ -634,6 +669,10 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	if g.Disconnect != nil {
 		g.Disconnect.Canonicalize()
 	}
+
+	if g.MaxRunTime != nil && g.OnTimeout == nil {
+		g.OnTimeout = pointerOf(TaskGroupOnTimeoutFail)
+	}
 }
 
 // These needs to be in sync with DefaultServiceJobRestartPolicy in
@@ -742,6 +781,55 @@ type DispatchPayloadConfig struct {
 	File string `hcl:"file,optional"`
 }
 
+// NodePayloadConfig configures the node meta and attributes written as JSON
+// to the local/node_payload.json file of a task before it starts.
+type NodePayloadConfig struct {
+	MetaKeys []string `mapstructure:"meta_keys" hcl:"meta_keys,optional"`
+	AttrKeys []string `mapstructure:"attr_keys" hcl:"attr_keys,optional"`
+	Strict   bool     `hcl:"strict,optional"`
+}
+
+// ServiceDiscovery configures the Nomad services whose addresses a task
+// discovers before it starts.
+type ServiceDiscovery struct {
+	HostsFile string             `mapstructure:"hosts_file" hcl:"hosts_file,optional"`
+	Upstreams []*ServiceUpstream `mapstructure:"upstream" hcl:"upstream,block"`
+}
+
+func (s *ServiceDiscovery) Canonicalize() {
+	for _, u := range s.Upstreams {
+		u.Canonicalize()
+	}
+}
+
+// ServiceUpstream is a Nomad service whose addresses are set in the
+// environment of a task, or written to its hosts file.
+type ServiceUpstream struct {
+	Name           string         `hcl:"name,label"`
+	Service        string         `hcl:"service,optional"`
+	Env            string         `hcl:"env,optional"`
+	Strategy       *string        `hcl:"strategy,optional"`
+	WaitForHealthy bool           `mapstructure:"wait_for_healthy" hcl:"wait_for_healthy,optional"`
+	WaitTimeout    *time.Duration `mapstructure:"wait_timeout" hcl:"wait_timeout,optional"`
+	ChangeMode     *string        `mapstructure:"change_mode" hcl:"change_mode,optional"`
+	ChangeSignal   string         `mapstructure:"change_signal" hcl:"change_signal,optional"`
+}
+
+func (u *ServiceUpstream) Canonicalize() {
+	if u.Service == "" {
+		u.Service = u.Name
+	}
+	if u.Strategy == nil {
+		u.Strategy = pointerOf("all")
+	}
+	if u.WaitTimeout == nil {
+		u.WaitTimeout = pointerOf(1 * time.Minute)
+	}
+	if u.ChangeMode == nil {
+		u.ChangeMode = pointerOf("restart")
+	}
+}
+
 const (
 	TaskLifecycleHookPrestart  = "prestart"
 	TaskLifecycleHookPoststart = "poststart"
@@ -775,10 +863,11 @@ type Task struct {
 	KillTimeout     *time.Duration         `mapstructure:"kill_timeout" hcl:"kill_timeout,optional"`
 	LogConfig       *LogConfig             `mapstructure:"logs" hcl:"logs,block"`
 	Artifacts       []*TaskArtifact        `hcl:"artifact,block"`
-	Vault           *Vault                 `hcl:"vault,block"`
+	Vault           *Vault                 `hcl:"-"`
 	Consul          *Consul                `hcl:"consul,block"`
 	Templates       []*Template            `hcl:"template,block"`
 	DispatchPayload *DispatchPayloadConfig `hcl:"dispatch_payload,block"`
+	NodePayload     *NodePayloadConfig     `mapstructure:"node_payload" hcl:"node_payload,block"`
 	VolumeMounts    []*VolumeMount         `hcl:"volume_mount,block"`
 	CSIPluginConfig *TaskCSIPluginConfig   `mapstructure:"csi_plugin" json:",omitempty" hcl:"csi_plugin,block"`
 	Leader          bool                   `hcl:"leader,optional"`
@@ -795,9 +884,18 @@ type Task struct {
 	// Workload Identities
 	Identities []*WorkloadIdentity `hcl:"identity,block"`
 
+	// Vaults are the Vault blocks of the task for clusters other than the one
+	// of Vault. When parsing a jobspec, the first vault block of the task is
+	// set as Vault and the remaining blocks are kept here.
+	Vaults []*Vault `hcl:"vault,block"`
+
 	Actions []*Action `hcl:"action,block"`
 
 	Schedule *TaskSchedule `hcl:"schedule,block"`
+
+	// ServiceDiscovery configures the Nomad services whose addresses are set
+	// in the environment of the task before it starts.
+	ServiceDiscovery *ServiceDiscovery `mapstructure:"service_discovery" hcl:"service_discovery,block"`
 }
 
 func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
@@ -820,6 +918,9 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	if t.Vault != nil {
 		t.Vault.Canonicalize()
 	}
+	for _, v := range t.Vaults {
+		v.Canonicalize()
+	}
 	if t.Consul != nil {
 		t.Consul.Canonicalize()
 	}
@@ -838,6 +939,9 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	for _, vm := range t.VolumeMounts {
 		vm.Canonicalize()
 	}
+	if t.ServiceDiscovery != nil {
+		t.ServiceDiscovery.Canonicalize()
+	}
 	if t.Lifecycle.Empty() {
 		t.Lifecycle = nil
 	}
@@ -856,13 +960,66 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 
 // TaskArtifact is used to download artifacts before running a task.
 type TaskArtifact struct {
-	GetterSource   *string           `mapstructure:"source" hcl:"source,optional"`
-	GetterOptions  map[string]string `mapstructure:"options" hcl:"options,block"`
-	GetterHeaders  map[string]string `mapstructure:"headers" hcl:"headers,block"`
-	GetterMode     *string           `mapstructure:"mode" hcl:"mode,optional"`
-	GetterInsecure *bool             `mapstructure:"insecure" hcl:"insecure,optional"`
-	RelativeDest   *string           `mapstructure:"destination" hcl:"destination,optional"`
-	Chown          bool              `mapstructure:"chown" hcl:"chown,optional"`
+	GetterSource      *string           `mapstructure:"source" hcl:"source,optional"`
+	GetterOptions     map[string]string `mapstructure:"options" hcl:"options,block"`
+	GetterHeaders     map[string]string `mapstructure:"headers" hcl:"headers,block"`
+	GetterMode        *string           `mapstructure:"mode" hcl:"mode,optional"`
+	GetterInsecure    *bool             `mapstructure:"insecure" hcl:"insecure,optional"`
+	GetterCertPin     string            `mapstructure:"cert_pin" hcl:"cert_pin,optional"`
+	GetterProxy       string            `mapstructure:"proxy" hcl:"proxy,optional"`
+	GetterKeepArchive bool              `mapstructure:"keep_archive" hcl:"keep_archive,optional"`
+	GetterVaultPKI    *ArtifactVaultPKI `mapstructure:"vault_pki" hcl:"vault_pki,block"`
+	GetterVaultAWS    *ArtifactVaultAWS `mapstructure:"vault_aws" hcl:"vault_aws,block"`
+	GetterPreAuth     *ArtifactPreAuth  `mapstructure:"pre_auth" hcl:"pre_auth,block"`
+	GetterPostCmd     *ArtifactPostCmd  `mapstructure:"post_cmd" hcl:"post_cmd,block"`
+	RelativeDest      *string           `mapstructure:"destination" hcl:"destination,optional"`
+	Chown             bool              `mapstructure:"chown" hcl:"chown,optional"`
+	GetterChownMode   string            `mapstructure:"chown_mode" hcl:"chown_mode,optional"`
+	Owner             string            `mapstructure:"owner" hcl:"owner,optional"`
+	Group             string            `mapstructure:"group" hcl:"group,optional"`
+	GetterExisting    string            `mapstructure:"existing" hcl:"existing,optional"`
+	GetterOptional    bool              `mapstructure:"optional" hcl:"optional,optional"`
+	GetterOverlay     bool              `mapstructure:"overlay" hcl:"overlay,optional"`
+
+	GetterExpectContentType string `mapstructure:"expect_content_type" hcl:"expect_content_type,optional"`
+	GetterMaxParallel       int    `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+	GetterFilename          string `mapstructure:"filename" hcl:"filename,optional"`
+	GetterArchive           string `mapstructure:"archive" hcl:"archive,optional"`
+}
+
+// ArtifactVaultPKI is used to issue a short-lived client certificate from a
+// Vault PKI secrets engine role, using the task's Vault token, which is
+// presented when downloading the artifact over TLS.
+type ArtifactVaultPKI struct {
+	Path       string         `mapstructure:"path" hcl:"path"`
+	CommonName string         `mapstructure:"common_name" hcl:"common_name,optional"`
+	TTL        *time.Duration `mapstructure:"ttl" hcl:"ttl,optional"`
+}
+
+// ArtifactVaultAWS is used to generate short-lived AWS credentials from a
+// Vault AWS secrets engine role, using the task's Vault token, which are used
+// when downloading the artifact from S3.
+type ArtifactVaultAWS struct {
+	Path string         `mapstructure:"path" hcl:"path"`
+	TTL  *time.Duration `mapstructure:"ttl" hcl:"ttl,optional"`
+}
+
+// ArtifactPreAuth is used to send a login request before downloading the
+// artifact over http(s). The cookies set in response to the login request are
+// sent with the artifact request.
+type ArtifactPreAuth struct {
+	URL             string `mapstructure:"url" hcl:"url"`
+	Method          string `mapstructure:"method" hcl:"method,optional"`
+	CredentialsFile string `mapstructure:"credentials_file" hcl:"credentials_file,optional"`
+	ContentType     string `mapstructure:"content_type" hcl:"content_type,optional"`
+}
+
+// ArtifactPostCmd is a command run once the artifact is in place, within the
+// artifact sandbox and as the user of the task. The command must be allowed by
+// the client's artifact configuration.
+type ArtifactPostCmd struct {
+	Command string   `mapstructure:"command" hcl:"command"`
+	Args    []string `mapstructure:"args" hcl:"args,optional"`
 }
 
 func (a *TaskArtifact) Canonicalize() {
@@ -883,15 +1040,16 @@ func (a *TaskArtifact) Canonicalize() {
 		a.GetterHeaders = nil
 	}
 	if a.RelativeDest == nil {
-		switch *a.GetterMode {
-		case "file":
+		switch {
+		case *a.GetterMode == "file" && a.GetterFilename == "":
 			// File mode should default to local/filename
 			dest := *a.GetterSource
 			dest = path.Base(dest)
 			dest = filepath.Join("local", dest)
 			a.RelativeDest = &dest
 		default:
-			// Default to a directory
+			// Default to a directory, which holds the file named by
+			// GetterFilename if set
 			a.RelativeDest = pointerOf("local/")
 		}
 	}
@@ -955,6 +1113,7 @@ type Template struct {
 	VaultGrace    *time.Duration `mapstructure:"vault_grace" hcl:"vault_grace,optional"`
 	Wait          *WaitConfig    `mapstructure:"wait" hcl:"wait,block"`
 	ErrMissingKey *bool          `mapstructure:"error_on_missing_key" hcl:"error_on_missing_key,optional"`
+	VaultCluster  *string        `mapstructure:"vault_cluster" hcl:"vault_cluster,optional"`
 }
 
 func (tmpl *Template) Canonicalize() {
@@ -1004,6 +1163,9 @@ func (tmpl *Template) Canonicalize() {
 	if tmpl.ErrMissingKey == nil {
 		tmpl.ErrMissingKey = pointerOf(false)
 	}
+	if tmpl.VaultCluster == nil {
+		tmpl.VaultCluster = pointerOf("")
+	}
 	//COMPAT(0.12) VaultGrace is deprecated and unused as of Vault 0.5
 	if tmpl.VaultGrace == nil {
 		tmpl.VaultGrace = pointerOf(time.Duration(0))
@@ -1154,6 +1316,8 @@ const (
 	TaskLeaderDead             = "Leader Task Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
 	TaskClientReconnected      = "Reconnected"
+	TaskMigratingDisk          = "Migrating Ephemeral Disk"
+	TaskDiskMigrationSkipped   = "Ephemeral Disk Migration Skipped"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
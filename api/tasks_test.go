@@ -324,6 +324,18 @@ func TestTask_Artifact(t *testing.T) {
 	must.Eq(t, false, a.Chown)
 }
 
+func TestTask_Artifact_Filename(t *testing.T) {
+	testutil.Parallel(t)
+
+	a := TaskArtifact{
+		GetterSource:   pointerOf("http://localhost/download.php?id=1"),
+		GetterMode:     pointerOf("file"),
+		GetterFilename: "app.tar.gz",
+	}
+	a.Canonicalize()
+	must.Eq(t, "local/", *a.RelativeDest)
+}
+
 func TestTask_VolumeMount(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -529,6 +541,42 @@ func TestTask_Canonicalize_Secret(t *testing.T) {
 	must.Eq(t, expected, testSecret)
 }
 
+func TestTask_Canonicalize_ServiceDiscovery(t *testing.T) {
+	testutil.Parallel(t)
+
+	discovery := &ServiceDiscovery{
+		Upstreams: []*ServiceUpstream{
+			{Name: "db", Env: "DB_ADDR"},
+			{
+				Name:        "cache",
+				Service:     "redis",
+				Strategy:    pointerOf("one"),
+				WaitTimeout: pointerOf(5 * time.Second),
+				ChangeMode:  pointerOf("noop"),
+			},
+		},
+	}
+	discovery.Canonicalize()
+
+	must.Eq(t, []*ServiceUpstream{
+		{
+			Name:        "db",
+			Service:     "db",
+			Env:         "DB_ADDR",
+			Strategy:    pointerOf("all"),
+			WaitTimeout: pointerOf(1 * time.Minute),
+			ChangeMode:  pointerOf("restart"),
+		},
+		{
+			Name:        "cache",
+			Service:     "redis",
+			Strategy:    pointerOf("one"),
+			WaitTimeout: pointerOf(5 * time.Second),
+			ChangeMode:  pointerOf("noop"),
+		},
+	}, discovery.Upstreams)
+}
+
 // Ensures no regression on https://github.com/hashicorp/nomad/issues/3132
 func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 	testutil.Parallel(t)
@@ -557,6 +605,28 @@ func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 	must.Nil(t, tg.Update)
 }
 
+func TestTaskGroup_Canonicalize_MaxRunTime(t *testing.T) {
+	testutil.Parallel(t)
+
+	job := &Job{
+		ID:   pointerOf("test"),
+		Type: pointerOf(JobTypeBatch),
+	}
+	job.Canonicalize()
+
+	// on_timeout is only defaulted along with a max run time
+	tg := &TaskGroup{Name: pointerOf("foo")}
+	tg.Canonicalize(job)
+	must.Nil(t, tg.OnTimeout)
+
+	tg = &TaskGroup{
+		Name:       pointerOf("foo"),
+		MaxRunTime: pointerOf(4 * time.Hour),
+	}
+	tg.Canonicalize(job)
+	must.Eq(t, TaskGroupOnTimeoutFail, *tg.OnTimeout)
+}
+
 func TestTaskGroup_Canonicalize_Scaling(t *testing.T) {
 	testutil.Parallel(t)
 
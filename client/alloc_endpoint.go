@@ -23,6 +23,15 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
 )
 
+// execIdleTimeout is how long an exec session is kept alive without receiving
+// any input, while API clients send a heartbeat every 10 seconds. Sessions
+// abandoned over a connection which broke without being closed are ended once
+// it expires, instead of keeping the command running in the task forever.
+const execIdleTimeout = 1 * time.Minute
+
+// errExecSessionIdle is the cause of exec sessions ended for being idle.
+var errExecSessionIdle = fmt.Errorf("exec session received no input for %s", execIdleTimeout)
+
 // Allocations endpoint is used for interacting with client allocations
 type Allocations struct {
 	c *Client
@@ -181,6 +190,38 @@ func (a *Allocations) Stats(args *cstructs.AllocStatsRequest, reply *cstructs.Al
 	return nil
 }
 
+// StatsHistory is used to collect the recent resource usage history of the
+// tasks of an allocation
+func (a *Allocations) StatsHistory(args *cstructs.AllocStatsHistoryRequest, reply *cstructs.AllocStatsHistoryResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "stats_history"}, time.Now())
+
+	alloc, err := a.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check read-job permission.
+	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob) {
+		return nstructs.ErrPermissionDenied
+	}
+
+	clientStats := a.c.StatsReporter()
+	aStats, err := clientStats.GetAllocStats(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	history, err := aStats.AllocStatsHistory(args.Task)
+	if err != nil {
+		return err
+	}
+
+	reply.History = history
+	return nil
+}
+
 // Checks is used to retrieve nomad service discovery check status information.
 func (a *Allocations) Checks(args *cstructs.AllocChecksRequest, reply *cstructs.AllocChecksResponse) error {
 	defer metrics.MeasureSince([]string{"client", "allocations", "checks"}, time.Now())
@@ -204,6 +245,27 @@ func (a *Allocations) Checks(args *cstructs.AllocChecksRequest, reply *cstructs.
 	return nil
 }
 
+// Networking is used to retrieve the network namespace details of an
+// allocation.
+func (a *Allocations) Networking(args *cstructs.AllocNetworkingRequest, reply *cstructs.AllocNetworkingResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "networking"}, time.Now())
+
+	ar, err := a.c.getAllocRunner(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check read-job permission
+	if aclObj, aclErr := a.c.ResolveToken(args.AuthToken); aclErr != nil {
+		return aclErr
+	} else if !aclObj.AllowNsOp(ar.Alloc().Namespace, acl.NamespaceCapabilityReadJob) {
+		return nstructs.ErrPermissionDenied
+	}
+
+	reply.Networking = ar.Networking()
+	return nil
+}
+
 // exec is used to execute command in a running task
 func (a *Allocations) exec(conn io.ReadWriteCloser) {
 	defer metrics.MeasureSince([]string{"client", "allocations", "exec"}, time.Now())
@@ -354,15 +416,19 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 		return pointer.Of(int64(404)), fmt.Errorf("task %q not started yet.", req.Task)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
 
 	h := ar.GetTaskExecHandler(req.Task)
 	if h == nil {
 		return pointer.Of(int64(404)), fmt.Errorf("task %q is not running.", req.Task)
 	}
 
-	err = h(ctx, req.Cmd, req.Tty, newExecStream(decoder, encoder))
+	stream := newIdleExecStream(ctx, cancel, newExecStream(decoder, encoder), execIdleTimeout)
+	err = h(ctx, req.Cmd, req.Tty, stream)
+	if errors.Is(context.Cause(ctx), errExecSessionIdle) {
+		return pointer.Of(int64(http.StatusRequestTimeout)), errExecSessionIdle
+	}
 	if err != nil {
 		code := pointer.Of(int64(500))
 		return code, err
@@ -371,6 +437,55 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	return nil, nil
 }
 
+// idleExecStream is an exec stream which cancels its session with
+// errExecSessionIdle when no input, including heartbeats, is received within
+// the idle timeout. Canceling the session terminates the command.
+type idleExecStream struct {
+	drivers.ExecTaskStream
+
+	activity chan struct{}
+}
+
+func newIdleExecStream(ctx context.Context, cancel context.CancelCauseFunc,
+	stream drivers.ExecTaskStream, timeout time.Duration) *idleExecStream {
+
+	s := &idleExecStream{
+		ExecTaskStream: stream,
+		activity:       make(chan struct{}, 1),
+	}
+	go s.watch(ctx, cancel, timeout)
+	return s
+}
+
+// Recv returns the next input of the session, resetting the idle timeout.
+func (s *idleExecStream) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	m, err := s.ExecTaskStream.Recv()
+	select {
+	case s.activity <- struct{}{}:
+	default:
+	}
+	return m, err
+}
+
+// watch cancels the session once no input has been received for timeout,
+// until ctx is done.
+func (s *idleExecStream) watch(ctx context.Context, cancel context.CancelCauseFunc, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.activity:
+			timer.Reset(timeout)
+		case <-timer.C:
+			cancel(errExecSessionIdle)
+			return
+		}
+	}
+}
+
 // newExecStream returns a new exec stream as expected by drivers that interpolate with RPC streaming format
 func newExecStream(decoder *codec.Decoder, encoder *codec.Encoder) drivers.ExecTaskStream {
 	buf := new(bytes.Buffer)
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -665,6 +666,40 @@ func TestAlloc_Checks(t *testing.T) {
 	})
 }
 
+func TestAlloc_Networking(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, nil)
+	t.Cleanup(func() {
+		must.NoError(t, cleanup())
+	})
+
+	t.Run("alloc does not exist", func(t *testing.T) {
+		request := cstructs.AllocNetworkingRequest{AllocID: "d3e34248-4843-be75-d4fd-4899975cfb38"}
+		var response cstructs.AllocNetworkingResponse
+		err := client.ClientRPC("Allocations.Networking", &request, &response)
+		must.EqError(t, err, `Unknown allocation "d3e34248-4843-be75-d4fd-4899975cfb38"`)
+	})
+
+	t.Run("mapped ports", func(t *testing.T) {
+		alloc := mock.Alloc()
+		alloc.AllocatedResources.Shared.Ports = nstructs.AllocatedPorts{
+			{Label: "http", Value: 25312, To: 8080, HostIP: "10.0.0.1"},
+		}
+		must.NoError(t, client.addAlloc(alloc, ""))
+
+		request := cstructs.AllocNetworkingRequest{AllocID: alloc.ID}
+		var response cstructs.AllocNetworkingResponse
+		err := client.ClientRPC("Allocations.Networking", &request, &response)
+		must.NoError(t, err)
+		must.Eq(t, "host", response.Networking.Mode)
+		must.Eq(t, []*cstructs.AllocNetworkingPort{
+			{Label: "http", HostIP: "10.0.0.1", HostPort: 25312, To: 8080},
+		}, response.Networking.Ports)
+		must.Nil(t, response.Networking.CNI)
+	})
+}
+
 func TestAlloc_ExecStreaming(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -769,6 +804,53 @@ OUTER:
 	}
 }
 
+// chanExecStream is an exec stream whose input is read from a channel.
+type chanExecStream struct {
+	drivers.ExecTaskStream
+	inputs chan *drivers.ExecTaskStreamingRequestMsg
+}
+
+func (s *chanExecStream) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	m, ok := <-s.inputs
+	if !ok {
+		return nil, io.EOF
+	}
+	return m, nil
+}
+
+func TestAlloc_idleExecStream(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	inputs := make(chan *drivers.ExecTaskStreamingRequestMsg)
+	defer close(inputs)
+	stream := newIdleExecStream(ctx, cancel, &chanExecStream{inputs: inputs}, 200*time.Millisecond)
+	go func() {
+		for {
+			if _, err := stream.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// heartbeats keep the session alive past the idle timeout
+	for i := 0; i < 6; i++ {
+		inputs <- &drivers.ExecTaskStreamingRequestMsg{}
+		time.Sleep(100 * time.Millisecond)
+		must.NoError(t, ctx.Err())
+	}
+
+	// the session is canceled once they stop
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected idle exec session to be canceled")
+	}
+	must.ErrorIs(t, context.Cause(ctx), errExecSessionIdle)
+}
+
 func TestAlloc_ExecStreaming_NoAllocation(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -6,19 +6,28 @@ package allocdir
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hpcloud/tail/watch"
 	tomb "gopkg.in/tomb.v1"
@@ -150,7 +159,9 @@ type AllocDirFS interface {
 	List(path string) ([]*cstructs.AllocFileInfo, error)
 	Stat(path string) (*cstructs.AllocFileInfo, error)
 	ReadAt(path string, offset int64) (io.ReadCloser, error)
+	WriteFile(path string, data []byte, perm os.FileMode) error
 	Snapshot(w io.Writer) error
+	SnapshotInfo() (*SnapshotInfo, error)
 	BlockUntilExists(ctx context.Context, path string) (chan error, error)
 	ChangeEvents(ctx context.Context, path string, curOffset int64) (*watch.FileChanges, error)
 }
@@ -187,21 +198,72 @@ func (a *AllocDir) NewTaskDir(task *structs.Task) *TaskDir {
 	return td
 }
 
+// snapshotRootPaths returns the directories included in a snapshot, in a
+// stable order so that repeated snapshots of an unchanged alloc dir are
+// identical. Callers must hold the read lock.
+func (a *AllocDir) snapshotRootPaths() []string {
+	rootPaths := []string{filepath.Join(a.SharedDir, SharedDataDir)}
+	for _, name := range slices.Sorted(maps.Keys(a.TaskDirs)) {
+		rootPaths = append(rootPaths, a.TaskDirs[name].LocalDir)
+	}
+	return rootPaths
+}
+
+// SnapshotInfo describes the snapshot Snapshot would create.
+type SnapshotInfo struct {
+	// Size is the total size in bytes of the regular files in the snapshot.
+	Size int64
+
+	// ETag is a quoted validator of the snapshot, which changes whenever a
+	// file in the snapshot is added, removed, or modified. It allows an
+	// interrupted snapshot to be resumed only if it is unchanged.
+	ETag string
+}
+
+// SnapshotInfo returns the size and validator of the snapshot Snapshot would
+// create, without reading the files in it.
+func (a *AllocDir) SnapshotInfo() (*SnapshotInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var size int64
+	h := sha256.New()
+	for _, path := range a.snapshotRootPaths() {
+		err := filepath.Walk(path, func(path string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if fileInfo.Mode().IsRegular() {
+				size += fileInfo.Size()
+			}
+			fmt.Fprintf(h, "%s\x00%o\x00%d\x00%d\x00",
+				path, fileInfo.Mode(), fileInfo.Size(), fileInfo.ModTime().UnixNano())
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &SnapshotInfo{
+		Size: size,
+		ETag: `"` + hex.EncodeToString(h.Sum(nil)) + `"`,
+	}, nil
+}
+
 // Snapshot creates an archive of the files and directories in the data dir of
 // the allocation and the task local directories
 //
 // Since a valid tar may have been written even when an error occurs, a special
 // file "NOMAD-${ALLOC_ID}-ERROR.log" will be appended to the tar with the
-// error message as the contents.
+// error message as the contents. On success a special file
+// "NOMAD-${ALLOC_ID}-CHECKSUMS.json" is appended instead, mapping the path of
+// each file in the archive to its SHA-256 checksum.
 func (a *AllocDir) Snapshot(w io.Writer) error {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	allocDataDir := filepath.Join(a.SharedDir, SharedDataDir)
-	rootPaths := []string{allocDataDir}
-	for _, taskdir := range a.TaskDirs {
-		rootPaths = append(rootPaths, taskdir.LocalDir)
-	}
+	rootPaths := a.snapshotRootPaths()
+	checksums := map[string]string{}
 
 	tw := tar.NewWriter(w)
 	defer tw.Close()
@@ -246,9 +308,11 @@ func (a *AllocDir) Snapshot(w io.Writer) error {
 		}
 		defer file.Close()
 
-		if _, err := io.Copy(tw, file); err != nil {
+		h := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(tw, h), file); err != nil {
 			return err
 		}
+		checksums[relPath] = hex.EncodeToString(h.Sum(nil))
 		return nil
 	}
 
@@ -269,7 +333,7 @@ func (a *AllocDir) Snapshot(w io.Writer) error {
 		}
 	}
 
-	return nil
+	return writeChecksums(tw, filepath.Base(a.AllocDir), checksums)
 }
 
 // Move other alloc directory's shared path and local dir to this alloc dir.
@@ -289,7 +353,7 @@ func (a *AllocDir) Move(other Interface, tasks []*structs.Task) error {
 	dataDir := filepath.Join(a.SharedDir, SharedDataDir)
 	if fileInfo, err := os.Stat(otherDataDir); fileInfo != nil && err == nil {
 		os.Remove(dataDir) // remove an empty data dir if it exists
-		if err := os.Rename(otherDataDir, dataDir); err != nil {
+		if err := moveDir(otherDataDir, dataDir); err != nil {
 			return fmt.Errorf("error moving data dir: %w", err)
 		}
 	}
@@ -308,7 +372,7 @@ func (a *AllocDir) Move(other Interface, tasks []*structs.Task) error {
 			}
 			localDir := filepath.Join(newTaskDir, TaskLocal)
 			os.Remove(localDir) // remove an empty local dir if it exists
-			if err := os.Rename(otherTaskLocal, localDir); err != nil {
+			if err := moveDir(otherTaskLocal, localDir); err != nil {
 				return fmt.Errorf("error moving task %q local dir: %w", task.Name, err)
 			}
 		}
@@ -492,6 +556,87 @@ func (a *AllocDir) ReadAt(path string, offset int64) (io.ReadCloser, error) {
 	return f, nil
 }
 
+// WriteFile writes data to the file at a path relative to the alloc dir,
+// creating its missing parent directories. Files can only be written within
+// the directory of a task, outside of its secrets and private directories. An
+// existing file is replaced rather than written through, so that a symlink in
+// its place cannot redirect the write outside of the task directory.
+func (a *AllocDir) WriteFile(path string, data []byte, perm os.FileMode) error {
+	if escapes, err := escapingfs.PathEscapesAllocDir(a.AllocDir, "", path); err != nil {
+		return fmt.Errorf("Failed to check if path escapes alloc directory: %w", err)
+	} else if escapes {
+		return fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	p := filepath.Join(a.AllocDir, path)
+
+	// Find the task directory holding the file
+	var taskDir string
+	a.mu.RLock()
+	for _, dir := range a.TaskDirs {
+		if caseInsensitiveHasPrefix(p, dir.SecretsDir) {
+			a.mu.RUnlock()
+			return fmt.Errorf("Writing secret file prohibited: %s", path)
+		}
+		if caseInsensitiveHasPrefix(p, dir.PrivateDir) {
+			a.mu.RUnlock()
+			return fmt.Errorf("Writing private file prohibited: %s", path)
+		}
+		if p != dir.Dir && !escapingfs.PathEscapesSandbox(dir.Dir, p) {
+			taskDir = dir.Dir
+		}
+	}
+	a.mu.RUnlock()
+	if taskDir == "" {
+		return fmt.Errorf("Writing files outside of a task directory prohibited: %s", path)
+	}
+
+	// Check that the parents of the file do not resolve outside of the task
+	// directory, as they may be symlinks created by the task. This only
+	// reports the error early, as the file is written through a root opened
+	// at the task directory, which refuses to resolve outside of it even if
+	// the task replaces the parents in the meantime.
+	if parent, escapes, err := escapingfs.ParentEscapes(taskDir, p); err != nil {
+		return fmt.Errorf("Failed to check if path escapes task directory: %w", err)
+	} else if escapes {
+		return fmt.Errorf("Path escapes the task directory: %s", parent)
+	}
+
+	root, err := os.OpenRoot(taskDir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	rel, err := filepath.Rel(taskDir, p)
+	if err != nil {
+		return err
+	}
+	if err := root.MkdirAll(filepath.Dir(rel), 0o755); err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(filepath.Dir(rel), ".nomad-write-"+uuid.Short())
+	f, err := root.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer root.Remove(tmp)
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm.Perm()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return root.Rename(tmp, rel)
+}
+
 // CaseInsensitiveHasPrefix checks if the prefix is a case-insensitive prefix.
 func caseInsensitiveHasPrefix(s, prefix string) bool {
 	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
@@ -578,6 +723,82 @@ func fileCopy(src, dst string, uid, gid int, perm os.FileMode) error {
 	return nil
 }
 
+// moveDir renames src to dst. If they are on different devices, as is the case
+// when moving between host disk classes, src is copied to dst and removed.
+func moveDir(src, dst string) error {
+	err := os.Rename(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := copyDir(src, dst); err != nil {
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+// copyDir recursively copies src to dst preserving the permissions and owner
+// of files, directories, and symlinks.
+func copyDir(src, dst string) error {
+	// directory permissions are set deepest first once their contents have
+	// been copied, in case they aren't writable
+	var dirs []fileInfo
+
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		fi, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		uid, gid := getOwner(fi)
+
+		switch {
+		case entry.IsDir():
+			if err := os.MkdirAll(target, fileMode777); err != nil {
+				return err
+			}
+			dirs = append(dirs, fileInfo{Name: target, Perm: fi.Mode().Perm()})
+		case fi.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		case fi.Mode().IsRegular():
+			return fileCopy(path, target, uid, gid, fi.Mode().Perm())
+		default:
+			// skip sockets, devices, and pipes which can't be copied
+			return nil
+		}
+
+		if uid != idUnsupported && gid != idUnsupported {
+			return os.Lchown(target, uid, gid)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirs[i].Name, dirs[i].Perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // pathExists is a helper function to check if the path exists.
 func pathExists(path string) bool {
 	if _, err := os.Stat(path); err != nil {
@@ -687,6 +908,38 @@ func SnapshotErrorFilename(allocID string) string {
 	return fmt.Sprintf("NOMAD-%s-ERROR.log", allocID)
 }
 
+// SnapshotChecksumsFilename returns the filename of the file mapping the paths
+// of the files in a snapshot to their checksums.
+func SnapshotChecksumsFilename(allocID string) string {
+	return fmt.Sprintf("NOMAD-%s-CHECKSUMS.json", allocID)
+}
+
+// writeChecksums writes a special file to a tar archive with the checksums of
+// the files in it. See Snapshot().
+func writeChecksums(tw *tar.Writer, allocID string, checksums map[string]string) error {
+	contents, err := json.Marshal(checksums)
+	if err != nil {
+		return err
+	}
+
+	hdr := tar.Header{
+		Name:       SnapshotChecksumsFilename(allocID),
+		Mode:       int64(fileMode666),
+		Size:       int64(len(contents)),
+		AccessTime: SnapshotErrorTime,
+		ChangeTime: SnapshotErrorTime,
+		ModTime:    SnapshotErrorTime,
+		Typeflag:   tar.TypeReg,
+	}
+
+	if err := tw.WriteHeader(&hdr); err != nil {
+		return err
+	}
+
+	_, err = tw.Write(contents)
+	return err
+}
+
 // writeError writes a special file to a tar archive with the error encountered
 // during snapshotting. See Snapshot().
 func writeError(tw *tar.Writer, allocID string, err error) error {
@@ -10,6 +10,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"io/fs"
 	"os"
@@ -184,9 +185,30 @@ func TestAllocDir_Snapshot(t *testing.T) {
 	var b bytes.Buffer
 	must.NoError(t, d.Snapshot(&b))
 
+	// Snapshots of an unchanged alloc dir are identical
+	var b2 bytes.Buffer
+	must.NoError(t, d.Snapshot(&b2))
+	must.Eq(t, b.Bytes(), b2.Bytes())
+
+	info, err := d.SnapshotInfo()
+	must.NoError(t, err)
+	must.Eq(t, 6, info.Size)
+
+	// The validator only changes along with the snapshot
+	info2, err := d.SnapshotInfo()
+	must.NoError(t, err)
+	must.Eq(t, info.ETag, info2.ETag)
+
+	must.NoError(t, os.WriteFile(filepath.Join(td1.LocalDir, "new"), nil, 0o644))
+	info2, err = d.SnapshotInfo()
+	must.NoError(t, err)
+	must.NotEq(t, info.ETag, info2.ETag)
+	must.NoError(t, os.Remove(filepath.Join(td1.LocalDir, "new")))
+
 	tr := tar.NewReader(&b)
 	var files []string
 	var links []string
+	var checksums map[string]string
 	for {
 		hdr, err := tr.Next()
 		if err != nil && err != io.EOF {
@@ -195,6 +217,10 @@ func TestAllocDir_Snapshot(t *testing.T) {
 		if err == io.EOF {
 			break
 		}
+		if hdr.Name == SnapshotChecksumsFilename("test") {
+			must.NoError(t, json.NewDecoder(tr).Decode(&checksums))
+			continue
+		}
 		if hdr.Typeflag == tar.TypeReg {
 			files = append(files, hdr.FileInfo().Name())
 		} else if hdr.Typeflag == tar.TypeSymlink {
@@ -204,6 +230,10 @@ func TestAllocDir_Snapshot(t *testing.T) {
 
 	must.SliceLen(t, 2, files)
 	must.SliceLen(t, 2, links)
+	must.Eq(t, map[string]string{
+		"alloc/data/bar": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		"web/local/lol":  "fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9",
+	}, checksums)
 }
 
 func TestAllocDir_Move(t *testing.T) {
@@ -253,6 +283,38 @@ func TestAllocDir_Move(t *testing.T) {
 	must.NotNil(t, fi)
 }
 
+func TestAllocDir_copyDir(t *testing.T) {
+	ci.Parallel(t)
+
+	src := filepath.Join(t.TempDir(), "src")
+	dst := filepath.Join(t.TempDir(), "dst")
+
+	must.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(src, "sub", "file"), []byte("foo"), 0o640))
+	must.NoError(t, os.Symlink("sub/file", filepath.Join(src, "link")))
+	must.NoError(t, os.Chmod(filepath.Join(src, "sub"), 0o555))
+	t.Cleanup(func() { os.Chmod(filepath.Join(src, "sub"), 0o755) })
+
+	must.NoError(t, copyDir(src, dst))
+	t.Cleanup(func() { os.Chmod(filepath.Join(dst, "sub"), 0o755) })
+
+	b, err := os.ReadFile(filepath.Join(dst, "sub", "file"))
+	must.NoError(t, err)
+	must.Eq(t, "foo", string(b))
+
+	fi, err := os.Stat(filepath.Join(dst, "sub", "file"))
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0o640), fi.Mode().Perm())
+
+	fi, err = os.Stat(filepath.Join(dst, "sub"))
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0o555), fi.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dst, "link"))
+	must.NoError(t, err)
+	must.Eq(t, "sub/file", link)
+}
+
 func TestAllocDir_EscapeChecking(t *testing.T) {
 	ci.Parallel(t)
 
@@ -313,6 +375,66 @@ func TestAllocDir_ReadAt_SecretDir(t *testing.T) {
 	must.EqError(t, err, "Reading secret file prohibited: web/secrets/test_file")
 }
 
+func TestAllocDir_WriteFile(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	t.Run("creates parents", func(t *testing.T) {
+		target := filepath.Join(t1.Name, TaskLocal, "a", "b", "file")
+		must.NoError(t, d.WriteFile(target, []byte("hi"), 0o750))
+
+		info, err := os.Stat(filepath.Join(d.AllocDir, target))
+		must.NoError(t, err)
+		must.Eq(t, os.FileMode(0o750), info.Mode().Perm())
+		b, err := os.ReadFile(filepath.Join(d.AllocDir, target))
+		must.NoError(t, err)
+		must.Eq(t, "hi", string(b))
+	})
+
+	t.Run("replaces symlink", func(t *testing.T) {
+		original := filepath.Join(td.LocalDir, "original")
+		must.NoError(t, os.WriteFile(original, []byte("original"), 0o644))
+		must.NoError(t, os.Symlink(original, filepath.Join(td.LocalDir, "link")))
+
+		must.NoError(t, d.WriteFile(filepath.Join(t1.Name, TaskLocal, "link"), []byte("new"), 0o644))
+
+		b, err := os.ReadFile(original)
+		must.NoError(t, err)
+		must.Eq(t, "original", string(b))
+		info, err := os.Lstat(filepath.Join(td.LocalDir, "link"))
+		must.NoError(t, err)
+		must.True(t, info.Mode().IsRegular())
+	})
+
+	t.Run("parent escapes", func(t *testing.T) {
+		outside := t.TempDir()
+		must.NoError(t, os.Symlink(outside, filepath.Join(td.LocalDir, "escape")))
+
+		err := d.WriteFile(filepath.Join(t1.Name, TaskLocal, "escape", "file"), []byte("hi"), 0o644)
+		must.ErrorContains(t, err, "Path escapes the task directory")
+		_, err = os.Stat(filepath.Join(outside, "file"))
+		must.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("prohibited", func(t *testing.T) {
+		must.EqError(t, d.WriteFile(filepath.Join(t1.Name, TaskSecrets, "file"), nil, 0o644),
+			"Writing secret file prohibited: web/secrets/file")
+		must.EqError(t, d.WriteFile(filepath.Join(SharedAllocName, "file"), nil, 0o644),
+			"Writing files outside of a task directory prohibited: alloc/file")
+		must.EqError(t, d.WriteFile(t1.Name, nil, 0o644),
+			"Writing files outside of a task directory prohibited: web")
+		must.EqError(t, d.WriteFile("../../file", nil, 0o644),
+			"Path escapes the alloc directory")
+	})
+}
+
 func TestAllocDir_SplitPath(t *testing.T) {
 	ci.Parallel(t)
 
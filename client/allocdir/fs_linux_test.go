@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
 	"github.com/shoenig/test/must"
 	"golang.org/x/sys/unix"
 )
@@ -175,3 +177,34 @@ func TestLinuxUnprivilegedSecretDir(t *testing.T) {
 		t.Fatalf("error removing nonexistent secrets dir %q: %v", secretsDir, err)
 	}
 }
+
+// TestLinuxTaskDir_UnmountOverlays asserts artifact overlays mounted within the
+// task dir are unmounted along with it.
+func TestLinuxTaskDir_UnmountOverlays(t *testing.T) {
+	ci.Parallel(t)
+	if unix.Geteuid() != 0 {
+		t.Skip("Must be run as root")
+	}
+
+	tmp := t.TempDir()
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	defer d.Destroy()
+	td := d.NewTaskDir(t1)
+	must.NoError(t, d.Build())
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	cached := t.TempDir()
+	outer := filepath.Join(td.LocalDir, "outer")
+	inner := filepath.Join(outer, "inner")
+	must.NoError(t, os.MkdirAll(filepath.Join(cached, "inner"), 0o755))
+	must.NoError(t, os.MkdirAll(outer, 0o755))
+	must.NoError(t, unix.Mount("overlay", outer, "overlay", unix.MS_RDONLY, "lowerdir="+cached+":"+outer))
+	must.NoError(t, unix.Mount("overlay", inner, "overlay", unix.MS_RDONLY, "lowerdir="+cached+":"+inner))
+
+	must.NoError(t, td.Unmount())
+
+	for _, dir := range []string{inner, outer} {
+		_, err := isMount(dir)
+		must.Eq(t, notFoundErr, err)
+	}
+}
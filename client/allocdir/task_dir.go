@@ -384,6 +384,11 @@ func (t *TaskDir) Unmount() error {
 		}
 	}
 
+	// Unmount any artifacts mounted as read-only overlays.
+	if err := t.unmountOverlays(); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
 	// Unmount dev/ and proc/ have been mounted.
 	if err := t.unmountSpecialDirs(); err != nil {
 		mErr = multierror.Append(mErr, err)
@@ -7,8 +7,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/moby/sys/mountinfo"
+	"golang.org/x/sys/unix"
 )
 
 // unmountSpecialDirs unmounts the dev and proc file system from the chroot. No
@@ -37,3 +41,27 @@ func (t *TaskDir) unmountSpecialDirs() error {
 
 	return mErr.ErrorOrNil()
 }
+
+// unmountOverlays unmounts the read-only artifact overlays mounted within the
+// task directory, deepest first so that nested overlays are unmounted before
+// the overlays they are mounted within.
+func (t *TaskDir) unmountOverlays() error {
+	prefix := t.Dir + string(filepath.Separator)
+	mounts, err := mountinfo.GetMounts(func(info *mountinfo.Info) (bool, bool) {
+		return info.FSType != "overlay" || !strings.HasPrefix(info.Mountpoint, prefix), false
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to list artifact overlays: %w", err)
+	}
+	sort.Slice(mounts, func(i, j int) bool {
+		return len(mounts[i].Mountpoint) > len(mounts[j].Mountpoint)
+	})
+
+	mErr := new(multierror.Error)
+	for _, m := range mounts {
+		if err := unix.Unmount(m.Mountpoint, 0); err != nil && err != unix.EINVAL {
+			mErr = multierror.Append(mErr, fmt.Errorf("Failed to unmount artifact overlay %q: %w", m.Mountpoint, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
@@ -10,3 +10,8 @@ package allocdir
 func (t *TaskDir) unmountSpecialDirs() error {
 	return nil
 }
+
+// currently a noop on non-Linux platforms, where artifacts are never mounted
+func (t *TaskDir) unmountOverlays() error {
+	return nil
+}
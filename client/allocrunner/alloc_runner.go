@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"sort"
 	"sync"
 	"time"
 
@@ -56,6 +57,9 @@ type allocRunner struct {
 	// clientConfig is the client configuration block.
 	clientConfig *config.Config
 
+	// nodeFunc returns the current node of the client.
+	nodeFunc func() *structs.Node
+
 	// clientBaseLabels are the base metric labels generated by the client.
 	// These can be used by processes which emit metrics that want to include
 	// these labels that include node_id, node_class, and node_pool.
@@ -139,6 +143,10 @@ type allocRunner struct {
 	state     *state.State
 	stateLock sync.RWMutex
 
+	// cniResult is the result of the CNI plugins which configured the
+	// network of the allocation, if any. It is guarded by stateLock.
+	cniResult *cstructs.CNIResult
+
 	// lastAcknowledgedState is the alloc runner state that was last
 	// acknowledged by the server (may lag behind ar.state)
 	lastAcknowledgedState *state.State
@@ -242,6 +250,7 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 		id:                       alloc.ID,
 		alloc:                    alloc,
 		clientConfig:             config.ClientConfig,
+		nodeFunc:                 config.NodeFunc,
 		clientBaseLabels:         config.BaseLabels,
 		consulServicesHandler:    config.ConsulServices,
 		consulProxiesClientFunc:  config.ConsulProxiesFunc,
@@ -283,10 +292,20 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 
 	ar.setHookStatsHandler(alloc.Namespace)
 
-	// Create alloc dir
+	// Create alloc dir, under the path of the requested host disk class if
+	// there is one
+	clientAllocDir := config.ClientConfig.AllocDir
+	if alloc.AllocatedResources != nil && alloc.AllocatedResources.Shared.DiskClass != "" {
+		class := alloc.AllocatedResources.Shared.DiskClass
+		if disk, ok := config.ClientConfig.HostDisks[class]; ok {
+			clientAllocDir = disk.Path
+		} else {
+			ar.logger.Warn("host disk class not configured, using alloc dir", "disk_class", class)
+		}
+	}
 	ar.allocDir = allocdir.NewAllocDir(
 		ar.logger,
-		config.ClientConfig.AllocDir,
+		clientAllocDir,
 		config.ClientConfig.AllocMountsDir,
 		alloc.ID,
 	)
@@ -327,6 +346,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 		trConfig := &taskrunner.Config{
 			Alloc:               ar.alloc,
 			ClientConfig:        ar.clientConfig,
+			NodeFunc:            ar.nodeFunc,
 			ClientBaseLabels:    ar.clientBaseLabels,
 			Task:                task,
 			TaskDir:             ar.allocDir.NewTaskDir(task),
@@ -350,6 +370,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			AllocHookResources:  ar.hookResources,
 			WIDMgr:              ar.widmgr,
 			Users:               ar.users,
+			RPCClient:           ar.rpcClient,
 		}
 
 		// Create, but do not Run, the task runner
@@ -485,9 +506,15 @@ func (ar *allocRunner) Restore() error {
 		return err
 	}
 
+	cniResult, err := ar.stateDB.GetAllocCNIResult(ar.id)
+	if err != nil {
+		return err
+	}
+
 	ar.stateLock.Lock()
 	ar.state.DeploymentStatus = ds
 	ar.state.NetworkStatus = ns
+	ar.cniResult = cniResult
 	ar.stateLock.Unlock()
 
 	states := make(map[string]*structs.TaskState)
@@ -825,6 +852,26 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 	return states
 }
 
+// killMaxRunTimeExceeded kills the tasks of the allocation after its task
+// group exceeded its max run time. Live tasks receive an event recording why
+// they are killed, which fails them if fail is true so that the allocation is
+// failed and may be rescheduled.
+func (ar *allocRunner) killMaxRunTimeExceeded(fail bool) {
+	event := structs.NewTaskEvent(structs.TaskMaxRunTimeExceeded)
+	if fail {
+		event.SetFailsTask()
+	}
+
+	for _, tr := range ar.tasks {
+		if tr.IsPoststopTask() || tr.TaskState().State == structs.TaskStateDead {
+			continue
+		}
+		tr.EmitEvent(event)
+	}
+
+	ar.killTasks()
+}
+
 // clientAlloc takes in the task states and returns an Allocation populated with
 // Client specific fields. Note: this mutates the allocRunner's state to store
 // the taskStates!
@@ -963,6 +1010,90 @@ func (ar *allocRunner) NetworkStatus() *structs.AllocNetworkStatus {
 	return ar.state.NetworkStatus.Copy()
 }
 
+// SetCNIResult stores the result of the CNI plugins which configured the
+// network of the allocation, which the plugins only return when they are
+// called, so that it remains available after the client restarts.
+func (ar *allocRunner) SetCNIResult(res *cstructs.CNIResult) {
+	ar.stateLock.Lock()
+	defer ar.stateLock.Unlock()
+	ar.cniResult = res.Copy()
+
+	if err := ar.stateDB.PutAllocCNIResult(ar.id, ar.cniResult); err != nil {
+		ar.logger.Warn("failed to persist CNI result", "error", err)
+	}
+}
+
+// Networking returns the ports allocated to the allocation, the ports they
+// are mapped to in its network namespace, and how the namespace was
+// configured.
+func (ar *allocRunner) Networking() *cstructs.AllocNetworking {
+	alloc := ar.Alloc()
+
+	networking := &cstructs.AllocNetworking{
+		Mode:  "host",
+		Ports: []*cstructs.AllocNetworkingPort{},
+	}
+
+	var nw *structs.NetworkResource
+	if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil && len(tg.Networks) > 0 {
+		nw = tg.Networks[0]
+		if nw.Mode != "" {
+			networking.Mode = nw.Mode
+		}
+	}
+
+	if alloc.AllocatedResources != nil {
+		shared := alloc.AllocatedResources.Shared
+		ports := shared.Ports
+		if len(ports) == 0 {
+			// allocations placed before ports were allocated by label only
+			// have them in the shared networks
+			for _, network := range shared.Networks {
+				for _, port := range append(network.DynamicPorts, network.ReservedPorts...) {
+					ports = append(ports, structs.AllocatedPortMapping{
+						Label:  port.Label,
+						Value:  port.Value,
+						To:     port.To,
+						HostIP: network.IP,
+					})
+				}
+			}
+		}
+		for _, port := range ports {
+			to := port.To
+			if to < 1 {
+				to = port.Value
+			}
+			networking.Ports = append(networking.Ports, &cstructs.AllocNetworkingPort{
+				Label:    port.Label,
+				HostIP:   port.HostIP,
+				HostPort: port.Value,
+				To:       to,
+			})
+		}
+		sort.Slice(networking.Ports, func(i, j int) bool {
+			return networking.Ports[i].Label < networking.Ports[j].Label
+		})
+	}
+
+	ar.stateLock.RLock()
+	status := ar.state.NetworkStatus.Copy()
+	networking.CNI = ar.cniResult.Copy()
+	ar.stateLock.RUnlock()
+
+	if status != nil {
+		networking.InterfaceName = status.InterfaceName
+		networking.Address = status.Address
+		networking.AddressIPv6 = status.AddressIPv6
+		networking.DNS = status.DNS
+	}
+	if networking.DNS == nil && nw != nil {
+		networking.DNS = nw.DNS.Copy()
+	}
+
+	return networking
+}
+
 // setIndexes is a helper for forcing alloc state on the alloc runner. This is
 // used during reconnect when the task has been marked unknown by the server.
 func (ar *allocRunner) setIndexes(update *structs.Allocation) {
@@ -1324,6 +1455,42 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 	return astat, nil
 }
 
+// AllocStatsHistory returns the recent resource usage history of the tasks of
+// the allocation. If taskFilter is set, only the history of that task -- if it
+// exists -- is returned.
+func (ar *allocRunner) AllocStatsHistory(taskFilter string) (map[string]*cstructs.TaskResourceUsageHistory, error) {
+	history := make(map[string]*cstructs.TaskResourceUsageHistory, len(ar.tasks))
+	for name, tr := range ar.tasks {
+		if taskFilter != "" && taskFilter != name {
+			continue
+		}
+		if h := tr.ResourceUsageHistory(); h != nil {
+			history[name] = h
+		}
+	}
+	return history, nil
+}
+
+// TemplateDependencyStates returns the state of the dependencies of the
+// templates of each task of the allocation which has templates, keyed by task
+// name.
+func (ar *allocRunner) TemplateDependencyStates() map[string][]*cstructs.TemplateDependencyState {
+	states := make(map[string][]*cstructs.TemplateDependencyState)
+	for name, tr := range ar.tasks {
+		if s := tr.TemplateDependencyStates(); len(s) > 0 {
+			states[name] = s
+		}
+	}
+	return states
+}
+
+// emitTaskEvent emits a copy of the event to each of the alloc's tasks.
+func (ar *allocRunner) emitTaskEvent(event *structs.TaskEvent) {
+	for _, tr := range ar.tasks {
+		tr.EmitEvent(event.Copy())
+	}
+}
+
 func (ar *allocRunner) GetTaskEventHandler(taskName string) drivermanager.EventHandler {
 	if tr, ok := ar.tasks[taskName]; ok {
 		return func(ev *drivers.TaskEvent) {
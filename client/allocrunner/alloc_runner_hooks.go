@@ -122,7 +122,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 			db:                      ar.stateDB,
 		}),
 		newUpstreamAllocsHook(hookLogger, ar.prevAllocWatcher),
-		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
+		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir, ar.emitTaskEvent),
 		newCPUPartsHook(hookLogger, ar.partitions, alloc),
 		newAllocHealthWatcherHook(hookLogger, alloc, hs, ar.Listener(), ar.consulServicesHandler, ar.checkStore),
 		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar),
@@ -143,6 +143,9 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		newCSIHook(alloc, hookLogger, ar.csiManager, ar.rpcClient, ar, ar.hookResources, ar.clientConfig.Node.SecretID),
 		newChecksHook(hookLogger, alloc, ar.checkStore, ar),
 	}
+	if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil && tg.MaxRunTime != nil {
+		ar.runnerHooks = append(ar.runnerHooks, newMaxRunTimeHook(hookLogger, alloc, ar, ar.Listener()))
+	}
 	if config.ExtraAllocHooks != nil {
 		ar.runnerHooks = append(ar.runnerHooks, config.ExtraAllocHooks...)
 	}
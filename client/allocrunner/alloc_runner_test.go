@@ -30,6 +30,7 @@ import (
 	regMock "github.com/hashicorp/nomad/client/serviceregistration/mock"
 	"github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -256,6 +257,56 @@ func TestAllocRunner_Lifecycle_Poststart(t *testing.T) {
 
 // TestAllocRunner_TaskMain_KillTG asserts that when main tasks die the
 // entire task group is killed.
+// TestAllocRunner_MaxRunTime asserts that an alloc whose task group exceeds its
+// max run time is killed and failed.
+func TestAllocRunner_MaxRunTime(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.RestartPolicy.Attempts = 0
+	tg.MaxRunTime = pointer.Of(100 * time.Millisecond)
+
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.KillTimeout = 10 * time.Millisecond
+	task.RestartPolicy.Attempts = 0
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if last.ClientStatus != structs.AllocClientStatusFailed {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusFailed)
+		}
+
+		state := last.TaskStates[task.Name]
+		if state.State != structs.TaskStateDead {
+			return false, fmt.Errorf("got state %v; want %v", state.State, structs.TaskStateDead)
+		}
+		for _, e := range state.Events {
+			if e.Type == structs.TaskMaxRunTimeExceeded {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("Did not find event %v", structs.TaskMaxRunTimeExceeded)
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
 func TestAllocRunner_TaskMain_KillTG(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2720,3 +2771,60 @@ func (h *allocPostrunHook) Postrun() error {
 	h.ran = true
 	return h.err
 }
+
+// TestAllocRunner_Networking asserts the networking details of an allocation
+// include its mapped ports, network status, and CNI result, and that the CNI
+// result is restored after the client restarts.
+func TestAllocRunner_Networking(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{{Mode: "bridge"}}
+	alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 25312, To: 8080, HostIP: "10.0.0.1"},
+		{Label: "admin", Value: 5000, HostIP: "10.0.0.1"},
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	conf.StateDB = state.NewMemDB(conf.Logger)
+
+	arIface, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	ar := arIface.(*allocRunner)
+
+	cniResult := &cstructs.CNIResult{
+		Interfaces: []*cstructs.CNIInterface{{
+			Name:      "eth0",
+			Sandbox:   "/var/run/netns/" + alloc.ID,
+			Addresses: []*cstructs.CNIAddress{{Address: "172.26.64.2", Gateway: "172.26.64.1"}},
+		}},
+		Routes: []*cstructs.CNIRoute{{Destination: "0.0.0.0/0", Gateway: "172.26.64.1"}},
+	}
+	ar.SetNetworkStatus(&structs.AllocNetworkStatus{
+		InterfaceName: "eth0",
+		Address:       "172.26.64.2",
+	})
+	ar.SetCNIResult(cniResult)
+	must.NoError(t, ar.PersistState())
+
+	expected := &cstructs.AllocNetworking{
+		Mode: "bridge",
+		Ports: []*cstructs.AllocNetworkingPort{
+			{Label: "admin", HostIP: "10.0.0.1", HostPort: 5000, To: 5000},
+			{Label: "http", HostIP: "10.0.0.1", HostPort: 25312, To: 8080},
+		},
+		InterfaceName: "eth0",
+		Address:       "172.26.64.2",
+		CNI:           cniResult,
+	}
+	must.Eq(t, expected, ar.Networking())
+
+	// the CNI plugins are not called again when the client restarts, so the
+	// result must be restored from the state
+	arIface2, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	ar2 := arIface2.(*allocRunner)
+	must.NoError(t, ar2.Restore())
+	must.Eq(t, expected, ar2.Networking())
+}
@@ -293,7 +293,7 @@ func (h *groupServiceHook) getWorkloadServicesLocked() *serviceregistration.Work
 
 	var netStatus *structs.AllocNetworkStatus
 	if h.networkStatus != nil {
-		netStatus = h.networkStatus.NetworkStatus()
+		netStatus = h.networkStatus.NetworkStatus().Advertised(h.tg.AdvertiseMode)
 	}
 
 	info := structs.AllocInfo{
@@ -311,6 +311,7 @@ func (h *groupServiceHook) getWorkloadServicesLocked() *serviceregistration.Work
 		Services:          h.services,
 		Networks:          h.networks,
 		NetworkStatus:     netStatus,
+		AdvertiseMode:     h.tg.AdvertiseMode,
 		Ports:             h.ports,
 		Canary:            h.canary,
 		Tokens:            tokens,
@@ -53,6 +53,8 @@ type AllocRunner interface {
 	GetAllocDir() allocdir.Interface
 	SetTaskPauseState(taskName string, ps structs.TaskScheduleState) error
 	GetTaskPauseState(taskName string) (structs.TaskScheduleState, error)
+	TemplateDependencyStates() map[string][]*cstructs.TemplateDependencyState
+	Networking() *cstructs.AllocNetworking
 }
 
 // TaskStateHandler exposes a handler to be called when a task's state changes
@@ -66,6 +68,10 @@ type TaskStateHandler interface {
 // allocation
 type AllocStatsReporter interface {
 	LatestAllocStats(taskFilter string) (*cstructs.AllocResourceUsage, error)
+
+	// AllocStatsHistory returns the recent resource usage history of each
+	// task, including tasks which exited recently.
+	AllocStatsHistory(taskFilter string) (map[string]*cstructs.TaskResourceUsageHistory, error)
 }
 
 // HookResourceSetter is used to communicate between alloc hooks and task hooks
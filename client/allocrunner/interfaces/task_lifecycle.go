@@ -57,6 +57,10 @@ type TaskPrestartRequest struct {
 	// Vault token may optionally be set if a Vault token is available
 	VaultToken string
 
+	// VaultClusterTokens holds the Vault tokens derived for any additional
+	// Vault blocks of the task, keyed by cluster name
+	VaultClusterTokens map[string]string
+
 	// NomadToken token may optionally be set if a Nomad token is available
 	NomadToken string
 
@@ -170,6 +174,8 @@ type TaskExitedHook interface {
 type TaskUpdateRequest struct {
 	VaultToken string
 
+	VaultClusterTokens map[string]string
+
 	NomadToken string
 
 	// Alloc is the current version of the allocation (may have been
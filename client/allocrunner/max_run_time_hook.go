@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const maxRunTimeHookName = "max_run_time"
+
+// maxRunTimeKiller kills the tasks of an allocation which exceeded the max run
+// time of its task group.
+type maxRunTimeKiller interface {
+	// killMaxRunTimeExceeded kills the tasks of the allocation, marking them
+	// as failed if fail is true.
+	killMaxRunTimeExceeded(fail bool)
+}
+
+// maxRunTimeHook enforces the max_run_time of the task group of a batch
+// allocation. It watches the task states of the allocation for the start of
+// its first main task, and kills the allocation once the max run time elapsed
+// since then.
+type maxRunTimeHook struct {
+	logger hclog.Logger
+	alloc  *structs.Allocation
+	killer maxRunTimeKiller
+
+	// listener receives the alloc updates carrying the task states. It is
+	// closed when the hook stops watching.
+	listener *cstructs.AllocListener
+
+	// lock is held by the hook methods, which are called concurrently on
+	// shutdown.
+	lock sync.Mutex
+
+	// cancelFn stops the watch goroutine, which closes watchDone when it
+	// exits. Initialized so that Postrun is safe to call without Prerun.
+	cancelFn  context.CancelFunc
+	watchDone chan struct{}
+}
+
+func newMaxRunTimeHook(
+	logger hclog.Logger,
+	alloc *structs.Allocation,
+	killer maxRunTimeKiller,
+	listener *cstructs.AllocListener,
+) *maxRunTimeHook {
+	closedDone := make(chan struct{})
+	close(closedDone)
+
+	return &maxRunTimeHook{
+		logger:    logger.Named(maxRunTimeHookName),
+		alloc:     alloc,
+		killer:    killer,
+		listener:  listener,
+		cancelFn:  func() {},
+		watchDone: closedDone,
+	}
+}
+
+// statically assert the hook implements the expected interfaces
+var (
+	_ interfaces.RunnerPrerunHook  = (*maxRunTimeHook)(nil)
+	_ interfaces.RunnerPostrunHook = (*maxRunTimeHook)(nil)
+	_ interfaces.ShutdownHook      = (*maxRunTimeHook)(nil)
+)
+
+func (h *maxRunTimeHook) Name() string {
+	return maxRunTimeHookName
+}
+
+func (h *maxRunTimeHook) Prerun(_ *taskenv.TaskEnv) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var ctx context.Context
+	ctx, h.cancelFn = context.WithCancel(context.Background())
+	h.watchDone = make(chan struct{})
+	go h.watch(ctx, h.watchDone)
+	return nil
+}
+
+func (h *maxRunTimeHook) Postrun() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.cancelFn()
+	h.listener.Close()
+
+	// Wait until the watcher exits
+	<-h.watchDone
+
+	return nil
+}
+
+func (h *maxRunTimeHook) Shutdown() {
+	// Same as Postrun
+	_ = h.Postrun()
+}
+
+// watch waits for the first main task of the allocation to start and kills
+// the allocation when its max run time elapsed since then. The deadline is
+// not moved when tasks restart.
+func (h *maxRunTimeHook) watch(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	timer, stop := helper.NewStoppedTimer()
+	defer stop()
+
+	// alloc updates only carry the client fields of the alloc, so the task
+	// group comes from the alloc the hook was created with
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+
+	var deadline time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case alloc, ok := <-h.listener.Ch():
+			if !ok || alloc.ClientTerminalStatus() {
+				return
+			}
+			if !deadline.IsZero() {
+				continue
+			}
+			deadline = tg.MaxRunTimeDeadline(alloc.TaskStates)
+			if deadline.IsZero() {
+				continue
+			}
+			h.logger.Debug("enforcing max run time", "deadline", deadline)
+			timer.Reset(time.Until(deadline))
+
+		case <-timer.C:
+			h.logger.Info("allocation exceeded its max run time, killing tasks",
+				"max_run_time", *tg.MaxRunTime, "on_timeout", tg.OnTimeout)
+			h.killer.killMaxRunTimeExceeded(tg.FailsOnTimeout())
+			return
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// mockMaxRunTimeKiller records the kills of the max run time hook.
+type mockMaxRunTimeKiller struct {
+	killed chan bool
+}
+
+func (k *mockMaxRunTimeKiller) killMaxRunTimeExceeded(fail bool) {
+	k.killed <- fail
+}
+
+func TestMaxRunTimeHook(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		onTimeout string
+		startedAt time.Time
+		expKill   bool
+		expFail   bool
+	}{
+		{
+			name:      "exceeded",
+			startedAt: time.Now().Add(-time.Hour),
+			expKill:   true,
+			expFail:   true,
+		},
+		{
+			name:      "exceeded complete",
+			onTimeout: structs.TaskGroupOnTimeoutComplete,
+			startedAt: time.Now().Add(-time.Hour),
+			expKill:   true,
+		},
+		{
+			name:      "not exceeded",
+			startedAt: time.Now(),
+		},
+		{
+			name: "not started",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := testlog.HCLogger(t)
+			b := cstructs.NewAllocBroadcaster(logger)
+			defer b.Close()
+
+			alloc := mock.BatchAlloc()
+			tg := alloc.Job.TaskGroups[0]
+			tg.MaxRunTime = pointer.Of(30 * time.Minute)
+			tg.OnTimeout = tc.onTimeout
+
+			killer := &mockMaxRunTimeKiller{killed: make(chan bool, 1)}
+			h := newMaxRunTimeHook(logger, alloc, killer, b.Listen())
+			must.NoError(t, h.Prerun(nil))
+
+			update := alloc.Copy()
+			update.ClientStatus = structs.AllocClientStatusRunning
+			update.TaskStates = map[string]*structs.TaskState{
+				tg.Tasks[0].Name: {State: structs.TaskStateRunning, StartedAt: tc.startedAt},
+			}
+			must.NoError(t, b.Send(update))
+
+			select {
+			case fail := <-killer.killed:
+				must.True(t, tc.expKill, must.Sprint("unexpected kill"))
+				must.Eq(t, tc.expFail, fail)
+			case <-time.After(200 * time.Millisecond):
+				must.False(t, tc.expKill, must.Sprint("expected kill"))
+			}
+
+			must.NoError(t, h.Postrun())
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 // diskMigrationHook migrates ephemeral disk volumes. Depends on alloc dir
@@ -19,6 +20,7 @@ import (
 type diskMigrationHook struct {
 	allocDir     allocdir.Interface
 	allocWatcher config.PrevAllocMigrator
+	emitEvent    func(*structs.TaskEvent)
 	logger       log.Logger
 }
 
@@ -26,10 +28,12 @@ func newDiskMigrationHook(
 	logger log.Logger,
 	allocWatcher config.PrevAllocMigrator,
 	allocDir allocdir.Interface,
+	emitEvent func(*structs.TaskEvent),
 ) *diskMigrationHook {
 	h := &diskMigrationHook{
 		allocDir:     allocDir,
 		allocWatcher: allocWatcher,
+		emitEvent:    emitEvent,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -51,7 +55,7 @@ func (h *diskMigrationHook) Prerun(_ *taskenv.TaskEnv) error {
 	}
 
 	// Wait for data to be migrated from a previous alloc if applicable
-	if err := h.allocWatcher.Migrate(ctx, h.allocDir); err != nil {
+	if err := h.allocWatcher.Migrate(ctx, h.allocDir, h.emitEvent); err != nil {
 		if err == context.Canceled {
 			return err
 		}
@@ -10,6 +10,7 @@ import (
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -52,6 +53,7 @@ func (a *allocNetworkIsolationSetter) SetNetworkIsolation(n *drivers.NetworkIsol
 type networkStatus interface {
 	SetNetworkStatus(*structs.AllocNetworkStatus)
 	NetworkStatus() *structs.AllocNetworkStatus
+	SetCNIResult(*cstructs.CNIResult)
 }
 
 // networkHook is an alloc lifecycle hook that manages the network namespace
@@ -177,6 +179,12 @@ CREATE:
 				return errors.New("network already configured but not found in state")
 			}
 			status = stateStatus
+		} else if r, ok := h.networkConfigurator.(cniResultReporter); ok {
+			// Keep the result of the CNI plugins which configured the
+			// network, which is only returned when they are called.
+			if res := r.CNIResult(); res != nil {
+				h.networkStatus.SetCNIResult(res)
+			}
 		}
 
 		// If the driver set the sandbox hostname label, then we will use that
@@ -4,9 +4,11 @@
 package allocrunner
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -33,6 +35,7 @@ type mockNetworkStatus struct {
 	expectedStatus *structs.AllocNetworkStatus
 	getCalls       int
 	setCalls       int
+	cniResult      *cstructs.CNIResult
 }
 
 func (m *mockNetworkStatus) SetNetworkStatus(status *structs.AllocNetworkStatus) {
@@ -40,6 +43,10 @@ func (m *mockNetworkStatus) SetNetworkStatus(status *structs.AllocNetworkStatus)
 	test.Eq(m.t, m.expectedStatus, status)
 }
 
+func (m *mockNetworkStatus) SetCNIResult(res *cstructs.CNIResult) {
+	m.cniResult = res
+}
+
 func (m *mockNetworkStatus) NetworkStatus() *structs.AllocNetworkStatus {
 	m.getCalls++
 	return m.expectedStatus
@@ -97,6 +104,66 @@ func TestNetworkHook_Prerun_Postrun_group(t *testing.T) {
 	must.True(t, destroyCalled)
 }
 
+// mockCNINetworkConfigurator is a NetworkConfigurator returning a network
+// status and a CNI result, as if it had called the CNI plugins.
+type mockCNINetworkConfigurator struct {
+	hostNetworkConfigurator
+	status *structs.AllocNetworkStatus
+	result *cstructs.CNIResult
+}
+
+func (m *mockCNINetworkConfigurator) Setup(context.Context, *structs.Allocation, *drivers.NetworkIsolationSpec, bool) (*structs.AllocNetworkStatus, error) {
+	return m.status, nil
+}
+
+func (m *mockCNINetworkConfigurator) CNIResult() *cstructs.CNIResult {
+	return m.result
+}
+
+// Test that the prerun hook keeps the result of the CNI plugins which
+// configured the network.
+func TestNetworkHook_Prerun_CNIResult(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{
+		{Mode: "bridge"},
+	}
+
+	spec := &drivers.NetworkIsolationSpec{
+		Mode: drivers.NetIsolationModeGroup,
+		Path: "test",
+	}
+	nm := &testutils.MockDriver{
+		MockNetworkManager: testutils.MockNetworkManager{
+			CreateNetworkF: func(string, *drivers.NetworkCreateRequest) (*drivers.NetworkIsolationSpec, bool, error) {
+				return spec, true, nil
+			},
+		},
+	}
+	setter := &mockNetworkIsolationSetter{
+		t:            t,
+		expectedSpec: spec,
+	}
+	statusSetter := &mockNetworkStatus{
+		t:              t,
+		expectedStatus: mock.AllocNetworkStatus(),
+	}
+	nc := &mockCNINetworkConfigurator{
+		status: mock.AllocNetworkStatus(),
+		result: &cstructs.CNIResult{
+			Interfaces: []*cstructs.CNIInterface{{Name: "eth0", Sandbox: "test"}},
+		},
+	}
+
+	hook := newNetworkHook(testlog.HCLogger(t), setter, alloc, nm, nc, statusSetter)
+	env := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region).Build()
+
+	must.NoError(t, hook.Prerun(env))
+	must.Eq(t, 1, statusSetter.setCalls)
+	must.Eq(t, nc.result, statusSetter.cniResult)
+}
+
 // Test that prerun and postrun hooks do not expect a NetworkIsolationSpec
 func TestNetworkHook_Prerun_Postrun_host(t *testing.T) {
 	ci.Parallel(t)
@@ -7,6 +7,7 @@ import (
 	"context"
 	"sync"
 
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -18,6 +19,13 @@ type NetworkConfigurator interface {
 	Teardown(context.Context, *structs.Allocation, *drivers.NetworkIsolationSpec) error
 }
 
+// cniResultReporter is implemented by the NetworkConfigurators which configure
+// the network with CNI plugins, to report the result of the plugins from the
+// last Setup which configured the network.
+type cniResultReporter interface {
+	CNIResult() *cstructs.CNIResult
+}
+
 // hostNetworkConfigurator is a noop implementation of a NetworkConfigurator for
 // when the alloc join's a client host's network namespace and thus does not
 // require further configuration
@@ -52,3 +60,12 @@ func (s *synchronizedNetworkConfigurator) Teardown(ctx context.Context, allocati
 	defer networkingGlobalMutex.Unlock()
 	return s.nc.Teardown(ctx, allocation, spec)
 }
+
+func (s *synchronizedNetworkConfigurator) CNIResult() *cstructs.CNIResult {
+	networkingGlobalMutex.Lock()
+	defer networkingGlobalMutex.Unlock()
+	if r, ok := s.nc.(cniResultReporter); ok {
+		return r.CNIResult()
+	}
+	return nil
+}
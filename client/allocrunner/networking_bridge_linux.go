@@ -9,6 +9,7 @@ import (
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/cni"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -128,6 +129,11 @@ func (b *bridgeNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Al
 	return b.cni.Setup(ctx, alloc, spec, created)
 }
 
+// CNIResult returns the result of the CNI plugins from the last Setup
+func (b *bridgeNetworkConfigurator) CNIResult() *cstructs.CNIResult {
+	return b.cni.CNIResult()
+}
+
 // Teardown calls the CNI plugins with the delete action
 func (b *bridgeNetworkConfigurator) Teardown(ctx context.Context, alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec) error {
 	return b.cni.Teardown(ctx, alloc, spec)
@@ -29,6 +29,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/go-version"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/envoy"
@@ -61,6 +62,10 @@ type cniNetworkConfigurator struct {
 	logger                  log.Logger
 	nsOpts                  *nsOpts
 	newIPTables             func(structs.NodeNetworkAF) (IPTablesCleanup, error)
+
+	// result is the result of the CNI plugins from the last Setup which
+	// configured the network
+	result *cstructs.CNIResult
 }
 
 func newCNINetworkConfigurator(logger log.Logger, cniPath, cniInterfacePrefix, cniConfDir, networkName string, ignorePortMappingHostIP bool, node *structs.Node) (*cniNetworkConfigurator, error) {
@@ -244,6 +249,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	if err != nil {
 		return nil, err
 	}
+	c.result = cniToResult(res)
 
 	// overwrite the nameservers with Consul DNS, if we have it; we don't need
 	// the port because the iptables rule redirects port 53 traffic to it
@@ -529,6 +535,69 @@ func (c *cniNetworkConfigurator) cniToAllocNet(res *cni.Result) (*structs.AllocN
 	return netStatus, nil
 }
 
+// CNIResult returns the result of the CNI plugins from the last Setup which
+// configured the network, or nil if the network was already configured.
+func (c *cniNetworkConfigurator) CNIResult() *cstructs.CNIResult {
+	return c.result
+}
+
+// cniToResult converts a cni.Result to the CNIResult kept in the client state,
+// with the interfaces sorted by name so the result is deterministic.
+func cniToResult(res *cni.Result) *cstructs.CNIResult {
+	result := &cstructs.CNIResult{
+		Interfaces: make([]*cstructs.CNIInterface, 0, len(res.Interfaces)),
+		Routes:     make([]*cstructs.CNIRoute, 0, len(res.Routes)),
+	}
+
+	names := make([]string, 0, len(res.Interfaces))
+	for name := range res.Interfaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		iface := res.Interfaces[name]
+		if iface == nil {
+			continue
+		}
+		ci := &cstructs.CNIInterface{
+			Name:      name,
+			MAC:       iface.Mac,
+			Sandbox:   iface.Sandbox,
+			Addresses: make([]*cstructs.CNIAddress, 0, len(iface.IPConfigs)),
+		}
+		for _, ipConfig := range iface.IPConfigs {
+			addr := &cstructs.CNIAddress{Address: ipConfig.IP.String()}
+			if ipConfig.Gateway != nil {
+				addr.Gateway = ipConfig.Gateway.String()
+			}
+			ci.Addresses = append(ci.Addresses, addr)
+		}
+		result.Interfaces = append(result.Interfaces, ci)
+	}
+
+	for _, route := range res.Routes {
+		if route == nil {
+			continue
+		}
+		r := &cstructs.CNIRoute{Destination: route.Dst.String()}
+		if route.GW != nil {
+			r.Gateway = route.GW.String()
+		}
+		result.Routes = append(result.Routes, r)
+	}
+
+	if len(res.DNS) > 0 && len(res.DNS[0].Nameservers) > 0 {
+		result.DNS = &structs.DNSConfig{
+			Servers:  res.DNS[0].Nameservers,
+			Searches: res.DNS[0].Search,
+			Options:  res.DNS[0].Options,
+		}
+	}
+
+	return result
+}
+
 // cniConfParser parses different config formats as appropriate
 type cniConfParser struct {
 	listBytes []byte
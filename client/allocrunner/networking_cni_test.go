@@ -18,6 +18,7 @@ import (
 	"github.com/containernetworking/cni/pkg/types"
 	"github.com/hashicorp/consul/sdk/iptables"
 	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -465,6 +466,50 @@ func TestCNI_cniToAllocNet_Invalid(t *testing.T) {
 	require.Nil(t, allocNet)
 }
 
+// TestCNI_cniToResult asserts the CNI result kept in the client state holds
+// every interface, sorted by name, along with the routes and DNS.
+func TestCNI_cniToResult(t *testing.T) {
+	ci.Parallel(t)
+
+	_, dst, _ := net.ParseCIDR("0.0.0.0/0")
+	cniResult := &cni.Result{
+		Interfaces: map[string]*cni.Config{
+			"nomad": {Mac: "aa:bb:cc:dd:ee:00"},
+			"eth0": {
+				Mac:     "aa:bb:cc:dd:ee:ff",
+				Sandbox: "/var/run/netns/test",
+				IPConfigs: []*cni.IPConfig{{
+					IP:      net.IPv4(172, 26, 64, 2),
+					Gateway: net.IPv4(172, 26, 64, 1),
+				}},
+			},
+			"invalid": nil,
+		},
+		Routes: []*types.Route{{Dst: *dst, GW: net.IPv4(172, 26, 64, 1)}},
+		DNS:    []types.DNS{{Nameservers: []string{"1.1.1.1"}}},
+	}
+
+	must.Eq(t, &cstructs.CNIResult{
+		Interfaces: []*cstructs.CNIInterface{
+			{
+				Name:    "eth0",
+				MAC:     "aa:bb:cc:dd:ee:ff",
+				Sandbox: "/var/run/netns/test",
+				Addresses: []*cstructs.CNIAddress{
+					{Address: "172.26.64.2", Gateway: "172.26.64.1"},
+				},
+			},
+			{
+				Name:      "nomad",
+				MAC:       "aa:bb:cc:dd:ee:00",
+				Addresses: []*cstructs.CNIAddress{},
+			},
+		},
+		Routes: []*cstructs.CNIRoute{{Destination: "0.0.0.0/0", Gateway: "172.26.64.1"}},
+		DNS:    &structs.DNSConfig{Servers: []string{"1.1.1.1"}},
+	}, cniToResult(cniResult))
+}
+
 func TestCNI_cniToAllocNet_Dualstack(t *testing.T) {
 	ci.Parallel(t)
 
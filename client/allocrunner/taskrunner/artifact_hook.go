@@ -6,12 +6,16 @@ package taskrunner
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
 	ci "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/vaultclient"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -20,18 +24,188 @@ type artifactHook struct {
 	eventEmitter ti.EventEmitter
 	logger       log.Logger
 	getter       ci.ArtifactGetter
+
+	// vaultClientFunc is used to issue client certificates for artifacts
+	// with a vault_pki block and AWS credentials for artifacts with a
+	// vault_aws block
+	vaultClientFunc vaultclient.VaultClientFunc
+
+	// rpc and region are used to read the private key of artifacts whose
+	// sshkey option references a Nomad variable
+	rpc    config.RPCer
+	region string
 }
 
-func newArtifactHook(e ti.EventEmitter, getter ci.ArtifactGetter, logger log.Logger) *artifactHook {
+func newArtifactHook(e ti.EventEmitter, getter ci.ArtifactGetter, vaultClientFunc vaultclient.VaultClientFunc, rpc config.RPCer, region string, logger log.Logger) *artifactHook {
 	h := &artifactHook{
-		eventEmitter: e,
-		getter:       getter,
+		eventEmitter:    e,
+		getter:          getter,
+		vaultClientFunc: vaultClientFunc,
+		rpc:             rpc,
+		region:          region,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
 }
 
+// clientCert issues the client certificate configured by the artifact's
+// vault_pki block using the task's Vault token, or returns nil if the
+// artifact has none.
+func (h *artifactHook) clientCert(ctx context.Context, req *interfaces.TaskPrestartRequest, artifact *structs.TaskArtifact) (*ci.ArtifactClientCert, error) {
+	pki := artifact.GetterVaultPKI
+	if pki == nil {
+		return nil, nil
+	}
+
+	vault := req.Task.Vault
+	if vault == nil || req.VaultToken == "" {
+		return nil, fmt.Errorf("vault_pki requires the task to have a Vault token")
+	}
+	if h.vaultClientFunc == nil {
+		return nil, fmt.Errorf("vault_pki requires Vault to be enabled on the client")
+	}
+
+	client, err := h.vaultClientFunc(vault.ClusterName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vault client: %w", err)
+	}
+
+	cert, err := client.IssueCertificate(ctx, vaultclient.PKIIssueRequest{
+		Token:      req.VaultToken,
+		Namespace:  vault.Namespace,
+		Path:       pki.Path,
+		CommonName: req.TaskEnv.ReplaceEnv(pki.CommonName),
+		TTL:        pki.TTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ci.ArtifactClientCert{
+		Certificate: cert.Certificate,
+		PrivateKey:  cert.PrivateKey,
+	}, nil
+}
+
+// awsCredentials generates the AWS credentials configured by the artifact's
+// vault_aws block using the task's Vault token, or returns nil if the artifact
+// has none. The credentials are generated for each download and are not
+// renewed, so they only need to outlive the download.
+func (h *artifactHook) awsCredentials(ctx context.Context, req *interfaces.TaskPrestartRequest, artifact *structs.TaskArtifact) (*ci.ArtifactAWSCredentials, error) {
+	aws := artifact.GetterVaultAWS
+	if aws == nil {
+		return nil, nil
+	}
+
+	vault := req.Task.Vault
+	if vault == nil || req.VaultToken == "" {
+		return nil, fmt.Errorf("%w: vault_aws requires the task to have a Vault token", getter.ErrAuthFailure)
+	}
+	if h.vaultClientFunc == nil {
+		return nil, fmt.Errorf("%w: vault_aws requires Vault to be enabled on the client", getter.ErrAuthFailure)
+	}
+
+	client, err := h.vaultClientFunc(vault.ClusterName())
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get Vault client: %w", getter.ErrAuthFailure, err)
+	}
+
+	creds, err := client.GenerateAWSCredentials(ctx, vaultclient.AWSCredentialsRequest{
+		Token:     req.VaultToken,
+		Namespace: vault.Namespace,
+		Path:      aws.Path,
+		TTL:       aws.TTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", getter.ErrAuthFailure, err)
+	}
+
+	return &ci.ArtifactAWSCredentials{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+	}, nil
+}
+
+// variableItem reads the item of the Nomad variable the option of the artifact
+// references using the task's workload identity, or returns the empty string
+// if the option does not reference a variable. Errors never include the value
+// of the item, as it holds a private key or keytab.
+func (h *artifactHook) variableItem(req *interfaces.TaskPrestartRequest, artifact *structs.TaskArtifact, option string) (string, error) {
+	path, item, ok := structs.ParseGetterVariable(req.TaskEnv.ReplaceEnv(artifact.GetterOptions[option]))
+	if !ok {
+		return "", nil
+	}
+
+	if req.NomadToken == "" {
+		return "", fmt.Errorf("%w: %s variable requires the task to have a workload identity", getter.ErrAuthFailure, option)
+	}
+	if h.rpc == nil {
+		return "", fmt.Errorf("%s variable requires the client to be connected to the servers", option)
+	}
+
+	args := &structs.VariablesReadRequest{
+		Path: path,
+		QueryOptions: structs.QueryOptions{
+			Region:     h.region,
+			Namespace:  req.Alloc.Namespace,
+			AuthToken:  req.NomadToken,
+			AllowStale: true,
+		},
+	}
+	var reply structs.VariablesReadResponse
+	if err := h.rpc.RPC(structs.VariablesReadRPCMethod, args, &reply); err != nil {
+		return "", fmt.Errorf("%w: failed to read %s variable %q: %w", getter.ErrAuthFailure, option, path, err)
+	}
+	if reply.Data == nil {
+		return "", fmt.Errorf("%w: %s variable %q does not exist", getter.ErrAuthFailure, option, path)
+	}
+
+	items := reply.Data.Items
+	if item == "" {
+		if len(items) != 1 {
+			return "", fmt.Errorf("%s variable %q holds %d items, and must be referenced as %s%s#<item>",
+				option, path, len(items), structs.GetterVariablePrefix, path)
+		}
+		for _, value := range items {
+			return value, nil
+		}
+	}
+	value, ok := items[item]
+	if !ok {
+		return "", fmt.Errorf("%s variable %q has no item %q", option, path, item)
+	}
+	return value, nil
+}
+
+// credentials returns the credentials issued for the download of artifact.
+func (h *artifactHook) credentials(ctx context.Context, req *interfaces.TaskPrestartRequest, artifact *structs.TaskArtifact) (*ci.ArtifactCredentials, error) {
+	cert, err := h.clientCert(ctx, req, artifact)
+	if err != nil {
+		return nil, err
+	}
+	aws, err := h.awsCredentials(ctx, req, artifact)
+	if err != nil {
+		return nil, err
+	}
+	sshKey, err := h.variableItem(req, artifact, "sshkey")
+	if err != nil {
+		return nil, err
+	}
+	keytab, err := h.variableItem(req, artifact, "negotiate_keytab")
+	if err != nil {
+		return nil, err
+	}
+	return &ci.ArtifactCredentials{
+		ClientCert:      cert,
+		AWS:             aws,
+		SSHKey:          sshKey,
+		NegotiateKeytab: keytab,
+	}, nil
+}
+
 func (h *artifactHook) doWork(
+	ctx context.Context,
 	req *interfaces.TaskPrestartRequest,
 	resp *interfaces.TaskPrestartResponse,
 	jobs chan *structs.TaskArtifact,
@@ -52,17 +226,34 @@ func (h *artifactHook) doWork(
 
 		h.logger.Debug("downloading artifact", "artifact", artifact.GetterSource, "aid", aid)
 
-		if err := h.getter.Get(req.TaskEnv, artifact, req.Task.User); err != nil {
+		var result *ci.FetchResult
+		creds, err := h.credentials(ctx, req, artifact)
+		if err == nil {
+			result, err = h.getter.GetWithCredentials(req.TaskEnv, artifact, req.Task.User, creds)
+		}
+		if err != nil {
 			wrapped := structs.NewRecoverableError(
-				fmt.Errorf("failed to download artifact %q: %v", artifact.GetterSource, err),
+				fmt.Errorf("failed to download artifact %q: %w", artifact.GetterSource, err),
 				true,
 			)
-			herr := NewHookError(wrapped, structs.NewTaskEvent(structs.TaskArtifactDownloadFailed).SetDownloadError(wrapped))
+			event := structs.NewTaskEvent(structs.TaskArtifactDownloadFailed).SetDownloadError(wrapped)
+			setFetchResultDetails(event, result)
+			herr := NewHookError(wrapped, event)
 
 			errorChannel <- herr
 			continue
 		}
 
+		h.logger.Debug("downloaded artifact", "artifact", artifact.GetterSource, "aid", aid,
+			"duration", result.Duration,
+			"bytes", result.Bytes,
+			"redirects", result.Redirects,
+			"retries", result.Retries,
+			"cache", result.Cache,
+			"scheme", result.Scheme,
+			"sha256", result.SHA256,
+		)
+
 		// Mark artifact as downloaded to avoid re-downloading due to
 		// retries caused by subsequent artifacts failing. Any
 		// non-empty value works.
@@ -72,6 +263,27 @@ func (h *artifactHook) doWork(
 	}
 }
 
+// setFetchResultDetails adds how the artifact was fetched to the details of
+// the task event, if it was fetched at all.
+func setFetchResultDetails(event *structs.TaskEvent, result *ci.FetchResult) {
+	if result == nil {
+		return
+	}
+	event.Details["fetch_duration"] = result.Duration.String()
+	event.Details["fetch_bytes"] = strconv.FormatInt(result.Bytes, 10)
+	event.Details["fetch_redirects"] = strconv.Itoa(result.Redirects)
+	event.Details["fetch_retries"] = strconv.Itoa(result.Retries)
+	if result.Cache != ci.FetchCacheNone {
+		event.Details["fetch_cache"] = string(result.Cache)
+	}
+	if result.Scheme != "" {
+		event.Details["fetch_scheme"] = result.Scheme
+	}
+	if result.SHA256 != "" {
+		event.Details["fetch_sha256"] = result.SHA256
+	}
+}
+
 func (*artifactHook) Name() string {
 	// Copied in client/state when upgrading from <0.9 schemas, so if you
 	// change it here you also must change it there.
@@ -107,7 +319,7 @@ func (h *artifactHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 		var wg sync.WaitGroup
 		for i := 0; i < maxConcurrency; i++ {
 			wg.Add(1)
-			go h.doWork(req, resp, jobsChannel, errorChannel, &wg, responseStateMutex)
+			go h.doWork(ctx, req, resp, jobsChannel, errorChannel, &wg, responseStateMutex)
 		}
 		wg.Wait()
 	}()
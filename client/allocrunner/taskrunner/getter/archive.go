@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// contentTypeArchives maps the content types of archives to the name of the
+// go-getter decompressor which extracts them. In "any" mode the destination is
+// a directory, which go-getter can only extract archives into, so compressed
+// content is assumed to be a compressed tarball.
+var contentTypeArchives = map[string]string{
+	"application/gzip":             "tgz",
+	"application/x-gzip":           "tgz",
+	"application/x-tgz":            "tgz",
+	"application/x-compressed-tar": "tgz",
+	"application/x-bzip2":          "tbz2",
+	"application/x-xz":             "txz",
+	"application/zstd":             "tzst",
+	"application/x-tar":            "tar",
+	"application/zip":              "zip",
+	"application/x-zip-compressed": "zip",
+}
+
+// archiveFromContentType returns the source with an "archive" query parameter
+// naming the decompressor for the content type the server reports for it.
+//
+// go-getter decides whether to extract an artifact from the "archive" query
+// parameter or the file extension of the source. Content type detection is
+// only a fallback for http(s) sources in "any" mode which have neither. The
+// source is returned unmodified if the content type cannot be determined or
+// is not a known archive type.
+func archiveFromContentType(ctx context.Context, p *parameters) string {
+	if p.Mode != getter.ClientModeAny {
+		return p.Source
+	}
+
+	u, err := url.Parse(p.Source)
+	if err != nil {
+		return p.Source
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return p.Source
+	}
+	if u.Query().Has("archive") || path.Ext(u.Path) != "" {
+		return p.Source
+	}
+
+	archive, ok := contentTypeArchives[contentType(ctx, p, u)]
+	if !ok {
+		return p.Source
+	}
+
+	q := u.Query()
+	q.Set("archive", archive)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// contentType returns the media type the server reports for u in response to
+// a HEAD request, or the empty string if it cannot be determined.
+func contentType(ctx context.Context, p *parameters, u *url.URL) string {
+	header, ok := head(ctx, p, u)
+	if !ok {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(mediaType)
+}
+
+// ErrAutoExtractDisabled is returned for artifacts which rely on being
+// extracted implicitly when the client disables automatic extraction.
+var ErrAutoExtractDisabled = errors.New(`artifact would be extracted automatically, which is disabled on this client; set mode = "dir" or the "archive" option to extract it`)
+
+// disableAutoExtract returns the source with extraction disabled, for clients
+// configured with disable_auto_extract.
+//
+// Artifacts which explicitly opt into extraction, with the "archive" option or
+// in "dir" mode, are returned unmodified. Other artifacts are stored verbatim,
+// except for those in "any" mode which would have been extracted because of
+// their file extension; they rely on implicit extraction and are rejected with
+// ErrAutoExtractDisabled.
+func disableAutoExtract(source string, mode getter.ClientMode) (string, error) {
+	// preserve a forced getter, such as "git::"
+	forced, rest := "", source
+	if i := strings.Index(source, "::"); i > 0 {
+		forced, rest = source[:i+2], source[i+2:]
+	}
+
+	u, err := url.Parse(rest)
+	if err != nil {
+		// leave reporting the invalid source to go-getter
+		return source, nil
+	}
+
+	q := u.Query()
+	if q.Has("archive") || mode == getter.ClientModeDir {
+		return source, nil
+	}
+	if mode == getter.ClientModeAny && archiveExtension(u.Path) != "" {
+		return "", ErrAutoExtractDisabled
+	}
+
+	q.Set("archive", "false")
+	u.RawQuery = q.Encode()
+	return forced + u.String(), nil
+}
+
+// archiveExtension returns the longest extension of p which go-getter would
+// extract, or the empty string if there is none.
+func archiveExtension(p string) string {
+	match := ""
+	for ext := range getter.Decompressors {
+		if strings.HasSuffix(p, "."+ext) && len(ext) > len(match) {
+			match = ext
+		}
+	}
+	return match
+}
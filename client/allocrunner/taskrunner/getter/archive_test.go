@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestArchive_archiveFromContentType(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gzip":
+			w.Header().Set("Content-Type", "application/gzip")
+		case "/zip":
+			w.Header().Set("Content-Type", "application/zip; charset=binary")
+		case "/auth":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/x-tar")
+		case "/nohead":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+		}
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		name    string
+		source  string
+		mode    getter.ClientMode
+		headers map[string][]string
+		exp     string
+	}{
+		{
+			name:   "gzip",
+			source: srv.URL + "/gzip",
+			mode:   getter.ClientModeAny,
+			exp:    srv.URL + "/gzip?archive=tgz",
+		},
+		{
+			name:   "zip with parameters",
+			source: srv.URL + "/zip?checksum=sha256%3Aabc",
+			mode:   getter.ClientModeAny,
+			exp:    srv.URL + "/zip?archive=zip&checksum=sha256%3Aabc",
+		},
+		{
+			name:    "headers",
+			source:  srv.URL + "/auth",
+			mode:    getter.ClientModeAny,
+			headers: map[string][]string{"Authorization": {"Bearer token"}},
+			exp:     srv.URL + "/auth?archive=tar",
+		},
+		{
+			name:   "not an archive",
+			source: srv.URL + "/text",
+			mode:   getter.ClientModeAny,
+			exp:    srv.URL + "/text",
+		},
+		{
+			name:   "head not allowed",
+			source: srv.URL + "/nohead",
+			mode:   getter.ClientModeAny,
+			exp:    srv.URL + "/nohead",
+		},
+		{
+			name:   "explicit archive",
+			source: srv.URL + "/gzip?archive=false",
+			mode:   getter.ClientModeAny,
+			exp:    srv.URL + "/gzip?archive=false",
+		},
+		{
+			name:   "extension",
+			source: srv.URL + "/gzip.txt",
+			mode:   getter.ClientModeAny,
+			exp:    srv.URL + "/gzip.txt",
+		},
+		{
+			name:   "file mode",
+			source: srv.URL + "/gzip",
+			mode:   getter.ClientModeFile,
+			exp:    srv.URL + "/gzip",
+		},
+		{
+			name:   "not http",
+			source: "git::" + srv.URL + "/gzip",
+			mode:   getter.ClientModeAny,
+			exp:    "git::" + srv.URL + "/gzip",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &parameters{
+				Source:  tc.source,
+				Mode:    tc.mode,
+				Headers: tc.headers,
+			}
+			must.Eq(t, tc.exp, archiveFromContentType(context.Background(), p))
+		})
+	}
+}
+
+func TestArchive_disableAutoExtract(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		source string
+		mode   getter.ClientMode
+		exp    string
+		expErr error
+	}{
+		{
+			name:   "explicit archive",
+			source: "https://example.com/file.tar.gz?archive=tgz",
+			mode:   getter.ClientModeAny,
+			exp:    "https://example.com/file.tar.gz?archive=tgz",
+		},
+		{
+			name:   "dir mode",
+			source: "https://example.com/file.tar.gz",
+			mode:   getter.ClientModeDir,
+			exp:    "https://example.com/file.tar.gz",
+		},
+		{
+			name:   "file mode",
+			source: "https://example.com/file.tar.gz?checksum=sha256%3Aabc",
+			mode:   getter.ClientModeFile,
+			exp:    "https://example.com/file.tar.gz?archive=false&checksum=sha256%3Aabc",
+		},
+		{
+			name:   "any mode archive",
+			source: "https://example.com/file.tar.gz",
+			mode:   getter.ClientModeAny,
+			expErr: ErrAutoExtractDisabled,
+		},
+		{
+			name:   "any mode extensionless",
+			source: "https://example.com/file",
+			mode:   getter.ClientModeAny,
+			exp:    "https://example.com/file?archive=false",
+		},
+		{
+			name:   "forced getter",
+			source: "git::https://github.com/hashicorp/nomad?ref=v1.0.0",
+			mode:   getter.ClientModeAny,
+			exp:    "git::https://github.com/hashicorp/nomad?archive=false&ref=v1.0.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := disableAutoExtract(tc.source, tc.mode)
+			if tc.expErr != nil {
+				must.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, result)
+		})
+	}
+}
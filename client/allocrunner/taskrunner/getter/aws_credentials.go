@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrAuthFailure is returned when the credentials an artifact is downloaded
+// with cannot be obtained, such as when Vault fails to generate the AWS
+// credentials of an artifact with a vault_aws block.
+var ErrAuthFailure = errors.New("failed to obtain artifact credentials")
+
+// setAWSCredentials sets the AWS credentials of the parameters, if any, as
+// the environment of the getter sub-process, where they take precedence over
+// any other credentials the s3 getter would find. The credentials are only
+// used by the s3 getter for this download.
+func (p *parameters) setAWSCredentials() error {
+	if p.AWSAccessKeyID == "" {
+		return nil
+	}
+
+	env := map[string]string{
+		"AWS_ACCESS_KEY_ID":     p.AWSAccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": p.AWSSecretAccessKey,
+		"AWS_SESSION_TOKEN":     p.AWSSessionToken,
+	}
+	for name, value := range env {
+		if err := os.Setenv(name, value); err != nil {
+			return err
+		}
+	}
+
+	// the instance credentials would otherwise be used instead
+	return os.Unsetenv("AWS_METADATA_URL")
+}
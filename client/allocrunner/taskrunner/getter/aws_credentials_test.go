@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"os"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+// TestAWSCredentials_setAWSCredentials is not parallel as it sets the
+// environment of the test process.
+func TestAWSCredentials_setAWSCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "client")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "client-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "client-session")
+	t.Setenv("AWS_METADATA_URL", "http://169.254.169.254")
+
+	t.Run("none", func(t *testing.T) {
+		p := &parameters{}
+		must.NoError(t, p.setAWSCredentials())
+		must.Eq(t, "client", os.Getenv("AWS_ACCESS_KEY_ID"))
+		must.Eq(t, "http://169.254.169.254", os.Getenv("AWS_METADATA_URL"))
+	})
+
+	t.Run("static", func(t *testing.T) {
+		p := &parameters{
+			AWSAccessKeyID:     "AKIA",
+			AWSSecretAccessKey: "secret",
+		}
+		must.NoError(t, p.setAWSCredentials())
+		must.Eq(t, "AKIA", os.Getenv("AWS_ACCESS_KEY_ID"))
+		must.Eq(t, "secret", os.Getenv("AWS_SECRET_ACCESS_KEY"))
+		must.Eq(t, "", os.Getenv("AWS_SESSION_TOKEN"))
+
+		_, ok := os.LookupEnv("AWS_METADATA_URL")
+		must.False(t, ok)
+	})
+
+	t.Run("temporary", func(t *testing.T) {
+		p := &parameters{
+			AWSAccessKeyID:     "ASIA",
+			AWSSecretAccessKey: "secret",
+			AWSSessionToken:    "session",
+		}
+		must.NoError(t, p.setAWSCredentials())
+		must.Eq(t, "ASIA", os.Getenv("AWS_ACCESS_KEY_ID"))
+		must.Eq(t, "session", os.Getenv("AWS_SESSION_TOKEN"))
+	})
+}
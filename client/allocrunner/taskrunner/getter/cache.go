@@ -0,0 +1,497 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+)
+
+const (
+	// cacheDataName is the name of the file or directory inside of a cache
+	// entry which contains the downloaded artifact content.
+	cacheDataName = "data"
+
+	// cacheDigestName is the name of the file inside of a cache entry which
+	// contains the tree digest of the entry content, recorded when the entry
+	// was created.
+	cacheDigestName = "digest"
+
+	// cacheSHA256Name is the name of the file inside of a cache entry which
+	// contains the SHA-256 digest of the content received when the entry was
+	// downloaded, if it is known.
+	cacheSHA256Name = "sha256"
+
+	// cacheStagingPrefix is the prefix of the temporary directories that
+	// artifacts are downloaded into before being moved into the cache.
+	cacheStagingPrefix = "staging-"
+
+	// cacheSourcePrefix is the prefix of the keys of entries holding the
+	// latest download of an artifact source, which are served when a later
+	// download of the same source fails.
+	cacheSourcePrefix = "source-"
+)
+
+// ErrCacheDisabled is returned when an operation requires the artifact
+// cache but the client was not configured with a cache directory.
+var ErrCacheDisabled = errors.New("artifact cache is not enabled")
+
+// cache is a content-addressable store of downloaded artifacts. Entries are
+// keyed by the checksum declared on the artifact, which go-getter verifies
+// before a download completes. Because the same content may be laid out
+// differently on disk depending on how it was fetched (e.g. extracted or
+// not), the key also covers the resolved source and getter mode.
+//
+// The content of an entry may be a directory tree rather than the file the
+// checksum was computed over, so a digest of the tree is recorded when the
+// entry is created and checked again before the entry is used.
+//
+// When the client serves stale artifacts, the cache also holds the latest
+// download of artifacts without a checksum, keyed by their source instead.
+// These entries are replaced by every successful download of the source.
+//
+// Entries are never removed automatically; the cache grows until an operator
+// removes entries from the cache directory.
+type cache struct {
+	dir string
+}
+
+// newCache returns a cache rooted at dir, or nil if dir is empty.
+func newCache(dir string) *cache {
+	if dir == "" {
+		return nil
+	}
+	return &cache{dir: dir}
+}
+
+// cacheKey returns the key under which the artifact fetched from source with
+// the given mode is stored. An empty key is returned if the checksum is not of
+// the form "type:value", in which case the artifact cannot be cached.
+//
+// The source is the fully resolved source URL, including any options which
+// affect the layout of the downloaded content, such as "archive". Keeping the
+// archive also affects the layout.
+func cacheKey(checksum string, mode getter.ClientMode, source string, keepArchive bool) string {
+	kind, value, ok := strings.Cut(strings.TrimSpace(checksum), ":")
+	if !ok || kind == "" || value == "" || strings.ContainsAny(value, `/\:`) {
+		return ""
+	}
+
+	h := sha256.New()
+	_, _ = io.WriteString(h, modeName(mode))
+	_, _ = io.WriteString(h, "\x00")
+	_, _ = io.WriteString(h, source)
+	if keepArchive {
+		_, _ = io.WriteString(h, "\x00keep_archive")
+	}
+	layout := hex.EncodeToString(h.Sum(nil))[:16]
+
+	return fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), strings.ToLower(value), layout)
+}
+
+// sourceKey returns the key under which the latest download of the artifact
+// described by params is stored for tasks of namespace. The namespace and the
+// credentials of the download are part of the key, so that a download made for
+// one task is never served to a task of another namespace, or presenting
+// different credentials. Credentials read from files within the task directory
+// are keyed by their path, so such downloads are only shared by the same task,
+// and ssh credentials by their fingerprint, as they are written to a new
+// directory for every download.
+func sourceKey(namespace string, params *parameters) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, namespace)
+	_, _ = io.WriteString(h, "\x00")
+	_, _ = io.WriteString(h, modeName(params.Mode))
+	_, _ = io.WriteString(h, "\x00")
+	_, _ = io.WriteString(h, params.Source)
+	if params.KeepArchive {
+		_, _ = io.WriteString(h, "\x00keep_archive")
+	}
+	for _, name := range slices.Sorted(maps.Keys(params.Headers)) {
+		for _, value := range params.Headers[name] {
+			_, _ = io.WriteString(h, "\x00"+name+":"+value)
+		}
+	}
+	for _, cred := range []string{
+		params.ClientCert, params.ClientKey,
+		params.AWSAccessKeyID, params.AWSSecretAccessKey, params.AWSSessionToken,
+		params.Proxy,
+	} {
+		_, _ = io.WriteString(h, "\x00"+cred)
+	}
+	_, _ = io.WriteString(h, "\x00")
+	_ = json.NewEncoder(h).Encode(params.PreAuth)
+	_ = json.NewEncoder(h).Encode(params.Negotiate)
+	if params.SSH != nil {
+		_, _ = io.WriteString(h, "\x00ssh:"+params.SSH.Fingerprint)
+	}
+	return cacheSourcePrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// modeName returns a stable name for mode.
+func modeName(mode getter.ClientMode) string {
+	switch mode {
+	case getter.ClientModeFile:
+		return "file"
+	case getter.ClientModeDir:
+		return "dir"
+	default:
+		return "any"
+	}
+}
+
+// entry returns the path to the content of the cache entry for key.
+func (c *cache) entry(key string) string {
+	return filepath.Join(c.dir, key, cacheDataName)
+}
+
+// lookup returns the path to the cached content for key, if it exists.
+func (c *cache) lookup(key string) (string, bool) {
+	if c == nil || key == "" {
+		return "", false
+	}
+	path := c.entry(key)
+	if _, err := os.Lstat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// verify checks that the content of the cache entry for key still matches the
+// digest recorded when the entry was created.
+func (c *cache) verify(key string) error {
+	expected, err := os.ReadFile(filepath.Join(c.dir, key, cacheDigestName))
+	if err != nil {
+		return fmt.Errorf("failed to read cache entry digest: %w", err)
+	}
+
+	actual, err := treeDigest(c.entry(key))
+	if err != nil {
+		return fmt.Errorf("failed to compute cache entry digest: %w", err)
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(expected), []byte(actual)) {
+		return fmt.Errorf("cache entry digest mismatch: expected %s, got %s", bytes.TrimSpace(expected), actual)
+	}
+	return nil
+}
+
+// sha256 returns the SHA-256 digest of the content received when the cache
+// entry for key was downloaded, or an empty string if it is not known.
+func (c *cache) sha256(key string) string {
+	sum, err := os.ReadFile(filepath.Join(c.dir, key, cacheSHA256Name))
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(sum))
+}
+
+// recordSHA256 records the SHA-256 digest of the content received when
+// downloading into staging, so that it is reported when the entry is
+// restored.
+func (c *cache) recordSHA256(staging, sum string) error {
+	if sum == "" {
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(staging, cacheSHA256Name), []byte(sum), 0o600); err != nil {
+		return fmt.Errorf("failed to write artifact digest: %w", err)
+	}
+	return nil
+}
+
+// age returns how long ago the cache entry for key was created, if it exists.
+func (c *cache) age(key string) (time.Duration, bool) {
+	if c == nil || key == "" {
+		return 0, false
+	}
+	info, err := os.Stat(filepath.Join(c.dir, key, cacheDigestName))
+	if err != nil {
+		return 0, false
+	}
+	return time.Since(info.ModTime()), true
+}
+
+// evict removes the cache entry for key.
+func (c *cache) evict(key string) error {
+	if c == nil || key == "" {
+		return nil
+	}
+	return os.RemoveAll(filepath.Join(c.dir, key))
+}
+
+// stage creates a temporary directory inside the cache into which an
+// artifact can be downloaded. The directory contains a tmp directory so that
+// it can be used as the task directory of the getter sub-process.
+func (c *cache) stage() (string, error) {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create artifact cache: %w", err)
+	}
+	staging, err := os.MkdirTemp(c.dir, cacheStagingPrefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to create artifact cache staging directory: %w", err)
+	}
+	if err := os.Mkdir(filepath.Join(staging, "tmp"), 0o700); err != nil {
+		_ = os.RemoveAll(staging)
+		return "", fmt.Errorf("failed to create artifact cache staging directory: %w", err)
+	}
+	return staging, nil
+}
+
+// commit records the digest of the content downloaded into staging and moves
+// it into the cache entry for key. If another download populated the entry
+// first the staged content is discarded, unless the entry is keyed by source,
+// in which case it is replaced by the newer download. The staging directory is
+// always removed.
+func (c *cache) commit(staging, key string) error {
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	if strings.HasPrefix(key, cacheSourcePrefix) {
+		if err := c.evict(key); err != nil {
+			return fmt.Errorf("failed to replace cached artifact: %w", err)
+		}
+	} else if _, exists := c.lookup(key); exists {
+		return nil
+	}
+
+	if err := os.RemoveAll(filepath.Join(staging, "tmp")); err != nil {
+		return err
+	}
+
+	digest, err := treeDigest(filepath.Join(staging, cacheDataName))
+	if err != nil {
+		return fmt.Errorf("failed to compute artifact digest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, cacheDigestName), []byte(digest), 0o600); err != nil {
+		return fmt.Errorf("failed to write artifact digest: %w", err)
+	}
+
+	err = os.Rename(staging, filepath.Join(c.dir, key))
+	if err != nil && !errors.Is(err, fs.ErrExist) {
+		if _, exists := c.lookup(key); exists {
+			return nil
+		}
+		return fmt.Errorf("failed to store artifact in cache: %w", err)
+	}
+	return nil
+}
+
+// vcsMetadata is the set of names of the files and directories holding the
+// metadata of a version control system checkout, which treeDigest skips. A
+// submodule checkout holds a .git file rather than a directory.
+var vcsMetadata = map[string]bool{
+	".git": true,
+	".hg":  true,
+}
+
+// treeDigest returns the hex encoded SHA-256 digest of the file or directory
+// tree rooted at root. Entries are visited in lexical order and each
+// contributes its slash separated path relative to root, its type, and its
+// content: the bytes of a regular file, or the target of a symlink. The
+// metadata of version control systems is left out, because git and hg rewrite
+// it when checking out the same revision again.
+//
+// The digest is also the value of tree-sha256 artifact checksums, so its
+// format must not change; it is documented for users computing the expected
+// value of an artifact.
+func treeDigest(root string) (string, error) {
+	return filteredTreeDigest(root, nil)
+}
+
+// filteredTreeDigest returns the digest of the tree rooted at root like
+// treeDigest, leaving out the entries below root for which include, if set,
+// returns false. The entries of an excluded directory are still visited.
+func filteredTreeDigest(root string, include func(path string) (bool, error)) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." && vcsMetadata[d.Name()] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel != "." && include != nil {
+			if ok, err := include(path); err != nil || !ok {
+				return err
+			}
+		}
+		_, _ = io.WriteString(h, filepath.ToSlash(rel))
+		_, _ = io.WriteString(h, "\x00")
+
+		switch {
+		case d.IsDir():
+			_, _ = io.WriteString(h, "d\x00")
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			_, _ = io.WriteString(h, "l\x00"+link+"\x00")
+		case d.Type().IsRegular():
+			_, _ = io.WriteString(h, "f\x00")
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(h, f)
+			_ = f.Close()
+			if err != nil {
+				return err
+			}
+			_, _ = io.WriteString(h, "\x00")
+		default:
+			return fmt.Errorf("irregular file %q", rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// restoreCached copies the cached artifact content at src to dst. It is run by
+// the getter sub-process, after the sub-process has been isolated. Existing
+// files at the destination are replaced and directories are merged, mirroring
+// the behavior of a fresh download into the same destination.
+//
+// Symlinks already present at the destination are never followed; they are
+// replaced by the cached content. The parent of dst must resolve to a path
+// within allocDir.
+func restoreCached(src, dst, allocDir string) error {
+	if err := checkParentWithin(allocDir, dst); err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return makeDir(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := removeExisting(target); err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		default:
+			return fmt.Errorf("cannot copy irregular file %q", rel)
+		}
+	})
+}
+
+// checkParentWithin returns ErrSandboxEscape if the deepest existing parent of
+// path does not resolve to a location within root.
+func checkParentWithin(root, path string) error {
+	parent, escapes, err := escapingfs.ParentEscapes(root, path)
+	if err != nil {
+		return err
+	}
+	if escapes {
+		return fmt.Errorf("%w: %s", ErrSandboxEscape, parent)
+	}
+	return os.MkdirAll(filepath.Dir(path), 0o755)
+}
+
+// makeDir ensures path is a directory, replacing a symlink if one exists.
+func makeDir(path string, perm fs.FileMode) error {
+	info, err := os.Lstat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return os.Mkdir(path, perm)
+	case err != nil:
+		return err
+	case info.IsDir():
+		return nil
+	case info.Mode()&fs.ModeSymlink != 0:
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		return os.Mkdir(path, perm)
+	default:
+		return fmt.Errorf("destination %q exists and is not a directory", path)
+	}
+}
+
+// removeExisting removes the file or symlink at path, if any, without
+// following symlinks.
+func removeExisting(path string) error {
+	info, err := os.Lstat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	case info.IsDir():
+		return fmt.Errorf("destination %q exists and is a directory", path)
+	default:
+		return os.Remove(path)
+	}
+}
+
+// copyFile copies the regular file at src to dst with the given permissions.
+// Any existing file or symlink at dst is replaced.
+func copyFile(src, dst string, perm fs.FileMode) error {
+	if err := removeExisting(dst); err != nil {
+		return err
+	}
+
+	r, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	w, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, r); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestCache_cacheKey(t *testing.T) {
+	ci.Parallel(t)
+
+	const source = "https://example.com/a.tgz?checksum=sha256%3Aabc123"
+
+	key := cacheKey("sha256:ABC123", getter.ClientModeFile, source, false)
+	must.StrHasPrefix(t, "sha256-abc123-", key)
+	must.Eq(t, key, cacheKey(" sha256:abc123 ", getter.ClientModeFile, source, false))
+
+	// the layout of the content depends on the mode and source options
+	must.NotEq(t, key, cacheKey("sha256:abc123", getter.ClientModeAny, source, false))
+	must.NotEq(t, key, cacheKey("sha256:abc123", getter.ClientModeFile, source+"&archive=false", false))
+	must.NotEq(t, key, cacheKey("sha256:abc123", getter.ClientModeFile, source, true))
+
+	must.Eq(t, "", cacheKey("", getter.ClientModeAny, source, false))
+	must.Eq(t, "", cacheKey("sha256", getter.ClientModeAny, source, false))
+	must.Eq(t, "", cacheKey("file:https://example.com/sums", getter.ClientModeAny, source, false))
+}
+
+func TestCache_sourceKey(t *testing.T) {
+	ci.Parallel(t)
+
+	newParams := func() *parameters {
+		return &parameters{
+			Mode:    getter.ClientModeAny,
+			Source:  "https://example.com/a.tgz",
+			Headers: http.Header{"Authorization": {"Bearer abc"}},
+		}
+	}
+
+	key := sourceKey("default", newParams())
+	must.StrHasPrefix(t, cacheSourcePrefix, key)
+	must.StrNotContains(t, key, "abc")
+	must.Eq(t, key, sourceKey("default", newParams()))
+
+	// downloads made with different options or credentials, or for another
+	// namespace, are never shared
+	for name, modify := range map[string]func(*parameters){
+		"mode":         func(p *parameters) { p.Mode = getter.ClientModeFile },
+		"source":       func(p *parameters) { p.Source += "?archive=false" },
+		"keep archive": func(p *parameters) { p.KeepArchive = true },
+		"headers":      func(p *parameters) { p.Headers = http.Header{"Authorization": {"Bearer xyz"}} },
+		"no headers":   func(p *parameters) { p.Headers = nil },
+		"client cert":  func(p *parameters) { p.ClientCert, p.ClientKey = "/task/cert.pem", "/task/key.pem" },
+		"aws":          func(p *parameters) { p.AWSAccessKeyID, p.AWSSecretAccessKey = "AKIA", "secret" },
+		"proxy":        func(p *parameters) { p.Proxy = "http://proxy.example.com:3128" },
+		"pre auth":     func(p *parameters) { p.PreAuth = &preAuth{URL: "https://example.com/login"} },
+		"negotiate":    func(p *parameters) { p.Negotiate = &negotiate{Principal: "web@EXAMPLE.COM"} },
+		"ssh":          func(p *parameters) { p.SSH = &sshConfig{Fingerprint: "abc"} },
+	} {
+		p := newParams()
+		modify(p)
+		must.NotEq(t, key, sourceKey("default", p), must.Sprint(name))
+	}
+	must.NotEq(t, key, sourceKey("other", newParams()))
+
+	// ssh credentials are keyed by their fingerprint rather than the
+	// directory they are written to
+	withSSH := func(dir, fingerprint string) *parameters {
+		p := newParams()
+		p.SSH = &sshConfig{Dir: dir, Command: "ssh -i '" + dir + "/id_key'", Fingerprint: fingerprint}
+		return p
+	}
+	must.Eq(t, sourceKey("default", withSSH("/tmp/a", "abc")), sourceKey("default", withSSH("/tmp/b", "abc")))
+	must.NotEq(t, sourceKey("default", withSSH("/tmp/a", "abc")), sourceKey("default", withSSH("/tmp/a", "xyz")))
+}
+
+func TestCache_commit_source(t *testing.T) {
+	ci.Parallel(t)
+
+	c := newCache(filepath.Join(t.TempDir(), "artifacts"))
+	key := sourceKey("default", &parameters{Mode: getter.ClientModeAny, Source: "https://example.com/a.txt"})
+	_, ok := c.age(key)
+	must.False(t, ok)
+
+	commit := func(content string) {
+		staging, err := c.stage()
+		must.NoError(t, err)
+		data := filepath.Join(staging, cacheDataName)
+		must.NoError(t, os.MkdirAll(data, 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(data, "a.txt"), []byte(content), 0o644))
+		must.NoError(t, c.commit(staging, key))
+	}
+
+	commit("a")
+	age, ok := c.age(key)
+	must.True(t, ok)
+	must.Less(t, time.Minute, age)
+
+	// entries keyed by source are replaced by newer downloads
+	commit("b")
+	must.NoError(t, c.verify(key))
+	b, err := os.ReadFile(filepath.Join(c.entry(key), "a.txt"))
+	must.NoError(t, err)
+	must.Eq(t, "b", string(b))
+}
+
+func TestCache_lookup_commit(t *testing.T) {
+	ci.Parallel(t)
+
+	var disabled *cache
+	_, ok := disabled.lookup("sha256-abc-any")
+	must.False(t, ok)
+
+	c := newCache(filepath.Join(t.TempDir(), "artifacts"))
+	_, ok = c.lookup("sha256-abc-any")
+	must.False(t, ok)
+
+	staging, err := c.stage()
+	must.NoError(t, err)
+	data := filepath.Join(staging, cacheDataName)
+	must.NoError(t, os.MkdirAll(data, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(data, "a.txt"), []byte("a"), 0o644))
+
+	must.NoError(t, c.commit(staging, "sha256-abc-any"))
+	_, err = os.Stat(staging)
+	must.True(t, os.IsNotExist(err))
+
+	_, ok = c.lookup("sha256-abc-any")
+	must.True(t, ok)
+	must.NoError(t, c.verify("sha256-abc-any"))
+
+	// modified content fails verification
+	must.NoError(t, os.WriteFile(filepath.Join(c.entry("sha256-abc-any"), "a.txt"), []byte("b"), 0o644))
+	must.ErrorContains(t, c.verify("sha256-abc-any"), "cache entry digest mismatch")
+
+	must.NoError(t, c.evict("sha256-abc-any"))
+	_, ok = c.lookup("sha256-abc-any")
+	must.False(t, ok)
+}
+
+func TestCache_restoreCached(t *testing.T) {
+	ci.Parallel(t)
+
+	src := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("b"), 0o644))
+
+	t.Run("merge", func(t *testing.T) {
+		allocDir := t.TempDir()
+		dst := filepath.Join(allocDir, "local")
+		must.NoError(t, os.MkdirAll(dst, 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(dst, "a.txt"), []byte("old"), 0o644))
+		must.NoError(t, os.WriteFile(filepath.Join(dst, "keep.txt"), []byte("keep"), 0o644))
+
+		must.NoError(t, restoreCached(src, dst, allocDir))
+
+		b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+		must.NoError(t, err)
+		must.Eq(t, "a", string(b))
+		must.FileExists(t, filepath.Join(dst, "keep.txt"))
+		must.FileExists(t, filepath.Join(dst, "sub", "b.txt"))
+	})
+
+	t.Run("symlinks are replaced", func(t *testing.T) {
+		outside := t.TempDir()
+		victim := filepath.Join(outside, "victim")
+		must.NoError(t, os.WriteFile(victim, []byte("original"), 0o644))
+
+		allocDir := t.TempDir()
+		dst := filepath.Join(allocDir, "local")
+		must.NoError(t, os.MkdirAll(dst, 0o755))
+		must.NoError(t, os.Symlink(victim, filepath.Join(dst, "a.txt")))
+		must.NoError(t, os.Symlink(outside, filepath.Join(dst, "sub")))
+
+		must.NoError(t, restoreCached(src, dst, allocDir))
+
+		b, err := os.ReadFile(victim)
+		must.NoError(t, err)
+		must.Eq(t, "original", string(b))
+		must.FileNotExists(t, filepath.Join(outside, "b.txt"))
+
+		info, err := os.Lstat(filepath.Join(dst, "sub"))
+		must.NoError(t, err)
+		must.True(t, info.IsDir())
+	})
+
+	t.Run("parent escapes", func(t *testing.T) {
+		allocDir := t.TempDir()
+		must.NoError(t, os.Symlink(t.TempDir(), filepath.Join(allocDir, "local")))
+
+		err := restoreCached(src, filepath.Join(allocDir, "local", "dst"), allocDir)
+		must.ErrorIs(t, err, ErrSandboxEscape)
+	})
+}
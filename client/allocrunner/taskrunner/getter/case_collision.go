@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// ErrCaseCollision is returned for artifacts whose archive holds files with
+// paths that differ only in case, such as README and readme, when the
+// destination is on a case-insensitive filesystem. Extracting them would
+// silently overwrite one file with the other.
+var ErrCaseCollision = errors.New("artifact archive has paths which collide on a case-insensitive filesystem")
+
+// exitCaseCollision is the exit code of the getter sub-process when the
+// artifact archive has colliding paths, so that ErrCaseCollision can be
+// returned across the process boundary.
+const exitCaseCollision = 4
+
+// caseCollisionDecompressor is a go-getter Decompressor which refuses to
+// extract archives holding paths that collide on the case-insensitive
+// filesystem of the destination.
+type caseCollisionDecompressor struct {
+	getter.Decompressor
+
+	// ext is the extension of the archives, which decides how their entries
+	// are listed.
+	ext string
+
+	// caseInsensitive returns whether the filesystem holding the directory
+	// dir is case-insensitive.
+	caseInsensitive func(dir string) (bool, error)
+}
+
+// detectCaseCollisions wraps each of the tarball and zip decompressors of
+// decompressors so that extracting an archive with paths colliding on a
+// case-insensitive destination returns ErrCaseCollision.
+func detectCaseCollisions(decompressors map[string]getter.Decompressor) map[string]getter.Decompressor {
+	result := make(map[string]getter.Decompressor, len(decompressors))
+	for ext, d := range decompressors {
+		if _, ok := tarCompressions[ext]; !ok && ext != "zip" {
+			result[ext] = d
+			continue
+		}
+		result[ext] = &caseCollisionDecompressor{
+			Decompressor:    d,
+			ext:             ext,
+			caseInsensitive: isCaseInsensitive,
+		}
+	}
+	return result
+}
+
+// Decompress extracts the archive at src into dst, unless dst is on a
+// case-insensitive filesystem and the archive has paths which differ only in
+// case.
+func (c *caseCollisionDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	if !dir {
+		return c.Decompressor.Decompress(dst, src, dir, umask)
+	}
+
+	insensitive, err := c.caseInsensitive(dst)
+	if err != nil {
+		return fmt.Errorf("failed to check case sensitivity of %s: %w", dst, err)
+	}
+	if insensitive {
+		names, err := archiveEntries(src, c.ext)
+		if err != nil {
+			return err
+		}
+		if a, b, ok := findCaseCollision(names); ok {
+			return fmt.Errorf("%w: %q and %q", ErrCaseCollision, a, b)
+		}
+	}
+
+	return c.Decompressor.Decompress(dst, src, dir, umask)
+}
+
+// archiveEntry is the path and mode of an entry of an archive.
+type archiveEntry struct {
+	name      string
+	isDir     bool
+	isSymlink bool
+	mode      fs.FileMode
+}
+
+// archiveEntries returns the entries of the tarball or zip archive at src,
+// with the given extension.
+func archiveEntries(src, ext string) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	err := eachArchiveEntry(src, ext, func(entry archiveEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// eachArchiveEntry calls fn with each entry of the tarball or zip archive at
+// src, with the given extension, in the order they are stored. The first
+// error returned by fn stops the iteration and is returned.
+func eachArchiveEntry(src, ext string, fn func(archiveEntry) error) error {
+	if ext == "zip" {
+		zipR, err := zip.OpenReader(src)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = zipR.Close() }()
+
+		for _, f := range zipR.File {
+			entry := archiveEntry{
+				name:      f.Name,
+				isDir:     f.FileInfo().IsDir(),
+				isSymlink: f.Mode()&fs.ModeSymlink != 0,
+				mode:      f.Mode(),
+			}
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	r, err := decompressReader(f, tarCompressions[ext])
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	tarR := newTarEntryReader(r)
+	for {
+		hdr, err := tarR.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		entry := archiveEntry{
+			name:      hdr.Name,
+			isDir:     hdr.FileInfo().IsDir(),
+			isSymlink: hdr.Typeflag == tar.TypeSymlink,
+			mode:      hdr.FileInfo().Mode(),
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// findCaseCollision returns the first two of entries whose paths differ only
+// in case, where at least one of them is not a directory. Directories which
+// differ only in case are merged on extraction rather than overwritten, and
+// entries repeating the same path are overwritten on any filesystem.
+func findCaseCollision(entries []archiveEntry) (string, string, bool) {
+	seen := make(map[string]archiveEntry, len(entries))
+	for _, entry := range entries {
+		entry.name = path.Clean(filepath.ToSlash(entry.name))
+		key := strings.ToLower(entry.name)
+
+		prev, ok := seen[key]
+		if !ok {
+			seen[key] = entry
+			continue
+		}
+		if prev.name != entry.name && !(prev.isDir && entry.isDir) {
+			return prev.name, entry.name, true
+		}
+	}
+	return "", "", false
+}
+
+// isCaseInsensitive returns whether the filesystem holding dir is
+// case-insensitive, by creating a file in the closest directory of dir which
+// exists and looking it up by its name in upper case.
+func isCaseInsensitive(dir string) (bool, error) {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil && info.IsDir() {
+			break
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false, nil
+		}
+		dir = parent
+	}
+
+	f, err := os.CreateTemp(dir, ".nomad-case-probe-")
+	if err != nil {
+		return false, err
+	}
+	probe := f.Name()
+	_ = f.Close()
+	defer func() { _ = os.Remove(probe) }()
+
+	upper := filepath.Join(dir, strings.ToUpper(filepath.Base(probe)))
+	probeInfo, err := os.Stat(probe)
+	if err != nil {
+		return false, err
+	}
+	upperInfo, err := os.Stat(upper)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return os.SameFile(probeInfo, upperInfo), nil
+}
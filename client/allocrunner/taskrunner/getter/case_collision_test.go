@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// collidingTarball returns a gzip compressed tarball holding files whose
+// paths differ only in case.
+func collidingTarball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/", Mode: 0o755, Typeflag: tar.TypeDir}))
+	for _, name := range []string{"app/README", "app/readme"} {
+		must.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(name))}))
+		_, err := tw.Write([]byte(name))
+		must.NoError(t, err)
+	}
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// collidingZip returns a zip archive holding files whose paths differ only in
+// case.
+func collidingZip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"App/config.json", "app/CONFIG.json"} {
+		w, err := zw.Create(name)
+		must.NoError(t, err)
+		_, err = w.Write([]byte(name))
+		must.NoError(t, err)
+	}
+	must.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestCaseCollision_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		ext     string
+		archive []byte
+		expect  string
+		files   []string
+	}{
+		{
+			name:    "tarball",
+			ext:     "tar.gz",
+			archive: collidingTarball(t),
+			expect:  `: "app/README" and "app/readme"`,
+			files:   []string{"app/README", "app/readme"},
+		},
+		{
+			name:    "zip",
+			ext:     "zip",
+			archive: collidingZip(t),
+			expect:  `: "App/config.json" and "app/CONFIG.json"`,
+			files:   []string{"App/config.json", "app/CONFIG.json"},
+		},
+	}
+
+	for _, tc := range cases {
+		decompress := func(t *testing.T, insensitive bool) (string, error) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "app."+tc.ext)
+			must.NoError(t, os.WriteFile(src, tc.archive, 0o644))
+			dst := filepath.Join(dir, "local")
+
+			d := detectCaseCollisions(getter.LimitedDecompressors(0, 0))[tc.ext].(*caseCollisionDecompressor)
+			d.caseInsensitive = func(string) (bool, error) { return insensitive, nil }
+			return dst, d.Decompress(dst, src, true, 0)
+		}
+
+		t.Run(tc.name+" case-insensitive", func(t *testing.T) {
+			dst, err := decompress(t, true)
+			must.ErrorIs(t, err, ErrCaseCollision)
+			must.ErrorContains(t, err, tc.expect)
+
+			// nothing is extracted
+			_, err = os.Stat(dst)
+			must.ErrorIs(t, err, os.ErrNotExist)
+		})
+
+		t.Run(tc.name+" case-sensitive", func(t *testing.T) {
+			dst, err := decompress(t, false)
+			must.NoError(t, err)
+			for _, name := range tc.files {
+				content, err := os.ReadFile(filepath.Join(dst, name))
+				must.NoError(t, err)
+				must.Eq(t, name, string(content))
+			}
+		})
+	}
+}
+
+func TestCaseCollision_detectCaseCollisions(t *testing.T) {
+	ci.Parallel(t)
+
+	decompressors := detectCaseCollisions(getter.LimitedDecompressors(0, 0))
+	for _, ext := range []string{"tar", "tar.gz", "tgz", "tar.zst", "zip"} {
+		d, ok := decompressors[ext].(*caseCollisionDecompressor)
+		must.True(t, ok, must.Sprint(ext))
+		must.Eq(t, ext, d.ext)
+	}
+
+	// single files cannot collide
+	_, ok := decompressors["gz"].(*getter.GzipDecompressor)
+	must.True(t, ok)
+}
+
+func TestCaseCollision_findCaseCollision(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		entries []archiveEntry
+		a, b    string
+	}{
+		{
+			name:    "distinct",
+			entries: []archiveEntry{{name: "a/README"}, {name: "a/LICENSE"}},
+		},
+		{
+			name:    "repeated",
+			entries: []archiveEntry{{name: "a/README"}, {name: "./a/README"}},
+		},
+		{
+			name:    "directories",
+			entries: []archiveEntry{{name: "App/", isDir: true}, {name: "app/", isDir: true}},
+		},
+		{
+			name:    "files",
+			entries: []archiveEntry{{name: "a/README"}, {name: "a/b"}, {name: "a/readme"}},
+			a:       "a/README",
+			b:       "a/readme",
+		},
+		{
+			name:    "file and directory",
+			entries: []archiveEntry{{name: "bin", isDir: true}, {name: "BIN"}},
+			a:       "bin",
+			b:       "BIN",
+		},
+		{
+			name:    "parent directories",
+			entries: []archiveEntry{{name: "App/run.sh"}, {name: "app/run.sh"}},
+			a:       "App/run.sh",
+			b:       "app/run.sh",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b, ok := findCaseCollision(tc.entries)
+			must.Eq(t, tc.a != "", ok)
+			must.Eq(t, tc.a, a)
+			must.Eq(t, tc.b, b)
+		})
+	}
+}
+
+func TestCaseCollision_isCaseInsensitive(t *testing.T) {
+	ci.Parallel(t)
+
+	if runtime.GOOS != "linux" {
+		t.Skip("the case sensitivity of the temporary directory varies")
+	}
+
+	dir := t.TempDir()
+	insensitive, err := isCaseInsensitive(filepath.Join(dir, "does", "not", "exist"))
+	must.NoError(t, err)
+	must.False(t, insensitive)
+
+	// the probe is removed
+	entries, err := os.ReadDir(dir)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+}
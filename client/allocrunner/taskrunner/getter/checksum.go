@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+)
+
+// checksumHashes are the file checksum algorithms supported by artifact
+// verification, keyed by the checksum type used in the artifact "checksum"
+// option.
+var checksumHashes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// ChecksumAlgorithms returns the checksum types supported by artifact
+// verification, in lexical order.
+func ChecksumAlgorithms() []string {
+	algos := []string{treeChecksumType}
+	for algo := range checksumHashes {
+		algos = append(algos, algo)
+	}
+	sort.Strings(algos)
+	return algos
+}
+
+// ComputeChecksum returns the value of the artifact "checksum" option, as
+// "type:value", which verifies the file or directory at path using algo.
+// Directories may only be checksummed with tree-sha256.
+func ComputeChecksum(path, algo string) (string, error) {
+	if algo == treeChecksumType {
+		digest, err := treeDigest(path)
+		if err != nil {
+			return "", err
+		}
+		return algo + ":" + digest, nil
+	}
+
+	newHash, ok := checksumHashes[algo]
+	if !ok {
+		return "", unsupportedChecksum(algo)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s checksum requires a regular file but %q is not", algo, path)
+	}
+
+	return checksumReader(f, algo, newHash)
+}
+
+// ComputeChecksumURL returns the value of the artifact "checksum" option, as
+// "type:value", which verifies the file served at the http or https source
+// using algo. Tree checksums are computed over the downloaded artifact and so
+// can only be computed with ComputeChecksum.
+func ComputeChecksumURL(ctx context.Context, source, algo string) (string, error) {
+	newHash, ok := checksumHashes[algo]
+	if !ok {
+		if algo == treeChecksumType {
+			return "", fmt.Errorf("%s checksum cannot be computed from a URL", algo)
+		}
+		return "", unsupportedChecksum(algo)
+	}
+
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse source URL %q: %w", source, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("source URL scheme must be http or https but found %q", u.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad response code: %d", resp.StatusCode)
+	}
+
+	return checksumReader(resp.Body, algo, newHash)
+}
+
+// checksumReader returns the "type:value" checksum of the content of r.
+func checksumReader(r io.Reader, algo string, newHash func() hash.Hash) (string, error) {
+	h := newHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return algo + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func unsupportedChecksum(algo string) error {
+	return fmt.Errorf("unsupported checksum type %q, must be one of %v", algo, ChecksumAlgorithms())
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestChecksum_ChecksumAlgorithms(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, []string{"md5", "sha1", "sha256", "sha512", "tree-sha256"}, ChecksumAlgorithms())
+}
+
+func TestChecksum_ComputeChecksum(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	must.NoError(t, os.WriteFile(file, []byte("hello"), 0o644))
+
+	cases := []struct {
+		algo string
+		exp  string
+	}{
+		{algo: "md5", exp: "md5:5d41402abc4b2a76b9719d911017c592"},
+		{algo: "sha1", exp: "sha1:aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"},
+		{algo: "sha256", exp: sha256Checksum("hello")},
+		{algo: "sha512", exp: "sha512:9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.algo, func(t *testing.T) {
+			sum, err := ComputeChecksum(file, tc.algo)
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, sum)
+
+			// the checksum is accepted by go-getter verification
+			dst := filepath.Join(t.TempDir(), "out.txt")
+			src := fmt.Sprintf("%s?checksum=%s", file, sum)
+			must.NoError(t, getter.GetFile(dst, src))
+		})
+	}
+
+	t.Run("tree-sha256", func(t *testing.T) {
+		sum, err := ComputeChecksum(dir, treeChecksumType)
+		must.NoError(t, err)
+		digest, err := treeDigest(dir)
+		must.NoError(t, err)
+		must.Eq(t, "tree-sha256:"+digest, sum)
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		_, err := ComputeChecksum(dir, "sha256")
+		must.ErrorContains(t, err, "requires a regular file")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := ComputeChecksum(file, "crc32")
+		must.EqError(t, err, `unsupported checksum type "crc32", must be one of [md5 sha1 sha256 sha512 tree-sha256]`)
+	})
+}
+
+func TestChecksum_ComputeChecksumURL(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/file.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+
+	sum, err := ComputeChecksumURL(ctx, srv.URL+"/file.txt", "sha256")
+	must.NoError(t, err)
+	must.Eq(t, sha256Checksum("hello"), sum)
+
+	_, err = ComputeChecksumURL(ctx, srv.URL+"/missing.txt", "sha256")
+	must.EqError(t, err, "bad response code: 404")
+
+	_, err = ComputeChecksumURL(ctx, srv.URL+"/file.txt", treeChecksumType)
+	must.EqError(t, err, "tree-sha256 checksum cannot be computed from a URL")
+
+	_, err = ComputeChecksumURL(ctx, "s3::https://bucket/file.txt", "sha256")
+	must.ErrorContains(t, err, "scheme must be http or https")
+}
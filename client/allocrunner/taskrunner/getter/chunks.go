@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// firstInvalidChunk returns the offset of the first chunk of the file at path
+// which does not match its checksum in part, or of the bytes past the last
+// chunk, or -1 if every chunk matches. A missing file has no valid chunk.
+func firstInvalidChunk(path string, part *manifestPart) (int64, error) {
+	if len(part.Chunks) == 0 {
+		return -1, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, min(part.ChunkSize, 1<<20))
+	for i, checksum := range part.Chunks {
+		offset := int64(i) * part.ChunkSize
+
+		kind, _, _ := strings.Cut(checksum, ":")
+		h := checksumHashes[kind]()
+		n, err := io.CopyBuffer(h, io.LimitReader(f, part.ChunkSize), buf)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read chunk %d: %w", i, err)
+		}
+
+		// every chunk but the last is whole
+		if n == 0 || (n < part.ChunkSize && i < len(part.Chunks)-1) {
+			return offset, nil
+		}
+		if !strings.EqualFold(kind+":"+hex.EncodeToString(h.Sum(nil)), checksum) {
+			return offset, nil
+		}
+	}
+
+	// the file may not be longer than its chunks
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if end := int64(len(part.Chunks)) * part.ChunkSize; info.Size() > end {
+		return end, nil
+	}
+	return -1, nil
+}
+
+// truncateInvalidChunks truncates the file at path before its first chunk
+// which does not match its checksum in part, so that downloading the part
+// again resumes from it.
+func truncateInvalidChunks(path string, part *manifestPart) error {
+	offset, err := firstInvalidChunk(path, part)
+	if err != nil || offset < 0 {
+		return err
+	}
+	if err := os.Truncate(path, offset); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestChunks_firstInvalidChunk(t *testing.T) {
+	ci.Parallel(t)
+
+	part := &manifestPart{
+		ChunkSize: 4,
+		Chunks:    []string{sha256Checksum("aaaa"), sha256Checksum("bbbb"), sha256Checksum("cc")},
+	}
+
+	cases := []struct {
+		name    string
+		content *string
+		exp     int64
+	}{
+		{name: "missing", exp: 0},
+		{name: "empty", content: pointer.Of(""), exp: 0},
+		{name: "complete", content: pointer.Of("aaaabbbbcc"), exp: -1},
+		{name: "partial chunk", content: pointer.Of("aaaabb"), exp: 4},
+		{name: "partial last chunk", content: pointer.Of("aaaabbbbc"), exp: 8},
+		{name: "corrupt chunk", content: pointer.Of("aaaabXbbcc"), exp: 4},
+		{name: "longer than chunks", content: pointer.Of("aaaabbbbccdd"), exp: 8},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "part")
+			if tc.content != nil {
+				must.NoError(t, os.WriteFile(path, []byte(*tc.content), 0o644))
+			}
+
+			offset, err := firstInvalidChunk(path, part)
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, offset)
+		})
+	}
+
+	t.Run("truncate", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "part")
+		must.NoError(t, os.WriteFile(path, []byte("aaaabXbbcc"), 0o644))
+		must.NoError(t, truncateInvalidChunks(path, part))
+
+		b, err := os.ReadFile(path)
+		must.NoError(t, err)
+		must.Eq(t, "aaaa", string(b))
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// ErrContentTypeMismatch is returned for http(s) artifacts served with a
+// Content-Type other than the one the artifact expects, such as an HTML error
+// or login page served in place of the artifact. The same content is served
+// when the artifact is downloaded again, so it is not worth retrying.
+var ErrContentTypeMismatch = errors.New("artifact content type mismatch")
+
+// exitContentTypeMismatch is the exit code of the getter sub-process when the
+// artifact is served with an unexpected content type, so that
+// ErrContentTypeMismatch can be returned across the process boundary.
+const exitContentTypeMismatch = 9
+
+// matchContentType returns ErrContentTypeMismatch unless the media type of the
+// Content-Type header actual matches expected. Parameters such as the charset
+// are ignored, and expected may be a wildcard such as "application/*".
+func matchContentType(expected, actual string) error {
+	want, _, err := mime.ParseMediaType(expected)
+	if err != nil {
+		return fmt.Errorf("invalid expected content type %q: %w", expected, err)
+	}
+	want = strings.ToLower(want)
+
+	got, _, err := mime.ParseMediaType(actual)
+	if err == nil {
+		got = strings.ToLower(got)
+		if got == want {
+			return nil
+		}
+		if prefix, ok := strings.CutSuffix(want, "/*"); ok && strings.HasPrefix(got, prefix+"/") {
+			return nil
+		}
+	}
+
+	if actual == "" {
+		actual = "none"
+	}
+	return fmt.Errorf("%w: expected %q, got %q", ErrContentTypeMismatch, expected, actual)
+}
+
+// contentTypeTransport is an http.RoundTripper which fails successful GET
+// responses whose Content-Type does not match expected before their body is
+// read, so that the wrong content is never saved as the artifact. Redirects
+// are followed by the http client before the final response is checked.
+type contentTypeTransport struct {
+	http.RoundTripper
+	expected string
+
+	// err is the mismatch, if any, which is recorded as go-getter does not
+	// wrap the errors of every download
+	err error
+}
+
+func (t *contentTypeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Method != http.MethodGet || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, nil
+	}
+
+	if err := matchContentType(t.expected, resp.Header.Get("Content-Type")); err != nil {
+		_ = resp.Body.Close()
+		t.err = err
+		return nil, err
+	}
+	return resp, nil
+}
+
+// contentTypeError returns the content type mismatch of the artifact
+// downloaded by c, if there was one, or err otherwise.
+func contentTypeError(c *getter.Client, err error) error {
+	g, ok := c.Getters["http"].(*getter.HttpGetter)
+	if !ok || g.Client == nil {
+		return err
+	}
+	if t, ok := g.Client.Transport.(*contentTypeTransport); ok && t.err != nil {
+		return t.err
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestContentType_matchContentType(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		expected string
+		actual   string
+		err      string
+	}{
+		{expected: "application/gzip", actual: "application/gzip"},
+		{expected: "application/gzip", actual: "Application/GZIP"},
+		{expected: "application/json", actual: "application/json; charset=utf-8"},
+		{expected: "application/*", actual: "application/zip"},
+		{expected: "application/gzip", actual: "text/html; charset=utf-8",
+			err: `artifact content type mismatch: expected "application/gzip", got "text/html; charset=utf-8"`},
+		{expected: "application/*", actual: "text/html",
+			err: `expected "application/*", got "text/html"`},
+		{expected: "application/gzip", actual: "",
+			err: `expected "application/gzip", got "none"`},
+		{expected: "application/gzip", actual: "garbage",
+			err: `expected "application/gzip", got "garbage"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expected+" "+tc.actual, func(t *testing.T) {
+			err := matchContentType(tc.expected, tc.actual)
+			if tc.err == "" {
+				must.NoError(t, err)
+				return
+			}
+			must.ErrorIs(t, err, ErrContentTypeMismatch)
+			must.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestContentType_client(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "/app.tar.gz", http.StatusFound)
+		case "/app.tar.gz":
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write([]byte("archive"))
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>please log in</html>"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, path string) (string, error) {
+		p := &parameters{
+			ExpectContentType: "application/gzip",
+			Source:            srv.URL + path + "?archive=false",
+			Destination:       filepath.Join(t.TempDir(), "out"),
+		}
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		return p.Destination, contentTypeError(c, c.Get())
+	}
+
+	t.Run("match", func(t *testing.T) {
+		dst, err := get(t, "/redirect")
+		must.NoError(t, err)
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.Eq(t, "archive", string(b))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		dst, err := get(t, "/login")
+		must.ErrorIs(t, err, ErrContentTypeMismatch)
+		must.ErrorContains(t, err, `expected "application/gzip", got "text/html; charset=utf-8"`)
+
+		// go-getter creates the destination before requesting the artifact,
+		// but none of the content is saved
+		b, _ := os.ReadFile(dst)
+		must.SliceEmpty(t, b)
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// dataURIFileName is the name of the file the content of a data URI is written
+// to when the artifact destination is a directory, such as the default local/
+// directory, since data URIs have no path to name the file after.
+const dataURIFileName = "data"
+
+// getDataURI writes the content embedded in the data URI source of p to its
+// destination, rather than downloading it with go-getter. go-getter would
+// mistake any "//" in the encoded content for a subdirectory, and write the
+// content to a file named after the URL path, which data URIs do not have.
+// The content is subject to the maximum download size, as if it were
+// downloaded over http(s).
+func getDataURI(p *parameters) error {
+	content, err := structs.ParseDataURI(p.Source)
+	if err != nil {
+		return err
+	}
+	if p.HTTPMaxBytes > 0 && int64(len(content)) > p.HTTPMaxBytes {
+		return maxBytesError(p.HTTPMaxBytes)
+	}
+
+	if p.Mode == getter.ClientModeDir {
+		return fmt.Errorf(`data URI cannot be downloaded as a directory, set the artifact mode to "file"`)
+	}
+	dst := p.Destination
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		dst = filepath.Join(dst, dataURIFileName)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("failed to create artifact destination: %w", err)
+	}
+	if err := os.WriteFile(dst, content, 0o644); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(content)
+	p.stats.recordContent(sum[:])
+	return nil
+}
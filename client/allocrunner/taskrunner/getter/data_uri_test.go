@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDataURI_getDataURI(t *testing.T) {
+	ci.Parallel(t)
+
+	get := func(t *testing.T, source string, maxBytes int64) (string, error) {
+		p := &parameters{
+			HTTPMaxBytes: maxBytes,
+			Mode:         getter.ClientModeAny,
+			Source:       source,
+			Destination:  filepath.Join(t.TempDir(), "local", "config.txt"),
+		}
+		return p.Destination, getDataURI(p)
+	}
+
+	t.Run("base64", func(t *testing.T) {
+		// "//" in the encoded content is not mistaken for a subdirectory
+		dst, err := get(t, "data:text/plain;base64,Pz8/Pz8//w==", 0)
+		must.NoError(t, err)
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.Eq(t, []byte{'?', '?', '?', '?', '?', '?', 0xff}, b)
+	})
+
+	t.Run("percent encoded", func(t *testing.T) {
+		p := &parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "data:,port%20%3D%208080",
+			Destination: filepath.Join(t.TempDir(), "local", "config.txt"),
+			stats:       new(fetchStats),
+		}
+		must.NoError(t, getDataURI(p))
+		b, err := os.ReadFile(p.Destination)
+		must.NoError(t, err)
+		must.Eq(t, "port = 8080", string(b))
+
+		// the digest of the decoded content is reported
+		must.Eq(t, sha256Checksum("port = 8080"), "sha256:"+p.stats.SHA256)
+	})
+
+	t.Run("exceeds max bytes", func(t *testing.T) {
+		dst, err := get(t, "data:,port%20%3D%208080", 4)
+		must.ErrorIs(t, err, ErrMaxBytesExceeded)
+		_, err = os.Stat(dst)
+		must.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := get(t, "data:;base64,!!!", 0)
+		must.ErrorContains(t, err, "invalid base64 encoding")
+	})
+
+	t.Run("directory destination", func(t *testing.T) {
+		p := &parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "data:,hello",
+			Destination: t.TempDir(),
+		}
+		must.NoError(t, getDataURI(p))
+		b, err := os.ReadFile(filepath.Join(p.Destination, dataURIFileName))
+		must.NoError(t, err)
+		must.Eq(t, "hello", string(b))
+	})
+}
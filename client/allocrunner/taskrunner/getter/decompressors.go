@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// Names of the decompressors registered by defaultDecompressorRegistry, which
+// the client configuration may force for an extension.
+const (
+	// decompressorGoGetter is the name of the decompressors of go-getter.
+	decompressorGoGetter = "go-getter"
+
+	// decompressorSparse is the name of the tarball decompressors extracting
+	// sparse entries as sparse files.
+	decompressorSparse = "sparse"
+)
+
+// Priorities of the registered decompressors. For each extension, the
+// decompressor with the highest priority is used, unless the client
+// configuration forces another one.
+const (
+	decompressorPriorityGoGetter = 0
+	decompressorPrioritySparse   = 10
+)
+
+// decompressorFactory creates the decompressor of archives with extension ext,
+// within the limits of p.
+type decompressorFactory func(ext string, p *parameters) getter.Decompressor
+
+// DecompressorLimits are the limits of the client configuration within which
+// a decompressor extracts an archive.
+type DecompressorLimits struct {
+	// FileCount is the maximum number of files extracted, or 0 if unlimited.
+	FileCount int
+
+	// Size is the maximum number of bytes extracted, or 0 if unlimited.
+	Size int64
+}
+
+// DecompressorFactory creates the decompressor of archives with extension ext,
+// within limits.
+type DecompressorFactory func(ext string, limits DecompressorLimits) getter.Decompressor
+
+// registeredDecompressor is a decompressor registered with
+// RegisterDecompressor for the extensions exts.
+type registeredDecompressor struct {
+	decompressorRegistration
+	exts []string
+}
+
+var (
+	// registeredDecompressors are the decompressors registered with
+	// RegisterDecompressor, in order of registration
+	registeredDecompressors     []registeredDecompressor
+	registeredDecompressorsLock sync.Mutex
+)
+
+// RegisterDecompressor registers the decompressor name for each of the archive
+// extensions exts, in addition to those Nomad registers. For each extension,
+// the decompressor with the highest priority is used unless the client
+// configuration forces another one with decompressor_overrides. Artifacts are
+// extracted by a sub-process running the same binary as the Nomad agent, so
+// builds of Nomad must register their decompressors from an init function for
+// them to be registered in the sub-process too. Registrations which would make
+// the decompressor used for an extension ambiguous are rejected.
+func RegisterDecompressor(name string, priority int, exts []string, factory DecompressorFactory) error {
+	registeredDecompressorsLock.Lock()
+	defer registeredDecompressorsLock.Unlock()
+
+	if factory == nil {
+		return fmt.Errorf("decompressor %q has no factory", name)
+	}
+	reg := registeredDecompressor{
+		decompressorRegistration: decompressorRegistration{
+			name:     name,
+			priority: priority,
+			factory: func(ext string, p *parameters) getter.Decompressor {
+				return factory(ext, DecompressorLimits{
+					FileCount: p.DecompressionLimitFileCount,
+					Size:      p.DecompressionLimitSize,
+				})
+			},
+		},
+		exts: slices.Clone(exts),
+	}
+
+	// registering into the registry used to extract artifacts checks that
+	// the decompressor does not conflict with those already registered
+	r := builtinDecompressorRegistry()
+	if err := r.registerAll(append(slices.Clone(registeredDecompressors), reg)); err != nil {
+		return err
+	}
+	registeredDecompressors = append(registeredDecompressors, reg)
+	return nil
+}
+
+// decompressorRegistration is a decompressor registered for an extension.
+type decompressorRegistration struct {
+	name     string
+	priority int
+	factory  decompressorFactory
+}
+
+// decompressorRegistry holds the decompressors which can extract each
+// extension, so that the one used is chosen explicitly by priority or by the
+// client configuration rather than by the order in which they are set up.
+type decompressorRegistry struct {
+	byExt map[string][]decompressorRegistration
+}
+
+func newDecompressorRegistry() *decompressorRegistry {
+	return &decompressorRegistry{
+		byExt: make(map[string][]decompressorRegistration),
+	}
+}
+
+// defaultDecompressorRegistry returns the registry of the decompressors used
+// to extract artifacts: those Nomad registers, and those registered with
+// RegisterDecompressor.
+func defaultDecompressorRegistry() *decompressorRegistry {
+	registeredDecompressorsLock.Lock()
+	defer registeredDecompressorsLock.Unlock()
+
+	// the registered decompressors were checked for conflicts as they were
+	// registered
+	r := builtinDecompressorRegistry()
+	if err := r.registerAll(registeredDecompressors); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// builtinDecompressorRegistry returns the registry of the decompressors Nomad
+// registers: those of go-getter for every extension, and the sparse tarball
+// decompressors with a higher priority.
+func builtinDecompressorRegistry() *decompressorRegistry {
+	r := newDecompressorRegistry()
+
+	// the registrations below never conflict
+	goGetterExts := slices.Sorted(maps.Keys(getter.LimitedDecompressors(0, 0)))
+	if err := r.register(decompressorGoGetter, decompressorPriorityGoGetter, goGetterExts,
+		func(ext string, p *parameters) getter.Decompressor {
+			return getter.LimitedDecompressors(p.DecompressionLimitFileCount, p.DecompressionLimitSize)[ext]
+		}); err != nil {
+		panic(err)
+	}
+
+	tarExts := slices.Sorted(maps.Keys(tarCompressions))
+	if err := r.register(decompressorSparse, decompressorPrioritySparse, tarExts, newSparseTarDecompressor); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// register adds the decompressor name for each of exts. Registering a
+// decompressor twice for the same extension, or with the same priority as
+// another decompressor of the extension, is an error since the decompressor
+// used for the extension would be ambiguous.
+func (r *decompressorRegistry) register(name string, priority int, exts []string, factory decompressorFactory) error {
+	if name == "" {
+		return fmt.Errorf("decompressor must have a name")
+	}
+	for _, ext := range exts {
+		for _, reg := range r.byExt[ext] {
+			switch {
+			case reg.name == name:
+				return fmt.Errorf("decompressor %q is already registered for %q", name, ext)
+			case reg.priority == priority:
+				return fmt.Errorf("decompressor %q has the same priority %d as %q for %q", name, priority, reg.name, ext)
+			}
+		}
+	}
+	for _, ext := range exts {
+		r.byExt[ext] = append(r.byExt[ext], decompressorRegistration{
+			name:     name,
+			priority: priority,
+			factory:  factory,
+		})
+		sort.Slice(r.byExt[ext], func(i, j int) bool {
+			return r.byExt[ext][i].priority > r.byExt[ext][j].priority
+		})
+	}
+	return nil
+}
+
+// registerAll registers each of regs for its extensions.
+func (r *decompressorRegistry) registerAll(regs []registeredDecompressor) error {
+	for _, reg := range regs {
+		if err := r.register(reg.name, reg.priority, reg.exts, reg.factory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate returns an error if overrides forces a decompressor which is not
+// registered for the extension.
+func (r *decompressorRegistry) validate(overrides map[string]string) error {
+	for _, ext := range slices.Sorted(maps.Keys(overrides)) {
+		if _, err := r.lookup(ext, overrides[ext]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup returns the registration of the decompressor name for ext.
+func (r *decompressorRegistry) lookup(ext, name string) (decompressorRegistration, error) {
+	regs, ok := r.byExt[ext]
+	if !ok {
+		return decompressorRegistration{}, fmt.Errorf("no decompressor is registered for %q", ext)
+	}
+	names := make([]string, len(regs))
+	for i, reg := range regs {
+		if reg.name == name {
+			return reg, nil
+		}
+		names[i] = reg.name
+	}
+	return decompressorRegistration{}, fmt.Errorf("decompressor %q is not registered for %q, must be one of: %s",
+		name, ext, strings.Join(names, ", "))
+}
+
+// decompressors returns the decompressor of each registered extension, which
+// is the one forced by p, or otherwise the one with the highest priority.
+func (r *decompressorRegistry) decompressors(p *parameters) (map[string]getter.Decompressor, error) {
+	result := make(map[string]getter.Decompressor, len(r.byExt))
+	for ext, regs := range r.byExt {
+		reg := regs[0]
+		if name, ok := p.DecompressorOverrides[ext]; ok {
+			var err error
+			if reg, err = r.lookup(ext, name); err != nil {
+				return nil, err
+			}
+		}
+		result[ext] = reg.factory(ext, p)
+	}
+	return result, nil
+}
+
+// ValidateDecompressorOverrides returns an error if overrides, which maps
+// archive extensions to the names of the decompressors forced for them, names
+// a decompressor which is not registered for its extension.
+func ValidateDecompressorOverrides(overrides map[string]string) error {
+	return defaultDecompressorRegistry().validate(overrides)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// fakeDecompressor is a decompressor which records the limits it was
+// created with.
+type fakeDecompressor struct {
+	ext        string
+	filesLimit int
+}
+
+func (d *fakeDecompressor) Decompress(string, string, bool, os.FileMode) error {
+	return nil
+}
+
+func fakeDecompressorFactory(ext string, p *parameters) getter.Decompressor {
+	return &fakeDecompressor{ext: ext, filesLimit: p.DecompressionLimitFileCount}
+}
+
+func TestDecompressorRegistry_defaults(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &parameters{
+		DecompressionLimitFileCount: 3,
+		DecompressionLimitSize:      98765,
+	}
+	decompressors, err := defaultDecompressorRegistry().decompressors(p)
+	must.NoError(t, err)
+
+	// every extension of go-getter is registered
+	for ext := range getter.LimitedDecompressors(0, 0) {
+		must.MapContainsKey(t, decompressors, ext)
+	}
+
+	// tarballs are extracted by the sparse decompressors, which have a higher
+	// priority than those of go-getter
+	for ext := range tarCompressions {
+		d, ok := decompressors[ext].(*sparseTarDecompressor)
+		must.True(t, ok, must.Sprint(ext))
+		must.Eq(t, 98765, d.FileSizeLimit)
+	}
+
+	zip, ok := decompressors["zip"].(*getter.ZipDecompressor)
+	must.True(t, ok)
+	must.Eq(t, 3, zip.FilesLimit)
+	_, ok = decompressors["gz"].(*getter.GzipDecompressor)
+	must.True(t, ok)
+}
+
+func TestDecompressorRegistry_overrides(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &parameters{
+		DecompressionLimitSize: 98765,
+		DecompressorOverrides: map[string]string{
+			"tar.gz": decompressorGoGetter,
+			"tar":    decompressorSparse,
+		},
+	}
+	decompressors, err := defaultDecompressorRegistry().decompressors(p)
+	must.NoError(t, err)
+
+	tgz, ok := decompressors["tar.gz"].(*getter.TarGzipDecompressor)
+	must.True(t, ok)
+	must.Eq(t, 98765, tgz.FileSizeLimit)
+	_, ok = decompressors["tar"].(*sparseTarDecompressor)
+	must.True(t, ok)
+
+	// other extensions are unaffected
+	_, ok = decompressors["tgz"].(*sparseTarDecompressor)
+	must.True(t, ok)
+
+	p.DecompressorOverrides = map[string]string{"zip": decompressorSparse}
+	_, err = defaultDecompressorRegistry().decompressors(p)
+	must.EqError(t, err, `decompressor "sparse" is not registered for "zip", must be one of: go-getter`)
+}
+
+func TestDecompressorRegistry_register(t *testing.T) {
+	ci.Parallel(t)
+
+	r := defaultDecompressorRegistry()
+
+	// a custom decompressor with the highest priority is used by default
+	must.NoError(t, r.register("fast-gzip", 20, []string{"gz", "tar.gz"}, fakeDecompressorFactory))
+	decompressors, err := r.decompressors(&parameters{DecompressionLimitFileCount: 7})
+	must.NoError(t, err)
+	must.Eq[getter.Decompressor](t, &fakeDecompressor{ext: "gz", filesLimit: 7}, decompressors["gz"])
+	must.Eq[getter.Decompressor](t, &fakeDecompressor{ext: "tar.gz", filesLimit: 7}, decompressors["tar.gz"])
+	_, ok := decompressors["tgz"].(*sparseTarDecompressor)
+	must.True(t, ok)
+
+	// unless overridden
+	decompressors, err = r.decompressors(&parameters{
+		DecompressorOverrides: map[string]string{"tar.gz": decompressorSparse},
+	})
+	must.NoError(t, err)
+	_, ok = decompressors["tar.gz"].(*sparseTarDecompressor)
+	must.True(t, ok)
+
+	// a custom decompressor with a lower priority is only used if forced
+	must.NoError(t, r.register("slow-zip", -10, []string{"zip"}, fakeDecompressorFactory))
+	decompressors, err = r.decompressors(&parameters{})
+	must.NoError(t, err)
+	_, ok = decompressors["zip"].(*getter.ZipDecompressor)
+	must.True(t, ok)
+	decompressors, err = r.decompressors(&parameters{
+		DecompressorOverrides: map[string]string{"zip": "slow-zip"},
+	})
+	must.NoError(t, err)
+	must.Eq[getter.Decompressor](t, &fakeDecompressor{ext: "zip"}, decompressors["zip"])
+
+	// a custom decompressor may add extensions
+	must.NoError(t, r.register("lz4", 0, []string{"lz4"}, fakeDecompressorFactory))
+	decompressors, err = r.decompressors(&parameters{})
+	must.NoError(t, err)
+	must.Eq[getter.Decompressor](t, &fakeDecompressor{ext: "lz4"}, decompressors["lz4"])
+
+	// ambiguous registrations are rejected, leaving the registry unchanged
+	must.EqError(t, r.register("fast-gzip", 30, []string{"tar.gz"}, fakeDecompressorFactory),
+		`decompressor "fast-gzip" is already registered for "tar.gz"`)
+	must.EqError(t, r.register("other-gzip", 20, []string{"tgz", "gz"}, fakeDecompressorFactory),
+		`decompressor "other-gzip" has the same priority 20 as "fast-gzip" for "gz"`)
+	must.EqError(t, r.register("", 40, []string{"gz"}, fakeDecompressorFactory),
+		"decompressor must have a name")
+	decompressors, err = r.decompressors(&parameters{})
+	must.NoError(t, err)
+	_, ok = decompressors["tgz"].(*sparseTarDecompressor)
+	must.True(t, ok)
+}
+
+func TestDecompressorRegistry_ValidateDecompressorOverrides(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, ValidateDecompressorOverrides(nil))
+	must.NoError(t, ValidateDecompressorOverrides(map[string]string{
+		"tar.gz": "go-getter",
+		"tgz":    "sparse",
+		"zip":    "go-getter",
+	}))
+	must.EqError(t, ValidateDecompressorOverrides(map[string]string{"zip": "pigz"}),
+		`decompressor "pigz" is not registered for "zip", must be one of: go-getter`)
+	must.EqError(t, ValidateDecompressorOverrides(map[string]string{"tar.gz": "pigz"}),
+		`decompressor "pigz" is not registered for "tar.gz", must be one of: sparse, go-getter`)
+	must.EqError(t, ValidateDecompressorOverrides(map[string]string{"rar": "go-getter"}),
+		`no decompressor is registered for "rar"`)
+}
+
+// TestRegisterDecompressor does not run in parallel, since it changes the
+// decompressors registered for every test.
+func TestRegisterDecompressor(t *testing.T) {
+	t.Cleanup(func() {
+		registeredDecompressorsLock.Lock()
+		defer registeredDecompressorsLock.Unlock()
+		registeredDecompressors = nil
+	})
+
+	factory := func(ext string, limits DecompressorLimits) getter.Decompressor {
+		return &fakeDecompressor{ext: ext, filesLimit: limits.FileCount}
+	}
+	must.NoError(t, RegisterDecompressor("fast-gzip", 20, []string{"tar.gz", "lz4"}, factory))
+
+	// the registered decompressor is used unless it is overridden
+	p := &parameters{DecompressionLimitFileCount: 5}
+	decompressors, err := defaultDecompressorRegistry().decompressors(p)
+	must.NoError(t, err)
+	must.Eq[getter.Decompressor](t, &fakeDecompressor{ext: "tar.gz", filesLimit: 5}, decompressors["tar.gz"])
+	must.Eq[getter.Decompressor](t, &fakeDecompressor{ext: "lz4", filesLimit: 5}, decompressors["lz4"])
+	must.NoError(t, ValidateDecompressorOverrides(map[string]string{
+		"tar.gz": decompressorSparse,
+		"lz4":    "fast-gzip",
+	}))
+
+	// registrations conflicting with Nomad's or earlier ones are rejected
+	must.EqError(t, RegisterDecompressor("other-gzip", decompressorPrioritySparse, []string{"tar.gz"}, factory),
+		`decompressor "other-gzip" has the same priority 10 as "sparse" for "tar.gz"`)
+	must.EqError(t, RegisterDecompressor("fast-gzip", 30, []string{"lz4"}, factory),
+		`decompressor "fast-gzip" is already registered for "lz4"`)
+	must.EqError(t, RegisterDecompressor("lz4", 30, []string{"lz4"}, nil),
+		`decompressor "lz4" has no factory`)
+	must.Len(t, 1, registeredDecompressors)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// ErrTooManyFilesInDir is returned for artifacts whose archive holds a
+// directory with more entries than the client allows. Directories with very
+// many entries degrade the performance of the filesystem and of tools
+// listing them.
+var ErrTooManyFilesInDir = errors.New("artifact archive has a directory exceeding the maximum number of files")
+
+// exitTooManyFilesInDir is the exit code of the getter sub-process when the
+// artifact archive has a directory with too many entries, so that
+// ErrTooManyFilesInDir can be returned across the process boundary.
+const exitTooManyFilesInDir = 5
+
+// dirLimitDecompressor is a go-getter Decompressor which refuses to extract
+// archives holding a directory with more than limit entries.
+type dirLimitDecompressor struct {
+	getter.Decompressor
+
+	// ext is the extension of the archives, which decides how their entries
+	// are listed.
+	ext string
+
+	// limit is the maximum number of entries of each directory.
+	limit int
+}
+
+// limitFilesPerDir wraps each of the tarball and zip decompressors of
+// decompressors so that extracting an archive with a directory of more than
+// limit entries returns ErrTooManyFilesInDir. The decompressors are returned
+// unchanged if limit is 0.
+func limitFilesPerDir(decompressors map[string]getter.Decompressor, limit int) map[string]getter.Decompressor {
+	if limit <= 0 {
+		return decompressors
+	}
+	result := make(map[string]getter.Decompressor, len(decompressors))
+	for ext, d := range decompressors {
+		if _, ok := tarCompressions[ext]; !ok && ext != "zip" {
+			result[ext] = d
+			continue
+		}
+		result[ext] = &dirLimitDecompressor{
+			Decompressor: d,
+			ext:          ext,
+			limit:        limit,
+		}
+	}
+	return result
+}
+
+// Decompress extracts the archive at src into dst, unless one of the
+// directories of the archive has more entries than the limit. The entries are
+// counted before anything is extracted, so that a rejected archive leaves
+// nothing behind.
+func (d *dirLimitDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	if dir {
+		counter := newDirCounter(d.limit)
+		if err := eachArchiveEntry(src, d.ext, counter.add); err != nil {
+			return err
+		}
+	}
+	return d.Decompressor.Decompress(dst, src, dir, umask)
+}
+
+// dirCounter counts the distinct entries of each directory of an archive,
+// including the parent directories implied by the paths of entries which are
+// not stored in the archive themselves.
+type dirCounter struct {
+	limit  int
+	seen   map[string]struct{}
+	counts map[string]int
+}
+
+func newDirCounter(limit int) *dirCounter {
+	return &dirCounter{
+		limit:  limit,
+		seen:   make(map[string]struct{}),
+		counts: make(map[string]int),
+	}
+}
+
+// add counts entry in its directory, and the directories along its path in
+// their parents, returning ErrTooManyFilesInDir once any of them exceeds the
+// limit.
+func (c *dirCounter) add(entry archiveEntry) error {
+	name := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(entry.name)), "/")
+	for name != "" {
+		if _, ok := c.seen[name]; ok {
+			// the parents of a path already seen have been counted too
+			return nil
+		}
+		c.seen[name] = struct{}{}
+
+		parent := path.Dir(name)
+		c.counts[parent]++
+		if c.counts[parent] > c.limit {
+			return fmt.Errorf("%w: %q has more than %d entries", ErrTooManyFilesInDir, parent, c.limit)
+		}
+
+		if parent == "." {
+			return nil
+		}
+		name = parent
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// crowdedTarball returns a gzip compressed tarball holding the given files,
+// without entries for their parent directories.
+func crowdedTarball(t *testing.T, names []string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		must.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(name))}))
+		_, err := tw.Write([]byte(name))
+		must.NoError(t, err)
+	}
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// crowdedZip returns a zip archive holding the given files.
+func crowdedZip(t *testing.T, names []string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		must.NoError(t, err)
+		_, err = w.Write([]byte(name))
+		must.NoError(t, err)
+	}
+	must.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDirLimit_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	names := []string{"app/bin/one", "app/bin/two", "app/bin/three", "app/README"}
+
+	cases := []struct {
+		name    string
+		ext     string
+		archive []byte
+	}{
+		{
+			name:    "tarball",
+			ext:     "tar.gz",
+			archive: crowdedTarball(t, names),
+		},
+		{
+			name:    "zip",
+			ext:     "zip",
+			archive: crowdedZip(t, names),
+		},
+	}
+
+	for _, tc := range cases {
+		decompress := func(t *testing.T, limit int) (string, error) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "app."+tc.ext)
+			must.NoError(t, os.WriteFile(src, tc.archive, 0o644))
+			dst := filepath.Join(dir, "local")
+
+			d := limitFilesPerDir(getter.LimitedDecompressors(0, 0), limit)[tc.ext]
+			return dst, d.Decompress(dst, src, true, 0)
+		}
+
+		t.Run(tc.name+" exceeded", func(t *testing.T) {
+			dst, err := decompress(t, 2)
+			must.ErrorIs(t, err, ErrTooManyFilesInDir)
+			must.ErrorContains(t, err, `"app/bin" has more than 2 entries`)
+
+			// nothing is extracted
+			_, err = os.Stat(dst)
+			must.ErrorIs(t, err, os.ErrNotExist)
+		})
+
+		t.Run(tc.name+" within limit", func(t *testing.T) {
+			dst, err := decompress(t, 3)
+			must.NoError(t, err)
+			for _, name := range names {
+				content, err := os.ReadFile(filepath.Join(dst, name))
+				must.NoError(t, err)
+				must.Eq(t, name, string(content))
+			}
+		})
+	}
+}
+
+func TestDirLimit_limitFilesPerDir(t *testing.T) {
+	ci.Parallel(t)
+
+	decompressors := limitFilesPerDir(getter.LimitedDecompressors(0, 0), 10)
+	for _, ext := range []string{"tar", "tar.gz", "tgz", "tar.zst", "zip"} {
+		d, ok := decompressors[ext].(*dirLimitDecompressor)
+		must.True(t, ok, must.Sprint(ext))
+		must.Eq(t, ext, d.ext)
+		must.Eq(t, 10, d.limit)
+	}
+
+	// single files are not directories
+	_, ok := decompressors["gz"].(*getter.GzipDecompressor)
+	must.True(t, ok)
+
+	// a limit of 0 is unlimited
+	decompressors = limitFilesPerDir(getter.LimitedDecompressors(0, 0), 0)
+	_, ok = decompressors["zip"].(*getter.ZipDecompressor)
+	must.True(t, ok)
+}
+
+func TestDirLimit_dirCounter(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		entries []archiveEntry
+		expErr  string
+	}{
+		{
+			name: "within limit",
+			entries: []archiveEntry{
+				{name: "a/", isDir: true},
+				{name: "a/1"},
+				{name: "a/2"},
+				{name: "b/1"},
+			},
+		},
+		{
+			name: "duplicates",
+			entries: []archiveEntry{
+				{name: "a/1"},
+				{name: "a/1"},
+				{name: "./a/2"},
+				{name: "a//2"},
+			},
+		},
+		{
+			name: "implied directories",
+			entries: []archiveEntry{
+				{name: "a/1"},
+				{name: "b/1"},
+				{name: "c/1"},
+			},
+			expErr: `"." has more than 2 entries`,
+		},
+		{
+			name: "nested",
+			entries: []archiveEntry{
+				{name: "a/b/1"},
+				{name: "a/b/2"},
+				{name: "a/b/3"},
+			},
+			expErr: `"a/b" has more than 2 entries`,
+		},
+		{
+			name: "absolute",
+			entries: []archiveEntry{
+				{name: "/a/1"},
+				{name: "/a/2"},
+				{name: "../a/3"},
+			},
+			expErr: `"a" has more than 2 entries`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := newDirCounter(2)
+			var err error
+			for _, entry := range tc.entries {
+				if err = counter.add(entry); err != nil {
+					break
+				}
+			}
+			if tc.expErr == "" {
+				must.NoError(t, err)
+				return
+			}
+			must.ErrorIs(t, err, ErrTooManyFilesInDir)
+			must.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
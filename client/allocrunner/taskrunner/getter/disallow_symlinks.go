@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// ErrSymlinkDisallowed is returned for artifacts holding a symlink when the
+// client disallows symlinks and is configured to reject such artifacts. Unlike
+// ErrSandboxEscape, it is returned for symlinks which resolve within the
+// sandbox too.
+var ErrSymlinkDisallowed = errors.New("artifact includes symlink but symlinks are disallowed")
+
+// exitSymlinkDisallowed is the exit code of the getter sub-process when the
+// artifact holds a symlink the client disallows, so that ErrSymlinkDisallowed
+// can be returned across the process boundary.
+const exitSymlinkDisallowed = 13
+
+const (
+	// symlinksSkip leaves the symlinks of artifacts out of the task
+	// directory.
+	symlinksSkip = "skip"
+
+	// symlinksFail rejects artifacts holding a symlink.
+	symlinksFail = "fail"
+)
+
+// symlinkDecompressor is a go-getter Decompressor which skips or refuses the
+// symlink entries of archives.
+type symlinkDecompressor struct {
+	getter.Decompressor
+
+	// ext is the extension of the archives, which decides how their entries
+	// are listed.
+	ext string
+
+	// action is either symlinksSkip or symlinksFail.
+	action string
+}
+
+// disallowSymlinks wraps each of the tarball and zip decompressors of
+// decompressors so that the symlink entries of archives are skipped or
+// rejected with ErrSymlinkDisallowed, depending on action. The decompressors
+// are returned unchanged if action is neither symlinksSkip nor symlinksFail.
+func disallowSymlinks(decompressors map[string]getter.Decompressor, action string) map[string]getter.Decompressor {
+	if action != symlinksSkip && action != symlinksFail {
+		return decompressors
+	}
+	result := make(map[string]getter.Decompressor, len(decompressors))
+	for ext, d := range decompressors {
+		if _, ok := tarCompressions[ext]; !ok && ext != "zip" {
+			result[ext] = d
+			continue
+		}
+		result[ext] = &symlinkDecompressor{
+			Decompressor: d,
+			ext:          ext,
+			action:       action,
+		}
+	}
+	return result
+}
+
+// Decompress extracts the archive at src into dst without its symlink entries.
+// The entries are listed before anything is extracted, so that a rejected
+// archive leaves nothing behind. The decompressors write skipped symlinks as
+// regular files, which are removed once the archive is extracted.
+func (d *symlinkDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	// whether the last entry of each path is a symlink, as it replaces any
+	// earlier entry of the same path
+	symlinks := make(map[string]bool)
+	err := eachArchiveEntry(src, d.ext, func(entry archiveEntry) error {
+		name := path.Clean(filepath.ToSlash(entry.name))
+		if entry.isSymlink && d.action == symlinksFail {
+			return fmt.Errorf("%w: %q", ErrSymlinkDisallowed, name)
+		}
+		symlinks[name] = entry.isSymlink
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := d.Decompressor.Decompress(dst, src, dir, umask); err != nil {
+		return err
+	}
+
+	for name, isSymlink := range symlinks {
+		if !isSymlink {
+			continue
+		}
+		target := dst
+		if dir {
+			target = filepath.Join(dst, filepath.FromSlash(name))
+		}
+		if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to skip symlink %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// checkSymlinks removes each symlink under root which changed since the
+// download started, or rejects the first one with ErrSymlinkDisallowed,
+// depending on action. Symlinks in archives are handled as they are extracted,
+// but other getters create them too, such as by cloning a git repository.
+// Symlinks the download did not write, such as those the task created in the
+// same directory, are left as is.
+func checkSymlinks(root, action string, since time.Time) error {
+	if action != symlinksSkip && action != symlinksFail {
+		return nil
+	}
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		if changed, err := changedSince(path, since); err != nil || !changed {
+			return err
+		}
+		if action == symlinksFail {
+			rel, _ := filepath.Rel(root, path)
+			return fmt.Errorf("%w: %q", ErrSymlinkDisallowed, rel)
+		}
+		return os.Remove(path)
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// symlinkTarball returns a gzip compressed tarball holding app/run and the
+// symlink app/link to it, which resolves within the sandbox.
+func symlinkTarball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/run", Mode: 0o755, Size: 3}))
+	_, err := tw.Write([]byte("run"))
+	must.NoError(t, err)
+	must.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "app/link",
+		Typeflag: tar.TypeSymlink,
+		Linkname: "run",
+		Mode:     0o777,
+	}))
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// symlinkZip returns a zip archive holding app/run and the symlink app/link
+// to it, which resolves within the sandbox.
+func symlinkZip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("app/run")
+	must.NoError(t, err)
+	_, err = w.Write([]byte("run"))
+	must.NoError(t, err)
+
+	hdr := &zip.FileHeader{Name: "app/link"}
+	hdr.SetMode(fs.ModeSymlink | 0o777)
+	w, err = zw.CreateHeader(hdr)
+	must.NoError(t, err)
+	_, err = w.Write([]byte("run"))
+	must.NoError(t, err)
+	must.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDisallowSymlinks_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		ext     string
+		archive []byte
+	}{
+		{
+			name:    "tarball",
+			ext:     "tar.gz",
+			archive: symlinkTarball(t),
+		},
+		{
+			name:    "zip",
+			ext:     "zip",
+			archive: symlinkZip(t),
+		},
+	}
+
+	for _, tc := range cases {
+		decompress := func(t *testing.T, action string) (string, error) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "app."+tc.ext)
+			must.NoError(t, os.WriteFile(src, tc.archive, 0o644))
+			dst := filepath.Join(dir, "local")
+
+			d := disallowSymlinks(getter.LimitedDecompressors(0, 0), action)[tc.ext]
+			return dst, d.Decompress(dst, src, true, 0)
+		}
+
+		t.Run(tc.name+" skip", func(t *testing.T) {
+			dst, err := decompress(t, symlinksSkip)
+			must.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(dst, "app", "run"))
+			must.NoError(t, err)
+			must.Eq(t, "run", string(content))
+
+			_, err = os.Lstat(filepath.Join(dst, "app", "link"))
+			must.ErrorIs(t, err, os.ErrNotExist)
+		})
+
+		t.Run(tc.name+" fail", func(t *testing.T) {
+			dst, err := decompress(t, symlinksFail)
+			must.ErrorIs(t, err, ErrSymlinkDisallowed)
+			must.ErrorContains(t, err, `"app/link"`)
+
+			// nothing is extracted
+			_, err = os.Stat(dst)
+			must.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestDisallowSymlinks_disallowSymlinks(t *testing.T) {
+	ci.Parallel(t)
+
+	decompressors := disallowSymlinks(getter.LimitedDecompressors(0, 0), symlinksFail)
+	for _, ext := range []string{"tar", "tar.gz", "tgz", "tar.zst", "zip"} {
+		d, ok := decompressors[ext].(*symlinkDecompressor)
+		must.True(t, ok, must.Sprintf("expected %s to be wrapped", ext))
+		must.Eq(t, ext, d.ext)
+	}
+
+	// single files cannot hold symlinks
+	_, ok := decompressors["gz"].(*symlinkDecompressor)
+	must.False(t, ok)
+
+	// symlinks are allowed unless skipped or refused
+	decompressors = disallowSymlinks(getter.LimitedDecompressors(0, 0), "")
+	_, ok = decompressors["zip"].(*symlinkDecompressor)
+	must.False(t, ok)
+}
+
+func TestDisallowSymlinks_checkSymlinks(t *testing.T) {
+	ci.Parallel(t)
+
+	since := time.Now().Add(-changeTimeSlack)
+
+	setup := func(t *testing.T) string {
+		root := t.TempDir()
+		must.NoError(t, os.MkdirAll(filepath.Join(root, "bin"), 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(root, "bin", "run"), []byte("run"), 0o755))
+		must.NoError(t, os.Symlink("run", filepath.Join(root, "bin", "link")))
+		return root
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		root := setup(t)
+		must.NoError(t, checkSymlinks(root, "", since))
+
+		_, err := os.Lstat(filepath.Join(root, "bin", "link"))
+		must.NoError(t, err)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		root := setup(t)
+		must.NoError(t, checkSymlinks(root, symlinksSkip, since))
+
+		_, err := os.Lstat(filepath.Join(root, "bin", "link"))
+		must.ErrorIs(t, err, os.ErrNotExist)
+		_, err = os.Stat(filepath.Join(root, "bin", "run"))
+		must.NoError(t, err)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		root := setup(t)
+		err := checkSymlinks(root, symlinksFail, since)
+		must.ErrorIs(t, err, ErrSymlinkDisallowed)
+		must.ErrorContains(t, err, filepath.Join("bin", "link"))
+	})
+
+	t.Run("written before download", func(t *testing.T) {
+		// symlinks already in the destination, such as those the task
+		// created before it restarted, are not the artifact's to check
+		root := setup(t)
+		must.NoError(t, checkSymlinks(root, symlinksFail, time.Now().Add(time.Hour)))
+		must.NoError(t, checkSymlinks(root, symlinksSkip, time.Now().Add(time.Hour)))
+
+		_, err := os.Lstat(filepath.Join(root, "bin", "link"))
+		must.NoError(t, err)
+	})
+}
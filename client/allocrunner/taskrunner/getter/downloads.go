@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// downloads tracks the artifact downloads in progress, for debugging.
+type downloads struct {
+	lock   sync.Mutex
+	nextID uint64
+	active map[uint64]*cstructs.ArtifactDownload
+}
+
+// start records the download of artifact as in progress, and returns a
+// function which records it as done.
+func (d *downloads) start(artifact *structs.TaskArtifact) func() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.active == nil {
+		d.active = make(map[uint64]*cstructs.ArtifactDownload)
+	}
+	id := d.nextID
+	d.nextID++
+	d.active[id] = &cstructs.ArtifactDownload{
+		Source:      redactSource(artifact.GetterSource),
+		Destination: artifact.RelativeDest,
+		StartedAt:   time.Now(),
+	}
+
+	return func() {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		delete(d.active, id)
+	}
+}
+
+// list returns the downloads in progress, oldest first.
+func (d *downloads) list() []*cstructs.ArtifactDownload {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	now := time.Now()
+	result := make([]*cstructs.ArtifactDownload, 0, len(d.active))
+	for _, download := range d.active {
+		c := *download
+		c.Duration = now.Sub(c.StartedAt)
+		result = append(result, &c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result
+}
+
+// Downloads returns the artifact downloads in progress, oldest first.
+func (s *Sandbox) Downloads() []*cstructs.ArtifactDownload {
+	return s.downloads.list()
+}
+
+// redactSource returns source without the credentials it may hold, and
+// without the content of data URIs, which may be large. Every query value is
+// redacted, as options such as presigned URL signatures or tokens hold
+// credentials under names of their own.
+func redactSource(source string) string {
+	if structs.IsDataURI(source) {
+		header, _, _ := strings.Cut(source, ",")
+		return header + ",..."
+	}
+
+	forced := ""
+	if ms := forcedGetter.FindStringSubmatch(source); ms != nil {
+		forced, source = ms[1]+"::", ms[2]
+	}
+	u, err := url.Parse(source)
+	if err != nil {
+		source, _, _ = strings.Cut(source, "?")
+		return forced + source
+	}
+	query := u.Query()
+	for name, values := range query {
+		for i := range values {
+			values[i] = "redacted"
+		}
+		query[name] = values
+	}
+	u.RawQuery = query.Encode()
+	return forced + getter.RedactURL(u)
+}
@@ -18,6 +18,13 @@ func (e *Error) Error() string {
 	return e.Err.Error()
 }
 
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
 func (e *Error) IsRecoverable() bool {
 	return e.Recoverable
 }
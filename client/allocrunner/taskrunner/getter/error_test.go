@@ -5,6 +5,7 @@ package getter
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -37,6 +38,12 @@ func TestError_IsRecoverable(t *testing.T) {
 	must.False(t, (&Error{Recoverable: false}).IsRecoverable())
 }
 
+func TestError_Unwrap(t *testing.T) {
+	err := &Error{Err: fmt.Errorf("%w: oops", ErrTruncatedArchive)}
+	must.ErrorIs(t, err, ErrTruncatedArchive)
+	must.Nil(t, (*Error)(nil).Unwrap())
+}
+
 func TestError_Equal(t *testing.T) {
 	cases := []struct {
 		name string
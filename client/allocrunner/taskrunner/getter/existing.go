@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ErrDestinationExists is returned when the destination of an artifact whose
+// existing mode is "fail" already holds content.
+var ErrDestinationExists = errors.New("artifact destination already exists")
+
+// checkExisting returns whether the download of artifact should be skipped
+// because its destination already holds content and its existing mode is
+// "skip". An error is returned if the destination already holds content and
+// the existing mode is "fail". The destination is overwritten otherwise.
+func checkExisting(artifact *structs.TaskArtifact, source, destination string, mode getter.ClientMode) (bool, error) {
+	switch artifact.GetterExisting {
+	case structs.GetterExistingSkip, structs.GetterExistingFail:
+	default:
+		return false, nil
+	}
+
+	path, exists, err := destinationExists(source, destination, mode)
+	if err != nil {
+		return false, &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("failed to inspect artifact destination: %w", err),
+			Recoverable: true,
+		}
+	}
+	if !exists {
+		return false, nil
+	}
+
+	if artifact.GetterExisting == structs.GetterExistingSkip {
+		return true, nil
+	}
+
+	// the content is still there when the download is retried
+	return false, &Error{
+		URL:         artifact.GetterSource,
+		Err:         fmt.Errorf("%w: %s", ErrDestinationExists, path),
+		Recoverable: false,
+	}
+}
+
+// destinationExists returns the path the artifact fetched from source with
+// mode is downloaded to within destination, and whether it already holds
+// content. Artifacts downloaded as a single file collide with an existing
+// file of the same name, while artifacts downloaded as a directory, or
+// extracted, collide with a non-empty destination.
+func destinationExists(source, destination string, mode getter.ClientMode) (string, bool, error) {
+	path := destination
+	if mode != getter.ClientModeFile {
+		if file, ok := downloadedFile(source, destination, mode); ok {
+			path = file
+		}
+	}
+
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return path, false, nil
+	} else if err != nil {
+		return path, false, err
+	}
+	if !info.IsDir() {
+		return path, true, nil
+	}
+
+	dir, err := os.Open(path)
+	if err != nil {
+		return path, false, err
+	}
+	defer dir.Close()
+
+	_, err = dir.Readdirnames(1)
+	if errors.Is(err, io.EOF) {
+		return path, false, nil
+	} else if err != nil {
+		return path, false, err
+	}
+	return path, true, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestExisting_checkExisting(t *testing.T) {
+	ci.Parallel(t)
+
+	// setup functions lay out the destination within the directory and
+	// return the destination
+	existingFile := func(t *testing.T, dir string) string {
+		dst := filepath.Join(dir, "app.bin")
+		must.NoError(t, os.WriteFile(dst, []byte("old"), 0o644))
+		return dst
+	}
+	existingFileInDir := func(t *testing.T, dir string) string {
+		must.NoError(t, os.WriteFile(filepath.Join(dir, "app.bin"), []byte("old"), 0o644))
+		return dir
+	}
+	otherFileInDir := func(t *testing.T, dir string) string {
+		must.NoError(t, os.WriteFile(filepath.Join(dir, "other.bin"), []byte("old"), 0o644))
+		return dir
+	}
+	emptyDir := func(t *testing.T, dir string) string {
+		return dir
+	}
+	missing := func(t *testing.T, dir string) string {
+		return filepath.Join(dir, "missing")
+	}
+
+	cases := []struct {
+		name     string
+		source   string
+		mode     getter.ClientMode
+		setup    func(*testing.T, string) string
+		existing string
+		expSkip  bool
+		expErr   bool
+	}{
+		// a file destination which exists is replaced
+		{name: "file overwrite", source: "http://example.com/app.bin", mode: getter.ClientModeFile, setup: existingFile, existing: structs.GetterExistingOverwrite},
+		{name: "file default", source: "http://example.com/app.bin", mode: getter.ClientModeFile, setup: existingFile},
+		{name: "file skip", source: "http://example.com/app.bin", mode: getter.ClientModeFile, setup: existingFile, existing: structs.GetterExistingSkip, expSkip: true},
+		{name: "file fail", source: "http://example.com/app.bin", mode: getter.ClientModeFile, setup: existingFile, existing: structs.GetterExistingFail, expErr: true},
+		{name: "file missing skip", source: "http://example.com/app.bin", mode: getter.ClientModeFile, setup: missing, existing: structs.GetterExistingSkip},
+		{name: "file missing fail", source: "http://example.com/app.bin", mode: getter.ClientModeFile, setup: missing, existing: structs.GetterExistingFail},
+
+		// a file downloaded into a directory only collides with a file of
+		// the same name
+		{name: "any file overwrite", source: "http://example.com/app.bin", mode: getter.ClientModeAny, setup: existingFileInDir, existing: structs.GetterExistingOverwrite},
+		{name: "any file skip", source: "http://example.com/app.bin", mode: getter.ClientModeAny, setup: existingFileInDir, existing: structs.GetterExistingSkip, expSkip: true},
+		{name: "any file fail", source: "http://example.com/app.bin", mode: getter.ClientModeAny, setup: existingFileInDir, existing: structs.GetterExistingFail, expErr: true},
+		{name: "any other file skip", source: "http://example.com/app.bin", mode: getter.ClientModeAny, setup: otherFileInDir, existing: structs.GetterExistingSkip},
+		{name: "any other file fail", source: "http://example.com/app.bin", mode: getter.ClientModeAny, setup: otherFileInDir, existing: structs.GetterExistingFail},
+
+		// an archive extracted into a directory which exists is merged in
+		{name: "archive overwrite", source: "http://example.com/app.tar.gz", mode: getter.ClientModeAny, setup: otherFileInDir, existing: structs.GetterExistingOverwrite},
+		{name: "archive skip", source: "http://example.com/app.tar.gz", mode: getter.ClientModeAny, setup: otherFileInDir, existing: structs.GetterExistingSkip, expSkip: true},
+		{name: "archive fail", source: "http://example.com/app.tar.gz", mode: getter.ClientModeAny, setup: otherFileInDir, existing: structs.GetterExistingFail, expErr: true},
+		{name: "archive empty dir skip", source: "http://example.com/app.tar.gz", mode: getter.ClientModeAny, setup: emptyDir, existing: structs.GetterExistingSkip},
+		{name: "archive empty dir fail", source: "http://example.com/app.tar.gz", mode: getter.ClientModeAny, setup: emptyDir, existing: structs.GetterExistingFail},
+		{name: "dir skip", source: "git::https://example.com/repo.git", mode: getter.ClientModeDir, setup: otherFileInDir, existing: structs.GetterExistingSkip, expSkip: true},
+		{name: "dir fail", source: "git::https://example.com/repo.git", mode: getter.ClientModeDir, setup: otherFileInDir, existing: structs.GetterExistingFail, expErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := tc.setup(t, t.TempDir())
+			artifact := &structs.TaskArtifact{
+				GetterSource:   tc.source,
+				GetterExisting: tc.existing,
+			}
+
+			skip, err := checkExisting(artifact, tc.source, dst, tc.mode)
+			if tc.expErr {
+				must.ErrorIs(t, err, ErrDestinationExists)
+				must.False(t, err.(*Error).IsRecoverable())
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expSkip, skip)
+		})
+	}
+}
+
+func TestSandbox_Get_existing(t *testing.T) {
+	ci.Parallel(t)
+
+	_, taskDir := SetupDir(t)
+	env := noopTaskEnv(taskDir)
+
+	dst := filepath.Join(taskDir, "local", "downloads")
+	must.NoError(t, os.MkdirAll(dst, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dst, "app.bin"), []byte("old"), 0o644))
+
+	// the source is unreachable, so the artifact is only gotten if the
+	// download is skipped
+	artifact := &structs.TaskArtifact{
+		GetterSource: "http://127.0.0.1:0/app.bin",
+		RelativeDest: "local/downloads",
+	}
+
+	sbox := TestSandbox(t)
+
+	t.Run("skip", func(t *testing.T) {
+		artifact := artifact.Copy()
+		artifact.GetterExisting = structs.GetterExistingSkip
+		_, err := sbox.Get(env, artifact, "nobody")
+		must.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dst, "app.bin"))
+		must.NoError(t, err)
+		must.Eq(t, "old", string(content))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		artifact := artifact.Copy()
+		artifact.GetterExisting = structs.GetterExistingFail
+		_, err := sbox.Get(env, artifact, "nobody")
+		must.ErrorIs(t, err, ErrDestinationExists)
+		must.ErrorContains(t, err, filepath.Join(dst, "app.bin"))
+	})
+}
@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/interfaces"
+)
+
+// forcedGetter matches sources which force a getter, such as git::https://,
+// as go-getter does.
+var forcedGetter = regexp.MustCompile(`^([A-Za-z0-9]+)::(.+)$`)
+
+// cachePrecedence orders the cache statuses of a fetch, so that the status
+// recorded last by an earlier step does not hide that the artifact had to be
+// downloaded, such as when a fresh download is restored from the cache.
+var cachePrecedence = []interfaces.FetchCacheStatus{
+	interfaces.FetchCacheNone,
+	interfaces.FetchCacheHit,
+	interfaces.FetchCacheMiss,
+	interfaces.FetchCacheStale,
+}
+
+// fetchStats are the statistics of the http requests of a download, which the
+// getter sub-process writes to standard output for the Nomad client to add to
+// the fetch result.
+type fetchStats struct {
+	Bytes     int64  `json:"bytes"`
+	Redirects int    `json:"redirects"`
+	Scheme    string `json:"scheme"`
+
+	// SHA256 is the hex encoded SHA-256 digest of the content received, if
+	// it was received in a single response, or otherwise of the artifact
+	// written to the destination, such as for git sources or manifests.
+	SHA256 string `json:"sha256,omitempty"`
+
+	// contents is the number of responses whose content was received
+	contents int
+
+	// the requests of manifest artifacts are sent concurrently
+	mu sync.Mutex
+}
+
+// recordContent records the SHA-256 digest of the content of a response,
+// which was received in full.
+func (s *fetchStats) recordContent(sum []byte) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contents++
+	if s.contents == 1 {
+		s.SHA256 = hex.EncodeToString(sum)
+	} else {
+		s.SHA256 = ""
+	}
+}
+
+// recordWritten records the digest of the artifact written to dst, unless the
+// digest of its content was recorded as it was received. That of a file is the
+// digest of its content, and that of a directory is the tree digest of the
+// entries which changed since the download started, which leaves out the
+// files already in the directory.
+func (s *fetchStats) recordWritten(dst string, since time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.SHA256 != "" {
+		return nil
+	}
+
+	info, err := os.Lstat(dst)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		f, err := os.Open(dst)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		s.SHA256 = hex.EncodeToString(h.Sum(nil))
+		return nil
+	}
+
+	sum, err := filteredTreeDigest(dst, func(path string) (bool, error) {
+		return changedSince(path, since)
+	})
+	if err != nil {
+		return err
+	}
+	s.SHA256 = sum
+	return nil
+}
+
+func (s *fetchStats) write(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = json.NewEncoder(w).Encode(s)
+}
+
+// readFetchStats returns the statistics written by the getter sub-process to
+// r, or nil if it wrote none, such as when it failed to start.
+func readFetchStats(r io.Reader) *fetchStats {
+	var s fetchStats
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil
+	}
+	return &s
+}
+
+// statsTransport is an http.RoundTripper which counts the bytes and redirects
+// of the responses it receives, and records the scheme of the latest request.
+// The content of successful GET requests is hashed as it is read, so that the
+// digest of the download is known without reading it again.
+type statsTransport struct {
+	http.RoundTripper
+	stats *fetchStats
+}
+
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.stats.mu.Lock()
+	defer t.stats.mu.Unlock()
+	t.stats.Scheme = req.URL.Scheme
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		if resp.Header.Get("Location") != "" {
+			t.stats.Redirects++
+		}
+	}
+	body := &statsBody{ReadCloser: resp.Body, stats: t.stats}
+	if req.Method == http.MethodGet && resp.StatusCode == http.StatusOK {
+		body.hash = sha256.New()
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// statsBody is a response body counting the bytes read from it, and hashing
+// them if it holds content.
+type statsBody struct {
+	io.ReadCloser
+	stats *fetchStats
+	hash  hash.Hash
+}
+
+func (b *statsBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.stats.mu.Lock()
+	b.stats.Bytes += int64(n)
+	b.stats.mu.Unlock()
+
+	// the digest is only recorded once the content is received in full
+	if b.hash != nil {
+		_, _ = b.hash.Write(p[:n])
+		if err == io.EOF {
+			b.stats.recordContent(b.hash.Sum(nil))
+			b.hash = nil
+		}
+	}
+	return n, err
+}
+
+// sourceScheme returns the scheme artifacts are fetched from source with,
+// which is the getter it forces or detects, if any, such as git for GitHub
+// repositories, or the scheme of its URL.
+func sourceScheme(source string) string {
+	detected, err := getter.Detect(source, "", getter.Detectors)
+	if err != nil {
+		return ""
+	}
+	if ms := forcedGetter.FindStringSubmatch(detected); ms != nil {
+		return ms[1]
+	}
+	u, err := url.Parse(detected)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme
+}
+
+// recordCache records how the cache served the artifact in the fetch result,
+// unless an earlier step recorded a status which takes precedence.
+func (p *parameters) recordCache(status interfaces.FetchCacheStatus) {
+	if p.result == nil {
+		return
+	}
+	if slices.Index(cachePrecedence, status) > slices.Index(cachePrecedence, p.result.Cache) {
+		p.result.Cache = status
+	}
+}
+
+// recordRetry records in the fetch result that the artifact is fetched again
+// after an attempt failed.
+func (p *parameters) recordRetry() {
+	if p.result != nil {
+		p.result.Retries++
+	}
+}
+
+// recordStats adds the statistics of a getter sub-process to the fetch
+// result.
+func (p *parameters) recordStats(stats *fetchStats) {
+	if p.result == nil || stats == nil {
+		return
+	}
+	p.result.Bytes += stats.Bytes
+	p.result.Redirects += stats.Redirects
+	if stats.Scheme != "" {
+		p.result.Scheme = stats.Scheme
+	}
+
+	// the digest describes the content placed by the latest sub-process
+	p.result.SHA256 = stats.SHA256
+}
+
+// recordSHA256 records the digest of the content received in the fetch
+// result, such as that of a cached artifact when it is restored.
+func (p *parameters) recordSHA256(sum string) {
+	if p.result != nil {
+		p.result.SHA256 = sum
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSandbox_Get_fetchResult(t *testing.T) {
+	testutil.RequireRoot(t)
+
+	body := "hello from the cache"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old/file.txt" {
+			w.Header().Set("Location", "/file.txt")
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+	artifact := &structs.TaskArtifact{
+		GetterSource:  srv.URL + "/old/file.txt",
+		GetterOptions: map[string]string{"checksum": sha256Checksum(body)},
+		RelativeDest:  "local/downloads",
+	}
+
+	_, taskDir := SetupDir(t)
+	result, err := sbox.Get(noopTaskEnv(taskDir), artifact, "nobody")
+	must.NoError(t, err)
+	must.Positive(t, result.Duration)
+	must.Eq(t, int64(len(body)), result.Bytes)
+	must.Eq(t, 1, result.Redirects)
+	must.Zero(t, result.Retries)
+	must.Eq(t, interfaces.FetchCacheMiss, result.Cache)
+	must.Eq(t, "http", result.Scheme)
+	must.Eq(t, sha256Checksum(body), "sha256:"+result.SHA256)
+
+	// the artifact is restored from the cache into another task once it is
+	// prefetched
+	_, taskDir = SetupDir(t)
+	must.NoError(t, sbox.Prefetch(noopTaskEnv(taskDir), artifact, ""))
+	result, err = sbox.Get(noopTaskEnv(taskDir), artifact, "nobody")
+	must.NoError(t, err)
+	must.Zero(t, result.Bytes)
+	must.Zero(t, result.Redirects)
+	must.Eq(t, interfaces.FetchCacheHit, result.Cache)
+
+	// the digest of the content received when it was cached is reported
+	must.Eq(t, sha256Checksum(body), "sha256:"+result.SHA256)
+
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "downloads", "file.txt"))
+	must.NoError(t, err)
+	must.Eq(t, body, string(b))
+}
+
+func TestFetchResult_recordCache(t *testing.T) {
+	ci.Parallel(t)
+
+	result := new(interfaces.FetchResult)
+	p := &parameters{result: result}
+
+	p.recordCache(interfaces.FetchCacheMiss)
+	must.Eq(t, interfaces.FetchCacheMiss, result.Cache)
+
+	// restoring a fresh download does not make it a hit
+	p.recordCache(interfaces.FetchCacheHit)
+	must.Eq(t, interfaces.FetchCacheMiss, result.Cache)
+
+	p.recordCache(interfaces.FetchCacheStale)
+	must.Eq(t, interfaces.FetchCacheStale, result.Cache)
+
+	// parameters without a result, such as of prefetches, are left alone
+	(&parameters{}).recordCache(interfaces.FetchCacheHit)
+	(&parameters{}).recordRetry()
+	(&parameters{}).recordStats(&fetchStats{Bytes: 1})
+}
+
+func TestFetchResult_stats(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	stats := &fetchStats{Bytes: 42, Redirects: 2, Scheme: "https", SHA256: "abc123"}
+	stats.write(&buf)
+
+	result := &interfaces.FetchResult{Bytes: 8, Scheme: "http"}
+	p := &parameters{result: result}
+	p.recordStats(readFetchStats(&buf))
+	must.Eq(t, int64(50), result.Bytes)
+	must.Eq(t, 2, result.Redirects)
+	must.Eq(t, "https", result.Scheme)
+	must.Eq(t, "abc123", result.SHA256)
+
+	// a sub-process which failed to start writes no statistics
+	must.Nil(t, readFetchStats(&bytes.Buffer{}))
+}
+
+func TestFetchResult_sourceScheme(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := map[string]string{
+		"https://example.com/file.tar.gz":           "https",
+		"git::https://example.com/repo.git":         "git",
+		"github.com/hashicorp/nomad":                "git",
+		"git@github.com:hashicorp/nomad.git":        "git",
+		"s3::https://s3.amazonaws.com/bucket/a.zip": "s3",
+		"gcs://bucket/a.zip":                        "gcs",
+	}
+	for source, scheme := range cases {
+		must.Eq(t, scheme, sourceScheme(source), must.Sprint(source))
+	}
+}
+
+func TestFetchResult_statsTransport(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/file.txt" {
+			w.Header().Set("Location", "/file.txt")
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+		_, _ = io.WriteString(w, "0123456789")
+	}))
+	t.Cleanup(srv.Close)
+
+	stats := new(fetchStats)
+	client := &http.Client{Transport: &statsTransport{RoundTripper: http.DefaultTransport, stats: stats}}
+	resp, err := client.Get(srv.URL + "/a")
+	must.NoError(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	must.NoError(t, err)
+	must.NoError(t, resp.Body.Close())
+
+	must.Eq(t, int64(10), stats.Bytes)
+	must.Eq(t, 1, stats.Redirects)
+	must.Eq(t, "http", stats.Scheme)
+
+	// only the content is hashed, not the body of the redirect
+	must.Eq(t, "sha256:"+stats.SHA256, sha256Checksum("0123456789"))
+
+	// no single digest describes the content of more than one response
+	resp, err = client.Get(srv.URL + "/file.txt")
+	must.NoError(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	must.NoError(t, err)
+	must.NoError(t, resp.Body.Close())
+	must.Eq(t, "", stats.SHA256)
+}
+
+func TestFetchStats_recordWritten(t *testing.T) {
+	ci.Parallel(t)
+
+	// a file is hashed when its content was not received in one response
+	file := filepath.Join(t.TempDir(), "file.txt")
+	must.NoError(t, os.WriteFile(file, []byte("hello"), 0o644))
+	stats := new(fetchStats)
+	must.NoError(t, stats.recordWritten(file, time.Now()))
+	must.Eq(t, helloSHA256, "sha256:"+stats.SHA256)
+
+	// the digest of the content received is kept
+	stats = &fetchStats{SHA256: "abc123"}
+	must.NoError(t, stats.recordWritten(file, time.Now()))
+	must.Eq(t, "abc123", stats.SHA256)
+
+	// only the files of a directory written by the download are hashed
+	dir := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "task.txt"), []byte("task"), 0o644))
+	time.Sleep(100 * time.Millisecond)
+
+	// the clock of the filesystem is coarse
+	since := time.Now().Add(-50 * time.Millisecond)
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "artifact.txt"), []byte("artifact"), 0o644))
+
+	expected := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(expected, "artifact.txt"), []byte("artifact"), 0o644))
+	sum, err := treeDigest(expected)
+	must.NoError(t, err)
+
+	stats = new(fetchStats)
+	must.NoError(t, stats.recordWritten(dir, since))
+	must.Eq(t, sum, stats.SHA256)
+}
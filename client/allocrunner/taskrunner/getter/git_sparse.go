@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/go-version"
+)
+
+// gitSparseOption is the artifact option listing the directories of a git
+// repository to check out, separated by commas.
+const gitSparseOption = "sparse"
+
+// gitSparseMinVersion is the oldest version of git which supports cloning
+// with a cone mode sparse checkout. Older versions check out the whole
+// repository instead.
+var gitSparseMinVersion = version.Must(version.NewVersion("2.25"))
+
+// sparseGitGetter is a git getter which only checks out the directories of
+// the repository listed by the "sparse" option, if any. Only the objects of
+// those directories are downloaded from servers which support partial clones.
+type sparseGitGetter struct {
+	*getter.GitGetter
+}
+
+func (g *sparseGitGetter) Get(dst string, u *url.URL) error {
+	q := u.Query()
+	if !q.Has(gitSparseOption) {
+		return g.GitGetter.Get(dst, u)
+	}
+
+	paths, err := parseSparsePaths(q.Get(gitSparseOption))
+	if err != nil {
+		return err
+	}
+	q.Del(gitSparseOption)
+
+	ctx := g.Context()
+	if g.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.Timeout)
+		defer cancel()
+	}
+
+	// check out the whole repository with versions of git which cannot check
+	// out only part of it
+	if !gitSupportsSparse(ctx) {
+		return g.GitGetter.Get(dst, withQuery(u, q))
+	}
+
+	return g.sparseClone(ctx, dst, u, q, paths)
+}
+
+// GetFile downloads a single file of the repository, which is never a sparse
+// checkout.
+func (g *sparseGitGetter) GetFile(dst string, u *url.URL) error {
+	q := u.Query()
+	q.Del(gitSparseOption)
+	return g.GitGetter.GetFile(dst, withQuery(u, q))
+}
+
+// sparseClone clones the repository at u into dst, checking out only paths.
+// The ref, depth, and sshkey options in q are handled like go-getter does.
+func (g *sparseGitGetter) sparseClone(ctx context.Context, dst string, u *url.URL, q url.Values, paths []string) error {
+	ref := q.Get("ref")
+	sshKey := q.Get("sshkey")
+	depth, _ := strconv.Atoi(q.Get("depth"))
+	q.Del("ref")
+	q.Del("sshkey")
+	q.Del("depth")
+	u = withQuery(u, q)
+
+	var sshKeyFile string
+	if sshKey != "" {
+		var err error
+		sshKeyFile, err = writeSSHKey(sshKey)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(sshKeyFile) }()
+	}
+
+	// clone without the content of any directory, and without downloading
+	// the objects of files outside of the checkout from servers which support
+	// partial clones
+	args := []string{"clone", "--sparse", "--filter=blob:none"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+		if ref != "" {
+			args = append(args, "--branch", ref)
+		}
+	}
+	args = append(args, "--", u.String(), dst)
+	if err := runGit(ctx, "", sshKeyFile, args...); err != nil {
+		return err
+	}
+
+	if err := runGit(ctx, dst, sshKeyFile, "sparse-checkout", "init", "--cone"); err != nil {
+		return err
+	}
+	if err := runGit(ctx, dst, sshKeyFile, append([]string{"sparse-checkout", "set"}, paths...)...); err != nil {
+		return err
+	}
+
+	// shallow clones already checked out the ref
+	if ref != "" && depth < 1 {
+		if err := runGit(ctx, dst, sshKeyFile, "checkout", ref); err != nil {
+			return err
+		}
+	}
+
+	// only download the submodules within the checkout
+	args = []string{"submodule", "update", "--init", "--recursive"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	args = append(args, "--")
+	args = append(args, paths...)
+	return runGit(ctx, dst, sshKeyFile, args...)
+}
+
+// parseSparsePaths parses the comma separated directories of the "sparse"
+// option, which must be relative to and within the repository.
+func parseSparsePaths(option string) ([]string, error) {
+	var paths []string
+	for _, p := range strings.Split(option, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		clean := path.Clean(strings.ReplaceAll(p, `\`, "/"))
+		switch {
+		case path.IsAbs(clean), strings.HasPrefix(clean, "-"):
+			return nil, fmt.Errorf("sparse path %q must be relative to the repository", p)
+		case clean == ".", clean == "..", strings.HasPrefix(clean, "../"):
+			return nil, fmt.Errorf("sparse path %q must be a directory within the repository", p)
+		}
+		paths = append(paths, clean)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("sparse option must list at least one path")
+	}
+	return paths, nil
+}
+
+// gitSupportsSparse returns whether the installed version of git supports
+// cone mode sparse checkouts.
+func gitSupportsSparse(ctx context.Context) bool {
+	out, err := exec.CommandContext(ctx, "git", "version").Output()
+	if err != nil {
+		return false
+	}
+
+	// for example "git version 2.39.5" or "git version 2.20.1.windows.1"
+	fields := strings.Fields(string(out))
+	if len(fields) < 3 {
+		return false
+	}
+	v, _, _ := strings.Cut(fields[2], ".windows.")
+	have, err := version.NewVersion(v)
+	if err != nil {
+		return false
+	}
+	return have.GreaterThanOrEqual(gitSparseMinVersion)
+}
+
+// writeSSHKey writes the base64 encoded sshkey option to a temporary file
+// readable only by its owner, and returns its path.
+func writeSSHKey(sshKey string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(sshKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode sshkey: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "nomad-git-ssh")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := f.Chmod(0o600); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	if _, err := f.Write(raw); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// runGit runs git with args in dir, using the ssh key file, if any, for ssh
+// remotes. The output of git is included in the error if it fails.
+func runGit(ctx context.Context, dir, sshKeyFile string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	if sshKeyFile != "" {
+		sshCommand := "ssh"
+		if existing := os.Getenv("GIT_SSH_COMMAND"); existing != "" {
+			sshCommand = existing
+		}
+		cmd.Env = append(os.Environ(), "GIT_SSH_COMMAND="+sshCommand+" -i "+sshKeyFile)
+	}
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(out.String()))
+	}
+	return nil
+}
+
+// withQuery returns a copy of u with the query q.
+func withQuery(u *url.URL, q url.Values) *url.URL {
+	c := *u
+	c.RawQuery = q.Encode()
+	return &c
+}
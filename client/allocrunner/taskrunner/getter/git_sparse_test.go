@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestGitSparse_parseSparsePaths(t *testing.T) {
+	ci.Parallel(t)
+
+	paths, err := parseSparsePaths(" services/api/ ,libs/common,, docs ")
+	must.NoError(t, err)
+	must.Eq(t, []string{"services/api", "libs/common", "docs"}, paths)
+
+	for _, option := range []string{"", " , ", "/etc", "-C", ".", "..", "../other", "a/../../b"} {
+		_, err := parseSparsePaths(option)
+		must.Error(t, err, must.Sprint(option))
+	}
+}
+
+func TestGitSparse_Get(t *testing.T) {
+	ci.Parallel(t)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	if !gitSupportsSparse(context.Background()) {
+		t.Skip("git does not support sparse checkouts")
+	}
+
+	// create a repository with a file at its root and two directories
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{
+			"-c", "user.name=nomad", "-c", "user.email=nomad@example.com",
+		}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		must.NoError(t, err, must.Sprint(string(out)))
+	}
+	for _, file := range []string{"README.md", "services/api/main.go", "services/web/main.go", "libs/common/lib.go"} {
+		must.NoError(t, os.MkdirAll(filepath.Join(repo, filepath.Dir(file)), 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(repo, file), []byte(file), 0o644))
+	}
+	git("init", "--initial-branch", "main")
+	git("add", ".")
+	git("commit", "-m", "initial")
+	git("tag", "v1")
+
+	get := func(t *testing.T, query string) string {
+		p := &parameters{
+			Source:      "git::file://" + repo + "?" + query,
+			Destination: filepath.Join(t.TempDir(), "repo"),
+			Mode:        getter.ClientModeDir,
+		}
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		must.NoError(t, c.Get())
+		return p.Destination
+	}
+
+	for _, query := range []string{
+		"sparse=services/api,libs/common",
+		"sparse=services/api,libs/common&ref=v1",
+		"sparse=services/api,libs/common&ref=main&depth=1",
+	} {
+		t.Run(query, func(t *testing.T) {
+			dst := get(t, query)
+			must.FileExists(t, filepath.Join(dst, "services/api/main.go"))
+			must.FileExists(t, filepath.Join(dst, "libs/common/lib.go"))
+			must.FileNotExists(t, filepath.Join(dst, "services/web/main.go"))
+
+			// cone mode checks out the files at the root of the repository
+			must.FileExists(t, filepath.Join(dst, "README.md"))
+		})
+	}
+
+	t.Run("without sparse", func(t *testing.T) {
+		dst := get(t, "ref=v1")
+		must.FileExists(t, filepath.Join(dst, "services/web/main.go"))
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// headTimeout is the maximum amount of time spent on a HEAD request made
+// before downloading an artifact.
+const headTimeout = 30 * time.Second
+
+// head issues a HEAD request for u with the artifact headers and returns the
+// response headers. The second return value is false if the request failed or
+// the server did not respond with 200 OK, e.g. because it does not support
+// HEAD requests.
+func head(ctx context.Context, p *parameters, u *url.URL) (http.Header, bool) {
+	ctx, cancel := context.WithTimeout(ctx, headTimeout)
+	defer cancel()
+
+	// the checksum is a go-getter parameter, not part of the resource
+	q := u.Query()
+	q.Del("checksum")
+	target := *u
+	target.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target.String(), nil)
+	if err != nil {
+		return nil, false
+	}
+	for key, values := range p.Headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, false
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false
+	}
+	return resp.Header, true
+}
+
+// checkContentLength rejects an http(s) artifact whose Content-Length, as
+// reported in response to a HEAD request, exceeds HTTPMaxBytes. If the server
+// does not support HEAD requests or omits the Content-Length, the limit is
+// enforced while the artifact is downloaded instead.
+func checkContentLength(ctx context.Context, p *parameters) error {
+	if p.HTTPMaxBytes <= 0 {
+		return nil
+	}
+
+	u, err := url.Parse(p.Source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+
+	header, ok := head(ctx, p, u)
+	if !ok {
+		return nil
+	}
+
+	length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	if length > p.HTTPMaxBytes {
+		return fmt.Errorf("%w: artifact size is %d bytes", maxBytesError(p.HTTPMaxBytes), length)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestHead_checkContentLength(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+		case "/unknown":
+			// streamed responses have no Content-Length
+			w.Header().Set("Transfer-Encoding", "chunked")
+			w.(http.Flusher).Flush()
+			return
+		}
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		w.Header().Set("Content-Length", strconv.Itoa(size))
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		name   string
+		source string
+		max    int64
+		expErr string
+	}{
+		{
+			name:   "within limit",
+			source: srv.URL + "/file?size=100",
+			max:    100,
+		},
+		{
+			name:   "exceeds limit",
+			source: srv.URL + "/file?size=101",
+			max:    100,
+			expErr: "artifact exceeds the maximum download size of 100 bytes: artifact size is 101 bytes",
+		},
+		{
+			name:   "no limit",
+			source: srv.URL + "/file?size=101",
+			max:    0,
+		},
+		{
+			name:   "head not allowed",
+			source: srv.URL + "/nohead?size=101",
+			max:    100,
+		},
+		{
+			name:   "unknown length",
+			source: srv.URL + "/unknown",
+			max:    100,
+		},
+		{
+			name:   "not http",
+			source: "git::" + srv.URL + "/file?size=101",
+			max:    100,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &parameters{
+				Source:       tc.source,
+				HTTPMaxBytes: tc.max,
+			}
+			err := checkContentLength(context.Background(), p)
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorIs(t, err, ErrMaxBytesExceeded)
+				must.EqError(t, err, tc.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// keepArchiveDecompressor is a go-getter Decompressor which retains a copy of
+// the downloaded archive alongside the contents it extracts, which go-getter
+// would otherwise remove once extracted.
+type keepArchiveDecompressor struct {
+	getter.Decompressor
+
+	// name is the file name the archive is retained as
+	name string
+
+	// user is the task user the archive is chowned to, if chown is set,
+	// unless overridden by the uid and gid of the artifact owner and group
+	user  string
+	owner string
+	group string
+	chown bool
+}
+
+// keepArchive wraps each of decompressors so that the archive downloaded for
+// p is retained after extraction.
+func keepArchive(decompressors map[string]getter.Decompressor, p *parameters) map[string]getter.Decompressor {
+	name := archiveName(p.Source)
+	result := make(map[string]getter.Decompressor, len(decompressors))
+	for ext, d := range decompressors {
+		result[ext] = &keepArchiveDecompressor{
+			Decompressor: d,
+			name:         name,
+			user:         p.User,
+			owner:        p.Owner,
+			group:        p.Group,
+			chown:        p.Chown,
+		}
+	}
+	return result
+}
+
+// Decompress extracts the archive at src into dst and then copies the
+// archive into the directory it was extracted into, or next to the extracted
+// file if dir is false.
+func (k *keepArchiveDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	if err := k.Decompressor.Decompress(dst, src, dir, umask); err != nil {
+		return err
+	}
+
+	kept := filepath.Join(filepath.Dir(dst), k.name)
+	if dir {
+		kept = filepath.Join(dst, k.name)
+	}
+	if kept == filepath.Clean(dst) {
+		return fmt.Errorf("cannot keep archive %s as it would replace the extracted file", k.name)
+	}
+
+	if err := copyFile(src, kept, 0o644); err != nil {
+		return fmt.Errorf("failed to keep archive: %w", err)
+	}
+
+	if k.chown {
+		return chownDestination(kept, k.user, k.owner, k.group, structs.GetterChownModeTop)
+	}
+	return nil
+}
+
+// archiveName returns the file name of the archive at source, which is the
+// last element of its path.
+func archiveName(source string) string {
+	// ignore a forced getter, such as "s3::"
+	if i := strings.Index(source, "::"); i > 0 {
+		source = source[i+2:]
+	}
+
+	name := "archive"
+	if u, err := url.Parse(source); err == nil {
+		if base := path.Base(u.Path); base != "." && base != "/" {
+			name = base
+		}
+	}
+	return name
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestKeepArchive_archiveName(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		source string
+		exp    string
+	}{
+		{source: "https://example.com/app.tar.gz?checksum=sha256:abc", exp: "app.tar.gz"},
+		{source: "s3::https://bucket.s3.amazonaws.com/dir/app.zip", exp: "app.zip"},
+		{source: "https://example.com/", exp: "archive"},
+		{source: "https://example.com", exp: "archive"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.source, func(t *testing.T) {
+			must.Eq(t, tc.exp, archiveName(tc.source))
+		})
+	}
+}
+
+func TestKeepArchive_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0o644, Size: 5}))
+	_, err := tw.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+
+	var compressed bytes.Buffer
+	gz = gzip.NewWriter(&compressed)
+	_, err = gz.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.NoError(t, gz.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app.tar.gz":
+			_, _ = w.Write(tarball.Bytes())
+		case "/hello.txt.gz":
+			_, _ = w.Write(compressed.Bytes())
+		}
+	}))
+	defer srv.Close()
+
+	get := func(t *testing.T, source, dst string, mode getter.ClientMode, keep bool) {
+		p := &parameters{
+			HTTPMaxBytes:                1e6,
+			DecompressionLimitFileCount: 10,
+			DecompressionLimitSize:      1e6,
+			Mode:                        mode,
+			Source:                      source,
+			Destination:                 dst,
+			KeepArchive:                 keep,
+		}
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		must.NoError(t, c.Get())
+	}
+
+	t.Run("dir", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "local")
+		get(t, srv.URL+"/app.tar.gz", dst, getter.ClientModeAny, true)
+
+		b, err := os.ReadFile(filepath.Join(dst, "hello.txt"))
+		must.NoError(t, err)
+		must.Eq(t, "hello", string(b))
+
+		b, err = os.ReadFile(filepath.Join(dst, "app.tar.gz"))
+		must.NoError(t, err)
+		must.Eq(t, tarball.Bytes(), b)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir := t.TempDir()
+		dst := filepath.Join(dir, "hello.txt")
+		get(t, srv.URL+"/hello.txt.gz", dst, getter.ClientModeFile, true)
+
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.Eq(t, "hello", string(b))
+
+		b, err = os.ReadFile(filepath.Join(dir, "hello.txt.gz"))
+		must.NoError(t, err)
+		must.Eq(t, compressed.Bytes(), b)
+	})
+
+	t.Run("not kept by default", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "local")
+		get(t, srv.URL+"/app.tar.gz", dst, getter.ClientModeAny, false)
+
+		must.FileExists(t, filepath.Join(dst, "hello.txt"))
+		must.FileNotExists(t, filepath.Join(dst, "app.tar.gz"))
+	})
+}
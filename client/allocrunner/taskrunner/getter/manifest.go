@@ -0,0 +1,576 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-getter"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// manifestAssembleConcat concatenates the parts of a manifest, in order,
+	// into a single file.
+	manifestAssembleConcat = "concat"
+
+	// manifestAssemblePlace places each part of a manifest at its own path
+	// within the destination of the artifact.
+	manifestAssemblePlace = "place"
+)
+
+const (
+	// manifestMaxSize is the maximum size of a manifest.
+	manifestMaxSize = 1 << 20
+
+	// manifestMaxParts is the maximum number of parts listed by a manifest.
+	manifestMaxParts = 4096
+
+	// manifestMaxChunks is the maximum number of chunks listed by a part.
+	manifestMaxChunks = 65536
+
+	// manifestParallelDownloads is the maximum number of parts of a manifest
+	// downloaded at the same time, unless the artifact or the client sets
+	// another maximum.
+	manifestParallelDownloads = 4
+)
+
+// manifest lists the parts which together form an artifact whose source is
+// "manifest::" followed by the http(s) URL of the manifest.
+type manifest struct {
+	// Assemble is how the parts form the artifact, either "concat" or
+	// "place". Defaults to "place".
+	Assemble string `json:"assemble"`
+
+	// Path is the path of the file the parts are concatenated into, relative
+	// to the destination of the artifact. It is only used when the artifact
+	// is downloaded as a directory.
+	Path string `json:"path"`
+
+	Parts []*manifestPart `json:"parts"`
+}
+
+// manifestPart is one of the parts of an artifact listed by a manifest.
+type manifestPart struct {
+	// URL is the http(s) URL of the part, which may be relative to the URL of
+	// the manifest.
+	URL string `json:"url"`
+
+	// Checksum is the "type:value" checksum of the part, if any.
+	Checksum string `json:"checksum"`
+
+	// Path is the path of the part relative to the destination of the
+	// artifact, when the parts are placed rather than concatenated.
+	Path string `json:"path"`
+
+	// ChunkSize is the size in bytes of the chunks of the part, if any. The
+	// last chunk may be shorter.
+	ChunkSize int64 `json:"chunk_size"`
+
+	// Chunks are the "type:value" checksums of the consecutive chunks of the
+	// part, which allow a partially downloaded part to be resumed from its
+	// last valid chunk, and corrupt chunks to be fetched again.
+	Chunks []string `json:"chunks"`
+
+	// url is URL resolved against the URL of the manifest
+	url *url.URL
+}
+
+// validate checks the manifest fetched from base and resolves the URLs of its
+// parts against base.
+func (m *manifest) validate(base *url.URL) error {
+	switch m.Assemble {
+	case "":
+		m.Assemble = manifestAssemblePlace
+	case manifestAssembleConcat, manifestAssemblePlace:
+	default:
+		return fmt.Errorf("unsupported assemble %q, must be one of: %s, %s",
+			m.Assemble, manifestAssembleConcat, manifestAssemblePlace)
+	}
+
+	if len(m.Parts) == 0 {
+		return errors.New("manifest lists no parts")
+	}
+	if len(m.Parts) > manifestMaxParts {
+		return fmt.Errorf("manifest lists %d parts, more than the maximum of %d", len(m.Parts), manifestMaxParts)
+	}
+	if m.Path != "" && !filepath.IsLocal(m.Path) {
+		return fmt.Errorf("path %q must be relative and within the artifact destination", m.Path)
+	}
+
+	paths := make(map[string]struct{}, len(m.Parts))
+	for i, part := range m.Parts {
+		if part == nil {
+			return fmt.Errorf("part %d is empty", i)
+		}
+
+		u, err := base.Parse(part.URL)
+		if err != nil {
+			return fmt.Errorf("part %d: invalid url %q: %w", i, part.URL, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("part %d: url scheme must be http or https but found %q", i, u.Scheme)
+		}
+		part.url = u
+
+		if part.Checksum != "" && !validPartChecksum(part.Checksum) {
+			return fmt.Errorf("part %d: invalid checksum %q, must be \"type:value\" with a type of md5, sha1, sha256 or sha512", i, part.Checksum)
+		}
+
+		switch {
+		case len(part.Chunks) > manifestMaxChunks:
+			return fmt.Errorf("part %d: lists %d chunks, more than the maximum of %d", i, len(part.Chunks), manifestMaxChunks)
+		case len(part.Chunks) > 0 && part.ChunkSize <= 0:
+			return fmt.Errorf("part %d: chunk_size must be positive when chunks are listed", i)
+		case len(part.Chunks) == 0 && part.ChunkSize != 0:
+			return fmt.Errorf("part %d: chunk_size cannot be set without chunks", i)
+		}
+		for j, chunk := range part.Chunks {
+			if !validPartChecksum(chunk) {
+				return fmt.Errorf("part %d: chunk %d: invalid checksum %q, must be \"type:value\" with a type of md5, sha1, sha256 or sha512", i, j, chunk)
+			}
+		}
+
+		switch m.Assemble {
+		case manifestAssembleConcat:
+			if part.Path != "" {
+				return fmt.Errorf("part %d: path cannot be set when parts are concatenated", i)
+			}
+		case manifestAssemblePlace:
+			if part.Path == "" || !filepath.IsLocal(part.Path) {
+				return fmt.Errorf("part %d: path %q must be relative and within the artifact destination", i, part.Path)
+			}
+			clean := filepath.Clean(part.Path)
+			if _, ok := paths[clean]; ok {
+				return fmt.Errorf("part %d: path %q is used by more than one part", i, part.Path)
+			}
+			paths[clean] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// manifestGetter is the go-getter Getter of "manifest::" sources. It fetches
+// the manifest and the parts it lists with the http getter of the artifact,
+// so the parts are subject to the same headers, timeouts and limits as other
+// http artifacts.
+type manifestGetter struct {
+	// http is the getter of the manifest and of its parts
+	http *getter.HttpGetter
+
+	// maxBytes is the maximum total size of the parts, if any
+	maxBytes int64
+
+	// maxParallel is the maximum number of parts downloaded at the same
+	// time, or 0 for manifestParallelDownloads
+	maxParallel int
+
+	// policy is the artifact policy every part is evaluated against before
+	// any of them is fetched, if any
+	policy *partPolicy
+
+	// err is the error of the last download, which go-getter does not wrap
+	// when it fails a directory download
+	err error
+}
+
+// SetClient is a no-op, as the http getter is given the client itself.
+func (g *manifestGetter) SetClient(*getter.Client) {}
+
+// ClientMode returns ClientModeDir, so that artifacts in "any" mode are
+// assembled within their destination directory. Artifacts in "file" mode are
+// downloaded with GetFile.
+func (g *manifestGetter) ClientMode(*url.URL) (getter.ClientMode, error) {
+	return getter.ClientModeDir, nil
+}
+
+// Get assembles the parts listed by the manifest at u within the directory
+// dst.
+func (g *manifestGetter) Get(dst string, u *url.URL) error {
+	g.err = g.get(dst, u)
+	return g.err
+}
+
+func (g *manifestGetter) get(dst string, u *url.URL) error {
+	m, err := g.fetch(u)
+	if err != nil {
+		return err
+	}
+
+	if m.Assemble == manifestAssembleConcat {
+		if m.Path == "" {
+			return errors.New(`manifest path must be set to concatenate parts into a directory, or the artifact mode set to "file"`)
+		}
+		return g.concat(filepath.Join(dst, m.Path), m.Parts)
+	}
+
+	return g.download(m.Parts, func(part *manifestPart) string {
+		return filepath.Join(dst, part.Path)
+	})
+}
+
+// GetFile concatenates the parts listed by the manifest at u into the file
+// dst.
+func (g *manifestGetter) GetFile(dst string, u *url.URL) error {
+	m, err := g.fetch(u)
+	if err != nil {
+		return err
+	}
+
+	if m.Assemble != manifestAssembleConcat {
+		return fmt.Errorf(`manifest parts are assembled with %q and cannot be downloaded as a file, set the artifact mode to "dir"`, m.Assemble)
+	}
+	return g.concat(dst, m.Parts)
+}
+
+// fetch downloads and validates the manifest at u, and evaluates its parts
+// against the artifact policy, if any.
+func (g *manifestGetter) fetch(u *url.URL) (*manifest, error) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("manifest url scheme must be http or https but found %q", u.Scheme)
+	}
+
+	dir, err := os.MkdirTemp("", "manifest")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "manifest.json")
+	if err := g.http.GetFile(path, u); err != nil {
+		return nil, fmt.Errorf("failed to download manifest: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(io.LimitReader(f, manifestMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > manifestMaxSize {
+		return nil, fmt.Errorf("manifest exceeds the maximum size of %d bytes", manifestMaxSize)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := m.validate(u); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := g.policy.check(m.Parts); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// concat downloads parts and concatenates them, in order, into the file dst.
+func (g *manifestGetter) concat(dst string, parts []*manifestPart) error {
+	dir, err := os.MkdirTemp("", "manifest")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	paths := make(map[*manifestPart]string, len(parts))
+	for i, part := range parts {
+		paths[part] = filepath.Join(dir, fmt.Sprintf("part-%d", i))
+	}
+	if err := g.download(parts, func(part *manifestPart) string { return paths[part] }); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	for _, part := range parts {
+		if err := appendFile(out, paths[part]); err != nil {
+			_ = out.Close()
+			return fmt.Errorf("failed to concatenate parts: %w", err)
+		}
+	}
+	return out.Close()
+}
+
+// download downloads parts, up to maxParallel at a time, each to the path
+// returned by dst, and verifies their checksums. The total size of the parts
+// is limited to maxBytes, if set, and the download is aborted as soon as the
+// bytes downloaded for the parts exceed it.
+func (g *manifestGetter) download(parts []*manifestPart, dst func(*manifestPart) string) error {
+	limit := g.maxParallel
+	if limit < 1 {
+		limit = manifestParallelDownloads
+	}
+
+	var budget *manifestBudget
+	if g.maxBytes > 0 && g.http.Client != nil {
+		budget = &manifestBudget{limit: g.maxBytes}
+		budget.remaining.Store(g.maxBytes)
+	}
+
+	// parts which have not started are skipped once any part fails
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(limit)
+
+	sizes := make([]int64, len(parts))
+	for i, part := range parts {
+		group.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			size, err := g.downloadPart(part, dst(part), budget)
+			sizes[i] = size
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	// parts resumed from a previous download were partly downloaded before
+	// the budget was set
+	if g.maxBytes > 0 {
+		var total int64
+		for _, size := range sizes {
+			total += size
+		}
+		if total > g.maxBytes {
+			return fmt.Errorf("%w: total size of the manifest parts is %d bytes", maxBytesError(g.maxBytes), total)
+		}
+	}
+	return nil
+}
+
+// downloadPart downloads part to path and returns its size. The bytes
+// downloaded are charged against budget, if any.
+//
+// A previous download of a part which can be verified is resumed with a range
+// request, which the http getter of the artifact otherwise disables because
+// the bytes already downloaded may be corrupt or stale. They are kept up to
+// the first corrupt chunk if the part lists chunks, or entirely if the whole
+// part is verified against its checksum once downloaded. A part which fails
+// verification is fetched again once, from its first corrupt chunk if it
+// lists chunks.
+func (g *manifestGetter) downloadPart(part *manifestPart, path string, budget *manifestBudget) (int64, error) {
+	base := g.http
+	var charged *partTransport
+	if budget != nil {
+		charged = &partTransport{RoundTripper: g.http.Client.Transport, budget: budget}
+		base = withTransport(g.http, charged)
+	}
+
+	http := base
+	switch {
+	case len(part.Chunks) > 0:
+		if err := truncateInvalidChunks(path, part); err != nil {
+			return 0, err
+		}
+		http = resumable(base)
+	case part.Checksum != "":
+		http = resumable(base)
+	default:
+		// the http getter writes over existing files without truncating
+		// them
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := http.GetFile(path, part.url); err != nil {
+			return 0, fmt.Errorf("failed to download part %s: %w", getter.RedactURL(part.url), err)
+		}
+
+		err := verifyPart(path, part)
+		if err == nil {
+			break
+		}
+		if attempt > 0 {
+			return 0, fmt.Errorf("part %s: %w", getter.RedactURL(part.url), err)
+		}
+
+		// the bytes discarded are downloaded again, so they are not charged
+		// twice
+		before := fileSize(path)
+		if len(part.Chunks) > 0 {
+			err = truncateInvalidChunks(path, part)
+		} else {
+			err = os.Remove(path)
+		}
+		if err != nil {
+			return 0, err
+		}
+		charged.refund(before - fileSize(path))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// fileSize returns the size of the file at path, or 0 if it does not exist.
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// withTransport returns a copy of the http getter which sends its requests
+// with transport.
+func withTransport(httpGetter *getter.HttpGetter, transport http.RoundTripper) *getter.HttpGetter {
+	g := *httpGetter
+	client := *httpGetter.Client
+	client.Transport = transport
+	g.Client = &client
+	return &g
+}
+
+// manifestBudget is the number of bytes which can still be downloaded for the
+// parts of a manifest, shared by the downloads of all of them.
+type manifestBudget struct {
+	limit     int64
+	remaining atomic.Int64
+}
+
+// partTransport is an http.RoundTripper which charges the response bodies of
+// the downloads of a part against the budget of its manifest, and fails
+// reading them with ErrMaxBytesExceeded once the budget is exhausted.
+type partTransport struct {
+	http.RoundTripper
+	budget *manifestBudget
+
+	// charged is the number of bytes charged for the part. A part is
+	// downloaded by a single goroutine, so it is not synchronized.
+	charged int64
+}
+
+func (t *partTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &partBody{ReadCloser: resp.Body, transport: t}
+	return resp, nil
+}
+
+// refund returns up to n of the bytes charged for the part to the budget.
+func (t *partTransport) refund(n int64) {
+	if t == nil {
+		return
+	}
+	n = min(n, t.charged)
+	t.charged -= n
+	t.budget.remaining.Add(n)
+}
+
+// partBody is a response body charged against the budget of a manifest.
+type partBody struct {
+	io.ReadCloser
+	transport *partTransport
+}
+
+func (b *partBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.transport.charged += int64(n)
+		if b.transport.budget.remaining.Add(-int64(n)) < 0 {
+			return n, fmt.Errorf("%w: total size of the manifest parts exceeds it", maxBytesError(b.transport.budget.limit))
+		}
+	}
+	return n, err
+}
+
+// resumable returns a copy of the http getter which resumes the download of
+// existing files with a range request, if the server supports them.
+func resumable(http *getter.HttpGetter) *getter.HttpGetter {
+	g := *http
+	g.DoNotCheckHeadFirst = false
+	return &g
+}
+
+// verifyPart verifies the file at path against the checksums of the chunks of
+// part, if any, and its checksum, if any.
+func verifyPart(path string, part *manifestPart) error {
+	offset, err := firstInvalidChunk(path, part)
+	if err != nil {
+		return err
+	}
+	switch {
+	case offset >= int64(len(part.Chunks))*part.ChunkSize:
+		return fmt.Errorf("part is longer than its %d chunks", len(part.Chunks))
+	case offset >= 0:
+		return fmt.Errorf("checksums did not match for chunk %d at offset %d", offset/part.ChunkSize, offset)
+	}
+	return verifyPartChecksum(path, part.Checksum)
+}
+
+// validPartChecksum returns whether checksum is a "type:value" checksum with
+// a supported type.
+func validPartChecksum(checksum string) bool {
+	kind, value, ok := strings.Cut(checksum, ":")
+	_, supported := checksumHashes[kind]
+	return ok && supported && value != ""
+}
+
+// verifyPartChecksum verifies the file at path against the "type:value"
+// checksum, if any.
+func verifyPartChecksum(path, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	kind, _, _ := strings.Cut(checksum, ":")
+	actual, err := ComputeChecksum(path, kind)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("checksums did not match: expected %s, got %s", checksum, actual)
+	}
+	return nil
+}
+
+// manifestError returns the error of the manifest getter of c in place of
+// err, if the manifest getter failed, so that the errors it wraps can be
+// told apart.
+func manifestError(c *getter.Client, err error) error {
+	if g, ok := c.Getters["manifest"].(*manifestGetter); ok && g.err != nil {
+		return g.err
+	}
+	return err
+}
+
+// appendFile appends the content of the file at path to out.
+func appendFile(out io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(out, f)
+	return err
+}
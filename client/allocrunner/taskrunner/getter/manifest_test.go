@@ -0,0 +1,532 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestManifest_client(t *testing.T) {
+	ci.Parallel(t)
+
+	parts := map[string]string{
+		"/parts/0":     "first,",
+		"/parts/1":     "second,",
+		"/parts/2":     "third",
+		"/parts/big-0": strings.Repeat("a", 400),
+		"/parts/big-1": strings.Repeat("b", 400),
+		"/parts/big-2": strings.Repeat("c", 400),
+	}
+	flaky := strings.Repeat("d", 200) + strings.Repeat("e", 200)
+	manifests := map[string]string{
+		"/concat.json": `{
+			"assemble": "concat",
+			"path": "model.bin",
+			"parts": [
+				{"url": "parts/0", "checksum": "` + sha256Checksum("first,") + `"},
+				{"url": "parts/1"},
+				{"url": "/parts/2", "checksum": "` + sha256Checksum("third") + `"}
+			]
+		}`,
+		"/place.json": `{
+			"parts": [
+				{"url": "parts/0", "path": "shards/0.bin"},
+				{"url": "parts/1", "path": "shards/1.bin"},
+				{"url": "parts/2", "path": "index.txt"}
+			]
+		}`,
+		"/bad-checksum.json": `{
+			"assemble": "concat",
+			"path": "model.bin",
+			"parts": [{"url": "parts/0", "checksum": "` + sha256Checksum("other") + `"}]
+		}`,
+		"/missing-part.json": `{"parts": [{"url": "parts/9", "path": "9.bin"}]}`,
+		"/big.json": `{"parts": [
+			{"url": "parts/big-0", "path": "0"},
+			{"url": "parts/big-1", "path": "1"},
+			{"url": "parts/big-2", "path": "2"}
+		]}`,
+		"/flaky.json": `{"parts": [{"url": "parts/flaky", "path": "flaky", "chunk_size": 200, "chunks": ["` +
+			sha256Checksum(strings.Repeat("d", 200)) + `", "` + sha256Checksum(strings.Repeat("e", 200)) + `"]}]}`,
+	}
+
+	var (
+		requested    sync.Map
+		flakyCorrupt atomic.Bool
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(r.URL.Path, true)
+		if r.URL.Path == "/parts/flaky" {
+			body := flaky
+			if r.Method == http.MethodGet && flakyCorrupt.CompareAndSwap(true, false) {
+				body = flaky[:200] + "X" + flaky[201:]
+			}
+			http.ServeContent(w, r, "flaky", time.Time{}, strings.NewReader(body))
+			return
+		}
+		if content, ok := parts[r.URL.Path]; ok {
+			_, _ = w.Write([]byte(content))
+			return
+		}
+		if content, ok := manifests[r.URL.Path]; ok {
+			_, _ = w.Write([]byte(content))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, p *parameters) error {
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		return manifestError(c, c.Get())
+	}
+
+	t.Run("concat", func(t *testing.T) {
+		dst := t.TempDir()
+		err := get(t, &parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "manifest::" + srv.URL + "/concat.json",
+			Destination: dst,
+		})
+		must.NoError(t, err)
+
+		b, err := os.ReadFile(filepath.Join(dst, "model.bin"))
+		must.NoError(t, err)
+		must.Eq(t, "first,second,third", string(b))
+	})
+
+	t.Run("concat file mode", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "weights.bin")
+		err := get(t, &parameters{
+			Mode:        getter.ClientModeFile,
+			Source:      "manifest::" + srv.URL + "/concat.json",
+			Destination: dst,
+		})
+		must.NoError(t, err)
+
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.Eq(t, "first,second,third", string(b))
+	})
+
+	t.Run("place", func(t *testing.T) {
+		dst := t.TempDir()
+		err := get(t, &parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "manifest::" + srv.URL + "/place.json",
+			Destination: dst,
+		})
+		must.NoError(t, err)
+
+		for path, content := range map[string]string{
+			"shards/0.bin": "first,",
+			"shards/1.bin": "second,",
+			"index.txt":    "third",
+		} {
+			b, err := os.ReadFile(filepath.Join(dst, path))
+			must.NoError(t, err)
+			must.Eq(t, content, string(b))
+		}
+	})
+
+	t.Run("place file mode", func(t *testing.T) {
+		err := get(t, &parameters{
+			Mode:        getter.ClientModeFile,
+			Source:      "manifest::" + srv.URL + "/place.json",
+			Destination: filepath.Join(t.TempDir(), "out"),
+		})
+		must.ErrorContains(t, err, "cannot be downloaded as a file")
+	})
+
+	t.Run("bad checksum", func(t *testing.T) {
+		err := get(t, &parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "manifest::" + srv.URL + "/bad-checksum.json",
+			Destination: t.TempDir(),
+		})
+		must.ErrorContains(t, err, "checksums did not match")
+	})
+
+	t.Run("missing part", func(t *testing.T) {
+		err := get(t, &parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "manifest::" + srv.URL + "/missing-part.json",
+			Destination: t.TempDir(),
+		})
+		must.ErrorContains(t, err, "bad response code: 404")
+	})
+
+	t.Run("exceeds max bytes", func(t *testing.T) {
+		err := get(t, &parameters{
+			HTTPMaxBytes: 600,
+			MaxParallel:  1,
+			Mode:         getter.ClientModeAny,
+			Source:       "manifest::" + srv.URL + "/big.json",
+			Destination:  t.TempDir(),
+		})
+		must.ErrorIs(t, err, ErrMaxBytesExceeded)
+		must.ErrorContains(t, err, "total size of the manifest parts exceeds it")
+
+		// the download is aborted before the last part
+		_, ok := requested.Load("/parts/big-2")
+		must.False(t, ok)
+	})
+
+	t.Run("part fetched again within max bytes", func(t *testing.T) {
+		// the corrupt chunk is charged once against the maximum download
+		// size, as its bytes are discarded before it is fetched again
+		flakyCorrupt.Store(true)
+		dst := t.TempDir()
+		must.NoError(t, get(t, &parameters{
+			HTTPMaxBytes: 500,
+			Mode:         getter.ClientModeAny,
+			Source:       "manifest::" + srv.URL + "/flaky.json",
+			Destination:  dst,
+		}))
+		must.False(t, flakyCorrupt.Load())
+
+		b, err := os.ReadFile(filepath.Join(dst, "flaky"))
+		must.NoError(t, err)
+		must.Eq(t, flaky, string(b))
+	})
+}
+
+func TestManifest_maxParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/manifest.json" {
+			_, _ = w.Write([]byte(`{"parts": [
+				{"url": "parts/0", "path": "0"},
+				{"url": "parts/1", "path": "1"},
+				{"url": "parts/2", "path": "2"},
+				{"url": "parts/3", "path": "3"},
+				{"url": "parts/4", "path": "4"},
+				{"url": "parts/5", "path": "5"}
+			]}`))
+			return
+		}
+
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte("part"))
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, maxParallel := range []int{1, 2} {
+		maxInFlight.Store(0)
+
+		c, err := (&parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "manifest::" + srv.URL + "/manifest.json",
+			Destination: t.TempDir(),
+			MaxParallel: maxParallel,
+		}).client(context.Background())
+		must.NoError(t, err)
+		must.NoError(t, manifestError(c, c.Get()))
+
+		must.Between(t, 1, maxInFlight.Load(), int32(maxParallel))
+	}
+}
+
+func TestManifest_policy(t *testing.T) {
+	ci.Parallel(t)
+
+	var parts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/manifest.json" {
+			_, _ = w.Write([]byte(`{"parts": [
+				{"url": "parts/0", "path": "0", "checksum": "sha256:` + strings.Repeat("0", 64) + `"},
+				{"url": "http://127.0.0.1:1/denied?token=secret", "path": "1"}
+			]}`))
+			return
+		}
+		parts.Add(1)
+		_, _ = w.Write([]byte("part"))
+	}))
+	t.Cleanup(srv.Close)
+
+	// the policy denies parts fetched from port 1 and records the input of
+	// every part
+	var l sync.Mutex
+	var inputs []policyInput
+	policy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input policyInput `json:"input"`
+		}
+		must.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		l.Lock()
+		inputs = append(inputs, req.Input)
+		l.Unlock()
+		decision := policyDecision{Allow: !strings.Contains(req.Input.Source, ":1/")}
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": decision})
+	}))
+	t.Cleanup(policy.Close)
+
+	source := "manifest::" + srv.URL + "/manifest.json"
+	get := func(policyURL string) error {
+		c, err := (&parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      source,
+			Destination: t.TempDir(),
+			PartPolicy: &partPolicy{
+				URL:   policyURL,
+				Input: &policyInput{Source: source, Scheme: "manifest", Destination: "local"},
+			},
+		}).client(context.Background())
+		must.NoError(t, err)
+		return manifestError(c, c.Get())
+	}
+
+	// no part is fetched once one of them is denied
+	err := get(policy.URL)
+	must.ErrorIs(t, err, ErrPolicyDenied)
+	must.ErrorContains(t, err, "part http://127.0.0.1:1/denied")
+	must.StrNotContains(t, err.Error(), "secret")
+	must.Eq(t, 0, parts.Load())
+
+	must.Len(t, 2, inputs)
+	must.Eq(t, policyInput{
+		Source:      srv.URL + "/parts/0",
+		Scheme:      "http",
+		Host:        "127.0.0.1",
+		Checksum:    "sha256",
+		Destination: "local",
+		Manifest:    source,
+	}, inputs[0])
+	must.Eq(t, "http://127.0.0.1:1/denied", inputs[1].Source)
+
+	// nor when the policy cannot be evaluated
+	err = get("http://127.0.0.1:0/v1/data/nomad/artifact")
+	must.ErrorContains(t, err, "failed to evaluate artifact policy for part")
+	must.False(t, errors.Is(err, ErrPolicyDenied))
+	must.Eq(t, 0, parts.Load())
+}
+
+func TestManifest_resume(t *testing.T) {
+	ci.Parallel(t)
+
+	const content = "aaaabbbbcccc"
+	chunks := `"chunk_size": 4, "chunks": ["` + sha256Checksum("aaaa") + `", "` +
+		sha256Checksum("bbbb") + `", "` + sha256Checksum("cccc") + `"]`
+
+	var (
+		lock    sync.Mutex
+		ranges  []string
+		corrupt int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chunks.json":
+			_, _ = w.Write([]byte(`{"parts": [{"url": "part", "path": "part.bin", ` + chunks + `}]}`))
+		case "/checksum.json":
+			_, _ = w.Write([]byte(`{"parts": [{"url": "part", "path": "part.bin", "checksum": "` + sha256Checksum(content) + `"}]}`))
+		case "/part":
+			lock.Lock()
+			body := content
+			if r.Method == http.MethodGet {
+				ranges = append(ranges, r.Header.Get("Range"))
+				if corrupt > 0 {
+					corrupt--
+					body = "aaaaXbbbcccc"
+				}
+			}
+			lock.Unlock()
+			http.ServeContent(w, r, "part", time.Time{}, strings.NewReader(body))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, manifest, partial string, corruptGets int) []string {
+		lock.Lock()
+		ranges, corrupt = nil, corruptGets
+		lock.Unlock()
+
+		dst := t.TempDir()
+		if partial != "" {
+			must.NoError(t, os.WriteFile(filepath.Join(dst, "part.bin"), []byte(partial), 0o644))
+		}
+
+		c, err := (&parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "manifest::" + srv.URL + manifest,
+			Destination: dst,
+		}).client(context.Background())
+		must.NoError(t, err)
+		must.NoError(t, manifestError(c, c.Get()))
+
+		b, err := os.ReadFile(filepath.Join(dst, "part.bin"))
+		must.NoError(t, err)
+		must.Eq(t, content, string(b))
+
+		lock.Lock()
+		defer lock.Unlock()
+		return ranges
+	}
+
+	t.Run("resume after valid chunks", func(t *testing.T) {
+		must.Eq(t, []string{"bytes=4-"}, get(t, "/chunks.json", "aaaab", 0))
+	})
+
+	t.Run("resume before corrupt chunk", func(t *testing.T) {
+		must.Eq(t, []string{"bytes=4-"}, get(t, "/chunks.json", "aaaaXbbbcc", 0))
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		must.SliceEmpty(t, get(t, "/chunks.json", content, 0))
+	})
+
+	t.Run("fetch corrupt chunk again", func(t *testing.T) {
+		must.Eq(t, []string{"", "bytes=4-"}, get(t, "/chunks.json", "", 1))
+	})
+
+	t.Run("whole file checksum", func(t *testing.T) {
+		must.Eq(t, []string{"bytes=4-"}, get(t, "/checksum.json", "aaaa", 0))
+	})
+
+	t.Run("whole file checksum mismatch", func(t *testing.T) {
+		must.Eq(t, []string{"bytes=4-", ""}, get(t, "/checksum.json", "aaaX", 0))
+	})
+
+	t.Run("corrupt chunk", func(t *testing.T) {
+		lock.Lock()
+		ranges, corrupt = nil, 2
+		lock.Unlock()
+
+		c, err := (&parameters{
+			Mode:        getter.ClientModeAny,
+			Source:      "manifest::" + srv.URL + "/chunks.json",
+			Destination: t.TempDir(),
+		}).client(context.Background())
+		must.NoError(t, err)
+		err = manifestError(c, c.Get())
+		must.ErrorContains(t, err, "checksums did not match for chunk 1 at offset 4")
+	})
+}
+
+func TestManifest_validate(t *testing.T) {
+	ci.Parallel(t)
+
+	base, err := url.Parse("https://example.com/models/manifest.json")
+	must.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		m      *manifest
+		expErr string
+	}{
+		{
+			name: "relative urls",
+			m: &manifest{
+				Parts: []*manifestPart{
+					{URL: "shard-0", Path: "0.bin"},
+					{URL: "https://cdn.example.com/shard-1", Path: "1.bin"},
+				},
+			},
+		},
+		{
+			name:   "unknown assemble",
+			m:      &manifest{Assemble: "zip", Parts: []*manifestPart{{URL: "a", Path: "a"}}},
+			expErr: `unsupported assemble "zip"`,
+		},
+		{
+			name:   "no parts",
+			m:      &manifest{},
+			expErr: "manifest lists no parts",
+		},
+		{
+			name:   "unsupported scheme",
+			m:      &manifest{Parts: []*manifestPart{{URL: "s3://bucket/a", Path: "a"}}},
+			expErr: "url scheme must be http or https",
+		},
+		{
+			name:   "invalid checksum",
+			m:      &manifest{Parts: []*manifestPart{{URL: "a", Path: "a", Checksum: "crc32:abc"}}},
+			expErr: `invalid checksum "crc32:abc"`,
+		},
+		{
+			name:   "place without path",
+			m:      &manifest{Parts: []*manifestPart{{URL: "a"}}},
+			expErr: "must be relative and within the artifact destination",
+		},
+		{
+			name:   "place path escapes",
+			m:      &manifest{Parts: []*manifestPart{{URL: "a", Path: "../a"}}},
+			expErr: "must be relative and within the artifact destination",
+		},
+		{
+			name:   "duplicate paths",
+			m:      &manifest{Parts: []*manifestPart{{URL: "a", Path: "a"}, {URL: "b", Path: "./a"}}},
+			expErr: "is used by more than one part",
+		},
+		{
+			name:   "concat with part path",
+			m:      &manifest{Assemble: "concat", Parts: []*manifestPart{{URL: "a", Path: "a"}}},
+			expErr: "path cannot be set when parts are concatenated",
+		},
+		{
+			name:   "chunks without chunk size",
+			m:      &manifest{Parts: []*manifestPart{{URL: "a", Path: "a", Chunks: []string{sha256Checksum("a")}}}},
+			expErr: "chunk_size must be positive when chunks are listed",
+		},
+		{
+			name:   "chunk size without chunks",
+			m:      &manifest{Parts: []*manifestPart{{URL: "a", Path: "a", ChunkSize: 4}}},
+			expErr: "chunk_size cannot be set without chunks",
+		},
+		{
+			name:   "invalid chunk checksum",
+			m:      &manifest{Parts: []*manifestPart{{URL: "a", Path: "a", ChunkSize: 4, Chunks: []string{"sha256"}}}},
+			expErr: `chunk 0: invalid checksum "sha256"`,
+		},
+		{
+			name:   "concat path escapes",
+			m:      &manifest{Assemble: "concat", Path: "/etc/passwd", Parts: []*manifestPart{{URL: "a"}}},
+			expErr: "must be relative and within the artifact destination",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.m.validate(base)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			for _, part := range tc.m.Parts {
+				must.NotNil(t, part.url)
+			}
+			must.Eq(t, "https://example.com/models/shard-0", tc.m.Parts[0].url.String())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrMaxBytesExceeded is returned for http(s) artifacts larger than the
+// maximum download size configured by the client. Downloading the artifact
+// again exceeds the limit all the same, so it is not worth retrying.
+var ErrMaxBytesExceeded = errors.New("artifact exceeds the maximum download size")
+
+// exitMaxBytesExceeded is the exit code of the getter sub-process when the
+// artifact exceeds the maximum download size, so that ErrMaxBytesExceeded can
+// be returned across the process boundary.
+const exitMaxBytesExceeded = 6
+
+// maxBytesError returns ErrMaxBytesExceeded including the limit, so that
+// operators can tell by how much the limit needs to be raised.
+func maxBytesError(limit int64) error {
+	return fmt.Errorf("%w of %d bytes", ErrMaxBytesExceeded, limit)
+}
+
+// maxBytesTransport is an http.RoundTripper which fails reading response
+// bodies longer than limit with ErrMaxBytesExceeded. Unlike the MaxBytes
+// option of go-getter, which silently truncates such responses, this lets an
+// oversized artifact be told apart from a failed or truncated download.
+type maxBytesTransport struct {
+	http.RoundTripper
+	limit int64
+}
+
+func (t *maxBytesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &maxBytesBody{ReadCloser: resp.Body, remaining: t.limit, limit: t.limit}
+	return resp, nil
+}
+
+// maxBytesBody is a response body returning ErrMaxBytesExceeded once more
+// than limit bytes are read.
+type maxBytesBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (b *maxBytesBody) Read(p []byte) (int, error) {
+	// read one byte past the limit to find out if the body exceeds it
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		return n, maxBytesError(b.limit)
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
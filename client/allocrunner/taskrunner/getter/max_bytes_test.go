@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestMaxBytes_client(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		if r.URL.Path == "/chunked" {
+			// streamed responses have no Content-Length
+			w.Header().Set("Transfer-Encoding", "chunked")
+		}
+		_, _ = w.Write([]byte(strings.Repeat("a", size)))
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, path string, size int) (string, error) {
+		p := &parameters{
+			HTTPMaxBytes: 100,
+			Source:       srv.URL + path + "?size=" + strconv.Itoa(size),
+			Destination:  filepath.Join(t.TempDir(), "out.txt"),
+		}
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		return p.Destination, c.Get()
+	}
+
+	for _, path := range []string{"/file", "/chunked"} {
+		t.Run(path+" within limit", func(t *testing.T) {
+			dst, err := get(t, path, 100)
+			must.NoError(t, err)
+			b, err := os.ReadFile(dst)
+			must.NoError(t, err)
+			must.Eq(t, 100, len(b))
+		})
+
+		t.Run(path+" exceeds limit", func(t *testing.T) {
+			_, err := get(t, path, 101)
+			must.ErrorIs(t, err, ErrMaxBytesExceeded)
+			must.ErrorContains(t, err, "maximum download size of 100 bytes")
+		})
+	}
+}
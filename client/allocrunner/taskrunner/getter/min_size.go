@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// minSizeDecompressor is a go-getter Decompressor which stores archives
+// smaller than a minimum size as downloaded instead of extracting them.
+type minSizeDecompressor struct {
+	getter.Decompressor
+
+	// minSize is the size in bytes below which archives are not extracted
+	minSize int64
+
+	// name is the file name small archives are stored as when they would be
+	// extracted into a directory
+	name string
+}
+
+// skipSmallArchives wraps each of decompressors so that the archive
+// downloaded for p is stored verbatim if it is smaller than minSize.
+func skipSmallArchives(decompressors map[string]getter.Decompressor, p *parameters, minSize int64) map[string]getter.Decompressor {
+	name := archiveName(p.Source)
+	result := make(map[string]getter.Decompressor, len(decompressors))
+	for ext, d := range decompressors {
+		result[ext] = &minSizeDecompressor{
+			Decompressor: d,
+			minSize:      minSize,
+			name:         name,
+		}
+	}
+	return result
+}
+
+// Decompress extracts the archive at src into dst, unless it is smaller than
+// the minimum size. A small archive is instead copied to dst, or into dst if
+// dir is true.
+func (m *minSizeDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if info.Size() >= m.minSize {
+		return m.Decompressor.Decompress(dst, src, dir, umask)
+	}
+
+	if dir {
+		if err := os.MkdirAll(dst, 0o755&^umask); err != nil {
+			return err
+		}
+		dst = filepath.Join(dst, m.name)
+	} else if err := os.MkdirAll(filepath.Dir(dst), 0o755&^umask); err != nil {
+		return err
+	}
+
+	if err := copyFile(src, dst, 0o644&^umask); err != nil {
+		return fmt.Errorf("failed to store archive smaller than %d bytes: %w", m.minSize, err)
+	}
+	return nil
+}
+
+// explicitExtraction returns whether the artifact at source opts into
+// extraction explicitly, with the "archive" option or in "dir" mode, rather
+// than being extracted because of its file extension or content type.
+func explicitExtraction(source string, mode getter.ClientMode) bool {
+	if mode == getter.ClientModeDir {
+		return true
+	}
+
+	// ignore a forced getter, such as "s3::"
+	if i := strings.Index(source, "::"); i > 0 {
+		source = source[i+2:]
+	}
+	u, err := url.Parse(source)
+	return err == nil && u.Query().Has("archive")
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestMinSize_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0o644, Size: 5}))
+	_, err := tw.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+
+	var compressed bytes.Buffer
+	gz = gzip.NewWriter(&compressed)
+	_, err = gz.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.NoError(t, gz.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app.tar.gz":
+			_, _ = w.Write(tarball.Bytes())
+		case "/hello.txt.gz":
+			_, _ = w.Write(compressed.Bytes())
+		}
+	}))
+	defer srv.Close()
+
+	get := func(t *testing.T, source, dst string, mode getter.ClientMode, minSize int64) {
+		p := &parameters{
+			HTTPMaxBytes:                1e6,
+			DecompressionLimitFileCount: 10,
+			DecompressionLimitSize:      1e6,
+			DecompressionMinSize:        minSize,
+			Mode:                        mode,
+			Source:                      source,
+			Destination:                 dst,
+		}
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		must.NoError(t, c.Get())
+	}
+
+	t.Run("dir below minimum", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "local")
+		get(t, srv.URL+"/app.tar.gz", dst, getter.ClientModeAny, 1e6)
+
+		b, err := os.ReadFile(filepath.Join(dst, "app.tar.gz"))
+		must.NoError(t, err)
+		must.Eq(t, tarball.Bytes(), b)
+		must.FileNotExists(t, filepath.Join(dst, "hello.txt"))
+	})
+
+	t.Run("dir above minimum", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "local")
+		get(t, srv.URL+"/app.tar.gz", dst, getter.ClientModeAny, 10)
+
+		b, err := os.ReadFile(filepath.Join(dst, "hello.txt"))
+		must.NoError(t, err)
+		must.Eq(t, "hello", string(b))
+		must.FileNotExists(t, filepath.Join(dst, "app.tar.gz"))
+	})
+
+	t.Run("file below minimum", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "hello.txt")
+		get(t, srv.URL+"/hello.txt.gz", dst, getter.ClientModeFile, 1e6)
+
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.Eq(t, compressed.Bytes(), b)
+	})
+
+	t.Run("file above minimum", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "hello.txt")
+		get(t, srv.URL+"/hello.txt.gz", dst, getter.ClientModeFile, 10)
+
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.Eq(t, "hello", string(b))
+	})
+}
+
+func TestMinSize_explicitExtraction(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		source string
+		mode   getter.ClientMode
+		exp    bool
+	}{
+		{source: "https://example.com/app.tar.gz", mode: getter.ClientModeAny, exp: false},
+		{source: "https://example.com/app.tar.gz", mode: getter.ClientModeDir, exp: true},
+		{source: "https://example.com/app?archive=tar.gz", mode: getter.ClientModeAny, exp: true},
+		{source: "s3::https://bucket.s3.amazonaws.com/app?archive=zip", mode: getter.ClientModeFile, exp: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.source, func(t *testing.T) {
+			must.Eq(t, tc.exp, explicitExtraction(tc.source, tc.mode))
+		})
+	}
+}
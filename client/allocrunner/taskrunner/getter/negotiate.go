@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// The artifact options configuring SPNEGO authentication, which are left out
+// of the source URL sent to the server.
+const (
+	negotiateOption          = "negotiate"
+	negotiateKeytabOption    = "negotiate_keytab"
+	negotiatePrincipalOption = "negotiate_principal"
+	negotiateSPNOption       = "negotiate_spn"
+)
+
+const (
+	// exitAuthFailure is the exit code of the getter sub-process when the
+	// artifact server rejects the credentials the artifact is downloaded
+	// with, so that ErrAuthFailure can be returned across the process
+	// boundary.
+	exitAuthFailure = 10
+
+	// negotiateMaxFile is the maximum size of the Kerberos configuration
+	// passed to the getter sub-process.
+	negotiateMaxFile = 1024 * 1024
+
+	defaultKrb5Config = "/etc/krb5.conf"
+)
+
+// negotiate is the Kerberos configuration the getter sub-process uses to
+// authenticate http artifact requests with SPNEGO ("Negotiate"), as Windows
+// integrated authentication does.
+type negotiate struct {
+	// Keytab is the host path of the keytab within the task directory the
+	// Principal logs in with. It is read by the getter sub-process, after the
+	// filesystem has been isolated, so that it cannot be used to read files
+	// outside the task.
+	Keytab    string `json:"keytab"`
+	Principal string `json:"principal"`
+
+	// KeytabData is the content of the keytab read from the Nomad variable
+	// the negotiate_keytab option references, which is used in place of
+	// Keytab. The ticket cache of the client is never used, so that tasks
+	// can only authenticate as their own principal.
+	KeytabData []byte `json:"keytab_data"`
+
+	// Config is the content of the Kerberos configuration of the client.
+	Config string `json:"config"`
+
+	// SPN is the service principal name tickets are requested for, if other
+	// than HTTP/ followed by the host of each request.
+	SPN string `json:"spn"`
+}
+
+func (n *negotiate) Equal(o *negotiate) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return n.Keytab == o.Keytab &&
+		n.Principal == o.Principal &&
+		string(n.KeytabData) == string(o.KeytabData) &&
+		n.Config == o.Config &&
+		n.SPN == o.SPN
+}
+
+// getNegotiate returns the Kerberos configuration of artifact, with its keytab
+// either resolved within the task directory, or the base64 encoded keytab
+// creds read from the Nomad variable the negotiate_keytab option references.
+// It returns nil if the artifact does not enable the negotiate option.
+func getNegotiate(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, creds *interfaces.ArtifactCredentials) (*negotiate, error) {
+	option, ok := artifact.GetterOptions[negotiateOption]
+	if !ok {
+		return nil, nil
+	}
+	if enabled, err := strconv.ParseBool(env.ReplaceEnv(option)); err != nil || !enabled {
+		return nil, nil
+	}
+
+	if err := negotiateSupported(); err != nil {
+		return nil, &Error{
+			URL:         artifact.GetterSource,
+			Err:         err,
+			Recoverable: false,
+		}
+	}
+
+	n := &negotiate{
+		Principal: env.ReplaceEnv(artifact.GetterOptions[negotiatePrincipalOption]),
+		SPN:       env.ReplaceEnv(artifact.GetterOptions[negotiateSPNOption]),
+	}
+
+	config, err := readKrb5File(krb5ConfigPath())
+	if err != nil {
+		return nil, &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("%w: failed to read Kerberos configuration: %w", ErrAuthFailure, err),
+			Recoverable: false,
+		}
+	}
+	n.Config = string(config)
+
+	keytab := env.ReplaceEnv(artifact.GetterOptions[negotiateKeytabOption])
+	if keytab == "" {
+		return nil, &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact negotiate option requires the negotiate_keytab option"),
+			Recoverable: false,
+		}
+	}
+
+	if path, _, ok := structs.ParseGetterVariable(keytab); ok {
+		if creds == nil || creds.NegotiateKeytab == "" {
+			return nil, &Error{
+				URL:         artifact.GetterSource,
+				Err:         fmt.Errorf("negotiate_keytab variable %q was not read", path),
+				Recoverable: false,
+			}
+		}
+		if n.KeytabData, err = base64.StdEncoding.DecodeString(creds.NegotiateKeytab); err != nil {
+			return nil, &Error{
+				URL:         artifact.GetterSource,
+				Err:         fmt.Errorf("failed to decode negotiate_keytab variable %q: %w", path, err),
+				Recoverable: false,
+			}
+		}
+		return n, nil
+	}
+
+	path, escapes := env.ClientPath(keytab, true)
+	if escapes {
+		return nil, &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact negotiate_keytab path escapes alloc directory"),
+			Recoverable: false,
+		}
+	}
+	n.Keytab = path
+	return n, nil
+}
+
+// isNegotiateOption returns whether the artifact option k configures SPNEGO
+// authentication.
+func isNegotiateOption(k string) bool {
+	switch k {
+	case negotiateOption, negotiateKeytabOption, negotiatePrincipalOption, negotiateSPNOption:
+		return true
+	}
+	return false
+}
+
+// krb5ConfigPath returns the path of the Kerberos configuration of the client,
+// which is the first of KRB5_CONFIG if set.
+func krb5ConfigPath() string {
+	if paths := os.Getenv("KRB5_CONFIG"); paths != "" {
+		path, _, _ := strings.Cut(paths, ":")
+		return path
+	}
+	return defaultKrb5Config
+}
+
+// readKrb5File reads the Kerberos configuration at path.
+func readKrb5File(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(io.LimitReader(f, negotiateMaxFile+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > negotiateMaxFile {
+		return nil, fmt.Errorf("%s exceeds the maximum size of %d bytes", path, negotiateMaxFile)
+	}
+	return b, nil
+}
+
+// negotiateTransport is an http.RoundTripper which authenticates requests
+// with an SPNEGO token, and fails requests the server still responds to with
+// 401 Unauthorized.
+type negotiateTransport struct {
+	http.RoundTripper
+
+	// authenticate sets the Authorization header of the request to a
+	// Negotiate token for the service principal name spn.
+	authenticate func(req *http.Request, spn string) error
+	spn          string
+
+	// err is the authentication failure, if any, which is recorded as
+	// go-getter does not wrap the errors of every download
+	err error
+}
+
+func (t *negotiateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	spn := t.spn
+	if spn == "" {
+		spn = "HTTP/" + req.URL.Hostname()
+	}
+
+	req = req.Clone(req.Context())
+	if err := t.authenticate(req, spn); err != nil {
+		t.err = fmt.Errorf("%w: failed to get Kerberos ticket for %s: %w", ErrAuthFailure, spn, err)
+		return nil, t.err
+	}
+
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		_ = resp.Body.Close()
+		t.err = fmt.Errorf("%w: server rejected Kerberos ticket for %s", ErrAuthFailure, spn)
+		return nil, t.err
+	}
+	return resp, nil
+}
+
+// negotiateLogin obtains the Kerberos credentials of Negotiate, if set, with
+// which the http requests for the artifact are authenticated.
+func (p *parameters) negotiateLogin() error {
+	if p.Negotiate == nil {
+		return nil
+	}
+
+	authenticate, err := p.Negotiate.authenticator()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAuthFailure, err)
+	}
+	p.negotiator = &negotiateTransport{
+		RoundTripper: p.httpTransport(),
+		authenticate: authenticate,
+		spn:          p.Negotiate.SPN,
+	}
+	return nil
+}
+
+// negotiateError returns the authentication failure of the artifact
+// downloaded with p, if there was one, or err otherwise.
+func negotiateError(p *parameters, err error) error {
+	if p.negotiator != nil && p.negotiator.err != nil {
+		return p.negotiator.err
+	}
+	return err
+}
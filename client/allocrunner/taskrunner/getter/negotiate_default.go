@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !kerberos
+
+package getter
+
+import (
+	"errors"
+	"net/http"
+)
+
+// negotiateSupported returns an error as SPNEGO authentication requires Nomad
+// to be built with the kerberos build tag.
+func negotiateSupported() error {
+	return errors.New("artifact negotiate authentication requires Nomad to be built with the kerberos build tag")
+}
+
+func (n *negotiate) authenticator() (func(*http.Request, string) error, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build kerberos
+
+package getter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+)
+
+// negotiateSupported returns nil as this build supports SPNEGO authentication.
+func negotiateSupported() error {
+	return nil
+}
+
+// authenticator logs in as the principal with the keytab, read either from the
+// task directory or from the keytab data, and returns a function setting the
+// Negotiate token of a request for a service principal name.
+func (n *negotiate) authenticator() (func(*http.Request, string) error, error) {
+	conf, err := config.NewFromString(n.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kerberos configuration: %w", err)
+	}
+
+	kt := new(keytab.Keytab)
+	if len(n.KeytabData) > 0 {
+		err = kt.Unmarshal(n.KeytabData)
+	} else {
+		kt, err = keytab.Load(n.Keytab)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keytab: %w", err)
+	}
+
+	username, realm, _ := strings.Cut(n.Principal, "@")
+	if realm == "" {
+		realm = conf.LibDefaults.DefaultRealm
+	}
+	cl := client.NewWithKeytab(username, realm, kt, conf, client.DisablePAFXFAST(true))
+	if err := cl.Login(); err != nil {
+		return nil, fmt.Errorf("failed to log in as %s: %w", n.Principal, err)
+	}
+
+	return func(req *http.Request, spn string) error {
+		return spnego.SetSPNEGOHeader(cl, req, spn)
+	}, nil
+}
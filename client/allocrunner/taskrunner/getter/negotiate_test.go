@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestNegotiate_getNegotiate(t *testing.T) {
+	env := noopTaskEnv("/path/to/task")
+
+	t.Run("none", func(t *testing.T) {
+		n, err := getNegotiate(env, &structs.TaskArtifact{}, nil)
+		must.NoError(t, err)
+		must.Nil(t, n)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		n, err := getNegotiate(env, &structs.TaskArtifact{
+			GetterOptions: map[string]string{"negotiate": "false"},
+		}, nil)
+		must.NoError(t, err)
+		must.Nil(t, n)
+	})
+
+	if err := negotiateSupported(); err != nil {
+		t.Run("unsupported", func(t *testing.T) {
+			_, err := getNegotiate(env, &structs.TaskArtifact{
+				GetterOptions: map[string]string{"negotiate": "true"},
+			}, nil)
+			must.ErrorContains(t, err, "kerberos build tag")
+		})
+		return
+	}
+
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "krb5.conf")
+	must.NoError(t, os.WriteFile(conf, []byte("[libdefaults]\n"), 0o644))
+	t.Setenv("KRB5_CONFIG", conf)
+
+	t.Run("keytab", func(t *testing.T) {
+		n, err := getNegotiate(env, &structs.TaskArtifact{
+			GetterOptions: map[string]string{
+				"negotiate":           "true",
+				"negotiate_keytab":    "secrets/http.keytab",
+				"negotiate_principal": "svc-nomad@EXAMPLE.COM",
+			},
+		}, nil)
+		must.NoError(t, err)
+		must.Eq(t, &negotiate{
+			Keytab:    "/path/to/task/secrets/http.keytab",
+			Principal: "svc-nomad@EXAMPLE.COM",
+			Config:    "[libdefaults]\n",
+		}, n)
+	})
+
+	t.Run("variable keytab", func(t *testing.T) {
+		n, err := getNegotiate(env, &structs.TaskArtifact{
+			GetterOptions: map[string]string{
+				"negotiate":           "true",
+				"negotiate_keytab":    "nomad_var://nomad/jobs/example#keytab",
+				"negotiate_principal": "svc-nomad@EXAMPLE.COM",
+			},
+		}, &interfaces.ArtifactCredentials{NegotiateKeytab: base64.StdEncoding.EncodeToString([]byte("keytab"))})
+		must.NoError(t, err)
+		must.Eq(t, &negotiate{
+			KeytabData: []byte("keytab"),
+			Principal:  "svc-nomad@EXAMPLE.COM",
+			Config:     "[libdefaults]\n",
+		}, n)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		options map[string]string
+		creds   *interfaces.ArtifactCredentials
+		expErr  string
+	}{
+		{
+			// the ticket cache of the client is never used
+			name:    "no keytab",
+			options: map[string]string{"negotiate": "true"},
+			expErr:  "requires the negotiate_keytab option",
+		},
+		{
+			name:    "variable not read",
+			options: map[string]string{"negotiate": "true", "negotiate_keytab": "nomad_var://nomad/jobs/example"},
+			expErr:  `negotiate_keytab variable "nomad/jobs/example" was not read`,
+		},
+		{
+			name:    "invalid variable keytab",
+			options: map[string]string{"negotiate": "true", "negotiate_keytab": "nomad_var://nomad/jobs/example"},
+			creds:   &interfaces.ArtifactCredentials{NegotiateKeytab: "not base64!"},
+			expErr:  "failed to decode negotiate_keytab variable",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := getNegotiate(env, &structs.TaskArtifact{GetterOptions: tc.options}, tc.creds)
+			must.ErrorContains(t, err, tc.expErr)
+
+			var getterErr *Error
+			must.True(t, errors.As(err, &getterErr))
+			must.False(t, getterErr.IsRecoverable())
+		})
+	}
+}
+
+func TestNegotiateTransport(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Negotiate "+r.Host {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("artifact"))
+	}))
+	t.Cleanup(srv.Close)
+
+	authenticate := func(req *http.Request, spn string) error {
+		must.Eq(t, "HTTP/"+req.URL.Hostname(), spn)
+		req.Header.Set("Authorization", "Negotiate "+req.URL.Host)
+		return nil
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		transport := &negotiateTransport{RoundTripper: http.DefaultTransport, authenticate: authenticate}
+		resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+		must.NoError(t, err)
+		_ = resp.Body.Close()
+		must.Eq(t, http.StatusOK, resp.StatusCode)
+		must.NoError(t, transport.err)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		transport := &negotiateTransport{
+			RoundTripper: http.DefaultTransport,
+			authenticate: func(*http.Request, string) error { return nil },
+		}
+		_, err := (&http.Client{Transport: transport}).Get(srv.URL)
+		must.ErrorIs(t, err, ErrAuthFailure)
+		must.ErrorIs(t, negotiateError(&parameters{negotiator: transport}, errors.New("download failed")), ErrAuthFailure)
+	})
+
+	t.Run("no ticket", func(t *testing.T) {
+		transport := &negotiateTransport{
+			RoundTripper: http.DefaultTransport,
+			authenticate: func(*http.Request, string) error { return errors.New("no credentials") },
+			spn:          "HTTP/artifacts.example.com",
+		}
+		_, err := (&http.Client{Transport: transport}).Get(srv.URL)
+		must.ErrorIs(t, err, ErrAuthFailure)
+		must.ErrorContains(t, err, "HTTP/artifacts.example.com")
+	})
+}
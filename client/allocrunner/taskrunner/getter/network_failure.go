@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+)
+
+// ErrNetworkFailure is returned for artifacts whose source could not be
+// reached due to a transient failure, such as the DNS server failing to
+// resolve its host (SERVFAIL). The download is retried.
+var ErrNetworkFailure = errors.New("network failure reaching artifact source")
+
+// ErrHostNotFound is returned for artifacts whose source host does not exist
+// (NXDOMAIN). Resolving the host again fails all the same, so it is not worth
+// retrying.
+var ErrHostNotFound = errors.New("artifact source host not found")
+
+const (
+	// exitNetworkFailure and exitHostNotFound are the exit codes of the
+	// getter sub-process when resolving the host of the artifact source
+	// fails, so that ErrNetworkFailure and ErrHostNotFound can be returned
+	// across the process boundary.
+	exitNetworkFailure = 11
+	exitHostNotFound   = 12
+
+	// resolveRetries is the number of times a connection whose host failed
+	// to resolve is dialed again within a download, after a backoff starting
+	// at resolveBackoffBase, before the download fails. The download as a
+	// whole is then retried with the restart policy of the task.
+	resolveRetries      = 3
+	resolveBackoffBase  = 250 * time.Millisecond
+	resolveBackoffLimit = 2 * time.Second
+)
+
+// resolveError wraps the DNS failure err, if it is one, into ErrHostNotFound
+// if the host does not exist, or ErrNetworkFailure otherwise.
+func resolveError(err error) error {
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		return err
+	}
+	if dnsErr.IsNotFound {
+		return fmt.Errorf("%w: %w", ErrHostNotFound, err)
+	}
+	return fmt.Errorf("%w: %w", ErrNetworkFailure, err)
+}
+
+// isNetworkFailure and isHostNotFound return whether err was caused by
+// resolving the host of the artifact source failing. go-getter formats the
+// errors of downloads into strings, so their messages are checked as well.
+func isNetworkFailure(err error) bool {
+	return errors.Is(err, ErrNetworkFailure) || strings.Contains(err.Error(), ErrNetworkFailure.Error())
+}
+
+func isHostNotFound(err error) bool {
+	return errors.Is(err, ErrHostNotFound) || strings.Contains(err.Error(), ErrHostNotFound.Error())
+}
+
+// dialContext returns the function http artifact requests connect with, which
+// dials with dialer, classifies DNS failures, and dials again with a short
+// backoff when the host of the request failed to resolve transiently.
+func dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		for attempt := uint64(0); ; attempt++ {
+			conn, err := dialer.DialContext(ctx, network, addr)
+			if err == nil {
+				return conn, nil
+			}
+			err = resolveError(err)
+			if !errors.Is(err, ErrNetworkFailure) || attempt == resolveRetries {
+				return nil, err
+			}
+
+			timer, stop := helper.NewSafeTimer(helper.Backoff(resolveBackoffBase, resolveBackoffLimit, attempt))
+			select {
+			case <-ctx.Done():
+				stop()
+				return nil, err
+			case <-timer.C:
+				stop()
+			}
+		}
+	}
+}
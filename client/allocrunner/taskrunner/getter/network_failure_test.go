@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/miekg/dns"
+	"github.com/shoenig/test/must"
+)
+
+// testResolver returns a resolver whose DNS server responds to the A queries
+// for every host with rcode until failures queries have been answered, and
+// with 127.0.0.1 afterwards, and to every other query with rcode. It returns
+// the number of A queries answered.
+func testResolver(rcode int, failures int32) (*net.Resolver, *atomic.Int32) {
+	queries := new(atomic.Int32)
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+		for {
+			// the resolver frames messages as over TCP, since the pipe is
+			// not a packet connection
+			var size uint16
+			if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+				return
+			}
+			b := make([]byte, size)
+			if _, err := io.ReadFull(conn, b); err != nil {
+				return
+			}
+			req := new(dns.Msg)
+			if err := req.Unpack(b); err != nil {
+				return
+			}
+
+			resp := new(dns.Msg)
+			resp.SetReply(req)
+			resp.RecursionAvailable = true
+			if q := req.Question[0]; q.Qtype == dns.TypeA && queries.Add(1) > failures {
+				resp.Answer = append(resp.Answer, &dns.A{
+					Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 1},
+					A:   net.IPv4(127, 0, 0, 1),
+				})
+			} else {
+				resp.SetRcode(req, rcode)
+			}
+			b, err := resp.Pack()
+			if err != nil {
+				return
+			}
+			if err := binary.Write(conn, binary.BigEndian, uint16(len(b))); err != nil {
+				return
+			}
+			if _, err := conn.Write(b); err != nil {
+				return
+			}
+		}
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(context.Context, string, string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go serve(server)
+			return client, nil
+		},
+	}, queries
+}
+
+func TestResolveError(t *testing.T) {
+	ci.Parallel(t)
+
+	other := errors.New("connection refused")
+	must.Eq(t, other, resolveError(other))
+
+	servfail := &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}
+	err := resolveError(&net.OpError{Op: "dial", Net: "tcp", Err: servfail})
+	must.ErrorIs(t, err, ErrNetworkFailure)
+	must.True(t, isNetworkFailure(err))
+	must.False(t, isHostNotFound(err))
+
+	nxdomain := &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}
+	err = resolveError(&net.OpError{Op: "dial", Net: "tcp", Err: nxdomain})
+	must.ErrorIs(t, err, ErrHostNotFound)
+	must.True(t, isHostNotFound(err))
+	must.False(t, isNetworkFailure(err))
+
+	// go-getter formats the errors of downloads into strings
+	must.True(t, isNetworkFailure(errors.New("error downloading: "+resolveError(servfail).Error())))
+	must.True(t, isHostNotFound(errors.New("error downloading: "+resolveError(nxdomain).Error())))
+}
+
+func TestParameters_httpTransport_resolve(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "artifact")
+	}))
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	must.NoError(t, err)
+	// the host is rooted so that it is not resolved again within the
+	// search domains of the client
+	source := "http://artifacts.nomad.test.:" + u.Port() + "/"
+
+	t.Run("transient", func(t *testing.T) {
+		resolver, queries := testResolver(dns.RcodeServerFailure, 2)
+		p := &parameters{resolver: resolver}
+		client := &http.Client{Transport: p.httpTransport()}
+		resp, err := client.Get(source)
+		must.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		must.NoError(t, err)
+		must.Eq(t, "artifact", string(b))
+		must.GreaterEq(t, 3, queries.Load())
+	})
+
+	t.Run("persistent", func(t *testing.T) {
+		resolver, queries := testResolver(dns.RcodeServerFailure, 100)
+		p := &parameters{resolver: resolver}
+		client := &http.Client{Transport: p.httpTransport()}
+		_, err := client.Get(source)
+		must.ErrorIs(t, err, ErrNetworkFailure)
+
+		// the resolver may itself query the server more than once
+		must.GreaterEq(t, resolveRetries+1, queries.Load())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		resolver, queries := testResolver(dns.RcodeNameError, 100)
+		p := &parameters{resolver: resolver}
+		client := &http.Client{Transport: p.httpTransport()}
+		_, err := client.Get(source)
+		must.ErrorIs(t, err, ErrHostNotFound)
+		must.Eq(t, 1, queries.Load())
+	})
+}
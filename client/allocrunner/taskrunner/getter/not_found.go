@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// ErrNotFound is returned for artifacts whose source does not exist, such as
+// when an http server responds with a 404 or an s3 bucket has no such key.
+var ErrNotFound = errors.New("artifact source not found")
+
+// exitNotFound is the exit code of the getter sub-process when the artifact
+// source does not exist, so that ErrNotFound can be returned across the
+// process boundary.
+const exitNotFound = 8
+
+// isNotFound returns whether err was caused by the artifact source not
+// existing. The getters of go-getter do not return typed errors for missing
+// sources, so their messages are checked instead.
+func isNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "bad response code: "+strconv.Itoa(http.StatusNotFound)) ||
+		strings.Contains(msg, "NoSuchKey") ||
+		strings.Contains(msg, "storage: object doesn't exist")
+}
+
+// removeEmptyDownload removes the empty file go-getter leaves at the
+// destination of source when a download fails before any content is received,
+// such as when the server responds with a 404, so that an optional artifact
+// which does not exist leaves nothing behind.
+func removeEmptyDownload(source, destination string, mode getter.ClientMode) {
+	file, ok := downloadedFile(source, destination, mode)
+	if !ok {
+		return
+	}
+	if info, err := os.Lstat(file); err == nil && info.Mode().IsRegular() && info.Size() == 0 {
+		_ = os.Remove(file)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestNotFound_isNotFound(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		err error
+		exp bool
+	}{
+		{err: errors.New("bad response code: 404"), exp: true},
+		{err: fmt.Errorf("error downloading 'http://example.com/app.bin': %w", errors.New("bad response code: 404")), exp: true},
+		{err: errors.New("operation error S3: GetObject, api error NoSuchKey: The specified key does not exist."), exp: true},
+		{err: errors.New("storage: object doesn't exist"), exp: true},
+		{err: fmt.Errorf("%w: bad response code: 404", ErrNotFound), exp: true},
+		{err: errors.New("bad response code: 403"), exp: false},
+		{err: errors.New("bad response code: 500"), exp: false},
+		{err: errors.New("dial tcp 127.0.0.1:80: connect: connection refused"), exp: false},
+		{err: errors.New("Checksums did not match for app.bin"), exp: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			must.Eq(t, tc.exp, isNotFound(tc.err))
+		})
+	}
+}
+
+func TestSandbox_Get_optional(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing.conf":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	sbox := TestSandbox(t)
+
+	t.Run("not found", func(t *testing.T) {
+		_, taskDir := SetupDir(t)
+		env := noopTaskEnv(taskDir)
+
+		artifact := &structs.TaskArtifact{
+			GetterSource: srv.URL + "/missing.conf",
+			RelativeDest: "local/overlay",
+		}
+		_, err := sbox.Get(env, artifact, "nobody")
+		must.ErrorIs(t, err, ErrNotFound)
+
+		artifact.GetterOptional = true
+		_, err = sbox.Get(env, artifact, "nobody")
+		must.NoError(t, err)
+
+		_, err = os.Stat(filepath.Join(taskDir, "local", "overlay", "missing.conf"))
+		must.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		_, taskDir := SetupDir(t)
+		env := noopTaskEnv(taskDir)
+
+		artifact := &structs.TaskArtifact{
+			GetterSource:   srv.URL + "/forbidden.conf",
+			RelativeDest:   "local/overlay",
+			GetterOptional: true,
+		}
+		_, err := sbox.Get(env, artifact, "nobody")
+		must.Error(t, err)
+		must.False(t, errors.Is(err, ErrNotFound))
+	})
+}
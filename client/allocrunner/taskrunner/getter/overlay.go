@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// mount exposes the cached content of artifact at the destination of params
+// through a read-only overlay mount, downloading the artifact into the cache
+// first if it is not already cached. It reports whether the artifact was
+// mounted; if it was not, the caller falls back to copying the artifact into
+// the destination.
+func (s *Sandbox) mount(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, key string, params *parameters) (bool, error) {
+	if s.cache == nil {
+		s.logger.Debug("artifact cache disabled, copying overlay artifact", "source", params.Source)
+		return false, nil
+	}
+
+	if key == "" {
+		return false, &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact must specify a checksum to be mounted as an overlay"),
+			Recoverable: false,
+		}
+	}
+
+	// the overlay remains mounted when the task restarts
+	if isOverlayMounted(params.Destination) {
+		s.logger.Debug("artifact overlay already mounted", "source", params.Source, "destination", params.Destination)
+		params.recordCache(interfaces.FetchCacheHit)
+		return true, nil
+	}
+
+	if err := overlaySupported(); err != nil {
+		s.logger.Debug("artifact overlays not supported, copying artifact", "source", params.Source, "error", err)
+		return false, nil
+	}
+
+	cached, ok := s.cache.lookup(key)
+	if !ok {
+		if err := s.fetch(env, artifact, params, key); err != nil {
+			return false, err
+		}
+		cached = s.cache.entry(key)
+	} else if err := s.cache.verify(key); err != nil {
+		// the copy is downloaded again once the entry is evicted
+		s.logger.Warn("evicting invalid cached artifact", "source", params.Source, "key", key, "error", err)
+		_ = s.cache.evict(key)
+		return false, nil
+	}
+
+	// only directories can be mounted, such as extracted archives
+	if info, err := os.Stat(cached); err != nil || !info.IsDir() {
+		s.logger.Debug("cached artifact is not a directory, copying artifact", "source", params.Source, "key", key)
+		return false, nil
+	}
+
+	if err := mountOverlay(cached, params.AllocDir, params.Destination); err != nil {
+		s.logger.Warn("failed to mount artifact overlay, copying artifact",
+			"source", params.Source, "destination", params.Destination, "error", err)
+		return false, nil
+	}
+
+	s.logger.Debug("mounted cached artifact", "source", params.Source, "destination", params.Destination, "key", key)
+	params.recordCache(interfaces.FetchCacheHit)
+	return true, nil
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package getter
+
+import "errors"
+
+// overlaySupported returns an error as artifacts are only mounted as overlays
+// on Linux.
+func overlaySupported() error {
+	return errors.New("artifact overlays are only supported on Linux")
+}
+
+func mountOverlay(string, string, string) error {
+	return errors.ErrUnsupported
+}
+
+func isOverlayMounted(string) bool {
+	return false
+}
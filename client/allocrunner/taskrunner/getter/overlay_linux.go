@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/sys/mountinfo"
+	"golang.org/x/sys/unix"
+)
+
+// overlaySupported returns an error if artifacts cannot be mounted as
+// overlays on this client.
+func overlaySupported() error {
+	if unix.Geteuid() != 0 {
+		return errors.New("mounting artifact overlays requires root")
+	}
+	return nil
+}
+
+// mountOverlay mounts a read-only overlay of the cached artifact directory at
+// dst, which must be within allocDir. Content already at dst remains visible
+// beneath the cached content.
+//
+// The task may have written to the allocation directory, so dst is opened
+// beneath allocDir without following symlinks, and the overlay is mounted through the opened
+// directory rather than by path, so that a symlink cannot redirect the mount
+// onto a host path.
+func mountOverlay(cached, allocDir, dst string) error {
+	// the layers of an overlay are separated by colons within the options
+	if strings.ContainsAny(cached, ",:") {
+		return fmt.Errorf("path %q cannot be used as an overlay layer", cached)
+	}
+
+	rel, err := filepath.Rel(allocDir, dst)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("overlay destination %q is not within the allocation directory", dst)
+	}
+
+	dir, err := mkdirBeneath(allocDir, rel)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	target := fmt.Sprintf("/proc/self/fd/%d", dir.Fd())
+	flags := unix.MS_RDONLY | unix.MS_NOSUID | unix.MS_NODEV
+	options := "lowerdir=" + cached + ":" + target
+	return unix.Mount("overlay", target, "overlay", uintptr(flags), options)
+}
+
+// mkdirBeneath creates the directory rel within root, along with any missing
+// parents, and returns it opened as a path. No component of rel may be a
+// symlink or resolve outside of root.
+func mkdirBeneath(root, rel string) (*os.File, error) {
+	fd, err := unix.Open(root, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q: %w", root, err)
+	}
+
+	how := &unix.OpenHow{
+		Flags:   unix.O_PATH | unix.O_DIRECTORY | unix.O_CLOEXEC,
+		Resolve: unix.RESOLVE_BENEATH | unix.RESOLVE_NO_SYMLINKS | unix.RESOLVE_NO_MAGICLINKS,
+	}
+	for _, name := range strings.Split(filepath.Clean(rel), string(filepath.Separator)) {
+		if name == "." || name == "" {
+			continue
+		}
+		if err := unix.Mkdirat(fd, name, 0o755); err != nil && !errors.Is(err, unix.EEXIST) {
+			_ = unix.Close(fd)
+			return nil, fmt.Errorf("failed to create %q: %w", name, err)
+		}
+		next, err := unix.Openat2(fd, name, how)
+		_ = unix.Close(fd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %q beneath %q: %w", rel, root, err)
+		}
+		fd = next
+	}
+	return os.NewFile(uintptr(fd), filepath.Join(root, rel)), nil
+}
+
+// isOverlayMounted returns whether an overlay is mounted at dst.
+func isOverlayMounted(dst string) bool {
+	mounts, err := mountinfo.GetMounts(mountinfo.SingleEntryFilter(dst))
+	return err == nil && len(mounts) > 0 && mounts[0].FSType == "overlay"
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package getter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"golang.org/x/sys/unix"
+)
+
+func TestOverlay_mountOverlay(t *testing.T) {
+	testutil.RequireRoot(t)
+
+	cached := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(cached, "base.txt"), []byte("base"), 0o644))
+
+	allocDir := t.TempDir()
+	dst := filepath.Join(allocDir, "task", "local", "base")
+	must.NoError(t, os.MkdirAll(dst, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dst, "existing.txt"), []byte("existing"), 0o644))
+
+	must.False(t, isOverlayMounted(dst))
+	must.NoError(t, mountOverlay(cached, allocDir, dst))
+	t.Cleanup(func() { _ = unix.Unmount(dst, 0) })
+	must.True(t, isOverlayMounted(dst))
+
+	// the cached content is layered over the existing content
+	b, err := os.ReadFile(filepath.Join(dst, "base.txt"))
+	must.NoError(t, err)
+	must.Eq(t, "base", string(b))
+	b, err = os.ReadFile(filepath.Join(dst, "existing.txt"))
+	must.NoError(t, err)
+	must.Eq(t, "existing", string(b))
+
+	// and cannot be modified through the overlay
+	err = os.WriteFile(filepath.Join(dst, "base.txt"), []byte("modified"), 0o644)
+	must.ErrorIs(t, err, unix.EROFS)
+
+	// the destination is created if it does not exist
+	created := filepath.Join(allocDir, "task", "local", "new", "dir")
+	must.NoError(t, mountOverlay(cached, allocDir, created))
+	t.Cleanup(func() { _ = unix.Unmount(created, 0) })
+	must.True(t, isOverlayMounted(created))
+
+	must.Error(t, mountOverlay(t.TempDir()+":b", allocDir, filepath.Join(allocDir, "task", "local", "other")))
+	must.ErrorContains(t, mountOverlay(cached, allocDir, t.TempDir()), "not within the allocation directory")
+}
+
+func TestOverlay_mountOverlay_symlink(t *testing.T) {
+	testutil.RequireRoot(t)
+
+	cached := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(cached, "base.txt"), []byte("base"), 0o644))
+
+	// a task plants symlinks to a host directory along the destination
+	host := t.TempDir()
+	allocDir := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(allocDir, "task", "local"), 0o755))
+	must.NoError(t, os.Symlink(host, filepath.Join(allocDir, "task", "local", "base")))
+	must.NoError(t, os.Symlink(host, filepath.Join(allocDir, "task", "secrets")))
+
+	for _, dst := range []string{
+		filepath.Join(allocDir, "task", "local", "base"),
+		filepath.Join(allocDir, "task", "secrets", "base"),
+	} {
+		err := mountOverlay(cached, allocDir, dst)
+		must.ErrorIs(t, err, unix.ELOOP, must.Sprint(dst))
+		must.False(t, isOverlayMounted(host))
+	}
+
+	// nothing was created on the host
+	entries, err := os.ReadDir(host)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+}
+
+func TestSandbox_Get_overlay(t *testing.T) {
+	testutil.RequireRoot(t)
+
+	// the source is unreachable, so the artifact is only gotten if it is
+	// served from the cache
+	newArtifact := func() *structs.TaskArtifact {
+		return &structs.TaskArtifact{
+			GetterSource:  "http://127.0.0.1:0/base.tar.gz",
+			GetterOptions: map[string]string{"checksum": sha256Checksum("base")},
+			RelativeDest:  "local/base",
+			GetterOverlay: true,
+		}
+	}
+
+	// populate caches the artifact as if it had been prefetched
+	populate := func(t *testing.T, sbox *Sandbox, env interfaces.EnvReplacer) {
+		source, err := getURL(env, newArtifact())
+		must.NoError(t, err)
+		key := cacheKey(getChecksum(env, newArtifact()), getMode(newArtifact()), source, false)
+
+		staging, err := sbox.cache.stage()
+		must.NoError(t, err)
+		must.NoError(t, os.Mkdir(filepath.Join(staging, cacheDataName), 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(staging, cacheDataName, "base.txt"), []byte("base"), 0o644))
+		must.NoError(t, sbox.cache.commit(staging, key))
+	}
+
+	t.Run("mounted", func(t *testing.T) {
+		sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+		_, taskDir := SetupDir(t)
+		env := noopTaskEnv(taskDir)
+		populate(t, sbox, env)
+
+		dst := filepath.Join(taskDir, "local", "base")
+		_, err := sbox.Get(env, newArtifact(), "nobody")
+		must.NoError(t, err)
+		t.Cleanup(func() { _ = unix.Unmount(dst, 0) })
+		must.True(t, isOverlayMounted(dst))
+
+		b, err := os.ReadFile(filepath.Join(dst, "base.txt"))
+		must.NoError(t, err)
+		must.Eq(t, "base", string(b))
+
+		// getting the artifact again leaves the overlay in place
+		_, err = sbox.Get(env, newArtifact(), "nobody")
+		must.NoError(t, err)
+	})
+
+	t.Run("no checksum", func(t *testing.T) {
+		sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+		_, taskDir := SetupDir(t)
+
+		artifact := newArtifact()
+		artifact.GetterOptions = nil
+		_, err := sbox.Get(noopTaskEnv(taskDir), artifact, "nobody")
+		must.ErrorContains(t, err, "artifact must specify a checksum to be mounted as an overlay")
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+
+	"github.com/hashicorp/nomad/helper/users"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// getOwner resolves the owner and group of artifact, which are either names or
+// numeric ids, to the numeric uid and gid it is chowned to. The gid defaults to
+// the primary group of the owner, if the owner has a user entry on the client.
+// Both are empty if the artifact is chowned to the task user.
+func getOwner(artifact *structs.TaskArtifact) (string, string, error) {
+	// artifacts are never chowned on windows
+	if !artifact.Chown || runtime.GOOS == "windows" {
+		return "", "", nil
+	}
+
+	var uid, gid string
+	if owner := artifact.Owner; owner != "" {
+		if isNumericID(owner) {
+			uid = owner
+			if u, err := users.LookupID(owner); err == nil {
+				gid = u.Gid
+			}
+		} else {
+			u, err := users.Lookup(owner)
+			if err != nil {
+				return "", "", &Error{
+					URL:         artifact.GetterSource,
+					Err:         fmt.Errorf("failed to resolve artifact owner %q: %w", owner, err),
+					Recoverable: false,
+				}
+			}
+			uid, gid = u.Uid, u.Gid
+		}
+	}
+
+	if group := artifact.Group; group != "" {
+		if isNumericID(group) {
+			gid = group
+		} else {
+			g, err := users.LookupGroup(group)
+			if err != nil {
+				return "", "", &Error{
+					URL:         artifact.GetterSource,
+					Err:         fmt.Errorf("failed to resolve artifact group %q: %w", group, err),
+					Recoverable: false,
+				}
+			}
+			gid = g.Gid
+		}
+	}
+
+	return uid, gid, nil
+}
+
+// isNumericID returns whether id is a numeric uid or gid rather than a name.
+func isNumericID(id string) bool {
+	_, err := strconv.ParseUint(id, 10, 32)
+	return err == nil
+}
+
+// chownIDs returns the uid and gid an artifact is chowned to, which are those
+// of the task user username unless overridden by the uid and gid its owner and
+// group resolved to. Either is -1 if it is left unchanged.
+func chownIDs(username, uid, gid string) (int, int, error) {
+	userUID, userGID := -1, -1
+	if username != "" && (uid == "" || gid == "") {
+		var err error
+		if userUID, userGID, _, err = users.LookupUnix(username); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	resolve := func(id string, fallback int) (int, error) {
+		if id == "" {
+			return fallback, nil
+		}
+		n, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing id %q: %w", id, err)
+		}
+		return int(n), nil
+	}
+
+	ownerUID, err := resolve(uid, userUID)
+	if err != nil {
+		return 0, 0, err
+	}
+	ownerGID, err := resolve(gid, userGID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return ownerUID, ownerGID, nil
+}
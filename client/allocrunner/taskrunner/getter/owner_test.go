@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package getter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestGetOwner(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		artifact *structs.TaskArtifact
+		expUID   string
+		expGID   string
+		expErr   string
+	}{
+		{
+			name:     "task user",
+			artifact: &structs.TaskArtifact{Chown: true},
+		},
+		{
+			name:     "not chowned",
+			artifact: &structs.TaskArtifact{Owner: "root", Group: "root"},
+		},
+		{
+			name:     "owner name",
+			artifact: &structs.TaskArtifact{Chown: true, Owner: "nobody"},
+			expUID:   "65534",
+			expGID:   "65534",
+		},
+		{
+			name:     "owner id",
+			artifact: &structs.TaskArtifact{Chown: true, Owner: "0"},
+			expUID:   "0",
+			expGID:   "0",
+		},
+		{
+			name:     "owner id without user",
+			artifact: &structs.TaskArtifact{Chown: true, Owner: "4242"},
+			expUID:   "4242",
+		},
+		{
+			name:     "owner and group",
+			artifact: &structs.TaskArtifact{Chown: true, Owner: "nobody", Group: "root"},
+			expUID:   "65534",
+			expGID:   "0",
+		},
+		{
+			name:     "group id",
+			artifact: &structs.TaskArtifact{Chown: true, Group: "4242"},
+			expGID:   "4242",
+		},
+		{
+			name:     "unknown owner",
+			artifact: &structs.TaskArtifact{Chown: true, Owner: "doesnotexist"},
+			expErr:   `failed to resolve artifact owner "doesnotexist"`,
+		},
+		{
+			name:     "unknown group",
+			artifact: &structs.TaskArtifact{Chown: true, Group: "doesnotexist"},
+			expErr:   `failed to resolve artifact group "doesnotexist"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uid, gid, err := getOwner(tc.artifact)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+
+				var getterErr *Error
+				must.True(t, errors.As(err, &getterErr))
+				must.False(t, getterErr.IsRecoverable())
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expUID, uid)
+			must.Eq(t, tc.expGID, gid)
+		})
+	}
+}
+
+func TestChownIDs(t *testing.T) {
+	ci.Parallel(t)
+
+	uid, gid, err := chownIDs("nobody", "", "")
+	must.NoError(t, err)
+	must.Eq(t, 65534, uid)
+	must.Eq(t, 65534, gid)
+
+	// the group of the task user is kept for owners without a user entry
+	uid, gid, err = chownIDs("nobody", "4242", "")
+	must.NoError(t, err)
+	must.Eq(t, 4242, uid)
+	must.Eq(t, 65534, gid)
+
+	// the task user need not exist when both are overridden
+	uid, gid, err = chownIDs("doesnotexist", "4242", "4343")
+	must.NoError(t, err)
+	must.Eq(t, 4242, uid)
+	must.Eq(t, 4343, gid)
+
+	// the group alone is left unchanged without a task user
+	uid, gid, err = chownIDs("", "", "4343")
+	must.NoError(t, err)
+	must.Eq(t, -1, uid)
+	must.Eq(t, 4343, gid)
+
+	_, _, err = chownIDs("doesnotexist", "", "")
+	must.Error(t, err)
+}
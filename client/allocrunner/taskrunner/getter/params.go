@@ -7,13 +7,15 @@ import (
 	"context"
 	"encoding/json"
 	"io"
-	"io/fs"
 	"maps"
+	"net"
+	"net/http"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/helper"
 )
 
@@ -23,18 +25,25 @@ import (
 // e.g. https://www.opencve.io/cve/CVE-2022-41716
 type parameters struct {
 	// Config
-	HTTPReadTimeout               time.Duration `json:"http_read_timeout"`
-	HTTPMaxBytes                  int64         `json:"http_max_bytes"`
-	GCSTimeout                    time.Duration `json:"gcs_timeout"`
-	GitTimeout                    time.Duration `json:"git_timeout"`
-	HgTimeout                     time.Duration `json:"hg_timeout"`
-	S3Timeout                     time.Duration `json:"s3_timeout"`
-	DecompressionLimitFileCount   int           `json:"decompression_limit_file_count"`
-	DecompressionLimitSize        int64         `json:"decompression_limit_size"`
-	DisableArtifactInspection     bool          `json:"disable_artifact_inspection"`
-	DisableFilesystemIsolation    bool          `json:"disable_filesystem_isolation"`
-	FilesystemIsolationExtraPaths []string      `json:"filesystem_isolation_extra_paths"`
-	SetEnvironmentVariables       string        `json:"set_environment_variables"`
+	HTTPReadTimeout               time.Duration     `json:"http_read_timeout"`
+	HTTPMaxBytes                  int64             `json:"http_max_bytes"`
+	GCSTimeout                    time.Duration     `json:"gcs_timeout"`
+	GitTimeout                    time.Duration     `json:"git_timeout"`
+	HgTimeout                     time.Duration     `json:"hg_timeout"`
+	S3Timeout                     time.Duration     `json:"s3_timeout"`
+	DecompressionLimitFileCount   int               `json:"decompression_limit_file_count"`
+	DecompressionLimitSize        int64             `json:"decompression_limit_size"`
+	DecompressionMinSize          int64             `json:"decompression_min_size"`
+	MaxFilesPerDir                int               `json:"max_files_per_dir"`
+	DecompressorOverrides         map[string]string `json:"decompressor_overrides"`
+	DisableArtifactInspection     bool              `json:"disable_artifact_inspection"`
+	DisableFilesystemIsolation    bool              `json:"disable_filesystem_isolation"`
+	FilesystemIsolationExtraPaths []string          `json:"filesystem_isolation_extra_paths"`
+	SetEnvironmentVariables       string            `json:"set_environment_variables"`
+	HTTPSizePreflight             bool              `json:"http_size_preflight"`
+	MinTLSVersion                 uint16            `json:"min_tls_version"`
+	DisallowSymlinks              string            `json:"disallow_symlinks"`
+	StripSetuid                   bool              `json:"strip_setuid"`
 
 	// Artifact
 	Mode        getter.ClientMode   `json:"artifact_mode"`
@@ -42,12 +51,100 @@ type parameters struct {
 	Source      string              `json:"artifact_source"`
 	Destination string              `json:"artifact_destination"`
 	Headers     map[string][]string `json:"artifact_headers"`
+	KeepArchive bool                `json:"artifact_keep_archive"`
+
+	// MaxParallel is the maximum number of chunks of the artifact, such as
+	// the parts listed by a manifest, downloaded at the same time.
+	MaxParallel int `json:"artifact_max_parallel"`
+
+	// ExpectContentType is the media type http responses for the artifact
+	// must be served with, if set.
+	ExpectContentType string `json:"artifact_expect_content_type"`
+
+	// UnixSocket is the path of the Unix domain socket that http requests
+	// are sent over instead of connecting to the host of Source.
+	UnixSocket string `json:"unix_socket"`
+
+	// ClientCert and ClientKey are the PEM encoded client certificate and
+	// private key presented to http servers requesting one. They are only
+	// passed to the getter sub-process over standard IO.
+	ClientCert string `json:"client_cert"`
+	ClientKey  string `json:"client_key"`
+
+	// AWSAccessKeyID, AWSSecretAccessKey and AWSSessionToken are the AWS
+	// credentials s3 requests are signed with, in place of those of the
+	// client. They are only passed to the getter sub-process over standard
+	// IO.
+	AWSAccessKeyID     string `json:"aws_access_key_id"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key"`
+	AWSSessionToken    string `json:"aws_session_token"`
+
+	// CertPin is the SHA-256 fingerprint the certificate presented by https
+	// servers must match, in place of verifying the certificate chain.
+	CertPin string `json:"cert_pin"`
+
+	// Proxy is the URL of the SOCKS5 proxy http(s) requests and git
+	// commands connect through, if any. It may include the credentials of
+	// the proxy, and is only passed to the getter sub-process over standard
+	// IO.
+	Proxy string `json:"proxy"`
+
+	// SSH is how git and hg commands connect to ssh remotes, if configured
+	// by the artifact.
+	SSH *sshConfig `json:"ssh"`
+
+	// PreAuth is the login request sent before downloading the artifact, if
+	// any, whose cookies are sent with the artifact requests.
+	PreAuth *preAuth `json:"pre_auth"`
+
+	// PostCmd is the command run once the artifact is in place, if any.
+	PostCmd *postCmd `json:"post_cmd"`
+
+	// Negotiate is the Kerberos configuration http requests are
+	// authenticated with using SPNEGO, if any. It is only passed to the
+	// getter sub-process over standard IO.
+	Negotiate *negotiate `json:"negotiate"`
+
+	// PartPolicy is the client's artifact policy the parts listed by a
+	// manifest are evaluated against before they are fetched, if any.
+	PartPolicy *partPolicy `json:"part_policy"`
+
+	// jar holds the cookies set in response to PreAuth, once it is sent by
+	// the getter sub-process
+	jar http.CookieJar
+
+	// negotiator authenticates http requests once the getter sub-process has
+	// obtained the Kerberos credentials of Negotiate
+	negotiator *negotiateTransport
+
+	// resolver resolves the hosts of http requests, if other than the
+	// default resolver
+	resolver *net.Resolver
+
+	// stats counts the http requests of the download in the getter
+	// sub-process
+	stats *fetchStats
+
+	// result is the fetch result the Nomad client adds the statistics of
+	// each getter sub-process run for the artifact to, if any
+	result *interfaces.FetchResult
+
+	// CacheSource is the path of a cache entry to restore the artifact from,
+	// in place of downloading it from Source.
+	CacheSource string `json:"cache_source"`
 
 	// Task Filesystem
-	AllocDir string `json:"alloc_dir"`
-	TaskDir  string `json:"task_dir"`
-	User     string `json:"user"`
-	Chown    bool   `json:"chown"`
+	AllocDir  string `json:"alloc_dir"`
+	TaskDir   string `json:"task_dir"`
+	User      string `json:"user"`
+	Chown     bool   `json:"chown"`
+	ChownMode string `json:"chown_mode"`
+
+	// Owner and Group are the numeric uid and gid the artifact owner and
+	// group resolved to, which the artifact is chowned to in place of the
+	// task user, if set.
+	Owner string `json:"owner"`
+	Group string `json:"group"`
 }
 
 func (p *parameters) reader() io.Reader {
@@ -101,6 +198,12 @@ func (p *parameters) Equal(o *parameters) bool {
 		return false
 	case p.DecompressionLimitSize != o.DecompressionLimitSize:
 		return false
+	case p.DecompressionMinSize != o.DecompressionMinSize:
+		return false
+	case p.MaxFilesPerDir != o.MaxFilesPerDir:
+		return false
+	case !maps.Equal(p.DecompressorOverrides, o.DecompressorOverrides):
+		return false
 	case p.DisableArtifactInspection != o.DisableArtifactInspection:
 		return false
 	case p.DisableFilesystemIsolation != o.DisableFilesystemIsolation:
@@ -109,14 +212,54 @@ func (p *parameters) Equal(o *parameters) bool {
 		return false
 	case p.SetEnvironmentVariables != o.SetEnvironmentVariables:
 		return false
+	case p.HTTPSizePreflight != o.HTTPSizePreflight:
+		return false
+	case p.MinTLSVersion != o.MinTLSVersion:
+		return false
+	case p.DisallowSymlinks != o.DisallowSymlinks:
+		return false
+	case p.StripSetuid != o.StripSetuid:
+		return false
 	case p.Mode != o.Mode:
 		return false
 	case p.Insecure != o.Insecure:
 		return false
+	case p.KeepArchive != o.KeepArchive:
+		return false
+	case p.MaxParallel != o.MaxParallel:
+		return false
 	case p.Source != o.Source:
 		return false
 	case p.Destination != o.Destination:
 		return false
+	case p.UnixSocket != o.UnixSocket:
+		return false
+	case p.ClientCert != o.ClientCert:
+		return false
+	case p.ClientKey != o.ClientKey:
+		return false
+	case p.AWSAccessKeyID != o.AWSAccessKeyID:
+		return false
+	case p.AWSSecretAccessKey != o.AWSSecretAccessKey:
+		return false
+	case p.AWSSessionToken != o.AWSSessionToken:
+		return false
+	case p.CertPin != o.CertPin:
+		return false
+	case p.Proxy != o.Proxy:
+		return false
+	case !p.SSH.Equal(o.SSH):
+		return false
+	case !p.PreAuth.Equal(o.PreAuth):
+		return false
+	case !p.PostCmd.Equal(o.PostCmd):
+		return false
+	case !p.Negotiate.Equal(o.Negotiate):
+		return false
+	case !p.PartPolicy.Equal(o.PartPolicy):
+		return false
+	case p.CacheSource != o.CacheSource:
+		return false
 	case p.TaskDir != o.TaskDir:
 		return false
 	case !maps.EqualFunc(p.Headers, o.Headers, headersCompareFn):
@@ -135,10 +278,10 @@ func headersCompareFn(a []string, b []string) bool {
 const (
 	// stop privilege escalation via setuid/setgid
 	// https://github.com/hashicorp/nomad/issues/6176
-	umask = fs.ModeSetuid | fs.ModeSetgid
+	umask = specialModeBits
 )
 
-func (p *parameters) client(ctx context.Context) *getter.Client {
+func (p *parameters) client(ctx context.Context) (*getter.Client, error) {
 	httpGetter := &getter.HttpGetter{
 		Netrc:  true,
 		Header: p.Headers,
@@ -155,17 +298,79 @@ func (p *parameters) client(ctx context.Context) *getter.Client {
 		// Read timeout for HTTP operations. Must be long enough to
 		// accommodate large/slow downloads.
 		ReadTimeout: p.HTTPReadTimeout,
+	}
+
+	// send requests over the Unix domain socket, if there is one, present
+	// the client certificate, if there is one, verify the server certificate
+	// against the pin, if there is one, connect through the proxy, if there
+	// is one, send the login cookies, if any, and authenticate with
+	// Kerberos, if configured to
+	if p.UnixSocket != "" || p.ClientCert != "" || p.CertPin != "" || p.Proxy != "" || p.jar != nil || p.negotiator != nil || p.HTTPMaxBytes > 0 || p.ExpectContentType != "" || p.stats != nil || p.MinTLSVersion != 0 {
+		httpGetter.Client = p.httpClient()
+	}
+
+	// Maximum download size. Must be large enough to accommodate large
+	// downloads. Enforced by the transport rather than go-getter, which
+	// silently truncates downloads exceeding it.
+	if p.HTTPMaxBytes > 0 {
+		httpGetter.Client.Transport = &maxBytesTransport{
+			RoundTripper: httpGetter.Client.Transport,
+			limit:        p.HTTPMaxBytes,
+		}
+	}
 
-		// Maximum download size. Must be large enough to accommodate
-		// large downloads.
-		MaxBytes: p.HTTPMaxBytes,
+	// Reject content other than the expected type, such as an HTML error
+	// page, before it is saved as the artifact.
+	if p.ExpectContentType != "" {
+		httpGetter.Client.Transport = &contentTypeTransport{
+			RoundTripper: httpGetter.Client.Transport,
+			expected:     p.ExpectContentType,
+		}
 	}
 
-	// setup custom decompressors with file count and total size limits
-	decompressors := getter.LimitedDecompressors(
-		p.DecompressionLimitFileCount,
-		p.DecompressionLimitSize,
-	)
+	// Count the bytes and redirects of the download for the fetch result.
+	if p.stats != nil {
+		httpGetter.Client.Transport = &statsTransport{
+			RoundTripper: httpGetter.Client.Transport,
+			stats:        p.stats,
+		}
+	}
+
+	// setup the decompressor of each extension with file count and total
+	// size limits, preferring those which write the holes of sparse tar
+	// entries as sparse regions unless configured otherwise
+	decompressors, err := defaultDecompressorRegistry().decompressors(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// refuse archives whose paths collide on case-insensitive filesystems
+	decompressors = detectCaseCollisions(decompressors)
+
+	// refuse archives with a directory holding too many entries
+	decompressors = limitFilesPerDir(decompressors, p.MaxFilesPerDir)
+
+	// skip or refuse the symlinks of archives if they are disallowed
+	decompressors = disallowSymlinks(decompressors, p.DisallowSymlinks)
+
+	// refuse archives with setuid, setgid, or sticky entries unless their
+	// bits are stripped
+	if !p.StripSetuid {
+		decompressors = rejectSetuid(decompressors)
+	}
+
+	// remove partially extracted content of truncated archives
+	decompressors = detectTruncation(decompressors)
+
+	// retain the downloaded archive once it has been extracted
+	if p.KeepArchive {
+		decompressors = keepArchive(decompressors, p)
+	}
+
+	// store archives below the minimum size as downloaded
+	if p.DecompressionMinSize > 0 {
+		decompressors = skipSmallArchives(decompressors, p, p.DecompressionMinSize)
+	}
 
 	return &getter.Client{
 		Ctx:             ctx,
@@ -177,8 +382,10 @@ func (p *parameters) client(ctx context.Context) *getter.Client {
 		DisableSymlinks: true,
 		Decompressors:   decompressors,
 		Getters: map[string]getter.Getter{
-			"git": &getter.GitGetter{
-				Timeout: p.GitTimeout,
+			"git": &sparseGitGetter{
+				GitGetter: &getter.GitGetter{
+					Timeout: p.GitTimeout,
+				},
 			},
 			"hg": &getter.HgGetter{
 				Timeout: p.HgTimeout,
@@ -186,11 +393,20 @@ func (p *parameters) client(ctx context.Context) *getter.Client {
 			"gcs": &getter.GCSGetter{
 				Timeout: p.GCSTimeout,
 			},
-			"s3": &getter.S3Getter{
-				Timeout: p.S3Timeout,
+			"s3": &s3Getter{
+				S3Getter: &getter.S3Getter{
+					Timeout: p.S3Timeout,
+				},
+				maxParallel: p.MaxParallel,
 			},
 			"http":  httpGetter,
 			"https": httpGetter,
+			"manifest": &manifestGetter{
+				http:        httpGetter,
+				maxBytes:    p.HTTPMaxBytes,
+				maxParallel: p.MaxParallel,
+				policy:      p.PartPolicy,
+			},
 		},
-	}
+	}, nil
 }
@@ -4,8 +4,13 @@
 package getter
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -24,6 +29,9 @@ const paramsAsJSON = `
   "s3_timeout": 5000000000,
   "decompression_limit_file_count": 3,
   "decompression_limit_size": 98765,
+  "decompression_min_size": 0,
+  "max_files_per_dir": 1000,
+  "decompressor_overrides": {"tar": "go-getter"},
   "disable_artifact_inspection": false,
   "disable_filesystem_isolation": true,
   "filesystem_isolation_extra_paths": [
@@ -32,6 +40,10 @@ const paramsAsJSON = `
     "d:r:/tmp/stash"
   ],
   "set_environment_variables": "",
+  "http_size_preflight": true,
+  "min_tls_version": 771,
+  "disallow_symlinks": "skip",
+  "strip_setuid": true,
   "artifact_mode": 2,
   "artifact_insecure": false,
   "artifact_source": "https://example.com/file.txt",
@@ -39,9 +51,29 @@ const paramsAsJSON = `
   "artifact_headers": {
     "X-Nomad-Artifact": ["hi"]
   },
+  "artifact_keep_archive": false,
+  "artifact_max_parallel": 2,
+  "artifact_expect_content_type": "",
+  "unix_socket": "/run/artifacts.sock",
+  "client_cert": "",
+  "client_key": "",
+  "aws_access_key_id": "",
+  "aws_secret_access_key": "",
+  "aws_session_token": "",
+  "cert_pin": "",
+  "proxy": "",
+  "ssh": null,
+  "pre_auth": null,
+  "post_cmd": null,
+  "negotiate": null,
+  "part_policy": null,
+  "cache_source": "",
   "alloc_dir": "/path/to/alloc",
   "task_dir": "/path/to/alloc/task",
   "chown": true,
+  "chown_mode": "top",
+  "owner": "",
+  "group": "",
   "user":"nobody"
 }`
 
@@ -54,22 +86,31 @@ var paramsAsStruct = &parameters{
 	S3Timeout:                   5 * time.Second,
 	DecompressionLimitFileCount: 3,
 	DecompressionLimitSize:      98765,
+	MaxFilesPerDir:              1000,
+	DecompressorOverrides:       map[string]string{"tar": "go-getter"},
 	DisableFilesystemIsolation:  true,
 	FilesystemIsolationExtraPaths: []string{
 		"f:r:/dev/urandom",
 		"d:rx:/opt/bin",
 		"d:r:/tmp/stash",
 	},
-	Mode:        getter.ClientModeFile,
-	Source:      "https://example.com/file.txt",
-	Destination: "local/out.txt",
-	AllocDir:    "/path/to/alloc",
-	TaskDir:     "/path/to/alloc/task",
+	HTTPSizePreflight: true,
+	MinTLSVersion:     tls.VersionTLS12,
+	DisallowSymlinks:  "skip",
+	StripSetuid:       true,
+	Mode:              getter.ClientModeFile,
+	Source:            "https://example.com/file.txt",
+	Destination:       "local/out.txt",
+	AllocDir:          "/path/to/alloc",
+	TaskDir:           "/path/to/alloc/task",
 	Headers: map[string][]string{
 		"X-Nomad-Artifact": {"hi"},
 	},
-	User:  "nobody",
-	Chown: true,
+	MaxParallel: 2,
+	UnixSocket:  "/run/artifacts.sock",
+	User:        "nobody",
+	Chown:       true,
+	ChownMode:   "top",
 }
 
 func TestParameters_reader(t *testing.T) {
@@ -109,8 +150,8 @@ func TestParameters_deadline(t *testing.T) {
 
 func TestParameters_client(t *testing.T) {
 	ctx := context.Background()
-	c := paramsAsStruct.client(ctx)
-	must.NotNil(t, c)
+	c, err := paramsAsStruct.client(ctx)
+	must.NoError(t, err)
 
 	// security options
 	must.False(t, c.Insecure)
@@ -121,14 +162,39 @@ func TestParameters_client(t *testing.T) {
 	must.Eq(t, "https://example.com/file.txt", c.Src)
 	must.Eq(t, "local/out.txt", c.Dst)
 
+	// decompressors are wrapped to detect truncated archives, and those of
+	// archives to skip symlinks, to limit the entries of each directory and
+	// to detect paths colliding in case
+	decompressor := func(ext string) getter.Decompressor {
+		d, ok := c.Decompressors[ext].(*truncationDecompressor)
+		must.True(t, ok)
+		if sl, ok := d.Decompressor.(*symlinkDecompressor); ok {
+			must.Eq(t, ext, sl.ext)
+			must.Eq(t, symlinksSkip, sl.action)
+			dl, ok := sl.Decompressor.(*dirLimitDecompressor)
+			must.True(t, ok)
+			must.Eq(t, ext, dl.ext)
+			must.Eq(t, 1000, dl.limit)
+			cc, ok := dl.Decompressor.(*caseCollisionDecompressor)
+			must.True(t, ok)
+			must.Eq(t, ext, cc.ext)
+			return cc.Decompressor
+		}
+		return d.Decompressor
+	}
+
 	// decompression limits
 	const fileCountLimit = 3
 	const fileSizeLimit = 98765
-	must.Eq(t, fileSizeLimit, c.Decompressors["zip"].(*getter.ZipDecompressor).FileSizeLimit)
-	must.Eq(t, fileCountLimit, c.Decompressors["zip"].(*getter.ZipDecompressor).FilesLimit)
-	must.Eq(t, fileSizeLimit, c.Decompressors["tar.gz"].(*getter.TarGzipDecompressor).FileSizeLimit)
-	must.Eq(t, fileCountLimit, c.Decompressors["tar.gz"].(*getter.TarGzipDecompressor).FilesLimit)
-	must.Eq(t, fileSizeLimit, c.Decompressors["xz"].(*getter.XzDecompressor).FileSizeLimit)
+	must.Eq(t, fileSizeLimit, decompressor("zip").(*getter.ZipDecompressor).FileSizeLimit)
+	must.Eq(t, fileCountLimit, decompressor("zip").(*getter.ZipDecompressor).FilesLimit)
+	must.Eq(t, fileSizeLimit, decompressor("tar.gz").(*sparseTarDecompressor).FileSizeLimit)
+	must.Eq(t, fileCountLimit, decompressor("tar.gz").(*sparseTarDecompressor).FilesLimit)
+	must.Eq(t, "gzip", decompressor("tar.gz").(*sparseTarDecompressor).compression)
+
+	// decompressor overrides
+	must.Eq(t, fileSizeLimit, decompressor("tar").(*getter.TarDecompressor).FileSizeLimit)
+	must.Eq(t, fileSizeLimit, decompressor("xz").(*getter.XzDecompressor).FileSizeLimit)
 	// xz does not support files count limit
 }
 
@@ -162,3 +228,30 @@ func TestParameters_Equal_headers(t *testing.T) {
 	p2.Headers["East"] = []string{"New York"}
 	must.NotEqual(t, p1, p2)
 }
+
+// TestParameters_client_gzipMultistream asserts that gzip files made of
+// multiple concatenated members, as written by parallel gzip tools, are
+// decompressed in full rather than stopping after the first member.
+func TestParameters_client_gzipMultistream(t *testing.T) {
+	c, err := paramsAsStruct.client(context.Background())
+	must.NoError(t, err)
+
+	var buf bytes.Buffer
+	for _, member := range []string{"hello ", "multistream ", "world"} {
+		zw := gzip.NewWriter(&buf)
+		_, err := zw.Write([]byte(member))
+		must.NoError(t, err)
+		must.NoError(t, zw.Close())
+	}
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "file.gz")
+	dst := filepath.Join(dir, "file")
+	must.NoError(t, os.WriteFile(src, buf.Bytes(), 0o644))
+
+	must.NoError(t, c.Decompressors["gz"].Decompress(dst, src, false, umask))
+
+	b, err := os.ReadFile(dst)
+	must.NoError(t, err)
+	must.Eq(t, "hello multistream world", string(b))
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Types of the tar entries which hold metadata about the archive or about the
+// entry which follows them, rather than files to extract.
+const (
+	// typeSolarisXHeader is the type of the PAX extended headers written by
+	// Solaris tar, which archive/tar does not interpret and returns as entries
+	// named like "PaxHeaders.1234/file".
+	typeSolarisXHeader = 'X'
+
+	// typeSolarisACL and typeSolarisXattr are the types of the ACLs and
+	// extended attributes of the entry which follows them, written by Solaris
+	// tar.
+	typeSolarisACL   = 'A'
+	typeSolarisXattr = 'E'
+
+	// typeGNUVolume is the type of the volume label written by GNU tar.
+	typeGNUVolume = 'V'
+)
+
+// maxPAXHeaderSize is the maximum size of the extended headers interpreted by
+// tarEntryReader, the same as the one enforced by archive/tar.
+const maxPAXHeaderSize = 1 << 20
+
+// tarEntryReader reads the entries of a tarball to extract. It skips the
+// metadata pseudo-entries archive/tar returns, rather than letting them be
+// extracted as files, and applies the long names and link names of the PAX
+// extended headers archive/tar does not interpret.
+type tarEntryReader struct {
+	*tar.Reader
+}
+
+func newTarEntryReader(r io.Reader) *tarEntryReader {
+	return &tarEntryReader{Reader: tar.NewReader(r)}
+}
+
+// Next advances to the next entry to extract and returns its header.
+//
+// The path and linkpath records of PAX extended headers are already applied by
+// archive/tar, except for those of the headers written by Solaris tar, which
+// are applied here to the entry which follows them.
+func (r *tarEntryReader) Next() (*tar.Header, error) {
+	var records map[string]string
+	for {
+		hdr, err := r.Reader.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		switch hdr.Typeflag {
+		case typeSolarisXHeader:
+			if records, err = readPAXRecords(r.Reader, hdr.Size); err != nil {
+				return nil, fmt.Errorf("invalid extended header %q: %w", hdr.Name, err)
+			}
+			continue
+		case tar.TypeXHeader, tar.TypeXGlobalHeader, typeSolarisACL, typeSolarisXattr, typeGNUVolume:
+			continue
+		}
+
+		if path, ok := records["path"]; ok {
+			hdr.Name = path
+		}
+		if linkpath, ok := records["linkpath"]; ok {
+			hdr.Linkname = linkpath
+		}
+		return hdr, nil
+	}
+}
+
+// readPAXRecords reads the size bytes of the records of a PAX extended header
+// from r. Each record is of the form "%d %s=%s\n", where the leading decimal
+// is the length of the whole record.
+func readPAXRecords(r io.Reader, size int64) (map[string]string, error) {
+	if size > maxPAXHeaderSize {
+		return nil, fmt.Errorf("size of %d bytes exceeds the maximum of %d bytes", size, maxPAXHeaderSize)
+	}
+	b, err := io.ReadAll(io.LimitReader(r, size))
+	if err != nil {
+		return nil, err
+	}
+
+	errMalformed := errors.New("malformed record")
+	records := make(map[string]string)
+	for s := string(b); len(s) > 0; {
+		length, _, ok := strings.Cut(s, " ")
+		n, err := strconv.Atoi(length)
+		if !ok || err != nil || n <= len(length)+1 || n > len(s) {
+			return nil, errMalformed
+		}
+
+		record, ok := strings.CutSuffix(s[len(length)+1:n], "\n")
+		if !ok {
+			return nil, errMalformed
+		}
+		key, value, ok := strings.Cut(record, "=")
+		if !ok || key == "" {
+			return nil, errMalformed
+		}
+		records[key] = value
+		s = s[n:]
+	}
+	return records, nil
+}
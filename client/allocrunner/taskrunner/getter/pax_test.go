@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// paxTarball returns a tarball whose entries have paths longer than the 100
+// characters of a ustar header, recorded in the extended headers written by
+// archive/tar and by Solaris tar, along with metadata pseudo-entries.
+func paxTarball(t *testing.T, longDir, longName, solarisName string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	write := func(hdr *tar.Header, content string) {
+		must.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(content))
+		must.NoError(t, err)
+	}
+
+	// global extended header, as written by git archive
+	write(&tar.Header{
+		Typeflag:   tar.TypeXGlobalHeader,
+		Name:       "pax_global_header",
+		PAXRecords: map[string]string{"comment": "a5a2f1b"},
+	}, "")
+
+	// directory and file with long paths in PAX extended headers
+	write(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     longDir + "/",
+		Mode:     0o755,
+		Format:   tar.FormatPAX,
+	}, "")
+	write(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     longName,
+		Mode:     0o644,
+		Size:     4,
+		Format:   tar.FormatPAX,
+	}, "long")
+
+	// Solaris extended header followed by the file it names, whose ustar
+	// header holds a truncated name
+	records := paxRecord("path", solarisName) + paxRecord("mtime", "1700000000")
+	write(&tar.Header{
+		Typeflag: typeSolarisXHeader,
+		Name:     "PaxHeaders.1234/solaris",
+		Size:     int64(len(records)),
+		Format:   tar.FormatUSTAR,
+	}, records)
+	write(&tar.Header{
+		Typeflag: typeSolarisACL,
+		Name:     "solaris",
+		Size:     5,
+		Format:   tar.FormatUSTAR,
+	}, "A:rwx")
+	write(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     "solaris",
+		Mode:     0o644,
+		Size:     7,
+		Format:   tar.FormatUSTAR,
+	}, "solaris")
+
+	must.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestPAX_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	longDir := strings.Repeat("d", 80) + "/" + strings.Repeat("e", 80)
+	longName := longDir + "/" + strings.Repeat("f", 120) + ".txt"
+	solarisName := strings.Repeat("s", 60) + "/" + strings.Repeat("t", 110) + ".txt"
+	must.Greater(t, 100, len(longName))
+	must.Greater(t, 100, len(solarisName))
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "pax.tar")
+	must.NoError(t, os.WriteFile(src, paxTarball(t, longDir, longName, solarisName), 0o644))
+
+	t.Run("extract", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "local")
+		d := &sparseTarDecompressor{}
+		must.NoError(t, d.Decompress(dst, src, true, 0))
+
+		b, err := os.ReadFile(filepath.Join(dst, longName))
+		must.NoError(t, err)
+		must.Eq(t, "long", string(b))
+
+		b, err = os.ReadFile(filepath.Join(dst, solarisName))
+		must.NoError(t, err)
+		must.Eq(t, "solaris", string(b))
+
+		// the pseudo-entries are not extracted
+		entries, err := os.ReadDir(dst)
+		must.NoError(t, err)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		must.Eq(t, []string{strings.Repeat("d", 80), strings.Repeat("s", 60)}, names)
+	})
+
+	t.Run("entries", func(t *testing.T) {
+		entries, err := archiveEntries(src, "tar")
+		must.NoError(t, err)
+		must.Eq(t, []archiveEntry{
+			{name: longDir + "/", isDir: true, mode: fs.ModeDir | 0o755},
+			{name: longName, mode: 0o644},
+			{name: solarisName, mode: 0o644},
+		}, entries)
+	})
+}
+
+func TestPAX_readPAXRecords(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		body   string
+		exp    map[string]string
+		expErr string
+	}{
+		{
+			name: "records",
+			body: paxRecord("path", "a/b") + paxRecord("linkpath", "c d=e") + paxRecord("mtime", "1"),
+			exp:  map[string]string{"path": "a/b", "linkpath": "c d=e", "mtime": "1"},
+		},
+		{
+			name: "empty",
+			body: "",
+			exp:  map[string]string{},
+		},
+		{
+			name:   "bad length",
+			body:   "99 path=a\n",
+			expErr: "malformed record",
+		},
+		{
+			name:   "no length",
+			body:   "path=a\n",
+			expErr: "malformed record",
+		},
+		{
+			name:   "no newline",
+			body:   "9 path=ab",
+			expErr: "malformed record",
+		},
+		{
+			name:   "no key",
+			body:   "5 =a\n",
+			expErr: "malformed record",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := readPAXRecords(strings.NewReader(tc.body), int64(len(tc.body)))
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, records)
+		})
+	}
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := readPAXRecords(strings.NewReader(""), maxPAXHeaderSize+1)
+		must.ErrorContains(t, err, "exceeds the maximum")
+	})
+}
@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// policyTimeout is the maximum duration of the evaluation of an artifact
+	// against the client's artifact policy.
+	policyTimeout = 10 * time.Second
+
+	// policyMaxBytes is the maximum size of a policy decision.
+	policyMaxBytes = 1 << 20
+)
+
+// ErrPolicyDenied is returned for artifacts which the client's artifact
+// policy does not allow to be fetched.
+var ErrPolicyDenied = errors.New("artifact denied by policy")
+
+// exitPolicyDenied is the exit code of the getter sub-process when the
+// client's artifact policy does not allow one of the parts listed by a
+// manifest to be fetched, so that ErrPolicyDenied can be returned across the
+// process boundary.
+const exitPolicyDenied = 15
+
+// policyInput is the input document an artifact is evaluated against the
+// policy with.
+type policyInput struct {
+	// Source is the interpolated source of the artifact, without its query
+	// and with its password redacted.
+	Source string `json:"source"`
+
+	// Scheme is the protocol the artifact is fetched with, such as "https"
+	// or "git".
+	Scheme string `json:"scheme"`
+
+	// Host is the host the artifact is fetched from, if any.
+	Host string `json:"host"`
+
+	// Insecure is true if the certificate of the host is not verified.
+	Insecure bool `json:"insecure"`
+
+	// Checksum is the type of the checksum the artifact is verified with,
+	// such as "sha256", or empty if it is not verified.
+	Checksum string `json:"checksum"`
+
+	// Destination is the interpolated destination of the artifact, relative
+	// to the task directory, or empty if the artifact is prefetched.
+	Destination string `json:"destination"`
+
+	// Prefetch is true if the artifact is fetched into the artifact cache
+	// rather than into a task directory.
+	Prefetch bool `json:"prefetch"`
+
+	// Manifest is the source of the manifest listing the artifact as one of
+	// its parts, or empty if the artifact is not a manifest part.
+	Manifest string `json:"manifest"`
+}
+
+// newPolicyInput returns the input document artifact, fetched from source, is
+// evaluated against the policy with.
+func newPolicyInput(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, source string) *policyInput {
+	input := &policyInput{
+		Insecure:    isInsecure(artifact),
+		Destination: env.ReplaceEnv(artifact.RelativeDest),
+	}
+	input.Scheme, input.Host, input.Source = policySource(source)
+	if kind, _, ok := strings.Cut(strings.TrimSpace(getChecksum(env, artifact)), ":"); ok {
+		input.Checksum = strings.ToLower(kind)
+	}
+	return input
+}
+
+// partPolicy is the client's artifact policy the parts listed by a manifest
+// are evaluated against before any of them is fetched by the getter
+// sub-process.
+type partPolicy struct {
+	// URL is the Open Policy Agent decision the parts are evaluated against.
+	URL string `json:"url"`
+
+	// Input is the input document the manifest artifact was evaluated with,
+	// from which the input document of each part is derived.
+	Input *policyInput `json:"input"`
+}
+
+func (p *partPolicy) Equal(o *partPolicy) bool {
+	if p == nil || o == nil {
+		return p == o
+	}
+	if p.Input == nil || o.Input == nil {
+		return p.URL == o.URL && p.Input == o.Input
+	}
+	return p.URL == o.URL && *p.Input == *o.Input
+}
+
+// check evaluates each of parts against the policy, failing closed: no part
+// is fetched unless the policy allows every one of them. It returns an error
+// wrapping ErrPolicyDenied if the policy denies a part.
+func (p *partPolicy) check(parts []*manifestPart) error {
+	if p == nil {
+		return nil
+	}
+	for _, part := range parts {
+		input := *p.Input
+		input.Manifest = p.Input.Source
+		input.Scheme, input.Host = part.url.Scheme, part.url.Hostname()
+		u := *part.url
+		u.RawQuery = ""
+		input.Source = u.Redacted()
+		input.Checksum = ""
+		if kind, _, ok := strings.Cut(part.Checksum, ":"); ok {
+			input.Checksum = strings.ToLower(kind)
+		}
+
+		decision, err := evaluatePolicy(p.URL, &input)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate artifact policy for part %s: %w", input.Source, err)
+		}
+		if !decision.Allow || len(decision.Deny) > 0 {
+			return fmt.Errorf("%w: part %s: %s", ErrPolicyDenied, input.Source, decision.reason())
+		}
+	}
+	return nil
+}
+
+// partPolicy returns the policy the manifest parts of the artifact evaluated
+// with input are checked against, if a policy is configured and the artifact
+// is a manifest.
+func (s *Sandbox) partPolicy(input *policyInput) *partPolicy {
+	policyURL := s.artifactConfig().PolicyURL
+	if policyURL == "" || input.Scheme != "manifest" {
+		return nil
+	}
+	return &partPolicy{URL: policyURL, Input: input}
+}
+
+// policySource returns the scheme and host of source, along with source
+// without its query, which may hold credentials passed as options, and with
+// its password redacted.
+func policySource(source string) (string, string, string) {
+	// the content embedded in data URIs is of no use to the policy
+	if structs.IsDataURI(source) {
+		return "data", "", "data:"
+	}
+
+	detected, err := getter.Detect(source, "", getter.Detectors)
+	if err != nil {
+		return "", "", ""
+	}
+	forced := ""
+	if ms := forcedGetter.FindStringSubmatch(detected); ms != nil {
+		forced, detected = ms[1], ms[2]
+	}
+	u, err := url.Parse(detected)
+	if err != nil {
+		return forced, "", ""
+	}
+	u.RawQuery = ""
+	if forced == "" {
+		return u.Scheme, u.Hostname(), u.Redacted()
+	}
+	return forced, u.Hostname(), forced + "::" + u.Redacted()
+}
+
+// policyDecision is the result of evaluating an artifact against the policy,
+// which is either a boolean or a document with an "allow" boolean and a
+// "deny" list of the reasons the artifact is denied.
+type policyDecision struct {
+	Allow bool     `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+func (d *policyDecision) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &d.Allow); err == nil {
+		return nil
+	}
+	type decision policyDecision
+	return json.Unmarshal(b, (*decision)(d))
+}
+
+// reason returns why the decision denies the artifact.
+func (d *policyDecision) reason() string {
+	if len(d.Deny) == 0 {
+		return "artifact is not allowed"
+	}
+	return strings.Join(d.Deny, "; ")
+}
+
+// checkPolicy evaluates artifact against the client's artifact policy, if one
+// is configured, returning an error wrapping ErrPolicyDenied if the policy does
+// not allow it to be fetched. Artifacts are also denied, with a recoverable
+// error, if the policy cannot be evaluated.
+func (s *Sandbox) checkPolicy(artifact *structs.TaskArtifact, input *policyInput) error {
+	policyURL := s.artifactConfig().PolicyURL
+	if policyURL == "" {
+		return nil
+	}
+
+	decision, err := evaluatePolicy(policyURL, input)
+	if err != nil {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("failed to evaluate artifact policy: %w", err),
+			Recoverable: true,
+		}
+	}
+	if !decision.Allow || len(decision.Deny) > 0 {
+		s.logger.Warn("artifact denied by policy", "source", input.Source, "reason", decision.reason())
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("%w: %s", ErrPolicyDenied, decision.reason()),
+			Recoverable: false,
+		}
+	}
+	return nil
+}
+
+// evaluatePolicy queries the Open Policy Agent decision at policyURL with
+// input, returning an error if the decision is undefined.
+func evaluatePolicy(policyURL string, input *policyInput) (*policyDecision, error) {
+	body, err := json.Marshal(map[string]any{"input": input})
+	if err != nil {
+		return nil, err
+	}
+
+	client := cleanhttp.DefaultClient()
+	client.Timeout = policyTimeout
+	resp, err := client.Post(policyURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code: %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Result *policyDecision `json:"result"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, policyMaxBytes)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode policy decision: %w", err)
+	}
+	if out.Result == nil {
+		return nil, errors.New("policy decision is undefined")
+	}
+	return out.Result, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ErrPostCmdFailed is returned when the post command of an artifact fails.
+// The command runs against the same artifact when it is downloaded again, so
+// it is not worth retrying.
+var ErrPostCmdFailed = errors.New("artifact post_cmd failed")
+
+// exitPostCmdFailed is the exit code of the getter sub-process when the post
+// command of the artifact fails, so that ErrPostCmdFailed can be returned
+// across the process boundary.
+const exitPostCmdFailed = 7
+
+// postCmdDefaultUser is the user the post command runs as when the task sets
+// no user, so that it never runs as the root user of the Nomad client.
+const postCmdDefaultUser = "nobody"
+
+// postCmdMaxOutput is the maximum number of bytes of the output of a failed
+// post command included in its error.
+const postCmdMaxOutput = 4096
+
+// postCmd is the command run by the getter sub-process once the artifact is in
+// place.
+type postCmd struct {
+	// Path is the absolute path of the binary, which is one of the binaries
+	// allowed by the client.
+	Path string   `json:"path"`
+	Args []string `json:"args"`
+}
+
+func (c *postCmd) Equal(o *postCmd) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+	return c.Path == o.Path && slices.Equal(c.Args, o.Args)
+}
+
+// getPostCmd returns the post command of artifact, with its command resolved
+// among the binaries in allowlist and its arguments interpolated, or nil if
+// the artifact does not configure one.
+func getPostCmd(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, allowlist []string) (*postCmd, error) {
+	conf := artifact.GetterPostCmd
+	if conf == nil {
+		return nil, nil
+	}
+
+	path, err := resolvePostCmd(env.ReplaceEnv(conf.Command), allowlist)
+	if err != nil {
+		return nil, &Error{
+			URL:         artifact.GetterSource,
+			Err:         err,
+			Recoverable: false,
+		}
+	}
+
+	cmd := &postCmd{Path: path}
+	for _, arg := range conf.Args {
+		cmd.Args = append(cmd.Args, env.ReplaceEnv(arg))
+	}
+	return cmd, nil
+}
+
+// resolvePostCmd returns the absolute path of command, which must be one of
+// the binaries in allowlist. A command given by name rather than by path
+// resolves to the binary of the same name in allowlist, so that the command
+// does not depend on the PATH of the sandbox.
+func resolvePostCmd(command string, allowlist []string) (string, error) {
+	if len(allowlist) == 0 {
+		return "", errors.New("artifact post_cmd is not allowed by the client, see the post_cmd_allowlist artifact configuration")
+	}
+
+	byName := !strings.ContainsRune(command, filepath.Separator) && !strings.ContainsRune(command, '/')
+	for _, allowed := range allowlist {
+		if command == allowed || (byName && filepath.Base(allowed) == command) {
+			return allowed, nil
+		}
+	}
+	return "", fmt.Errorf("artifact post_cmd %q is not allowed by the client, must be one of: %s",
+		command, strings.Join(allowlist, ", "))
+}
+
+// runPostCmd runs the post command of the artifact, if any, as the user of the
+// task, or as nobody if the task sets no user, with the destination of the
+// artifact as its working directory. The
+// command inherits the environment of the getter sub-process and is bound by
+// the deadline of ctx.
+func (p *parameters) runPostCmd(ctx context.Context) error {
+	if p.PostCmd == nil {
+		return nil
+	}
+
+	dir := p.Destination
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	user := p.User
+	if user == "" {
+		user = postCmdDefaultUser
+	}
+
+	attr, err := postCmdSysProcAttr(user)
+	if err != nil {
+		return fmt.Errorf("%w: failed to run as user %q: %v", ErrPostCmdFailed, user, err)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.PostCmd.Path, p.PostCmd.Args...)
+	cmd.Dir = dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.SysProcAttr = attr
+
+	if err := cmd.Run(); err != nil {
+		out := output.Bytes()
+		if len(out) > postCmdMaxOutput {
+			out = out[len(out)-postCmdMaxOutput:]
+		}
+		return fmt.Errorf("%w: %s: %v: %s", ErrPostCmdFailed, p.PostCmd.Path, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
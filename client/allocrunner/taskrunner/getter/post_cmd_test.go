@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestPostCmd_resolvePostCmd(t *testing.T) {
+	ci.Parallel(t)
+
+	allowlist := []string{"/usr/bin/chmod", "/opt/bin/unpack"}
+
+	cases := []struct {
+		name    string
+		command string
+		allow   []string
+		exp     string
+		expErr  string
+	}{
+		{
+			name:    "by path",
+			command: "/opt/bin/unpack",
+			allow:   allowlist,
+			exp:     "/opt/bin/unpack",
+		},
+		{
+			name:    "by name",
+			command: "chmod",
+			allow:   allowlist,
+			exp:     "/usr/bin/chmod",
+		},
+		{
+			name:    "path not allowed",
+			command: "/bin/chmod",
+			allow:   allowlist,
+			expErr:  `artifact post_cmd "/bin/chmod" is not allowed by the client`,
+		},
+		{
+			name:    "relative path not allowed",
+			command: "bin/unpack",
+			allow:   allowlist,
+			expErr:  "is not allowed by the client",
+		},
+		{
+			name:    "name not allowed",
+			command: "rm",
+			allow:   allowlist,
+			expErr:  "must be one of: /usr/bin/chmod, /opt/bin/unpack",
+		},
+		{
+			name:    "no allowlist",
+			command: "chmod",
+			expErr:  "see the post_cmd_allowlist artifact configuration",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := resolvePostCmd(tc.command, tc.allow)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, path)
+		})
+	}
+}
+
+func TestPostCmd_getPostCmd(t *testing.T) {
+	ci.Parallel(t)
+
+	env := noopTaskEnv("/path/to/task")
+	allowlist := []string{"/usr/bin/chmod"}
+
+	t.Run("none", func(t *testing.T) {
+		cmd, err := getPostCmd(env, &structs.TaskArtifact{GetterSource: "x"}, allowlist)
+		must.NoError(t, err)
+		must.Nil(t, cmd)
+	})
+
+	t.Run("interpolated", func(t *testing.T) {
+		env := varsTaskEnv("/path/to/task", map[string]string{
+			"POST_CMD": "chmod",
+			"APP":      "local/app",
+		})
+		cmd, err := getPostCmd(env, &structs.TaskArtifact{
+			GetterSource: "x",
+			GetterPostCmd: &structs.ArtifactPostCmd{
+				Command: "${POST_CMD}",
+				Args:    []string{"+x", "${APP}"},
+			},
+		}, allowlist)
+		must.NoError(t, err)
+		must.Eq(t, &postCmd{
+			Path: "/usr/bin/chmod",
+			Args: []string{"+x", "local/app"},
+		}, cmd)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		_, err := getPostCmd(env, &structs.TaskArtifact{
+			GetterSource:  "x",
+			GetterPostCmd: &structs.ArtifactPostCmd{Command: "rm"},
+		}, allowlist)
+		must.ErrorContains(t, err, "is not allowed by the client")
+		must.False(t, err.(*Error).IsRecoverable())
+	})
+}
+
+func TestPostCmd_runPostCmd(t *testing.T) {
+	ci.Parallel(t)
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	t.Run("working directory", func(t *testing.T) {
+		dst := postCmdTempDir(t)
+		p := &parameters{
+			Destination: dst,
+			PostCmd: &postCmd{
+				Path: sh,
+				Args: []string{"-c", "echo done > marker"},
+			},
+		}
+		must.NoError(t, p.runPostCmd(context.Background()))
+
+		b, err := os.ReadFile(filepath.Join(dst, "marker"))
+		must.NoError(t, err)
+		must.Eq(t, "done\n", string(b))
+	})
+
+	t.Run("file destination", func(t *testing.T) {
+		dir := postCmdTempDir(t)
+		dst := filepath.Join(dir, "artifact.bin")
+		must.NoError(t, os.WriteFile(dst, []byte("bin"), 0o644))
+		p := &parameters{
+			Destination: dst,
+			PostCmd: &postCmd{
+				Path: sh,
+				Args: []string{"-c", "cp artifact.bin copy.bin"},
+			},
+		}
+		must.NoError(t, p.runPostCmd(context.Background()))
+		must.FileExists(t, filepath.Join(dir, "copy.bin"))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		p := &parameters{
+			Destination: postCmdTempDir(t),
+			PostCmd: &postCmd{
+				Path: sh,
+				Args: []string{"-c", "echo bad checksum >&2; exit 3"},
+			},
+		}
+		err := p.runPostCmd(context.Background())
+		must.ErrorIs(t, err, ErrPostCmdFailed)
+		must.ErrorContains(t, err, "bad checksum")
+	})
+
+	t.Run("none", func(t *testing.T) {
+		p := &parameters{Destination: t.TempDir()}
+		must.NoError(t, p.runPostCmd(context.Background()))
+	})
+
+	t.Run("no task user", func(t *testing.T) {
+		if os.Geteuid() != 0 {
+			t.Skip("post command only changes user when run as root")
+		}
+		nobody, err := user.Lookup(postCmdDefaultUser)
+		if err != nil {
+			t.Skip("nobody user is not available")
+		}
+
+		dst := postCmdTempDir(t)
+		p := &parameters{
+			Destination: dst,
+			PostCmd: &postCmd{
+				Path: sh,
+				Args: []string{"-c", "id -u > uid"},
+			},
+		}
+		must.NoError(t, p.runPostCmd(context.Background()))
+
+		b, err := os.ReadFile(filepath.Join(dst, "uid"))
+		must.NoError(t, err)
+		must.Eq(t, nobody.Uid+"\n", string(b))
+	})
+}
+
+// postCmdTempDir returns a temporary directory the post command can write to,
+// which runs as nobody rather than root when the test runs as root.
+func postCmdTempDir(t *testing.T) string {
+	dir := t.TempDir()
+	if os.Geteuid() == 0 {
+		must.NoError(t, os.Chmod(filepath.Dir(dir), 0o755))
+		must.NoError(t, os.Chmod(dir, 0o777))
+	}
+	return dir
+}
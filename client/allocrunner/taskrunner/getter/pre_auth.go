@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"os"
+	"time"
+
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// preAuthTimeout is the maximum amount of time spent on the login request
+	// made before downloading an artifact.
+	preAuthTimeout = 1 * time.Minute
+
+	// preAuthMaxCredentials is the maximum size of a credentials file sent as
+	// the body of the login request.
+	preAuthMaxCredentials = 64 * 1024
+
+	defaultPreAuthMethod      = http.MethodPost
+	defaultPreAuthContentType = "application/x-www-form-urlencoded"
+)
+
+// preAuth is the login request sent by the getter sub-process before
+// downloading an artifact over http(s).
+type preAuth struct {
+	URL         string `json:"url"`
+	Method      string `json:"method"`
+	ContentType string `json:"content_type"`
+
+	// CredentialsFile is the host path of the file sent as the request body.
+	// It is read by the getter sub-process, after the filesystem has been
+	// isolated, so that it cannot be used to read files outside the task.
+	CredentialsFile string `json:"credentials_file"`
+}
+
+func (a *preAuth) Equal(o *preAuth) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	return *a == *o
+}
+
+// getPreAuth returns the login request of artifact, with its URL interpolated
+// and its credentials file resolved within the task directory, or nil if the
+// artifact does not configure one.
+func getPreAuth(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) (*preAuth, error) {
+	conf := artifact.GetterPreAuth
+	if conf == nil {
+		return nil, nil
+	}
+
+	auth := &preAuth{
+		URL:         env.ReplaceEnv(conf.URL),
+		Method:      conf.Method,
+		ContentType: conf.ContentType,
+	}
+	if auth.Method == "" {
+		auth.Method = defaultPreAuthMethod
+	}
+
+	if conf.CredentialsFile != "" {
+		path, escapes := env.ClientPath(conf.CredentialsFile, true)
+		if escapes {
+			return nil, &Error{
+				URL:         artifact.GetterSource,
+				Err:         fmt.Errorf("artifact pre_auth credentials_file path escapes alloc directory"),
+				Recoverable: false,
+			}
+		}
+		auth.CredentialsFile = path
+		if auth.ContentType == "" {
+			auth.ContentType = defaultPreAuthContentType
+		}
+	}
+	return auth, nil
+}
+
+// httpClient returns the client used for http artifact requests, which sends
+// the cookies set by the login request if there was one, and authenticates
+// with Kerberos if configured to.
+func (p *parameters) httpClient() *http.Client {
+	var transport http.RoundTripper = p.httpTransport()
+	if p.negotiator != nil {
+		transport = p.negotiator
+	}
+	return &http.Client{
+		Transport: transport,
+		Jar:       p.jar,
+	}
+}
+
+// preAuthenticate sends the login request, if there is one, and keeps the
+// cookies set in response to it for the artifact requests which follow.
+func (p *parameters) preAuthenticate(ctx context.Context) error {
+	if p.PreAuth == nil {
+		return nil
+	}
+
+	var body []byte
+	if p.PreAuth.CredentialsFile != "" {
+		f, err := os.Open(p.PreAuth.CredentialsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		body, err = io.ReadAll(io.LimitReader(f, preAuthMaxCredentials+1))
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		if len(body) > preAuthMaxCredentials {
+			return fmt.Errorf("credentials file exceeds the maximum size of %d bytes", preAuthMaxCredentials)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, preAuthTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, p.PreAuth.Method, p.PreAuth.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if p.PreAuth.ContentType != "" {
+		req.Header.Set("Content-Type", p.PreAuth.ContentType)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return err
+	}
+	p.jar = jar
+
+	// redirects are followed, so that cookies set along the way are kept
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, preAuthMaxCredentials))
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("login request failed with status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestPreAuth_getPreAuth(t *testing.T) {
+	ci.Parallel(t)
+
+	env := noopTaskEnv("/path/to/task")
+
+	t.Run("none", func(t *testing.T) {
+		auth, err := getPreAuth(env, &structs.TaskArtifact{})
+		must.NoError(t, err)
+		must.Nil(t, auth)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		auth, err := getPreAuth(env, &structs.TaskArtifact{
+			GetterPreAuth: &structs.ArtifactPreAuth{
+				URL:             "https://example.com/login",
+				CredentialsFile: "secrets/login",
+			},
+		})
+		must.NoError(t, err)
+		must.Eq(t, &preAuth{
+			URL:             "https://example.com/login",
+			Method:          http.MethodPost,
+			ContentType:     "application/x-www-form-urlencoded",
+			CredentialsFile: "/path/to/task/secrets/login",
+		}, auth)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		auth, err := getPreAuth(env, &structs.TaskArtifact{
+			GetterPreAuth: &structs.ArtifactPreAuth{
+				URL:    "https://example.com/login",
+				Method: http.MethodGet,
+			},
+		})
+		must.NoError(t, err)
+		must.Eq(t, &preAuth{
+			URL:    "https://example.com/login",
+			Method: http.MethodGet,
+		}, auth)
+	})
+
+	t.Run("interpolated", func(t *testing.T) {
+		auth, err := getPreAuth(upTaskEnv("/path/to/task"), &structs.TaskArtifact{
+			GetterPreAuth: &structs.ArtifactPreAuth{
+				URL:             "https://example.com/login",
+				CredentialsFile: "secrets/login",
+				ContentType:     "application/json",
+			},
+		})
+		must.NoError(t, err)
+		must.Eq(t, "HTTPS://EXAMPLE.COM/LOGIN", auth.URL)
+		must.Eq(t, "/path/to/task/SECRETS/LOGIN", auth.CredentialsFile)
+		must.Eq(t, "application/json", auth.ContentType)
+	})
+
+	t.Run("escapes", func(t *testing.T) {
+		_, err := getPreAuth(env, &structs.TaskArtifact{
+			GetterPreAuth: &structs.ArtifactPreAuth{
+				URL:             "https://example.com/login",
+				CredentialsFile: "../../../etc/shadow",
+			},
+		})
+		must.ErrorContains(t, err, "credentials_file path escapes alloc directory")
+	})
+}
+
+// preAuthServer returns a server which sets a session cookie in response to a
+// login request with the given credentials, and only serves the artifact to
+// requests with the session cookie.
+func preAuthServer(t *testing.T, credentials string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || string(body) != credentials ||
+			r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t", Path: "/"})
+		http.Redirect(w, r, "/portal", http.StatusFound)
+	})
+	mux.HandleFunc("/portal", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/file.txt", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "s3cr3t" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = io.WriteString(w, "hello behind a login")
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestParameters_preAuthenticate(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := preAuthServer(t, "user=alice&password=hunter2")
+
+	dir := t.TempDir()
+	credentials := filepath.Join(dir, "login")
+	must.NoError(t, os.WriteFile(credentials, []byte("user=alice&password=hunter2"), 0o600))
+	wrong := filepath.Join(dir, "wrong")
+	must.NoError(t, os.WriteFile(wrong, []byte("user=alice&password=bogus"), 0o600))
+
+	newParams := func(auth *preAuth) *parameters {
+		return &parameters{
+			HTTPMaxBytes: 1e6,
+			Source:       srv.URL + "/file.txt",
+			Destination:  filepath.Join(t.TempDir(), "out.txt"),
+			PreAuth:      auth,
+		}
+	}
+
+	t.Run("authenticated", func(t *testing.T) {
+		p := newParams(&preAuth{
+			URL:             srv.URL + "/login",
+			Method:          http.MethodPost,
+			ContentType:     "application/x-www-form-urlencoded",
+			CredentialsFile: credentials,
+		})
+		must.NoError(t, p.preAuthenticate(context.Background()))
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		must.NoError(t, c.Get())
+
+		b, err := os.ReadFile(p.Destination)
+		must.NoError(t, err)
+		must.Eq(t, "hello behind a login", string(b))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		p := newParams(nil)
+		must.NoError(t, p.preAuthenticate(context.Background()))
+		c, err := p.client(context.Background())
+		must.NoError(t, err)
+		must.ErrorContains(t, c.Get(), "403")
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		p := newParams(&preAuth{
+			URL:             srv.URL + "/login",
+			Method:          http.MethodPost,
+			ContentType:     "application/x-www-form-urlencoded",
+			CredentialsFile: wrong,
+		})
+		must.ErrorContains(t, p.preAuthenticate(context.Background()), "login request failed with status 401")
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		p := newParams(&preAuth{
+			URL:             srv.URL + "/login",
+			Method:          http.MethodPost,
+			CredentialsFile: filepath.Join(dir, "missing"),
+		})
+		must.ErrorContains(t, p.preAuthenticate(context.Background()), "failed to read credentials file")
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// getProxy returns the URL of the SOCKS5 proxy the artifact is downloaded
+// through, interpolated with env, or the empty string if there is none. Git
+// and Mercurial sources cloned over ssh are rejected, since ssh does not
+// connect through the proxy and would reach the remote directly.
+func getProxy(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) (string, error) {
+	if artifact.GetterProxy == "" {
+		return "", nil
+	}
+
+	proxy := env.ReplaceEnv(artifact.GetterProxy)
+	u, err := structs.ParseGetterProxy(proxy)
+	if err == nil && u == nil {
+		err = errors.New("proxy includes a value which could not be interpolated")
+	}
+	if err != nil {
+		return "", &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("invalid artifact proxy: %w", err),
+			Recoverable: false,
+		}
+	}
+	if isSSHSource(env.ReplaceEnv(artifact.GetterSource)) {
+		return "", &Error{
+			URL:         artifact.GetterSource,
+			Err:         errors.New("artifact with a proxy cannot be fetched over ssh, use an https source instead"),
+			Recoverable: false,
+		}
+	}
+	return proxy, nil
+}
+
+// isSSHSource returns whether source is fetched over ssh, such as
+// git::ssh://git@example.com/repo.git or git@github.com:org/repo.git.
+func isSSHSource(source string) bool {
+	detected, err := getter.Detect(source, "", getter.Detectors)
+	if err != nil {
+		return false
+	}
+	if ms := forcedGetter.FindStringSubmatch(detected); ms != nil {
+		detected = ms[2]
+	}
+	u, err := url.Parse(detected)
+	return err == nil && u.Scheme == "ssh"
+}
+
+// setProxy sets the proxy of the parameters, if any, as the environment of
+// the getter sub-process, so that the git commands it runs connect to http(s)
+// remotes through the proxy. The http getter is given the proxy by its
+// transport instead.
+func (p *parameters) setProxy() error {
+	if p.Proxy == "" {
+		return nil
+	}
+	return os.Setenv("ALL_PROXY", p.Proxy)
+}
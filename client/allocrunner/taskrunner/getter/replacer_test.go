@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/escapingfs"
 )
 
@@ -50,6 +51,12 @@ func (r *upReplacer) ClientPath(p string, join bool) (string, bool) {
 	return path, escapes
 }
 
+// varsTaskEnv creates a task environment with the given taskDir which
+// interpolates vars, as the task environment of a task setting them does.
+func varsTaskEnv(taskDir string, vars map[string]string) interfaces.EnvReplacer {
+	return taskenv.NewTaskEnv(vars, vars, nil, nil, nil, taskDir, filepath.Dir(taskDir))
+}
+
 func clientPath(taskDir, path string, join bool) (string, bool) {
 	if !filepath.IsAbs(path) || (escapingfs.PathEscapesSandbox(taskDir, path) && join) {
 		path = filepath.Join(taskDir, path)
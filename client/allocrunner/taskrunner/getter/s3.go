@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/v2/endpoints"
+	"github.com/hashicorp/go-getter"
+	"golang.org/x/sync/errgroup"
+)
+
+// s3PartSize is the size of the ranges a single s3 object is downloaded in
+// when its parts are downloaded at the same time.
+const s3PartSize = 8 << 20
+
+// s3Getter is the go-getter Getter of s3 sources. It downloads directories
+// like the go-getter S3Getter, and single objects larger than s3PartSize in
+// ranges, up to maxParallel of them at the same time.
+type s3Getter struct {
+	*getter.S3Getter
+
+	// maxParallel is the maximum number of ranges of an object downloaded at
+	// the same time, or 0 to download objects over a single connection
+	maxParallel int
+}
+
+// GetFile downloads the s3 object at u to dst. Objects which cannot be read
+// in ranges, including those which do not exist or cannot be accessed, are
+// left to the go-getter S3Getter, so that it reports their errors.
+func (g *s3Getter) GetFile(dst string, u *url.URL) error {
+	if g.maxParallel < 2 {
+		return g.S3Getter.GetFile(dst, u)
+	}
+
+	ctx := g.Context()
+	if g.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.Timeout)
+		defer cancel()
+	}
+
+	obj, err := parseS3URL(u)
+	if err != nil {
+		return g.S3Getter.GetFile(dst, u)
+	}
+	client, err := newS3Client(ctx, u, obj)
+	if err != nil {
+		return g.S3Getter.GetFile(dst, u)
+	}
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:    aws.String(obj.bucket),
+		Key:       aws.String(obj.key),
+		VersionId: obj.versionID(),
+	})
+	if err != nil || aws.ToInt64(head.ContentLength) <= s3PartSize {
+		return g.S3Getter.GetFile(dst, u)
+	}
+
+	return g.getRanges(ctx, client, obj, aws.ToString(head.ETag), aws.ToInt64(head.ContentLength), dst)
+}
+
+// getRanges downloads the size bytes of obj to dst in ranges of s3PartSize,
+// up to maxParallel at the same time. Every range is requested with etag, so
+// that an object replaced during the download fails it instead of mixing the
+// content of both objects.
+func (g *s3Getter) getRanges(ctx context.Context, client *s3.Client, obj *s3Object, etag string, size int64, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755&^umask); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o666&^umask)
+	if err != nil {
+		return err
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(g.maxParallel)
+	for offset := int64(0); offset < size; offset += s3PartSize {
+		group.Go(func() error {
+			return getS3Range(ctx, client, obj, etag, f, offset, min(s3PartSize, size-offset))
+		})
+	}
+	if err := group.Wait(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// getS3Range writes the n bytes of obj starting at offset to f.
+func getS3Range(ctx context.Context, client *s3.Client, obj *s3Object, etag string, f *os.File, offset, n int64) error {
+	req := &s3.GetObjectInput{
+		Bucket:    aws.String(obj.bucket),
+		Key:       aws.String(obj.key),
+		VersionId: obj.versionID(),
+		Range:     aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+n-1)),
+	}
+	if etag != "" {
+		req.IfMatch = aws.String(etag)
+	}
+	resp, err := client.GetObject(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	written, err := io.Copy(io.NewOffsetWriter(f, offset), io.LimitReader(resp.Body, n))
+	if err != nil {
+		return err
+	}
+	if written != n {
+		return fmt.Errorf("failed to download bytes %d-%d of %s: %w", offset, offset+n-1, obj.key, io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+// s3Object is the location of an s3 object.
+type s3Object struct {
+	region  string
+	bucket  string
+	key     string
+	version string
+	creds   *credentials.StaticCredentialsProvider
+	isAWS   bool
+}
+
+// versionID returns the version of the object requested, if any.
+func (o *s3Object) versionID() *string {
+	if o.version == "" {
+		return nil
+	}
+	return aws.String(o.version)
+}
+
+// parseS3URL returns the object u refers to, as parsed by the go-getter
+// S3Getter. Amazon S3 URLs are virtual-hosted or path-style, with the region
+// in the host, and the URLs of other S3 compatible services are path-style,
+// with the region in the query.
+func parseS3URL(u *url.URL) (*s3Object, error) {
+	invalid := errors.New("URL is not a valid S3 URL")
+	obj := new(s3Object)
+	query := u.Query()
+
+	var awsDomain string
+	for _, partition := range endpoints.DefaultPartitions() {
+		if strings.HasSuffix(u.Host, partition.DNSSuffix()) {
+			awsDomain = partition.DNSSuffix()
+			break
+		}
+	}
+
+	if awsDomain != "" {
+		obj.isAWS = true
+		hostParts := strings.Split(strings.TrimSuffix(u.Host, awsDomain), ".")
+		switch len(hostParts) {
+		case 2:
+			// path-style
+			obj.region = strings.TrimPrefix(strings.TrimPrefix(hostParts[0], "s3-"), "s3")
+			if obj.region == "" {
+				obj.region = "us-east-1"
+			}
+			pathParts := strings.SplitN(u.Path, "/", 3)
+			if len(pathParts) < 3 {
+				return nil, invalid
+			}
+			obj.bucket, obj.key = pathParts[1], pathParts[2]
+		case 3:
+			// virtual-hosted, with the region following a dash
+			obj.region = strings.TrimPrefix(strings.TrimPrefix(hostParts[1], "s3-"), "s3")
+			if obj.region == "" {
+				return nil, invalid
+			}
+			pathParts := strings.SplitN(u.Path, "/", 2)
+			if len(pathParts) < 2 {
+				return nil, invalid
+			}
+			obj.bucket, obj.key = hostParts[0], pathParts[1]
+		case 4:
+			// virtual-hosted, with the region following a dot
+			obj.region = hostParts[2]
+			pathParts := strings.SplitN(u.Path, "/", 2)
+			if len(pathParts) < 2 {
+				return nil, invalid
+			}
+			obj.bucket, obj.key = hostParts[0], pathParts[1]
+		default:
+			return nil, invalid
+		}
+	} else {
+		pathParts := strings.SplitN(u.Path, "/", 3)
+		if len(pathParts) != 3 {
+			return nil, invalid
+		}
+		obj.bucket, obj.key = pathParts[1], pathParts[2]
+		obj.region = query.Get("region")
+		if obj.region == "" {
+			obj.region = "us-east-1"
+		}
+	}
+	obj.version = query.Get("version")
+
+	_, hasID := query["aws_access_key_id"]
+	_, hasSecret := query["aws_access_key_secret"]
+	_, hasToken := query["aws_access_token"]
+	if hasID || hasSecret || hasToken {
+		provider := credentials.NewStaticCredentialsProvider(
+			query.Get("aws_access_key_id"),
+			query.Get("aws_access_key_secret"),
+			query.Get("aws_access_token"),
+		)
+		obj.creds = &provider
+	}
+	return obj, nil
+}
+
+// newS3Client returns a client of the service obj is stored in, configured
+// with the same credentials as the go-getter S3Getter would be.
+func newS3Client(ctx context.Context, u *url.URL, obj *s3Object) (*s3.Client, error) {
+	var (
+		cfg aws.Config
+		err error
+	)
+	if profile := u.Query().Get("aws_profile"); profile != "" {
+		cfg, err = config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	} else {
+		var opts []func(*config.LoadOptions) error
+		var creds aws.CredentialsProvider
+		if metadataURL := os.Getenv("AWS_METADATA_URL"); obj.creds == nil && metadataURL != "" {
+			creds = ec2rolecreds.New(func(o *ec2rolecreds.Options) {
+				o.Client = imds.New(imds.Options{
+					Endpoint:          metadataURL,
+					ClientEnableState: imds.ClientEnabled,
+				})
+			})
+		} else if obj.creds != nil {
+			creds = obj.creds
+		}
+		if creds != nil {
+			opts = append(opts,
+				config.WithEC2IMDSClientEnableState(imds.ClientEnabled),
+				config.WithCredentialsProvider(creds))
+		}
+		opts = append(opts, config.WithRegion(obj.region))
+		cfg, err = config.LoadDefaultConfig(ctx, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+		if !obj.isAWS {
+			o.BaseEndpoint = aws.String("https://" + u.Host)
+		}
+	}), nil
+}
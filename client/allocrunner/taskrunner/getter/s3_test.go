@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestS3_parseS3URL(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, tc := range []struct {
+		source string
+		exp    s3Object
+	}{
+		{
+			source: "https://s3.amazonaws.com/bucket/path/to/object",
+			exp:    s3Object{region: "us-east-1", bucket: "bucket", key: "path/to/object", isAWS: true},
+		},
+		{
+			source: "https://s3-eu-west-1.amazonaws.com/bucket/object?version=3",
+			exp:    s3Object{region: "eu-west-1", bucket: "bucket", key: "object", version: "3", isAWS: true},
+		},
+		{
+			source: "https://bucket.s3-eu-west-1.amazonaws.com/object",
+			exp:    s3Object{region: "eu-west-1", bucket: "bucket", key: "object", isAWS: true},
+		},
+		{
+			source: "https://bucket.s3.eu-west-1.amazonaws.com/object",
+			exp:    s3Object{region: "eu-west-1", bucket: "bucket", key: "object", isAWS: true},
+		},
+		{
+			source: "https://minio.example.com/bucket/object?region=eu-west-1",
+			exp:    s3Object{region: "eu-west-1", bucket: "bucket", key: "object"},
+		},
+	} {
+		t.Run(tc.source, func(t *testing.T) {
+			u, err := url.Parse(tc.source)
+			must.NoError(t, err)
+			obj, err := parseS3URL(u)
+			must.NoError(t, err)
+			must.Nil(t, obj.creds)
+			must.True(t, tc.exp == *obj, must.Sprintf("%+v", *obj))
+		})
+	}
+
+	u, err := url.Parse("https://minio.example.com/bucket?aws_access_key_id=id&aws_access_key_secret=secret")
+	must.NoError(t, err)
+	_, err = parseS3URL(u)
+	must.ErrorContains(t, err, "not a valid S3 URL")
+
+	u, err = url.Parse("https://minio.example.com/bucket/object?aws_access_key_id=id&aws_access_key_secret=secret")
+	must.NoError(t, err)
+	obj, err := parseS3URL(u)
+	must.NoError(t, err)
+	must.NotNil(t, obj.creds)
+	must.Eq(t, "id", obj.creds.Value.AccessKeyID)
+	must.Eq(t, "secret", obj.creds.Value.SecretAccessKey)
+}
+
+// s3Server is a minimal S3 compatible service serving a single object, which
+// records the ranges it is requested with.
+type s3Server struct {
+	*httptest.Server
+	body []byte
+
+	l      sync.Mutex
+	ranges []string
+}
+
+func newS3Server(t *testing.T, body []byte) *s3Server {
+	s := &s3Server{body: body}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bucket/object" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+			return
+		}
+		if r.Method == http.MethodGet {
+			s.l.Lock()
+			s.ranges = append(s.ranges, r.Header.Get("Range"))
+			s.l.Unlock()
+		}
+		w.Header().Set("ETag", `"abc"`)
+		http.ServeContent(w, r, "object", time.Time{}, bytes.NewReader(s.body))
+	}))
+	t.Cleanup(s.Close)
+
+	// trust the certificate of the server
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+	must.NoError(t, os.WriteFile(bundle, cert, 0o644))
+	t.Setenv("AWS_CA_BUNDLE", bundle)
+	return s
+}
+
+func (s *s3Server) url(t *testing.T, path string) *url.URL {
+	u, err := url.Parse(s.URL + path + "?aws_access_key_id=id&aws_access_key_secret=secret")
+	must.NoError(t, err)
+	return u
+}
+
+func TestS3Getter_GetFile(t *testing.T) {
+	t.Setenv("AWS_METADATA_URL", "")
+
+	body := bytes.Repeat([]byte("0123456789abcdef"), (2*s3PartSize+1024)/16)
+	srv := newS3Server(t, body)
+
+	get := func(t *testing.T, maxParallel int, path string) (string, error) {
+		g := &s3Getter{S3Getter: new(getter.S3Getter), maxParallel: maxParallel}
+		g.SetClient(&getter.Client{Ctx: context.Background()})
+		dst := filepath.Join(t.TempDir(), "object")
+		return dst, g.GetFile(dst, srv.url(t, path))
+	}
+
+	t.Run("ranges", func(t *testing.T) {
+		srv.ranges = nil
+		dst, err := get(t, 2, "/bucket/object")
+		must.NoError(t, err)
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.True(t, bytes.Equal(body, b))
+		must.SliceContainsAll(t, []string{
+			"bytes=0-8388607",
+			"bytes=8388608-16777215",
+			"bytes=16777216-16778239",
+		}, srv.ranges)
+	})
+
+	t.Run("single connection", func(t *testing.T) {
+		srv.ranges = nil
+		dst, err := get(t, 1, "/bucket/object")
+		must.NoError(t, err)
+		b, err := os.ReadFile(dst)
+		must.NoError(t, err)
+		must.True(t, bytes.Equal(body, b))
+		must.Eq(t, []string{""}, srv.ranges)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := get(t, 2, "/bucket/missing")
+		must.Error(t, err)
+		must.True(t, isNotFound(err), must.Sprint(err))
+		must.False(t, strings.Contains(err.Error(), "secret"))
+	})
+}
@@ -4,76 +4,574 @@
 package getter
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"time"
+
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 // New creates a Sandbox with the given ArtifactConfig.
 func New(ac *config.ArtifactConfig, logger hclog.Logger) *Sandbox {
-	return &Sandbox{
+	s := &Sandbox{
 		logger: logger.Named("artifact"),
-		ac:     ac,
 	}
+	s.ac.Store(ac)
+	if ac != nil {
+		s.cache = newCache(ac.CacheDir)
+	}
+	return s
 }
 
 // A Sandbox is used to download artifacts.
 type Sandbox struct {
 	logger hclog.Logger
-	ac     *config.ArtifactConfig
+	ac     atomic.Pointer[config.ArtifactConfig]
+	cache  *cache
+
+	// downloads are the downloads in progress
+	downloads downloads
+}
+
+// SetConfig replaces the ArtifactConfig used by subsequent downloads, such as
+// when the client configuration is reloaded. Downloads in progress keep the
+// configuration they started with. The artifact cache remains in the
+// directory it was created in.
+func (s *Sandbox) SetConfig(ac *config.ArtifactConfig) {
+	s.ac.Store(ac)
 }
 
-func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, user string) error {
+// artifactConfig returns the current ArtifactConfig of the sandbox.
+func (s *Sandbox) artifactConfig() *config.ArtifactConfig {
+	return s.ac.Load()
+}
+
+// Get downloads artifact into the task directory. The source and destination
+// of artifact, and the values of its options and headers, are interpolated
+// with env before the artifact is downloaded. The returned result describes
+// how the artifact was fetched, whether or not it succeeded.
+func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, user string) (*interfaces.FetchResult, error) {
+	return s.GetWithCredentials(env, artifact, user, nil)
+}
+
+// GetWithCredentials downloads artifact like Get, presenting the client
+// certificate of creds to http servers which request one, and signing s3
+// requests with the AWS credentials of creds. The credentials are only passed
+// to the getter sub-process in memory and are never written to disk.
+func (s *Sandbox) GetWithCredentials(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, user string, creds *interfaces.ArtifactCredentials) (*interfaces.FetchResult, error) {
 	s.logger.Debug("get", "source", artifact.GetterSource, "destination", artifact.RelativeDest, "user", user)
 
-	source, err := getURL(env, artifact)
+	done := s.downloads.start(artifact)
+	defer done()
+
+	start := time.Now()
+	result := new(interfaces.FetchResult)
+	err := s.get(env, artifact, user, creds, result)
+	result.Duration = time.Since(start)
+	return result, err
+}
+
+// get downloads artifact into the task directory for GetWithCredentials,
+// recording how it was fetched in result.
+func (s *Sandbox) get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, user string, creds *interfaces.ArtifactCredentials, result *interfaces.FetchResult) error {
+	source, err := s.getSource(env, artifact)
 	if err != nil {
 		return err
 	}
+	result.Scheme = sourceScheme(source)
 
 	destination, err := getDestination(env, artifact)
 	if err != nil {
 		return err
 	}
 
-	mode := getMode(artifact)
-	insecure := isInsecure(artifact)
-	headers := getHeaders(env, artifact)
+	input := newPolicyInput(env, artifact, source)
+	if err = s.checkPolicy(artifact, input); err != nil {
+		return err
+	}
+
+	// skip the download if a previous one already left the artifact in place
+	if satisfied(env, artifact, source, destination, getMode(artifact)) {
+		s.logger.Debug("artifact already satisfies checksum", "source", source, "destination", destination)
+		return nil
+	}
+
+	// leave content already at the destination in place if configured to
+	if skip, err := checkExisting(artifact, source, destination, getMode(artifact)); err != nil {
+		return err
+	} else if skip {
+		s.logger.Debug("artifact destination already exists", "source", source, "destination", destination)
+		return nil
+	}
+
 	allocDir, taskDir := getWritableDirs(env)
+	params := s.parameters(env, artifact, source)
+	params.result = result
+	params.PartPolicy = s.partPolicy(input)
+	if params.UnixSocket, err = s.unixSocket(artifact, source); err != nil {
+		return err
+	}
+	if params.PreAuth, err = getPreAuth(env, artifact); err != nil {
+		return err
+	}
+	if params.Negotiate, err = getNegotiate(env, artifact, creds); err != nil {
+		return err
+	}
+	if params.Proxy, err = getProxy(env, artifact); err != nil {
+		return err
+	}
+	if params.PostCmd, err = getPostCmd(env, artifact, s.artifactConfig().PostCmdAllowlist); err != nil {
+		return err
+	}
+	if params.PostCmd != nil {
+		// the post command runs within the filesystem isolation
+		params.FilesystemIsolationExtraPaths = append(
+			slices.Clone(params.FilesystemIsolationExtraPaths),
+			"f:rx:"+params.PostCmd.Path,
+		)
+	}
+	if params.SSH, err = getSSH(env, artifact, creds); err != nil {
+		return err
+	}
+	if params.SSH != nil {
+		defer s.removeSSH(params.SSH)
+		params.FilesystemIsolationExtraPaths = sshExtraPaths(params)
+	}
+	params.Destination = destination
+	params.AllocDir = allocDir
+	params.TaskDir = taskDir
+	params.User = user
+	params.Chown = artifact.Chown
+	params.ChownMode = artifact.GetterChownMode
+	if params.Owner, params.Group, err = getOwner(artifact); err != nil {
+		return err
+	}
+	if creds != nil && creds.ClientCert != nil {
+		params.ClientCert = creds.ClientCert.Certificate
+		params.ClientKey = creds.ClientCert.PrivateKey
+	}
+	if creds != nil && creds.AWS != nil {
+		params.AWSAccessKeyID = creds.AWS.AccessKeyID
+		params.AWSSecretAccessKey = creds.AWS.SecretAccessKey
+		params.AWSSessionToken = creds.AWS.SessionToken
+	}
+
+	// use the cached copy of the artifact if one was prefetched, mounting it
+	// instead of copying it if configured to
+	key := cacheKey(getChecksum(env, artifact), params.Mode, source, params.KeepArchive)
+	if artifact.GetterOverlay {
+		if mounted, err := s.mount(env, artifact, key, params); err != nil || mounted {
+			return err
+		}
+	}
+	if !s.restore(key, params) {
+		if err = s.download(env, artifact, params); err != nil {
+			// an optional artifact which does not exist is left out
+			if artifact.GetterOptional && errors.Is(err, ErrNotFound) {
+				removeEmptyDownload(source, destination, params.Mode)
+				s.logger.Warn("optional artifact not found", "source", source, "destination", destination, "error", err)
+				return nil
+			}
+			return err
+		}
+	}
+
+	if err = checkTreeChecksum(env, artifact, destination); err != nil {
+		return err
+	}
+
+	if roots := s.artifactConfig().SymlinkRewriteRoots; len(roots) > 0 {
+		if err = rewriteSymlinks(destination, allocDir, taskDir, roots); err != nil {
+			return fmt.Errorf("failed to rewrite artifact symlinks: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Prefetch downloads artifact into the artifact cache without placing it into
+// any task directory, so that subsequent calls to Get for the same artifact
+// are served from the cache. The artifact must declare a checksum, which is
+// verified during the download and used as part of the cache key.
+func (s *Sandbox) Prefetch(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, user string) error {
+	s.logger.Debug("prefetch", "source", artifact.GetterSource, "user", user)
+
+	if s.cache == nil {
+		return ErrCacheDisabled
+	}
+
+	source, err := s.getSource(env, artifact)
+	if err != nil {
+		return err
+	}
+
+	input := newPolicyInput(env, artifact, source)
+	input.Destination, input.Prefetch = "", true
+	if err = s.checkPolicy(artifact, input); err != nil {
+		return err
+	}
+
+	params := s.parameters(env, artifact, source)
+	params.PartPolicy = s.partPolicy(input)
+	if params.UnixSocket, err = s.unixSocket(artifact, source); err != nil {
+		return err
+	}
+
+	// there is no task directory to read the credentials from
+	if artifact.GetterPreAuth != nil && artifact.GetterPreAuth.CredentialsFile != "" {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact with a pre_auth credentials_file cannot be prefetched"),
+			Recoverable: false,
+		}
+	}
+	if params.PreAuth, err = getPreAuth(env, artifact); err != nil {
+		return err
+	}
+
+	// there is no task to read the keytab of, and the client never
+	// authenticates with its own credentials
+	if _, ok := artifact.GetterOptions[negotiateOption]; ok {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact with a negotiate option cannot be prefetched"),
+			Recoverable: false,
+		}
+	}
+	if params.Proxy, err = getProxy(env, artifact); err != nil {
+		return err
+	}
+
+	// there is no task Vault token to generate the credentials with
+	if artifact.GetterVaultAWS != nil {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact with a vault_aws block cannot be prefetched"),
+			Recoverable: false,
+		}
+	}
+
+	// there is no task workload identity to read the private key with
+	if _, _, ok := structs.ParseGetterVariable(env.ReplaceEnv(artifact.GetterOptions[sshKeyOption])); ok {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact with an sshkey variable cannot be prefetched"),
+			Recoverable: false,
+		}
+	}
+
+	key := cacheKey(getChecksum(env, artifact), params.Mode, source, params.KeepArchive)
+	if key == "" {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact must specify a checksum to be prefetched"),
+			Recoverable: false,
+		}
+	}
+
+	if _, ok := s.cache.lookup(key); ok {
+		s.logger.Trace("artifact already cached", "source", source, "key", key)
+		return nil
+	}
+
+	params.User = user
+	if params.SSH, err = getSSH(env, artifact, nil); err != nil {
+		return err
+	}
+	if params.SSH != nil {
+		defer s.removeSSH(params.SSH)
+		params.FilesystemIsolationExtraPaths = sshExtraPaths(params)
+	}
+	return s.fetch(env, artifact, params, key)
+}
+
+// sshExtraPaths returns the filesystem isolation extra paths of params with
+// the private ssh directory added, which ssh reads the private key and known
+// hosts from within the filesystem isolation.
+func sshExtraPaths(params *parameters) []string {
+	return append(
+		slices.Clone(params.FilesystemIsolationExtraPaths),
+		"d:r:"+params.SSH.Dir,
+	)
+}
+
+// removeSSH shreds the private key of the ssh configuration of a download and
+// removes its private directory once the download is done.
+func (s *Sandbox) removeSSH(c *sshConfig) {
+	if err := c.remove(); err != nil {
+		s.logger.Warn("failed to remove artifact ssh configuration", "error", err)
+	}
+}
+
+// fetch downloads the artifact described by params into the cache entry for
+// key, in place of the destination of params. The download is inspected by
+// the getter sub-process and its checksum is verified before the content is
+// committed to the cache.
+func (s *Sandbox) fetch(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, params *parameters, key string) error {
+	staging, err := s.cache.stage()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	fetch := *params
+	if fetch.result == nil {
+		// the digest of the download is kept with the cache entry, even
+		// if there is no fetch result to report it in
+		fetch.result = new(interfaces.FetchResult)
+	}
+	fetch.Destination = filepath.Join(staging, cacheDataName)
+	fetch.AllocDir = staging
+	fetch.TaskDir = staging
+	if fetch.PreAuth != nil && fetch.PreAuth.CredentialsFile != "" {
+		// the credentials remain in the task directory
+		fetch.FilesystemIsolationExtraPaths = append(
+			slices.Clone(fetch.FilesystemIsolationExtraPaths),
+			"f:r:"+fetch.PreAuth.CredentialsFile,
+		)
+	}
+	if fetch.Negotiate != nil && fetch.Negotiate.Keytab != "" {
+		// the keytab remains in the task directory
+		fetch.FilesystemIsolationExtraPaths = append(
+			slices.Clone(fetch.FilesystemIsolationExtraPaths),
+			"f:r:"+fetch.Negotiate.Keytab,
+		)
+	}
+
+	if err = s.runCmd(&fetch); err != nil {
+		return err
+	}
+
+	if err = checkTreeChecksum(env, artifact, fetch.Destination); err != nil {
+		return err
+	}
+	if err = s.cache.recordSHA256(staging, fetch.result.SHA256); err != nil {
+		return err
+	}
+
+	params.recordCache(interfaces.FetchCacheMiss)
+	return s.cache.commit(staging, key)
+}
+
+// download downloads the artifact described by params into its destination.
+// If the client serves stale artifacts, the artifact is downloaded into the
+// cache and restored from there instead, so that the latest download can be
+// served when a later download fails, as long as it is not older than the
+// configured staleness.
+func (s *Sandbox) download(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, params *parameters) error {
+	staleIfError := s.artifactConfig().CacheStaleIfError
+	if s.cache == nil || staleIfError <= 0 {
+		return s.runCmd(params)
+	}
+
+	// the artifact is chowned and its post command run once restored
+	fetch := *params
+	fetch.Chown = false
+	fetch.PostCmd = nil
+
+	key := sourceKey(taskNamespace(env), params)
+	if err := s.fetch(env, artifact, &fetch, key); err != nil {
+		if !isStaleable(err) {
+			return err
+		}
+		age, ok := s.cache.age(key)
+		if !ok || age > staleIfError {
+			return err
+		}
+		if !s.restore(key, params) {
+			return err
+		}
+		params.recordCache(interfaces.FetchCacheStale)
+		s.logger.Warn("serving stale cached artifact after download failed",
+			"source", params.Source, "destination", params.Destination, "age", age, "error", err)
+		return nil
+	}
+
+	if !s.restore(key, params) {
+		return s.runCmd(params)
+	}
+	return nil
+}
+
+// isStaleable returns whether a download which failed with err may be served
+// from a stale cached copy. Only failures which are retried, such as the
+// source being unreachable, qualify; an artifact whose source no longer exists,
+// or whose credentials were refused, is not served.
+func isStaleable(err error) bool {
+	var gErr *Error
+	return errors.As(err, &gErr) && gErr.Recoverable &&
+		!errors.Is(err, ErrNotFound) && !errors.Is(err, ErrAuthFailure)
+}
+
+// taskNamespace returns the namespace of the task env belongs to.
+func taskNamespace(env interfaces.EnvReplacer) string {
+	return env.ReplaceEnv("${" + taskenv.Namespace + "}")
+}
 
-	params := &parameters{
+// getSource returns the resolved source of artifact, with extraction disabled
+// if the client is configured to not extract artifacts automatically.
+func (s *Sandbox) getSource(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) (string, error) {
+	source, err := getURL(env, artifact)
+	if err != nil || !s.artifactConfig().DisableAutoExtract {
+		return source, err
+	}
+
+	source, err = disableAutoExtract(source, getMode(artifact))
+	if err != nil {
+		return "", &Error{
+			URL:         artifact.GetterSource,
+			Err:         err,
+			Recoverable: false,
+		}
+	}
+	return source, nil
+}
+
+// parameters returns the getter sub-process parameters common to every
+// download of artifact. The task filesystem fields are left for the caller to
+// fill in.
+func (s *Sandbox) parameters(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, source string) *parameters {
+	ac := s.artifactConfig()
+	return &parameters{
 		// downloader configuration
-		HTTPReadTimeout:               s.ac.HTTPReadTimeout,
-		HTTPMaxBytes:                  s.ac.HTTPMaxBytes,
-		GCSTimeout:                    s.ac.GCSTimeout,
-		GitTimeout:                    s.ac.GitTimeout,
-		HgTimeout:                     s.ac.HgTimeout,
-		S3Timeout:                     s.ac.S3Timeout,
-		DecompressionLimitFileCount:   s.ac.DecompressionLimitFileCount,
-		DecompressionLimitSize:        s.ac.DecompressionLimitSize,
-		DisableArtifactInspection:     s.ac.DisableArtifactInspection,
-		DisableFilesystemIsolation:    s.ac.DisableFilesystemIsolation,
-		FilesystemIsolationExtraPaths: s.ac.FilesystemIsolationExtraPaths,
-		SetEnvironmentVariables:       s.ac.SetEnvironmentVariables,
+		HTTPReadTimeout:               ac.HTTPReadTimeout,
+		HTTPMaxBytes:                  ac.HTTPMaxBytes,
+		GCSTimeout:                    ac.GCSTimeout,
+		GitTimeout:                    ac.GitTimeout,
+		HgTimeout:                     ac.HgTimeout,
+		S3Timeout:                     ac.S3Timeout,
+		DecompressionLimitFileCount:   ac.DecompressionLimitFileCount,
+		DecompressionLimitSize:        ac.DecompressionLimitSize,
+		DecompressionMinSize:          getDecompressionMinSize(artifact, ac, source),
+		MaxFilesPerDir:                ac.MaxFilesPerDir,
+		DecompressorOverrides:         ac.DecompressorOverrides,
+		DisableArtifactInspection:     ac.DisableArtifactInspection,
+		DisableFilesystemIsolation:    ac.DisableFilesystemIsolation,
+		FilesystemIsolationExtraPaths: ac.FilesystemIsolationExtraPaths,
+		SetEnvironmentVariables:       ac.SetEnvironmentVariables,
+		HTTPSizePreflight:             ac.HTTPSizePreflight,
+		MinTLSVersion:                 ac.MinTLSVersion,
+		DisallowSymlinks:              ac.DisallowSymlinks,
+		StripSetuid:                   ac.StripSetuid,
 
 		// artifact configuration
-		Mode:        mode,
-		Insecure:    insecure,
+		Mode:        getMode(artifact),
+		Insecure:    isInsecure(artifact),
+		CertPin:     artifact.GetterCertPin,
 		Source:      source,
-		Destination: destination,
-		Headers:     headers,
+		Headers:     getHeaders(env, artifact, defaultHeaders(ac, source)),
+		KeepArchive: artifact.GetterKeepArchive,
+		MaxParallel: getMaxParallel(artifact, ac),
 
-		// task filesystem
-		AllocDir: allocDir,
-		TaskDir:  taskDir,
-		User:     user,
-		Chown:    artifact.Chown,
+		ExpectContentType: artifact.GetterExpectContentType,
 	}
+}
 
-	if err = s.runCmd(params); err != nil {
-		return err
+// getDecompressionMinSize returns the size below which the archive of
+// artifact is not extracted, which is the client's minimum unless the artifact
+// opts into extraction explicitly.
+func getDecompressionMinSize(artifact *structs.TaskArtifact, ac *config.ArtifactConfig, source string) int64 {
+	if explicitExtraction(source, getMode(artifact)) {
+		return 0
+	}
+	return ac.DecompressionMinSize
+}
+
+// getMaxParallel returns the maximum number of chunks of artifact downloaded
+// at the same time. The artifact may lower the client's maximum, but never
+// raise it.
+func getMaxParallel(artifact *structs.TaskArtifact, ac *config.ArtifactConfig) int {
+	switch {
+	case artifact.GetterMaxParallel <= 0:
+		return ac.MaxParallelChunks
+	case ac.MaxParallelChunks <= 0:
+		return artifact.GetterMaxParallel
+	}
+	return min(artifact.GetterMaxParallel, ac.MaxParallelChunks)
+}
+
+// defaultHeaders returns the default headers of ac for the scheme of source,
+// if any.
+func defaultHeaders(ac *config.ArtifactConfig, source string) http.Header {
+	if len(ac.DefaultHeaders) == 0 {
+		return nil
+	}
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil
+	}
+	return ac.DefaultHeaders[u.Scheme]
+}
+
+// restore populates the destination described by params from the cache entry
+// for key, and reports whether it did so. The copy is performed by the getter
+// sub-process so that it is subject to the same filesystem isolation and
+// inspection as a download.
+//
+// An entry which fails verification or cannot be restored is evicted, and the
+// caller falls back to downloading the artifact.
+func (s *Sandbox) restore(key string, params *parameters) bool {
+	cached, ok := s.cache.lookup(key)
+	if !ok {
+		if s.cache != nil && key != "" {
+			params.recordCache(interfaces.FetchCacheMiss)
+		}
+		return false
 	}
 
+	if err := s.cache.verify(key); err != nil {
+		s.logger.Warn("evicting invalid cached artifact", "source", params.Source, "key", key, "error", err)
+		_ = s.cache.evict(key)
+		params.recordCache(interfaces.FetchCacheMiss)
+		return false
+	}
+
+	restore := *params
+	restore.CacheSource = cached
+	restore.FilesystemIsolationExtraPaths = append(
+		slices.Clone(params.FilesystemIsolationExtraPaths),
+		"d:r:"+filepath.Dir(cached),
+	)
+
+	if err := s.runCmd(&restore); err != nil {
+		s.logger.Warn("evicting cached artifact that could not be restored", "source", params.Source, "key", key, "error", err)
+		_ = s.cache.evict(key)
+		params.recordRetry()
+		return false
+	}
+
+	s.logger.Debug("restored cached artifact", "source", params.Source, "key", key)
+	params.recordCache(interfaces.FetchCacheHit)
+	params.recordSHA256(s.cache.sha256(key))
+	return true
+}
+
+// Check returns an error if the prerequisites of downloading artifacts are not
+// met on this client. Artifacts are downloaded by a sub-process running the
+// nomad executable, which must still be present and executable, as it may not
+// be after an upgrade which replaced it in place.
+func (s *Sandbox) Check() error {
+	bin, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the nomad executable: %w", err)
+	}
+	info, err := os.Stat(bin)
+	if err != nil {
+		return fmt.Errorf("failed to stat the nomad executable: %w", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode()&0o111 == 0 {
+		return fmt.Errorf("nomad executable %q is not executable", bin)
+	}
 	return nil
 }
@@ -4,17 +4,26 @@
 package getter
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/cgi"
 	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/helper/testlog"
@@ -50,7 +59,7 @@ func TestSandbox_Get_http(t *testing.T) {
 		RelativeDest: "local/downloads",
 	}
 
-	err := sbox.Get(env, artifact, "nobody")
+	_, err := sbox.Get(env, artifact, "nobody")
 	must.NoError(t, err)
 
 	b, err := os.ReadFile(filepath.Join(taskDir, "local", "downloads", "go.mod"))
@@ -78,12 +87,12 @@ func TestSandbox_Get_insecure_http(t *testing.T) {
 		RelativeDest: "local/downloads",
 	}
 
-	err := sbox.Get(env, artifact, "nobody")
+	_, err := sbox.Get(env, artifact, "nobody")
 	must.Error(t, err)
 	must.StrContains(t, err.Error(), "x509: certificate signed by unknown authority")
 
 	artifact.GetterInsecure = true
-	err = sbox.Get(env, artifact, "nobody")
+	_, err = sbox.Get(env, artifact, "nobody")
 	must.NoError(t, err)
 }
 
@@ -103,7 +112,7 @@ func TestSandbox_Get_chown(t *testing.T) {
 		Chown:        true,
 	}
 
-	err := sbox.Get(env, artifact, "nobody")
+	_, err := sbox.Get(env, artifact, "nobody")
 	must.NoError(t, err)
 
 	info, err := os.Stat(filepath.Join(taskDir, "local", "downloads"))
@@ -141,9 +150,9 @@ func TestSandbox_Get_inspection(t *testing.T) {
 
 			_, taskDir := SetupDir(t)
 			env := noopTaskEnv(taskDir)
-			sbox.ac.DisableFilesystemIsolation = true
+			sbox.artifactConfig().DisableFilesystemIsolation = true
 
-			err := sbox.Get(env, artifact, "nobody")
+			_, err := sbox.Get(env, artifact, "nobody")
 			must.ErrorIs(t, err, ErrSandboxEscape)
 		})
 
@@ -153,10 +162,10 @@ func TestSandbox_Get_inspection(t *testing.T) {
 
 			_, taskDir := SetupDir(t)
 			env := noopTaskEnv(taskDir)
-			sbox.ac.DisableFilesystemIsolation = true
-			sbox.ac.DisableArtifactInspection = true
+			sbox.artifactConfig().DisableFilesystemIsolation = true
+			sbox.artifactConfig().DisableArtifactInspection = true
 
-			err := sbox.Get(env, artifact, "nobody")
+			_, err := sbox.Get(env, artifact, "nobody")
 			must.NoError(t, err)
 		})
 	})
@@ -188,13 +197,533 @@ func TestSandbox_Get_inspection(t *testing.T) {
 
 		_, taskDir := SetupDir(t)
 		env := noopTaskEnv(taskDir)
-		sbox.ac.DisableFilesystemIsolation = true
+		sbox.artifactConfig().DisableFilesystemIsolation = true
 
-		err = sbox.Get(env, artifact, "nobody")
+		_, err = sbox.Get(env, artifact, "nobody")
 		must.NoError(t, err)
 	})
 }
 
+func TestSandbox_Get_contentType(t *testing.T) {
+	testutil.RequireRoot(t)
+	logger := testlog.HCLogger(t)
+
+	// serve a tarball from an endpoint without a file extension
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0o644, Size: 5}))
+	_, err := tw.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write(tarball.Bytes())
+	}))
+	defer srv.Close()
+
+	ac := artifactConfig(10 * time.Second)
+	sbox := New(ac, logger)
+
+	_, taskDir := SetupDir(t)
+	env := noopTaskEnv(taskDir)
+
+	artifact := &structs.TaskArtifact{
+		GetterSource: srv.URL + "/download",
+		RelativeDest: "local/downloads",
+	}
+
+	_, err = sbox.Get(env, artifact, "nobody")
+	must.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "downloads", "hello.txt"))
+	must.NoError(t, err)
+	must.Eq(t, "hello", string(b))
+}
+
+func TestSandbox_Get_setuid(t *testing.T) {
+	testutil.RequireRoot(t)
+	logger := testlog.HCLogger(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(setuidTarball(t))
+	}))
+	defer srv.Close()
+
+	artifact := &structs.TaskArtifact{
+		GetterSource: srv.URL + "/tool.tar.gz",
+		RelativeDest: "local/downloads",
+	}
+
+	t.Run("strip", func(t *testing.T) {
+		ac := artifactConfig(10 * time.Second)
+		ac.StripSetuid = true
+		sbox := New(ac, logger)
+
+		_, taskDir := SetupDir(t)
+		_, err := sbox.Get(noopTaskEnv(taskDir), artifact, "nobody")
+		must.NoError(t, err)
+
+		info, err := os.Stat(filepath.Join(taskDir, "local", "downloads", "bin", "tool"))
+		must.NoError(t, err)
+		must.Eq(t, 0o755, info.Mode())
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		ac := artifactConfig(10 * time.Second)
+		ac.StripSetuid = false
+		sbox := New(ac, logger)
+
+		_, taskDir := SetupDir(t)
+		_, err := sbox.Get(noopTaskEnv(taskDir), artifact, "nobody")
+		must.ErrorIs(t, err, ErrSetuidDisallowed)
+		must.False(t, structs.IsRecoverable(err))
+
+		_, err = os.Stat(filepath.Join(taskDir, "local", "downloads", "bin", "tool"))
+		must.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestSandbox_Get_archiveNone(t *testing.T) {
+	testutil.RequireRoot(t)
+	logger := testlog.HCLogger(t)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.NoError(t, gz.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer srv.Close()
+
+	ac := artifactConfig(10 * time.Second)
+	sbox := New(ac, logger)
+
+	_, taskDir := SetupDir(t)
+	env := noopTaskEnv(taskDir)
+
+	artifact := &structs.TaskArtifact{
+		GetterSource:  srv.URL + "/hello.txt.gz",
+		RelativeDest:  "local/",
+		GetterArchive: structs.GetterArchiveNone,
+	}
+
+	_, err = sbox.Get(env, artifact, "nobody")
+	must.NoError(t, err)
+
+	// the file is stored compressed, as downloaded
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "hello.txt.gz"))
+	must.NoError(t, err)
+	must.Eq(t, compressed.Bytes(), b)
+	must.FileNotExists(t, filepath.Join(taskDir, "local", "hello.txt"))
+}
+
+func TestSandbox_Get_filename(t *testing.T) {
+	testutil.RequireRoot(t)
+	logger := testlog.HCLogger(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("port = 8080"))
+	}))
+	defer srv.Close()
+
+	ac := artifactConfig(10 * time.Second)
+	sbox := New(ac, logger)
+
+	_, taskDir := SetupDir(t)
+	env := noopTaskEnv(taskDir)
+
+	artifact := &structs.TaskArtifact{
+		GetterSource:   srv.URL + "/download.php?token=abc123",
+		RelativeDest:   "local/config",
+		GetterFilename: "app.conf",
+	}
+
+	_, err := sbox.Get(env, artifact, "nobody")
+	must.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "config", "app.conf"))
+	must.NoError(t, err)
+	must.Eq(t, "port = 8080", string(b))
+}
+
+func TestSandbox_Get_interpolation(t *testing.T) {
+	testutil.RequireRoot(t)
+	logger := testlog.HCLogger(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1.2.3/bundle.txt" || r.URL.Query().Get("channel") != "stable" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "bundle 1.2.3")
+	}))
+	defer srv.Close()
+
+	ac := artifactConfig(10 * time.Second)
+	sbox := New(ac, logger)
+
+	_, taskDir := SetupDir(t)
+	env := varsTaskEnv(taskDir, map[string]string{
+		"ARTIFACT_VERSION": "1.2.3",
+		"ARTIFACT_CHANNEL": "stable",
+	})
+
+	artifact := &structs.TaskArtifact{
+		GetterSource:  srv.URL + "/${ARTIFACT_VERSION}/bundle.txt",
+		GetterOptions: map[string]string{"channel": "${ARTIFACT_CHANNEL}"},
+		GetterMode:    structs.GetterModeFile,
+		RelativeDest:  "local/${ARTIFACT_VERSION}/bundle.txt",
+	}
+
+	_, err := sbox.Get(env, artifact, "nobody")
+	must.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "1.2.3", "bundle.txt"))
+	must.NoError(t, err)
+	must.Eq(t, "bundle 1.2.3", string(b))
+}
+
+func TestSandbox_Get_dataURI(t *testing.T) {
+	testutil.RequireRoot(t)
+	logger := testlog.HCLogger(t)
+
+	ac := artifactConfig(10 * time.Second)
+	sbox := New(ac, logger)
+
+	_, taskDir := SetupDir(t)
+	env := noopTaskEnv(taskDir)
+
+	artifact := &structs.TaskArtifact{
+		GetterSource: "data:text/plain;base64,cG9ydCA9IDgwODAK",
+		GetterMode:   structs.GetterModeFile,
+		RelativeDest: "local/config/app.conf",
+		Chown:        true,
+	}
+
+	_, err := sbox.Get(env, artifact, "nobody")
+	must.NoError(t, err)
+
+	path := filepath.Join(taskDir, "local", "config", "app.conf")
+	b, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.Eq(t, "port = 8080\n", string(b))
+
+	info, err := os.Stat(path)
+	must.NoError(t, err)
+	must.Eq(t, 65534, info.Sys().(*syscall.Stat_t).Uid)
+}
+
+// cachingArtifactConfig returns an artifact config with the artifact cache
+// enabled in a temporary directory.
+func cachingArtifactConfig(t *testing.T) *config.ArtifactConfig {
+	ac := artifactConfig(10 * time.Second)
+	ac.CacheDir = filepath.Join(t.TempDir(), "artifacts")
+	return ac
+}
+
+// countingServer serves body at every path and counts the requests made.
+func countingServer(t *testing.T, body string) (*httptest.Server, *atomic.Int32) {
+	count := new(atomic.Int32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, count
+}
+
+func sha256Checksum(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestSandbox_parameters_defaultHeaders(t *testing.T) {
+	ci.Parallel(t)
+
+	ac := artifactConfig(10 * time.Second)
+	ac.DefaultHeaders = map[string]http.Header{
+		"https": {"Accept": {"application/octet-stream"}, "X-Org": {"acme"}},
+	}
+	sbox := New(ac, testlog.HCLogger(t))
+	env := noopTaskEnv(t.TempDir())
+	artifact := &structs.TaskArtifact{
+		GetterHeaders: map[string]string{"X-Org": "other"},
+	}
+
+	params := sbox.parameters(env, artifact, "https://example.com/file.txt")
+	must.MapEq(t, map[string][]string{
+		"Accept": {"application/octet-stream"},
+		"X-Org":  {"other"},
+	}, params.Headers)
+
+	params = sbox.parameters(env, artifact, "http://example.com/file.txt")
+	must.MapEq(t, map[string][]string{
+		"X-Org": {"other"},
+	}, params.Headers)
+}
+
+func TestSandbox_SetConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	sbox := New(artifactConfig(10*time.Second), testlog.HCLogger(t))
+	env := noopTaskEnv(t.TempDir())
+	artifact := &structs.TaskArtifact{}
+
+	params := sbox.parameters(env, artifact, "https://example.com/file.txt")
+	must.Eq(t, 10*time.Second, params.HTTPReadTimeout)
+
+	ac := artifactConfig(time.Minute)
+	ac.HTTPMaxBytes = 1000
+	sbox.SetConfig(ac)
+
+	params = sbox.parameters(env, artifact, "https://example.com/file.txt")
+	must.Eq(t, time.Minute, params.HTTPReadTimeout)
+	must.Eq(t, 1000, params.HTTPMaxBytes)
+}
+
+func TestSandbox_Prefetch_disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	sbox := New(artifactConfig(10*time.Second), testlog.HCLogger(t))
+	artifact := &structs.TaskArtifact{
+		GetterSource:  "https://example.com/file.txt",
+		GetterOptions: map[string]string{"checksum": sha256Checksum("hello")},
+	}
+
+	err := sbox.Prefetch(noopTaskEnv(t.TempDir()), artifact, "")
+	must.ErrorIs(t, err, ErrCacheDisabled)
+}
+
+func TestSandbox_Prefetch_noChecksum(t *testing.T) {
+	ci.Parallel(t)
+
+	sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+	artifact := &structs.TaskArtifact{
+		GetterSource: "https://example.com/file.txt",
+	}
+
+	err := sbox.Prefetch(noopTaskEnv(t.TempDir()), artifact, "")
+	must.EqError(t, err, "artifact must specify a checksum to be prefetched")
+}
+
+func TestSandbox_Prefetch_alreadyCached(t *testing.T) {
+	ci.Parallel(t)
+
+	sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+	env := noopTaskEnv(t.TempDir())
+
+	// the source is unreachable, so any attempt to download it fails
+	artifact := &structs.TaskArtifact{
+		GetterSource:  "http://127.0.0.1:0/file.txt",
+		GetterOptions: map[string]string{"checksum": sha256Checksum("hello")},
+	}
+	source, err := getURL(env, artifact)
+	must.NoError(t, err)
+	key := cacheKey(getChecksum(env, artifact), getMode(artifact), source, false)
+
+	staging, err := sbox.cache.stage()
+	must.NoError(t, err)
+	must.NoError(t, os.Mkdir(filepath.Join(staging, cacheDataName), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(staging, cacheDataName, "file.txt"), []byte("hello"), 0o644))
+	must.NoError(t, sbox.cache.commit(staging, key))
+
+	must.NoError(t, sbox.Prefetch(env, artifact, ""))
+}
+
+func TestSandbox_Prefetch_cleanup(t *testing.T) {
+	testutil.RequireRoot(t)
+
+	ac := cachingArtifactConfig(t)
+	sbox := New(ac, testlog.HCLogger(t))
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	artifact := &structs.TaskArtifact{
+		GetterSource:  srv.URL + "/file.txt",
+		GetterOptions: map[string]string{"checksum": sha256Checksum("hello")},
+	}
+
+	err := sbox.Prefetch(noopTaskEnv(t.TempDir()), artifact, "")
+	must.Error(t, err)
+
+	// the staging directory of the failed download must be removed
+	entries, err := os.ReadDir(ac.CacheDir)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+}
+
+func TestSandbox_Get_cached(t *testing.T) {
+	testutil.RequireRoot(t)
+
+	const body = "hello from the cache"
+	srv, count := countingServer(t, body)
+
+	newArtifact := func() *structs.TaskArtifact {
+		return &structs.TaskArtifact{
+			GetterSource:  srv.URL + "/file.txt",
+			GetterOptions: map[string]string{"checksum": sha256Checksum(body)},
+			RelativeDest:  "local/downloads",
+		}
+	}
+
+	t.Run("chown", func(t *testing.T) {
+		count.Store(0)
+		sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+		_, taskDir := SetupDir(t)
+		env := noopTaskEnv(taskDir)
+
+		must.NoError(t, sbox.Prefetch(env, newArtifact(), ""))
+		must.Eq(t, 1, count.Load())
+
+		artifact := newArtifact()
+		artifact.Chown = true
+		_, err := sbox.Get(env, artifact, "nobody")
+		must.NoError(t, err)
+		must.Eq(t, 1, count.Load()) // served from the cache
+
+		path := filepath.Join(taskDir, "local", "downloads", "file.txt")
+		b, err := os.ReadFile(path)
+		must.NoError(t, err)
+		must.Eq(t, body, string(b))
+
+		info, err := os.Stat(path)
+		must.NoError(t, err)
+		must.Eq(t, 65534, info.Sys().(*syscall.Stat_t).Uid) // nobody's conventional uid
+	})
+
+	t.Run("destination symlink", func(t *testing.T) {
+		count.Store(0)
+		sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+		_, taskDir := SetupDir(t)
+		env := noopTaskEnv(taskDir)
+
+		must.NoError(t, sbox.Prefetch(env, newArtifact(), ""))
+
+		// a file outside of the sandbox that the task tries to overwrite
+		victim := filepath.Join(t.TempDir(), "victim")
+		must.NoError(t, os.WriteFile(victim, []byte("original"), 0o644))
+
+		downloads := filepath.Join(taskDir, "local", "downloads")
+		must.NoError(t, os.MkdirAll(downloads, 0o755))
+		must.NoError(t, os.Symlink(victim, filepath.Join(downloads, "file.txt")))
+
+		_, err := sbox.Get(env, newArtifact(), "nobody")
+		must.NoError(t, err)
+		must.Eq(t, 1, count.Load())
+
+		b, err := os.ReadFile(victim)
+		must.NoError(t, err)
+		must.Eq(t, "original", string(b))
+
+		info, err := os.Lstat(filepath.Join(downloads, "file.txt"))
+		must.NoError(t, err)
+		must.True(t, info.Mode().IsRegular())
+	})
+
+	t.Run("tampered entry", func(t *testing.T) {
+		count.Store(0)
+		sbox := New(cachingArtifactConfig(t), testlog.HCLogger(t))
+		_, taskDir := SetupDir(t)
+		env := noopTaskEnv(taskDir)
+
+		must.NoError(t, sbox.Prefetch(env, newArtifact(), ""))
+
+		source, err := getURL(env, newArtifact())
+		must.NoError(t, err)
+		key := cacheKey(sha256Checksum(body), getMode(newArtifact()), source, false)
+		cached, ok := sbox.cache.lookup(key)
+		must.True(t, ok)
+		must.NoError(t, os.WriteFile(filepath.Join(cached, "file.txt"), []byte("tampered"), 0o644))
+
+		// the tampered entry is evicted and the artifact is downloaded again
+		_, err = sbox.Get(env, newArtifact(), "nobody")
+		must.NoError(t, err)
+		must.Eq(t, 2, count.Load())
+		_, ok = sbox.cache.lookup(key)
+		must.False(t, ok)
+
+		b, err := os.ReadFile(filepath.Join(taskDir, "local", "downloads", "file.txt"))
+		must.NoError(t, err)
+		must.Eq(t, body, string(b))
+	})
+}
+
+func TestSandbox_Get_staleIfError(t *testing.T) {
+	testutil.RequireRoot(t)
+
+	const body = "hello from the last download"
+	srv, count := countingServer(t, body)
+
+	ac := cachingArtifactConfig(t)
+	ac.CacheStaleIfError = time.Hour
+	sbox := New(ac, testlog.HCLogger(t))
+	artifact := &structs.TaskArtifact{
+		GetterSource: srv.URL + "/file.txt",
+		RelativeDest: "local/downloads",
+	}
+
+	get := func(t *testing.T) (string, error) {
+		_, taskDir := SetupDir(t)
+		_, err := sbox.Get(noopTaskEnv(taskDir), artifact, "nobody")
+		return filepath.Join(taskDir, "local", "downloads", "file.txt"), err
+	}
+
+	// the first download populates the cache
+	path, err := get(t)
+	must.NoError(t, err)
+	must.Eq(t, 1, count.Load())
+	b, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.Eq(t, body, string(b))
+
+	// the cached copy is served once the source is unreachable
+	srv.Close()
+	path, err = get(t)
+	must.NoError(t, err)
+	b, err = os.ReadFile(path)
+	must.NoError(t, err)
+	must.Eq(t, body, string(b))
+
+	// but not once it is older than the staleness window
+	key := sourceKey(taskNamespace(noopTaskEnv("")), &parameters{Mode: getMode(artifact), Source: artifact.GetterSource})
+	old := time.Now().Add(-2 * time.Hour)
+	must.NoError(t, os.Chtimes(filepath.Join(ac.CacheDir, key, cacheDigestName), old, old))
+	_, err = get(t)
+	must.Error(t, err)
+}
+
+func TestSandbox_getMaxParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	ac := &config.ArtifactConfig{MaxParallelChunks: 4}
+	must.Eq(t, 4, getMaxParallel(&structs.TaskArtifact{}, ac))
+	must.Eq(t, 2, getMaxParallel(&structs.TaskArtifact{GetterMaxParallel: 2}, ac))
+
+	// artifacts cannot raise the limit of the client
+	must.Eq(t, 4, getMaxParallel(&structs.TaskArtifact{GetterMaxParallel: 16}, ac))
+}
+
+func TestSandbox_isStaleable(t *testing.T) {
+	ci.Parallel(t)
+
+	staleable := func(err error, recoverable bool) bool {
+		return isStaleable(&Error{URL: "https://example.com/a.txt", Err: err, Recoverable: recoverable})
+	}
+	must.True(t, staleable(errors.New("connection refused"), true))
+	must.False(t, staleable(errors.New("invalid checksum"), false))
+	must.False(t, staleable(ErrNotFound, true))
+	must.False(t, staleable(ErrAuthFailure, true))
+	must.False(t, isStaleable(errors.New("connection refused")))
+}
+
 func makeAndServeGitRepo(t *testing.T, repoPath string) *httptest.Server {
 	t.Helper()
 
@@ -235,3 +764,10 @@ func makeAndServeGitRepo(t *testing.T, repoPath string) *httptest.Server {
 
 	return srv
 }
+
+func TestSandbox_Check(t *testing.T) {
+	ci.Parallel(t)
+
+	sbox := TestSandbox(t)
+	must.NoError(t, sbox.Check())
+}
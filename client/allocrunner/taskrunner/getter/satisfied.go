@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// satisfied returns whether the destination of artifact already holds content
+// matching the checksum declared on the artifact, in which case downloading
+// the artifact again would leave the destination unchanged. Artifacts without
+// a checksum are never satisfied.
+//
+// Tree checksums are verified against the destination itself. Other checksums
+// are verified against the file the artifact is downloaded as, which only
+// remains on disk for artifacts which are not extracted.
+func satisfied(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, source, destination string, mode getter.ClientMode) bool {
+	if expected, ok := getTreeChecksum(env, artifact); ok {
+		if _, err := os.Lstat(destination); err != nil {
+			return false
+		}
+		actual, err := treeDigest(destination)
+		return err == nil && actual == expected
+	}
+
+	kind, value, ok := strings.Cut(strings.TrimSpace(getChecksum(env, artifact)), ":")
+	if !ok {
+		return false
+	}
+	if _, ok := checksumHashes[kind]; !ok {
+		return false
+	}
+
+	file, ok := downloadedFile(source, destination, mode)
+	if !ok {
+		return false
+	}
+	info, err := os.Lstat(file)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	actual, err := ComputeChecksum(file, kind)
+	return err == nil && strings.EqualFold(actual, kind+":"+value)
+}
+
+// downloadedFile returns the path of the file go-getter downloads source as
+// when getting it into destination with mode, and whether source is
+// downloaded as a single file which is not extracted.
+func downloadedFile(source, destination string, mode getter.ClientMode) (string, bool) {
+	if mode == getter.ClientModeDir {
+		return "", false
+	}
+
+	// ignore a forced getter, such as "s3::"
+	if i := strings.Index(source, "::"); i > 0 {
+		source = source[i+2:]
+	}
+
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", false
+	}
+
+	// sources with a subdirectory are always directories
+	if _, subDir := getter.SourceDirSubdir(u.Path); subDir != "" {
+		return "", false
+	}
+
+	// the archive option overrides the extension of the source path
+	q := u.Query()
+	if archive := q.Get("archive"); archive != "" {
+		if _, ok := getter.Decompressors[archive]; ok {
+			return "", false
+		}
+	} else if archiveExtension(u.Path) != "" {
+		return "", false
+	}
+
+	if mode == getter.ClientModeFile {
+		return destination, true
+	}
+
+	name := path.Base(u.Path)
+	if v := q.Get("filename"); v != "" {
+		name = v
+	}
+	if name == "." || name == "/" || strings.Contains(name, "..") {
+		return "", false
+	}
+	return filepath.Join(destination, name), true
+}
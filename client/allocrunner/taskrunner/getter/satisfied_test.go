@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// helloSHA256 is the sha256 checksum of the content "hello"
+const helloSHA256 = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestSatisfied_satisfied(t *testing.T) {
+	ci.Parallel(t)
+
+	dst := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(dst, "app.bin"), []byte("hello"), 0o644))
+
+	treeChecksum, err := ComputeChecksum(dst, treeChecksumType)
+	must.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		source   string
+		checksum string
+		mode     getter.ClientMode
+		exp      bool
+	}{
+		{
+			name:   "no checksum",
+			source: "https://example.com/app.bin",
+			mode:   getter.ClientModeAny,
+		},
+		{
+			name:     "file checksum",
+			source:   "https://example.com/app.bin",
+			checksum: helloSHA256,
+			mode:     getter.ClientModeAny,
+			exp:      true,
+		},
+		{
+			name:     "file checksum mismatch",
+			source:   "https://example.com/app.bin",
+			checksum: "md5:00000000000000000000000000000000",
+			mode:     getter.ClientModeAny,
+		},
+		{
+			name:     "file checksum missing file",
+			source:   "https://example.com/other.bin",
+			checksum: helloSHA256,
+			mode:     getter.ClientModeAny,
+		},
+		{
+			name:     "filename option",
+			source:   "https://example.com/download?filename=app.bin",
+			checksum: helloSHA256,
+			mode:     getter.ClientModeAny,
+			exp:      true,
+		},
+		{
+			name:     "file mode",
+			source:   "https://example.com/other.bin",
+			checksum: helloSHA256,
+			mode:     getter.ClientModeFile,
+		},
+		{
+			name:     "dir mode",
+			source:   "https://example.com/app.bin",
+			checksum: helloSHA256,
+			mode:     getter.ClientModeDir,
+		},
+		{
+			name:     "extracted archive",
+			source:   "https://example.com/app.bin?archive=zip",
+			checksum: helloSHA256,
+			mode:     getter.ClientModeAny,
+		},
+		{
+			name:     "unsupported checksum",
+			source:   "https://example.com/app.bin",
+			checksum: "file:https://example.com/SHA256SUMS",
+			mode:     getter.ClientModeAny,
+		},
+		{
+			name:     "tree checksum",
+			source:   "git::https://example.com/app.git",
+			checksum: treeChecksum,
+			mode:     getter.ClientModeDir,
+			exp:      true,
+		},
+		{
+			name:     "tree checksum mismatch",
+			source:   "git::https://example.com/app.git",
+			checksum: "tree-sha256:0000",
+			mode:     getter.ClientModeDir,
+		},
+	}
+
+	env := noopTaskEnv("/path/to/task")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			artifact := &structs.TaskArtifact{GetterSource: tc.source}
+			if tc.checksum != "" {
+				artifact.GetterOptions = map[string]string{"checksum": tc.checksum}
+			}
+			must.Eq(t, tc.exp, satisfied(env, artifact, tc.source, dst, tc.mode))
+		})
+	}
+
+	t.Run("file mode destination", func(t *testing.T) {
+		artifact := &structs.TaskArtifact{
+			GetterSource:  "https://example.com/other.bin",
+			GetterOptions: map[string]string{"checksum": helloSHA256},
+		}
+		must.True(t, satisfied(env, artifact, artifact.GetterSource, filepath.Join(dst, "app.bin"), getter.ClientModeFile))
+	})
+
+	t.Run("tree checksum missing destination", func(t *testing.T) {
+		artifact := &structs.TaskArtifact{
+			GetterSource:  "git::https://example.com/app.git",
+			GetterOptions: map[string]string{"checksum": treeChecksum},
+		}
+		must.False(t, satisfied(env, artifact, artifact.GetterSource, filepath.Join(dst, "missing"), getter.ClientModeDir))
+	})
+}
+
+func TestSatisfied_downloadedFile(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		source string
+		mode   getter.ClientMode
+		exp    string
+	}{
+		{
+			name:   "base name",
+			source: "https://example.com/path/app.bin?checksum=sha256:abc",
+			mode:   getter.ClientModeAny,
+			exp:    "/dst/app.bin",
+		},
+		{
+			name:   "forced getter",
+			source: "s3::https://bucket.s3.amazonaws.com/app.bin",
+			mode:   getter.ClientModeAny,
+			exp:    "/dst/app.bin",
+		},
+		{
+			name:   "file mode",
+			source: "https://example.com/app.bin",
+			mode:   getter.ClientModeFile,
+			exp:    "/dst",
+		},
+		{
+			name:   "archive disabled",
+			source: "https://example.com/app.tar.gz?archive=false",
+			mode:   getter.ClientModeAny,
+			exp:    "/dst/app.tar.gz",
+		},
+		{
+			name:   "archive",
+			source: "https://example.com/app.tar.gz",
+			mode:   getter.ClientModeAny,
+		},
+		{
+			name:   "subdirectory",
+			source: "https://example.com/app.bin//sub",
+			mode:   getter.ClientModeAny,
+		},
+		{
+			name:   "filename traversal",
+			source: "https://example.com/app.bin?filename=../app.bin",
+			mode:   getter.ClientModeAny,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			file, ok := downloadedFile(tc.source, "/dst", tc.mode)
+			must.Eq(t, tc.exp != "", ok)
+			must.Eq(t, tc.exp, file)
+		})
+	}
+}
+
+func TestSandbox_Get_satisfied(t *testing.T) {
+	ci.Parallel(t)
+
+	_, taskDir := SetupDir(t)
+	env := noopTaskEnv(taskDir)
+
+	dst := filepath.Join(taskDir, "local", "downloads")
+	must.NoError(t, os.MkdirAll(dst, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dst, "app.bin"), []byte("hello"), 0o644))
+
+	// the source is unreachable, so the artifact is only gotten if the
+	// download is skipped
+	artifact := &structs.TaskArtifact{
+		GetterSource:  "http://127.0.0.1:0/app.bin",
+		GetterOptions: map[string]string{"checksum": helloSHA256},
+		RelativeDest:  "local/downloads",
+	}
+
+	sbox := TestSandbox(t)
+	_, err := sbox.Get(env, artifact, "nobody")
+	must.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dst, "app.bin"))
+	must.NoError(t, err)
+	must.Eq(t, "hello", string(content))
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// ErrSetuidDisallowed is returned for artifacts holding a file with the
+// setuid, setgid, or sticky bit set when the client is configured to reject
+// such artifacts rather than strip the bits.
+var ErrSetuidDisallowed = errors.New("artifact includes setuid, setgid, or sticky file but they are disallowed")
+
+// exitSetuidDisallowed is the exit code of the getter sub-process when the
+// artifact holds a setuid, setgid, or sticky file the client rejects, so that
+// ErrSetuidDisallowed can be returned across the process boundary.
+const exitSetuidDisallowed = 14
+
+// specialModeBits are the mode bits stripped from the files of artifacts, or
+// for which artifacts are rejected.
+const specialModeBits = fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+
+// setuidDecompressor is a go-getter Decompressor which refuses to extract
+// archives holding an entry with the setuid, setgid, or sticky bit set.
+type setuidDecompressor struct {
+	getter.Decompressor
+
+	// ext is the extension of the archives, which decides how their entries
+	// are listed.
+	ext string
+}
+
+// rejectSetuid wraps each of the tarball and zip decompressors of
+// decompressors so that extracting an archive with a setuid, setgid, or sticky
+// entry returns ErrSetuidDisallowed.
+func rejectSetuid(decompressors map[string]getter.Decompressor) map[string]getter.Decompressor {
+	result := make(map[string]getter.Decompressor, len(decompressors))
+	for ext, d := range decompressors {
+		if _, ok := tarCompressions[ext]; !ok && ext != "zip" {
+			result[ext] = d
+			continue
+		}
+		result[ext] = &setuidDecompressor{
+			Decompressor: d,
+			ext:          ext,
+		}
+	}
+	return result
+}
+
+// Decompress extracts the archive at src into dst, unless one of its entries
+// has the setuid, setgid, or sticky bit set. The entries are listed before
+// anything is extracted, as the bits are otherwise masked on extraction, and
+// so that a rejected archive leaves nothing behind.
+func (d *setuidDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	err := eachArchiveEntry(src, d.ext, func(entry archiveEntry) error {
+		if entry.mode&specialModeBits != 0 {
+			return fmt.Errorf("%w: %q has mode %v",
+				ErrSetuidDisallowed, path.Clean(filepath.ToSlash(entry.name)), entry.mode)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return d.Decompressor.Decompress(dst, src, dir, umask)
+}
+
+// checkSetuid removes the setuid, setgid, and sticky bits of each file and
+// directory under root which changed since the download started if strip is
+// true, or otherwise rejects the first one with any of them set with
+// ErrSetuidDisallowed. Archives are checked as they are extracted, but other
+// getters and decompressors may set the bits too. Files the download did not
+// write, such as those the task wrote to the same directory, are left as is.
+func checkSetuid(root string, strip bool, since time.Time) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		mode := info.Mode()
+		if mode&specialModeBits == 0 {
+			return nil
+		}
+		if changed, err := changedSince(path, since); err != nil || !changed {
+			return err
+		}
+		if !strip {
+			rel, _ := filepath.Rel(root, path)
+			return fmt.Errorf("%w: %q has mode %v", ErrSetuidDisallowed, rel, mode)
+		}
+		return chmodNoFollow(path, mode&^specialModeBits)
+	})
+}
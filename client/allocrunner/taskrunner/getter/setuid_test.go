@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// setuidTarball returns a gzip compressed tarball holding bin/tool with the
+// setuid bit set.
+func setuidTarball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "bin/tool", Mode: 0o4755, Size: 4}))
+	_, err := tw.Write([]byte("tool"))
+	must.NoError(t, err)
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestSetuid_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	decompress := func(t *testing.T, decompressors map[string]getter.Decompressor) (string, error) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "tool.tar.gz")
+		must.NoError(t, os.WriteFile(src, setuidTarball(t), 0o644))
+		dst := filepath.Join(dir, "local")
+		return dst, decompressors["tar.gz"].Decompress(dst, src, true, umask)
+	}
+
+	t.Run("strip", func(t *testing.T) {
+		dst, err := decompress(t, getter.LimitedDecompressors(0, 0))
+		must.NoError(t, err)
+
+		info, err := os.Stat(filepath.Join(dst, "bin", "tool"))
+		must.NoError(t, err)
+		must.Eq(t, 0, info.Mode()&fs.ModeSetuid)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		dst, err := decompress(t, rejectSetuid(getter.LimitedDecompressors(0, 0)))
+		must.ErrorIs(t, err, ErrSetuidDisallowed)
+		must.ErrorContains(t, err, `"bin/tool"`)
+
+		// nothing is extracted
+		_, err = os.Stat(dst)
+		must.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestSetuid_rejectSetuid(t *testing.T) {
+	ci.Parallel(t)
+
+	decompressors := rejectSetuid(getter.LimitedDecompressors(0, 0))
+	for _, ext := range []string{"tar", "tar.gz", "tgz", "tar.zst", "zip"} {
+		d, ok := decompressors[ext].(*setuidDecompressor)
+		must.True(t, ok, must.Sprintf("expected %s to be wrapped", ext))
+		must.Eq(t, ext, d.ext)
+	}
+
+	// single files have no mode
+	_, ok := decompressors["gz"].(*setuidDecompressor)
+	must.False(t, ok)
+}
+
+func TestSetuid_checkSetuid(t *testing.T) {
+	ci.Parallel(t)
+
+	since := time.Now().Add(-changeTimeSlack)
+
+	setup := func(t *testing.T) string {
+		root := t.TempDir()
+		must.NoError(t, os.MkdirAll(filepath.Join(root, "bin"), 0o755))
+		tool := filepath.Join(root, "bin", "tool")
+		must.NoError(t, os.WriteFile(tool, []byte("tool"), 0o755))
+		must.NoError(t, os.Chmod(tool, 0o755|fs.ModeSetuid))
+		must.NoError(t, os.Chmod(filepath.Join(root, "bin"), 0o755|fs.ModeSticky))
+		return root
+	}
+
+	t.Run("strip", func(t *testing.T) {
+		root := setup(t)
+		must.NoError(t, checkSetuid(root, true, since))
+
+		info, err := os.Stat(filepath.Join(root, "bin", "tool"))
+		must.NoError(t, err)
+		must.Eq(t, fs.FileMode(0o755), info.Mode())
+
+		info, err = os.Stat(filepath.Join(root, "bin"))
+		must.NoError(t, err)
+		must.Eq(t, fs.ModeDir|0o755, info.Mode())
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		root := setup(t)
+		err := checkSetuid(root, false, since)
+		must.ErrorIs(t, err, ErrSetuidDisallowed)
+		must.ErrorContains(t, err, `"bin"`)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		root := t.TempDir()
+		must.NoError(t, os.WriteFile(filepath.Join(root, "run"), []byte("run"), 0o755))
+		must.NoError(t, checkSetuid(root, false, since))
+	})
+
+	t.Run("written before download", func(t *testing.T) {
+		// files already in the destination, such as those the task wrote
+		// before it restarted, are not the artifact's to check
+		root := setup(t)
+		must.NoError(t, checkSetuid(root, false, time.Now().Add(time.Hour)))
+		must.NoError(t, checkSetuid(root, true, time.Now().Add(time.Hour)))
+
+		info, err := os.Stat(filepath.Join(root, "bin", "tool"))
+		must.NoError(t, err)
+		must.Eq(t, fs.FileMode(0o755)|fs.ModeSetuid, info.Mode())
+	})
+}
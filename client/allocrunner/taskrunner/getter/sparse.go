@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// sparseBlockSize is the size of the blocks in which the content of sparse
+// tar entries is written. Blocks of zeros are skipped rather than written, so
+// the filesystem leaves holes in their place.
+const sparseBlockSize = 4096
+
+// tarCompressions maps the extension of each tarball go-getter can extract to
+// the compression of the tarball.
+var tarCompressions = map[string]string{
+	"tar":     "",
+	"tar.bz2": "bzip2",
+	"tar.gz":  "gzip",
+	"tar.xz":  "xz",
+	"tar.zst": "zstd",
+	"tbz2":    "bzip2",
+	"tgz":     "gzip",
+	"txz":     "xz",
+	"tzst":    "zstd",
+}
+
+// sparseTarDecompressor is a go-getter Decompressor for tarballs which writes
+// the holes of GNU and PAX sparse entries as sparse regions of the extracted
+// files, so disk images and similar artifacts take up about as much disk as
+// their data rather than their full size. Other entries are extracted the same
+// way the go-getter tar decompressors extract them.
+type sparseTarDecompressor struct {
+	// compression is the compression of the tarball, or empty if it is not
+	// compressed.
+	compression string
+
+	// FileSizeLimit limits the total size of all decompressed files. The
+	// zero value means no limit.
+	FileSizeLimit int64
+
+	// FilesLimit limits the number of files that are allowed to be
+	// decompressed. The zero value means no limit.
+	FilesLimit int
+}
+
+// newSparseTarDecompressor returns the decompressor of tarballs with extension
+// ext which extracts sparse entries as sparse files, within the limits of p.
+func newSparseTarDecompressor(ext string, p *parameters) getter.Decompressor {
+	return &sparseTarDecompressor{
+		compression:   tarCompressions[ext],
+		FileSizeLimit: p.DecompressionLimitSize,
+		FilesLimit:    p.DecompressionLimitFileCount,
+	}
+}
+
+// Decompress extracts the tarball at src into dst.
+func (d *sparseTarDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	// If we're going into a directory we should make that first
+	mkdir := dst
+	if !dir {
+		mkdir = filepath.Dir(dst)
+	}
+	if err := os.MkdirAll(mkdir, fileMode(0755, umask)); err != nil {
+		return err
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	r, err := decompressReader(f, d.compression)
+	if err != nil {
+		return fmt.Errorf("Error opening a %s reader for %s: %w", d.compression, src, err)
+	}
+	defer func() { _ = r.Close() }()
+
+	return d.untar(r, dst, src, dir, umask)
+}
+
+// untar extracts the uncompressed tarball read from r into dst.
+func (d *sparseTarDecompressor) untar(r io.Reader, dst, src string, dir bool, umask os.FileMode) error {
+	tarR := newTarEntryReader(r)
+	done := false
+	dirHdrs := []*tar.Header{}
+	now := time.Now()
+
+	var (
+		fileSize   int64
+		filesCount int
+	)
+
+	for {
+		if d.FilesLimit > 0 {
+			filesCount++
+			if filesCount > d.FilesLimit {
+				return fmt.Errorf("tar archive contains too many files: %d > %d", filesCount, d.FilesLimit)
+			}
+		}
+
+		hdr, err := tarR.Next()
+		if err == io.EOF {
+			if !done {
+				return fmt.Errorf("empty archive: %s", src)
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		path := dst
+		if dir {
+			// Disallow parent traversal
+			if containsDotDot(hdr.Name) {
+				return fmt.Errorf("entry contains '..': %s", hdr.Name)
+			}
+			path = filepath.Join(path, hdr.Name)
+		}
+
+		fileInfo := hdr.FileInfo()
+		fileSize += fileInfo.Size()
+		if d.FileSizeLimit > 0 && fileSize > d.FileSizeLimit {
+			return fmt.Errorf("tar archive larger than limit: %d", d.FileSizeLimit)
+		}
+
+		if fileInfo.IsDir() {
+			if !dir {
+				return fmt.Errorf("expected a single file: %s", src)
+			}
+			if err := os.MkdirAll(path, fileMode(0755, umask)); err != nil {
+				return err
+			}
+
+			// Set the directory attributes after all files have been
+			// extracted into it.
+			dirHdrs = append(dirHdrs, hdr)
+			continue
+		}
+
+		// There is no ordering guarantee that a file in a directory is listed
+		// before the directory
+		if err := os.MkdirAll(filepath.Dir(path), fileMode(0755, umask)); err != nil {
+			return err
+		}
+
+		// We have a file. If we already decoded, then it is an error
+		if !dir && done {
+			return fmt.Errorf("expected a single file, got multiple: %s", src)
+		}
+		done = true
+
+		if err := extractFile(path, tarR, fileInfo.Mode(), umask, isSparse(hdr)); err != nil {
+			return err
+		}
+		if err := os.Chtimes(path, headerTime(hdr.AccessTime, now), headerTime(hdr.ModTime, now)); err != nil {
+			return err
+		}
+	}
+
+	for _, dirHdr := range dirHdrs {
+		path := filepath.Join(dst, dirHdr.Name)
+		if err := os.Chmod(path, fileMode(dirHdr.FileInfo().Mode(), umask)); err != nil {
+			return err
+		}
+		if err := os.Chtimes(path, headerTime(dirHdr.AccessTime, now), headerTime(dirHdr.ModTime, now)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decompressReader returns a reader of the content read from r decompressed
+// with compression, which is empty if the content is not compressed.
+func decompressReader(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case "bzip2":
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case "gzip":
+		return gzip.NewReader(r)
+	case "xz":
+		xzR, err := xz.NewReader(bufio.NewReader(r))
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xzR), nil
+	case "zstd":
+		zstdR, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zstdR.IOReadCloser(), nil
+	}
+	return io.NopCloser(r), nil
+}
+
+// isSparse returns whether the tar entry is a GNU or PAX sparse file.
+func isSparse(hdr *tar.Header) bool {
+	if hdr.Typeflag == tar.TypeGNUSparse {
+		return true
+	}
+	for k := range hdr.PAXRecords {
+		if strings.HasPrefix(k, "GNU.sparse.") {
+			return true
+		}
+	}
+	return false
+}
+
+// extractFile writes the content read from r to the file at path. The holes
+// of sparse files are skipped rather than written as zeros.
+func extractFile(path string, r io.Reader, mode, umask os.FileMode, sparse bool) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, fileMode(mode, umask))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	if sparse {
+		err = copySparse(f, r)
+	} else {
+		_, err = io.Copy(f, r)
+	}
+	if err != nil {
+		// Close & remove the file in case of partial write
+		_ = f.Close()
+		_ = os.Remove(path)
+		return err
+	}
+
+	// Explicitly chmod; the process umask is applied otherwise.
+	return os.Chmod(path, fileMode(mode, umask))
+}
+
+// copySparse copies r to f in blocks, seeking over the blocks which are all
+// zeros so the filesystem leaves holes in their place.
+func copySparse(f *os.File, r io.Reader) error {
+	buf := make([]byte, sparseBlockSize)
+	var size int64
+	for {
+		n, err := readBlock(r, buf)
+		if n > 0 {
+			block := buf[:n]
+			if isZeros(block) {
+				_, seekErr := f.Seek(int64(n), io.SeekCurrent)
+				if seekErr != nil {
+					return seekErr
+				}
+			} else if _, writeErr := f.Write(block); writeErr != nil {
+				return writeErr
+			}
+			size += int64(n)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// A file ending in a hole must be extended to its full size.
+	return f.Truncate(size)
+}
+
+// readBlock reads from r until buf is full or r returns an error, which is
+// returned as is unlike io.ReadFull.
+func readBlock(r io.Reader, buf []byte) (int, error) {
+	var n int
+	for n < len(buf) {
+		m, err := r.Read(buf[n:])
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// isZeros returns whether b only contains zeros.
+func isZeros(b []byte) bool {
+	return len(b) > 0 && b[0] == 0 && bytes.Equal(b[1:], b[:len(b)-1])
+}
+
+// headerTime returns t if it is set in the tar header, otherwise now.
+func headerTime(t, now time.Time) time.Time {
+	if t.Unix() > 0 {
+		return t
+	}
+	return now
+}
+
+// fileMode returns mode with the umask applied.
+func fileMode(mode, umask os.FileMode) os.FileMode {
+	return mode & ^umask
+}
+
+// containsDotDot checks if the filepath value v contains a ".." entry.
+func containsDotDot(v string) bool {
+	if !strings.Contains(v, "..") {
+		return false
+	}
+	return slices.Contains(strings.FieldsFunc(v, func(r rune) bool {
+		return r == '/' || r == '\\'
+	}), "..")
+}
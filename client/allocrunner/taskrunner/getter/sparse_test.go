@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// rawTarHeader returns a ustar header block. archive/tar cannot write sparse
+// entries, so the tarballs holding them are assembled from raw blocks.
+func rawTarHeader(name string, typeflag byte, size int) []byte {
+	b := make([]byte, 512)
+	copy(b[0:100], name)
+	copy(b[100:108], "0000644\x00")
+	copy(b[108:116], "0000000\x00")
+	copy(b[116:124], "0000000\x00")
+	copy(b[124:136], fmt.Sprintf("%011o\x00", size))
+	copy(b[136:148], fmt.Sprintf("%011o\x00", 0))
+	b[156] = typeflag
+	copy(b[257:265], "ustar\x0000")
+
+	copy(b[148:156], "        ")
+	sum := 0
+	for _, c := range b {
+		sum += int(c)
+	}
+	copy(b[148:156], fmt.Sprintf("%06o\x00 ", sum))
+	return b
+}
+
+// rawTarPad pads b to a multiple of the tar block size.
+func rawTarPad(b []byte) []byte {
+	if rem := len(b) % 512; rem != 0 {
+		b = append(b, make([]byte, 512-rem)...)
+	}
+	return b
+}
+
+// paxRecord formats a PAX extended header record, which is prefixed with its
+// own length.
+func paxRecord(k, v string) string {
+	record := fmt.Sprintf(" %s=%s\n", k, v)
+	size := len(record)
+	for {
+		prefixed := fmt.Sprintf("%d%s", size, record)
+		if len(prefixed) == size {
+			return prefixed
+		}
+		size = len(prefixed)
+	}
+}
+
+// sparseTarball returns a tarball with a regular file and a PAX 1.0 sparse
+// file of size bytes holding data at offset, along with the content of the
+// sparse file.
+func sparseTarball(t *testing.T, size, offset int, data []byte) ([]byte, []byte) {
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/", Mode: 0o755, Typeflag: tar.TypeDir}))
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/readme", Mode: 0o644, Size: 5}))
+	_, err := tw.Write([]byte("hello"))
+	must.NoError(t, err)
+	must.NoError(t, tw.Flush())
+
+	records := paxRecord("GNU.sparse.major", "1") +
+		paxRecord("GNU.sparse.minor", "0") +
+		paxRecord("GNU.sparse.name", "app/disk.img") +
+		paxRecord("GNU.sparse.realsize", fmt.Sprint(size))
+	buf.Write(rawTarHeader("app/PaxHeaders/disk.img", tar.TypeXHeader, len(records)))
+	buf.Write(rawTarPad([]byte(records)))
+
+	sparseMap := rawTarPad([]byte(fmt.Sprintf("1\n%d\n%d\n", offset, len(data))))
+	body := append(sparseMap, data...)
+	buf.Write(rawTarHeader("app/GNUSparseFile.0/disk.img", tar.TypeReg, len(body)))
+	buf.Write(rawTarPad(body))
+
+	// end of archive
+	buf.Write(make([]byte, 1024))
+
+	content := make([]byte, size)
+	copy(content[offset:], data)
+	return buf.Bytes(), content
+}
+
+func TestSparse_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	const size = 8 * 1024 * 1024
+	const offset = 1024 * 1024
+	data := bytes.Repeat([]byte("nomad"), 2000)
+	tarball, content := sparseTarball(t, size, offset, data)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(tarball)
+	must.NoError(t, err)
+	must.NoError(t, gz.Close())
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.tgz")
+	must.NoError(t, os.WriteFile(src, compressed.Bytes(), 0o644))
+	dst := filepath.Join(dir, "local")
+
+	d := &sparseTarDecompressor{compression: "gzip"}
+	must.NoError(t, d.Decompress(dst, src, true, 0o022))
+
+	readme, err := os.ReadFile(filepath.Join(dst, "app", "readme"))
+	must.NoError(t, err)
+	must.Eq(t, "hello", string(readme))
+
+	path := filepath.Join(dst, "app", "disk.img")
+	got, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.Eq(t, size, len(got))
+	must.True(t, bytes.Equal(content, got))
+
+	// the holes are not allocated on disk
+	info, err := os.Stat(path)
+	must.NoError(t, err)
+	allocated := info.Sys().(*syscall.Stat_t).Blocks * 512
+	must.Less(t, 1024*1024, allocated)
+}
+
+func TestSparse_Decompress_limits(t *testing.T) {
+	ci.Parallel(t)
+
+	tarball, _ := sparseTarball(t, 1024*1024, 0, []byte("nomad"))
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.tar")
+	must.NoError(t, os.WriteFile(src, tarball, 0o644))
+
+	d := &sparseTarDecompressor{FilesLimit: 2}
+	err := d.Decompress(filepath.Join(dir, "files"), src, true, 0o022)
+	must.EqError(t, err, "tar archive contains too many files: 3 > 2")
+
+	// the size limit applies to the full size of sparse files
+	d = &sparseTarDecompressor{FileSizeLimit: 1024}
+	err = d.Decompress(filepath.Join(dir, "size"), src, true, 0o022)
+	must.EqError(t, err, "tar archive larger than limit: 1024")
+}
+
+func TestSparse_newSparseTarDecompressor(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &parameters{
+		DecompressionLimitFileCount: 3,
+		DecompressionLimitSize:      98765,
+	}
+
+	for ext, compression := range tarCompressions {
+		d, ok := newSparseTarDecompressor(ext, p).(*sparseTarDecompressor)
+		must.True(t, ok, must.Sprint(ext))
+		must.Eq(t, compression, d.compression)
+		must.Eq(t, 3, d.FilesLimit)
+		must.Eq(t, 98765, d.FileSizeLimit)
+	}
+}
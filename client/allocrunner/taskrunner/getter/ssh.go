@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// The artifact options configuring how git and hg connect to ssh remotes,
+// which are left out of the source URL so that go-getter does not write the
+// private key into the task directory, and so that it never appears in logs.
+const (
+	sshKeyOption                   = "sshkey"
+	sshKnownHostsOption            = "ssh_known_hosts"
+	sshStrictHostKeyCheckingOption = "ssh_strict_host_key_checking"
+)
+
+// The names of the files written to the private ssh directory.
+const (
+	sshKeyFile        = "id_key"
+	sshKnownHostsFile = "known_hosts"
+	sshHgrcFile       = "hgrc"
+)
+
+// hgrcDefaultPath lists the configuration files hg reads by default, which
+// remain in effect when the hg configuration of the download is added.
+var hgrcDefaultPath = []string{"/etc/mercurial/hgrc", "/etc/mercurial/hgrc.d"}
+
+// isSSHOption returns whether the artifact option k configures how git and hg
+// connect to ssh remotes.
+func isSSHOption(k string) bool {
+	switch k {
+	case sshKeyOption, sshKnownHostsOption, sshStrictHostKeyCheckingOption:
+		return true
+	}
+	return false
+}
+
+// sshConfig is how the git and hg commands run by the getter sub-process
+// connect to ssh remotes.
+type sshConfig struct {
+	// Dir is the private directory the Nomad client wrote the private key,
+	// known hosts, and hg configuration of the download to. It is outside of
+	// the task directory, so that the task can never read the key, and is
+	// removed once the download is done.
+	Dir string `json:"dir"`
+
+	// Command is the ssh command git and hg connect to remotes with.
+	Command string `json:"command"`
+
+	// Fingerprint is the digest of the private key and known hosts of the
+	// download, which stands in for them in the key of the cached artifact as
+	// Dir is different for every download. It is never sent to the getter
+	// sub-process.
+	Fingerprint string `json:"-"`
+}
+
+func (c *sshConfig) Equal(o *sshConfig) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+	return c.Dir == o.Dir && c.Command == o.Command
+}
+
+// getSSH writes the private key and known hosts configured by the ssh options
+// of artifact to a new private directory, and returns how git and hg connect
+// to ssh remotes with them. The private key is either the base64 encoded value
+// of the sshkey option, or the key creds read from the Nomad variable the
+// option references. It returns nil if the artifact sets no ssh options. The
+// caller must remove the configuration once the download is done.
+func getSSH(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, creds *interfaces.ArtifactCredentials) (*sshConfig, error) {
+	invalid := func(err error) error {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         err,
+			Recoverable: false,
+		}
+	}
+
+	var key []byte
+	if value := env.ReplaceEnv(artifact.GetterOptions[sshKeyOption]); value != "" {
+		if path, _, ok := structs.ParseGetterVariable(value); ok {
+			if creds == nil || creds.SSHKey == "" {
+				return nil, invalid(fmt.Errorf("sshkey variable %q was not read", path))
+			}
+			key = []byte(creds.SSHKey)
+		} else {
+			var err error
+			if key, err = base64.StdEncoding.DecodeString(value); err != nil {
+				// the error only reports the offset of the invalid byte
+				return nil, invalid(fmt.Errorf("failed to decode sshkey option: %w", err))
+			}
+		}
+	}
+
+	knownHosts := env.ReplaceEnv(artifact.GetterOptions[sshKnownHostsOption])
+
+	strict := true
+	if value := env.ReplaceEnv(artifact.GetterOptions[sshStrictHostKeyCheckingOption]); value != "" {
+		var err error
+		if strict, err = strconv.ParseBool(value); err != nil {
+			return nil, invalid(fmt.Errorf("ssh_strict_host_key_checking option must be a boolean but found %q", value))
+		}
+		if !strict && knownHosts != "" {
+			return nil, invalid(errors.New("ssh_known_hosts option cannot be set when ssh_strict_host_key_checking is false"))
+		}
+	}
+
+	if len(key) == 0 && knownHosts == "" && strict {
+		return nil, nil
+	}
+
+	dir, err := os.MkdirTemp("", "nomad-artifact-ssh-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssh directory: %w", err)
+	}
+	c := &sshConfig{Dir: dir, Fingerprint: sshFingerprint(key, knownHosts, strict)}
+
+	args := []string{"ssh"}
+	if len(key) > 0 {
+		path := filepath.Join(dir, sshKeyFile)
+		if err := writeSSHFile(path, key); err != nil {
+			_ = c.remove()
+			return nil, err
+		}
+		args = append(args, "-i", shellQuote(path), "-o", "IdentitiesOnly=yes")
+	}
+	switch {
+	case knownHosts != "":
+		path := filepath.Join(dir, sshKnownHostsFile)
+		if err := writeSSHFile(path, []byte(strings.TrimSpace(knownHosts)+"\n")); err != nil {
+			_ = c.remove()
+			return nil, err
+		}
+		args = append(args, "-o", shellQuote("UserKnownHostsFile="+path), "-o", "StrictHostKeyChecking=yes")
+	case !strict:
+		args = append(args, "-o", "UserKnownHostsFile=/dev/null", "-o", "StrictHostKeyChecking=no")
+	}
+	c.Command = strings.Join(args, " ")
+
+	// hg has no equivalent of GIT_SSH_COMMAND, so the ssh command is given
+	// to it as configuration instead
+	hgrc := "[ui]\nssh = " + c.Command + "\n"
+	if err := writeSSHFile(filepath.Join(dir, sshHgrcFile), []byte(hgrc)); err != nil {
+		_ = c.remove()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// sshFingerprint returns the digest of the private key and known hosts an ssh
+// remote is connected to with.
+func sshFingerprint(key []byte, knownHosts string, strict bool) string {
+	h := sha256.New()
+	_, _ = h.Write(key)
+	_, _ = io.WriteString(h, "\x00"+knownHosts+"\x00"+strconv.FormatBool(strict))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeSSHFile writes b to a new file at path readable only by the Nomad
+// client.
+func writeSSHFile(path string, b []byte) error {
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// remove shreds the private key, by overwriting it before it is unlinked, and
+// removes the private directory with the rest of the configuration.
+func (c *sshConfig) remove() error {
+	if c == nil {
+		return nil
+	}
+	shredErr := shredFile(filepath.Join(c.Dir, sshKeyFile))
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return fmt.Errorf("failed to remove ssh directory: %w", err)
+	}
+	return shredErr
+}
+
+// shredFile overwrites the content of the file at path with zeros and flushes
+// it to disk, so that the content does not remain on disk once the file is
+// removed. It does nothing if the file does not exist.
+func shredFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to shred %s: %w", filepath.Base(path), err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to shred %s: %w", filepath.Base(path), err)
+	}
+	if _, err = f.Write(make([]byte, info.Size())); err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to shred %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// shellQuote quotes s as a single word for the shell git and hg run the ssh
+// command with.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// setSSH sets the environment of the getter sub-process so that the git and
+// hg commands it runs connect to ssh remotes with the ssh command of the
+// parameters, if any. hg reads the command from the configuration written
+// next to the private key, in addition to its default configuration.
+func (p *parameters) setSSH() error {
+	if p.SSH == nil {
+		return nil
+	}
+	if err := os.Setenv("GIT_SSH_COMMAND", p.SSH.Command); err != nil {
+		return err
+	}
+
+	hgrcPath := slices.Clone(hgrcDefaultPath)
+	if home, err := os.UserHomeDir(); err == nil {
+		hgrcPath = append(hgrcPath, filepath.Join(home, ".hgrc"))
+	}
+	hgrcPath = append(hgrcPath, filepath.Join(p.SSH.Dir, sshHgrcFile))
+	return os.Setenv("HGRCPATH", strings.Join(hgrcPath, string(os.PathListSeparator)))
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// stderrTailLimit is the number of bytes at the end of the stderr of the getter
+// sub-process which are kept, so that a sub-process writing endlessly cannot
+// exhaust the memory of the client.
+const stderrTailLimit = 16 * 1024
+
+// ExitError is returned, wrapped in an *Error, when the getter sub-process
+// exits unexpectedly instead of reporting why the download failed, such as
+// when it is killed by the kernel for running out of memory or for making a
+// system call its isolation denies.
+type ExitError struct {
+	// ExitCode is the exit code of the sub-process, or -1 if it was
+	// terminated by a signal.
+	ExitCode int
+
+	// Signal is the name of the signal which terminated the sub-process, such
+	// as "killed", or empty if it exited.
+	Signal string
+
+	// Deadline is set if the sub-process was terminated because the download
+	// exceeded its deadline, after which it is killed.
+	Deadline time.Duration
+
+	// Stderr is the end of the output of the sub-process, at most
+	// stderrTailLimit bytes of it.
+	Stderr string
+
+	// Err is the error returned by running the sub-process.
+	Err error
+}
+
+func (e *ExitError) Error() string {
+	var msg string
+	switch {
+	case e.Signal != "":
+		msg = fmt.Sprintf("getter subprocess was terminated by signal %q", e.Signal)
+	case e.ExitCode >= 0:
+		msg = fmt.Sprintf("getter subprocess exited with status %d", e.ExitCode)
+	default:
+		msg = fmt.Sprintf("getter subprocess failed: %v", e.Err)
+	}
+	if e.Deadline > 0 {
+		msg += fmt.Sprintf(" after exceeding its deadline of %v", e.Deadline)
+	}
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// newExitError returns an ExitError describing how the sub-process run by ctx
+// with the given deadline exited, along with the end of its output.
+func newExitError(ctx context.Context, deadline time.Duration, err error, stderr string) *ExitError {
+	e := &ExitError{
+		ExitCode: -1,
+		Stderr:   stderr,
+		Err:      err,
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		e.ExitCode = exitErr.ExitCode()
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			e.Signal = status.Signal().String()
+		}
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		e.Deadline = deadline
+	}
+	return e
+}
+
+// tailBuffer is an io.Writer keeping only the last limit bytes written to it.
+type tailBuffer struct {
+	limit     int
+	buf       []byte
+	truncated bool
+}
+
+func newTailBuffer(limit int) *tailBuffer {
+	return &tailBuffer{limit: limit}
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(p) >= t.limit {
+		t.truncated = t.truncated || len(t.buf) > 0 || len(p) > t.limit
+		t.buf = append(t.buf[:0], p[len(p)-t.limit:]...)
+		return n, nil
+	}
+	if over := len(t.buf) + len(p) - t.limit; over > 0 {
+		t.truncated = true
+		t.buf = append(t.buf[:0], t.buf[over:]...)
+	}
+	t.buf = append(t.buf, p...)
+	return n, nil
+}
+
+// Reader returns a reader of the bytes kept, without the partial line at the
+// start if earlier bytes were discarded.
+func (t *tailBuffer) Reader() *bytes.Reader {
+	b := t.buf
+	if t.truncated {
+		if i := bytes.IndexByte(b, '\n'); i >= 0 {
+			b = b[i+1:]
+		}
+	}
+	return bytes.NewReader(b)
+}
+
+// String returns the lines kept, as the Reader returns them, without leading
+// and trailing whitespace.
+func (t *tailBuffer) String() string {
+	b, _ := io.ReadAll(t.Reader())
+	return strings.TrimSpace(string(b))
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestTailBuffer(t *testing.T) {
+	ci.Parallel(t)
+
+	read := func(tb *tailBuffer) string {
+		b, err := io.ReadAll(tb.Reader())
+		must.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		tb := newTailBuffer(16)
+		_, _ = tb.Write([]byte("one\n"))
+		_, _ = tb.Write([]byte("two\n"))
+		must.Eq(t, "one\ntwo\n", read(tb))
+	})
+
+	t.Run("keeps the end", func(t *testing.T) {
+		tb := newTailBuffer(10)
+		_, _ = tb.Write([]byte("first\n"))
+		_, _ = tb.Write([]byte("second\n"))
+		_, _ = tb.Write([]byte("third\n"))
+
+		// the partial line at the start is dropped
+		must.Eq(t, "third\n", read(tb))
+		must.Eq(t, 10, len(tb.buf))
+	})
+
+	t.Run("large write", func(t *testing.T) {
+		tb := newTailBuffer(8)
+		n, err := tb.Write([]byte(strings.Repeat("x", 100) + "\nlast\n"))
+		must.NoError(t, err)
+		must.Eq(t, 106, n)
+		must.Eq(t, "last\n", read(tb))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		tb := newTailBuffer(10)
+		_, _ = tb.Write([]byte("first\nsecond\nthird\n"))
+		must.Eq(t, "third", tb.String())
+	})
+}
+
+func TestExitError(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireLinux(t)
+
+	t.Run("exit status", func(t *testing.T) {
+		ctx := context.Background()
+		err := exec.CommandContext(ctx, "sh", "-c", "exit 7").Run()
+		must.Error(t, err)
+
+		exitErr := newExitError(ctx, time.Minute, err, "out of space")
+		must.Eq(t, 7, exitErr.ExitCode)
+		must.Eq(t, "", exitErr.Signal)
+		must.EqError(t, exitErr, "getter subprocess exited with status 7: out of space")
+	})
+
+	t.Run("signal", func(t *testing.T) {
+		ctx := context.Background()
+		err := exec.CommandContext(ctx, "sh", "-c", "kill -KILL $$").Run()
+		must.Error(t, err)
+
+		exitErr := newExitError(ctx, time.Minute, err, "")
+		must.Eq(t, -1, exitErr.ExitCode)
+		must.Eq(t, "killed", exitErr.Signal)
+		must.EqError(t, exitErr, `getter subprocess was terminated by signal "killed"`)
+
+		var target *exec.ExitError
+		must.True(t, errors.As(exitErr, &target))
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := exec.CommandContext(ctx, "sleep", "10").Run()
+		must.Error(t, err)
+
+		exitErr := newExitError(ctx, 100*time.Millisecond, err, "")
+		must.Eq(t, "killed", exitErr.Signal)
+		must.EqError(t, exitErr, `getter subprocess was terminated by signal "killed" after exceeding its deadline of 100ms`)
+	})
+}
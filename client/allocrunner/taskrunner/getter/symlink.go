@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper/escapingfs"
+)
+
+// rewriteRoot returns the path of the absolute symlink target relative to the
+// first of roots it lies under, and whether there was such a root.
+func rewriteRoot(target string, roots []string) (string, bool) {
+	target = filepath.Clean(target)
+	for _, root := range roots {
+		rel, err := filepath.Rel(filepath.Clean(root), target)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return rel, true
+	}
+	return "", false
+}
+
+// rewriteSymlinks walks dir and replaces every absolute symlink whose target
+// lies under one of roots with a relative symlink to the same path under
+// taskDir. Absolute symlinks under none of roots which resolve outside of
+// allocDir cause ErrSandboxEscape to be returned.
+func rewriteSymlinks(dir, allocDir, taskDir string, roots []string) error {
+	taskDir, err := filepath.Abs(taskDir)
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Only care about symlinks
+		if entry.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		if !filepath.IsAbs(target) {
+			return nil
+		}
+
+		rel, ok := rewriteRoot(target, roots)
+		if !ok {
+			isWithin, err := escapingfs.PathWithin(allocDir, target)
+			if err != nil {
+				return err
+			}
+			if !isWithin {
+				return fmt.Errorf("%w: %s -> %s", ErrSandboxEscape, path, target)
+			}
+			return nil
+		}
+
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		relTarget, err := filepath.Rel(filepath.Dir(abs), filepath.Join(taskDir, rel))
+		if err != nil {
+			return err
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		return os.Symlink(relTarget, path)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSymlink_rewriteRoot(t *testing.T) {
+	ci.Parallel(t)
+
+	roots := []string{"/opt/app", "/usr/local/lib/"}
+
+	cases := []struct {
+		target string
+		exp    string
+		ok     bool
+	}{
+		{target: "/opt/app/bin/run", exp: "bin/run", ok: true},
+		{target: "/opt/app", exp: ".", ok: true},
+		{target: "/usr/local/lib/libfoo.so", exp: "libfoo.so", ok: true},
+		{target: "/opt/application/bin", ok: false},
+		{target: "/opt/app/../../etc/passwd", ok: false},
+		{target: "/etc/passwd", ok: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.target, func(t *testing.T) {
+			rel, ok := rewriteRoot(tc.target, roots)
+			must.Eq(t, tc.ok, ok)
+			must.Eq(t, tc.exp, rel)
+		})
+	}
+}
+
+func TestSymlink_rewriteSymlinks(t *testing.T) {
+	ci.Parallel(t)
+
+	roots := []string{"/opt/app"}
+
+	setup := func(t *testing.T) (string, string, string) {
+		allocDir := t.TempDir()
+		taskDir := filepath.Join(allocDir, "task")
+		dest := filepath.Join(taskDir, "local", "app")
+		must.NoError(t, os.MkdirAll(filepath.Join(dest, "bin"), 0o755))
+		must.NoError(t, os.MkdirAll(filepath.Join(taskDir, "lib"), 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(taskDir, "lib", "libfoo.so"), []byte("foo"), 0o644))
+		return allocDir, taskDir, dest
+	}
+
+	t.Run("rewrites matching roots", func(t *testing.T) {
+		allocDir, taskDir, dest := setup(t)
+		link := filepath.Join(dest, "bin", "libfoo.so")
+		must.NoError(t, os.Symlink("/opt/app/lib/libfoo.so", link))
+		must.NoError(t, os.Symlink("run", filepath.Join(dest, "bin", "relative")))
+
+		must.NoError(t, rewriteSymlinks(dest, allocDir, taskDir, roots))
+
+		target, err := os.Readlink(link)
+		must.NoError(t, err)
+		must.Eq(t, "../../../lib/libfoo.so", target)
+
+		b, err := os.ReadFile(link)
+		must.NoError(t, err)
+		must.Eq(t, "foo", string(b))
+
+		// relative symlinks are left alone
+		target, err = os.Readlink(filepath.Join(dest, "bin", "relative"))
+		must.NoError(t, err)
+		must.Eq(t, "run", target)
+	})
+
+	t.Run("allows absolute links within the alloc dir", func(t *testing.T) {
+		allocDir, taskDir, dest := setup(t)
+		abs := filepath.Join(taskDir, "lib", "libfoo.so")
+		must.NoError(t, os.Symlink(abs, filepath.Join(dest, "libfoo.so")))
+
+		must.NoError(t, rewriteSymlinks(dest, allocDir, taskDir, roots))
+
+		target, err := os.Readlink(filepath.Join(dest, "libfoo.so"))
+		must.NoError(t, err)
+		must.Eq(t, abs, target)
+	})
+
+	t.Run("rejects other absolute links", func(t *testing.T) {
+		allocDir, taskDir, dest := setup(t)
+		must.NoError(t, os.Symlink("/etc/passwd", filepath.Join(dest, "passwd")))
+
+		err := rewriteSymlinks(dest, allocDir, taskDir, roots)
+		must.ErrorIs(t, err, ErrSandboxEscape)
+	})
+}
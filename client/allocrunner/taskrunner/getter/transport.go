@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// httpTransport returns the transport used for http artifact requests, which
+// connects to UnixSocket if set regardless of the requested host, or dials
+// the host again when resolving it fails transiently otherwise, rejects
+// servers which only negotiate a TLS version below MinTLSVersion, presents
+// ClientCert to servers which request a client certificate, only accepts
+// server certificates matching CertPin if set, and connects through the SOCKS5
+// Proxy if set.
+func (p *parameters) httpTransport() *http.Transport {
+	transport := cleanhttp.DefaultTransport()
+	transport.DialContext = dialContext(&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Resolver:  p.resolver,
+	})
+	if p.Insecure || p.ClientCert != "" || p.CertPin != "" || p.MinTLSVersion != 0 {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: p.Insecure,
+			MinVersion:         p.MinTLSVersion,
+		}
+	}
+	if p.ClientCert != "" {
+		transport.TLSClientConfig.GetClientCertificate = p.clientCertificate
+	}
+	if p.CertPin != "" {
+		// the pin identifies the exact certificate to accept, so it replaces
+		// the verification of the chain and host name, which would reject
+		// pinned self-signed certificates
+		transport.TLSClientConfig.InsecureSkipVerify = true
+		transport.TLSClientConfig.VerifyConnection = p.verifyCertPin
+	}
+	if p.Proxy != "" && p.UnixSocket == "" {
+		// the proxy was validated by the client, and the credentials it may
+		// include are sent to the proxy rather than the artifact server
+		if u, err := url.Parse(p.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(u)
+		}
+	}
+	if p.UnixSocket != "" {
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", p.UnixSocket)
+		}
+	}
+	return transport
+}
+
+// clientCertificate parses the client certificate and private key presented
+// during the TLS handshake.
+func (p *parameters) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair([]byte(p.ClientCert), []byte(p.ClientKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load artifact client certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// verifyCertPin rejects the connection unless the SHA-256 fingerprint of the
+// certificate presented by the server matches CertPin.
+func (p *parameters) verifyCertPin(state tls.ConnectionState) error {
+	pin, err := structs.ParseCertPin(p.CertPin)
+	if err != nil {
+		return err
+	}
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate to verify against the artifact cert_pin")
+	}
+
+	fingerprint := sha256.Sum256(state.PeerCertificates[0].Raw)
+	if subtle.ConstantTimeCompare(fingerprint[:], pin) != 1 {
+		return fmt.Errorf("server certificate fingerprint sha256:%s does not match the artifact cert_pin",
+			hex.EncodeToString(fingerprint[:]))
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/tlsutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestParameters_httpTransport_clientCert(t *testing.T) {
+	ci.Parallel(t)
+
+	signer, _, err := tlsutil.GeneratePrivateKey()
+	must.NoError(t, err)
+	ca, _, err := tlsutil.GenerateCA(tlsutil.CAOpts{Signer: signer, Days: 1})
+	must.NoError(t, err)
+	cert, key, err := tlsutil.GenerateCert(tlsutil.CertOpts{
+		Signer:      signer,
+		CA:          ca,
+		Name:        "artifact.example.com",
+		Days:        1,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	must.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	must.True(t, pool.AppendCertsFromPEM([]byte(ca)))
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	t.Run("with client cert", func(t *testing.T) {
+		p := &parameters{Insecure: true, ClientCert: cert, ClientKey: key}
+		client := &http.Client{Transport: p.httpTransport()}
+		resp, err := client.Get(srv.URL)
+		must.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		must.NoError(t, err)
+		must.Eq(t, "artifact.example.com", string(b))
+	})
+
+	t.Run("without client cert", func(t *testing.T) {
+		p := &parameters{Insecure: true}
+		client := &http.Client{Transport: p.httpTransport()}
+		_, err := client.Get(srv.URL)
+		must.Error(t, err)
+	})
+
+	t.Run("invalid client cert", func(t *testing.T) {
+		p := &parameters{Insecure: true, ClientCert: cert, ClientKey: "bogus"}
+		client := &http.Client{Transport: p.httpTransport()}
+		_, err := client.Get(srv.URL)
+		must.ErrorContains(t, err, "failed to load artifact client certificate")
+	})
+}
+
+func TestParameters_httpTransport_certPin(t *testing.T) {
+	ci.Parallel(t)
+
+	// the test server presents a self-signed certificate, which fails normal
+	// chain verification
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "pinned")
+	}))
+	t.Cleanup(srv.Close)
+
+	fingerprint := sha256.Sum256(srv.Certificate().Raw)
+	pin := "sha256:" + hex.EncodeToString(fingerprint[:])
+
+	get := func(p *parameters) (string, error) {
+		client := &http.Client{Transport: p.httpTransport()}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		return string(b), err
+	}
+
+	t.Run("without pin", func(t *testing.T) {
+		_, err := get(&parameters{})
+		must.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("matching pin", func(t *testing.T) {
+		body, err := get(&parameters{CertPin: pin})
+		must.NoError(t, err)
+		must.Eq(t, "pinned", body)
+	})
+
+	t.Run("mismatched pin", func(t *testing.T) {
+		other := sha256.Sum256([]byte("other"))
+		_, err := get(&parameters{CertPin: hex.EncodeToString(other[:])})
+		must.ErrorContains(t, err, "does not match the artifact cert_pin")
+	})
+
+	t.Run("mismatched pin with insecure", func(t *testing.T) {
+		other := sha256.Sum256([]byte("other"))
+		_, err := get(&parameters{Insecure: true, CertPin: hex.EncodeToString(other[:])})
+		must.ErrorContains(t, err, "does not match the artifact cert_pin")
+	})
+}
+
+func TestParameters_httpTransport_minTLSVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	// the test server only negotiates TLS 1.0
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "artifact")
+	}))
+	srv.TLS = &tls.Config{
+		MinVersion: tls.VersionTLS10,
+		MaxVersion: tls.VersionTLS10,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	t.Run("rejected", func(t *testing.T) {
+		p := &parameters{Insecure: true, MinTLSVersion: tls.VersionTLS12}
+		client := &http.Client{Transport: p.httpTransport()}
+		_, err := client.Get(srv.URL)
+		must.ErrorContains(t, err, "protocol version not supported")
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		p := &parameters{Insecure: true, MinTLSVersion: tls.VersionTLS10}
+		client := &http.Client{Transport: p.httpTransport()}
+		resp, err := client.Get(srv.URL)
+		must.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		must.NoError(t, err)
+		must.Eq(t, "artifact", string(b))
+	})
+}
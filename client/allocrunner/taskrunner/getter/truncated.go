@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// ErrTruncatedArchive is returned for artifacts whose archive ends before the
+// end of its compressed stream or tarball, such as after an interrupted
+// download. Anything extracted from the archive is removed.
+var ErrTruncatedArchive = errors.New("artifact archive is truncated")
+
+// exitTruncatedArchive is the exit code of the getter sub-process when the
+// artifact archive is truncated, so that ErrTruncatedArchive can be returned
+// across the process boundary.
+const exitTruncatedArchive = 3
+
+// truncationDecompressor is a go-getter Decompressor which detects archives
+// that end unexpectedly and removes anything written by their partial
+// extraction.
+type truncationDecompressor struct {
+	getter.Decompressor
+}
+
+// detectTruncation wraps each of decompressors so that extracting a truncated
+// archive returns ErrTruncatedArchive and leaves nothing behind.
+func detectTruncation(decompressors map[string]getter.Decompressor) map[string]getter.Decompressor {
+	result := make(map[string]getter.Decompressor, len(decompressors))
+	for ext, d := range decompressors {
+		result[ext] = &truncationDecompressor{Decompressor: d}
+	}
+	return result
+}
+
+// Decompress extracts the archive at src into dst. If the archive is
+// truncated, every path under dst which did not exist before extraction is
+// removed and ErrTruncatedArchive is returned.
+func (t *truncationDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	existing, err := listPaths(dst)
+	if err != nil {
+		return err
+	}
+
+	err = t.Decompressor.Decompress(dst, src, dir, umask)
+	if err == nil || !isTruncated(err) {
+		return err
+	}
+
+	if cleanupErr := removeNewPaths(dst, existing); cleanupErr != nil {
+		return fmt.Errorf("%w: %v; failed to remove partially extracted files: %v", ErrTruncatedArchive, err, cleanupErr)
+	}
+	return fmt.Errorf("%w: %v", ErrTruncatedArchive, err)
+}
+
+// isTruncated returns whether err was caused by reaching the end of an
+// archive unexpectedly. The decompressors of go-getter do not always wrap the
+// errors of the underlying readers, so their messages are checked as well.
+func isTruncated(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := err.Error()
+	return strings.HasSuffix(msg, io.ErrUnexpectedEOF.Error()) ||
+		strings.HasSuffix(msg, ": "+io.EOF.Error())
+}
+
+// listPaths returns the set of paths at and under root, which may not exist.
+func listPaths(root string) (map[string]struct{}, error) {
+	paths := make(map[string]struct{})
+	err := filepath.WalkDir(root, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		paths[path] = struct{}{}
+		return nil
+	})
+	return paths, err
+}
+
+// removeNewPaths removes every path at and under root which is not in
+// existing.
+func removeNewPaths(root string, existing map[string]struct{}) error {
+	var removed []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if _, ok := existing[path]; ok {
+			return nil
+		}
+		removed = append(removed, path)
+		if entry.IsDir() {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, path := range removed {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
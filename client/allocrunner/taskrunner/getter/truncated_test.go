@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// testTarball returns a gzip compressed tarball of a directory holding files
+// of random, incompressible content.
+func testTarball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/", Mode: 0o755, Typeflag: tar.TypeDir}))
+	for i := 0; i < 4; i++ {
+		content := make([]byte, 16*1024)
+		_, err := rand.Read(content)
+		must.NoError(t, err)
+		must.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: fmt.Sprintf("app/file%d", i),
+			Mode: 0o644,
+			Size: int64(len(content)),
+		}))
+		_, err = tw.Write(content)
+		must.NoError(t, err)
+	}
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestTruncation_Decompress(t *testing.T) {
+	ci.Parallel(t)
+
+	tarball := testTarball(t)
+	d := detectTruncation(getter.LimitedDecompressors(0, 0))["tar.gz"]
+
+	decompress := func(t *testing.T, archive []byte) (string, error) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "app.tar.gz")
+		must.NoError(t, os.WriteFile(src, archive, 0o644))
+
+		// the destination already holds another artifact, which must be left
+		// in place
+		dst := filepath.Join(dir, "local")
+		must.NoError(t, os.MkdirAll(filepath.Join(dst, "other"), 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(dst, "other", "file"), []byte("hi"), 0o644))
+
+		return dst, d.Decompress(dst, src, true, 0)
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		dst, err := decompress(t, tarball)
+		must.NoError(t, err)
+		must.FileExists(t, filepath.Join(dst, "app", "file3"))
+		must.FileExists(t, filepath.Join(dst, "other", "file"))
+	})
+
+	offsets := []int{0, 5, 100, len(tarball) / 4, len(tarball) / 2, 3 * len(tarball) / 4, len(tarball) - 100}
+	for _, offset := range offsets {
+		t.Run(fmt.Sprintf("truncated at %d", offset), func(t *testing.T) {
+			dst, err := decompress(t, tarball[:offset])
+			must.ErrorIs(t, err, ErrTruncatedArchive)
+
+			entries, err := os.ReadDir(dst)
+			must.NoError(t, err)
+			must.SliceLen(t, 1, entries)
+			must.Eq(t, "other", entries[0].Name())
+			must.FileExists(t, filepath.Join(dst, "other", "file"))
+		})
+	}
+
+	t.Run("corrupt", func(t *testing.T) {
+		_, err := decompress(t, []byte("not a gzip archive"))
+		must.Error(t, err)
+		must.False(t, isTruncated(err))
+	})
+}
+
+func TestTruncation_Decompress_file(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	content := make([]byte, 64*1024)
+	_, err := rand.Read(content)
+	must.NoError(t, err)
+	_, err = gz.Write(content)
+	must.NoError(t, err)
+	must.NoError(t, gz.Close())
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.gz")
+	must.NoError(t, os.WriteFile(src, buf.Bytes()[:buf.Len()/2], 0o644))
+
+	d := detectTruncation(getter.LimitedDecompressors(0, 0))["gz"]
+	dst := filepath.Join(dir, "local", "app")
+	err = d.Decompress(dst, src, false, 0)
+	must.ErrorIs(t, err, ErrTruncatedArchive)
+	must.FileNotExists(t, dst)
+}
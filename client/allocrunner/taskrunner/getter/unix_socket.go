@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// unixSocket returns the path of the Unix domain socket that the client maps
+// the host of the http(s) source to, or the empty string if there is none.
+// The socket must exist and be accessible to the getter sub-process.
+func (s *Sandbox) unixSocket(artifact *structs.TaskArtifact, source string) (string, error) {
+	sockets := s.artifactConfig().UnixSockets
+	if len(sockets) == 0 {
+		return "", nil
+	}
+
+	u, err := url.Parse(source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", nil
+	}
+
+	path, ok := sockets[u.Hostname()]
+	if !ok {
+		return "", nil
+	}
+
+	if err := checkUnixSocket(path); err != nil {
+		return "", &Error{
+			URL:         artifact.GetterSource,
+			Err:         err,
+			Recoverable: true,
+		}
+	}
+	return path, nil
+}
+
+// checkUnixSocket returns an error if path is not a Unix domain socket which
+// the getter sub-process is able to connect to.
+func checkUnixSocket(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat unix socket: %w", err)
+	}
+	if info.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("%s is not a unix socket", path)
+	}
+	if err := socketAccessible(path); err != nil {
+		return fmt.Errorf("unix socket %s is not accessible to the artifact sandbox: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package getter
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// unixSocketServer serves handler on a Unix domain socket and returns the
+// path of the socket.
+func unixSocketServer(t *testing.T, handler http.Handler) string {
+	// socket paths are limited to about 100 characters, which the test temp
+	// dir may exceed
+	dir, err := os.MkdirTemp("", "nomad-sock")
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "artifacts.sock")
+	ln, err := net.Listen("unix", path)
+	must.NoError(t, err)
+
+	srv := &http.Server{Handler: handler}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return path
+}
+
+func TestSandbox_unixSocket(t *testing.T) {
+	ci.Parallel(t)
+
+	socket := unixSocketServer(t, http.NotFoundHandler())
+	file := filepath.Join(t.TempDir(), "file")
+	must.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	ac := artifactConfig(0)
+	ac.UnixSockets = map[string]string{
+		"artifacts.local": socket,
+		"missing.local":   filepath.Join(t.TempDir(), "missing.sock"),
+		"file.local":      file,
+	}
+	sbox := New(ac, testlog.HCLogger(t))
+
+	cases := []struct {
+		source string
+		exp    string
+		expErr string
+	}{
+		{source: "http://artifacts.local/app.tar.gz", exp: socket},
+		{source: "https://artifacts.local:8443/app.tar.gz", exp: socket},
+		{source: "http://example.com/app.tar.gz", exp: ""},
+		{source: "git::https://artifacts.local/repo.git", exp: ""},
+		{source: "http://missing.local/app.tar.gz", expErr: "failed to stat unix socket"},
+		{source: "http://file.local/app.tar.gz", expErr: "is not a unix socket"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.source, func(t *testing.T) {
+			artifact := &structs.TaskArtifact{GetterSource: tc.source}
+			path, err := sbox.unixSocket(artifact, tc.source)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				var getterErr *Error
+				must.ErrorAs(t, err, &getterErr)
+				must.True(t, getterErr.IsRecoverable())
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, path)
+		})
+	}
+}
+
+func TestParameters_client_unixSocket(t *testing.T) {
+	ci.Parallel(t)
+
+	socket := unixSocketServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the virtual host is sent as the HTTP host
+		if r.Host != "artifacts.local" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = io.WriteString(w, "hello over a unix socket")
+	}))
+
+	dst := filepath.Join(t.TempDir(), "out.txt")
+	p := &parameters{
+		HTTPMaxBytes: 1e6,
+		Source:       "http://artifacts.local/file.txt",
+		Destination:  dst,
+		UnixSocket:   socket,
+	}
+	c, err := p.client(context.Background())
+	must.NoError(t, err)
+	must.NoError(t, c.Get())
+
+	b, err := os.ReadFile(dst)
+	must.NoError(t, err)
+	must.Eq(t, "hello over a unix socket", string(b))
+}
@@ -16,25 +16,53 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/helper/escapingfs"
 	"github.com/hashicorp/nomad/helper/subproc"
-	"github.com/hashicorp/nomad/helper/users"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 const (
 	// githubPrefixSSH is the prefix for downloading via git using ssh from GitHub.
 	githubPrefixSSH = "git@github.com:"
+
+	// treeChecksumType is the checksum type of artifacts which are verified
+	// against the digest of their downloaded file or directory tree after
+	// extraction, rather than by go-getter.
+	treeChecksumType = "tree-sha256"
 )
 
 var ErrSandboxEscape = errors.New("artifact includes symlink that resolves outside of sandbox")
 
+// SandboxEscapeError is the ErrSandboxEscape returned for the symlink at Path,
+// which resolves to Resolved outside of the inspected directory.
+type SandboxEscapeError struct {
+	Path     string
+	Resolved string
+}
+
+func (e *SandboxEscapeError) Error() string {
+	return fmt.Sprintf("%v: %s resolves to %s", ErrSandboxEscape, e.Path, e.Resolved)
+}
+
+func (e *SandboxEscapeError) Unwrap() error {
+	return ErrSandboxEscape
+}
+
 func getURL(taskEnv interfaces.EnvReplacer, artifact *structs.TaskArtifact) (string, error) {
 	source := taskEnv.ReplaceEnv(artifact.GetterSource)
 
+	// data URIs take no options, and parsing their query would alter the
+	// content they embed
+	if structs.IsDataURI(source) {
+		return source, nil
+	}
+
 	// fixup GitHub SSH URL such as git@github.com:hashicorp/nomad.git
 	gitSSH := false
 	if strings.HasPrefix(source, githubPrefixSSH) {
@@ -52,10 +80,33 @@ func getURL(taskEnv interfaces.EnvReplacer, artifact *structs.TaskArtifact) (str
 	}
 
 	// build the URL by substituting as necessary
+	_, treeChecksum := getTreeChecksum(taskEnv, artifact)
 	q := u.Query()
 	for k, v := range artifact.GetterOptions {
+		// go-getter does not understand tree checksums, which are verified
+		// once the artifact has been downloaded
+		if k == "checksum" && treeChecksum {
+			continue
+		}
+		// the Kerberos configuration is passed to the getter sub-process
+		// separately
+		if isNegotiateOption(k) {
+			continue
+		}
+		// the ssh configuration is written outside of the task directory
+		// for the getter sub-process, and the private key must not appear
+		// in the source
+		if isSSHOption(k) {
+			continue
+		}
 		q.Set(k, taskEnv.ReplaceEnv(v))
 	}
+
+	// go-getter stores artifacts with an archive value it has no
+	// decompressor for as downloaded
+	if artifact.GetterArchive == structs.GetterArchiveNone {
+		q.Set("archive", "false")
+	}
 	u.RawQuery = q.Encode()
 
 	// add the prefix back if necessary
@@ -67,8 +118,56 @@ func getURL(taskEnv interfaces.EnvReplacer, artifact *structs.TaskArtifact) (str
 	return sourceURL, nil
 }
 
+// getChecksum returns the checksum declared on the artifact, if any.
+func getChecksum(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) string {
+	return env.ReplaceEnv(artifact.GetterOptions["checksum"])
+}
+
+// getTreeChecksum returns the expected tree digest of the artifact, if it
+// declares a tree-sha256 checksum.
+func getTreeChecksum(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) (string, bool) {
+	kind, value, ok := strings.Cut(strings.TrimSpace(getChecksum(env, artifact)), ":")
+	if !ok || kind != treeChecksumType {
+		return "", false
+	}
+	return strings.ToLower(value), true
+}
+
+// checkTreeChecksum verifies the file or directory tree at path against the
+// tree-sha256 checksum declared on the artifact, if any.
+func checkTreeChecksum(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, path string) error {
+	expected, ok := getTreeChecksum(env, artifact)
+	if !ok {
+		return nil
+	}
+
+	actual, err := treeDigest(path)
+	if err != nil {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("failed to compute artifact tree checksum: %w", err),
+			Recoverable: true,
+		}
+	}
+
+	if actual != expected {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("artifact tree checksum mismatch: expected %s, got %s", expected, actual),
+			Recoverable: false,
+		}
+	}
+	return nil
+}
+
+// getDestination returns the path artifact is downloaded to, which is the file
+// named by its filename within its destination if it sets one.
 func getDestination(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) (string, error) {
-	destination, escapes := env.ClientPath(artifact.RelativeDest, true)
+	dest := artifact.RelativeDest
+	if artifact.GetterFilename != "" {
+		dest = filepath.Join(dest, artifact.GetterFilename)
+	}
+	destination, escapes := env.ClientPath(dest, true)
 	if escapes {
 		return "", &Error{
 			URL:         artifact.GetterSource,
@@ -79,7 +178,12 @@ func getDestination(env interfaces.EnvReplacer, artifact *structs.TaskArtifact)
 	return destination, nil
 }
 
+// getMode returns the mode artifact is downloaded in. An artifact with a
+// filename is always downloaded as a file.
 func getMode(artifact *structs.TaskArtifact) getter.ClientMode {
+	if artifact.GetterFilename != "" {
+		return getter.ClientModeFile
+	}
 	switch artifact.GetterMode {
 	case structs.GetterModeFile:
 		return getter.ClientModeFile
@@ -90,8 +194,11 @@ func getMode(artifact *structs.TaskArtifact) getter.ClientMode {
 	}
 }
 
-func chownDestination(destination, username string) error {
-	if destination == "" || username == "" {
+// chownDestination changes the owner of destination to the user, unless
+// overridden by the uid and gid the owner and group of the artifact resolved
+// to. The contents of destination are changed too, unless mode is "top".
+func chownDestination(destination, username, uid, gid, mode string) error {
+	if destination == "" || (username == "" && uid == "" && gid == "") {
 		return nil
 	}
 
@@ -103,29 +210,57 @@ func chownDestination(destination, username string) error {
 		return nil
 	}
 
-	uid, gid, _, err := users.LookupUnix(username)
+	ownerUID, ownerGID, err := chownIDs(username, uid, gid)
 	if err != nil {
 		return err
 	}
 
+	if mode == structs.GetterChownModeTop {
+		return os.Lchown(destination, ownerUID, ownerGID)
+	}
+
 	return filepath.Walk(destination, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		return os.Chown(path, uid, gid)
+		// never follow symlinks, which may point outside of the sandbox
+		return os.Lchown(path, ownerUID, ownerGID)
 	})
 }
 
+// changeTimeSlack is subtracted from the time a download starts when telling
+// apart the files it wrote, as filesystems record the time files change from a
+// coarse clock which may lag behind the clock of the getter sub-process.
+const changeTimeSlack = time.Second
+
+// changedSince returns whether the file at path changed at or after since,
+// which tells apart the files written by a download from those already in its
+// destination, such as the files of other artifacts or those the task wrote
+// before it restarted.
+func changedSince(path string, since time.Time) (bool, error) {
+	ctime, err := changeTime(path)
+	if err != nil {
+		return false, err
+	}
+	return !ctime.Before(since), nil
+}
+
 func isInsecure(artifact *structs.TaskArtifact) bool {
 	return artifact.GetterInsecure
 }
 
-func getHeaders(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) map[string][]string {
+// getHeaders returns the headers to send when downloading artifact. The
+// headers of artifact take precedence over defaults, which are the client's
+// default headers for the scheme of the artifact source.
+func getHeaders(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, defaults http.Header) map[string][]string {
 	m := artifact.GetterHeaders
-	if len(m) == 0 {
+	if len(m) == 0 && len(defaults) == 0 {
 		return nil
 	}
-	headers := make(http.Header, len(m))
+	headers := defaults.Clone()
+	if headers == nil {
+		headers = make(http.Header, len(m))
+	}
 	for k, v := range m {
 		headers.Set(k, env.ReplaceEnv(v))
 	}
@@ -166,28 +301,137 @@ func (s *Sandbox) runCmd(env *parameters) error {
 	bin := subproc.Self()
 
 	// final method of ensuring subprocess termination
-	ctx, cancel := subproc.Context(env.deadline())
+	deadline := env.deadline()
+	ctx, cancel := subproc.Context(deadline)
 	defer cancel()
 
-	// start the subprocess, passing in parameters via stdin
-	output := new(bytes.Buffer)
+	// start the subprocess, passing in parameters via stdin, and reading
+	// the statistics of the download from stdout; only the end of its
+	// output is kept
+	output := newTailBuffer(stderrTailLimit)
+	stats := new(bytes.Buffer)
 	cmd := exec.CommandContext(ctx, bin, SubCommand)
 	cmd.Env = environment(env.TaskDir, env.SetEnvironmentVariables)
 	cmd.Stdin = env.reader()
-	cmd.Stdout = output
+	cmd.Stdout = stats
 	cmd.Stderr = output
 
 	// start & wait for the subprocess to terminate
-	if err := cmd.Run(); err != nil {
-		msg := subproc.Log(output, s.logger.Error)
+	err := cmd.Run()
+	env.recordStats(readFetchStats(stats))
+	if err != nil {
+		// the output is not logged here, since it is part of the error
+		// returned, which the task runner logs and records as a task event
+		msg := output.String()
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			switch exitErr.ExitCode() {
+			case exitTruncatedArchive:
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrTruncatedArchive, msg),
+					Recoverable: true,
+				}
+			case exitCaseCollision:
+				// extracting the archive again collides all the same
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrCaseCollision, msg),
+					Recoverable: false,
+				}
+			case exitTooManyFilesInDir:
+				// the archive holds the same directories when downloaded again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrTooManyFilesInDir, msg),
+					Recoverable: false,
+				}
+			case exitSymlinkDisallowed:
+				// the artifact holds the same symlinks when downloaded again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrSymlinkDisallowed, msg),
+					Recoverable: false,
+				}
+			case exitSetuidDisallowed:
+				// the artifact holds the same setuid files when downloaded
+				// again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrSetuidDisallowed, msg),
+					Recoverable: false,
+				}
+			case exitMaxBytesExceeded:
+				// the artifact is just as large when downloaded again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrMaxBytesExceeded, msg),
+					Recoverable: false,
+				}
+			case exitNotFound:
+				// the source may yet be published, so the download is
+				// retried like before
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrNotFound, msg),
+					Recoverable: true,
+				}
+			case exitContentTypeMismatch:
+				// the same content is served when downloaded again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrContentTypeMismatch, msg),
+					Recoverable: false,
+				}
+			case exitNetworkFailure:
+				// the DNS server may yet recover, so the download is
+				// retried like before
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrNetworkFailure, msg),
+					Recoverable: true,
+				}
+			case exitHostNotFound:
+				// the host does not exist when resolved again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrHostNotFound, msg),
+					Recoverable: false,
+				}
+			case exitAuthFailure:
+				// the credentials may yet be renewed, so the download is
+				// retried like before
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrAuthFailure, msg),
+					Recoverable: true,
+				}
+			case exitPolicyDenied:
+				// the manifest lists the same parts when downloaded again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrPolicyDenied, msg),
+					Recoverable: false,
+				}
+			case exitPostCmdFailed:
+				// the command runs against the same artifact when downloaded
+				// again
+				return &Error{
+					URL:         env.Source,
+					Err:         fmt.Errorf("%w: %v", ErrPostCmdFailed, msg),
+					Recoverable: false,
+				}
+			}
+		}
 
 		return &Error{
 			URL:         env.Source,
-			Err:         fmt.Errorf("getter subprocess failed: %v: %v", err, msg),
+			Err:         newExitError(ctx, deadline, err, msg),
 			Recoverable: true,
 		}
 	}
-	subproc.Log(output, s.logger.Debug)
+	subproc.Log(output.Reader(), s.logger.Debug)
 
 	// if filesystem isolation was not disabled and lockdown
 	// is available on this platform, do not continue to inspection
@@ -202,29 +446,20 @@ func (s *Sandbox) runCmd(env *parameters) error {
 
 	// inspect the writable directories. start with inspecting the
 	// alloc directory
-	allocInspector, err := genWalkInspector(env.AllocDir)
-	if err != nil {
-		return err
-	}
-
-	if err := filepath.WalkDir(env.AllocDir, allocInspector); err != nil {
+	rewriteRoots := s.artifactConfig().SymlinkRewriteRoots
+	if err := inspectDir(s.logger, env.AllocDir, rewriteRoots); err != nil {
 		return err
 	}
 
 	// the task directory is within the alloc directory. however, if
 	// that ever changes for some reason, make sure it is checked as well
-	isWithin, err := isPathWithin(env.AllocDir, env.TaskDir)
+	isWithin, err := escapingfs.PathWithin(env.AllocDir, env.TaskDir)
 	if err != nil {
 		return err
 	}
 
 	if !isWithin {
-		taskInspector, err := genWalkInspector(env.TaskDir)
-		if err != nil {
-			return err
-		}
-
-		if err := filepath.WalkDir(env.TaskDir, taskInspector); err != nil {
+		if err := inspectDir(s.logger, env.TaskDir, rewriteRoots); err != nil {
 			return err
 		}
 	}
@@ -232,13 +467,35 @@ func (s *Sandbox) runCmd(env *parameters) error {
 	return nil
 }
 
+// InspectDir checks that no symlink within root resolves outside of root,
+// as the client does for downloaded artifacts on platforms without filesystem
+// isolation. A symlink which escapes root is returned as a
+// *SandboxEscapeError, which wraps ErrSandboxEscape.
+func InspectDir(root string) error {
+	return inspectDir(hclog.NewNullLogger(), root, nil)
+}
+
+// inspectDir checks that no symlink within root resolves outside of root,
+// other than absolute symlinks under one of rewriteRoots.
+func inspectDir(logger hclog.Logger, root string, rewriteRoots []string) error {
+	inspector, err := genWalkInspector(logger, root, rewriteRoots)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, inspector)
+}
+
 // generateWalkInspector creates a walk function to check for symlinks
-// that resolve outside of the rootDir.
-func genWalkInspector(rootDir string) (fs.WalkDirFunc, error) {
+// that resolve outside of the rootDir. Absolute symlinks targeting a path
+// under one of rewriteRoots are skipped, as they are rewritten to stay within
+// the task directory once the artifact is in place. The decision made for
+// each entry is logged at debug level.
+func genWalkInspector(logger hclog.Logger, rootDir string, rewriteRoots []string) (fs.WalkDirFunc, error) {
 	rootDir, err := filepath.Abs(rootDir)
 	if err != nil {
 		return nil, err
 	}
+	logger = logger.With("root", rootDir)
 
 	var walkFn fs.WalkDirFunc
 
@@ -256,9 +513,21 @@ func genWalkInspector(rootDir string) (fs.WalkDirFunc, error) {
 
 		// Only care about symlinks
 		if info.Mode()&fs.ModeSymlink != fs.ModeSymlink {
+			logger.Debug("inspected artifact entry", "path", path, "decision", "not a symlink")
 			return nil
 		}
 
+		if len(rewriteRoots) > 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if _, ok := rewriteRoot(target, rewriteRoots); ok && filepath.IsAbs(target) {
+				logger.Debug("inspected artifact entry", "path", path, "target", target, "decision", "rewrite root")
+				return nil
+			}
+		}
+
 		// Build up the actual path
 		resolved, err := filepath.EvalSymlinks(path)
 		if err != nil {
@@ -271,44 +540,18 @@ func genWalkInspector(rootDir string) (fs.WalkDirFunc, error) {
 		}
 
 		// Check that entry is still within sandbox
-		isWithin, err := isPathWithin(rootDir, toCheck)
+		isWithin, err := escapingfs.PathWithin(rootDir, toCheck)
 		if err != nil {
 			return err
 		}
 
 		if !isWithin {
-			return ErrSandboxEscape
+			logger.Debug("inspected artifact entry", "path", path, "resolved", toCheck, "decision", "escapes sandbox")
+			return &SandboxEscapeError{Path: path, Resolved: toCheck}
 		}
 
+		logger.Debug("inspected artifact entry", "path", path, "resolved", toCheck, "decision", "within sandbox")
 		return nil
 	}
 	return walkFn, nil
 }
-
-// isPathWithin checks if the toCheckPath is within the rootPath. It
-// uses the os.SameFile function to perform the path check so paths
-// are compared appropriately based on the filesystem.
-func isPathWithin(rootPath, toCheckPath string) (bool, error) {
-	rootPath = filepath.Clean(rootPath)
-	toCheckPath = filepath.Clean(toCheckPath)
-
-	if len(rootPath) > len(toCheckPath) {
-		return false, nil
-	}
-
-	rootStat, err := os.Stat(rootPath)
-	if err != nil {
-		return false, err
-	}
-
-	checkStat, err := os.Stat(toCheckPath[0:len(rootPath)])
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return os.SameFile(rootStat, checkStat), nil
-}
@@ -6,11 +6,22 @@
 package getter
 
 import (
+	"os"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/users"
+	"golang.org/x/sys/unix"
 )
 
+// socketAccessible returns an error if the current user cannot connect to the
+// Unix domain socket at path.
+func socketAccessible(path string) error {
+	return unix.Access(path, unix.W_OK)
+}
+
 // lockdown is not available by default
 func lockdownAvailable() bool {
 	return false
@@ -30,3 +41,38 @@ func defaultEnvironment(taskDir string) map[string]string {
 		"TMPDIR": tmpDir,
 	}
 }
+
+// postCmdSysProcAttr returns the attributes of the process of the artifact
+// post command, which runs as username if the getter sub-process runs as root.
+func postCmdSysProcAttr(username string) (*syscall.SysProcAttr, error) {
+	if os.Geteuid() != 0 {
+		return nil, nil
+	}
+	uid, gid, _, err := users.LookupUnix(username)
+	if err != nil {
+		return nil, err
+	}
+	return &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+	}, nil
+}
+
+// changeTime returns the time the file at path last changed, without following
+// it if it is a symlink. Unlike the modification time, it is set by the kernel
+// and cannot be set to the time recorded in an archive, or by the task.
+func changeTime(path string) (time.Time, error) {
+	var st unix.Stat_t
+	if err := unix.Lstat(path, &st); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(st.Ctim.Unix()), nil
+}
+
+// chmodNoFollow changes the mode of the file at path to mode, without
+// following it if it is a symlink.
+func chmodNoFollow(path string, mode os.FileMode) error {
+	if err := unix.Fchmodat(unix.AT_FDCWD, path, uint32(mode.Perm()), unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return &os.PathError{Op: "chmod", Path: path, Err: err}
+	}
+	return nil
+}
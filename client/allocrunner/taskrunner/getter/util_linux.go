@@ -9,8 +9,11 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/users"
 	"github.com/mitchellh/go-homedir"
 	"github.com/shoenig/go-landlock"
 	"golang.org/x/sys/unix"
@@ -56,6 +59,12 @@ func defaultEnvironment(taskDir string) map[string]string {
 	}
 }
 
+// socketAccessible returns an error if the current user cannot connect to the
+// Unix domain socket at path.
+func socketAccessible(path string) error {
+	return unix.Access(path, unix.W_OK)
+}
+
 // lockdownAvailable returns if lockdown is implemented for
 // the current platform.
 func lockdownAvailable() bool {
@@ -181,3 +190,50 @@ func filesForVCS(
 	}
 	return result
 }
+
+// postCmdSysProcAttr returns the attributes of the process of the artifact
+// post command, which runs as username if the getter sub-process runs as root.
+func postCmdSysProcAttr(username string) (*syscall.SysProcAttr, error) {
+	if os.Geteuid() != 0 {
+		return nil, nil
+	}
+	uid, gid, _, err := users.LookupUnix(username)
+	if err != nil {
+		return nil, err
+	}
+	return &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+	}, nil
+}
+
+// changeTime returns the time the file at path last changed, without following
+// it if it is a symlink. Unlike the modification time, it is set by the kernel
+// and cannot be set to the time recorded in an archive, or by the task.
+func changeTime(path string) (time.Time, error) {
+	var st unix.Stat_t
+	if err := unix.Lstat(path, &st); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(st.Ctim.Unix()), nil
+}
+
+// chmodNoFollow changes the mode of the file at path to mode, without
+// following it if it is a symlink. Kernels without fchmodat2 cannot change the
+// mode of a path without following it, in which case the file is opened
+// without following it and changed through its descriptor instead.
+func chmodNoFollow(path string, mode os.FileMode) error {
+	err := unix.Fchmodat(unix.AT_FDCWD, path, uint32(mode.Perm()), unix.AT_SYMLINK_NOFOLLOW)
+	if !errors.Is(err, unix.EOPNOTSUPP) {
+		return err
+	}
+
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	defer unix.Close(fd)
+	if err := unix.Fchmod(fd, uint32(mode.Perm())); err != nil {
+		return &os.PathError{Op: "chmod", Path: path, Err: err}
+	}
+	return nil
+}
@@ -8,8 +8,12 @@ package getter
 import (
 	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
 
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/mitchellh/go-homedir"
 	"github.com/shoenig/go-landlock"
 	"github.com/shoenig/test/must"
@@ -96,3 +100,74 @@ func TestUtil_loadVersionControlGlobalConfigs(t *testing.T) {
 		landlock.File(urandom, "r"),
 	}, paths)
 }
+
+func TestUtil_chownDestination(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	setup := func(t *testing.T) string {
+		dst := t.TempDir()
+		must.NoError(t, os.MkdirAll(filepath.Join(dst, "sub"), 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(dst, "sub", "file"), []byte("hi"), 0o644))
+		return dst
+	}
+
+	owner := func(t *testing.T, path string) uint32 {
+		info, err := os.Lstat(path)
+		must.NoError(t, err)
+		return info.Sys().(*syscall.Stat_t).Uid
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		dst := setup(t)
+		must.NoError(t, chownDestination(dst, "nobody", "", "", structs.GetterChownModeRecursive))
+		must.Eq(t, 65534, owner(t, dst))
+		must.Eq(t, 65534, owner(t, filepath.Join(dst, "sub")))
+		must.Eq(t, 65534, owner(t, filepath.Join(dst, "sub", "file")))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		dst := setup(t)
+		must.NoError(t, chownDestination(dst, "nobody", "", "", ""))
+		must.Eq(t, 65534, owner(t, filepath.Join(dst, "sub", "file")))
+	})
+
+	t.Run("top", func(t *testing.T) {
+		dst := setup(t)
+		must.NoError(t, chownDestination(dst, "nobody", "", "", structs.GetterChownModeTop))
+		must.Eq(t, 65534, owner(t, dst))
+		must.Eq(t, 0, owner(t, filepath.Join(dst, "sub")))
+		must.Eq(t, 0, owner(t, filepath.Join(dst, "sub", "file")))
+	})
+
+	t.Run("owner and group", func(t *testing.T) {
+		dst := setup(t)
+		must.NoError(t, chownDestination(dst, "nobody", "4242", "4343", structs.GetterChownModeRecursive))
+		info, err := os.Lstat(filepath.Join(dst, "sub", "file"))
+		must.NoError(t, err)
+		must.Eq(t, 4242, info.Sys().(*syscall.Stat_t).Uid)
+		must.Eq(t, 4343, info.Sys().(*syscall.Stat_t).Gid)
+	})
+}
+
+func TestUtil_chmodNoFollow(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	must.NoError(t, os.WriteFile(target, []byte("target"), 0o644))
+	must.NoError(t, os.Chmod(target, 0o755|os.ModeSetuid))
+	link := filepath.Join(dir, "link")
+	must.NoError(t, os.Symlink(target, link))
+
+	// a symlink swapped in place of a file of the artifact is not followed
+	must.Error(t, chmodNoFollow(link, 0o755))
+	info, err := os.Stat(target)
+	must.NoError(t, err)
+	must.Eq(t, 0o755|os.ModeSetuid, info.Mode())
+
+	must.NoError(t, chmodNoFollow(target, 0o755))
+	info, err = os.Stat(target)
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0o755), info.Mode())
+}
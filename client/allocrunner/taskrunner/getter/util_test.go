@@ -6,13 +6,16 @@ package getter
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-getter"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/mitchellh/go-homedir"
 	"github.com/shoenig/test/must"
@@ -44,17 +47,45 @@ func TestUtil_getURL(t *testing.T) {
 		name: "option",
 		artifact: &structs.TaskArtifact{
 			GetterSource:  "git::github.com/hashicorp/nomad",
-			GetterOptions: map[string]string{"sshkey": "abc123"},
+			GetterOptions: map[string]string{"ref": "abc123"},
 		},
-		expURL: "git::github.com/hashicorp/nomad?sshkey=abc123",
+		expURL: "git::github.com/hashicorp/nomad?ref=abc123",
 		expErr: nil,
 	}, {
 		name: "github case",
 		artifact: &structs.TaskArtifact{
 			GetterSource:  "git@github.com:hashicorp/nomad.git",
-			GetterOptions: map[string]string{"sshkey": "abc123"},
+			GetterOptions: map[string]string{"ref": "abc123"},
 		},
-		expURL: "git@github.com:hashicorp/nomad.git?sshkey=abc123",
+		expURL: "git@github.com:hashicorp/nomad.git?ref=abc123",
+		expErr: nil,
+	}, {
+		name:     "data uri",
+		artifact: &structs.TaskArtifact{GetterSource: "data:,what%3F?a=b"},
+		expURL:   "data:,what%3F?a=b",
+		expErr:   nil,
+	}, {
+		name: "tree checksum",
+		artifact: &structs.TaskArtifact{
+			GetterSource: "git::github.com/hashicorp/nomad",
+			GetterOptions: map[string]string{
+				"checksum": "tree-sha256:abc123",
+				"ref":      "v1.0.0",
+			},
+		},
+		expURL: "git::github.com/hashicorp/nomad?ref=v1.0.0",
+		expErr: nil,
+	}, {
+		name: "negotiate",
+		artifact: &structs.TaskArtifact{
+			GetterSource: "https://example.com/file.txt",
+			GetterOptions: map[string]string{
+				"negotiate":     "true",
+				"negotiate_spn": "HTTP/example.com",
+				"archive":       "false",
+			},
+		},
+		expURL: "https://example.com/file.txt?archive=false",
 		expErr: nil,
 	}}
 
@@ -70,6 +101,66 @@ func TestUtil_getURL(t *testing.T) {
 	}
 }
 
+func TestUtil_getURL_interpolation(t *testing.T) {
+	ci.Parallel(t)
+
+	env := varsTaskEnv("/path/to/task", map[string]string{
+		"ARTIFACT_VERSION": "1.2.3",
+		"ARTIFACT_SUM":     "abc123",
+	})
+	artifact := &structs.TaskArtifact{
+		GetterSource: "https://repo/${ARTIFACT_VERSION}/bundle.tar.gz?archive=${ARTIFACT_VERSION}",
+		GetterOptions: map[string]string{
+			"checksum": "sha256:${ARTIFACT_SUM}",
+			"ref":      "v${ARTIFACT_VERSION}",
+		},
+	}
+
+	result, err := getURL(env, artifact)
+	must.NoError(t, err)
+	must.Eq(t, "https://repo/1.2.3/bundle.tar.gz?archive=1.2.3&checksum=sha256%3Aabc123&ref=v1.2.3", result)
+	must.Eq(t, "sha256:abc123", getChecksum(env, artifact))
+}
+
+func TestUtil_checkTreeChecksum(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("a"), 0o644))
+	must.NoError(t, os.Mkdir(filepath.Join(root, "sub"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("b"), 0o644))
+	must.NoError(t, os.Symlink("a.txt", filepath.Join(root, "link")))
+
+	// the digest of the documented canonical form of the tree
+	const digest = "a0af19dcfccff7e5980a4483cfe5d57fcc27f34bc30716d1b4815ea8b60d537a"
+
+	artifact := func(checksum string) *structs.TaskArtifact {
+		return &structs.TaskArtifact{
+			GetterSource:  "git::github.com/hashicorp/nomad",
+			GetterOptions: map[string]string{"checksum": checksum},
+		}
+	}
+
+	env := noopTaskEnv("/path/to/task")
+	must.NoError(t, checkTreeChecksum(env, artifact("tree-sha256:"+digest), root))
+	must.NoError(t, checkTreeChecksum(env, artifact("tree-sha256:"+strings.ToUpper(digest)), root))
+
+	// ordinary checksums are verified by go-getter
+	must.NoError(t, checkTreeChecksum(env, artifact("sha256:"+digest), root))
+
+	// version control metadata is not part of the tree
+	must.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "objects"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(root, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(root, "sub", ".git"), []byte("gitdir: ../.git/modules/sub"), 0o644))
+	must.NoError(t, os.Mkdir(filepath.Join(root, ".hg"), 0o755))
+	must.NoError(t, checkTreeChecksum(env, artifact("tree-sha256:"+digest), root))
+
+	must.NoError(t, os.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("c"), 0o644))
+	err := checkTreeChecksum(env, artifact("tree-sha256:"+digest), root)
+	must.ErrorContains(t, err, "artifact tree checksum mismatch")
+	must.False(t, err.(*Error).IsRecoverable())
+}
+
 func TestUtil_getDestination(t *testing.T) {
 	ci.Parallel(t)
 
@@ -89,6 +180,25 @@ func TestUtil_getDestination(t *testing.T) {
 		must.EqError(t, err, "artifact destination path escapes alloc directory")
 		must.Eq(t, "", result)
 	})
+
+	t.Run("interpolation", func(t *testing.T) {
+		env := varsTaskEnv("/path/to/alloc/task", map[string]string{
+			"ARTIFACT_VERSION": "1.2.3",
+			"ESCAPE":           "../../../../etc",
+		})
+
+		result, err := getDestination(env, &structs.TaskArtifact{
+			RelativeDest: "local/${ARTIFACT_VERSION}",
+		})
+		must.NoError(t, err)
+		must.Eq(t, "/path/to/alloc/task/local/1.2.3", result)
+
+		// the interpolated destination must not escape the alloc directory
+		_, err = getDestination(env, &structs.TaskArtifact{
+			RelativeDest: "${ESCAPE}",
+		})
+		must.EqError(t, err, "artifact destination path escapes alloc directory")
+	})
 }
 
 func TestUtil_getMode(t *testing.T) {
@@ -120,7 +230,7 @@ func TestUtil_getHeaders(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		result := getHeaders(env, &structs.TaskArtifact{
 			GetterHeaders: nil,
-		})
+		}, nil)
 		must.Nil(t, result)
 	})
 
@@ -130,12 +240,31 @@ func TestUtil_getHeaders(t *testing.T) {
 				"color":  "red",
 				"number": "six",
 			},
-		})
+		}, nil)
 		must.MapEq(t, map[string][]string{
 			"Color":  {"RED"},
 			"Number": {"SIX"},
 		}, result)
 	})
+
+	t.Run("defaults", func(t *testing.T) {
+		defaults := http.Header{
+			"Accept": {"application/octet-stream"},
+			"Color":  {"blue"},
+		}
+		result := getHeaders(env, &structs.TaskArtifact{
+			GetterHeaders: map[string]string{
+				"color": "red",
+			},
+		}, defaults)
+		must.MapEq(t, map[string][]string{
+			"Accept": {"application/octet-stream"},
+			"Color":  {"RED"},
+		}, result)
+
+		// the defaults are not modified
+		must.Eq(t, []string{"blue"}, defaults["Color"])
+	})
 }
 
 func TestUtil_getTaskDir(t *testing.T) {
@@ -243,58 +372,50 @@ func TestUtil_environment(t *testing.T) {
 	})
 }
 
-func TestUtil_isPathWithin(t *testing.T) {
-	tdir := t.TempDir()
-	pathFn := func(parent string) string {
-		dir, err := os.MkdirTemp(parent, "testing-path")
-		must.NoError(t, err, must.Sprint("failed to create temporary directory"))
-		return dir
-	}
+func TestUtil_genWalkInspector(t *testing.T) {
+	ci.Parallel(t)
 
-	t.Run("when path not within root", func(t *testing.T) {
-		root := pathFn(tdir)
-		check := pathFn(tdir)
-		result, err := isPathWithin(root, check)
+	root := t.TempDir()
+	outside := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(root, "file"), []byte("ok"), 0o644))
+	must.NoError(t, os.Symlink("file", filepath.Join(root, "good")))
 
-		must.NoError(t, err)
-		must.False(t, result)
-	})
+	inspector, err := genWalkInspector(testlog.HCLogger(t), root, nil)
+	must.NoError(t, err)
+	must.NoError(t, filepath.WalkDir(root, inspector))
 
-	t.Run("when path within root", func(t *testing.T) {
-		root := pathFn(tdir)
-		check := pathFn(root)
-		result, err := isPathWithin(root, check)
+	bad := filepath.Join(root, "bad")
+	must.NoError(t, os.Symlink(outside, bad))
 
-		must.NoError(t, err)
-		must.True(t, result)
-	})
+	err = filepath.WalkDir(root, inspector)
+	must.ErrorIs(t, err, ErrSandboxEscape)
+	must.ErrorContains(t, err, bad+" resolves to "+outside)
+}
+
+func TestUtil_InspectDir(t *testing.T) {
+	ci.Parallel(t)
 
-	t.Run("when root within path", func(t *testing.T) {
-		check := pathFn(tdir)
-		root := pathFn(check)
-		result, err := isPathWithin(root, check)
+	root := t.TempDir()
+	outside := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(root, "a", "b"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(root, "a", "file"), []byte("ok"), 0o644))
+	must.NoError(t, os.Symlink("../file", filepath.Join(root, "a", "b", "good")))
+	must.NoError(t, InspectDir(root))
 
-		must.NoError(t, err)
-		must.False(t, result)
-	})
+	bad := filepath.Join(root, "a", "b", "bad")
+	must.NoError(t, os.Symlink("../../../"+filepath.Base(outside), bad))
 
-	t.Run("when path does not exist", func(t *testing.T) {
-		root := filepath.Join(tdir, "missing")
-		check := filepath.Join(root, "unknown")
-		result, err := isPathWithin(root, check)
+	resolved, err := filepath.EvalSymlinks(bad)
+	must.NoError(t, err)
 
-		must.ErrorContains(t, err, "no such file or directory")
-		must.False(t, result)
-	})
+	err = InspectDir(root)
+	must.ErrorIs(t, err, ErrSandboxEscape)
 
-	t.Run("when path not within root but shorter", func(t *testing.T) {
-		root, err := os.MkdirTemp(tdir, "testing-path-XXX")
-		must.NoError(t, err)
-		check, err := os.MkdirTemp(tdir, "testing-path-XXXX")
-		must.NoError(t, err)
-		result, err := isPathWithin(root, check)
+	var escapeErr *SandboxEscapeError
+	must.True(t, errors.As(err, &escapeErr))
+	must.Eq(t, bad, escapeErr.Path)
+	must.Eq(t, resolved, escapeErr.Resolved)
 
-		must.NoError(t, err)
-		must.False(t, result)
-	})
+	// the inspection used for downloaded artifacts reports the same symlink
+	must.EqError(t, inspectDir(testlog.HCLogger(t), root, nil), err.Error())
 }
@@ -8,10 +8,18 @@ package getter
 import (
 	"os"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 )
 
+// socketAccessible is not implemented on Windows, where connecting to the
+// socket reports any access errors
+func socketAccessible(string) error {
+	return nil
+}
+
 // lockdown is not implemented on Windows
 func lockdownAvailable() bool {
 	return false
@@ -34,3 +42,25 @@ func defaultEnvironment(taskDir string) map[string]string {
 		"TEMP":        tmpDir,
 	}
 }
+
+// postCmdSysProcAttr is not implemented on Windows, where the artifact post
+// command runs as the same user as the getter sub-process
+func postCmdSysProcAttr(string) (*syscall.SysProcAttr, error) {
+	return nil, nil
+}
+
+// changeTime returns the modification time of the file at path as an
+// approximation, as Windows does not record when a file last changed.
+func changeTime(path string) (time.Time, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// chmodNoFollow changes the mode of the file at path to mode. Windows has no
+// setuid, setgid, or sticky bits, so it is never called for them.
+func chmodNoFollow(path string, mode os.FileMode) error {
+	return os.Chmod(path, mode)
+}
@@ -4,10 +4,13 @@
 package getter
 
 import (
+	"errors"
 	"os"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/helper/subproc"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 const (
@@ -31,6 +34,11 @@ func init() {
 			return subproc.ExitFailure
 		}
 
+		// report the statistics of the download to the client, even if it
+		// fails
+		env.stats = new(fetchStats)
+		defer env.stats.write(os.Stdout)
+
 		// create context with the overall timeout
 		ctx, cancel := subproc.Context(env.deadline())
 		defer cancel()
@@ -46,27 +54,161 @@ func init() {
 			}
 		}
 
-		// create the go-getter client
-		// options were already transformed into url query parameters
-		// headers were already replaced and are usable now
-		c := env.client(ctx)
+		// the files changed from now on are those of the artifact, which
+		// are checked once it is in place
+		since := time.Now().Add(-changeTimeSlack)
+
+		if env.CacheSource != "" {
+			// restore the artifact from the cache instead of downloading it
+			if err := restoreCached(env.CacheSource, env.Destination, env.AllocDir); err != nil {
+				subproc.Print("failed to restore cached artifact: %v", err)
+				return subproc.ExitFailure
+			}
+		} else if structs.IsDataURI(env.Source) {
+			// write the content embedded in the job instead of
+			// downloading it
+			if err := getDataURI(env); err != nil {
+				subproc.Print("failed to decode artifact: %v", err)
+				if errors.Is(err, ErrMaxBytesExceeded) {
+					return exitMaxBytesExceeded
+				}
+				return subproc.ExitFailure
+			}
+		} else {
+			// obtain the Kerberos credentials requests are authenticated
+			// with, if any
+			if err := env.negotiateLogin(); err != nil {
+				subproc.Print("failed to authenticate artifact download: %v", err)
+				return exitAuthFailure
+			}
+
+			// log in to servers which only serve the artifact to an
+			// authenticated session
+			if err := env.preAuthenticate(ctx); err != nil {
+				subproc.Print("failed to authenticate artifact download: %v", err)
+				return subproc.ExitFailure
+			}
+
+			// servers may serve archives from extensionless endpoints, in
+			// which case the content type decides whether to extract them
+			env.Source = archiveFromContentType(ctx, env)
+
+			// reject oversized artifacts before downloading them
+			if env.HTTPSizePreflight {
+				if err := checkContentLength(ctx, env); err != nil {
+					subproc.Print("failed to download artifact: %v", err)
+					return exitMaxBytesExceeded
+				}
+			}
+
+			// sign s3 requests with the credentials issued for this
+			// download, if any
+			if err := env.setAWSCredentials(); err != nil {
+				subproc.Print("failed to set artifact AWS credentials: %v", err)
+				return subproc.ExitFailure
+			}
+
+			// connect git to http(s) remotes through the proxy, if any
+			if err := env.setProxy(); err != nil {
+				subproc.Print("failed to set artifact proxy: %v", err)
+				return subproc.ExitFailure
+			}
+
+			// connect git and hg to ssh remotes with the private key and
+			// known hosts of the artifact, if any
+			if err := env.setSSH(); err != nil {
+				subproc.Print("failed to set artifact ssh configuration: %v", err)
+				return subproc.ExitFailure
+			}
+
+			// create the go-getter client
+			// options were already transformed into url query parameters
+			// headers were already replaced and are usable now
+			c, err := env.client(ctx)
+			if err != nil {
+				subproc.Print("failed to create artifact client: %v", err)
+				return subproc.ExitFailure
+			}
+
+			// run the go-getter client
+			if err := c.Get(); err != nil {
+				err = manifestError(c, err)
+				err = contentTypeError(c, err)
+				err = negotiateError(env, err)
+				subproc.Print("failed to download artifact: %v", err)
+				switch {
+				case errors.Is(err, ErrTruncatedArchive):
+					return exitTruncatedArchive
+				case errors.Is(err, ErrCaseCollision):
+					return exitCaseCollision
+				case errors.Is(err, ErrTooManyFilesInDir):
+					return exitTooManyFilesInDir
+				case errors.Is(err, ErrSymlinkDisallowed):
+					return exitSymlinkDisallowed
+				case errors.Is(err, ErrSetuidDisallowed):
+					return exitSetuidDisallowed
+				case errors.Is(err, ErrMaxBytesExceeded):
+					return exitMaxBytesExceeded
+				case errors.Is(err, ErrPolicyDenied):
+					return exitPolicyDenied
+				case isNotFound(err):
+					return exitNotFound
+				case errors.Is(err, ErrContentTypeMismatch):
+					return exitContentTypeMismatch
+				case errors.Is(err, ErrAuthFailure):
+					return exitAuthFailure
+				case isHostNotFound(err):
+					return exitHostNotFound
+				case isNetworkFailure(err):
+					return exitNetworkFailure
+				}
+				return subproc.ExitFailure
+			}
+
+			// hash the artifact written if its content was not
+			// received in a single response, such as from git
+			if err := env.stats.recordWritten(env.Destination, since); err != nil {
+				l.Warn("failed to hash artifact", "error", err)
+			}
+		}
+
+		// skip or refuse the symlinks the artifact holds if they are
+		// disallowed, including those not extracted from an archive
+		if err := checkSymlinks(env.Destination, env.DisallowSymlinks, since); err != nil {
+			subproc.Print("failed to download artifact: %v", err)
+			if errors.Is(err, ErrSymlinkDisallowed) {
+				return exitSymlinkDisallowed
+			}
+			return subproc.ExitFailure
+		}
 
-		// run the go-getter client
-		if err := c.Get(); err != nil {
+		// strip or refuse the setuid, setgid, and sticky bits of the
+		// artifact, including those of files not extracted from an archive
+		if err := checkSetuid(env.Destination, env.StripSetuid, since); err != nil {
 			subproc.Print("failed to download artifact: %v", err)
+			if errors.Is(err, ErrSetuidDisallowed) {
+				return exitSetuidDisallowed
+			}
 			return subproc.ExitFailure
 		}
 
 		// chown the resulting artifact to the task user, but only if configured
 		// to do so in the artifact block (for compatibility)
 		if env.Chown {
-			err := chownDestination(env.Destination, env.User)
+			err := chownDestination(env.Destination, env.User, env.Owner, env.Group, env.ChownMode)
 			if err != nil {
 				subproc.Print("failed to chown artifact: %v", err)
 				return subproc.ExitFailure
 			}
 		}
 
+		// normalize the artifact now that it is in place and owned by the
+		// task user
+		if err := env.runPostCmd(ctx); err != nil {
+			subproc.Print("failed to run artifact post command: %v", err)
+			return exitPostCmdFailed
+		}
+
 		subproc.Print("artifact download was a success")
 		return subproc.ExitSuccess
 	})
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// nodePayload is the content of the node payload file.
+type nodePayload struct {
+	Meta map[string]string `json:"meta"`
+	Attr map[string]string `json:"attr"`
+}
+
+// nodePayloadHook writes the node meta and attributes selected by the node
+// payload block of the task to the task dir
+type nodePayloadHook struct {
+	// node returns the current node, whose dynamic meta may have changed
+	// since the allocation was placed
+	node func() *structs.Node
+
+	logger hclog.Logger
+}
+
+func newNodePayloadHook(node func() *structs.Node, logger hclog.Logger) *nodePayloadHook {
+	h := &nodePayloadHook{
+		node: node,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (*nodePayloadHook) Name() string {
+	return "node_payload"
+}
+
+func (h *nodePayloadHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	config := req.Task.NodePayload
+	if config == nil {
+		resp.Done = true
+		return nil
+	}
+
+	payload, err := buildNodePayload(h.node(), config)
+	if err != nil {
+		// the node meta may yet be set, so the task is restarted
+		return structs.NewRecoverableError(err, true)
+	}
+
+	if err := writeNodePayload(req.TaskDir.Dir, payload); err != nil {
+		return err
+	}
+
+	h.logger.Trace("node payload written",
+		"path", req.TaskDir.LocalDir,
+		"meta", len(payload.Meta),
+		"attr", len(payload.Attr),
+	)
+
+	// Node payload written successfully; mark as done
+	resp.Done = true
+	return nil
+}
+
+// buildNodePayload returns the node meta and attributes selected by config.
+// Keys the node has no value for are given an empty value, or fail the payload
+// if config is strict.
+func buildNodePayload(node *structs.Node, config *structs.NodePayloadConfig) (*nodePayload, error) {
+	payload := &nodePayload{
+		Meta: make(map[string]string, len(config.MetaKeys)),
+		Attr: make(map[string]string, len(config.AttrKeys)),
+	}
+
+	var missing []string
+	for _, key := range config.MetaKeys {
+		value, ok := node.Meta[key]
+		if !ok {
+			missing = append(missing, "meta."+key)
+		}
+		payload.Meta[key] = value
+	}
+	for _, key := range config.AttrKeys {
+		value, ok := node.Attributes[key]
+		if !ok {
+			missing = append(missing, "attr."+key)
+		}
+		payload.Attr[key] = value
+	}
+
+	if config.Strict && len(missing) > 0 {
+		return nil, fmt.Errorf("node has no value for node payload keys: %s", strings.Join(missing, ", "))
+	}
+	return payload, nil
+}
+
+// writeNodePayload writes the payload as JSON to the node payload file in the
+// local directory of the given task directory. The task may have written to its
+// directory, so the file is written beneath it without following symlinks
+// which lead outside of it.
+func writeNodePayload(taskDir string, payload *nodePayload) error {
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	root, err := os.OpenRoot(taskDir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	if err := root.MkdirAll(allocdir.TaskLocal, 0777); err != nil {
+		return err
+	}
+
+	return root.WriteFile(filepath.Join(allocdir.TaskLocal, structs.NodePayloadFile), b, 0644)
+}
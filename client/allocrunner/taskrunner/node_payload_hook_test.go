@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/shoenig/test/must"
+)
+
+// Statically assert the node payload hook implements the expected interfaces
+var _ interfaces.TaskPrestartHook = (*nodePayloadHook)(nil)
+
+// TestTaskRunner_NodePayloadHook asserts that the selected node meta and
+// attributes are written to the node payload file, with empty values for keys
+// the node does not have unless the payload is strict.
+func TestTaskRunner_NodePayloadHook(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.SysBatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	allocDir := allocdir.NewAllocDir(logger, t.TempDir(), t.TempDir(), alloc.ID)
+	t.Cleanup(func() { _ = allocDir.Destroy() })
+	taskDir := allocDir.NewTaskDir(task)
+	must.NoError(t, taskDir.Build(fsisolation.None, nil, task.User))
+
+	node := mock.Node()
+	node.Meta["disk_serial"] = "S3Z9NB0K"
+	node.Attributes["kernel.version"] = "6.8.0"
+	h := newNodePayloadHook(func() *structs.Node { return node }, logger)
+
+	prestart := func(config *structs.NodePayloadConfig) (*interfaces.TaskPrestartResponse, error) {
+		task := task.Copy()
+		task.NodePayload = config
+		req := &interfaces.TaskPrestartRequest{Task: task, TaskDir: taskDir}
+		resp := &interfaces.TaskPrestartResponse{}
+		return resp, h.Prestart(context.Background(), req, resp)
+	}
+	read := func() *nodePayload {
+		b, err := os.ReadFile(filepath.Join(taskDir.LocalDir, structs.NodePayloadFile))
+		must.NoError(t, err)
+		var payload nodePayload
+		must.NoError(t, json.Unmarshal(b, &payload))
+		return &payload
+	}
+
+	t.Run("none", func(t *testing.T) {
+		resp, err := prestart(nil)
+		must.NoError(t, err)
+		must.True(t, resp.Done)
+		must.FileNotExists(t, filepath.Join(taskDir.LocalDir, structs.NodePayloadFile))
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		resp, err := prestart(&structs.NodePayloadConfig{
+			MetaKeys: []string{"disk_serial", "rack"},
+			AttrKeys: []string{"kernel.version"},
+		})
+		must.NoError(t, err)
+		must.True(t, resp.Done)
+		must.Eq(t, &nodePayload{
+			Meta: map[string]string{"disk_serial": "S3Z9NB0K", "rack": ""},
+			Attr: map[string]string{"kernel.version": "6.8.0"},
+		}, read())
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		resp, err := prestart(&structs.NodePayloadConfig{
+			MetaKeys: []string{"disk_serial", "rack"},
+			AttrKeys: []string{"kernel.version"},
+			Strict:   true,
+		})
+		must.ErrorContains(t, err, "node has no value for node payload keys: meta.rack")
+		must.True(t, structs.IsRecoverable(err))
+		must.False(t, resp.Done)
+
+		// dynamic node meta is picked up when the task restarts
+		node.Meta["rack"] = "r1"
+		_, err = prestart(&structs.NodePayloadConfig{
+			MetaKeys: []string{"disk_serial", "rack"},
+			Strict:   true,
+		})
+		must.NoError(t, err)
+		must.Eq(t, map[string]string{"disk_serial": "S3Z9NB0K", "rack": "r1"}, read().Meta)
+	})
+
+	t.Run("symlink", func(t *testing.T) {
+		// a task plants a symlink to a host file at the payload path
+		host := filepath.Join(t.TempDir(), "host.txt")
+		must.NoError(t, os.WriteFile(host, []byte("host"), 0o644))
+		path := filepath.Join(taskDir.LocalDir, structs.NodePayloadFile)
+		must.NoError(t, os.Remove(path))
+		must.NoError(t, os.Symlink(host, path))
+
+		_, err := prestart(&structs.NodePayloadConfig{MetaKeys: []string{"disk_serial"}})
+		must.Error(t, err)
+
+		b, err := os.ReadFile(host)
+		must.NoError(t, err)
+		must.Eq(t, "host", string(b))
+	})
+}
@@ -196,7 +196,7 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 			serviceID := serviceregistration.MakeAllocServiceID(
 				h.alloc.ID, h.task.Name, service)
 			sc := newScriptCheck(&scriptCheckConfig{
-				consulNamespace: h.taskConsulNamespace,
+				consulNamespace: service.ConsulNamespace(h.taskConsulNamespace),
 				allocID:         h.alloc.ID,
 				taskName:        h.task.Name,
 				check:           check,
@@ -236,7 +236,7 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 			serviceID := serviceregistration.MakeAllocServiceID(
 				h.alloc.ID, groupTaskName, service)
 			sc := newScriptCheck(&scriptCheckConfig{
-				consulNamespace: h.groupConsulNamespace,
+				consulNamespace: service.ConsulNamespace(h.groupConsulNamespace),
 				allocID:         h.alloc.ID,
 				taskName:        groupTaskName,
 				check:           check,
@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-template/signals"
+	log "github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// serviceDiscoveryWatchTime is the maximum duration of the blocking
+	// queries watching the instances of an upstream for changes.
+	serviceDiscoveryWatchTime = 5 * time.Minute
+
+	// serviceDiscoveryRetryInterval is how long to wait before watching an
+	// upstream again after a failed query.
+	serviceDiscoveryRetryInterval = 10 * time.Second
+)
+
+// serviceDiscoveryHook sets the addresses of the Nomad services a task
+// discovers in its environment, and writes them to its hosts file, before the
+// task starts. The instances of each service are then watched for changes,
+// which are applied according to the change mode of its upstream.
+type serviceDiscoveryHook struct {
+	alloc     *structs.Allocation
+	rpc       config.RPCer
+	region    string
+	lifecycle ti.TaskLifecycle
+	logger    log.Logger
+
+	// lock guards the fields below, which are set on each prestart
+	lock sync.Mutex
+
+	// discovery is the service discovery block of the task
+	discovery *structs.ServiceDiscovery
+
+	// allocDir is the allocation directory the hosts file is written beneath
+	allocDir string
+
+	// hostsFile is the path of the hosts file relative to allocDir, if any
+	hostsFile string
+
+	// token is the Nomad token of the task the services are queried with
+	token string
+
+	// addrs are the addresses last discovered for each upstream, by name
+	addrs map[string][]string
+
+	// cancelFn stops watching the upstreams for changes
+	cancelFn context.CancelFunc
+}
+
+func newServiceDiscoveryHook(tr *TaskRunner, logger log.Logger) *serviceDiscoveryHook {
+	h := &serviceDiscoveryHook{
+		alloc:     tr.Alloc(),
+		rpc:       tr.rpcClient,
+		region:    tr.clientConfig.Region,
+		lifecycle: tr,
+		cancelFn:  func() {},
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+// statically assert the hook implements the expected interfaces
+var (
+	_ interfaces.TaskPrestartHook = (*serviceDiscoveryHook)(nil)
+	_ interfaces.TaskStopHook     = (*serviceDiscoveryHook)(nil)
+)
+
+func (*serviceDiscoveryHook) Name() string {
+	return "service_discovery"
+}
+
+func (h *serviceDiscoveryHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	discovery := req.Task.ServiceDiscovery
+	if discovery == nil {
+		resp.Done = true
+		return nil
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	// the instances are discovered again when the task restarts
+	h.cancelFn()
+
+	addrs := make(map[string][]string, len(discovery.Upstreams))
+	indexes := make(map[string]uint64, len(discovery.Upstreams))
+	env := make(map[string]string, len(discovery.Upstreams))
+	for _, u := range discovery.Upstreams {
+		regs, index, err := h.resolve(ctx, u, req.NomadToken)
+		if err != nil {
+			return err
+		}
+		addrs[u.Name] = upstreamAddrs(regs)
+		indexes[u.Name] = index
+		if u.Env != "" {
+			env[u.Env] = strings.Join(addrs[u.Name], ",")
+		}
+	}
+
+	hostsFile := ""
+	if discovery.HostsFile != "" {
+		// the hosts file may be written to the shared alloc directory, so it
+		// is written beneath the allocation directory rather than the task's
+		var err error
+		hostsFile, err = filepath.Rel(req.TaskDir.AllocDir, filepath.Join(req.TaskDir.Dir, discovery.HostsFile))
+		if err != nil {
+			return fmt.Errorf("failed to write hosts file: %w", err)
+		}
+		if err := writeUpstreamHosts(req.TaskDir.AllocDir, hostsFile, discovery.Upstreams, addrs); err != nil {
+			return fmt.Errorf("failed to write hosts file: %w", err)
+		}
+	}
+	resp.Env = env
+
+	h.discovery = discovery
+	h.allocDir = req.TaskDir.AllocDir
+	h.hostsFile = hostsFile
+	h.token = req.NomadToken
+	h.addrs = addrs
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	h.cancelFn = cancel
+	for _, u := range discovery.Upstreams {
+		go h.watch(watchCtx, u, indexes[u.Name])
+	}
+	return nil
+}
+
+func (h *serviceDiscoveryHook) Stop(_ context.Context, _ *interfaces.TaskStopRequest, _ *interfaces.TaskStopResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.cancelFn()
+	return nil
+}
+
+// resolve returns the instances of the service of the upstream, waiting up to
+// its wait timeout for one to be registered, along with the index to watch
+// them for changes from.
+func (h *serviceDiscoveryHook) resolve(ctx context.Context, u *structs.ServiceUpstream, token string) ([]*structs.ServiceRegistration, uint64, error) {
+	deadline := time.Now().Add(u.WaitTimeout)
+
+	var index uint64
+	for {
+		reply, err := h.query(u, token, index, time.Until(deadline))
+		if err != nil {
+			return nil, 0, structs.NewRecoverableError(
+				fmt.Errorf("failed to discover service %q for upstream %q: %w", u.Service, u.Name, err), true)
+		}
+		if len(reply.Services) > 0 {
+			return reply.Services, reply.Index, nil
+		}
+		index = reply.Index
+
+		if ctx.Err() != nil || !time.Now().Before(deadline) {
+			break
+		}
+	}
+
+	instance := "instance"
+	if u.WaitForHealthy {
+		instance = "healthy instance"
+	}
+	return nil, 0, structs.NewRecoverableError(
+		fmt.Errorf("no %s of service %q for upstream %q within %v", instance, u.Service, u.Name, u.WaitTimeout), true)
+}
+
+// query returns the instances of the service of the upstream once their index
+// is greater than index, or once maxQueryTime elapsed.
+func (h *serviceDiscoveryHook) query(u *structs.ServiceUpstream, token string, index uint64, maxQueryTime time.Duration) (*structs.ServiceRegistrationByNameResponse, error) {
+	args := &structs.ServiceRegistrationByNameRequest{
+		ServiceName: u.Service,
+		Healthy:     u.WaitForHealthy,
+		QueryOptions: structs.QueryOptions{
+			Region:        h.region,
+			Namespace:     h.alloc.Namespace,
+			AuthToken:     token,
+			AllowStale:    true,
+			MinQueryIndex: index,
+			// a zero max query time would block for the server default
+			MaxQueryTime: max(maxQueryTime, time.Millisecond),
+		},
+	}
+	if u.Strategy == structs.ServiceUpstreamStrategyOne {
+		// the instance is chosen consistently for the allocation
+		args.Choose = "1|" + h.alloc.ID
+	}
+
+	var reply structs.ServiceRegistrationByNameResponse
+	if err := h.rpc.RPC(structs.ServiceRegistrationGetServiceRPCMethod, args, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// watch watches the instances of the service of the upstream for changes
+// from index until ctx is canceled, applying its change mode when they do.
+func (h *serviceDiscoveryHook) watch(ctx context.Context, u *structs.ServiceUpstream, index uint64) {
+	h.lock.Lock()
+	token := h.token
+	h.lock.Unlock()
+
+	for {
+		reply, err := h.query(u, token, index, serviceDiscoveryWatchTime)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			h.logger.Warn("failed to watch upstream", "upstream", u.Name, "service", u.Service, "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(serviceDiscoveryRetryInterval):
+				continue
+			}
+		}
+		if reply.Index == index {
+			continue
+		}
+		index = reply.Index
+
+		if len(reply.Services) == 0 {
+			// the addresses last discovered are kept until an instance is
+			// registered again, since the task would not start without one
+			h.logger.Warn("no instance of upstream service", "upstream", u.Name, "service", u.Service)
+			continue
+		}
+		if !h.update(ctx, u, upstreamAddrs(reply.Services)) {
+			return
+		}
+	}
+}
+
+// update records the addresses discovered for the upstream and, if they
+// changed, rewrites the hosts file and applies the change mode of the
+// upstream. It returns false if the task was restarted, or failed to restart
+// or be signaled, and the upstream should no longer be watched.
+func (h *serviceDiscoveryHook) update(ctx context.Context, u *structs.ServiceUpstream, addrs []string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if ctx.Err() != nil {
+		return false
+	}
+	if slices.Equal(h.addrs[u.Name], addrs) {
+		return true
+	}
+	h.addrs[u.Name] = addrs
+	h.logger.Debug("upstream changed", "upstream", u.Name, "service", u.Service, "addrs", addrs)
+
+	if h.hostsFile != "" {
+		if err := writeUpstreamHosts(h.allocDir, h.hostsFile, h.discovery.Upstreams, h.addrs); err != nil {
+			h.logger.Error("failed to write hosts file", "path", h.hostsFile, "error", err)
+		}
+	}
+
+	switch u.ChangeMode {
+	case structs.ServiceUpstreamChangeModeRestart:
+		const noFailure = false
+		err := h.lifecycle.Restart(ctx, structs.NewTaskEvent(structs.TaskRestartSignal).
+			SetDisplayMessage(fmt.Sprintf("Upstream[%s]: service %q changed", u.Name, u.Service)), noFailure)
+		if err != nil {
+			// Ignore error from kill because if that fails there's really
+			// nothing to be done.
+			_ = h.lifecycle.Kill(ctx, structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(fmt.Sprintf("Upstream[%s]: failed to restart: %v", u.Name, err)))
+		}
+		return false
+
+	case structs.ServiceUpstreamChangeModeSignal:
+		if err := h.signalTask(u); err != nil {
+			h.logger.Error("failed to send signal", "upstream", u.Name, "signal", u.ChangeSignal)
+			// Ignore error from kill because if that fails there's really
+			// nothing to be done.
+			_ = h.lifecycle.Kill(ctx, structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(fmt.Sprintf("Upstream[%s]: failed to send signal: %v", u.Name, err)))
+			return false
+		}
+	}
+	return true
+}
+
+// signalTask sends the change signal of the upstream to the task.
+func (h *serviceDiscoveryHook) signalTask(u *structs.ServiceUpstream) error {
+	s, err := signals.Parse(u.ChangeSignal)
+	if err != nil {
+		return fmt.Errorf("failed to parse signal: %w", err)
+	}
+
+	event := structs.NewTaskEvent(structs.TaskSignaling).
+		SetTaskSignal(s).
+		SetDisplayMessage(fmt.Sprintf("Upstream[%s]: service %q changed", u.Name, u.Service))
+	return h.lifecycle.Signal(event, u.ChangeSignal)
+}
+
+// upstreamAddrs returns the host:port addresses of the instances of a service,
+// sorted so that they can be compared.
+func upstreamAddrs(regs []*structs.ServiceRegistration) []string {
+	addrs := make([]string, 0, len(regs))
+	for _, reg := range regs {
+		addrs = append(addrs, net.JoinHostPort(reg.Address, strconv.Itoa(reg.Port)))
+	}
+	slices.Sort(addrs)
+	return addrs
+}
+
+// writeUpstreamHosts writes the hosts file at path relative to allocDir, with a
+// line mapping the host of each address of each upstream to the name of the
+// upstream. The file is written through a root opened at allocDir, so that
+// symlinks the task created cannot redirect the write outside of it.
+func writeUpstreamHosts(allocDir, path string, upstreams []*structs.ServiceUpstream, addrs map[string][]string) error {
+	var b strings.Builder
+	b.WriteString("# Nomad service discovery upstreams\n")
+	for _, u := range upstreams {
+		for _, addr := range addrs[u.Name] {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&b, "%s\t%s\n", host, u.Name)
+		}
+	}
+
+	root, err := os.OpenRoot(allocDir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	if err := root.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// write to a temporary file renamed into place, so that the task never
+	// reads a partially written file
+	tmp := path + ".tmp"
+	if err := root.WriteFile(tmp, []byte(b.String()), 0o644); err != nil {
+		return err
+	}
+	return root.Rename(tmp, path)
+}
@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// mockServiceRPC is a config.RPCer serving the registrations of a service,
+// blocking queries until they change.
+type mockServiceRPC struct {
+	lock     sync.Mutex
+	index    uint64
+	services []*structs.ServiceRegistration
+	changeCh chan struct{}
+	requests []*structs.ServiceRegistrationByNameRequest
+}
+
+func newMockServiceRPC(services ...*structs.ServiceRegistration) *mockServiceRPC {
+	return &mockServiceRPC{
+		index:    1,
+		services: services,
+		changeCh: make(chan struct{}),
+	}
+}
+
+func (m *mockServiceRPC) RPC(method string, args, reply any) error {
+	req := args.(*structs.ServiceRegistrationByNameRequest)
+
+	m.lock.Lock()
+	m.requests = append(m.requests, req)
+	changeCh := m.changeCh
+	blocked := req.MinQueryIndex >= m.index
+	m.lock.Unlock()
+
+	if blocked {
+		select {
+		case <-changeCh:
+		case <-time.After(req.MaxQueryTime):
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	resp := reply.(*structs.ServiceRegistrationByNameResponse)
+	resp.Services = m.services
+	resp.Index = m.index
+	return nil
+}
+
+func (m *mockServiceRPC) setServices(services ...*structs.ServiceRegistration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.index++
+	m.services = services
+	close(m.changeCh)
+	m.changeCh = make(chan struct{})
+}
+
+func (m *mockServiceRPC) lastRequest() *structs.ServiceRegistrationByNameRequest {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.requests[len(m.requests)-1]
+}
+
+func testServiceDiscoveryHook(t *testing.T, rpc *mockServiceRPC) (*serviceDiscoveryHook, *trtesting.MockTaskHooks) {
+	lifecycle := trtesting.NewMockTaskHooks()
+	h := &serviceDiscoveryHook{
+		alloc:     mock.Alloc(),
+		rpc:       rpc,
+		region:    "global",
+		lifecycle: lifecycle,
+		logger:    testlog.HCLogger(t),
+		cancelFn:  func() {},
+	}
+	t.Cleanup(func() {
+		must.NoError(t, h.Stop(context.Background(), nil, nil))
+	})
+	return h, lifecycle
+}
+
+func testServiceRegistration(address string, port int) *structs.ServiceRegistration {
+	return &structs.ServiceRegistration{ServiceName: "postgres", Address: address, Port: port}
+}
+
+func TestServiceDiscoveryHook_Prestart(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := newMockServiceRPC(
+		testServiceRegistration("10.0.0.2", 5432),
+		testServiceRegistration("10.0.0.1", 5432),
+	)
+	h, _ := testServiceDiscoveryHook(t, rpc)
+
+	dir := t.TempDir()
+	req := &interfaces.TaskPrestartRequest{
+		Task: &structs.Task{
+			ServiceDiscovery: &structs.ServiceDiscovery{
+				HostsFile: "local/hosts",
+				Upstreams: []*structs.ServiceUpstream{{
+					Name:        "db",
+					Service:     "postgres",
+					Env:         "DB_ADDR",
+					Strategy:    structs.ServiceUpstreamStrategyAll,
+					WaitTimeout: time.Second,
+					ChangeMode:  structs.ServiceUpstreamChangeModeNoop,
+				}},
+			},
+		},
+		TaskDir:    &allocdir.TaskDir{AllocDir: dir, Dir: dir},
+		NomadToken: "token",
+	}
+	resp := &interfaces.TaskPrestartResponse{}
+	must.NoError(t, h.Prestart(context.Background(), req, resp))
+	must.Eq(t, map[string]string{"DB_ADDR": "10.0.0.1:5432,10.0.0.2:5432"}, resp.Env)
+
+	b, err := os.ReadFile(filepath.Join(dir, "local", "hosts"))
+	must.NoError(t, err)
+	must.StrContains(t, string(b), "10.0.0.1\tdb\n10.0.0.2\tdb\n")
+
+	last := rpc.lastRequest()
+	must.Eq(t, "postgres", last.ServiceName)
+	must.Eq(t, "token", last.AuthToken)
+	must.Eq(t, "", last.Choose)
+}
+
+func TestServiceDiscoveryHook_Prestart_strategyOne(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := newMockServiceRPC(testServiceRegistration("10.0.0.1", 5432))
+	h, _ := testServiceDiscoveryHook(t, rpc)
+
+	req := &interfaces.TaskPrestartRequest{
+		Task: &structs.Task{
+			ServiceDiscovery: &structs.ServiceDiscovery{
+				Upstreams: []*structs.ServiceUpstream{{
+					Name:           "db",
+					Service:        "postgres",
+					Env:            "DB_ADDR",
+					Strategy:       structs.ServiceUpstreamStrategyOne,
+					WaitForHealthy: true,
+					WaitTimeout:    time.Second,
+					ChangeMode:     structs.ServiceUpstreamChangeModeNoop,
+				}},
+			},
+		},
+		TaskDir: &allocdir.TaskDir{Dir: t.TempDir()},
+	}
+	resp := &interfaces.TaskPrestartResponse{}
+	must.NoError(t, h.Prestart(context.Background(), req, resp))
+	must.Eq(t, "10.0.0.1:5432", resp.Env["DB_ADDR"])
+
+	last := rpc.lastRequest()
+	must.Eq(t, "1|"+h.alloc.ID, last.Choose)
+	must.True(t, last.Healthy)
+}
+
+func TestServiceDiscoveryHook_Prestart_timeout(t *testing.T) {
+	ci.Parallel(t)
+
+	h, _ := testServiceDiscoveryHook(t, newMockServiceRPC())
+
+	req := &interfaces.TaskPrestartRequest{
+		Task: &structs.Task{
+			ServiceDiscovery: &structs.ServiceDiscovery{
+				Upstreams: []*structs.ServiceUpstream{{
+					Name:           "db",
+					Service:        "postgres",
+					Env:            "DB_ADDR",
+					Strategy:       structs.ServiceUpstreamStrategyAll,
+					WaitForHealthy: true,
+					WaitTimeout:    100 * time.Millisecond,
+					ChangeMode:     structs.ServiceUpstreamChangeModeNoop,
+				}},
+			},
+		},
+		TaskDir: &allocdir.TaskDir{Dir: t.TempDir()},
+	}
+	err := h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{})
+	must.EqError(t, err, `no healthy instance of service "postgres" for upstream "db" within 100ms`)
+	must.True(t, structs.IsRecoverable(err))
+}
+
+func TestServiceDiscoveryHook_Prestart_symlink(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := newMockServiceRPC(testServiceRegistration("10.0.0.1", 5432))
+	h, _ := testServiceDiscoveryHook(t, rpc)
+
+	// the task replaced its local directory with a symlink to a host
+	// directory, which the hosts file must not be written to
+	dir := t.TempDir()
+	host := t.TempDir()
+	must.NoError(t, os.Symlink(host, filepath.Join(dir, "local")))
+
+	req := &interfaces.TaskPrestartRequest{
+		Task: &structs.Task{
+			ServiceDiscovery: &structs.ServiceDiscovery{
+				HostsFile: "local/hosts",
+				Upstreams: []*structs.ServiceUpstream{{
+					Name:        "db",
+					Service:     "postgres",
+					Strategy:    structs.ServiceUpstreamStrategyAll,
+					WaitTimeout: time.Second,
+					ChangeMode:  structs.ServiceUpstreamChangeModeNoop,
+				}},
+			},
+		},
+		TaskDir: &allocdir.TaskDir{AllocDir: dir, Dir: dir},
+	}
+	err := h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{})
+	must.ErrorContains(t, err, "failed to write hosts file")
+
+	entries, err := os.ReadDir(host)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+}
+
+func TestServiceDiscoveryHook_changeMode(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name       string
+		changeMode string
+		signal     string
+		expRestart bool
+		expSignal  bool
+	}{
+		{name: "restart", changeMode: structs.ServiceUpstreamChangeModeRestart, expRestart: true},
+		{name: "signal", changeMode: structs.ServiceUpstreamChangeModeSignal, signal: "SIGHUP", expSignal: true},
+		{name: "noop", changeMode: structs.ServiceUpstreamChangeModeNoop},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rpc := newMockServiceRPC(testServiceRegistration("10.0.0.1", 5432))
+			h, lifecycle := testServiceDiscoveryHook(t, rpc)
+
+			dir := t.TempDir()
+			req := &interfaces.TaskPrestartRequest{
+				Task: &structs.Task{
+					ServiceDiscovery: &structs.ServiceDiscovery{
+						HostsFile: "hosts",
+						Upstreams: []*structs.ServiceUpstream{{
+							Name:         "db",
+							Service:      "postgres",
+							Strategy:     structs.ServiceUpstreamStrategyAll,
+							WaitTimeout:  time.Second,
+							ChangeMode:   tc.changeMode,
+							ChangeSignal: tc.signal,
+						}},
+					},
+				},
+				TaskDir: &allocdir.TaskDir{AllocDir: dir, Dir: dir},
+			}
+			must.NoError(t, h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{}))
+
+			rpc.setServices(testServiceRegistration("10.0.0.2", 5432))
+
+			select {
+			case <-lifecycle.RestartCh:
+				must.True(t, tc.expRestart)
+			case <-lifecycle.SignalCh:
+				must.True(t, tc.expSignal)
+				must.Eq(t, []string{"SIGHUP"}, lifecycle.Signals())
+			case <-time.After(time.Second):
+				must.False(t, tc.expRestart || tc.expSignal)
+			}
+
+			// the hosts file is rewritten regardless of the change mode
+			must.Wait(t, wait.InitialSuccess(
+				wait.BoolFunc(func() bool {
+					b, _ := os.ReadFile(filepath.Join(dir, "hosts"))
+					return strings.Contains(string(b), "10.0.0.2\tdb\n")
+				}),
+				wait.Timeout(time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+		})
+	}
+}
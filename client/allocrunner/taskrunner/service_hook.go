@@ -258,6 +258,7 @@ func (h *serviceHook) getWorkloadServices() *serviceregistration.WorkloadService
 		DriverExec:        h.driverExec,
 		DriverNetwork:     h.driverNet,
 		Networks:          h.networks,
+		AdvertiseMode:     h.tg.AdvertiseMode,
 		Canary:            h.canary,
 		Ports:             h.ports,
 		Tokens:            tokens,
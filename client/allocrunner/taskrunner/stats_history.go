@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// statsHistory is a ring buffer of the recent resource usage of a task, so that
+// the usage leading up to the task exiting, such as when it runs out of
+// memory, can be inspected. It holds at most one sample per resolution over
+// duration, and is kept for duration after the task exits.
+type statsHistory struct {
+	duration   time.Duration
+	resolution time.Duration
+
+	// samples is the ring buffer, and next is the index the next sample is
+	// written at, which holds the oldest sample once the buffer is full
+	samples []*cstructs.TaskResourceUsage
+	next    int
+	full    bool
+
+	// exitedAt is when the task last exited, or zero while it runs
+	exitedAt time.Time
+
+	mu sync.Mutex
+}
+
+// newStatsHistory returns the resource usage history of a task, or nil if the
+// history is disabled.
+func newStatsHistory(duration, resolution time.Duration) *statsHistory {
+	if duration <= 0 || resolution <= 0 {
+		return nil
+	}
+	return &statsHistory{
+		duration:   duration,
+		resolution: resolution,
+		samples:    make([]*cstructs.TaskResourceUsage, max(int(duration/resolution), 1)),
+	}
+}
+
+// add records the CPU and memory usage of ru, unless the latest sample is more
+// recent than the resolution of the history. The usage of individual processes
+// and devices is left out to bound the size of the history.
+func (h *statsHistory) add(ru *cstructs.TaskResourceUsage) {
+	if h == nil || ru == nil || ru.ResourceUsage == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if latest := h.latest(); latest != nil && time.Duration(ru.Timestamp-latest.Timestamp) < h.resolution {
+		return
+	}
+
+	h.samples[h.next] = &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: ru.ResourceUsage.MemoryStats,
+			CpuStats:    ru.ResourceUsage.CpuStats,
+		},
+		Timestamp: ru.Timestamp,
+	}
+	h.next = (h.next + 1) % len(h.samples)
+	if h.next == 0 {
+		h.full = true
+	}
+
+	// the task has been restarted
+	h.exitedAt = time.Time{}
+}
+
+// latest returns the most recent sample, if any. The lock must be held.
+func (h *statsHistory) latest() *cstructs.TaskResourceUsage {
+	if h.next == 0 && !h.full {
+		return nil
+	}
+	return h.samples[(h.next-1+len(h.samples))%len(h.samples)]
+}
+
+// exited records that the task exited at now.
+func (h *statsHistory) exited(now time.Time) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.exitedAt = now
+}
+
+// snapshot returns the samples of the history, oldest first. The history of a
+// task which exited longer than the duration of the history ago is dropped,
+// and nil is returned for it.
+func (h *statsHistory) snapshot(now time.Time) *cstructs.TaskResourceUsageHistory {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.exitedAt.IsZero() && now.Sub(h.exitedAt) > h.duration {
+		clear(h.samples)
+		h.next = 0
+		h.full = false
+		h.exitedAt = time.Time{}
+		return nil
+	}
+
+	history := &cstructs.TaskResourceUsageHistory{
+		Resolution: h.resolution,
+	}
+	if !h.exitedAt.IsZero() {
+		history.ExitedAt = h.exitedAt.UnixNano()
+	}
+	if h.full {
+		history.Samples = append(history.Samples, h.samples[h.next:]...)
+	}
+	history.Samples = append(history.Samples, h.samples[:h.next]...)
+	return history
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStatsHistory_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, newStatsHistory(0, 10*time.Second))
+	must.Nil(t, newStatsHistory(5*time.Minute, 0))
+
+	// a disabled history is safe to use
+	var h *statsHistory
+	h.add(statsHistorySample(time.Now(), 1))
+	h.exited(time.Now())
+	must.Nil(t, h.snapshot(time.Now()))
+}
+
+// TestStatsHistory_Samples asserts that the history holds at most one sample
+// per resolution, dropping the oldest samples once it is full.
+func TestStatsHistory_Samples(t *testing.T) {
+	ci.Parallel(t)
+
+	start := time.Now()
+	h := newStatsHistory(time.Minute, 10*time.Second)
+	must.Len(t, 6, h.samples)
+	must.Len(t, 0, h.snapshot(start).Samples)
+
+	for i := 0; i < 20; i++ {
+		h.add(statsHistorySample(start.Add(time.Duration(i)*5*time.Second), uint64(i)))
+	}
+
+	history := h.snapshot(start)
+	must.Eq(t, 10*time.Second, history.Resolution)
+	must.Zero(t, history.ExitedAt)
+
+	var rss []uint64
+	for _, sample := range history.Samples {
+		rss = append(rss, sample.ResourceUsage.MemoryStats.RSS)
+	}
+	must.Eq(t, []uint64{8, 10, 12, 14, 16, 18}, rss)
+}
+
+// TestStatsHistory_Exited asserts that the history of an exited task is kept
+// for the duration of the history, or until the task is restarted.
+func TestStatsHistory_Exited(t *testing.T) {
+	ci.Parallel(t)
+
+	start := time.Now()
+	h := newStatsHistory(time.Minute, 10*time.Second)
+	h.add(statsHistorySample(start, 1))
+
+	exitedAt := start.Add(5 * time.Second)
+	h.exited(exitedAt)
+	history := h.snapshot(exitedAt.Add(time.Minute))
+	must.Eq(t, exitedAt.UnixNano(), history.ExitedAt)
+	must.Len(t, 1, history.Samples)
+
+	// restarting the task clears the exit
+	h.add(statsHistorySample(start.Add(time.Minute), 2))
+	history = h.snapshot(start.Add(time.Hour))
+	must.Zero(t, history.ExitedAt)
+	must.Len(t, 2, history.Samples)
+
+	// the history is dropped once the task has exited for its duration
+	h.exited(exitedAt)
+	must.Nil(t, h.snapshot(exitedAt.Add(time.Minute+time.Second)))
+	must.Len(t, 0, h.snapshot(exitedAt).Samples)
+}
+
+func statsHistorySample(ts time.Time, rss uint64) *cstructs.TaskResourceUsage {
+	return &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: &cstructs.MemoryStats{RSS: rss},
+			CpuStats:    &cstructs.CpuStats{TotalTicks: float64(rss)},
+		},
+		Timestamp: ts.UnixNano(),
+	}
+}
@@ -49,7 +49,7 @@ func (h *taskDirHook) Name() string {
 func (h *taskDirHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
 	fsi := h.runner.driverCapabilities.FSIsolation
 	if v, ok := req.PreviousState[TaskDirHookIsDoneDataKey]; ok && v == "true" {
-		setEnvvars(h.runner.envBuilder, fsi, h.runner.taskDir, h.runner.clientConfig)
+		setEnvvars(h.runner.envBuilder, fsi, h.runner.taskDir, h.runner.clientConfig, h.runner.node())
 		resp.State = map[string]string{
 			TaskDirHookIsDoneDataKey: "true",
 		}
@@ -72,15 +72,17 @@ func (h *taskDirHook) Prestart(ctx context.Context, req *interfaces.TaskPrestart
 	}
 
 	// Update the environment variables based on the built task directory
-	setEnvvars(h.runner.envBuilder, fsi, h.runner.taskDir, h.runner.clientConfig)
+	setEnvvars(h.runner.envBuilder, fsi, h.runner.taskDir, h.runner.clientConfig, h.runner.node())
 	resp.State = map[string]string{
 		TaskDirHookIsDoneDataKey: "true",
 	}
 	return nil
 }
 
-// setEnvvars sets path and host env vars depending on the FS isolation used.
-func setEnvvars(envBuilder *taskenv.Builder, fsi fsisolation.Mode, taskDir *allocdir.TaskDir, conf *cconfig.Config) {
+// setEnvvars sets path and host env vars depending on the FS isolation used,
+// and the node meta env vars from the current node. It runs on every prestart,
+// so restarted tasks see the node meta set since they were started.
+func setEnvvars(envBuilder *taskenv.Builder, fsi fsisolation.Mode, taskDir *allocdir.TaskDir, conf *cconfig.Config, node *structs.Node) {
 
 	envBuilder.SetClientTaskRoot(taskDir.Dir)
 	envBuilder.SetClientSharedAllocDir(taskDir.SharedAllocDir)
@@ -115,4 +117,9 @@ func setEnvvars(envBuilder *taskenv.Builder, fsi fsisolation.Mode, taskDir *allo
 		), ",")
 		envBuilder.SetHostEnvvars(filter)
 	}
+
+	// Export the node meta allowed by the client configuration
+	if node != nil {
+		envBuilder.SetNodeMetaEnv(conf.EnvFromNodeMeta.Filter(node.Meta))
+	}
 }
@@ -88,6 +88,9 @@ type TaskRunner struct {
 
 	clientConfig *config.Config
 
+	// nodeFunc returns the current node of the client, if set.
+	nodeFunc func() *structs.Node
+
 	// stateUpdater is used to emit updated task state
 	stateUpdater interfaces.TaskStateHandler
 
@@ -201,6 +204,11 @@ type TaskRunner struct {
 	vaultToken     string
 	vaultTokenLock sync.Mutex
 
+	// vaultClusterTokens are the current Vault tokens for any additional
+	// Vault blocks, keyed by cluster. They are guarded by vaultTokenLock and
+	// should be accessed with the getter.
+	vaultClusterTokens map[string]string
+
 	// nomadToken is the current Nomad workload identity token. It
 	// should be accessed with the getter.
 	nomadToken     string
@@ -227,6 +235,10 @@ type TaskRunner struct {
 	resourceUsage     *cstructs.TaskResourceUsage
 	resourceUsageLock sync.Mutex
 
+	// statsHistory is the recent resource usage of the task, or nil if the
+	// client does not keep any
+	statsHistory *statsHistory
+
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
@@ -286,6 +298,9 @@ type TaskRunner struct {
 	// users manages the pool of dynamic workload users
 	users dynamic.Pool
 
+	// rpcClient is used by hooks to query the servers
+	rpcClient config.RPCer
+
 	// hookStatsHandler is used by certain hooks to emit telemetry data, if the
 	// operator has not disabled this functionality.
 	hookStatsHandler interfaces.HookStatsHandler
@@ -302,6 +317,11 @@ type Config struct {
 	TaskDir      *allocdir.TaskDir
 	Logger       log.Logger
 
+	// NodeFunc returns the current node of the client, including dynamic
+	// metadata set after the task runner was created. The node of
+	// ClientConfig is used if nil.
+	NodeFunc func() *structs.Node
+
 	// ClientBaseLabels are the base metric labels generated by the client.
 	ClientBaseLabels []metrics.Label
 
@@ -371,6 +391,10 @@ type Config struct {
 
 	// Users manages a pool of dynamic workload users
 	Users dynamic.Pool
+
+	// RPCClient is the client used by hooks to query the servers, such as
+	// for the services a task discovers.
+	RPCClient config.RPCer
 }
 
 func NewTaskRunner(config *Config) (*TaskRunner, error) {
@@ -398,6 +422,8 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		alloc:                   config.Alloc,
 		allocID:                 config.Alloc.ID,
 		clientConfig:            config.ClientConfig,
+		nodeFunc:                config.NodeFunc,
+		statsHistory:            newStatsHistory(config.ClientConfig.StatsHistoryDuration, config.ClientConfig.StatsHistoryResolution),
 		clientBaseLabels:        config.ClientBaseLabels,
 		task:                    config.Task,
 		taskDir:                 config.TaskDir,
@@ -434,6 +460,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		wranglers:               config.Wranglers,
 		widmgr:                  config.WIDMgr,
 		users:                   config.Users,
+		rpcClient:               config.RPCClient,
 	}
 
 	// Create the logger based on the allocation ID
@@ -860,8 +887,11 @@ func (tr *TaskRunner) emitExitResultEvent(result *drivers.ExitResult) {
 	event := structs.NewTaskEvent(structs.TaskTerminated).
 		SetExitCode(result.ExitCode).
 		SetSignal(result.Signal).
+		SetSignalName(helper.SignalName(result.Signal)).
+		SetCoreDumped(result.CoreDumped).
 		SetOOMKilled(result.OOMKilled).
-		SetExitMessage(result.Err)
+		SetExitMessage(result.Err).
+		SetResourceUsage(result.MaxRSS, result.UserCPUTime, result.SystemCPUTime)
 
 	tr.EmitEvent(event)
 
@@ -1557,11 +1587,19 @@ func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
 	return ru
 }
 
+// ResourceUsageHistory returns the recent resource usage of the task, which is
+// kept for a while after the task exits. Returns nil if the client does not
+// keep the history or the task exited too long ago.
+func (tr *TaskRunner) ResourceUsageHistory() *cstructs.TaskResourceUsageHistory {
+	return tr.statsHistory.snapshot(time.Now())
+}
+
 // UpdateStats updates and emits the latest stats from the driver.
 func (tr *TaskRunner) UpdateStats(ru *cstructs.TaskResourceUsage) {
 	tr.resourceUsageLock.Lock()
 	tr.resourceUsage = ru
 	tr.resourceUsageLock.Unlock()
+	tr.statsHistory.add(ru)
 	if ru != nil {
 		tr.emitStats(ru)
 	}
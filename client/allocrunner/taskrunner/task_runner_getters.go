@@ -4,7 +4,10 @@
 package taskrunner
 
 import (
+	"maps"
+
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -26,6 +29,14 @@ func (tr *TaskRunner) setAlloc(updated *structs.Allocation, task *structs.Task)
 	tr.task = task
 }
 
+// node returns the current node of the client.
+func (tr *TaskRunner) node() *structs.Node {
+	if tr.nodeFunc != nil {
+		return tr.nodeFunc()
+	}
+	return tr.clientConfig.Node
+}
+
 // IsLeader returns true if this task is the leader of its task group.
 func (tr *TaskRunner) IsLeader() bool {
 	return tr.taskLeader
@@ -41,6 +52,17 @@ func (tr *TaskRunner) IsSidecarTask() bool {
 	return tr.Task().Lifecycle != nil && tr.Task().Lifecycle.Sidecar
 }
 
+// TemplateDependencyStates returns the state of the dependencies of the
+// templates of the task, or nil if it has none.
+func (tr *TaskRunner) TemplateDependencyStates() []*cstructs.TemplateDependencyState {
+	for _, hook := range tr.runnerHooks {
+		if h, ok := hook.(*templateHook); ok {
+			return h.dependencyStates()
+		}
+	}
+	return nil
+}
+
 func (tr *TaskRunner) Task() *structs.Task {
 	tr.taskLock.RLock()
 	defer tr.taskLock.RUnlock()
@@ -79,6 +101,39 @@ func (tr *TaskRunner) setVaultToken(token string) {
 	tr.envBuilder.SetVaultToken(token, ns, tr.task.Vault.Env)
 }
 
+// getVaultClusterTokens returns a copy of the Vault tokens derived for the
+// task's additional Vault blocks, keyed by cluster.
+func (tr *TaskRunner) getVaultClusterTokens() map[string]string {
+	tr.vaultTokenLock.Lock()
+	defer tr.vaultTokenLock.Unlock()
+	return maps.Clone(tr.vaultClusterTokens)
+}
+
+// setVaultClusterToken updates the token of one of the task's additional
+// Vault blocks on the task runner as well as in the task's environment.
+func (tr *TaskRunner) setVaultClusterToken(cluster, token string) {
+	tr.vaultTokenLock.Lock()
+	defer tr.vaultTokenLock.Unlock()
+
+	if tr.vaultClusterTokens == nil {
+		tr.vaultClusterTokens = make(map[string]string)
+	}
+	tr.vaultClusterTokens[cluster] = token
+
+	vault := tr.task.LookupVault(cluster)
+	if vault == nil {
+		return
+	}
+
+	ns := vault.Namespace
+	if ns == "" {
+		if conf := tr.clientConfig.GetVaultConfigs(tr.logger)[cluster]; conf != nil {
+			ns = conf.Namespace
+		}
+	}
+	tr.envBuilder.SetVaultClusterToken(cluster, token, ns, vault.Env)
+}
+
 func (tr *TaskRunner) getNomadToken() string {
 	tr.nomadTokenLock.Lock()
 	defer tr.nomadTokenLock.Unlock()
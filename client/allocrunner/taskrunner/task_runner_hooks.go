@@ -65,20 +65,26 @@ func (tr *TaskRunner) initHooks() {
 		newIdentityHook(tr, hookLogger),
 		newConsulHook(hookLogger, tr),
 	}
-	// If Vault is enabled, add the hook
+	// If Vault is enabled, add a hook for each Vault block
 	if task.Vault != nil && tr.vaultClientFunc != nil {
-		tr.runnerHooks = append(tr.runnerHooks, newVaultHook(&vaultHookConfig{
-			vaultBlock:       task.Vault,
-			vaultConfigsFunc: tr.clientConfig.GetVaultConfigs,
-			clientFunc:       tr.vaultClientFunc,
-			events:           tr,
-			lifecycle:        tr,
-			updater:          tr,
-			logger:           hookLogger,
-			alloc:            tr.Alloc(),
-			task:             tr.Task(),
-			widmgr:           tr.widmgr,
-		}))
+		for _, vault := range task.AllVaults() {
+			var updater vaultTokenUpdateHandler = tr
+			if vault != task.Vault {
+				updater = &vaultClusterTokenUpdater{tr: tr, cluster: vault.ClusterName()}
+			}
+			tr.runnerHooks = append(tr.runnerHooks, newVaultHook(&vaultHookConfig{
+				vaultBlock:       vault,
+				vaultConfigsFunc: tr.clientConfig.GetVaultConfigs,
+				clientFunc:       tr.vaultClientFunc,
+				events:           tr,
+				lifecycle:        tr,
+				updater:          updater,
+				logger:           hookLogger,
+				alloc:            tr.Alloc(),
+				task:             tr.Task(),
+				widmgr:           tr.widmgr,
+			}))
+		}
 	}
 
 	if len(task.Secrets) > 0 {
@@ -96,9 +102,10 @@ func (tr *TaskRunner) initHooks() {
 	tr.runnerHooks = append(tr.runnerHooks, []interfaces.TaskHook{
 		newLogMonHook(tr, hookLogger),
 		newDispatchHook(alloc, hookLogger),
+		newNodePayloadHook(tr.node, hookLogger),
 		newVolumeHook(tr, hookLogger),
-		newArtifactHook(tr, tr.getter, hookLogger),
-		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, tr.clientConfig.PublishAllocationMetrics, hookLogger),
+		newArtifactHook(tr, tr.getter, tr.vaultClientFunc, tr.rpcClient, tr.clientConfig.Region, hookLogger),
+		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, tr.clientConfig.PublishAllocationMetrics || tr.statsHistory != nil, hookLogger),
 		newDeviceHook(tr.devicemanager, hookLogger),
 		newAPIHook(tr.shutdownCtx, tr.clientConfig.APIListenerRegistrar, hookLogger),
 		newWranglerHook(tr.wranglers, task.Name, alloc.ID, task.UsesCores(), hookLogger),
@@ -117,6 +124,12 @@ func (tr *TaskRunner) initHooks() {
 			}))
 	}
 
+	// If the task discovers Nomad services, add the hook before the template
+	// hook so that templates may read the addresses from the environment.
+	if task.ServiceDiscovery != nil {
+		tr.runnerHooks = append(tr.runnerHooks, newServiceDiscoveryHook(tr, hookLogger))
+	}
+
 	// Get the consul namespace for the TG of the allocation.
 	consulNamespace := tr.alloc.ConsulNamespaceForTask(tr.taskName)
 
@@ -274,6 +287,7 @@ func (tr *TaskRunner) prestart() error {
 		}
 
 		req.VaultToken = tr.getVaultToken()
+		req.VaultClusterTokens = tr.getVaultClusterTokens()
 		req.NomadToken = tr.getNomadToken()
 
 		// Time the prestart hook
@@ -410,6 +424,9 @@ func (tr *TaskRunner) exited() error {
 		}()
 	}
 
+	// keep the resource usage leading up to the exit
+	tr.statsHistory.exited(time.Now())
+
 	var merr multierror.Error
 	for _, hook := range tr.runnerHooks {
 		post, ok := hook.(interfaces.TaskExitedHook)
@@ -523,10 +540,11 @@ func (tr *TaskRunner) updateHooks() {
 
 		// Build the request
 		req := interfaces.TaskUpdateRequest{
-			NomadToken: tr.getNomadToken(),
-			VaultToken: tr.getVaultToken(),
-			Alloc:      alloc,
-			TaskEnv:    tr.envBuilder.Build(),
+			NomadToken:         tr.getNomadToken(),
+			VaultToken:         tr.getVaultToken(),
+			VaultClusterTokens: tr.getVaultClusterTokens(),
+			Alloc:              alloc,
+			TaskEnv:            tr.envBuilder.Build(),
 		}
 
 		// Time the update hook
@@ -16,6 +16,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"testing"
@@ -1240,6 +1241,71 @@ func TestTaskRunner_Dispatch_Payload(t *testing.T) {
 	require.Equal(t, expected, data)
 }
 
+// TestTaskRunner_EnvFromNodeMeta asserts that the node meta allowed by the
+// client configuration is exported into the task environment, and that
+// changes to the node meta are only seen once the task restarts.
+func TestTaskRunner_EnvFromNodeMeta(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10m",
+	}
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+	conf.ClientConfig.EnvFromNodeMeta = &config.EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack"},
+		DeniedPrefixes:  []string{"rack_secret"},
+		EnvPrefix:       "NODE_",
+	}
+
+	var nodeLock sync.Mutex
+	node := conf.ClientConfig.Node.Copy()
+	node.Meta = map[string]string{"rack": "r1", "rack_secret": "s", "zone": "z1"}
+	conf.NodeFunc = func() *structs.Node {
+		nodeLock.Lock()
+		defer nodeLock.Unlock()
+		return node
+	}
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	go tr.Run()
+	defer tr.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+
+	testWaitForTaskToStart(t, tr)
+
+	env := tr.envBuilder.Build().All()
+	must.Eq(t, "r1", env["NOMAD_META_NODE_rack"])
+	must.MapNotContainsKey(t, env, "NOMAD_META_NODE_rack_secret")
+	must.MapNotContainsKey(t, env, "NOMAD_META_NODE_zone")
+
+	// update the dynamic node meta, which is not seen by the running task
+	nodeLock.Lock()
+	node = node.Copy()
+	node.Meta["rack"] = "r2"
+	nodeLock.Unlock()
+	must.Eq(t, "r1", tr.envBuilder.Build().All()["NOMAD_META_NODE_rack"])
+
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).SetRestartReason("test")
+	must.NoError(t, tr.Restart(context.Background(), event, false))
+
+	testutil.WaitForResult(func() (bool, error) {
+		ts := tr.TaskState()
+		if ts.Restarts != 1 || ts.State != structs.TaskStateRunning {
+			return false, fmt.Errorf("expected running after 1 restart but found %s after %d", ts.State, ts.Restarts)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+
+	must.Eq(t, "r2", tr.envBuilder.Build().All()["NOMAD_META_NODE_rack"])
+}
+
 // TestTaskRunner_SignalFailure asserts that signal errors are properly
 // propagated from the driver to TaskRunner.
 func TestTaskRunner_SignalFailure(t *testing.T) {
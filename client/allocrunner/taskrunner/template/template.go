@@ -17,6 +17,7 @@ import (
 	"time"
 
 	ctconf "github.com/hashicorp/consul-template/config"
+	"github.com/hashicorp/consul-template/dependency"
 	"github.com/hashicorp/consul-template/manager"
 	"github.com/hashicorp/consul-template/renderer"
 	"github.com/hashicorp/consul-template/signals"
@@ -25,6 +26,7 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/config"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -218,6 +220,44 @@ func NewTaskTemplateManager(config *TaskTemplateManagerConfig) (*TaskTemplateMan
 	return tm, nil
 }
 
+// DependencyStates returns the state of the dependencies of each template,
+// ordered by destination, for debugging.
+func (tm *TaskTemplateManager) DependencyStates() []*cstructs.TemplateDependencyState {
+	events := tm.runner.RenderEvents()
+
+	var states []*cstructs.TemplateDependencyState
+	for id, tmpls := range tm.lookup {
+		event := events[id]
+		for _, tmpl := range tmpls {
+			state := &cstructs.TemplateDependencyState{DestPath: tmpl.DestPath}
+			if event != nil {
+				state.Rendered = !event.LastDidRender.IsZero()
+				state.LastRendered = event.LastDidRender
+				state.MissingDeps = dependencyNames(event.MissingDeps)
+				state.UsedDeps = dependencyNames(event.UsedDeps)
+			}
+			states = append(states, state)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].DestPath < states[j].DestPath
+	})
+	return states
+}
+
+// dependencyNames returns the sorted names of the dependencies of set.
+func dependencyNames(set *dependency.Set) []string {
+	if set == nil {
+		return nil
+	}
+	var names []string
+	for _, dep := range set.List() {
+		names = append(names, dep.String())
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Stop is used to stop the consul-template runner
 func (tm *TaskTemplateManager) Stop() {
 	tm.shutdownLock.Lock()
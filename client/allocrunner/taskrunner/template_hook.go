@@ -6,6 +6,7 @@ package taskrunner
 import (
 	"context"
 	"fmt"
+	"maps"
 	"sync"
 
 	log "github.com/hashicorp/go-hclog"
@@ -63,9 +64,11 @@ type templateHook struct {
 	// logger is used to log
 	logger log.Logger
 
-	// templateManager is used to manage any consul-templates this task may have
-	templateManager *template.TaskTemplateManager
-	managerLock     sync.Mutex
+	// templateManagers are used to manage any consul-templates this task may
+	// have, keyed by the Vault cluster the templates use. Templates using the
+	// task's primary Vault block are keyed by the empty string.
+	templateManagers map[string]*template.TaskTemplateManager
+	managerLock      sync.Mutex
 
 	// consulNamespace is the current Consul namespace
 	consulNamespace string
@@ -76,6 +79,10 @@ type templateHook struct {
 	// vaultNamespace is the current Vault namespace
 	vaultNamespace string
 
+	// vaultClusterTokens are the current Vault tokens for the task's
+	// additional Vault blocks, keyed by cluster
+	vaultClusterTokens map[string]string
+
 	// nomadToken is the current Nomad token
 	nomadToken string
 
@@ -106,24 +113,37 @@ func (*templateHook) Name() string {
 	return templateHookName
 }
 
+// dependencyStates returns the state of the dependencies of the templates of
+// the task, for debugging.
+func (h *templateHook) dependencyStates() []*cstructs.TemplateDependencyState {
+	h.managerLock.Lock()
+	defer h.managerLock.Unlock()
+
+	var states []*cstructs.TemplateDependencyState
+	for _, m := range h.templateManagers {
+		states = append(states, m.DependencyStates()...)
+	}
+	return states
+}
+
 func (h *templateHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
 	h.managerLock.Lock()
 	defer h.managerLock.Unlock()
 
 	// If we have already run prerun before exit early.
-	if h.templateManager != nil {
+	if len(h.templateManagers) != 0 {
 		if !h.config.renderOnTaskRestart {
 			return nil
 		}
 		h.logger.Info("re-rendering templates on task restart")
-		h.templateManager.Stop()
-		h.templateManager = nil
+		h.stopManagers()
 	}
 
 	// Store request information so they can be used in other hooks.
 	h.task = req.Task
 	h.taskDir = req.TaskDir.Dir
 	h.vaultToken = req.VaultToken
+	h.vaultClusterTokens = req.VaultClusterTokens
 	h.nomadToken = req.NomadToken
 	h.taskID = req.Alloc.ID + "-" + req.Task.Name
 
@@ -172,24 +192,65 @@ func (h *templateHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 		h.vaultNamespace = req.Task.Vault.Namespace
 	}
 
-	once, watch := []*structs.Template{}, []*structs.Template{}
-	for _, tmpl := range h.config.templates {
-		if tmpl.Once {
-			once = append(once, tmpl)
-		} else {
-			watch = append(watch, tmpl)
+	h.templateManagers = make(map[string]*template.TaskTemplateManager)
+	for cluster, tmpls := range h.templatesByVaultCluster(h.config.templates) {
+		once, watch := []*structs.Template{}, []*structs.Template{}
+		for _, tmpl := range tmpls {
+			if tmpl.Once {
+				once = append(once, tmpl)
+			} else {
+				watch = append(watch, tmpl)
+			}
+		}
+
+		if err := h.renderTemplates(ctx, cluster, once, watch); err != nil {
+			return err
 		}
 	}
 
-	return h.renderTemplates(ctx, once, watch)
+	return nil
 }
 
-func (h *templateHook) newManager(tmpls []*structs.Template) (manager *template.TaskTemplateManager, unblock chan struct{}, err error) {
+// templatesByVaultCluster groups the templates by the Vault cluster they use.
+// Templates using the task's primary Vault block are grouped under the empty
+// string, which is always present.
+func (h *templateHook) templatesByVaultCluster(tmpls []*structs.Template) map[string][]*structs.Template {
+	groups := map[string][]*structs.Template{"": nil}
+	for _, tmpl := range tmpls {
+		cluster := tmpl.VaultCluster
+		if h.task.Vault != nil && cluster == h.task.Vault.ClusterName() {
+			cluster = ""
+		}
+		groups[cluster] = append(groups[cluster], tmpl)
+	}
+	return groups
+}
+
+// stopManagers stops and removes all the template managers.
+func (h *templateHook) stopManagers() {
+	for _, m := range h.templateManagers {
+		m.Stop()
+	}
+	h.templateManagers = nil
+}
+
+func (h *templateHook) newManager(cluster string, tmpls []*structs.Template) (manager *template.TaskTemplateManager, unblock chan struct{}, err error) {
 	vaultCluster := h.task.GetVaultClusterName()
+	vaultBlock := h.task.Vault
+	vaultToken := h.vaultToken
+	vaultNamespace := h.vaultNamespace
+	if cluster != "" {
+		vaultCluster = cluster
+		vaultBlock = h.task.LookupVault(cluster)
+		vaultToken = h.vaultClusterTokens[cluster]
+		if vaultBlock != nil {
+			vaultNamespace = vaultBlock.Namespace
+		}
+	}
 	vaultConfig := h.config.clientConfig.GetVaultConfigs(h.logger)[vaultCluster]
 
 	// Fail if task has a vault block but no client config was found.
-	if h.task.Vault != nil && vaultConfig == nil {
+	if vaultBlock != nil && vaultConfig == nil {
 		return nil, nil, fmt.Errorf("Vault cluster %q is disabled or not configured", vaultCluster)
 	}
 
@@ -207,9 +268,9 @@ func (h *templateHook) newManager(tmpls []*structs.Template) (manager *template.
 		ConsulNamespace:      h.config.consulNamespace,
 		ConsulToken:          h.consulToken,
 		ConsulConfig:         consulConfig,
-		VaultToken:           h.vaultToken,
+		VaultToken:           vaultToken,
 		VaultConfig:          vaultConfig,
-		VaultNamespace:       h.vaultNamespace,
+		VaultNamespace:       vaultNamespace,
 		TaskDir:              h.taskDir,
 		EnvBuilder:           h.config.envBuilder,
 		MaxTemplateEventRate: template.DefaultMaxTemplateEventRate,
@@ -231,9 +292,7 @@ func (h *templateHook) Stop(_ context.Context, req *interfaces.TaskStopRequest,
 	defer h.managerLock.Unlock()
 
 	// Shutdown any created template
-	if h.templateManager != nil {
-		h.templateManager.Stop()
-	}
+	h.stopManagers()
 
 	return nil
 }
@@ -244,25 +303,32 @@ func (h *templateHook) Update(ctx context.Context, req *interfaces.TaskUpdateReq
 	defer h.managerLock.Unlock()
 
 	// no template manager to manage
-	if h.templateManager == nil {
+	if len(h.templateManagers) == 0 {
 		return nil
 	}
 
 	// neither vault or nomad token has been updated, nothing to do
-	if req.VaultToken == h.vaultToken && req.NomadToken == h.nomadToken {
+	if req.VaultToken == h.vaultToken && req.NomadToken == h.nomadToken &&
+		maps.Equal(req.VaultClusterTokens, h.vaultClusterTokens) {
 		return nil
 	} else {
 		h.vaultToken = req.VaultToken
+		h.vaultClusterTokens = req.VaultClusterTokens
 		h.nomadToken = req.NomadToken
 	}
 
-	tmpls := h.templateManager.Templates()
+	managers := h.templateManagers
 
-	// shutdown the old template
-	h.templateManager.Stop()
-	h.templateManager = nil
+	// shutdown the old templates
+	h.stopManagers()
+	h.templateManagers = make(map[string]*template.TaskTemplateManager)
 
-	err := h.renderTemplates(ctx, nil, tmpls)
+	var err error
+	for cluster, m := range managers {
+		if err = h.renderTemplates(ctx, cluster, nil, m.Templates()); err != nil {
+			break
+		}
+	}
 	if err != nil {
 		err = fmt.Errorf("failed to build template manager: %v", err)
 		h.logger.Error("failed to build template manager", "error", err)
@@ -275,15 +341,15 @@ func (h *templateHook) Update(ctx context.Context, req *interfaces.TaskUpdateReq
 	return nil
 }
 
-// renderTemplates creates the template managers and waits until each template has rendered, setting the watch
-// templateManger on the hook when complete so it can be referenced during token updates.
-func (h *templateHook) renderTemplates(ctx context.Context, once []*structs.Template, watch []*structs.Template) error {
-	onceMgr, unblockOne, err := h.newManager(once)
+// renderTemplates creates the template managers for a Vault cluster and waits until each template has rendered,
+// setting the watch template manager on the hook when complete so it can be referenced during token updates.
+func (h *templateHook) renderTemplates(ctx context.Context, cluster string, once []*structs.Template, watch []*structs.Template) error {
+	onceMgr, unblockOne, err := h.newManager(cluster, once)
 	if err != nil {
 		return err
 	}
 
-	watchMgr, unblockWatch, err := h.newManager(watch)
+	watchMgr, unblockWatch, err := h.newManager(cluster, watch)
 	if err != nil {
 		return err
 	}
@@ -305,6 +371,6 @@ func (h *templateHook) renderTemplates(ctx context.Context, once []*structs.Temp
 
 	// The template hook only needs to manage "watched" templates.
 	// We can ignore the "once" manager after it's templates render.
-	h.templateManager = watchMgr
+	h.templateManagers[cluster] = watchMgr
 	return nil
 }
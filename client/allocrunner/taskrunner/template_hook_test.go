@@ -313,7 +313,7 @@ func TestTemplateHook_Update(t *testing.T) {
 		TaskDir: &allocdir.TaskDir{Dir: tmpDir},
 	}
 
-	// sets the templateManager used in update
+	// sets the template managers used in update
 	must.NoError(t, hook.Prestart(context.TODO(), req, nil))
 
 	// deleted rendered templates
@@ -6,12 +6,15 @@ package taskrunner
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	log "github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/users"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -58,6 +61,20 @@ func validateTask(task *structs.Task, taskEnv *taskenv.TaskEnv, conf *config.Con
 		}
 	}
 
+	// Validate the owner and group artifacts are chowned to, which are denied
+	// like the user regardless of the driver, since the artifact is chowned
+	// on the client
+	for _, artifact := range task.Artifacts {
+		if !artifact.Chown {
+			continue
+		}
+		for _, name := range artifactOwnerNames(artifact) {
+			if _, unallowed := unallowedUsers[name]; unallowed {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("artifact (%s) owned by %q is disallowed", artifact.GetterSource, name))
+			}
+		}
+	}
+
 	// Validate the Service names once they're interpolated
 	for _, service := range task.Services {
 		name := taskEnv.ReplaceEnv(service.Name)
@@ -66,8 +83,48 @@ func validateTask(task *structs.Task, taskEnv *taskenv.TaskEnv, conf *config.Con
 		}
 	}
 
+	// Validate the function calls in interpolated values, which would
+	// otherwise be left in place
+	for _, k := range slices.Sorted(maps.Keys(task.Env)) {
+		if _, err := taskEnv.ReplaceEnvStrict(task.Env[k]); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("env %q failed validation: %v", k, err))
+		}
+	}
+	for _, artifact := range task.Artifacts {
+		for _, v := range []string{artifact.GetterSource, artifact.RelativeDest} {
+			if _, err := taskEnv.ReplaceEnvStrict(v); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("artifact (%s) failed validation: %v", artifact.GetterSource, err))
+			}
+		}
+	}
+	for _, tmpl := range task.Templates {
+		if _, err := taskEnv.ReplaceEnvStrict(tmpl.DestPath); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("template (%s) failed validation: %v", tmpl.DestPath, err))
+		}
+	}
+
 	if len(mErr.Errors) == 1 {
 		return mErr.Errors[0]
 	}
 	return mErr.ErrorOrNil()
 }
+
+// artifactOwnerNames returns the owner and group the artifact is chowned to,
+// along with the names of the user and group numeric ids resolve to on the
+// client, so that a denied user cannot be given by its id instead.
+func artifactOwnerNames(artifact *structs.TaskArtifact) []string {
+	var names []string
+	if owner := artifact.Owner; owner != "" {
+		names = append(names, owner)
+		if u, err := users.LookupID(owner); err == nil {
+			names = append(names, u.Username)
+		}
+	}
+	if group := artifact.Group; group != "" {
+		names = append(names, group)
+		if g, err := users.LookupGroupID(group); err == nil {
+			names = append(names, g.Name)
+		}
+	}
+	return names
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,6 +39,49 @@ func TestTaskRunner_Validate_UserEnforcement(t *testing.T) {
 	require.NoError(t, validateTask(task, taskEnv, conf))
 }
 
+func TestTaskRunner_Validate_ArtifactOwner(t *testing.T) {
+	ci.Parallel(t)
+
+	taskEnv := taskenv.NewEmptyBuilder().Build()
+	conf := config.DefaultConfig()
+
+	cases := []struct {
+		name   string
+		owner  string
+		group  string
+		chown  bool
+		expErr string
+	}{
+		{name: "owner denied", owner: "root", chown: true, expErr: `owned by "root" is disallowed`},
+		{name: "owner id denied", owner: "0", chown: true, expErr: `owned by "root" is disallowed`},
+		{name: "group denied", group: "root", chown: true, expErr: `owned by "root" is disallowed`},
+		{name: "group id denied", group: "0", chown: true, expErr: `owned by "root" is disallowed`},
+		{name: "allowed", owner: "nobody", chown: true},
+		{name: "not chowned", owner: "root"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the task driver is not checked, as artifacts are chowned on
+			// the client
+			task := &structs.Task{
+				Driver: "docker",
+				Artifacts: []*structs.TaskArtifact{{
+					GetterSource: "https://example.com/file",
+					Chown:        tc.chown,
+					Owner:        tc.owner,
+					Group:        tc.group,
+				}},
+			}
+			err := validateTask(task, taskEnv, conf)
+			if tc.expErr == "" {
+				must.NoError(t, err)
+				return
+			}
+			must.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
+
 func TestTaskRunner_Validate_ServiceName(t *testing.T) {
 	ci.Parallel(t)
 
@@ -65,3 +109,40 @@ func TestTaskRunner_Validate_ServiceName(t *testing.T) {
 	task.Services[0].Name = "${BAD}"
 	require.Error(t, validateTask(task, builder.Build(), conf))
 }
+
+func TestTaskRunner_Validate_Funcs(t *testing.T) {
+	ci.Parallel(t)
+
+	builder := taskenv.NewEmptyBuilder()
+	builder.SetHookEnv("test", map[string]string{"HOST": "Web-01.example.com"})
+	conf := config.DefaultConfig()
+
+	task := &structs.Task{
+		Env: map[string]string{
+			"NAME":  `${lower(split(HOST, ".")[0])}`,
+			"PLAIN": "${MISSING}",
+		},
+		Artifacts: []*structs.TaskArtifact{{
+			GetterSource: `https://example.com/${trimSuffix(HOST, ".example.com")}.tar.gz`,
+			RelativeDest: "local/",
+		}},
+		Templates: []*structs.Template{{
+			DestPath: `local/${upper(HOST)}.conf`,
+		}},
+	}
+	must.NoError(t, validateTask(task, builder.Build(), conf))
+
+	task.Env["NAME"] = `${lower(MISSING)}`
+	err := validateTask(task, builder.Build(), conf)
+	must.ErrorContains(t, err, `env "NAME" failed validation: failed to interpolate "${lower(MISSING)}"`)
+
+	task.Env["NAME"] = "ok"
+	task.Artifacts[0].GetterSource = `https://example.com/${split(HOST, ".")}`
+	err = validateTask(task, builder.Build(), conf)
+	must.ErrorContains(t, err, "result of split must be indexed")
+
+	task.Artifacts[0].GetterSource = "https://example.com/app.tar.gz"
+	task.Templates[0].DestPath = `local/${lower(HOST}.conf`
+	err = validateTask(task, builder.Build(), conf)
+	must.ErrorContains(t, err, `template (local/${lower(HOST}.conf) failed validation`)
+}
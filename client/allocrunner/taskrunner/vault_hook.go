@@ -35,6 +35,9 @@ const (
 	// to retrieve a Vault token
 	vaultBackoffLimit = 3 * time.Minute
 
+	// vaultHookName is the name of the hook for the task's primary Vault block
+	vaultHookName = "vault"
+
 	// vaultTokenFile is the name of the file holding the Vault token inside the
 	// task's secret directory
 	vaultTokenFile = "vault_token"
@@ -52,6 +55,21 @@ func (tr *TaskRunner) updatedVaultToken(token string) {
 	tr.triggerUpdateHooks()
 }
 
+// vaultClusterTokenUpdater handles token updates for one of the task's
+// additional Vault blocks.
+type vaultClusterTokenUpdater struct {
+	tr      *TaskRunner
+	cluster string
+}
+
+func (u *vaultClusterTokenUpdater) updatedVaultToken(token string) {
+	// Update the task runner and environment
+	u.tr.setVaultClusterToken(u.cluster, token)
+
+	// Trigger update hooks with the new Vault token
+	u.tr.triggerUpdateHooks()
+}
+
 type vaultHookConfig struct {
 	vaultBlock       *structs.Vault
 	vaultConfigsFunc func(hclog.Logger) map[string]*sconfig.VaultConfig
@@ -69,6 +87,14 @@ type vaultHook struct {
 	// vaultBlock is the vault block for the task
 	vaultBlock *structs.Vault
 
+	// name is the name of the hook. Hooks for additional Vault blocks are
+	// suffixed with their cluster name.
+	name string
+
+	// tokenFile is the name of the file holding the Vault token inside the
+	// task's private and secret directories.
+	tokenFile string
+
 	// vaultConfig is the Nomad client configuration for Vault.
 	vaultConfig      *sconfig.VaultConfig
 	vaultConfigsFunc func(hclog.Logger) map[string]*sconfig.VaultConfig
@@ -125,6 +151,7 @@ type vaultHook struct {
 }
 
 func newVaultHook(config *vaultHookConfig) *vaultHook {
+	cluster := config.vaultBlock.ClusterName()
 	ctx, cancel := context.WithCancel(context.Background())
 	h := &vaultHook{
 		vaultBlock:           config.vaultBlock,
@@ -140,16 +167,21 @@ func newVaultHook(config *vaultHookConfig) *vaultHook {
 		cancel:               cancel,
 		future:               newTokenFuture(),
 		widmgr:               config.widmgr,
-		widName:              config.task.Vault.IdentityName(),
+		widName:              config.vaultBlock.IdentityName(),
 		allowTokenExpiration: config.vaultBlock.AllowTokenExpiration,
 	}
+	h.name, h.tokenFile = vaultHookName, vaultTokenFile
+	if config.task.Vault != nil && cluster != config.task.Vault.ClusterName() {
+		h.name = vaultHookName + "_" + cluster
+		h.tokenFile = vaultTokenFile + "." + cluster
+	}
 	h.logger = config.logger.Named(h.Name())
 
 	return h
 }
 
-func (*vaultHook) Name() string {
-	return "vault"
+func (h *vaultHook) Name() string {
+	return h.name
 }
 
 func (h *vaultHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
@@ -161,7 +193,7 @@ func (h *vaultHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRe
 		return nil
 	}
 
-	cluster := h.task.GetVaultClusterName()
+	cluster := h.vaultBlock.ClusterName()
 	vclient, err := h.clientFunc(cluster)
 	if err != nil {
 		return err
@@ -176,8 +208,8 @@ func (h *vaultHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRe
 	// Try to recover a token if it was previously written in the secrets
 	// directory
 	recoveredToken := ""
-	h.privateDirTokenPath = filepath.Join(req.TaskDir.PrivateDir, vaultTokenFile)
-	h.secretsDirTokenPath = filepath.Join(req.TaskDir.SecretsDir, vaultTokenFile)
+	h.privateDirTokenPath = filepath.Join(req.TaskDir.PrivateDir, h.tokenFile)
+	h.secretsDirTokenPath = filepath.Join(req.TaskDir.SecretsDir, h.tokenFile)
 
 	// Handle upgrade path by searching for the previous token in all possible
 	// paths where the token may be.
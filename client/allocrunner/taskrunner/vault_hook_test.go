@@ -360,6 +360,64 @@ func TestTaskRunner_VaultHook(t *testing.T) {
 	}
 }
 
+func TestTaskRunner_VaultHook_additionalBlock(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &structs.Task{
+		Name: "web",
+		Vault: &structs.Vault{
+			Cluster: structs.VaultDefaultCluster,
+		},
+		Vaults: []*structs.Vault{
+			{Cluster: "prod"},
+		},
+		Identities: []*structs.WorkloadIdentity{
+			{Name: "vault_default"},
+			{Name: "vault_prod"},
+		},
+	}
+	alloc := mock.MinAlloc()
+	alloc.Job.TaskGroups[0].Tasks[0] = task
+
+	hook := setupTestVaultHook(t, &vaultHookConfig{
+		task:       task,
+		alloc:      alloc,
+		vaultBlock: task.Vaults[0],
+		vaultConfigsFunc: func(hclog.Logger) map[string]*sconfig.VaultConfig {
+			return map[string]*sconfig.VaultConfig{
+				"default": {Role: "client-role"},
+				"prod":    {Role: "client-prod-role"},
+			}
+		},
+	})
+	must.Eq(t, "vault_prod", hook.Name())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	t.Cleanup(cancel)
+
+	req := &interfaces.TaskPrestartRequest{
+		TaskEnv: taskenv.NewEmptyTaskEnv(),
+		TaskDir: &allocdir.TaskDir{
+			SecretsDir: t.TempDir(),
+			PrivateDir: t.TempDir(),
+		},
+		Task: task,
+	}
+	must.NoError(t, hook.Prestart(ctx, req, &interfaces.TaskPrestartResponse{}))
+	t.Cleanup(hook.Shutdown)
+
+	// Token must be derived with the identity and role of the additional
+	// block and written to its own files.
+	updater := (hook.updater).(*vaultTokenUpdaterMock)
+	must.StrHasSuffix(t, "client-prod-role", updater.currentToken)
+	must.Eq(t, "vault_token.prod", filepath.Base(hook.privateDirTokenPath))
+	must.Eq(t, "vault_token.prod", filepath.Base(hook.secretsDirTokenPath))
+
+	tokenFile, err := os.ReadFile(hook.secretsDirTokenPath)
+	must.NoError(t, err)
+	must.Eq(t, updater.currentToken, string(tokenFile))
+}
+
 func TestTaskRunner_VaultHook_recover(t *testing.T) {
 	ci.Parallel(t)
 
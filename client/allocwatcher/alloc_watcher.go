@@ -6,14 +6,20 @@ package allocwatcher
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	hclog "github.com/hashicorp/go-hclog"
 	nomadapi "github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/client/allocdir"
@@ -22,12 +28,18 @@ import (
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/escapingfs"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
 )
 
 const (
 	// getRemoteRetryIntv is minimum interval on which we retry
 	// to fetch remote objects. We pick a value between this and 2x this.
 	getRemoteRetryIntv = 30 * time.Second
+
+	// migrateProgressIntv is the minimum interval between the task events
+	// reporting the progress of a remote migration, since each is persisted
+	// in the state of every task of the alloc.
+	migrateProgressIntv = 30 * time.Second
 )
 
 // RPCer is the interface needed by a prevAllocWatcher to make RPC calls.
@@ -263,7 +275,7 @@ func (p *localPrevAlloc) Wait(ctx context.Context) error {
 }
 
 // Migrate from previous local alloc dir to destination alloc dir.
-func (p *localPrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface) error {
+func (p *localPrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface, _ func(*structs.TaskEvent)) error {
 	if !p.sticky {
 		// Not a sticky volume, nothing to migrate
 		return nil
@@ -309,6 +321,18 @@ type remotePrevAlloc struct {
 	// Migrate() iff the previous alloc has not already been GC'd.
 	nodeID string
 
+	// emitEvent emits task events describing the progress of the migration.
+	// Set by Migrate() and may be nil.
+	emitEvent func(*structs.TaskEvent)
+
+	// snapshotSize is the size of the files in the previous alloc's snapshot
+	// if reported by its node.
+	snapshotSize int64
+
+	// snapshotETag is the validator of the previous alloc's snapshot reported
+	// by its node. An interrupted snapshot is only resumed if it is unchanged.
+	snapshotETag string
+
 	// waiting and migrating are true when alloc runner is waiting on the
 	// prevAllocWatcher. Writers must acquire the waitingLock and readers
 	// should use the helper methods IsWaiting and IsMigrating.
@@ -426,11 +450,12 @@ func (p *remotePrevAlloc) Wait(ctx context.Context) error {
 
 // Migrate alloc data from a remote node if the new alloc has migration enabled
 // and the old alloc hasn't been GC'd.
-func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface) error {
+func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface, emitEvent func(*structs.TaskEvent)) error {
 	if !p.migrate {
 		// Volume wasn't configured to be migrated, return early
 		return nil
 	}
+	p.emitEvent = emitEvent
 
 	p.waitingLock.Lock()
 	p.migrating = true
@@ -449,12 +474,24 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface)
 		return nil
 	}
 
-	addr, err := p.getNodeAddr(ctx, p.nodeID)
+	node, err := p.getNode(ctx, p.nodeID)
 	if err != nil {
 		return err
 	}
 
-	prevAllocDir, err := p.migrateAllocDir(ctx, addr)
+	if node.Status == structs.NodeStatusDown {
+		p.logger.Warn("unable to migrate data from previous alloc; node is down", "node_id", node.ID)
+		p.emit(structs.NewTaskEvent(structs.TaskDiskMigrationSkipped).
+			SetMessage(fmt.Sprintf("Node %s of previous allocation is down", node.ID)))
+		return nil
+	}
+
+	scheme := "http://"
+	if node.TLSEnabled {
+		scheme = "https://"
+	}
+
+	prevAllocDir, err := p.migrateAllocDir(ctx, scheme+node.HTTPAddr)
 	if err != nil {
 		return err
 	}
@@ -472,8 +509,8 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface)
 	return nil
 }
 
-// getNodeAddr gets the node from the server with the given Node ID
-func (p *remotePrevAlloc) getNodeAddr(ctx context.Context, nodeID string) (string, error) {
+// getNode gets the node from the server with the given Node ID
+func (p *remotePrevAlloc) getNode(ctx context.Context, nodeID string) (*structs.Node, error) {
 	req := structs.NodeSpecificRequest{
 		NodeID: nodeID,
 		QueryOptions: structs.QueryOptions{
@@ -493,21 +530,16 @@ func (p *remotePrevAlloc) getNodeAddr(ctx context.Context, nodeID string) (strin
 			case <-time.After(retry):
 				continue
 			case <-ctx.Done():
-				return "", ctx.Err()
+				return nil, ctx.Err()
 			}
 		}
 		break
 	}
 
 	if resp.Node == nil {
-		return "", fmt.Errorf("node %q not found", nodeID)
-	}
-
-	scheme := "http://"
-	if resp.Node.TLSEnabled {
-		scheme = "https://"
+		return nil, fmt.Errorf("node %q not found", nodeID)
 	}
-	return scheme + resp.Node.HTTPAddr, nil
+	return resp.Node, nil
 }
 
 // migrate a remote alloc dir to local node. Caller is responsible for calling
@@ -533,12 +565,21 @@ func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context, nodeAddr string)
 		return nil, err
 	}
 
-	url := fmt.Sprintf("/v1/client/allocation/%v/snapshot", p.prevAllocID)
-	qo := &nomadapi.QueryOptions{AuthToken: p.migrateToken}
-	resp, err := apiClient.Raw().Response(url, qo)
-	if err != nil {
-		prevAllocDir.Destroy()
-		return nil, fmt.Errorf("error getting snapshot from previous alloc %q: %w", p.prevAllocID, err)
+	// Resume the snapshot from the last byte received if the connection is
+	// interrupted, and limit its bandwidth if configured
+	stream := &resumableStream{
+		ctx:    ctx,
+		logger: p.logger,
+		open: func(offset int64) (io.ReadCloser, error) {
+			return p.openSnapshot(ctx, apiClient, nodeAddr, offset)
+		},
+	}
+	resp := struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: newRateLimitedReader(ctx, stream, p.config.MigrateBandwidthLimit),
+		Closer: stream,
 	}
 
 	if err := p.streamAllocDir(ctx, resp, prevAllocDir.AllocDir); err != nil {
@@ -549,6 +590,78 @@ func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context, nodeAddr string)
 	return prevAllocDir, nil
 }
 
+// openSnapshot requests the snapshot of the previous alloc from its node,
+// starting at offset.
+func (p *remotePrevAlloc) openSnapshot(ctx context.Context, apiClient *nomadapi.Client, nodeAddr string, offset int64) (io.ReadCloser, error) {
+	url := fmt.Sprintf("%s/v1/client/allocation/%v/snapshot?offset=%d", nodeAddr, p.prevAllocID, offset)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Nomad-Token", p.migrateToken)
+
+	// Resuming a snapshot which has changed, or which cannot be identified,
+	// would mix the bytes of two different snapshots
+	if offset > 0 {
+		if p.snapshotETag == "" {
+			return nil, fmt.Errorf("%w: node did not identify the snapshot of previous alloc %q",
+				errSnapshotNotResumable, p.prevAllocID)
+		}
+		req.Header.Set("If-Range", p.snapshotETag)
+	}
+
+	resp, err := apiClient.Raw().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting snapshot from previous alloc %q: %w", p.prevAllocID, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("error getting snapshot from previous alloc %q: unexpected response code %d: %s",
+			p.prevAllocID, resp.StatusCode, body)
+	}
+
+	if offset == 0 {
+		if size, err := strconv.ParseInt(resp.Header.Get("X-Nomad-Snapshot-Size"), 10, 64); err == nil {
+			p.snapshotSize = size
+		}
+		p.snapshotETag = resp.Header.Get("ETag")
+	} else if resp.Header.Get("ETag") != p.snapshotETag {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: snapshot of previous alloc %q changed",
+			errSnapshotNotResumable, p.prevAllocID)
+	}
+	return resp.Body, nil
+}
+
+// emit the task event if there is an emitter.
+func (p *remotePrevAlloc) emit(event *structs.TaskEvent) {
+	if p.emitEvent != nil {
+		p.emitEvent(event)
+	}
+}
+
+// emitProgress emits a task event reporting the number of bytes of the
+// snapshot written so far.
+func (p *remotePrevAlloc) emitProgress(written int64) {
+	msg := "Migrating ephemeral disk: " + humanize.IBytes(uint64(written))
+	if p.snapshotSize > 0 {
+		msg += "/" + humanize.IBytes(uint64(p.snapshotSize))
+	}
+	p.emit(structs.NewTaskEvent(structs.TaskMigratingDisk).SetMessage(msg))
+}
+
+// verifyChecksums returns an error if any file listed in expected was not
+// received with the same checksum.
+func verifyChecksums(expected, received map[string]string) error {
+	for name, sum := range expected {
+		if received[name] != sum {
+			return fmt.Errorf("checksum mismatch for %q", name)
+		}
+	}
+	return nil
+}
+
 // stream remote alloc to dir to a local path. Caller should cleanup dest on
 // error.
 func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser, dest string) error {
@@ -572,6 +685,17 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 	// if we see this file, there was an error on the remote side
 	errorFilename := allocdir.SnapshotErrorFilename(p.prevAllocID)
 
+	// if we see this file, it lists the checksums of the files sent
+	checksumsFilename := allocdir.SnapshotChecksumsFilename(p.prevAllocID)
+	checksums := map[string]string{}
+	verified := false
+
+	// Report progress periodically as files are written, no matter how many
+	// files or chunks the snapshot holds
+	var written int64
+	progress := rate.Sometimes{Interval: migrateProgressIntv}
+	progress.Do(func() { p.emitProgress(written) })
+
 	buf := make([]byte, 1024)
 	for !canceled() {
 		// Get the next header
@@ -579,6 +703,10 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 
 		// Snapshot has ended
 		if err == io.EOF {
+			if !verified {
+				p.logger.Debug("previous alloc snapshot did not include checksums; skipping verification")
+			}
+			p.emitProgress(written)
 			return nil
 		}
 
@@ -605,6 +733,19 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 				p.prevAllocID, p.allocID, string(errBuf))
 		}
 
+		if hdr.Name == checksumsFilename {
+			var expected map[string]string
+			if err := json.NewDecoder(tr).Decode(&expected); err != nil {
+				return fmt.Errorf("error reading checksums of previous alloc %q: %w", p.prevAllocID, err)
+			}
+			if err := verifyChecksums(expected, checksums); err != nil {
+				return fmt.Errorf("error verifying previous alloc %q for new alloc %q: %w",
+					p.prevAllocID, p.allocID, err)
+			}
+			verified = true
+			continue
+		}
+
 		// If the header is for a directory we create the directory
 		if hdr.Typeflag == tar.TypeDir {
 			name := filepath.Join(dest, hdr.Name)
@@ -663,6 +804,7 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 
 			// We write in chunks so that we can test if the client
 			// is still alive
+			h := sha256.New()
 			for !canceled() {
 				n, err := tr.Read(buf)
 				if n > 0 && (err == nil || err == io.EOF) {
@@ -670,6 +812,10 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 						f.Close()
 						return fmt.Errorf("error writing to file %q: %w", f.Name(), err)
 					}
+					h.Write(buf[:n])
+
+					written += int64(n)
+					progress.Do(func() { p.emitProgress(written) })
 				}
 
 				if err != nil {
@@ -680,6 +826,7 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 					break
 				}
 			}
+			checksums[hdr.Name] = hex.EncodeToString(h.Sum(nil))
 
 		}
 	}
@@ -699,7 +846,9 @@ type NoopPrevAlloc struct{}
 func (NoopPrevAlloc) Wait(context.Context) error { return nil }
 
 // Migrate returns nil immediately.
-func (NoopPrevAlloc) Migrate(context.Context, allocdir.Interface) error { return nil }
+func (NoopPrevAlloc) Migrate(context.Context, allocdir.Interface, func(*structs.TaskEvent)) error {
+	return nil
+}
 
 func (NoopPrevAlloc) IsWaiting() bool   { return false }
 func (NoopPrevAlloc) IsMigrating() bool { return false }
@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +18,7 @@ import (
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
+	nomadapi "github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -109,7 +112,7 @@ func TestPrevAlloc_Noop(t *testing.T) {
 	go func() {
 		watcher.Wait(context.Background())
 		done <- 1
-		migrator.Migrate(context.Background(), nil)
+		migrator.Migrate(context.Background(), nil, nil)
 		done <- 1
 	}()
 	require.False(t, watcher.IsWaiting())
@@ -312,3 +315,109 @@ func TestPrevAlloc_StreamAllocDir_FileEscape(t *testing.T) {
 	err = prevAlloc.streamAllocDir(context.Background(), io.NopCloser(tarBuf), dest)
 	must.EqError(t, err, "archive contains object that escapes alloc dir")
 }
+
+// TestPrevAlloc_StreamAllocDir_Checksums asserts that the files received are
+// verified against the checksums at the end of the snapshot, and that progress
+// events are emitted.
+func TestPrevAlloc_StreamAllocDir_Checksums(t *testing.T) {
+	ci.Parallel(t)
+
+	snapshot := func(checksum string) io.ReadCloser {
+		tarBuf := bytes.NewBuffer(nil)
+		tw := tar.NewWriter(tarBuf)
+		must.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     "foo.txt",
+			Mode:     0666,
+			Size:     3,
+			ModTime:  time.Now(),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte("foo"))
+		must.NoError(t, err)
+
+		checksums := fmt.Sprintf(`{"foo.txt":%q}`, checksum)
+		must.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     allocdir.SnapshotChecksumsFilename("abc"),
+			Mode:     0666,
+			Size:     int64(len(checksums)),
+			ModTime:  time.Now(),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err = tw.Write([]byte(checksums))
+		must.NoError(t, err)
+		must.NoError(t, tw.Close())
+		return io.NopCloser(tarBuf)
+	}
+
+	var events []*structs.TaskEvent
+	prevAlloc := &remotePrevAlloc{
+		logger:       testlog.HCLogger(t),
+		allocID:      "123",
+		prevAllocID:  "abc",
+		migrate:      true,
+		snapshotSize: 3,
+		emitEvent: func(ev *structs.TaskEvent) {
+			events = append(events, ev)
+		},
+	}
+
+	sum := "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	dest := t.TempDir()
+	must.NoError(t, prevAlloc.streamAllocDir(context.Background(), snapshot(sum), dest))
+	must.FileExists(t, filepath.Join(dest, "foo.txt"))
+	must.FileNotExists(t, filepath.Join(dest, allocdir.SnapshotChecksumsFilename("abc")))
+
+	must.SliceLen(t, 2, events)
+	must.Eq(t, structs.TaskMigratingDisk, events[0].Type)
+	must.Eq(t, "Migrating ephemeral disk: 0 B/3 B", events[0].Message)
+	must.Eq(t, "Migrating ephemeral disk: 3 B/3 B", events[1].Message)
+
+	err := prevAlloc.streamAllocDir(context.Background(), snapshot("bad"), t.TempDir())
+	must.ErrorContains(t, err, `checksum mismatch for "foo.txt"`)
+}
+
+func TestPrevAlloc_OpenSnapshot_Resume(t *testing.T) {
+	ci.Parallel(t)
+
+	etag := `"v1"`
+	var ifRange []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifRange = append(ifRange, r.Header.Get("If-Range"))
+		w.Header().Set("ETag", etag)
+		w.Header().Set("X-Nomad-Snapshot-Size", "3")
+		_, _ = w.Write([]byte("foo"))
+	}))
+	defer srv.Close()
+
+	apiConfig := nomadapi.DefaultConfig()
+	apiConfig.Address = srv.URL
+	apiClient, err := nomadapi.NewClient(apiConfig)
+	must.NoError(t, err)
+
+	prevAlloc := &remotePrevAlloc{
+		logger:      testlog.HCLogger(t),
+		prevAllocID: "abc",
+	}
+	open := func(offset int64) error {
+		body, err := prevAlloc.openSnapshot(context.Background(), apiClient, srv.URL, offset)
+		if err == nil {
+			body.Close()
+		}
+		return err
+	}
+
+	// An unchanged snapshot is resumed with the validator of the first request
+	must.NoError(t, open(0))
+	must.Eq(t, 3, prevAlloc.snapshotSize)
+	must.NoError(t, open(2))
+	must.Eq(t, []string{"", `"v1"`}, ifRange)
+
+	// A changed snapshot is not resumed
+	etag = `"v2"`
+	must.ErrorIs(t, open(2), errSnapshotNotResumable)
+
+	// Nor is a snapshot the node did not identify
+	etag = ""
+	must.NoError(t, open(0))
+	must.ErrorIs(t, open(2), errSnapshotNotResumable)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocwatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// migrateMaxResumes is the number of consecutive times an interrupted
+	// snapshot stream is resumed before the migration fails.
+	migrateMaxResumes = 5
+
+	// migrateResumeIntv is the interval to wait before resuming an
+	// interrupted snapshot stream, multiplied by the number of consecutive
+	// failures.
+	migrateResumeIntv = time.Second
+
+	// migrateMaxBurst is the most bytes read at once from a rate limited
+	// snapshot stream.
+	migrateMaxBurst = 1024 * 1024
+)
+
+// errSnapshotNotResumable is returned when opening an interrupted snapshot
+// stream at an offset fails in a way which retrying cannot fix.
+var errSnapshotNotResumable = errors.New("snapshot stream cannot be resumed")
+
+// resumableStream reads a snapshot of a previous alloc, reopening it at the
+// current offset when the connection is interrupted instead of starting over.
+type resumableStream struct {
+	ctx    context.Context
+	logger hclog.Logger
+
+	// open returns the snapshot starting at offset
+	open func(offset int64) (io.ReadCloser, error)
+
+	body     io.ReadCloser
+	offset   int64
+	failures int
+}
+
+func (s *resumableStream) Read(p []byte) (int, error) {
+	for {
+		if s.body == nil {
+			body, err := s.open(s.offset)
+			if err != nil {
+				if errors.Is(err, errSnapshotNotResumable) {
+					return 0, err
+				}
+				if err := s.retry(err); err != nil {
+					return 0, err
+				}
+				continue
+			}
+			s.body = body
+		}
+
+		n, err := s.body.Read(p)
+		s.offset += int64(n)
+		if n > 0 {
+			s.failures = 0
+		}
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+
+		// The stream was interrupted, so resume it from the current offset
+		s.body.Close()
+		s.body = nil
+		if n > 0 {
+			return n, nil
+		}
+		if err := s.retry(err); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// retry waits to resume the stream after err, or returns an error if the
+// stream has failed too many times in a row or the context is done.
+func (s *resumableStream) retry(err error) error {
+	if s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+
+	s.failures++
+	if s.failures > migrateMaxResumes {
+		return fmt.Errorf("snapshot stream failed %d times: %w", migrateMaxResumes, err)
+	}
+
+	wait := time.Duration(s.failures) * migrateResumeIntv
+	s.logger.Warn("snapshot stream interrupted; resuming",
+		"offset", s.offset, "error", err, "wait", wait)
+
+	timer, stop := helper.NewSafeTimer(wait)
+	defer stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *resumableStream) Close() error {
+	if s.body == nil {
+		return nil
+	}
+	return s.body.Close()
+}
+
+// rateLimitedReader limits the rate at which bytes are read from r.
+type rateLimitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+// newRateLimitedReader returns a reader that reads from r at no more than
+// limit bytes per second, or r if limit is not positive.
+func newRateLimitedReader(ctx context.Context, r io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	burst := int(min(limit, migrateMaxBurst))
+	return &rateLimitedReader{
+		ctx:     ctx,
+		r:       r,
+		limiter: rate.NewLimiter(rate.Limit(limit), burst),
+	}
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if burst := r.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if err := r.limiter.WaitN(r.ctx, n); err != nil {
+			return n, err
+		}
+	}
+	return n, err
+}
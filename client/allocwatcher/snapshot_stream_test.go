@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocwatcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+// flakyReader returns an error along with the bytes read once more than limit
+// bytes have been read.
+type flakyReader struct {
+	r     io.Reader
+	limit int
+	read  int
+}
+
+func (f *flakyReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	f.read += n
+	if err == nil && f.read > f.limit {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+func TestResumableStream(t *testing.T) {
+	ci.Parallel(t)
+
+	data := bytes.Repeat([]byte("0123456789"), 100)
+
+	var offsets []int64
+	stream := &resumableStream{
+		ctx:    context.Background(),
+		logger: testlog.HCLogger(t),
+		open: func(offset int64) (io.ReadCloser, error) {
+			offsets = append(offsets, offset)
+			return io.NopCloser(&flakyReader{
+				r:     bytes.NewReader(data[offset:]),
+				limit: 300,
+			}), nil
+		},
+	}
+
+	b, err := io.ReadAll(stream)
+	must.NoError(t, err)
+	must.Eq(t, data, b)
+	must.Greater(t, 1, len(offsets))
+	must.Eq(t, 0, offsets[0])
+}
+
+func TestResumableStream_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opens := 0
+	stream := &resumableStream{
+		ctx:    ctx,
+		logger: testlog.HCLogger(t),
+		open: func(int64) (io.ReadCloser, error) {
+			opens++
+			cancel()
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	_, err := io.ReadAll(stream)
+	must.ErrorIs(t, err, context.Canceled)
+	must.Eq(t, 1, opens)
+}
+
+func TestResumableStream_NotResumable(t *testing.T) {
+	ci.Parallel(t)
+
+	opens := 0
+	stream := &resumableStream{
+		ctx:    context.Background(),
+		logger: testlog.HCLogger(t),
+		open: func(offset int64) (io.ReadCloser, error) {
+			opens++
+			if offset > 0 {
+				return nil, fmt.Errorf("%w: snapshot changed", errSnapshotNotResumable)
+			}
+			return io.NopCloser(&flakyReader{
+				r:     bytes.NewReader(make([]byte, 100)),
+				limit: 10,
+			}), nil
+		},
+	}
+
+	// The stream fails without waiting to retry
+	_, err := io.ReadAll(stream)
+	must.ErrorIs(t, err, errSnapshotNotResumable)
+	must.Eq(t, 2, opens)
+}
+
+func TestRateLimitedReader(t *testing.T) {
+	ci.Parallel(t)
+
+	const limit = 64 * 1024
+	data := make([]byte, 2*limit)
+
+	start := time.Now()
+	b, err := io.ReadAll(newRateLimitedReader(context.Background(), bytes.NewReader(data), limit))
+	must.NoError(t, err)
+	must.Eq(t, len(data), len(b))
+
+	// the first limit bytes are allowed immediately by the burst, and the
+	// rest take a second
+	must.Greater(t, 900*time.Millisecond, time.Since(start))
+}
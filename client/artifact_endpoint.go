@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ArtifactCache endpoint is used for managing the client's artifact cache.
+type ArtifactCache struct {
+	c *Client
+}
+
+func newArtifactCacheEndpoint(c *Client) *ArtifactCache {
+	return &ArtifactCache{c: c}
+}
+
+// Prefetch downloads an artifact into the artifact cache so that allocations
+// using the same artifact later start without downloading it.
+func (a *ArtifactCache) Prefetch(args *structs.ArtifactPrefetchRequest, reply *structs.ArtifactPrefetchResponse) error {
+	defer metrics.MeasureSince([]string{"client", "artifact_cache", "prefetch"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	// there is no task, so only the empty environment is available for
+	// interpolation
+	env := taskenv.NewEmptyTaskEnv()
+	if err := a.c.getter.Prefetch(env, args.Artifact, ""); err != nil {
+		if errors.Is(err, getter.ErrCacheDisabled) {
+			return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
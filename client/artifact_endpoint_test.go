@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestArtifactCache_Prefetch_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanupS := nomad.TestACLServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.ACLEnabled = true
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	req := &structs.ArtifactPrefetchRequest{
+		NodeID: c1.NodeID(),
+		Artifact: &structs.TaskArtifact{
+			GetterSource:  "https://example.com/file.txt",
+			GetterOptions: map[string]string{"checksum": "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		},
+	}
+
+	// Prefetching should fail without auth
+	var resp structs.ArtifactPrefetchResponse
+	err := c1.ClientRPC("ArtifactCache.Prefetch", req, &resp)
+	must.ErrorContains(t, err, structs.ErrPermissionDenied.Error())
+
+	// A node write token is allowed, but the test client has no cache
+	policyGood := mock.NodePolicy(acl.PolicyWrite)
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "artifact", policyGood)
+
+	req.AuthToken = tokenGood.SecretID
+	err = c1.ClientRPC("ArtifactCache.Prefetch", req, &resp)
+	must.ErrorContains(t, err, "artifact cache is not enabled")
+}
+
+func TestArtifactCache_Prefetch_Validation(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	var resp structs.ArtifactPrefetchResponse
+
+	req := &structs.ArtifactPrefetchRequest{NodeID: c1.NodeID()}
+	err := c1.ClientRPC("ArtifactCache.Prefetch", req, &resp)
+	must.ErrorContains(t, err, "missing required Artifact object")
+
+	req.Artifact = &structs.TaskArtifact{
+		GetterSource: "https://example.com/file.txt",
+	}
+	err = c1.ClientRPC("ArtifactCache.Prefetch", req, &resp)
+	must.ErrorContains(t, err, "artifact must specify a checksum to be prefetched")
+}
@@ -35,7 +35,6 @@ import (
 	"github.com/hashicorp/nomad/client/fingerprint"
 	"github.com/hashicorp/nomad/client/hoststats"
 	hvm "github.com/hashicorp/nomad/client/hostvolumemanager"
-	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/proclib"
@@ -162,6 +161,12 @@ type Client struct {
 	// at runtime it may be accessed outside of locks.
 	metaStatic map[string]string
 
+	// metaIndex is the modify index of the merged static and dynamic node
+	// metadata. metaIndexCh is closed and replaced whenever metaIndex is
+	// incremented to wake blocking reads. Both are guarded by configLock.
+	metaIndex   uint64
+	metaIndexCh chan struct{}
+
 	logger    hclog.InterceptLogger
 	rpcLogger hclog.Logger
 
@@ -315,8 +320,8 @@ type Client struct {
 	// EnterpriseClient is used to set and check enterprise features for clients
 	EnterpriseClient *EnterpriseClient
 
-	// getter is an interface for retrieving artifacts.
-	getter cinterfaces.ArtifactGetter
+	// getter is used for retrieving artifacts.
+	getter *getter.Sandbox
 
 	// wranglers is used to keep track of processes and manage their interaction
 	// with drivers and stuff
@@ -578,15 +583,7 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.hostStatsCollector = statsCollector
 
 	// Add the garbage collector
-	gcConfig := &GCConfig{
-		MaxAllocs:           cfg.GCMaxAllocs,
-		DiskUsageThreshold:  cfg.GCDiskUsageThreshold,
-		InodeUsageThreshold: cfg.GCInodeUsageThreshold,
-		Interval:            cfg.GCInterval,
-		ParallelDestroys:    cfg.GCParallelDestroys,
-		ReservedDiskMB:      int(cfg.Node.ReservedResources.Disk.DiskMB),
-	}
-	c.garbageCollector = NewAllocGarbageCollector(c.logger, statsCollector, c, gcConfig)
+	c.garbageCollector = NewAllocGarbageCollector(c.logger, statsCollector, c, newGCConfig(cfg))
 	go c.garbageCollector.Run()
 
 	// Set the preconfigured list of static servers
@@ -768,6 +765,13 @@ func (c *Client) init() error {
 
 	c.logger.Info("using alloc directory", "alloc_dir", conf.AllocDir)
 
+	// Ensure the host disk class dirs exist.
+	for name, disk := range conf.HostDisks {
+		if err := os.MkdirAll(disk.Path, 0o711); err != nil {
+			return fmt.Errorf("failed creating dir for host disk %q: %w", name, err)
+		}
+	}
+
 	reserved := "<none>"
 	if conf.Node != nil && conf.Node.ReservedResources != nil {
 		// Node should always be non-nil due to initialization in the
@@ -853,11 +857,41 @@ func (c *Client) Reload(newConfig *config.Config) error {
 		}
 	}
 
+	c.reloadArtifactAndGC(newConfig)
+
 	c.fingerprintManager.Reload()
 
 	return nil
 }
 
+// reloadArtifactAndGC swaps the artifact and garbage collection configuration
+// of the client for those of newConfig, under the config lock so that the
+// artifact getter and garbage collector are never out of sync with the
+// client's config. The artifact cache directory and the number of parallel
+// garbage collection destroys are fixed once the client is running.
+func (c *Client) reloadArtifactAndGC(newConfig *config.Config) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	updated := c.config.Copy()
+	if newConfig.Artifact != nil {
+		artifact := newConfig.Artifact.Copy()
+		if updated.Artifact != nil {
+			artifact.CacheDir = updated.Artifact.CacheDir
+		}
+		updated.Artifact = artifact
+	}
+	updated.GCInterval = newConfig.GCInterval
+	updated.GCDiskUsageThreshold = newConfig.GCDiskUsageThreshold
+	updated.GCInodeUsageThreshold = newConfig.GCInodeUsageThreshold
+	updated.GCMaxAllocs = newConfig.GCMaxAllocs
+	updated.GC = newConfig.GC
+	c.config = updated
+
+	c.getter.SetConfig(updated.Artifact)
+	c.garbageCollector.SetConfig(newGCConfig(updated))
+}
+
 // Leave is used to prepare the client to leave the cluster
 func (c *Client) Leave() error {
 	if c.GetConfig().DevMode {
@@ -1718,7 +1752,7 @@ func (c *Client) setupNode() error {
 	c.metaStatic = maps.Clone(node.Meta)
 
 	// Merge dynamic node metadata
-	c.metaDynamic, err = c.stateDB.GetNodeMeta()
+	c.metaDynamic, c.metaIndex, err = c.stateDB.GetNodeMeta()
 	if err != nil {
 		return fmt.Errorf("error reading dynamic node metadata: %w", err)
 	}
@@ -1744,9 +1778,15 @@ func (c *Client) setupNode() error {
 		node.Meta[dk] = *dv
 	}
 
+	// The static node metadata may have changed since the index was
+	// persisted, so always advance it to invalidate any earlier check-and-set
+	// index
+	c.metaIndex++
+	c.metaIndexCh = make(chan struct{})
+
 	// Write back dynamic node metadata as tombstones may have been removed
 	// above
-	if err := c.stateDB.PutNodeMeta(c.metaDynamic); err != nil {
+	if err := c.stateDB.PutNodeMeta(c.metaDynamic, c.metaIndex); err != nil {
 		return fmt.Errorf("error syncing dynamic node metadata: %w", err)
 	}
 
@@ -2987,6 +3027,7 @@ func (c *Client) newAllocRunnerConfig(
 		CSIManager:          c.csimanager,
 		CheckStore:          c.checkStore,
 		ClientConfig:        c.GetConfig(),
+		NodeFunc:            c.Node,
 		ConsulServices:      c.consulServices,
 		ConsulProxiesFunc:   c.consulProxiesFunc,
 		DeviceManager:       c.devicemanager,
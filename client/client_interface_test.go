@@ -166,6 +166,11 @@ func (ar *emptyAllocRunner) LatestAllocStats(taskFilter string) (*cstructs.Alloc
 	}, nil
 }
 
+// AllocStatsHistory lets this empty runner implement AllocStatsReporter
+func (ar *emptyAllocRunner) AllocStatsHistory(taskFilter string) (map[string]*cstructs.TaskResourceUsageHistory, error) {
+	return map[string]*cstructs.TaskResourceUsageHistory{}, nil
+}
+
 func (ar *emptyAllocRunner) SetTaskPauseState(taskName string, ps structs.TaskScheduleState) error {
 	return nil
 }
@@ -173,3 +178,11 @@ func (ar *emptyAllocRunner) SetTaskPauseState(taskName string, ps structs.TaskSc
 func (ar *emptyAllocRunner) GetTaskPauseState(taskName string) (structs.TaskScheduleState, error) {
 	return "", nil
 }
+
+func (ar *emptyAllocRunner) TemplateDependencyStates() map[string][]*cstructs.TemplateDependencyState {
+	return nil
+}
+
+func (ar *emptyAllocRunner) Networking() *cstructs.AllocNetworking {
+	return &cstructs.AllocNetworking{}
+}
@@ -4,9 +4,11 @@
 package client
 
 import (
+	"fmt"
 	"time"
 
 	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/structs"
 	nstructs "github.com/hashicorp/nomad/nomad/structs"
 )
@@ -31,3 +33,79 @@ func (s *ClientStats) Stats(args *nstructs.NodeSpecificRequest, reply *structs.C
 	reply.HostStats = clientStats.LatestHostStats()
 	return nil
 }
+
+// Debug is used to retrieve the state of the client's subsystems for
+// debugging. Each subsystem is collected separately, so that one which fails
+// does not prevent the others from being returned.
+func (s *ClientStats) Debug(args *nstructs.NodeSpecificRequest, reply *structs.ClientDebugResponse) error {
+	defer metrics.MeasureSince([]string{"client", "client_stats", "debug"}, time.Now())
+
+	// Check node write permissions, as the state of the subsystems reveals
+	// the workloads of every namespace
+	if aclObj, err := s.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	collectors := map[string]func() error{
+		"artifacts": func() error {
+			reply.Artifacts = s.c.getter.Downloads()
+			return nil
+		},
+		"templates": func() error {
+			reply.Templates = make(map[string]map[string][]*structs.TemplateDependencyState)
+			for allocID, ar := range s.c.getAllocRunners() {
+				if states := ar.TemplateDependencyStates(); len(states) > 0 {
+					reply.Templates[allocID] = states
+				}
+			}
+			return nil
+		},
+		"cgroups": func() error {
+			root, cgroups, err := cgroupslib.Layout()
+			reply.Cgroups = &structs.CgroupLayout{
+				Mode:    cgroupMode(cgroupslib.GetMode()),
+				Root:    root,
+				Cgroups: cgroups,
+			}
+			return err
+		},
+		"gc": func() error {
+			reply.GC = s.c.garbageCollector.Stats()
+			return nil
+		},
+	}
+	for name, collect := range collectors {
+		if err := collectDebug(collect); err != nil {
+			if reply.Errors == nil {
+				reply.Errors = make(map[string]string)
+			}
+			reply.Errors[name] = err.Error()
+		}
+	}
+	return nil
+}
+
+// collectDebug runs the collector of a subsystem for Debug, recovering from
+// any panic so that the other subsystems are still collected.
+func collectDebug(collect func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return collect()
+}
+
+// cgroupMode returns the name of the cgroups version of mode.
+func cgroupMode(mode cgroupslib.Mode) string {
+	switch mode {
+	case cgroupslib.CG1:
+		return "v1"
+	case cgroupslib.CG2:
+		return "v2"
+	default:
+		return "off"
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/mock"
 	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -90,3 +91,59 @@ func TestClientStats_Stats_ACL(t *testing.T) {
 		require.NotNil(resp.HostStats)
 	}
 }
+
+func TestClientStats_Debug(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	req := &nstructs.NodeSpecificRequest{}
+	var resp structs.ClientDebugResponse
+	must.NoError(t, client.ClientRPC("ClientStats.Debug", &req, &resp))
+	must.MapEmpty(t, resp.Errors)
+	must.SliceEmpty(t, resp.Artifacts)
+	must.MapEmpty(t, resp.Templates)
+	must.NotNil(t, resp.Cgroups)
+	must.NotNil(t, resp.GC)
+	must.Eq(t, client.config.GCMaxAllocs, resp.GC.MaxAllocs)
+}
+
+func TestClientStats_Debug_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	server, addr, root, cleanupS := testACLServer(t, nil)
+	defer cleanupS()
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+	})
+	defer cleanupC()
+
+	// node:read is not enough to read the state of the subsystems
+	token := mock.CreatePolicyAndToken(t, server.State(), 1005, "read", mock.NodePolicy(acl.PolicyRead))
+	req := &nstructs.NodeSpecificRequest{}
+	req.AuthToken = token.SecretID
+	var resp structs.ClientDebugResponse
+	must.EqError(t, client.ClientRPC("ClientStats.Debug", &req, &resp), nstructs.ErrPermissionDenied.Error())
+
+	token = mock.CreatePolicyAndToken(t, server.State(), 1007, "write", mock.NodePolicy(acl.PolicyWrite))
+	req.AuthToken = token.SecretID
+	must.NoError(t, client.ClientRPC("ClientStats.Debug", &req, &resp))
+	must.NotNil(t, resp.GC)
+
+	req.AuthToken = root.SecretID
+	must.NoError(t, client.ClientRPC("ClientStats.Debug", &req, &resp))
+}
+
+func TestClientStats_collectDebug(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, collectDebug(func() error { return nil }))
+	must.EqError(t, collectDebug(func() error {
+		var m map[string]int
+		m["a"] = 1
+		return nil
+	}), "panic: assignment to entry in nil map")
+}
@@ -45,6 +45,10 @@ type AllocRunnerConfig struct {
 	// ClientConfig is the clients configuration.
 	ClientConfig *Config
 
+	// NodeFunc returns the current node of the client, which unlike the node
+	// of ClientConfig reflects later changes to its dynamic metadata.
+	NodeFunc func() *structs.Node
+
 	// Alloc captures the allocation that should be run.
 	Alloc *structs.Allocation
 
@@ -147,6 +151,7 @@ type PrevAllocMigrator interface {
 	// IsMigrating returns true if a concurrent caller is in Migrate
 	IsMigrating() bool
 
-	// Migrate data from previous alloc
-	Migrate(ctx context.Context, dest allocdir.Interface) error
+	// Migrate data from previous alloc, calling emitEvent with task events
+	// describing the progress of the migration
+	Migrate(ctx context.Context, dest allocdir.Interface, emitEvent func(*structs.TaskEvent)) error
 }
@@ -5,18 +5,27 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/helper/tlsutil"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 // ArtifactConfig is the internal readonly copy of the client agent's
 // ArtifactConfig.
 type ArtifactConfig struct {
-	HTTPReadTimeout time.Duration
-	HTTPMaxBytes    int64
+	HTTPReadTimeout   time.Duration
+	HTTPMaxBytes      int64
+	HTTPSizePreflight bool
+
+	// MinTLSVersion is the minimum TLS version of the connections http
+	// artifacts are downloaded over, such as tls.VersionTLS12.
+	MinTLSVersion uint16
 
 	GCSTimeout time.Duration
 	GitTimeout time.Duration
@@ -26,10 +35,68 @@ type ArtifactConfig struct {
 	DecompressionLimitFileCount int
 	DecompressionLimitSize      int64
 
+	// DecompressionMinSize is the size below which archives are stored as
+	// downloaded rather than extracted, or 0 if archives of any size are
+	// extracted.
+	DecompressionMinSize int64
+
+	// MaxFilesPerDir is the maximum number of entries a single directory of
+	// an extracted archive may hold, or 0 if unlimited.
+	MaxFilesPerDir int
+
+	// MaxParallelChunks is the maximum number of chunks of a single artifact
+	// downloaded at the same time, unless the artifact sets its own maximum.
+	MaxParallelChunks int
+
+	// DecompressorOverrides maps archive extensions to the name of the
+	// decompressor forced for them.
+	DecompressorOverrides map[string]string
+
 	DisableArtifactInspection     bool
+	DisableAutoExtract            bool
 	DisableFilesystemIsolation    bool
 	FilesystemIsolationExtraPaths []string
 	SetEnvironmentVariables       string
+
+	// CacheDir is the directory in which prefetched artifacts are stored,
+	// addressed by their checksum. The cache is disabled when empty.
+	CacheDir string
+
+	// CacheStaleIfError is the maximum age of a cached copy of an artifact
+	// served when downloading the artifact fails, or 0 if stale copies are
+	// never served.
+	CacheStaleIfError time.Duration
+
+	// SymlinkRewriteRoots are the install roots that absolute symlinks in
+	// artifacts are rewritten relative to the task directory from.
+	SymlinkRewriteRoots []string
+
+	// DisallowSymlinks is "skip" or "fail" if artifacts may not create
+	// symlinks in the task directory, deciding whether symlinks are left out
+	// or the artifact is rejected.
+	DisallowSymlinks string
+
+	// StripSetuid is true if the setuid, setgid, and sticky bits of the files
+	// of artifacts are removed, or false if such artifacts are rejected.
+	StripSetuid bool
+
+	// UnixSockets maps virtual HTTP host names to the paths of the Unix
+	// domain sockets that http artifacts from those hosts are downloaded over.
+	UnixSockets map[string]string
+
+	// DefaultHeaders maps the http and https schemes to headers which are
+	// sent with every artifact download from a source with that scheme,
+	// unless the artifact sets the same header.
+	DefaultHeaders map[string]http.Header
+
+	// PostCmdAllowlist is the absolute paths of the binaries artifacts may run
+	// as their post command.
+	PostCmdAllowlist []string
+
+	// PolicyURL is the URL of the Open Policy Agent decision artifacts are
+	// evaluated against before they are fetched, or empty if artifacts are
+	// not evaluated against a policy.
+	PolicyURL string
 }
 
 // ArtifactConfigFromAgent creates a new internal readonly copy of the client
@@ -70,6 +137,41 @@ func ArtifactConfigFromAgent(c *config.ArtifactConfig) (*ArtifactConfig, error)
 		return nil, fmt.Errorf("error parsing DecompressionLimitSize: %w", err)
 	}
 
+	decompressionMinSize, err := humanize.ParseBytes(*c.DecompressionMinSize)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing DecompressionMinSize: %w", err)
+	}
+
+	cacheStaleIfError, err := time.ParseDuration(*c.CacheStaleIfError)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CacheStaleIfError: %w", err)
+	}
+
+	minTLSVersion, err := tlsutil.ParseMinVersion(*c.MinTLSVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing MinTLSVersion: %w", err)
+	}
+
+	var unixSockets map[string]string
+	if len(c.UnixSockets) > 0 {
+		unixSockets = make(map[string]string, len(c.UnixSockets))
+		for host, addr := range c.UnixSockets {
+			unixSockets[host] = strings.TrimPrefix(addr, "unix://")
+		}
+	}
+
+	var defaultHeaders map[string]http.Header
+	if len(c.DefaultHeaders) > 0 {
+		defaultHeaders = make(map[string]http.Header, len(c.DefaultHeaders))
+		for scheme, headers := range c.DefaultHeaders {
+			h := make(http.Header, len(headers))
+			for name, value := range headers {
+				h.Set(name, value)
+			}
+			defaultHeaders[scheme] = h
+		}
+	}
+
 	return &ArtifactConfig{
 		HTTPReadTimeout:               httpReadTimeout,
 		HTTPMaxBytes:                  int64(httpMaxSize),
@@ -79,10 +181,26 @@ func ArtifactConfigFromAgent(c *config.ArtifactConfig) (*ArtifactConfig, error)
 		S3Timeout:                     s3Timeout,
 		DecompressionLimitFileCount:   *c.DecompressionFileCountLimit,
 		DecompressionLimitSize:        int64(decompressionSizeLimit),
+		DecompressionMinSize:          int64(decompressionMinSize),
+		MaxFilesPerDir:                *c.MaxFilesPerDir,
+		MaxParallelChunks:             *c.MaxParallelChunks,
+		DecompressorOverrides:         maps.Clone(c.DecompressorOverrides),
 		DisableArtifactInspection:     *c.DisableArtifactInspection,
 		DisableFilesystemIsolation:    *c.DisableFilesystemIsolation,
 		FilesystemIsolationExtraPaths: slices.Clone(c.FilesystemIsolationExtraPaths),
 		SetEnvironmentVariables:       *c.SetEnvironmentVariables,
+		CacheDir:                      *c.CacheDir,
+		CacheStaleIfError:             cacheStaleIfError,
+		HTTPSizePreflight:             *c.HTTPSizePreflight,
+		MinTLSVersion:                 minTLSVersion,
+		DisableAutoExtract:            *c.DisableAutoExtract,
+		SymlinkRewriteRoots:           slices.Clone(c.SymlinkRewriteRoots),
+		DisallowSymlinks:              *c.DisallowSymlinks,
+		StripSetuid:                   *c.StripSetuid,
+		UnixSockets:                   unixSockets,
+		DefaultHeaders:                defaultHeaders,
+		PostCmdAllowlist:              slices.Clone(c.PostCmdAllowlist),
+		PolicyURL:                     *c.PolicyURL,
 	}, nil
 
 }
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"crypto/tls"
+	"net/http"
 	"testing"
 	"time"
 
@@ -34,8 +36,116 @@ func TestArtifactConfigFromAgent(t *testing.T) {
 				S3Timeout:                   30 * time.Minute,
 				DecompressionLimitFileCount: 4096,
 				DecompressionLimitSize:      100_000_000_000,
+				MaxFilesPerDir:              100000,
+				MaxParallelChunks:           4,
+				MinTLSVersion:               tls.VersionTLS12,
+				StripSetuid:                 true,
 			},
 		},
+		{
+			name: "unix sockets",
+			config: func() *config.ArtifactConfig {
+				c := config.DefaultArtifactConfig()
+				c.UnixSockets = map[string]string{"artifacts.local": "unix:///run/artifacts.sock"}
+				return c
+			}(),
+			exp: &ArtifactConfig{
+				HTTPReadTimeout:             30 * time.Minute,
+				HTTPMaxBytes:                100_000_000_000,
+				GCSTimeout:                  30 * time.Minute,
+				GitTimeout:                  30 * time.Minute,
+				HgTimeout:                   30 * time.Minute,
+				S3Timeout:                   30 * time.Minute,
+				DecompressionLimitFileCount: 4096,
+				DecompressionLimitSize:      100_000_000_000,
+				MaxFilesPerDir:              100000,
+				MaxParallelChunks:           4,
+				MinTLSVersion:               tls.VersionTLS12,
+				StripSetuid:                 true,
+				UnixSockets:                 map[string]string{"artifacts.local": "/run/artifacts.sock"},
+			},
+		},
+		{
+			name: "default headers",
+			config: func() *config.ArtifactConfig {
+				c := config.DefaultArtifactConfig()
+				c.DefaultHeaders = map[string]map[string]string{"https": {"x-org": "acme"}}
+				c.DecompressorOverrides = map[string]string{"tar.gz": "go-getter"}
+				return c
+			}(),
+			exp: &ArtifactConfig{
+				HTTPReadTimeout:             30 * time.Minute,
+				HTTPMaxBytes:                100_000_000_000,
+				GCSTimeout:                  30 * time.Minute,
+				GitTimeout:                  30 * time.Minute,
+				HgTimeout:                   30 * time.Minute,
+				S3Timeout:                   30 * time.Minute,
+				DecompressionLimitFileCount: 4096,
+				DecompressionLimitSize:      100_000_000_000,
+				MaxFilesPerDir:              100000,
+				MaxParallelChunks:           4,
+				MinTLSVersion:               tls.VersionTLS12,
+				StripSetuid:                 true,
+				DecompressorOverrides:       map[string]string{"tar.gz": "go-getter"},
+				DefaultHeaders:              map[string]http.Header{"https": {"X-Org": {"acme"}}},
+			},
+		},
+		{
+			name: "cache stale if error",
+			config: func() *config.ArtifactConfig {
+				c := config.DefaultArtifactConfig()
+				c.CacheDir = pointer.Of("/var/cache/nomad")
+				c.CacheStaleIfError = pointer.Of("1h")
+				return c
+			}(),
+			exp: &ArtifactConfig{
+				HTTPReadTimeout:             30 * time.Minute,
+				HTTPMaxBytes:                100_000_000_000,
+				GCSTimeout:                  30 * time.Minute,
+				GitTimeout:                  30 * time.Minute,
+				HgTimeout:                   30 * time.Minute,
+				S3Timeout:                   30 * time.Minute,
+				DecompressionLimitFileCount: 4096,
+				DecompressionLimitSize:      100_000_000_000,
+				MaxFilesPerDir:              100000,
+				MaxParallelChunks:           4,
+				MinTLSVersion:               tls.VersionTLS12,
+				StripSetuid:                 true,
+				CacheDir:                    "/var/cache/nomad",
+				CacheStaleIfError:           time.Hour,
+			},
+		},
+		{
+			name: "min tls version",
+			config: func() *config.ArtifactConfig {
+				c := config.DefaultArtifactConfig()
+				c.MinTLSVersion = pointer.Of("tls13")
+				return c
+			}(),
+			exp: &ArtifactConfig{
+				HTTPReadTimeout:             30 * time.Minute,
+				HTTPMaxBytes:                100_000_000_000,
+				GCSTimeout:                  30 * time.Minute,
+				GitTimeout:                  30 * time.Minute,
+				HgTimeout:                   30 * time.Minute,
+				S3Timeout:                   30 * time.Minute,
+				DecompressionLimitFileCount: 4096,
+				DecompressionLimitSize:      100_000_000_000,
+				MaxFilesPerDir:              100000,
+				MaxParallelChunks:           4,
+				MinTLSVersion:               tls.VersionTLS13,
+				StripSetuid:                 true,
+			},
+		},
+		{
+			name: "invalid min tls version",
+			config: func() *config.ArtifactConfig {
+				c := config.DefaultArtifactConfig()
+				c.MinTLSVersion = pointer.Of("ssl3")
+				return c
+			}(),
+			expErr: "error parsing MinTLSVersion",
+		},
 		{
 			name: "invalid http read timeout",
 			config: &config.ArtifactConfig{
@@ -33,6 +33,10 @@ import (
 	"github.com/hashicorp/yamux"
 )
 
+// MaxStatsHistorySamples is the maximum number of samples of the resource usage
+// history kept for each task, which bounds the memory the history takes up.
+const MaxStatsHistorySamples = 1000
+
 var (
 	// DefaultEnvDenylist is the default set of environment variables that are
 	// filtered when passing the environment variables of the host to a task.
@@ -227,6 +231,15 @@ type Config struct {
 	// TLSConfig holds various TLS related configurations
 	TLSConfig *structsc.TLSConfig
 
+	// StatsHistoryDuration is how much recent resource usage of each task is
+	// kept, and for how long after the task exits. Zero disables the history.
+	StatsHistoryDuration time.Duration
+
+	// StatsHistoryResolution is the minimum interval between the samples of
+	// the resource usage history. The history of each task holds at most
+	// MaxStatsHistorySamples samples.
+	StatsHistoryResolution time.Duration
+
 	// GCInterval is the time interval at which the client triggers garbage
 	// collection
 	GCInterval time.Duration
@@ -272,6 +285,11 @@ type Config struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool
 
+	// HealthNonFatal is the names of the components, such as "driver.qemu",
+	// whose failures are reported by the client's health but do not make the
+	// client unhealthy.
+	HealthNonFatal []string
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *ClientTemplateConfig
 
@@ -341,6 +359,9 @@ type Config struct {
 	// HostNetworks is a map of the conigured host networks by name.
 	HostNetworks map[string]*structs.ClientHostNetworkConfig
 
+	// HostDisks is a map of the configured host disk classes by name.
+	HostDisks map[string]*structs.ClientHostDiskConfig
+
 	// CommonPluginDir is the root directory for plugins that implement
 	// the common plugin interface
 	CommonPluginDir string
@@ -355,6 +376,11 @@ type Config struct {
 	// This configuration is only considered if no host networks are defined.
 	BindWildcardDefaultHostNetwork bool
 
+	// MigrateBandwidthLimit is the maximum number of bytes per second at which
+	// ephemeral disk data is migrated from other clients. Zero means
+	// unlimited.
+	MigrateBandwidthLimit int64
+
 	// CgroupParent is the parent cgroup Nomad should use when managing any cgroup subsystems.
 	// Currently this only includes the 'cpuset' cgroup subsystem.
 	CgroupParent string
@@ -384,9 +410,17 @@ type Config struct {
 	// Drain configuration from the agent's config file.
 	Drain *DrainConfig
 
+	// GC configures per job type garbage collection policies and the low
+	// disk usage threshold.
+	GC *GCConfig
+
 	// Uesrs configuration from the agent's config file.
 	Users *UsersConfig
 
+	// EnvFromNodeMeta configures the node metadata exported into the
+	// environment of every task. Nil if no node metadata is exported.
+	EnvFromNodeMeta *EnvFromNodeMetaConfig
+
 	// ExtraAllocHooks are run with other allocation hooks, mainly for testing.
 	ExtraAllocHooks []interfaces.RunnerHook
 
@@ -893,10 +927,13 @@ func (c *Config) Copy() *Config {
 	nc.HostVolumes = structs.CopyMapStringClientHostVolumeConfig(nc.HostVolumes)
 	nc.ConsulConfigs = helper.DeepCopyMap(c.ConsulConfigs)
 	nc.VaultConfigs = helper.DeepCopyMap(c.VaultConfigs)
+	nc.HostDisks = helper.DeepCopyMap(c.HostDisks)
 	nc.TemplateConfig = c.TemplateConfig.Copy()
 	nc.ReservableCores = slices.Clone(c.ReservableCores)
+	nc.HealthNonFatal = slices.Clone(c.HealthNonFatal)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Users = c.Users.Copy()
+	nc.EnvFromNodeMeta = c.EnvFromNodeMeta.Copy()
 	return &nc
 }
 
@@ -910,6 +947,8 @@ func DefaultConfig() *Config {
 			structs.ConsulDefaultCluster: structsc.DefaultConsulConfig()},
 		Region:                  "global",
 		StatsCollectionInterval: 1 * time.Second,
+		StatsHistoryDuration:    5 * time.Minute,
+		StatsHistoryResolution:  10 * time.Second,
 		TLSConfig:               &structsc.TLSConfig{},
 		GCInterval:              1 * time.Minute,
 		GCParallelDestroys:      2,
@@ -925,6 +964,7 @@ func DefaultConfig() *Config {
 		CNIConfigDir:            "/opt/cni/config",
 		CNIInterfacePrefix:      "eth",
 		HostNetworks:            map[string]*structs.ClientHostNetworkConfig{},
+		HostDisks:               map[string]*structs.ClientHostDiskConfig{},
 		CgroupParent:            "nomad.slice", // SETH todo
 		MaxDynamicPort:          structs.DefaultMinDynamicPort,
 		MinDynamicPort:          structs.DefaultMaxDynamicPort,
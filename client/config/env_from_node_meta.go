@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"slices"
+	"strings"
+
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// DefaultEnvFromNodeMetaPrefix is prepended to the exported node metadata
+// keys if no env_prefix is configured.
+const DefaultEnvFromNodeMetaPrefix = "NODE_"
+
+// EnvFromNodeMetaConfig describes which node metadata is exported into the
+// environment of every task.
+type EnvFromNodeMetaConfig struct {
+	// AllowedPrefixes are the prefixes of the node metadata keys exported.
+	AllowedPrefixes []string
+
+	// DeniedPrefixes are the prefixes of the node metadata keys never
+	// exported, even if they match an allowed prefix.
+	DeniedPrefixes []string
+
+	// EnvPrefix is prepended to the exported node metadata keys.
+	EnvPrefix string
+}
+
+// EnvFromNodeMetaConfigFromAgent converts the agent configuration, returning
+// nil if no node metadata is exported.
+func EnvFromNodeMetaConfigFromAgent(c *sconfig.EnvFromNodeMetaConfig) *EnvFromNodeMetaConfig {
+	if c == nil || len(c.AllowedPrefixes) == 0 {
+		return nil
+	}
+
+	conf := &EnvFromNodeMetaConfig{
+		AllowedPrefixes: slices.Clone(c.AllowedPrefixes),
+		DeniedPrefixes:  slices.Clone(c.DeniedPrefixes),
+		EnvPrefix:       DefaultEnvFromNodeMetaPrefix,
+	}
+	if c.EnvPrefix != nil {
+		conf.EnvPrefix = *c.EnvPrefix
+	}
+	return conf
+}
+
+func (e *EnvFromNodeMetaConfig) Copy() *EnvFromNodeMetaConfig {
+	if e == nil {
+		return nil
+	}
+	return &EnvFromNodeMetaConfig{
+		AllowedPrefixes: slices.Clone(e.AllowedPrefixes),
+		DeniedPrefixes:  slices.Clone(e.DeniedPrefixes),
+		EnvPrefix:       e.EnvPrefix,
+	}
+}
+
+// Filter returns the node metadata with a key matching an allowed prefix and
+// no denied prefix, keyed by EnvPrefix followed by the metadata key.
+func (e *EnvFromNodeMetaConfig) Filter(meta map[string]string) map[string]string {
+	if e == nil {
+		return nil
+	}
+
+	hasPrefix := func(key string) func(string) bool {
+		return func(prefix string) bool { return strings.HasPrefix(key, prefix) }
+	}
+
+	filtered := make(map[string]string)
+	for k, v := range meta {
+		if !slices.ContainsFunc(e.AllowedPrefixes, hasPrefix(k)) ||
+			slices.ContainsFunc(e.DeniedPrefixes, hasPrefix(k)) {
+			continue
+		}
+		filtered[e.EnvPrefix+k] = v
+	}
+	return filtered
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestEnvFromNodeMetaConfig_FromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, EnvFromNodeMetaConfigFromAgent(nil))
+	must.Nil(t, EnvFromNodeMetaConfigFromAgent(&sconfig.EnvFromNodeMetaConfig{}))
+
+	must.Eq(t, &EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack"},
+		EnvPrefix:       "NODE_",
+	}, EnvFromNodeMetaConfigFromAgent(&sconfig.EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack"},
+	}))
+
+	must.Eq(t, &EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack"},
+		EnvPrefix:       "",
+	}, EnvFromNodeMetaConfigFromAgent(&sconfig.EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack"},
+		EnvPrefix:       pointer.Of(""),
+	}))
+}
+
+func TestEnvFromNodeMetaConfig_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	meta := map[string]string{
+		"rack":        "r1",
+		"rack_row":    "a",
+		"zone":        "z1",
+		"zone_secret": "hunter2",
+		"owner":       "ops",
+	}
+
+	var conf *EnvFromNodeMetaConfig
+	must.MapEmpty(t, conf.Filter(meta))
+
+	conf = &EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack", "zone"},
+		DeniedPrefixes:  []string{"zone_secret"},
+		EnvPrefix:       "NODE_",
+	}
+	must.Eq(t, map[string]string{
+		"NODE_rack":     "r1",
+		"NODE_rack_row": "a",
+		"NODE_zone":     "z1",
+	}, conf.Filter(meta))
+}
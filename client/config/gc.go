@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// GCConfig describes how the client garbage collects terminal allocations in
+// addition to the GC* options.
+type GCConfig struct {
+	// DiskUsageLowThreshold is the disk usage given as a percent that garbage
+	// collection started by exceeding GCDiskUsageThreshold continues until
+	// usage is below. Zero means GCDiskUsageThreshold.
+	DiskUsageLowThreshold float64
+
+	// MaxAges is the duration after becoming terminal that allocations of
+	// each job type are garbage collected.
+	MaxAges map[string]time.Duration
+}
+
+// GCConfigFromAgent creates the internal read-only copy of the client agent's
+// GCConfig.
+func GCConfigFromAgent(c *config.ClientGCConfig) (*GCConfig, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	gc := &GCConfig{
+		MaxAges: make(map[string]time.Duration, len(c.Policies)),
+	}
+
+	if c.DiskUsageLowThreshold != nil {
+		if *c.DiskUsageLowThreshold < 0 || *c.DiskUsageLowThreshold > 100 {
+			return nil, fmt.Errorf("disk_usage_low_threshold must be between 0 and 100 but found %v",
+				*c.DiskUsageLowThreshold)
+		}
+		gc.DiskUsageLowThreshold = *c.DiskUsageLowThreshold
+	}
+
+	jobTypes := []string{
+		structs.JobTypeService,
+		structs.JobTypeBatch,
+		structs.JobTypeSystem,
+		structs.JobTypeSysBatch,
+	}
+	for _, p := range c.Policies {
+		if !slices.Contains(jobTypes, p.JobType) {
+			return nil, fmt.Errorf("invalid job_type %q for policy", p.JobType)
+		}
+		if _, ok := gc.MaxAges[p.JobType]; ok {
+			return nil, fmt.Errorf("duplicate policy for job_type %q", p.JobType)
+		}
+		maxAge, err := time.ParseDuration(p.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing max_age of %s policy: %w", p.JobType, err)
+		}
+		if maxAge <= 0 {
+			return nil, fmt.Errorf("max_age of %s policy must be positive", p.JobType)
+		}
+		gc.MaxAges[p.JobType] = maxAge
+	}
+
+	return gc, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestGCConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		config *config.ClientGCConfig
+		exp    *GCConfig
+		expErr string
+	}{
+		{
+			name:   "nil",
+			config: nil,
+			exp:    nil,
+		},
+		{
+			name: "valid",
+			config: &config.ClientGCConfig{
+				DiskUsageLowThreshold: pointer.Of(60.0),
+				Policies: []*config.ClientGCPolicy{
+					{JobType: "batch", MaxAge: "1h"},
+					{JobType: "sysbatch", MaxAge: "30m"},
+				},
+			},
+			exp: &GCConfig{
+				DiskUsageLowThreshold: 60,
+				MaxAges: map[string]time.Duration{
+					"batch":    time.Hour,
+					"sysbatch": 30 * time.Minute,
+				},
+			},
+		},
+		{
+			name: "invalid low threshold",
+			config: &config.ClientGCConfig{
+				DiskUsageLowThreshold: pointer.Of(120.0),
+			},
+			expErr: "disk_usage_low_threshold must be between 0 and 100 but found 120",
+		},
+		{
+			name: "invalid job type",
+			config: &config.ClientGCConfig{
+				Policies: []*config.ClientGCPolicy{{JobType: "foo", MaxAge: "1h"}},
+			},
+			expErr: `invalid job_type "foo" for policy`,
+		},
+		{
+			name: "duplicate job type",
+			config: &config.ClientGCConfig{
+				Policies: []*config.ClientGCPolicy{
+					{JobType: "batch", MaxAge: "1h"},
+					{JobType: "batch", MaxAge: "2h"},
+				},
+			},
+			expErr: `duplicate policy for job_type "batch"`,
+		},
+		{
+			name: "invalid max age",
+			config: &config.ClientGCConfig{
+				Policies: []*config.ClientGCPolicy{{JobType: "batch", MaxAge: "foo"}},
+			},
+			expErr: "error parsing max_age of batch policy",
+		},
+		{
+			name: "non-positive max age",
+			config: &config.ClientGCConfig{
+				Policies: []*config.ClientGCPolicy{{JobType: "batch", MaxAge: "0s"}},
+			},
+			expErr: "max_age of batch policy must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc, err := GCConfigFromAgent(tc.config)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, gc)
+		})
+	}
+}
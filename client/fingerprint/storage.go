@@ -51,13 +51,45 @@ func (f *StorageFingerprint) Fingerprint(req *FingerprintRequest, resp *Fingerpr
 	resp.AddAttribute("unique.storage.volume", volume)
 	resp.AddAttribute("unique.storage.bytestotal", strconv.FormatUint(total, 10))
 
+	classes, err := f.hostDiskClasses(cfg.HostDisks)
+	if err != nil {
+		return err
+	}
+
 	// set the disk size for the response
 	resp.NodeResources = &structs.NodeResources{
 		Disk: structs.NodeDiskResources{
-			DiskMB: int64(total / bytesPerMegabyte),
+			DiskMB:  int64(total / bytesPerMegabyte),
+			Classes: classes,
 		},
 	}
 	resp.Detected = true
 
 	return nil
 }
+
+// hostDiskClasses returns the capacity in megabytes of each configured host
+// disk class. Classes without a configured size use the size of the volume
+// their path is on.
+func (f *StorageFingerprint) hostDiskClasses(disks map[string]*structs.ClientHostDiskConfig) (map[string]int64, error) {
+	if len(disks) == 0 {
+		return nil, nil
+	}
+
+	classes := make(map[string]int64, len(disks))
+	for name, disk := range disks {
+		size, err := disk.SizeMB()
+		if err != nil {
+			return nil, fmt.Errorf("invalid size for host disk %q: %v", name, err)
+		}
+		if size == 0 {
+			_, total, err := f.diskInfo(disk.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to determine disk space for host disk %q at %s: %v", name, disk.Path, err)
+			}
+			size = int64(total / bytesPerMegabyte)
+		}
+		classes[name] = size
+	}
+	return classes, nil
+}
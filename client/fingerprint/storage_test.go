@@ -8,8 +8,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 )
 
 func TestStorageFingerprint(t *testing.T) {
@@ -38,3 +40,28 @@ func TestStorageFingerprint(t *testing.T) {
 		t.Errorf("Expected node.NodeResources.DiskMB.DiskMB to be non-zero")
 	}
 }
+
+func TestStorageFingerprint_HostDisks(t *testing.T) {
+	ci.Parallel(t)
+
+	fp := NewStorageFingerprint(testlog.HCLogger(t))
+	node := &structs.Node{
+		Attributes: make(map[string]string),
+	}
+
+	cfg := &config.Config{
+		HostDisks: map[string]*structs.ClientHostDiskConfig{
+			"fast": {Name: "fast", Path: t.TempDir(), Size: "10GiB"},
+			"bulk": {Name: "bulk", Path: t.TempDir()},
+		},
+	}
+	request := &FingerprintRequest{Config: cfg, Node: node}
+	var response FingerprintResponse
+	must.NoError(t, fp.Fingerprint(request, &response))
+
+	must.NotNil(t, response.NodeResources)
+	classes := response.NodeResources.Disk.Classes
+	must.MapLen(t, 2, classes)
+	must.Eq(t, 10*1024, classes["fast"])
+	must.Positive(t, classes["bulk"])
+}
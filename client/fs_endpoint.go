@@ -157,6 +157,36 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	return nil
 }
 
+// Write is used to write a file in the directory of a task.
+func (f *FileSystem) Write(args *cstructs.FsWriteRequest, reply *cstructs.FsWriteResponse) error {
+	defer metrics.MeasureSince([]string{"client", "file_system", "write"}, time.Now())
+
+	alloc, err := f.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace write-fs permission.
+	if aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityWriteFS) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Path == "" {
+		return pathNotPresentErr
+	}
+	if len(args.Data) > cstructs.FsWriteMaxBytes {
+		return fmt.Errorf("file exceeds the maximum size of %d bytes", cstructs.FsWriteMaxBytes)
+	}
+
+	fs, err := f.c.GetAllocFS(args.AllocID)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(args.Path, args.Data, os.FileMode(args.FileMode))
+}
+
 // stream is is used to stream the contents of file in an allocation's
 // directory.
 func (f *FileSystem) stream(conn io.ReadWriteCloser) {
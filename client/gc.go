@@ -7,11 +7,15 @@ import (
 	"container/heap"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/hoststats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 )
 
 const (
@@ -19,6 +23,15 @@ const (
 	MB = 1024 * 1024
 )
 
+// The policies that trigger garbage collection other than the max age for a
+// job type, which is named after the job type.
+const (
+	gcPolicyDiskUsage  = "disk_usage"
+	gcPolicyInodeUsage = "inode_usage"
+	gcPolicyMaxAllocs  = "max_allocs"
+	gcPolicyForced     = "forced"
+)
+
 // GCConfig allows changing the behaviour of the garbage collector
 type GCConfig struct {
 	// MaxAllocs is the maximum number of allocations to track before a GC
@@ -29,6 +42,33 @@ type GCConfig struct {
 	Interval            time.Duration
 	ReservedDiskMB      int
 	ParallelDestroys    int
+
+	// DiskUsageLowThreshold is the disk usage that garbage collection started
+	// by exceeding DiskUsageThreshold continues until usage is below. Zero
+	// means DiskUsageThreshold.
+	DiskUsageLowThreshold float64
+
+	// MaxAges is the duration after becoming terminal that
+	// allocations of each job type are garbage collected.
+	MaxAges map[string]time.Duration
+}
+
+// newGCConfig returns the garbage collector configuration of the client
+// configuration cfg.
+func newGCConfig(cfg *config.Config) *GCConfig {
+	gcConfig := &GCConfig{
+		MaxAllocs:           cfg.GCMaxAllocs,
+		DiskUsageThreshold:  cfg.GCDiskUsageThreshold,
+		InodeUsageThreshold: cfg.GCInodeUsageThreshold,
+		Interval:            cfg.GCInterval,
+		ParallelDestroys:    cfg.GCParallelDestroys,
+		ReservedDiskMB:      int(cfg.Node.ReservedResources.Disk.DiskMB),
+	}
+	if cfg.GC != nil {
+		gcConfig.DiskUsageLowThreshold = cfg.GC.DiskUsageLowThreshold
+		gcConfig.MaxAges = cfg.GC.MaxAges
+	}
+	return gcConfig
 }
 
 // AllocCounter is used by AllocGarbageCollector to discover how many un-GC'd
@@ -39,7 +79,10 @@ type AllocCounter interface {
 
 // AllocGarbageCollector garbage collects terminated allocations on a node
 type AllocGarbageCollector struct {
-	config *GCConfig
+	// config is replaced when the client configuration is reloaded, and must
+	// only be accessed with configLock held.
+	config     *GCConfig
+	configLock sync.Mutex
 
 	// allocRunners marked for GC
 	allocRunners *IndexedGCAllocPQ
@@ -60,6 +103,15 @@ type AllocGarbageCollector struct {
 	// triggerCh is ticked by the Trigger method to cause a GC
 	triggerCh chan struct{}
 
+	// diskUsageHigh is true once disk usage exceeds the disk usage threshold
+	// and until it drops below the low threshold
+	diskUsageHigh bool
+
+	// collected counts the allocations garbage collected, and lastCollected
+	// is the unix nanosecond time the last one was, for debugging
+	collected     atomic.Uint64
+	lastCollected atomic.Int64
+
 	logger hclog.Logger
 }
 
@@ -90,7 +142,8 @@ func NewAllocGarbageCollector(logger hclog.Logger, statsCollector hoststats.Node
 
 // Run the periodic garbage collector.
 func (a *AllocGarbageCollector) Run() {
-	ticker := time.NewTicker(a.config.Interval)
+	interval := a.getConfig().Interval
+	ticker := time.NewTicker(interval)
 	for {
 		select {
 		case <-a.triggerCh:
@@ -100,12 +153,41 @@ func (a *AllocGarbageCollector) Run() {
 			return
 		}
 
+		// Pick up the interval of a reloaded configuration
+		if newInterval := a.getConfig().Interval; newInterval > 0 && newInterval != interval {
+			interval = newInterval
+			ticker.Reset(interval)
+		}
+
+		a.collectExpired()
+
 		if err := a.keepUsageBelowThreshold(); err != nil {
 			a.logger.Error("error garbage collecting allocations", "error", err)
 		}
 	}
 }
 
+// getConfig returns the current configuration of the garbage collector.
+func (a *AllocGarbageCollector) getConfig() *GCConfig {
+	a.configLock.Lock()
+	defer a.configLock.Unlock()
+	return a.config
+}
+
+// SetConfig replaces the thresholds, interval and max ages of the garbage
+// collector with those of cfg, and triggers a collection so that they take
+// effect immediately. The number of parallel destroys is fixed when the
+// garbage collector is created and is not changed.
+func (a *AllocGarbageCollector) SetConfig(cfg *GCConfig) {
+	a.configLock.Lock()
+	newConfig := *cfg
+	newConfig.ParallelDestroys = a.config.ParallelDestroys
+	a.config = &newConfig
+	a.configLock.Unlock()
+
+	a.Trigger()
+}
+
 // Trigger forces the garbage collector to run.
 func (a *AllocGarbageCollector) Trigger() {
 	select {
@@ -133,23 +215,45 @@ func (a *AllocGarbageCollector) keepUsageBelowThreshold() error {
 		// See if we are below thresholds for used disk space and inode usage
 		diskStats := a.statsCollector.Stats().AllocDirStats
 		reason := ""
+		policy := ""
 		logf := a.logger.Warn
 
 		liveAllocs := a.allocCounter.NumAllocs()
+		cfg := a.getConfig()
+
+		// Once disk usage exceeds the threshold keep collecting until it
+		// drops below the low threshold, so that GC doesn't flap around the
+		// threshold
+		lowThreshold := cfg.DiskUsageLowThreshold
+		if lowThreshold <= 0 || lowThreshold > cfg.DiskUsageThreshold {
+			lowThreshold = cfg.DiskUsageThreshold
+		}
+		if diskStats.UsedPercent > cfg.DiskUsageThreshold {
+			a.diskUsageHigh = true
+		} else if diskStats.UsedPercent <= lowThreshold {
+			a.diskUsageHigh = false
+		}
 
 		switch {
-		case diskStats.UsedPercent > a.config.DiskUsageThreshold:
+		case diskStats.UsedPercent > cfg.DiskUsageThreshold:
 			reason = fmt.Sprintf("disk usage of %.0f is over gc threshold of %.0f",
-				diskStats.UsedPercent, a.config.DiskUsageThreshold)
-		case diskStats.InodesUsedPercent > a.config.InodeUsageThreshold:
+				diskStats.UsedPercent, cfg.DiskUsageThreshold)
+			policy = gcPolicyDiskUsage
+		case a.diskUsageHigh:
+			reason = fmt.Sprintf("disk usage of %.0f is over gc low threshold of %.0f",
+				diskStats.UsedPercent, lowThreshold)
+			policy = gcPolicyDiskUsage
+		case diskStats.InodesUsedPercent > cfg.InodeUsageThreshold:
 			reason = fmt.Sprintf("inode usage of %.0f is over gc threshold of %.0f",
-				diskStats.InodesUsedPercent, a.config.InodeUsageThreshold)
-		case liveAllocs > a.config.MaxAllocs:
+				diskStats.InodesUsedPercent, cfg.InodeUsageThreshold)
+			policy = gcPolicyInodeUsage
+		case liveAllocs > cfg.MaxAllocs:
 			// if we're unable to gc, don't WARN until at least 2x over limit
-			if liveAllocs < (a.config.MaxAllocs * 2) {
+			if liveAllocs < (cfg.MaxAllocs * 2) {
 				logf = a.logger.Info
 			}
-			reason = fmt.Sprintf("number of allocations (%d) is over the limit (%d)", liveAllocs, a.config.MaxAllocs)
+			reason = fmt.Sprintf("number of allocations (%d) is over the limit (%d)", liveAllocs, cfg.MaxAllocs)
+			policy = gcPolicyMaxAllocs
 		}
 
 		if reason == "" {
@@ -160,20 +264,45 @@ func (a *AllocGarbageCollector) keepUsageBelowThreshold() error {
 		// Collect an allocation
 		gcAlloc := a.allocRunners.Pop()
 		if gcAlloc == nil {
-			logf("garbage collection skipped because no terminal allocations", "reason", reason)
+			logf("garbage collection skipped because no terminal allocations", "reason", reason, "policy", policy)
 			break
 		}
 
-		go a.destroyAllocRunner(gcAlloc.allocID, gcAlloc.allocRunner, reason)
+		go a.destroyAllocRunner(gcAlloc.allocID, gcAlloc.allocRunner, reason, policy)
 	}
 	return nil
 }
 
+// collectExpired garbage collects terminal allocations which have been marked
+// for collection for longer than the max age for their job type.
+func (a *AllocGarbageCollector) collectExpired() {
+	cfg := a.getConfig()
+	if len(cfg.MaxAges) == 0 {
+		return
+	}
+
+	now := time.Now()
+	expired := a.allocRunners.RemoveFunc(func(gcAlloc *GCAlloc) bool {
+		maxAge, ok := cfg.MaxAges[gcAlloc.jobType()]
+		return ok && now.Sub(gcAlloc.timeStamp) > maxAge
+	})
+
+	for _, gcAlloc := range expired {
+		jobType := gcAlloc.jobType()
+		reason := fmt.Sprintf("terminal for longer than the max age of %s for %s jobs",
+			cfg.MaxAges[jobType], jobType)
+		go a.destroyAllocRunner(gcAlloc.allocID, gcAlloc.allocRunner, reason, jobType)
+	}
+}
+
 // destroyAllocRunner is used to destroy an allocation runner. It will acquire a
 // lock to restrict parallelism and then destroy the alloc runner, returning
-// once the allocation has been destroyed.
-func (a *AllocGarbageCollector) destroyAllocRunner(allocID string, ar interfaces.AllocRunner, reason string) {
-	a.logger.Info("garbage collecting allocation", "alloc_id", allocID, "reason", reason)
+// once the allocation has been destroyed. The policy that triggered the
+// collection is logged and recorded in metrics.
+func (a *AllocGarbageCollector) destroyAllocRunner(allocID string, ar interfaces.AllocRunner, reason, policy string) {
+	a.logger.Info("garbage collecting allocation", "alloc_id", allocID, "reason", reason, "policy", policy)
+	metrics.IncrCounterWithLabels([]string{"client", "gc", "allocs_collected"}, 1,
+		[]metrics.Label{{Name: "policy", Value: policy}})
 
 	// Acquire the destroy lock
 	select {
@@ -190,11 +319,30 @@ func (a *AllocGarbageCollector) destroyAllocRunner(allocID string, ar interfaces
 	}
 
 	a.logger.Debug("alloc garbage collected", "alloc_id", allocID)
+	a.collected.Add(1)
+	a.lastCollected.Store(time.Now().UnixNano())
 
 	// Release the lock
 	<-a.destroyCh
 }
 
+// Stats returns the state of the garbage collector for debugging.
+func (a *AllocGarbageCollector) Stats() *cstructs.GCStats {
+	config := a.getConfig()
+	stats := &cstructs.GCStats{
+		TrackedAllocs:       a.allocRunners.Length(),
+		NumAllocs:           a.allocCounter.NumAllocs(),
+		MaxAllocs:           config.MaxAllocs,
+		DiskUsageThreshold:  config.DiskUsageThreshold,
+		InodeUsageThreshold: config.InodeUsageThreshold,
+		Collected:           a.collected.Load(),
+	}
+	if last := a.lastCollected.Load(); last != 0 {
+		stats.LastCollected = time.Unix(0, last)
+	}
+	return stats
+}
+
 func (a *AllocGarbageCollector) Stop() {
 	close(a.shutdownCh)
 }
@@ -208,7 +356,7 @@ func (a *AllocGarbageCollector) Collect(allocID string) bool {
 		return false
 	}
 
-	a.destroyAllocRunner(allocID, gcAlloc.allocRunner, "forced collection")
+	a.destroyAllocRunner(allocID, gcAlloc.allocRunner, "forced collection", gcPolicyForced)
 	return true
 }
 
@@ -226,7 +374,7 @@ func (a *AllocGarbageCollector) CollectAll() {
 			return
 		}
 
-		go a.destroyAllocRunner(gcAlloc.allocID, gcAlloc.allocRunner, "forced full node collection")
+		go a.destroyAllocRunner(gcAlloc.allocID, gcAlloc.allocRunner, "forced full node collection", gcPolicyForced)
 	}
 }
 
@@ -246,6 +394,14 @@ type GCAlloc struct {
 	index       int
 }
 
+// jobType returns the type of the allocation's job.
+func (g *GCAlloc) jobType() string {
+	if alloc := g.allocRunner.Alloc(); alloc != nil && alloc.Job != nil {
+		return alloc.Job.Type
+	}
+	return ""
+}
+
 type GCAllocPQImpl []*GCAlloc
 
 func (pq GCAllocPQImpl) Len() int {
@@ -341,6 +497,22 @@ func (i *IndexedGCAllocPQ) Remove(allocID string) *GCAlloc {
 	return nil
 }
 
+// RemoveFunc removes and returns the allocs for which fn returns true.
+func (i *IndexedGCAllocPQ) RemoveFunc(fn func(*GCAlloc) bool) []*GCAlloc {
+	i.pqLock.Lock()
+	defer i.pqLock.Unlock()
+
+	var removed []*GCAlloc
+	for allocID, gcAlloc := range i.index {
+		if fn(gcAlloc) {
+			heap.Remove(&i.heap, gcAlloc.index)
+			delete(i.index, allocID)
+			removed = append(removed, gcAlloc)
+		}
+	}
+	return removed
+}
+
 func (i *IndexedGCAllocPQ) Length() int {
 	i.pqLock.Lock()
 	defer i.pqLock.Unlock()
@@ -167,6 +167,39 @@ func TestAllocGarbageCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestAllocGarbageCollector_Stats(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	gc := NewAllocGarbageCollector(logger, &MockStatsCollector{}, &MockAllocCounter{}, gcConfig())
+
+	ar1, cleanup1 := allocrunner.TestAllocRunnerFromAlloc(t, mock.Alloc())
+	defer cleanup1()
+	ar2, cleanup2 := allocrunner.TestAllocRunnerFromAlloc(t, mock.Alloc())
+	defer cleanup2()
+
+	go ar1.Run()
+	go ar2.Run()
+
+	gc.MarkForCollection(ar1.Alloc().ID, ar1)
+	gc.MarkForCollection(ar2.Alloc().ID, ar2)
+
+	stats := gc.Stats()
+	must.Eq(t, 2, stats.TrackedAllocs)
+	must.Eq(t, gcConfig().MaxAllocs, stats.MaxAllocs)
+	must.Eq(t, 0, stats.Collected)
+	must.True(t, stats.LastCollected.IsZero())
+
+	// Exit the alloc runners
+	exitAllocRunner(ar1, ar2)
+
+	must.True(t, gc.Collect(ar1.Alloc().ID))
+	stats = gc.Stats()
+	must.Eq(t, 1, stats.TrackedAllocs)
+	must.Eq(t, 1, stats.Collected)
+	must.False(t, stats.LastCollected.IsZero())
+}
+
 func TestAllocGarbageCollector_CollectAll(t *testing.T) {
 	ci.Parallel(t)
 
@@ -267,3 +300,110 @@ func TestAllocGarbageCollector_KeepUsageBelowThreshold(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocGarbageCollector_KeepUsageBelowLowThreshold(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	config := gcConfig()
+	config.DiskUsageLowThreshold = 60
+
+	// each collection reads the stats once per collected alloc and once more
+	// when there is nothing left to collect
+	stats := &MockStatsCollector{
+		availableValues: []uint64{0, 0, 0, 0, 0},
+		usedPercents:    []float64{85, 85, 70, 70, 55},
+		inodePercents:   []float64{0, 0, 0, 0, 0},
+	}
+	gc := NewAllocGarbageCollector(logger, stats, &MockAllocCounter{}, config)
+
+	collect := func() *GCAlloc {
+		ar, cleanup := allocrunner.TestAllocRunnerFromAlloc(t, mock.Alloc())
+		t.Cleanup(cleanup)
+		exitAllocRunner(ar)
+		gc.MarkForCollection(ar.Alloc().ID, ar)
+
+		must.NoError(t, gc.keepUsageBelowThreshold())
+		return gc.allocRunners.Pop()
+	}
+
+	// above the threshold
+	must.Nil(t, collect())
+
+	// below the threshold but above the low threshold
+	must.Nil(t, collect())
+
+	// below the low threshold
+	must.NotNil(t, collect())
+}
+
+func TestAllocGarbageCollector_CollectExpired(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	config := gcConfig()
+	config.MaxAges = map[string]time.Duration{
+		structs.JobTypeBatch: time.Hour,
+	}
+	gc := NewAllocGarbageCollector(logger, &MockStatsCollector{}, &MockAllocCounter{}, config)
+
+	mark := func(alloc *structs.Allocation, age time.Duration) interfaces.AllocRunner {
+		ar, cleanup := allocrunner.TestAllocRunnerFromAlloc(t, alloc)
+		t.Cleanup(cleanup)
+		exitAllocRunner(ar)
+		gc.MarkForCollection(alloc.ID, ar)
+		gc.allocRunners.index[alloc.ID].timeStamp = time.Now().Add(-age)
+		return ar
+	}
+
+	// expired batch alloc
+	mark(mock.BatchAlloc(), 2*time.Hour)
+
+	// unexpired batch alloc
+	batch := mark(mock.BatchAlloc(), time.Minute)
+
+	// service alloc without a policy
+	service := mark(mock.Alloc(), 2*time.Hour)
+
+	gc.collectExpired()
+
+	must.Eq(t, 2, gc.allocRunners.Length())
+	for _, ar := range []interfaces.AllocRunner{batch, service} {
+		_, ok := gc.allocRunners.index[ar.Alloc().ID]
+		must.True(t, ok)
+	}
+}
+
+func TestAllocGarbageCollector_SetConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	stats := &MockStatsCollector{
+		availableValues: []uint64{0},
+		usedPercents:    []float64{0},
+		inodePercents:   []float64{0},
+	}
+	gc := NewAllocGarbageCollector(logger, stats, &MockAllocCounter{allocs: 50}, gcConfig())
+
+	ar, cleanup := allocrunner.TestAllocRunnerFromAlloc(t, mock.Alloc())
+	t.Cleanup(cleanup)
+	exitAllocRunner(ar)
+	gc.MarkForCollection(ar.Alloc().ID, ar)
+
+	// below the max allocs
+	must.NoError(t, gc.keepUsageBelowThreshold())
+	must.Eq(t, 1, gc.allocRunners.Length())
+
+	config := gcConfig()
+	config.MaxAllocs = 10
+	config.ParallelDestroys = 8
+	gc.SetConfig(config)
+
+	// the parallelism is fixed when the garbage collector is created
+	must.Eq(t, 1, gc.getConfig().ParallelDestroys)
+	must.Eq(t, 10, gc.getConfig().MaxAllocs)
+
+	// above the reloaded max allocs
+	must.NoError(t, gc.keepUsageBelowThreshold())
+	must.Eq(t, 0, gc.allocRunners.Length())
+}
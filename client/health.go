@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/client/config"
+)
+
+const (
+	// healthComponentCNI is the name of the health component of the CNI
+	// plugins found in the client's cni_path.
+	healthComponentCNI = "cni"
+
+	// healthComponentArtifactSandbox is the name of the health component of
+	// the prerequisites of downloading artifacts.
+	healthComponentArtifactSandbox = "artifact_sandbox"
+
+	// healthComponentDriverPrefix and healthComponentCSIPrefix prefix the
+	// names of the health components of task drivers and CSI node plugins,
+	// as in "driver.docker".
+	healthComponentDriverPrefix = "driver."
+	healthComponentCSIPrefix    = "csi."
+
+	// cniPluginAttributePrefix prefixes the node attributes set by the CNI
+	// plugins fingerprinter for each plugin found.
+	cniPluginAttributePrefix = "plugins.cni.version."
+)
+
+// ComponentHealth is the health of one of the components the client depends
+// on to run workloads, such as a task driver or a CSI node plugin.
+type ComponentHealth struct {
+	// Healthy is true if the component is usable.
+	Healthy bool `json:"healthy"`
+
+	// Fatal is true if the client is unhealthy when the component is not.
+	Fatal bool `json:"fatal"`
+
+	// Detail describes the health of the component.
+	Detail string `json:"detail,omitempty"`
+
+	// LastChecked is when the health of the component was last updated.
+	LastChecked time.Time `json:"last_checked"`
+}
+
+// ComponentsHealth returns the health of the task drivers, CSI node plugins,
+// CNI plugins and artifact sandbox of the client, keyed by component name.
+func (c *Client) ComponentsHealth() map[string]*ComponentHealth {
+	return componentsHealth(c.GetConfig(), c.getter.Check(), time.Now())
+}
+
+// componentsHealth returns the health of the components of the client
+// configured by cfg, given the result of the artifact sandbox check.
+//
+// Task drivers which were never detected, such as those which are disabled or
+// whose binary is not installed, are reported but not fatal, since no task is
+// placed on them. The same goes for CNI when none of the directories of
+// cni_path exist. Components named in the HealthNonFatal configuration are
+// never fatal.
+func componentsHealth(cfg *config.Config, sandboxErr error, now time.Time) map[string]*ComponentHealth {
+	components := make(map[string]*ComponentHealth)
+
+	for name, info := range cfg.Node.Drivers {
+		components[healthComponentDriverPrefix+name] = &ComponentHealth{
+			Healthy:     info.Detected && info.Healthy,
+			Fatal:       info.Detected,
+			Detail:      info.HealthDescription,
+			LastChecked: info.UpdateTime,
+		}
+	}
+
+	for name, info := range cfg.Node.CSINodePlugins {
+		components[healthComponentCSIPrefix+name] = &ComponentHealth{
+			Healthy:     info.Healthy,
+			Fatal:       true,
+			Detail:      info.HealthDescription,
+			LastChecked: info.UpdateTime,
+		}
+	}
+
+	components[healthComponentCNI] = cniHealth(cfg.CNIPath, cfg.Node.Attributes, now)
+
+	sandbox := &ComponentHealth{
+		Healthy:     true,
+		Fatal:       true,
+		Detail:      "healthy",
+		LastChecked: now,
+	}
+	if sandboxErr != nil {
+		sandbox.Healthy = false
+		sandbox.Detail = sandboxErr.Error()
+	}
+	components[healthComponentArtifactSandbox] = sandbox
+
+	for _, name := range cfg.HealthNonFatal {
+		if component, ok := components[name]; ok {
+			component.Fatal = false
+		}
+	}
+	return components
+}
+
+// cniHealth returns the health of the CNI plugins found in cniPath by the
+// fingerprinter, which sets an attribute for each of them.
+func cniHealth(cniPath string, attrs map[string]string, now time.Time) *ComponentHealth {
+	health := &ComponentHealth{LastChecked: now}
+
+	var found int
+	for _, dir := range filepath.SplitList(cniPath) {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			found++
+		}
+	}
+	if found == 0 {
+		health.Detail = fmt.Sprintf("no directory of cni_path %q exists", cniPath)
+		return health
+	}
+
+	var plugins int
+	for attr := range attrs {
+		if strings.HasPrefix(attr, cniPluginAttributePrefix) {
+			plugins++
+		}
+	}
+
+	health.Fatal = true
+	if plugins == 0 {
+		health.Detail = fmt.Sprintf("no CNI plugins found in cni_path %q", cniPath)
+		return health
+	}
+	health.Healthy = true
+	health.Detail = fmt.Sprintf("%d CNI plugins found", plugins)
+	return health
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHealth_componentsHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	updated := now.Add(-time.Minute)
+	cniDir := t.TempDir()
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			CNIPath: cniDir,
+			Node: &structs.Node{
+				Attributes: map[string]string{
+					"plugins.cni.version.bridge": "v1.0.0",
+				},
+				Drivers: map[string]*structs.DriverInfo{
+					"docker": {
+						Detected:          true,
+						Healthy:           false,
+						HealthDescription: "Docker is unresponsive",
+						UpdateTime:        updated,
+					},
+					"exec": {
+						Detected:          true,
+						Healthy:           true,
+						HealthDescription: "Healthy",
+						UpdateTime:        updated,
+					},
+					"qemu": {
+						Detected:          false,
+						Healthy:           false,
+						HealthDescription: "Driver qemu is not detected",
+						UpdateTime:        updated,
+					},
+				},
+				CSINodePlugins: map[string]*structs.CSIInfo{
+					"ebs": {
+						Healthy:           false,
+						HealthDescription: "unhealthy",
+						UpdateTime:        updated,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("components", func(t *testing.T) {
+		components := componentsHealth(newConfig(), nil, now)
+		must.Eq(t, map[string]*ComponentHealth{
+			"driver.docker": {
+				Healthy:     false,
+				Fatal:       true,
+				Detail:      "Docker is unresponsive",
+				LastChecked: updated,
+			},
+			"driver.exec": {
+				Healthy:     true,
+				Fatal:       true,
+				Detail:      "Healthy",
+				LastChecked: updated,
+			},
+			"driver.qemu": {
+				Healthy:     false,
+				Fatal:       false,
+				Detail:      "Driver qemu is not detected",
+				LastChecked: updated,
+			},
+			"csi.ebs": {
+				Healthy:     false,
+				Fatal:       true,
+				Detail:      "unhealthy",
+				LastChecked: updated,
+			},
+			"cni": {
+				Healthy:     true,
+				Fatal:       true,
+				Detail:      "1 CNI plugins found",
+				LastChecked: now,
+			},
+			"artifact_sandbox": {
+				Healthy:     true,
+				Fatal:       true,
+				Detail:      "healthy",
+				LastChecked: now,
+			},
+		}, components)
+	})
+
+	t.Run("non fatal", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.HealthNonFatal = []string{"driver.docker", "csi.ebs", "driver.unknown"}
+		components := componentsHealth(cfg, nil, now)
+		must.False(t, components["driver.docker"].Fatal)
+		must.False(t, components["csi.ebs"].Fatal)
+		must.True(t, components["driver.exec"].Fatal)
+		must.MapNotContainsKey(t, components, "driver.unknown")
+	})
+
+	t.Run("sandbox unavailable", func(t *testing.T) {
+		components := componentsHealth(newConfig(), errors.New("nomad executable is missing"), now)
+		must.Eq(t, &ComponentHealth{
+			Healthy:     false,
+			Fatal:       true,
+			Detail:      "nomad executable is missing",
+			LastChecked: now,
+		}, components["artifact_sandbox"])
+	})
+
+	t.Run("no cni plugins", func(t *testing.T) {
+		cfg := newConfig()
+		delete(cfg.Node.Attributes, "plugins.cni.version.bridge")
+		components := componentsHealth(cfg, nil, now)
+		must.False(t, components["cni"].Healthy)
+		must.True(t, components["cni"].Fatal)
+		must.StrContains(t, components["cni"].Detail, "no CNI plugins found")
+	})
+
+	t.Run("no cni path", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.CNIPath = filepath.Join(cniDir, "missing")
+		components := componentsHealth(cfg, nil, now)
+		must.False(t, components["cni"].Healthy)
+		must.False(t, components["cni"].Fatal)
+		must.StrContains(t, components["cni"].Detail, "no directory of cni_path")
+	})
+}
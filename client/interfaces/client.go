@@ -4,6 +4,8 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/hashicorp/nomad/client/lib/idset"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/client/lib/proclib"
@@ -40,8 +42,113 @@ type EnvReplacer interface {
 
 // ArtifactGetter is an interface satisfied by the getter package.
 type ArtifactGetter interface {
-	// Get artifact and put it in the task directory.
-	Get(EnvReplacer, *structs.TaskArtifact, string) error
+	// Get artifact and put it in the task directory. The result describes
+	// how the artifact was fetched, and is returned even if it failed.
+	Get(EnvReplacer, *structs.TaskArtifact, string) (*FetchResult, error)
+
+	// GetWithCredentials gets an artifact like Get, using the credentials
+	// issued for its download.
+	GetWithCredentials(EnvReplacer, *structs.TaskArtifact, string, *ArtifactCredentials) (*FetchResult, error)
+
+	// Prefetch artifact into the client's artifact cache without placing it
+	// in a task directory.
+	Prefetch(EnvReplacer, *structs.TaskArtifact, string) error
+
+	// Check returns an error if the prerequisites of getting artifacts are
+	// not met.
+	Check() error
+}
+
+// FetchCacheStatus is how the client artifact cache served the fetch of an
+// artifact.
+type FetchCacheStatus string
+
+const (
+	// FetchCacheNone is the status of artifacts fetched without the cache.
+	FetchCacheNone FetchCacheStatus = ""
+
+	// FetchCacheHit is the status of artifacts restored from the cache.
+	FetchCacheHit FetchCacheStatus = "hit"
+
+	// FetchCacheMiss is the status of artifacts downloaded because the cache
+	// held no copy of them.
+	FetchCacheMiss FetchCacheStatus = "miss"
+
+	// FetchCacheStale is the status of artifacts restored from a stale copy
+	// in the cache after their download failed.
+	FetchCacheStale FetchCacheStatus = "stale"
+)
+
+// FetchResult describes how a single artifact was fetched, such as for task
+// events and debugging.
+type FetchResult struct {
+	// Duration is how long fetching the artifact took.
+	Duration time.Duration
+
+	// Bytes is the number of bytes of http responses received while fetching
+	// the artifact. It is zero for artifacts fetched without http, such as
+	// with git.
+	Bytes int64
+
+	// Redirects is the number of http redirects followed.
+	Redirects int
+
+	// Retries is the number of times the artifact was fetched again after an
+	// attempt failed, such as when a cached copy could not be restored.
+	Retries int
+
+	// Cache is how the client artifact cache served the artifact.
+	Cache FetchCacheStatus
+
+	// Scheme is the scheme the artifact was finally fetched with, such as
+	// https after a redirect from http, or git.
+	Scheme string
+
+	// SHA256 is the hex encoded SHA-256 digest of the content received,
+	// whether or not the artifact declares a checksum, computed as the
+	// content is received. For archives it is the digest of the archive
+	// rather than of the extracted files. For artifacts fetched without http,
+	// such as with git or from s3, and for artifacts whose content is
+	// received in more than one response, such as manifests, it is instead
+	// the digest of the file written, or the tree digest of the files written
+	// to a directory.
+	SHA256 string
+}
+
+// ArtifactCredentials are the credentials issued for the download of a single
+// artifact. They are only kept in memory for the duration of the download.
+type ArtifactCredentials struct {
+	// ClientCert is presented when downloading the artifact over TLS.
+	ClientCert *ArtifactClientCert
+
+	// AWS is used in place of the client's credentials when downloading
+	// the artifact from S3.
+	AWS *ArtifactAWSCredentials
+
+	// SSHKey is the PEM encoded private key git and hg connect to ssh
+	// remotes with, read from the Nomad variable the artifact's sshkey
+	// option references.
+	SSHKey string
+
+	// NegotiateKeytab is the base64 encoded keytab the artifact's SPNEGO
+	// principal logs in with, read from the Nomad variable the artifact's
+	// negotiate_keytab option references.
+	NegotiateKeytab string
+}
+
+// ArtifactClientCert is a PEM encoded client certificate and private key
+// presented when downloading an artifact over TLS.
+type ArtifactClientCert struct {
+	Certificate string
+	PrivateKey  string
+}
+
+// ArtifactAWSCredentials are the AWS credentials used when downloading an
+// artifact from S3. The session token is only set for temporary credentials.
+type ArtifactAWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
 }
 
 // ProcessWranglers is an interface satisfied by the proclib package.
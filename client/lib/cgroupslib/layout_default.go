@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package cgroupslib
+
+// Layout returns nothing on non-Linux systems.
+func Layout() (string, []string, error) {
+	return "", nil, nil
+}
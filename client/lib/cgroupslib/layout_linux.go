@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// Layout returns the path of the cgroup Nomad nests the cgroups of tasks
+// under, and the paths of the cgroups nested under it relative to it. In
+// cgroups v1 the cpuset cgroups are returned, as the cpuset controller holds
+// the partitions of reserved and shared cores. Nothing is returned if cgroups
+// are not enabled.
+func Layout() (string, []string, error) {
+	var parent string
+	switch GetMode() {
+	case CG1:
+		parent = filepath.Join(root, "cpuset", NomadCgroupParent)
+	case CG2:
+		parent = filepath.Join(root, NomadCgroupParent)
+	default:
+		return "", nil, nil
+	}
+
+	var cgroups []string
+	err := filepath.WalkDir(parent, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || path == parent {
+			return nil
+		}
+		rel, err := filepath.Rel(parent, path)
+		if err != nil {
+			return err
+		}
+		cgroups = append(cgroups, rel)
+		return nil
+	})
+	return parent, cgroups, err
+}
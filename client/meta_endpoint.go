@@ -9,6 +9,7 @@ import (
 	"time"
 
 	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -35,10 +36,20 @@ func (n *NodeMeta) Apply(args *structs.NodeMetaApplyRequest, reply *structs.Node
 		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
 	}
 
-	var stateErr error
+	var stateErr, casErr error
 	var dyn map[string]*string
+	var index uint64
 
 	newNode := n.c.UpdateNode(func(node *structs.Node) {
+		// Reject check-and-set updates made against metadata which has
+		// since changed
+		if args.CheckIndex != nil && *args.CheckIndex != n.c.metaIndex {
+			casErr = structs.NewErrRPCCodedf(http.StatusConflict,
+				"node metadata check-and-set failed: modify index is %d but expected %d",
+				n.c.metaIndex, *args.CheckIndex)
+			return
+		}
+
 		// First update the Client's state store. This must be done
 		// atomically with updating the metadata inmemory to avoid
 		// bad interleaving between concurrent updates.
@@ -55,7 +66,8 @@ func (n *NodeMeta) Apply(args *structs.NodeMetaApplyRequest, reply *structs.Node
 			}
 		}
 
-		if stateErr = n.c.stateDB.PutNodeMeta(dyn); stateErr != nil {
+		index = n.c.metaIndex + 1
+		if stateErr = n.c.stateDB.PutNodeMeta(dyn, index); stateErr != nil {
 			return
 		}
 
@@ -72,8 +84,16 @@ func (n *NodeMeta) Apply(args *structs.NodeMetaApplyRequest, reply *structs.Node
 
 			node.Meta[k] = *v
 		}
+
+		// Wake blocking reads
+		n.c.metaIndex = index
+		close(n.c.metaIndexCh)
+		n.c.metaIndexCh = make(chan struct{})
 	})
 
+	if casErr != nil {
+		return casErr
+	}
 	if stateErr != nil {
 		return stateErr
 	}
@@ -84,6 +104,7 @@ func (n *NodeMeta) Apply(args *structs.NodeMetaApplyRequest, reply *structs.Node
 	reply.Meta = newNode.Meta
 	reply.Dynamic = dyn
 	reply.Static = n.c.metaStatic
+	reply.ModifyIndex = index
 	return nil
 }
 
@@ -98,12 +119,36 @@ func (n *NodeMeta) Read(args *structs.NodeSpecificRequest, reply *structs.NodeMe
 	}
 
 	// Must acquire configLock to ensure reads aren't interleaved with
-	// writes
+	// writes, so that the metadata returned is consistent with its index
 	n.c.configLock.Lock()
 	defer n.c.configLock.Unlock()
 
-	reply.Meta = n.c.config.Node.Meta
+	// Block until the metadata changes from the index the caller has already
+	// seen, or the query times out
+	if wait := args.TimeToBlock(); wait > 0 {
+		timer, stop := helper.NewSafeTimer(wait)
+		defer stop()
+
+	WAIT:
+		for n.c.metaIndex <= args.MinQueryIndex {
+			updateCh := n.c.metaIndexCh
+			n.c.configLock.Unlock()
+			select {
+			case <-updateCh:
+				n.c.configLock.Lock()
+			case <-timer.C:
+				n.c.configLock.Lock()
+				break WAIT
+			case <-n.c.shutdownCh:
+				n.c.configLock.Lock()
+				break WAIT
+			}
+		}
+	}
+
+	reply.Meta = maps.Clone(n.c.config.Node.Meta)
 	reply.Dynamic = maps.Clone(n.c.metaDynamic)
 	reply.Static = n.c.metaStatic
+	reply.ModifyIndex = n.c.metaIndex
 	return nil
 }
@@ -4,7 +4,9 @@
 package client
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
@@ -181,3 +183,87 @@ func TestNodeMeta_unset(t *testing.T) {
 	must.MapNotContainsKey(t, resp.Dynamic, "dynamic_meta")
 	must.MapNotContainsKey(t, resp.Meta, "dynamic_meta")
 }
+
+func TestNodeMeta_CAS(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	// Read the current index
+	var readResp structs.NodeMetaResponse
+	readReq := &structs.NodeSpecificRequest{NodeID: c1.NodeID()}
+	must.NoError(t, c1.ClientRPC("NodeMeta.Read", readReq, &readResp))
+	index := readResp.ModifyIndex
+	must.Positive(t, index)
+
+	// Applying against the current index succeeds and increments it
+	applyReq := &structs.NodeMetaApplyRequest{
+		NodeID:     c1.NodeID(),
+		Meta:       map[string]*string{"owner": pointer.Of("a")},
+		CheckIndex: pointer.Of(index),
+	}
+	var resp structs.NodeMetaResponse
+	must.NoError(t, c1.ClientRPC("NodeMeta.Apply", applyReq, &resp))
+	must.Eq(t, index+1, resp.ModifyIndex)
+	must.Eq(t, "a", resp.Meta["owner"])
+
+	// Applying against the stale index fails and leaves the metadata as-is
+	applyReq.Meta = map[string]*string{"owner": pointer.Of("b")}
+	err := c1.ClientRPC("NodeMeta.Apply", applyReq, &resp)
+	must.ErrorContains(t, err, "check-and-set")
+	code, _, ok := structs.CodeFromRPCCodedErr(err)
+	must.True(t, ok)
+	must.Eq(t, http.StatusConflict, code)
+
+	must.NoError(t, c1.ClientRPC("NodeMeta.Read", readReq, &readResp))
+	must.Eq(t, index+1, readResp.ModifyIndex)
+	must.Eq(t, "a", readResp.Meta["owner"])
+}
+
+func TestNodeMeta_blockingRead(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	var readResp structs.NodeMetaResponse
+	readReq := &structs.NodeSpecificRequest{NodeID: c1.NodeID()}
+	must.NoError(t, c1.ClientRPC("NodeMeta.Read", readReq, &readResp))
+	index := readResp.ModifyIndex
+
+	// Blocking reads time out if the metadata is unchanged
+	readReq.MinQueryIndex = index
+	readReq.MaxQueryTime = 50 * time.Millisecond
+	must.NoError(t, c1.ClientRPC("NodeMeta.Read", readReq, &readResp))
+	must.Eq(t, index, readResp.ModifyIndex)
+
+	// Blocking reads return once the metadata is modified
+	time.AfterFunc(100*time.Millisecond, func() {
+		applyReq := &structs.NodeMetaApplyRequest{
+			NodeID: c1.NodeID(),
+			Meta:   map[string]*string{"watched": pointer.Of("true")},
+		}
+		var resp structs.NodeMetaResponse
+		c1.ClientRPC("NodeMeta.Apply", applyReq, &resp)
+	})
+
+	readReq.MaxQueryTime = 10 * time.Second
+	start := time.Now()
+	must.NoError(t, c1.ClientRPC("NodeMeta.Read", readReq, &readResp))
+	must.Less(t, 5*time.Second, time.Since(start))
+	must.Eq(t, index+1, readResp.ModifyIndex)
+	must.Eq(t, "true", readResp.Meta["watched"])
+}
@@ -30,6 +30,7 @@ type rpcEndpoints struct {
 	NodeIdentity *NodeIdentity
 	NodeMeta     *NodeMeta
 	HostVolume   *HostVolume
+	Artifact     *ArtifactCache
 }
 
 // ClientRPC is used to make a local, client only RPC call
@@ -305,6 +306,7 @@ func (c *Client) setupClientRpc(rpcs map[string]interface{}) {
 		c.endpoints.NodeIdentity = newNodeIdentityEndpoint(c)
 		c.endpoints.NodeMeta = newNodeMetaEndpoint(c)
 		c.endpoints.HostVolume = newHostVolumesEndpoint(c)
+		c.endpoints.Artifact = newArtifactCacheEndpoint(c)
 		c.setupClientRpcServer(c.rpcServer)
 	}
 
@@ -322,6 +324,7 @@ func (c *Client) setupClientRpcServer(server *rpc.Server) {
 	_ = server.Register(c.endpoints.NodeIdentity)
 	server.Register(c.endpoints.NodeMeta)
 	server.Register(c.endpoints.HostVolume)
+	_ = server.Register(c.endpoints.Artifact)
 }
 
 // rpcConnListener is a long lived function that listens for new connections
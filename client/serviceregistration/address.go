@@ -5,6 +5,7 @@ package serviceregistration
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -177,6 +178,28 @@ func GetAddress(
 	}
 }
 
+// DualStackAddresses returns the IPv4 and IPv6 addresses to advertise in dual
+// mode for a service whose address resolved to ip. Both are known when ip is
+// an address of the allocation network which reported an address of each
+// family, otherwise ip is returned as the address of its own family.
+func DualStackAddresses(ip string, netStatus *structs.AllocNetworkStatus) (string, string) {
+	if netStatus != nil && netStatus.AddressIPv6 != "" && netStatus.Address != netStatus.AddressIPv6 {
+		if ip == netStatus.Address || ip == netStatus.AddressIPv6 {
+			return netStatus.Address, netStatus.AddressIPv6
+		}
+	}
+
+	parsed := net.ParseIP(ip)
+	switch {
+	case parsed == nil:
+		return "", ""
+	case parsed.To4() != nil:
+		return ip, ""
+	default:
+		return "", ip
+	}
+}
+
 // getAddressPort is a helper function to return the IPv6 or IPv4 address based on the addressMode
 func getAddressPort(addressMode string, netStatus *structs.AllocNetworkStatus, port int) (string, int, error) {
 	if addressMode == structs.AddressModeAllocIPv6 {
@@ -452,3 +452,66 @@ func Test_GetAddress(t *testing.T) {
 		})
 	}
 }
+
+func Test_DualStackAddresses(t *testing.T) {
+	dual := &structs.AllocNetworkStatus{
+		Address:     "172.26.64.2",
+		AddressIPv6: "fd00:26::2",
+	}
+	ipv6Only := &structs.AllocNetworkStatus{
+		Address:     "fd00:26::2",
+		AddressIPv6: "fd00:26::2",
+	}
+
+	testCases := []struct {
+		name    string
+		ip      string
+		status  *structs.AllocNetworkStatus
+		expIPv4 string
+		expIPv6 string
+	}{
+		{
+			name:    "alloc address",
+			ip:      "172.26.64.2",
+			status:  dual,
+			expIPv4: "172.26.64.2",
+			expIPv6: "fd00:26::2",
+		},
+		{
+			name:    "alloc ipv6 address",
+			ip:      "fd00:26::2",
+			status:  dual,
+			expIPv4: "172.26.64.2",
+			expIPv6: "fd00:26::2",
+		},
+		{
+			name:    "host ipv4 address",
+			ip:      "10.0.0.1",
+			status:  dual,
+			expIPv4: "10.0.0.1",
+		},
+		{
+			name:    "host ipv6 address",
+			ip:      "2001:db8::1",
+			expIPv6: "2001:db8::1",
+		},
+		{
+			name:    "ipv6 only alloc",
+			ip:      "fd00:26::2",
+			status:  ipv6Only,
+			expIPv6: "fd00:26::2",
+		},
+		{
+			name: "agent address",
+			ip:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ipv4, ipv6 := DualStackAddresses(tc.ip, tc.status)
+			require.Equal(t, tc.expIPv4, ipv4)
+			require.Equal(t, tc.expIPv6, ipv6)
+		})
+	}
+}
@@ -388,6 +388,12 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		copy(tags, serviceSpec.Tags)
 	}
 
+	// Use the canary weights when this is a canary which sets them.
+	weights := serviceSpec.Weights
+	if workload.Canary && serviceSpec.CanaryWeights != nil {
+		weights = serviceSpec.CanaryWeights
+	}
+
 	return &structs.ServiceRegistration{
 		ID:          serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID, workload.Name(), serviceSpec),
 		ServiceName: serviceSpec.Name,
@@ -399,6 +405,7 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		Tags:        tags,
 		Address:     ip,
 		Port:        port,
+		Weights:     weights.Copy(),
 	}, nil
 }
 
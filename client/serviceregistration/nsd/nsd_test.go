@@ -341,6 +341,33 @@ func TestServiceRegistrationHandler_UpdateWorkload(t *testing.T) {
 
 }
 
+func TestServiceRegistrationHandler_generateNomadServiceRegistration_weights(t *testing.T) {
+	h := NewServiceRegistrationHandler(hclog.NewNullLogger(), &ServiceRegistrationHandlerCfg{
+		Enabled:      true,
+		CheckWatcher: new(mockCheckWatcher),
+	}).(*ServiceRegistrationHandler)
+
+	workload := mockWorkload()
+	service := workload.Services[0]
+	service.Weights = &structs.ServiceWeights{Passing: 10, Warning: 1}
+
+	// canaries use the weights when they set no canary weights
+	workload.Canary = true
+	reg, err := h.generateNomadServiceRegistration(service, workload)
+	must.NoError(t, err)
+	must.Eq(t, &structs.ServiceWeights{Passing: 10, Warning: 1}, reg.Weights)
+
+	service.CanaryWeights = &structs.ServiceWeights{Passing: 1, Warning: 1}
+	reg, err = h.generateNomadServiceRegistration(service, workload)
+	must.NoError(t, err)
+	must.Eq(t, &structs.ServiceWeights{Passing: 1, Warning: 1}, reg.Weights)
+
+	workload.Canary = false
+	reg, err = h.generateNomadServiceRegistration(service, workload)
+	must.NoError(t, err)
+	must.Eq(t, &structs.ServiceWeights{Passing: 10, Warning: 1}, reg.Weights)
+}
+
 func TestServiceRegistrationHandler_dedupUpdatedWorkload(t *testing.T) {
 	testCases := []struct {
 		inputOldWorkload  *serviceregistration.WorkloadServices
@@ -37,6 +37,11 @@ type WorkloadServices struct {
 	// Can be nil.
 	NetworkStatus *structs.AllocNetworkStatus
 
+	// AdvertiseMode is the address family the services are advertised with,
+	// from the task group. In dual mode the services are also registered with
+	// the address of each family where the provider supports it.
+	AdvertiseMode string
+
 	// AllocatedPorts is the list of port mappings.
 	Ports structs.AllocatedPorts
 
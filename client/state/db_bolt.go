@@ -34,6 +34,7 @@ allocations/
    |--> alloc          -> allocEntry{*structs.Allocation}
 	 |--> deploy_status  -> deployStatusEntry{*structs.AllocDeploymentStatus}
 	 |--> network_status -> networkStatusEntry{*structs.AllocNetworkStatus}
+	 |--> cni_result -> cniResultEntry{*cstructs.CNIResult}
 	 |--> acknowledged_state -> acknowledgedStateEntry{*arstate.State}
 	 |--> alloc_volumes -> allocVolumeStatesEntry{arstate.AllocVolumes}
      |--> alloc_identities -> allocIdentitiesEntry{}
@@ -92,6 +93,9 @@ var (
 	// stored under
 	allocNetworkStatusKey = []byte("network_status")
 
+	// allocCNIResultKey is the key *cstructs.CNIResult is stored under
+	allocCNIResultKey = []byte("cni_result")
+
 	// acknowledgedStateKey is the key *arstate.State is stored under
 	acknowledgedStateKey = []byte("acknowledged_state")
 
@@ -138,6 +142,10 @@ var (
 	// nodeMetaKey is the key at which dynamic node metadata is stored.
 	nodeMetaKey = []byte("meta")
 
+	// nodeMetaIndexKey is the key at which the modify index of the node
+	// metadata is stored.
+	nodeMetaIndexKey = []byte("index")
+
 	// nodeBucket is the bucket name in which data about the node is stored.
 	nodeBucket = []byte("node")
 
@@ -428,6 +436,60 @@ func (s *BoltStateDB) GetNetworkStatus(allocID string) (*structs.AllocNetworkSta
 	return entry.NetworkStatus, nil
 }
 
+// cniResultEntry wraps values for CNIResult keys.
+type cniResultEntry struct {
+	CNIResult *cstructs.CNIResult
+}
+
+// PutAllocCNIResult stores the result of the CNI plugins which configured an
+// allocation's network.
+func (s *BoltStateDB) PutAllocCNIResult(allocID string, res *cstructs.CNIResult, opts ...WriteOption) error {
+	return s.updateWithOptions(opts, func(tx *boltdd.Tx) error {
+		allocBkt, err := getAllocationBucket(tx, allocID)
+		if err != nil {
+			return err
+		}
+
+		entry := cniResultEntry{
+			CNIResult: res,
+		}
+		return allocBkt.Put(allocCNIResultKey, &entry)
+	})
+}
+
+// GetAllocCNIResult retrieves the result of the CNI plugins which configured
+// an allocation's network, which may be nil.
+func (s *BoltStateDB) GetAllocCNIResult(allocID string) (*cstructs.CNIResult, error) {
+	var entry cniResultEntry
+
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		allAllocsBkt := tx.Bucket(allocationsBucketName)
+		if allAllocsBkt == nil {
+			// No state, return
+			return nil
+		}
+
+		allocBkt := allAllocsBkt.Bucket([]byte(allocID))
+		if allocBkt == nil {
+			// No state for alloc, return
+			return nil
+		}
+
+		return allocBkt.Get(allocCNIResultKey, &entry)
+	})
+
+	// It's valid for this field to be nil/missing
+	if boltdd.IsErrNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.CNIResult, nil
+}
+
 // PutAcknowledgedState stores an allocation's last acknowledged state or
 // returns an error if it could not be stored.
 func (s *BoltStateDB) PutAcknowledgedState(allocID string, state *arstate.State, opts ...WriteOption) error {
@@ -1042,23 +1104,26 @@ func (s *BoltStateDB) PurgeCheckResults(allocID string) error {
 }
 
 // PutNodeMeta sets dynamic node metadata for merging with the copy from the
-// Client's config.
+// Client's config, along with the modify index of the node metadata.
 //
 // This overwrites existing dynamic node metadata entirely.
-func (s *BoltStateDB) PutNodeMeta(meta map[string]*string) error {
+func (s *BoltStateDB) PutNodeMeta(meta map[string]*string, index uint64) error {
 	return s.db.Update(func(tx *boltdd.Tx) error {
 		b, err := tx.CreateBucketIfNotExists(nodeMetaBucket)
 		if err != nil {
 			return err
 		}
 
-		return b.Put(nodeMetaKey, meta)
+		if err := b.Put(nodeMetaKey, meta); err != nil {
+			return err
+		}
+		return b.Put(nodeMetaIndexKey, index)
 	})
 }
 
 // GetNodeMeta retrieves node metadata for merging with the copy from
-// the Client's config.
-func (s *BoltStateDB) GetNodeMeta() (m map[string]*string, err error) {
+// the Client's config, along with the modify index of the node metadata.
+func (s *BoltStateDB) GetNodeMeta() (m map[string]*string, index uint64, err error) {
 	err = s.db.View(func(tx *boltdd.Tx) error {
 		b := tx.Bucket(nodeMetaBucket)
 		if b == nil {
@@ -1066,10 +1131,19 @@ func (s *BoltStateDB) GetNodeMeta() (m map[string]*string, err error) {
 		}
 
 		m, err = getNodeMeta(b)
-		return err
+		if err != nil {
+			return err
+		}
+
+		// the index is missing if the metadata was written by an older
+		// client, in which case it starts from zero
+		if err := b.Get(nodeMetaIndexKey, &index); err != nil && !boltdd.IsErrNotFound(err) {
+			return err
+		}
+		return nil
 	})
 
-	return m, err
+	return m, index, err
 }
 
 func getNodeMeta(b *boltdd.Bucket) (map[string]*string, error) {
@@ -59,6 +59,14 @@ func (m *ErrDB) PutNetworkStatus(allocID string, ns *structs.AllocNetworkStatus,
 	return fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) GetAllocCNIResult(allocID string) (*cstructs.CNIResult, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) PutAllocCNIResult(allocID string, res *cstructs.CNIResult, opts ...WriteOption) error {
+	return fmt.Errorf("Error!")
+}
+
 func (m *ErrDB) PutAcknowledgedState(allocID string, state *arstate.State, opts ...WriteOption) error {
 	return fmt.Errorf("Error!")
 }
@@ -143,12 +151,12 @@ func (m *ErrDB) PurgeCheckResults(allocID string) error {
 	return fmt.Errorf("Error!")
 }
 
-func (m *ErrDB) PutNodeMeta(map[string]*string) error {
+func (m *ErrDB) PutNodeMeta(map[string]*string, uint64) error {
 	return fmt.Errorf("Error!")
 }
 
-func (m *ErrDB) GetNodeMeta() (map[string]*string, error) {
-	return nil, fmt.Errorf("Error!")
+func (m *ErrDB) GetNodeMeta() (map[string]*string, uint64, error) {
+	return nil, 0, fmt.Errorf("Error!")
 }
 
 func (m *ErrDB) PutNodeRegistration(reg *cstructs.NodeRegistration) error {
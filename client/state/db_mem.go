@@ -31,6 +31,9 @@ type MemDB struct {
 	// alloc_id -> value
 	networkStatus map[string]*structs.AllocNetworkStatus
 
+	// alloc_id -> value
+	cniResults map[string]*cstructs.CNIResult
+
 	// alloc_id -> value
 	acknowledgedState map[string]*arstate.State
 
@@ -62,6 +65,9 @@ type MemDB struct {
 	// key -> value or nil
 	nodeMeta map[string]*string
 
+	// modify index of the node metadata
+	nodeMetaIndex uint64
+
 	nodeRegistration *cstructs.NodeRegistration
 
 	dynamicHostVolumes map[string]*cstructs.HostVolumeState
@@ -80,6 +86,7 @@ func NewMemDB(logger hclog.Logger) *MemDB {
 		allocs:             make(map[string]*structs.Allocation),
 		deployStatus:       make(map[string]*structs.AllocDeploymentStatus),
 		networkStatus:      make(map[string]*structs.AllocNetworkStatus),
+		cniResults:         make(map[string]*cstructs.CNIResult),
 		acknowledgedState:  make(map[string]*arstate.State),
 		localTaskState:     make(map[string]map[string]*state.LocalState),
 		taskState:          make(map[string]map[string]*structs.TaskState),
@@ -145,6 +152,19 @@ func (m *MemDB) PutNetworkStatus(allocID string, ns *structs.AllocNetworkStatus,
 	return nil
 }
 
+func (m *MemDB) GetAllocCNIResult(allocID string) (*cstructs.CNIResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cniResults[allocID], nil
+}
+
+func (m *MemDB) PutAllocCNIResult(allocID string, res *cstructs.CNIResult, _ ...WriteOption) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cniResults[allocID] = res
+	return nil
+}
+
 func (m *MemDB) PutAcknowledgedState(allocID string, state *arstate.State, opts ...WriteOption) error {
 	m.mu.Lock()
 	m.acknowledgedState[allocID] = state
@@ -275,6 +295,7 @@ func (m *MemDB) DeleteAllocationBucket(allocID string, _ ...WriteOption) error {
 	delete(m.taskState, allocID)
 	delete(m.localTaskState, allocID)
 	delete(m.identities, allocID)
+	delete(m.cniResults, allocID)
 
 	return nil
 }
@@ -354,17 +375,18 @@ func (m *MemDB) PurgeCheckResults(allocID string) error {
 	return nil
 }
 
-func (m *MemDB) PutNodeMeta(nm map[string]*string) error {
+func (m *MemDB) PutNodeMeta(nm map[string]*string, index uint64) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.nodeMeta = nm
+	m.nodeMetaIndex = index
 	return nil
 }
 
-func (m *MemDB) GetNodeMeta() (map[string]*string, error) {
+func (m *MemDB) GetNodeMeta() (map[string]*string, uint64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.nodeMeta, nil
+	return m.nodeMeta, m.nodeMetaIndex, nil
 }
 
 func (m *MemDB) PutNodeRegistration(reg *cstructs.NodeRegistration) error {
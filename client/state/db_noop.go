@@ -51,6 +51,14 @@ func (n NoopDB) PutNetworkStatus(allocID string, ds *structs.AllocNetworkStatus,
 	return nil
 }
 
+func (n NoopDB) GetAllocCNIResult(allocID string) (*cstructs.CNIResult, error) {
+	return nil, nil
+}
+
+func (n NoopDB) PutAllocCNIResult(allocID string, res *cstructs.CNIResult, opts ...WriteOption) error {
+	return nil
+}
+
 func (n NoopDB) PutAcknowledgedState(allocID string, state *arstate.State, opts ...WriteOption) error {
 	return nil
 }
@@ -131,12 +139,12 @@ func (n NoopDB) PurgeCheckResults(allocID string) error {
 	return nil
 }
 
-func (n NoopDB) PutNodeMeta(map[string]*string) error {
+func (n NoopDB) PutNodeMeta(map[string]*string, uint64) error {
 	return nil
 }
 
-func (n NoopDB) GetNodeMeta() (map[string]*string, error) {
-	return nil, nil
+func (n NoopDB) GetNodeMeta() (map[string]*string, uint64, error) {
+	return nil, 0, nil
 }
 
 func (n NoopDB) PutNodeRegistration(reg *cstructs.NodeRegistration) error {
@@ -511,6 +511,27 @@ func TestStateDB_NodeIdentity(t *testing.T) {
 	})
 }
 
+func TestStateDB_NodeMeta(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		meta, index, err := db.GetNodeMeta()
+		must.NoError(t, err)
+		must.Nil(t, meta)
+		must.Eq(t, 0, index)
+
+		value := "bar"
+		must.NoError(t, db.PutNodeMeta(map[string]*string{"foo": &value, "unset": nil}, 7))
+
+		meta, index, err = db.GetNodeMeta()
+		must.NoError(t, err)
+		must.Eq(t, 7, index)
+		must.Eq(t, "bar", *meta["foo"])
+		must.MapContainsKey(t, meta, "unset")
+		must.Nil(t, meta["unset"])
+	})
+}
+
 func TestStateDB_ConsulACLToken(t *testing.T) {
 	ci.Parallel(t)
 
@@ -537,6 +558,40 @@ func TestStateDB_ConsulACLToken(t *testing.T) {
 	})
 }
 
+func TestStateDB_CNIResult(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		alloc1 := mock.Alloc()
+
+		must.NoError(t, db.PutAllocation(alloc1))
+		res, err := db.GetAllocCNIResult(alloc1.ID)
+		must.NoError(t, err)
+		must.Nil(t, res)
+
+		cniResult := &cstructs.CNIResult{
+			Interfaces: []*cstructs.CNIInterface{{
+				Name:      "eth0",
+				MAC:       "aa:bb:cc:dd:ee:ff",
+				Sandbox:   "/var/run/netns/" + alloc1.ID,
+				Addresses: []*cstructs.CNIAddress{{Address: "172.26.64.2", Gateway: "172.26.64.1"}},
+			}},
+			Routes: []*cstructs.CNIRoute{{Destination: "0.0.0.0/0", Gateway: "172.26.64.1"}},
+		}
+		must.NoError(t, db.PutAllocCNIResult(alloc1.ID, cniResult))
+
+		res, err = db.GetAllocCNIResult(alloc1.ID)
+		must.NoError(t, err)
+		must.Eq(t, cniResult, res)
+
+		// the result is deleted along with the allocation
+		must.NoError(t, db.DeleteAllocationBucket(alloc1.ID))
+		res, err = db.GetAllocCNIResult(alloc1.ID)
+		must.NoError(t, err)
+		must.Nil(t, res)
+	})
+}
+
 // TestStateDB_Upgrade asserts calling Upgrade on new databases always
 // succeeds.
 func TestStateDB_Upgrade(t *testing.T) {
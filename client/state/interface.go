@@ -46,6 +46,14 @@ type StateDB interface {
 	// PutNetworkStatus puts the allocation's network status. It may be nil.
 	PutNetworkStatus(allocID string, ns *structs.AllocNetworkStatus, opts ...WriteOption) error
 
+	// GetAllocCNIResult gets the result of the CNI plugins which configured
+	// the allocation's network. It may be nil.
+	GetAllocCNIResult(allocID string) (*cstructs.CNIResult, error)
+
+	// PutAllocCNIResult puts the result of the CNI plugins which configured
+	// the allocation's network.
+	PutAllocCNIResult(allocID string, res *cstructs.CNIResult, opts ...WriteOption) error
+
 	// PutAcknowledgedState stores an allocation's last acknowledged state or
 	// returns an error if it could not be stored.
 	PutAcknowledgedState(string, *arstate.State, ...WriteOption) error
@@ -125,14 +133,14 @@ type StateDB interface {
 	GetCheckResults() (checks.ClientResults, error)
 
 	// PutNodeMeta sets dynamic node metadata for merging with the copy from the
-	// Client's config.
+	// Client's config, along with the modify index of the node metadata.
 	//
 	// This overwrites existing dynamic node metadata entirely.
-	PutNodeMeta(map[string]*string) error
+	PutNodeMeta(map[string]*string, uint64) error
 
 	// GetNodeMeta retrieves node metadata for merging with the copy from
-	// the Client's config.
-	GetNodeMeta() (map[string]*string, error)
+	// the Client's config, along with the modify index of the node metadata.
+	GetNodeMeta() (map[string]*string, uint64, error)
 
 	PutNodeRegistration(*cstructs.NodeRegistration) error
 	GetNodeRegistration() (*cstructs.NodeRegistration, error)
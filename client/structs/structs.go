@@ -37,6 +37,105 @@ type ClientStatsResponse struct {
 	structs.QueryMeta
 }
 
+// ClientDebugResponse is used to return the state of the subsystems of a
+// node for debugging. Each subsystem is collected separately, and the errors
+// of those which could not be collected are returned in Errors, keyed by the
+// name of the subsystem, rather than failing the request.
+type ClientDebugResponse struct {
+	// Artifacts are the artifact downloads in progress.
+	Artifacts []*ArtifactDownload
+
+	// Templates are the dependency states of the templates of each task,
+	// keyed by allocation ID and task name.
+	Templates map[string]map[string][]*TemplateDependencyState
+
+	// Cgroups is the layout of the cgroups managed by the client.
+	Cgroups *CgroupLayout
+
+	// GC is the state of the allocation garbage collector.
+	GC *GCStats
+
+	// Errors are the errors of the subsystems which could not be collected.
+	Errors map[string]string
+
+	structs.QueryMeta
+}
+
+// ArtifactDownload is an artifact download in progress.
+type ArtifactDownload struct {
+	// Source is the source of the artifact, with any credentials redacted.
+	Source string
+
+	// Destination is the destination of the artifact relative to the task
+	// directory.
+	Destination string
+
+	// StartedAt is when the download started.
+	StartedAt time.Time
+
+	// Duration is how long the download has been in progress for.
+	Duration time.Duration
+}
+
+// TemplateDependencyState is the state of the dependencies of a template.
+type TemplateDependencyState struct {
+	// DestPath is the destination of the template relative to the task
+	// directory.
+	DestPath string
+
+	// Rendered is whether the template has been rendered since the task
+	// started.
+	Rendered bool
+
+	// LastRendered is when the template was last written to disk.
+	LastRendered time.Time
+
+	// MissingDeps are the dependencies the template is waiting for data of.
+	MissingDeps []string
+
+	// UsedDeps are all the dependencies of the template.
+	UsedDeps []string
+}
+
+// CgroupLayout is the layout of the cgroups managed by a client.
+type CgroupLayout struct {
+	// Mode is the cgroups version of the node, either "v1", "v2" or "off".
+	Mode string
+
+	// Root is the path of the cgroup all others are nested under.
+	Root string
+
+	// Cgroups are the paths of the cgroups nested under Root, relative to it.
+	Cgroups []string
+}
+
+// GCStats is the state of the allocation garbage collector of a client.
+type GCStats struct {
+	// TrackedAllocs is the number of terminal allocations marked for
+	// garbage collection.
+	TrackedAllocs int
+
+	// NumAllocs is the number of allocations which have not been garbage
+	// collected.
+	NumAllocs int
+
+	// MaxAllocs is the number of allocations beyond which allocations are
+	// garbage collected.
+	MaxAllocs int
+
+	// DiskUsageThreshold and InodeUsageThreshold are the usage percentages
+	// beyond which allocations are garbage collected.
+	DiskUsageThreshold  float64
+	InodeUsageThreshold float64
+
+	// Collected is the number of allocations garbage collected since the
+	// client started.
+	Collected uint64
+
+	// LastCollected is when an allocation was last garbage collected.
+	LastCollected time.Time
+}
+
 // MonitorRequest is used to request and stream logs from a client node.
 type MonitorRequest struct {
 	// LogLevel is the log level filter we want to stream logs on
@@ -141,6 +240,32 @@ type FsStatResponse struct {
 	structs.QueryMeta
 }
 
+// FsWriteMaxBytes is the maximum size of the content of a file written with
+// an FsWriteRequest.
+const FsWriteMaxBytes = 64 * 1024 * 1024
+
+// FsWriteRequest is used to write a file in the directory of a task.
+type FsWriteRequest struct {
+	// AllocID is the allocation to write the file in
+	AllocID string
+
+	// Path is the path of the file to write, relative to the alloc dir
+	Path string
+
+	// Data is the content of the file
+	Data []byte
+
+	// FileMode holds the permission bits of the file
+	FileMode uint32
+
+	structs.QueryOptions
+}
+
+// FsWriteResponse is used to return the result of writing a file
+type FsWriteResponse struct {
+	structs.QueryMeta
+}
+
 // FsStreamRequest is the initial request for streaming the content of a file.
 type FsStreamRequest struct {
 	// AllocID is the allocation to stream logs from
@@ -241,6 +366,128 @@ type AllocChecksResponse struct {
 	Results map[structs.CheckID]*structs.CheckQueryResult
 }
 
+// AllocNetworkingRequest is used to request the network namespace details of
+// a given allocation.
+type AllocNetworkingRequest struct {
+	// AllocID is the allocation to retrieve the networking details of
+	AllocID string
+
+	structs.QueryOptions
+}
+
+// AllocNetworkingResponse is used to return the network namespace details of
+// an allocation.
+type AllocNetworkingResponse struct {
+	Networking *AllocNetworking
+	structs.QueryMeta
+}
+
+// AllocNetworking describes the network of an allocation: the ports allocated
+// to it on the host, the ports they are mapped to in its network namespace,
+// and how the namespace was configured.
+type AllocNetworking struct {
+	// Mode is the network mode of the allocation, such as "host", "bridge",
+	// or "cni/<network>"
+	Mode string
+
+	// Ports are the ports allocated to the allocation, sorted by label
+	Ports []*AllocNetworkingPort
+
+	// InterfaceName, Address, and AddressIPv6 are the interface and addresses
+	// of the allocation in its network namespace, if it has one
+	InterfaceName string
+	Address       string
+	AddressIPv6   string
+
+	// DNS is the DNS configuration of the network namespace, if any
+	DNS *structs.DNSConfig
+
+	// CNI is the result of the CNI plugins the network namespace was
+	// configured with, or nil if it was not configured with CNI
+	CNI *CNIResult
+}
+
+// AllocNetworkingPort is a port allocated on the host and the port it is
+// mapped to in the network namespace of the allocation.
+type AllocNetworkingPort struct {
+	// Label is the label of the port in the network block
+	Label string
+
+	// HostIP and HostPort are the address and port allocated on the host
+	HostIP   string
+	HostPort int
+
+	// To is the port traffic to the host port is forwarded to in the network
+	// namespace, which is the host port itself unless the port is mapped
+	To int
+}
+
+// CNIResult is the result of the CNI plugins which configured the network
+// namespace of an allocation. It is kept in the client state so that it
+// remains available after the client restarts.
+type CNIResult struct {
+	// Interfaces are the interfaces created by the plugins, sorted by name
+	Interfaces []*CNIInterface
+
+	// Routes are the routes set by the plugins
+	Routes []*CNIRoute
+
+	// DNS is the DNS configuration returned by the plugins, if any
+	DNS *structs.DNSConfig
+}
+
+// CNIInterface is an interface created by a CNI plugin.
+type CNIInterface struct {
+	Name string
+	MAC  string
+
+	// Sandbox is the path of the network namespace of the interface, or
+	// empty if the interface is on the host
+	Sandbox string
+
+	// Addresses are the addresses and gateways of the interface
+	Addresses []*CNIAddress
+}
+
+// CNIAddress is an address of a CNI interface.
+type CNIAddress struct {
+	Address string
+	Gateway string
+}
+
+// CNIRoute is a route set by a CNI plugin, with the destination in CIDR
+// notation.
+type CNIRoute struct {
+	Destination string
+	Gateway     string
+}
+
+// Copy returns a deep copy of the CNI result.
+func (r *CNIResult) Copy() *CNIResult {
+	if r == nil {
+		return nil
+	}
+	c := &CNIResult{
+		Interfaces: make([]*CNIInterface, 0, len(r.Interfaces)),
+		Routes:     make([]*CNIRoute, 0, len(r.Routes)),
+		DNS:        r.DNS.Copy(),
+	}
+	for _, iface := range r.Interfaces {
+		ic := *iface
+		ic.Addresses = make([]*CNIAddress, 0, len(iface.Addresses))
+		for _, addr := range iface.Addresses {
+			ac := *addr
+			ic.Addresses = append(ic.Addresses, &ac)
+		}
+		c.Interfaces = append(c.Interfaces, &ic)
+	}
+	for _, route := range r.Routes {
+		rc := *route
+		c.Routes = append(c.Routes, &rc)
+	}
+	return c
+}
+
 // AllocStatsRequest is used to request the resource usage of a given
 // allocation, potentially filtering by task
 type AllocStatsRequest struct {
@@ -260,6 +507,40 @@ type AllocStatsResponse struct {
 	structs.QueryMeta
 }
 
+// AllocStatsHistoryRequest is used to request the recent resource usage
+// history of the tasks of an allocation, potentially filtering by task
+type AllocStatsHistoryRequest struct {
+	// AllocID is the allocation to retrieve the history for
+	AllocID string
+
+	// Task is an optional filter to only request the history of the task.
+	Task string
+
+	structs.QueryOptions
+}
+
+// AllocStatsHistoryResponse is used to return the resource usage history of
+// the tasks of an allocation.
+type AllocStatsHistoryResponse struct {
+	// History is the resource usage history of each task, keyed by task name
+	History map[string]*TaskResourceUsageHistory
+	structs.QueryMeta
+}
+
+// TaskResourceUsageHistory is the recent CPU and memory usage of a task, which
+// is kept for a while after the task exits.
+type TaskResourceUsageHistory struct {
+	// Samples are the resource usage of the task, oldest first, without the
+	// usage of individual processes and devices
+	Samples []*TaskResourceUsage
+
+	// Resolution is the minimum interval between samples
+	Resolution time.Duration
+
+	// ExitedAt is when the task exited (UnixNano), or zero while it runs
+	ExitedAt int64
+}
+
 // MemoryStats holds memory usage related stats
 type MemoryStats struct {
 	RSS            uint64
@@ -376,6 +376,13 @@ func (t *TaskEnv) ReplaceEnv(arg string) string {
 	return hargs.ReplaceEnv(arg, t.EnvMap, t.NodeAttrs, t.TaskSecrets)
 }
 
+// ReplaceEnvStrict replaces all occurrences of environment variables, Node
+// attributes, and task secrets in arg like ReplaceEnv, but returns an error
+// naming the expression if a function call cannot be evaluated.
+func (t *TaskEnv) ReplaceEnvStrict(arg string) (string, error) {
+	return hargs.ReplaceEnvStrict(arg, t.EnvMap, t.NodeAttrs, t.TaskSecrets)
+}
+
 // replaceEnvClient takes an arg and replaces all occurrences of client-specific
 // environment variables and Nomad variables.  If the variable is found in the
 // passed map it is replaced, otherwise the original string is returned.
@@ -442,6 +449,10 @@ type Builder struct {
 	// taskMeta are the meta attributes on the task
 	taskMeta map[string]string
 
+	// nodeMetaEnv is the node metadata exported as task meta, keyed by the
+	// name following MetaPrefix
+	nodeMetaEnv map[string]string
+
 	// allocDir from task's perspective; eg /alloc
 	allocDir string
 
@@ -479,6 +490,7 @@ type Builder struct {
 	vaultToken           string
 	vaultNamespace       string
 	injectVaultToken     bool
+	vaultClusterTokens   map[string]vaultClusterToken // cluster -> token
 	workloadTokenDefault string
 	workloadTokens       map[string]string // identity name -> encoded JWT
 	jobID                string
@@ -500,6 +512,10 @@ type Builder struct {
 	networkStatus  *structs.AllocNetworkStatus
 	allocatedPorts structs.AllocatedPorts
 
+	// advertiseMode is the advertise mode of the task group, which selects
+	// the address family of the network status used for env vars.
+	advertiseMode string
+
 	// hookEnvs are env vars set by hooks and stored by hook name to
 	// support adding/removing vars from multiple hooks (eg HookA adds A:1,
 	// HookB adds A:2, HookA removes A, A should equal 2)
@@ -623,7 +639,7 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	// Build the network namespace information if we have the required detail
 	// available.
 	if b.networkStatus != nil && b.allocatedPorts != nil {
-		addNomadAllocNetwork(envMap, b.allocatedPorts, b.networkStatus)
+		addNomadAllocNetwork(envMap, b.allocatedPorts, b.networkStatus.Advertised(b.advertiseMode))
 	}
 
 	// Build the Vault Token
@@ -636,6 +652,18 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 		envMap[VaultNamespace] = b.vaultNamespace
 	}
 
+	// Build the Vault Tokens and Namespaces of additional Vault blocks
+	for cluster, vt := range b.vaultClusterTokens {
+		if !vt.inject || vt.token == "" {
+			continue
+		}
+		suffix := "_" + helper.CleanEnvVar(strings.ToUpper(cluster), '_')
+		envMap[VaultToken+suffix] = vt.token
+		if vt.namespace != "" {
+			envMap[VaultNamespace+suffix] = vt.namespace
+		}
+	}
+
 	// Build the Nomad Workload Token
 	if b.workloadTokenDefault != "" {
 		envMap[WorkloadToken] = b.workloadTokenDefault
@@ -647,6 +675,13 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 		envMap[UnixAddr] = "unix://" + filepath.Join(secretsDir, "api.sock")
 	}
 
+	// Copy exported node meta first, as the meta and env vars defined by the
+	// job override it
+	for k, v := range b.nodeMetaEnv {
+		envMap[MetaPrefix+strings.ToUpper(k)] = v
+		envMap[MetaPrefix+k] = v
+	}
+
 	// Copy and interpolate task meta
 	for k, v := range b.taskMeta {
 		envMap[hargs.ReplaceEnv(k, nodeAttrs, envMap)] = hargs.ReplaceEnv(v, nodeAttrs, envMap)
@@ -827,6 +862,7 @@ func (b *Builder) setAlloc(alloc *structs.Allocation) *Builder {
 	}
 
 	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	b.advertiseMode = tg.AdvertiseMode
 
 	b.otherPorts = make(map[string]string, len(tg.Tasks)*2)
 
@@ -1115,6 +1151,16 @@ func (b *Builder) SetHostEnvvars(filter []string) *Builder {
 	return b
 }
 
+// SetNodeMetaEnv sets the node metadata exported into the task environment,
+// keyed by the name following NOMAD_META_. Both the given and the upper cased
+// names are set, like task meta.
+func (b *Builder) SetNodeMetaEnv(m map[string]string) *Builder {
+	b.mu.Lock()
+	b.nodeMetaEnv = m
+	b.mu.Unlock()
+	return b
+}
+
 func (b *Builder) SetTemplateEnv(m map[string]string) *Builder {
 	b.mu.Lock()
 	b.templateEnv = m
@@ -1131,6 +1177,31 @@ func (b *Builder) SetVaultToken(token, namespace string, inject bool) *Builder {
 	return b
 }
 
+// vaultClusterToken is the Vault token of one of the task's additional Vault
+// blocks.
+type vaultClusterToken struct {
+	token     string
+	namespace string
+	inject    bool
+}
+
+// SetVaultClusterToken sets the Vault token and namespace for the Vault block
+// of the given cluster. If inject is true they are exposed to the task as
+// VAULT_TOKEN_<CLUSTER> and VAULT_NAMESPACE_<CLUSTER>.
+func (b *Builder) SetVaultClusterToken(cluster, token, namespace string, inject bool) *Builder {
+	b.mu.Lock()
+	if b.vaultClusterTokens == nil {
+		b.vaultClusterTokens = map[string]vaultClusterToken{}
+	}
+	b.vaultClusterTokens[cluster] = vaultClusterToken{
+		token:     token,
+		namespace: namespace,
+		inject:    inject,
+	}
+	b.mu.Unlock()
+	return b
+}
+
 func (b *Builder) SetDefaultWorkloadToken(token string) *Builder {
 	b.mu.Lock()
 	b.workloadTokenDefault = token
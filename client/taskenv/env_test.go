@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/shoenig/test"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -570,6 +571,24 @@ func TestEnvironment_VaultToken(t *testing.T) {
 	}
 }
 
+func TestEnvironment_VaultClusterToken(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	a := mock.Alloc()
+	env := NewBuilder(n, a, a.Job.TaskGroups[0].Tasks[0], "global")
+	env.SetVaultToken("123", "", true)
+	env.SetVaultClusterToken("pki-east", "456", "pki", true)
+	env.SetVaultClusterToken("infra", "789", "", false)
+
+	act := env.Build().All()
+	require.Equal(t, "123", act[VaultToken])
+	require.Equal(t, "456", act["VAULT_TOKEN_PKI_EAST"])
+	require.Equal(t, "pki", act["VAULT_NAMESPACE_PKI_EAST"])
+	require.NotContains(t, act, "VAULT_TOKEN_INFRA")
+	require.NotContains(t, act, VaultNamespace)
+}
+
 func TestEnvironment_Envvars(t *testing.T) {
 	ci.Parallel(t)
 
@@ -591,6 +610,64 @@ func TestEnvironment_Envvars(t *testing.T) {
 	}
 }
 
+// TestEnvironment_NodeMetaEnv asserts exported node meta is set as task meta
+// env vars, which the meta and env vars of the job override.
+func TestEnvironment_NodeMetaEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	a := mock.Alloc()
+	a.Job.Meta = map[string]string{"NODE_zone": "job-zone"}
+	task := a.Job.TaskGroups[0].Tasks[0]
+	task.Env = map[string]string{"NOMAD_META_NODE_row": "task-row"}
+
+	builder := NewBuilder(n, a, task, "global")
+	builder.SetNodeMetaEnv(map[string]string{
+		"NODE_rack": "r1",
+		"NODE_zone": "z1",
+		"NODE_row":  "a",
+	})
+	out := builder.Build().All()
+
+	must.Eq(t, "r1", out["NOMAD_META_NODE_rack"])
+	must.Eq(t, "r1", out["NOMAD_META_NODE_RACK"])
+	must.Eq(t, "job-zone", out["NOMAD_META_NODE_zone"])
+	must.Eq(t, "job-zone", out["NOMAD_META_NODE_ZONE"])
+	must.Eq(t, "task-row", out["NOMAD_META_NODE_row"])
+
+	// node meta env vars are replaced rather than merged
+	builder.SetNodeMetaEnv(map[string]string{"NODE_zone": "z2"})
+	out = builder.Build().All()
+	must.MapNotContainsKey(t, out, "NOMAD_META_NODE_rack")
+}
+
+func TestEnvironment_Funcs(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	n.Attributes["unique.hostname"] = "Web-01.DC1.example.com"
+	a := mock.Alloc()
+	task := a.Job.TaskGroups[0].Tasks[0]
+	task.Env = map[string]string{
+		"SHORT_HOST": `${lower(split(attr.unique.hostname, ".")[0])}`,
+		"DOMAIN":     `${trimSuffix(attr.unique.hostname, ".example.com")}`,
+		"INVALID":    `${lower(attr.missing)}`,
+	}
+
+	env := NewBuilder(n, a, task, "global").Build()
+	out := env.All()
+	must.Eq(t, "web-01", out["SHORT_HOST"])
+	must.Eq(t, "Web-01.DC1", out["DOMAIN"])
+	must.Eq(t, "${lower(attr.missing)}", out["INVALID"])
+
+	replaced, err := env.ReplaceEnvStrict(`local/${upper(NOMAD_TASK_NAME)}.conf`)
+	must.NoError(t, err)
+	must.Eq(t, "local/"+strings.ToUpper(task.Name)+".conf", replaced)
+
+	_, err = env.ReplaceEnvStrict(task.Env["INVALID"])
+	must.ErrorContains(t, err, `unknown variable "attr.missing"`)
+}
+
 // TestEnvironment_HookVars asserts hook env vars are LWW and deletes of later
 // writes allow earlier hook's values to be visible.
 func TestEnvironment_HookVars(t *testing.T) {
@@ -888,6 +965,34 @@ func Test_addNetNamespacePort(t *testing.T) {
 	}
 }
 
+func TestEnvironment_AllocNetwork_AdvertiseMode(t *testing.T) {
+	ci.Parallel(t)
+
+	a := mock.Alloc()
+	a.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 25000, To: 8080, HostIP: "2001:db8::10"},
+	}
+	tg := a.Job.LookupTaskGroup(a.TaskGroup)
+	netStatus := &structs.AllocNetworkStatus{
+		InterfaceName: "eth0",
+		Address:       "172.26.64.11",
+		AddressIPv6:   "fd00:26::11",
+	}
+
+	env := NewBuilder(mock.Node(), a, tg.Tasks[0], "global").
+		SetNetworkStatus(netStatus).Build().Map()
+	must.Eq(t, "172.26.64.11", env["NOMAD_ALLOC_IP_http"])
+	must.Eq(t, "172.26.64.11:8080", env["NOMAD_ALLOC_ADDR_http"])
+	must.Eq(t, "[2001:db8::10]:25000", env["NOMAD_ADDR_http"])
+
+	tg.AdvertiseMode = structs.AdvertiseModeIPv6
+	env = NewBuilder(mock.Node(), a, tg.Tasks[0], "global").
+		SetNetworkStatus(netStatus).Build().Map()
+	must.Eq(t, "fd00:26::11", env["NOMAD_ALLOC_IP_http"])
+	must.Eq(t, "[fd00:26::11]:8080", env["NOMAD_ALLOC_ADDR_http"])
+	must.Eq(t, "2001:db8::10", env["NOMAD_IP_http"])
+}
+
 func TestEnvironment_SetPortMapEnvs(t *testing.T) {
 	ci.Parallel(t)
 
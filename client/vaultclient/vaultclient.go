@@ -40,6 +40,62 @@ type JWTLoginRequest struct {
 	Namespace string
 }
 
+// PKIIssueRequest is used to issue a certificate from a Vault PKI secrets
+// engine role.
+type PKIIssueRequest struct {
+	// Token is the Vault ACL token used to issue the certificate.
+	Token string
+
+	// Namespace is the Vault namespace of the PKI secrets engine. If empty,
+	// the Nomad client's Vault configuration namespace will be used.
+	Namespace string
+
+	// Path is the path of the PKI role's issue endpoint, such as
+	// "pki/issue/artifacts".
+	Path string
+
+	// CommonName is the common name requested for the certificate.
+	CommonName string
+
+	// TTL is the requested lifetime of the certificate. If zero the role's
+	// default TTL is used.
+	TTL time.Duration
+}
+
+// PKICertificate is a PEM encoded certificate and private key issued by a
+// Vault PKI secrets engine.
+type PKICertificate struct {
+	Certificate string
+	PrivateKey  string
+}
+
+// AWSCredentialsRequest is used to generate credentials from a Vault AWS
+// secrets engine role.
+type AWSCredentialsRequest struct {
+	// Token is the Vault ACL token used to generate the credentials.
+	Token string
+
+	// Namespace is the Vault namespace of the AWS secrets engine. If empty,
+	// the Nomad client's Vault configuration namespace will be used.
+	Namespace string
+
+	// Path is the path of the AWS role's credentials endpoint, such as
+	// "aws/creds/artifacts" or "aws/sts/artifacts".
+	Path string
+
+	// TTL is the requested lifetime of the credentials. If zero the role's
+	// default TTL is used.
+	TTL time.Duration
+}
+
+// AWSCredentials are AWS credentials generated by a Vault AWS secrets engine.
+// The session token is only set for temporary credentials.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
 // VaultClient is the interface which nomad client uses to interact with vault and
 // periodically renews the tokens and secrets.
 type VaultClient interface {
@@ -54,6 +110,13 @@ type VaultClient interface {
 	// duration.
 	DeriveTokenWithJWT(context.Context, JWTLoginRequest) (string, bool, int, error)
 
+	// IssueCertificate issues a certificate from a PKI secrets engine role.
+	IssueCertificate(context.Context, PKIIssueRequest) (*PKICertificate, error)
+
+	// GenerateAWSCredentials generates credentials from an AWS secrets
+	// engine role.
+	GenerateAWSCredentials(context.Context, AWSCredentialsRequest) (*AWSCredentials, error)
+
 	// RenewToken renews a token with the given increment and adds it to
 	// the min-heap for periodic renewal.
 	RenewToken(string, int) (<-chan error, error)
@@ -280,6 +343,108 @@ func (c *vaultClient) DeriveTokenWithJWT(ctx context.Context, req JWTLoginReques
 	return s.Auth.ClientToken, s.Auth.Renewable, s.Auth.LeaseDuration, nil
 }
 
+// IssueCertificate issues a certificate from a PKI secrets engine role using
+// the request's token.
+func (c *vaultClient) IssueCertificate(ctx context.Context, req PKIIssueRequest) (*PKICertificate, error) {
+	if !c.config.IsEnabled() {
+		return nil, fmt.Errorf("vault client not enabled")
+	}
+	if !c.isRunning() {
+		return nil, fmt.Errorf("vault client is not running")
+	}
+
+	c.lock.Lock()
+	defer c.unlockAndUnset()
+
+	c.client.SetToken(req.Token)
+	if req.Namespace != "" {
+		c.client.SetNamespace(req.Namespace)
+	}
+
+	data := map[string]any{}
+	if req.CommonName != "" {
+		data["common_name"] = req.CommonName
+	}
+	if req.TTL > 0 {
+		data["ttl"] = req.TTL.String()
+	}
+
+	s, err := c.client.Logical().WriteWithContext(ctx, req.Path, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate: %v", err)
+	}
+	if s == nil || s.Data == nil {
+		return nil, errors.New("certificate issue returned an empty secret")
+	}
+
+	cert, _ := s.Data["certificate"].(string)
+	key, _ := s.Data["private_key"].(string)
+	if cert == "" || key == "" {
+		return nil, errors.New("certificate issue did not return a certificate and private key")
+	}
+
+	for _, w := range s.Warnings {
+		c.logger.Warn("certificate issue warning", "warning", w)
+	}
+
+	return &PKICertificate{Certificate: cert, PrivateKey: key}, nil
+}
+
+// GenerateAWSCredentials generates credentials from an AWS secrets engine role
+// using the request's token. The lease of the credentials is not renewed.
+func (c *vaultClient) GenerateAWSCredentials(ctx context.Context, req AWSCredentialsRequest) (*AWSCredentials, error) {
+	if !c.config.IsEnabled() {
+		return nil, fmt.Errorf("vault client not enabled")
+	}
+	if !c.isRunning() {
+		return nil, fmt.Errorf("vault client is not running")
+	}
+
+	c.lock.Lock()
+	defer c.unlockAndUnset()
+
+	c.client.SetToken(req.Token)
+	if req.Namespace != "" {
+		c.client.SetNamespace(req.Namespace)
+	}
+
+	// the ttl can only be requested by writing to the endpoint
+	var s *vaultapi.Secret
+	var err error
+	if req.TTL > 0 {
+		s, err = c.client.Logical().WriteWithContext(ctx, req.Path, map[string]any{
+			"ttl": req.TTL.String(),
+		})
+	} else {
+		s, err = c.client.Logical().ReadWithContext(ctx, req.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate AWS credentials: %v", err)
+	}
+	if s == nil || s.Data == nil {
+		return nil, errors.New("AWS credentials request returned an empty secret")
+	}
+
+	creds := &AWSCredentials{}
+	creds.AccessKeyID, _ = s.Data["access_key"].(string)
+	creds.SecretAccessKey, _ = s.Data["secret_key"].(string)
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, errors.New("AWS credentials request did not return an access key and secret key")
+	}
+
+	// older versions of Vault return the token as security_token
+	creds.SessionToken, _ = s.Data["session_token"].(string)
+	if creds.SessionToken == "" {
+		creds.SessionToken, _ = s.Data["security_token"].(string)
+	}
+
+	for _, w := range s.Warnings {
+		c.logger.Warn("AWS credentials warning", "warning", w)
+	}
+
+	return creds, nil
+}
+
 // RenewToken renews the supplied token for a given duration (in seconds) and
 // adds it to the min-heap so that it is renewed periodically by the renewal
 // loop. Any error returned during renewal will be written to a buffered
@@ -37,6 +37,14 @@ type MockVaultClient struct {
 	// function.
 	deriveTokenWithJWTFn func(context.Context, JWTLoginRequest) (string, bool, int, error)
 
+	// issueCertificateFn allows the caller to control the IssueCertificate
+	// function.
+	issueCertificateFn func(context.Context, PKIIssueRequest) (*PKICertificate, error)
+
+	// generateAWSCredentialsFn allows the caller to control the
+	// GenerateAWSCredentials function.
+	generateAWSCredentialsFn func(context.Context, AWSCredentialsRequest) (*AWSCredentials, error)
+
 	// renewable determines if the tokens returned should be marked as renewable
 	renewable bool
 
@@ -70,6 +78,26 @@ func (vc *MockVaultClient) DeriveTokenWithJWT(ctx context.Context, req JWTLoginR
 	return token, vc.renewable, vc.duration, nil
 }
 
+func (vc *MockVaultClient) IssueCertificate(ctx context.Context, req PKIIssueRequest) (*PKICertificate, error) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	if vc.issueCertificateFn != nil {
+		return vc.issueCertificateFn(ctx, req)
+	}
+	return nil, fmt.Errorf("no certificate issuer configured")
+}
+
+func (vc *MockVaultClient) GenerateAWSCredentials(ctx context.Context, req AWSCredentialsRequest) (*AWSCredentials, error) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	if vc.generateAWSCredentialsFn != nil {
+		return vc.generateAWSCredentialsFn(ctx, req)
+	}
+	return nil, fmt.Errorf("no AWS credentials generator configured")
+}
+
 func (vc *MockVaultClient) SetDeriveTokenError(allocID string, tasks []string, err error) {
 	vc.mu.Lock()
 	defer vc.mu.Unlock()
@@ -168,3 +196,18 @@ func (vc *MockVaultClient) SetDeriveTokenWithJWTFn(f func(context.Context, JWTLo
 	defer vc.mu.Unlock()
 	vc.deriveTokenWithJWTFn = f
 }
+
+// SetIssueCertificateFn sets the function used to issue certificates.
+func (vc *MockVaultClient) SetIssueCertificateFn(f func(context.Context, PKIIssueRequest) (*PKICertificate, error)) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.issueCertificateFn = f
+}
+
+// SetGenerateAWSCredentialsFn sets the function used to generate AWS
+// credentials.
+func (vc *MockVaultClient) SetGenerateAWSCredentialsFn(f func(context.Context, AWSCredentialsRequest) (*AWSCredentials, error)) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.generateAWSCredentialsFn = f
+}
@@ -5,13 +5,16 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	golog "log"
 	"net"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -22,6 +25,7 @@ import (
 	metrics "github.com/hashicorp/go-metrics/compat"
 	uuidparse "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	clientconsul "github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/lib/idset"
@@ -41,6 +45,8 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/yamux"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 const (
@@ -133,6 +139,31 @@ type Agent struct {
 	taskAPIServer *builtinAPI
 
 	inmemSink *metrics.InmemSink
+
+	// tracerProvider exports the spans recorded by the server, if tracing is
+	// enabled.
+	tracerProvider *sdktrace.TracerProvider
+
+	// reloadCh receives requests to reload the agent configuration made over
+	// the HTTP API, which are served by the command running the agent as if
+	// it had received SIGHUP.
+	reloadCh chan *reloadRequest
+}
+
+// reloadRequest is a request to reload the agent configuration from its
+// configuration files.
+type reloadRequest struct {
+	// resultCh receives the result of the reload once it is done.
+	resultCh chan *reloadResult
+}
+
+// reloadResult is the result of reloading the agent configuration.
+type reloadResult struct {
+	// Changed is the sections of the configuration which changed.
+	Changed []string
+
+	// Err is the error which stopped the reload, if any.
+	Err error
 }
 
 // NewAgent is used to create a new agent with the given configuration
@@ -142,6 +173,7 @@ func NewAgent(config *Config, logger log.InterceptLogger, logOutput io.Writer, i
 		logOutput:  logOutput,
 		shutdownCh: make(chan struct{}),
 		inmemSink:  inmem,
+		reloadCh:   make(chan *reloadRequest),
 	}
 
 	// Create the loggers
@@ -324,6 +356,22 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		if agentConfig.Autopilot.MinQuorum != 0 {
 			conf.AutopilotConfig.MinQuorum = uint(agentConfig.Autopilot.MinQuorum)
 		}
+		if agentConfig.Autopilot.MinDiskFree != "" {
+			minDiskFree, err := humanize.ParseBytes(agentConfig.Autopilot.MinDiskFree)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid Config, autopilot min_disk_free: %w", err)
+			}
+			conf.AutopilotConfig.MinDiskFree = minDiskFree
+		}
+		if agentConfig.Autopilot.MaxLastLogAge != 0 {
+			conf.AutopilotConfig.MaxLastLogAge = agentConfig.Autopilot.MaxLastLogAge
+		}
+		if agentConfig.Autopilot.MaxAppliedIndexLag != 0 {
+			if agentConfig.Autopilot.MaxAppliedIndexLag < 0 {
+				return nil, fmt.Errorf("Invalid Config, autopilot max_applied_index_lag must be non-negative")
+			}
+			conf.AutopilotConfig.MaxAppliedIndexLag = uint64(agentConfig.Autopilot.MaxAppliedIndexLag)
+		}
 		if agentConfig.Autopilot.EnableRedundancyZones != nil {
 			conf.AutopilotConfig.EnableRedundancyZones = *agentConfig.Autopilot.EnableRedundancyZones
 		}
@@ -368,6 +416,15 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.JobTrackedVersions = *agentConfig.Server.JobTrackedVersions
 	}
 
+	if agentConfig.Server.JobMaxVersionsPerJob != nil {
+		if *agentConfig.Server.JobMaxVersionsPerJob <= 0 {
+			return nil, fmt.Errorf("job_max_versions_per_job must be greater than 0")
+		}
+		conf.JobMaxVersionsPerJob = *agentConfig.Server.JobMaxVersionsPerJob
+	}
+
+	conf.BuiltinAutoscaler = agentConfig.Server.BuiltinAutoscaler
+
 	conf.OIDCIssuer = agentConfig.Server.OIDCIssuer
 
 	// Set up the bind addresses
@@ -564,6 +621,9 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	// Setup telemetry related config
 	conf.StatsCollectionInterval = agentConfig.Telemetry.collectionInterval
 	conf.DisableDispatchedJobSummaryMetrics = agentConfig.Telemetry.DisableDispatchedJobSummaryMetrics
+	conf.PublishJobAggregateMetrics = agentConfig.Telemetry.PublishJobAggregateMetrics
+	conf.JobAggregateMetricsInterval = agentConfig.Telemetry.jobAggregateMetricsInterval
+	conf.JobAggregateMetricsNamespaces = agentConfig.Telemetry.JobAggregateMetricsNamespaces
 	conf.DisableQuotaUtilizationMetrics = agentConfig.Telemetry.DisableQuotaUtilizationMetrics
 	conf.DisableRPCRateMetricsLabels = agentConfig.Telemetry.DisableRPCRateMetricsLabels
 
@@ -924,6 +984,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.MaxDynamicPort = agentConfig.Client.MaxDynamicPort
 	conf.MinDynamicPort = agentConfig.Client.MinDynamicPort
 	conf.DisableRemoteExec = agentConfig.Client.DisableRemoteExec
+	conf.HealthNonFatal = slices.Clone(agentConfig.Client.HealthNonFatal)
 
 	if agentConfig.Client.TemplateConfig != nil {
 		conf.TemplateConfig = conf.TemplateConfig.Merge(agentConfig.Client.TemplateConfig)
@@ -1011,6 +1072,21 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.TLSConfig = agentConfig.TLSConfig
 	conf.Node.TLSEnabled = conf.TLSConfig.EnableHTTP
 
+	// Set the resource usage history configs
+	if agentConfig.Client.StatsHistory != nil {
+		conf.StatsHistoryDuration = *agentConfig.Client.StatsHistory
+	}
+	if agentConfig.Client.StatsHistoryResolution != 0 {
+		conf.StatsHistoryResolution = agentConfig.Client.StatsHistoryResolution
+	}
+	if conf.StatsHistoryDuration < 0 || conf.StatsHistoryResolution < 0 {
+		return nil, fmt.Errorf("stats_history and stats_history_resolution must not be negative")
+	}
+	if conf.StatsHistoryDuration > 0 && conf.StatsHistoryDuration/conf.StatsHistoryResolution > clientconfig.MaxStatsHistorySamples {
+		return nil, fmt.Errorf("stats_history must not hold more than %d samples of stats_history_resolution",
+			clientconfig.MaxStatsHistorySamples)
+	}
+
 	// Set the GC related configs
 	conf.GCInterval = agentConfig.Client.GCInterval
 	conf.GCParallelDestroys = agentConfig.Client.GCParallelDestroys
@@ -1019,6 +1095,12 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.GCMaxAllocs = agentConfig.Client.GCMaxAllocs
 	conf.GCVolumesOnNodeGC = agentConfig.Client.GCVolumesOnNodeGC
 
+	gcConfig, err := clientconfig.GCConfigFromAgent(agentConfig.Client.GC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gc config: %v", err)
+	}
+	conf.GC = gcConfig
+
 	if agentConfig.Client.NoHostUUID != nil {
 		conf.NoHostUUID = *agentConfig.Client.NoHostUUID
 	} else {
@@ -1065,8 +1147,22 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	for _, hn := range agentConfig.Client.HostNetworks {
 		conf.HostNetworks[hn.Name] = hn
 	}
+	for _, hd := range agentConfig.Client.HostDisks {
+		if err := hd.Validate(); err != nil {
+			return nil, err
+		}
+		conf.HostDisks[hd.Name] = hd
+	}
 	conf.BindWildcardDefaultHostNetwork = agentConfig.Client.BindWildcardDefaultHostNetwork
 
+	if limit := agentConfig.Client.MigrateBandwidthLimit; limit != "" {
+		bytes, err := humanize.ParseBytes(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migrate_bandwidth_limit: %w", err)
+		}
+		conf.MigrateBandwidthLimit = int64(bytes)
+	}
+
 	if agentConfig.Client.NomadServiceDiscovery != nil {
 		conf.NomadServiceDiscovery = *agentConfig.Client.NomadServiceDiscovery
 	}
@@ -1075,6 +1171,9 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid artifact config: %v", err)
 	}
+	if err := getter.ValidateDecompressorOverrides(artifactConfig.DecompressorOverrides); err != nil {
+		return nil, fmt.Errorf("invalid artifact config: decompressor_overrides: %v", err)
+	}
 
 	conf.Artifact = artifactConfig
 
@@ -1085,6 +1184,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Drain = drainConfig
 
 	conf.Users = clientconfig.UsersConfigFromAgent(agentConfig.Client.Users)
+	conf.EnvFromNodeMeta = clientconfig.EnvFromNodeMetaConfigFromAgent(agentConfig.Client.EnvFromNodeMeta)
 
 	conf.LogFile = agentConfig.LogFile
 	return conf, nil
@@ -1113,6 +1213,20 @@ func (a *Agent) setupServer() error {
 		return fmt.Errorf("failed to configure keyring: %v", err)
 	}
 
+	// Export the spans of the server, attributed to its node ID as neither
+	// the server nor the client is set up yet
+	if tracing := a.config.Telemetry.Tracing; tracing.Enabled() {
+		attrs := append(a.telemetryAttributes(),
+			attribute.String("service.instance.id", conf.NodeID),
+			attribute.String("nomad.node_id", conf.NodeID),
+		)
+		a.tracerProvider, err = newTracerProvider(tracing, attrs...)
+		if err != nil {
+			return fmt.Errorf("failed to configure tracing: %v", err)
+		}
+		conf.TracerProvider = a.tracerProvider
+	}
+
 	// Create the server
 	server, err := nomad.NewServer(conf,
 		a.consulCatalog,           // self service discovery
@@ -1449,6 +1563,7 @@ func (a *Agent) Shutdown() error {
 			a.logger.Error("server shutdown failed", "error", err)
 		}
 	}
+	a.shutdownTracerProvider()
 
 	if err := a.consulServices.Shutdown(); err != nil {
 		a.logger.Error("shutting down Consul client failed", "error", err)
@@ -1478,6 +1593,35 @@ func (a *Agent) Server() *nomad.Server {
 	return a.server
 }
 
+// telemetryAttributes returns the attributes describing the agent which the
+// metrics exported over OTLP are attributed to. The node ID is that of the
+// client, if the agent runs one, or of the server otherwise.
+func (a *Agent) telemetryAttributes() []attribute.KeyValue {
+	config := a.GetConfig()
+	attrs := []attribute.KeyValue{
+		attribute.String("service.name", "nomad"),
+		attribute.String("service.version", config.Version.VersionNumber()),
+		attribute.String("nomad.region", config.Region),
+		attribute.String("nomad.datacenter", config.Datacenter),
+		attribute.String("nomad.node_name", config.NodeName),
+	}
+
+	var nodeID string
+	if a.client != nil {
+		nodeID = a.client.NodeID()
+		attrs = append(attrs, attribute.String("nomad.node_pool", a.client.Node().NodePool))
+	} else if a.server != nil {
+		nodeID = a.server.GetConfig().NodeID
+	}
+	if nodeID != "" {
+		attrs = append(attrs,
+			attribute.String("service.instance.id", nodeID),
+			attribute.String("nomad.node_id", nodeID),
+		)
+	}
+	return attrs
+}
+
 // Stats is used to return statistics for debugging and insight
 // for various sub-systems
 func (a *Agent) Stats() map[string]map[string]string {
@@ -1507,32 +1651,117 @@ func (a *Agent) ShouldReload(newConfig *Config) (agent, http bool) {
 		agent = true
 	}
 
-	isEqual, err := a.config.TLSConfig.CertificateInfoIsEqual(newConfig.TLSConfig)
+	tlsAgent, http := a.shouldReloadTLS(newConfig.TLSConfig)
+	return agent || tlsAgent, http
+}
+
+// shouldReloadTLS determines if the TLS configuration changed, requiring the
+// agent and possibly its HTTP connections to be reloaded. The config lock
+// must be held.
+func (a *Agent) shouldReloadTLS(newConfig *config.TLSConfig) (agent, http bool) {
+	isEqual, err := a.config.TLSConfig.CertificateInfoIsEqual(newConfig)
 	if err != nil {
 		a.logger.Error("parsing TLS certificate", "error", err)
-		return agent, false
+		return false, false
 	} else if !isEqual {
 		return true, true
 	}
 
 	// Allow the ability to only reload HTTP connections
-	if a.config.TLSConfig.EnableHTTP != newConfig.TLSConfig.EnableHTTP {
+	if a.config.TLSConfig.EnableHTTP != newConfig.EnableHTTP {
 		http = true
 		agent = true
 	}
 
 	// Allow the ability to only reload HTTP connections
-	if a.config.TLSConfig.EnableRPC != newConfig.TLSConfig.EnableRPC {
+	if a.config.TLSConfig.EnableRPC != newConfig.EnableRPC {
 		agent = true
 	}
 
-	if a.config.TLSConfig.RPCUpgradeMode != newConfig.TLSConfig.RPCUpgradeMode {
+	if a.config.TLSConfig.RPCUpgradeMode != newConfig.RPCUpgradeMode {
 		agent = true
 	}
 
 	return agent, http
 }
 
+// reloadedSections returns the sections of the configuration reloaded
+// without restarting the agent which differ between the current
+// configuration and newConfig.
+func (a *Agent) reloadedSections(newConfig *Config) []string {
+	a.configLock.Lock()
+	defer a.configLock.Unlock()
+
+	var sections []string
+	if newConfig.LogLevel != "" && newConfig.LogLevel != a.config.LogLevel {
+		sections = append(sections, "log_level")
+	}
+	if tlsChanged, _ := a.shouldReloadTLS(newConfig.TLSConfig); tlsChanged {
+		sections = append(sections, "tls")
+	}
+	if !reflect.DeepEqual(a.config.Telemetry, newConfig.Telemetry) {
+		sections = append(sections, "telemetry")
+	}
+
+	current, updated := a.config.Client, newConfig.Client
+	if current == nil || !current.Enabled || updated == nil {
+		return sections
+	}
+	if !current.Artifact.Equal(updated.Artifact) {
+		sections = append(sections, "client.artifact")
+	}
+	if current.GCInterval != updated.GCInterval ||
+		current.GCDiskUsageThreshold != updated.GCDiskUsageThreshold ||
+		current.GCInodeUsageThreshold != updated.GCInodeUsageThreshold ||
+		current.GCMaxAllocs != updated.GCMaxAllocs ||
+		!reflect.DeepEqual(current.GC, updated.GC) {
+		sections = append(sections, "client.gc")
+	}
+	return sections
+}
+
+// setReloadedConfig updates the telemetry and the client artifact and garbage
+// collection configuration of the agent with those of newConfig, once they
+// have been reloaded.
+func (a *Agent) setReloadedConfig(newConfig *Config) {
+	a.configLock.Lock()
+	defer a.configLock.Unlock()
+
+	current := a.config.Copy()
+	current.Telemetry = newConfig.Telemetry.Copy()
+	if current.Client != nil && current.Client.Enabled && newConfig.Client != nil {
+		current.Client.Artifact = newConfig.Client.Artifact.Copy()
+		current.Client.GCInterval = newConfig.Client.GCInterval
+		current.Client.GCIntervalHCL = newConfig.Client.GCIntervalHCL
+		current.Client.GCDiskUsageThreshold = newConfig.Client.GCDiskUsageThreshold
+		current.Client.GCInodeUsageThreshold = newConfig.Client.GCInodeUsageThreshold
+		current.Client.GCMaxAllocs = newConfig.Client.GCMaxAllocs
+		current.Client.GC = newConfig.Client.GC.Copy()
+	}
+	a.config = current
+}
+
+// RequestReload asks the command running the agent to reload the agent
+// configuration from its configuration files, as on SIGHUP, and waits for the
+// sections of the configuration which changed.
+func (a *Agent) RequestReload(ctx context.Context) ([]string, error) {
+	req := &reloadRequest{resultCh: make(chan *reloadResult, 1)}
+	select {
+	case a.reloadCh <- req:
+	case <-a.shutdownCh:
+		return nil, errors.New("agent is shutting down")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case result := <-req.resultCh:
+		return result.Changed, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // Reload handles configuration changes for the agent. Provides a method that
 // is easier to unit test, as this action is invoked via SIGHUP.
 func (a *Agent) Reload(newConfig *Config) error {
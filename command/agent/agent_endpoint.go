@@ -21,12 +21,14 @@ import (
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/client"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/command/agent/host"
 	"github.com/hashicorp/nomad/command/agent/monitor"
 	"github.com/hashicorp/nomad/command/agent/pprof"
 	"github.com/hashicorp/nomad/nomad"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/serf/serf"
 )
 
@@ -100,6 +102,16 @@ func (s *HTTPServer) AgentSelfRequest(resp http.ResponseWriter, req *http.Reques
 		self.Config.Telemetry.CirconusAPIToken = "<redacted>"
 	}
 
+	if self.Config != nil && self.Config.Client != nil && self.Config.Client.Artifact != nil {
+		for _, headers := range self.Config.Client.Artifact.DefaultHeaders {
+			for name := range headers {
+				if config.IsSensitiveHeader(name) {
+					headers[name] = "<redacted>"
+				}
+			}
+		}
+	}
+
 	return self, nil
 }
 
@@ -442,6 +454,34 @@ func (s *HTTPServer) AgentForceLeaveRequest(resp http.ResponseWriter, req *http.
 	return nil, err
 }
 
+// AgentReloadRequest reloads the agent configuration from its configuration
+// files, as on SIGHUP, and returns the sections of the configuration which
+// changed.
+func (s *HTTPServer) AgentReloadRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	aclObj, err := s.ResolveToken(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check agent write permissions
+	if !aclObj.AllowAgentWrite() {
+		return nil, structs.ErrPermissionDenied
+	}
+
+	changed, err := s.agent.RequestReload(req.Context())
+	if err != nil {
+		return nil, CodedError(500, fmt.Sprintf("failed to reload agent configuration: %v", err))
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	return reloadResponse{Changed: changed}, nil
+}
+
 func (s *HTTPServer) AgentPprofRequest(resp http.ResponseWriter, req *http.Request) ([]byte, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/agent/pprof/")
 	switch path {
@@ -664,6 +704,10 @@ type joinResult struct {
 	Error     string `json:"error"`
 }
 
+type reloadResponse struct {
+	Changed []string `json:"changed"`
+}
+
 func (s *HTTPServer) HealthRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if req.Method != http.MethodGet {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -674,6 +718,11 @@ func (s *HTTPServer) HealthRequest(resp http.ResponseWriter, req *http.Request)
 		return nil, nil
 	}
 
+	verbose, err := parseBool(req, "verbose")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
 	health := healthResponse{}
 	getClient := true
 	getServer := true
@@ -713,6 +762,17 @@ func (s *HTTPServer) HealthRequest(resp http.ResponseWriter, req *http.Request)
 				Message: "ok",
 			}
 		}
+
+		// Any component failing fatally makes an otherwise healthy client
+		// unhealthy
+		components := client.ComponentsHealth()
+		if unhealthy := unhealthyComponents(components); health.Client.Ok && len(unhealthy) > 0 {
+			health.Client.Ok = false
+			health.Client.Message = "unhealthy components: " + strings.Join(unhealthy, ", ")
+		}
+		if verbose != nil && *verbose {
+			health.Client.Components = components
+		}
 	}
 
 	// If we should check the server and it exists, see if there's a leader
@@ -762,6 +822,22 @@ func (h healthResponse) ok() bool {
 type healthResponseAgent struct {
 	Ok      bool   `json:"ok"`
 	Message string `json:"message,omitempty"`
+
+	// Components is only set for clients when verbose health is requested.
+	Components map[string]*client.ComponentHealth `json:"components,omitempty"`
+}
+
+// unhealthyComponents returns the sorted names of the components which are
+// not healthy and make the client unhealthy.
+func unhealthyComponents(components map[string]*client.ComponentHealth) []string {
+	var names []string
+	for name, component := range components {
+		if !component.Healthy && component.Fatal {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 // AgentHostRequest runs on servers and clients, and captures information about the host system to add
@@ -27,6 +27,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/pool"
@@ -87,6 +88,18 @@ func TestHTTP_AgentSelf(t *testing.T) {
 		require.NoError(err)
 		self = obj.(agentSelf)
 		require.Equal("<redacted>", self.Config.Telemetry.CirconusAPIToken)
+
+		// Assign artifact default headers and require sensitive ones are redacted.
+		s.Config.Client.Artifact.DefaultHeaders = map[string]map[string]string{
+			"https": {"Authorization": "Bearer badc0deb", "X-Org": "acme"},
+		}
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.AgentSelfRequest(respW, req)
+		require.NoError(err)
+		self = obj.(agentSelf)
+		require.Equal("<redacted>", self.Config.Client.Artifact.DefaultHeaders["https"]["Authorization"])
+		require.Equal("acme", self.Config.Client.Artifact.DefaultHeaders["https"]["X-Org"])
+		require.Equal("Bearer badc0deb", s.Config.Client.Artifact.DefaultHeaders["https"]["Authorization"])
 	})
 }
 
@@ -912,6 +925,80 @@ func TestHTTP_AgentForceLeave_ACL(t *testing.T) {
 	})
 }
 
+func TestHTTP_AgentReload(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// Serve the reload request as the command running the agent would
+		go func() {
+			req := <-s.Agent.reloadCh
+			req.resultCh <- &reloadResult{Changed: []string{"telemetry"}}
+		}()
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/agent/reload", nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.AgentReloadRequest(respW, req)
+		must.NoError(t, err)
+		must.Eq(t, reloadResponse{Changed: []string{"telemetry"}}, obj.(reloadResponse))
+
+		// Failed reloads are returned as errors
+		go func() {
+			req := <-s.Agent.reloadCh
+			req.resultCh <- &reloadResult{Err: errors.New("bad config")}
+		}()
+
+		_, err = s.Server.AgentReloadRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "failed to reload agent configuration: bad config")
+
+		// Only PUT and POST are allowed
+		req, err = http.NewRequest(http.MethodGet, "/v1/agent/reload", nil)
+		must.NoError(t, err)
+		_, err = s.Server.AgentReloadRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+	})
+}
+
+func TestHTTP_AgentReload_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+
+		go func() {
+			req := <-s.Agent.reloadCh
+			req.resultCh <- &reloadResult{}
+		}()
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/agent/reload", nil)
+		must.NoError(t, err)
+
+		// Try request without a token and expect failure
+		{
+			_, err := s.Server.AgentReloadRequest(httptest.NewRecorder(), req)
+			must.EqError(t, err, structs.ErrPermissionDenied.Error())
+		}
+
+		// Try request with an invalid token and expect failure
+		{
+			token := mock.CreatePolicyAndToken(t, state, 1005, "invalid", mock.AgentPolicy(acl.PolicyRead))
+			setToken(req, token)
+			_, err := s.Server.AgentReloadRequest(httptest.NewRecorder(), req)
+			must.EqError(t, err, structs.ErrPermissionDenied.Error())
+		}
+
+		// Try request with a valid token
+		{
+			token := mock.CreatePolicyAndToken(t, state, 1007, "valid", mock.AgentPolicy(acl.PolicyWrite))
+			setToken(req, token)
+			obj, err := s.Server.AgentReloadRequest(httptest.NewRecorder(), req)
+			must.NoError(t, err)
+			must.Eq(t, reloadResponse{Changed: []string{}}, obj.(reloadResponse))
+		}
+	})
+}
+
 func TestHTTP_AgentSetServers(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -1407,6 +1494,19 @@ func TestHTTP_AgentHealth_BadServer(t *testing.T) {
 	}
 }
 
+func TestHTTP_AgentHealth_unhealthyComponents(t *testing.T) {
+	ci.Parallel(t)
+
+	components := map[string]*client.ComponentHealth{
+		"driver.exec":   {Healthy: true, Fatal: true},
+		"driver.docker": {Healthy: false, Fatal: true},
+		"driver.qemu":   {Healthy: false, Fatal: false},
+		"csi.ebs":       {Healthy: false, Fatal: true},
+	}
+	require.Equal(t, []string{"csi.ebs", "driver.docker"}, unhealthyComponents(components))
+	require.Empty(t, unhealthyComponents(nil))
+}
+
 func TestHTTP_AgentHealth_BadClient(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
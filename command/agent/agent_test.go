@@ -906,6 +906,30 @@ func TestAgent_ClientConfig_JobMaxSourceSize(t *testing.T) {
 	must.Eq(t, 1e6, serverConf.JobMaxSourceSize)
 }
 
+func TestAgent_ClientConfig_StatsHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DefaultConfig()
+	conf.DevMode = true
+	a := &Agent{config: conf}
+
+	c, err := a.clientConfig()
+	must.NoError(t, err)
+	must.Eq(t, 5*time.Minute, c.StatsHistoryDuration)
+	must.Eq(t, 10*time.Second, c.StatsHistoryResolution)
+
+	// the history can be disabled
+	conf.Client.StatsHistory = pointer.Of(time.Duration(0))
+	c, err = a.clientConfig()
+	must.NoError(t, err)
+	must.Zero(t, c.StatsHistoryDuration)
+
+	conf.Client.StatsHistory = pointer.Of(time.Hour)
+	conf.Client.StatsHistoryResolution = time.Second
+	_, err = a.clientConfig()
+	must.ErrorContains(t, err, "must not hold more than 1000 samples")
+}
+
 // Clients should inherit telemetry configuration
 func TestAgent_Client_TelemetryConfiguration(t *testing.T) {
 	ci.Parallel(t)
@@ -2010,6 +2034,27 @@ func TestAgent_ServerConfig_JobMaxCount(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_JobMaxVersionsPerJob(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DevConfig(nil)
+	must.NoError(t, conf.normalizeAddrs())
+
+	// unset by default, so that job_tracked_versions applies
+	serverConf, err := convertServerConfig(conf)
+	must.NoError(t, err)
+	must.Eq(t, 0, serverConf.JobMaxVersionsPerJob)
+
+	conf.Server.JobMaxVersionsPerJob = pointer.Of(10)
+	serverConf, err = convertServerConfig(conf)
+	must.NoError(t, err)
+	must.Eq(t, 10, serverConf.JobMaxVersionsPerJob)
+
+	conf.Server.JobMaxVersionsPerJob = pointer.Of(0)
+	_, err = convertServerConfig(conf)
+	must.ErrorContains(t, err, "job_max_versions_per_job must be greater than 0")
+}
+
 func Test_convertServerConfig_clientIntroduction(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2066,3 +2111,44 @@ func Test_convertServerConfig_clientIntroduction(t *testing.T) {
 		})
 	}
 }
+
+func TestAgent_reloadedSections(t *testing.T) {
+	ci.Parallel(t)
+
+	newAgent := func(clientEnabled bool) *Agent {
+		conf := DefaultConfig()
+		conf.Client.Enabled = clientEnabled
+		return &Agent{logger: testlog.HCLogger(t), config: conf}
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		a := newAgent(true)
+		must.SliceEmpty(t, a.reloadedSections(DefaultConfig()))
+	})
+
+	t.Run("telemetry", func(t *testing.T) {
+		a := newAgent(false)
+		newConfig := DefaultConfig()
+		newConfig.Telemetry.StatsiteAddr = "127.0.0.1:8125"
+		must.Eq(t, []string{"telemetry"}, a.reloadedSections(newConfig))
+	})
+
+	t.Run("client", func(t *testing.T) {
+		a := newAgent(true)
+		newConfig := DefaultConfig()
+		newConfig.Client.Enabled = true
+		newConfig.Client.Artifact.HTTPReadTimeout = pointer.Of("5m")
+		newConfig.Client.GCMaxAllocs = 10
+		must.Eq(t, []string{"client.artifact", "client.gc"}, a.reloadedSections(newConfig))
+
+		a.setReloadedConfig(newConfig)
+		must.SliceEmpty(t, a.reloadedSections(newConfig))
+	})
+
+	t.Run("client disabled", func(t *testing.T) {
+		a := newAgent(false)
+		newConfig := DefaultConfig()
+		newConfig.Client.GCMaxAllocs = 10
+		must.SliceEmpty(t, a.reloadedSections(newConfig))
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
@@ -59,6 +60,11 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 		}
 	}
 
+	projection, err := parseFields(req, reflect.TypeOf(structs.AllocListStub{}))
+	if err != nil {
+		return nil, err
+	}
+
 	var out structs.AllocListResponse
 	if err := s.agent.RPC("Alloc.List", &args, &out); err != nil {
 		return nil, err
@@ -71,7 +77,7 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 	for _, alloc := range out.Allocations {
 		alloc.SetEventDisplayMessages()
 	}
-	return out.Allocations, nil
+	return projectFields(out.Allocations, projection)
 }
 
 func (s *HTTPServer) AllocSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -222,6 +228,9 @@ func (s *HTTPServer) ClientAllocRequest(resp http.ResponseWriter, req *http.Requ
 	// tokenize the suffix of the path to get the alloc id and find the action
 	// invoked on the alloc id
 	tokens := strings.Split(reqSuffix, "/")
+	if len(tokens) == 3 && tokens[1] == "stats" && tokens[2] == "history" {
+		return s.allocStatsHistory(tokens[0], resp, req)
+	}
 	if len(tokens) != 2 {
 		return nil, CodedError(404, resourceNotFoundErr)
 	}
@@ -229,6 +238,8 @@ func (s *HTTPServer) ClientAllocRequest(resp http.ResponseWriter, req *http.Requ
 	switch tokens[1] {
 	case "checks":
 		return s.allocChecks(allocID, resp, req)
+	case "networking":
+		return s.allocNetworking(allocID, resp, req)
 	case "stats":
 		return s.allocStats(allocID, resp, req)
 	case "exec":
@@ -509,16 +520,59 @@ func (s *HTTPServer) allocSnapshot(allocID string, resp http.ResponseWriter, req
 		return nil, structs.ErrPermissionDenied
 	}
 
+	// The offset allows an interrupted migration to resume streaming the
+	// snapshot from where it left off
+	var offset int64
+	if o := req.URL.Query().Get("offset"); o != "" {
+		var err error
+		offset, err = strconv.ParseInt(o, 10, 64)
+		if err != nil || offset < 0 {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("invalid offset %q", o))
+		}
+	}
+
 	allocFS, err := s.agent.Client().GetAllocFS(allocID)
 	if err != nil {
 		return nil, fmt.Errorf(allocNotFoundErr)
 	}
-	if err := allocFS.Snapshot(resp); err != nil {
+
+	// Like an If-Range header for a Range request, the snapshot is only
+	// resumed if it is unchanged since the client began streaming it, and is
+	// streamed in full otherwise. The client compares the ETag to detect this.
+	info, err := allocFS.SnapshotInfo()
+	if err == nil {
+		resp.Header().Set("X-Nomad-Snapshot-Size", strconv.FormatInt(info.Size, 10))
+		resp.Header().Set("ETag", info.ETag)
+	}
+	if offset > 0 && (err != nil || req.Header.Get("If-Range") != info.ETag) {
+		offset = 0
+	}
+
+	if err := allocFS.Snapshot(&offsetWriter{w: resp, skip: offset}); err != nil {
 		return nil, fmt.Errorf("error making snapshot: %v", err)
 	}
 	return nil, nil
 }
 
+// offsetWriter discards the first skip bytes written to it and writes the
+// rest to w.
+type offsetWriter struct {
+	w    io.Writer
+	skip int64
+}
+
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	if o.skip >= int64(len(p)) {
+		o.skip -= int64(len(p))
+		return len(p), nil
+	}
+
+	skipped := int(o.skip)
+	o.skip = 0
+	n, err := o.w.Write(p[skipped:])
+	return skipped + n, err
+}
+
 func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 
 	// Build the request and parse the ACL token
@@ -554,6 +608,41 @@ func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *h
 	return reply.Stats, rpcErr
 }
 
+func (s *HTTPServer) allocStatsHistory(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	// Build the request and parse the ACL token
+	task := req.URL.Query().Get("task")
+	args := cstructs.AllocStatsHistoryRequest{
+		AllocID: allocID,
+		Task:    task,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(allocID)
+
+	// Make the RPC
+	var reply cstructs.AllocStatsHistoryResponse
+	var rpcErr error
+	if useLocalClient {
+		rpcErr = s.agent.Client().ClientRPC("Allocations.StatsHistory", &args, &reply)
+	} else if useClientRPC {
+		rpcErr = s.agent.Client().RPC("ClientAllocations.StatsHistory", &args, &reply)
+	} else if useServerRPC {
+		rpcErr = s.agent.Server().RPC("ClientAllocations.StatsHistory", &args, &reply)
+	} else {
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+	}
+
+	return reply.History, rpcErr
+}
+
 func (s *HTTPServer) allocChecks(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
 	// Build the request and parse the ACL token
 	args := cstructs.AllocChecksRequest{
@@ -587,6 +676,39 @@ func (s *HTTPServer) allocChecks(allocID string, resp http.ResponseWriter, req *
 	return reply.Results, rpcErr
 }
 
+func (s *HTTPServer) allocNetworking(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
+	// Build the request and parse the ACL token
+	args := cstructs.AllocNetworkingRequest{
+		AllocID: allocID,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(allocID)
+
+	// Make the RPC
+	var reply cstructs.AllocNetworkingResponse
+	var rpcErr error
+	switch {
+	case useLocalClient:
+		rpcErr = s.agent.Client().ClientRPC("Allocations.Networking", &args, &reply)
+	case useClientRPC:
+		rpcErr = s.agent.Client().RPC("ClientAllocations.Networking", &args, &reply)
+	case useServerRPC:
+		rpcErr = s.agent.Server().RPC("ClientAllocations.Networking", &args, &reply)
+	default:
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+	}
+
+	return reply.Networking, rpcErr
+}
+
 func (s *HTTPServer) allocExec(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	// Build the request and parse the ACL token
 	task := req.URL.Query().Get("task")
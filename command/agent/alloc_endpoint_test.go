@@ -5,6 +5,7 @@ package agent
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -96,6 +97,46 @@ func TestHTTP_AllocsList(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocsList_Fields(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		alloc := mock.Alloc()
+		must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+		must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+		t.Run("fields", func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/allocations?resources=true&fields=ID,ClientStatus,AllocatedResources.Shared.DiskMB", nil)
+			must.NoError(t, err)
+			respW := httptest.NewRecorder()
+
+			obj, err := s.Server.AllocsRequest(respW, req)
+			must.NoError(t, err)
+			must.NotEq(t, "", respW.Result().Header.Get("X-Nomad-Index"))
+
+			must.Eq(t, []map[string]interface{}{{
+				"ID":           alloc.ID,
+				"ClientStatus": alloc.ClientStatus,
+				"AllocatedResources": map[string]interface{}{
+					"Shared": map[string]interface{}{
+						"DiskMB": uint64(alloc.AllocatedResources.Shared.DiskMB),
+					},
+				},
+			}}, obj.([]map[string]interface{}))
+		})
+
+		t.Run("unknown field", func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/allocations?fields=ID,Bogus", nil)
+			must.NoError(t, err)
+			respW := httptest.NewRecorder()
+
+			_, err = s.Server.AllocsRequest(respW, req)
+			must.EqError(t, err, `unknown field "Bogus"`)
+			must.Eq(t, http.StatusBadRequest, err.(HTTPCodedError).Code())
+		})
+	})
+}
+
 func TestHTTP_AllocsPrefixList(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -728,6 +769,20 @@ func TestHTTP_AllocSnapshot_WithMigrateToken(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocSnapshot_offsetWriter(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	w := &offsetWriter{w: &buf, skip: 5}
+
+	for _, p := range []string{"abc", "defg", "hij"} {
+		n, err := w.Write([]byte(p))
+		must.NoError(t, err)
+		must.Eq(t, len(p), n)
+	}
+	must.Eq(t, "fghij", buf.String())
+}
+
 // TestHTTP_AllocSnapshot_Atomic ensures that when a client encounters an error
 // snapshotting a valid tar is not returned.
 func TestHTTP_AllocSnapshot_Atomic(t *testing.T) {
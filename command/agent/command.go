@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -58,6 +60,12 @@ type Command struct {
 	agent          *Agent
 	httpServers    []*HTTPServer
 	retryJoinErrCh chan struct{}
+
+	// metricsSink is the global metrics sink, whose sinks are replaced when
+	// the telemetry configuration is reloaded. The prometheus sink is
+	// registered once and kept across reloads.
+	metricsSink    *reloadableSink
+	prometheusSink metrics.MetricSink
 }
 
 func (c *Command) readConfig() *Config {
@@ -505,6 +513,11 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		return false
 	}
 
+	if err := config.HTTPAPIResponseCompression.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("http_api_response_compression block invalid: %v", err))
+		return false
+	}
+
 	if !config.DevMode {
 		// Ensure that we have the directories we need to run.
 		if config.Server.Enabled && config.DataDir == "" {
@@ -891,8 +904,20 @@ func (c *Command) Run(args []string) int {
 				srv.Shutdown()
 			}
 		}
+
+		// Flush the metrics not yet sent by the sinks
+		c.shutdownSinks(c.metricsSink.swap(nil))
 	}()
 
+	// Export metrics over OTLP, now that the node ID they are attributed to
+	// is known
+	if config.Telemetry != nil && config.Telemetry.OTLP != nil {
+		if err := c.reloadTelemetry(config); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error initializing telemetry: %s", err))
+			return 1
+		}
+	}
+
 	// Join startup nodes if specified
 	if err := c.startupJoin(config); err != nil {
 		c.Ui.Error(err.Error())
@@ -1144,6 +1169,18 @@ func (c *Command) handleSignals() int {
 
 			return c.terminateGracefully(signalCh, sdSock)
 
+		case req := <-c.agent.reloadCh:
+			sdNotifyReloading(sdSock)
+			result, err := c.reload()
+			if err != nil {
+				req.resultCh <- &reloadResult{Err: err}
+				c.Ui.Error(fmt.Sprintf("Fatal error while reloading: %v", err))
+				return 1
+			}
+			req.resultCh <- result
+
+			sdNotify(sdSock, sdReady)
+
 		case <-c.retryJoinErrCh:
 			return 1
 		}
@@ -1172,11 +1209,20 @@ func (c *Command) reloadHTTPServer() error {
 // It will only return an error if the reload encountered a fatal error that must
 // cause an agent termination.
 func (c *Command) handleReload() error {
+	_, err := c.reload()
+	return err
+}
+
+// reload reloads the agent configuration from its configuration files and
+// returns the sections of the configuration which changed. Errors which stop
+// the reload without affecting the running agent are returned in the result,
+// while the returned error is fatal and must cause an agent termination.
+func (c *Command) reload() (*reloadResult, error) {
 	c.Ui.Output("Reloading configuration...")
 	newConf := c.readConfig()
 	if newConf == nil {
 		c.Ui.Error("Failed to reload configs")
-		return nil
+		return &reloadResult{Err: errors.New("failed to read the agent configuration")}, nil
 	}
 
 	// Change the log level
@@ -1191,13 +1237,23 @@ func (c *Command) handleReload() error {
 		newConf.LogLevel = c.agent.GetConfig().LogLevel
 	}
 
+	result := &reloadResult{Changed: c.agent.reloadedSections(newConf)}
+
 	shouldReloadAgent, shouldReloadHTTP := c.agent.ShouldReload(newConf)
 	if shouldReloadAgent {
 		c.agent.logger.Debug("starting reload of agent config")
 		err := c.agent.Reload(newConf)
 		if err != nil {
 			c.agent.logger.Error("failed to reload the config", "error", err)
-			return nil
+			return &reloadResult{Err: err}, nil
+		}
+	}
+
+	if slices.Contains(result.Changed, "telemetry") {
+		c.agent.logger.Debug("starting reload of telemetry config")
+		if err := c.reloadTelemetry(newConf); err != nil {
+			c.agent.logger.Error("failed to reload the telemetry config", "error", err)
+			return &reloadResult{Err: err}, nil
 		}
 	}
 
@@ -1206,7 +1262,7 @@ func (c *Command) handleReload() error {
 		sconf, err := convertServerConfig(newConf)
 		if err != nil {
 			c.agent.logger.Error("failed to convert server config", "error", err)
-			return nil
+			return &reloadResult{Err: err}, nil
 		}
 
 		// Finalize the config to get the agent objects injected in
@@ -1215,7 +1271,7 @@ func (c *Command) handleReload() error {
 		// Reload the config
 		if err := s.Reload(sconf); err != nil {
 			c.agent.logger.Error("reloading server config failed", "error", err)
-			return fmt.Errorf("reloading server config failed: %w", err)
+			return nil, fmt.Errorf("reloading server config failed: %w", err)
 		}
 	}
 
@@ -1224,21 +1280,23 @@ func (c *Command) handleReload() error {
 		clientConfig, err := convertClientConfig(newConf)
 		if err != nil {
 			c.agent.logger.Error("failed to convert client config", "error", err)
-			return nil
+			return &reloadResult{Err: err}, nil
 		}
 
 		// Finalize the config to get the agent objects injected in
 		if err := c.agent.finalizeClientConfig(clientConfig); err != nil {
 			c.agent.logger.Error("failed to finalize client config", "error", err)
-			return nil
+			return &reloadResult{Err: err}, nil
 		}
 
 		if err := client.Reload(clientConfig); err != nil {
 			c.agent.logger.Error("reloading client config failed", "error", err)
-			return fmt.Errorf("reloading client config failed: %w", err)
+			return nil, fmt.Errorf("reloading client config failed: %w", err)
 		}
 	}
 
+	c.agent.setReloadedConfig(newConf)
+
 	// reload HTTP server after we have reloaded both client and server, in case
 	// we error in either of the above cases. For example, reloading the http
 	// server to a TLS connection could succeed, while reloading the server's rpc
@@ -1249,7 +1307,11 @@ func (c *Command) handleReload() error {
 			c.agent.httpLogger.Error("reloading config failed", "error", err)
 		}
 	}
-	return nil
+
+	if len(result.Changed) > 0 {
+		c.agent.logger.Info("reloaded agent configuration", "changed", strings.Join(result.Changed, ","))
+	}
+	return result, nil
 }
 
 // setupTelemetry is used to set up the telemetry sub-systems.
@@ -1288,12 +1350,80 @@ func (c *Command) setupTelemetry(config *Config) (*metrics.InmemSink, error) {
 		metricsConf.FilterDefault = *telConfig.FilterDefault
 	}
 
+	fanout, err := c.telemetrySinks(config)
+	if err != nil {
+		return inm, err
+	}
+
+	// Initialize the global sink
+	if len(fanout) == 0 {
+		metricsConf.EnableHostname = false
+	}
+	c.metricsSink = newReloadableSink(inm, fanout)
+	metrics.NewGlobal(metricsConf, c.metricsSink)
+
+	return inm, nil
+}
+
+// reloadTelemetry replaces the metrics sinks and prefix filters of the agent
+// with those of config. The in-memory sink backing the metrics API is kept, as
+// are the other parameters of the telemetry block, which only take effect
+// when the agent restarts.
+func (c *Command) reloadTelemetry(config *Config) error {
+	telConfig := config.Telemetry
+	if telConfig == nil {
+		telConfig = &Telemetry{}
+	}
+
+	allowedPrefixes, blockedPrefixes, err := telConfig.PrefixFilters()
+	if err != nil {
+		return err
+	}
+
+	fanout, err := c.telemetrySinks(config)
+	if err != nil {
+		return err
+	}
+
+	previous := c.metricsSink.swap(fanout)
+	metrics.UpdateFilter(allowedPrefixes, blockedPrefixes)
+	c.shutdownSinks(previous)
+	return nil
+}
+
+// shutdownSinks shuts down the metrics sinks, other than the prometheus sink
+// which is kept across reloads.
+func (c *Command) shutdownSinks(sinks metrics.FanoutSink) {
+	for _, sink := range sinks {
+		if sink == c.prometheusSink {
+			continue
+		}
+		if s, ok := sink.(metrics.ShutdownSink); ok {
+			s.Shutdown()
+		}
+	}
+}
+
+// telemetrySinks returns the metrics sinks configured in the telemetry block
+// of config, other than the in-memory sink.
+func (c *Command) telemetrySinks(config *Config) (fanout metrics.FanoutSink, err error) {
+	telConfig := config.Telemetry
+	if telConfig == nil {
+		telConfig = &Telemetry{}
+	}
+
+	// Shut down the sinks already created if a later one fails
+	defer func() {
+		if err != nil {
+			c.shutdownSinks(fanout)
+		}
+	}()
+
 	// Configure the statsite sink
-	var fanout metrics.FanoutSink
 	if telConfig.StatsiteAddr != "" {
 		sink, err := metrics.NewStatsiteSink(telConfig.StatsiteAddr)
 		if err != nil {
-			return inm, err
+			return fanout, err
 		}
 		fanout = append(fanout, sink)
 	}
@@ -1302,25 +1432,28 @@ func (c *Command) setupTelemetry(config *Config) (*metrics.InmemSink, error) {
 	if telConfig.StatsdAddr != "" {
 		sink, err := metrics.NewStatsdSink(telConfig.StatsdAddr)
 		if err != nil {
-			return inm, err
+			return fanout, err
 		}
 		fanout = append(fanout, sink)
 	}
 
-	// Configure the prometheus sink
+	// Configure the prometheus sink, which can only be registered once
 	if telConfig.PrometheusMetrics {
-		promSink, err := prometheus.NewPrometheusSink()
-		if err != nil {
-			return inm, err
+		if c.prometheusSink == nil {
+			promSink, err := prometheus.NewPrometheusSink()
+			if err != nil {
+				return fanout, err
+			}
+			c.prometheusSink = promSink
 		}
-		fanout = append(fanout, promSink)
+		fanout = append(fanout, c.prometheusSink)
 	}
 
 	// Configure the datadog sink
 	if telConfig.DataDogAddr != "" {
 		sink, err := datadog.NewDogStatsdSink(telConfig.DataDogAddr, config.NodeName)
 		if err != nil {
-			return inm, err
+			return fanout, err
 		}
 		sink.SetTags(telConfig.DataDogTags)
 		fanout = append(fanout, sink)
@@ -1357,22 +1490,23 @@ func (c *Command) setupTelemetry(config *Config) (*metrics.InmemSink, error) {
 
 		sink, err := circonus.NewCirconusSink(cfg)
 		if err != nil {
-			return inm, err
+			return fanout, err
 		}
 		sink.Start()
 		fanout = append(fanout, sink)
 	}
 
-	// Initialize the global sink
-	if len(fanout) > 0 {
-		fanout = append(fanout, inm)
-		metrics.NewGlobal(metricsConf, fanout)
-	} else {
-		metricsConf.EnableHostname = false
-		metrics.NewGlobal(metricsConf, inm)
+	// Configure the OTLP sink, once the agent is set up as the node ID its
+	// metrics are attributed to is not known before
+	if telConfig.OTLP != nil && c.agent != nil {
+		sink, err := newOTLPSink(telConfig.OTLP, c.agent.telemetryAttributes()...)
+		if err != nil {
+			return fanout, err
+		}
+		fanout = append(fanout, sink)
 	}
 
-	return inm, nil
+	return fanout, nil
 }
 
 func (c *Command) startupJoin(config *Config) error {
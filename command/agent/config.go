@@ -10,6 +10,7 @@ import (
 	"io"
 	"maps"
 	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -177,6 +178,10 @@ type Config struct {
 	// set arbitrary headers on API responses
 	HTTPAPIResponseHeaders map[string]string `hcl:"http_api_response_headers"`
 
+	// HTTPAPIResponseCompression configures the compression of HTTP API
+	// responses
+	HTTPAPIResponseCompression *HTTPAPIResponseCompression `hcl:"http_api_response_compression"`
+
 	// Sentinel holds sentinel related settings
 	Sentinel *config.SentinelConfig `hcl:"sentinel"`
 
@@ -338,6 +343,16 @@ type ClientConfig struct {
 	// particular set of ports.
 	Reserved *Resources `hcl:"reserved"`
 
+	// StatsHistory is how much recent resource usage of each task is kept,
+	// and for how long after the task exits. Zero disables the history.
+	StatsHistory    *time.Duration
+	StatsHistoryHCL string `hcl:"stats_history" json:"-"`
+
+	// StatsHistoryResolution is the minimum interval between the samples of
+	// the resource usage history.
+	StatsHistoryResolution    time.Duration
+	StatsHistoryResolutionHCL string `hcl:"stats_history_resolution" json:"-"`
+
 	// GCInterval is the time interval at which the client triggers garbage
 	// collection
 	GCInterval    time.Duration
@@ -364,6 +379,10 @@ type ClientConfig struct {
 	// you know that a GC'd node can never come back
 	GCVolumesOnNodeGC bool `hcl:"gc_volumes_on_node_gc"`
 
+	// GC configures per job type garbage collection policies and the low
+	// disk usage threshold.
+	GC *config.ClientGCConfig `hcl:"gc"`
+
 	// NoHostUUID disables using the host's UUID and will force generation of a
 	// random UUID.
 	NoHostUUID *bool `hcl:"no_host_uuid"`
@@ -371,6 +390,11 @@ type ClientConfig struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool `hcl:"disable_remote_exec"`
 
+	// HealthNonFatal is the names of the components, such as "driver.qemu",
+	// whose failures are reported by the agent health endpoint but do not
+	// make the client unhealthy.
+	HealthNonFatal []string `hcl:"health_non_fatal"`
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *client.ClientTemplateConfig `hcl:"template"`
 
@@ -411,11 +435,20 @@ type ClientConfig struct {
 	// if the host uses multiple interfaces
 	HostNetworks []*structs.ClientHostNetworkConfig `hcl:"host_network"`
 
+	// HostDisks describes the disk classes available to place allocation
+	// ephemeral disks on, in addition to the data_dir.
+	HostDisks []*structs.ClientHostDiskConfig `hcl:"host_disk"`
+
 	// BindWildcardDefaultHostNetwork toggles if when there are no host networks,
 	// should the port mapping rules match the default network address (false) or
 	// matching any destination address (true). Defaults to true
 	BindWildcardDefaultHostNetwork bool `hcl:"bind_wildcard_default_host_network"`
 
+	// MigrateBandwidthLimit is the maximum rate, such as "50MiB", per second at
+	// which ephemeral disk data is migrated from other clients. Defaults to
+	// unlimited.
+	MigrateBandwidthLimit string `hcl:"migrate_bandwidth_limit"`
+
 	// CgroupParent sets the parent cgroup for subsystems managed by Nomad. If the cgroup
 	// doest not exist Nomad will attempt to create it during startup. Defaults to '/nomad'
 	CgroupParent string `hcl:"cgroup_parent"`
@@ -435,6 +468,10 @@ type ClientConfig struct {
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
+	// EnvFromNodeMeta is used to export node metadata into the environment of
+	// every task.
+	EnvFromNodeMeta *config.EnvFromNodeMetaConfig `hcl:"env_from_node_meta"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 
@@ -454,6 +491,7 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc := *c
 	nc.Servers = slices.Clone(c.Servers)
 	nc.Options = maps.Clone(c.Options)
+	nc.HealthNonFatal = slices.Clone(c.HealthNonFatal)
 	nc.Meta = maps.Clone(c.Meta)
 	nc.ChrootEnv = maps.Clone(c.ChrootEnv)
 	nc.Reserved = c.Reserved.Copy()
@@ -462,10 +500,13 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.ServerJoin = c.ServerJoin.Copy()
 	nc.HostVolumes = helper.CopySlice(c.HostVolumes)
 	nc.HostNetworks = helper.CopySlice(c.HostNetworks)
+	nc.HostDisks = helper.CopySlice(c.HostDisks)
 	nc.NomadServiceDiscovery = pointer.Copy(c.NomadServiceDiscovery)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
+	nc.GC = c.GC.Copy()
 	nc.Users = c.Users.Copy()
+	nc.EnvFromNodeMeta = c.EnvFromNodeMeta.Copy()
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
@@ -774,6 +815,15 @@ type ServerConfig struct {
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions *int `hcl:"job_tracked_versions"`
 
+	// JobMaxVersionsPerJob is the number of untagged versions of a job that
+	// are kept when the job does not set version_retention. If unset,
+	// JobTrackedVersions is used.
+	JobMaxVersionsPerJob *int `hcl:"job_max_versions_per_job"`
+
+	// BuiltinAutoscaler enables the autoscaler built into the servers, which
+	// evaluates horizontal scaling policies with a "nomad" source.
+	BuiltinAutoscaler bool `hcl:"builtin_autoscaler"`
+
 	// OIDCIssuer if set enables OIDC Discovery and uses this value as the
 	// issuer. Third parties such as AWS IAM OIDC Provider expect the issuer to
 	// be a publicly accessible HTTPS URL signed by a trusted well-known CA.
@@ -817,6 +867,7 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.JobMaxPriority = pointer.Copy(s.JobMaxPriority)
 	ns.JobMaxCount = pointer.Copy(s.JobMaxCount)
 	ns.JobTrackedVersions = pointer.Copy(s.JobTrackedVersions)
+	ns.JobMaxVersionsPerJob = pointer.Copy(s.JobMaxVersionsPerJob)
 	ns.ClientIntroduction = s.ClientIntroduction.Copy()
 	return &ns
 }
@@ -1268,6 +1319,22 @@ type Telemetry struct {
 	// a small memory overhead.
 	DisableDispatchedJobSummaryMetrics bool `hcl:"disable_dispatched_job_summary_metrics"`
 
+	// PublishJobAggregateMetrics allows the leader to publish the allocation
+	// counts, queued evaluations and requested resources of each job and
+	// namespace, with the children of dispatched and periodic jobs rolled into
+	// their parent job.
+	PublishJobAggregateMetrics bool `hcl:"publish_job_aggregate_metrics"`
+
+	// JobAggregateMetricsInterval is the interval at which the job aggregate
+	// metrics are published.
+	JobAggregateMetricsInterval string        `hcl:"job_aggregate_metrics_interval"`
+	jobAggregateMetricsInterval time.Duration `hcl:"-"`
+
+	// JobAggregateMetricsNamespaces limits the job aggregate metrics of each
+	// job to the jobs in these namespaces. The metrics of every namespace are
+	// published regardless.
+	JobAggregateMetricsNamespaces []string `hcl:"job_aggregate_metrics_namespaces"`
+
 	// DisableQuotaUtilizationMetrics allows to disable publishing of quota
 	// utilization metrics
 	DisableQuotaUtilizationMetrics bool `hcl:"disable_quota_utilization_metrics"`
@@ -1282,6 +1349,13 @@ type Telemetry struct {
 	// metrics.
 	DisableAllocationHookMetrics *bool `hcl:"disable_allocation_hook_metrics"`
 
+	// OTLP configures the export of metrics to an OpenTelemetry collector.
+	OTLP *OTLPTelemetry `hcl:"otlp"`
+
+	// Tracing configures the export of spans recorded by servers to an
+	// OpenTelemetry collector.
+	Tracing *TracingTelemetry `hcl:"tracing"`
+
 	// Circonus: see https://github.com/circonus-labs/circonus-gometrics
 	// for more details on the various configuration options.
 	// Valid configuration combinations:
@@ -1366,6 +1440,9 @@ func (t *Telemetry) Copy() *Telemetry {
 	nt.DataDogTags = slices.Clone(t.DataDogTags)
 	nt.PrefixFilter = slices.Clone(t.PrefixFilter)
 	nt.FilterDefault = pointer.Copy(t.FilterDefault)
+	nt.JobAggregateMetricsNamespaces = slices.Clone(t.JobAggregateMetricsNamespaces)
+	nt.OTLP = t.OTLP.Copy()
+	nt.Tracing = t.Tracing.Copy()
 	nt.ExtraKeysHCL = slices.Clone(t.ExtraKeysHCL)
 	return &nt
 }
@@ -1409,6 +1486,197 @@ func (t *Telemetry) Validate() error {
 		return errors.New("telemetry in-memory collection interval cannot be greater than retention period")
 	}
 
+	if t.PublishJobAggregateMetrics && t.jobAggregateMetricsInterval <= 0 {
+		return errors.New("telemetry job aggregate metrics interval must be greater than zero")
+	}
+
+	if err := t.OTLP.Validate(); err != nil {
+		return fmt.Errorf("telemetry otlp: %w", err)
+	}
+
+	if err := t.Tracing.Validate(); err != nil {
+		return fmt.Errorf("telemetry tracing: %w", err)
+	}
+
+	return nil
+}
+
+const (
+	// OTLPProtocolGRPC and OTLPProtocolHTTP are the transports metrics are
+	// exported to an OpenTelemetry collector with.
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http/protobuf"
+)
+
+// OTLPTelemetry is the configuration for exporting metrics to an
+// OpenTelemetry collector over OTLP.
+type OTLPTelemetry struct {
+	// Endpoint is the address of the collector, as a host and port or a URL.
+	Endpoint string `hcl:"endpoint"`
+
+	// Protocol is the transport metrics are exported with, either grpc or
+	// http/protobuf. Defaults to grpc.
+	Protocol string `hcl:"protocol"`
+
+	// Headers are sent with every export, such as to authenticate with the
+	// collector.
+	Headers map[string]string `hcl:"headers"`
+
+	// Interval is the interval at which metrics are exported.
+	Interval string        `hcl:"interval"`
+	interval time.Duration `hcl:"-"`
+
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool `hcl:"insecure"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// DefaultOTLPInterval is the interval at which metrics are exported to an
+// OpenTelemetry collector, unless configured otherwise.
+const DefaultOTLPInterval = 10 * time.Second
+
+func (o *OTLPTelemetry) Copy() *OTLPTelemetry {
+	if o == nil {
+		return nil
+	}
+
+	no := *o
+	no.Headers = maps.Clone(o.Headers)
+	no.ExtraKeysHCL = slices.Clone(o.ExtraKeysHCL)
+	return &no
+}
+
+func (o *OTLPTelemetry) Merge(b *OTLPTelemetry) *OTLPTelemetry {
+	if o == nil {
+		return b.Copy()
+	}
+
+	result := o.Copy()
+	if b == nil {
+		return result
+	}
+
+	if b.Endpoint != "" {
+		result.Endpoint = b.Endpoint
+	}
+	if b.Protocol != "" {
+		result.Protocol = b.Protocol
+	}
+	if b.Headers != nil {
+		result.Headers = maps.Clone(b.Headers)
+	}
+	if b.Interval != "" {
+		result.Interval = b.Interval
+	}
+	if b.interval != 0 {
+		result.interval = b.interval
+	}
+	if b.Insecure {
+		result.Insecure = b.Insecure
+	}
+	return result
+}
+
+// Validate the OTLP configuration. It is safe to call, without checking
+// whether the config object is nil first.
+func (o *OTLPTelemetry) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	switch o.Protocol {
+	case "", OTLPProtocolGRPC, OTLPProtocolHTTP:
+	default:
+		return fmt.Errorf("protocol must be %q or %q, got %q", OTLPProtocolGRPC, OTLPProtocolHTTP, o.Protocol)
+	}
+	if o.interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	return nil
+}
+
+// TracingTelemetry is the configuration for exporting the spans servers
+// record for job registrations, and the evaluations and plans which follow
+// them, to an OpenTelemetry collector over OTLP. Tracing is disabled unless
+// an endpoint is set.
+type TracingTelemetry struct {
+	// OTLPEndpoint is the URL of the collector receiving spans over gRPC. TLS
+	// is used unless the scheme is http.
+	OTLPEndpoint string `hcl:"otlp_endpoint"`
+
+	// SampleRatio is the ratio of traces started by servers which are
+	// sampled, from 0 to 1. Spans of requests carrying a traceparent are
+	// sampled as decided by the caller. Defaults to 1.
+	SampleRatio *float64 `hcl:"sample_ratio"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// DefaultTracingSampleRatio is the ratio of traces started by servers which
+// are sampled, unless configured otherwise.
+const DefaultTracingSampleRatio = 1.0
+
+func (t *TracingTelemetry) Copy() *TracingTelemetry {
+	if t == nil {
+		return nil
+	}
+
+	nt := *t
+	nt.SampleRatio = pointer.Copy(t.SampleRatio)
+	nt.ExtraKeysHCL = slices.Clone(t.ExtraKeysHCL)
+	return &nt
+}
+
+func (t *TracingTelemetry) Merge(b *TracingTelemetry) *TracingTelemetry {
+	if t == nil {
+		return b.Copy()
+	}
+
+	result := t.Copy()
+	if b == nil {
+		return result
+	}
+
+	if b.OTLPEndpoint != "" {
+		result.OTLPEndpoint = b.OTLPEndpoint
+	}
+	if b.SampleRatio != nil {
+		result.SampleRatio = pointer.Copy(b.SampleRatio)
+	}
+	return result
+}
+
+// Enabled returns whether spans are exported. It is safe to call, without
+// checking whether the config object is nil first.
+func (t *TracingTelemetry) Enabled() bool {
+	return t != nil && t.OTLPEndpoint != ""
+}
+
+// Validate the tracing configuration. It is safe to call, without checking
+// whether the config object is nil first.
+func (t *TracingTelemetry) Validate() error {
+	if t == nil {
+		return nil
+	}
+
+	if t.OTLPEndpoint != "" {
+		u, err := url.Parse(t.OTLPEndpoint)
+		if err != nil {
+			return fmt.Errorf("otlp_endpoint is not a valid URL: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("otlp_endpoint must be an http or https URL, got %q", t.OTLPEndpoint)
+		}
+	}
+	if t.SampleRatio != nil && (*t.SampleRatio < 0 || *t.SampleRatio > 1) {
+		return fmt.Errorf("sample_ratio must be between 0 and 1, got %v", *t.SampleRatio)
+	}
 	return nil
 }
 
@@ -1466,6 +1734,98 @@ func (e *Eventlog) Validate() error {
 	return nil
 }
 
+// HTTPAPIResponseCompression is the configuration for compressing HTTP API
+// responses
+type HTTPAPIResponseCompression struct {
+	// Enabled controls whether responses are compressed for clients which
+	// accept one of Encodings.
+	Enabled *bool `hcl:"enabled"`
+
+	// MinSize is the size in bytes of the smallest response body which is
+	// compressed. Smaller responses are sent uncompressed, as compressing them
+	// costs more than it saves.
+	MinSize *int `hcl:"min_size"`
+
+	// Encodings are the content encodings responses may be compressed with,
+	// in order of preference when the client accepts several of them equally.
+	// Supported encodings are "gzip" and "zstd".
+	Encodings []string `hcl:"encodings"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// DefaultHTTPAPIResponseCompression returns the default configuration for
+// compressing HTTP API responses, which compresses responses of at least 1KiB
+// with gzip.
+func DefaultHTTPAPIResponseCompression() *HTTPAPIResponseCompression {
+	return &HTTPAPIResponseCompression{
+		Enabled:   pointer.Of(true),
+		MinSize:   pointer.Of(1024),
+		Encodings: []string{encodingGzip},
+	}
+}
+
+// Copy is used to copy the HTTPAPIResponseCompression configuration
+func (c *HTTPAPIResponseCompression) Copy() *HTTPAPIResponseCompression {
+	if c == nil {
+		return nil
+	}
+
+	nc := *c
+	nc.Enabled = pointer.Copy(c.Enabled)
+	nc.MinSize = pointer.Copy(c.MinSize)
+	nc.Encodings = slices.Clone(c.Encodings)
+	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
+	return &nc
+}
+
+// Merge is used to merge HTTPAPIResponseCompression configurations
+func (c *HTTPAPIResponseCompression) Merge(b *HTTPAPIResponseCompression) *HTTPAPIResponseCompression {
+	if c == nil {
+		return b.Copy()
+	}
+
+	result := c.Copy()
+
+	if b == nil {
+		return result
+	}
+
+	if b.Enabled != nil {
+		result.Enabled = pointer.Copy(b.Enabled)
+	}
+
+	if b.MinSize != nil {
+		result.MinSize = pointer.Copy(b.MinSize)
+	}
+
+	if len(b.Encodings) != 0 {
+		result.Encodings = slices.Clone(b.Encodings)
+	}
+
+	return result
+}
+
+// Validate validates the HTTPAPIResponseCompression configuration
+func (c *HTTPAPIResponseCompression) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.MinSize != nil && *c.MinSize < 0 {
+		return fmt.Errorf("min_size must be >= 0 but found %d", *c.MinSize)
+	}
+
+	for _, encoding := range c.Encodings {
+		if !slices.Contains(supportedEncodings, encoding) {
+			return fmt.Errorf("encoding %q must be one of %v", encoding, supportedEncodings)
+		}
+	}
+
+	return nil
+}
+
 // Ports encapsulates the various ports we bind to for network services. If any
 // are not specified then the defaults are used instead.
 type Ports struct {
@@ -1729,21 +2089,23 @@ func DefaultConfig() *Config {
 			StreamCloseTimeoutHCL:     "5m",
 		},
 		Client: &ClientConfig{
-			Enabled:               false,
-			NodePool:              structs.NodePoolDefault,
-			MaxKillTimeout:        "30s",
-			ClientMinPort:         14000,
-			ClientMaxPort:         14512,
-			MinDynamicPort:        20000,
-			MaxDynamicPort:        32000,
-			Reserved:              &Resources{},
-			GCInterval:            1 * time.Minute,
-			GCParallelDestroys:    2,
-			GCDiskUsageThreshold:  80,
-			GCInodeUsageThreshold: 70,
-			GCMaxAllocs:           50,
-			NoHostUUID:            pointer.Of(true),
-			DisableRemoteExec:     false,
+			Enabled:                false,
+			NodePool:               structs.NodePoolDefault,
+			MaxKillTimeout:         "30s",
+			ClientMinPort:          14000,
+			ClientMaxPort:          14512,
+			MinDynamicPort:         20000,
+			MaxDynamicPort:         32000,
+			Reserved:               &Resources{},
+			StatsHistory:           pointer.Of(5 * time.Minute),
+			StatsHistoryResolution: 10 * time.Second,
+			GCInterval:             1 * time.Minute,
+			GCParallelDestroys:     2,
+			GCDiskUsageThreshold:   80,
+			GCInodeUsageThreshold:  70,
+			GCMaxAllocs:            50,
+			NoHostUUID:             pointer.Of(true),
+			DisableRemoteExec:      false,
 			ServerJoin: &ServerJoin{
 				RetryJoin:        []string{},
 				RetryInterval:    30 * time.Second,
@@ -1798,20 +2160,23 @@ func DefaultConfig() *Config {
 			CollectionInterval:           "1s",
 			collectionInterval:           1 * time.Second,
 			DisableAllocationHookMetrics: pointer.Of(false),
+			JobAggregateMetricsInterval:  "1m",
+			jobAggregateMetricsInterval:  1 * time.Minute,
 		},
 		Eventlog: &Eventlog{
 			Enabled: false,
 			Level:   "error",
 		},
-		TLSConfig:          &config.TLSConfig{},
-		Sentinel:           &config.SentinelConfig{},
-		Version:            version.GetVersion(),
-		Autopilot:          config.DefaultAutopilotConfig(),
-		Audit:              &config.AuditConfig{},
-		DisableUpdateCheck: pointer.Of(false),
-		Limits:             config.DefaultLimits(),
-		Reporting:          config.DefaultReporting(),
-		KEKProviders:       []*structs.KEKProviderConfig{},
+		HTTPAPIResponseCompression: DefaultHTTPAPIResponseCompression(),
+		TLSConfig:                  &config.TLSConfig{},
+		Sentinel:                   &config.SentinelConfig{},
+		Version:                    version.GetVersion(),
+		Autopilot:                  config.DefaultAutopilotConfig(),
+		Audit:                      &config.AuditConfig{},
+		DisableUpdateCheck:         pointer.Of(false),
+		Limits:                     config.DefaultLimits(),
+		Reporting:                  config.DefaultReporting(),
+		KEKProviders:               []*structs.KEKProviderConfig{},
 	}
 
 	return cfg
@@ -2050,6 +2415,8 @@ func (c *Config) Merge(b *Config) *Config {
 		result.HTTPAPIResponseHeaders[k] = v
 	}
 
+	result.HTTPAPIResponseCompression = c.HTTPAPIResponseCompression.Merge(b.HTTPAPIResponseCompression)
+
 	result.Limits = c.Limits.Merge(b.Limits)
 
 	result.KEKProviders = mergeKEKProviderConfigs(result.KEKProviders, b.KEKProviders)
@@ -2186,6 +2553,7 @@ func (c *Config) Copy() *Config {
 	nc.Files = slices.Clone(c.Files)
 	nc.TLSConfig = c.TLSConfig.Copy()
 	nc.HTTPAPIResponseHeaders = maps.Clone(c.HTTPAPIResponseHeaders)
+	nc.HTTPAPIResponseCompression = c.HTTPAPIResponseCompression.Copy()
 	nc.Sentinel = c.Sentinel.Copy()
 	nc.Autopilot = c.Autopilot.Copy()
 	nc.Plugins = helper.CopySlice(c.Plugins)
@@ -2653,6 +3021,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.JobTrackedVersions != nil {
 		result.JobTrackedVersions = b.JobTrackedVersions
 	}
+	if b.JobMaxVersionsPerJob != nil {
+		result.JobMaxVersionsPerJob = pointer.Of(*b.JobMaxVersionsPerJob)
+	}
+	if b.BuiltinAutoscaler {
+		result.BuiltinAutoscaler = true
+	}
 
 	if b.OIDCIssuer != "" {
 		result.OIDCIssuer = b.OIDCIssuer
@@ -2763,6 +3137,18 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.ReservableCores != "" {
 		result.ReservableCores = b.ReservableCores
 	}
+	if b.StatsHistory != nil {
+		result.StatsHistory = pointer.Of(*b.StatsHistory)
+	}
+	if b.StatsHistoryHCL != "" {
+		result.StatsHistoryHCL = b.StatsHistoryHCL
+	}
+	if b.StatsHistoryResolution != 0 {
+		result.StatsHistoryResolution = b.StatsHistoryResolution
+	}
+	if b.StatsHistoryResolutionHCL != "" {
+		result.StatsHistoryResolutionHCL = b.StatsHistoryResolutionHCL
+	}
 	if b.GCInterval != 0 {
 		result.GCInterval = b.GCInterval
 	}
@@ -2784,6 +3170,7 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.GCVolumesOnNodeGC {
 		result.GCVolumesOnNodeGC = b.GCVolumesOnNodeGC
 	}
+	result.GC = c.GC.Merge(b.GC)
 	// NoHostUUID defaults to true, merge if false
 	if b.NoHostUUID != nil {
 		result.NoHostUUID = b.NoHostUUID
@@ -2793,6 +3180,10 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.DisableRemoteExec = b.DisableRemoteExec
 	}
 
+	if len(b.HealthNonFatal) != 0 {
+		result.HealthNonFatal = append(result.HealthNonFatal, b.HealthNonFatal...)
+	}
+
 	if b.TemplateConfig != nil {
 		result.TemplateConfig = result.TemplateConfig.Merge(b.TemplateConfig)
 	}
@@ -2859,10 +3250,20 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.HostNetworks = append(result.HostNetworks, b.HostNetworks...)
 	}
 
+	result.HostDisks = c.HostDisks
+
+	if len(b.HostDisks) != 0 {
+		result.HostDisks = append(result.HostDisks, b.HostDisks...)
+	}
+
 	if b.BindWildcardDefaultHostNetwork {
 		result.BindWildcardDefaultHostNetwork = true
 	}
 
+	if b.MigrateBandwidthLimit != "" {
+		result.MigrateBandwidthLimit = b.MigrateBandwidthLimit
+	}
+
 	// This value is a pointer, therefore if it is not nil the user has
 	// supplied an override value.
 	if b.NomadServiceDiscovery != nil {
@@ -2876,6 +3277,7 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result.Artifact = c.Artifact.Merge(b.Artifact)
 	result.Drain = c.Drain.Merge(b.Drain)
 	result.Users = c.Users.Merge(b.Users)
+	result.EnvFromNodeMeta = c.EnvFromNodeMeta.Merge(b.EnvFromNodeMeta)
 
 	if b.NodeMaxAllocs != 0 {
 		result.NodeMaxAllocs = b.NodeMaxAllocs
@@ -2992,6 +3394,18 @@ func (t *Telemetry) Merge(b *Telemetry) *Telemetry {
 	if b.DisableDispatchedJobSummaryMetrics {
 		result.DisableDispatchedJobSummaryMetrics = b.DisableDispatchedJobSummaryMetrics
 	}
+	if b.PublishJobAggregateMetrics {
+		result.PublishJobAggregateMetrics = b.PublishJobAggregateMetrics
+	}
+	if b.JobAggregateMetricsInterval != "" {
+		result.JobAggregateMetricsInterval = b.JobAggregateMetricsInterval
+	}
+	if b.jobAggregateMetricsInterval != 0 {
+		result.jobAggregateMetricsInterval = b.jobAggregateMetricsInterval
+	}
+	if b.JobAggregateMetricsNamespaces != nil {
+		result.JobAggregateMetricsNamespaces = b.JobAggregateMetricsNamespaces
+	}
 	if b.DisableQuotaUtilizationMetrics {
 		result.DisableQuotaUtilizationMetrics = b.DisableQuotaUtilizationMetrics
 	}
@@ -3001,6 +3415,12 @@ func (t *Telemetry) Merge(b *Telemetry) *Telemetry {
 	if b.DisableAllocationHookMetrics != nil {
 		result.DisableAllocationHookMetrics = b.DisableAllocationHookMetrics
 	}
+	if b.OTLP != nil {
+		result.OTLP = result.OTLP.Merge(b.OTLP)
+	}
+	if b.Tracing != nil {
+		result.Tracing = result.Tracing.Merge(b.Tracing)
+	}
 
 	return &result
 }
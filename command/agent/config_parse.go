@@ -106,6 +106,12 @@ func ParseConfigFile(path string) (*Config, error) {
 	// convert strings to time.Durations
 	tds := []durationConversionMap{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL, nil},
+		{"client.stats_history", nil, &c.Client.StatsHistoryHCL,
+			func(d *time.Duration) {
+				c.Client.StatsHistory = d
+			},
+		},
+		{"client.stats_history_resolution", &c.Client.StatsHistoryResolution, &c.Client.StatsHistoryResolutionHCL, nil},
 		{"acl.token_ttl", &c.ACL.TokenTTL, &c.ACL.TokenTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.PolicyTTL, &c.ACL.PolicyTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.RoleTTL, &c.ACL.RoleTTLHCL, nil},
@@ -120,9 +126,11 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"server.server_join.retry_interval", &c.Server.ServerJoin.RetryInterval, &c.Server.ServerJoin.RetryIntervalHCL, nil},
 		{"autopilot.server_stabilization_time", &c.Autopilot.ServerStabilizationTime, &c.Autopilot.ServerStabilizationTimeHCL, nil},
 		{"autopilot.last_contact_threshold", &c.Autopilot.LastContactThreshold, &c.Autopilot.LastContactThresholdHCL, nil},
+		{"autopilot.max_last_log_age", &c.Autopilot.MaxLastLogAge, &c.Autopilot.MaxLastLogAgeHCL, nil},
 		{"telemetry.in_memory_collection_interval", &c.Telemetry.inMemoryCollectionInterval, &c.Telemetry.InMemoryCollectionInterval, nil},
 		{"telemetry.in_memory_retention_period", &c.Telemetry.inMemoryRetentionPeriod, &c.Telemetry.InMemoryRetentionPeriod, nil},
 		{"telemetry.collection_interval", &c.Telemetry.collectionInterval, &c.Telemetry.CollectionInterval, nil},
+		{"telemetry.job_aggregate_metrics_interval", &c.Telemetry.jobAggregateMetricsInterval, &c.Telemetry.JobAggregateMetricsInterval, nil},
 		{"client.template.block_query_wait", nil, &c.Client.TemplateConfig.BlockQueryWaitTimeHCL,
 			func(d *time.Duration) {
 				c.Client.TemplateConfig.BlockQueryWaitTime = d
@@ -204,6 +212,12 @@ func ParseConfigFile(path string) (*Config, error) {
 		},
 	}
 
+	if c.Telemetry.OTLP != nil {
+		tds = append(tds, durationConversionMap{
+			"telemetry.otlp.interval", &c.Telemetry.OTLP.interval, &c.Telemetry.OTLP.Interval, nil,
+		})
+	}
+
 	// Parse durations for Consul and Vault config blocks if provided.
 	for _, consulConfig := range c.Consuls {
 
@@ -330,6 +344,12 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_network")
 	}
 
+	// Remove HostDisk extra keys
+	for _, hd := range c.Client.HostDisks {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, hd.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_disk")
+	}
+
 	// Remove Template extra keys
 	for _, t := range []string{"function_denylist", "disable_file_sandbox", "max_stale", "wait", "wait_bounds", "block_query_wait", "consul_retry", "vault_retry", "nomad_retry"} {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, t)
@@ -356,6 +376,11 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, k)
 	}
 
+	for _, k := range []string{"encodings"} {
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
+		helper.RemoveEqualFold(&c.ExtraKeysHCL, "http_api_response_compression")
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "telemetry")
@@ -91,6 +91,7 @@ var basicConfig = &Config{
 		GCVolumesOnNodeGC:     true,
 		NoHostUUID:            pointer.Of(false),
 		DisableRemoteExec:     true,
+		HealthNonFatal:        []string{"driver.qemu"},
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
@@ -161,6 +162,7 @@ var basicConfig = &Config{
 		JobMaxPriority:     pointer.Of(200),
 		JobMaxCount:        pointer.Of(1000),
 		StartTimeout:       "1m",
+		BuiltinAutoscaler:  true,
 		ClientIntroduction: &ClientIntroduction{
 			Enforcement:           "warn",
 			DefaultIdentityTTLHCL: "5m",
@@ -305,6 +307,11 @@ var basicConfig = &Config{
 	HTTPAPIResponseHeaders: map[string]string{
 		"Access-Control-Allow-Origin": "*",
 	},
+	HTTPAPIResponseCompression: &HTTPAPIResponseCompression{
+		Enabled:   pointer.Of(true),
+		MinSize:   pointer.Of(2048),
+		Encodings: []string{"zstd", "gzip"},
+	},
 	Sentinel: &config.SentinelConfig{
 		Imports: []*config.SentinelImport{
 			{
@@ -327,6 +334,10 @@ var basicConfig = &Config{
 		LastContactThresholdHCL:    "12705s",
 		MaxTrailingLogs:            17849,
 		MinQuorum:                  3,
+		MinDiskFree:                "5GB",
+		MaxLastLogAge:              30 * time.Second,
+		MaxLastLogAgeHCL:           "30s",
+		MaxAppliedIndexLag:         1000,
 		EnableRedundancyZones:      &trueValue,
 		DisableUpgradeMigration:    &trueValue,
 		EnableCustomUpgrades:       &trueValue,
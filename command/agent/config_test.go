@@ -191,6 +191,11 @@ func TestConfig_Merge(t *testing.T) {
 		HTTPAPIResponseHeaders: map[string]string{
 			"Access-Control-Allow-Origin": "*",
 		},
+		HTTPAPIResponseCompression: &HTTPAPIResponseCompression{
+			Enabled:   pointer.Of(true),
+			MinSize:   pointer.Of(1024),
+			Encodings: []string{"gzip"},
+		},
 		Vaults: []*config.VaultConfig{{
 			Name:          structs.VaultDefaultCluster,
 			Addr:          "1",
@@ -224,6 +229,9 @@ func TestConfig_Merge(t *testing.T) {
 			LastContactThreshold:    1 * time.Second,
 			MaxTrailingLogs:         1,
 			MinQuorum:               1,
+			MinDiskFree:             "1GB",
+			MaxLastLogAge:           1 * time.Second,
+			MaxAppliedIndexLag:      1,
 			EnableRedundancyZones:   &falseValue,
 			DisableUpgradeMigration: &falseValue,
 			EnableCustomUpgrades:    &falseValue,
@@ -304,6 +312,17 @@ func TestConfig_Merge(t *testing.T) {
 			DisableQuotaUtilizationMetrics:     false,
 			DisableRPCRateMetricsLabels:        true,
 			FilterDefault:                      pointer.Of(false),
+			OTLP: &OTLPTelemetry{
+				Endpoint: "collector.example.com:4318",
+				Protocol: OTLPProtocolHTTP,
+				Headers:  map[string]string{"X-Token": "secret"},
+				Interval: "15s",
+				Insecure: true,
+			},
+			Tracing: &TracingTelemetry{
+				OTLPEndpoint: "http://collector.example.com:4317",
+				SampleRatio:  pointer.Of(0.25),
+			},
 		},
 		Client: &ClientConfig{
 			Enabled:   true,
@@ -391,6 +410,7 @@ func TestConfig_Merge(t *testing.T) {
 			JobMaxCount:        pointer.Of(1000),
 			OIDCIssuer:         "https://oidc.test.nomadproject.io",
 			StartTimeout:       "1m",
+			BuiltinAutoscaler:  true,
 		},
 		ACL: &ACLConfig{
 			Enabled:               true,
@@ -419,6 +439,11 @@ func TestConfig_Merge(t *testing.T) {
 			"Access-Control-Allow-Origin":  "*",
 			"Access-Control-Allow-Methods": "GET, POST, OPTIONS",
 		},
+		HTTPAPIResponseCompression: &HTTPAPIResponseCompression{
+			Enabled:   pointer.Of(false),
+			MinSize:   pointer.Of(4096),
+			Encodings: []string{"zstd", "gzip"},
+		},
 		Vaults: []*config.VaultConfig{{
 			Name:                structs.VaultDefaultCluster,
 			Addr:                "2",
@@ -470,6 +495,9 @@ func TestConfig_Merge(t *testing.T) {
 			LastContactThreshold:    2 * time.Second,
 			MaxTrailingLogs:         2,
 			MinQuorum:               2,
+			MinDiskFree:             "2GB",
+			MaxLastLogAge:           2 * time.Second,
+			MaxAppliedIndexLag:      2,
 			EnableRedundancyZones:   &trueValue,
 			DisableUpgradeMigration: &trueValue,
 			EnableCustomUpgrades:    &trueValue,
@@ -1581,6 +1609,15 @@ func TestTelemetry_Validate(t *testing.T) {
 			},
 			expectedError: errors.New("telemetry in-memory retention period must be greater than zero"),
 		},
+		{
+			name: "missing job aggregate metrics interval",
+			inputTelemetry: &Telemetry{
+				inMemoryCollectionInterval: 1 * time.Second,
+				inMemoryRetentionPeriod:    10 * time.Second,
+				PublishJobAggregateMetrics: true,
+			},
+			expectedError: errors.New("telemetry job aggregate metrics interval must be greater than zero"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1607,6 +1644,20 @@ func TestTelemetry_Parse(t *testing.T) {
 		disable_dispatched_job_summary_metrics = true
 		disable_quota_utilization_metrics = true
 		disable_rpc_rate_metrics_labels = true
+		publish_job_aggregate_metrics = true
+		job_aggregate_metrics_interval = "30s"
+		job_aggregate_metrics_namespaces = ["default"]
+		otlp {
+			endpoint = "collector.example.com:4317"
+			headers = {
+				"X-Token" = "secret"
+			}
+			interval = "15s"
+		}
+		tracing {
+			otlp_endpoint = "http://collector.example.com:4317"
+			sample_ratio = 0.5
+		}
 	}`), 0600)
 	must.NoError(t, err)
 
@@ -1619,6 +1670,19 @@ func TestTelemetry_Parse(t *testing.T) {
 	must.True(t, config.Telemetry.DisableDispatchedJobSummaryMetrics)
 	must.True(t, config.Telemetry.DisableQuotaUtilizationMetrics)
 	must.True(t, config.Telemetry.DisableRPCRateMetricsLabels)
+	must.True(t, config.Telemetry.PublishJobAggregateMetrics)
+	must.Eq(t, 30*time.Second, config.Telemetry.jobAggregateMetricsInterval)
+	must.Eq(t, []string{"default"}, config.Telemetry.JobAggregateMetricsNamespaces)
+	must.Eq(t, &OTLPTelemetry{
+		Endpoint: "collector.example.com:4317",
+		Headers:  map[string]string{"X-Token": "secret"},
+		Interval: "15s",
+		interval: 15 * time.Second,
+	}, config.Telemetry.OTLP)
+	must.Eq(t, &TracingTelemetry{
+		OTLPEndpoint: "http://collector.example.com:4317",
+		SampleRatio:  pointer.Of(0.5),
+	}, config.Telemetry.Tracing)
 }
 
 func TestEventBroker_Parse(t *testing.T) {
@@ -2106,3 +2170,52 @@ func TestEventlog_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPAPIResponseCompression_Validate(t *testing.T) {
+	ci.Parallel(t)
+	testCases := []struct {
+		desc        string
+		compression *HTTPAPIResponseCompression
+		shouldErr   bool
+	}{
+		{
+			desc:        "default",
+			compression: DefaultHTTPAPIResponseCompression(),
+		},
+		{
+			desc: "valid encodings",
+			compression: &HTTPAPIResponseCompression{
+				Encodings: []string{"zstd", "gzip"},
+			},
+		},
+		{
+			desc: "invalid encoding",
+			compression: &HTTPAPIResponseCompression{
+				Encodings: []string{"br"},
+			},
+			shouldErr: true,
+		},
+		{
+			desc: "negative min size",
+			compression: &HTTPAPIResponseCompression{
+				MinSize: pointer.Of(-1),
+			},
+			shouldErr: true,
+		},
+		{
+			desc: "nil compression",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ci.Parallel(t)
+
+			if tc.shouldErr {
+				must.Error(t, tc.compression.Validate())
+			} else {
+				must.NoError(t, tc.compression.Validate())
+			}
+		})
+	}
+}
@@ -217,7 +217,7 @@ func connectUpstreams(in []structs.ConsulUpstream) []api.Upstream {
 			Datacenter:           upstream.Datacenter,
 			LocalBindAddress:     upstream.LocalBindAddress,
 			MeshGateway:          connectMeshGateway(upstream.MeshGateway),
-			Config:               maps.Clone(upstream.Config),
+			Config:               upstream.ProxyConfig(),
 		}
 	}
 	return upstreams
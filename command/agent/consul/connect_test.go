@@ -403,6 +403,33 @@ func TestConnect_connectUpstreams(t *testing.T) {
 			}}),
 		)
 	})
+
+	t.Run("connect timeout and passive health check", func(t *testing.T) {
+		must.Eq(t,
+			[]api.Upstream{{
+				DestinationName: "foo",
+				LocalBindPort:   8000,
+				Config: map[string]any{
+					"protocol":           "http",
+					"connect_timeout_ms": int64(2500),
+					"passive_health_check": map[string]any{
+						"max_failures": 3,
+						"interval":     "30s",
+					},
+				},
+			}},
+			connectUpstreams([]structs.ConsulUpstream{{
+				DestinationName: "foo",
+				LocalBindPort:   8000,
+				Config:          map[string]any{"protocol": "http"},
+				ConnectTimeout:  2500 * time.Millisecond,
+				PassiveHealthCheck: structs.ConsulPassiveHealthCheck{
+					MaxFailures: 3,
+					Interval:    30 * time.Second,
+				},
+			}}),
+		)
+	})
 }
 
 func TestConnect_connectProxyConfig(t *testing.T) {
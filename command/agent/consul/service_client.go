@@ -1013,15 +1013,26 @@ func (c *ServiceClient) sync(reason syncReason) error {
 		return fmt.Errorf("failed to query Consul namespaces: %w", err)
 	}
 
-	// Accumulate all services in Consul across all namespaces.
+	// Accumulate all services in Consul across all namespaces. Services known
+	// to Nomad but found outside the namespace they are registered in, such as
+	// after the namespace of a service was updated, are collected separately
+	// so they can be removed from their previous namespace.
 	// Note: this query has to use the Nomad agent's own Consul token
 	servicesInConsul := make(map[string]*api.AgentService)
+	staleServices := make(map[string]map[string]*api.AgentService)
 	for _, namespace := range namespaces {
 		if nsServices, err := c.agentAPI.ServicesWithFilterOpts("", &api.QueryOptions{Namespace: normalizeNamespace(namespace)}); err != nil {
 			metrics.IncrCounter([]string{"client", "consul", "sync_failure"}, 1)
 			return fmt.Errorf("failed to query Consul services: %w", err)
 		} else {
 			for k, v := range nsServices {
+				if c.registeredInOtherNamespace(k, namespace) {
+					if staleServices[namespace] == nil {
+						staleServices[namespace] = make(map[string]*api.AgentService)
+					}
+					staleServices[namespace][k] = v
+					continue
+				}
 				servicesInConsul[k] = v
 			}
 		}
@@ -1073,6 +1084,27 @@ func (c *ServiceClient) sync(reason syncReason) error {
 		metrics.IncrCounter([]string{"client", "consul", "service_deregistrations"}, 1)
 	}
 
+	// Remove Nomad services from namespaces they are no longer registered in.
+	for namespace, services := range staleServices {
+		for id := range services {
+			// Sidecars are removed along with their parent service.
+			if maybeConnectSidecar(id) {
+				continue
+			}
+
+			err := c.syncRemoveService(normalizeNamespace(namespace), id, services)
+			if err != nil {
+				metrics.IncrCounter([]string{"client", "consul", "sync_failure"}, 1)
+				mErr = multierror.Append(mErr, err)
+				fails++
+				continue
+			}
+
+			sdereg++
+			metrics.IncrCounter([]string{"client", "consul", "service_deregistrations"}, 1)
+		}
+	}
+
 	// Add Nomad managed services missing in Consul, or updated via Nomad.
 	for id, serviceInNomad := range c.services {
 		serviceInConsul, exists := servicesInConsul[id]
@@ -1184,6 +1216,17 @@ func (c *ServiceClient) sync(reason syncReason) error {
 	return mErr.ErrorOrNil()
 }
 
+// registeredInOtherNamespace returns true if the service or sidecar id, found
+// in the given Consul namespace, is known to Nomad and registered in a
+// different namespace.
+func (c *ServiceClient) registeredInOtherNamespace(id, namespace string) bool {
+	service, ok := c.services[strings.TrimSuffix(id, sidecarSuffix)]
+	if !ok {
+		return false
+	}
+	return normalizeNamespace(service.Namespace) != normalizeNamespace(namespace)
+}
+
 // syncRemoveService removes an unwanted service from Consul. If the service has
 // a sidecar, we need to remove the sidecar first, otherwise Consul will produce
 // a warning and an error when removing the parent service. So this returns
@@ -1342,8 +1385,12 @@ func (c *ServiceClient) serviceRegs(
 	// newConnectGateway returns nil if there's no Connect gateway.
 	gateway := newConnectGateway(service.Connect)
 
-	// newWeights returns nil if there's no Weights.
+	// newWeights returns nil if there's no Weights. Use the canary weights
+	// when this is a canary which sets them.
 	weights := newWeights(service.Weights)
+	if workload.Canary && service.CanaryWeights != nil {
+		weights = newWeights(service.CanaryWeights)
+	}
 
 	// Determine whether to use meta or canary_meta
 	var meta map[string]string
@@ -1406,12 +1453,24 @@ func (c *ServiceClient) serviceRegs(
 		return nil, err
 	}
 
+	// In dual mode, tag the service with its address of each family, unless
+	// the job tags these addresses itself
+	if workload.AdvertiseMode == structs.AdvertiseModeDual {
+		ipv4, ipv6 := serviceregistration.DualStackAddresses(ip, workload.NetworkStatus)
+		if _, ok := taggedAddresses["lan_ipv4"]; !ok && ipv4 != "" {
+			taggedAddresses["lan_ipv4"] = api.ServiceAddress{Address: ipv4, Port: port}
+		}
+		if _, ok := taggedAddresses["lan_ipv6"]; !ok && ipv6 != "" {
+			taggedAddresses["lan_ipv6"] = api.ServiceAddress{Address: ipv6, Port: port}
+		}
+	}
+
 	// Build the Consul Service registration request
 	serviceReg := &api.AgentServiceRegistration{
 		Kind:              kind,
 		ID:                id,
 		Name:              service.Name,
-		Namespace:         workload.ProviderNamespace,
+		Namespace:         service.ConsulNamespace(workload.ProviderNamespace),
 		Tags:              tags,
 		EnableTagOverride: service.EnableTagOverride,
 		Address:           ip,
@@ -1510,7 +1569,7 @@ func (c *ServiceClient) checkRegs(
 		}
 
 		checkID := MakeCheckID(serviceID, check)
-		registration, err := createCheckReg(serviceID, checkID, check, ip, port, workload.ProviderNamespace)
+		registration, err := createCheckReg(serviceID, checkID, check, ip, port, service.ConsulNamespace(workload.ProviderNamespace))
 		if err != nil {
 			return nil, fmt.Errorf("failed to add check %q: %v", check.Name, err)
 		}
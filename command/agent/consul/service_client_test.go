@@ -594,6 +594,53 @@ func TestServiceRegistration_CheckOnUpdate(t *testing.T) {
 	}
 }
 
+func TestServiceRegistration_AdvertiseModeDual(t *testing.T) {
+	ci.Parallel(t)
+
+	mockAgent := NewMockAgent(ossFeatures)
+	namespacesClient := NewNamespacesClient(NewMockNamespaces(nil), mockAgent)
+	logger := testlog.HCLogger(t)
+	sc := NewServiceClient(mockAgent, namespacesClient, logger, true)
+
+	ws := &serviceregistration.WorkloadServices{
+		AllocInfo:     structs.AllocInfo{AllocID: uuid.Generate(), Group: "group"},
+		AdvertiseMode: structs.AdvertiseModeDual,
+		NetworkStatus: &structs.AllocNetworkStatus{
+			Address:     "172.26.64.2",
+			AddressIPv6: "fd00:26::2",
+		},
+		Ports: structs.AllocatedPorts{
+			{Label: "http", Value: 25000, To: 8080, HostIP: "10.0.0.1"},
+		},
+	}
+
+	// alloc addresses are tagged with both families
+	service := &structs.Service{Name: "web", PortLabel: "http", AddressMode: structs.AddressModeAlloc}
+	ops := new(operations)
+	_, err := sc.serviceRegs(ops, service, ws)
+	must.NoError(t, err)
+	must.Eq(t, "172.26.64.2", ops.regServices[0].Address)
+	must.Eq(t, map[string]api.ServiceAddress{
+		"lan_ipv4": {Address: "172.26.64.2", Port: 8080},
+		"lan_ipv6": {Address: "fd00:26::2", Port: 8080},
+	}, ops.regServices[0].TaggedAddresses)
+
+	// host addresses are only tagged with their own family, and tagged
+	// addresses of the service are kept
+	service = &structs.Service{
+		Name:            "web",
+		PortLabel:       "http",
+		TaggedAddresses: map[string]string{"lan_ipv6": "[2001:db8::1]:443"},
+	}
+	ops = new(operations)
+	_, err = sc.serviceRegs(ops, service, ws)
+	must.NoError(t, err)
+	must.Eq(t, map[string]api.ServiceAddress{
+		"lan_ipv4": {Address: "10.0.0.1", Port: 25000},
+		"lan_ipv6": {Address: "2001:db8::1", Port: 443},
+	}, ops.regServices[0].TaggedAddresses)
+}
+
 func TestSyncLogic_proxyUpstreamsDifferent(t *testing.T) {
 	ci.Parallel(t)
 
@@ -856,6 +856,34 @@ func TestConsul_CanaryMeta_NoMeta(t *testing.T) {
 	require.Len(ctx.FakeConsul.services["default"], 0)
 }
 
+// TestConsul_CanaryWeights asserts CanaryWeights are used when Canary=true
+func TestConsul_CanaryWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx := setupFake(t)
+
+	ctx.Workload.Canary = true
+	ctx.Workload.Services[0].Weights = &structs.ServiceWeights{Passing: 10, Warning: 1}
+	ctx.Workload.Services[0].CanaryWeights = &structs.ServiceWeights{Passing: 1, Warning: 1}
+
+	must.NoError(t, ctx.ServiceClient.RegisterWorkload(ctx.Workload))
+	must.NoError(t, ctx.syncOnce(syncNewOps))
+	must.MapLen(t, 1, ctx.FakeConsul.services["default"])
+	for _, service := range ctx.FakeConsul.services["default"] {
+		must.Eq(t, &api.AgentWeights{Passing: 1, Warning: 1}, service.Weights)
+	}
+
+	// Disable canary and assert weights are not the canary weights
+	origWorkload := ctx.Workload.Copy()
+	ctx.Workload.Canary = false
+	must.NoError(t, ctx.ServiceClient.UpdateWorkload(origWorkload, ctx.Workload))
+	must.NoError(t, ctx.syncOnce(syncNewOps))
+	must.MapLen(t, 1, ctx.FakeConsul.services["default"])
+	for _, service := range ctx.FakeConsul.services["default"] {
+		must.Eq(t, &api.AgentWeights{Passing: 10, Warning: 1}, service.Weights)
+	}
+}
+
 // TestConsul_PeriodicSync asserts that Nomad periodically reconciles with
 // Consul.
 func TestConsul_PeriodicSync(t *testing.T) {
@@ -1321,3 +1349,51 @@ func TestConsul_ServiceDeregistration_InProbation(t *testing.T) {
 	require.NotContains(ctx.FakeConsul.checks["default"], MakeCheckID(outofbandWorkloadServiceID, outofbandWorkload.Services[0].Checks[0]))
 	require.NotContains(ctx.FakeConsul.checks["default"], MakeCheckID(explicitlyRemovedWorkloadServiceID, explicitlyRemovedWorkload.Services[0].Checks[0]))
 }
+
+// TestConsul_ChangeServiceNamespace asserts services are registered in the
+// namespace of their consul block, and moved when it changes.
+func TestConsul_ChangeServiceNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	agentClient := NewMockAgent(Features{Enterprise: true, Namespaces: true})
+	nsClient := NewNamespacesClient(NewMockNamespaces([]string{"shared", "team"}), agentClient)
+	serviceClient := NewServiceClient(agentClient, nsClient, testlog.HCLogger(t), true)
+	serviceClient.deregisterProbationExpiry = time.Now().Add(-1 * time.Minute)
+	ctx := &testFakeCtx{
+		ServiceClient: serviceClient,
+		FakeConsul:    agentClient,
+		Workload:      testWorkload(),
+	}
+
+	ctx.Workload.ProviderNamespace = "team"
+	ctx.Workload.Services = append(ctx.Workload.Services, &structs.Service{
+		Name:      "shared-service",
+		PortLabel: "y",
+		Consul:    &structs.Consul{Namespace: "shared"},
+		Checks: []*structs.ServiceCheck{{
+			Name:     "check",
+			Type:     "tcp",
+			Interval: time.Second,
+			Timeout:  time.Second,
+		}},
+	})
+	sharedID := serviceregistration.MakeAllocServiceID(
+		ctx.Workload.AllocInfo.AllocID, ctx.Workload.Name(), ctx.Workload.Services[1])
+
+	must.NoError(t, ctx.ServiceClient.RegisterWorkload(ctx.Workload))
+	must.NoError(t, ctx.syncOnce(syncNewOps))
+	must.MapLen(t, 1, ctx.FakeConsul.services["team"])
+	must.MapContainsKey(t, ctx.FakeConsul.services["shared"], sharedID)
+	must.MapContainsKey(t, ctx.FakeConsul.checks["shared"], MakeCheckID(sharedID, ctx.Workload.Services[1].Checks[0]))
+
+	// move the service into the namespace of its group
+	updated := ctx.Workload.Copy()
+	updated.Services[1].Consul = nil
+	must.NoError(t, ctx.ServiceClient.UpdateWorkload(ctx.Workload, updated))
+	must.NoError(t, ctx.syncOnce(syncNewOps))
+
+	must.MapEmpty(t, ctx.FakeConsul.services["shared"])
+	must.MapEmpty(t, ctx.FakeConsul.checks["shared"])
+	must.MapLen(t, 2, ctx.FakeConsul.services["team"])
+	must.MapContainsKey(t, ctx.FakeConsul.services["team"], sharedID)
+}
@@ -6,6 +6,7 @@ package agent
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -37,6 +38,11 @@ func (s *HTTPServer) evalsListRequest(resp http.ResponseWriter, req *http.Reques
 	args.FilterEvalStatus = query.Get("status")
 	args.FilterJobID = query.Get("job")
 
+	projection, err := parseFields(req, reflect.TypeOf(structs.Evaluation{}))
+	if err != nil {
+		return nil, err
+	}
+
 	var out structs.EvalListResponse
 	if err := s.agent.RPC("Eval.List", &args, &out); err != nil {
 		return nil, err
@@ -46,7 +52,7 @@ func (s *HTTPServer) evalsListRequest(resp http.ResponseWriter, req *http.Reques
 	if out.Evaluations == nil {
 		out.Evaluations = make([]*structs.Evaluation, 0)
 	}
-	return out.Evaluations, nil
+	return projectFields(out.Evaluations, projection)
 }
 
 func (s *HTTPServer) evalsDeleteRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// fieldsJsonHandle decodes serialized list responses for projection, with
+// objects decoded as maps keyed by their field names.
+var fieldsJsonHandle = &codec.JsonHandle{
+	BasicHandle: codec.BasicHandle{
+		DecodeOptions: codec.DecodeOptions{
+			MapType: reflect.TypeOf(map[string]interface{}(nil)),
+		},
+	},
+	HTMLCharsAsIs: true,
+}
+
+// parseFields parses the "fields" query parameter of list endpoints, which is
+// a comma-separated list of JSON paths such as "ID,TaskStates.web.State" to
+// project each listed object onto. Each path is validated against the type of
+// the listed objects, and an unknown path results in a 400 error. A nil result
+// means the objects are not projected.
+func parseFields(req *http.Request, typ reflect.Type) ([][]string, error) {
+	param := req.URL.Query().Get("fields")
+	if param == "" {
+		return nil, nil
+	}
+
+	var fields [][]string
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		path := strings.Split(field, ".")
+		if !validFieldPath(typ, path) {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("unknown field %q", field))
+		}
+		fields = append(fields, path)
+	}
+	return fields, nil
+}
+
+// projectFields returns the list obj with each object reduced to the given
+// fields, or obj unchanged if there are none. The projection applies to obj as
+// it is serialized, so it includes any field added by encoding extensions and
+// respects the field selection of other query parameters, such as
+// "resources=true".
+func projectFields(obj interface{}, fields [][]string) (interface{}, error) {
+	if len(fields) == 0 {
+		return obj, nil
+	}
+
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf, structs.JsonHandleWithExtensions).Encode(obj); err != nil {
+		return nil, err
+	}
+
+	var objects []map[string]interface{}
+	if err := codec.NewDecoderBytes(buf.Bytes(), fieldsJsonHandle).Decode(&objects); err != nil {
+		return nil, err
+	}
+
+	projected := make([]map[string]interface{}, len(objects))
+	for i, object := range objects {
+		projected[i] = make(map[string]interface{}, len(fields))
+		for _, path := range fields {
+			projectPath(projected[i], object, path)
+		}
+	}
+	return projected, nil
+}
+
+// projectPath copies the value at path in src into dst, creating the objects
+// along the path as needed. Lists along the path are projected element by
+// element, and a path which is absent from src is skipped.
+func projectPath(dst, src map[string]interface{}, path []string) {
+	value, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		dst[path[0]] = value
+		return
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		child, ok := dst[path[0]].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			dst[path[0]] = child
+		}
+		projectPath(child, value, path[1:])
+	case []interface{}:
+		children, ok := dst[path[0]].([]interface{})
+		if !ok {
+			children = make([]interface{}, len(value))
+			dst[path[0]] = children
+		}
+		for i, elem := range value {
+			elem, ok := elem.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			child, ok := children[i].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				children[i] = child
+			}
+			projectPath(child, elem, path[1:])
+		}
+	default:
+		// null or a scalar has no fields to descend into
+		dst[path[0]] = value
+	}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// validFieldPath returns whether path names a field of objects of type typ as
+// they are serialized. Lists are transparent to paths, and the element of a
+// path following a map names a key of the map, which may be anything.
+func validFieldPath(typ reflect.Type, path []string) bool {
+	for len(path) > 0 {
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			typ = typ.Elem()
+			continue
+		case reflect.Interface:
+			return true
+		case reflect.Map:
+			typ = typ.Elem()
+		case reflect.Struct:
+			if typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(textMarshalerType) {
+				return false
+			}
+			field, ok := jsonField(typ, path[0])
+			if !ok {
+				return false
+			}
+			typ = field.Type
+		default:
+			return false
+		}
+		path = path[1:]
+	}
+	return true
+}
+
+// jsonField returns the field of the struct type typ serialized with the given
+// name, including the fields of embedded structs which are inlined.
+func jsonField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		tag := field.Tag.Get("codec")
+		if tag == "" {
+			tag = field.Tag.Get("json")
+		}
+		tagName, _, _ := strings.Cut(tag, ",")
+		if tagName == "-" {
+			continue
+		}
+
+		if field.Anonymous && tagName == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if found, ok := jsonField(embedded, name); ok {
+					return found, true
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if tagName == "" {
+			tagName = field.Name
+		}
+		if tagName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
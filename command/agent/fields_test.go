@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestFields_parseFields(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		query  string
+		exp    [][]string
+		expErr string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:  "top level",
+			query: "ID,%20JobID,,ClientStatus",
+			exp:   [][]string{{"ID"}, {"JobID"}, {"ClientStatus"}},
+		},
+		{
+			name:  "nested",
+			query: "TaskStates.web.Events.Type,AllocatedResources.Shared.Networks.IP",
+			exp: [][]string{
+				{"TaskStates", "web", "Events", "Type"},
+				{"AllocatedResources", "Shared", "Networks", "IP"},
+			},
+		},
+		{
+			name:   "unknown",
+			query:  "ID,Bogus",
+			expErr: `unknown field "Bogus"`,
+		},
+		{
+			name:   "unknown nested",
+			query:  "TaskStates.web.Bogus",
+			expErr: `unknown field "TaskStates.web.Bogus"`,
+		},
+		{
+			name:   "scalar",
+			query:  "ID.Bogus",
+			expErr: `unknown field "ID.Bogus"`,
+		},
+		{
+			name:   "time",
+			query:  "TaskStates.web.StartedAt.Bogus",
+			expErr: `unknown field "TaskStates.web.StartedAt.Bogus"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/allocations?fields="+tc.query, nil)
+			must.NoError(t, err)
+
+			fields, err := parseFields(req, reflect.TypeOf(structs.AllocListStub{}))
+			if tc.expErr != "" {
+				must.EqError(t, err, tc.expErr)
+				must.Eq(t, http.StatusBadRequest, err.(HTTPCodedError).Code())
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, fields)
+		})
+	}
+}
+
+func TestFields_projectFields(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.ModifyIndex = 1<<64 - 1
+	alloc.TaskStates = map[string]*structs.TaskState{
+		"web": {
+			State: structs.TaskStateRunning,
+			Events: []*structs.TaskEvent{
+				{Type: structs.TaskStarted, Time: 10},
+				{Type: structs.TaskRestarting, Time: 20},
+			},
+		},
+	}
+	stub := alloc.Stub(nil)
+
+	t.Run("none", func(t *testing.T) {
+		obj, err := projectFields([]*structs.AllocListStub{stub}, nil)
+		must.NoError(t, err)
+		must.Eq(t, []*structs.AllocListStub{stub}, obj.([]*structs.AllocListStub))
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		obj, err := projectFields([]*structs.AllocListStub{stub}, [][]string{
+			{"ID"},
+			{"ModifyIndex"},
+			{"TaskStates", "web", "State"},
+			{"TaskStates", "web", "Events", "Type"},
+			{"TaskStates", "api", "State"},
+
+			// omitted unless resources are requested
+			{"AllocatedResources", "Shared"},
+		})
+		must.NoError(t, err)
+		must.Eq(t, []map[string]interface{}{{
+			"ID":          stub.ID,
+			"ModifyIndex": uint64(1<<64 - 1),
+			"TaskStates": map[string]interface{}{
+				"web": map[string]interface{}{
+					"State": structs.TaskStateRunning,
+					"Events": []interface{}{
+						map[string]interface{}{"Type": structs.TaskStarted},
+						map[string]interface{}{"Type": structs.TaskRestarting},
+					},
+				},
+			},
+		}}, obj.([]map[string]interface{}))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		obj, err := projectFields([]*structs.AllocListStub{}, [][]string{{"ID"}})
+		must.NoError(t, err)
+		must.SliceEmpty(t, obj.([]map[string]interface{}))
+	})
+}
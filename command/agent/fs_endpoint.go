@@ -41,6 +41,8 @@ func (s *HTTPServer) FsRequest(resp http.ResponseWriter, req *http.Request) (int
 		return s.wrapUntrustedContent(s.FileCatRequest)(resp, req)
 	case strings.HasPrefix(path, "stream/"):
 		return s.Stream(resp, req)
+	case strings.HasPrefix(path, "write/"):
+		return s.FileWriteRequest(resp, req)
 	case strings.HasPrefix(path, "logs/"):
 		// Logs are *trusted* content because the endpoint
 		// explicitly sets the Content-Type to text/plain or
@@ -133,6 +135,69 @@ func (s *HTTPServer) FileStatRequest(resp http.ResponseWriter, req *http.Request
 	return reply.Info, nil
 }
 
+func (s *HTTPServer) FileWriteRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var allocID, path string
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/write/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = req.URL.Query().Get("path"); path == "" {
+		return nil, fileNameNotPresentErr
+	}
+
+	var mode uint64 = 0o644
+	if m := req.URL.Query().Get("mode"); m != "" {
+		var err error
+		if mode, err = strconv.ParseUint(m, 8, 32); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse mode field to uint32: %v", err))
+		}
+	}
+
+	// Read the content of the file, up to the maximum size of a write
+	data, err := io.ReadAll(io.LimitReader(req.Body, cstructs.FsWriteMaxBytes+1))
+	if err != nil {
+		return nil, CodedError(400, fmt.Sprintf("failed to read file content: %v", err))
+	}
+	if len(data) > cstructs.FsWriteMaxBytes {
+		return nil, CodedError(413, fmt.Sprintf("file exceeds the maximum size of %d bytes", cstructs.FsWriteMaxBytes))
+	}
+
+	// Create the request
+	args := &cstructs.FsWriteRequest{
+		AllocID:  allocID,
+		Path:     path,
+		Data:     data,
+		FileMode: uint32(mode),
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Make the RPC
+	localClient, remoteClient, localServer := s.rpcHandlerForAlloc(allocID)
+
+	var reply cstructs.FsWriteResponse
+	var rpcErr error
+	if localClient {
+		rpcErr = s.agent.Client().ClientRPC("FileSystem.Write", &args, &reply)
+	} else if remoteClient {
+		rpcErr = s.agent.Client().RPC("FileSystem.Write", &args, &reply)
+	} else if localServer {
+		rpcErr = s.agent.Server().RPC("FileSystem.Write", &args, &reply)
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+
+		return nil, rpcErr
+	}
+
+	return nil, nil
+}
+
 func (s *HTTPServer) FileReadAtRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var allocID, path string
 	var offset, limit int64
@@ -149,6 +149,36 @@ func TestHTTP_FS_Stat_MissingParams(t *testing.T) {
 	})
 }
 
+func TestHTTP_FS_Write_MissingParams(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/client/fs/write/foo?path=/web/local/file", nil)
+		require.NoError(err)
+
+		_, err = s.Server.FileWriteRequest(httptest.NewRecorder(), req)
+		require.EqualError(err, ErrInvalidMethod)
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/client/fs/write/", nil)
+		require.NoError(err)
+
+		_, err = s.Server.FileWriteRequest(httptest.NewRecorder(), req)
+		require.EqualError(err, allocIDNotPresentErr.Error())
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/client/fs/write/foo", nil)
+		require.NoError(err)
+
+		_, err = s.Server.FileWriteRequest(httptest.NewRecorder(), req)
+		require.EqualError(err, fileNameNotPresentErr.Error())
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/client/fs/write/foo?path=/web/local/file&mode=rw", nil)
+		require.NoError(err)
+
+		_, err = s.Server.FileWriteRequest(httptest.NewRecorder(), req)
+		require.ErrorContains(err, "failed to parse mode field")
+	})
+}
+
 func TestHTTP_FS_ReadAt_MissingParams(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -20,7 +20,6 @@ import (
 	"time"
 
 	assetfs "github.com/elazarl/go-bindata-assetfs"
-	"github.com/gorilla/handlers"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-connlimit"
 	log "github.com/hashicorp/go-hclog"
@@ -35,6 +34,7 @@ import (
 	"github.com/hashicorp/nomad/command/agent/event"
 	"github.com/hashicorp/nomad/helper/noxssrw"
 	"github.com/hashicorp/nomad/helper/tlsutil"
+	"github.com/hashicorp/nomad/helper/tracing"
 	"github.com/hashicorp/nomad/nomad"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
@@ -93,6 +93,7 @@ type RPCer interface {
 	Stats() map[string]map[string]string
 	GetConfig() *Config
 	GetMetricsSink() *metrics.InmemSink
+	RequestReload(context.Context) ([]string, error)
 }
 
 // HTTPServer is used to wrap an Agent and expose it over an HTTP interface
@@ -184,7 +185,7 @@ func NewHTTPServers(agent *Agent, config *Config) ([]*HTTPServer, error) {
 		// Create HTTP server with timeouts
 		httpServer := http.Server{
 			Addr:      srv.Addr,
-			Handler:   handlers.CompressHandler(srv.mux),
+			Handler:   newCompressionHandler(srv.mux, config.HTTPAPIResponseCompression),
 			ConnState: makeConnState(config.TLSConfig.EnableHTTP, handshakeTimeout, maxConns, srv.logger),
 			ErrorLog:  newHTTPServerLogger(srv.logger),
 		}
@@ -455,8 +456,10 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.Handle("/v1/client/fs/", wrapCORS(s.wrap(s.FsRequest)))
 	s.mux.HandleFunc("/v1/client/gc", s.wrap(s.ClientGCRequest))
 	s.mux.Handle("/v1/client/stats", wrapCORS(s.wrap(s.ClientStatsRequest)))
+	s.mux.Handle("/v1/client/debug", wrapCORS(s.wrap(s.ClientDebugRequest)))
 	s.mux.Handle("/v1/client/allocation/", wrapCORS(s.wrap(s.ClientAllocRequest)))
 	s.mux.Handle("/v1/client/metadata", wrapCORS(s.wrap(s.NodeMetaRequest)))
+	s.mux.Handle("/v1/client/artifact/prefetch", wrapCORS(s.wrap(s.ArtifactPrefetchRequest)))
 	s.mux.Handle("/v1/client/identity", wrapCORS(s.wrap(s.NodeIdentityGetRequest)))
 	s.mux.Handle("/v1/client/identity/renew", wrapCORS(s.wrap(s.NodeIdentityRenewRequest)))
 
@@ -464,6 +467,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/agent/join", s.wrap(s.AgentJoinRequest))
 	s.mux.HandleFunc("/v1/agent/members", s.wrap(s.AgentMembersRequest))
 	s.mux.HandleFunc("/v1/agent/force-leave", s.wrap(s.AgentForceLeaveRequest))
+	s.mux.HandleFunc("/v1/agent/reload", s.wrap(s.AgentReloadRequest))
 	s.mux.HandleFunc("/v1/agent/servers", s.wrap(s.AgentServersRequest))
 	s.mux.HandleFunc("/v1/agent/schedulers", s.wrap(s.AgentSchedulerWorkerInfoRequest))
 	s.mux.HandleFunc("/v1/agent/schedulers/config", s.wrap(s.AgentSchedulerWorkerConfigRequest))
@@ -512,6 +516,9 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/maintenance-windows", s.wrap(s.OperatorMaintenanceWindowsRequest))
+	s.mux.HandleFunc("/v1/operator/maintenance-window", s.wrap(s.OperatorMaintenanceWindowRequest))
+	s.mux.HandleFunc("/v1/operator/maintenance-window/", s.wrap(s.OperatorMaintenanceWindowRequest))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
 
@@ -923,9 +930,23 @@ func parseWait(resp http.ResponseWriter, req *http.Request, b *structs.QueryOpti
 	return false
 }
 
-// parseConsistency is used to parse the ?stale query params.
+// parseConsistency is used to parse the ?stale and ?max_stale query params.
+// Setting max_stale implies stale.
 func parseConsistency(resp http.ResponseWriter, req *http.Request, b *structs.QueryOptions) error {
 	query := req.URL.Query()
+	if maxStale := query.Get("max_stale"); maxStale != "" {
+		dur, err := time.ParseDuration(maxStale)
+		if err != nil || dur < 0 {
+			errMsg := "Invalid max_stale duration"
+			resp.Header().Set(contentTypeHeader, plainContentType)
+			resp.WriteHeader(http.StatusBadRequest)
+			resp.Write([]byte(errMsg))
+			return CodedError(http.StatusBadRequest, errMsg)
+		}
+		b.AllowStale = true
+		b.MaxStaleDuration = dur
+		return nil
+	}
 	if staleVal, ok := query["stale"]; ok {
 		if len(staleVal) == 0 || staleVal[0] == "" {
 			b.AllowStale = true
@@ -977,6 +998,14 @@ func parseIdempotencyToken(req *http.Request, n *string) {
 	}
 }
 
+// parseTraceParent is used to parse the W3C traceparent header, which is
+// dropped if invalid
+func parseTraceParent(req *http.Request, n *string) {
+	if traceParent := req.Header.Get(tracing.TraceParentHeader); traceParent != "" {
+		*n = tracing.Normalize(traceParent)
+	}
+}
+
 // parseBool parses a query parameter to a boolean or returns (nil, nil) if the
 // parameter is not present.
 func parseBool(req *http.Request, field string) (*bool, error) {
@@ -1048,6 +1077,7 @@ func (s *HTTPServer) parse(resp http.ResponseWriter, req *http.Request, r *strin
 	}
 	parseFilter(req, b)
 	parseReverse(req, b)
+	parseTraceParent(req, &b.TraceParent)
 	return parseWait(resp, req, b)
 }
 
@@ -1125,6 +1155,7 @@ func (s *HTTPServer) parseWriteRequest(req *http.Request, w *structs.WriteReques
 	s.parseToken(req, &w.AuthToken)
 	s.parseRegion(req, &w.Region)
 	parseIdempotencyToken(req, &w.IdempotencyToken)
+	parseTraceParent(req, &w.TraceParent)
 }
 
 // wrapUntrustedContent wraps handlers in a http.ResponseWriter that prevents
@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+)
+
+// supportedEncodings are the content encodings HTTP API responses may be
+// compressed with.
+var supportedEncodings = []string{encodingGzip, encodingZstd}
+
+// encoder is a compressing writer which can be reused for another response
+// once closed.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// encoderPools holds the reusable encoders of each supported encoding.
+var encoderPools = map[string]*sync.Pool{
+	encodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+	encodingZstd: {New: func() any {
+		// a single goroutine per encoder, as responses are compressed
+		// concurrently with each other
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	}},
+}
+
+// compressionHandler is an http.Handler which compresses the responses of the
+// wrapped handler for clients which accept one of the configured encodings.
+type compressionHandler struct {
+	handler   http.Handler
+	minSize   int
+	encodings []string
+}
+
+// newCompressionHandler returns handler wrapped to compress its responses as
+// configured by conf, or handler itself if compression is disabled.
+func newCompressionHandler(handler http.Handler, conf *HTTPAPIResponseCompression) http.Handler {
+	conf = DefaultHTTPAPIResponseCompression().Merge(conf)
+	if !*conf.Enabled || len(conf.Encodings) == 0 {
+		return handler
+	}
+	return &compressionHandler{
+		handler:   handler,
+		minSize:   *conf.MinSize,
+		encodings: conf.Encodings,
+	}
+}
+
+func (h *compressionHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodHead || req.Header.Get("Upgrade") != "" || isStreamingPath(req.URL.Path) {
+		h.handler.ServeHTTP(resp, req)
+		return
+	}
+
+	resp.Header().Add("Vary", "Accept-Encoding")
+	encoding := negotiateEncoding(req.Header.Values("Accept-Encoding"), h.encodings)
+	if encoding == "" {
+		h.handler.ServeHTTP(resp, req)
+		return
+	}
+
+	cw := &compressWriter{
+		ResponseWriter: resp,
+		encoding:       encoding,
+		minSize:        h.minSize,
+	}
+	defer cw.Close()
+	h.handler.ServeHTTP(cw, req)
+}
+
+// isStreamingPath returns whether path is an endpoint streaming its response,
+// which has its own framing and must reach the client as soon as it is
+// flushed.
+func isStreamingPath(path string) bool {
+	switch {
+	case path == "/v1/event/stream", path == "/v1/agent/monitor":
+		return true
+	case strings.HasPrefix(path, "/v1/client/fs/logs/"), strings.HasPrefix(path, "/v1/client/fs/stream/"):
+		return true
+	case strings.HasPrefix(path, "/v1/client/allocation/") && strings.HasSuffix(path, "/exec"):
+		return true
+	}
+	return false
+}
+
+// negotiateEncoding returns the encoding of encodings the client accepts with
+// the highest quality according to its Accept-Encoding header values, or the
+// empty string if it accepts none of them. Encodings the client accepts with
+// equal quality are preferred in their order in encodings.
+func negotiateEncoding(accept []string, encodings []string) string {
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, value := range accept {
+		for _, part := range strings.Split(value, ",") {
+			name, params, _ := strings.Cut(part, ";")
+			name = strings.ToLower(strings.TrimSpace(name))
+			q := 1.0
+			if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				parsed, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					continue
+				}
+				q = parsed
+			}
+			if name == "*" {
+				wildcard = q
+			} else if name != "" {
+				qualities[name] = q
+			}
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range encodings {
+		q, ok := qualities[encoding]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressWriter is an http.ResponseWriter which buffers the beginning of a
+// response until it is known to be at least minSize bytes long, and then
+// compresses it with encoding. Shorter responses are sent uncompressed when
+// the writer is closed or flushed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	// code is the status code written by the handler, which is deferred
+	// until the response is known to be compressed or not
+	code int
+
+	// buf is the beginning of the response, until decided
+	buf     []byte
+	decided bool
+
+	// enc compresses the response, or is nil if it is sent uncompressed
+	enc encoder
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// decide writes the deferred header and the buffered beginning of the
+// response, compressing it if compress is set and the handler has not
+// encoded the response itself.
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+
+	header := w.Header()
+	if compress && header.Get("Content-Encoding") == "" {
+		// the content type would otherwise be detected from compressed bytes
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", http.DetectContentType(w.buf))
+		}
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+
+		w.enc = encoderPools[w.encoding].Get().(encoder)
+		w.enc.Reset(w.ResponseWriter)
+	}
+
+	if w.code != 0 {
+		w.ResponseWriter.WriteHeader(w.code)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.enc != nil {
+		_, err := w.enc.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// Flush sends the response written so far to the client. A response which is
+// flushed before reaching minSize is sent uncompressed.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close completes the response and releases its encoder, if any.
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.enc == nil {
+		return nil
+	}
+
+	err := w.enc.Close()
+	w.enc.Reset(nil)
+	encoderPools[w.encoding].Put(w.enc)
+	w.enc = nil
+	return err
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for use by
+// http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
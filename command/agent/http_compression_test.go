@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/shoenig/test/must"
+)
+
+// decodeResponseBody returns the body of resp decoded according to its
+// Content-Encoding.
+func decodeResponseBody(t testing.TB, resp *http.Response) string {
+	var r io.Reader = resp.Body
+	switch resp.Header.Get("Content-Encoding") {
+	case encodingGzip:
+		gz, err := gzip.NewReader(resp.Body)
+		must.NoError(t, err)
+		r = gz
+	case encodingZstd:
+		zr, err := zstd.NewReader(resp.Body)
+		must.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	}
+	body, err := io.ReadAll(r)
+	must.NoError(t, err)
+	return string(body)
+}
+
+func TestHTTP_compressionHandler(t *testing.T) {
+	ci.Parallel(t)
+
+	large := strings.Repeat(`{"ID":"example"}`, 100)
+	handler := http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/small":
+			resp.Header().Set("Content-Type", "application/json")
+			_, _ = resp.Write([]byte(`{}`))
+		case "/v1/encoded":
+			resp.Header().Set("Content-Encoding", "br")
+			_, _ = resp.Write([]byte(large))
+		case "/v1/missing":
+			resp.WriteHeader(http.StatusNotFound)
+			_, _ = resp.Write([]byte(large))
+		default:
+			resp.Header().Set("Content-Type", "application/json")
+			resp.Header().Set("Content-Length", fmt.Sprint(len(large)))
+			// write in pieces smaller than the minimum size
+			for i := 0; i < len(large); i += 100 {
+				_, _ = resp.Write([]byte(large[i : i+100]))
+			}
+		}
+	})
+
+	conf := &HTTPAPIResponseCompression{
+		Encodings: []string{encodingGzip, encodingZstd},
+	}
+	srv := httptest.NewServer(newCompressionHandler(handler, conf))
+	defer srv.Close()
+
+	cases := []struct {
+		name     string
+		path     string
+		accept   string
+		encoding string
+		code     int
+		body     string
+	}{
+		{
+			name:     "gzip",
+			path:     "/v1/jobs",
+			accept:   "gzip",
+			encoding: encodingGzip,
+			body:     large,
+		},
+		{
+			name:     "zstd",
+			path:     "/v1/jobs",
+			accept:   "zstd",
+			encoding: encodingZstd,
+			body:     large,
+		},
+		{
+			name:     "configured preference",
+			path:     "/v1/jobs",
+			accept:   "zstd, gzip",
+			encoding: encodingGzip,
+			body:     large,
+		},
+		{
+			name:     "client preference",
+			path:     "/v1/jobs",
+			accept:   "gzip;q=0.5, zstd",
+			encoding: encodingZstd,
+			body:     large,
+		},
+		{
+			name: "not accepted",
+			path: "/v1/jobs",
+			body: large,
+		},
+		{
+			name:   "unsupported",
+			path:   "/v1/jobs",
+			accept: "br, gzip;q=0",
+			body:   large,
+		},
+		{
+			name:   "small",
+			path:   "/v1/small",
+			accept: "gzip",
+			body:   `{}`,
+		},
+		{
+			name:     "encoded by handler",
+			path:     "/v1/encoded",
+			accept:   "gzip",
+			encoding: "br",
+			body:     large,
+		},
+		{
+			name:     "status code",
+			path:     "/v1/missing",
+			accept:   "gzip",
+			encoding: encodingGzip,
+			code:     http.StatusNotFound,
+			body:     large,
+		},
+		{
+			name:   "streaming",
+			path:   "/v1/event/stream",
+			accept: "gzip",
+			body:   large,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+tc.path, nil)
+			must.NoError(t, err)
+			if tc.accept != "" {
+				req.Header.Set("Accept-Encoding", tc.accept)
+			}
+
+			// the transport does not decompress responses it did not ask to
+			// be compressed
+			resp, err := srv.Client().Do(req)
+			must.NoError(t, err)
+			defer resp.Body.Close()
+
+			code := tc.code
+			if code == 0 {
+				code = http.StatusOK
+			}
+			must.Eq(t, code, resp.StatusCode)
+			must.Eq(t, tc.encoding, resp.Header.Get("Content-Encoding"))
+			if tc.encoding != "br" {
+				must.Eq(t, tc.body, decodeResponseBody(t, resp))
+			}
+		})
+	}
+}
+
+func TestHTTP_compressionHandler_disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	_, ok := newCompressionHandler(handler, &HTTPAPIResponseCompression{
+		Enabled: pointer.Of(false),
+	}).(*compressionHandler)
+	must.False(t, ok)
+
+	// compression is enabled by default
+	_, ok = newCompressionHandler(handler, nil).(*compressionHandler)
+	must.True(t, ok)
+}
+
+func TestHTTP_compressWriter_Flush(t *testing.T) {
+	ci.Parallel(t)
+
+	rec := httptest.NewRecorder()
+	w := &compressWriter{ResponseWriter: rec, encoding: encodingGzip, minSize: 1024}
+
+	// a response flushed before reaching the minimum size is not compressed
+	_, err := w.Write([]byte("first"))
+	must.NoError(t, err)
+	must.False(t, rec.Flushed)
+	w.Flush()
+	must.True(t, rec.Flushed)
+	must.Eq(t, "first", rec.Body.String())
+
+	_, err = w.Write([]byte(" second"))
+	must.NoError(t, err)
+	must.NoError(t, w.Close())
+	must.Eq(t, "", rec.Header().Get("Content-Encoding"))
+	must.Eq(t, "first second", rec.Body.String())
+}
+
+func TestHTTP_negotiateEncoding(t *testing.T) {
+	ci.Parallel(t)
+
+	encodings := []string{encodingGzip, encodingZstd}
+	cases := []struct {
+		accept []string
+		exp    string
+	}{
+		{accept: nil, exp: ""},
+		{accept: []string{"identity"}, exp: ""},
+		{accept: []string{"gzip"}, exp: encodingGzip},
+		{accept: []string{"GZIP"}, exp: encodingGzip},
+		{accept: []string{"zstd"}, exp: encodingZstd},
+		{accept: []string{"zstd, gzip"}, exp: encodingGzip},
+		{accept: []string{"zstd", "gzip;q=0.9"}, exp: encodingZstd},
+		{accept: []string{"gzip;q=0, zstd;q=0.1"}, exp: encodingZstd},
+		{accept: []string{"*"}, exp: encodingGzip},
+		{accept: []string{"*;q=0.5, gzip;q=0.1"}, exp: encodingZstd},
+		{accept: []string{"*;q=0"}, exp: ""},
+		{accept: []string{"gzip;q=bogus"}, exp: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.accept, "|"), func(t *testing.T) {
+			must.Eq(t, tc.exp, negotiateEncoding(tc.accept, encodings))
+		})
+	}
+}
+
+func TestHTTP_isStreamingPath(t *testing.T) {
+	ci.Parallel(t)
+
+	for path, exp := range map[string]bool{
+		"/v1/event/stream":                      true,
+		"/v1/agent/monitor":                     true,
+		"/v1/client/fs/logs/a1b2":               true,
+		"/v1/client/fs/stream/a1b2":             true,
+		"/v1/client/allocation/a1b2/exec":       true,
+		"/v1/client/allocation/a1b2/stats":      false,
+		"/v1/client/fs/cat/a1b2":                false,
+		"/v1/agent/monitor/export":              false,
+		"/v1/jobs":                              false,
+		"/v1/job/exec":                          false,
+		"/v1/client/allocation/a1b2/exec/extra": false,
+	} {
+		must.Eq(t, exp, isStreamingPath(path), must.Sprint(path))
+	}
+}
+
+// BenchmarkHTTP_compressionHandler measures the cost of compressing a large
+// allocation list response with each encoding, against sending it
+// uncompressed. The compressed size is reported to show the tradeoff.
+func BenchmarkHTTP_compressionHandler(b *testing.B) {
+	stubs := make([]*structs.AllocListStub, 500)
+	for i := range stubs {
+		stubs[i] = mock.Alloc().Stub(nil)
+	}
+	var buf bytes.Buffer
+	must.NoError(b, codec.NewEncoder(&buf, structs.JsonHandleWithExtensions).Encode(stubs))
+	body := buf.Bytes()
+
+	handler := http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.Header().Set("Content-Type", "application/json")
+		_, _ = resp.Write(body)
+	})
+	compression := newCompressionHandler(handler, &HTTPAPIResponseCompression{
+		Encodings: supportedEncodings,
+	})
+
+	for _, encoding := range []string{"identity", encodingGzip, encodingZstd} {
+		b.Run(encoding, func(b *testing.B) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/allocations", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+
+			b.ReportAllocs()
+			b.SetBytes(int64(len(body)))
+			var size int
+			for i := 0; i < b.N; i++ {
+				rec := httptest.NewRecorder()
+				compression.ServeHTTP(rec, req)
+				size = rec.Body.Len()
+			}
+			b.ReportMetric(float64(size), "resp-bytes")
+		})
+	}
+}
@@ -508,6 +508,37 @@ func TestParseConsistency(t *testing.T) {
 	must.False(t, b.AllowStale)
 }
 
+func TestParseConsistency_MaxStale(t *testing.T) {
+	ci.Parallel(t)
+
+	b := structs.QueryOptions{}
+	req, err := http.NewRequest(http.MethodGet, "/v1/jobs?max_stale=5s", nil)
+	must.NoError(t, err)
+	resp := httptest.NewRecorder()
+	must.NoError(t, parseConsistency(resp, req, &b))
+	must.True(t, b.AllowStale)
+	must.Eq(t, 5*time.Second, b.MaxStaleDuration)
+
+	b = structs.QueryOptions{}
+	req, err = http.NewRequest(http.MethodGet, "/v1/jobs?stale=true&max_stale=500ms", nil)
+	must.NoError(t, err)
+	resp = httptest.NewRecorder()
+	must.NoError(t, parseConsistency(resp, req, &b))
+	must.True(t, b.AllowStale)
+	must.Eq(t, 500*time.Millisecond, b.MaxStaleDuration)
+
+	for _, maxStale := range []string{"5", "-5s", "soon"} {
+		b = structs.QueryOptions{}
+		req, err = http.NewRequest(http.MethodGet, "/v1/jobs?max_stale="+maxStale, nil)
+		must.NoError(t, err)
+		resp = httptest.NewRecorder()
+		must.Error(t, parseConsistency(resp, req, &b))
+		must.False(t, b.AllowStale)
+		must.EqOp(t, 400, resp.Code)
+		must.EqOp(t, "Invalid max_stale duration", resp.Body.String())
+	}
+}
+
 func TestParseRegion(t *testing.T) {
 	ci.Parallel(t)
 	s := makeHTTPServer(t, nil)
@@ -586,6 +617,47 @@ func TestParseToken(t *testing.T) {
 	}
 }
 
+func TestParseTraceParent(t *testing.T) {
+	ci.Parallel(t)
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	cases := []struct {
+		Name     string
+		Header   string
+		Expected string
+	}{
+		{
+			Name:     "Parses traceparent header",
+			Header:   traceParent,
+			Expected: traceParent,
+		},
+		{
+			Name:     "Ignores invalid traceparent header",
+			Header:   "00-foo-bar-01",
+			Expected: "",
+		},
+		{
+			Name:     "Ignores missing traceparent header",
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPut, "/v1/jobs", nil)
+			must.NoError(t, err)
+			if tc.Header != "" {
+				req.Header.Set("traceparent", tc.Header)
+			}
+
+			var parsed string
+			parseTraceParent(req, &parsed)
+			must.Eq(t, tc.Expected, parsed)
+		})
+	}
+}
+
 func TestParseBool(t *testing.T) {
 	ci.Parallel(t)
 
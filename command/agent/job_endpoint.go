@@ -9,6 +9,7 @@ import (
 	"maps"
 	"net/http"
 	"net/url"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
@@ -54,6 +55,11 @@ func (s *HTTPServer) jobListRequest(resp http.ResponseWriter, req *http.Request)
 		args.Fields.Meta = *jobMeta
 	}
 
+	projection, err := parseFields(req, reflect.TypeOf(structs.JobListStub{}))
+	if err != nil {
+		return nil, err
+	}
+
 	var out structs.JobListResponse
 	if err := s.agent.RPC("Job.List", &args, &out); err != nil {
 		return nil, err
@@ -63,7 +69,7 @@ func (s *HTTPServer) jobListRequest(resp http.ResponseWriter, req *http.Request)
 	if out.Jobs == nil {
 		out.Jobs = make([]*structs.JobListStub, 0)
 	}
-	return out.Jobs, nil
+	return projectFields(out.Jobs, projection)
 }
 
 func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -81,6 +87,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/periodic/force"):
 		jobID := strings.TrimSuffix(path, "/periodic/force")
 		return s.periodicForceRequest(resp, req, jobID)
+	case strings.HasSuffix(path, "/periodic/status"):
+		jobID := strings.TrimSuffix(path, "/periodic/status")
+		return s.periodicStatusRequest(resp, req, jobID)
 	case strings.HasSuffix(path, "/plan"):
 		jobID := strings.TrimSuffix(path, "/plan")
 		return s.jobPlan(resp, req, jobID)
@@ -257,6 +266,27 @@ func (s *HTTPServer) periodicForceRequest(resp http.ResponseWriter, req *http.Re
 	return out, nil
 }
 
+func (s *HTTPServer) periodicStatusRequest(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.PeriodicStatusRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.PeriodicStatusResponse
+	if err := s.agent.RPC("Periodic.Status", &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+	return out, nil
+}
+
 func (s *HTTPServer) jobAllocations(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodGet {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -746,6 +776,7 @@ func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
 		Message:        args.Message,
 		Error:          args.Error,
 		Meta:           args.Meta,
+		Counts:         args.Counts,
 		JobModifyIndex: args.JobModifyIndex,
 	}
 	// parseWriteRequest overrides Namespace, Region and AuthToken
@@ -1153,6 +1184,8 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		Affinities:     ApiAffinitiesToStructs(job.Affinities),
 		UI:             ApiJobUIConfigToStructs(job.UI),
 		VersionTag:     ApiJobVersionTagToStructs(job.VersionTag),
+
+		VersionRetention: *job.VersionRetention,
 	}
 
 	// Update has been pushed into the task groups. stagger and max_parallel are
@@ -1191,6 +1224,17 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		if job.Periodic.Specs != nil {
 			j.Periodic.Specs = job.Periodic.Specs
 		}
+
+		j.Periodic.Exclusions = job.Periodic.Exclusions
+		if job.Periodic.ExclusionCalendar != nil {
+			j.Periodic.ExclusionCalendar = *job.Periodic.ExclusionCalendar
+		}
+		if job.Periodic.MissedLaunchPolicy != nil {
+			j.Periodic.MissedLaunchPolicy = *job.Periodic.MissedLaunchPolicy
+		}
+		if job.Periodic.MaxMissedLaunches != nil {
+			j.Periodic.MaxMissedLaunches = *job.Periodic.MaxMissedLaunches
+		}
 	}
 
 	if job.ParameterizedJob != nil {
@@ -1234,6 +1278,9 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 	tg.Name = *taskGroup.Name
 	tg.Count = *taskGroup.Count
+	if taskGroup.ReplicatePer != nil {
+		tg.ReplicatePer = *taskGroup.ReplicatePer
+	}
 	tg.Meta = taskGroup.Meta
 	tg.Constraints = ApiConstraintsToStructs(taskGroup.Constraints)
 	tg.Affinities = ApiAffinitiesToStructs(taskGroup.Affinities)
@@ -1253,6 +1300,18 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.ShutdownDelay = taskGroup.ShutdownDelay
 	}
 
+	if taskGroup.MaxRunTime != nil {
+		tg.MaxRunTime = taskGroup.MaxRunTime
+	}
+
+	if taskGroup.OnTimeout != nil {
+		tg.OnTimeout = *taskGroup.OnTimeout
+	}
+
+	if taskGroup.AdvertiseMode != nil {
+		tg.AdvertiseMode = *taskGroup.AdvertiseMode
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -1262,6 +1321,9 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			MaxDelay:      *taskGroup.ReschedulePolicy.MaxDelay,
 			Unlimited:     *taskGroup.ReschedulePolicy.Unlimited,
 		}
+		if taskGroup.ReschedulePolicy.Jitter != nil {
+			tg.ReschedulePolicy.Jitter = *taskGroup.ReschedulePolicy.Jitter
+		}
 	}
 
 	if taskGroup.Disconnect != nil {
@@ -1298,6 +1360,9 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		SizeMB:  *taskGroup.EphemeralDisk.SizeMB,
 		Migrate: *taskGroup.EphemeralDisk.Migrate,
 	}
+	if taskGroup.EphemeralDisk.Class != nil {
+		tg.EphemeralDisk.Class = *taskGroup.EphemeralDisk.Class
+	}
 
 	if len(taskGroup.Spreads) > 0 {
 		tg.Spreads = []*structs.Spread{}
@@ -1443,27 +1508,43 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 		for _, ta := range apiTask.Artifacts {
 			structsTask.Artifacts = append(structsTask.Artifacts,
 				&structs.TaskArtifact{
-					GetterSource:   *ta.GetterSource,
-					GetterOptions:  maps.Clone(ta.GetterOptions),
-					GetterHeaders:  maps.Clone(ta.GetterHeaders),
-					GetterMode:     *ta.GetterMode,
-					GetterInsecure: *ta.GetterInsecure,
-					RelativeDest:   *ta.RelativeDest,
-					Chown:          ta.Chown,
+					GetterSource:      *ta.GetterSource,
+					GetterOptions:     maps.Clone(ta.GetterOptions),
+					GetterHeaders:     maps.Clone(ta.GetterHeaders),
+					GetterMode:        *ta.GetterMode,
+					GetterInsecure:    *ta.GetterInsecure,
+					GetterCertPin:     ta.GetterCertPin,
+					GetterProxy:       ta.GetterProxy,
+					GetterKeepArchive: ta.GetterKeepArchive,
+					GetterVaultPKI:    apiArtifactVaultPKIToStructs(ta.GetterVaultPKI),
+					GetterVaultAWS:    apiArtifactVaultAWSToStructs(ta.GetterVaultAWS),
+					GetterPreAuth:     apiArtifactPreAuthToStructs(ta.GetterPreAuth),
+					GetterPostCmd:     apiArtifactPostCmdToStructs(ta.GetterPostCmd),
+					RelativeDest:      *ta.RelativeDest,
+					Chown:             ta.Chown,
+					GetterChownMode:   ta.GetterChownMode,
+					Owner:             ta.Owner,
+					Group:             ta.Group,
+					GetterExisting:    ta.GetterExisting,
+					GetterOptional:    ta.GetterOptional,
+					GetterOverlay:     ta.GetterOverlay,
+
+					GetterExpectContentType: ta.GetterExpectContentType,
+					GetterMaxParallel:       ta.GetterMaxParallel,
+					GetterFilename:          ta.GetterFilename,
+					GetterArchive:           ta.GetterArchive,
 				})
 		}
 	}
 
 	if apiTask.Vault != nil {
-		structsTask.Vault = &structs.Vault{
-			Role:                 apiTask.Vault.Role,
-			Namespace:            *apiTask.Vault.Namespace,
-			Cluster:              apiTask.Vault.Cluster,
-			Env:                  *apiTask.Vault.Env,
-			DisableFile:          *apiTask.Vault.DisableFile,
-			ChangeMode:           *apiTask.Vault.ChangeMode,
-			ChangeSignal:         *apiTask.Vault.ChangeSignal,
-			AllowTokenExpiration: *apiTask.Vault.AllowTokenExpiration,
+		structsTask.Vault = apiVaultToStructs(apiTask.Vault)
+	}
+
+	if len(apiTask.Vaults) > 0 {
+		structsTask.Vaults = make([]*structs.Vault, 0, len(apiTask.Vaults))
+		for _, v := range apiTask.Vaults {
+			structsTask.Vaults = append(structsTask.Vaults, apiVaultToStructs(v))
 		}
 	}
 
@@ -1506,6 +1587,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 					VaultGrace:    *template.VaultGrace,
 					Wait:          apiWaitConfigToStructsWaitConfig(template.Wait),
 					ErrMissingKey: *template.ErrMissingKey,
+					VaultCluster:  *template.VaultCluster,
 				})
 		}
 	}
@@ -1516,6 +1598,33 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 		}
 	}
 
+	if apiTask.NodePayload != nil {
+		structsTask.NodePayload = &structs.NodePayloadConfig{
+			MetaKeys: slices.Clone(apiTask.NodePayload.MetaKeys),
+			AttrKeys: slices.Clone(apiTask.NodePayload.AttrKeys),
+			Strict:   apiTask.NodePayload.Strict,
+		}
+	}
+
+	if apiTask.ServiceDiscovery != nil {
+		structsTask.ServiceDiscovery = &structs.ServiceDiscovery{
+			HostsFile: apiTask.ServiceDiscovery.HostsFile,
+		}
+		for _, u := range apiTask.ServiceDiscovery.Upstreams {
+			structsTask.ServiceDiscovery.Upstreams = append(structsTask.ServiceDiscovery.Upstreams,
+				&structs.ServiceUpstream{
+					Name:           u.Name,
+					Service:        u.Service,
+					Env:            u.Env,
+					Strategy:       *u.Strategy,
+					WaitForHealthy: u.WaitForHealthy,
+					WaitTimeout:    *u.WaitTimeout,
+					ChangeMode:     *u.ChangeMode,
+					ChangeSignal:   u.ChangeSignal,
+				})
+		}
+	}
+
 	if apiTask.Lifecycle != nil {
 		structsTask.Lifecycle = &structs.TaskLifecycleConfig{
 			Hook:    apiTask.Lifecycle.Hook,
@@ -1658,11 +1767,12 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 	out = make([]*structs.NetworkResource, len(in))
 	for i, nw := range in {
 		out[i] = &structs.NetworkResource{
-			Mode:     nw.Mode,
-			CIDR:     nw.CIDR,
-			IP:       nw.IP,
-			Hostname: nw.Hostname,
-			MBits:    nw.Megabits(),
+			Mode:       nw.Mode,
+			CIDR:       nw.CIDR,
+			IP:         nw.IP,
+			Hostname:   nw.Hostname,
+			MBits:      nw.Megabits(),
+			PreferIPv6: nw.PreferIPv6,
 		}
 
 		if nw.DNS != nil {
@@ -1788,7 +1898,10 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 			out[i].Identity = apiWorkloadIdentityToStructs(s.Identity)
 		}
 
+		out[i].Consul = apiConsulToStructs(s.Consul)
+
 		out[i].Weights = apiWorkloadWeightsToStructs(s.Weights)
+		out[i].CanaryWeights = apiWorkloadWeightsToStructs(s.CanaryWeights)
 
 	}
 
@@ -2069,11 +2182,26 @@ func apiUpstreamsToStructs(in []*api.ConsulUpstream) []structs.ConsulUpstream {
 			LocalBindAddress:     upstream.LocalBindAddress,
 			MeshGateway:          apiMeshGatewayToStructs(upstream.MeshGateway),
 			Config:               maps.Clone(upstream.Config),
+			PassiveHealthCheck:   apiPassiveHealthCheckToStructs(upstream.PassiveHealthCheck),
+		}
+		if upstream.ConnectTimeout != nil {
+			upstreams[i].ConnectTimeout = *upstream.ConnectTimeout
 		}
 	}
 	return upstreams
 }
 
+func apiPassiveHealthCheckToStructs(in *api.ConsulPassiveHealthCheck) structs.ConsulPassiveHealthCheck {
+	var phc structs.ConsulPassiveHealthCheck
+	if in != nil {
+		phc.MaxFailures = in.MaxFailures
+		if in.Interval != nil {
+			phc.Interval = *in.Interval
+		}
+	}
+	return phc
+}
+
 func apiMeshGatewayToStructs(in *api.ConsulMeshGateway) structs.ConsulMeshGateway {
 	var gw structs.ConsulMeshGateway
 	if in != nil {
@@ -2188,6 +2316,68 @@ func apiVolumeMountsToStructs(in []*api.VolumeMount) []*structs.VolumeMount {
 	return out
 }
 
+func apiArtifactVaultPKIToStructs(in *api.ArtifactVaultPKI) *structs.ArtifactVaultPKI {
+	if in == nil {
+		return nil
+	}
+	out := &structs.ArtifactVaultPKI{
+		Path:       in.Path,
+		CommonName: in.CommonName,
+	}
+	if in.TTL != nil {
+		out.TTL = *in.TTL
+	}
+	return out
+}
+
+func apiArtifactVaultAWSToStructs(in *api.ArtifactVaultAWS) *structs.ArtifactVaultAWS {
+	if in == nil {
+		return nil
+	}
+	out := &structs.ArtifactVaultAWS{
+		Path: in.Path,
+	}
+	if in.TTL != nil {
+		out.TTL = *in.TTL
+	}
+	return out
+}
+
+func apiArtifactPreAuthToStructs(in *api.ArtifactPreAuth) *structs.ArtifactPreAuth {
+	if in == nil {
+		return nil
+	}
+	return &structs.ArtifactPreAuth{
+		URL:             in.URL,
+		Method:          in.Method,
+		CredentialsFile: in.CredentialsFile,
+		ContentType:     in.ContentType,
+	}
+}
+
+func apiArtifactPostCmdToStructs(in *api.ArtifactPostCmd) *structs.ArtifactPostCmd {
+	if in == nil {
+		return nil
+	}
+	return &structs.ArtifactPostCmd{
+		Command: in.Command,
+		Args:    slices.Clone(in.Args),
+	}
+}
+
+func apiVaultToStructs(in *api.Vault) *structs.Vault {
+	return &structs.Vault{
+		Role:                 in.Role,
+		Namespace:            *in.Namespace,
+		Cluster:              in.Cluster,
+		Env:                  *in.Env,
+		DisableFile:          *in.DisableFile,
+		ChangeMode:           *in.ChangeMode,
+		ChangeSignal:         *in.ChangeSignal,
+		AllowTokenExpiration: *in.AllowTokenExpiration,
+	}
+}
+
 func apiConsulToStructs(in *api.Consul) *structs.Consul {
 	if in == nil {
 		return nil
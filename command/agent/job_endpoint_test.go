@@ -2746,6 +2746,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        pointer.Of("cron"),
 			ProhibitOverlap: pointer.Of(true),
 			TimeZone:        pointer.Of("test zone"),
+
+			Exclusions:         []string{"*-12-25"},
+			ExclusionCalendar:  pointer.Of("nomad/jobs/holidays"),
+			MissedLaunchPolicy: pointer.Of("run_all"),
+			MaxMissedLaunches:  pointer.Of(5),
 		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
 			Payload:      "payload",
@@ -2826,6 +2831,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					SizeMB:  pointer.Of(100),
 					Sticky:  pointer.Of(true),
 					Migrate: pointer.Of(true),
+					Class:   pointer.Of("fast"),
 				},
 				Update: &api.UpdateStrategy{
 					HealthCheck:      pointer.Of(structs.UpdateStrategyHealthCheck_Checks),
@@ -2974,6 +2980,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Passing: 7,
 									Warning: 2,
 								},
+								CanaryWeights: &api.ServiceWeights{
+									Passing: 1,
+									Warning: 1,
+								},
 								CheckRestart: &api.CheckRestart{
 									Limit: 4,
 									Grace: pointer.Of(11 * time.Second),
@@ -3076,9 +3086,27 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								GetterOptions: map[string]string{
 									"a": "b",
 								},
-								GetterMode:   pointer.Of("dir"),
-								RelativeDest: pointer.Of("dest"),
-								Chown:        true,
+								GetterMode:    pointer.Of("dir"),
+								GetterCertPin: "sha256:abc",
+								GetterProxy:   "socks5://bastion:1080",
+								GetterVaultAWS: &api.ArtifactVaultAWS{
+									Path: "aws/sts/artifacts",
+									TTL:  pointer.Of(15 * time.Minute),
+								},
+								GetterPreAuth: &api.ArtifactPreAuth{
+									URL:             "https://example.com/login",
+									CredentialsFile: "secrets/login",
+								},
+								GetterPostCmd: &api.ArtifactPostCmd{
+									Command: "chmod",
+									Args:    []string{"+x", "app"},
+								},
+								RelativeDest:    pointer.Of("dest"),
+								Chown:           true,
+								GetterChownMode: "top",
+								Owner:           "app",
+								Group:           "1000",
+								GetterExisting:  "skip",
 							},
 						},
 						Vault: &api.Vault{
@@ -3183,6 +3211,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        "cron",
 			ProhibitOverlap: true,
 			TimeZone:        "test zone",
+
+			Exclusions:         []string{"*-12-25"},
+			ExclusionCalendar:  "nomad/jobs/holidays",
+			MissedLaunchPolicy: "run_all",
+			MaxMissedLaunches:  5,
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
 			Payload:      "payload",
@@ -3263,6 +3296,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					SizeMB:  100,
 					Sticky:  true,
 					Migrate: true,
+					Class:   "fast",
 				},
 				Update: &structs.UpdateStrategy{
 					Stagger:          1 * time.Second,
@@ -3423,6 +3457,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Passing: 7,
 									Warning: 2,
 								},
+								CanaryWeights: &structs.ServiceWeights{
+									Passing: 1,
+									Warning: 1,
+								},
 								OnUpdate: structs.OnUpdateRequireHealthy,
 								Checks: []*structs.ServiceCheck{
 									{
@@ -3529,9 +3567,27 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								GetterOptions: map[string]string{
 									"a": "b",
 								},
-								GetterMode:   "dir",
-								RelativeDest: "dest",
-								Chown:        true,
+								GetterMode:    "dir",
+								GetterCertPin: "sha256:abc",
+								GetterProxy:   "socks5://bastion:1080",
+								GetterVaultAWS: &structs.ArtifactVaultAWS{
+									Path: "aws/sts/artifacts",
+									TTL:  15 * time.Minute,
+								},
+								GetterPreAuth: &structs.ArtifactPreAuth{
+									URL:             "https://example.com/login",
+									CredentialsFile: "secrets/login",
+								},
+								GetterPostCmd: &structs.ArtifactPostCmd{
+									Command: "chmod",
+									Args:    []string{"+x", "app"},
+								},
+								RelativeDest:    "dest",
+								Chown:           true,
+								GetterChownMode: "top",
+								Owner:           "app",
+								Group:           "1000",
+								GetterExisting:  "skip",
 							},
 						},
 						Vault: &structs.Vault{
@@ -4272,6 +4328,11 @@ func TestConversion_apiUpstreamsToStructs(t *testing.T) {
 		Datacenter:           "dc2",
 		LocalBindAddress:     "127.0.0.2",
 		MeshGateway:          structs.ConsulMeshGateway{Mode: "local"},
+		ConnectTimeout:       5 * time.Second,
+		PassiveHealthCheck: structs.ConsulPassiveHealthCheck{
+			MaxFailures: 3,
+			Interval:    10 * time.Second,
+		},
 	}}, apiUpstreamsToStructs([]*api.ConsulUpstream{{
 		DestinationName:      "upstream",
 		DestinationNamespace: "ns2",
@@ -4284,9 +4345,21 @@ func TestConversion_apiUpstreamsToStructs(t *testing.T) {
 		Datacenter:           "dc2",
 		LocalBindAddress:     "127.0.0.2",
 		MeshGateway:          &api.ConsulMeshGateway{Mode: "local"},
+		ConnectTimeout:       pointer.Of(5 * time.Second),
+		PassiveHealthCheck: &api.ConsulPassiveHealthCheck{
+			MaxFailures: 3,
+			Interval:    pointer.Of(10 * time.Second),
+		},
 	}}))
 }
 
+func TestConversion_apiPassiveHealthCheckToStructs(t *testing.T) {
+	ci.Parallel(t)
+	require.Equal(t, structs.ConsulPassiveHealthCheck{}, apiPassiveHealthCheckToStructs(nil))
+	require.Equal(t, structs.ConsulPassiveHealthCheck{MaxFailures: 5},
+		apiPassiveHealthCheckToStructs(&api.ConsulPassiveHealthCheck{MaxFailures: 5}))
+}
+
 func TestConversion_apiConsulMeshGatewayToStructs(t *testing.T) {
 	ci.Parallel(t)
 	require.Equal(t, structs.ConsulMeshGateway{}, apiMeshGatewayToStructs(nil))
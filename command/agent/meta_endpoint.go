@@ -51,6 +51,7 @@ func (s *HTTPServer) nodeMetaRead(resp http.ResponseWriter, req *http.Request) (
 		return nil, rpcErr
 	}
 
+	setIndex(resp, reply.ModifyIndex)
 	return reply, nil
 }
 
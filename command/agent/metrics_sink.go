@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"sync"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+)
+
+// reloadableSink is the metrics sink of the agent. It fans metrics out to the
+// in-memory sink backing the metrics API and to the sinks configured in the
+// telemetry block, which are replaced when the agent configuration is
+// reloaded.
+type reloadableSink struct {
+	inmem *metrics.InmemSink
+
+	sinks metrics.FanoutSink
+	lock  sync.RWMutex
+}
+
+func newReloadableSink(inmem *metrics.InmemSink, sinks metrics.FanoutSink) *reloadableSink {
+	return &reloadableSink{
+		inmem: inmem,
+		sinks: sinks,
+	}
+}
+
+// swap replaces the configured sinks and returns the previous ones. No metric
+// is sent to the previous sinks once swap returns, so they can be shut down.
+func (s *reloadableSink) swap(sinks metrics.FanoutSink) metrics.FanoutSink {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	previous := s.sinks
+	s.sinks = sinks
+	return previous
+}
+
+func (s *reloadableSink) SetGauge(key []string, val float32) {
+	s.inmem.SetGauge(key, val)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.sinks.SetGauge(key, val)
+}
+
+func (s *reloadableSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.inmem.SetGaugeWithLabels(key, val, labels)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.sinks.SetGaugeWithLabels(key, val, labels)
+}
+
+func (s *reloadableSink) EmitKey(key []string, val float32) {
+	s.inmem.EmitKey(key, val)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.sinks.EmitKey(key, val)
+}
+
+func (s *reloadableSink) IncrCounter(key []string, val float32) {
+	s.inmem.IncrCounter(key, val)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.sinks.IncrCounter(key, val)
+}
+
+func (s *reloadableSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.inmem.IncrCounterWithLabels(key, val, labels)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.sinks.IncrCounterWithLabels(key, val, labels)
+}
+
+func (s *reloadableSink) AddSample(key []string, val float32) {
+	s.inmem.AddSample(key, val)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.sinks.AddSample(key, val)
+}
+
+func (s *reloadableSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.inmem.AddSampleWithLabels(key, val, labels)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.sinks.AddSampleWithLabels(key, val, labels)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestReloadableSink_swap(t *testing.T) {
+	ci.Parallel(t)
+
+	counter := func(sink *metrics.InmemSink) float64 {
+		data := sink.Data()
+		must.SliceLen(t, 1, data)
+		return data[0].Counters["nomad.test"].Sum
+	}
+
+	inmem := metrics.NewInmemSink(time.Minute, time.Minute)
+	before := metrics.NewInmemSink(time.Minute, time.Minute)
+	after := metrics.NewInmemSink(time.Minute, time.Minute)
+
+	sink := newReloadableSink(inmem, metrics.FanoutSink{before})
+	sink.IncrCounter([]string{"nomad", "test"}, 1)
+
+	previous := sink.swap(metrics.FanoutSink{after})
+	must.Eq(t, metrics.FanoutSink{before}, previous)
+	sink.IncrCounter([]string{"nomad", "test"}, 2)
+
+	must.Eq(t, 3, counter(inmem))
+	must.Eq(t, 1, counter(before))
+	must.Eq(t, 2, counter(after))
+
+	// the in-memory sink keeps receiving metrics without other sinks
+	sink.swap(nil)
+	sink.IncrCounter([]string{"nomad", "test"}, 4)
+	must.Eq(t, 7, counter(inmem))
+}
@@ -6,6 +6,7 @@ package agent
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -29,6 +30,11 @@ func (s *HTTPServer) NodesRequest(resp http.ResponseWriter, req *http.Request) (
 	}
 	args.Fields = fields
 
+	projection, err := parseFields(req, reflect.TypeOf(structs.NodeListStub{}))
+	if err != nil {
+		return nil, err
+	}
+
 	var out structs.NodeListResponse
 	if err := s.agent.RPC("Node.List", &args, &out); err != nil {
 		return nil, err
@@ -39,7 +45,7 @@ func (s *HTTPServer) NodesRequest(resp http.ResponseWriter, req *http.Request) (
 		out.Nodes = make([]*structs.NodeListStub, 0)
 	}
 
-	return out.Nodes, nil
+	return projectFields(out.Nodes, projection)
 }
 
 func (s *HTTPServer) NodeSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -30,6 +30,8 @@ func (s *HTTPServer) OperatorRequest(resp http.ResponseWriter, req *http.Request
 		return s.OperatorRaftConfiguration(resp, req)
 	case strings.HasPrefix(path, "peer"):
 		return s.OperatorRaftPeer(resp, req)
+	case strings.HasPrefix(path, "status"):
+		return s.OperatorRaftStatus(resp, req)
 	case strings.HasPrefix(path, "transfer-leadership"):
 		return s.OperatorRaftTransferLeadership(resp, req)
 	default:
@@ -58,6 +60,27 @@ func (s *HTTPServer) OperatorRaftConfiguration(resp http.ResponseWriter, req *ht
 	return reply, nil
 }
 
+// OperatorRaftStatus is used to inspect the replication status of the servers
+// in the Raft configuration. Only the leader tracks it, so this does not
+// support the stale query mode.
+func (s *HTTPServer) OperatorRaftStatus(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.RaftStatusResponse
+	if err := s.agent.RPC("Operator.RaftGetStatus", &args, &reply); err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
 // OperatorRaftPeer supports actions on Raft peers.
 func (s *HTTPServer) OperatorRaftPeer(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if req.Method != http.MethodDelete {
@@ -160,6 +183,9 @@ func (s *HTTPServer) OperatorAutopilotConfiguration(resp http.ResponseWriter, re
 			MaxTrailingLogs:         reply.MaxTrailingLogs,
 			MinQuorum:               reply.MinQuorum,
 			ServerStabilizationTime: reply.ServerStabilizationTime,
+			MinDiskFree:             reply.MinDiskFree,
+			MaxLastLogAge:           reply.MaxLastLogAge,
+			MaxAppliedIndexLag:      reply.MaxAppliedIndexLag,
 			EnableRedundancyZones:   reply.EnableRedundancyZones,
 			DisableUpgradeMigration: reply.DisableUpgradeMigration,
 			EnableCustomUpgrades:    reply.EnableCustomUpgrades,
@@ -184,6 +210,9 @@ func (s *HTTPServer) OperatorAutopilotConfiguration(resp http.ResponseWriter, re
 			MaxTrailingLogs:         conf.MaxTrailingLogs,
 			MinQuorum:               conf.MinQuorum,
 			ServerStabilizationTime: conf.ServerStabilizationTime,
+			MinDiskFree:             conf.MinDiskFree,
+			MaxLastLogAge:           conf.MaxLastLogAge,
+			MaxAppliedIndexLag:      conf.MaxAppliedIndexLag,
 			EnableRedundancyZones:   conf.EnableRedundancyZones,
 			DisableUpgradeMigration: conf.DisableUpgradeMigration,
 			EnableCustomUpgrades:    conf.EnableCustomUpgrades,
@@ -245,18 +274,19 @@ func (s *HTTPServer) OperatorServerHealth(resp http.ResponseWriter, req *http.Re
 	}
 	for _, server := range reply.Servers {
 		out.Servers = append(out.Servers, api.ServerHealth{
-			ID:          server.ID,
-			Name:        server.Name,
-			Address:     server.Address,
-			Version:     server.Version,
-			Leader:      server.Leader,
-			SerfStatus:  server.SerfStatus.String(),
-			LastContact: server.LastContact,
-			LastTerm:    server.LastTerm,
-			LastIndex:   server.LastIndex,
-			Healthy:     server.Healthy,
-			Voter:       server.Voter,
-			StableSince: server.StableSince.Round(time.Second).UTC(),
+			ID:           server.ID,
+			Name:         server.Name,
+			Address:      server.Address,
+			Version:      server.Version,
+			Leader:       server.Leader,
+			SerfStatus:   server.SerfStatus.String(),
+			LastContact:  server.LastContact,
+			LastTerm:     server.LastTerm,
+			LastIndex:    server.LastIndex,
+			Healthy:      server.Healthy,
+			Voter:        server.Voter,
+			StableSince:  server.StableSince.Round(time.Second).UTC(),
+			FailedChecks: server.FailedChecks,
 		})
 	}
 
@@ -342,6 +372,111 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	return reply, nil
 }
 
+// OperatorMaintenanceWindowsRequest is used to list the maintenance windows.
+func (s *HTTPServer) OperatorMaintenanceWindowsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.MaintenanceWindowListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.MaintenanceWindowListResponse
+	if err := s.agent.RPC("Operator.MaintenanceWindowList", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.MaintenanceWindows == nil {
+		out.MaintenanceWindows = make([]*structs.MaintenanceWindow, 0)
+	}
+	return out.MaintenanceWindows, nil
+}
+
+// OperatorMaintenanceWindowRequest is used to read, register, and delete a
+// maintenance window.
+func (s *HTTPServer) OperatorMaintenanceWindowRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	name := strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, "/v1/operator/maintenance-window"), "/")
+
+	switch req.Method {
+	case http.MethodGet:
+		if name == "" {
+			return nil, CodedError(http.StatusBadRequest, "missing maintenance window name")
+		}
+		return s.maintenanceWindowQuery(resp, req, name)
+	case http.MethodPut, http.MethodPost:
+		return s.maintenanceWindowUpsert(resp, req, name)
+	case http.MethodDelete:
+		if name == "" {
+			return nil, CodedError(http.StatusBadRequest, "missing maintenance window name")
+		}
+		return s.maintenanceWindowDelete(resp, req, name)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) maintenanceWindowQuery(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
+	args := structs.MaintenanceWindowSpecificRequest{
+		Name: name,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleMaintenanceWindowResponse
+	if err := s.agent.RPC("Operator.MaintenanceWindowGet", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.MaintenanceWindow == nil {
+		return nil, CodedError(http.StatusNotFound, "maintenance window not found")
+	}
+	return out.MaintenanceWindow, nil
+}
+
+func (s *HTTPServer) maintenanceWindowUpsert(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
+	var window structs.MaintenanceWindow
+	if err := decodeBody(req, &window); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	if name != "" && window.Name != name {
+		return nil, CodedError(http.StatusBadRequest, "Maintenance window name does not match request path")
+	}
+
+	args := structs.MaintenanceWindowUpsertRequest{
+		MaintenanceWindows: []*structs.MaintenanceWindow{&window},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Operator.MaintenanceWindowUpsert", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) maintenanceWindowDelete(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
+	args := structs.MaintenanceWindowDeleteRequest{
+		Names: []string{name},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Operator.MaintenanceWindowDelete", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
 func (s *HTTPServer) SnapshotRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case http.MethodGet:
@@ -57,6 +57,30 @@ func TestHTTP_OperatorRaftConfiguration(t *testing.T) {
 	})
 }
 
+func TestHTTP_OperatorRaftStatus(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/operator/raft/status", nil)
+		must.NoError(t, err)
+
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorRequest(resp, req)
+		must.NoError(t, err)
+		must.Eq(t, http.StatusOK, resp.Code)
+
+		out, ok := obj.(structs.RaftStatusResponse)
+		must.True(t, ok, must.Sprintf("unexpected: %T", obj))
+		must.Len(t, 1, out.Servers)
+		must.True(t, out.Servers[0].Leader)
+		must.Eq(t, structs.RaftPeerStateLeader, out.Servers[0].State)
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/operator/raft/status", nil)
+		must.NoError(t, err)
+		_, err = s.Server.OperatorRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+	})
+}
+
 func TestHTTP_OperatorRaftPeer(t *testing.T) {
 	ci.Parallel(t)
 
@@ -606,6 +630,59 @@ func TestOperator_SchedulerCASConfiguration(t *testing.T) {
 	})
 }
 
+func TestOperator_MaintenanceWindowRequests(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		window := mock.MaintenanceWindow()
+
+		// Register the maintenance window.
+		req, err := http.NewRequest(http.MethodPut, "/v1/operator/maintenance-window", encodeReq(window))
+		must.NoError(t, err)
+		resp := httptest.NewRecorder()
+		_, err = s.Server.OperatorMaintenanceWindowRequest(resp, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", resp.Header().Get("X-Nomad-Index"))
+
+		// The name in the body must match the request path.
+		req, err = http.NewRequest(http.MethodPut, "/v1/operator/maintenance-window/other", encodeReq(window))
+		must.NoError(t, err)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorMaintenanceWindowRequest(resp, req)
+		must.ErrorContains(t, err, "does not match request path")
+
+		// Read it back.
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/maintenance-window/"+window.Name, nil)
+		must.NoError(t, err)
+		resp = httptest.NewRecorder()
+		obj, err := s.Server.OperatorMaintenanceWindowRequest(resp, req)
+		must.NoError(t, err)
+		got := obj.(*structs.MaintenanceWindow)
+		must.Eq(t, window.Grace, got.Grace)
+		must.Eq(t, window.Datacenter, got.Datacenter)
+
+		// List the maintenance windows.
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/maintenance-windows", nil)
+		must.NoError(t, err)
+		resp = httptest.NewRecorder()
+		obj, err = s.Server.OperatorMaintenanceWindowsRequest(resp, req)
+		must.NoError(t, err)
+		must.Len(t, 1, obj.([]*structs.MaintenanceWindow))
+
+		// Delete the maintenance window.
+		req, err = http.NewRequest(http.MethodDelete, "/v1/operator/maintenance-window/"+window.Name, nil)
+		must.NoError(t, err)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorMaintenanceWindowRequest(resp, req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/maintenance-window/"+window.Name, nil)
+		must.NoError(t, err)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorMaintenanceWindowRequest(resp, req)
+		must.ErrorContains(t, err, "maintenance window not found")
+	})
+}
+
 func TestOperator_SnapshotRequests(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// otlpShutdownTimeout bounds how long the metrics recorded since the last
+// export are flushed to the collector for when the sink is shut down.
+const otlpShutdownTimeout = 5 * time.Second
+
+// otlpSink is a metrics sink exporting gauges, counters and samples to an
+// OpenTelemetry collector as OTLP gauges, counters and histograms. Metrics are
+// aggregated cumulatively and exported periodically, so that an export the
+// collector misses, such as while it restarts, is made up for by the next one.
+type otlpSink struct {
+	provider *sdkmetric.MeterProvider
+	meter    metric.Meter
+
+	gauges     map[string]metric.Float64Gauge
+	counters   map[string]metric.Float64Counter
+	histograms map[string]metric.Float64Histogram
+	lock       sync.Mutex
+}
+
+// newOTLPSink returns a sink exporting metrics as configured by conf, with
+// the attributes describing the agent attached to the resource of every
+// export.
+func newOTLPSink(conf *OTLPTelemetry, attrs ...attribute.KeyValue) (*otlpSink, error) {
+	exporter, err := newOTLPExporter(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+
+	interval := conf.interval
+	if interval == 0 {
+		interval = DefaultOTLPInterval
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(resource.NewSchemaless(attrs...)),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval))),
+	)
+	return &otlpSink{
+		provider:   provider,
+		meter:      provider.Meter("github.com/hashicorp/nomad"),
+		gauges:     map[string]metric.Float64Gauge{},
+		counters:   map[string]metric.Float64Counter{},
+		histograms: map[string]metric.Float64Histogram{},
+	}, nil
+}
+
+// newOTLPExporter returns the exporter sending metrics to the collector over
+// the protocol of conf. The exporters retry failed exports with a backoff.
+func newOTLPExporter(conf *OTLPTelemetry) (sdkmetric.Exporter, error) {
+	// the endpoint may be given with a scheme, which decides whether TLS is
+	// used unless insecure is set
+	withURL := strings.Contains(conf.Endpoint, "://")
+
+	switch conf.Protocol {
+	case OTLPProtocolHTTP:
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithHeaders(conf.Headers)}
+		if withURL {
+			opts = append(opts, otlpmetrichttp.WithEndpointURL(conf.Endpoint))
+		} else {
+			opts = append(opts, otlpmetrichttp.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(context.Background(), opts...)
+	default:
+		opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithHeaders(conf.Headers)}
+		if withURL {
+			opts = append(opts, otlpmetricgrpc.WithEndpointURL(conf.Endpoint))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(context.Background(), opts...)
+	}
+}
+
+// otlpName returns the name of the OTLP metric for the go-metrics key.
+func otlpName(key []string) string {
+	return strings.Join(key, ".")
+}
+
+// otlpAttributes returns the labels of a metric as OTLP attributes.
+func otlpAttributes(labels []metrics.Label) metric.MeasurementOption {
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for _, label := range labels {
+		attrs = append(attrs, attribute.String(label.Name, label.Value))
+	}
+	return metric.WithAttributes(attrs...)
+}
+
+func (s *otlpSink) gauge(key []string) metric.Float64Gauge {
+	name := otlpName(key)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if g, ok := s.gauges[name]; ok {
+		return g
+	}
+	// the instrument is a no-op if the name is invalid
+	g, _ := s.meter.Float64Gauge(name)
+	s.gauges[name] = g
+	return g
+}
+
+func (s *otlpSink) counter(key []string) metric.Float64Counter {
+	name := otlpName(key)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if c, ok := s.counters[name]; ok {
+		return c
+	}
+	c, _ := s.meter.Float64Counter(name)
+	s.counters[name] = c
+	return c
+}
+
+func (s *otlpSink) histogram(key []string) metric.Float64Histogram {
+	name := otlpName(key)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if h, ok := s.histograms[name]; ok {
+		return h
+	}
+	h, _ := s.meter.Float64Histogram(name)
+	s.histograms[name] = h
+	return h
+}
+
+func (s *otlpSink) SetGauge(key []string, val float32) {
+	s.SetGaugeWithLabels(key, val, nil)
+}
+
+func (s *otlpSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.gauge(key).Record(context.Background(), float64(val), otlpAttributes(labels))
+}
+
+// EmitKey is not supported, as by the prometheus sink.
+func (s *otlpSink) EmitKey(key []string, val float32) {}
+
+func (s *otlpSink) IncrCounter(key []string, val float32) {
+	s.IncrCounterWithLabels(key, val, nil)
+}
+
+func (s *otlpSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.counter(key).Add(context.Background(), float64(val), otlpAttributes(labels))
+}
+
+func (s *otlpSink) AddSample(key []string, val float32) {
+	s.AddSampleWithLabels(key, val, nil)
+}
+
+func (s *otlpSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.histogram(key).Record(context.Background(), float64(val), otlpAttributes(labels))
+}
+
+// Shutdown exports the metrics recorded since the last export and stops the
+// sink.
+func (s *otlpSink) Shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpShutdownTimeout)
+	defer cancel()
+	_ = s.provider.Shutdown(ctx)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOTLPSink_http(t *testing.T) {
+	ci.Parallel(t)
+
+	// the collector records the metrics and resource attributes of every
+	// export
+	var lock sync.Mutex
+	exported := map[string]*metricspb.Metric{}
+	resource := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		must.Eq(t, "/v1/metrics", r.URL.Path)
+		must.Eq(t, "secret", r.Header.Get("X-Token"))
+
+		b, err := io.ReadAll(r.Body)
+		must.NoError(t, err)
+		req := new(colmetricspb.ExportMetricsServiceRequest)
+		must.NoError(t, proto.Unmarshal(b, req))
+
+		lock.Lock()
+		defer lock.Unlock()
+		for _, rm := range req.ResourceMetrics {
+			for _, kv := range rm.Resource.Attributes {
+				resource[kv.Key] = kv.Value.GetStringValue()
+			}
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					exported[m.Name] = m
+				}
+			}
+		}
+
+		resp, _ := proto.Marshal(new(colmetricspb.ExportMetricsServiceResponse))
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	sink, err := newOTLPSink(&OTLPTelemetry{
+		Endpoint: srv.URL,
+		Protocol: OTLPProtocolHTTP,
+		Headers:  map[string]string{"X-Token": "secret"},
+		Insecure: true,
+	}, attribute.String("nomad.node_id", "node-1"))
+	must.NoError(t, err)
+
+	labels := []metrics.Label{{Name: "namespace", Value: "default"}}
+	sink.SetGaugeWithLabels([]string{"nomad", "test", "gauge"}, 2, labels)
+	sink.IncrCounter([]string{"nomad", "test", "counter"}, 1)
+	sink.IncrCounter([]string{"nomad", "test", "counter"}, 2)
+	sink.AddSample([]string{"nomad", "test", "sample"}, 5)
+
+	// shutting down the sink exports the metrics not yet exported
+	sink.Shutdown()
+
+	lock.Lock()
+	defer lock.Unlock()
+	must.Eq(t, "node-1", resource["nomad.node_id"])
+
+	gauge := exported["nomad.test.gauge"].GetGauge()
+	must.NotNil(t, gauge)
+	must.Eq(t, 2, gauge.DataPoints[0].GetAsDouble())
+	must.Eq(t, "namespace", gauge.DataPoints[0].Attributes[0].Key)
+
+	counter := exported["nomad.test.counter"].GetSum()
+	must.NotNil(t, counter)
+	must.True(t, counter.IsMonotonic)
+	must.Eq(t, 3, counter.DataPoints[0].GetAsDouble())
+
+	histogram := exported["nomad.test.sample"].GetHistogram()
+	must.NotNil(t, histogram)
+	must.Eq(t, 1, histogram.DataPoints[0].GetCount())
+	must.Eq(t, 5, histogram.DataPoints[0].GetSum())
+}
+
+func TestOTLPTelemetry_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *OTLPTelemetry
+	must.NoError(t, nilConfig.Validate())
+
+	must.NoError(t, (&OTLPTelemetry{Endpoint: "localhost:4317"}).Validate())
+	must.NoError(t, (&OTLPTelemetry{Endpoint: "localhost:4318", Protocol: OTLPProtocolHTTP}).Validate())
+	must.ErrorContains(t, (&OTLPTelemetry{}).Validate(), "endpoint must be set")
+	must.ErrorContains(t, (&OTLPTelemetry{Endpoint: "localhost:4317", Protocol: "udp"}).Validate(),
+		"protocol must be")
+}
@@ -5,6 +5,7 @@ package agent
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -93,9 +94,11 @@ func (s *HTTPServer) ServiceRegistrationRequest(resp http.ResponseWriter, req *h
 func (s *HTTPServer) serviceGetRequest(
 	resp http.ResponseWriter, req *http.Request, serviceName string) (interface{}, error) {
 
+	healthy, _ := strconv.ParseBool(req.URL.Query().Get("healthy"))
 	args := structs.ServiceRegistrationByNameRequest{
 		ServiceName: serviceName,
 		Choose:      req.URL.Query().Get("choose"),
+		Healthy:     healthy,
 	}
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
@@ -12,6 +12,26 @@ import (
 )
 
 func (s *HTTPServer) ClientStatsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var reply cstructs.ClientStatsResponse
+	if err := s.clientStatsRPC(resp, req, "ClientStats.Stats", &reply); err != nil {
+		return nil, err
+	}
+	return reply.HostStats, nil
+}
+
+// ClientDebugRequest returns the state of the subsystems of a client for
+// debugging, such as the artifact downloads in progress.
+func (s *HTTPServer) ClientDebugRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var reply cstructs.ClientDebugResponse
+	if err := s.clientStatsRPC(resp, req, "ClientStats.Debug", &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// clientStatsRPC makes the ClientStats RPC method for the node requested by
+// req, on the local client if it is the node.
+func (s *HTTPServer) clientStatsRPC(resp http.ResponseWriter, req *http.Request, method string, reply any) error {
 
 	// Build the request and get the requested Node ID
 	args := structs.NodeSpecificRequest{}
@@ -22,14 +42,13 @@ func (s *HTTPServer) ClientStatsRequest(resp http.ResponseWriter, req *http.Requ
 	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForNode(args.NodeID)
 
 	// Make the RPC
-	var reply cstructs.ClientStatsResponse
 	var rpcErr error
 	if useLocalClient {
-		rpcErr = s.agent.Client().ClientRPC("ClientStats.Stats", &args, &reply)
+		rpcErr = s.agent.Client().ClientRPC(method, &args, reply)
 	} else if useClientRPC {
-		rpcErr = s.agent.Client().RPC("ClientStats.Stats", &args, &reply)
+		rpcErr = s.agent.Client().RPC(method, &args, reply)
 	} else if useServerRPC {
-		rpcErr = s.agent.Server().RPC("ClientStats.Stats", &args, &reply)
+		rpcErr = s.agent.Server().RPC(method, &args, reply)
 	} else {
 		rpcErr = CodedError(400, "No local Node and node_id not provided")
 	}
@@ -40,9 +59,6 @@ func (s *HTTPServer) ClientStatsRequest(resp http.ResponseWriter, req *http.Requ
 		} else if strings.Contains(rpcErr.Error(), "Unknown node") {
 			rpcErr = CodedError(404, rpcErr.Error())
 		}
-
-		return nil, rpcErr
 	}
-
-	return reply.HostStats, nil
+	return rpcErr
 }
@@ -5,6 +5,7 @@ package agent
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -14,16 +15,33 @@ func (s *HTTPServer) GarbageCollectRequest(resp http.ResponseWriter, req *http.R
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
 
-	var args structs.GenericRequest
+	var args structs.GarbageCollectRequest
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
 	}
 
-	var gResp structs.GenericResponse
+	if types := req.URL.Query().Get("type"); types != "" {
+		args.Types = strings.Split(types, ",")
+	}
+	dryRun, err := parseBool(req, "dry_run")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	args.DryRun = dryRun != nil && *dryRun
+
+	if err := args.Validate(); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	var gResp structs.GarbageCollectResponse
 	if err := s.agent.RPC("System.GarbageCollect", &args, &gResp); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	if !args.DryRun {
+		return nil, nil
+	}
+	setIndex(resp, gResp.Index)
+	return gResp, nil
 }
 
 func (s *HTTPServer) ReconcileJobSummaries(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
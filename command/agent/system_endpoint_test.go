@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 )
 
 func TestHTTP_SystemGarbageCollect(t *testing.T) {
@@ -48,3 +50,26 @@ func TestHTTP_ReconcileJobSummaries(t *testing.T) {
 		}
 	})
 }
+
+func TestHTTP_SystemGarbageCollect_DryRun(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest(http.MethodPut, "/v1/system/gc?type=jobs,nodes&dry_run=true", nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.GarbageCollectRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+		resp := obj.(structs.GarbageCollectResponse)
+		must.MapLen(t, 2, resp.Classes)
+		must.MapContainsKeys(t, resp.Classes, []string{structs.GCTypeJobs, structs.GCTypeNodes})
+
+		// unknown types are rejected
+		req, err = http.NewRequest(http.MethodPut, "/v1/system/gc?type=allocs", nil)
+		must.NoError(t, err)
+		_, err = s.Server.GarbageCollectRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, `invalid garbage collection type "allocs"`)
+	})
+}
@@ -98,6 +98,7 @@ client {
   gc_volumes_on_node_gc    = true
   no_host_uuid             = false
   disable_remote_exec      = true
+  health_non_fatal         = ["driver.qemu"]
 
   host_volume "tmp" {
     path = "/tmp"
@@ -146,6 +147,7 @@ server {
   job_max_priority              = 200
   job_max_count                 = 1000
   start_timeout                 = "1m"
+  builtin_autoscaler            = true
 
   plan_rejection_tracker {
     enabled        = true
@@ -238,6 +240,12 @@ http_api_response_headers {
   Access-Control-Allow-Origin = "*"
 }
 
+http_api_response_compression {
+  enabled   = true
+  min_size  = 2048
+  encodings = ["zstd", "gzip"]
+}
+
 consul {
   server_service_name    = "nomad"
   server_http_check_name = "nomad-server-http-health-check"
@@ -328,6 +336,9 @@ autopilot {
   last_contact_threshold    = "12705s"
   max_trailing_logs         = 17849
   min_quorum                = 3
+  min_disk_free             = "5GB"
+  max_last_log_age          = "30s"
+  max_applied_index_lag     = 1000
   enable_redundancy_zones   = true
   server_stabilization_time = "23057s"
   enable_custom_upgrades    = true
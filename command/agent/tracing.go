@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// tracingShutdownTimeout bounds how long the spans not yet exported are
+// flushed to the collector for when the agent is shut down.
+const tracingShutdownTimeout = 5 * time.Second
+
+// newTracerProvider returns the provider of the tracers servers record spans
+// with, which exports them to the collector of conf in batches. The
+// attributes describing the agent are attached to the resource of every
+// export.
+func newTracerProvider(conf *TracingTelemetry, attrs ...attribute.KeyValue) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracegrpc.New(context.Background(),
+		otlptracegrpc.WithEndpointURL(conf.OTLPEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+
+	ratio := DefaultTracingSampleRatio
+	if conf.SampleRatio != nil {
+		ratio = *conf.SampleRatio
+	}
+
+	// requests carrying a traceparent are sampled as decided by the caller,
+	// so that traces are not left incomplete
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithResource(resource.NewSchemaless(attrs...)),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	), nil
+}
+
+// shutdownTracerProvider exports the spans not yet exported and stops the
+// tracer provider of the agent, if any.
+func (a *Agent) shutdownTracerProvider() {
+	if a.tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := a.tracerProvider.Shutdown(ctx); err != nil {
+		a.logger.Warn("failed to export remaining spans", "error", err)
+	}
+}
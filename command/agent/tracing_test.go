@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+func TestTracingTelemetry_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *TracingTelemetry
+	must.NoError(t, nilConfig.Validate())
+	must.False(t, nilConfig.Enabled())
+
+	must.NoError(t, (&TracingTelemetry{}).Validate())
+	must.False(t, (&TracingTelemetry{}).Enabled())
+
+	conf := &TracingTelemetry{OTLPEndpoint: "http://localhost:4317", SampleRatio: pointer.Of(0.5)}
+	must.NoError(t, conf.Validate())
+	must.True(t, conf.Enabled())
+
+	must.ErrorContains(t, (&TracingTelemetry{OTLPEndpoint: "localhost:4317"}).Validate(),
+		"otlp_endpoint must be an http or https URL")
+	must.ErrorContains(t, (&TracingTelemetry{SampleRatio: pointer.Of(1.5)}).Validate(),
+		"sample_ratio must be between 0 and 1")
+}
+
+func TestTracingTelemetry_newTracerProvider(t *testing.T) {
+	ci.Parallel(t)
+
+	// the exporter connects lazily, so no collector is needed
+	provider, err := newTracerProvider(&TracingTelemetry{OTLPEndpoint: "http://127.0.0.1:4317"})
+	must.NoError(t, err)
+	must.NotNil(t, provider)
+
+	a := &Agent{tracerProvider: provider, logger: testlog.HCLogger(t)}
+	a.shutdownTracerProvider()
+}
@@ -41,6 +41,13 @@ Usage: nomad alloc exec [options] <allocation> <command>
   this command requires the 'alloc-node-exec', 'alloc-exec', 'read-job',
   and 'list-jobs' capabilities for the allocation's namespace.
 
+  Without a pseudo-tty, the stdout and stderr of the command are written to
+  the stdout and stderr of this command respectively, so that they can be
+  redirected separately. The exit code of this command is the exit code of the
+  command run in the task, so that it can be used in scripts, as in:
+
+      $ nomad alloc exec -i=false -t=false <allocation> /bin/check > out 2> err
+
 General Options:
 
   ` + generalOptionsUsage(usageOptsDefault) + `
@@ -60,8 +67,9 @@ Exec Specific Options:
     Pass stdin to the container, defaults to true.  Pass -i=false to disable.
 
   -t
-    Allocate a pseudo-tty, defaults to true if stdin is detected to be a tty session.
-    Pass -t=false to disable explicitly.
+    Allocate a pseudo-tty, defaults to true if stdin, stdout and stderr are
+    detected to be a tty session. Pass -t=false to disable explicitly. With a
+    pseudo-tty, the stderr of the command is merged into its stdout.
 
   -e <escape_char>
     Sets the escape character for sessions with a pty (default: '~').  The escape
@@ -113,7 +121,7 @@ func (l *AllocExecCommand) Run(args []string) int {
 	flags.Usage = func() { l.Ui.Output(l.Help()) }
 	flags.BoolVar(&job, "job", false, "")
 	flags.BoolVar(&stdinOpt, "i", true, "")
-	flags.BoolVar(&ttyOpt, "t", isTty(), "")
+	flags.BoolVar(&ttyOpt, "t", isExecTty(), "")
 	flags.StringVar(&escapeChar, "e", "~", "")
 	flags.StringVar(&task, "task", "", "")
 	flags.StringVar(&group, "group", "", "")
@@ -306,6 +314,14 @@ func (l *AllocExecCommand) execImpl(client *api.Client, alloc *api.Allocation, t
 		alloc, task, tty, command, stdin, stdout, stderr, sizeCh, nil)
 }
 
+// isExecTty returns true if stdin, stdout and stderr are all terminals. A
+// pseudo-tty merges the stderr of the command into its stdout, so one isn't
+// allocated by default when stderr is redirected.
+func isExecTty() bool {
+	_, isStderrTerminal := term.GetFdInfo(os.Stderr)
+	return isTty() && isStderrTerminal
+}
+
 // setRawTerminal sets the stream terminal in raw mode, so process captures
 // Ctrl+C and other commands to forward to remote process.
 // It returns a cleanup function that restores terminal to original mode.
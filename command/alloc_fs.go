@@ -41,8 +41,14 @@ Alias: nomad fs
   allocation, or displays the file at the given path. The path is relative to
   the root of the alloc dir and defaults to root if unspecified.
 
+  With -recurse, the files under the directory at the given path are
+  downloaded into a local directory instead. With -upload, the files under a
+  local directory are uploaded into the directory of a task at the given path,
+  such as "<task>/local/target". Symlinks are skipped with a warning.
+
   When ACLs are enabled, this command requires a token with the 'read-fs',
   'read-job', and 'list-jobs' capabilities for the allocation's namespace.
+  Uploading files requires the 'write-fs' capability instead of 'read-fs'.
 
 General Options:
 
@@ -79,6 +85,26 @@ FS Specific Options:
 
   -c
     Sets the tail location in number of bytes relative to the end of the file.
+
+  -recurse
+    Download the files under the directory at the given path into the local
+    directory set with -output-dir, preserving the directory structure.
+
+  -output-dir <dir>
+    Sets the local directory the files are downloaded into with -recurse.
+
+  -upload <local-path>
+    Upload the files under a local directory, or a single local file, to the
+    given path. Files can only be uploaded within the directory of a task,
+    outside of its secrets and private directories, and may not exceed 64MiB
+    each.
+
+  -concurrency <n>
+    Sets the number of files downloaded or uploaded at once. Defaults to 4.
+
+  -max-size <size>
+    Sets the maximum total size of the files downloaded or uploaded, such as
+    "500MiB". Set to 0 for no limit. Defaults to 1GiB.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -90,15 +116,20 @@ func (f *AllocFSCommand) Synopsis() string {
 func (f *AllocFSCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(f.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-H":       complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
-			"-job":     complete.PredictAnything,
-			"-group":   complete.PredictAnything,
-			"-stat":    complete.PredictNothing,
-			"-f":       complete.PredictNothing,
-			"-tail":    complete.PredictNothing,
-			"-n":       complete.PredictAnything,
-			"-c":       complete.PredictAnything,
+			"-H":           complete.PredictNothing,
+			"-verbose":     complete.PredictNothing,
+			"-job":         complete.PredictAnything,
+			"-group":       complete.PredictAnything,
+			"-stat":        complete.PredictNothing,
+			"-f":           complete.PredictNothing,
+			"-tail":        complete.PredictNothing,
+			"-n":           complete.PredictAnything,
+			"-c":           complete.PredictAnything,
+			"-recurse":     complete.PredictNothing,
+			"-output-dir":  complete.PredictDirs("*"),
+			"-upload":      complete.PredictFiles("*"),
+			"-concurrency": complete.PredictAnything,
+			"-max-size":    complete.PredictAnything,
 		})
 }
 
@@ -120,9 +151,10 @@ func (f *AllocFSCommand) AutocompleteArgs() complete.Predictor {
 func (f *AllocFSCommand) Name() string { return "alloc fs" }
 
 func (f *AllocFSCommand) Run(args []string) int {
-	var verbose, machine, job, stat, tail, follow bool
+	var verbose, machine, job, stat, tail, follow, recurse bool
 	var numLines, numBytes int64
-	var group string
+	var group, outputDir, upload, maxSizeStr string
+	var concurrency int
 
 	flags := f.Meta.FlagSet(f.Name(), FlagSetClient)
 	flags.Usage = func() { f.Ui.Output(f.Help()) }
@@ -135,12 +167,42 @@ func (f *AllocFSCommand) Run(args []string) int {
 	flags.BoolVar(&tail, "tail", false, "")
 	flags.Int64Var(&numLines, "n", -1, "")
 	flags.Int64Var(&numBytes, "c", -1, "")
+	flags.BoolVar(&recurse, "recurse", false, "")
+	flags.StringVar(&outputDir, "output-dir", "", "")
+	flags.StringVar(&upload, "upload", "", "")
+	flags.IntVar(&concurrency, "concurrency", defaultTransferConcurrency, "")
+	flags.StringVar(&maxSizeStr, "max-size", defaultTransferMaxSize, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 	args = flags.Args()
 
+	transfer := recurse || upload != ""
+	switch {
+	case recurse && upload != "":
+		f.Ui.Error("-recurse cannot be used with -upload")
+		return 1
+	case transfer && (stat || follow || tail):
+		f.Ui.Error("-recurse and -upload cannot be used with -stat, -f or -tail")
+		return 1
+	case recurse && outputDir == "":
+		f.Ui.Error("-recurse requires -output-dir to be set")
+		return 1
+	case !recurse && outputDir != "":
+		f.Ui.Error("-output-dir can only be used with -recurse")
+		return 1
+	case concurrency < 1:
+		f.Ui.Error("-concurrency must be at least 1")
+		return 1
+	}
+
+	maxSize, err := humanize.ParseBytes(maxSizeStr)
+	if err != nil {
+		f.Ui.Error(fmt.Sprintf("Invalid -max-size %q: %v", maxSizeStr, err))
+		return 1
+	}
+
 	if len(args) < 1 {
 		if job {
 			f.Ui.Error("A job ID is required")
@@ -160,6 +222,10 @@ func (f *AllocFSCommand) Run(args []string) int {
 	path := "/"
 	if len(args) == 2 {
 		path = args[1]
+	} else if upload != "" {
+		f.Ui.Error("-upload requires the path to upload to")
+		f.Ui.Error(commandErrorText(f))
+		return 1
 	}
 
 	client, err := f.Meta.Client()
@@ -219,6 +285,11 @@ func (f *AllocFSCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Upload to a path which may not exist yet
+	if upload != "" {
+		return f.uploadDir(client, alloc, upload, path, concurrency, int64(maxSize))
+	}
+
 	// Get file stat info
 	file, _, err := client.AllocFS().Stat(alloc, path, nil)
 	if err != nil {
@@ -249,6 +320,14 @@ func (f *AllocFSCommand) Run(args []string) int {
 		return 0
 	}
 
+	if recurse {
+		if !file.IsDir {
+			f.Ui.Error(fmt.Sprintf("-recurse requires a directory, %q is a file", path))
+			return 1
+		}
+		return f.downloadDir(client, alloc, path, outputDir, concurrency, int64(maxSize))
+	}
+
 	// Determine if the path is a file or a directory.
 	if file.IsDir {
 		// We have a directory, list it.
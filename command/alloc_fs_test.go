@@ -54,6 +54,31 @@ func TestFSCommand_Fails(t *testing.T) {
 
 	ui.ErrorWriter.Reset()
 
+	// Fails on misuse of the transfer flags
+	code = cmd.Run([]string{"-recurse", "foobar", "/alloc/logs"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "-recurse requires -output-dir to be set")
+
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-recurse", "-output-dir=dump", "-upload=dump", "foobar", "/web/local"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "-recurse cannot be used with -upload")
+
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-upload=dump", "foobar"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "-upload requires the path to upload to")
+
+	ui.ErrorWriter.Reset()
+
 	// Fails on connection failure
 	code = cmd.Run([]string{"-address=nope", "foobar"})
 	must.One(t, code)
@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/api"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// defaultTransferConcurrency is the number of files downloaded or
+	// uploaded at once by alloc fs -recurse and -upload.
+	defaultTransferConcurrency = 4
+
+	// defaultTransferMaxSize is the maximum total size of the files
+	// downloaded or uploaded by alloc fs -recurse and -upload.
+	defaultTransferMaxSize = "1GiB"
+)
+
+// errTransferMaxSize is returned when the files to download or upload exceed
+// the maximum total size.
+var errTransferMaxSize = errors.New("exceeds the maximum total size")
+
+// transferFile is a regular file to download or upload, at a slash-separated
+// path relative to the root of the transfer.
+type transferFile struct {
+	rel  string
+	size int64
+	mode fs.FileMode
+}
+
+// transferTotal returns the total size of files, or errTransferMaxSize if it
+// exceeds maxSize. A maxSize of 0 is unlimited.
+func transferTotal(files []transferFile, maxSize int64) (int64, error) {
+	var total int64
+	for _, file := range files {
+		total += file.size
+	}
+	if maxSize > 0 && total > maxSize {
+		return total, fmt.Errorf("%s of files %w of %s, set with -max-size",
+			humanize.IBytes(uint64(total)), errTransferMaxSize, humanize.IBytes(uint64(maxSize)))
+	}
+	return total, nil
+}
+
+// transferBudget bounds the bytes actually copied during a transfer, since
+// files may grow after being listed, such as logs.
+type transferBudget struct {
+	remaining atomic.Int64
+	unlimited bool
+}
+
+func newTransferBudget(maxSize int64) *transferBudget {
+	b := &transferBudget{unlimited: maxSize <= 0}
+	b.remaining.Store(maxSize)
+	return b
+}
+
+// writer returns a writer to w failing with errTransferMaxSize once the
+// budget is spent.
+func (b *transferBudget) writer(w io.Writer) io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		if !b.unlimited && b.remaining.Add(-int64(len(p))) < 0 {
+			return 0, fmt.Errorf("files grew while copied and %w, set with -max-size", errTransferMaxSize)
+		}
+		return w.Write(p)
+	})
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+// listAllocFiles walks the allocation directory at root using list, and
+// returns the regular files found under it. Symlinks and other irregular
+// files are skipped, with a warning returned for each of them.
+func listAllocFiles(root string, list func(string) ([]*api.AllocFileInfo, error)) ([]transferFile, []string, error) {
+	var files []transferFile
+	var warnings []string
+
+	dirs := []string{""}
+	for len(dirs) > 0 {
+		dir := dirs[0]
+		dirs = dirs[1:]
+
+		entries, err := list(path.Join(root, dir))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error listing %q: %w", path.Join(root, dir), err)
+		}
+		for _, entry := range entries {
+			rel := path.Join(dir, entry.Name)
+			switch {
+			case entry.IsDir:
+				dirs = append(dirs, rel)
+			case strings.HasPrefix(entry.FileMode, "L"):
+				warnings = append(warnings, fmt.Sprintf("Skipping symlink %q", path.Join(root, rel)))
+			case !strings.HasPrefix(entry.FileMode, "-"):
+				warnings = append(warnings, fmt.Sprintf("Skipping irregular file %q", path.Join(root, rel)))
+			default:
+				files = append(files, transferFile{rel: rel, size: entry.Size})
+			}
+		}
+	}
+	return files, warnings, nil
+}
+
+// listLocalFiles walks the local directory at root and returns the regular
+// files found under it. Symlinks and other irregular files are skipped, with a
+// warning returned for each of them. If root is a file, it is returned alone
+// with a relative path of ".".
+func listLocalFiles(root string) ([]transferFile, []string, error) {
+	var files []transferFile
+	var warnings []string
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return nil
+		case d.Type()&fs.ModeSymlink != 0:
+			warnings = append(warnings, fmt.Sprintf("Skipping symlink %q", p))
+			return nil
+		case !d.Type().IsRegular():
+			warnings = append(warnings, fmt.Sprintf("Skipping irregular file %q", p))
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, transferFile{
+			rel:  filepath.ToSlash(rel),
+			size: info.Size(),
+			mode: info.Mode().Perm(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, warnings, nil
+}
+
+// downloadDir downloads the regular files under the directory at remote in the
+// allocation into the local directory at local.
+func (f *AllocFSCommand) downloadDir(client *api.Client, alloc *api.Allocation,
+	remote, local string, concurrency int, maxSize int64) int {
+
+	list := func(p string) ([]*api.AllocFileInfo, error) {
+		files, _, err := client.AllocFS().List(alloc, p, nil)
+		return files, err
+	}
+	files, warnings, err := listAllocFiles(remote, list)
+	if err != nil {
+		f.Ui.Error(err.Error())
+		return 1
+	}
+	for _, warning := range warnings {
+		f.Ui.Warn(warning)
+	}
+
+	total, err := transferTotal(files, maxSize)
+	if err != nil {
+		f.Ui.Error(fmt.Sprintf("Error downloading %q: %v", remote, err))
+		return 1
+	}
+
+	budget := newTransferBudget(maxSize)
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for _, file := range files {
+		g.Go(func() error {
+			src := path.Join(remote, file.rel)
+			dst := filepath.Join(local, filepath.FromSlash(file.rel))
+			if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+				return err
+			}
+
+			r, err := client.AllocFS().Cat(alloc, src, nil)
+			if err != nil {
+				return fmt.Errorf("Error reading %q: %w", src, err)
+			}
+			defer r.Close()
+
+			out, err := os.Create(dst)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(budget.writer(out), r); err != nil {
+				out.Close()
+				return fmt.Errorf("Error downloading %q: %w", src, err)
+			}
+			return out.Close()
+		})
+	}
+	if err := g.Wait(); err != nil {
+		f.Ui.Error(err.Error())
+		return 1
+	}
+
+	f.Ui.Output(fmt.Sprintf("Downloaded %d files (%s) from %q to %q",
+		len(files), humanize.IBytes(uint64(total)), remote, local))
+	return 0
+}
+
+// uploadDir uploads the regular files under the local directory at local into
+// the directory at remote in the allocation.
+func (f *AllocFSCommand) uploadDir(client *api.Client, alloc *api.Allocation,
+	local, remote string, concurrency int, maxSize int64) int {
+
+	files, warnings, err := listLocalFiles(local)
+	if err != nil {
+		f.Ui.Error(fmt.Sprintf("Error listing %q: %v", local, err))
+		return 1
+	}
+	for _, warning := range warnings {
+		f.Ui.Warn(warning)
+	}
+
+	total, err := transferTotal(files, maxSize)
+	if err != nil {
+		f.Ui.Error(fmt.Sprintf("Error uploading %q: %v", local, err))
+		return 1
+	}
+
+	budget := newTransferBudget(maxSize)
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for _, file := range files {
+		g.Go(func() error {
+			src := filepath.Join(local, filepath.FromSlash(file.rel))
+			dst := path.Join(remote, file.rel)
+
+			in, err := os.Open(src)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+
+			// read the file through the budget, since it may have grown
+			pr, pw := io.Pipe()
+			go func() {
+				_, err := io.Copy(budget.writer(pw), in)
+				pw.CloseWithError(err)
+			}()
+			if _, err := client.AllocFS().Write(alloc, dst, pr, file.mode, nil); err != nil {
+				pr.CloseWithError(err)
+				return fmt.Errorf("Error uploading %q to %q: %w", src, dst, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		f.Ui.Error(err.Error())
+		return 1
+	}
+
+	f.Ui.Output(fmt.Sprintf("Uploaded %d files (%s) from %q to %q",
+		len(files), humanize.IBytes(uint64(total)), local, remote))
+	return 0
+}
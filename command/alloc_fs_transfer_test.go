@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocFSTransfer_listAllocFiles(t *testing.T) {
+	ci.Parallel(t)
+
+	tree := map[string][]*api.AllocFileInfo{
+		"alloc/logs": {
+			{Name: "web.stdout.0", Size: 10, FileMode: "-rw-r--r--"},
+			{Name: "rotated", IsDir: true, FileMode: "drwxr-xr-x"},
+			{Name: "link", FileMode: "Lrwxrwxrwx"},
+			{Name: "fifo", FileMode: "prw-r--r--"},
+		},
+		"alloc/logs/rotated": {
+			{Name: "web.stdout.1", Size: 20, FileMode: "-rw-r--r--"},
+		},
+	}
+	list := func(p string) ([]*api.AllocFileInfo, error) {
+		entries, ok := tree[p]
+		if !ok {
+			return nil, fmt.Errorf("no such directory")
+		}
+		return entries, nil
+	}
+
+	files, warnings, err := listAllocFiles("alloc/logs", list)
+	must.NoError(t, err)
+	must.Eq(t, []transferFile{
+		{rel: "web.stdout.0", size: 10},
+		{rel: "rotated/web.stdout.1", size: 20},
+	}, files)
+	must.Eq(t, []string{
+		`Skipping symlink "alloc/logs/link"`,
+		`Skipping irregular file "alloc/logs/fifo"`,
+	}, warnings)
+
+	_, _, err = listAllocFiles("missing", list)
+	must.ErrorContains(t, err, `Error listing "missing": no such directory`)
+}
+
+func TestAllocFSTransfer_listLocalFiles(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(root, "conf", "extra"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(root, "run.sh"), []byte("#!/bin/sh"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(root, "conf", "extra", "app.conf"), []byte("a=b"), 0o600))
+	must.NoError(t, os.Symlink("/etc/passwd", filepath.Join(root, "conf", "passwd")))
+
+	files, warnings, err := listLocalFiles(root)
+	must.NoError(t, err)
+	must.Eq(t, []transferFile{
+		{rel: "conf/extra/app.conf", size: 3, mode: 0o600},
+		{rel: "run.sh", size: 9, mode: 0o755},
+	}, files)
+	must.Eq(t, []string{
+		fmt.Sprintf("Skipping symlink %q", filepath.Join(root, "conf", "passwd")),
+	}, warnings)
+
+	// a single file is relative to itself
+	files, _, err = listLocalFiles(filepath.Join(root, "run.sh"))
+	must.NoError(t, err)
+	must.Eq(t, []transferFile{{rel: ".", size: 9, mode: 0o755}}, files)
+}
+
+func TestAllocFSTransfer_maxSize(t *testing.T) {
+	ci.Parallel(t)
+
+	files := []transferFile{{rel: "a", size: 600}, {rel: "b", size: 500}}
+
+	total, err := transferTotal(files, 0)
+	must.NoError(t, err)
+	must.Eq(t, 1100, total)
+
+	_, err = transferTotal(files, 1100)
+	must.NoError(t, err)
+
+	_, err = transferTotal(files, 1024)
+	must.ErrorIs(t, err, errTransferMaxSize)
+	must.ErrorContains(t, err, "1.1 KiB of files exceeds the maximum total size of 1.0 KiB")
+
+	// files growing while copied are stopped at the limit
+	var buf bytes.Buffer
+	budget := newTransferBudget(10)
+	w := budget.writer(&buf)
+	_, err = w.Write([]byte("0123456789"))
+	must.NoError(t, err)
+	_, err = w.Write([]byte("a"))
+	must.ErrorIs(t, err, errTransferMaxSize)
+	must.Eq(t, "0123456789", buf.String())
+
+	_, err = newTransferBudget(0).writer(&buf).Write([]byte("unlimited"))
+	must.NoError(t, err)
+}
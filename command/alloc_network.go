@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocNetworkCommand struct {
+	Meta
+}
+
+func (c *AllocNetworkCommand) Help() string {
+	helpText := `
+Usage: nomad alloc network [options] <allocation>
+
+  Outputs the network namespace details of an allocation, as reported by the
+  client running it: the ports allocated on the host and the ports they are
+  mapped to in the network namespace, the interfaces and routes created by
+  the CNI plugins, and the DNS configuration.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the allocation's namespace.
+
+General Options:
+
+` + generalOptionsUsage(usageOptsDefault) + `
+
+Network Specific Options:
+
+  -json
+    Output the network namespace details in a JSON format.
+
+  -t
+    Format and display the network namespace details using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocNetworkCommand) Synopsis() string {
+	return "Outputs the network namespace details of an allocation"
+}
+
+func (c *AllocNetworkCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *AllocNetworkCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return nil
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocNetworkCommand) Name() string {
+	return "alloc network"
+}
+
+func (c *AllocNetworkCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got only one argument
+	args = flags.Args()
+	if numArgs := len(args); numArgs < 1 {
+		c.Ui.Error("An allocation ID is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	} else if numArgs > 1 {
+		c.Ui.Error("This command takes one argument (allocation ID)")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
+		return 1
+	}
+
+	allocID := args[0]
+	if len(allocID) == 1 {
+		c.Ui.Error("Alloc ID must contain at least two characters.")
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+	allocations, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+	if len(allocations) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+	if len(allocations) > 1 {
+		out := formatAllocListStubs(allocations, false, shortId)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+
+	q := &api.QueryOptions{Namespace: allocations[0].Namespace}
+	networking, err := client.Allocations().Networking(allocations[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation networking: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, networking)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color(formatAllocNetworking(networking)))
+	return 0
+}
+
+// formatAllocNetworking formats the network namespace details of an
+// allocation for the output of the alloc network command.
+func formatAllocNetworking(n *api.AllocNetworking) string {
+	var out strings.Builder
+
+	basic := []string{
+		fmt.Sprintf("Mode|%s", n.Mode),
+		fmt.Sprintf("Interface|%s", n.InterfaceName),
+		fmt.Sprintf("Address|%s", n.Address),
+	}
+	if n.AddressIPv6 != "" {
+		basic = append(basic, fmt.Sprintf("Address IPv6|%s", n.AddressIPv6))
+	}
+	out.WriteString(formatKV(basic))
+
+	out.WriteString("\n\n[bold]Ports[reset]\n")
+	if len(n.Ports) == 0 {
+		out.WriteString("No ports allocated")
+	} else {
+		ports := make([]string, 0, len(n.Ports)+1)
+		ports = append(ports, "Label|Host IP|Host Port|To")
+		for _, p := range n.Ports {
+			ports = append(ports, fmt.Sprintf("%s|%s|%d|%d", p.Label, p.HostIP, p.HostPort, p.To))
+		}
+		out.WriteString(formatList(ports))
+	}
+
+	if n.DNS != nil {
+		out.WriteString("\n\n[bold]DNS[reset]\n")
+		out.WriteString(formatKV([]string{
+			fmt.Sprintf("Servers|%s", strings.Join(n.DNS.Servers, ", ")),
+			fmt.Sprintf("Searches|%s", strings.Join(n.DNS.Searches, ", ")),
+			fmt.Sprintf("Options|%s", strings.Join(n.DNS.Options, ", ")),
+		}))
+	}
+
+	if n.CNI != nil {
+		out.WriteString("\n\n[bold]CNI Interfaces[reset]\n")
+		if len(n.CNI.Interfaces) == 0 {
+			out.WriteString("No interfaces")
+		} else {
+			ifaces := make([]string, 0, len(n.CNI.Interfaces)+1)
+			ifaces = append(ifaces, "Name|MAC|Sandbox|Addresses")
+			for _, iface := range n.CNI.Interfaces {
+				addrs := make([]string, 0, len(iface.Addresses))
+				for _, addr := range iface.Addresses {
+					a := addr.Address
+					if addr.Gateway != "" {
+						a += " via " + addr.Gateway
+					}
+					addrs = append(addrs, a)
+				}
+				ifaces = append(ifaces, fmt.Sprintf("%s|%s|%s|%s",
+					iface.Name, iface.MAC, iface.Sandbox, strings.Join(addrs, ", ")))
+			}
+			out.WriteString(formatList(ifaces))
+		}
+
+		out.WriteString("\n\n[bold]CNI Routes[reset]\n")
+		if len(n.CNI.Routes) == 0 {
+			out.WriteString("No routes")
+		} else {
+			routes := make([]string, 0, len(n.CNI.Routes)+1)
+			routes = append(routes, "Destination|Gateway")
+			for _, r := range n.CNI.Routes {
+				routes = append(routes, fmt.Sprintf("%s|%s", r.Destination, r.Gateway))
+			}
+			out.WriteString(formatList(routes))
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocNetworkCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = (*AllocNetworkCommand)(nil)
+}
+
+func TestAllocNetworkCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &AllocNetworkCommand{Meta: Meta{Ui: ui}}
+
+	// fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying allocation")
+	ui.ErrorWriter.Reset()
+
+	// fails on missing allocation
+	code = cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No allocation(s) with prefix or id")
+	ui.ErrorWriter.Reset()
+
+	// fails on prefix with too few characters
+	code = cmd.Run([]string{"-address=" + url, "2"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "must contain at least two characters.")
+}
+
+func TestAllocNetworkCommand_format(t *testing.T) {
+	ci.Parallel(t)
+
+	out := formatAllocNetworking(&api.AllocNetworking{
+		Mode:          "bridge",
+		InterfaceName: "eth0",
+		Address:       "172.26.64.2",
+		Ports: []*api.AllocNetworkingPort{
+			{Label: "http", HostIP: "10.0.0.1", HostPort: 25312, To: 8080},
+		},
+		DNS: &api.DNSConfig{Servers: []string{"1.1.1.1"}},
+		CNI: &api.CNIResult{
+			Interfaces: []*api.CNIInterface{{
+				Name:      "eth0",
+				MAC:       "aa:bb:cc:dd:ee:ff",
+				Sandbox:   "/var/run/netns/test",
+				Addresses: []*api.CNIAddress{{Address: "172.26.64.2", Gateway: "172.26.64.1"}},
+			}},
+			Routes: []*api.CNIRoute{{Destination: "0.0.0.0/0", Gateway: "172.26.64.1"}},
+		},
+	})
+
+	must.RegexMatch(t, regexp.MustCompile(`Mode\s+= bridge`), out)
+	must.RegexMatch(t, regexp.MustCompile(`http\s+10\.0\.0\.1\s+25312\s+8080`), out)
+	must.RegexMatch(t, regexp.MustCompile(`Servers\s+= 1\.1\.1\.1`), out)
+	must.StrContains(t, out, "172.26.64.2 via 172.26.64.1")
+	must.RegexMatch(t, regexp.MustCompile(`0\.0\.0\.0/0\s+172\.26\.64\.1`), out)
+
+	// without CNI, only the ports are listed
+	out = formatAllocNetworking(&api.AllocNetworking{Mode: "host"})
+	must.StrContains(t, out, "No ports allocated")
+	must.StrNotContains(t, out, "CNI")
+}
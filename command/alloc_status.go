@@ -36,7 +36,7 @@ Usage: nomad alloc status [options] <allocation>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsStale) + `
 
 Alloc Status Options:
 
@@ -55,8 +55,13 @@ Alloc Status Options:
   -json
     Output the allocation in its JSON format.
 
-  -t
+  -t, -template
     Format and display allocation using a Go template.
+
+  -o
+    Display the list of allocations with the given columns, either "wide" for
+    all the available columns or a comma-separated list of columns such as
+    "columns=ID,Job ID,Status". Only used when no allocation is given.
 `
 
 	return strings.TrimSpace(helpText)
@@ -67,13 +72,15 @@ func (c *AllocStatusCommand) Synopsis() string {
 }
 
 func (c *AllocStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetStale),
 		complete.Flags{
-			"-short":   complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
-			"-json":    complete.PredictNothing,
-			"-t":       complete.PredictAnything,
-			"-ui":      complete.PredictNothing,
+			"-short":    complete.PredictNothing,
+			"-verbose":  complete.PredictNothing,
+			"-json":     complete.PredictNothing,
+			"-t":        complete.PredictAnything,
+			"-template": complete.PredictAnything,
+			"-o":        complete.PredictAnything,
+			"-ui":       complete.PredictNothing,
 		})
 }
 
@@ -96,15 +103,17 @@ func (c *AllocStatusCommand) Name() string { return "alloc status" }
 
 func (c *AllocStatusCommand) Run(args []string) int {
 	var short, displayStats, verbose, json, openURL bool
-	var tmpl string
+	var tmpl, output string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetStale)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&short, "short", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&displayStats, "stats", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
+	flags.StringVar(&tmpl, "template", "", "")
+	flags.StringVar(&output, "o", "", "")
 	flags.BoolVar(&openURL, "ui", false, "")
 
 	if err := flags.Parse(args); err != nil {
@@ -114,6 +123,21 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	// Check that we got exactly one allocation ID
 	args = flags.Args()
 
+	if err := validateOutputFlags(json, tmpl, output); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	if output != "" && len(args) != 0 {
+		c.Ui.Error("-o can only be used to list allocations")
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -122,14 +146,19 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	}
 
 	// If args not specified but output format is specified, format and output the allocations data list
-	if len(args) == 0 && (json || len(tmpl) > 0) {
+	if len(args) == 0 && (json || len(tmpl) > 0 || output != "") {
 		allocs, _, err := client.Allocations().List(nil)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error querying allocations: %v", err))
 			return 1
 		}
 
-		out, err := Format(json, tmpl, allocs)
+		var out string
+		if output != "" {
+			out, err = formatOutput(output, allocs, allocListColumns(length))
+		} else {
+			out, err = Format(json, tmpl, allocs)
+		}
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -148,12 +177,6 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	}
 	allocID := args[0]
 
-	// Truncate the id unless full length is requested
-	length := shortId
-	if verbose {
-		length = fullId
-	}
-
 	// Query the allocation info
 	if len(allocID) == 1 {
 		c.Ui.Error("Identifier must contain at least two characters.")
@@ -217,6 +240,12 @@ func (c *AllocStatusCommand) Run(args []string) int {
 			c.Ui.Output("")
 			c.Ui.Output(checkOutput)
 		}
+
+		// add the reschedule attempts which led to the allocation
+		if alloc.RescheduleTracker != nil && len(alloc.RescheduleTracker.Events) > 0 {
+			c.Ui.Output(c.Colorize().Color("\n[bold]Reschedule Events[reset]"))
+			c.Ui.Output(formatAllocRescheduleEvents(alloc.RescheduleTracker.Events, length))
+		}
 	}
 
 	if short {
@@ -224,6 +253,7 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	} else {
 		var statsErr error
 		var stats *api.AllocResourceUsage
+		var history map[string]*api.TaskResourceUsageHistory
 		stats, statsErr = client.Allocations().Stats(alloc, nil)
 		if statsErr != nil {
 			c.Ui.Output("")
@@ -232,8 +262,11 @@ func (c *AllocStatusCommand) Run(args []string) int {
 			} else {
 				c.Ui.Output("Omitting resource statistics since the node is down.")
 			}
+		} else if hasExitedTask(alloc) {
+			// The history is best effort, as the client may not keep it
+			history, _ = client.Allocations().StatsHistory(alloc, "", nil)
 		}
-		c.outputTaskDetails(alloc, stats, displayStats, verbose)
+		c.outputTaskDetails(alloc, stats, history, displayStats, verbose)
 	}
 
 	// Format the detailed status
@@ -255,6 +288,26 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	return 0
 }
 
+// allocListColumns returns the columns of the list of allocations which can
+// be selected with the -o flag, with IDs truncated to uuidLength.
+func allocListColumns(uuidLength int) []outputColumn[*api.AllocationListStub] {
+	return []outputColumn[*api.AllocationListStub]{
+		{name: "ID", value: func(a *api.AllocationListStub) string { return limit(a.ID, uuidLength) }},
+		{name: "Eval ID", value: func(a *api.AllocationListStub) string { return limit(a.EvalID, uuidLength) }},
+		{name: "Name", value: func(a *api.AllocationListStub) string { return a.Name }},
+		{name: "Namespace", value: func(a *api.AllocationListStub) string { return a.Namespace }},
+		{name: "Job ID", value: func(a *api.AllocationListStub) string { return a.JobID }},
+		{name: "Task Group", value: func(a *api.AllocationListStub) string { return a.TaskGroup }},
+		{name: "Version", value: func(a *api.AllocationListStub) string { return strconv.FormatUint(a.JobVersion, 10) }},
+		{name: "Node ID", value: func(a *api.AllocationListStub) string { return limit(a.NodeID, uuidLength) }},
+		{name: "Node Name", value: func(a *api.AllocationListStub) string { return a.NodeName }},
+		{name: "Desired", value: func(a *api.AllocationListStub) string { return a.DesiredStatus }},
+		{name: "Status", value: func(a *api.AllocationListStub) string { return a.ClientStatus }},
+		{name: "Created", value: func(a *api.AllocationListStub) string { return formatUnixNanoTime(a.CreateTime) }},
+		{name: "Modified", value: func(a *api.AllocationListStub) string { return formatUnixNanoTime(a.ModifyTime) }},
+	}
+}
+
 func formatAllocShortInfo(alloc *api.Allocation, client *api.Client) string {
 	formattedCreateTime := prettyTimeDiff(time.Unix(0, alloc.CreateTime), time.Now())
 	formattedModifyTime := prettyTimeDiff(time.Unix(0, alloc.ModifyTime), time.Now())
@@ -351,6 +404,29 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 	return formatKV(basic), nil
 }
 
+// formatAllocRescheduleEvents formats the reschedule attempts of an
+// allocation, with the delay after which each attempt was made and the
+// reschedule policy it was computed from.
+func formatAllocRescheduleEvents(events []*api.RescheduleEvent, uuidLength int) string {
+	out := make([]string, 0, len(events)+1)
+	out = append(out, "Time|Previous Alloc ID|Previous Node ID|Delay|Jitter|Source")
+	for _, event := range events {
+		source := event.DelaySource
+		if source == "" {
+			source = "<none>"
+		}
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			formatUnixNanoTime(event.RescheduleTime),
+			limit(event.PrevAllocID, uuidLength),
+			limit(event.PrevNodeID, uuidLength),
+			event.Delay,
+			event.Jitter,
+			source,
+		))
+	}
+	return formatList(out)
+}
+
 func formatAllocNetworkInfo(alloc *api.Allocation) string {
 	nw := alloc.AllocatedResources.Shared.Networks[0]
 	addrs := []string{"Label|Dynamic|Address"}
@@ -417,7 +493,7 @@ func futureEvalTimePretty(evalID string, client *api.Client) string {
 
 // outputTaskDetails prints task details for each task in the allocation,
 // optionally printing verbose statistics if displayStats is set
-func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api.AllocResourceUsage, displayStats bool, verbose bool) {
+func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api.AllocResourceUsage, history map[string]*api.TaskResourceUsageHistory, displayStats bool, verbose bool) {
 	taskLifecycles := map[string]*api.TaskLifecycle{}
 	for _, t := range alloc.Job.LookupTaskGroup(alloc.TaskGroup).Tasks {
 		taskLifecycles[t.Name] = t.Lifecycle
@@ -433,6 +509,7 @@ func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api
 
 		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("\n[bold]Task %q%v is %q[reset]", task, lcIndicator, state.State)))
 		c.outputTaskResources(alloc, task, stats, displayStats)
+		c.outputTaskUsageBeforeExit(history[task])
 		c.Ui.Output("")
 		c.outputTaskVolumes(alloc, task, verbose)
 		c.outputTaskStatus(state)
@@ -532,12 +609,30 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		parts = append(parts, fmt.Sprintf("Exit Code: %d", event.ExitCode))
 
 		if event.Signal != 0 {
-			parts = append(parts, fmt.Sprintf("Signal: %d", event.Signal))
+			if name := event.Details["signal_name"]; name != "" {
+				parts = append(parts, fmt.Sprintf("Signal: %d (%s)", event.Signal, name))
+			} else {
+				parts = append(parts, fmt.Sprintf("Signal: %d", event.Signal))
+			}
+		}
+
+		if event.Details["core_dumped"] == "true" {
+			parts = append(parts, "Core Dumped")
 		}
 
 		if event.Message != "" {
 			parts = append(parts, fmt.Sprintf("Exit Message: %q", event.Message))
 		}
+
+		if rss, _ := strconv.ParseUint(event.Details["max_rss_bytes"], 10, 64); rss != 0 {
+			parts = append(parts, fmt.Sprintf("Max RSS: %s", humanize.IBytes(rss)))
+		}
+		userCPU, _ := strconv.ParseInt(event.Details["user_cpu_time"], 10, 64)
+		systemCPU, _ := strconv.ParseInt(event.Details["system_cpu_time"], 10, 64)
+		if userCPU != 0 || systemCPU != 0 {
+			parts = append(parts, fmt.Sprintf("CPU Time: %v user, %v system",
+				time.Duration(userCPU), time.Duration(systemCPU)))
+		}
 		desc = strings.Join(parts, ", ")
 	case api.TaskRestarting:
 		in := fmt.Sprintf("Task restarting in %v", time.Duration(event.StartDelay))
@@ -675,6 +770,84 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 	}
 }
 
+// hasExitedTask returns whether any task of the allocation has exited, and so
+// may have a resource usage history from before it exited.
+func hasExitedTask(alloc *api.Allocation) bool {
+	for _, state := range alloc.TaskStates {
+		if !state.FinishedAt.IsZero() || !state.LastRestart.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// outputTaskUsageBeforeExit prints the memory and CPU usage of a task leading
+// up to when it last exited, if the task exited recently.
+func (c *AllocStatusCommand) outputTaskUsageBeforeExit(history *api.TaskResourceUsageHistory) {
+	if history == nil || history.ExitedAt == 0 || len(history.Samples) == 0 {
+		return
+	}
+
+	var memory, cpu []float64
+	for _, sample := range history.Samples {
+		if sample.ResourceUsage == nil {
+			continue
+		}
+		var usage, ticks float64
+		if ms := sample.ResourceUsage.MemoryStats; ms != nil {
+			usage = float64(ms.RSS)
+			if ms.RSS == 0 && !slices.Contains(ms.Measured, "RSS") {
+				usage = float64(ms.Usage)
+			}
+		}
+		if cs := sample.ResourceUsage.CpuStats; cs != nil {
+			ticks = cs.TotalTicks
+		}
+		memory = append(memory, usage)
+		cpu = append(cpu, ticks)
+	}
+	if len(memory) == 0 {
+		return
+	}
+
+	first := time.Unix(0, history.Samples[0].Timestamp)
+	exited := time.Unix(0, history.ExitedAt)
+
+	c.Ui.Output("")
+	c.Ui.Output(fmt.Sprintf("Resource usage before exit (%s before %s):",
+		formatTimeDifference(first, exited, time.Second), formatTime(exited)))
+	c.Ui.Output(formatListWithSpaces([]string{
+		"Resource|Last|Peak|Usage",
+		fmt.Sprintf("Memory|%s|%s|%s",
+			humanize.IBytes(uint64(memory[len(memory)-1])),
+			humanize.IBytes(uint64(slices.Max(memory))),
+			sparkline(memory)),
+		fmt.Sprintf("CPU|%v MHz|%v MHz|%s",
+			math.Floor(cpu[len(cpu)-1]),
+			math.Floor(slices.Max(cpu)),
+			sparkline(cpu)),
+	}))
+}
+
+// sparkBars are the bars of a sparkline, from lowest to highest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline returns the values as a line of bars scaled between zero and the
+// highest value.
+func sparkline(values []float64) string {
+	peak := slices.Max(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 {
+			i = int(v / peak * float64(len(sparkBars)-1))
+		}
+		b.WriteRune(sparkBars[max(0, min(i, len(sparkBars)-1))])
+	}
+	return b.String()
+}
+
 // outputVerboseResourceUsage outputs the verbose resource usage for the passed
 // task
 func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsage *api.ResourceUsage) {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -236,6 +237,68 @@ func TestAllocStatusCommand_RescheduleInfo(t *testing.T) {
 	must.RegexMatch(t, regexp.MustCompile(".*Reschedule Attempts\\s*=\\s*1/2"), out)
 }
 
+func TestAllocStatusCommand_formatAllocRescheduleEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	events := []*api.RescheduleEvent{
+		{
+			RescheduleTime: time.Now().UnixNano(),
+			PrevAllocID:    "11111111-2222-3333-4444-555555555555",
+			PrevNodeID:     "66666666-7777-8888-9999-000000000000",
+			Delay:          30 * time.Second,
+		},
+		{
+			RescheduleTime: time.Now().UnixNano(),
+			PrevAllocID:    "aaaaaaaa-2222-3333-4444-555555555555",
+			PrevNodeID:     "bbbbbbbb-7777-8888-9999-000000000000",
+			Delay:          5 * time.Minute,
+			Jitter:         12 * time.Second,
+			DelaySource:    "node_pool:dev",
+		},
+	}
+
+	out := formatAllocRescheduleEvents(events, shortId)
+	must.RegexMatch(t, regexp.MustCompile(`11111111\s+66666666\s+30s\s+0s\s+<none>`), out)
+	must.RegexMatch(t, regexp.MustCompile(`aaaaaaaa\s+bbbbbbbb\s+5m0s\s+12s\s+node_pool:dev`), out)
+}
+
+func TestAllocStatusCommand_outputTaskUsageBeforeExit(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocStatusCommand{Meta: Meta{Ui: ui}}
+
+	start := time.Now()
+	sample := func(offset time.Duration, rss uint64, ticks float64) *api.TaskResourceUsage {
+		return &api.TaskResourceUsage{
+			ResourceUsage: &api.ResourceUsage{
+				MemoryStats: &api.MemoryStats{RSS: rss, Measured: []string{"RSS"}},
+				CpuStats:    &api.CpuStats{TotalTicks: ticks},
+			},
+			Timestamp: start.Add(offset).UnixNano(),
+		}
+	}
+	history := &api.TaskResourceUsageHistory{
+		Samples: []*api.TaskResourceUsage{
+			sample(0, 64*1024*1024, 100),
+			sample(10*time.Second, 128*1024*1024, 400),
+			sample(20*time.Second, 256*1024*1024, 200),
+		},
+		Resolution: 10 * time.Second,
+	}
+
+	// the history of a running task is not output
+	cmd.outputTaskUsageBeforeExit(history)
+	must.Eq(t, "", ui.OutputWriter.String())
+
+	history.ExitedAt = start.Add(25 * time.Second).UnixNano()
+	cmd.outputTaskUsageBeforeExit(history)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Resource usage before exit (25s before")
+	must.RegexMatch(t, regexp.MustCompile(`Memory\s+256 MiB\s+256 MiB\s+▂▄█`), out)
+	must.RegexMatch(t, regexp.MustCompile(`CPU\s+200 MHz\s+400 MHz\s+▂█▄`), out)
+}
+
 func TestAllocStatusCommand_ScoreMetrics(t *testing.T) {
 	ci.Parallel(t)
 	srv, client, url := testServer(t, true, nil)
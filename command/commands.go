@@ -265,6 +265,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc network": func() (cli.Command, error) {
+			return &AllocNetworkCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc pause": func() (cli.Command, error) {
 			return &AllocPauseCommand{
 				Meta: meta,
@@ -491,6 +496,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job periodic status": func() (cli.Command, error) {
+			return &JobPeriodicStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job plan": func() (cli.Command, error) {
 			return &JobPlanCommand{
 				Meta: meta,
@@ -812,6 +822,26 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 			}, nil
 		},
 
+		"operator maintenance": func() (cli.Command, error) {
+			return &OperatorMaintenanceCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator maintenance apply": func() (cli.Command, error) {
+			return &OperatorMaintenanceApplyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator maintenance delete": func() (cli.Command, error) {
+			return &OperatorMaintenanceDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator maintenance list": func() (cli.Command, error) {
+			return &OperatorMaintenanceListCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator metrics": func() (cli.Command, error) {
 			return &OperatorMetricsCommand{
 				Meta: meta,
@@ -854,6 +884,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator raft status": func() (cli.Command, error) {
+			return &OperatorRaftStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator scheduler": func() (cli.Command, error) {
 			return &OperatorSchedulerCommand{
 				Meta: meta,
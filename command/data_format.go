@@ -6,6 +6,8 @@ package command
 import (
 	"bytes"
 	"fmt"
+	"reflect"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
@@ -70,11 +72,54 @@ func (p *TemplateFormat) TransformData(data interface{}) (string, error) {
 
 	err = t.Execute(&out, data)
 	if err != nil {
+		if fields := templateFields(data); fields != "" {
+			return "", fmt.Errorf("%w (%s)", err, fields)
+		}
 		return "", err
 	}
 	return out.String(), nil
 }
 
+// templateFields describes the fields available at the top level of a template
+// executed over data, to help fixing templates which fail to execute. An empty
+// string is returned if data is not a struct or a list of structs.
+func templateFields(data interface{}) string {
+	t := reflect.TypeOf(data)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+
+	list := t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+	if list {
+		t = t.Elem()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() {
+			fields = append(fields, f.Name)
+		}
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+
+	if list {
+		return fmt.Sprintf("data is a list, use {{range .}} to access the fields of its items: %s",
+			strings.Join(fields, ", "))
+	}
+	return fmt.Sprintf("available fields are: %s", strings.Join(fields, ", "))
+}
+
 func Format(json bool, template string, data interface{}) (string, error) {
 	var format string
 	if json && len(template) > 0 {
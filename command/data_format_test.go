@@ -79,3 +79,31 @@ func TestDataFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestDataFormat_templateFields(t *testing.T) {
+	ci.Parallel(t)
+	type testData struct {
+		Region string
+		ID     string
+		secret string
+	}
+
+	fm, err := DataFormat("template", "{{.Name}}")
+	must.NoError(t, err)
+
+	_, err = fm.TransformData(&testData{})
+	must.ErrorContains(t, err, "can't evaluate field Name")
+	must.ErrorContains(t, err, "available fields are: Region, ID)")
+
+	_, err = fm.TransformData([]*testData{{}})
+	must.ErrorContains(t, err, "data is a list, use {{range .}} to access the fields of its items: Region, ID)")
+
+	_, err = fm.TransformData(map[string]string{})
+	must.NoError(t, err)
+
+	fm, err = DataFormat("template", "{{.Name.First}}")
+	must.NoError(t, err)
+	_, err = fm.TransformData(map[string]string{"Name": "x"})
+	must.ErrorContains(t, err, "can't evaluate field First")
+	must.StrNotContains(t, err.Error(), "available fields")
+}
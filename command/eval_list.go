@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -25,7 +26,7 @@ Usage: nomad eval list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsStale) + `
 
 Eval List Options:
 
@@ -50,9 +51,14 @@ Eval List Options:
   -json
     Output the evaluation in its JSON format.
 
-  -t
+  -t, -template
     Format and display evaluation using a Go template.
 
+  -o
+    Display the list of evaluations with the given columns, either "wide" for
+    all the available columns or a comma-separated list of columns such as
+    "columns=ID,Job ID,Status".
+
   -ui
     Open the evaluations page in the browser.
 `
@@ -65,10 +71,12 @@ func (c *EvalListCommand) Synopsis() string {
 }
 
 func (c *EvalListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetStale),
 		complete.Flags{
 			"-json":       complete.PredictNothing,
 			"-t":          complete.PredictAnything,
+			"-template":   complete.PredictAnything,
+			"-o":          complete.PredictAnything,
 			"-verbose":    complete.PredictNothing,
 			"-filter":     complete.PredictAnything,
 			"-job":        complete.PredictAnything,
@@ -99,14 +107,16 @@ func (c *EvalListCommand) Name() string { return "eval list" }
 func (c *EvalListCommand) Run(args []string) int {
 	var monitor, verbose, json, openURL bool
 	var perPage int
-	var tmpl, pageToken, filter, filterJobID, filterStatus string
+	var tmpl, output, pageToken, filter, filterJobID, filterStatus string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetStale)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&monitor, "monitor", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
+	flags.StringVar(&tmpl, "template", "", "")
+	flags.StringVar(&output, "o", "", "")
 	flags.BoolVar(&openURL, "ui", false, "")
 	flags.IntVar(&perPage, "per-page", 0, "")
 	flags.StringVar(&pageToken, "page-token", "", "")
@@ -125,6 +135,10 @@ func (c *EvalListCommand) Run(args []string) int {
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
+	if err := validateOutputFlags(json, tmpl, output); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
 
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -169,7 +183,20 @@ func (c *EvalListCommand) Run(args []string) int {
 		return 0
 	}
 
-	c.Ui.Output(formatEvalList(evals, verbose))
+	if output != "" {
+		length := shortId
+		if verbose {
+			length = fullId
+		}
+		out, err := formatOutput(output, evals, evalListColumns(length))
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+	} else {
+		c.Ui.Output(formatEvalList(evals, verbose))
+	}
 
 	if qm.NextToken != "" {
 		c.Ui.Output(fmt.Sprintf(`
@@ -240,3 +267,25 @@ func formatEvalList(evals []*api.Evaluation, verbose bool) string {
 
 	return formatList(out)
 }
+
+// evalListColumns returns the columns of the list of evaluations which can be
+// selected with the -o flag, with IDs truncated to uuidLength.
+func evalListColumns(uuidLength int) []outputColumn[*api.Evaluation] {
+	return []outputColumn[*api.Evaluation]{
+		{name: "ID", value: func(e *api.Evaluation) string { return limit(e.ID, uuidLength) }},
+		{name: "Priority", value: func(e *api.Evaluation) string { return strconv.Itoa(e.Priority) }},
+		{name: "Type", value: func(e *api.Evaluation) string { return e.Type }},
+		{name: "Triggered By", value: func(e *api.Evaluation) string { return e.TriggeredBy }},
+		{name: "Job ID", value: func(e *api.Evaluation) string { return e.JobID }},
+		{name: "Namespace", value: func(e *api.Evaluation) string { return e.Namespace }},
+		{name: "Node ID", value: func(e *api.Evaluation) string { return limit(e.NodeID, uuidLength) }},
+		{name: "Deployment ID", value: func(e *api.Evaluation) string { return limit(e.DeploymentID, uuidLength) }},
+		{name: "Status", value: func(e *api.Evaluation) string { return e.Status }},
+		{name: "Placement Failures", value: func(e *api.Evaluation) string {
+			failures, _ := evalFailureStatus(e)
+			return failures
+		}},
+		{name: "Created", value: func(e *api.Evaluation) string { return formatUnixNanoTime(e.CreateTime) }},
+		{name: "Modified", value: func(e *api.Evaluation) string { return formatUnixNanoTime(e.ModifyTime) }},
+	}
+}
@@ -31,7 +31,7 @@ Usage: nomad eval status [options] <evaluation>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsStale) + `
 
 Eval Status Options:
 
@@ -61,7 +61,7 @@ func (c *EvalStatusCommand) Synopsis() string {
 }
 
 func (c *EvalStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetStale),
 		complete.Flags{
 			"-json":    complete.PredictNothing,
 			"-monitor": complete.PredictNothing,
@@ -92,7 +92,7 @@ func (c *EvalStatusCommand) Run(args []string) int {
 	var monitor, verbose, json, openURL bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetStale)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&monitor, "monitor", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
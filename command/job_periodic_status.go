@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type JobPeriodicStatusCommand struct {
+	Meta
+}
+
+func (c *JobPeriodicStatusCommand) Help() string {
+	helpText := `
+Usage: nomad job periodic status <job id>
+
+  This command is used to display the schedule of a periodic job: its latest
+  launch and its next three launches. The next launches skip the dates the job
+  excludes, including those of its exclusion calendar.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobPeriodicStatusCommand) Synopsis() string {
+	return "Display the upcoming launches of a periodic job"
+}
+
+func (c *JobPeriodicStatusCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *JobPeriodicStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Jobs().PrefixList(a.Last)
+		if err != nil {
+			return []string{}
+		}
+
+		// filter this by periodic jobs
+		matches := make([]string, 0, len(resp))
+		for _, job := range resp {
+			if job.Periodic {
+				matches = append(matches, job.ID)
+			}
+		}
+		return matches
+	})
+}
+
+func (c *JobPeriodicStatusCommand) Name() string { return "job periodic status" }
+
+func (c *JobPeriodicStatusCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, func(j *api.JobListStub) bool {
+		return j.Periodic
+	})
+	if err != nil {
+		var noPrefixErr *NoJobWithPrefixError
+		if errors.As(err, &noPrefixErr) {
+			err = fmt.Errorf("No periodic job(s) with prefix or ID %q found", jobIDPrefix)
+		}
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	q := &api.QueryOptions{Namespace: namespace}
+
+	job, _, err := client.Jobs().Info(jobID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying job %q: %s", jobID, err))
+		return 1
+	}
+
+	status, _, err := client.Jobs().PeriodicStatus(jobID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying periodic status of job %q: %s", jobID, err))
+		return 1
+	}
+
+	c.Ui.Output(c.formatStatus(job, status, time.Now()))
+	if status.ExclusionCalendarError != "" {
+		c.Ui.Warn(fmt.Sprintf("\nThe exclusion calendar could not be read, launches are not excluded by it: %s",
+			status.ExclusionCalendarError))
+	}
+	return 0
+}
+
+// formatStatus formats the periodic configuration of job and the launches of
+// its status, relative to now.
+func (c *JobPeriodicStatusCommand) formatStatus(job *api.Job, status *api.PeriodicStatus, now time.Time) string {
+	periodic := job.Periodic
+
+	crons := periodic.Specs
+	if periodic.Spec != nil && *periodic.Spec != "" {
+		crons = []string{*periodic.Spec}
+	}
+
+	lastLaunch := "none"
+	if !status.LastLaunch.IsZero() {
+		lastLaunch = fmt.Sprintf("%s (%s ago)",
+			formatTime(status.LastLaunch), formatTimeDifference(status.LastLaunch, now, time.Second))
+	}
+
+	basic := []string{
+		fmt.Sprintf("ID|%s", *job.ID),
+		fmt.Sprintf("Crons|%s", strings.Join(crons, ", ")),
+		fmt.Sprintf("Time Zone|%s", pointerOrEmpty(periodic.TimeZone)),
+		fmt.Sprintf("Exclusions|%s", strings.Join(periodic.Exclusions, ", ")),
+		fmt.Sprintf("Exclusion Calendar|%s", pointerOrEmpty(periodic.ExclusionCalendar)),
+		fmt.Sprintf("Last Launch|%s", lastLaunch),
+	}
+	out := formatKV(basic)
+
+	out += c.Colorize().Color("\n\n[bold]Next Launches[reset]\n")
+	if len(status.NextLaunches) == 0 {
+		return out + "No upcoming launches"
+	}
+	launches := make([]string, len(status.NextLaunches))
+	for i, launch := range status.NextLaunches {
+		launches[i] = fmt.Sprintf("%s (%s from now)",
+			formatTime(launch), formatTimeDifference(now, launch, time.Second))
+	}
+	return out + strings.Join(launches, "\n")
+}
+
+// pointerOrEmpty returns the string s points to, or an empty string if s is
+// nil.
+func pointerOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobPeriodicStatusCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobPeriodicStatusCommand{}
+}
+
+func TestJobPeriodicStatusCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobPeriodicStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	out := ui.ErrorWriter.String()
+	must.StrContains(t, out, commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "12"})
+	must.One(t, code)
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "Error querying job prefix")
+}
+
+func TestJobPeriodicStatusCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Register a job launching every 15 minutes on weekdays and hourly on
+	// weekends, except on Christmas
+	j := testJob("job1_is_periodic")
+	j.Periodic = &api.PeriodicConfig{
+		SpecType:   pointer.Of(api.PeriodicSpecCron),
+		Specs:      []string{"*/15 * * * MON-FRI", "0 * * * SAT,SUN"},
+		Exclusions: []string{"*-12-25"},
+	}
+	_, _, err := client.Jobs().Register(j, nil)
+	must.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	cmd := &JobPeriodicStatusCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "job1_is_periodic"})
+	must.Zero(t, code)
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "*/15 * * * MON-FRI, 0 * * * SAT,SUN")
+	must.StrContains(t, out, "*-12-25")
+	must.StrContains(t, out, "Next Launches")
+	must.StrContains(t, out, "from now")
+}
+
+func TestJobPeriodicStatusCommand_formatStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	cmd := &JobPeriodicStatusCommand{Meta: Meta{Ui: cli.NewMockUi()}}
+	job := &api.Job{
+		ID: pointer.Of("report"),
+		Periodic: &api.PeriodicConfig{
+			Spec:              pointer.Of("0 * * * *"),
+			TimeZone:          pointer.Of("UTC"),
+			ExclusionCalendar: pointer.Of("nomad/jobs/report/holidays"),
+		},
+	}
+	now := time.Date(2024, time.December, 24, 10, 30, 0, 0, time.UTC)
+	status := &api.PeriodicStatus{
+		LastLaunch: now.Add(-30 * time.Minute),
+		NextLaunches: []time.Time{
+			now.Add(30 * time.Minute),
+			now.Add(90 * time.Minute),
+		},
+	}
+
+	out := cmd.formatStatus(job, status, now)
+	must.StrContains(t, out, "nomad/jobs/report/holidays")
+	must.StrContains(t, out, "(30m0s ago)")
+	must.StrContains(t, out, "(30m0s from now)")
+	must.StrContains(t, out, "(1h30m0s from now)")
+
+	// a stopped job has no upcoming launches
+	status.NextLaunches = nil
+	must.StrContains(t, cmd.formatStatus(job, status, now), "No upcoming launches")
+}
@@ -56,7 +56,10 @@ Alias: nomad plan
   Multiregion jobs do not return a job modify index.
 
   A structured diff between the local and remote job is displayed to
-  give insight into what the scheduler will attempt to do and why.
+  give insight into what the scheduler will attempt to do and why. With
+  "-diff-format=json" the plan is output as JSON instead, including the diff,
+  the scheduler annotations and a change class summarizing the effect of the
+  plan on the job: "none", "in-place", "destructive" or "scale-only".
 
   If the job has specified the region, the -region flag and NOMAD_REGION
   environment variable are overridden and the job's region is used.
@@ -79,6 +82,11 @@ Plan Options:
     Determines whether the diff between the remote job and planned job is shown.
     Defaults to true.
 
+  -diff-format=<human|json>
+    Format of the plan output. The "json" format outputs the plan response,
+    including the diff, as JSON, keyed by region for multiregion jobs.
+    Defaults to "human".
+
   -json
     Parses the job file as JSON. If the outer object has a Job field, such as
     from "nomad job inspect" or "nomad run -output", the value of the field is
@@ -116,6 +124,7 @@ func (c *JobPlanCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-diff":            complete.PredictNothing,
+			"-diff-format":     complete.PredictSet("human", "json"),
 			"-policy-override": complete.PredictNothing,
 			"-verbose":         complete.PredictNothing,
 			"-json":            complete.PredictNothing,
@@ -137,11 +146,12 @@ func (c *JobPlanCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobPlanCommand) Name() string { return "job plan" }
 func (c *JobPlanCommand) Run(args []string) int {
 	var diff, policyOverride, verbose bool
-	var vaultNamespace string
+	var diffFormat, vaultNamespace string
 
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flagSet.Usage = func() { c.Ui.Output(c.Help()) }
 	flagSet.BoolVar(&diff, "diff", true, "")
+	flagSet.StringVar(&diffFormat, "diff-format", "human", "")
 	flagSet.BoolVar(&policyOverride, "policy-override", false, "")
 	flagSet.BoolVar(&verbose, "verbose", false, "")
 	flagSet.BoolVar(&c.JobGetter.JSON, "json", false, "")
@@ -167,6 +177,12 @@ func (c *JobPlanCommand) Run(args []string) int {
 		return 255
 	}
 
+	if diffFormat != "human" && diffFormat != "json" {
+		c.Ui.Error(fmt.Sprintf("Invalid -diff-format %q, must be one of: human, json", diffFormat))
+		return 255
+	}
+	jsonOutput := diffFormat == "json"
+
 	path := args[0]
 	// Get Job struct from Jobfile
 	_, job, err := c.JobGetter.Get(path)
@@ -207,7 +223,7 @@ func (c *JobPlanCommand) Run(args []string) int {
 	}
 
 	if job.IsMultiregion() {
-		return c.multiregionPlan(client, job, opts, diff, verbose)
+		return c.multiregionPlan(client, job, opts, diff, verbose, jsonOutput)
 	}
 
 	// Submit the job
@@ -217,6 +233,10 @@ func (c *JobPlanCommand) Run(args []string) int {
 		return 255
 	}
 
+	if jsonOutput {
+		return c.outputPlanJSON(resp, getExitCode(resp))
+	}
+
 	runArgs := strings.Builder{}
 	for _, varArg := range c.JobGetter.Vars {
 		runArgs.WriteString(fmt.Sprintf("-var=%q ", varArg))
@@ -235,7 +255,7 @@ func (c *JobPlanCommand) Run(args []string) int {
 	return exitCode
 }
 
-func (c *JobPlanCommand) multiregionPlan(client *api.Client, job *api.Job, opts *api.PlanOptions, diff, verbose bool) int {
+func (c *JobPlanCommand) multiregionPlan(client *api.Client, job *api.Job, opts *api.PlanOptions, diff, verbose, jsonOutput bool) int {
 
 	var exitCode int
 	plans := map[string]*api.JobPlanResponse{}
@@ -258,6 +278,13 @@ func (c *JobPlanCommand) multiregionPlan(client *api.Client, job *api.Job, opts
 		return exitCode
 	}
 
+	if jsonOutput {
+		for _, resp := range plans {
+			exitCode = max(exitCode, getExitCode(resp))
+		}
+		return c.outputPlanJSON(plans, exitCode)
+	}
+
 	for regionName, resp := range plans {
 		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]Region: %q[reset]", regionName)))
 		regionExitCode := c.outputPlannedJob(job, resp, diff, verbose)
@@ -295,6 +322,18 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 	return getExitCode(resp)
 }
 
+// outputPlanJSON outputs plan as JSON and returns exitCode, unless the plan
+// cannot be formatted.
+func (c *JobPlanCommand) outputPlanJSON(plan any, exitCode int) int {
+	out, err := Format(true, "", plan)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting plan: %s", err))
+		return 255
+	}
+	c.Ui.Output(out)
+	return exitCode
+}
+
 // addPreemptions shows details about preempted allocations
 func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
 	c.Ui.Output(c.Colorize().Color("[bold][yellow]Preemptions:\n[reset]"))
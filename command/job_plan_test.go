@@ -4,6 +4,7 @@
 package command
 
 import (
+	"encoding/json"
 	"os"
 	"strconv"
 	"strings"
@@ -306,3 +307,32 @@ func TestPlanCommand_JSON(t *testing.T) {
 	must.Eq(t, 255, code)
 	must.StrContains(t, ui.ErrorWriter.String(), "Error during plan: Put")
 }
+
+func TestPlanCommand_DiffFormat(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("invalid", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobPlanCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=http://nope", "-diff-format=yaml", "testdata/example-basic.nomad"})
+		must.Eq(t, 255, code)
+		must.StrContains(t, ui.ErrorWriter.String(), `Invalid -diff-format "yaml"`)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		s := testutil.NewTestServer(t, nil)
+		defer s.Stop()
+
+		ui := cli.NewMockUi()
+		cmd := &JobPlanCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address", "http://" + s.HTTPAddr, "-diff-format=json", "testdata/example-basic.nomad"})
+		must.One(t, code) // no client running, fail to place
+
+		var resp api.JobPlanResponse
+		must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &resp))
+		must.Eq(t, api.PlanChangeClassInPlace, resp.ChangeClass)
+		must.Eq(t, api.DiffTypeAdded, resp.Diff.Type)
+		must.NotNil(t, resp.Annotations)
+		must.MapNotEmpty(t, resp.FailedTGAllocs)
+	})
+}
@@ -28,9 +28,14 @@ type JobScaleCommand struct {
 func (j *JobScaleCommand) Help() string {
 	helpText := `
 Usage: nomad job scale [options] <job> [<group>] <count>
+       nomad job scale [options] <job> <group>=<count> [<group>=<count>...]
 
   Perform a scaling action by altering the count within a job group.
 
+  Several groups can be scaled at once by passing their new counts as
+  <group>=<count> pairs. All of the groups are scaled together, with a single
+  new job version and evaluation.
+
   Upon successful job submission, this command will immediately
   enter an interactive monitor. This is useful to watch Nomad's
   internals make scheduling decisions and place the submitted work
@@ -98,11 +103,20 @@ func (j *JobScaleCommand) Run(args []string) int {
 	}
 
 	var countString, groupString string
+	var counts map[string]int64
 	args = flags.Args()
 
-	// It is possible to specify either 2 or 3 arguments. Check and assign the
-	// args so they can be validate later on.
-	if numArgs := len(args); numArgs < 2 || numArgs > 3 {
+	// It is possible to specify either 2 or 3 arguments, or any number of
+	// group=count pairs. Check and assign the args so they can be validated
+	// later on.
+	if len(args) >= 2 && strings.Contains(args[1], "=") {
+		var err error
+		counts, err = parseGroupCounts(args[1:])
+		if err != nil {
+			j.Ui.Error(err.Error())
+			return 1
+		}
+	} else if numArgs := len(args); numArgs < 2 || numArgs > 3 {
 		j.Ui.Error("Command requires at least two arguments and no more than three")
 		return 1
 	} else if numArgs == 3 {
@@ -113,10 +127,14 @@ func (j *JobScaleCommand) Run(args []string) int {
 	}
 
 	// Convert the count string arg to an int as required by the API.
-	count, err := strconv.Atoi(countString)
-	if err != nil {
-		j.Ui.Error(fmt.Sprintf("Failed to convert count string to int: %s", err))
-		return 1
+	var count int
+	if counts == nil {
+		var err error
+		count, err = strconv.Atoi(countString)
+		if err != nil {
+			j.Ui.Error(fmt.Sprintf("Failed to convert count string to int: %s", err))
+			return 1
+		}
 	}
 
 	// Get the HTTP client.
@@ -143,9 +161,11 @@ func (j *JobScaleCommand) Run(args []string) int {
 		return 1
 	}
 
-	if err := j.performGroupCheck(jobScaleStatusResp.TaskGroups, &groupString); err != nil {
-		j.Ui.Error(err.Error())
-		return 1
+	if counts == nil {
+		if err := j.performGroupCheck(jobScaleStatusResp.TaskGroups, &groupString); err != nil {
+			j.Ui.Error(err.Error())
+			return 1
+		}
 	}
 
 	// This is our default message added to scaling submissions.
@@ -163,6 +183,11 @@ func (j *JobScaleCommand) Run(args []string) int {
 		PolicyOverride: false,
 		JobModifyIndex: checkIndex,
 	}
+	if counts != nil {
+		req.Count = nil
+		req.Target = map[string]string{"Job": jobID}
+		req.Counts = counts
+	}
 
 	resp, _, err := client.Jobs().ScaleWithRequest(jobID, req, w)
 	if err != nil {
@@ -250,3 +275,23 @@ func (j *JobScaleCommand) performGroupCheck(groups map[string]api.TaskGroupScale
 	// If we got here, we didn't find a match and therefore return an error.
 	return fmt.Errorf("Group %v not found within job", *group)
 }
+
+// parseGroupCounts parses the <group>=<count> pairs of the groups to scale.
+func parseGroupCounts(args []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(args))
+	for _, arg := range args {
+		group, countString, ok := strings.Cut(arg, "=")
+		if !ok || group == "" {
+			return nil, fmt.Errorf("Invalid group count %q, expected <group>=<count>", arg)
+		}
+		if _, exists := counts[group]; exists {
+			return nil, fmt.Errorf("Group %q specified more than once", group)
+		}
+		count, err := strconv.ParseInt(countString, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to convert count string for group %q to int: %s", group, err)
+		}
+		counts[group] = count
+	}
+	return counts, nil
+}
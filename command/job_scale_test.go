@@ -128,6 +128,38 @@ func TestJobScaleCommand_MultiGroup(t *testing.T) {
 	if out := ui.OutputWriter.String(); !strings.Contains(out, "Evaluation ID:") {
 		t.Fatalf("Expected Evaluation ID within output: %v", out)
 	}
+
+	// Scale both groups at once.
+	code := cmd.Run([]string{"-address=" + url, "-detach", "scale_cmd_multi_group", "group1=3", "group2=2"})
+	must.Zero(t, code)
+
+	info, _, err := client.Jobs().Info("scale_cmd_multi_group", nil)
+	must.NoError(t, err)
+	for _, tg := range info.TaskGroups {
+		switch *tg.Name {
+		case "group1":
+			must.Eq(t, 3, *tg.Count)
+		case "group2":
+			must.Eq(t, 2, *tg.Count)
+		}
+	}
+}
+
+func TestJobScaleCommand_parseGroupCounts(t *testing.T) {
+	ci.Parallel(t)
+
+	counts, err := parseGroupCounts([]string{"web=5", "worker=10"})
+	must.NoError(t, err)
+	must.Eq(t, map[string]int64{"web": 5, "worker": 10}, counts)
+
+	_, err = parseGroupCounts([]string{"web=5", "worker"})
+	must.ErrorContains(t, err, `Invalid group count "worker"`)
+
+	_, err = parseGroupCounts([]string{"web=five"})
+	must.ErrorContains(t, err, `Failed to convert count string for group "web"`)
+
+	_, err = parseGroupCounts([]string{"web=1", "web=2"})
+	must.ErrorContains(t, err, `Group "web" specified more than once`)
 }
 
 func TestJobScaleCommand_Parameterized(t *testing.T) {
@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ type JobStatusCommand struct {
 	verbose   bool
 	json      bool
 	tmpl      string
+	output    string
 	openURL   bool
 }
 
@@ -57,7 +59,7 @@ Usage: nomad job status [options] <job>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsStale) + `
 
 Status Options:
 
@@ -75,6 +77,17 @@ Status Options:
   -verbose
     Display full information.
 
+  -json
+    Output the job status in its JSON format.
+
+  -t, -template
+    Format and display the job status using a Go template.
+
+  -o
+    Display the list of jobs with the given columns, either "wide" for all the
+    available columns or a comma-separated list of columns such as
+    "columns=Name,Status,Running". Only used when no job is given.
+
   -ui
     Open the job status page in the browser.
 `
@@ -86,12 +99,16 @@ func (c *JobStatusCommand) Synopsis() string {
 }
 
 func (c *JobStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetStale),
 		complete.Flags{
 			"-all-allocs": complete.PredictNothing,
 			"-evals":      complete.PredictNothing,
 			"-short":      complete.PredictNothing,
 			"-verbose":    complete.PredictNothing,
+			"-json":       complete.PredictNothing,
+			"-t":          complete.PredictAnything,
+			"-template":   complete.PredictAnything,
+			"-o":          complete.PredictAnything,
 			"-ui":         complete.PredictNothing,
 		})
 }
@@ -116,13 +133,15 @@ func (c *JobStatusCommand) Name() string { return "status" }
 func (c *JobStatusCommand) Run(args []string) int {
 	var short bool
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetStale)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&short, "short", false, "")
 	flags.BoolVar(&c.evals, "evals", false, "")
 	flags.BoolVar(&c.allAllocs, "all-allocs", false, "")
 	flags.BoolVar(&c.json, "json", false, "")
 	flags.StringVar(&c.tmpl, "t", "", "")
+	flags.StringVar(&c.tmpl, "template", "", "")
+	flags.StringVar(&c.output, "o", "", "")
 	flags.BoolVar(&c.verbose, "verbose", false, "")
 	flags.BoolVar(&c.openURL, "ui", false, "")
 
@@ -137,6 +156,14 @@ func (c *JobStatusCommand) Run(args []string) int {
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
+	if err := validateOutputFlags(c.json, c.tmpl, c.output); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	if c.output != "" && len(args) != 0 {
+		c.Ui.Error("-o can only be used to list jobs")
+		return 1
+	}
 
 	// Truncate the id unless full length is requested
 	c.length = shortId
@@ -192,6 +219,14 @@ func (c *JobStatusCommand) Run(args []string) int {
 					return 1
 				}
 
+				c.Ui.Output(out)
+			} else if c.output != "" {
+				out, err := formatOutput(c.output, jobs, jobStatusColumns)
+				if err != nil {
+					c.Ui.Error(err.Error())
+					return 1
+				}
+
 				c.Ui.Output(out)
 			} else {
 				c.Ui.Output(createStatusListOutput(jobs, allNamespaces))
@@ -874,6 +909,40 @@ func createStatusListOutput(jobs []*api.JobListStub, displayNS bool) string {
 	return formatList(out)
 }
 
+// jobStatusColumns are the columns of the list of jobs which can be selected
+// with the -o flag.
+var jobStatusColumns = []outputColumn[*api.JobListStub]{
+	{name: "ID", value: func(j *api.JobListStub) string { return j.ID }},
+	{name: "Name", value: func(j *api.JobListStub) string { return j.Name }},
+	{name: "Namespace", value: func(j *api.JobListStub) string { return j.Namespace }},
+	{name: "Datacenters", value: func(j *api.JobListStub) string { return strings.Join(j.Datacenters, ",") }},
+	{name: "Type", value: getTypeString},
+	{name: "Priority", value: func(j *api.JobListStub) string { return strconv.Itoa(j.Priority) }},
+	{name: "Status", value: func(j *api.JobListStub) string { return getStatusString(j.Status, &j.Stop) }},
+	{name: "Submit Date", value: func(j *api.JobListStub) string { return formatTime(time.Unix(0, j.SubmitTime)) }},
+	{name: "Queued", value: jobAllocCount(func(s api.TaskGroupSummary) int { return s.Queued })},
+	{name: "Starting", value: jobAllocCount(func(s api.TaskGroupSummary) int { return s.Starting })},
+	{name: "Running", value: jobAllocCount(func(s api.TaskGroupSummary) int { return s.Running })},
+	{name: "Complete", value: jobAllocCount(func(s api.TaskGroupSummary) int { return s.Complete })},
+	{name: "Failed", value: jobAllocCount(func(s api.TaskGroupSummary) int { return s.Failed })},
+	{name: "Lost", value: jobAllocCount(func(s api.TaskGroupSummary) int { return s.Lost })},
+	{name: "Unknown", value: jobAllocCount(func(s api.TaskGroupSummary) int { return s.Unknown })},
+}
+
+// jobAllocCount returns a function summing the count of allocations returned
+// by count across the task groups of a job.
+func jobAllocCount(count func(api.TaskGroupSummary) int) func(*api.JobListStub) string {
+	return func(j *api.JobListStub) string {
+		total := 0
+		if j.JobSummary != nil {
+			for _, summary := range j.JobSummary.Summary {
+				total += count(summary)
+			}
+		}
+		return strconv.Itoa(total)
+	}
+}
+
 func getTypeString(job *api.JobListStub) string {
 	t := job.Type
 
@@ -4,12 +4,14 @@
 package command
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/cap/util"
 	"github.com/hashicorp/cli"
@@ -35,6 +37,10 @@ const (
 	FlagSetNone    FlagSetFlags = 0
 	FlagSetClient  FlagSetFlags = 1 << iota
 	FlagSetDefault              = FlagSetClient
+
+	// FlagSetStale enables the flags for allowing stale reads, for
+	// commands which only read.
+	FlagSetStale FlagSetFlags = 1 << iota
 )
 
 // Meta contains the meta-options and functionality that nearly every
@@ -70,6 +76,11 @@ type Meta struct {
 	clientKey     string
 	tlsServerName string
 	insecure      bool
+
+	// stale and maxStale are set by the -stale and -max-stale flags of
+	// commands with FlagSetStale
+	stale    bool
+	maxStale time.Duration
 }
 
 // FlagSet returns a FlagSet with the common flags that every
@@ -98,6 +109,22 @@ func (m *Meta) FlagSet(n string, fs FlagSetFlags) *flag.FlagSet {
 
 	}
 
+	// FlagSetStale is used to enable the settings for allowing stale reads.
+	if fs&FlagSetStale != 0 {
+		f.BoolVar(&m.stale, "stale", false, "")
+		f.Var(funcVar(func(s string) error {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			if d < 0 {
+				return errors.New("must not be negative")
+			}
+			m.maxStale = d
+			return nil
+		}), "max-stale", "")
+	}
+
 	f.SetOutput(&uiErrorWriter{ui: m.Ui})
 
 	return f
@@ -109,7 +136,7 @@ func (m *Meta) AutocompleteFlags(fs FlagSetFlags) complete.Flags {
 		return nil
 	}
 
-	return complete.Flags{
+	flags := complete.Flags{
 		"-address":         complete.PredictAnything,
 		"-region":          complete.PredictAnything,
 		"-namespace":       NamespacePredictor(m.Client, nil),
@@ -124,6 +151,11 @@ func (m *Meta) AutocompleteFlags(fs FlagSetFlags) complete.Flags {
 		"-tls-skip-verify": complete.PredictNothing,
 		"-token":           complete.PredictAnything,
 	}
+	if fs&FlagSetStale != 0 {
+		flags["-stale"] = complete.PredictNothing
+		flags["-max-stale"] = complete.PredictAnything
+	}
+	return flags
 }
 
 // askQuestion asks question to user until they provide a valid response.
@@ -198,6 +230,13 @@ func (m *Meta) clientConfig() *api.Config {
 		config.TLSConfig.Insecure = m.insecure
 	}
 
+	if m.stale {
+		config.AllowStale = true
+	}
+	if m.maxStale != 0 {
+		config.MaxStale = m.maxStale
+	}
+
 	return config
 }
 
@@ -391,6 +430,7 @@ type usageOptsFlags uint8
 const (
 	usageOptsDefault     usageOptsFlags = 0
 	usageOptsNoNamespace                = 1 << iota
+	usageOptsStale
 )
 
 // generalOptionsUsage returns the help string for the global options.
@@ -464,11 +504,26 @@ func generalOptionsUsage(usageOpts usageOptsFlags) string {
     Overrides the NOMAD_TOKEN environment variable if set.
 `
 
+	staleText := `
+  -stale
+    Allow any server to answer the queries of the command, rather than only
+    the leader. The results may be stale.
+
+  -max-stale=<duration>
+    Bound how stale the results of the queries may be, for example "5s". A
+    server which has not heard from the leader for longer forwards the queries
+    to the leader instead. Implies -stale.
+`
+
 	if usageOpts&usageOptsNoNamespace == 0 {
 		helpText = helpText + namespaceText
 	}
 
 	helpText = helpText + remainingText
+
+	if usageOpts&usageOptsStale != 0 {
+		helpText = helpText + staleText
+	}
 	return strings.TrimSpace(helpText)
 }
 
@@ -6,10 +6,12 @@ package command
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/creack/pty"
 	"github.com/hashicorp/cli"
@@ -48,6 +50,13 @@ func TestMeta_FlagSet(t *testing.T) {
 				"token",
 			},
 		},
+		{
+			FlagSetStale,
+			[]string{
+				"stale",
+				"max-stale",
+			},
+		},
 	}
 
 	for i, tc := range cases {
@@ -68,6 +77,24 @@ func TestMeta_FlagSet(t *testing.T) {
 	}
 }
 
+func TestMeta_FlagSet_Stale(t *testing.T) {
+	ci.Parallel(t)
+
+	var m Meta
+	fs := m.FlagSet("foo", FlagSetClient|FlagSetStale)
+	must.NoError(t, fs.Parse([]string{"-stale", "-max-stale=5s"}))
+
+	config := m.clientConfig()
+	must.True(t, config.AllowStale)
+	must.Eq(t, 5*time.Second, config.MaxStale)
+
+	m = Meta{}
+	fs = m.FlagSet("foo", FlagSetClient|FlagSetStale)
+	fs.SetOutput(io.Discard)
+	must.ErrorContains(t, fs.Parse([]string{"-max-stale=-5s"}), "must not be negative")
+	must.ErrorContains(t, fs.Parse([]string{"-max-stale=soon"}), "invalid value")
+}
+
 func TestMeta_Colorize(t *testing.T) {
 
 	type testCaseSetupFn func(*testing.T, *Meta)
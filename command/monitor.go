@@ -320,6 +320,16 @@ func (m *monitor) monitor(evalID string) int {
 	return 0
 }
 
+// formatPortCollision describes which allocation holds a static port, e.g.
+// "Port 8080 on 10.0.0.5 in use by alloc 4f2a1b3c (job api-gateway)".
+func formatPortCollision(c *api.PortCollision) string {
+	if c.AllocID == "" {
+		return fmt.Sprintf("Port %d on %s reserved by node %s", c.Port, c.HostIP, limit(c.NodeID, shortId))
+	}
+	return fmt.Sprintf("Port %d on %s in use by alloc %s (job %s)",
+		c.Port, c.HostIP, limit(c.AllocID, shortId), c.JobID)
+}
+
 func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix string) string {
 	// Print a helpful message if we have an eligibility problem
 	var out string
@@ -353,6 +363,9 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 	for dim, num := range metrics.DimensionExhausted {
 		out += fmt.Sprintf("%s* Dimension %q exhausted on %d nodes\n", prefix, dim, num)
 	}
+	for _, c := range metrics.PortCollisions {
+		out += fmt.Sprintf("%s* %s\n", prefix, formatPortCollision(c))
+	}
 
 	// Print quota info
 	for _, dim := range metrics.QuotaExhausted {
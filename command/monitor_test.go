@@ -373,6 +373,32 @@ node-2  1        0        3        0        2
 node-3  0        0        0        4        3
 `,
 		},
+		{
+			Name: "display port collisions",
+			Metrics: &api.AllocationMetric{
+				NodesEvaluated: 2,
+				NodesInPool:    2,
+				PortCollisions: []*api.PortCollision{
+					{
+						NodeID:  "1ad2c5c6-9a0e-4b8a-9a1f-5cbbd0e7b4a1",
+						HostIP:  "10.0.0.5",
+						Port:    8080,
+						Label:   "http",
+						AllocID: "4f2a1b3c-6b9e-4d2c-8f1a-0e5d7c9b3a21",
+						JobID:   "api-gateway",
+					},
+					{
+						NodeID: "9c1e7d2b-3f4a-4e6b-8d0c-2a5b7e9f1c34",
+						HostIP: "10.0.0.6",
+						Port:   22,
+						Label:  "ssh",
+					},
+				},
+			},
+			Expected: `
+* Port 8080 on 10.0.0.5 in use by alloc 4f2a1b3c (job api-gateway)
+* Port 22 on 10.0.0.6 reserved by node 9c1e7d2b`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -18,7 +18,7 @@ type NodeMetaApplyCommand struct {
 
 func (c *NodeMetaApplyCommand) Help() string {
 	helpText := `
-Usage: nomad node meta apply [-node-id ...] [-unset ...] key1=value1 ... kN=vN
+Usage: nomad node meta apply [-node-id ...] [-unset ...] [-cas ...] key1=value1 ... kN=vN
 
   Modify a node's metadata. This command only applies to client agents, and can
   be used to update the scheduling metadata the node registers.
@@ -39,6 +39,11 @@ Node Meta Apply Options:
   -unset key1,...,keyN
     Unset the comma separated list of keys.
 
+  -cas
+    Only apply the changes if the node metadata's modify index matches the
+    given index, as returned by "nomad node meta read". Fails if the metadata
+    has been modified since.
+
   Example:
     $ nomad node meta apply -unset testing,tempvar ready=1 role=preinit-db
 `
@@ -52,18 +57,25 @@ func (c *NodeMetaApplyCommand) Synopsis() string {
 func (c *NodeMetaApplyCommand) Name() string { return "node meta apply" }
 
 func (c *NodeMetaApplyCommand) Run(args []string) int {
-	var unset, nodeID string
+	var unset, nodeID, casStr string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&unset, "unset", "", "")
 	flags.StringVar(&nodeID, "node-id", "", "")
+	flags.StringVar(&casStr, "cas", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 	args = flags.Args()
 
+	casIndex, cas, err := parseCheckIndex(casStr)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing -cas value %q: %s", casStr, err))
+		return 1
+	}
+
 	if unset == "" && len(args) == 0 {
 		c.Ui.Error("Must specify -unset or at least 1 key=value pair")
 		return 1
@@ -94,7 +106,12 @@ func (c *NodeMetaApplyCommand) Run(args []string) int {
 		Meta:   meta,
 	}
 
-	if _, err := client.Nodes().Meta().Apply(&req, nil); err != nil {
+	if cas {
+		_, err = client.Nodes().MetaCAS(&req, casIndex, nil)
+	} else {
+		_, err = client.Nodes().Meta().Apply(&req, nil)
+	}
+	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error applying dynamic node metadata: %s", err))
 		return 1
 	}
@@ -107,6 +124,7 @@ func (c *NodeMetaApplyCommand) AutocompleteFlags() complete.Flags {
 		complete.Flags{
 			"-node-id": complete.PredictNothing,
 			"-unset":   complete.PredictNothing,
+			"-cas":     complete.PredictNothing,
 		})
 }
 
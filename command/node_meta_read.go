@@ -103,7 +103,9 @@ func (c *NodeMetaReadCommand) Run(args []string) int {
 		return 0
 	}
 
-	c.Ui.Output(c.Colorize().Color("[bold]All Meta[reset]"))
+	c.Ui.Output(formatKV([]string{fmt.Sprintf("Modify Index|%d", meta.ModifyIndex)}))
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]All Meta[reset]"))
 	c.Ui.Output(formatNodeMeta(meta.Meta))
 
 	// Print dynamic meta
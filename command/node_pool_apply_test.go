@@ -66,6 +66,11 @@ node_pool "dev" {
   meta {
     test = "true"
   }
+
+  reschedule_overrides {
+    delay  = "5m"
+    jitter = "30s"
+  }
 }`
 	_, err = file.WriteString(hclTestFile)
 	must.NoError(t, err)
@@ -81,6 +86,9 @@ node_pool "dev" {
 	must.NotNil(t, got.Meta)
 	must.Eq(t, "true", got.Meta["test"])
 	must.Eq(t, 720*time.Hour, got.NodeIdentityTTL)
+	must.NotNil(t, got.RescheduleOverrides)
+	must.Eq(t, 5*time.Minute, got.RescheduleOverrides.Delay)
+	must.Eq(t, 30*time.Second, got.RescheduleOverrides.Jitter)
 
 	// Create node pool with JSON file.
 	jsonTestFile := `
@@ -150,5 +150,23 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 		c.Ui.Output("No scheduler configuration")
 	}
 
+	if overrides := pool.RescheduleOverrides; overrides != nil {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Reschedule Overrides[reset]"))
+		var out []string
+		if overrides.Delay != 0 {
+			out = append(out, fmt.Sprintf("Delay|%s", overrides.Delay))
+		}
+		if overrides.DelayFunction != "" {
+			out = append(out, fmt.Sprintf("Delay Function|%s", overrides.DelayFunction))
+		}
+		if overrides.MaxDelay != 0 {
+			out = append(out, fmt.Sprintf("Max Delay|%s", overrides.MaxDelay))
+		}
+		if overrides.Jitter != 0 {
+			out = append(out, fmt.Sprintf("Jitter|%s", overrides.Jitter))
+		}
+		c.Ui.Output(formatKV(out))
+	}
+
 	return 0
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/nomad/api/contexts"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/posener/complete"
+	"github.com/ryanuber/go-glob"
 )
 
 const (
@@ -43,6 +44,7 @@ type NodeStatusCommand struct {
 	pageToken   string
 	filter      string
 	tmpl        string
+	output      string
 	openURL     bool
 }
 
@@ -64,7 +66,7 @@ Usage: nomad node status [options] <node>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsStale) + `
 
 Node Status Options:
 
@@ -105,8 +107,13 @@ Node Status Options:
   -json
     Output the node in its JSON format.
 
-  -t
+  -t, -template
     Format and display node using a Go template.
+
+  -o
+    Display the list of nodes with the given columns, either "wide" for all the
+    available columns or a comma-separated list of columns such as
+    "columns=Name,Node Pool,Status". Only used when no node is given.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -116,7 +123,7 @@ func (c *NodeStatusCommand) Synopsis() string {
 }
 
 func (c *NodeStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetStale),
 		complete.Flags{
 			"-allocs":     complete.PredictNothing,
 			"-filter":     complete.PredictAnything,
@@ -157,7 +164,7 @@ func (c *NodeStatusCommand) Name() string { return "node status" }
 
 func (c *NodeStatusCommand) Run(args []string) int {
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetStale)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&c.short, "short", false, "")
 	flags.BoolVar(&c.os, "os", false, "")
@@ -168,6 +175,8 @@ func (c *NodeStatusCommand) Run(args []string) int {
 	flags.BoolVar(&c.stats, "stats", false, "")
 	flags.BoolVar(&c.json, "json", false, "")
 	flags.StringVar(&c.tmpl, "t", "", "")
+	flags.StringVar(&c.tmpl, "template", "", "")
+	flags.StringVar(&c.output, "o", "", "")
 	flags.StringVar(&c.filter, "filter", "", "")
 	flags.IntVar(&c.perPage, "per-page", 0, "")
 	flags.StringVar(&c.pageToken, "page-token", "", "")
@@ -184,6 +193,14 @@ func (c *NodeStatusCommand) Run(args []string) int {
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
+	if err := validateOutputFlags(c.json, c.tmpl, c.output); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	if c.output != "" && (len(args) != 0 || c.self) {
+		c.Ui.Error("-o can only be used to list nodes")
+		return 1
+	}
 
 	// Truncate the id unless full length is requested
 	c.length = shortId
@@ -200,8 +217,8 @@ func (c *NodeStatusCommand) Run(args []string) int {
 
 	// Use list mode if no node name was provided
 	if len(args) == 0 && !c.self {
-		if c.quiet && (c.verbose || c.json) {
-			c.Ui.Error("-quiet cannot be used with -verbose or -json")
+		if c.quiet && (c.verbose || c.json || c.output != "") {
+			c.Ui.Error("-quiet cannot be used with -verbose, -json or -o")
 			return 1
 		}
 
@@ -215,7 +232,7 @@ func (c *NodeStatusCommand) Run(args []string) int {
 
 		// If the user requested showing the node OS, include this within the
 		// query params.
-		if c.os {
+		if c.os || c.output != "" {
 			opts.Params = map[string]string{"os": "true"}
 		}
 
@@ -238,6 +255,18 @@ func (c *NodeStatusCommand) Run(args []string) int {
 			return 0
 		}
 
+		// If columns are specified, output the node list with them
+		if c.output != "" {
+			out, err := formatOutput(c.output, nodes, nodeStatusColumns(c.length))
+			if err != nil {
+				c.Ui.Error(err.Error())
+				return 1
+			}
+
+			c.Ui.Output(out)
+			return 0
+		}
+
 		// Return nothing if no nodes found
 		if len(nodes) == 0 {
 			c.Ui.Output("No nodes registered")
@@ -386,6 +415,24 @@ Results have been paginated. To get the next page run:
 
 }
 
+// nodeStatusColumns returns the columns of the list of nodes which can be
+// selected with the -o flag, with IDs truncated to uuidLength.
+func nodeStatusColumns(uuidLength int) []outputColumn[*api.NodeListStub] {
+	return []outputColumn[*api.NodeListStub]{
+		{name: "ID", value: func(n *api.NodeListStub) string { return limit(n.ID, uuidLength) }},
+		{name: "Node Pool", value: func(n *api.NodeListStub) string { return n.NodePool }},
+		{name: "DC", value: func(n *api.NodeListStub) string { return n.Datacenter }},
+		{name: "Name", value: func(n *api.NodeListStub) string { return n.Name }},
+		{name: "Class", value: func(n *api.NodeListStub) string { return n.NodeClass }},
+		{name: "OS", value: func(n *api.NodeListStub) string { return n.Attributes["os.name"] }},
+		{name: "Address", value: func(n *api.NodeListStub) string { return n.Address }},
+		{name: "Version", value: func(n *api.NodeListStub) string { return n.Version }},
+		{name: "Drain", value: func(n *api.NodeListStub) string { return strconv.FormatBool(n.Drain) }},
+		{name: "Eligibility", value: func(n *api.NodeListStub) string { return n.SchedulingEligibility }},
+		{name: "Status", value: func(n *api.NodeListStub) string { return n.Status }},
+	}
+}
+
 func nodeDrivers(n *api.Node) []string {
 	var drivers []string
 	for k, v := range n.Attributes {
@@ -498,6 +545,16 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 		}
 	}
 
+	// Listing maintenance windows requires operator:read, so they are only
+	// shown if the token allows it.
+	status := node.Status
+	if windows, _, err := client.Operator().MaintenanceWindows(nil); err == nil {
+		if w := nodeMaintenanceWindow(windows, node, time.Now()); w != nil {
+			status = fmt.Sprintf("%s (in maintenance window %q until %s)",
+				node.Status, w.Name, formatTime(w.End()))
+		}
+	}
+
 	// Format the header output
 	basic := []string{
 		fmt.Sprintf("ID|%s", node.ID),
@@ -507,7 +564,7 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 		fmt.Sprintf("DC|%s", node.Datacenter),
 		fmt.Sprintf("Drain|%v", formatDrain(node)),
 		fmt.Sprintf("Eligibility|%s", node.SchedulingEligibility),
-		fmt.Sprintf("Status|%s", node.Status),
+		fmt.Sprintf("Status|%s", status),
 		fmt.Sprintf("CSI Controllers|%s", strings.Join(nodeCSIControllerNames(node), ",")),
 		fmt.Sprintf("CSI Drivers|%s", strings.Join(nodeCSINodeNames(node), ",")),
 	}
@@ -797,9 +854,9 @@ func (c *NodeStatusCommand) outputNodeEvent(events []*api.NodeEvent) {
 	size := len(events)
 	nodeEvents := make([]string, size+1)
 	if c.verbose {
-		nodeEvents[0] = "Time|Subsystem|Message|Details"
+		nodeEvents[0] = "Time|Subsystem|Actor|Message|Details"
 	} else {
-		nodeEvents[0] = "Time|Subsystem|Message"
+		nodeEvents[0] = "Time|Subsystem|Actor|Message"
 	}
 
 	for i, event := range events {
@@ -808,9 +865,9 @@ func (c *NodeStatusCommand) outputNodeEvent(events []*api.NodeEvent) {
 		msg := event.Message
 		if c.verbose {
 			details := formatEventDetails(event.Details)
-			nodeEvents[size-i] = fmt.Sprintf("%s|%s|%s|%s", timestamp, subsystem, msg, details)
+			nodeEvents[size-i] = fmt.Sprintf("%s|%s|%s|%s|%s", timestamp, subsystem, event.Actor, msg, details)
 		} else {
-			nodeEvents[size-i] = fmt.Sprintf("%s|%s|%s", timestamp, subsystem, msg)
+			nodeEvents[size-i] = fmt.Sprintf("%s|%s|%s|%s", timestamp, subsystem, event.Actor, msg)
 		}
 	}
 	c.Ui.Output(formatList(nodeEvents))
@@ -1094,3 +1151,25 @@ func formatNodeStubList(nodes []*api.NodeListStub, verbose bool) string {
 
 	return formatList(out)
 }
+
+// nodeMaintenanceWindow returns the active maintenance window with the longest
+// grace which selects the node, or nil if the node is not in a maintenance
+// window.
+func nodeMaintenanceWindow(windows []*api.MaintenanceWindow, node *api.Node, now time.Time) *api.MaintenanceWindow {
+	var active *api.MaintenanceWindow
+	for _, w := range windows {
+		if !w.Active(now) {
+			continue
+		}
+		if w.NodePool != "" && w.NodePool != api.NodePoolAll && w.NodePool != node.NodePool {
+			continue
+		}
+		if w.Datacenter != "" && !glob.Glob(w.Datacenter, node.Datacenter) {
+			continue
+		}
+		if active == nil || w.Grace > active.Grace {
+			active = w
+		}
+	}
+	return active
+}
@@ -224,7 +224,7 @@ func TestNodeStatusCommand_Fails(t *testing.T) {
 		t.Fatalf("expected exit 1, got: %d", code)
 	}
 
-	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-quiet cannot be used with -verbose or -json") {
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-quiet cannot be used with -verbose, -json or -o") {
 		t.Fatalf("expected getting formatter error, got: %s", out)
 	}
 	ui.ErrorWriter.Reset()
@@ -234,7 +234,7 @@ func TestNodeStatusCommand_Fails(t *testing.T) {
 		t.Fatalf("expected exit 1, got: %d", code)
 	}
 
-	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-quiet cannot be used with -verbose or -json") {
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-quiet cannot be used with -verbose, -json or -o") {
 		t.Fatalf("expected getting formatter error, got: %s", out)
 	}
 }
@@ -295,3 +295,24 @@ func TestNodeStatusCommand_FormatDrain(t *testing.T) {
 	node.DrainStrategy.IgnoreSystemJobs = true
 	must.Eq(t, "true; 1970-01-01T00:00:01Z deadline; ignoring system jobs", formatDrain(node))
 }
+
+func TestNodeStatusCommand_nodeMaintenanceWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	node := &api.Node{NodePool: "edge", Datacenter: "us-east-1a"}
+
+	pool := &api.MaintenanceWindow{Name: "pool", NodePool: "edge",
+		Start: now.Add(-time.Minute), Duration: time.Hour, Grace: time.Minute}
+	all := &api.MaintenanceWindow{Name: "all", NodePool: api.NodePoolAll, Datacenter: "us-east-*",
+		Start: now.Add(-time.Minute), Duration: time.Hour, Grace: time.Hour}
+	otherDC := &api.MaintenanceWindow{Name: "other-dc", Datacenter: "us-west-*",
+		Start: now.Add(-time.Minute), Duration: time.Hour, Grace: 2 * time.Hour}
+	pending := &api.MaintenanceWindow{Name: "pending", NodePool: "edge",
+		Start: now.Add(time.Hour), Duration: time.Hour, Grace: 2 * time.Hour}
+
+	must.Nil(t, nodeMaintenanceWindow(nil, node, now))
+	must.Nil(t, nodeMaintenanceWindow([]*api.MaintenanceWindow{otherDC, pending}, node, now))
+	must.Eq(t, pool, nodeMaintenanceWindow([]*api.MaintenanceWindow{pool, otherDC, pending}, node, now))
+	must.Eq(t, all, nodeMaintenanceWindow([]*api.MaintenanceWindow{pool, all, otherDC, pending}, node, now))
+}
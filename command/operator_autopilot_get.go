@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/posener/complete"
 )
 
@@ -50,6 +51,9 @@ func (c *OperatorAutopilotGetCommand) Run(args []string) int {
 	c.Ui.Output(fmt.Sprintf("MaxTrailingLogs = %v", config.MaxTrailingLogs))
 	c.Ui.Output(fmt.Sprintf("MinQuorum = %v", config.MinQuorum))
 	c.Ui.Output(fmt.Sprintf("ServerStabilizationTime = %v", config.ServerStabilizationTime.String()))
+	c.Ui.Output(fmt.Sprintf("MinDiskFree = %v", humanize.Bytes(config.MinDiskFree)))
+	c.Ui.Output(fmt.Sprintf("MaxLastLogAge = %v", config.MaxLastLogAge.String()))
+	c.Ui.Output(fmt.Sprintf("MaxAppliedIndexLag = %v", config.MaxAppliedIndexLag))
 	c.Ui.Output(fmt.Sprintf("EnableRedundancyZones = %v", config.EnableRedundancyZones))
 	c.Ui.Output(fmt.Sprintf("DisableUpgradeMigration = %v", config.DisableUpgradeMigration))
 	c.Ui.Output(fmt.Sprintf("EnableCustomUpgrades = %v", config.EnableCustomUpgrades))
@@ -98,6 +98,9 @@ func formatAutopilotState(state *api.OperatorHealthReply) string {
 	out = out + fmt.Sprintf("Leader: %s\n", state.Leader)
 	out = out + fmt.Sprintf("Voters:  \n\t%s\n", renderServerIDList(state.Voters))
 	out = out + fmt.Sprintf("Servers: \n%s\n", formatServerHealth(state.Servers))
+	if failed := formatFailedChecks(state.Servers); failed != "" {
+		out = out + fmt.Sprintf("FailedChecks: \n%s\n", failed)
+	}
 
 	out = formatCommandToEnt(out, state)
 	return out
@@ -133,6 +136,21 @@ func formatServerHealth(servers []api.ServerHealth) string {
 	return formatList(out)
 }
 
+// formatFailedChecks returns the autopilot health checks failed by each
+// server, or an empty string if all servers pass them.
+func formatFailedChecks(servers []api.ServerHealth) string {
+	var out []string
+	for _, p := range servers {
+		for _, check := range p.FailedChecks {
+			out = append(out, fmt.Sprintf("%s|%s", p.Name, check))
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return formatList(append([]string{"Name|Check"}, out...))
+}
+
 func renderServerIDList(ids []string) string {
 	rows := make([]string, len(ids))
 	for i, id := range ids {
@@ -54,3 +54,23 @@ func TestOperatorAutopilotStateCommand_JSON(t *testing.T) {
 	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &operatorHealthyReply))
 	must.True(t, operatorHealthyReply.Healthy)
 }
+
+func TestOperatorAutopilotStateCommand_formatFailedChecks(t *testing.T) {
+	ci.Parallel(t)
+
+	state := &api.OperatorHealthReply{
+		Leader: "a",
+		Voters: []string{"a", "b"},
+		Servers: []api.ServerHealth{
+			{ID: "a", Name: "server-a", Healthy: true},
+			{ID: "b", Name: "server-b", FailedChecks: []string{"free disk space 1.0 GiB is below min_disk_free 5.0 GiB"}},
+		},
+	}
+	out := formatAutopilotState(state)
+	must.StrContains(t, out, "FailedChecks:")
+	must.StrContains(t, out, "server-b  free disk space 1.0 GiB is below min_disk_free 5.0 GiB")
+	must.StrNotContains(t, out, "server-a  free")
+
+	state.Servers[1].FailedChecks = nil
+	must.StrNotContains(t, formatAutopilotState(state), "FailedChecks:")
+}
@@ -22,6 +22,9 @@ func (c *OperatorAutopilotSetCommand) AutocompleteFlags() complete.Flags {
 			"-max-trailing-logs":         complete.PredictAnything,
 			"-last-contact-threshold":    complete.PredictAnything,
 			"-server-stabilization-time": complete.PredictAnything,
+			"-min-disk-free":             complete.PredictAnything,
+			"-max-last-log-age":          complete.PredictAnything,
+			"-max-applied-index-lag":     complete.PredictAnything,
 			"-enable-redundancy-zones":   complete.PredictNothing,
 			"-disable-upgrade-migration": complete.PredictNothing,
 			"-enable-custom-upgrades":    complete.PredictNothing,
@@ -46,6 +49,9 @@ func (c *OperatorAutopilotSetCommand) Run(args []string) int {
 	var minQuorum flaghelper.UintValue
 	var lastContactThreshold flaghelper.DurationValue
 	var serverStabilizationTime flaghelper.DurationValue
+	var minDiskFree flaghelper.BytesValue
+	var maxLastLogAge flaghelper.DurationValue
+	var maxAppliedIndexLag flaghelper.UintValue
 	var enableRedundancyZones flaghelper.BoolValue
 	var disableUpgradeMigration flaghelper.BoolValue
 	var enableCustomUpgrades flaghelper.BoolValue
@@ -61,6 +67,9 @@ func (c *OperatorAutopilotSetCommand) Run(args []string) int {
 	flagSet.Var(&disableUpgradeMigration, "disable-upgrade-migration", "")
 	flagSet.Var(&enableCustomUpgrades, "enable-custom-upgrades", "")
 	flagSet.Var(&minQuorum, "min-quorum", "")
+	flagSet.Var(&minDiskFree, "min-disk-free", "")
+	flagSet.Var(&maxLastLogAge, "max-last-log-age", "")
+	flagSet.Var(&maxAppliedIndexLag, "max-applied-index-lag", "")
 
 	if err := flagSet.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
@@ -98,6 +107,14 @@ func (c *OperatorAutopilotSetCommand) Run(args []string) int {
 
 	serverStabilizationTime.Merge(&conf.ServerStabilizationTime)
 
+	minDiskFree.Merge(&conf.MinDiskFree)
+
+	maxLastLogAge.Merge(&conf.MaxLastLogAge)
+
+	lag := uint(conf.MaxAppliedIndexLag)
+	maxAppliedIndexLag.Merge(&lag)
+	conf.MaxAppliedIndexLag = uint64(lag)
+
 	// Check-and-set the new configuration.
 	result, _, err := operator.AutopilotCASConfiguration(conf, nil)
 	if err != nil {
@@ -145,10 +162,25 @@ Set Config Options:
      from the leader before being considered unhealthy. Must be a
      duration value such as "200ms".
 
+  -max-applied-index-lag=<value>
+     Controls the maximum number of log entries that the index applied by
+     a server can trail the leader's by before the server is considered
+     unhealthy. A value of 0 disables the check.
+
+  -max-last-log-age=<duration>
+     Controls how far behind the leader's the last log applied by a server
+     can be, in time, before the server is considered unhealthy. Must be a
+     duration value such as "30s". A value of 0 disables the check.
+
   -max-trailing-logs=<value>
      Controls the maximum number of log entries that a server can trail
      the leader by before being considered unhealthy.
 
+  -min-disk-free=<size>
+     Controls the minimum free space of the volume holding a server's Raft
+     data before the server is considered unhealthy. Must be a size such
+     as "5GB". A value of 0 disables the check.
+
   -min-quorum=<value>
       Controls the minimum number of servers required in a cluster
       before autopilot can prune dead servers.
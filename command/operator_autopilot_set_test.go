@@ -33,6 +33,9 @@ func TestOperatorAutopilotSetConfigCommand(t *testing.T) {
 		"-min-quorum=3",
 		"-last-contact-threshold=123ms",
 		"-server-stabilization-time=123ms",
+		"-min-disk-free=5GB",
+		"-max-last-log-age=30s",
+		"-max-applied-index-lag=1000",
 		"-enable-redundancy-zones=true",
 		"-disable-upgrade-migration=true",
 		"-enable-custom-upgrades=true",
@@ -55,6 +58,9 @@ func TestOperatorAutopilotSetConfigCommand(t *testing.T) {
 	must.Eq(t, 3, conf.MinQuorum)
 	must.Eq(t, 123*time.Millisecond, conf.LastContactThreshold)
 	must.Eq(t, 123*time.Millisecond, conf.ServerStabilizationTime)
+	must.Eq(t, 5_000_000_000, conf.MinDiskFree)
+	must.Eq(t, 30*time.Second, conf.MaxLastLogAge)
+	must.Eq(t, 1000, conf.MaxAppliedIndexLag)
 	must.True(t, conf.EnableRedundancyZones)
 	must.True(t, conf.DisableUpgradeMigration)
 	must.True(t, conf.EnableCustomUpgrades)
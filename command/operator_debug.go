@@ -25,6 +25,7 @@ import (
 	"syscall"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-multierror"
 	goversion "github.com/hashicorp/go-version"
@@ -53,6 +54,8 @@ type OperatorDebugCommand struct {
 	logFileExport      bool
 	maxNodes           int
 	nodeClass          string
+	clientState        bool
+	clientStateMaxSize uint64
 	nodeIDs            []string
 	serverIDs          []string
 	topics             map[api.Topic][]string
@@ -214,6 +217,16 @@ Debug Options:
   -node-class=<node-class>
     Filter client nodes based on node class.
 
+  -client-state
+    Include the state of the subsystems of the selected client nodes in the
+    capture: driver fingerprints and health, artifact downloads in progress,
+    template dependencies, cgroup layout, and garbage collector statistics.
+    Written to client/<node-id>/. Defaults to false.
+
+  -client-state-max-size=<size>
+    Cap the size of the client state captured from each client node. Files
+    which would exceed the cap are left out of the capture. Defaults to 10MB.
+
   -pprof-duration=<duration>
     Duration for pprof collection. Defaults to 1s or -duration, whichever is less.
 
@@ -249,21 +262,23 @@ func (c *OperatorDebugCommand) Synopsis() string {
 func (c *OperatorDebugCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-duration":             complete.PredictAnything,
-			"-event-index":          complete.PredictAnything,
-			"-event-topic":          complete.PredictAnything,
-			"-interval":             complete.PredictAnything,
-			"-log-level":            complete.PredictSet("TRACE", "DEBUG", "INFO", "WARN", "ERROR"),
-			"-log-include-location": complete.PredictAnything,
-			"-max-nodes":            complete.PredictAnything,
-			"-node-class":           NodeClassPredictor(c.Client),
-			"-node-id":              NodePredictor(c.Client),
-			"-server-id":            ServerPredictor(c.Client),
-			"-output":               complete.PredictDirs("*"),
-			"-pprof-duration":       complete.PredictAnything,
-			"-consul-token":         complete.PredictAnything,
-			"-vault-token":          complete.PredictAnything,
-			"-verbose":              complete.PredictAnything,
+			"-duration":              complete.PredictAnything,
+			"-event-index":           complete.PredictAnything,
+			"-event-topic":           complete.PredictAnything,
+			"-interval":              complete.PredictAnything,
+			"-log-level":             complete.PredictSet("TRACE", "DEBUG", "INFO", "WARN", "ERROR"),
+			"-log-include-location":  complete.PredictAnything,
+			"-max-nodes":             complete.PredictAnything,
+			"-node-class":            NodeClassPredictor(c.Client),
+			"-node-id":               NodePredictor(c.Client),
+			"-client-state":          complete.PredictNothing,
+			"-client-state-max-size": complete.PredictAnything,
+			"-server-id":             ServerPredictor(c.Client),
+			"-output":                complete.PredictDirs("*"),
+			"-pprof-duration":        complete.PredictAnything,
+			"-consul-token":          complete.PredictAnything,
+			"-vault-token":           complete.PredictAnything,
+			"-verbose":               complete.PredictAnything,
 		})
 }
 
@@ -374,7 +389,7 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	var duration, interval, pprofInterval, output, pprofDuration, eventTopic, logLookback string
 	var eventIndex int64
-	var nodeIDs, serverIDs string
+	var nodeIDs, serverIDs, clientStateMaxSize string
 	var allowStale bool
 
 	flags.StringVar(&duration, "duration", "5m", "")
@@ -388,6 +403,8 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	flags.IntVar(&c.maxNodes, "max-nodes", 10, "")
 	flags.StringVar(&c.nodeClass, "node-class", "", "")
 	flags.StringVar(&nodeIDs, "node-id", "all", "")
+	flags.BoolVar(&c.clientState, "client-state", false, "")
+	flags.StringVar(&clientStateMaxSize, "client-state-max-size", "10MB", "")
 	flags.StringVar(&serverIDs, "server-id", "all", "")
 	flags.BoolVar(&allowStale, "stale", false, "")
 	flags.StringVar(&output, "output", "", "")
@@ -433,6 +450,13 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Parse the client state size cap
+	c.clientStateMaxSize, err = humanize.ParseBytes(clientStateMaxSize)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing -client-state-max-size: %s: %s", clientStateMaxSize, err.Error()))
+		return 1
+	}
+
 	// Parse the capture duration
 	d, err := time.ParseDuration(duration)
 	if err != nil {
@@ -683,6 +707,9 @@ func (c *OperatorDebugCommand) Run(args []string) int {
 	if c.nodeClass != "" {
 		c.Ui.Output(fmt.Sprintf("       Node Class: %s", c.nodeClass))
 	}
+	if c.clientState {
+		c.Ui.Output(fmt.Sprintf("     Client State: max %s per client", humanize.Bytes(c.clientStateMaxSize)))
+	}
 	c.Ui.Output(fmt.Sprintf("         Interval: %s", interval))
 	c.Ui.Output(fmt.Sprintf("         Duration: %s", duration))
 	c.Ui.Output(fmt.Sprintf("   pprof Interval: %s", pprofInterval))
@@ -757,6 +784,9 @@ func (c *OperatorDebugCommand) collect(client *api.Client) error {
 	c.collectVault(clusterDir, vaultAddr)
 
 	c.collectAgentHosts(client)
+	if c.clientState {
+		c.collectClientStates(client)
+	}
 	c.collectPeriodicPprofs(client)
 
 	c.collectPeriodic(client)
@@ -1029,6 +1059,90 @@ func (c *OperatorDebugCommand) collectAgentHost(path, id string, client *api.Cli
 	c.reportErr(writeResponseToFile(host, c.newFile(path, "agent-host.json")))
 }
 
+// collectClientStates calls collectClientState for each selected node
+func (c *OperatorDebugCommand) collectClientStates(client *api.Client) {
+	for _, n := range c.nodeIDs {
+		c.collectClientState(n, client)
+	}
+}
+
+// clientStateSubsystems are the subsystems whose state is collected from each
+// client by collectClientState, in addition to its drivers.
+var clientStateSubsystems = []string{"artifacts", "templates", "cgroups", "gc"}
+
+// collectClientState gets the state of the subsystems of the client id. Each
+// subsystem is written to its own file, or the error retrieving it in its
+// place, so that one which fails does not prevent the others from being
+// captured.
+func (c *OperatorDebugCommand) collectClientState(id string, client *api.Client) {
+	w := &clientStateWriter{
+		c:         c,
+		dir:       filepath.Join(clientDir, id),
+		remaining: c.clientStateMaxSize,
+	}
+
+	node, _, err := client.Nodes().Info(id, c.queryOpts())
+	if err != nil {
+		w.writeError("drivers.json", fmt.Errorf("failed to retrieve client drivers: %w", err))
+	} else {
+		w.write("drivers.json", node.Drivers)
+	}
+
+	state, err := client.Nodes().Debug(id, c.queryOpts())
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("%s/%s: Failed to retrieve client state, err: %v", clientDir, id, err))
+		for _, name := range clientStateSubsystems {
+			w.writeError(name+".json", fmt.Errorf("failed to retrieve client state: %w", err))
+		}
+		return
+	}
+
+	subsystems := map[string]any{
+		"artifacts": state.Artifacts,
+		"templates": state.Templates,
+		"cgroups":   state.Cgroups,
+		"gc":        state.GC,
+	}
+	for _, name := range clientStateSubsystems {
+		if msg, ok := state.Errors[name]; ok {
+			w.writeError(name+".json", errors.New(msg))
+			continue
+		}
+		w.write(name+".json", subsystems[name])
+	}
+}
+
+// clientStateWriter writes the state of a client into its directory, leaving
+// out files which would exceed the size cap of the client state.
+type clientStateWriter struct {
+	c         *OperatorDebugCommand
+	dir       string
+	remaining uint64
+}
+
+// write writes obj as JSON to file, unless it exceeds the remaining size.
+func (w *clientStateWriter) write(file string, obj any) {
+	buf, err := json.Marshal(obj)
+	if err != nil {
+		w.writeError(file, err)
+		return
+	}
+	if uint64(len(buf)) > w.remaining {
+		w.c.Ui.Warn(fmt.Sprintf("%s/%s: Skipped, exceeds client state size cap of %s",
+			w.dir, file, humanize.Bytes(w.c.clientStateMaxSize)))
+		w.writeError(file, fmt.Errorf("skipped %s, exceeds client state size cap of %s",
+			humanize.Bytes(uint64(len(buf))), humanize.Bytes(w.c.clientStateMaxSize)))
+		return
+	}
+	w.remaining -= uint64(len(buf))
+	w.c.reportErr(w.c.writeBytes(w.dir, file, buf))
+}
+
+// writeError writes err to file in place of the state it holds.
+func (w *clientStateWriter) writeError(file string, err error) {
+	w.c.reportErr(w.c.writeError(w.dir, file, err))
+}
+
 func (c *OperatorDebugCommand) collectPeriodicPprofs(client *api.Client) {
 
 	pprofNodeIDs := []string{}
@@ -576,6 +576,7 @@ func TestDebug_Fail_Pprof(t *testing.T) {
 
 	// Debug on server with endpoints disabled
 	code := cmd.Run([]string{"-address", url, "-duration", "250ms", "-interval", "250ms", "-server-id", "all"})
+	removeArchive(t, ui.OutputWriter.String())
 
 	assert.Equal(t, 0, code) // Pprof failure isn't fatal
 	require.Contains(t, ui.OutputWriter.String(), "Starting debugger")
@@ -850,6 +851,69 @@ func TestDebug_RedirectError(t *testing.T) {
 	assert.True(t, isRedirectError(err), err.Error())
 }
 
+func TestDebug_CollectClientState(t *testing.T) {
+	ci.Parallel(t)
+
+	const nodeID = "f7476465-4d6e-c0de-26d0-e383c49be941"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/node/" + nodeID:
+			fmt.Fprintln(w, `{"ID": "`+nodeID+`", "Drivers": {"exec": {"Detected": true, "Healthy": true}}}`)
+		case "/v1/client/debug":
+			if r.URL.Query().Get("node_id") != nodeID {
+				http.Error(w, "Unknown node", http.StatusNotFound)
+				return
+			}
+			fmt.Fprintln(w, `{
+				"Artifacts": [{"Source": "https://example.com/app.tar.gz", "Destination": "local/"}],
+				"Cgroups": {"Mode": "v2", "Root": "/sys/fs/cgroup/nomad.slice", "Cgroups": ["`+strings.Repeat("a", 1000)+`"]},
+				"GC": {"MaxAllocs": 50},
+				"Errors": {"templates": "panic: broken"}
+			}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	config := api.DefaultConfig()
+	config.Address = ts.URL
+	client, err := api.NewClient(config)
+	must.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorDebugCommand{Meta: Meta{Ui: ui}}
+	cmd.collectDir = t.TempDir()
+	cmd.clientStateMaxSize = 1000
+	cmd.opts = &api.QueryOptions{}
+	cmd.collectClientState(nodeID, client)
+
+	dir := filepath.Join(cmd.collectDir, clientDir, nodeID)
+	read := func(file string) string {
+		b, err := os.ReadFile(filepath.Join(dir, file))
+		must.NoError(t, err)
+		return string(b)
+	}
+
+	must.StrContains(t, read("drivers.json"), `"Healthy":true`)
+	must.StrContains(t, read("artifacts.json"), "https://example.com/app.tar.gz")
+	must.StrContains(t, read("gc.json"), `"MaxAllocs":50`)
+
+	// a subsystem which failed does not prevent the others from being captured
+	must.Eq(t, `{"Error":"panic: broken"}`, read("templates.json"))
+
+	// state exceeding the size cap is left out
+	must.StrContains(t, read("cgroups.json"), "exceeds client state size cap of 1.0 kB")
+	must.StrContains(t, ui.ErrorWriter.String(), "cgroups.json: Skipped")
+
+	// a client which cannot be reached leaves errors in place of its state
+	cmd.collectClientState("unknown", client)
+	dir = filepath.Join(cmd.collectDir, clientDir, "unknown")
+	must.StrContains(t, read("drivers.json"), "failed to retrieve client drivers")
+	must.StrContains(t, read("gc.json"), "failed to retrieve client state")
+}
+
 // TestDebug_StaleLeadership verifies that APIs that are required to
 // complete a debug run have their query options configured with the
 // -stale flag
@@ -1055,6 +1119,7 @@ func TestDebug_EventStream(t *testing.T) {
 
 	archive := extractArchiveName(testOut.output)
 	require.NotEmpty(t, archive)
+	removeArchive(t, testOut.output)
 
 	// TODO dmay: verify evenstream.json output file contains expected content
 }
@@ -1073,6 +1138,15 @@ func extractArchiveName(captureOutput string) string {
 	return file
 }
 
+// removeArchive removes the archive named in captureOutput once the test
+// completes, so that tests which create archives in the working directory do
+// not leave them behind.
+func removeArchive(t *testing.T, captureOutput string) {
+	if archive := extractArchiveName(captureOutput); archive != "" {
+		t.Cleanup(func() { _ = os.Remove(archive) })
+	}
+}
+
 func TestDebug_MonitorExportFiles(t *testing.T) {
 	f := mon.PrepFile(t).Name()
 	setLogFile := func(c *agent.Config) {
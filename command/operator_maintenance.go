@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/hashicorp/cli"
+)
+
+// Ensure OperatorMaintenanceCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorMaintenanceCommand{}
+
+type OperatorMaintenanceCommand struct {
+	Meta
+}
+
+func (o *OperatorMaintenanceCommand) Help() string {
+	helpText := `
+Usage: nomad operator maintenance <subcommand> [options]
+
+  This command groups subcommands for managing maintenance windows. While a
+  maintenance window is active, allocations on the disconnected nodes it
+  selects are given a grace on top of their disconnect lost_after before they
+  are considered lost and replaced.
+
+  Register a two hour window with one hour of grace for the "edge" node pool:
+
+      $ nomad operator maintenance apply -start=2025-06-01T22:00:00Z \
+          -duration=2h -grace=1h -node-pool=edge network-upgrade
+
+  List the maintenance windows:
+
+      $ nomad operator maintenance list
+
+  Delete a maintenance window:
+
+      $ nomad operator maintenance delete network-upgrade
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (o *OperatorMaintenanceCommand) Synopsis() string {
+	return "Manage maintenance windows for disconnected clients"
+}
+
+func (o *OperatorMaintenanceCommand) Name() string { return "operator maintenance" }
+
+func (o *OperatorMaintenanceCommand) Run(_ []string) int { return cli.RunResultHelp }
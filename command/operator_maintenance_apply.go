@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorMaintenanceApplyCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorMaintenanceApplyCommand{}
+
+type OperatorMaintenanceApplyCommand struct {
+	Meta
+}
+
+func (o *OperatorMaintenanceApplyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-description": complete.PredictAnything,
+			"-start":       complete.PredictAnything,
+			"-duration":    complete.PredictAnything,
+			"-grace":       complete.PredictAnything,
+			"-node-pool":   nodePoolPredictor(o.Client, nil),
+			"-datacenter":  complete.PredictAnything,
+		},
+	)
+}
+
+func (o *OperatorMaintenanceApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (o *OperatorMaintenanceApplyCommand) Name() string { return "operator maintenance apply" }
+
+func (o *OperatorMaintenanceApplyCommand) Run(args []string) int {
+	var description, start, nodePool, datacenter string
+	var duration, grace time.Duration
+
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.StringVar(&description, "description", "", "")
+	flags.StringVar(&start, "start", "", "")
+	flags.DurationVar(&duration, "duration", 0, "")
+	flags.DurationVar(&grace, "grace", 0, "")
+	flags.StringVar(&nodePool, "node-pool", "", "")
+	flags.StringVar(&datacenter, "datacenter", "", "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		o.Ui.Error("This command takes one argument: <name>")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	window := &api.MaintenanceWindow{
+		Name:        args[0],
+		Description: description,
+		Start:       time.Now().UTC(),
+		Duration:    duration,
+		Grace:       grace,
+		NodePool:    nodePool,
+		Datacenter:  datacenter,
+	}
+	if start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			o.Ui.Error(fmt.Sprintf("Error parsing -start as an RFC 3339 time: %s", err))
+			return 1
+		}
+		window.Start = t
+	}
+
+	if duration <= 0 {
+		o.Ui.Error("The -duration flag must be a positive duration")
+		return 1
+	}
+	if grace <= 0 {
+		o.Ui.Error("The -grace flag must be a positive duration")
+		return 1
+	}
+	if nodePool == "" && datacenter == "" {
+		o.Ui.Error("At least one of -node-pool or -datacenter must be set")
+		return 1
+	}
+
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.Operator().MaintenanceWindowRegister(window, nil); err != nil {
+		o.Ui.Error(fmt.Sprintf("Error applying maintenance window: %s", err))
+		return 1
+	}
+
+	o.Ui.Output(fmt.Sprintf("Successfully applied maintenance window %q!", window.Name))
+	return 0
+}
+
+func (o *OperatorMaintenanceApplyCommand) Synopsis() string {
+	return "Create or update a maintenance window"
+}
+
+func (o *OperatorMaintenanceApplyCommand) Help() string {
+	helpText := `
+Usage: nomad operator maintenance apply [options] <name>
+
+  Create or update a maintenance window. While the window is active,
+  allocations on disconnected nodes it selects are given the window's grace on
+  top of their disconnect lost_after before they are considered lost and
+  replaced. The window is removed once it ends.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Maintenance Apply Options:
+
+  -description
+    A human readable description of the maintenance window.
+
+  -start
+    The time the maintenance window begins, in RFC 3339 format. Defaults to
+    the current time.
+
+  -duration
+    How long the maintenance window lasts. Required.
+
+  -grace
+    The duration added to the disconnect lost_after of allocations on selected
+    nodes while the window is active. Required.
+
+  -node-pool
+    Select the nodes in this node pool.
+
+  -datacenter
+    Select the nodes in datacenters matching this glob. If -node-pool is also
+    set, nodes must match both.
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorMaintenanceApplyCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "missing name",
+			args:        []string{"-duration=1h", "-grace=30m", "-datacenter=dc1"},
+			expectedErr: "This command takes one argument",
+		},
+		{
+			name:        "invalid start",
+			args:        []string{"-start=tomorrow", "-duration=1h", "-grace=30m", "-datacenter=dc1", "network"},
+			expectedErr: "Error parsing -start",
+		},
+		{
+			name:        "missing duration",
+			args:        []string{"-grace=30m", "-datacenter=dc1", "network"},
+			expectedErr: "The -duration flag must be a positive duration",
+		},
+		{
+			name:        "missing grace",
+			args:        []string{"-duration=1h", "-datacenter=dc1", "network"},
+			expectedErr: "The -grace flag must be a positive duration",
+		},
+		{
+			name:        "missing selector",
+			args:        []string{"-duration=1h", "-grace=30m", "network"},
+			expectedErr: "At least one of -node-pool or -datacenter must be set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &OperatorMaintenanceApplyCommand{Meta: Meta{Ui: ui}}
+
+			must.One(t, cmd.Run(tc.args))
+			must.StrContains(t, ui.ErrorWriter.String(), tc.expectedErr)
+		})
+	}
+}
+
+func TestOperatorMaintenanceCommands_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	applyCmd := &OperatorMaintenanceApplyCommand{Meta: Meta{Ui: ui}}
+	must.Zero(t, applyCmd.Run([]string{
+		"-address=" + url,
+		"-description=network work",
+		"-duration=1h",
+		"-grace=30m",
+		"-datacenter=dc*",
+		"network",
+	}))
+	must.StrContains(t, ui.OutputWriter.String(), `Successfully applied maintenance window "network"!`)
+
+	window, _, err := client.Operator().MaintenanceWindow("network", nil)
+	must.NoError(t, err)
+	must.Eq(t, "network work", window.Description)
+	must.Eq(t, time.Hour, window.Duration)
+	must.Eq(t, 30*time.Minute, window.Grace)
+	must.Eq(t, "dc*", window.Datacenter)
+
+	ui.OutputWriter.Reset()
+	listCmd := &OperatorMaintenanceListCommand{Meta: Meta{Ui: ui}}
+	must.Zero(t, listCmd.Run([]string{"-address=" + url}))
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "network")
+	must.StrContains(t, out, "active")
+
+	ui.OutputWriter.Reset()
+	deleteCmd := &OperatorMaintenanceDeleteCommand{Meta: Meta{Ui: ui}}
+	must.Zero(t, deleteCmd.Run([]string{"-address=" + url, "network"}))
+	must.StrContains(t, ui.OutputWriter.String(), `Successfully deleted maintenance window "network"!`)
+
+	ui.OutputWriter.Reset()
+	must.Zero(t, listCmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.OutputWriter.String(), "No maintenance windows")
+
+	// Deleting a missing window fails.
+	must.One(t, deleteCmd.Run([]string{"-address=" + url, "network"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "not found")
+}
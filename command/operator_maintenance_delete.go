@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorMaintenanceDeleteCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorMaintenanceDeleteCommand{}
+
+type OperatorMaintenanceDeleteCommand struct {
+	Meta
+}
+
+func (o *OperatorMaintenanceDeleteCommand) AutocompleteFlags() complete.Flags {
+	return o.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (o *OperatorMaintenanceDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorMaintenanceDeleteCommand) Name() string { return "operator maintenance delete" }
+
+func (o *OperatorMaintenanceDeleteCommand) Run(args []string) int {
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		o.Ui.Error("This command takes one argument: <name>")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+	name := args[0]
+
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.Operator().MaintenanceWindowDelete(name, nil); err != nil {
+		o.Ui.Error(fmt.Sprintf("Error deleting maintenance window: %s", err))
+		return 1
+	}
+
+	o.Ui.Output(fmt.Sprintf("Successfully deleted maintenance window %q!", name))
+	return 0
+}
+
+func (o *OperatorMaintenanceDeleteCommand) Synopsis() string {
+	return "Delete a maintenance window"
+}
+
+func (o *OperatorMaintenanceDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad operator maintenance delete [options] <name>
+
+  Delete a maintenance window before it ends. Disconnected nodes the window
+  selected are re-evaluated, so allocations that have exceeded their disconnect
+  lost_after are replaced.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+
+	return strings.TrimSpace(helpText)
+}
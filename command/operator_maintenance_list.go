@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorMaintenanceListCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorMaintenanceListCommand{}
+
+type OperatorMaintenanceListCommand struct {
+	Meta
+
+	json bool
+	tmpl string
+}
+
+func (o *OperatorMaintenanceListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		},
+	)
+}
+
+func (o *OperatorMaintenanceListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorMaintenanceListCommand) Name() string { return "operator maintenance list" }
+
+func (o *OperatorMaintenanceListCommand) Run(args []string) int {
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		o.Ui.Error("This command takes no arguments")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	windows, _, err := client.Operator().MaintenanceWindows(nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error listing maintenance windows: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, windows)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	if len(windows) == 0 {
+		o.Ui.Output("No maintenance windows")
+		return 0
+	}
+
+	o.Ui.Output(formatMaintenanceWindows(windows, time.Now()))
+	return 0
+}
+
+func formatMaintenanceWindows(windows []*api.MaintenanceWindow, now time.Time) string {
+	out := make([]string, 0, len(windows)+1)
+	out = append(out, "Name|Start|End|Grace|Node Pool|Datacenter|Status")
+	for _, w := range windows {
+		status := "pending"
+		if w.Active(now) {
+			status = "active"
+		} else if !now.Before(w.End()) {
+			status = "ended"
+		}
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+			w.Name,
+			formatTime(w.Start),
+			formatTime(w.End()),
+			w.Grace,
+			w.NodePool,
+			w.Datacenter,
+			status,
+		))
+	}
+	return formatList(out)
+}
+
+func (o *OperatorMaintenanceListCommand) Synopsis() string {
+	return "List maintenance windows"
+}
+
+func (o *OperatorMaintenanceListCommand) Help() string {
+	helpText := `
+Usage: nomad operator maintenance list [options]
+
+  List the maintenance windows registered in the region.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Maintenance List Options:
+
+  -json
+    Output the maintenance windows in their JSON format.
+
+  -t
+    Format and display the maintenance windows using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
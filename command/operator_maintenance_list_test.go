@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorMaintenanceListCommand_formatMaintenanceWindows(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	windows := []*api.MaintenanceWindow{
+		{
+			Name:     "pending",
+			Start:    now.Add(time.Hour),
+			Duration: time.Hour,
+			Grace:    time.Minute,
+			NodePool: "edge",
+		},
+		{
+			Name:       "active",
+			Start:      now.Add(-time.Minute),
+			Duration:   time.Hour,
+			Grace:      time.Minute,
+			Datacenter: "dc1",
+		},
+		{
+			Name:       "ended",
+			Start:      now.Add(-2 * time.Hour),
+			Duration:   time.Hour,
+			Grace:      time.Minute,
+			Datacenter: "dc1",
+		},
+	}
+
+	lines := strings.Split(formatMaintenanceWindows(windows, now), "\n")
+	must.Len(t, 4, lines)
+	must.StrContains(t, lines[0], "Node Pool")
+	must.StrHasPrefix(t, "pending", lines[1])
+	must.StrHasSuffix(t, "pending", strings.TrimSpace(lines[1]))
+	must.StrHasSuffix(t, "active", strings.TrimSpace(lines[2]))
+	must.StrHasSuffix(t, "ended", strings.TrimSpace(lines[3]))
+	must.StrContains(t, lines[2], "<none>")
+}
@@ -25,6 +25,10 @@ Usage: nomad operator raft <subcommand> [options]
 
       $ nomad operator raft list-peers
 
+  Display the replication status of Raft peers:
+
+      $ nomad operator raft status
+
   Remove a Raft peer:
 
       $ nomad operator raft remove-peer -peer-address "IP:Port"
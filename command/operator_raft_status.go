@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorRaftStatusCommand struct {
+	Meta
+}
+
+func (c *OperatorRaftStatusCommand) Help() string {
+	helpText := `
+Usage: nomad operator raft status [options]
+
+  Displays the replication status of each Raft peer, as known by the leader.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Status Options:
+
+  -json
+    Output the replication status in JSON format.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorRaftStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorRaftStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorRaftStatusCommand) Synopsis() string {
+	return "Display the replication status of Raft peers"
+}
+
+func (c *OperatorRaftStatusCommand) Name() string { return "operator raft status" }
+
+func (c *OperatorRaftStatusCommand) Run(args []string) int {
+	var fJson bool
+
+	flags := c.Meta.FlagSet("raft", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&fJson, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	status, err := client.Operator().RaftGetStatus(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to retrieve raft status: %v", err))
+		return 1
+	}
+
+	if fJson {
+		out, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to serialize raft status: %v", err))
+			return 1
+		}
+		c.Ui.Output(string(out))
+		return 0
+	}
+
+	c.Ui.Output(formatRaftStatus(status))
+	return 0
+}
+
+// formatRaftStatus formats the replication status of the Raft peers as a
+// table, preceded by the log indexes of the leader.
+func formatRaftStatus(status *api.RaftStatus) string {
+	basic := []string{
+		fmt.Sprintf("Term|%d", status.Term),
+		fmt.Sprintf("Last Index|%d", status.LastIndex),
+		fmt.Sprintf("Commit Index|%d", status.CommitIndex),
+	}
+
+	sort.Slice(status.Servers, func(i, j int) bool {
+		return status.Servers[i].Node < status.Servers[j].Node
+	})
+
+	peers := []string{"Node|ID|Address|Voter|State|Last Contact|Match Index|Trailing Logs|Failures"}
+	for _, s := range status.Servers {
+		lastContact := "-"
+		if !s.Leader && s.State != "unknown" {
+			lastContact = s.LastContact.String()
+		}
+		peers = append(peers, fmt.Sprintf("%s|%s|%s|%t|%s|%s|%d|%d|%d",
+			s.Node, s.ID, s.Address, s.Voter, s.State, lastContact,
+			s.MatchIndex, s.TrailingLogs, s.ReplicationFailures))
+	}
+
+	return formatKV(basic) + "\n\n" + formatList(peers)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperator_Raft_Status_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorRaftStatusCommand{}
+}
+
+func TestOperator_Raft_Status(t *testing.T) {
+	ci.Parallel(t)
+	s, _, addr := testServer(t, false, nil)
+	defer s.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorRaftStatusCommand{Meta: Meta{Ui: ui}}
+
+	code := c.Run([]string{"-address=" + addr})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Commit Index")
+	must.StrContains(t, out, "Match Index")
+	must.StrContains(t, out, "leader")
+
+	ui.OutputWriter.Reset()
+	code = c.Run([]string{"-address=" + addr, "-json"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	var status api.RaftStatus
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &status))
+	must.Len(t, 1, status.Servers)
+	must.True(t, status.Servers[0].Leader)
+	must.Eq(t, "leader", status.Servers[0].State)
+}
+
+func TestOperator_Raft_Status_Args(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	c := &OperatorRaftStatusCommand{Meta: Meta{Ui: ui}}
+
+	code := c.Run([]string{"extra"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	// keys without hash preferences fall back to RIPEMD160, which must be
+	// compiled in to encrypt for them
+	_ "golang.org/x/crypto/ripemd160"
+)
+
+// readKeyRing reads the OpenPGP keys in the file at path, which may be ASCII
+// armored or binary.
+func readKeyRing(path string) (openpgp.EntityList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		keys, err = openpgp.ReadKeyRing(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenPGP keys from %q: %w", path, err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no OpenPGP keys found in %q", path)
+	}
+	return keys, nil
+}
+
+// encryptSnapshot writes the snapshot read from in to out, encrypted for each
+// of the recipients.
+func encryptSnapshot(out io.Writer, in io.Reader, recipients openpgp.EntityList) error {
+	w, err := openpgp.Encrypt(out, recipients, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, in); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// decryptSnapshot writes the snapshot read from in, which was encrypted for a
+// key in keys, to out. passphrase is called for the passphrase of the key if
+// the private key is protected by one.
+func decryptSnapshot(out io.Writer, in io.Reader, keys openpgp.EntityList, passphrase func() (string, error)) error {
+	prompted := false
+	prompt := func(candidates []openpgp.Key, symmetric bool) ([]byte, error) {
+		if symmetric || prompted {
+			return nil, errors.New("unable to decrypt the private key")
+		}
+		prompted = true
+
+		secret, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range candidates {
+			if k.PrivateKey != nil && k.PrivateKey.Encrypted {
+				if err := k.PrivateKey.Decrypt([]byte(secret)); err != nil {
+					return nil, fmt.Errorf("failed to decrypt the private key: %w", err)
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	md, err := openpgp.ReadMessage(in, keys, prompt, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+
+	// the integrity of the message is only checked once it has been read in
+	// full, so the output must not be used if this fails
+	if _, err := io.Copy(out, md.UnverifiedBody); err != nil {
+		return fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/go-getter"
+)
+
+// isRemoteSnapshot returns whether path is the URL of a snapshot in an object
+// store or on a web server, rather than the path of a local file.
+func isRemoteSnapshot(path string) bool {
+	// go-getter forced getters, such as "s3::https://..."
+	if strings.Contains(path, "::") {
+		return true
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "s3", "gs", "http", "https":
+		return true
+	}
+	return false
+}
+
+// parseObjectURL returns the bucket and object key of an s3:// or gs:// URL.
+func parseObjectURL(u *url.URL) (string, string, error) {
+	bucket := u.Host
+	key := strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" {
+		return "", "", fmt.Errorf("%s URL must be of the form %s://<bucket>/<key>", u.Scheme, u.Scheme)
+	}
+	return bucket, key, nil
+}
+
+// uploadSnapshot writes the snapshot file f to the remote destination dst.
+// s3:// and gs:// destinations are written with the credentials found by the
+// AWS and Google Cloud SDKs. http:// and https:// destinations are written
+// with a PUT request, so they are usually pre-signed URLs such as S3 and GCS
+// signed URLs or Azure Blob Storage SAS URLs.
+func uploadSnapshot(ctx context.Context, dst string, f *os.File) error {
+	u, err := url.Parse(dst)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot destination: %w", err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	switch u.Scheme {
+	case "s3":
+		bucket, key, err := parseObjectURL(u)
+		if err != nil {
+			return err
+		}
+		client, err := s3Client(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   f,
+		})
+		return err
+
+	case "gs":
+		bucket, key, err := parseObjectURL(u)
+		if err != nil {
+			return err
+		}
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		w := client.Bucket(bucket).Object(key).NewWriter(ctx)
+		if _, err := io.Copy(w, f); err != nil {
+			_ = w.Close()
+			return err
+		}
+		return w.Close()
+
+	case "http", "https":
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, dst, f)
+		if err != nil {
+			return err
+		}
+		req.ContentLength = info.Size()
+		req.Header.Set("Content-Type", "application/octet-stream")
+
+		// required by Azure Blob Storage, and ignored by other stores
+		req.Header.Set("x-ms-blob-type", "BlockBlob")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return fmt.Errorf("unexpected response code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported snapshot destination %q, must be an s3://, gs://, or https:// URL", dst)
+	}
+}
+
+// downloadSnapshot writes the remote snapshot at src to the file dst. s3:// and
+// gs:// sources are read with the AWS and Google Cloud SDKs, and all other
+// sources are read with go-getter, as artifacts are.
+func downloadSnapshot(ctx context.Context, src, dst string) error {
+	u, err := url.Parse(src)
+	if err == nil && (u.Scheme == "s3" || u.Scheme == "gs") {
+		bucket, key, err := parseObjectURL(u)
+		if err != nil {
+			return err
+		}
+
+		var r io.ReadCloser
+		if u.Scheme == "s3" {
+			client, err := s3Client(ctx)
+			if err != nil {
+				return err
+			}
+			out, err := client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				return err
+			}
+			r = out.Body
+		} else {
+			client, err := storage.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			if r, err = client.Bucket(bucket).Object(key).NewReader(ctx); err != nil {
+				return err
+			}
+		}
+		defer r.Close()
+
+		f, err := os.Create(dst)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, r); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	httpGetter := &getter.HttpGetter{
+		Netrc: true,
+
+		// Do not support the custom X-Terraform-Get header and
+		// associated logic.
+		XTerraformGetDisabled: true,
+	}
+
+	c := &getter.Client{
+		Ctx:             ctx,
+		Src:             src,
+		Dst:             dst,
+		Mode:            getter.ClientModeFile,
+		DisableSymlinks: true,
+
+		// snapshots are gzip compressed archives which must be restored as
+		// they are, so never decompress them
+		Decompressors: map[string]getter.Decompressor{},
+		Getters: map[string]getter.Getter{
+			"http":  httpGetter,
+			"https": httpGetter,
+			"s3":    new(getter.S3Getter),
+			"gcs":   new(getter.GCSGetter),
+		},
+	}
+	return c.Get()
+}
+
+// s3Client returns an S3 client using the default AWS credential chain and
+// region configuration.
+func s3Client(ctx context.Context) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return s3.NewFromConfig(cfg), nil
+}
+
+// fileChecksum returns the SHA-256 checksum of the file f, in the form
+// "sha256:<hex>", and rewinds f.
+func fileChecksum(f *os.File) (string, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyChecksum returns an error if the SHA-256 checksum of the file f does
+// not match expected, which may be given with or without the "sha256:"
+// prefix.
+func verifyChecksum(f *os.File, expected string) error {
+	expected = strings.ToLower(strings.TrimPrefix(expected, "sha256:"))
+	if len(expected) != sha256.Size*2 {
+		return errors.New("checksum must be a hex encoded SHA-256 checksum")
+	}
+
+	actual, err := fileChecksum(f)
+	if err != nil {
+		return err
+	}
+	if strings.TrimPrefix(actual, "sha256:") != expected {
+		return fmt.Errorf("checksum mismatch: expected sha256:%s, got %s", expected, actual)
+	}
+	return nil
+}
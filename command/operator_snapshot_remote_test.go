@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSnapshot_isRemoteSnapshot(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := map[string]bool{
+		"backup.snap":                      false,
+		"/var/backups/nomad.snap":          false,
+		`C:\backups\nomad.snap`:            false,
+		"s3://backups/nomad.snap":          true,
+		"gs://backups/nomad.snap":          true,
+		"https://example.com/nomad.snap":   true,
+		"s3::https://s3.amazonaws.com/b/k": true,
+	}
+	for path, remote := range cases {
+		must.Eq(t, remote, isRemoteSnapshot(path), must.Sprint(path))
+	}
+}
+
+func TestOperatorSnapshot_verifyChecksum(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "backup.snap")
+	must.NoError(t, os.WriteFile(path, []byte("nomad"), 0o644))
+	f, err := os.Open(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	checksum, err := fileChecksum(f)
+	must.NoError(t, err)
+
+	must.NoError(t, verifyChecksum(f, checksum))
+	must.NoError(t, verifyChecksum(f, checksum[len("sha256:"):]))
+	must.ErrorContains(t, verifyChecksum(f, "sha256:"+strings.Repeat("0", 64)), "checksum mismatch")
+	must.ErrorContains(t, verifyChecksum(f, "md5:abc"), "must be a hex encoded SHA-256 checksum")
+}
@@ -4,11 +4,16 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/snapshot"
 	"github.com/posener/complete"
 )
 
@@ -18,7 +23,7 @@ type OperatorSnapshotRestoreCommand struct {
 
 func (c *OperatorSnapshotRestoreCommand) Help() string {
 	helpText := `
-Usage: nomad operator snapshot restore [options] <file>
+Usage: nomad operator snapshot restore [options] <file|url>
 
   Restores an atomic, point-in-time snapshot of the state of the Nomad servers
   which includes jobs, nodes, allocations, periodic jobs, and ACLs.
@@ -28,6 +33,14 @@ Usage: nomad operator snapshot restore [options] <file>
   intended to be used when recovering from a disaster, restoring into a fresh
   cluster of Nomad servers.
 
+  The snapshot may be read from a local file, or streamed from an object store
+  given an s3://<bucket>/<key> or gs://<bucket>/<object> URL, which use the
+  credentials found by the AWS and Google Cloud SDKs. Any other URL is read
+  with go-getter, as artifacts are, such as a pre-signed https:// URL.
+
+  The snapshot is verified against the checksums it contains before it is
+  sent to the servers, so a corrupt snapshot is never restored.
+
   If ACLs are enabled, a management token must be supplied in order to perform
   snapshot operations.
 
@@ -35,14 +48,35 @@ Usage: nomad operator snapshot restore [options] <file>
 
     $ nomad operator snapshot restore backup.snap
 
+  To restore a snapshot from S3, encrypted for the OpenPGP key in "ops.key":
+
+    $ nomad operator snapshot restore -decrypt=ops.key s3://backups/nomad.snap.gpg
+
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Snapshot Restore Options:
+
+  -checksum=<checksum>
+    The SHA-256 checksum of the snapshot file, as reported by 'nomad operator
+    snapshot save'. The snapshot is not restored if its checksum does not match.
+    For encrypted snapshots this is the checksum of the encrypted file.
+
+  -decrypt=<file>
+    Decrypt the snapshot with the OpenPGP private keys in the file, which may be
+    ASCII armored or binary. You are prompted for the passphrase of a private
+    key protected by one.
+`
 	return strings.TrimSpace(helpText)
 }
 
 func (c *OperatorSnapshotRestoreCommand) AutocompleteFlags() complete.Flags {
-	return c.Meta.AutocompleteFlags(FlagSetClient)
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-checksum": complete.PredictAnything,
+			"-decrypt":  complete.PredictFiles("*"),
+		})
 }
 
 func (c *OperatorSnapshotRestoreCommand) AutocompleteArgs() complete.Predictor {
@@ -56,9 +90,14 @@ func (c *OperatorSnapshotRestoreCommand) Synopsis() string {
 func (c *OperatorSnapshotRestoreCommand) Name() string { return "operator snapshot restore" }
 
 func (c *OperatorSnapshotRestoreCommand) Run(args []string) int {
+	var checksum, decrypt string
+
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 
+	flags.StringVar(&checksum, "checksum", "", "")
+	flags.StringVar(&decrypt, "decrypt", "", "")
+
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
 		return 1
@@ -67,18 +106,88 @@ func (c *OperatorSnapshotRestoreCommand) Run(args []string) int {
 	// Check for misuse
 	args = flags.Args()
 	if len(args) != 1 {
-		c.Ui.Error("This command takes one argument: <filename>")
+		c.Ui.Error("This command takes one argument: <filename|url>")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
 
-	snap, err := os.Open(args[0])
+	var keys openpgp.EntityList
+	if decrypt != "" {
+		var err error
+		keys, err = readKeyRing(decrypt)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading decryption keys: %v", err))
+			return 1
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "nomad-snapshot-")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to create temporary directory: %v", err))
+		return 1
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := args[0]
+	if isRemoteSnapshot(path) {
+		path = filepath.Join(tmpDir, "download.snap")
+		err := downloadSnapshot(context.Background(), args[0], path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error downloading snapshot file: %v", err))
+			return 1
+		}
+	}
+
+	snap, err := os.Open(path)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %q", err))
 		return 1
 	}
 	defer snap.Close()
 
+	if checksum != "" {
+		if err := verifyChecksum(snap, checksum); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error verifying snapshot checksum: %v", err))
+			return 1
+		}
+	}
+
+	if keys != nil {
+		decrypted, err := os.Create(filepath.Join(tmpDir, "decrypted.snap"))
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to create file: %v", err))
+			return 1
+		}
+		defer decrypted.Close()
+
+		passphrase := func() (string, error) {
+			return c.Ui.AskSecret("Private key passphrase:")
+		}
+		if err := decryptSnapshot(decrypted, snap, keys, passphrase); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		snap = decrypted
+	}
+
+	// verify the snapshot before starting the restore, as the servers only
+	// find out the snapshot is corrupt once they have started restoring it
+	if _, err := snap.Seek(0, io.SeekStart); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading snapshot file: %v", err))
+		return 1
+	}
+	if _, err := snapshot.Verify(snap); err != nil {
+		c.Ui.Error(fmt.Sprintf("Snapshot failed verification: %v", err))
+		if keys == nil {
+			c.Ui.Error("Use the -decrypt option if the snapshot is encrypted")
+		}
+		return 1
+	}
+	if _, err := snap.Seek(0, io.SeekStart); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading snapshot file: %v", err))
+		return 1
+	}
+
 	// Set up a client.
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -4,14 +4,23 @@
 package command
 
 import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
 )
@@ -93,3 +102,126 @@ func TestOperatorSnapshotRestore_Fails(t *testing.T) {
 	must.One(t, code)
 	must.StrContains(t, ui.ErrorWriter.String(), "no such file")
 }
+
+func TestOperatorSnapshotRestore_Corrupt(t *testing.T) {
+	ci.Parallel(t)
+
+	snapshotPath := generateSnapshotFile(t, nil)
+	b, err := os.ReadFile(snapshotPath)
+	must.NoError(t, err)
+
+	// truncate the snapshot so its checksums no longer match
+	corruptPath := filepath.Join(t.TempDir(), "corrupt.snap")
+	must.NoError(t, os.WriteFile(corruptPath, b[:len(b)/2], 0o644))
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSnapshotRestoreCommand{Meta: Meta{Ui: ui}}
+
+	// the snapshot is rejected before the servers are contacted
+	code := cmd.Run([]string{"--address=http://127.0.0.1:1", corruptPath})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Snapshot failed verification")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"--address=http://127.0.0.1:1", "-checksum=sha256:" + strings.Repeat("0", 64), snapshotPath})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "checksum mismatch")
+}
+
+func TestOperatorSnapshotRestore_RemoteEncrypted(t *testing.T) {
+	ci.Parallel(t)
+
+	tmpDir := t.TempDir()
+
+	// write the key pair snapshots are encrypted for
+	entity, err := openpgp.NewEntity("ops", "", "ops@example.com", nil)
+	must.NoError(t, err)
+	var pub, priv bytes.Buffer
+	must.NoError(t, entity.Serialize(&pub))
+	must.NoError(t, entity.SerializePrivate(&priv, nil))
+	pubPath := filepath.Join(tmpDir, "ops.pub")
+	privPath := filepath.Join(tmpDir, "ops.key")
+	must.NoError(t, os.WriteFile(pubPath, pub.Bytes(), 0o644))
+	must.NoError(t, os.WriteFile(privPath, priv.Bytes(), 0o600))
+
+	// serve an object store accepting pre-signed uploads
+	var lock sync.Mutex
+	objects := map[string][]byte{}
+	store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = b
+		default:
+			b, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(b)
+		}
+	}))
+	defer store.Close()
+	objectURL := store.URL + "/backups/nomad.snap.gpg"
+
+	srv1, _, url1 := testServer(t, false, func(c *agent.Config) {
+		c.DevMode = false
+		c.DataDir = filepath.Join(tmpDir, "server1")
+
+		c.AdvertiseAddrs.HTTP = "127.0.0.1"
+		c.AdvertiseAddrs.RPC = "127.0.0.1"
+		c.AdvertiseAddrs.Serf = "127.0.0.1"
+	})
+	defer srv1.Shutdown()
+
+	job := mock.Job()
+	job.ID = "snapshot-test-job"
+	must.NoError(t, srv1.Agent.Server().State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	ui := cli.NewMockUi()
+	saveCmd := &OperatorSnapshotSaveCommand{Meta: Meta{Ui: ui}}
+	code := saveCmd.Run([]string{"--address=" + url1, "-encrypt=" + pubPath, objectURL})
+	must.Eq(t, "", ui.ErrorWriter.String())
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "State file written to "+objectURL)
+
+	checksum := regexp.MustCompile(`sha256:[0-9a-f]{64}`).FindString(ui.OutputWriter.String())
+	must.NotEq(t, "", checksum)
+
+	srv2, _, url2 := testServer(t, false, func(c *agent.Config) {
+		c.DevMode = false
+		c.DataDir = filepath.Join(tmpDir, "server2")
+
+		c.AdvertiseAddrs.HTTP = "127.0.0.1"
+		c.AdvertiseAddrs.RPC = "127.0.0.1"
+		c.AdvertiseAddrs.Serf = "127.0.0.1"
+	})
+	defer srv2.Shutdown()
+
+	// the encrypted snapshot fails verification without the key
+	ui = cli.NewMockUi()
+	restoreCmd := &OperatorSnapshotRestoreCommand{Meta: Meta{Ui: ui}}
+	code = restoreCmd.Run([]string{"--address=" + url2, objectURL})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Use the -decrypt option")
+
+	// the public key cannot decrypt the snapshot
+	ui = cli.NewMockUi()
+	restoreCmd = &OperatorSnapshotRestoreCommand{Meta: Meta{Ui: ui}}
+	code = restoreCmd.Run([]string{"--address=" + url2, "-decrypt=" + pubPath, objectURL})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "failed to decrypt snapshot")
+
+	ui = cli.NewMockUi()
+	restoreCmd = &OperatorSnapshotRestoreCommand{Meta: Meta{Ui: ui}}
+	code = restoreCmd.Run([]string{"--address=" + url2, "-decrypt=" + privPath, "-checksum=" + checksum, objectURL})
+	must.Eq(t, "", ui.ErrorWriter.String())
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "Snapshot Restored")
+
+	foundJob, err := srv2.Agent.Server().State().JobByID(nil, structs.DefaultNamespace, "snapshot-test-job")
+	must.NoError(t, err)
+	must.NotNil(t, foundJob)
+}
@@ -4,12 +4,14 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/helper/raftutil"
 	"github.com/posener/complete"
@@ -21,11 +23,17 @@ type OperatorSnapshotSaveCommand struct {
 
 func (c *OperatorSnapshotSaveCommand) Help() string {
 	helpText := `
-Usage: nomad operator snapshot save [options] <file>
+Usage: nomad operator snapshot save [options] <file|url>
 
   Retrieves an atomic, point-in-time snapshot of the state of the Nomad servers
   which includes jobs, nodes, allocations, periodic jobs, and ACLs.
 
+  The snapshot may be written to a local file, or streamed to an object store
+  given an s3://<bucket>/<key> or gs://<bucket>/<object> URL, which use the
+  credentials found by the AWS and Google Cloud SDKs. An https:// URL is written
+  with a PUT request, such as a pre-signed S3 URL or an Azure Blob Storage SAS
+  URL.
+
   If ACLs are enabled, a management token must be supplied in order to perform
   snapshot operations.
 
@@ -38,6 +46,10 @@ Usage: nomad operator snapshot save [options] <file>
 
     $ nomad operator snapshot save -stale backup.snap
 
+  To save a snapshot to S3, encrypted for the OpenPGP public key in "ops.asc":
+
+    $ nomad operator snapshot save -encrypt=ops.asc s3://backups/nomad.snap.gpg
+
   This is useful for situations where a cluster is in a degraded state and no
   leader is available. To target a specific server for a snapshot, you can run
   the 'nomad operator snapshot save' command on that specific server.
@@ -49,6 +61,12 @@ General Options:
 
 Snapshot Save Options:
 
+  -encrypt=<file>
+    Encrypt the snapshot for the OpenPGP public keys in the file, which may be
+    ASCII armored or binary. The snapshot can only be restored with the private
+    key of one of the recipients, by passing it to the -decrypt option of
+    'nomad operator snapshot restore'.
+
   -redact
     The -redact option will locally edit the snapshot to remove any cleartext key
     material from the root keyring. Only the AEAD keyring provider has cleartext
@@ -66,7 +84,9 @@ Snapshot Save Options:
 func (c *OperatorSnapshotSaveCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-stale": complete.PredictAnything,
+			"-encrypt": complete.PredictFiles("*"),
+			"-redact":  complete.PredictNothing,
+			"-stale":   complete.PredictAnything,
 		})
 }
 
@@ -82,12 +102,14 @@ func (c *OperatorSnapshotSaveCommand) Name() string { return "operator snapshot
 
 func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 	var stale, redact bool
+	var encrypt string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 
 	flags.BoolVar(&stale, "stale", false, "")
 	flags.BoolVar(&redact, "redact", false, "")
+	flags.StringVar(&encrypt, "encrypt", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
@@ -98,7 +120,7 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 	// Check that we either got no filename or exactly one.
 	args = flags.Args()
 	if len(args) > 1 {
-		c.Ui.Error("This command takes either no arguments or one: <filename>")
+		c.Ui.Error("This command takes either no arguments or one: <filename|url>")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
@@ -110,7 +132,10 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 		filename = args[0]
 	}
 
-	if _, err := os.Lstat(filename); err == nil {
+	remote := isRemoteSnapshot(filename)
+	if remote {
+		// object stores are written to from a temporary file
+	} else if _, err := os.Lstat(filename); err == nil {
 		c.Ui.Error(fmt.Sprintf("Destination file already exists: %q", filename))
 		c.Ui.Error(commandErrorText(c))
 		return 1
@@ -119,6 +144,16 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 		return 1
 	}
 
+	var recipients openpgp.EntityList
+	if encrypt != "" {
+		var err error
+		recipients, err = readKeyRing(encrypt)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading encryption keys: %v", err))
+			return 1
+		}
+	}
+
 	// Set up a client.
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -126,11 +161,20 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 		return 1
 	}
 
-	tmpFile, err := os.Create(filename + ".tmp")
+	var tmpFile *os.File
+	if remote {
+		tmpFile, err = os.CreateTemp("", "nomad-snapshot-*.tmp")
+	} else {
+		tmpFile, err = os.Create(filename + ".tmp")
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to create file: %v", err))
 		return 1
 	}
+	defer func() {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}()
 
 	// Fetch the current configuration.
 	q := &api.QueryOptions{
@@ -159,12 +203,57 @@ func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
 		}
 	}
 
-	err = os.Rename(tmpFile.Name(), filename)
+	if recipients != nil {
+		encrypted, err := c.encrypt(tmpFile, recipients)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to encrypt snapshot: %v", err))
+			return 1
+		}
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		tmpFile = encrypted
+	}
+
+	checksum, err := fileChecksum(tmpFile)
 	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Failed to finalize snapshot file: %v", err))
+		c.Ui.Error(fmt.Sprintf("Failed to checksum snapshot file: %v", err))
 		return 1
 	}
 
+	if remote {
+		err = uploadSnapshot(context.Background(), filename, tmpFile)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to upload snapshot file: %v", err))
+			return 1
+		}
+	} else {
+		err = os.Rename(tmpFile.Name(), filename)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to finalize snapshot file: %v", err))
+			return 1
+		}
+	}
+
 	c.Ui.Output(fmt.Sprintf("State file written to %v", filename))
+	c.Ui.Output(fmt.Sprintf("Snapshot checksum: %s", checksum))
 	return 0
 }
+
+// encrypt writes the snapshot in f to a new temporary file next to f,
+// encrypted for the recipients.
+func (c *OperatorSnapshotSaveCommand) encrypt(f *os.File, recipients openpgp.EntityList) (*os.File, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	out, err := os.Create(f.Name() + ".enc")
+	if err != nil {
+		return nil, err
+	}
+	if err := encryptSnapshot(out, f, recipients); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	// outputWide is the value of the -o flag displaying all the columns
+	// available to a list command.
+	outputWide = "wide"
+
+	// outputColumnsPrefix prefixes the value of the -o flag selecting the
+	// columns displayed by a list command, as in "columns=ID,Status".
+	outputColumnsPrefix = "columns="
+)
+
+// outputColumn is a column of the table displayed by a list command when the
+// -o flag is given.
+type outputColumn[T any] struct {
+	// name is the header of the column, which is also used to select it.
+	name string
+
+	// value returns the content of the column for a row.
+	value func(T) string
+}
+
+// validateOutputFlags returns an error if the -o flag is combined with
+// another output format flag.
+func validateOutputFlags(json bool, tmpl, output string) error {
+	if output != "" && (json || len(tmpl) > 0) {
+		return fmt.Errorf("-o cannot be used with -json or -t")
+	}
+	return nil
+}
+
+// formatOutput formats rows into a table of the columns selected by the value
+// of the -o flag.
+func formatOutput[T any](output string, rows []T, columns []outputColumn[T]) (string, error) {
+	selected, err := selectOutputColumns(output, columns)
+	if err != nil {
+		return "", err
+	}
+	return formatOutputColumns(rows, selected), nil
+}
+
+// selectOutputColumns returns the columns selected by the value of the -o
+// flag: all of them for "wide", or those named by "columns=A,B,C" in the
+// given order. Names are matched regardless of case, spaces, dashes and
+// underscores, so that "Submit Date" can be selected with "submit_date".
+func selectOutputColumns[T any](output string, columns []outputColumn[T]) ([]outputColumn[T], error) {
+	if output == outputWide {
+		return columns, nil
+	}
+
+	list, ok := strings.CutPrefix(output, outputColumnsPrefix)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported output %q, must be %q or %q followed by a comma-separated list of columns",
+			output, outputWide, outputColumnsPrefix)
+	}
+
+	byName := make(map[string]outputColumn[T], len(columns))
+	for _, column := range columns {
+		byName[normalizeColumnName(column.name)] = column
+	}
+
+	var selected []outputColumn[T]
+	for _, name := range strings.Split(list, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		column, ok := byName[normalizeColumnName(name)]
+		if !ok {
+			return nil, fmt.Errorf("Unknown column %q, available columns are: %s",
+				strings.TrimSpace(name), outputColumnNames(columns))
+		}
+		selected = append(selected, column)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("No columns selected, available columns are: %s",
+			outputColumnNames(columns))
+	}
+	return selected, nil
+}
+
+// normalizeColumnName returns the name used to match a column selected by
+// the -o flag.
+func normalizeColumnName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// outputColumnNames returns the comma-separated names of columns, for use in
+// error messages.
+func outputColumnNames[T any](columns []outputColumn[T]) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatOutputColumns formats rows into a table of the given columns, with a
+// header line. Unlike formatList, columns are aligned on the width of their
+// contents in a terminal, so that names holding wide characters, such as
+// those of CJK scripts or emoji, do not shift the columns which follow. Blank
+// fields are replaced with a placeholder for awk-ability.
+func formatOutputColumns[T any](rows []T, columns []outputColumn[T]) string {
+	cells := make([][]string, len(rows)+1)
+	cells[0] = make([]string, len(columns))
+	for i, column := range columns {
+		cells[0][i] = column.name
+	}
+	for i, row := range rows {
+		cells[i+1] = make([]string, len(columns))
+		for j, column := range columns {
+			value := column.value(row)
+			if strings.TrimSpace(value) == "" {
+				value = "<none>"
+			}
+			cells[i+1][j] = value
+		}
+	}
+
+	widths := make([]int, len(columns))
+	for _, line := range cells {
+		for i, cell := range line {
+			widths[i] = max(widths[i], runewidth.StringWidth(cell))
+		}
+	}
+
+	var b strings.Builder
+	for n, line := range cells {
+		if n > 0 {
+			b.WriteByte('\n')
+		}
+		for i, cell := range line {
+			b.WriteString(cell)
+			if i == len(line)-1 {
+				break
+			}
+			b.WriteString(strings.Repeat(" ", widths[i]-runewidth.StringWidth(cell)+2))
+		}
+	}
+	return b.String()
+}
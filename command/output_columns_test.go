@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mattn/go-runewidth"
+	"github.com/shoenig/test/must"
+)
+
+type testOutputRow struct {
+	Name   string
+	Status string
+}
+
+var testOutputColumns = []outputColumn[testOutputRow]{
+	{name: "Name", value: func(r testOutputRow) string { return r.Name }},
+	{name: "Submit Date", value: func(r testOutputRow) string { return "today" }},
+	{name: "Status", value: func(r testOutputRow) string { return r.Status }},
+}
+
+func TestOutputColumns_selectOutputColumns(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		output string
+		exp    []string
+		expErr string
+	}{
+		{
+			output: "wide",
+			exp:    []string{"Name", "Submit Date", "Status"},
+		},
+		{
+			output: "columns=Status,Name",
+			exp:    []string{"Status", "Name"},
+		},
+		{
+			output: "columns=status, submit_date,",
+			exp:    []string{"Status", "Submit Date"},
+		},
+		{
+			output: "columns=SubmitDate",
+			exp:    []string{"Submit Date"},
+		},
+		{
+			output: "columns=Name,Bogus",
+			expErr: `Unknown column "Bogus", available columns are: Name, Submit Date, Status`,
+		},
+		{
+			output: "columns=",
+			expErr: "No columns selected",
+		},
+		{
+			output: "yaml",
+			expErr: `Unsupported output "yaml"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.output, func(t *testing.T) {
+			columns, err := selectOutputColumns(tc.output, testOutputColumns)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, strings.Split(outputColumnNames(columns), ", "))
+		})
+	}
+}
+
+func TestOutputColumns_formatOutputColumns(t *testing.T) {
+	ci.Parallel(t)
+
+	rows := []testOutputRow{
+		{Name: "web", Status: "running"},
+		{Name: "", Status: "pending"},
+	}
+	out := formatOutputColumns(rows, []outputColumn[testOutputRow]{
+		testOutputColumns[2], testOutputColumns[0],
+	})
+	must.Eq(t, "Status   Name\nrunning  web\npending  <none>", out)
+}
+
+func TestOutputColumns_formatOutputColumns_wide(t *testing.T) {
+	ci.Parallel(t)
+
+	rows := []testOutputRow{
+		{Name: "web", Status: "running"},
+		{Name: "ウェブサーバー", Status: "running"},
+		{Name: "数据库", Status: "pending"},
+		{Name: "café", Status: "dead"},
+	}
+	columns := []outputColumn[testOutputRow]{testOutputColumns[0], testOutputColumns[2]}
+	lines := strings.Split(formatOutputColumns(rows, columns), "\n")
+	must.SliceLen(t, len(rows)+1, lines)
+
+	// the second column starts at the same position on the terminal on every
+	// line, which is two cells past the widest name of 7 double width runes
+	for _, line := range lines {
+		name, status, ok := strings.Cut(line, "  ")
+		must.True(t, ok, must.Sprint(line))
+		status = strings.TrimLeft(status, " ")
+		must.Eq(t, 16, runewidth.StringWidth(line)-runewidth.StringWidth(status), must.Sprint(line))
+		must.StrHasPrefix(t, name, line)
+	}
+	must.Eq(t, "ウェブサーバー  running", lines[2])
+	must.Eq(t, "数据库          pending", lines[3])
+	must.Eq(t, "café            dead", lines[4])
+}
+
+func TestOutputColumns_validateOutputFlags(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, validateOutputFlags(false, "", "wide"))
+	must.NoError(t, validateOutputFlags(true, "", ""))
+	must.ErrorContains(t, validateOutputFlags(true, "", "wide"), "-o cannot be used with -json or -t")
+	must.ErrorContains(t, validateOutputFlags(false, "{{.}}", "wide"), "-o cannot be used with -json or -t")
+}
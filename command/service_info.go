@@ -36,7 +36,7 @@ Usage: nomad service info [options] <service_name>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsStale) + `
 
 Service Info Options:
 
@@ -67,7 +67,7 @@ func (s *ServiceInfoCommand) Synopsis() string {
 }
 
 func (s *ServiceInfoCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient|FlagSetStale),
 		complete.Flags{
 			"-json":       complete.PredictNothing,
 			"-filter":     complete.PredictAnything,
@@ -89,7 +89,7 @@ func (s *ServiceInfoCommand) Run(args []string) int {
 		tmpl, filter, pageToken string
 	)
 
-	flags := s.Meta.FlagSet(s.Name(), FlagSetClient)
+	flags := s.Meta.FlagSet(s.Name(), FlagSetClient|FlagSetStale)
 	flags.Usage = func() { s.Ui.Output(s.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
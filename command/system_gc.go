@@ -5,8 +5,11 @@ package command
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
 )
 
@@ -24,7 +27,22 @@ Usage: nomad system gc [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+GC Options:
+
+  -type <types>
+    Comma separated list of the types of objects to garbage collect, out of
+    "jobs", "evals", "deployments", "volumes", "nodes", and "plugins". All
+    objects are garbage collected by default.
+
+  -dry-run
+    Report the number of objects of each type eligible for garbage collection,
+    along with the server GC thresholds, without garbage collecting any of
+    them. "Eligible" objects are collected by the periodic garbage collection
+    with the configured thresholds, while "Forced" objects are collected by
+    this command, which ignores the thresholds.
+`
 	return strings.TrimSpace(helpText)
 }
 
@@ -33,7 +51,11 @@ func (c *SystemGCCommand) Synopsis() string {
 }
 
 func (c *SystemGCCommand) AutocompleteFlags() complete.Flags {
-	return c.Meta.AutocompleteFlags(FlagSetClient)
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-type":    complete.PredictSet(gcTypes...),
+			"-dry-run": complete.PredictNothing,
+		})
 }
 
 func (c *SystemGCCommand) AutocompleteArgs() complete.Predictor {
@@ -43,8 +65,13 @@ func (c *SystemGCCommand) AutocompleteArgs() complete.Predictor {
 func (c *SystemGCCommand) Name() string { return "system gc" }
 
 func (c *SystemGCCommand) Run(args []string) int {
+	var types string
+	var dryRun bool
+
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&types, "type", "", "")
+	flags.BoolVar(&dryRun, "dry-run", false, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -55,6 +82,18 @@ func (c *SystemGCCommand) Run(args []string) int {
 		c.Ui.Error(commandErrorText(c))
 	}
 
+	opts := &api.GarbageCollectOptions{DryRun: dryRun}
+	if types != "" {
+		for _, t := range strings.Split(types, ",") {
+			t = strings.TrimSpace(t)
+			if !slices.Contains(gcTypes, t) {
+				c.Ui.Error(fmt.Sprintf("Invalid -type %q, must be one of %s", t, strings.Join(gcTypes, ", ")))
+				return 1
+			}
+			opts.Types = append(opts.Types, t)
+		}
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -62,9 +101,44 @@ func (c *SystemGCCommand) Run(args []string) int {
 		return 1
 	}
 
-	if err := client.System().GarbageCollect(); err != nil {
+	resp, _, err := client.System().GarbageCollectOpts(opts, nil)
+	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error running system garbage-collection: %s", err))
 		return 1
 	}
+
+	if dryRun {
+		c.Ui.Output(formatGCStats(resp.Classes))
+	}
 	return 0
 }
+
+// gcTypes are the types of objects a garbage collection can be targeted at.
+var gcTypes = []string{"jobs", "evals", "deployments", "volumes", "nodes", "plugins"}
+
+// formatGCStats formats the objects eligible for garbage collection as a
+// table, in the order of gcTypes.
+func formatGCStats(classes map[string]*api.GarbageCollectClassStats) string {
+	rows := []string{"Type|Eligible|Forced|Thresholds"}
+	for _, t := range gcTypes {
+		stats, ok := classes[t]
+		if !ok {
+			continue
+		}
+
+		names := make([]string, 0, len(stats.Thresholds))
+		for name := range stats.Thresholds {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		thresholds := make([]string, 0, len(names))
+		for _, name := range names {
+			thresholds = append(thresholds, fmt.Sprintf("%s=%s", name, stats.Thresholds[name]))
+		}
+
+		rows = append(rows, fmt.Sprintf("%s|%d|%d|%s",
+			t, stats.Eligible, stats.Forced, strings.Join(thresholds, ", ")))
+	}
+	return formatList(rows)
+}
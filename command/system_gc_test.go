@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
 )
 
 func TestSystemGCCommand_Implements(t *testing.T) {
@@ -29,3 +30,33 @@ func TestSystemGCCommand_Good(t *testing.T) {
 		t.Fatalf("expected exit 0, got: %d; %v", code, ui.ErrorWriter.String())
 	}
 }
+
+func TestSystemGCCommand_DryRun(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &SystemGCCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "-type=evals,deployments", "-dry-run"})
+	must.Zero(t, code)
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "eval_gc_threshold=1h0m0s")
+	must.StrContains(t, out, "deployment_gc_threshold=1h0m0s")
+	must.StrNotContains(t, out, "job_gc_threshold")
+}
+
+func TestSystemGCCommand_InvalidType(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &SystemGCCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-type=evals,allocs"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `Invalid -type "allocs"`)
+}
@@ -7,7 +7,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -55,6 +58,23 @@ type taskHandle struct {
 
 	exitResult     *drivers.ExitResult
 	exitResultLock sync.Mutex
+
+	// usage is the resource usage of the container observed while collecting
+	// its stats, which is reported in the exit result since the cgroup of the
+	// container is removed when it exits
+	usage     exitUsage
+	usageLock sync.Mutex
+}
+
+// exitUsage is the resource usage of a container reported when it exits.
+type exitUsage struct {
+	maxRSS        uint64
+	userCPUTime   time.Duration
+	systemCPUTime time.Duration
+
+	// oomKills is the number of processes of the container killed by the
+	// OOM killer of its cgroup, which docker does not report with cgroups v2
+	oomKills uint64
 }
 
 func (h *taskHandle) ExitResult() *drivers.ExitResult {
@@ -302,6 +322,12 @@ func (h *taskHandle) run() {
 		h.logger.Error("failed to wait for container; already terminated")
 	}
 
+	// the cgroup of the container may not be removed yet
+	h.recordOOMKills()
+	h.usageLock.Lock()
+	usage := h.usage
+	h.usageLock.Unlock()
+
 	ctx, inspectCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer inspectCancel()
 
@@ -309,7 +335,8 @@ func (h *taskHandle) run() {
 	oom := false
 	if ierr != nil {
 		h.logger.Error("failed to inspect container", "error", ierr)
-	} else if container.State.OOMKilled {
+	}
+	if (ierr == nil && container.State.OOMKilled) || usage.oomKills > 0 {
 		h.logger.Error("OOM Killed",
 			"container_id", h.containerID,
 			"container_image", h.containerImage,
@@ -318,8 +345,9 @@ func (h *taskHandle) run() {
 			"nomad_alloc_id", h.task.AllocID)
 
 		// Note that with cgroups.v2 the cgroup OOM killer is not
-		// observed by docker container status. But we can't test the
-		// exit code, as 137 is used for any SIGKILL
+		// observed by docker container status, so the OOM kills of the
+		// cgroup are counted too. We can't test the exit code, as 137 is
+		// used for any SIGKILL
 		oom = true
 		werr = fmt.Errorf("OOM Killed")
 	}
@@ -340,14 +368,83 @@ func (h *taskHandle) run() {
 		}
 	}
 
-	// Set the result
+	// Set the result. The resource usage is the last observed while the
+	// container ran, since its cgroup is removed when it exits.
 	h.exitResultLock.Lock()
 	h.exitResult = &drivers.ExitResult{
-		ExitCode:  int(exitCode.StatusCode),
-		Signal:    0,
-		OOMKilled: oom,
-		Err:       werr,
+		ExitCode:      int(exitCode.StatusCode),
+		Signal:        exitSignal(exitCode.StatusCode),
+		OOMKilled:     oom,
+		Err:           werr,
+		MaxRSS:        usage.maxRSS,
+		UserCPUTime:   usage.userCPUTime,
+		SystemCPUTime: usage.systemCPUTime,
 	}
 	h.exitResultLock.Unlock()
 	close(h.waitCh)
 }
+
+// recordUsage records the resource usage of the container in stats, to report
+// it in the exit result.
+func (h *taskHandle) recordUsage(stats *containerapi.StatsResponse) {
+	rss := stats.MemoryStats.Stats["rss"]
+	if rss == 0 {
+		// This is the equivalent stat of anonymous mappings for cgroups v2.
+		rss = stats.MemoryStats.Stats["anon"]
+	}
+
+	// docker reports CPU time in nanoseconds, or in 100s of nanoseconds on
+	// Windows
+	unit := time.Nanosecond
+	if runtime.GOOS == "windows" {
+		unit = 100 * time.Nanosecond
+	}
+
+	h.usageLock.Lock()
+	defer h.usageLock.Unlock()
+	h.usage.maxRSS = max(h.usage.maxRSS, rss)
+	h.usage.userCPUTime = time.Duration(stats.CPUStats.CPUUsage.UsageInUsermode) * unit
+	h.usage.systemCPUTime = time.Duration(stats.CPUStats.CPUUsage.UsageInKernelmode) * unit
+	h.recordOOMKillsLocked()
+}
+
+// recordOOMKills records the number of OOM kills of the cgroup of the
+// container, if it uses cgroups v2 and the cgroup still exists.
+func (h *taskHandle) recordOOMKills() {
+	h.usageLock.Lock()
+	defer h.usageLock.Unlock()
+	h.recordOOMKillsLocked()
+}
+
+func (h *taskHandle) recordOOMKillsLocked() {
+	if cgroupslib.GetMode() != cgroupslib.CG2 {
+		return
+	}
+	b, err := os.ReadFile(filepath.Join(h.dockerCgroup(), "memory.events"))
+	if err != nil {
+		return
+	}
+	h.usage.oomKills = max(h.usage.oomKills, parseOOMKills(string(b)))
+}
+
+// parseOOMKills returns the oom_kill count of the content of a cgroups v2
+// memory.events file.
+func parseOOMKills(events string) uint64 {
+	for _, line := range strings.Split(events, "\n") {
+		if value, ok := strings.CutPrefix(line, "oom_kill "); ok {
+			n, _ := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+// exitSignal returns the signal which terminated a container exiting with
+// code, which the container runtime reports as 128 plus the signal number, or
+// 0 if it exited on its own.
+func exitSignal(code int64) int {
+	if code > 128 && code <= 128+64 {
+		return int(code - 128)
+	}
+	return 0
+}
@@ -5,7 +5,9 @@ package docker
 
 import (
 	"testing"
+	"time"
 
+	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/shoenig/test/must"
@@ -50,3 +52,44 @@ func Test_dockerCgroup(t *testing.T) {
 		must.Eq(t, "/sys/fs/cgroup/docker/abc123", result)
 	})
 }
+
+func Test_exitSignal(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, 0, exitSignal(0))
+	must.Eq(t, 0, exitSignal(1))
+	must.Eq(t, 0, exitSignal(128))
+	must.Eq(t, 9, exitSignal(137))
+	must.Eq(t, 15, exitSignal(143))
+	must.Eq(t, 0, exitSignal(255))
+}
+
+func Test_parseOOMKills(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, 2, parseOOMKills("low 0\nhigh 0\nmax 4\noom 2\noom_kill 2\noom_group_kill 0\n"))
+	must.Eq(t, 0, parseOOMKills("low 0\nhigh 0\n"))
+}
+
+func TestTaskHandle_recordUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	// the cgroup of the container does not exist
+	h := &taskHandle{containerCgroup: t.TempDir()}
+
+	stats := new(containerapi.StatsResponse)
+	stats.MemoryStats.Stats = map[string]uint64{"anon": 4096}
+	stats.CPUStats.CPUUsage.UsageInUsermode = 3000
+	stats.CPUStats.CPUUsage.UsageInKernelmode = 1000
+	h.recordUsage(stats)
+
+	// the maximum RSS is kept while the CPU times are the latest
+	stats.MemoryStats.Stats = map[string]uint64{"rss": 1024}
+	stats.CPUStats.CPUUsage.UsageInUsermode = 5000
+	h.recordUsage(stats)
+
+	must.Eq(t, 4096, h.usage.maxRSS)
+	must.Eq(t, 5000*time.Nanosecond, h.usage.userCPUTime)
+	must.Eq(t, 1000*time.Nanosecond, h.usage.systemCPUTime)
+	must.Eq(t, 0, h.usage.oomKills)
+}
@@ -113,6 +113,7 @@ func (h *taskHandle) collectStats(ctx context.Context, destCh *usageSender, inte
 			stats, err := h.collectDockerStats(ctx)
 			switch err {
 			case nil:
+				h.recordUsage(stats)
 				resourceUsage := util.DockerStatsToTaskResourceUsage(stats, compute)
 				destCh.send(resourceUsage)
 				ticker.Reset(interval)
@@ -603,9 +603,13 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode:  ps.ExitCode,
-			Signal:    ps.Signal,
-			OOMKilled: ps.OOMKilled,
+			ExitCode:      ps.ExitCode,
+			Signal:        ps.Signal,
+			OOMKilled:     ps.OOMKilled,
+			CoreDumped:    ps.CoreDumped,
+			MaxRSS:        ps.MaxRSS,
+			UserCPUTime:   ps.UserCPUTime,
+			SystemCPUTime: ps.SystemCPUTime,
 		}
 	}
 
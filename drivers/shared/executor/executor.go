@@ -308,6 +308,25 @@ type ProcessState struct {
 	Signal    int
 	OOMKilled bool
 	Time      time.Time
+
+	// CoreDumped, MaxRSS (in bytes), UserCPUTime and SystemCPUTime are taken
+	// from the resource usage of the process once it exited, where the
+	// platform reports them.
+	CoreDumped    bool
+	MaxRSS        uint64
+	UserCPUTime   time.Duration
+	SystemCPUTime time.Duration
+}
+
+// setResourceUsage sets the core dump flag and the resource usage of the
+// exited process described by state.
+func (ps *ProcessState) setResourceUsage(state *os.ProcessState) {
+	if state == nil {
+		return
+	}
+	ps.UserCPUTime = state.UserTime()
+	ps.SystemCPUTime = state.SystemTime()
+	ps.CoreDumped, ps.MaxRSS = coreDumpedAndMaxRSS(state)
 }
 
 // ExecutorVersion is the version of the executor
@@ -573,6 +592,7 @@ func (e *UniversalExecutor) wait() {
 	err := e.childCmd.Wait()
 	if err == nil {
 		e.exitState = &ProcessState{Pid: pid, ExitCode: 0, Time: time.Now()}
+		e.exitState.setResourceUsage(e.childCmd.ProcessState)
 		return
 	}
 
@@ -599,6 +619,7 @@ func (e *UniversalExecutor) wait() {
 	}
 
 	e.exitState = &ProcessState{Pid: pid, ExitCode: exitCode, Signal: signal, Time: time.Now()}
+	e.exitState.setResourceUsage(e.childCmd.ProcessState)
 }
 
 var (
@@ -335,6 +335,7 @@ func (l *LibcontainerExecutor) wait() {
 		OOMKilled: oomKilled.Load(),
 		Time:      time.Now(),
 	}
+	l.exitState.setResourceUsage(ps)
 }
 
 // Shutdown stops all processes started and cleans up any resources
@@ -180,6 +180,8 @@ func TestExecutor_Start_Wait(t *testing.T) {
 
 			ps, err = executor.Wait(context.Background())
 			require.NoError(err)
+			require.NotZero(ps.MaxRSS)
+			require.False(ps.CoreDumped)
 			require.NoError(executor.Shutdown("SIGINT", 100*time.Millisecond))
 
 			expected := "hello world"
@@ -825,6 +825,10 @@ type ProcessState struct {
 	Signal               int32                `protobuf:"varint,3,opt,name=signal,proto3" json:"signal,omitempty"`
 	Time                 *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
 	OomKilled            bool                 `protobuf:"varint,5,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	CoreDumped           bool                 `protobuf:"varint,6,opt,name=core_dumped,json=coreDumped,proto3" json:"core_dumped,omitempty"`
+	MaxRssBytes          uint64               `protobuf:"varint,7,opt,name=max_rss_bytes,json=maxRssBytes,proto3" json:"max_rss_bytes,omitempty"`
+	UserCpuTimeNanos     int64                `protobuf:"varint,8,opt,name=user_cpu_time_nanos,json=userCpuTimeNanos,proto3" json:"user_cpu_time_nanos,omitempty"`
+	SystemCpuTimeNanos   int64                `protobuf:"varint,9,opt,name=system_cpu_time_nanos,json=systemCpuTimeNanos,proto3" json:"system_cpu_time_nanos,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -890,6 +894,34 @@ func (m *ProcessState) GetOomKilled() bool {
 	return false
 }
 
+func (m *ProcessState) GetCoreDumped() bool {
+	if m != nil {
+		return m.CoreDumped
+	}
+	return false
+}
+
+func (m *ProcessState) GetMaxRssBytes() uint64 {
+	if m != nil {
+		return m.MaxRssBytes
+	}
+	return 0
+}
+
+func (m *ProcessState) GetUserCpuTimeNanos() int64 {
+	if m != nil {
+		return m.UserCpuTimeNanos
+	}
+	return 0
+}
+
+func (m *ProcessState) GetSystemCpuTimeNanos() int64 {
+	if m != nil {
+		return m.SystemCpuTimeNanos
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest.CgroupV1OverrideEntry")
@@ -916,83 +948,88 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1205 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xfb, 0x6e, 0x1b, 0xc5,
-	0x17, 0xee, 0xc6, 0x71, 0x6c, 0x1f, 0xdb, 0x89, 0x3b, 0xbf, 0x36, 0xdd, 0xfa, 0x27, 0xd4, 0xb0,
-	0x48, 0xd4, 0x82, 0xb2, 0x69, 0xd3, 0xab, 0x40, 0xa2, 0xd0, 0xa4, 0xa0, 0xaa, 0x17, 0xa2, 0x4d,
-	0x69, 0x25, 0xfe, 0x60, 0x99, 0xee, 0x4e, 0xed, 0xa9, 0xd7, 0x3b, 0xcb, 0xcc, 0xac, 0x9b, 0x48,
-	0x48, 0xbc, 0x04, 0x48, 0x3c, 0x00, 0xaf, 0xc7, 0x3b, 0xa0, 0xb9, 0x6d, 0xec, 0xb4, 0x54, 0x9b,
-	0x22, 0xfe, 0xca, 0x9e, 0xcf, 0xdf, 0xb9, 0xcc, 0x39, 0x73, 0xbe, 0x09, 0x5c, 0x49, 0x39, 0x9d,
-	0x13, 0x2e, 0xb6, 0xc5, 0x04, 0x73, 0x92, 0x6e, 0x93, 0x43, 0x92, 0x94, 0x92, 0xf1, 0xed, 0x82,
-	0x33, 0xc9, 0x2a, 0x33, 0xd4, 0x26, 0xfa, 0x78, 0x82, 0xc5, 0x84, 0x26, 0x8c, 0x17, 0x61, 0xce,
-	0x66, 0x38, 0x0d, 0x8b, 0xac, 0x1c, 0xd3, 0x5c, 0x84, 0xcb, 0xbc, 0xe1, 0xa5, 0x31, 0x63, 0xe3,
-	0x8c, 0x98, 0x20, 0x2f, 0xca, 0x97, 0xdb, 0x92, 0xce, 0x88, 0x90, 0x78, 0x56, 0x58, 0x42, 0x60,
-	0x1d, 0xb7, 0x5d, 0x7a, 0x93, 0xce, 0x58, 0x86, 0x13, 0xfc, 0xd5, 0x86, 0xfe, 0x23, 0x5c, 0xe6,
-	0xc9, 0x24, 0x22, 0x3f, 0x97, 0x44, 0x48, 0x34, 0x80, 0x46, 0x32, 0x4b, 0x7d, 0x6f, 0xcb, 0x1b,
-	0x75, 0x22, 0xf5, 0x89, 0x10, 0xac, 0x62, 0x3e, 0x16, 0xfe, 0xca, 0x56, 0x63, 0xd4, 0x89, 0xf4,
-	0x37, 0x7a, 0x02, 0x1d, 0x4e, 0x04, 0x2b, 0x79, 0x42, 0x84, 0xdf, 0xd8, 0xf2, 0x46, 0xdd, 0x9d,
-	0xab, 0xe1, 0x3f, 0x15, 0x6e, 0xf3, 0x9b, 0x94, 0x61, 0xe4, 0xfc, 0xa2, 0xe3, 0x10, 0xe8, 0x12,
-	0x74, 0x85, 0x4c, 0x59, 0x29, 0xe3, 0x02, 0xcb, 0x89, 0xbf, 0xaa, 0xb3, 0x83, 0x81, 0xf6, 0xb1,
-	0x9c, 0x58, 0x02, 0xe1, 0xdc, 0x10, 0x9a, 0x15, 0x81, 0x70, 0xae, 0x09, 0x03, 0x68, 0x90, 0x7c,
-	0xee, 0xaf, 0xe9, 0x22, 0xd5, 0xa7, 0xaa, 0xbb, 0x14, 0x84, 0xfb, 0x2d, 0xcd, 0xd5, 0xdf, 0xe8,
-	0x22, 0xb4, 0x25, 0x16, 0xd3, 0x38, 0xa5, 0xdc, 0x6f, 0x6b, 0xbc, 0xa5, 0xec, 0x3d, 0xca, 0xd1,
-	0x65, 0xd8, 0x70, 0xf5, 0xc4, 0x19, 0x9d, 0x51, 0x29, 0xfc, 0xce, 0x96, 0x37, 0x6a, 0x47, 0xeb,
-	0x0e, 0x7e, 0xa4, 0x51, 0x74, 0x03, 0xce, 0xbd, 0xc0, 0x82, 0x26, 0x71, 0xc1, 0x59, 0x42, 0x84,
-	0x88, 0x93, 0x31, 0x67, 0x65, 0xe1, 0x83, 0x62, 0xdf, 0x5b, 0xf1, 0xbd, 0x08, 0xe9, 0xdf, 0xf7,
-	0xcd, 0xcf, 0xbb, 0xfa, 0x57, 0xb4, 0x07, 0x6b, 0x33, 0x56, 0xe6, 0x52, 0xf8, 0xdd, 0xad, 0xc6,
-	0xa8, 0xbb, 0x73, 0xa5, 0x66, 0xbb, 0x1e, 0x2b, 0xa7, 0xc8, 0xfa, 0xa2, 0x6f, 0xa1, 0x95, 0x92,
-	0x39, 0x55, 0x5d, 0xef, 0xe9, 0x30, 0x9f, 0xd5, 0x0c, 0xb3, 0xa7, 0xbd, 0x22, 0xe7, 0x8d, 0x26,
-	0x70, 0x36, 0x27, 0xf2, 0x35, 0xe3, 0xd3, 0x98, 0x0a, 0x96, 0x61, 0x49, 0x59, 0xee, 0xf7, 0xf5,
-	0x20, 0xbf, 0xa8, 0x19, 0xf2, 0x89, 0xf1, 0x7f, 0xe0, 0xdc, 0x0f, 0x0a, 0x92, 0x44, 0x83, 0xfc,
-	0x04, 0x8a, 0x02, 0xe8, 0xe7, 0x2c, 0x2e, 0xe8, 0x9c, 0xc9, 0x98, 0x33, 0x26, 0xfd, 0x75, 0xdd,
-	0xd5, 0x6e, 0xce, 0xf6, 0x15, 0x16, 0x31, 0x26, 0xd1, 0x08, 0x06, 0x29, 0x79, 0x89, 0xcb, 0x4c,
-	0xc6, 0x05, 0x4d, 0xe3, 0x19, 0x4b, 0x89, 0xbf, 0xa1, 0xc7, 0xb3, 0x6e, 0xf1, 0x7d, 0x9a, 0x3e,
-	0x66, 0x29, 0x59, 0x64, 0xd2, 0x22, 0x31, 0xcc, 0xc1, 0x12, 0xf3, 0x41, 0x91, 0x68, 0xe6, 0x47,
-	0xd0, 0x4f, 0x8a, 0x52, 0x10, 0xe9, 0xe6, 0x73, 0x56, 0xd3, 0x7a, 0x06, 0xb4, 0x53, 0xf9, 0x00,
-	0x00, 0x67, 0x19, 0x7b, 0x1d, 0x27, 0xb8, 0x10, 0x3e, 0xd2, 0x97, 0xa7, 0xa3, 0x91, 0x5d, 0x5c,
-	0x08, 0x14, 0x40, 0x2f, 0xc1, 0x05, 0x7e, 0x41, 0x33, 0x2a, 0x29, 0x11, 0xfe, 0xff, 0x34, 0x61,
-	0x09, 0x43, 0x57, 0x00, 0x99, 0x04, 0xf1, 0x7c, 0x27, 0x66, 0x73, 0xc2, 0x39, 0x4d, 0x89, 0x7f,
-	0x4e, 0x27, 0x1b, 0x98, 0x5f, 0x9e, 0xed, 0x7c, 0x67, 0x71, 0x74, 0x74, 0xcc, 0xbe, 0x76, 0xcc,
-	0x3e, 0xaf, 0x67, 0xf9, 0x30, 0xac, 0xb7, 0xfa, 0xe1, 0xd2, 0xc6, 0x86, 0xe6, 0x28, 0xcf, 0xae,
-	0xb9, 0x1c, 0xf7, 0x73, 0xc9, 0x8f, 0xaa, 0xd4, 0x15, 0xac, 0x06, 0xc1, 0xd8, 0x2c, 0x16, 0x09,
-	0xe3, 0x24, 0xc6, 0xe9, 0x2b, 0x7f, 0x73, 0xcb, 0x1b, 0x35, 0xa3, 0x2e, 0x63, 0xb3, 0x03, 0x85,
-	0x7d, 0x9d, 0xbe, 0x52, 0xfb, 0xa1, 0xef, 0x84, 0xda, 0x8f, 0x0b, 0x66, 0x3f, 0x94, 0xbd, 0x47,
-	0xf9, 0x70, 0x17, 0xce, 0xbf, 0x35, 0x93, 0xda, 0xbc, 0x29, 0x39, 0x72, 0x8a, 0x31, 0x25, 0x47,
-	0xe8, 0x1c, 0x34, 0xe7, 0x38, 0x2b, 0x89, 0xbf, 0xa2, 0x31, 0x63, 0x7c, 0xbe, 0x72, 0xc7, 0x0b,
-	0x7e, 0x82, 0x75, 0x57, 0xbc, 0x28, 0x58, 0x2e, 0x08, 0x7a, 0x02, 0x2d, 0xbb, 0x47, 0x3a, 0x42,
-	0x77, 0xe7, 0x46, 0xdd, 0x2e, 0xd8, 0xfd, 0x3a, 0x90, 0x58, 0x92, 0xc8, 0x05, 0x09, 0xfa, 0xd0,
-	0x7d, 0x8e, 0xa9, 0xb4, 0xcd, 0x09, 0x7e, 0x84, 0x9e, 0x31, 0xff, 0xa3, 0x74, 0x8f, 0x60, 0xe3,
-	0x60, 0x52, 0xca, 0x94, 0xbd, 0xce, 0x9d, 0x82, 0x6e, 0xc2, 0x9a, 0xa0, 0xe3, 0x1c, 0x67, 0xb6,
-	0x25, 0xd6, 0x42, 0x1f, 0x42, 0x6f, 0xcc, 0x71, 0x42, 0xe2, 0x82, 0x70, 0xca, 0x52, 0xdd, 0x9c,
-	0x46, 0xd4, 0xd5, 0xd8, 0xbe, 0x86, 0x02, 0x04, 0x83, 0xe3, 0x68, 0xa6, 0xe2, 0x60, 0x02, 0x9b,
-	0xdf, 0x17, 0xa9, 0x4a, 0x5a, 0x09, 0xa7, 0x4d, 0xb4, 0x24, 0xc2, 0xde, 0xbf, 0x16, 0xe1, 0xe0,
-	0x22, 0x5c, 0x78, 0x23, 0x93, 0x2d, 0x62, 0x00, 0xeb, 0xcf, 0x08, 0x17, 0x94, 0xb9, 0x53, 0x06,
-	0x9f, 0xc2, 0x46, 0x85, 0xd8, 0xde, 0xfa, 0xd0, 0x9a, 0x1b, 0xc8, 0x9e, 0xdc, 0x99, 0xc1, 0x27,
-	0xd0, 0x53, 0x7d, 0xab, 0x2a, 0x1f, 0x42, 0x9b, 0xe6, 0x92, 0xf0, 0xb9, 0x6d, 0x52, 0x23, 0xaa,
-	0xec, 0xe0, 0x39, 0xf4, 0x2d, 0xd7, 0x86, 0xfd, 0x06, 0x9a, 0x42, 0x01, 0xa7, 0x3c, 0xe2, 0x53,
-	0x2c, 0xa6, 0x26, 0x90, 0x71, 0x0f, 0x2e, 0x43, 0xff, 0x40, 0x4f, 0xe2, 0xed, 0x83, 0x6a, 0xba,
-	0x41, 0xa9, 0xc3, 0x3a, 0xa2, 0x3d, 0xfe, 0x14, 0xba, 0xf7, 0x0f, 0x49, 0xe2, 0x1c, 0x6f, 0x41,
-	0x3b, 0x25, 0x38, 0xcd, 0x68, 0x4e, 0x6c, 0x51, 0xc3, 0xd0, 0xbc, 0xc6, 0xa1, 0x7b, 0x8d, 0xc3,
-	0xa7, 0xee, 0x35, 0x8e, 0x2a, 0xae, 0x7b, 0x5b, 0x57, 0xde, 0x7c, 0x5b, 0x1b, 0xc7, 0x6f, 0x6b,
-	0xb0, 0x0b, 0x3d, 0x93, 0xcc, 0x9e, 0x7f, 0x13, 0xd6, 0x58, 0x29, 0x8b, 0x52, 0xea, 0x5c, 0xbd,
-	0xc8, 0x5a, 0xe8, 0xff, 0xd0, 0x21, 0x87, 0x54, 0xc6, 0x89, 0xd2, 0xc0, 0x15, 0x7d, 0x82, 0xb6,
-	0x02, 0x76, 0x59, 0x4a, 0x82, 0x3f, 0x3d, 0xe8, 0x2d, 0xde, 0x58, 0x95, 0xbb, 0xa0, 0xa9, 0x3d,
-	0xa9, 0xfa, 0x7c, 0xa7, 0xff, 0x42, 0x6f, 0x1a, 0x8b, 0xbd, 0x41, 0x21, 0xac, 0xaa, 0xff, 0x33,
-	0xf4, 0x0b, 0xfd, 0xee, 0x63, 0x6b, 0x9e, 0x12, 0x58, 0x25, 0x3a, 0x53, 0x9a, 0x65, 0x24, 0xd5,
-	0xcf, 0x76, 0x3b, 0xea, 0x30, 0x36, 0x7b, 0xa8, 0x81, 0x9d, 0xdf, 0x3b, 0xd0, 0xbe, 0x6f, 0xf7,
-	0x0c, 0x1d, 0xc1, 0x9a, 0x11, 0x07, 0x74, 0xf3, 0xbd, 0x94, 0x70, 0x78, 0xeb, 0xb4, 0x6e, 0x76,
-	0xbc, 0x67, 0x90, 0x80, 0x55, 0x25, 0x13, 0xe8, 0x7a, 0xdd, 0x08, 0x0b, 0x1a, 0x33, 0xbc, 0x71,
-	0x3a, 0xa7, 0x2a, 0xe9, 0xaf, 0xd0, 0x76, 0xdb, 0x8e, 0x6e, 0xd7, 0x8d, 0x71, 0x42, 0x6d, 0x86,
-	0x77, 0x4e, 0xef, 0x58, 0x15, 0xf0, 0x9b, 0x07, 0x1b, 0x27, 0x36, 0x1e, 0x7d, 0x59, 0x37, 0xde,
-	0xdb, 0x45, 0x69, 0x78, 0xf7, 0xbd, 0xfd, 0xab, 0xb2, 0x7e, 0x81, 0x96, 0x95, 0x16, 0x54, 0x7b,
-	0xa2, 0xcb, 0xea, 0x34, 0xbc, 0x7d, 0x6a, 0xbf, 0x2a, 0xfb, 0x21, 0x34, 0xb5, 0x6c, 0xa0, 0xda,
-	0x63, 0x5d, 0x94, 0xb6, 0xe1, 0xcd, 0x53, 0x7a, 0xb9, 0xbc, 0x57, 0x3d, 0x75, 0xff, 0x8d, 0xee,
-	0xd4, 0xbf, 0xff, 0x4b, 0x82, 0x56, 0xff, 0xfe, 0x9f, 0x90, 0x37, 0x7d, 0xff, 0xd5, 0x1a, 0xd6,
-	0xbf, 0xff, 0x0b, 0x72, 0x58, 0xff, 0xfe, 0x2f, 0xca, 0x5a, 0x70, 0x06, 0xfd, 0xe1, 0x41, 0x5f,
-	0x41, 0x07, 0x92, 0x13, 0x3c, 0xa3, 0xf9, 0x18, 0xdd, 0xad, 0xa9, 0xed, 0xca, 0xcb, 0xe8, 0xbb,
-	0xf5, 0x74, 0xa5, 0x7c, 0xf5, 0xfe, 0x01, 0x5c, 0x59, 0x23, 0xef, 0xaa, 0x77, 0xaf, 0xf5, 0x43,
-	0xd3, 0x48, 0xda, 0x9a, 0xfe, 0x73, 0xfd, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5b, 0xc0, 0xa1,
-	0x7e, 0xc4, 0x0d, 0x00, 0x00,
+	// 1284 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x6d, 0x6f, 0x1b, 0x45,
+	0x10, 0xc6, 0x76, 0xfc, 0x36, 0xb6, 0x13, 0x77, 0xdb, 0xa6, 0x57, 0x23, 0xd4, 0x70, 0x48, 0xd4,
+	0x82, 0xd6, 0x69, 0xd3, 0xf4, 0x45, 0x20, 0x51, 0x68, 0x52, 0x50, 0xd5, 0x36, 0x44, 0x97, 0xd2,
+	0x4a, 0x7c, 0xe0, 0xd8, 0xdc, 0x6d, 0xed, 0xad, 0xef, 0x6e, 0x8f, 0xdd, 0x3d, 0x37, 0x96, 0x90,
+	0xf8, 0x13, 0x20, 0xf1, 0x9f, 0xf8, 0x2d, 0xfc, 0x07, 0xb4, 0x2f, 0x77, 0xb1, 0xd3, 0x02, 0xe7,
+	0x22, 0x3e, 0xf9, 0xf6, 0xd9, 0x79, 0x66, 0x66, 0x67, 0x76, 0x9f, 0x31, 0x5c, 0x0b, 0x39, 0x9d,
+	0x11, 0x2e, 0xb6, 0xc5, 0x04, 0x73, 0x12, 0x6e, 0x93, 0x13, 0x12, 0x64, 0x92, 0xf1, 0xed, 0x94,
+	0x33, 0xc9, 0x8a, 0xe5, 0x48, 0x2f, 0xd1, 0xc7, 0x13, 0x2c, 0x26, 0x34, 0x60, 0x3c, 0x1d, 0x25,
+	0x2c, 0xc6, 0xe1, 0x28, 0x8d, 0xb2, 0x31, 0x4d, 0xc4, 0x68, 0xd9, 0x6e, 0x70, 0x65, 0xcc, 0xd8,
+	0x38, 0x22, 0xc6, 0xc9, 0x71, 0xf6, 0x72, 0x5b, 0xd2, 0x98, 0x08, 0x89, 0xe3, 0xd4, 0x1a, 0xb8,
+	0x96, 0xb8, 0x9d, 0x87, 0x37, 0xe1, 0xcc, 0xca, 0xd8, 0xb8, 0x7f, 0xb6, 0xa0, 0xf7, 0x04, 0x67,
+	0x49, 0x30, 0xf1, 0xc8, 0x4f, 0x19, 0x11, 0x12, 0xf5, 0xa1, 0x16, 0xc4, 0xa1, 0x53, 0xd9, 0xaa,
+	0x0c, 0xdb, 0x9e, 0xfa, 0x44, 0x08, 0xd6, 0x30, 0x1f, 0x0b, 0xa7, 0xba, 0x55, 0x1b, 0xb6, 0x3d,
+	0xfd, 0x8d, 0x0e, 0xa0, 0xcd, 0x89, 0x60, 0x19, 0x0f, 0x88, 0x70, 0x6a, 0x5b, 0x95, 0x61, 0x67,
+	0xe7, 0xc6, 0xe8, 0xef, 0x12, 0xb7, 0xf1, 0x4d, 0xc8, 0x91, 0x97, 0xf3, 0xbc, 0x53, 0x17, 0xe8,
+	0x0a, 0x74, 0x84, 0x0c, 0x59, 0x26, 0xfd, 0x14, 0xcb, 0x89, 0xb3, 0xa6, 0xa3, 0x83, 0x81, 0x0e,
+	0xb1, 0x9c, 0x58, 0x03, 0xc2, 0xb9, 0x31, 0xa8, 0x17, 0x06, 0x84, 0x73, 0x6d, 0xd0, 0x87, 0x1a,
+	0x49, 0x66, 0x4e, 0x43, 0x27, 0xa9, 0x3e, 0x55, 0xde, 0x99, 0x20, 0xdc, 0x69, 0x6a, 0x5b, 0xfd,
+	0x8d, 0x2e, 0x43, 0x4b, 0x62, 0x31, 0xf5, 0x43, 0xca, 0x9d, 0x96, 0xc6, 0x9b, 0x6a, 0xbd, 0x4f,
+	0x39, 0xba, 0x0a, 0x1b, 0x79, 0x3e, 0x7e, 0x44, 0x63, 0x2a, 0x85, 0xd3, 0xde, 0xaa, 0x0c, 0x5b,
+	0xde, 0x7a, 0x0e, 0x3f, 0xd1, 0x28, 0xda, 0x85, 0x0b, 0xc7, 0x58, 0xd0, 0xc0, 0x4f, 0x39, 0x0b,
+	0x88, 0x10, 0x7e, 0x30, 0xe6, 0x2c, 0x4b, 0x1d, 0x50, 0xd6, 0x0f, 0xaa, 0x4e, 0xc5, 0x43, 0x7a,
+	0xff, 0xd0, 0x6c, 0xef, 0xe9, 0x5d, 0xb4, 0x0f, 0x8d, 0x98, 0x65, 0x89, 0x14, 0x4e, 0x67, 0xab,
+	0x36, 0xec, 0xec, 0x5c, 0x2b, 0x59, 0xae, 0xa7, 0x8a, 0xe4, 0x59, 0x2e, 0xfa, 0x06, 0x9a, 0x21,
+	0x99, 0x51, 0x55, 0xf5, 0xae, 0x76, 0x73, 0xbd, 0xa4, 0x9b, 0x7d, 0xcd, 0xf2, 0x72, 0x36, 0x9a,
+	0xc0, 0xb9, 0x84, 0xc8, 0xd7, 0x8c, 0x4f, 0x7d, 0x2a, 0x58, 0x84, 0x25, 0x65, 0x89, 0xd3, 0xd3,
+	0x8d, 0xfc, 0xbc, 0xa4, 0xcb, 0x03, 0xc3, 0x7f, 0x94, 0xd3, 0x8f, 0x52, 0x12, 0x78, 0xfd, 0xe4,
+	0x0c, 0x8a, 0x5c, 0xe8, 0x25, 0xcc, 0x4f, 0xe9, 0x8c, 0x49, 0x9f, 0x33, 0x26, 0x9d, 0x75, 0x5d,
+	0xd5, 0x4e, 0xc2, 0x0e, 0x15, 0xe6, 0x31, 0x26, 0xd1, 0x10, 0xfa, 0x21, 0x79, 0x89, 0xb3, 0x48,
+	0xfa, 0x29, 0x0d, 0xfd, 0x98, 0x85, 0xc4, 0xd9, 0xd0, 0xed, 0x59, 0xb7, 0xf8, 0x21, 0x0d, 0x9f,
+	0xb2, 0x90, 0x2c, 0x5a, 0xd2, 0x34, 0x30, 0x96, 0xfd, 0x25, 0xcb, 0x47, 0x69, 0xa0, 0x2d, 0x3f,
+	0x82, 0x5e, 0x90, 0x66, 0x82, 0xc8, 0xbc, 0x3f, 0xe7, 0xb4, 0x59, 0xd7, 0x80, 0xb6, 0x2b, 0x1f,
+	0x00, 0xe0, 0x28, 0x62, 0xaf, 0xfd, 0x00, 0xa7, 0xc2, 0x41, 0xfa, 0xf2, 0xb4, 0x35, 0xb2, 0x87,
+	0x53, 0x81, 0x5c, 0xe8, 0x06, 0x38, 0xc5, 0xc7, 0x34, 0xa2, 0x92, 0x12, 0xe1, 0x9c, 0xd7, 0x06,
+	0x4b, 0x18, 0xba, 0x06, 0xc8, 0x04, 0xf0, 0x67, 0x3b, 0x3e, 0x9b, 0x11, 0xce, 0x69, 0x48, 0x9c,
+	0x0b, 0x3a, 0x58, 0xdf, 0xec, 0x3c, 0xdf, 0xf9, 0xd6, 0xe2, 0x68, 0x7e, 0x6a, 0x7d, 0xf3, 0xd4,
+	0xfa, 0xa2, 0xee, 0xe5, 0xe3, 0x51, 0xb9, 0xa7, 0x3f, 0x5a, 0x7a, 0xb1, 0x23, 0x73, 0x94, 0xe7,
+	0x37, 0xf3, 0x18, 0x0f, 0x13, 0xc9, 0xe7, 0x45, 0xe8, 0x02, 0x56, 0x8d, 0x60, 0x2c, 0xf6, 0x45,
+	0xc0, 0x38, 0xf1, 0x71, 0xf8, 0xca, 0xd9, 0xdc, 0xaa, 0x0c, 0xeb, 0x5e, 0x87, 0xb1, 0xf8, 0x48,
+	0x61, 0x5f, 0x85, 0xaf, 0xd4, 0xfb, 0xd0, 0x77, 0x42, 0xbd, 0x8f, 0x4b, 0xe6, 0x7d, 0xa8, 0xf5,
+	0x3e, 0xe5, 0x83, 0x3d, 0xb8, 0xf8, 0xd6, 0x48, 0xea, 0xe5, 0x4d, 0xc9, 0x3c, 0x57, 0x8c, 0x29,
+	0x99, 0xa3, 0x0b, 0x50, 0x9f, 0xe1, 0x28, 0x23, 0x4e, 0x55, 0x63, 0x66, 0xf1, 0x59, 0xf5, 0x5e,
+	0xc5, 0xfd, 0x11, 0xd6, 0xf3, 0xe4, 0x45, 0xca, 0x12, 0x41, 0xd0, 0x01, 0x34, 0xed, 0x3b, 0xd2,
+	0x1e, 0x3a, 0x3b, 0xbb, 0x65, 0xab, 0x60, 0xdf, 0xd7, 0x91, 0xc4, 0x92, 0x78, 0xb9, 0x13, 0xb7,
+	0x07, 0x9d, 0x17, 0x98, 0x4a, 0x5b, 0x1c, 0xf7, 0x07, 0xe8, 0x9a, 0xe5, 0xff, 0x14, 0xee, 0x09,
+	0x6c, 0x1c, 0x4d, 0x32, 0x19, 0xb2, 0xd7, 0x49, 0xae, 0xa0, 0x9b, 0xd0, 0x10, 0x74, 0x9c, 0xe0,
+	0xc8, 0x96, 0xc4, 0xae, 0xd0, 0x87, 0xd0, 0x1d, 0x73, 0x1c, 0x10, 0x3f, 0x25, 0x9c, 0xb2, 0x50,
+	0x17, 0xa7, 0xe6, 0x75, 0x34, 0x76, 0xa8, 0x21, 0x17, 0x41, 0xff, 0xd4, 0x9b, 0xc9, 0xd8, 0x9d,
+	0xc0, 0xe6, 0x77, 0x69, 0xa8, 0x82, 0x16, 0xc2, 0x69, 0x03, 0x2d, 0x89, 0x70, 0xe5, 0x3f, 0x8b,
+	0xb0, 0x7b, 0x19, 0x2e, 0xbd, 0x11, 0xc9, 0x26, 0xd1, 0x87, 0xf5, 0xe7, 0x84, 0x0b, 0xca, 0xf2,
+	0x53, 0xba, 0x9f, 0xc2, 0x46, 0x81, 0xd8, 0xda, 0x3a, 0xd0, 0x9c, 0x19, 0xc8, 0x9e, 0x3c, 0x5f,
+	0xba, 0x9f, 0x40, 0x57, 0xd5, 0xad, 0xc8, 0x7c, 0x00, 0x2d, 0x9a, 0x48, 0xc2, 0x67, 0xb6, 0x48,
+	0x35, 0xaf, 0x58, 0xbb, 0x2f, 0xa0, 0x67, 0x6d, 0xad, 0xdb, 0xaf, 0xa1, 0x2e, 0x14, 0xb0, 0xe2,
+	0x11, 0x9f, 0x61, 0x31, 0x35, 0x8e, 0x0c, 0xdd, 0xbd, 0x0a, 0xbd, 0x23, 0xdd, 0x89, 0xb7, 0x37,
+	0xaa, 0x9e, 0x37, 0x4a, 0x1d, 0x36, 0x37, 0xb4, 0xc7, 0x9f, 0x42, 0xe7, 0xe1, 0x09, 0x09, 0x72,
+	0xe2, 0x1d, 0x68, 0x85, 0x04, 0x87, 0x11, 0x4d, 0x88, 0x4d, 0x6a, 0x30, 0x32, 0xd3, 0x78, 0x94,
+	0x4f, 0xe3, 0xd1, 0xb3, 0x7c, 0x1a, 0x7b, 0x85, 0x6d, 0x3e, 0x5b, 0xab, 0x6f, 0xce, 0xd6, 0xda,
+	0xe9, 0x6c, 0x75, 0xf7, 0xa0, 0x6b, 0x82, 0xd9, 0xf3, 0x6f, 0x42, 0x83, 0x65, 0x32, 0xcd, 0xa4,
+	0x8e, 0xd5, 0xf5, 0xec, 0x0a, 0xbd, 0x0f, 0x6d, 0x72, 0x42, 0xa5, 0x1f, 0x28, 0x0d, 0xac, 0xea,
+	0x13, 0xb4, 0x14, 0xb0, 0xc7, 0x42, 0xe2, 0xfe, 0x51, 0x85, 0xee, 0xe2, 0x8d, 0x55, 0xb1, 0x53,
+	0x1a, 0xda, 0x93, 0xaa, 0xcf, 0x7f, 0xe4, 0x2f, 0xd4, 0xa6, 0xb6, 0x58, 0x1b, 0x34, 0x82, 0x35,
+	0xf5, 0x3f, 0xc3, 0x59, 0xfb, 0xd7, 0x63, 0x6b, 0x3b, 0x25, 0xb0, 0x4a, 0x74, 0xa6, 0x34, 0x8a,
+	0x48, 0xa8, 0xc7, 0x76, 0xcb, 0x6b, 0x33, 0x16, 0x3f, 0xd6, 0x80, 0x1a, 0xeb, 0x5a, 0x8e, 0xc2,
+	0x2c, 0x4e, 0x49, 0xe8, 0x34, 0xf4, 0x3e, 0x28, 0x68, 0x5f, 0x23, 0x4a, 0xb4, 0x62, 0x7c, 0xe2,
+	0x73, 0x21, 0xfc, 0xe3, 0xb9, 0x24, 0x42, 0x4f, 0xf3, 0x35, 0xaf, 0x13, 0xe3, 0x13, 0x4f, 0x88,
+	0x07, 0x0a, 0x42, 0xd7, 0xe1, 0xbc, 0x1a, 0xee, 0x7e, 0x90, 0x66, 0xbe, 0x0a, 0xea, 0x27, 0x38,
+	0x61, 0x42, 0xcf, 0xf7, 0x9a, 0xd7, 0x57, 0x5b, 0x7b, 0x69, 0xa6, 0x12, 0x3b, 0x50, 0x38, 0xba,
+	0x09, 0x17, 0xc5, 0x5c, 0x48, 0x12, 0x9f, 0x25, 0xb4, 0x35, 0x01, 0x99, 0xcd, 0x45, 0xca, 0xce,
+	0x6f, 0x6d, 0x68, 0x3d, 0xb4, 0x72, 0x80, 0xe6, 0xd0, 0x30, 0x1a, 0x86, 0x6e, 0xbf, 0x93, 0x60,
+	0x0f, 0xee, 0xac, 0x4a, 0xb3, 0xb7, 0xf0, 0x3d, 0x24, 0x60, 0x4d, 0xa9, 0x19, 0xba, 0x55, 0xd6,
+	0xc3, 0x82, 0x14, 0x0e, 0x76, 0x57, 0x23, 0x15, 0x41, 0x7f, 0x81, 0x56, 0x2e, 0x4a, 0xe8, 0x6e,
+	0x59, 0x1f, 0x67, 0x44, 0x71, 0x70, 0x6f, 0x75, 0x62, 0x91, 0xc0, 0xaf, 0x15, 0xd8, 0x38, 0x23,
+	0x4c, 0xe8, 0x8b, 0xb2, 0xfe, 0xde, 0xae, 0x9d, 0x83, 0xfb, 0xef, 0xcc, 0x2f, 0xd2, 0xfa, 0x19,
+	0x9a, 0x56, 0x01, 0x51, 0xe9, 0x8e, 0x2e, 0x8b, 0xe8, 0xe0, 0xee, 0xca, 0xbc, 0x22, 0xfa, 0x09,
+	0xd4, 0xb5, 0xba, 0xa1, 0xd2, 0x6d, 0x5d, 0x54, 0xe0, 0xc1, 0xed, 0x15, 0x59, 0x79, 0xdc, 0x1b,
+	0x15, 0x75, 0xff, 0x8d, 0x3c, 0x96, 0xbf, 0xff, 0x4b, 0xba, 0x3b, 0xb8, 0xb3, 0x2a, 0x6d, 0xf1,
+	0xfe, 0xab, 0x67, 0x58, 0xfe, 0xfe, 0x2f, 0xa8, 0xf6, 0x60, 0x77, 0x35, 0x52, 0x11, 0xf4, 0xf7,
+	0x0a, 0xf4, 0x14, 0x74, 0x24, 0x39, 0xc1, 0x31, 0x4d, 0xc6, 0xe8, 0x7e, 0xc9, 0x11, 0xa4, 0x58,
+	0x66, 0x0c, 0x59, 0x66, 0x9e, 0xca, 0x97, 0xef, 0xee, 0x20, 0x4f, 0x6b, 0x58, 0xb9, 0x51, 0x79,
+	0xd0, 0xfc, 0xbe, 0x6e, 0x94, 0xb7, 0xa1, 0x7f, 0x6e, 0xfd, 0x35, 0x00, 0xf7, 0x44, 0xb0, 0x6b,
+	0x6b, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	hclog "github.com/hashicorp/go-hclog"
@@ -127,6 +128,11 @@ func processStateToProto(ps *ProcessState) (*proto.ProcessState, error) {
 		Signal:    int32(ps.Signal),
 		OomKilled: ps.OOMKilled,
 		Time:      timestamp,
+
+		CoreDumped:         ps.CoreDumped,
+		MaxRssBytes:        ps.MaxRSS,
+		UserCpuTimeNanos:   ps.UserCPUTime.Nanoseconds(),
+		SystemCpuTimeNanos: ps.SystemCPUTime.Nanoseconds(),
 	}
 
 	return pb, nil
@@ -144,6 +150,11 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 		Signal:    int(pb.Signal),
 		OOMKilled: pb.OomKilled,
 		Time:      timestamp,
+
+		CoreDumped:    pb.CoreDumped,
+		MaxRSS:        pb.MaxRssBytes,
+		UserCPUTime:   time.Duration(pb.UserCpuTimeNanos),
+		SystemCPUTime: time.Duration(pb.SystemCpuTimeNanos),
 	}, nil
 }
 
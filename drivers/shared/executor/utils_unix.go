@@ -7,7 +7,9 @@
 package executor
 
 import (
+	"os"
 	"os/exec"
+	"runtime"
 	"syscall"
 )
 
@@ -20,3 +22,23 @@ func isolateCommand(cmd *exec.Cmd) {
 	}
 	cmd.SysProcAttr.Setsid = true
 }
+
+// coreDumpedAndMaxRSS returns whether the exited process described by state
+// dumped core, and its maximum resident set size in bytes.
+func coreDumpedAndMaxRSS(state *os.ProcessState) (bool, uint64) {
+	var coreDumped bool
+	if status, ok := state.Sys().(syscall.WaitStatus); ok {
+		coreDumped = status.CoreDump()
+	}
+
+	var maxRSS uint64
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok && rusage.Maxrss > 0 {
+		// getrusage(2) reports the max RSS in bytes on macOS and in
+		// kilobytes elsewhere
+		maxRSS = uint64(rusage.Maxrss)
+		if runtime.GOOS != "darwin" {
+			maxRSS *= 1024
+		}
+	}
+	return coreDumped, maxRSS
+}
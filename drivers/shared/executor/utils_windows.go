@@ -3,7 +3,16 @@
 
 package executor
 
-import "os/exec"
+import (
+	"os"
+	"os/exec"
+)
 
 // TODO Figure out if this is needed in Windows
 func isolateCommand(cmd *exec.Cmd) {}
+
+// coreDumpedAndMaxRSS returns false and zero, as processes do not dump core
+// and their max resident set size is not reported on Windows.
+func coreDumpedAndMaxRSS(*os.ProcessState) (bool, uint64) {
+	return false, 0
+}
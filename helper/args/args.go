@@ -9,10 +9,17 @@ var (
 	envRe = regexp.MustCompile(`\${[a-zA-Z0-9_\-\.]+}`)
 )
 
-// ReplaceEnv takes an arg and replaces all occurrences of environment variables.
-// If the variable is found in the passed map it is replaced, otherwise the
-// original string is returned.
+// ReplaceEnv takes an arg and replaces all occurrences of environment variables
+// and function calls. If the variable is found in the passed map it is
+// replaced, otherwise the original string is returned. Function calls which
+// cannot be evaluated are likewise left in place.
 func ReplaceEnv(arg string, environments ...map[string]string) string {
+	replaced, _ := replace(arg, false, environments)
+	return replaced
+}
+
+// replaceVars replaces all occurrences of environment variables in arg.
+func replaceVars(arg string, environments []map[string]string) string {
 	return envRe.ReplaceAllStringFunc(arg, func(arg string) string {
 		stripped := arg[2 : len(arg)-1]
 		for _, env := range environments {
@@ -31,6 +38,7 @@ func ReplaceEnvWithPlaceHolder(arg string, placeholder string) string {
 }
 
 // ContainsEnv takes an arg and returns true if if contains an environment variable reference
+// or function call
 func ContainsEnv(arg string) bool {
-	return envRe.MatchString(arg)
+	return envRe.MatchString(arg) || ContainsFunc(arg)
 }
@@ -0,0 +1,317 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package args
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// funcRe matches the start of an interpolated function call, such as
+	// "${lower(". Function calls are only interpolated when written with the
+	// call syntax, so plain variable references are unaffected.
+	funcRe = regexp.MustCompile(`\$\{[a-zA-Z][a-zA-Z0-9]*\(`)
+)
+
+// function is a function which can be called within an interpolation. The
+// first argument of every function is the string it transforms.
+type function struct {
+	// params is the number of arguments of the function.
+	params int
+
+	// list is true if the function returns a list, which must be indexed to
+	// produce a string.
+	list bool
+
+	fn func(args []string) ([]string, error)
+}
+
+// funcs are the functions which can be called within an interpolation.
+var funcs = map[string]function{
+	"lower": {
+		params: 1,
+		fn: func(args []string) ([]string, error) {
+			return []string{strings.ToLower(args[0])}, nil
+		},
+	},
+	"upper": {
+		params: 1,
+		fn: func(args []string) ([]string, error) {
+			return []string{strings.ToUpper(args[0])}, nil
+		},
+	},
+	"trimSuffix": {
+		params: 2,
+		fn: func(args []string) ([]string, error) {
+			return []string{strings.TrimSuffix(args[0], args[1])}, nil
+		},
+	},
+	"regexReplaceAll": {
+		params: 3,
+		fn: func(args []string) ([]string, error) {
+			re, err := regexp.Compile(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %v", args[1], err)
+			}
+			return []string{re.ReplaceAllString(args[0], args[2])}, nil
+		},
+	},
+	"split": {
+		params: 2,
+		list:   true,
+		fn: func(args []string) ([]string, error) {
+			return strings.Split(args[0], args[1]), nil
+		},
+	},
+}
+
+// ContainsFunc returns true if arg contains an interpolated function call.
+func ContainsFunc(arg string) bool {
+	return funcRe.MatchString(arg)
+}
+
+// ReplaceEnvStrict replaces all occurrences of environment variables and
+// function calls in arg like ReplaceEnv, but returns an error naming the
+// expression if a function call cannot be parsed or evaluated, such as when
+// it references a variable which is not found in the passed maps.
+func ReplaceEnvStrict(arg string, environments ...map[string]string) (string, error) {
+	return replace(arg, true, environments)
+}
+
+// replace replaces all occurrences of environment variables and function
+// calls in arg. If strict is false, function calls which cannot be evaluated
+// are left in place, like variables which are not found.
+func replace(arg string, strict bool, environments []map[string]string) (string, error) {
+	locs := funcRe.FindAllStringIndex(arg, -1)
+	if len(locs) == 0 {
+		return replaceVars(arg, environments), nil
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range locs {
+		// skip calls within the string arguments of a previous call
+		if loc[0] < last {
+			continue
+		}
+
+		p := &funcParser{input: arg, pos: loc[0] + 2, envs: environments}
+		value, err := p.parseExpr()
+		if err != nil {
+			if strict {
+				return "", fmt.Errorf("failed to interpolate %q: %v", exprText(arg, loc[0], p.pos), err)
+			}
+			continue
+		}
+
+		b.WriteString(replaceVars(arg[last:loc[0]], environments))
+		b.WriteString(value)
+		last = p.pos
+	}
+	b.WriteString(replaceVars(arg[last:], environments))
+
+	return b.String(), nil
+}
+
+// exprText returns the text of the expression starting at start for use in
+// errors. The expression ends at the first closing brace at or after end, as
+// parsing may have stopped before reaching it.
+func exprText(arg string, start, end int) string {
+	end = min(end, len(arg))
+	if i := strings.IndexByte(arg[end:], '}'); i >= 0 {
+		return arg[start : end+i+1]
+	}
+	return arg[start:]
+}
+
+// funcParser parses and evaluates a single interpolated function call of the
+// form "${fn(arg, ...)}" or "${fn(arg, ...)[n]}". Arguments are quoted string
+// literals, variable references, or nested function calls.
+type funcParser struct {
+	input string
+	pos   int
+	envs  []map[string]string
+}
+
+// parseExpr parses the call starting at the current position and the closing
+// brace of the interpolation, and returns the resulting string.
+func (p *funcParser) parseExpr() (string, error) {
+	value, err := p.parseString()
+	if err != nil {
+		return "", err
+	}
+
+	p.skipSpace()
+	if !p.consume('}') {
+		return "", p.errorf("expected '}'")
+	}
+	return value, nil
+}
+
+// parseString parses an argument which must evaluate to a string.
+func (p *funcParser) parseString() (string, error) {
+	p.skipSpace()
+	if p.peek() == '"' {
+		return p.parseLiteral()
+	}
+
+	start := p.pos
+	name := p.parseName()
+	if name == "" {
+		return "", p.errorf("expected a string, variable, or function call")
+	}
+
+	p.skipSpace()
+	if p.peek() != '(' {
+		return p.lookup(name)
+	}
+
+	f, ok := funcs[name]
+	if !ok {
+		return "", fmt.Errorf("unknown function %q", name)
+	}
+	result, err := p.parseCall(name, f)
+	if err != nil {
+		return "", err
+	}
+
+	p.skipSpace()
+	if !p.consume('[') {
+		if f.list {
+			return "", fmt.Errorf("result of %s must be indexed", name)
+		}
+		return result[0], nil
+	}
+	if !f.list {
+		return "", fmt.Errorf("result of %s cannot be indexed", name)
+	}
+
+	p.skipSpace()
+	digits := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	index, err := strconv.Atoi(p.input[digits:p.pos])
+	if err != nil {
+		return "", p.errorf("expected an index")
+	}
+	p.skipSpace()
+	if !p.consume(']') {
+		return "", p.errorf("expected ']'")
+	}
+	if index >= len(result) {
+		return "", fmt.Errorf("index %d out of range for %s with %d elements",
+			index, p.input[start:p.pos], len(result))
+	}
+	return result[index], nil
+}
+
+// parseCall parses the parenthesized arguments of function name and calls it.
+func (p *funcParser) parseCall(name string, f function) ([]string, error) {
+	p.consume('(')
+
+	var args []string
+	p.skipSpace()
+	if !p.consume(')') {
+		for {
+			arg, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			p.skipSpace()
+			if p.consume(')') {
+				break
+			}
+			if !p.consume(',') {
+				return nil, p.errorf("expected ',' or ')'")
+			}
+		}
+	}
+
+	if len(args) != f.params {
+		return nil, fmt.Errorf("%s takes %d argument(s) but %d were given", name, f.params, len(args))
+	}
+	return f.fn(args)
+}
+
+// parseLiteral parses a double quoted string literal, which may escape double
+// quotes and backslashes with a backslash.
+func (p *funcParser) parseLiteral() (string, error) {
+	p.consume('"')
+
+	var b strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.pos == len(p.input) {
+				return "", p.errorf("unterminated string")
+			}
+			b.WriteByte(p.input[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// parseName parses a function name or variable reference, which may contain
+// the same characters as a plain variable reference.
+func (p *funcParser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			c == '_' || c == '-' || c == '.') {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// lookup returns the value of the variable name from the first map it is
+// found in.
+func (p *funcParser) lookup(name string) (string, error) {
+	for _, env := range p.envs {
+		if value, ok := env[name]; ok {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("unknown variable %q", name)
+}
+
+func (p *funcParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *funcParser) consume(c byte) bool {
+	if p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *funcParser) skipSpace() {
+	for p.peek() == ' ' || p.peek() == '\t' {
+		p.pos++
+	}
+}
+
+func (p *funcParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("%s at offset %d", fmt.Sprintf(format, a...), p.pos)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package args
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestArgs_ReplaceEnv_Funcs(t *testing.T) {
+	env := map[string]string{
+		"attr.unique.hostname": "Web-01.DC1.example.com",
+		"NOMAD_ALLOC_INDEX":    "3",
+	}
+
+	cases := []struct {
+		input string
+		exp   string
+	}{
+		{input: `${lower(attr.unique.hostname)}`, exp: "web-01.dc1.example.com"},
+		{input: `${upper(attr.unique.hostname)}`, exp: "WEB-01.DC1.EXAMPLE.COM"},
+		{input: `${trimSuffix(attr.unique.hostname, ".example.com")}`, exp: "Web-01.DC1"},
+		{input: `${regexReplaceAll(attr.unique.hostname, "[.-]", "_")}`, exp: "Web_01_DC1_example_com"},
+		{input: `${regexReplaceAll(attr.unique.hostname, "^([^.]+)\\..*$", "$1")}`, exp: "Web-01"},
+		{input: `${split(attr.unique.hostname, ".")[0]}`, exp: "Web-01"},
+		{input: `${split(attr.unique.hostname, ".")[ 2 ]}`, exp: "example"},
+		{input: `${lower(split(attr.unique.hostname, ".")[0])}`, exp: "web-01"},
+		{input: `${upper("quoted \"literal\"")}`, exp: `QUOTED "LITERAL"`},
+		{input: `${lower(attr.unique.hostname)}-${NOMAD_ALLOC_INDEX}`, exp: "web-01.dc1.example.com-3"},
+		{input: `/srv/${split(attr.unique.hostname, ".")[1]}/${NOMAD_ALLOC_INDEX}`, exp: "/srv/DC1/3"},
+
+		// plain variables and other syntax are unaffected
+		{input: `${NOMAD_ALLOC_INDEX}`, exp: "3"},
+		{input: `${FOO}`, exp: "${FOO}"},
+		{input: `$(lower attr.unique.hostname)`, exp: "$(lower attr.unique.hostname)"},
+
+		// calls which cannot be evaluated are left in place
+		{input: `${lower(missing)}`, exp: "${lower(missing)}"},
+		{input: `${unknown(attr.unique.hostname)}`, exp: "${unknown(attr.unique.hostname)}"},
+		{input: `${lower(attr.unique.hostname}-${NOMAD_ALLOC_INDEX}`, exp: "${lower(attr.unique.hostname}-3"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			must.Eq(t, tc.exp, ReplaceEnv(tc.input, env))
+		})
+	}
+}
+
+func TestArgs_ReplaceEnvStrict(t *testing.T) {
+	env := map[string]string{
+		"attr.unique.hostname": "web-01.dc1.example.com",
+	}
+
+	got, err := ReplaceEnvStrict(`${split(attr.unique.hostname, ".")[0]}.${FOO}`, env)
+	must.NoError(t, err)
+	must.Eq(t, "web-01.${FOO}", got)
+
+	cases := []struct {
+		input string
+		err   string
+	}{
+		{input: `${lower(missing)}`, err: `failed to interpolate "${lower(missing)}": unknown variable "missing"`},
+		{input: `x-${unknown(attr.unique.hostname)}-y`, err: `"${unknown(attr.unique.hostname)}": unknown function "unknown"`},
+		{input: `${lower(attr.unique.hostname, "x")}`, err: "lower takes 1 argument(s) but 2 were given"},
+		{input: `${split(attr.unique.hostname, ".")}`, err: "result of split must be indexed"},
+		{input: `${lower(attr.unique.hostname)[0]}`, err: "result of lower cannot be indexed"},
+		{input: `${split(attr.unique.hostname, ".")[9]}`, err: "index 9 out of range"},
+		{input: `${regexReplaceAll(attr.unique.hostname, "(", "")}`, err: "invalid regular expression"},
+		{input: `${lower("unterminated)}`, err: "unterminated string"},
+		{input: `${lower(attr.unique.hostname}`, err: `failed to interpolate "${lower(attr.unique.hostname}": expected ',' or ')'`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := ReplaceEnvStrict(tc.input, env)
+			must.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestArgs_ContainsFunc(t *testing.T) {
+	must.True(t, ContainsFunc(`${lower(attr.unique.hostname)}`))
+	must.True(t, ContainsFunc(`prefix-${split(attr.unique.hostname, ".")[0]}`))
+	must.False(t, ContainsFunc(`${attr.unique.hostname}`))
+	must.False(t, ContainsFunc(`${ lower(attr.unique.hostname) }`))
+	must.False(t, ContainsFunc(`lower(attr.unique.hostname)`))
+
+	must.True(t, ContainsEnv(`${lower(attr.unique.hostname)}`))
+}
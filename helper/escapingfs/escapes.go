@@ -118,6 +118,71 @@ func PathEscapesSandbox(sandboxDir, path string) bool {
 	return false
 }
 
+// PathWithin checks if the toCheckPath is within the rootPath. It uses the
+// os.SameFile function to perform the path check so paths are compared
+// appropriately based on the filesystem.
+func PathWithin(rootPath, toCheckPath string) (bool, error) {
+	rootPath = filepath.Clean(rootPath)
+	toCheckPath = filepath.Clean(toCheckPath)
+
+	if len(rootPath) > len(toCheckPath) {
+		return false, nil
+	}
+
+	// a sibling of the root sharing its name as a prefix, such as /foo/barbaz
+	// for /foo/bar, is not within the root
+	if len(toCheckPath) > len(rootPath) &&
+		toCheckPath[len(rootPath)] != filepath.Separator &&
+		!strings.HasSuffix(rootPath, string(filepath.Separator)) {
+		return false, nil
+	}
+
+	rootStat, err := os.Stat(rootPath)
+	if err != nil {
+		return false, err
+	}
+
+	checkStat, err := os.Stat(toCheckPath[0:len(rootPath)])
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return os.SameFile(rootStat, checkStat), nil
+}
+
+// ParentEscapes returns true if the deepest existing parent of path does not
+// resolve to a location within root, such as when one of the parents of path
+// is a symlink pointing outside of root. This allows checking a path before it
+// is created. The deepest existing parent is returned as well.
+func ParentEscapes(root, path string) (string, bool, error) {
+	parent := filepath.Dir(path)
+	for {
+		if _, err := os.Lstat(parent); err == nil {
+			break
+		}
+		next := filepath.Dir(parent)
+		if next == parent {
+			break
+		}
+		parent = next
+	}
+
+	resolved, err := filepath.EvalSymlinks(parent)
+	if err != nil {
+		return parent, false, err
+	}
+
+	within, err := PathWithin(root, resolved)
+	if err != nil {
+		return parent, false, err
+	}
+	return parent, !within, nil
+}
+
 // EnsurePath is used to make sure a path exists
 func EnsurePath(path string, dir bool) error {
 	if !dir {
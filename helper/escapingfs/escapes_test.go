@@ -231,6 +231,120 @@ func TestPathEscapesSandbox(t *testing.T) {
 	}
 }
 
+func TestPathWithin(t *testing.T) {
+	tdir := t.TempDir()
+	pathFn := func(parent string) string {
+		dir, err := os.MkdirTemp(parent, "testing-path")
+		must.NoError(t, err, must.Sprint("failed to create temporary directory"))
+		return dir
+	}
+
+	t.Run("when path not within root", func(t *testing.T) {
+		root := pathFn(tdir)
+		check := pathFn(tdir)
+		result, err := PathWithin(root, check)
+
+		must.NoError(t, err)
+		must.False(t, result)
+	})
+
+	t.Run("when path within root", func(t *testing.T) {
+		root := pathFn(tdir)
+		check := pathFn(root)
+		result, err := PathWithin(root, check)
+
+		must.NoError(t, err)
+		must.True(t, result)
+	})
+
+	t.Run("when root within path", func(t *testing.T) {
+		check := pathFn(tdir)
+		root := pathFn(check)
+		result, err := PathWithin(root, check)
+
+		must.NoError(t, err)
+		must.False(t, result)
+	})
+
+	t.Run("when path does not exist", func(t *testing.T) {
+		root := filepath.Join(tdir, "missing")
+		check := filepath.Join(root, "unknown")
+		result, err := PathWithin(root, check)
+
+		must.ErrorContains(t, err, "no such file or directory")
+		must.False(t, result)
+	})
+
+	t.Run("when path not within root but shorter", func(t *testing.T) {
+		root, err := os.MkdirTemp(tdir, "testing-path-XXX")
+		must.NoError(t, err)
+		check, err := os.MkdirTemp(tdir, "testing-path-XXXX")
+		must.NoError(t, err)
+		result, err := PathWithin(root, check)
+
+		must.NoError(t, err)
+		must.False(t, result)
+	})
+
+	t.Run("when path is a sibling prefixed by root", func(t *testing.T) {
+		root := filepath.Join(tdir, "app")
+		check := filepath.Join(tdir, "app-data")
+		must.NoError(t, os.Mkdir(root, 0o755))
+		must.NoError(t, os.Mkdir(check, 0o755))
+		result, err := PathWithin(root, check)
+
+		must.NoError(t, err)
+		must.False(t, result)
+	})
+}
+
+func TestParentEscapes(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	must.NoError(t, os.MkdirAll(filepath.Join(root, "local", "dir"), 0o755))
+	must.NoError(t, os.Symlink(outside, filepath.Join(root, "local", "escape")))
+	must.NoError(t, os.Symlink(filepath.Join(root, "local", "dir"), filepath.Join(root, "local", "link")))
+
+	cases := []struct {
+		name      string
+		path      string
+		expParent string
+		expEscape bool
+	}{
+		{
+			name:      "existing parent",
+			path:      filepath.Join(root, "local", "dir", "file"),
+			expParent: filepath.Join(root, "local", "dir"),
+		},
+		{
+			name:      "missing parents",
+			path:      filepath.Join(root, "local", "a", "b", "file"),
+			expParent: filepath.Join(root, "local"),
+		},
+		{
+			name:      "symlink within root",
+			path:      filepath.Join(root, "local", "link", "file"),
+			expParent: filepath.Join(root, "local", "link"),
+		},
+		{
+			name:      "symlink outside root",
+			path:      filepath.Join(root, "local", "escape", "a", "file"),
+			expParent: filepath.Join(root, "local", "escape"),
+			expEscape: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parent, escapes, err := ParentEscapes(root, tc.path)
+			must.NoError(t, err)
+			must.Eq(t, tc.expParent, parent)
+			must.Eq(t, tc.expEscape, escapes)
+		})
+	}
+}
+
 func TestHasPrefixCaseInsensitive(t *testing.T) {
 	cases := []struct {
 		name     string
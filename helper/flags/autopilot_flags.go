@@ -11,6 +11,8 @@ import (
 	"math/bits"
 	"strconv"
 	"time"
+
+	"github.com/dustin/go-humanize"
 )
 
 // BoolValue provides a flag value that's aware if it has been set.
@@ -110,3 +112,36 @@ func (u *UintValue) String() string {
 	}
 	return fmt.Sprintf("%v", current)
 }
+
+// BytesValue provides a flag value that's aware if it has been set. It accepts
+// sizes such as "5GB" as well as a plain number of bytes.
+type BytesValue struct {
+	v *uint64
+}
+
+// Merge will overlay this value if it has been set.
+func (b *BytesValue) Merge(onto *uint64) {
+	if b.v != nil {
+		*onto = *(b.v)
+	}
+}
+
+// Set implements the flag.Value interface.
+func (b *BytesValue) Set(v string) error {
+	parsed, err := humanize.ParseBytes(v)
+	if err != nil {
+		return err
+	}
+
+	b.v = &parsed
+	return nil
+}
+
+// String implements the flag.Value interface.
+func (b *BytesValue) String() string {
+	var current uint64
+	if b.v != nil {
+		current = *(b.v)
+	}
+	return humanize.Bytes(current)
+}
@@ -24,13 +24,17 @@ func TestFlagHelper_Pointers_Set(t *testing.T) {
 
 		U UintValue
 		u uint = 99
+
+		Y BytesValue
+		y uint64 = 1024
 	)
 	flagSet := flag.NewFlagSet("test", flag.PanicOnError)
 	flagSet.Var(&B, "b", "bool")
 	flagSet.Var(&D, "d", "duration")
 	flagSet.Var(&U, "u", "uint")
+	flagSet.Var(&Y, "y", "bytes")
 
-	args := []string{"-b", "false", "-d", "1m", "-u", "42"}
+	args := []string{"-b", "false", "-d", "1m", "-u", "42", "-y", "5GB"}
 	err := flagSet.Parse(args)
 	require.NoError(t, err)
 
@@ -45,6 +49,10 @@ func TestFlagHelper_Pointers_Set(t *testing.T) {
 	require.Equal(t, "42", U.String())
 	U.Merge(&u)
 	require.Equal(t, uint(42), u)
+
+	require.Equal(t, "5.0 GB", Y.String())
+	Y.Merge(&y)
+	require.Equal(t, uint64(5_000_000_000), y)
 }
 
 func TestFlagHelper_Pointers_Ignored(t *testing.T) {
@@ -59,11 +67,15 @@ func TestFlagHelper_Pointers_Ignored(t *testing.T) {
 
 		U UintValue
 		u uint = 99
+
+		Y BytesValue
+		y uint64 = 1024
 	)
 	flagSet := flag.NewFlagSet("test", flag.PanicOnError)
 	flagSet.Var(&B, "b", "bool")
 	flagSet.Var(&D, "d", "duration")
 	flagSet.Var(&U, "u", "uint")
+	flagSet.Var(&Y, "y", "bytes")
 
 	var args []string
 	err := flagSet.Parse(args)
@@ -80,4 +92,8 @@ func TestFlagHelper_Pointers_Ignored(t *testing.T) {
 	require.Equal(t, "0", U.String())
 	U.Merge(&u)
 	require.Equal(t, uint(99), u)
+
+	require.Equal(t, "0 B", Y.String())
+	Y.Merge(&y)
+	require.Equal(t, uint64(1024), y)
 }
@@ -6,8 +6,22 @@
 
 package helper
 
-import "os"
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
 
 func IsExecutable(i os.FileInfo) bool {
 	return !i.IsDir() && i.Mode()&0o111 != 0
 }
+
+// SignalName returns the name of the signal numbered sig on this platform,
+// such as "SIGKILL", or an empty string if it is unknown.
+func SignalName(sig int) string {
+	if sig <= 0 {
+		return ""
+	}
+	return unix.SignalName(syscall.Signal(sig))
+}
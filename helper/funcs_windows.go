@@ -14,3 +14,9 @@ import (
 func IsExecutable(i os.FileInfo) bool {
 	return strings.HasSuffix(i.Name(), ".exe")
 }
+
+// SignalName returns an empty string, as processes are not terminated by
+// signals on Windows.
+func SignalName(int) string {
+	return ""
+}
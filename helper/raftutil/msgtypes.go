@@ -71,4 +71,6 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.HostVolumeRegisterRequestType:                "HostVolumeRegisterRequestType",
 	structs.HostVolumeDeleteRequestType:                  "HostVolumeDeleteRequestType",
 	structs.TaskGroupHostVolumeClaimDeleteRequestType:    "TaskGroupHostVolumeClaimDeleteRequestType",
+	structs.MaintenanceWindowUpsertRequestType:           "MaintenanceWindowUpsertRequestType",
+	structs.MaintenanceWindowDeleteRequestType:           "MaintenanceWindowDeleteRequestType",
 }
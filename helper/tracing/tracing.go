@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package tracing provides helper functions for carrying OpenTelemetry trace
+// context across process boundaries as W3C traceparent values.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceParentHeader is the HTTP header carrying the W3C traceparent of the
+// span a request belongs to.
+const TraceParentHeader = "traceparent"
+
+var propagator = propagation.TraceContext{}
+
+// Context returns a context carrying the remote span of the W3C traceparent,
+// or the background context if traceParent is empty or invalid.
+func Context(traceParent string) context.Context {
+	ctx := context.Background()
+	if traceParent == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{TraceParentHeader: traceParent})
+}
+
+// TraceParent returns the W3C traceparent of the span of ctx, or an empty
+// string if ctx carries no valid span.
+func TraceParent(ctx context.Context) string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ""
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get(TraceParentHeader)
+}
+
+// Normalize returns the W3C traceparent in its canonical form, or an empty
+// string if it is invalid.
+func Normalize(traceParent string) string {
+	return TraceParent(Context(traceParent))
+}
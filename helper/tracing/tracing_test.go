@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing_Normalize(t *testing.T) {
+	ci.Parallel(t)
+
+	const valid = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	cases := []struct {
+		name string
+		in   string
+		exp  string
+	}{
+		{name: "valid", in: valid, exp: valid},
+		{name: "unsampled", in: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			exp: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{name: "empty", in: "", exp: ""},
+		{name: "malformed", in: "not-a-traceparent", exp: ""},
+		{name: "zero trace id", in: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", exp: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, Normalize(tc.in))
+		})
+	}
+}
+
+func TestTracing_Context(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx := Context("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	sc := trace.SpanContextFromContext(ctx)
+	must.True(t, sc.IsValid())
+	must.True(t, sc.IsRemote())
+	must.True(t, sc.IsSampled())
+	must.Eq(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+
+	must.False(t, trace.SpanContextFromContext(Context("")).IsValid())
+}
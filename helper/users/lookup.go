@@ -47,6 +47,30 @@ func LookupUnix(username string) (int, int, string, error) {
 	return uid, gid, u.HomeDir, nil
 }
 
+// LookupID returns the user.User entry associated with the given numeric
+// UID, acquired while holding a global process lock.
+func LookupID(uid string) (*user.User, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	return user.LookupId(uid)
+}
+
+// LookupGroup returns the user.Group entry associated with the given group
+// name, acquired while holding a global process lock.
+func LookupGroup(name string) (*user.Group, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	return user.LookupGroup(name)
+}
+
+// LookupGroupID returns the user.Group entry associated with the given numeric
+// GID, acquired while holding a global process lock.
+func LookupGroupID(gid string) (*user.Group, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	return user.LookupGroupId(gid)
+}
+
 // lock is used to serialize all user lookup at the process level, because
 // some NSS implementations are not concurrency safe
 var lock sync.Mutex
@@ -130,3 +130,25 @@ func TestLookupUnix_nobody(t *testing.T) {
 	must.Eq(t, 65534, gid)           // systemd specific
 	must.Eq(t, "/nonexistent", home) // ubuntu specific
 }
+
+func TestLookupID_root(t *testing.T) {
+	u, err := LookupID("0")
+	must.NoError(t, err)
+	must.Eq(t, "root", u.Username)
+	must.Eq(t, "0", u.Gid)
+}
+
+func TestLookupGroup_root(t *testing.T) {
+	g, err := LookupGroup("root")
+	must.NoError(t, err)
+	must.Eq(t, "0", g.Gid)
+
+	_, err = LookupGroup("doesnotexist")
+	must.Error(t, err)
+}
+
+func TestLookupGroupID_root(t *testing.T) {
+	g, err := LookupGroupID("0")
+	must.NoError(t, err)
+	must.Eq(t, "root", g.Name)
+}
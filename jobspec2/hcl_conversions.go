@@ -364,6 +364,15 @@ func decodeTask(body hcl.Body, ctx *hcl.EvalContext, val interface{}) hcl.Diagno
 		t.Meta = metaAttr
 	}
 
+	// the first vault block is the primary Vault block of the task
+	if len(t.Vaults) > 0 {
+		t.Vault = t.Vaults[0]
+		t.Vaults = t.Vaults[1:]
+		if len(t.Vaults) == 0 {
+			t.Vaults = nil
+		}
+	}
+
 	return diags
 }
 
@@ -49,6 +49,9 @@ func normalizeJob(jc *jobConfig) {
 
 			// normalize Vault
 			normalizeVault(t.Vault)
+			for _, v := range t.Vaults {
+				normalizeVault(v)
+			}
 
 			if t.Vault == nil {
 				t.Vault = jc.Vault
@@ -1047,6 +1047,33 @@ func TestParse_UndefinedVariables(t *testing.T) {
 	})
 }
 
+// TestParse_RuntimeFunctions asserts that escaped function calls are left for
+// the client to interpolate at runtime
+func TestParse_RuntimeFunctions(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "example" {
+  group "group" {
+    task "task" {
+      env {
+        SHORT_HOST = "$${lower(split(attr.unique.hostname, \".\")[0])}"
+        DOMAIN     = "$${trimSuffix(attr.unique.hostname, \".example.com\")}"
+      }
+    }
+  }
+}`
+
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+
+	env := job.TaskGroups[0].Tasks[0].Env
+	must.Eq(t, `${lower(split(attr.unique.hostname, ".")[0])}`, env["SHORT_HOST"])
+	must.Eq(t, `${trimSuffix(attr.unique.hostname, ".example.com")}`, env["DOMAIN"])
+}
+
 func TestParseServiceCheck(t *testing.T) {
 	t.Parallel()
 
@@ -1098,6 +1125,112 @@ func TestParseServiceCheck(t *testing.T) {
 	require.Equal(t, expectedJob, parsedJob)
 }
 
+func TestParseConnectUpstream(t *testing.T) {
+	t.Parallel()
+
+	hcl := ` job "connect_upstream" {
+  group "group" {
+    service {
+      name = "foo-service"
+      connect {
+        sidecar_service {
+          proxy {
+            upstreams {
+              destination_name = "database"
+              local_bind_port  = 5432
+              connect_timeout  = "5s"
+              passive_health_check {
+                max_failures = 3
+                interval     = "30s"
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	upstreams := parsedJob.TaskGroups[0].Services[0].Connect.SidecarService.Proxy.Upstreams
+	require.Equal(t, []*api.ConsulUpstream{{
+		DestinationName: "database",
+		LocalBindPort:   5432,
+		ConnectTimeout:  pointerOf(5 * time.Second),
+		PassiveHealthCheck: &api.ConsulPassiveHealthCheck{
+			MaxFailures: 3,
+			Interval:    pointerOf(30 * time.Second),
+		},
+	}}, upstreams)
+}
+
+func TestParseMultipleVault(t *testing.T) {
+	t.Parallel()
+
+	hcl := ` job "multiple_vault" {
+  group "group" {
+    vault {
+      role = "group"
+    }
+
+    task "inherits" {
+      driver = "docker"
+    }
+
+    task "multiple" {
+      driver = "docker"
+
+      vault {
+        role = "kv"
+      }
+
+      vault {
+        cluster     = "pki"
+        role        = "issuer"
+        change_mode = "noop"
+      }
+
+      template {
+        data          = "{{ with secret \"pki/issue/web\" }}{{ .Data.certificate }}{{ end }}"
+        destination   = "local/cert.pem"
+        vault_cluster = "pki"
+      }
+    }
+  }
+}
+`
+	parsedJob, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+
+	inherits := parsedJob.TaskGroups[0].Tasks[0]
+	must.Eq(t, "group", inherits.Vault.Role)
+	must.Nil(t, inherits.Vaults)
+
+	multiple := parsedJob.TaskGroups[0].Tasks[1]
+	must.Eq(t, &api.Vault{
+		Role:        "kv",
+		Env:         pointerOf(true),
+		DisableFile: pointerOf(false),
+		ChangeMode:  pointerOf("restart"),
+	}, multiple.Vault)
+	must.Eq(t, []*api.Vault{{
+		Cluster:     "pki",
+		Role:        "issuer",
+		Env:         pointerOf(true),
+		DisableFile: pointerOf(false),
+		ChangeMode:  pointerOf("noop"),
+	}}, multiple.Vaults)
+	must.Eq(t, "pki", *multiple.Templates[0].VaultCluster)
+}
+
 func TestWaitConfig(t *testing.T) {
 	t.Parallel()
 
@@ -69,7 +69,7 @@ func (d *AutopilotDelegate) KnownServers() map[raft.ServerID]*autopilot.Server {
 }
 
 // NotifyState will be called when the autopilot state is updated. The Nomad
-// leader heartbeats a metric for monitoring based on this information. This
+// leader heartbeats metrics for monitoring based on this information. This
 // method is required to implement the ApplicationIntegration interface
 func (d *AutopilotDelegate) NotifyState(state *autopilot.State) {
 	if d.server.raft.State() == raft.Leader {
@@ -79,6 +79,13 @@ func (d *AutopilotDelegate) NotifyState(state *autopilot.State) {
 		} else {
 			metrics.SetGauge([]string{"nomad", "autopilot", "healthy"}, 0)
 		}
+
+		status, err := d.server.raftStatus(state)
+		if err != nil {
+			d.server.logger.Warn("failed to get raft replication status", "error", err)
+			return
+		}
+		emitRaftReplicationMetrics(status)
 	}
 }
 
@@ -123,8 +130,15 @@ func (s *Server) GetClusterHealth() *structs.OperatorHealthReply {
 		Servers:          make([]structs.ServerHealth, 0, len(state.Servers)),
 	}
 
-	for _, srv := range state.Servers {
+	failed := checkServersHealth(s.getOrCreateAutopilotConfig(), state, s.statsFetcher.HealthStats())
+
+	for id, srv := range state.Servers {
 		srvHealth := autopilotToServerHealth(srv)
+		if checks := failed[id]; len(checks) > 0 {
+			srvHealth.FailedChecks = checks
+			srvHealth.Healthy = false
+			health.Healthy = false
+		}
 
 		health.Servers = append(health.Servers, srvHealth)
 	}
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/raft"
+	autopilot "github.com/hashicorp/raft-autopilot"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// minHealthCheckDemotionVoters is the fewest voters autopilot leaves in the
+// cluster when demoting servers failing health checks.
+const minHealthCheckDemotionVoters = 3
+
+// serverHealthStats returns the stats of this server compared against the
+// health check thresholds of the autopilot config.
+func (s *Server) serverHealthStats() *structs.ServerHealthStats {
+	stats := &structs.ServerHealthStats{
+		AppliedIndex:  s.raft.AppliedIndex(),
+		LastAppliedAt: s.fsm.LastAppliedAt(),
+	}
+
+	if !s.config.DevMode && s.config.DataDir != "" {
+		usage, err := disk.Usage(filepath.Join(s.config.DataDir, raftState))
+		if err != nil {
+			s.logger.Warn("failed to get free disk space of raft directory", "error", err)
+		} else {
+			stats.DiskFreeBytes = usage.Free
+			stats.DiskTotalBytes = usage.Total
+		}
+	}
+
+	return stats
+}
+
+// checkServerHealth returns the health check thresholds of the autopilot
+// config that the server with stats fails. The applied index and last log of
+// the server are compared to those of the leader, if its stats are known.
+func checkServerHealth(conf *structs.AutopilotConfig, stats, leader *structs.ServerHealthStats) []string {
+	if conf == nil || stats == nil {
+		return nil
+	}
+
+	var failed []string
+	if conf.MinDiskFree > 0 && stats.DiskTotalBytes > 0 && stats.DiskFreeBytes < conf.MinDiskFree {
+		failed = append(failed, fmt.Sprintf("free disk space %s is below min_disk_free %s",
+			humanize.IBytes(stats.DiskFreeBytes), humanize.IBytes(conf.MinDiskFree)))
+	}
+
+	if leader == nil {
+		return failed
+	}
+
+	if conf.MaxAppliedIndexLag > 0 && leader.AppliedIndex > stats.AppliedIndex {
+		if lag := leader.AppliedIndex - stats.AppliedIndex; lag > conf.MaxAppliedIndexLag {
+			failed = append(failed, fmt.Sprintf("applied index trails the leader by %d, above max_applied_index_lag %d",
+				lag, conf.MaxAppliedIndexLag))
+		}
+	}
+
+	if conf.MaxLastLogAge > 0 && !stats.LastAppliedAt.IsZero() && !leader.LastAppliedAt.IsZero() {
+		if age := leader.LastAppliedAt.Sub(stats.LastAppliedAt); age > conf.MaxLastLogAge {
+			failed = append(failed, fmt.Sprintf("last applied log is %s older than the leader's, above max_last_log_age %s",
+				age.Round(time.Millisecond), conf.MaxLastLogAge))
+		}
+	}
+
+	return failed
+}
+
+// checkServersHealth returns the health checks failed by each of the servers
+// in the autopilot state which fails any.
+func checkServersHealth(conf *structs.AutopilotConfig, state *autopilot.State, stats map[raft.ServerID]*structs.ServerHealthStats) map[raft.ServerID][]string {
+	if conf == nil || state == nil ||
+		(conf.MinDiskFree == 0 && conf.MaxLastLogAge == 0 && conf.MaxAppliedIndexLag == 0) {
+		return nil
+	}
+
+	failed := make(map[raft.ServerID][]string)
+	for id := range state.Servers {
+		if checks := checkServerHealth(conf, stats[id], stats[state.Leader]); len(checks) > 0 {
+			failed[id] = checks
+		}
+	}
+	return failed
+}
+
+// applyHealthChecks adjusts the Raft changes calculated by the autopilot
+// promoter so that servers failing health checks are never promoted. Voters
+// failing health checks are demoted, as long as a majority of the remaining
+// voters is healthy and at least the larger of minQuorum and three voters
+// remain. Leadership is transferred away from a leader failing health checks
+// if there is a healthy voter to transfer it to.
+func applyHealthChecks(changes autopilot.RaftChanges, state *autopilot.State, failed map[raft.ServerID][]string, minQuorum uint) autopilot.RaftChanges {
+	if len(failed) == 0 || state == nil {
+		return changes
+	}
+
+	isFailed := func(id raft.ServerID) bool {
+		_, ok := failed[id]
+		return ok
+	}
+	isHealthy := func(id raft.ServerID) bool {
+		srv, ok := state.Servers[id]
+		return ok && srv.Health.Healthy && !isFailed(id)
+	}
+
+	changes.Promotions = slices.DeleteFunc(slices.Clone(changes.Promotions), isFailed)
+
+	voters := slices.Clone(state.Voters)
+	sort.Slice(voters, func(i, j int) bool { return voters[i] < voters[j] })
+
+	healthyVoters := 0
+	for _, id := range voters {
+		if isHealthy(id) {
+			healthyVoters++
+		}
+	}
+
+	minVoters := max(minHealthCheckDemotionVoters, int(minQuorum))
+	for _, id := range voters {
+		if !isFailed(id) || id == state.Leader || slices.Contains(changes.Demotions, id) {
+			continue
+		}
+		remaining := len(voters) - len(changes.Demotions) - 1
+		if remaining < minVoters || healthyVoters <= remaining/2 {
+			continue
+		}
+		changes.Demotions = append(changes.Demotions, id)
+	}
+
+	if isFailed(state.Leader) && changes.Leader == "" {
+		for _, id := range voters {
+			if id != state.Leader && isHealthy(id) && !slices.Contains(changes.Demotions, id) {
+				changes.Leader = id
+				break
+			}
+		}
+	}
+
+	return changes
+}
+
+// healthCheckPromoter wraps an autopilot promoter so it acts on the health
+// check thresholds of the autopilot config.
+type healthCheckPromoter struct {
+	autopilot.Promoter
+	srv *Server
+
+	// failed holds the health checks each server failed when last checked,
+	// so that changes in server health are only reported once.
+	failed     map[raft.ServerID][]string
+	failedLock sync.Mutex
+}
+
+func newHealthCheckPromoter(srv *Server, promoter autopilot.Promoter) *healthCheckPromoter {
+	return &healthCheckPromoter{
+		Promoter: promoter,
+		srv:      srv,
+		failed:   make(map[raft.ServerID][]string),
+	}
+}
+
+// CalculatePromotionsAndDemotions implements the autopilot.Promoter
+// interface. It is only called on the leader.
+func (p *healthCheckPromoter) CalculatePromotionsAndDemotions(c *autopilot.Config, state *autopilot.State) autopilot.RaftChanges {
+	changes := p.Promoter.CalculatePromotionsAndDemotions(c, state)
+
+	conf := p.srv.getOrCreateAutopilotConfig()
+	failed := checkServersHealth(conf, state, p.srv.statsFetcher.HealthStats())
+	p.report(state, failed)
+
+	if conf == nil {
+		return changes
+	}
+	return applyHealthChecks(changes, state, failed, conf.MinQuorum)
+}
+
+// report emits the number of health checks each server fails as a metric,
+// and logs and publishes an event when a server starts or stops failing
+// health checks.
+func (p *healthCheckPromoter) report(state *autopilot.State, failed map[raft.ServerID][]string) {
+	p.failedLock.Lock()
+	defer p.failedLock.Unlock()
+
+	var events []structs.Event
+	for id, srv := range state.Servers {
+		checks := failed[id]
+		metrics.SetGaugeWithLabels([]string{"nomad", "autopilot", "failed_health_checks"}, float32(len(checks)),
+			[]metrics.Label{{Name: "server_name", Value: srv.Server.Name}})
+
+		_, wasFailed := p.failed[id]
+		switch {
+		case len(checks) > 0 && !wasFailed:
+			p.srv.logger.Warn("server is failing autopilot health checks",
+				"server", srv.Server.Name, "checks", checks)
+		case len(checks) == 0 && wasFailed:
+			p.srv.logger.Info("server is passing autopilot health checks", "server", srv.Server.Name)
+		default:
+			continue
+		}
+
+		health := autopilotToServerHealth(srv)
+		health.FailedChecks = checks
+		events = append(events, structs.Event{
+			Topic:      structs.TopicOperator,
+			Type:       structs.TypeServerHealthUpdated,
+			Key:        string(id),
+			FilterKeys: []string{srv.Server.Name},
+			Payload:    &structs.ServerHealthEvent{Server: &health},
+		})
+	}
+	p.failed = failed
+
+	if len(events) == 0 {
+		return
+	}
+	broker, err := p.srv.State().EventBroker()
+	if err != nil {
+		// the event broker is disabled
+		return
+	}
+	index, err := p.srv.State().LatestIndex()
+	if err != nil {
+		p.srv.logger.Error("failed to publish server health events", "error", err)
+		return
+	}
+	for i := range events {
+		events[i].Index = index
+	}
+	broker.Publish(&structs.Events{Index: index, Events: events})
+}
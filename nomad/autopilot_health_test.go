@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	autopilot "github.com/hashicorp/raft-autopilot"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+)
+
+var _ autopilot.Promoter = (*healthCheckPromoter)(nil)
+
+func TestAutopilot_checkServerHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	leader := &structs.ServerHealthStats{
+		DiskFreeBytes:  100 << 30,
+		DiskTotalBytes: 200 << 30,
+		AppliedIndex:   5000,
+		LastAppliedAt:  now,
+	}
+	conf := &structs.AutopilotConfig{
+		MinDiskFree:        5 << 30,
+		MaxLastLogAge:      time.Minute,
+		MaxAppliedIndexLag: 1000,
+	}
+
+	cases := []struct {
+		name   string
+		conf   *structs.AutopilotConfig
+		stats  *structs.ServerHealthStats
+		leader *structs.ServerHealthStats
+		expect []string
+	}{
+		{
+			name:   "no stats",
+			conf:   conf,
+			leader: leader,
+		},
+		{
+			name: "healthy",
+			conf: conf,
+			stats: &structs.ServerHealthStats{
+				DiskFreeBytes:  10 << 30,
+				DiskTotalBytes: 200 << 30,
+				AppliedIndex:   4500,
+				LastAppliedAt:  now.Add(-30 * time.Second),
+			},
+			leader: leader,
+		},
+		{
+			name: "all failed",
+			conf: conf,
+			stats: &structs.ServerHealthStats{
+				DiskFreeBytes:  1 << 30,
+				DiskTotalBytes: 200 << 30,
+				AppliedIndex:   2000,
+				LastAppliedAt:  now.Add(-2 * time.Minute),
+			},
+			leader: leader,
+			expect: []string{
+				"free disk space 1.0 GiB is below min_disk_free 5.0 GiB",
+				"applied index trails the leader by 3000, above max_applied_index_lag 1000",
+				"last applied log is 2m0s older than the leader's, above max_last_log_age 1m0s",
+			},
+		},
+		{
+			name: "checks disabled",
+			conf: &structs.AutopilotConfig{},
+			stats: &structs.ServerHealthStats{
+				DiskFreeBytes:  1 << 30,
+				DiskTotalBytes: 200 << 30,
+				AppliedIndex:   2000,
+				LastAppliedAt:  now.Add(-2 * time.Minute),
+			},
+			leader: leader,
+		},
+		{
+			name: "unknown disk and leader",
+			conf: conf,
+			stats: &structs.ServerHealthStats{
+				AppliedIndex:  2000,
+				LastAppliedAt: now.Add(-2 * time.Minute),
+			},
+		},
+		{
+			name: "nothing applied",
+			conf: conf,
+			stats: &structs.ServerHealthStats{
+				AppliedIndex: 4900,
+			},
+			leader: leader,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expect, checkServerHealth(tc.conf, tc.stats, tc.leader))
+		})
+	}
+}
+
+func TestAutopilot_applyHealthChecks(t *testing.T) {
+	ci.Parallel(t)
+
+	state := func(voters ...raft.ServerID) *autopilot.State {
+		s := &autopilot.State{
+			Leader:  "a",
+			Voters:  voters,
+			Servers: make(map[raft.ServerID]*autopilot.ServerState),
+		}
+		for _, id := range []raft.ServerID{"a", "b", "c", "d", "e", "f"} {
+			s.Servers[id] = &autopilot.ServerState{
+				Server: autopilot.Server{ID: id},
+				Health: autopilot.ServerHealth{Healthy: true},
+			}
+		}
+		return s
+	}
+	failed := func(ids ...raft.ServerID) map[raft.ServerID][]string {
+		m := make(map[raft.ServerID][]string)
+		for _, id := range ids {
+			m[id] = []string{"failed"}
+		}
+		return m
+	}
+
+	cases := []struct {
+		name      string
+		changes   autopilot.RaftChanges
+		state     *autopilot.State
+		failed    map[raft.ServerID][]string
+		minQuorum uint
+		expect    autopilot.RaftChanges
+	}{
+		{
+			name:    "all healthy",
+			changes: autopilot.RaftChanges{Promotions: []raft.ServerID{"f"}},
+			state:   state("a", "b", "c", "d", "e"),
+			expect:  autopilot.RaftChanges{Promotions: []raft.ServerID{"f"}},
+		},
+		{
+			name:    "failed server not promoted",
+			changes: autopilot.RaftChanges{Promotions: []raft.ServerID{"e", "f"}},
+			state:   state("a", "b", "c"),
+			failed:  failed("f"),
+			expect:  autopilot.RaftChanges{Promotions: []raft.ServerID{"e"}},
+		},
+		{
+			name:   "failed voter demoted",
+			state:  state("a", "b", "c", "d", "e"),
+			failed: failed("d"),
+			expect: autopilot.RaftChanges{Demotions: []raft.ServerID{"d"}},
+		},
+		{
+			name:   "demotions stop at three voters",
+			state:  state("a", "b", "c", "d"),
+			failed: failed("c", "d"),
+			expect: autopilot.RaftChanges{Demotions: []raft.ServerID{"c"}},
+		},
+		{
+			name:   "no demotions without a healthy majority",
+			state:  state("a", "b", "c", "d", "e"),
+			failed: failed("c", "d", "e"),
+			expect: autopilot.RaftChanges{},
+		},
+		{
+			name:      "demotions stop at min quorum",
+			state:     state("a", "b", "c", "d", "e"),
+			failed:    failed("d", "e"),
+			minQuorum: 4,
+			expect:    autopilot.RaftChanges{Demotions: []raft.ServerID{"d"}},
+		},
+		{
+			name:   "three voters never demoted",
+			state:  state("a", "b", "c"),
+			failed: failed("c"),
+			expect: autopilot.RaftChanges{},
+		},
+		{
+			name:   "leadership transferred from failed leader",
+			state:  state("a", "b", "c"),
+			failed: failed("a", "b"),
+			expect: autopilot.RaftChanges{Leader: "c"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expect, applyHealthChecks(tc.changes, tc.state, tc.failed, tc.minQuorum))
+		})
+	}
+}
+
+func TestAutopilot_ServerHealthChecks(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.DevMode = false   // Keep the raft data on disk
+		c.AutopilotConfig.MinDiskFree = 1 << 62
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	testutil.WaitForResult(func() (bool, error) {
+		health := s1.GetClusterHealth()
+		if health == nil || len(health.Servers) != 1 {
+			return false, nil
+		}
+		if len(health.Servers[0].FailedChecks) != 1 {
+			return false, nil
+		}
+		return !health.Healthy && !health.Servers[0].Healthy, nil
+	}, func(err error) {
+		t.Fatalf("expected server to fail the disk health check: %v", err)
+	})
+}
@@ -513,6 +513,17 @@ func (b *BlockedEvals) UnblockNode(nodeID string, index uint64) {
 	for e := range evals {
 		b.system.Remove(e)
 		b.stats.Unblock(e)
+
+		// System evals are also captured by computed node class, so stop
+		// tracking them there to avoid enqueuing them again on a later
+		// unblock.
+		if _, ok := b.captured[e.ID]; ok {
+			delete(b.captured, e.ID)
+			nsID := structs.NewNamespacedID(e.JobID, e.Namespace)
+			if b.jobs[nsID] == e.ID {
+				delete(b.jobs, nsID)
+			}
+		}
 	}
 
 	b.evalBroker.EnqueueAll(evals)
@@ -582,6 +593,9 @@ func (b *BlockedEvals) unblock(computedClass, quota string, index uint64) {
 		unblocked[wrapped.eval] = wrapped.token
 		delete(b.jobs, structs.NewNamespacedID(wrapped.eval.JobID, wrapped.eval.Namespace))
 		delete(b.captured, id)
+		if wrapped.eval.Type == structs.JobTypeSystem {
+			b.system.Remove(wrapped.eval)
+		}
 		if wrapped.eval.QuotaLimitReached != "" {
 			numQuotaLimit++
 		}
@@ -646,6 +646,35 @@ func TestBlockedEvals_UnblockNode(t *testing.T) {
 	must.MapLen(t, 0, stats.BlockedResources.ByJob)
 }
 
+func TestBlockedEvals_UnblockNode_Captured(t *testing.T) {
+	ci.Parallel(t)
+
+	blocked, broker := testBlockedEvals(t)
+
+	// System evals are tracked both by node and by computed node class
+	e := mock.BlockedEval()
+	e.Type = structs.JobTypeSystem
+	e.NodeID = "foo"
+	e.ClassEligibility = map[string]bool{"v1:123": true}
+	blocked.Block(e)
+
+	blocked.UnblockNode("foo", 1000)
+	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
+	must.MapEmpty(t, blocked.captured)
+	must.MapEmpty(t, blocked.jobs)
+
+	// A system eval unblocked by its class is no longer tracked by node
+	e = mock.BlockedEval()
+	e.Type = structs.JobTypeSystem
+	e.NodeID = "foo"
+	e.ClassEligibility = map[string]bool{"v1:123": true}
+	blocked.Block(e)
+
+	blocked.Unblock("v1:123", 1001)
+	requireBlockedEvalsEnqueued(t, blocked, broker, 2)
+	must.MapEmpty(t, blocked.system.byNode)
+}
+
 func TestBlockedEvals_SystemUntrack(t *testing.T) {
 	ci.Parallel(t)
 	blocked, _ := testBlockedEvals(t)
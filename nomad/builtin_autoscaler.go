@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// builtinAutoscalerInterval is how often the builtin autoscaler evaluates
+	// scaling policies.
+	builtinAutoscalerInterval = 30 * time.Second
+
+	// externalAutoscalerMetaPrefix is the prefix of the scaling event
+	// metadata keys set by the external Nomad Autoscaler. Groups it has
+	// scaled are left alone by the builtin autoscaler.
+	externalAutoscalerMetaPrefix = "nomad_autoscaler."
+)
+
+// builtinAutoscaler evaluates horizontal scaling policies with a "nomad"
+// source on the leader. It scales each policy's group so that the average
+// utilization of the policy's metric across the group's running allocations
+// is kept at the policy's target, within the policy's bounds.
+type builtinAutoscaler struct {
+	srv    *Server
+	logger hclog.Logger
+
+	// allocStats returns the resource usage reported by the client running
+	// the allocation.
+	allocStats func(*structs.Allocation) (*cstructs.AllocResourceUsage, error)
+}
+
+func newBuiltinAutoscaler(s *Server) *builtinAutoscaler {
+	a := &builtinAutoscaler{
+		srv:    s,
+		logger: s.logger.Named("builtin_autoscaler"),
+	}
+	a.allocStats = a.clientAllocStats
+	return a
+}
+
+// run evaluates scaling policies periodically until stopCh is closed.
+func (a *builtinAutoscaler) run(stopCh chan struct{}) {
+	ticker := time.NewTicker(builtinAutoscalerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			a.evaluatePolicies()
+		}
+	}
+}
+
+// evaluatePolicies evaluates every enabled scaling policy with a "nomad"
+// source.
+func (a *builtinAutoscaler) evaluatePolicies() {
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		a.logger.Error("failed to get state", "error", err)
+		return
+	}
+
+	iter, err := snap.ScalingPoliciesByTypePrefix(nil, structs.ScalingPolicyTypeHorizontal)
+	if err != nil {
+		a.logger.Error("failed to get scaling policies", "error", err)
+		return
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		policy := raw.(*structs.ScalingPolicy)
+		if !policy.Enabled || policy.Type != structs.ScalingPolicyTypeHorizontal {
+			continue
+		}
+
+		builtin, err := policy.BuiltinPolicy()
+		if err != nil {
+			a.logger.Warn("invalid scaling policy", "policy_id", policy.ID, "error", err)
+			continue
+		}
+		if builtin == nil {
+			continue
+		}
+
+		if err := a.evaluatePolicy(snap, policy, builtin); err != nil {
+			a.logger.Error("failed to evaluate scaling policy", "policy_id", policy.ID, "error", err)
+		}
+	}
+}
+
+// evaluatePolicy scales the group targeted by policy if the average
+// utilization of its allocations differs from the target.
+func (a *builtinAutoscaler) evaluatePolicy(snap *state.StateSnapshot, policy *structs.ScalingPolicy, builtin *structs.BuiltinScalingPolicy) error {
+	namespace := policy.Target[structs.ScalingTargetNamespace]
+	jobID := policy.Target[structs.ScalingTargetJob]
+	group := policy.Target[structs.ScalingTargetGroup]
+	logger := a.logger.With("namespace", namespace, "job_id", jobID, "group", group)
+
+	// policies with checks are meant for the external Nomad Autoscaler
+	if _, ok := policy.Policy["check"]; ok {
+		return nil
+	}
+
+	job, err := snap.JobByID(nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil || job.Stopped() || job.Type != structs.JobTypeService {
+		return nil
+	}
+	tg := job.LookupTaskGroup(group)
+	if tg == nil {
+		return nil
+	}
+
+	events, _, err := snap.ScalingEventsByJob(nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+	if reason := skipScaling(events[group], builtin.Cooldown, time.Now()); reason != "" {
+		logger.Trace("skipping scaling policy", "reason", reason)
+		return nil
+	}
+
+	deployment, err := snap.LatestDeploymentByJobID(nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+	if deployment != nil && deployment.Active() && deployment.JobCreateIndex == job.CreateIndex {
+		logger.Trace("skipping scaling policy", "reason", "active deployment")
+		return nil
+	}
+
+	allocs, err := snap.AllocsByJob(nil, namespace, jobID, false)
+	if err != nil {
+		return err
+	}
+
+	var total float64
+	var sampled int
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != group || alloc.TerminalStatus() ||
+			alloc.ClientStatus != structs.AllocClientStatusRunning {
+			continue
+		}
+		usage, err := a.allocStats(alloc)
+		if err != nil {
+			logger.Debug("failed to get allocation stats", "alloc_id", alloc.ID, "error", err)
+			continue
+		}
+		if u, ok := allocUtilization(alloc, usage, builtin.Metric); ok {
+			total += u
+			sampled++
+		}
+	}
+	if sampled == 0 {
+		return nil
+	}
+
+	average := total / float64(sampled)
+	count := int64(tg.Count)
+	desired := int64(math.Ceil(float64(count) * average / builtin.Target))
+	desired = max(policy.Min, min(policy.Max, desired))
+	if desired == count {
+		return nil
+	}
+
+	req := &structs.JobScaleRequest{
+		JobID:  jobID,
+		Target: policy.Target,
+		Count:  &desired,
+		Message: fmt.Sprintf("scaling from %d to %d as average %s utilization %.2f is off target %.2f",
+			count, desired, builtin.Metric, average, builtin.Target),
+		Meta: map[string]interface{}{
+			structs.ScalingEventMetaBuiltinAutoscaler: true,
+			"metric": builtin.Metric,
+			"value":  average,
+			"target": builtin.Target,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    a.srv.Region(),
+			Namespace: namespace,
+			AuthToken: a.srv.getLeaderAcl(),
+		},
+	}
+
+	logger.Info("scaling group", "count", count, "desired", desired,
+		"metric", builtin.Metric, "value", average, "target", builtin.Target)
+
+	var resp structs.JobRegisterResponse
+	return a.srv.RPC("Job.Scale", req, &resp)
+}
+
+// clientAllocStats returns the resource usage of alloc from the client running
+// it.
+func (a *builtinAutoscaler) clientAllocStats(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+	req := &cstructs.AllocStatsRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    a.srv.Region(),
+			Namespace: alloc.Namespace,
+			AuthToken: a.srv.getLeaderAcl(),
+		},
+	}
+	var resp cstructs.AllocStatsResponse
+	if err := a.srv.RPC("ClientAllocations.Stats", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Stats, nil
+}
+
+// skipScaling returns why a group with the given scaling events, newest
+// first, must not be scaled by the builtin autoscaler, or the empty string if
+// it may be. Groups scaled by the external Nomad Autoscaler are never scaled,
+// and no group is scaled within cooldown of its last change of count.
+func skipScaling(events []*structs.ScalingEvent, cooldown time.Duration, now time.Time) string {
+	for _, event := range events {
+		for key := range event.Meta {
+			if strings.HasPrefix(key, externalAutoscalerMetaPrefix) {
+				return "scaled by external autoscaler"
+			}
+		}
+	}
+
+	for _, event := range events {
+		if event.Count == nil || event.Error {
+			continue
+		}
+		if now.Sub(time.Unix(0, event.Time)) < cooldown {
+			return "cooldown"
+		}
+		break
+	}
+	return ""
+}
+
+// allocUtilization returns the utilization of metric by alloc, as a fraction
+// of the resources allocated to it, and whether it could be determined.
+func allocUtilization(alloc *structs.Allocation, usage *cstructs.AllocResourceUsage, metric string) (float64, bool) {
+	if usage == nil || usage.ResourceUsage == nil || alloc.AllocatedResources == nil {
+		return 0, false
+	}
+	resources := alloc.AllocatedResources.Comparable().Flattened
+
+	switch metric {
+	case structs.ScalingPolicyMetricCPU:
+		stats := usage.ResourceUsage.CpuStats
+		if stats == nil || resources.Cpu.CpuShares <= 0 {
+			return 0, false
+		}
+		return stats.TotalTicks / float64(resources.Cpu.CpuShares), true
+
+	case structs.ScalingPolicyMetricMemory:
+		stats := usage.ResourceUsage.MemoryStats
+		if stats == nil || resources.Memory.MemoryMB <= 0 {
+			return 0, false
+		}
+		used := stats.RSS
+		if used == 0 {
+			used = stats.Usage
+		}
+		return float64(used) / float64(resources.Memory.MemoryMB*1024*1024), true
+	}
+	return 0, false
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestBuiltinAutoscaler_evaluatePolicies(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	job, policy := mock.JobWithScalingPolicy()
+	policy.Min = 1
+	policy.Max = 15
+	policy.Policy = map[string]interface{}{
+		"source": structs.ScalingPolicySourceNomad,
+		"metric": structs.ScalingPolicyMetricCPU,
+		"target": 0.5,
+	}
+	job.TaskGroups[0].Count = 4
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+	// each allocation uses all of its 500 MHz
+	a := newBuiltinAutoscaler(s1)
+	a.allocStats = func(*structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+		return &cstructs.AllocResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				CpuStats: &cstructs.CpuStats{TotalTicks: 500},
+			},
+		}, nil
+	}
+
+	// scaled to twice the count to halve utilization, within the maximum
+	a.evaluatePolicies()
+	out, err := store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 8, out.TaskGroups[0].Count)
+
+	events, _, err := store.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, events[job.TaskGroups[0].Name])
+	event := events[job.TaskGroups[0].Name][0]
+	must.Eq(t, 8, *event.Count)
+	must.Eq(t, 4, event.PreviousCount)
+	must.StrContains(t, event.Message, "average cpu utilization 1.00 is off target 0.50")
+	must.Eq(t, true, event.Meta[structs.ScalingEventMetaBuiltinAutoscaler])
+
+	// the group is left alone during cooldown
+	a.evaluatePolicies()
+	out, err = store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 8, out.TaskGroups[0].Count)
+}
+
+func TestBuiltinAutoscaler_skipScaling(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	ago := func(d time.Duration) int64 { return now.Add(-d).UnixNano() }
+
+	cases := []struct {
+		name   string
+		events []*structs.ScalingEvent
+		exp    string
+	}{
+		{
+			name: "no events",
+		},
+		{
+			name: "within cooldown",
+			events: []*structs.ScalingEvent{
+				{Time: ago(time.Minute), Count: pointer.Of(int64(2))},
+			},
+			exp: "cooldown",
+		},
+		{
+			name: "after cooldown",
+			events: []*structs.ScalingEvent{
+				{Time: ago(10 * time.Minute), Count: pointer.Of(int64(2))},
+			},
+		},
+		{
+			name: "only count changes start cooldown",
+			events: []*structs.ScalingEvent{
+				{Time: ago(time.Minute), Message: "no count"},
+				{Time: ago(time.Minute), Count: pointer.Of(int64(2)), Error: true},
+				{Time: ago(10 * time.Minute), Count: pointer.Of(int64(2))},
+			},
+		},
+		{
+			name: "external autoscaler",
+			events: []*structs.ScalingEvent{
+				{Time: ago(time.Hour), Count: pointer.Of(int64(2)),
+					Meta: map[string]interface{}{"nomad_autoscaler.count.original": 3}},
+			},
+			exp: "scaled by external autoscaler",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, skipScaling(tc.events, 5*time.Minute, now))
+		})
+	}
+}
+
+func TestBuiltinAutoscaler_allocUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	usage := &cstructs.AllocResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			CpuStats:    &cstructs.CpuStats{TotalTicks: 250},
+			MemoryStats: &cstructs.MemoryStats{RSS: 64 * 1024 * 1024},
+		},
+	}
+
+	u, ok := allocUtilization(alloc, usage, structs.ScalingPolicyMetricCPU)
+	must.True(t, ok)
+	must.Eq(t, 0.5, u)
+
+	u, ok = allocUtilization(alloc, usage, structs.ScalingPolicyMetricMemory)
+	must.True(t, ok)
+	must.Eq(t, 0.25, u)
+
+	_, ok = allocUtilization(alloc, &cstructs.AllocResourceUsage{}, structs.ScalingPolicyMetricCPU)
+	must.False(t, ok)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ArtifactCache endpoint is used to forward artifact cache requests to the
+// client agent connected to the target node.
+type ArtifactCache struct {
+	srv    *Server
+	logger log.Logger
+}
+
+func newArtifactCacheEndpoint(srv *Server) *ArtifactCache {
+	return &ArtifactCache{
+		srv:    srv,
+		logger: srv.logger.Named("artifact_cache"),
+	}
+}
+
+func (a *ArtifactCache) Prefetch(args *structs.ArtifactPrefetchRequest, reply *structs.ArtifactPrefetchResponse) error {
+	const method = "ArtifactCache.Prefetch"
+
+	// Prevent infinite loop between leader and
+	// follower-with-the-target-node-connection.
+	args.QueryOptions.AllowStale = true
+
+	authErr := a.srv.Authenticate(nil, args)
+	if done, err := a.srv.forward(method, args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("artifact_cache", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "artifact_cache", "prefetch"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := a.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	return a.srv.forwardClientRPC(method, args.NodeID, args, reply)
+}
@@ -51,3 +51,33 @@ func (s *ClientStats) Stats(args *nstructs.NodeSpecificRequest, reply *structs.C
 
 	return s.srv.forwardClientRPC("ClientStats.Stats", args.NodeID, args, reply)
 }
+
+// Debug forwards the request for the state of the subsystems of a client to
+// the client.
+func (s *ClientStats) Debug(args *nstructs.NodeSpecificRequest, reply *structs.ClientDebugResponse) error {
+
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hope
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+	authErr := s.srv.Authenticate(nil, args)
+
+	// Potentially forward to a different region.
+	if done, err := s.srv.forward("ClientStats.Debug", args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("client_stats", nstructs.RateMetricRead, args)
+	if authErr != nil {
+		return nstructs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_stats", "debug"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	return s.srv.forwardClientRPC("ClientStats.Debug", args.NodeID, args, reply)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
 	"github.com/hashicorp/yamux"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -362,6 +363,21 @@ type Config struct {
 	// publishing Job summary metrics
 	DisableDispatchedJobSummaryMetrics bool
 
+	// PublishJobAggregateMetrics enables the leader to publish the allocation
+	// counts, queued evaluations and requested resources of each job and
+	// namespace as metrics, every JobAggregateMetricsInterval
+	PublishJobAggregateMetrics  bool
+	JobAggregateMetricsInterval time.Duration
+
+	// JobAggregateMetricsNamespaces are the namespaces whose jobs the job
+	// aggregate metrics are published for, or all namespaces if empty
+	JobAggregateMetricsNamespaces []string
+
+	// TracerProvider creates the tracer of the spans the server records for
+	// job registrations and the evaluations and plans which follow them. The
+	// spans are not recorded if nil.
+	TracerProvider trace.TracerProvider
+
 	// DisableQuotaUtilizationMetrics allows to disable publishing of quota
 	// utilization metrics
 	DisableQuotaUtilizationMetrics bool
@@ -433,9 +449,18 @@ type Config struct {
 	// JobTrackedVersions is the number of historic Job versions that are kept.
 	JobTrackedVersions int
 
+	// JobMaxVersionsPerJob is the number of untagged versions of a Job that
+	// are kept when it does not set a version retention. If zero,
+	// JobTrackedVersions is used.
+	JobMaxVersionsPerJob int
+
 	// JobMaxCount is the maximum total task group counts for a single Job.
 	JobMaxCount int
 
+	// BuiltinAutoscaler enables the leader to evaluate horizontal scaling
+	// policies with a "nomad" source and scale their groups.
+	BuiltinAutoscaler bool
+
 	Reporting *config.ReportingConfig
 
 	// OIDCIssuer is the URL for the OIDC Issuer field in Workload Identity JWTs.
@@ -473,6 +498,7 @@ func (c *Config) Copy() *Config {
 	// Logger log.InterceptLogger
 	// PluginLoader loader.PluginCatalog
 	// PluginSingletonLoader loader.PluginCatalog
+	// TracerProvider trace.TracerProvider
 
 	nc.RPCAddr = pointer.Copy(c.RPCAddr)
 	nc.ClientRPCAdvertise = pointer.Copy(c.ClientRPCAdvertise)
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -84,26 +85,56 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 	}
 }
 
-// forceGC is used to garbage collect all eligible objects.
+// forceGC is used to garbage collect all eligible objects. The classes of
+// objects to collect may be smuggled in the eval's JobID as a comma separated
+// list of structs.GCTypes, in which case only those are collected.
 func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	// set a minimal threshold for all objects to make force GC possible
 	force := pointer.Of(time.Millisecond)
 
-	if err := c.jobGC(eval, force); err != nil {
-		return err
+	types := structs.GCTypes
+	targeted := false
+	if parts := strings.SplitN(eval.JobID, ":", 2); len(parts) == 2 && parts[1] != "" {
+		types = strings.Split(parts[1], ",")
+		targeted = true
 	}
-	if err := c.evalGC(force); err != nil {
-		return err
+
+	if slices.Contains(types, structs.GCTypeJobs) {
+		if err := c.jobGC(eval, force); err != nil {
+			return err
+		}
 	}
-	if err := c.deploymentGC(force); err != nil {
-		return err
+	if slices.Contains(types, structs.GCTypeEvals) {
+		if err := c.evalGC(force); err != nil {
+			return err
+		}
 	}
-	if err := c.csiPluginGC(eval, force); err != nil {
-		return err
+	if slices.Contains(types, structs.GCTypeDeployments) {
+		if err := c.deploymentGC(force); err != nil {
+			return err
+		}
 	}
-	if err := c.csiVolumeClaimGC(eval, force); err != nil {
-		return err
+	if slices.Contains(types, structs.GCTypePlugins) {
+		if err := c.csiPluginGC(eval, force); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(types, structs.GCTypeVolumes) {
+		if err := c.csiVolumeClaimGC(eval, force); err != nil {
+			return err
+		}
+	}
+
+	// Node GC must occur after the others to ensure the allocations are
+	// cleared.
+	if targeted {
+		if slices.Contains(types, structs.GCTypeNodes) {
+			return c.nodeGC(eval, force)
+		}
+		return nil
 	}
+
+	// tokens and keys are only collected by an untargeted GC
 	if err := c.expiredOneTimeTokenGC(eval); err != nil {
 		return err
 	}
@@ -122,13 +153,123 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	return c.nodeGC(eval, force)
 }
 
+// gcStats returns the number of objects of each of the given classes of
+// structs.GCTypes which are eligible for garbage collection, without
+// collecting any of them. The counts are computed by the same scans the core
+// GC jobs run, both with the configured thresholds and with the threshold of a
+// forced GC.
+func (c *CoreScheduler) gcStats(types []string) (map[string]*structs.GCClassStats, error) {
+	force := pointer.Of(time.Millisecond)
+	config := c.srv.config
+
+	stats := make(map[string]*structs.GCClassStats, len(types))
+	for _, t := range types {
+		var count func(*time.Duration) (int, error)
+		var thresholds map[string]time.Duration
+
+		switch t {
+		case structs.GCTypeJobs:
+			count = func(threshold *time.Duration) (int, error) {
+				jobs, _, _, err := c.jobGCEligible(threshold)
+				return len(jobs), err
+			}
+			thresholds = map[string]time.Duration{
+				"job_gc_threshold": config.JobGCThreshold,
+			}
+		case structs.GCTypeEvals:
+			count = func(threshold *time.Duration) (int, error) {
+				evals, _, err := c.evalGCEligible(threshold)
+				return len(evals), err
+			}
+			thresholds = map[string]time.Duration{
+				"eval_gc_threshold":       config.EvalGCThreshold,
+				"batch_eval_gc_threshold": config.BatchEvalGCThreshold,
+			}
+		case structs.GCTypeDeployments:
+			count = func(threshold *time.Duration) (int, error) {
+				deployments, err := c.deploymentGCEligible(threshold)
+				return len(deployments), err
+			}
+			thresholds = map[string]time.Duration{
+				"deployment_gc_threshold": config.DeploymentGCThreshold,
+			}
+		case structs.GCTypeVolumes:
+			count = func(threshold *time.Duration) (int, error) {
+				vols, err := c.csiVolumeClaimGCEligible(threshold)
+				return len(vols), err
+			}
+			thresholds = map[string]time.Duration{
+				"csi_volume_claim_gc_threshold": config.CSIVolumeClaimGCThreshold,
+			}
+		case structs.GCTypeNodes:
+			count = func(threshold *time.Duration) (int, error) {
+				nodes, err := c.nodeGCEligible(threshold)
+				return len(nodes), err
+			}
+			thresholds = map[string]time.Duration{
+				"node_gc_threshold": config.NodeGCThreshold,
+			}
+		case structs.GCTypePlugins:
+			count = func(threshold *time.Duration) (int, error) {
+				plugins, err := c.csiPluginGCEligible(threshold)
+				return len(plugins), err
+			}
+			thresholds = map[string]time.Duration{
+				"csi_plugin_gc_threshold": config.CSIPluginGCThreshold,
+			}
+		default:
+			return nil, fmt.Errorf("invalid garbage collection type %q", t)
+		}
+
+		eligible, err := count(nil)
+		if err != nil {
+			return nil, err
+		}
+		forced, err := count(force)
+		if err != nil {
+			return nil, err
+		}
+		stats[t] = &structs.GCClassStats{
+			Eligible:   eligible,
+			Forced:     forced,
+			Thresholds: thresholds,
+		}
+	}
+	return stats, nil
+}
+
 // jobGC is used to garbage collect eligible jobs.
 func (c *CoreScheduler) jobGC(eval *structs.Evaluation, customThreshold *time.Duration) error {
+	gcJob, gcEval, gcAlloc, err := c.jobGCEligible(customThreshold)
+	if err != nil {
+		return err
+	}
+
+	// Fast-path the nothing case
+	if len(gcEval) == 0 && len(gcAlloc) == 0 && len(gcJob) == 0 {
+		return nil
+	}
+
+	c.logger.Debug("job GC found eligible objects",
+		"jobs", len(gcJob), "evals", len(gcEval), "allocs", len(gcAlloc))
+
+	// Reap the evals and allocs
+	if err := c.evalReap(gcEval, gcAlloc); err != nil {
+		return err
+	}
+
+	// Reap the jobs
+	return c.jobReap(gcJob, eval.LeaderACL)
+}
+
+// jobGCEligible returns the jobs eligible for garbage collection, along with
+// the evaluations and allocations collected with them.
+func (c *CoreScheduler) jobGCEligible(customThreshold *time.Duration) ([]*structs.Job, []string, []string, error) {
 	// Get all the jobs eligible for garbage collection.
 	ws := memdb.NewWatchSet()
 	iter, err := c.snap.JobsByGC(ws, true)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
 	var threshold time.Duration
@@ -197,21 +338,7 @@ OUTER:
 
 	}
 
-	// Fast-path the nothing case
-	if len(gcEval) == 0 && len(gcAlloc) == 0 && len(gcJob) == 0 {
-		return nil
-	}
-
-	c.logger.Debug("job GC found eligible objects",
-		"jobs", len(gcJob), "evals", len(gcEval), "allocs", len(gcAlloc))
-
-	// Reap the evals and allocs
-	if err := c.evalReap(gcEval, gcAlloc); err != nil {
-		return err
-	}
-
-	// Reap the jobs
-	return c.jobReap(gcJob, eval.LeaderACL)
+	return gcJob, gcEval, gcAlloc, nil
 }
 
 // jobReap contacts the leader and issues a reap on the passed jobs
@@ -270,11 +397,29 @@ func (c *CoreScheduler) partitionJobReap(jobs []*structs.Job, leaderACL string,
 
 // evalGC is used to garbage collect old evaluations
 func (c *CoreScheduler) evalGC(customThreshold *time.Duration) error {
+	gcEval, gcAlloc, err := c.evalGCEligible(customThreshold)
+	if err != nil {
+		return err
+	}
+
+	// Fast-path the nothing case
+	if len(gcEval) == 0 && len(gcAlloc) == 0 {
+		return nil
+	}
+	c.logger.Debug("eval GC found eligibile objects",
+		"evals", len(gcEval), "allocs", len(gcAlloc))
+
+	return c.evalReap(gcEval, gcAlloc)
+}
+
+// evalGCEligible returns the evaluations and allocations eligible for garbage
+// collection.
+func (c *CoreScheduler) evalGCEligible(customThreshold *time.Duration) ([]string, []string, error) {
 	// Iterate over the evaluations
 	ws := memdb.NewWatchSet()
 	iter, err := c.snap.Evals(ws, false)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	var threshold, batchThreshold time.Duration
@@ -302,7 +447,7 @@ func (c *CoreScheduler) evalGC(customThreshold *time.Duration) error {
 
 		gc, allocs, err := c.gcEval(eval, gcCutoffTime, false)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		if gc {
@@ -311,14 +456,7 @@ func (c *CoreScheduler) evalGC(customThreshold *time.Duration) error {
 		gcAlloc = append(gcAlloc, allocs...)
 	}
 
-	// Fast-path the nothing case
-	if len(gcEval) == 0 && len(gcAlloc) == 0 {
-		return nil
-	}
-	c.logger.Debug("eval GC found eligibile objects",
-		"evals", len(gcEval), "allocs", len(gcAlloc))
-
-	return c.evalReap(gcEval, gcAlloc)
+	return gcEval, gcAlloc, nil
 }
 
 // gcEval returns whether the eval should be garbage collected given the cutoff
@@ -466,11 +604,26 @@ func (c *CoreScheduler) partitionEvalReap(evals, allocs []string, batchSize int)
 
 // nodeGC is used to garbage collect old nodes
 func (c *CoreScheduler) nodeGC(eval *structs.Evaluation, customThreshold *time.Duration) error {
+	gcNode, err := c.nodeGCEligible(customThreshold)
+	if err != nil {
+		return err
+	}
+
+	// Fast-path the nothing case
+	if len(gcNode) == 0 {
+		return nil
+	}
+	c.logger.Debug("node GC found eligible nodes", "nodes", len(gcNode))
+	return c.nodeReap(eval, gcNode)
+}
+
+// nodeGCEligible returns the IDs of the nodes eligible for garbage collection.
+func (c *CoreScheduler) nodeGCEligible(customThreshold *time.Duration) ([]string, error) {
 	// Iterate over the evaluations
 	ws := memdb.NewWatchSet()
 	iter, err := c.snap.Nodes(ws)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var threshold time.Duration
@@ -521,12 +674,7 @@ OUTER:
 		gcNode = append(gcNode, node.ID)
 	}
 
-	// Fast-path the nothing case
-	if len(gcNode) == 0 {
-		return nil
-	}
-	c.logger.Debug("node GC found eligible nodes", "nodes", len(gcNode))
-	return c.nodeReap(eval, gcNode)
+	return gcNode, nil
 }
 
 func (c *CoreScheduler) nodeReap(eval *structs.Evaluation, nodeIDs []string) error {
@@ -574,11 +722,27 @@ func (c *CoreScheduler) nodeReap(eval *structs.Evaluation, nodeIDs []string) err
 
 // deploymentGC is used to garbage collect old deployments
 func (c *CoreScheduler) deploymentGC(customThreshold *time.Duration) error {
+	gcDeployment, err := c.deploymentGCEligible(customThreshold)
+	if err != nil {
+		return err
+	}
+
+	// Fast-path the nothing case
+	if len(gcDeployment) == 0 {
+		return nil
+	}
+	c.logger.Debug("deployment GC found eligible deployments", "deployments", len(gcDeployment))
+	return c.deploymentReap(gcDeployment)
+}
+
+// deploymentGCEligible returns the IDs of the deployments eligible for garbage
+// collection.
+func (c *CoreScheduler) deploymentGCEligible(customThreshold *time.Duration) ([]string, error) {
 	// Iterate over the deployments
 	ws := memdb.NewWatchSet()
 	iter, err := c.snap.Deployments(ws, state.SortDefault)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var threshold time.Duration
@@ -626,12 +790,7 @@ OUTER:
 		gcDeployment = append(gcDeployment, deploy.ID)
 	}
 
-	// Fast-path the nothing case
-	if len(gcDeployment) == 0 {
-		return nil
-	}
-	c.logger.Debug("deployment GC found eligible deployments", "deployments", len(gcDeployment))
-	return c.deploymentReap(gcDeployment)
+	return gcDeployment, nil
 }
 
 // deploymentReap contacts the leader and issues a reap on the passed
@@ -774,11 +933,26 @@ func (c *CoreScheduler) csiVolumeClaimGC(eval *structs.Evaluation, customThresho
 		return gcClaims(eval.Namespace, volID)
 	}
 
+	vols, err := c.csiVolumeClaimGCEligible(customThreshold)
+	if err != nil {
+		return err
+	}
+	for _, vol := range vols {
+		if err := gcClaims(vol.Namespace, vol.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csiVolumeClaimGCEligible returns the volumes with claims eligible for
+// garbage collection.
+func (c *CoreScheduler) csiVolumeClaimGCEligible(customThreshold *time.Duration) ([]*structs.CSIVolume, error) {
 	ws := memdb.NewWatchSet()
 
 	iter, err := c.snap.CSIVolumes(ws)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var threshold time.Duration
@@ -790,6 +964,7 @@ func (c *CoreScheduler) csiVolumeClaimGC(eval *structs.Evaluation, customThresho
 	}
 	cutoffTime := c.getCutoffTime(threshold)
 
+	var gcVols []*structs.CSIVolume
 	for i := iter.Next(); i != nil; i = iter.Next() {
 		vol := i.(*structs.CSIVolume)
 
@@ -804,28 +979,48 @@ func (c *CoreScheduler) csiVolumeClaimGC(eval *structs.Evaluation, customThresho
 		// out a lot of do-nothing RPCs.
 		vol, err := c.snap.CSIVolumeDenormalize(ws, vol.Copy())
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if len(vol.PastClaims) > 0 {
-			err = gcClaims(vol.Namespace, vol.ID)
-			if err != nil {
-				return err
-			}
+			gcVols = append(gcVols, vol)
 		}
-
 	}
-	return nil
-
+	return gcVols, nil
 }
 
 // csiPluginGC is used to garbage collect unused plugins
 func (c *CoreScheduler) csiPluginGC(eval *structs.Evaluation, customThreshold *time.Duration) error {
+	plugins, err := c.csiPluginGCEligible(customThreshold)
+	if err != nil {
+		return err
+	}
 
+	for _, plugin := range plugins {
+		req := &structs.CSIPluginDeleteRequest{ID: plugin.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    c.srv.Region(),
+				AuthToken: eval.LeaderACL,
+			}}
+		err := c.srv.RPC("CSIPlugin.Delete", req, &structs.CSIPluginDeleteResponse{})
+		if err != nil {
+			if strings.Contains(err.Error(), "plugin in use") {
+				continue
+			}
+			c.logger.Error("failed to GC plugin", "plugin_id", plugin.ID, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// csiPluginGCEligible returns the unused plugins eligible for garbage
+// collection.
+func (c *CoreScheduler) csiPluginGCEligible(customThreshold *time.Duration) ([]*structs.CSIPlugin, error) {
 	ws := memdb.NewWatchSet()
 
 	iter, err := c.snap.CSIPlugins(ws)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var threshold time.Duration
@@ -837,6 +1032,7 @@ func (c *CoreScheduler) csiPluginGC(eval *structs.Evaluation, customThreshold *t
 	}
 	cutoffTime := c.getCutoffTime(threshold)
 
+	var gcPlugins []*structs.CSIPlugin
 	for i := iter.Next(); i != nil; i = iter.Next() {
 		plugin := i.(*structs.CSIPlugin)
 		if !plugin.IsEmpty() {
@@ -849,21 +1045,9 @@ func (c *CoreScheduler) csiPluginGC(eval *structs.Evaluation, customThreshold *t
 			continue
 		}
 
-		req := &structs.CSIPluginDeleteRequest{ID: plugin.ID,
-			QueryOptions: structs.QueryOptions{
-				Region:    c.srv.Region(),
-				AuthToken: eval.LeaderACL,
-			}}
-		err := c.srv.RPC("CSIPlugin.Delete", req, &structs.CSIPluginDeleteResponse{})
-		if err != nil {
-			if strings.Contains(err.Error(), "plugin in use") {
-				continue
-			}
-			c.logger.Error("failed to GC plugin", "plugin_id", plugin.ID, "error", err)
-			return err
-		}
+		gcPlugins = append(gcPlugins, plugin)
 	}
-	return nil
+	return gcPlugins, nil
 }
 
 func (c *CoreScheduler) expiredOneTimeTokenGC(eval *structs.Evaluation) error {
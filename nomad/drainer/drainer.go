@@ -270,7 +270,8 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 	event := structs.NewNodeEvent().
 		SetSubsystem(structs.NodeEventSubsystemDrain).
 		SetMessage(NodeDrainEventComplete).
-		AddDetail(NodeDrainEventDetailDeadlined, "true")
+		AddDetail(NodeDrainEventDetailDeadlined, "true").
+		SetActor(structs.NodeEventActorDrainer)
 
 	// Submit the node transitions in a sharded form to ensure a reasonable
 	// Raft transaction size.
@@ -344,7 +345,8 @@ func (n *NodeDrainer) handleMigratedAllocs(allocs []*structs.Allocation) {
 	// Create the node event
 	event := structs.NewNodeEvent().
 		SetSubsystem(structs.NodeEventSubsystemDrain).
-		SetMessage(NodeDrainEventComplete)
+		SetMessage(NodeDrainEventComplete).
+		SetActor(structs.NodeEventActorDrainer)
 
 	// Submit the node transitions in a sharded form to ensure a reasonable
 	// Raft transaction size.
@@ -102,7 +102,8 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 		// Create the node event
 		event := structs.NewNodeEvent().
 			SetSubsystem(structs.NodeEventSubsystemDrain).
-			SetMessage(NodeDrainEventComplete)
+			SetMessage(NodeDrainEventComplete).
+			SetActor(structs.NodeEventActorDrainer)
 
 		index, err := n.raft.NodesDrainComplete([]string{node.ID}, event)
 		if err != nil {
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-bexpr"
@@ -59,6 +61,7 @@ const (
 	JobSubmissionSnapshot                SnapshotType = 29
 	RootKeySnapshot                      SnapshotType = 30
 	HostVolumeSnapshot                   SnapshotType = 31
+	MaintenanceWindowSnapshot            SnapshotType = 32
 
 	// TimeTableSnapshot
 	// Deprecated: Nomad no longer supports TimeTable snapshots since 1.9.2
@@ -110,6 +113,7 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	JobSubmissionSnapshot:                "JobSubmission",
 	RootKeySnapshot:                      "WrappedRootKeys",
 	HostVolumeSnapshot:                   "HostVolumeSnapshot",
+	MaintenanceWindowSnapshot:            "MaintenanceWindow",
 	NamespaceSnapshot:                    "Namespace",
 }
 
@@ -152,6 +156,11 @@ type nomadFSM struct {
 	// new state store). Everything internal here is synchronized by the
 	// Raft side, so doesn't need to lock this.
 	stateLock sync.RWMutex
+
+	// lastAppliedAt is the time, in Unix nanoseconds, the last log applied was
+	// appended by the leader. It is reported to autopilot so the leader can
+	// tell how far behind its own state a server's state is.
+	lastAppliedAt atomic.Int64
 }
 
 // nomadSnapshot is used to provide a snapshot of the current
@@ -196,17 +205,22 @@ type FSMConfig struct {
 
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
+
+	// JobMaxVersionsPerJob is the number of untagged versions of a job that
+	// are kept when it does not set a version retention.
+	JobMaxVersionsPerJob int
 }
 
 // NewFSM is used to construct a new FSM with a blank state.
 func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 	// Create a state store
 	sconfig := &state.StateStoreConfig{
-		Logger:             config.Logger,
-		Region:             config.Region,
-		EnablePublisher:    config.EnableEventBroker,
-		EventBufferSize:    config.EventBufferSize,
-		JobTrackedVersions: config.JobTrackedVersions,
+		Logger:               config.Logger,
+		Region:               config.Region,
+		EnablePublisher:      config.EnableEventBroker,
+		EventBufferSize:      config.EventBufferSize,
+		JobTrackedVersions:   config.JobTrackedVersions,
+		JobMaxVersionsPerJob: config.JobMaxVersionsPerJob,
 	}
 	state, err := state.NewStateStore(sconfig)
 	if err != nil {
@@ -247,7 +261,21 @@ func (n *nomadFSM) State() *state.StateStore {
 	return n.state
 }
 
+// LastAppliedAt returns the time the last log applied was appended by the
+// leader, or the zero time if no log with an append time has been applied.
+func (n *nomadFSM) LastAppliedAt() time.Time {
+	nanos := n.lastAppliedAt.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
 func (n *nomadFSM) Apply(log *raft.Log) interface{} {
+	if !log.AppendedAt.IsZero() {
+		n.lastAppliedAt.Store(log.AppendedAt.UnixNano())
+	}
+
 	buf := log.Data
 	msgType := structs.MessageType(buf[0])
 
@@ -397,6 +425,10 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyHostVolumeDelete(msgType, buf[1:], log.Index)
 	case structs.TaskGroupHostVolumeClaimDeleteRequestType:
 		return n.applyTaskGroupHostVolumeClaimDelete(buf[1:], log.Index)
+	case structs.MaintenanceWindowUpsertRequestType:
+		return n.applyMaintenanceWindowUpsert(msgType, buf[1:], log.Index)
+	case structs.MaintenanceWindowDeleteRequestType:
+		return n.applyMaintenanceWindowDelete(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -441,6 +473,14 @@ func (n *nomadFSM) applyUpsertNode(reqType structs.MessageType, buf []byte, inde
 	// Handle upgrade paths
 	req.Node.Canonicalize()
 
+	// Lookup the existing node so that changes to its metadata, such as
+	// dynamic metadata applied to the client, can be detected
+	existing, err := n.state.NodeByID(nil, req.Node.ID)
+	if err != nil {
+		n.logger.Error("UpsertNode failed to lookup node", "node_id", req.Node.ID, "error", err)
+		return err
+	}
+
 	// Upsert node.
 	var opts []state.NodeUpsertOption
 	if req.CreateNodePool {
@@ -456,6 +496,14 @@ func (n *nomadFSM) applyUpsertNode(reqType structs.MessageType, buf []byte, inde
 	// state.
 	if req.Node.Status == structs.NodeStatusReady {
 		n.blockedEvals.Unblock(req.Node.ComputedClass, index)
+
+		// Changes to unique metadata keys, such as dynamic node metadata
+		// applied to the client, do not change the computed class of the
+		// node, so also unblock evals blocked on this node, such as those of
+		// system jobs, when its metadata changes.
+		if existing != nil && !maps.Equal(existing.Meta, req.Node.Meta) {
+			n.blockedEvals.UnblockNode(req.Node.ID, index)
+		}
 	}
 
 	return nil
@@ -1522,11 +1570,12 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 
 	// Create a new state store
 	config := &state.StateStoreConfig{
-		Logger:             n.config.Logger,
-		Region:             n.config.Region,
-		EnablePublisher:    n.config.EnableEventBroker,
-		EventBufferSize:    n.config.EventBufferSize,
-		JobTrackedVersions: n.config.JobTrackedVersions,
+		Logger:               n.config.Logger,
+		Region:               n.config.Region,
+		EnablePublisher:      n.config.EnableEventBroker,
+		EventBufferSize:      n.config.EventBufferSize,
+		JobTrackedVersions:   n.config.JobTrackedVersions,
+		JobMaxVersionsPerJob: n.config.JobMaxVersionsPerJob,
 	}
 	newState, err := state.NewStateStore(config)
 	if err != nil {
@@ -1944,6 +1993,15 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				}
 			}
 
+		case MaintenanceWindowSnapshot:
+			window := new(structs.MaintenanceWindow)
+			if err := dec.Decode(window); err != nil {
+				return err
+			}
+			if err := restore.MaintenanceWindowRestore(window); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2442,6 +2500,36 @@ func (n *nomadFSM) applyHostVolumeDelete(msgType structs.MessageType, buf []byte
 	return nil
 }
 
+func (n *nomadFSM) applyMaintenanceWindowUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_maintenance_window_upsert"}, time.Now())
+
+	var req structs.MaintenanceWindowUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertMaintenanceWindows(msgType, index, req.MaintenanceWindows); err != nil {
+		n.logger.Error("UpsertMaintenanceWindows failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (n *nomadFSM) applyMaintenanceWindowDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_maintenance_window_delete"}, time.Now())
+
+	var req structs.MaintenanceWindowDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteMaintenanceWindows(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteMaintenanceWindows failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) applyTaskGroupHostVolumeClaimDelete(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_task_group_host_volume_claim_delete"}, time.Now())
 
@@ -2586,6 +2674,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistMaintenanceWindows(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -3294,6 +3386,22 @@ func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink, encoder *code
 	return nil
 }
 
+func (s *nomadSnapshot) persistMaintenanceWindows(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.MaintenanceWindows(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		window := raw.(*structs.MaintenanceWindow)
+
+		sink.Write([]byte{byte(MaintenanceWindowSnapshot)})
+		if err := encoder.Encode(window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
@@ -182,6 +182,64 @@ func TestFSM_UpsertNode(t *testing.T) {
 
 }
 
+func TestFSM_UpsertNode_MetaChange(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	fsm.blockedEvals.SetEnabled(true)
+
+	node := mock.Node()
+	must.NoError(t, node.ComputeClass())
+	must.NoError(t, fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Block a service eval on the node's class, and a system eval on the node
+	// itself, both of which found the node's class ineligible
+	serviceEval := mock.Eval()
+	serviceEval.ClassEligibility = map[string]bool{node.ComputedClass: false}
+	fsm.blockedEvals.Block(serviceEval)
+
+	systemEval := mock.Eval()
+	systemEval.Type = structs.JobTypeSystem
+	systemEval.NodeID = node.ID
+	systemEval.ClassEligibility = map[string]bool{node.ComputedClass: false}
+	fsm.blockedEvals.Block(systemEval)
+
+	must.Eq(t, 2, fsm.blockedEvals.Stats().TotalBlocked)
+
+	upsert := func(t *testing.T, node *structs.Node) {
+		t.Helper()
+		must.NoError(t, node.ComputeClass())
+		buf, err := structs.Encode(structs.NodeRegisterRequestType, structs.NodeRegisterRequest{Node: node})
+		must.NoError(t, err)
+		must.Nil(t, fsm.Apply(makeLog(buf)))
+	}
+
+	waitForBlocked := func(t *testing.T, expected int) {
+		t.Helper()
+		testutil.WaitForResult(func() (bool, error) {
+			if blocked := fsm.blockedEvals.Stats().TotalBlocked; blocked != expected {
+				return false, fmt.Errorf("expected %d blocked evals, got %d", expected, blocked)
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatalf("err: %s", err)
+		})
+	}
+
+	// Changing unique metadata does not change the class of the node, so only
+	// the eval blocked on the node is unblocked
+	node = node.Copy()
+	node.Meta["unique.firmware"] = "2.4.0"
+	upsert(t, node)
+	waitForBlocked(t, 1)
+
+	// Changing other metadata changes the class of the node, which unblocks
+	// the eval blocked on its previous class
+	node = node.Copy()
+	node.Meta["firmware"] = "2.4.0"
+	upsert(t, node)
+	waitForBlocked(t, 0)
+}
+
 func TestFSM_UpsertNode_Canonicalize(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -751,6 +809,51 @@ func TestFSM_NodePoolUpsert(t *testing.T) {
 	must.NotEq(t, preTTLNodePool.Hash, preTTLNodePoolResp.Hash)
 }
 
+func TestFSM_MaintenanceWindowUpsertDelete(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	windows := []*structs.MaintenanceWindow{
+		mock.MaintenanceWindow(),
+		mock.MaintenanceWindow(),
+	}
+
+	// Create the maintenance windows.
+	upsertReq := structs.MaintenanceWindowUpsertRequest{
+		MaintenanceWindows: windows,
+	}
+	buf, err := structs.Encode(structs.MaintenanceWindowUpsertRequestType, upsertReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	ws := memdb.NewWatchSet()
+	for _, window := range windows {
+		got, err := fsm.State().MaintenanceWindowByName(ws, window.Name)
+		must.NoError(t, err)
+		must.Eq(t, window, got, must.Cmp(cmpopts.IgnoreFields(
+			structs.MaintenanceWindow{},
+			"CreateIndex",
+			"ModifyIndex",
+		)))
+	}
+
+	// Delete one of the maintenance windows.
+	deleteReq := structs.MaintenanceWindowDeleteRequest{
+		Names: []string{windows[0].Name},
+	}
+	buf, err = structs.Encode(structs.MaintenanceWindowDeleteRequestType, deleteReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	got, err := fsm.State().MaintenanceWindowByName(ws, windows[0].Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	got, err = fsm.State().MaintenanceWindowByName(ws, windows[1].Name)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+}
+
 func TestFSM_RegisterJob(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -2343,6 +2446,27 @@ func TestFSM_SnapshotRestore_NodePoolsPreTTL(t *testing.T) {
 	must.NotEq(t, pool.Hash, out.Hash)
 }
 
+func TestFSM_SnapshotRestore_MaintenanceWindows(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state
+	testFSM := testFSM(t)
+	testState := testFSM.State()
+	window := mock.MaintenanceWindow()
+	must.NoError(t,
+		testState.UpsertMaintenanceWindows(
+			structs.MsgTypeTestSetup,
+			1000, []*structs.MaintenanceWindow{window},
+		))
+
+	// Verify the contents
+	testFSM2 := testSnapshotRestore(t, testFSM)
+	testState2 := testFSM2.State()
+	out, err := testState2.MaintenanceWindowByName(nil, window.Name)
+	must.NoError(t, err)
+	must.Eq(t, window, out)
+}
+
 func TestFSM_SnapshotRestore_Jobs(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
@@ -159,9 +159,12 @@ func (h *nodeHeartbeater) invalidateHeartbeat(id string) {
 
 	// Make a request to update the node status
 	req := structs.NodeUpdateStatusRequest{
-		NodeID:    id,
-		Status:    structs.NodeStatusDown,
-		NodeEvent: structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster).SetMessage(NodeHeartbeatEventMissed),
+		NodeID: id,
+		Status: structs.NodeStatusDown,
+		NodeEvent: structs.NewNodeEvent().
+			SetSubsystem(structs.NodeEventSubsystemCluster).
+			SetMessage(NodeHeartbeatEventMissed).
+			SetActor(structs.NodeEventActorHeartbeat),
 		WriteRequest: structs.WriteRequest{
 			Region:    h.srv.config.Region,
 			AuthToken: h.srv.getLeaderAcl(),
@@ -201,6 +204,14 @@ func (h *nodeHeartbeater) disconnectState(id string) (bool, bool) {
 	}
 
 	now := time.Now().UTC()
+
+	// Nodes in a maintenance window have their allocs' lost_after extended.
+	grace, err := maintenanceWindowGrace(h.srv.State(), node, now)
+	if err != nil {
+		h.logger.Error("error retrieving maintenance windows", "error", err)
+		return false, false
+	}
+
 	// Check if the node has any allocs that are configured with max_client_disconnect,
 	// that are past the disconnect window, and if so, whether it has at least one
 	// alloc that isn't yet expired.
@@ -216,7 +227,7 @@ func (h *nodeHeartbeater) disconnectState(id string) (bool, bool) {
 		// yet expired.
 		if allocCanDisconnect &&
 			alloc.DesiredStatus == structs.AllocDesiredStatusRun &&
-			!alloc.Expired(now) {
+			!alloc.ExpiredWithGrace(now, grace) {
 			return true, true
 		}
 	}
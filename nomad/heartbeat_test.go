@@ -308,6 +308,7 @@ func TestHeartbeat_InvalidateHeartbeat_DisconnectedClient(t *testing.T) {
 		name                  string
 		now                   time.Time
 		lostAfterOnDisconnect time.Duration
+		maintenanceGrace      time.Duration
 		expectedNodeStatus    string
 	}{
 		{
@@ -328,6 +329,13 @@ func TestHeartbeat_InvalidateHeartbeat_DisconnectedClient(t *testing.T) {
 			now:                   time.Now().UTC().Add(-5 * time.Second),
 			expectedNodeStatus:    structs.NodeStatusDown,
 		},
+		{
+			name:                  "has-reconnects-in-maintenance-window",
+			lostAfterOnDisconnect: 5 * time.Second,
+			maintenanceGrace:      time.Minute,
+			now:                   time.Now().UTC().Add(-10 * time.Second),
+			expectedNodeStatus:    structs.NodeStatusDisconnected,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -355,6 +363,14 @@ func TestHeartbeat_InvalidateHeartbeat_DisconnectedClient(t *testing.T) {
 
 			must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 2, []*structs.Allocation{alloc}))
 
+			if tc.maintenanceGrace > 0 {
+				window := mock.MaintenanceWindow()
+				window.Datacenter = node.Datacenter
+				window.Grace = tc.maintenanceGrace
+				must.NoError(t, state.UpsertMaintenanceWindows(structs.MsgTypeTestSetup, 3,
+					[]*structs.MaintenanceWindow{window}))
+			}
+
 			// Trigger status update
 			s1.invalidateHeartbeat(node.ID)
 			out, err := state.NodeByID(nil, node.ID)
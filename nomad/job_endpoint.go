@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"sort"
 	"strings"
@@ -21,12 +22,14 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/tracing"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler"
 	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -95,7 +98,13 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 }
 
 // Register is used to upsert a job for scheduling
-func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) (err error) {
+	// Trace the registration, as a child of the span of the request if any,
+	// and forward the span along with the request
+	ctx, span := j.srv.startSpan(args.TraceParent, "Job.Register", jobRegisterSpanAttributes(args))
+	defer func() { endSpan(span, err) }()
+	args.TraceParent = tracing.TraceParent(ctx)
+
 	authErr := j.srv.Authenticate(j.ctx, args)
 	if done, err := j.srv.forward("Job.Register", args, args, reply); done {
 		return err
@@ -325,8 +334,10 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 			Status:      structs.EvalStatusPending,
 			CreateTime:  now,
 			ModifyTime:  now,
+			TraceParent: args.TraceParent,
 		}
 		reply.EvalID = eval.ID
+		span.SetAttributes(attribute.String("nomad.eval_id", eval.ID))
 	}
 
 	// Check if the job has changed at all
@@ -985,74 +996,108 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		return structs.NewErrRPCCoded(404, fmt.Sprintf("job %q not found", args.JobID))
 	}
 
-	if job.Type == structs.JobTypeSystem && *args.Count > 1 {
-		return structs.NewErrRPCCoded(http.StatusBadRequest, `jobs of type "system" can only be scaled between 0 and 1`)
+	// Find the target groups and their new counts
+	groupNames := args.GroupNames()
+	counts := args.Counts
+	if args.Count != nil {
+		counts = map[string]int64{groupNames[0]: *args.Count}
+	}
+
+	if job.Type == structs.JobTypeSystem {
+		for _, count := range counts {
+			if count > 1 {
+				return structs.NewErrRPCCoded(http.StatusBadRequest, `jobs of type "system" can only be scaled between 0 and 1`)
+			}
+		}
 	}
 
 	// Since job is going to be mutated we must copy it since state store methods
 	// return a shared pointer.
 	job = job.Copy()
 
-	// Find target group in job TaskGroups
-	groupName := args.Target[structs.ScalingTargetGroup]
-	var group *structs.TaskGroup
-	for _, tg := range job.TaskGroups {
-		if tg.Name == groupName {
-			group = tg
-			break
+	// Find target groups in job TaskGroups, reporting every missing group
+	groups := make(map[string]*structs.TaskGroup, len(groupNames))
+	var groupErrs []string
+	for _, groupName := range groupNames {
+		group := job.LookupTaskGroup(groupName)
+		if group == nil {
+			groupErrs = append(groupErrs,
+				fmt.Sprintf("task group %q specified for scaling does not exist in job", groupName))
+			continue
 		}
+		if _, ok := counts[groupName]; ok && group.ReplicatePer != "" {
+			groupErrs = append(groupErrs,
+				fmt.Sprintf("task group %q count is computed from replicate_per and cannot be scaled", groupName))
+			continue
+		}
+		groups[groupName] = group
 	}
-
-	if group == nil {
-		return structs.NewErrRPCCoded(400,
-			fmt.Sprintf("task group %q specified for scaling does not exist in job", groupName))
+	if len(groupErrs) > 0 {
+		return structs.NewErrRPCCoded(400, strings.Join(groupErrs, "; "))
 	}
 
 	now := time.Now().UnixNano()
-	prevCount := int64(group.Count)
 
-	event := &structs.ScalingEventRequest{
-		Namespace: job.Namespace,
-		JobID:     job.ID,
-		TaskGroup: groupName,
-		ScalingEvent: &structs.ScalingEvent{
+	// The scaling events of every group are recorded at once
+	events := make(map[string]*structs.ScalingEvent, len(groupNames))
+	for _, groupName := range groupNames {
+		event := &structs.ScalingEvent{
 			Time:          now,
-			PreviousCount: prevCount,
-			Count:         args.Count,
+			PreviousCount: int64(groups[groupName].Count),
 			Message:       args.Message,
 			Error:         args.Error,
 			Meta:          args.Meta,
-		},
+		}
+		if count, ok := counts[groupName]; ok {
+			event.Count = pointer.Of(count)
+		}
+		if len(args.Counts) > 0 {
+			event.Groups = maps.Clone(args.Counts)
+		}
+		events[groupName] = event
 	}
 
-	if args.Count != nil {
+	if len(counts) > 0 {
 		// Further validation for count-based scaling event
-		if group.Scaling != nil {
-			if *args.Count < group.Scaling.Min {
-				return structs.NewErrRPCCoded(400,
-					fmt.Sprintf("group count was less than scaling policy minimum: %d < %d",
-						*args.Count, group.Scaling.Min))
+		for _, groupName := range groupNames {
+			group := groups[groupName]
+			count := counts[groupName]
+
+			prefix := ""
+			if len(groupNames) > 1 {
+				prefix = fmt.Sprintf("task group %q: ", groupName)
 			}
-			if group.Scaling.Max < *args.Count {
-				return structs.NewErrRPCCoded(400,
-					fmt.Sprintf("group count was greater than scaling policy maximum: %d > %d",
-						*args.Count, group.Scaling.Max))
+			if group.Scaling != nil {
+				if count < group.Scaling.Min {
+					groupErrs = append(groupErrs,
+						fmt.Sprintf("%sgroup count was less than scaling policy minimum: %d < %d",
+							prefix, count, group.Scaling.Min))
+				}
+				if group.Scaling.Max < count {
+					groupErrs = append(groupErrs,
+						fmt.Sprintf("%sgroup count was greater than scaling policy maximum: %d > %d",
+							prefix, count, group.Scaling.Max))
+				}
 			}
 		}
+		if len(groupErrs) > 0 {
+			return structs.NewErrRPCCoded(400, strings.Join(groupErrs, "; "))
+		}
+
+		// Update group counts
+		for groupName, count := range counts {
+			groups[groupName].Count = int(count)
+		}
 
 		// Ensure that JobMaxCount is respected.
-		newCount := int(*args.Count)
 		totalCount := 0
 		for _, tg := range job.TaskGroups {
 			totalCount += tg.Count
 		}
-		totalCount = totalCount - group.Count + newCount
 		if j.srv.config.JobMaxCount > 0 && totalCount > j.srv.config.JobMaxCount {
 			return fmt.Errorf("total count was greater than configured job_max_count: %d > %d", totalCount, j.srv.config.JobMaxCount)
 		}
 
-		// Update group count
-		group.Count = newCount
 		job.SubmitTime = now
 
 		// Block scaling event if there's an active deployment
@@ -1074,11 +1119,29 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 			}
 		}
 
-		// Commit the job update
+		// Create an eval for non-dispatch jobs, which is committed along
+		// with the job update; its job modify index is set by the FSM
+		var eval *structs.Evaluation
+		if !(job.IsPeriodic() || job.IsParameterized()) {
+			eval = &structs.Evaluation{
+				ID:          uuid.Generate(),
+				Namespace:   namespace,
+				Priority:    job.Priority, // Safe as nil check performed above.
+				Type:        job.Type,
+				TriggeredBy: structs.EvalTriggerScaling,
+				JobID:       args.JobID,
+				Status:      structs.EvalStatusPending,
+				CreateTime:  now,
+				ModifyTime:  now,
+			}
+		}
+
+		// Commit the job update of every group and its eval at once
 		_, jobModifyIndex, err := j.srv.raftApply(
 			structs.JobRegisterRequestType,
 			structs.JobRegisterRequest{
 				Job:            job,
+				Eval:           eval,
 				EnforceIndex:   true,
 				JobModifyIndex: job.ModifyIndex,
 				PolicyOverride: args.PolicyOverride,
@@ -1091,47 +1154,34 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		}
 		reply.JobModifyIndex = jobModifyIndex
 
-		// Create an eval for non-dispatch jobs
-		if !(job.IsPeriodic() || job.IsParameterized()) {
-			eval := &structs.Evaluation{
-				ID:             uuid.Generate(),
-				Namespace:      namespace,
-				Priority:       job.Priority, // Safe as nil check performed above.
-				Type:           job.Type,
-				TriggeredBy:    structs.EvalTriggerScaling,
-				JobID:          args.JobID,
-				JobModifyIndex: reply.JobModifyIndex,
-				Status:         structs.EvalStatusPending,
-				CreateTime:     now,
-				ModifyTime:     now,
-			}
-
-			_, evalIndex, err := j.srv.raftApply(
-				structs.EvalUpdateRequestType,
-				&structs.EvalUpdateRequest{
-					Evals:        []*structs.Evaluation{eval},
-					WriteRequest: structs.WriteRequest{Region: args.Region},
-				},
-			)
-			if err != nil {
-				j.logger.Error("eval create failed", "error", err, "method", "scale")
-				return err
-			}
-
+		if eval != nil {
 			reply.EvalID = eval.ID
-			reply.EvalCreateIndex = evalIndex
-			event.ScalingEvent.EvalID = &reply.EvalID
+			reply.EvalCreateIndex = jobModifyIndex
+			for _, event := range events {
+				event.EvalID = &reply.EvalID
+			}
 		}
 	} else {
 		reply.JobModifyIndex = job.ModifyIndex
 	}
 
-	_, eventIndex, err := j.srv.raftApply(structs.ScalingEventRegisterRequestType, event)
+	eventReq := &structs.ScalingEventRequest{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+	}
+	if len(events) == 1 {
+		// a single group is recorded as before, so that servers which do not
+		// know of ScalingEvents record it too
+		eventReq.TaskGroup = groupNames[0]
+		eventReq.ScalingEvent = events[groupNames[0]]
+	} else {
+		eventReq.ScalingEvents = events
+	}
+	_, eventIndex, err := j.srv.raftApply(structs.ScalingEventRegisterRequestType, eventReq)
 	if err != nil {
 		j.logger.Error("scaling event create failed", "error", err)
 		return err
 	}
-
 	reply.Index = eventIndex
 
 	j.srv.setQueryMeta(&reply.QueryMeta)
@@ -1931,7 +1981,7 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 
 	// If it is a periodic job calculate the next launch
 	if args.Job.IsPeriodic() && args.Job.Periodic.Enabled {
-		reply.NextPeriodicLaunch, err = args.Job.Periodic.Next(time.Now().In(args.Job.Periodic.GetLocation()))
+		reply.NextPeriodicLaunch, err = j.srv.periodicDispatcher.launchAfter(args.Job, time.Now().In(args.Job.Periodic.GetLocation()))
 		if err != nil {
 			return fmt.Errorf("Failed to parse cron expression: %v", err)
 		}
@@ -1940,11 +1990,48 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	reply.FailedTGAllocs = updatedEval.FailedTGAllocs
 	reply.JobModifyIndex = index
 	reply.Annotations = annotations
+	reply.ChangeClass = planChangeClass(existingJob, args.Job, annotations)
 	reply.CreatedEvals = planner.CreateEvals
 	reply.Index = index
 	return nil
 }
 
+// planChangeClass returns the change class of the plan of job, given the
+// currently registered version of the job, if any, and the annotations of the
+// scheduler. Plans which replace allocations or stop the allocations of
+// removed task groups are destructive, whatever else they change.
+func planChangeClass(existing, job *structs.Job, annotations *structs.PlanAnnotations) string {
+	if annotations != nil {
+		for _, updates := range annotations.DesiredTGUpdates {
+			if updates.DestructiveUpdate > 0 || updates.Canary > 0 {
+				return structs.PlanChangeClassDestructive
+			}
+		}
+	}
+
+	if existing == nil {
+		return structs.PlanChangeClassInPlace
+	}
+	if !existing.SpecChanged(job) {
+		return structs.PlanChangeClassNone
+	}
+
+	// Compare the jobs with the counts of the existing task groups, so that
+	// only the changes other than scaling remain
+	scaled := job.Copy()
+	for _, tg := range existing.TaskGroups {
+		updated := scaled.LookupTaskGroup(tg.Name)
+		if updated == nil {
+			return structs.PlanChangeClassDestructive
+		}
+		updated.Count = tg.Count
+	}
+	if !existing.SpecChanged(scaled) {
+		return structs.PlanChangeClassScaleOnly
+	}
+	return structs.PlanChangeClassInPlace
+}
+
 // validateJobUpdate ensures updates to a job are valid.
 func validateJobUpdate(old, new *structs.Job) error {
 	// Validate Dispatch not set on new Jobs
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -31,6 +32,21 @@ func (jobConsulHook) validateTaskPartitionMatchesGroup(groupPartition string, ta
 	return nil
 }
 
+// validateServicePartitionMatches validates that any partition set for the
+// service.Consul matches the partition of the group or task registering it.
+// Services are registered with the local Consul agent, which belongs to a
+// single admin partition.
+func (jobConsulHook) validateServicePartitionMatches(partition string, service *structs.Service) error {
+	if service.Consul == nil || service.Consul.Partition == "" || partition == "" {
+		return nil
+	}
+	if service.Consul.Partition != partition {
+		return fmt.Errorf("service.consul.partition %q must match the group or task consul.partition %q if both are set",
+			service.Consul.Partition, partition)
+	}
+	return nil
+}
+
 // mutateImpl ensures that the job's Consul blocks have been configured with the
 // correct Consul cluster if unset, and sets constraints on the Consul admin
 // partition if set. This should be called by the Mutate method.
@@ -50,6 +66,7 @@ func (jobConsulHook) mutateImpl(job *structs.Job, defaultCluster string) *struct
 			if service.IsConsul() && service.Cluster == "" {
 				service.Cluster = defaultCluster
 			}
+			group.Constraints = appendServiceConsulConstraints(group.Constraints, service)
 		}
 
 		for _, task := range group.Tasks {
@@ -66,6 +83,7 @@ func (jobConsulHook) mutateImpl(job *structs.Job, defaultCluster string) *struct
 				if service.IsConsul() && service.Cluster == "" {
 					service.Cluster = defaultCluster
 				}
+				task.Constraints = appendServiceConsulConstraints(task.Constraints, service)
 			}
 		}
 	}
@@ -73,6 +91,44 @@ func (jobConsulHook) mutateImpl(job *structs.Job, defaultCluster string) *struct
 	return job
 }
 
+// appendServiceConsulConstraints adds the constraints required by the
+// service's consul block, if any, to constraints: the service's Consul admin
+// partition, and Consul namespaces support if the service sets a namespace.
+func appendServiceConsulConstraints(constraints []*structs.Constraint, service *structs.Service) []*structs.Constraint {
+	if !service.IsConsul() || service.Consul == nil {
+		return constraints
+	}
+
+	var required []*structs.Constraint
+	if service.Consul.Partition != "" {
+		required = append(required, newConsulPartitionConstraint(service.Cluster, service.Consul.Partition))
+	}
+	if service.Consul.Namespace != "" {
+		required = append(required, newConsulNamespacesConstraint(service.Cluster))
+	}
+
+	for _, c := range required {
+		if !slices.ContainsFunc(constraints, c.Equal) {
+			constraints = append(constraints, c)
+		}
+	}
+	return constraints
+}
+
+// newConsulNamespacesConstraint produces a constraint that the Consul cluster
+// supports namespaces, based on the cluster name.
+func newConsulNamespacesConstraint(cluster string) *structs.Constraint {
+	ltarget := "${attr.consul.ft.namespaces}"
+	if cluster != structs.ConsulDefaultCluster && cluster != "" {
+		ltarget = "${attr.consul." + cluster + ".ft.namespaces}"
+	}
+	return &structs.Constraint{
+		LTarget: ltarget,
+		RTarget: "true",
+		Operand: "=",
+	}
+}
+
 // newConsulPartitionConstraint produces a constraint on the Consul admin
 // partition, based on the cluster name. In Nomad CE this will always be in the
 // default cluster.
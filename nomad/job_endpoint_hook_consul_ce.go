@@ -30,15 +30,26 @@ func (h jobConsulHook) Validate(job *structs.Job) ([]error, error) {
 				if err := h.validateCluster(service.Cluster); err != nil {
 					return nil, err
 				}
+				if err := h.validateServiceConsul(groupPartition, service); err != nil {
+					return nil, err
+				}
 			}
 		}
 
 		for _, task := range group.Tasks {
+			taskPartition := groupPartition
+			if task.Consul != nil && task.Consul.Partition != "" {
+				taskPartition = task.Consul.Partition
+			}
+
 			for _, service := range task.Services {
 				if service.Provider == structs.ServiceProviderConsul {
 					if err := h.validateCluster(service.Cluster); err != nil {
 						return nil, err
 					}
+					if err := h.validateServiceConsul(taskPartition, service); err != nil {
+						return nil, err
+					}
 				}
 			}
 
@@ -65,6 +76,18 @@ func (h jobConsulHook) validateCluster(name string) error {
 	return nil
 }
 
+// validateServiceConsul validates the consul block of a service registered in
+// a group or task using the given Consul admin partition.
+func (h jobConsulHook) validateServiceConsul(partition string, service *structs.Service) error {
+	if service.Consul == nil {
+		return nil
+	}
+	if service.Consul.Namespace != "" {
+		return errors.New("service.consul.namespace requires Nomad Enterprise")
+	}
+	return h.validateServicePartitionMatches(partition, service)
+}
+
 // Mutate ensures that the job's Consul cluster has been configured to be the
 // default Consul cluster if unset
 func (h jobConsulHook) Mutate(job *structs.Job) (*structs.Job, []error, error) {
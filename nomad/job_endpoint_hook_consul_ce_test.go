@@ -61,3 +61,72 @@ func TestJobEndpointHook_ConsulCE(t *testing.T) {
 	_, err = hook.Validate(job)
 	must.EqError(t, err, "non-default Consul cluster requires Nomad Enterprise")
 }
+
+func TestJobEndpointHook_ConsulCE_ServiceConsul(t *testing.T) {
+	ci.Parallel(t)
+
+	hook := jobConsulHook{}
+
+	t.Run("constraints", func(t *testing.T) {
+		job := mock.Job()
+		svc := job.TaskGroups[0].Tasks[0].Services[0].Copy()
+		svc.Provider = structs.ServiceProviderConsul
+		svc.Consul = &structs.Consul{Namespace: "shared", Partition: "foo"}
+		job.TaskGroups[0].Services = []*structs.Service{svc, svc.Copy()}
+
+		_, _, err := hook.Mutate(job)
+		must.NoError(t, err)
+
+		partition := &structs.Constraint{
+			LTarget: "${attr.consul.partition}",
+			RTarget: "foo",
+			Operand: "=",
+		}
+		namespaces := &structs.Constraint{
+			LTarget: "${attr.consul.ft.namespaces}",
+			RTarget: "true",
+			Operand: "=",
+		}
+
+		// constraints are added once for all services of the group
+		count := func(c *structs.Constraint) (n int) {
+			for _, other := range job.TaskGroups[0].Constraints {
+				if c.Equal(other) {
+					n++
+				}
+			}
+			return n
+		}
+		must.Eq(t, 1, count(partition))
+		must.Eq(t, 1, count(namespaces))
+	})
+
+	t.Run("namespace", func(t *testing.T) {
+		job := mock.Job()
+		svc := job.TaskGroups[0].Tasks[0].Services[0]
+		svc.Provider = structs.ServiceProviderConsul
+		svc.Consul = &structs.Consul{Namespace: "shared"}
+
+		_, err := hook.Validate(job)
+		must.EqError(t, err, "service.consul.namespace requires Nomad Enterprise")
+	})
+
+	t.Run("partition", func(t *testing.T) {
+		job := mock.Job()
+		job.TaskGroups[0].Consul = &structs.Consul{
+			Cluster:   structs.ConsulDefaultCluster,
+			Partition: "foo",
+		}
+		svc := job.TaskGroups[0].Tasks[0].Services[0]
+		svc.Provider = structs.ServiceProviderConsul
+		svc.Cluster = structs.ConsulDefaultCluster
+
+		svc.Consul = &structs.Consul{Partition: "foo"}
+		_, err := hook.Validate(job)
+		must.NoError(t, err)
+
+		svc.Consul = &structs.Consul{Partition: "bar"}
+		_, err = hook.Validate(job)
+		must.EqError(t, err, `service.consul.partition "bar" must match the group or task consul.partition "foo" if both are set`)
+	})
+}
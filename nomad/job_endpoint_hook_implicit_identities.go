@@ -139,17 +139,19 @@ func (h jobImplicitIdentitiesHook) handleConsulTask(t *structs.Task, tg *structs
 	t.Identities = append(t.Identities, taskWID)
 }
 
-// handleVault injects a workload identity to the task if:
-//  1. The task has a Vault block.
-//  2. The task does not have an identity for the Vault cluster.
-//  3. The server is configured with a `vault.default_identity`.
+// handleVault injects a workload identity to the task for each of its Vault
+// blocks if:
+//  1. The task does not have an identity for the Vault cluster.
+//  2. The server is configured with a `vault.default_identity`.
 func (h jobImplicitIdentitiesHook) handleVault(t *structs.Task) {
-	if t.Vault == nil {
-		return
+	for _, vault := range t.AllVaults() {
+		h.handleVaultBlock(t, vault)
 	}
+}
 
+func (h jobImplicitIdentitiesHook) handleVaultBlock(t *structs.Task, vault *structs.Vault) {
 	// Use the Vault identity specified in the task.
-	vaultWIDName := t.Vault.IdentityName()
+	vaultWIDName := vault.IdentityName()
 	vaultWID := t.GetIdentity(vaultWIDName)
 	if vaultWID != nil {
 		return
@@ -157,7 +159,7 @@ func (h jobImplicitIdentitiesHook) handleVault(t *structs.Task) {
 
 	// If the task doesn't specify an identity for Vault, fallback to the
 	// default identity defined in the server configuration.
-	vaultWID = h.srv.config.VaultIdentityConfig(t.GetVaultClusterName())
+	vaultWID = h.srv.config.VaultIdentityConfig(vault.ClusterName())
 	if vaultWID == nil {
 		// If no identity is found skip inject the implicit identity and
 		// fallback to the legacy flow.
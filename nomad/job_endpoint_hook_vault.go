@@ -25,11 +25,13 @@ func (h jobVaultHook) Validate(job *structs.Job) ([]error, error) {
 	}
 
 	for _, tg := range vaultBlocks {
-		for _, vaultBlock := range tg {
-			vconf := h.srv.config.VaultConfigs[vaultBlock.Cluster]
-			if !vconf.IsEnabled() {
-				return nil, fmt.Errorf("Vault %q not enabled but used in the job",
-					vaultBlock.Cluster)
+		for _, taskBlocks := range tg {
+			for _, vaultBlock := range taskBlocks {
+				vconf := h.srv.config.VaultConfigs[vaultBlock.Cluster]
+				if !vconf.IsEnabled() {
+					return nil, fmt.Errorf("Vault %q not enabled but used in the job",
+						vaultBlock.Cluster)
+				}
 			}
 		}
 	}
@@ -14,7 +14,7 @@ import (
 )
 
 // validateNamespaces returns an error if the job contains any Vault namespaces.
-func (jobVaultHook) validateNamespaces(blocks map[string]map[string]*structs.Vault) error {
+func (jobVaultHook) validateNamespaces(blocks map[string]map[string][]*structs.Vault) error {
 
 	requestedNamespaces := structs.VaultNamespaceSet(blocks)
 	if len(requestedNamespaces) > 0 {
@@ -23,11 +23,16 @@ func (jobVaultHook) validateNamespaces(blocks map[string]map[string]*structs.Vau
 	return nil
 }
 
-func (h jobVaultHook) validateClustersForNamespace(_ *structs.Job, blocks map[string]map[string]*structs.Vault) error {
+func (h jobVaultHook) validateClustersForNamespace(_ *structs.Job, blocks map[string]map[string][]*structs.Vault) error {
 	for _, tg := range blocks {
-		for _, vault := range tg {
-			if vault.Cluster != "default" {
-				return errors.New("non-default Vault cluster requires Nomad Enterprise")
+		for _, vaults := range tg {
+			if len(vaults) > 1 {
+				return errors.New("multiple Vault blocks per task require Nomad Enterprise")
+			}
+			for _, vault := range vaults {
+				if vault.Cluster != "default" {
+					return errors.New("non-default Vault cluster requires Nomad Enterprise")
+				}
 			}
 		}
 	}
@@ -38,10 +43,11 @@ func (h jobVaultHook) validateClustersForNamespace(_ *structs.Job, blocks map[st
 func (h jobVaultHook) Mutate(job *structs.Job) (*structs.Job, []error, error) {
 	for _, tg := range job.TaskGroups {
 		for _, task := range tg.Tasks {
-			if task.Vault == nil || task.Vault.Cluster != "" {
-				continue
+			for _, vault := range task.AllVaults() {
+				if vault.Cluster == "" {
+					vault.Cluster = "default"
+				}
 			}
-			task.Vault.Cluster = "default"
 		}
 	}
 
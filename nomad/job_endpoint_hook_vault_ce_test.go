@@ -51,6 +51,18 @@ func TestJobEndpointHook_VaultCE(t *testing.T) {
 	err = hook.validateClustersForNamespace(job, job.Vault())
 	must.EqError(t, err, "non-default Vault cluster requires Nomad Enterprise")
 
+	// additional Vault blocks are assigned to the default cluster if unset
+	// and require Nomad Enterprise
+	job = mock.Job()
+	job.TaskGroups[0].Tasks[0].Vault = &structs.Vault{Cluster: "pki"}
+	job.TaskGroups[0].Tasks[0].Vaults = []*structs.Vault{{}}
+	_, _, err = hook.Mutate(job)
+	must.NoError(t, err)
+	must.Eq(t, structs.VaultDefaultCluster, job.TaskGroups[0].Tasks[0].Vaults[0].Cluster)
+
+	err = hook.validateClustersForNamespace(job, job.Vault())
+	must.EqError(t, err, "multiple Vault blocks per task require Nomad Enterprise")
+
 	job = mock.Job()
 	job.TaskGroups[0].Tasks[0].Vault = &structs.Vault{Cluster: structs.VaultDefaultCluster}
 	warnings, err := hook.Validate(job)
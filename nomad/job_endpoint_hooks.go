@@ -279,6 +279,8 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 
 	taskScheduleTaskGroups := j.RequiredScheduleTask()
 
+	replicatedTaskGroups := j.RequiredReplicatePer()
+
 	secretBlocks := j.Secrets()
 
 	// Hot path where none of our things require constraints.
@@ -289,6 +291,7 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		numaTaskGroups.Empty() && bridgeNetworkingTaskGroups.Empty() &&
 		transparentProxyTaskGroups.Empty() &&
 		taskScheduleTaskGroups.Empty() &&
+		replicatedTaskGroups.Empty() &&
 		len(secretBlocks) == 0 {
 		return j, nil, nil
 	}
@@ -301,8 +304,9 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		vaultTasks := lang.MapKeys(vaultBlocks[tg.Name])
 		sort.Strings(vaultTasks)
 		for _, vaultTask := range vaultTasks {
-			vaultBlock := vaultBlocks[tg.Name][vaultTask]
-			mutateConstraint(constraintMatcherLeft, tg, vaultConstraintFn(vaultBlock))
+			for _, vaultBlock := range vaultBlocks[tg.Name][vaultTask] {
+				mutateConstraint(constraintMatcherLeft, tg, vaultConstraintFn(vaultBlock))
+			}
 		}
 
 		for _, secretProviders := range secretBlocks {
@@ -369,6 +373,12 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		if taskScheduleTaskGroups.Contains(tg.Name) {
 			mutateConstraint(constraintMatcherLeft, tg, taskScheduleConstraint)
 		}
+
+		// If the task group is replicated per node attribute, place at most
+		// one allocation per value of the attribute.
+		if replicatedTaskGroups.Contains(tg.Name) {
+			mutateConstraint(constraintMatcherFull, tg, replicatePerConstraintFn(tg.ReplicatePer))
+		}
 	}
 
 	return j, nil, nil
@@ -402,6 +412,17 @@ func secretsConstraintFn(provider string) *structs.Constraint {
 	}
 }
 
+// replicatePerConstraintFn returns a constraint that places at most one
+// allocation of a task group on nodes sharing the value of the node attribute
+// the task group is replicated per.
+func replicatePerConstraintFn(attribute string) *structs.Constraint {
+	return &structs.Constraint{
+		LTarget: attribute,
+		RTarget: "1",
+		Operand: structs.ConstraintDistinctProperty,
+	}
+}
+
 // consulConstraintFn returns a service discovery constraint that matches the
 // fingerprint of the requested Consul cluster. This is to support Nomad
 // Enterprise but neither the fingerprint or non-default cluster are allowed
@@ -592,23 +613,21 @@ func (v *jobValidate) validateVaultIdentity(t *structs.Task, okForIdentity bool)
 		return warnings, nil
 	}
 
-	vaultWIDName := t.Vault.IdentityName()
-	vaultWID := t.GetIdentity(vaultWIDName)
+	for _, vault := range t.AllVaults() {
+		vaultWIDName := vault.IdentityName()
+		vaultWID := t.GetIdentity(vaultWIDName)
 
-	if vaultWID != nil && !okForIdentity {
-		return warnings, fmt.Errorf("Task %s cannot have an identity for Vault until all servers are upgraded to %s or later", t.Name, minVersionMultiIdentities)
-	}
+		if vaultWID != nil && !okForIdentity {
+			return warnings, fmt.Errorf("Task %s cannot have an identity for Vault until all servers are upgraded to %s or later", t.Name, minVersionMultiIdentities)
+		}
 
-	if vaultWID == nil {
 		// Tasks using non-default clusters are required to have an identity.
-		if t.Vault.Cluster != structs.VaultDefaultCluster {
+		if vaultWID == nil && vault.Cluster != structs.VaultDefaultCluster {
 			return warnings, fmt.Errorf(
 				"Task %s uses Vault cluster %s but does not have an identity named %s and no default identity is provided in agent configuration",
-				t.Name, t.Vault.Cluster, vaultWIDName,
+				t.Name, vault.Cluster, vaultWIDName,
 			)
 		}
-
-		return warnings, nil
 	}
 
 	return warnings, nil
@@ -1308,6 +1308,38 @@ func Test_jobImpliedConstraints_Mutate(t *testing.T) {
 			expectedOutputWarnings: nil,
 			expectedOutputError:    nil,
 		},
+		{
+			name: "task group replicated per node attribute",
+			inputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name:         "group-per-rack",
+						ReplicatePer: "${meta.rack}",
+						Tasks:        []*structs.Task{{Name: "collector"}},
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name:         "group-per-rack",
+						ReplicatePer: "${meta.rack}",
+						Tasks:        []*structs.Task{{Name: "collector"}},
+						Constraints: []*structs.Constraint{
+							{
+								LTarget: "${meta.rack}",
+								RTarget: "1",
+								Operand: structs.ConstraintDistinctProperty,
+							},
+						},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+		},
 		{
 			name: "task with secret",
 			inputJob: &structs.Job{
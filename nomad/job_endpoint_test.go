@@ -6147,6 +6147,95 @@ func TestJobEndpoint_Plan_WithDiff(t *testing.T) {
 	if len(planResp.FailedTGAllocs) == 0 {
 		t.Fatalf("no failed task group alloc metrics")
 	}
+	must.Eq(t, structs.PlanChangeClassNone, planResp.ChangeClass)
+}
+
+func TestJobEndpoint_planChangeClass(t *testing.T) {
+	ci.Parallel(t)
+
+	existing := mock.Job()
+	existing.TaskGroups = append(existing.TaskGroups, existing.TaskGroups[0].Copy())
+	existing.TaskGroups[1].Name = "cache"
+
+	updates := func(u *structs.DesiredUpdates) *structs.PlanAnnotations {
+		return &structs.PlanAnnotations{
+			DesiredTGUpdates: map[string]*structs.DesiredUpdates{"web": u},
+		}
+	}
+
+	cases := []struct {
+		name        string
+		existing    *structs.Job
+		update      func(*structs.Job)
+		annotations *structs.PlanAnnotations
+		exp         string
+	}{
+		{
+			name:   "new job",
+			update: func(*structs.Job) {},
+			exp:    structs.PlanChangeClassInPlace,
+		},
+		{
+			name:     "no changes",
+			existing: existing,
+			update:   func(*structs.Job) {},
+			exp:      structs.PlanChangeClassNone,
+		},
+		{
+			name:     "scale",
+			existing: existing,
+			update: func(j *structs.Job) {
+				j.TaskGroups[0].Count = 20
+				j.TaskGroups[1].Count = 1
+			},
+			annotations: updates(&structs.DesiredUpdates{Place: 10}),
+			exp:         structs.PlanChangeClassScaleOnly,
+		},
+		{
+			name:     "in-place",
+			existing: existing,
+			update: func(j *structs.Job) {
+				j.TaskGroups[0].Count = 20
+				j.TaskGroups[0].Tasks[0].Services[0].Tags = []string{"new"}
+			},
+			annotations: updates(&structs.DesiredUpdates{Place: 10, InPlaceUpdate: 10}),
+			exp:         structs.PlanChangeClassInPlace,
+		},
+		{
+			name:     "destructive",
+			existing: existing,
+			update: func(j *structs.Job) {
+				j.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+			},
+			annotations: updates(&structs.DesiredUpdates{DestructiveUpdate: 10}),
+			exp:         structs.PlanChangeClassDestructive,
+		},
+		{
+			name:     "canary",
+			existing: existing,
+			update: func(j *structs.Job) {
+				j.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+			},
+			annotations: updates(&structs.DesiredUpdates{Canary: 1, Ignore: 10}),
+			exp:         structs.PlanChangeClassDestructive,
+		},
+		{
+			name:     "removed task group",
+			existing: existing,
+			update: func(j *structs.Job) {
+				j.TaskGroups = j.TaskGroups[:1]
+			},
+			exp: structs.PlanChangeClassDestructive,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := existing.Copy()
+			tc.update(job)
+			must.Eq(t, tc.exp, planChangeClass(tc.existing, job, tc.annotations))
+		})
+	}
 }
 
 func TestJobEndpoint_Plan_NoDiff(t *testing.T) {
@@ -7247,13 +7336,101 @@ func TestJobEndpoint_Scale(t *testing.T) {
 	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
 	require.NoError(err)
 	require.NotEmpty(resp.EvalID)
-	require.Greater(resp.EvalCreateIndex, resp.JobModifyIndex)
+	require.Equal(resp.EvalCreateIndex, resp.JobModifyIndex)
 
 	events, _, _ := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
 	require.Equal(1, len(events[groupName]))
 	require.Equal(int64(originalCount), events[groupName][0].PreviousCount)
 }
 
+func TestJobEndpoint_Scale_MultipleGroups(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	job := mock.Job()
+	worker := job.TaskGroups[0].Copy()
+	worker.Name = "worker"
+	job.TaskGroups = append(job.TaskGroups, worker)
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Counts: map[string]int64{
+			"web":     5,
+			"worker":  7,
+			"missing": 1,
+			"other":   1,
+		},
+		Message: "scale web and worker",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// every missing group is reported and nothing is scaled
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	must.ErrorContains(t, err, `task group "missing" specified for scaling does not exist in job`)
+	must.ErrorContains(t, err, `task group "other" specified for scaling does not exist in job`)
+
+	out, err := store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 0, out.Version)
+
+	// both groups are scaled with a single job version and evaluation
+	delete(scale.Counts, "missing")
+	delete(scale.Counts, "other")
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+	must.NotEq(t, "", resp.EvalID)
+
+	// the eval is committed along with the job
+	must.Eq(t, resp.JobModifyIndex, resp.EvalCreateIndex)
+	eval, err := store.EvalByID(nil, resp.EvalID)
+	must.NoError(t, err)
+	must.Eq(t, resp.JobModifyIndex, eval.JobModifyIndex)
+
+	out, err = store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1, out.Version)
+	must.Eq(t, 5, out.LookupTaskGroup("web").Count)
+	must.Eq(t, 7, out.LookupTaskGroup("worker").Count)
+
+	evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	var scaleEvals int
+	for _, eval := range evals {
+		if eval.TriggeredBy == structs.EvalTriggerScaling {
+			scaleEvals++
+		}
+	}
+	must.Eq(t, 1, scaleEvals)
+
+	// the scaling event of each group records every group touched
+	events, _, err := store.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	for group, count := range map[string]int64{"web": 5, "worker": 7} {
+		must.Len(t, 1, events[group])
+		must.Eq(t, count, *events[group][0].Count)
+		must.Eq(t, 10, events[group][0].PreviousCount)
+		must.Eq(t, resp.EvalID, *events[group][0].EvalID)
+		must.Eq(t, map[string]int64{"web": 5, "worker": 7}, events[group][0].Groups)
+
+		// the events of every group are committed at once
+		must.Eq(t, resp.Index, events[group][0].CreateIndex)
+	}
+
+	// counts can't be combined with a target group
+	scale.Target = map[string]string{structs.ScalingTargetGroup: "web"}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	must.ErrorContains(t, err, "can't contain both counts and a target group count")
+}
+
 func TestJobEndpoint_Scale_DeploymentBlocking(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -7328,7 +7505,7 @@ func TestJobEndpoint_Scale_DeploymentBlocking(t *testing.T) {
 		} else {
 			require.NoError(err, "test case %q", tc)
 			require.NotEmpty(resp.EvalID)
-			require.Greater(resp.EvalCreateIndex, resp.JobModifyIndex)
+			require.Equal(resp.EvalCreateIndex, resp.JobModifyIndex)
 		}
 	}
 }
@@ -7864,7 +8041,7 @@ func TestJobEndpoint_Scale_Priority(t *testing.T) {
 	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
 	requireAssertion.NoError(err)
 	requireAssertion.NotEmpty(resp.EvalID)
-	requireAssertion.Greater(resp.EvalCreateIndex, resp.JobModifyIndex)
+	requireAssertion.Equal(resp.EvalCreateIndex, resp.JobModifyIndex)
 
 	// Check the evaluation priority matches the job priority.
 	eval, err := fsmState.EvalByID(nil, resp.EvalID)
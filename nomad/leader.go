@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -90,6 +91,17 @@ var minVersionMultiIdentities = version.Must(version.NewVersion("1.7.0"))
 // servers must meet before the feature can be used.
 var minVersionDynamicHostVolumes = version.Must(version.NewVersion("1.10.0"))
 
+// minVersionMaintenanceWindows is the Nomad version at which maintenance
+// windows were introduced. It forms the minimum version all local servers must
+// meet before the feature can be used.
+var minVersionMaintenanceWindows = version.Must(version.NewVersion("1.11.1"))
+
+// minVersionTargetedGC is the Nomad version at which garbage collections
+// targeted at types of objects, and dry runs of them, were introduced. Older
+// servers ignore the targeted types and the dry run, and collect everything, so
+// all servers of the region must meet it before either can be requested.
+var minVersionTargetedGC = version.Must(version.NewVersion("1.11.1"))
+
 // minVersionNodeIdentity is the Nomad version at which the node identity
 // feature was introduced. It forms the minimum version all local servers must
 // meet before the feature can be used.
@@ -467,6 +479,19 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Periodically publish job and namespace aggregate metrics
+	if s.config.PublishJobAggregateMetrics {
+		go s.publishJobAggregateMetrics(stopCh)
+	}
+
+	// Periodically remove maintenance windows which have ended
+	go s.reapExpiredMaintenanceWindows(stopCh)
+
+	// Evaluate scaling policies with a "nomad" source
+	if s.config.BuiltinAutoscaler {
+		go newBuiltinAutoscaler(s).run(stopCh)
+	}
+
 	// Populate the variable lock TTL timers, so we can start tracking renewals
 	// and expirations.
 	if err := s.restoreLockTTLTimers(); err != nil {
@@ -884,17 +909,17 @@ func (s *Server) restorePeriodicDispatcher() error {
 				job.ID, job.Namespace)
 		}
 
-		// nextLaunch is the next launch that should occur.
-		nextLaunch, err := job.Periodic.Next(launch.Launch.In(job.Periodic.GetLocation()))
+		// missed are the launches which should have occurred since the last
+		// one and are made up for according to the missed launch policy of
+		// the job. Launches in the future are handled by the periodic
+		// dispatcher.
+		loc := job.Periodic.GetLocation()
+		missed, err := job.Periodic.MissedLaunches(launch.Launch.In(loc), now.In(loc), s.periodicDispatcher.calendar(job))
 		if err != nil {
-			logger.Error("failed to determine next periodic launch for job", "job", job.NamespacedID(), "error", err)
+			logger.Error("failed to determine missed periodic launches for job", "job", job.NamespacedID(), "error", err)
 			continue
 		}
-
-		// We skip force launching the job if  there should be no next launch
-		// (the zero case) or if the next launch time is in the future. If it is
-		// in the future, it will be handled by the periodic dispatcher.
-		if nextLaunch.IsZero() || !nextLaunch.Before(now) {
+		if len(missed) == 0 {
 			continue
 		}
 
@@ -908,12 +933,14 @@ func (s *Server) restorePeriodicDispatcher() error {
 			continue
 		}
 
-		if _, err := s.periodicDispatcher.ForceEval(job.Namespace, job.ID); err != nil {
-			logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
-			return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
+		for _, scheduled := range missed {
+			if _, err := s.periodicDispatcher.launchMissed(job, scheduled, now); err != nil {
+				logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
+				return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
+			}
 		}
 
-		logger.Debug("periodic job force run during leadership establishment", "job", job.NamespacedID())
+		logger.Debug("periodic job force run during leadership establishment", "job", job.NamespacedID(), "missed_launches", len(missed))
 	}
 
 	return nil
@@ -1396,6 +1423,186 @@ func (s *Server) iterateJobStatusMetrics(jobs *memdb.ResultIterator) {
 	metrics.SetGauge([]string{"nomad", "job_status", "dead"}, float32(dead))
 }
 
+// jobAggregate is the allocation counts, queued evaluations and requested
+// resources of a job, including its dispatched and periodic children, or of a
+// namespace.
+type jobAggregate struct {
+	Running     int
+	Pending     int
+	Failed      int
+	QueuedEvals int
+
+	// RequestedCPU and RequestedMemoryMB are the resources allocated to the
+	// allocations which are not terminal
+	RequestedCPU      int64
+	RequestedMemoryMB int64
+}
+
+// publishJobAggregateMetrics publishes the job and namespace aggregates as
+// metrics
+func (s *Server) publishJobAggregateMetrics(stopCh chan struct{}) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+			timer.Reset(s.config.JobAggregateMetricsInterval)
+			snap, err := s.State().Snapshot()
+			if err != nil {
+				s.logger.Error("failed to get state", "error", err)
+				continue
+			}
+			jobs, namespaces, err := aggregateJobMetrics(snap, s.config.JobAggregateMetricsNamespaces)
+			if err != nil {
+				s.logger.Error("failed to aggregate job metrics", "error", err)
+				continue
+			}
+
+			for id, agg := range jobs {
+				emitJobAggregateMetrics("job_aggregate", agg, []metrics.Label{
+					{Name: "job", Value: id.ID},
+					{Name: "namespace", Value: id.Namespace},
+				})
+			}
+			for namespace, agg := range namespaces {
+				emitJobAggregateMetrics("namespace_aggregate", agg, []metrics.Label{
+					{Name: "namespace", Value: namespace},
+				})
+			}
+		}
+	}
+}
+
+// aggregateJobMetrics returns the aggregates of each job in the given
+// namespaces, or in every namespace if none are given, and of every namespace.
+// Dispatched and periodic children are aggregated into their parent job, so
+// that the number of metrics does not grow with the number of children.
+func aggregateJobMetrics(snap *state.StateSnapshot, allowed []string) (
+	map[structs.NamespacedID]*jobAggregate, map[string]*jobAggregate, error) {
+
+	ws := memdb.NewWatchSet()
+	jobs := map[structs.NamespacedID]*jobAggregate{}
+	namespaces := map[string]*jobAggregate{}
+
+	// parents maps the children jobs to their parent, to aggregate the
+	// evaluations of children into the parent
+	parents := map[structs.NamespacedID]string{}
+
+	iter, err := snap.Namespaces(ws)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get namespaces: %w", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		namespaces[raw.(*structs.Namespace).Name] = &jobAggregate{}
+	}
+
+	// aggregates returns the aggregates of the job and namespace to add to,
+	// the job aggregate being nil if the namespace is not allowed
+	aggregates := func(namespace, jobID string) (*jobAggregate, *jobAggregate) {
+		nsAgg, ok := namespaces[namespace]
+		if !ok {
+			nsAgg = &jobAggregate{}
+			namespaces[namespace] = nsAgg
+		}
+		if len(allowed) > 0 && !slices.Contains(allowed, namespace) {
+			return nil, nsAgg
+		}
+		id := structs.NewNamespacedID(jobID, namespace)
+		jobAgg, ok := jobs[id]
+		if !ok {
+			jobAgg = &jobAggregate{}
+			jobs[id] = jobAgg
+		}
+		return jobAgg, nsAgg
+	}
+
+	iter, err = snap.Jobs(ws, state.SortDefault)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if job.ParentID != "" {
+			parents[job.NamespacedID()] = job.ParentID
+			continue
+		}
+		aggregates(job.Namespace, job.ID)
+	}
+
+	iter, err = snap.Allocs(ws, state.SortDefault)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get allocations: %w", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		jobID := alloc.JobID
+		if alloc.Job != nil && alloc.Job.ParentID != "" {
+			jobID = alloc.Job.ParentID
+		}
+
+		jobAgg, nsAgg := aggregates(alloc.Namespace, jobID)
+		for _, agg := range []*jobAggregate{jobAgg, nsAgg} {
+			if agg == nil {
+				continue
+			}
+			switch alloc.ClientStatus {
+			case structs.AllocClientStatusRunning:
+				agg.Running++
+			case structs.AllocClientStatusPending:
+				agg.Pending++
+			case structs.AllocClientStatusFailed:
+				agg.Failed++
+			}
+			if !alloc.TerminalStatus() {
+				if resources := alloc.AllocatedResources.Comparable(); resources != nil {
+					agg.RequestedCPU += resources.Flattened.Cpu.CpuShares
+					agg.RequestedMemoryMB += resources.Flattened.Memory.MemoryMB
+				}
+			}
+		}
+	}
+
+	iter, err = snap.Evals(ws, state.SortDefault)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get evaluations: %w", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		eval := raw.(*structs.Evaluation)
+		if eval.Status != structs.EvalStatusPending {
+			continue
+		}
+		jobID := eval.JobID
+		if parent, ok := parents[structs.NewNamespacedID(eval.JobID, eval.Namespace)]; ok {
+			jobID = parent
+		}
+		jobAgg, nsAgg := aggregates(eval.Namespace, jobID)
+		if jobAgg != nil {
+			jobAgg.QueuedEvals++
+		}
+		nsAgg.QueuedEvals++
+	}
+
+	return jobs, namespaces, nil
+}
+
+func emitJobAggregateMetrics(prefix string, agg *jobAggregate, labels []metrics.Label) {
+	metrics.SetGaugeWithLabels([]string{"nomad", prefix, "running"},
+		float32(agg.Running), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", prefix, "pending"},
+		float32(agg.Pending), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", prefix, "failed"},
+		float32(agg.Failed), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", prefix, "queued_evals"},
+		float32(agg.QueuedEvals), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", prefix, "requested_cpu"},
+		float32(agg.RequestedCPU), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", prefix, "requested_memory"},
+		float32(agg.RequestedMemoryMB), labels)
+}
+
 // revokeLeadership is invoked once we step down as leader.
 // This is used to cleanup any state that may be specific to a leader.
 func (s *Server) revokeLeadership() error {
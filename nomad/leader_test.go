@@ -442,6 +442,61 @@ func TestLeader_PeriodicDispatcher_Restore_NoEvals(t *testing.T) {
 	}
 }
 
+func TestLeader_PeriodicDispatcher_Restore_MissedLaunches(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Inject a periodic job which missed three launches, of which the two
+	// most recent are made up for.
+	now := time.Now().Round(time.Second)
+	missed := []time.Time{now.Add(-30 * time.Second), now.Add(-20 * time.Second), now.Add(-10 * time.Second)}
+	job := testPeriodicJob(append(missed, now.Add(time.Hour))...)
+	job.Periodic.MissedLaunchPolicy = structs.PeriodicMissedLaunchRunAll
+	job.Periodic.MaxMissedLaunches = 2
+	req := structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	_, _, err := s1.raftApply(structs.JobRegisterRequestType, req)
+	must.NoError(t, err)
+
+	s1.periodicDispatcher.SetEnabled(false)
+
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertPeriodicLaunch(1000, &structs.PeriodicLaunch{
+		ID:        job.ID,
+		Namespace: job.Namespace,
+		Launch:    now.Add(-time.Minute),
+	}))
+
+	s1.periodicDispatcher.SetEnabled(true)
+	must.NoError(t, s1.restorePeriodicDispatcher())
+
+	ws := memdb.NewWatchSet()
+	for i, scheduled := range missed {
+		childID := s1.periodicDispatcher.derivedJobID(job, scheduled)
+		child, err := state.JobByID(ws, job.Namespace, childID)
+		must.NoError(t, err)
+		if i == 0 {
+			must.Nil(t, child)
+			continue
+		}
+		must.NotNil(t, child)
+		must.StrHasPrefix(t, "missed: scheduled ", child.Meta[structs.PeriodicLaunchReasonMeta])
+	}
+
+	last, err := state.PeriodicLaunchByID(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, missed[2].Unix(), last.Launch.Unix())
+}
+
 type mockJobEvalDispatcher struct {
 	forceEvalCalled, children bool
 	evalToReturn              *structs.Evaluation
@@ -1892,3 +1947,87 @@ func TestServer_handleEvalBrokerStateChange(t *testing.T) {
 		})
 	}
 }
+
+func TestLeader_aggregateJobMetrics(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+
+	ns := mock.Namespace()
+	must.NoError(t, store.UpsertNamespaces(100, []*structs.Namespace{ns}))
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	child := parent.Copy()
+	child.ID = parent.ID + structs.DispatchLaunchSuffix + "abc"
+	child.ParentID = parent.ID
+	child.Dispatched = true
+	child.ParameterizedJob = nil
+	other := mock.Job()
+	other.Namespace = ns.Name
+	for i, job := range []*structs.Job{parent, child, other} {
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, uint64(101+i), nil, job))
+	}
+
+	alloc := func(job *structs.Job, status string) *structs.Allocation {
+		a := mock.Alloc()
+		a.Job = job
+		a.JobID = job.ID
+		a.Namespace = job.Namespace
+		a.ClientStatus = status
+		return a
+	}
+	failed := alloc(child, structs.AllocClientStatusFailed)
+	failed.DesiredStatus = structs.AllocDesiredStatusStop
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 110, []*structs.Allocation{
+		alloc(parent, structs.AllocClientStatusPending),
+		alloc(child, structs.AllocClientStatusRunning),
+		failed,
+		alloc(other, structs.AllocClientStatusRunning),
+	}))
+
+	eval := mock.Eval()
+	eval.JobID = child.ID
+	blocked := mock.Eval()
+	blocked.JobID = child.ID
+	blocked.Status = structs.EvalStatusBlocked
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 111, []*structs.Evaluation{eval, blocked}))
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+
+	// the children are rolled into the parent
+	expected := &jobAggregate{
+		Running:           1,
+		Pending:           1,
+		Failed:            1,
+		QueuedEvals:       1,
+		RequestedCPU:      1000,
+		RequestedMemoryMB: 512,
+	}
+	otherExpected := &jobAggregate{
+		Running:           1,
+		RequestedCPU:      500,
+		RequestedMemoryMB: 256,
+	}
+
+	jobs, namespaces, err := aggregateJobMetrics(snap, nil)
+	must.NoError(t, err)
+	must.Eq(t, map[structs.NamespacedID]*jobAggregate{
+		parent.NamespacedID(): expected,
+		other.NamespacedID():  otherExpected,
+	}, jobs)
+	must.Eq(t, map[string]*jobAggregate{
+		structs.DefaultNamespace: expected,
+		ns.Name:                  otherExpected,
+	}, namespaces)
+
+	// the jobs of namespaces which are not allowed are left out, but not
+	// the namespaces themselves
+	jobs, namespaces, err = aggregateJobMetrics(snap, []string{structs.DefaultNamespace})
+	must.NoError(t, err)
+	must.Eq(t, map[structs.NamespacedID]*jobAggregate{
+		parent.NamespacedID(): expected,
+	}, jobs)
+	must.MapLen(t, 2, namespaces)
+}
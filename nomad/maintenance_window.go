@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// maintenanceWindowReapInterval is how often the leader checks for maintenance
+// windows which have ended.
+var maintenanceWindowReapInterval = 30 * time.Second
+
+// reapExpiredMaintenanceWindows periodically deletes the maintenance windows
+// which have ended and re-evaluates the disconnected nodes they selected.
+func (s *Server) reapExpiredMaintenanceWindows(stopCh chan struct{}) {
+	timer, timerStop := helper.NewSafeTimer(maintenanceWindowReapInterval)
+	defer timerStop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+			if err := s.deleteExpiredMaintenanceWindows(time.Now()); err != nil {
+				s.logger.Error("failed to reap expired maintenance windows", "error", err)
+			}
+			timer.Reset(maintenanceWindowReapInterval)
+		}
+	}
+}
+
+// deleteExpiredMaintenanceWindows deletes the maintenance windows which have
+// ended by now.
+func (s *Server) deleteExpiredMaintenanceWindows(now time.Time) error {
+	iter, err := s.State().MaintenanceWindows(nil)
+	if err != nil {
+		return err
+	}
+
+	var expired []*structs.MaintenanceWindow
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		window := raw.(*structs.MaintenanceWindow)
+		if window.Expired(now) {
+			expired = append(expired, window)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	req := structs.MaintenanceWindowDeleteRequest{
+		Names: make([]string, 0, len(expired)),
+		WriteRequest: structs.WriteRequest{
+			Region: s.Region(),
+		},
+	}
+	for _, window := range expired {
+		req.Names = append(req.Names, window.Name)
+	}
+	if _, _, err := s.raftApply(structs.MaintenanceWindowDeleteRequestType, &req); err != nil {
+		return err
+	}
+
+	s.logger.Debug("deleted expired maintenance windows", "names", req.Names)
+	return s.evalMaintenanceWindowNodes(expired)
+}
+
+// maintenanceWindowGrace returns the grace of the active maintenance window
+// which selects the node, or zero if the node is not in a maintenance window.
+func maintenanceWindowGrace(store *state.StateStore, node *structs.Node, now time.Time) (time.Duration, error) {
+	iter, err := store.MaintenanceWindows(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var windows []*structs.MaintenanceWindow
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		windows = append(windows, raw.(*structs.MaintenanceWindow))
+	}
+
+	if window := structs.ActiveMaintenanceWindow(windows, node, now); window != nil {
+		return window.Grace, nil
+	}
+	return 0, nil
+}
+
+// evalMaintenanceWindowNodes creates evaluations for the disconnected nodes
+// selected by the windows. Follow-up evaluations for their unknown allocations
+// may have run while the grace of a window applied, so without this their
+// allocations wouldn't be replaced until the next unrelated evaluation.
+func (s *Server) evalMaintenanceWindowNodes(windows []*structs.MaintenanceWindow) error {
+	iter, err := s.State().Nodes(nil)
+	if err != nil {
+		return err
+	}
+
+	nodeEndpoint := NewNodeEndpoint(s, nil)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if node.Status != structs.NodeStatusDisconnected {
+			continue
+		}
+		for _, window := range windows {
+			if !window.Matches(node) {
+				continue
+			}
+			if _, _, err := nodeEndpoint.createNodeEvals(node, node.ModifyIndex); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
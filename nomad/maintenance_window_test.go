@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestMaintenanceWindow_Grace(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	node := mock.Node()
+	now := time.Now()
+
+	grace, err := maintenanceWindowGrace(store, node, now)
+	must.NoError(t, err)
+	must.Zero(t, grace)
+
+	window := mock.MaintenanceWindow()
+	window.Datacenter = node.Datacenter
+	must.NoError(t, store.UpsertMaintenanceWindows(structs.MsgTypeTestSetup, 1000,
+		[]*structs.MaintenanceWindow{window}))
+
+	grace, err = maintenanceWindowGrace(store, node, now)
+	must.NoError(t, err)
+	must.Eq(t, window.Grace, grace)
+
+	grace, err = maintenanceWindowGrace(store, node, window.End())
+	must.NoError(t, err)
+	must.Zero(t, grace)
+}
+
+func TestMaintenanceWindow_DeleteExpired(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	// Create a disconnected node with a running job.
+	node := mock.Node()
+	node.Status = structs.NodeStatusDisconnected
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.ClientStatus = structs.AllocClientStatusUnknown
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, alloc.Job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc}))
+
+	active := mock.MaintenanceWindow()
+	active.Datacenter = node.Datacenter
+	ended := mock.MaintenanceWindow()
+	ended.Datacenter = node.Datacenter
+	ended.Start = time.Now().Add(-2 * time.Hour)
+	must.NoError(t, store.UpsertMaintenanceWindows(structs.MsgTypeTestSetup, 1003,
+		[]*structs.MaintenanceWindow{active, ended}))
+
+	must.NoError(t, s1.deleteExpiredMaintenanceWindows(time.Now()))
+
+	got, err := store.MaintenanceWindowByName(nil, ended.Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	got, err = store.MaintenanceWindowByName(nil, active.Name)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+
+	// The disconnected node selected by the ended window is re-evaluated.
+	evals, err := store.EvalsByJob(nil, alloc.Namespace, alloc.JobID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerNodeUpdate, evals[0].TriggeredBy)
+	must.Eq(t, node.ID, evals[0].NodeID)
+
+	// Nothing happens when no window has ended.
+	must.NoError(t, s1.deleteExpiredMaintenanceWindows(time.Now()))
+	evals, err = store.EvalsByJob(nil, alloc.Namespace, alloc.JobID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+}
@@ -255,6 +255,17 @@ func NodePool() *structs.NodePool {
 	return pool
 }
 
+func MaintenanceWindow() *structs.MaintenanceWindow {
+	return &structs.MaintenanceWindow{
+		Name:        fmt.Sprintf("window-%s", uuid.Short()),
+		Description: "test maintenance window",
+		Start:       time.Now().Add(-time.Minute).Round(0),
+		Duration:    time.Hour,
+		Grace:       30 * time.Minute,
+		Datacenter:  "dc1",
+	}
+}
+
 // ServiceRegistrations generates an array containing two unique service
 // registrations.
 func ServiceRegistrations() []*structs.ServiceRegistration {
@@ -844,7 +844,8 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 		if node.Status == structs.NodeStatusDown && args.NodeEvent == nil {
 			args.NodeEvent = structs.NewNodeEvent().
 				SetSubsystem(structs.NodeEventSubsystemCluster).
-				SetMessage(NodeHeartbeatEventReregistered)
+				SetMessage(NodeHeartbeatEventReregistered).
+				SetActor(structs.NodeEventActorHeartbeat)
 		}
 
 		_, index, err = n.srv.raftApply(structs.NodeUpdateStatusRequestType, args)
@@ -965,7 +966,9 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	}
 
 	// Construct the node event
-	args.NodeEvent = structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemDrain)
+	args.NodeEvent = structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemDrain).
+		SetIdentity(args.GetIdentity())
 	if node.DrainStrategy == nil && args.DrainStrategy != nil {
 		args.NodeEvent.SetMessage(NodeDrainEventDrainSet)
 	} else if node.DrainStrategy != nil && args.DrainStrategy != nil {
@@ -1099,7 +1102,9 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	args.UpdatedAt = time.Now().Unix()
 
 	// Construct the node event
-	args.NodeEvent = structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster)
+	args.NodeEvent = structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemCluster).
+		SetIdentity(args.GetIdentity())
 	if node.SchedulingEligibility == args.Eligibility {
 		return nil // Nothing to do
 	} else if args.Eligibility == structs.NodeSchedulingEligible {
@@ -1825,7 +1830,7 @@ func (n *Node) createNodeEvals(node *structs.Node, nodeIndex uint64) ([]string,
 		return nil, 0, fmt.Errorf("failed to find system jobs for '%s': %v", nodeID, err)
 	}
 
-	var sysJobs []*structs.Job
+	var nodeJobs []*structs.Job
 	for jobI := sysJobsIter.Next(); jobI != nil; jobI = sysJobsIter.Next() {
 		job := jobI.(*structs.Job)
 		// Avoid creating evals for jobs that don't run in this datacenter or
@@ -1833,12 +1838,25 @@ func (n *Node) createNodeEvals(node *structs.Node, nodeIndex uint64) ([]string,
 		// datacenter/pool is a good optimization to start with as their
 		// cardinality tends to be low so the check shouldn't add much work.
 		if node.IsInPool(job.NodePool) && node.IsInAnyDC(job.Datacenters) {
-			sysJobs = append(sysJobs, job)
+			nodeJobs = append(nodeJobs, job)
+		}
+	}
+
+	// Jobs replicated per node attribute may need an allocation for a value
+	// of the attribute the node adds
+	replicatedIter, err := snap.JobsByReplicated(nil, true)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find replicated jobs for '%s': %v", nodeID, err)
+	}
+	for jobI := replicatedIter.Next(); jobI != nil; jobI = replicatedIter.Next() {
+		job := jobI.(*structs.Job)
+		if node.IsInPool(job.NodePool) && node.IsInAnyDC(job.Datacenters) {
+			nodeJobs = append(nodeJobs, job)
 		}
 	}
 
 	// Fast-path if nothing to do
-	if len(allocs) == 0 && len(sysJobs) == 0 {
+	if len(allocs) == 0 && len(nodeJobs) == 0 {
 		return nil, 0, nil
 	}
 
@@ -1883,8 +1901,9 @@ func (n *Node) createNodeEvals(node *structs.Node, nodeIndex uint64) ([]string,
 		evalIDs = append(evalIDs, eval.ID)
 	}
 
-	// Create an evaluation for each system job.
-	for _, job := range sysJobs {
+	// Create an evaluation for each system job and each job replicated per
+	// node attribute.
+	for _, job := range nodeJobs {
 		// Still dedup on JobID as the node may already have the system job.
 		if _, ok := jobIDs[job.NamespacedID()]; ok {
 			continue
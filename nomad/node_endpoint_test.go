@@ -1335,6 +1335,90 @@ func TestClientEndpoint_Register_GetEvals(t *testing.T) {
 	}
 }
 
+// TestClientEndpoint_Register_MetaChange asserts that jobs constrained on node
+// metadata are placed once the metadata of a node, such as dynamic metadata
+// applied to the client, changes to satisfy them.
+func TestClientEndpoint_Register_MetaChange(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.config.Region)
+
+	node := mock.Node()
+	node.Meta["firmware"] = "2.2.0"
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Register a service job and a system job which are not feasible on the
+	// node until its metadata changes
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.Constraints = append(job.Constraints, &structs.Constraint{
+		LTarget: "${meta.firmware}",
+		RTarget: ">= 2.3.0",
+		Operand: structs.ConstraintSemver,
+	})
+	sysJob := mock.SystemJob()
+	sysJob.Constraints = append(sysJob.Constraints, &structs.Constraint{
+		LTarget: "${meta.unique.rack}",
+		RTarget: "r2",
+		Operand: "=",
+	})
+	for _, job := range []*structs.Job{job, sysJob} {
+		jobReq := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var jobResp structs.JobRegisterResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", jobReq, &jobResp))
+	}
+
+	// Wait for the service job's eval to be blocked
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return s1.blockedEvals.Stats().TotalBlocked == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// Apply new metadata to the node
+	node = node.Copy()
+	node.Meta["firmware"] = "v2.4.0"
+	node.Meta["unique.rack"] = "r2"
+	reg.Node = node
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Both jobs are placed on the node
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			for _, job := range []*structs.Job{job, sysJob} {
+				allocs, err := s1.fsm.State().AllocsByJob(nil, job.Namespace, job.ID, true)
+				if err != nil {
+					return err
+				}
+				if len(allocs) != 1 || allocs[0].NodeID != node.ID {
+					return fmt.Errorf("expected job %q to be placed on node, got %d allocs", job.ID, len(allocs))
+				}
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, 0, s1.blockedEvals.Stats().TotalBlocked)
+}
+
 func TestClientEndpoint_UpdateStatus_GetEvals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2485,6 +2569,14 @@ func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 	{
 		var resp structs.NodeEligibilityUpdateResponse
 		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", dereg, &resp), "RPC")
+
+		// The node event records the token which made the change
+		out, err := state.NodeByID(nil, node.ID)
+		require.NoError(err)
+		event := out.Events[len(out.Events)-1]
+		require.Equal(NodeEligibilityEventIneligible, event.Message)
+		require.Equal(validToken.Name, event.Actor)
+		require.Equal(validToken.AccessorID, event.AccessorID)
 	}
 
 	// Try with a invalid token
@@ -3962,6 +4054,60 @@ func TestClientEndpoint_CreateNodeEvals_MultipleDCes(t *testing.T) {
 	require.Equal(t, defaultJob.ID, eval.JobID)
 }
 
+func TestClientEndpoint_CreateNodeEvals_Replicated(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+
+	idx, err := state.LatestIndex()
+	must.NoError(t, err)
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, idx, node))
+	idx++
+
+	// Inject a job replicated per rack, a stopped one, one in another node
+	// pool, and a job which is not replicated
+	replicated := mock.Job()
+	replicated.TaskGroups[0].ReplicatePer = "${meta.rack}"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, idx, nil, replicated))
+	idx++
+
+	stopped := mock.Job()
+	stopped.TaskGroups[0].ReplicatePer = "${meta.rack}"
+	stopped.Stop = true
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, idx, nil, stopped))
+	idx++
+
+	pool := mock.NodePool()
+	must.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, idx, []*structs.NodePool{pool}))
+	idx++
+
+	otherPool := mock.Job()
+	otherPool.NodePool = pool.Name
+	otherPool.TaskGroups[0].ReplicatePer = "${meta.rack}"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, idx, nil, otherPool))
+	idx++
+
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, idx, nil, mock.Job()))
+
+	// Create evaluations
+	nodeEndpoint := NewNodeEndpoint(s1, nil)
+	evalIDs, index, err := nodeEndpoint.createNodeEvals(node, 1)
+	must.NoError(t, err)
+	must.NonZero(t, index)
+	must.Len(t, 1, evalIDs)
+
+	eval, err := state.EvalByID(nil, evalIDs[0])
+	must.NoError(t, err)
+	must.Eq(t, replicated.ID, eval.JobID)
+	must.Eq(t, structs.EvalTriggerNodeUpdate, eval.TriggeredBy)
+}
+
 func TestClientEndpoint_Evaluate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -105,6 +105,36 @@ func (op *Operator) RaftGetConfiguration(args *structs.GenericRequest, reply *st
 	return nil
 }
 
+// RaftGetStatus is used to retrieve the replication status of the servers in
+// the Raft configuration, as known by the leader.
+func (op *Operator) RaftGetStatus(args *structs.GenericRequest, reply *structs.RaftStatusResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	// Only the leader tracks the replication status of its followers.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.RaftGetStatus", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	if aclObj, err := op.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	status, err := op.srv.raftStatus(op.srv.autopilot.GetState())
+	if err != nil {
+		return err
+	}
+	*reply = *status
+	return nil
+}
+
 // RaftRemovePeerByAddress COMPAT(1.12.0) was used to support Raft Protocol v2,
 // which was removed in Nomad 1.4.0 but the API was not removed. Remove this RPC
 // entirely in Nomad 1.12.0.
@@ -863,3 +893,189 @@ func decodeStreamOutput(decoder *codec.Decoder) (io.Reader, <-chan error) {
 
 	return pr, errCh
 }
+
+// MaintenanceWindowList is used to list the maintenance windows.
+func (op *Operator) MaintenanceWindowList(args *structs.MaintenanceWindowListRequest, reply *structs.MaintenanceWindowListResponse) error {
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.MaintenanceWindowList", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			iter, err := store.MaintenanceWindows(ws)
+			if err != nil {
+				return err
+			}
+
+			windows := []*structs.MaintenanceWindow{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				windows = append(windows, raw.(*structs.MaintenanceWindow))
+			}
+			reply.MaintenanceWindows = windows
+
+			index, err := store.Index(state.TableMaintenanceWindows)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+			return nil
+		}}
+	return op.srv.blockingRPC(&opts)
+}
+
+// MaintenanceWindowGet is used to retrieve a specific maintenance window.
+func (op *Operator) MaintenanceWindowGet(args *structs.MaintenanceWindowSpecificRequest, reply *structs.SingleMaintenanceWindowResponse) error {
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.MaintenanceWindowGet", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			window, err := store.MaintenanceWindowByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			reply.MaintenanceWindow = window
+			if window != nil {
+				reply.Index = window.ModifyIndex
+			} else {
+				// Return the last index that affected the maintenance windows
+				// table if the requested window doesn't exist.
+				index, err := store.Index(state.TableMaintenanceWindows)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			return nil
+		}}
+	return op.srv.blockingRPC(&opts)
+}
+
+// MaintenanceWindowUpsert is used to create or update maintenance windows.
+func (op *Operator) MaintenanceWindowUpsert(args *structs.MaintenanceWindowUpsertRequest, reply *structs.GenericResponse) error {
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.MaintenanceWindowUpsert", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator write access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !op.srv.peersCache.ServersMeetMinimumVersion(op.srv.Region(), minVersionMaintenanceWindows, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert maintenance windows", minVersionMaintenanceWindows)
+	}
+
+	if len(args.MaintenanceWindows) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one maintenance window")
+	}
+	now := time.Now()
+	for _, window := range args.MaintenanceWindows {
+		if err := window.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid maintenance window %q: %v", window.Name, err)
+		}
+		if window.Expired(now) {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "maintenance window %q ended at %v", window.Name, window.End())
+		}
+	}
+
+	_, index, err := op.srv.raftApply(structs.MaintenanceWindowUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// MaintenanceWindowDelete is used to delete maintenance windows. Nodes that
+// were disconnected during the windows are re-evaluated, so allocations that
+// are no longer covered by a grace are replaced.
+func (op *Operator) MaintenanceWindowDelete(args *structs.MaintenanceWindowDeleteRequest, reply *structs.GenericResponse) error {
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.MaintenanceWindowDelete", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator write access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !op.srv.peersCache.ServersMeetMinimumVersion(op.srv.Region(), minVersionMaintenanceWindows, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete maintenance windows", minVersionMaintenanceWindows)
+	}
+
+	if len(args.Names) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one maintenance window to delete")
+	}
+
+	windows := make([]*structs.MaintenanceWindow, 0, len(args.Names))
+	for _, name := range args.Names {
+		window, err := op.srv.State().MaintenanceWindowByName(nil, name)
+		if err != nil {
+			return err
+		}
+		if window == nil {
+			return structs.NewErrRPCCodedf(http.StatusNotFound, "maintenance window %q not found", name)
+		}
+		windows = append(windows, window)
+	}
+
+	_, index, err := op.srv.raftApply(structs.MaintenanceWindowDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+
+	if err := op.srv.evalMaintenanceWindowNodes(windows); err != nil {
+		op.logger.Error("failed to create evaluations for maintenance window nodes", "error", err)
+	}
+	return nil
+}
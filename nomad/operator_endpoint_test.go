@@ -146,6 +146,59 @@ func TestOperator_RaftGetConfiguration_ACL(t *testing.T) {
 	}
 }
 
+func TestOperator_RaftGetStatus_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+	readToken := mock.CreatePolicyAndToken(t, state, 1003, "test-read", `operator { policy = "read" }`)
+
+	// Wait for autopilot to know the server's name
+	testutil.WaitForResult(func() (bool, error) {
+		return s1.autopilot.GetState() != nil, nil
+	}, func(err error) {
+		t.Fatalf("autopilot state not available: %v", err)
+	})
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+
+	// Try with no token and expect permission denied
+	var reply structs.RaftStatusResponse
+	err := msgpackrpc.CallWithCodec(codec, "Operator.RaftGetStatus", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token and expect permission denied
+	arg.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.RaftGetStatus", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Use operator read and management tokens
+	for _, token := range []string{readToken.SecretID, root.SecretID} {
+		arg.AuthToken = token
+		reply = structs.RaftStatusResponse{}
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.RaftGetStatus", &arg, &reply))
+
+		must.Len(t, 1, reply.Servers)
+		me := reply.Servers[0]
+		must.True(t, me.Leader)
+		must.True(t, me.Voter)
+		must.Eq(t, structs.RaftPeerStateLeader, me.State)
+		must.Eq(t, fmt.Sprintf("%v.%v", s1.config.NodeName, s1.config.Region), me.Node)
+		must.Eq(t, reply.LastIndex, me.MatchIndex)
+		must.Positive(t, reply.Term)
+		must.Positive(t, reply.CommitIndex)
+	}
+}
+
 func TestOperator_RaftRemovePeerByID(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1129,3 +1182,141 @@ func TestOperator_SnapshotRestore_ACL(t *testing.T) {
 		})
 	}
 }
+
+func TestOperator_MaintenanceWindow_CRUD(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	window := mock.MaintenanceWindow()
+
+	// Register the maintenance window.
+	upsertReq := &structs.MaintenanceWindowUpsertRequest{
+		MaintenanceWindows: []*structs.MaintenanceWindow{window},
+		WriteRequest: structs.WriteRequest{
+			Region: s1.config.Region,
+		},
+	}
+	var upsertResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowUpsert", upsertReq, &upsertResp))
+	must.NonZero(t, upsertResp.Index)
+
+	// Read it back.
+	getReq := &structs.MaintenanceWindowSpecificRequest{
+		Name: window.Name,
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var getResp structs.SingleMaintenanceWindowResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowGet", getReq, &getResp))
+	must.NotNil(t, getResp.MaintenanceWindow)
+	must.Eq(t, window.Grace, getResp.MaintenanceWindow.Grace)
+	must.Eq(t, upsertResp.Index, getResp.Index)
+
+	// List the maintenance windows.
+	listReq := &structs.MaintenanceWindowListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var listResp structs.MaintenanceWindowListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowList", listReq, &listResp))
+	must.Len(t, 1, listResp.MaintenanceWindows)
+	must.Eq(t, window.Name, listResp.MaintenanceWindows[0].Name)
+
+	// Windows that have already ended are rejected.
+	ended := mock.MaintenanceWindow()
+	ended.Start = time.Now().Add(-2 * time.Hour)
+	upsertReq.MaintenanceWindows = []*structs.MaintenanceWindow{ended}
+	err := msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowUpsert", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "ended at")
+
+	// Invalid windows are rejected.
+	invalid := mock.MaintenanceWindow()
+	invalid.Grace = 0
+	upsertReq.MaintenanceWindows = []*structs.MaintenanceWindow{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowUpsert", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "grace must be positive")
+
+	// Delete the maintenance window.
+	deleteReq := &structs.MaintenanceWindowDeleteRequest{
+		Names: []string{window.Name},
+		WriteRequest: structs.WriteRequest{
+			Region: s1.config.Region,
+		},
+	}
+	var deleteResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowDelete", deleteReq, &deleteResp))
+	must.Greater(t, upsertResp.Index, deleteResp.Index)
+
+	getReq.MinQueryIndex = 0
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowGet", getReq, &getResp))
+	must.Nil(t, getResp.MaintenanceWindow)
+
+	// Deleting a missing window fails.
+	err = msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowDelete", deleteReq, &deleteResp)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestOperator_MaintenanceWindow_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+	readToken := mock.CreatePolicyAndToken(t, state, 1003, "test-read", `operator { policy = "read" }`)
+
+	window := mock.MaintenanceWindow()
+	upsertReq := &structs.MaintenanceWindowUpsertRequest{
+		MaintenanceWindows: []*structs.MaintenanceWindow{window},
+		WriteRequest: structs.WriteRequest{
+			Region: s1.config.Region,
+		},
+	}
+	var upsertResp structs.GenericResponse
+
+	// Writes require operator write.
+	upsertReq.AuthToken = readToken.SecretID
+	err := msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowUpsert", upsertReq, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowUpsert", upsertReq, &upsertResp))
+
+	// Reads require operator read.
+	listReq := &structs.MaintenanceWindowListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    s1.config.Region,
+			AuthToken: invalidToken.SecretID,
+		},
+	}
+	var listResp structs.MaintenanceWindowListResponse
+	err = msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowList", listReq, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	listReq.AuthToken = readToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowList", listReq, &listResp))
+	must.Len(t, 1, listResp.MaintenanceWindows)
+
+	deleteReq := &structs.MaintenanceWindowDeleteRequest{
+		Names: []string{window.Name},
+		WriteRequest: structs.WriteRequest{
+			Region:    s1.config.Region,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var deleteResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowDelete", deleteReq, &deleteResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	deleteReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.MaintenanceWindowDelete", deleteReq, &deleteResp))
+}
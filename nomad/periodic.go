@@ -6,6 +6,8 @@ package nomad
 import (
 	"container/heap"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -45,6 +47,10 @@ type JobEvalDispatcher interface {
 
 	// RunningChildren returns whether the passed job has any running children.
 	RunningChildren(job *structs.Job) (bool, error)
+
+	// PeriodicCalendar returns the exclusion calendar of the passed job, read
+	// from the variable it references, or nil if it references none.
+	PeriodicCalendar(job *structs.Job) (*structs.PeriodicCalendar, error)
 }
 
 // DispatchJob creates an evaluation for the passed job and commits both the
@@ -135,6 +141,46 @@ func (s *Server) RunningChildren(job *structs.Job) (bool, error) {
 	return false, nil
 }
 
+// errPeriodicCalendarNotFound is returned by PeriodicCalendar when the variable
+// a job references as its exclusion calendar does not exist.
+var errPeriodicCalendarNotFound = errors.New("not found")
+
+// PeriodicCalendar returns the exclusion calendar of the passed job, read from
+// the variable it references, or nil if it references none.
+func (s *Server) PeriodicCalendar(job *structs.Job) (*structs.PeriodicCalendar, error) {
+	path := job.Periodic.ExclusionCalendar
+	if path == "" {
+		return nil, nil
+	}
+
+	v, err := s.fsm.State().GetVariable(memdb.NewWatchSet(), job.Namespace, path)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, fmt.Errorf("exclusion calendar variable %q %w", path, errPeriodicCalendarNotFound)
+	}
+
+	b, err := s.encrypter.Decrypt(v.Data, v.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt exclusion calendar variable %q: %v", path, err)
+	}
+	var items structs.VariableItems
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode exclusion calendar variable %q: %v", path, err)
+	}
+	ics, ok := items[structs.PeriodicExclusionCalendarItem]
+	if !ok {
+		return nil, fmt.Errorf("exclusion calendar variable %q has no %q item", path, structs.PeriodicExclusionCalendarItem)
+	}
+
+	calendar, err := structs.ParsePeriodicCalendar(ics)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclusion calendar variable %q: %v", path, err)
+	}
+	return calendar, nil
+}
+
 // NewPeriodicDispatch returns a periodic dispatcher that is used to track and
 // launch periodic jobs.
 func NewPeriodicDispatch(logger log.Logger, dispatcher JobEvalDispatcher) *PeriodicDispatch {
@@ -214,7 +260,7 @@ func (p *PeriodicDispatch) Add(job *structs.Job) error {
 
 	// Add or update the job.
 	p.tracked[tuple] = job
-	next, err := job.Periodic.Next(time.Now().In(job.Periodic.GetLocation()))
+	next, err := p.launchAfter(job, time.Now().In(job.Periodic.GetLocation()))
 	if err != nil {
 		return fmt.Errorf("failed adding job %s: %v", job.NamespacedID(), err)
 	}
@@ -303,6 +349,32 @@ func (p *PeriodicDispatch) ForceEval(namespace, jobID string) (*structs.Evaluati
 	return p.createEval(job, time.Now().In(job.Periodic.GetLocation()))
 }
 
+// launchMissed launches the periodic job to make up for its launch scheduled
+// at the passed time, which was missed while no leader was running the
+// dispatcher. The child job records why it was launched in its meta, which is
+// also carried by the event of its registration.
+func (p *PeriodicDispatch) launchMissed(job *structs.Job, scheduled, now time.Time) (*structs.Evaluation, error) {
+	derived, err := p.deriveJob(job, scheduled)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := structs.MissedLaunchReason(scheduled, now)
+	if derived.Meta == nil {
+		derived.Meta = make(map[string]string, 1)
+	}
+	derived.Meta[structs.PeriodicLaunchReasonMeta] = reason
+
+	eval, err := p.dispatcher.DispatchJob(derived)
+	if err != nil {
+		p.logger.Error("failed to dispatch job", "job", job.NamespacedID(), "error", err)
+		return nil, err
+	}
+
+	p.logger.Info("launched missed periodic job", "job", job.NamespacedID(), "child", derived.ID, "reason", reason)
+	return eval, nil
+}
+
 // shouldRun returns whether the long lived run function should run.
 func (p *PeriodicDispatch) shouldRun() bool {
 	p.l.RLock()
@@ -340,13 +412,22 @@ func (p *PeriodicDispatch) run(ctx context.Context, updateCh <-chan struct{}) {
 func (p *PeriodicDispatch) dispatch(job *structs.Job, launchTime time.Time) {
 	p.l.Lock()
 
-	nextLaunch, err := job.Periodic.Next(launchTime)
+	calendar := p.calendar(job)
+	nextLaunch, err := job.Periodic.NextExcluding(launchTime, calendar)
 	if err != nil {
 		p.logger.Error("failed to parse next periodic launch", "job", job.NamespacedID(), "error", err)
 	} else if err := p.heap.Update(job, nextLaunch); err != nil {
 		p.logger.Error("failed to update next launch of periodic job", "job", job.NamespacedID(), "error", err)
 	}
 
+	// The exclusion calendar may have changed since the launch was
+	// scheduled, so it is consulted again before launching.
+	if calendar.Excludes(launchTime) {
+		p.logger.Debug("skipping launch of periodic job on date excluded by its calendar", "job", job.NamespacedID(), "launch_time", launchTime)
+		p.l.Unlock()
+		return
+	}
+
 	// If the job prohibits overlapping and there are running children, we skip
 	// the launch.
 	if job.Periodic.ProhibitOverlap {
@@ -369,6 +450,27 @@ func (p *PeriodicDispatch) dispatch(job *structs.Job, launchTime time.Time) {
 	p.createEval(job, launchTime)
 }
 
+// launchAfter returns the next launch of the job after fromTime, skipping the
+// dates excluded by its exclusions and its exclusion calendar.
+func (p *PeriodicDispatch) launchAfter(job *structs.Job, fromTime time.Time) (time.Time, error) {
+	return job.Periodic.NextExcluding(fromTime, p.calendar(job))
+}
+
+// calendar returns the exclusion calendar of the job. A calendar which cannot
+// be read is logged and treated as excluding no dates, so that a missing or
+// invalid variable does not stop the job from launching.
+func (p *PeriodicDispatch) calendar(job *structs.Job) *structs.PeriodicCalendar {
+	if job.Periodic.ExclusionCalendar == "" {
+		return nil
+	}
+	calendar, err := p.dispatcher.PeriodicCalendar(job)
+	if err != nil {
+		p.logger.Warn("failed to read exclusion calendar of periodic job", "job", job.NamespacedID(), "error", err)
+		return nil
+	}
+	return calendar
+}
+
 // nextLaunch returns the next job to launch and when it should be launched. If
 // the next job can't be determined, an error is returned. If the dispatcher is
 // stopped, a nil job will be returned.
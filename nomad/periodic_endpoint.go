@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -81,3 +82,88 @@ func (p *Periodic) Force(args *structs.PeriodicForceRequest, reply *structs.Peri
 	reply.Index = eval.CreateIndex
 	return nil
 }
+
+// Status returns the latest and upcoming launches of a periodic job
+func (p *Periodic) Status(args *structs.PeriodicStatusRequest, reply *structs.PeriodicStatusResponse) error {
+
+	authErr := p.srv.Authenticate(p.ctx, args)
+	if done, err := p.srv.forward("Periodic.Status", args, args, reply); done {
+		return err
+	}
+	p.srv.MeasureRPCRate("periodic", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "periodic", "status"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := p.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+
+	// Lookup the job
+	snap, err := p.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	job, err := snap.JobByID(ws, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job not found")
+	}
+	if !job.IsPeriodic() {
+		return fmt.Errorf("job %q is not periodic", job.ID)
+	}
+
+	launch, err := snap.PeriodicLaunchByID(ws, job.Namespace, job.ID)
+	if err != nil {
+		return err
+	}
+	if launch != nil {
+		reply.LastLaunch = launch.Launch
+	}
+
+	if job.IsPeriodicActive() {
+		calendar, err := p.srv.PeriodicCalendar(job)
+		if err != nil {
+			p.logger.Warn("failed to read exclusion calendar of periodic job", "job", job.NamespacedID(), "error", err)
+			reply.ExclusionCalendarError = exclusionCalendarError(job, err)
+		}
+
+		now := time.Now().In(job.Periodic.GetLocation())
+		reply.NextLaunches, err = job.Periodic.NextLaunches(now, calendar, structs.PeriodicStatusLaunches)
+		if err != nil {
+			return fmt.Errorf("failed to compute launches of job %q: %v", job.ID, err)
+		}
+	}
+
+	index, err := snap.Index("jobs")
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	p.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// exclusionCalendarError returns the error reported to readers of the periodic
+// status of job when its exclusion calendar cannot be read. Only whether the
+// variable exists is reported, as the errors of decrypting and parsing it may
+// quote its content.
+func exclusionCalendarError(job *structs.Job, err error) string {
+	if errors.Is(err, errPeriodicCalendarNotFound) {
+		return fmt.Sprintf("exclusion calendar variable %q not found", job.Periodic.ExclusionCalendar)
+	}
+	return fmt.Sprintf("exclusion calendar variable %q is invalid", job.Periodic.ExclusionCalendar)
+}
@@ -4,7 +4,9 @@
 package nomad
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
@@ -13,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -198,3 +201,91 @@ func TestPeriodicEndpoint_Force_NonPeriodic(t *testing.T) {
 		t.Fatalf("Force on non-periodic job should err")
 	}
 }
+
+func TestPeriodicEndpoint_Status(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, "global")
+
+	// Create a periodic job launching daily.
+	job := mock.PeriodicJob()
+	job.Periodic.Spec = "0 0 * * *"
+	job.Periodic.ExclusionCalendar = "nomad/jobs/" + job.ID + "/holidays"
+
+	// Create a calendar excluding tomorrow.
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	applyReq := &structs.VariablesApplyRequest{
+		Op: structs.VarOpSet,
+		Var: &structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{
+				Namespace: structs.DefaultNamespace,
+				Path:      job.Periodic.ExclusionCalendar,
+			},
+			Items: structs.VariableItems{
+				structs.PeriodicExclusionCalendarItem: "BEGIN:VEVENT\nDTSTART;VALUE=DATE:" +
+					tomorrow.Format("20060102") + "\nEND:VEVENT\n",
+			},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var applyResp structs.VariablesApplyResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, applyReq, &applyResp))
+
+	// Insert the job.
+	job.Periodic.Canonicalize()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	req := &structs.PeriodicStatusRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.PeriodicStatusResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp))
+	must.Eq(t, "", resp.ExclusionCalendarError)
+	must.Len(t, structs.PeriodicStatusLaunches, resp.NextLaunches)
+	must.Eq(t, tomorrow.AddDate(0, 0, 1), resp.NextLaunches[0].UTC())
+	must.Eq(t, tomorrow.AddDate(0, 0, 2), resp.NextLaunches[1].UTC())
+
+	// A calendar which cannot be read excludes no dates.
+	job = job.Copy()
+	job.Periodic.ExclusionCalendar = "nomad/jobs/" + job.ID + "/missing"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, job))
+
+	resp = structs.PeriodicStatusResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp))
+	must.Eq(t, fmt.Sprintf("exclusion calendar variable %q not found", job.Periodic.ExclusionCalendar), resp.ExclusionCalendarError)
+	must.Eq(t, tomorrow, resp.NextLaunches[0].UTC())
+
+	// The content of an invalid calendar is not reported.
+	applyReq.Var.Path = "nomad/jobs/" + job.ID + "/invalid"
+	applyReq.Var.Items = structs.VariableItems{
+		structs.PeriodicExclusionCalendarItem: "BEGIN:VEVENT\nDTSTART:s3cr3t\nEND:VEVENT\n",
+	}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, applyReq, &applyResp))
+	job = job.Copy()
+	job.Periodic.ExclusionCalendar = applyReq.Var.Path
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job))
+
+	resp = structs.PeriodicStatusResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp))
+	must.Eq(t, fmt.Sprintf("exclusion calendar variable %q is invalid", job.Periodic.ExclusionCalendar), resp.ExclusionCalendarError)
+	must.StrNotContains(t, resp.ExclusionCalendarError, "s3cr3t")
+
+	// A job which is not periodic has no status.
+	nonPeriodic := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, nonPeriodic))
+	req.JobID = nonPeriodic.ID
+	err := msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp)
+	must.ErrorContains(t, err, "is not periodic")
+}
@@ -25,12 +25,16 @@ import (
 )
 
 type MockJobEvalDispatcher struct {
-	Jobs map[structs.NamespacedID]*structs.Job
-	lock sync.Mutex
+	Jobs      map[structs.NamespacedID]*structs.Job
+	Calendars map[string]*structs.PeriodicCalendar
+	lock      sync.Mutex
 }
 
 func NewMockJobEvalDispatcher() *MockJobEvalDispatcher {
-	return &MockJobEvalDispatcher{Jobs: make(map[structs.NamespacedID]*structs.Job)}
+	return &MockJobEvalDispatcher{
+		Jobs:      make(map[structs.NamespacedID]*structs.Job),
+		Calendars: make(map[string]*structs.PeriodicCalendar),
+	}
 }
 
 func (m *MockJobEvalDispatcher) DispatchJob(job *structs.Job) (*structs.Evaluation, error) {
@@ -55,6 +59,16 @@ func (m *MockJobEvalDispatcher) RunningChildren(parent *structs.Job) (bool, erro
 	return false, nil
 }
 
+func (m *MockJobEvalDispatcher) PeriodicCalendar(job *structs.Job) (*structs.PeriodicCalendar, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	calendar, ok := m.Calendars[job.Periodic.ExclusionCalendar]
+	if !ok {
+		return nil, fmt.Errorf("exclusion calendar variable %q not found", job.Periodic.ExclusionCalendar)
+	}
+	return calendar, nil
+}
+
 // LaunchTimes returns the launch times of child jobs in sorted order.
 func (m *MockJobEvalDispatcher) LaunchTimes(p *PeriodicDispatch, namespace, parentID string) ([]time.Time, error) {
 	m.lock.Lock()
@@ -460,6 +474,37 @@ func TestPeriodicDispatch_Run_Multiple(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_Run_ExclusionCalendar(t *testing.T) {
+	ci.Parallel(t)
+	p, m := testPeriodicDispatcher(t)
+
+	// Create a job that will be launched twice, with a calendar which
+	// excludes no dates when it is added.
+	launch1 := time.Now().Round(1 * time.Second).Add(1 * time.Second)
+	launch2 := time.Now().Round(1 * time.Second).Add(2 * time.Second)
+	job := testPeriodicJob(launch1, launch2)
+	job.Periodic.ExclusionCalendar = "nomad/jobs/" + job.ID + "/holidays"
+	m.Calendars[job.Periodic.ExclusionCalendar] = nil
+	must.NoError(t, p.Add(job))
+
+	// Exclude the dates of the launches once they are scheduled.
+	calendar, err := structs.ParsePeriodicExclusions([]string{
+		launch1.Format("2006-01-02"),
+		launch2.Format("2006-01-02"),
+	})
+	must.NoError(t, err)
+	m.lock.Lock()
+	m.Calendars[job.Periodic.ExclusionCalendar] = calendar
+	m.lock.Unlock()
+
+	time.Sleep(3 * time.Second)
+
+	// Check that the job was not launched.
+	times, err := m.LaunchTimes(p, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.SliceEmpty(t, times)
+}
+
 func TestPeriodicDispatch_Run_SameTime(t *testing.T) {
 	ci.Parallel(t)
 	p, m := testPeriodicDispatcher(t)
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // planner is used to manage the submitted allocation plans that are waiting
@@ -125,6 +126,10 @@ func (p *planner) planApply() {
 			return
 		}
 
+		// Trace the plan from the time it was enqueued until it is applied
+		_, pending.span = p.srv.startSpan(pending.plan.TraceParent, "Plan.Apply",
+			planSpanAttributes(pending.plan), trace.WithTimestamp(pending.enqueueTime))
+
 		// If last plan has completed get a new snapshot
 		select {
 		case idx := <-planIndexCh:
@@ -169,6 +174,8 @@ func (p *planner) planApply() {
 			continue
 		}
 
+		pending.span.SetAttributes(planResultSpanAttributes(result)...)
+
 		// Check if any of the rejected nodes should be made ineligible.
 		for _, nodeID := range result.RejectedNodes {
 			if p.badNodeTracker.Add(nodeID) {
@@ -251,6 +258,7 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 		DeploymentUpdates: result.DeploymentUpdates,
 		IneligibleNodes:   result.IneligibleNodes,
 		EvalID:            plan.EvalID,
+		ScalingEvents:     plan.ScalingEvents,
 		UpdatedAt:         unixNow,
 	}
 
@@ -12,6 +12,7 @@ import (
 	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -58,6 +59,10 @@ type pendingPlan struct {
 	enqueueTime time.Time
 	result      *structs.PlanResult
 	errCh       chan error
+
+	// span traces the plan from the time it was enqueued until it is
+	// responded to, if the planner started one.
+	span trace.Span
 }
 
 // Wait is used to block for the plan result or potential error
@@ -68,6 +73,9 @@ func (p *pendingPlan) Wait() (*structs.PlanResult, error) {
 
 // respond is used to set the response and error for the future
 func (p *pendingPlan) respond(result *structs.PlanResult, err error) {
+	if p.span != nil {
+		endSpan(p.span, err)
+	}
 	p.result = result
 	p.errCh <- err
 }
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"sync"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/raft"
+	autopilot "github.com/hashicorp/raft-autopilot"
+)
+
+// raftObservationBuffer is the number of Raft observations buffered for the
+// replication tracker before Raft drops them.
+const raftObservationBuffer = 64
+
+// raftReplicationTracker tracks the heartbeats from the leader to each of its
+// followers which have failed, as observed from Raft. The Raft library does
+// not expose the replication state of followers otherwise.
+type raftReplicationTracker struct {
+	failures map[raft.ServerID]uint64
+	l        sync.RWMutex
+}
+
+func newRaftReplicationTracker() *raftReplicationTracker {
+	return &raftReplicationTracker{
+		failures: make(map[raft.ServerID]uint64),
+	}
+}
+
+// run registers the tracker as an observer of r and tracks the observations
+// until shutdownCh is closed.
+func (t *raftReplicationTracker) run(r *raft.Raft, shutdownCh <-chan struct{}) {
+	ch := make(chan raft.Observation, raftObservationBuffer)
+	observer := raft.NewObserver(ch, false, func(o *raft.Observation) bool {
+		switch o.Data.(type) {
+		case raft.FailedHeartbeatObservation, raft.ResumedHeartbeatObservation,
+			raft.LeaderObservation, raft.PeerObservation:
+			return true
+		}
+		return false
+	})
+	r.RegisterObserver(observer)
+	defer r.DeregisterObserver(observer)
+
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case o := <-ch:
+			t.observe(o.Data)
+		}
+	}
+}
+
+func (t *raftReplicationTracker) observe(data interface{}) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	switch o := data.(type) {
+	case raft.FailedHeartbeatObservation:
+		t.failures[o.PeerID]++
+	case raft.ResumedHeartbeatObservation:
+		delete(t.failures, o.PeerID)
+	case raft.LeaderObservation:
+		// failures of the previous leader to reach its followers no longer
+		// apply
+		clear(t.failures)
+	case raft.PeerObservation:
+		if o.Removed {
+			delete(t.failures, o.Peer.ID)
+		}
+	}
+}
+
+// Failures returns the number of consecutive heartbeats to the peer with the
+// given ID which have failed.
+func (t *raftReplicationTracker) Failures(id raft.ServerID) uint64 {
+	t.l.RLock()
+	defer t.l.RUnlock()
+	return t.failures[id]
+}
+
+// raftStatus returns the replication status of the servers in the Raft
+// configuration, with the stats of the autopilot state. It is only accurate on
+// the leader. The state is passed in since autopilot holds its lock while
+// notifying the delegate of state updates.
+func (s *Server) raftStatus(state *autopilot.State) (*structs.RaftStatusResponse, error) {
+	future := s.raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+
+	_, leaderID := s.raft.LeaderWithID()
+	status := &structs.RaftStatusResponse{
+		Term:        s.raft.CurrentTerm(),
+		LastIndex:   s.raft.LastIndex(),
+		CommitIndex: s.raft.CommitIndex(),
+	}
+	status.Servers = raftPeerStatuses(future.Configuration().Servers, leaderID,
+		status.LastIndex, state, s.raftReplication.Failures)
+	return status, nil
+}
+
+// raftPeerStatuses returns the replication status of each of the servers,
+// from the stats autopilot fetched from them and the heartbeat failures
+// tracked by the leader.
+func raftPeerStatuses(servers []raft.Server, leaderID raft.ServerID, lastIndex uint64,
+	state *autopilot.State, failures func(raft.ServerID) uint64) []*structs.RaftPeerStatus {
+
+	statuses := make([]*structs.RaftPeerStatus, 0, len(servers))
+	for _, server := range servers {
+		status := &structs.RaftPeerStatus{
+			ID:      server.ID,
+			Node:    "(unknown)",
+			Address: server.Address,
+			Leader:  server.ID == leaderID,
+			Voter:   server.Suffrage == raft.Voter,
+			State:   structs.RaftPeerStateUnknown,
+		}
+
+		var srv *autopilot.ServerState
+		if state != nil {
+			srv = state.Servers[server.ID]
+		}
+		if srv != nil && srv.Server.Name != "" {
+			status.Node = srv.Server.Name
+		}
+
+		switch {
+		case status.Leader:
+			status.State = structs.RaftPeerStateLeader
+			status.MatchIndex = lastIndex
+		case srv != nil && srv.Stats.LastTerm > 0:
+			status.State = structs.RaftPeerStateReplicating
+			status.LastContact = srv.Stats.LastContact
+			status.MatchIndex = srv.Stats.LastIndex
+			if lastIndex > status.MatchIndex {
+				status.TrailingLogs = lastIndex - status.MatchIndex
+			}
+		}
+
+		if !status.Leader {
+			status.ReplicationFailures = failures(server.ID)
+			if status.ReplicationFailures > 0 {
+				status.State = structs.RaftPeerStateFailing
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// emitRaftReplicationMetrics emits the replication status of each follower
+// as labeled gauges. The stats of followers autopilot has not fetched yet are
+// skipped, but their heartbeat failures are not.
+func emitRaftReplicationMetrics(status *structs.RaftStatusResponse) {
+	for _, peer := range status.Servers {
+		if peer.Leader {
+			continue
+		}
+		labels := []metrics.Label{
+			{Name: "peer_id", Value: string(peer.ID)},
+			{Name: "server_name", Value: peer.Node},
+		}
+		metrics.SetGaugeWithLabels([]string{"nomad", "raft", "replication", "failures"},
+			float32(peer.ReplicationFailures), labels)
+		if peer.MatchIndex == 0 {
+			continue
+		}
+		metrics.SetGaugeWithLabels([]string{"nomad", "raft", "replication", "last_contact"},
+			float32(peer.LastContact.Milliseconds()), labels)
+		metrics.SetGaugeWithLabels([]string{"nomad", "raft", "replication", "match_index"},
+			float32(peer.MatchIndex), labels)
+		metrics.SetGaugeWithLabels([]string{"nomad", "raft", "replication", "trailing_logs"},
+			float32(peer.TrailingLogs), labels)
+	}
+}
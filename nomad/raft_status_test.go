@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	autopilot "github.com/hashicorp/raft-autopilot"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func TestRaftReplicationTracker_observe(t *testing.T) {
+	ci.Parallel(t)
+
+	tracker := newRaftReplicationTracker()
+	tracker.observe(raft.FailedHeartbeatObservation{PeerID: "b"})
+	tracker.observe(raft.FailedHeartbeatObservation{PeerID: "b"})
+	tracker.observe(raft.FailedHeartbeatObservation{PeerID: "c"})
+	tracker.observe(raft.FailedHeartbeatObservation{PeerID: "d"})
+	must.Eq(t, 2, tracker.Failures("b"))
+	must.Eq(t, 1, tracker.Failures("c"))
+	must.Eq(t, 0, tracker.Failures("e"))
+
+	tracker.observe(raft.ResumedHeartbeatObservation{PeerID: "b"})
+	must.Eq(t, 0, tracker.Failures("b"))
+
+	tracker.observe(raft.PeerObservation{Removed: true, Peer: raft.Server{ID: "c"}})
+	must.Eq(t, 0, tracker.Failures("c"))
+	must.Eq(t, 1, tracker.Failures("d"))
+
+	tracker.observe(raft.LeaderObservation{LeaderID: "b"})
+	must.Eq(t, 0, tracker.Failures("d"))
+}
+
+func TestRaft_raftPeerStatuses(t *testing.T) {
+	ci.Parallel(t)
+
+	servers := []raft.Server{
+		{ID: "a", Address: "10.0.0.1:4647", Suffrage: raft.Voter},
+		{ID: "b", Address: "10.0.0.2:4647", Suffrage: raft.Voter},
+		{ID: "c", Address: "10.0.0.3:4647", Suffrage: raft.Voter},
+		{ID: "d", Address: "10.0.0.4:4647", Suffrage: raft.Nonvoter},
+	}
+	state := &autopilot.State{
+		Servers: map[raft.ServerID]*autopilot.ServerState{
+			"a": {
+				Server: autopilot.Server{ID: "a", Name: "server-a"},
+				Stats:  autopilot.ServerStats{LastTerm: 2, LastIndex: 100},
+			},
+			"b": {
+				Server: autopilot.Server{ID: "b", Name: "server-b"},
+				Stats:  autopilot.ServerStats{LastContact: 20 * time.Millisecond, LastTerm: 2, LastIndex: 90},
+			},
+			"c": {
+				Server: autopilot.Server{ID: "c", Name: "server-c"},
+				Stats:  autopilot.ServerStats{LastContact: 5 * time.Second, LastTerm: 2, LastIndex: 40},
+			},
+		},
+	}
+	failures := func(id raft.ServerID) uint64 {
+		if id == "c" || id == "d" {
+			return 3
+		}
+		return 0
+	}
+
+	must.Eq(t, []*structs.RaftPeerStatus{
+		{
+			ID:         "a",
+			Node:       "server-a",
+			Address:    "10.0.0.1:4647",
+			Leader:     true,
+			Voter:      true,
+			State:      structs.RaftPeerStateLeader,
+			MatchIndex: 100,
+		},
+		{
+			ID:           "b",
+			Node:         "server-b",
+			Address:      "10.0.0.2:4647",
+			Voter:        true,
+			State:        structs.RaftPeerStateReplicating,
+			LastContact:  20 * time.Millisecond,
+			MatchIndex:   90,
+			TrailingLogs: 10,
+		},
+		{
+			ID:                  "c",
+			Node:                "server-c",
+			Address:             "10.0.0.3:4647",
+			Voter:               true,
+			State:               structs.RaftPeerStateFailing,
+			LastContact:         5 * time.Second,
+			MatchIndex:          40,
+			TrailingLogs:        60,
+			ReplicationFailures: 3,
+		},
+		{
+			ID:                  "d",
+			Node:                "(unknown)",
+			Address:             "10.0.0.4:4647",
+			State:               structs.RaftPeerStateFailing,
+			ReplicationFailures: 3,
+		},
+	}, raftPeerStatuses(servers, "a", 100, state, failures))
+
+	// without autopilot state the followers' stats are unknown
+	statuses := raftPeerStatuses(servers[:2], "a", 100, nil, failures)
+	must.Eq(t, structs.RaftPeerStateLeader, statuses[0].State)
+	must.Eq(t, structs.RaftPeerStateUnknown, statuses[1].State)
+	must.Eq(t, 0, statuses[1].TrailingLogs)
+}
@@ -599,7 +599,23 @@ func (r *rpcHandler) forward(method string, info structs.RPCInfo, args interface
 
 	// Check if we can allow a stale read
 	if info.IsRead() && info.AllowStaleRead() {
-		return false, nil
+		maxStale := info.MaxStaleRead()
+		if maxStale <= 0 || r.srv.staleness() <= maxStale {
+			return false, nil
+		}
+
+		// This server is too stale to serve the read, so fall back to
+		// forwarding it to the leader.
+		remoteServer, err := r.getLeaderForRPC()
+		if err != nil {
+			return true, structs.ErrMaxStaleExceeded
+		}
+		if remoteServer == nil {
+			return false, nil
+		}
+		info.SetForwarded()
+		err = r.forwardLeader(remoteServer, method, args, reply)
+		return true, err
 	}
 
 	remoteServer, err := r.getLeaderForRPC()
@@ -838,12 +854,31 @@ func (r *rpcHandler) setQueryMeta(m *structs.QueryMeta) {
 		m.LastContact = 0
 		m.KnownLeader = true
 	} else {
-		m.LastContact = time.Since(r.srv.raft.LastContact())
+		m.LastContact = r.srv.staleness()
 		leaderAddr, _ := r.srv.raft.LeaderWithID()
 		m.KnownLeader = (leaderAddr != "")
 	}
 }
 
+// staleness returns an estimate of how stale the state of this server is,
+// which bounds stale reads and is reported as the LastContact of queries. It
+// is zero on the leader. On followers it is the time since the last contact
+// with the leader, or since the last applied log if the follower is behind on
+// applying committed logs and that is longer.
+func (s *Server) staleness() time.Duration {
+	if s.IsLeader() {
+		return 0
+	}
+
+	stale := time.Since(s.raft.LastContact())
+	if s.raft.AppliedIndex() < s.raft.CommitIndex() {
+		if lag := time.Since(s.fsm.LastAppliedAt()); lag > stale {
+			stale = lag
+		}
+	}
+	return stale
+}
+
 // queryFn is used to perform a query operation. If a re-query is needed, the
 // passed-in watch set will be used to block for changes. The passed-in state
 // store should be used (vs. calling fsm.State()) since the given state store
@@ -149,6 +149,84 @@ func TestRPC_forwardLeader(t *testing.T) {
 	}
 }
 
+func TestRPC_forward_MaxStale(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS1()
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	leader, follower := s1, s2
+	if !s1.IsLeader() {
+		leader, follower = s2, s1
+	}
+	must.Zero(t, leader.staleness())
+
+	stale := func(maxStale time.Duration) *structs.GenericRequest {
+		return &structs.GenericRequest{
+			QueryOptions: structs.QueryOptions{
+				Region:           follower.Region(),
+				AllowStale:       true,
+				MaxStaleDuration: maxStale,
+			},
+		}
+	}
+
+	// a stale read without a bound is served by the follower
+	args := stale(0)
+	done, err := follower.forward("Status.Ping", args, args, &struct{}{})
+	must.NoError(t, err)
+	must.False(t, done)
+
+	// as is a stale read within the bound
+	args = stale(time.Hour)
+	done, err = follower.forward("Status.Ping", args, args, &struct{}{})
+	must.NoError(t, err)
+	must.False(t, done)
+
+	// a stale read the follower is too stale for is forwarded to the leader
+	args = stale(time.Nanosecond)
+	done, err = follower.forward("Status.Ping", args, args, &struct{}{})
+	must.NoError(t, err)
+	must.True(t, done)
+	must.True(t, args.IsForwarded())
+
+	// the leader serves it
+	done, err = leader.forward("Status.Ping", args, args, &struct{}{})
+	must.NoError(t, err)
+	must.False(t, done)
+}
+
+func TestRPC_forward_MaxStale_NoLeader(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+		c.RPCHoldTimeout = 20 * time.Millisecond
+	})
+	defer cleanupS1()
+
+	args := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:           s1.Region(),
+			AllowStale:       true,
+			MaxStaleDuration: time.Second,
+		},
+	}
+	done, err := s1.forward("Status.Ping", args, args, &struct{}{})
+	must.True(t, done)
+	must.ErrorIs(t, err, structs.ErrMaxStaleExceeded)
+	must.True(t, structs.IsErrMaxStaleExceeded(err))
+}
+
 func TestRPC_WaitForConsistentReads(t *testing.T) {
 	ci.Parallel(t)
 
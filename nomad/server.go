@@ -31,6 +31,7 @@ import (
 	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
 	"github.com/hashicorp/serf/serf"
 	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/helper"
@@ -301,6 +302,14 @@ type Server struct {
 	// Nomad router.
 	statsFetcher *StatsFetcher
 
+	// tracer records the spans of job registrations and the evaluations and
+	// plans which follow them, if tracing is enabled.
+	tracer trace.Tracer
+
+	// raftReplication tracks the failures of the leader to replicate to
+	// each of its followers, for the Raft status endpoint.
+	raftReplication *raftReplicationTracker
+
 	// reportingManager is used to configure and handle all the license reporting
 	// dependencies.
 	reportingManager *reporting.Manager
@@ -375,6 +384,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
 	s.shutdownCh = s.shutdownCtx.Done()
 
+	// Create the tracer, which records nothing unless tracing is enabled
+	s.tracer = newTracer(config.TracerProvider)
+
 	// Generate a timeout context for the server process which we wait for and
 	// can time out on.
 	startupTimeout, startupCancel := context.WithTimeout(s.shutdownCtx, s.config.StartTimeout)
@@ -1288,6 +1300,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	// These endpoints are client RPCs and don't include a connection context
 	_ = server.Register(NewClientStatsEndpoint(s))
 	_ = server.Register(newNodeMetaEndpoint(s))
+	_ = server.Register(newArtifactCacheEndpoint(s))
 	_ = server.Register(newNodeIdentityEndpoint(s))
 
 	// These endpoints have their streaming component registered in
@@ -1346,15 +1359,16 @@ func (s *Server) setupRaft() error {
 
 	// Create the FSM
 	fsmConfig := &FSMConfig{
-		EvalBroker:         s.evalBroker,
-		Periodic:           s.periodicDispatcher,
-		Blocked:            s.blockedEvals,
-		Encrypter:          s.encrypter,
-		Logger:             s.logger,
-		Region:             s.Region(),
-		EnableEventBroker:  s.config.EnableEventBroker,
-		EventBufferSize:    s.config.EventBufferSize,
-		JobTrackedVersions: s.config.JobTrackedVersions,
+		EvalBroker:           s.evalBroker,
+		Periodic:             s.periodicDispatcher,
+		Blocked:              s.blockedEvals,
+		Encrypter:            s.encrypter,
+		Logger:               s.logger,
+		Region:               s.Region(),
+		EnableEventBroker:    s.config.EnableEventBroker,
+		EventBufferSize:      s.config.EventBufferSize,
+		JobTrackedVersions:   s.config.JobTrackedVersions,
+		JobMaxVersionsPerJob: s.config.JobMaxVersionsPerJob,
 	}
 
 	var err error
@@ -1532,6 +1546,9 @@ func (s *Server) setupRaft() error {
 	if err != nil {
 		return err
 	}
+
+	s.raftReplication = newRaftReplicationTracker()
+	go s.raftReplication.run(s.raft, s.shutdownCh)
 	return nil
 }
 
@@ -29,7 +29,7 @@ func (s *Server) setupEnterprise(config *Config) error {
 		autopilot.WithLogger(s.logger),
 		autopilot.WithReconcileInterval(config.AutopilotInterval),
 		autopilot.WithUpdateInterval(config.ServerHealthInterval),
-		autopilot.WithPromoter(s.autopilotPromoter()),
+		autopilot.WithPromoter(newHealthCheckPromoter(s, s.autopilotPromoter())),
 	)
 
 	return nil
@@ -373,6 +373,15 @@ func (s *ServiceRegistration) GetService(
 				return err
 			}
 
+			// Skip the registrations of unhealthy allocations if requested.
+			if args.Healthy {
+				iter = memdb.NewFilterIterator(iter, func(raw interface{}) bool {
+					reg := raw.(*structs.ServiceRegistration)
+					alloc, err := stateStore.AllocByID(ws, reg.AllocID)
+					return err != nil || !serviceRegistrationHealthy(alloc)
+				})
+			}
+
 			pager, err := paginator.NewPaginator(iter, args.QueryOptions, nil,
 				paginator.NamespaceIDTokenizer[*structs.ServiceRegistration](args.NextToken),
 				(*structs.ServiceRegistration).Stub)
@@ -403,11 +412,38 @@ func (s *ServiceRegistration) GetService(
 
 			// Use the index table to populate the query meta as we have no way
 			// of tracking the max index on deletes.
-			return s.srv.setReplyQueryMeta(stateStore, state.TableServiceRegistrations, &reply.QueryMeta)
+			if err := s.srv.setReplyQueryMeta(stateStore, state.TableServiceRegistrations, &reply.QueryMeta); err != nil {
+				return err
+			}
+
+			// The health of the registrations changes with their allocations,
+			// so include the index of the allocations for blocking queries to
+			// return once it changes.
+			if args.Healthy {
+				allocsIndex, err := stateStore.Index(state.TableAllocs)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(reply.Index, allocsIndex)
+			}
+			return nil
 		},
 	})
 }
 
+// serviceRegistrationHealthy returns whether the services registered by alloc
+// are healthy: the allocation is running and, if it is part of a deployment,
+// reported healthy.
+func serviceRegistrationHealthy(alloc *structs.Allocation) bool {
+	if alloc == nil || alloc.ClientStatus != structs.AllocClientStatusRunning {
+		return false
+	}
+	if alloc.DeploymentStatus.HasHealth() {
+		return alloc.DeploymentStatus.IsHealthy()
+	}
+	return alloc.DeploymentID == ""
+}
+
 // choose uses rendezvous hashing to make a stable selection of a subset of services
 // to return.
 //
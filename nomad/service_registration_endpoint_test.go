@@ -12,6 +12,7 @@ import (
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -1419,6 +1420,86 @@ func TestServiceRegistration_GetService(t *testing.T) {
 				must.Eq(t, "10.0.0.1", result[1].Address)
 			},
 		},
+		{
+			name: "healthy",
+			serverFn: func(t *testing.T) (*Server, *structs.ACLToken, func()) {
+				server, cleanup := TestServer(t, nil)
+				return server, nil, cleanup
+			},
+			testFn: func(t *testing.T, s *Server, _ *structs.ACLToken) {
+				codec := rpcClient(t, s)
+				testutil.WaitForKeyring(t, s.RPC, "global")
+
+				// a running allocation outside of a deployment is healthy
+				running := mock.Alloc()
+				running.ClientStatus = structs.AllocClientStatusRunning
+				running.DeploymentID = ""
+
+				pending := mock.Alloc()
+				pending.ClientStatus = structs.AllocClientStatusPending
+				pending.DeploymentID = ""
+
+				// a running allocation of a deployment is healthy once reported
+				unhealthy := mock.Alloc()
+				unhealthy.ClientStatus = structs.AllocClientStatusRunning
+				unhealthy.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(false)}
+
+				deployed := mock.Alloc()
+				deployed.ClientStatus = structs.AllocClientStatusRunning
+				deployed.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+
+				allocs := []*structs.Allocation{running, pending, unhealthy, deployed}
+				must.NoError(t, s.fsm.State().UpsertAllocs(structs.MsgTypeTestSetup, 10, allocs))
+
+				var services []*structs.ServiceRegistration
+				for i, alloc := range allocs {
+					services = append(services, &structs.ServiceRegistration{
+						ID:          fmt.Sprintf("id_%d", i),
+						Namespace:   structs.DefaultNamespace,
+						ServiceName: "s1",
+						NodeID:      alloc.NodeID,
+						Datacenter:  "dc1",
+						JobID:       alloc.JobID,
+						AllocID:     alloc.ID,
+						Address:     fmt.Sprintf("10.0.0.%d", i),
+						Port:        9001,
+					})
+				}
+				must.NoError(t, s.fsm.State().UpsertServiceRegistrations(structs.MsgTypeTestSetup, 20, services))
+
+				serviceRegReq := &structs.ServiceRegistrationByNameRequest{
+					ServiceName: "s1",
+					Healthy:     true,
+					QueryOptions: structs.QueryOptions{
+						Namespace: structs.DefaultNamespace,
+						Region:    DefaultRegion,
+					},
+				}
+				var serviceRegResp structs.ServiceRegistrationByNameResponse
+				err := msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				must.NoError(t, err)
+				must.Eq(t, uint64(20), serviceRegResp.Index)
+
+				result := serviceRegResp.Services
+				must.Len(t, 2, result)
+				must.Eq(t, running.ID, result[0].AllocID)
+				must.Eq(t, deployed.ID, result[1].AllocID)
+
+				// the health of the allocations is part of the index
+				pending = pending.Copy()
+				pending.ClientStatus = structs.AllocClientStatusRunning
+				must.NoError(t, s.fsm.State().UpdateAllocsFromClient(
+					structs.MsgTypeTestSetup, 30, []*structs.Allocation{pending}))
+
+				serviceRegReq.MinQueryIndex = 20
+				err = msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				must.NoError(t, err)
+				must.Eq(t, uint64(30), serviceRegResp.Index)
+				must.Len(t, 3, serviceRegResp.Services)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -64,11 +64,43 @@ func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 			event.Index = changes.Index
 			events = append(events, event)
 		}
+		events = append(events, nodeEventsFromChange(change, changes.Index)...)
 	}
 
 	return &structs.Events{Index: changes.Index, Events: events}
 }
 
+// nodeEventsFromChange returns an event for each NodeEvent added to a Node at
+// the given index, so subscribers can follow why a node's status, eligibility
+// or drain changed without diffing the node's event list.
+func nodeEventsFromChange(change memdb.Change, index uint64) []structs.Event {
+	if change.Table != "nodes" || change.Deleted() {
+		return nil
+	}
+	after, ok := change.After.(*structs.Node)
+	if !ok {
+		return nil
+	}
+
+	var events []structs.Event
+	for _, nodeEvent := range after.Events {
+		if nodeEvent.CreateIndex != index {
+			continue
+		}
+		events = append(events, structs.Event{
+			Topic: structs.TopicNode,
+			Type:  structs.TypeNodeEventAdded,
+			Key:   after.ID,
+			Index: index,
+			Payload: &structs.NodeEventStreamEvent{
+				NodeID:    after.ID,
+				NodeEvent: nodeEvent.Copy(),
+			},
+		})
+	}
+	return events
+}
+
 func eventFromChange(change memdb.Change) (structs.Event, bool) {
 	if change.Deleted() {
 		switch change.Table {
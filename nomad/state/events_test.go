@@ -347,13 +347,20 @@ func TestEventsFromChanges_UpsertNodeEventsType(t *testing.T) {
 
 	must.NoError(t, s.UpsertNodeEvents(msgType, 100, req.NodeEvents))
 	events := WaitForEvents(t, s, 100, 1, 1*time.Second)
-	must.Len(t, 2, events)
+	must.Len(t, 4, events)
 
 	for _, e := range events {
 		must.Eq(t, structs.TopicNode, e.Topic)
-		must.Eq(t, structs.TypeNodeEvent, e.Type)
-		event := e.Payload.(*structs.NodeStreamEvent)
-		must.Eq(t, "update", event.Node.Events[len(event.Node.Events)-1].Message)
+		switch e.Type {
+		case structs.TypeNodeEvent:
+			event := e.Payload.(*structs.NodeStreamEvent)
+			must.Eq(t, "update", event.Node.Events[len(event.Node.Events)-1].Message)
+		case structs.TypeNodeEventAdded:
+			event := e.Payload.(*structs.NodeEventStreamEvent)
+			must.Eq(t, "update", event.NodeEvent.Message)
+		default:
+			t.Fatalf("unexpected event type %q", e.Type)
+		}
 	}
 
 }
@@ -379,7 +386,7 @@ func TestEventsFromChanges_NodeUpdateStatusRequest(t *testing.T) {
 
 	must.NoError(t, s.UpdateNodeStatus(msgType, 100, req))
 	events := WaitForEvents(t, s, 100, 1, 1*time.Second)
-	must.Len(t, 1, events)
+	must.Len(t, 2, events)
 
 	e := events[0]
 	must.Eq(t, structs.TopicNode, e.Topic)
@@ -387,6 +394,14 @@ func TestEventsFromChanges_NodeUpdateStatusRequest(t *testing.T) {
 	event := e.Payload.(*structs.NodeStreamEvent)
 	must.Eq(t, "down", event.Node.Events[len(event.Node.Events)-1].Message)
 	must.Eq(t, structs.NodeStatusDown, event.Node.Status)
+
+	e = events[1]
+	must.Eq(t, structs.TopicNode, e.Topic)
+	must.Eq(t, structs.TypeNodeEventAdded, e.Type)
+	must.Eq(t, n1.ID, e.Key)
+	added := e.Payload.(*structs.NodeEventStreamEvent)
+	must.Eq(t, n1.ID, added.NodeID)
+	must.Eq(t, "down", added.NodeEvent.Message)
 }
 
 func TestEventsFromChanges_NodePoolUpsertRequestType(t *testing.T) {
@@ -584,12 +599,17 @@ func TestEventsFromChanges_BatchNodeUpdateDrainRequestType(t *testing.T) {
 	must.NoError(t, s.BatchUpdateNodeDrain(msgType, 100, req.UpdatedAt, req.Updates, req.NodeEvents))
 
 	events := WaitForEvents(t, s, 100, 1, 1*time.Second)
-	must.Len(t, 2, events)
+	must.Len(t, 4, events)
 
 	for _, e := range events {
 		must.Eq(t, 100, int(e.Index))
-		must.Eq(t, structs.TypeNodeDrain, e.Type)
 		must.Eq(t, structs.TopicNode, e.Topic)
+		if e.Type == structs.TypeNodeEventAdded {
+			ne := e.Payload.(*structs.NodeEventStreamEvent)
+			must.Eq(t, event.Message, ne.NodeEvent.Message)
+			continue
+		}
+		must.Eq(t, structs.TypeNodeDrain, e.Type)
 		ne := e.Payload.(*structs.NodeStreamEvent)
 		must.Eq(t, event.Message, ne.Node.Events[len(ne.Node.Events)-1].Message)
 	}
@@ -608,9 +628,11 @@ func TestEventsFromChanges_NodeUpdateEligibilityRequestType(t *testing.T) {
 	msgType := structs.NodeUpdateEligibilityRequestType
 
 	event := &structs.NodeEvent{
-		Message:   "Node marked as ineligible",
-		Subsystem: structs.NodeEventSubsystemCluster,
-		Timestamp: time.Now(),
+		Message:    "Node marked as ineligible",
+		Subsystem:  structs.NodeEventSubsystemCluster,
+		Timestamp:  time.Now(),
+		Actor:      "operator",
+		AccessorID: "b3b5a3b8-8d1b-4cd5-9e4c-0d4ac1d6a6e4",
 	}
 
 	req := structs.NodeUpdateEligibilityRequest{
@@ -623,16 +645,26 @@ func TestEventsFromChanges_NodeUpdateEligibilityRequestType(t *testing.T) {
 	must.NoError(t, s.UpdateNodeEligibility(msgType, 100, req.NodeID, req.Eligibility, req.UpdatedAt, req.NodeEvent))
 
 	events := WaitForEvents(t, s, 100, 1, 1*time.Second)
-	must.Len(t, 1, events)
+	must.Len(t, 2, events)
 
-	for _, e := range events {
-		must.Eq(t, 100, int(e.Index))
-		must.Eq(t, structs.TypeNodeDrain, e.Type)
-		must.Eq(t, structs.TopicNode, e.Topic)
-		ne := e.Payload.(*structs.NodeStreamEvent)
-		must.Eq(t, event.Message, ne.Node.Events[len(ne.Node.Events)-1].Message)
-		must.Eq(t, structs.NodeSchedulingIneligible, ne.Node.SchedulingEligibility)
-	}
+	e := events[0]
+	must.Eq(t, 100, int(e.Index))
+	must.Eq(t, structs.TypeNodeDrain, e.Type)
+	must.Eq(t, structs.TopicNode, e.Topic)
+	ne := e.Payload.(*structs.NodeStreamEvent)
+	must.Eq(t, event.Message, ne.Node.Events[len(ne.Node.Events)-1].Message)
+	must.Eq(t, structs.NodeSchedulingIneligible, ne.Node.SchedulingEligibility)
+
+	// The node event is also published on its own along with its actor
+	e = events[1]
+	must.Eq(t, 100, int(e.Index))
+	must.Eq(t, structs.TypeNodeEventAdded, e.Type)
+	must.Eq(t, structs.TopicNode, e.Topic)
+	added := e.Payload.(*structs.NodeEventStreamEvent)
+	must.Eq(t, n1.ID, added.NodeID)
+	must.Eq(t, event.Message, added.NodeEvent.Message)
+	must.Eq(t, "operator", added.NodeEvent.Actor)
+	must.Eq(t, "b3b5a3b8-8d1b-4cd5-9e4c-0d4ac1d6a6e4", added.NodeEvent.AccessorID)
 }
 
 func TestEventsFromChanges_AllocUpdateDesiredTransitionRequestType(t *testing.T) {
@@ -904,6 +936,8 @@ func TestNodeEventsFromChanges(t *testing.T) {
 						Node: testNode(),
 					},
 				},
+				nodeEventAdded(testNodeID(), "test event one"),
+				nodeEventAdded(testNodeID(), "test event two"),
 				{
 					Topic: structs.TopicNode,
 					Type:  structs.TypeNodeEvent,
@@ -913,6 +947,8 @@ func TestNodeEventsFromChanges(t *testing.T) {
 						Node: testNode(nodeIDTwo),
 					},
 				},
+				nodeEventAdded(testNodeIDTwo(), "test event one"),
+				nodeEventAdded(testNodeIDTwo(), "test event two"),
 			},
 		},
 	}
@@ -952,7 +988,12 @@ func TestNodeEventsFromChanges(t *testing.T) {
 				case structs.NodeDeregisterRequestType:
 					requireNodeDeregistrationEventEqual(t, tc.WantEvents[idx], g)
 				case structs.UpsertNodeEventsType:
-					requireNodeEventCount(t, 3, g)
+					must.Eq(t, want.Type, g.Type)
+					if g.Type == structs.TypeNodeEventAdded {
+						requireNodeEventAddedEqual(t, want, g)
+					} else {
+						requireNodeEventCount(t, 3, g)
+					}
 				default:
 					t.Fatal("unhandled message type")
 				}
@@ -997,7 +1038,8 @@ func TestNodeDrainEventFromChanges(t *testing.T) {
 	changes := Changes{Changes: tx.Changes(), Index: 100, MsgType: structs.NodeUpdateDrainRequestType}
 	got := eventsFromChanges(tx, changes)
 
-	must.Len(t, 1, got.Events)
+	must.Len(t, 2, got.Events)
+	must.Eq(t, structs.TypeNodeEventAdded, got.Events[1].Type)
 
 	must.Eq(t, structs.TopicNode, got.Events[0].Topic)
 	must.Eq(t, structs.TypeNodeDrain, got.Events[0].Type)
@@ -1361,6 +1403,30 @@ func requireNodeEventCount(t *testing.T, want int, got structs.Event) {
 	must.Len(t, want, gotPayload.Node.Events)
 }
 
+func requireNodeEventAddedEqual(t *testing.T, want, got structs.Event) {
+	t.Helper()
+
+	wantPayload := want.Payload.(*structs.NodeEventStreamEvent)
+	gotPayload := got.Payload.(*structs.NodeEventStreamEvent)
+
+	must.Eq(t, wantPayload.NodeID, gotPayload.NodeID)
+	must.Eq(t, wantPayload.NodeEvent.Message, gotPayload.NodeEvent.Message)
+	must.Eq(t, got.Index, gotPayload.NodeEvent.CreateIndex)
+}
+
+func nodeEventAdded(nodeID, msg string) structs.Event {
+	return structs.Event{
+		Topic: structs.TopicNode,
+		Type:  structs.TypeNodeEventAdded,
+		Key:   nodeID,
+		Index: 100,
+		Payload: &structs.NodeEventStreamEvent{
+			NodeID:    nodeID,
+			NodeEvent: &structs.NodeEvent{Message: msg},
+		},
+	}
+}
+
 type nodeOpts func(n *structs.Node)
 
 func nodeNotReady(n *structs.Node) {
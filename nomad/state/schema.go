@@ -31,6 +31,7 @@ const (
 	TableCSIVolumes               = "csi_volumes"
 	TableCSIPlugins               = "csi_plugins"
 	TableTaskGroupHostVolumeClaim = "task_volume"
+	TableMaintenanceWindows       = "maintenance_windows"
 )
 
 const (
@@ -104,6 +105,7 @@ func init() {
 		bindingRulesTableSchema,
 		hostVolumeTableSchema,
 		taskGroupHostVolumeClaimSchema,
+		maintenanceWindowTableSchema,
 	}...)
 }
 
@@ -208,6 +210,27 @@ func nodePoolTableSchema() *memdb.TableSchema {
 	}
 }
 
+// maintenanceWindowTableSchema returns the MemDB schema for the maintenance
+// windows table. This table is used to store the maintenance windows
+// registered by operators.
+func maintenanceWindowTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableMaintenanceWindows,
+		Indexes: map[string]*memdb.IndexSchema{
+			// Name is the primary index used for lookup and is required to be
+			// unique.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
 // jobTableSchema returns the MemDB schema for the jobs table.
 // This table is used to store all the jobs that have been submitted.
 func jobTableSchema() *memdb.TableSchema {
@@ -261,6 +284,14 @@ func jobTableSchema() *memdb.TableSchema {
 					Conditional: jobIsPeriodic,
 				},
 			},
+			"replicated": {
+				Name:         "replicated",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: jobIsReplicated,
+				},
+			},
 			"pool": {
 				Name:         "pool",
 				AllowMissing: false,
@@ -437,6 +468,17 @@ func jobIsPeriodic(obj interface{}) (bool, error) {
 	return false, nil
 }
 
+// jobIsReplicated satisfies the ConditionalIndexFunc interface and creates an
+// index of running jobs with task groups replicated per node attribute.
+func jobIsReplicated(obj interface{}) (bool, error) {
+	j, ok := obj.(*structs.Job)
+	if !ok {
+		return false, fmt.Errorf("Unexpected type: %v", obj)
+	}
+
+	return !j.Stop && !j.RequiredReplicatePer().Empty(), nil
+}
+
 // deploymentSchema returns the MemDB schema tracking a job's deployments
 func deploymentSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"sort"
@@ -91,6 +92,11 @@ type StateStoreConfig struct {
 
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
+
+	// JobMaxVersionsPerJob is the number of untagged versions of a job that
+	// are kept when it does not set a version retention. If zero,
+	// JobTrackedVersions is used.
+	JobMaxVersionsPerJob int
 }
 
 func (c *StateStoreConfig) Validate() error {
@@ -389,7 +395,8 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 
 		nodeEvent := structs.NewNodeEvent().
 			SetSubsystem(structs.NodeEventSubsystemScheduler).
-			SetMessage(NodeEligibilityEventPlanRejectThreshold)
+			SetMessage(NodeEligibilityEventPlanRejectThreshold).
+			SetActor(structs.NodeEventActorScheduler)
 
 		err := s.updateNodeEligibilityImpl(index, nodeID,
 			structs.NodeSchedulingIneligible, results.UpdatedAt, nodeEvent, txn)
@@ -460,6 +467,21 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 		}
 	}
 
+	// Record the count changes of task groups the scheduler computed
+	if results.Job != nil {
+		for _, group := range slices.Sorted(maps.Keys(results.ScalingEvents)) {
+			req := &structs.ScalingEventRequest{
+				Namespace:    results.Job.Namespace,
+				JobID:        results.Job.ID,
+				TaskGroup:    group,
+				ScalingEvent: results.ScalingEvents[group],
+			}
+			if err := s.upsertScalingEventImpl(index, req, txn); err != nil {
+				return err
+			}
+		}
+	}
+
 	return txn.Commit()
 }
 
@@ -877,6 +899,15 @@ func (s *StateStore) UpsertScalingEvent(index uint64, req *structs.ScalingEventR
 	txn := s.db.WriteTxn(index)
 	defer txn.Abort()
 
+	if err := s.upsertScalingEventImpl(index, req, txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// upsertScalingEventImpl is used to insert a new scaling event within the
+// given transaction.
+func (s *StateStore) upsertScalingEventImpl(index uint64, req *structs.ScalingEventRequest, txn *txn) error {
 	// Get the existing events
 	existing, err := txn.First("scaling_event", "id", req.Namespace, req.JobID)
 	if err != nil {
@@ -895,19 +926,29 @@ func (s *StateStore) UpsertScalingEvent(index uint64, req *structs.ScalingEventR
 	}
 
 	jobEvents.ModifyIndex = index
-	req.ScalingEvent.CreateIndex = index
 
-	events := jobEvents.ScalingEvents[req.TaskGroup]
-	// Prepend this latest event
-	events = append(
-		[]*structs.ScalingEvent{req.ScalingEvent},
-		events...,
-	)
-	// Truncate older events
-	if len(events) > structs.JobTrackedScalingEvents {
-		events = events[0:structs.JobTrackedScalingEvents]
+	groupEvents := maps.Clone(req.ScalingEvents)
+	if req.ScalingEvent != nil {
+		if groupEvents == nil {
+			groupEvents = make(map[string]*structs.ScalingEvent, 1)
+		}
+		groupEvents[req.TaskGroup] = req.ScalingEvent
+	}
+	for group, event := range groupEvents {
+		event.CreateIndex = index
+
+		events := jobEvents.ScalingEvents[group]
+		// Prepend this latest event
+		events = append(
+			[]*structs.ScalingEvent{event},
+			events...,
+		)
+		// Truncate older events
+		if len(events) > structs.JobTrackedScalingEvents {
+			events = events[0:structs.JobTrackedScalingEvents]
+		}
+		jobEvents.ScalingEvents[group] = events
 	}
-	jobEvents.ScalingEvents[req.TaskGroup] = events
 
 	// Insert the new event
 	if err := txn.Insert("scaling_event", jobEvents); err != nil {
@@ -919,7 +960,7 @@ func (s *StateStore) UpsertScalingEvent(index uint64, req *structs.ScalingEventR
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
-	return txn.Commit()
+	return nil
 }
 
 // ScalingEvents returns an iterator over all the job scaling events
@@ -2197,12 +2238,17 @@ func (s *StateStore) upsertJobVersion(index uint64, job *structs.Job, txn *txn)
 		return fmt.Errorf("failed to look up job versions for %q: %v", job.ID, err)
 	}
 
+	max, err := s.jobVersionRetention(txn, job.Namespace, job.ID)
+	if err != nil {
+		return err
+	}
+
 	// If we are below the limit there is no GCing to be done
-	if len(all) <= s.config.JobTrackedVersions {
+	if len(all) <= max {
 		return nil
 	}
 
-	// We have to delete a historic job to make room.
+	// We have to delete historic jobs to make room.
 	// Find index of the highest versioned stable job
 	stableIdx := -1
 	for i, j := range all {
@@ -2212,22 +2258,47 @@ func (s *StateStore) upsertJobVersion(index uint64, job *structs.Job, txn *txn)
 		}
 	}
 
-	// If the stable job is the oldest version, do a swap to bring it into the
-	// keep set.
-	max := s.config.JobTrackedVersions
-	if stableIdx == max {
-		all[max-1], all[max] = all[max], all[max-1]
+	// If the stable job is beyond the limit, do a swap to bring it into the
+	// keep set. The latest version is the job itself and is always kept, so
+	// when only one version is kept the stable job is kept in addition to it,
+	// as its allocations may still be running.
+	if stableIdx >= max {
+		if max == 1 {
+			max = 2
+		}
+		all[max-1], all[stableIdx] = all[stableIdx], all[max-1]
 	}
 
-	// Delete the oldest one
-	d := all[max]
-	if err := txn.Delete("job_version", d); err != nil {
-		return fmt.Errorf("failed to delete job %v (%d) from job_version", d.ID, d.Version)
+	// Delete the oldest ones. Usually a single version is beyond the limit,
+	// but there are more once the retention of the job is lowered.
+	for _, d := range all[max:] {
+		if err := txn.Delete("job_version", d); err != nil {
+			return fmt.Errorf("failed to delete job %v (%d) from job_version", d.ID, d.Version)
+		}
 	}
 
 	return nil
 }
 
+// jobVersionRetention returns the number of untagged versions of the job
+// which are kept, which is the version_retention of the latest version of the
+// job if set, or otherwise JobMaxVersionsPerJob, or JobTrackedVersions if that
+// is not set either. It is always at least one, so that the latest version of
+// the job is kept.
+func (s *StateStore) jobVersionRetention(txn *txn, namespace, id string) (int, error) {
+	all, err := s.jobVersionByID(txn, nil, namespace, id, true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up job versions for %q: %v", id, err)
+	}
+	if len(all) > 0 && all[0].VersionRetention > 0 {
+		return all[0].VersionRetention, nil
+	}
+	if s.config.JobMaxVersionsPerJob > 0 {
+		return s.config.JobMaxVersionsPerJob, nil
+	}
+	return max(s.config.JobTrackedVersions, 1), nil
+}
+
 // GetJobSubmissions returns an iterator that contains all job submissions
 // stored within state. This is not currently exposed via RPC and is only used
 // for snapshot persist and restore functionality.
@@ -2479,6 +2550,21 @@ func (s *StateStore) JobsByPeriodic(ws memdb.WatchSet, periodic bool) (memdb.Res
 	return iter, nil
 }
 
+// JobsByReplicated returns an iterator over all the running jobs with or
+// without task groups replicated per node attribute.
+func (s *StateStore) JobsByReplicated(ws memdb.WatchSet, replicated bool) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("jobs", "replicated", replicated)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // JobsByScheduler returns an iterator over all the jobs with the specific
 // scheduler type.
 func (s *StateStore) JobsByScheduler(ws memdb.WatchSet, schedulerType string) (memdb.ResultIterator, error) {
@@ -5730,7 +5816,10 @@ func (s *StateStore) pruneJobSubmissions(namespace, jobID string, txn *txn) erro
 	// although the number of tracked submissions is the same as the number of
 	// tracked job versions, do not assume a 1:1 correlation, as there could be
 	// holes in the submissions (or none at all)
-	limit := s.config.JobTrackedVersions
+	limit, err := s.jobVersionRetention(txn, namespace, jobID)
+	if err != nil {
+		return err
+	}
 
 	// iterate through all stored submissions
 	iter, err := txn.Get("job_submission", "id_prefix", namespace, jobID)
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// MaintenanceWindows returns an iterator over all maintenance windows.
+func (s *StateStore) MaintenanceWindows(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableMaintenanceWindows, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance windows lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// MaintenanceWindowByName returns the maintenance window that matches the
+// given name or nil if there is no match.
+func (s *StateStore) MaintenanceWindowByName(ws memdb.WatchSet, name string) (*structs.MaintenanceWindow, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableMaintenanceWindows, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance window lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+
+	return existing.(*structs.MaintenanceWindow), nil
+}
+
+// UpsertMaintenanceWindows inserts or updates the given set of maintenance
+// windows.
+func (s *StateStore) UpsertMaintenanceWindows(msgType structs.MessageType, index uint64, windows []*structs.MaintenanceWindow) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, window := range windows {
+		if window == nil {
+			continue
+		}
+
+		existing, err := txn.First(TableMaintenanceWindows, indexID, window.Name)
+		if err != nil {
+			return fmt.Errorf("maintenance window lookup failed: %w", err)
+		}
+
+		if existing != nil {
+			window.CreateIndex = existing.(*structs.MaintenanceWindow).CreateIndex
+		} else {
+			window.CreateIndex = index
+		}
+		window.ModifyIndex = index
+
+		if err := txn.Insert(TableMaintenanceWindows, window); err != nil {
+			return fmt.Errorf("maintenance window insert failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableMaintenanceWindows, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteMaintenanceWindows removes the given set of maintenance windows.
+func (s *StateStore) DeleteMaintenanceWindows(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		existing, err := txn.First(TableMaintenanceWindows, indexID, name)
+		if err != nil {
+			return fmt.Errorf("maintenance window lookup failed: %w", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("maintenance window %q not found", name)
+		}
+
+		if err := txn.Delete(TableMaintenanceWindows, existing); err != nil {
+			return fmt.Errorf("maintenance window deletion failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableMaintenanceWindows, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_MaintenanceWindows_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	windows := []*structs.MaintenanceWindow{
+		mock.MaintenanceWindow(),
+		mock.MaintenanceWindow(),
+	}
+
+	must.NoError(t, store.UpsertMaintenanceWindows(
+		structs.MaintenanceWindowUpsertRequestType, 1000, windows))
+
+	ws := memdb.NewWatchSet()
+	got, err := store.MaintenanceWindowByName(ws, windows[0].Name)
+	must.NoError(t, err)
+	must.Eq(t, windows[0], got)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, 1000, got.ModifyIndex)
+
+	iter, err := store.MaintenanceWindows(ws)
+	must.NoError(t, err)
+	found := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		found++
+	}
+	must.Eq(t, 2, found)
+
+	index, err := store.Index(TableMaintenanceWindows)
+	must.NoError(t, err)
+	must.Eq(t, 1000, index)
+
+	// Update keeps the create index and fires the watch set.
+	update := windows[0].Copy()
+	update.Grace = 2 * update.Grace
+	must.NoError(t, store.UpsertMaintenanceWindows(
+		structs.MaintenanceWindowUpsertRequestType, 1001,
+		[]*structs.MaintenanceWindow{update}))
+	must.True(t, watchFired(ws))
+
+	got, err = store.MaintenanceWindowByName(nil, update.Name)
+	must.NoError(t, err)
+	must.Eq(t, update.Grace, got.Grace)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, 1001, got.ModifyIndex)
+
+	// Deleting a missing window fails the whole transaction.
+	err = store.DeleteMaintenanceWindows(
+		structs.MaintenanceWindowDeleteRequestType, 1002,
+		[]string{windows[1].Name, "missing"})
+	must.EqError(t, err, `maintenance window "missing" not found`)
+
+	got, err = store.MaintenanceWindowByName(nil, windows[1].Name)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+
+	must.NoError(t, store.DeleteMaintenanceWindows(
+		structs.MaintenanceWindowDeleteRequestType, 1002,
+		[]string{windows[1].Name}))
+
+	got, err = store.MaintenanceWindowByName(nil, windows[1].Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	index, err = store.Index(TableMaintenanceWindows)
+	must.NoError(t, err)
+	must.Eq(t, 1002, index)
+}
@@ -276,6 +276,15 @@ func (r *StateRestore) JobSubmissionRestore(jobSubmission *structs.JobSubmission
 	return nil
 }
 
+// MaintenanceWindowRestore restores a single maintenance window into the
+// maintenance_windows table.
+func (r *StateRestore) MaintenanceWindowRestore(window *structs.MaintenanceWindow) error {
+	if err := r.txn.Insert(TableMaintenanceWindows, window); err != nil {
+		return fmt.Errorf("maintenance window insert failed: %w", err)
+	}
+	return nil
+}
+
 // HostVolumeRestore restores a single host volume into the host_volumes table
 func (r *StateRestore) HostVolumeRestore(vol *structs.HostVolume) error {
 	if err := r.txn.Insert(TableHostVolumes, vol); err != nil {
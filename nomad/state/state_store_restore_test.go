@@ -540,3 +540,22 @@ func TestStateStore_JobSubmissionRestore(t *testing.T) {
 	must.NoError(t, err)
 	must.Eq(t, jobSubmission, *out)
 }
+
+func TestStateStore_MaintenanceWindowRestore(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	window := mock.MaintenanceWindow()
+	window.CreateIndex = 1000
+	window.ModifyIndex = 1000
+
+	restore, err := testState.Restore()
+	must.NoError(t, err)
+	must.NoError(t, restore.MaintenanceWindowRestore(window))
+	must.NoError(t, restore.Commit())
+
+	ws := memdb.NewWatchSet()
+	out, err := testState.MaintenanceWindowByName(ws, window.Name)
+	must.NoError(t, err)
+	must.Eq(t, window, out)
+}
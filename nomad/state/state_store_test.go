@@ -2746,6 +2746,87 @@ func TestStateStore_DeleteJobTxn_BatchDeletes(t *testing.T) {
 	must.Eq(t, deletionIndex, index)
 }
 
+// TestStatestore_JobVersionRetention tests that the version_retention of a
+// job overrides the configured server.job_tracked_versions, and that versions
+// beyond a lowered retention are pruned on the next update of the job.
+func TestStatestore_JobVersionRetention(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	state.config.JobTrackedVersions = 3
+
+	job := mock.MinJob()
+
+	upsertJob := func(t *testing.T) {
+		t.Helper()
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, nextIndex(state), nil, job.Copy()))
+	}
+
+	assertVersions := func(t *testing.T, expect []uint64) {
+		t.Helper()
+		jobs, err := state.JobVersionsByID(nil, job.Namespace, job.ID)
+		must.NoError(t, err)
+		vs := make([]uint64, len(jobs))
+		for i, j := range jobs {
+			vs[i] = j.Version
+		}
+		must.Eq(t, expect, vs)
+	}
+
+	// the job retains more versions than the servers
+	job.VersionRetention = 5
+	for range 7 {
+		upsertJob(t)
+	}
+	assertVersions(t, []uint64{6, 5, 4, 3, 2})
+
+	// tagged versions are not counted against the retention of the job
+	must.NoError(t, state.UpdateJobVersionTag(nextIndex(state), job.Namespace, &structs.JobApplyTagRequest{
+		JobID:   job.ID,
+		Name:    "release",
+		Tag:     &structs.JobVersionTag{Name: "release"},
+		Version: 2,
+	}))
+
+	// lowering the retention prunes all the older untagged versions once the
+	// job is updated
+	job.VersionRetention = 2
+	upsertJob(t)
+	assertVersions(t, []uint64{7, 6, 2})
+
+	// without a retention, the servers' applies
+	job.VersionRetention = 0
+	for range 3 {
+		upsertJob(t)
+	}
+	assertVersions(t, []uint64{10, 9, 8, 2})
+
+	// a single retained version is the latest version of the job, along with
+	// the latest stable version if that is an older one
+	job.VersionRetention = 1
+	upsertJob(t)
+	assertVersions(t, []uint64{11, 2})
+
+	job.Stable = true
+	upsertJob(t)
+	job.Stable = false
+	upsertJob(t)
+	upsertJob(t)
+	assertVersions(t, []uint64{14, 12, 2})
+
+	job.Stable = true
+	upsertJob(t)
+	assertVersions(t, []uint64{15, 2})
+
+	// without a retention, the servers' job_max_versions_per_job applies
+	// rather than their job_tracked_versions
+	state.config.JobMaxVersionsPerJob = 2
+	job.VersionRetention = 0
+	upsertJob(t)
+	upsertJob(t)
+	assertVersions(t, []uint64{17, 16, 2})
+}
+
 // TestStatestore_JobVersionTag tests that job versions which are tagged
 // do not count against the configured server.job_tracked_versions count,
 // do not get deleted when new versions are created,
@@ -3331,6 +3412,43 @@ func TestStateStore_JobsByPeriodic(t *testing.T) {
 	must.False(t, watchFired(ws), must.Sprint("watch should not have fired"))
 }
 
+func TestStateStore_JobsByReplicated(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	replicated := mock.Job()
+	replicated.TaskGroups[0].ReplicatePer = "${meta.rack}"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, replicated))
+
+	stopped := mock.Job()
+	stopped.TaskGroups[0].ReplicatePer = "${meta.rack}"
+	stopped.Stop = true
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, stopped))
+
+	ids := func(replicated bool) []string {
+		iter, err := state.JobsByReplicated(nil, replicated)
+		must.NoError(t, err)
+
+		var out []string
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			out = append(out, raw.(*structs.Job).ID)
+		}
+		return out
+	}
+	must.Eq(t, []string{replicated.ID}, ids(true))
+	must.SliceContainsAll(t, []string{job.ID, stopped.ID}, ids(false))
+
+	// the job leaves the index once it is stopped
+	replicated = replicated.Copy()
+	replicated.Stop = true
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, replicated))
+	must.SliceEmpty(t, ids(true))
+}
+
 func TestStateStore_JobsByScheduler(t *testing.T) {
 	ci.Parallel(t)
 
@@ -9771,6 +9889,34 @@ func TestStateStore_UpsertScalingEvent(t *testing.T) {
 	must.False(t, watchFired(ws))
 }
 
+func TestStateStore_UpsertScalingEvent_Groups(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+
+	web := structs.NewScalingEvent("scale web and worker")
+	worker := structs.NewScalingEvent("scale web and worker")
+	must.NoError(t, state.UpsertScalingEvent(1000, &structs.ScalingEventRequest{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		ScalingEvents: map[string]*structs.ScalingEvent{
+			"web":    web,
+			"worker": worker,
+		},
+	}))
+
+	out, eventsIndex, err := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1000, eventsIndex)
+	must.Eq(t, map[string][]*structs.ScalingEvent{
+		"web":    {web},
+		"worker": {worker},
+	}, out)
+	must.Eq(t, 1000, out["web"][0].CreateIndex)
+	must.Eq(t, 1000, out["worker"][0].CreateIndex)
+}
+
 func TestStateStore_UpsertScalingEvent_LimitAndOrder(t *testing.T) {
 	ci.Parallel(t)
 
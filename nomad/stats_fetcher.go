@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"context"
+	"maps"
 	"net"
 	"sync"
 
@@ -29,6 +30,11 @@ type StatsFetcher struct {
 	region       string
 	inflight     map[raft.ServerID]struct{}
 	inflightLock sync.Mutex
+
+	// health holds the last health stats reported by each server, which
+	// autopilot's server stats have no place for.
+	health     map[raft.ServerID]*structs.ServerHealthStats
+	healthLock sync.RWMutex
 }
 
 // NewStatsFetcher returns a stats fetcher.
@@ -38,9 +44,17 @@ func NewStatsFetcher(logger log.Logger, pool *pool.ConnPool, region string) *Sta
 		pool:     pool,
 		region:   region,
 		inflight: make(map[raft.ServerID]struct{}),
+		health:   make(map[raft.ServerID]*structs.ServerHealthStats),
 	}
 }
 
+// HealthStats returns the last health stats reported by each server.
+func (f *StatsFetcher) HealthStats() map[raft.ServerID]*structs.ServerHealthStats {
+	f.healthLock.RLock()
+	defer f.healthLock.RUnlock()
+	return maps.Clone(f.health)
+}
+
 // fetch does the RPC to fetch the server stats from a single server. We don't
 // cancel this when the context is canceled because we only want one in-flight
 // RPC to each server, so we let it finish and then clean up the in-flight
@@ -71,6 +85,10 @@ func (f *StatsFetcher) fetch(server *autopilot.Server, replyCh chan *autopilot.S
 		return
 	}
 
+	f.healthLock.Lock()
+	f.health[server.ID] = reply.Health
+	f.healthLock.Unlock()
+
 	replyCh <- reply.ToAutopilotServerStats()
 }
 
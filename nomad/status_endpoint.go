@@ -142,6 +142,7 @@ func (s *Status) RaftStats(args *structs.GenericRequest, reply *structs.RaftStat
 		return fmt.Errorf("error parsing server's last_log_term value: %s", err)
 	}
 
+	reply.Health = s.srv.serverHealthStats()
 	return nil
 }
 
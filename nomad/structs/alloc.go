@@ -5,6 +5,8 @@ package structs
 
 import (
 	"container/heap"
+	"fmt"
+	"hash/fnv"
 	"maps"
 	"slices"
 	"strconv"
@@ -441,8 +443,9 @@ func (a *Allocation) MigrateStrategy() *MigrateStrategy {
 }
 
 // NextRescheduleTime returns a time on or after which the allocation is eligible to be rescheduled,
-// and whether the next reschedule time is within policy's interval if the policy doesn't allow unlimited reschedules
-func (a *Allocation) NextRescheduleTime() (time.Time, bool) {
+// and whether the next reschedule time is within policy's interval if the policy doesn't allow unlimited reschedules.
+// The reschedule overrides of pool, the node pool of the allocation's node, are applied if it is not nil.
+func (a *Allocation) NextRescheduleTime(pool *NodePool) (time.Time, bool) {
 	failTime := a.LastEventTime()
 	reschedulePolicy := a.ReschedulePolicy()
 	isRescheduledBatch := a.Job.Type == JobTypeBatch && a.DesiredTransition.ShouldReschedule()
@@ -458,12 +461,12 @@ func (a *Allocation) NextRescheduleTime() (time.Time, bool) {
 		return time.Time{}, false
 	}
 
-	return a.nextRescheduleTime(failTime, reschedulePolicy)
+	return a.nextRescheduleTime(failTime, reschedulePolicy, pool)
 }
 
-func (a *Allocation) nextRescheduleTime(failTime time.Time, reschedulePolicy *ReschedulePolicy) (time.Time, bool) {
-	nextDelay := a.NextDelay()
-	nextRescheduleTime := failTime.Add(nextDelay)
+func (a *Allocation) nextRescheduleTime(failTime time.Time, reschedulePolicy *ReschedulePolicy, pool *NodePool) (time.Time, bool) {
+	nextDelay, jitter, _ := a.RescheduleDelay(pool)
+	nextRescheduleTime := failTime.Add(nextDelay + jitter)
 	rescheduleEligible := reschedulePolicy.Unlimited || (reschedulePolicy.Attempts > 0 && a.RescheduleTracker == nil)
 	if reschedulePolicy.Attempts > 0 && a.RescheduleTracker != nil && a.RescheduleTracker.Events != nil {
 		// Check for eligibility based on the interval if max attempts is set
@@ -477,20 +480,20 @@ func (a *Allocation) nextRescheduleTime(failTime time.Time, reschedulePolicy *Re
 // NextRescheduleTimeByTime works like NextRescheduleTime but allows callers
 // specify a failure time. Useful for things like determining whether to reschedule
 // an alloc on a disconnected node.
-func (a *Allocation) NextRescheduleTimeByTime(t time.Time) (time.Time, bool) {
+func (a *Allocation) NextRescheduleTimeByTime(t time.Time, pool *NodePool) (time.Time, bool) {
 	reschedulePolicy := a.ReschedulePolicy()
 	if reschedulePolicy == nil {
 		return time.Time{}, false
 	}
 
-	return a.nextRescheduleTime(t, reschedulePolicy)
+	return a.nextRescheduleTime(t, reschedulePolicy, pool)
 }
 
-func (a *Allocation) RescheduleTimeOnDisconnect(now time.Time) (time.Time, bool) {
+func (a *Allocation) RescheduleTimeOnDisconnect(now time.Time, pool *NodePool) (time.Time, bool) {
 	tg := a.Job.LookupTaskGroup(a.TaskGroup)
 	if tg == nil || tg.Disconnect == nil || tg.Disconnect.Replace == nil {
 		// Kept to maintain backwards compatibility with behavior prior to 1.8.0
-		return a.NextRescheduleTimeByTime(now)
+		return a.NextRescheduleTimeByTime(now, pool)
 	}
 
 	return now, *tg.Disconnect.Replace
@@ -589,14 +592,42 @@ func (a *Allocation) PreventReplaceOnDisconnect() bool {
 	return false
 }
 
-// NextDelay returns a duration after which the allocation can be rescheduled.
-// It is calculated according to the delay function and previous reschedule attempts.
-func (a *Allocation) NextDelay() time.Duration {
+// RescheduleDelay returns the delay and jitter after which the allocation can
+// be rescheduled, and the source of the reschedule policy they were computed
+// from. The reschedule overrides of pool, the node pool of the allocation's
+// node, are applied if it is not nil.
+func (a *Allocation) RescheduleDelay(pool *NodePool) (time.Duration, time.Duration, string) {
 	policy := a.ReschedulePolicy()
 	// Can be nil if the task group was updated to remove its reschedule policy
 	if policy == nil {
+		return 0, 0, RescheduleDelaySourceJob
+	}
+
+	source := RescheduleDelaySourceJob
+	if pool != nil && pool.RescheduleOverrides != nil {
+		policy = pool.RescheduleOverrides.Apply(policy)
+		source = RescheduleDelaySourceNodePool + pool.Name
+	}
+
+	return a.nextDelay(policy), rescheduleJitter(a.ID, policy.Jitter), source
+}
+
+// rescheduleJitter returns a duration between zero and limit derived from the
+// allocation ID, so that it is the same every time it is computed for an
+// allocation but differs between allocations.
+func rescheduleJitter(allocID string, limit time.Duration) time.Duration {
+	if limit <= 0 {
 		return 0
 	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(allocID))
+	return time.Duration(h.Sum64() % uint64(limit))
+}
+
+// nextDelay returns a duration after which the allocation can be rescheduled.
+// It is calculated according to the delay function of policy and previous
+// reschedule attempts.
+func (a *Allocation) nextDelay(policy *ReschedulePolicy) time.Duration {
 	delayDur := policy.Delay
 	if a.RescheduleTracker == nil || a.RescheduleTracker.Events == nil || len(a.RescheduleTracker.Events) == 0 {
 		return delayDur
@@ -783,6 +814,14 @@ func (a *Allocation) AllocationDiff() *AllocationDiff {
 // Expired determines whether an allocation has exceeded its Disconnect.LostAfter
 // duration relative to the passed time stamp.
 func (a *Allocation) Expired(now time.Time) bool {
+	return a.ExpiredWithGrace(now, 0)
+}
+
+// ExpiredWithGrace determines whether an allocation has exceeded its
+// Disconnect.LostAfter duration, extended by grace, relative to the passed time
+// stamp. The grace is given by maintenance windows to nodes that are expected
+// to be disconnected.
+func (a *Allocation) ExpiredWithGrace(now time.Time, grace time.Duration) bool {
 	if a == nil || a.Job == nil {
 		return false
 	}
@@ -807,10 +846,41 @@ func (a *Allocation) Expired(now time.Time) bool {
 		return false
 	}
 
-	expiry := lastUnknown.Add(timeout)
+	expiry := lastUnknown.Add(timeout + grace)
 	return expiry.Sub(now) <= 0
 }
 
+// MaxRunTimeDeadline returns the time at which the allocation exceeds the max
+// run time of its task group. The zero time is returned if the task group has
+// no max run time or if none of its main tasks started yet.
+func (a *Allocation) MaxRunTimeDeadline() time.Time {
+	if a == nil || a.Job == nil {
+		return time.Time{}
+	}
+	return a.Job.LookupTaskGroup(a.TaskGroup).MaxRunTimeDeadline(a.TaskStates)
+}
+
+// MaxRunTimeExpiry returns the time after which the scheduler considers that
+// the allocation exceeded the max run time of its task group when its client
+// cannot enforce it: the deadline extended by the longest kill timeout of its
+// tasks, which the client would have waited for. The zero time is returned if
+// the allocation has no deadline.
+func (a *Allocation) MaxRunTimeExpiry() time.Time {
+	deadline := a.MaxRunTimeDeadline()
+	if deadline.IsZero() {
+		return deadline
+	}
+
+	kill := DefaultKillTimeout
+	for _, t := range a.Job.LookupTaskGroup(a.TaskGroup).Tasks {
+		if t.KillTimeout > kill {
+			kill = t.KillTimeout
+		}
+	}
+
+	return deadline.Add(kill)
+}
+
 // LastUnknown returns the timestamp for the last time the allocation
 // transitioned into the unknown client status.
 func (a *Allocation) LastUnknown() time.Time {
@@ -1054,6 +1124,43 @@ type AllocMetric struct {
 	// This is to prevent creating many failed allocations for a
 	// single task group.
 	CoalescedFailures int
+
+	// PortCollisions are examples of static ports that could not be
+	// reserved, capped at MaxPortCollisions.
+	PortCollisions []*PortCollision
+}
+
+// MaxPortCollisions is the maximum number of port collisions recorded in an
+// AllocMetric, to bound the size of failed placement metrics.
+const MaxPortCollisions = 5
+
+// PortCollision describes a static port which could not be reserved on a node
+// because it was already in use.
+type PortCollision struct {
+	NodeID string
+	HostIP string
+	Port   int
+	Label  string
+
+	// AllocID and JobID identify the allocation holding the port. They are
+	// empty if the port is reserved by the node itself.
+	AllocID string
+	JobID   string
+}
+
+func (p *PortCollision) Copy() *PortCollision {
+	if p == nil {
+		return nil
+	}
+	np := *p
+	return &np
+}
+
+func (p *PortCollision) String() string {
+	if p.AllocID == "" {
+		return fmt.Sprintf("port %d on %s reserved by node %s", p.Port, p.HostIP, p.NodeID)
+	}
+	return fmt.Sprintf("port %d on %s in use by alloc %s (job %s)", p.Port, p.HostIP, p.AllocID, p.JobID)
 }
 
 func (a *AllocMetric) Copy() *AllocMetric {
@@ -1070,6 +1177,12 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.QuotaExhausted = slices.Clone(na.QuotaExhausted)
 	na.Scores = maps.Clone(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
+	if a.PortCollisions != nil {
+		na.PortCollisions = make([]*PortCollision, len(a.PortCollisions))
+		for i, c := range a.PortCollisions {
+			na.PortCollisions[i] = c.Copy()
+		}
+	}
 	return na
 }
 
@@ -1109,6 +1222,15 @@ func (a *AllocMetric) ExhaustedNode(node *Node, dimension string) {
 	}
 }
 
+// ExhaustedPort records a port collision which caused the node to be
+// exhausted. Only the first MaxPortCollisions collisions are kept.
+func (a *AllocMetric) ExhaustedPort(collision *PortCollision) {
+	if len(a.PortCollisions) >= MaxPortCollisions {
+		return
+	}
+	a.PortCollisions = append(a.PortCollisions, collision)
+}
+
 func (a *AllocMetric) ExhaustQuota(dimensions []string) {
 	if a.QuotaExhausted == nil {
 		a.QuotaExhausted = make([]string, 0, len(dimensions))
@@ -1363,6 +1485,18 @@ func (a *AllocNetworkStatus) IsZero() bool {
 	return true
 }
 
+// Advertised returns the network status with the address advertised in the
+// given advertise mode as its Address. Only AdvertiseModeIPv6 changes the
+// address, to the IPv6 address if one was reported.
+func (a *AllocNetworkStatus) Advertised(mode string) *AllocNetworkStatus {
+	if a == nil || mode != AdvertiseModeIPv6 || a.AddressIPv6 == "" {
+		return a
+	}
+	c := a.Copy()
+	c.Address = a.AddressIPv6
+	return c
+}
+
 // NetworkStatus is an interface satisfied by alloc runner, for acquiring the
 // network status of an allocation.
 type NetworkStatus interface {
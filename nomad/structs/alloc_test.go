@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/shoenig/test/must"
 )
@@ -406,6 +407,56 @@ func TestAllocation_Expired_Disconnected(t *testing.T) {
 	}
 }
 
+func TestAllocation_ExpiredWithGrace(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := MockAlloc()
+	alloc.Job.TaskGroups[0].Disconnect = &DisconnectStrategy{
+		LostAfter: 5 * time.Second,
+	}
+	alloc.ClientStatus = AllocClientStatusUnknown
+
+	now := time.Now()
+	alloc.AllocStates = []*AllocState{{
+		Field: AllocStateFieldClientStatus,
+		Value: AllocClientStatusUnknown,
+		Time:  now,
+	}}
+
+	must.True(t, alloc.Expired(now.Add(10*time.Second)))
+	must.True(t, alloc.ExpiredWithGrace(now.Add(10*time.Second), 0))
+	must.False(t, alloc.ExpiredWithGrace(now.Add(10*time.Second), time.Minute))
+	must.True(t, alloc.ExpiredWithGrace(now.Add(65*time.Second), time.Minute))
+}
+
+func TestAllocation_MaxRunTimeDeadline(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := MockAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.Tasks = append(tg.Tasks, &Task{
+		Name:        "init",
+		Lifecycle:   &TaskLifecycleConfig{Hook: TaskLifecycleHookPrestart},
+		KillTimeout: time.Minute,
+	})
+
+	// without a max run time there is no deadline
+	now := time.Now()
+	alloc.TaskStates = map[string]*TaskState{
+		"init": {State: TaskStateDead, StartedAt: now.Add(-time.Hour)},
+	}
+	must.True(t, alloc.MaxRunTimeDeadline().IsZero())
+
+	// the deadline is counted from the start of the main tasks
+	tg.MaxRunTime = pointer.Of(2 * time.Hour)
+	must.True(t, alloc.MaxRunTimeDeadline().IsZero())
+	must.True(t, alloc.MaxRunTimeExpiry().IsZero())
+
+	alloc.TaskStates["web"] = &TaskState{State: TaskStateRunning, StartedAt: now}
+	must.Eq(t, now.Add(2*time.Hour), alloc.MaxRunTimeDeadline())
+	must.Eq(t, now.Add(2*time.Hour+time.Minute), alloc.MaxRunTimeExpiry())
+}
+
 func TestAllocation_NextRescheduleTime(t *testing.T) {
 	now := time.Now()
 	makeTestAlloc := func(batch bool) *Allocation {
@@ -637,7 +688,7 @@ func TestAllocation_NextRescheduleTime(t *testing.T) {
 				tc.allocFn(alloc)
 			}
 
-			nextTime, eligible := alloc.NextRescheduleTime()
+			nextTime, eligible := alloc.NextRescheduleTime(nil)
 			if tc.isEligible {
 				must.True(t, eligible)
 				must.Eq(t, now.Add(2*time.Minute), nextTime)
@@ -668,3 +719,50 @@ func TestAllocation_Index(t *testing.T) {
 		t.Fatalf("Got %d and %d", a1.Index(), a2.Index())
 	}
 }
+
+func TestAllocMetric_ExhaustedPort(t *testing.T) {
+	ci.Parallel(t)
+
+	metric := new(AllocMetric)
+	for i := 0; i < MaxPortCollisions+2; i++ {
+		metric.ExhaustedPort(&PortCollision{
+			NodeID: fmt.Sprintf("node-%d", i),
+			HostIP: "10.0.0.5",
+			Port:   8080,
+			Label:  "http",
+		})
+	}
+	must.Len(t, MaxPortCollisions, metric.PortCollisions)
+	must.Eq(t, "node-0", metric.PortCollisions[0].NodeID)
+
+	// copies must not share collisions
+	copied := metric.Copy()
+	copied.PortCollisions[0].Port = 9090
+	must.Eq(t, 8080, metric.PortCollisions[0].Port)
+}
+
+func TestAllocNetworkStatus_Advertised(t *testing.T) {
+	ci.Parallel(t)
+
+	dual := &AllocNetworkStatus{
+		InterfaceName: "eth0",
+		Address:       "172.26.64.2",
+		AddressIPv6:   "fd00:26::2",
+	}
+
+	must.Eq(t, dual, dual.Advertised(""))
+	must.Eq(t, dual, dual.Advertised(AdvertiseModeIPv4))
+	must.Eq(t, dual, dual.Advertised(AdvertiseModeDual))
+
+	ipv6 := dual.Advertised(AdvertiseModeIPv6)
+	must.Eq(t, "fd00:26::2", ipv6.Address)
+	must.Eq(t, "eth0", ipv6.InterfaceName)
+	must.Eq(t, "172.26.64.2", dual.Address)
+
+	// without an IPv6 address the IPv4 address is kept
+	ipv4Only := &AllocNetworkStatus{Address: "172.26.64.2"}
+	must.Eq(t, "172.26.64.2", ipv4Only.Advertised(AdvertiseModeIPv6).Address)
+
+	var nilStatus *AllocNetworkStatus
+	must.Nil(t, nilStatus.Advertised(AdvertiseModeIPv6))
+}
@@ -82,6 +82,10 @@ type ServerHealth struct {
 
 	// StableSince is the last time this server's Healthy value changed.
 	StableSince time.Time
+
+	// FailedChecks holds the reasons the server fails the health check
+	// thresholds of the Autopilot config, such as MinDiskFree.
+	FailedChecks []string `json:",omitempty"`
 }
 
 // AutopilotZone holds the list of servers in a redundancy zone.  (Enterprise only)
@@ -153,6 +157,27 @@ type RaftStats struct {
 
 	// LastIndex is the last log index this server has a record of in its Raft log.
 	LastIndex uint64
+
+	// Health holds the stats used by the health check thresholds of the
+	// Autopilot config. It is nil for servers which do not report them.
+	Health *ServerHealthStats
+}
+
+// ServerHealthStats holds the stats of a server compared against the health
+// check thresholds of the Autopilot config.
+type ServerHealthStats struct {
+	// DiskFreeBytes and DiskTotalBytes are the free and total space of the
+	// volume holding the server's Raft data. DiskTotalBytes is zero if the
+	// space is unknown, such as for servers in dev mode.
+	DiskFreeBytes  uint64
+	DiskTotalBytes uint64
+
+	// AppliedIndex is the index of the last log applied to the server's state.
+	AppliedIndex uint64
+
+	// LastAppliedAt is the time the last log applied to the server's state was
+	// appended by the leader.
+	LastAppliedAt time.Time
 }
 
 func (s *RaftStats) ToAutopilotServerStats() *autopilot.ServerStats {
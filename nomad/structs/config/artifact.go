@@ -5,8 +5,12 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"math"
+	"net/url"
+	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -53,6 +57,33 @@ type ArtifactConfig struct {
 	// Default is 100GB.
 	DecompressionSizeLimit *string `hcl:"decompression_size_limit"`
 
+	// DecompressionMinSize is the size below which archives are not
+	// extracted automatically, but stored as downloaded. Artifacts which set
+	// the "archive" option or the "dir" mode are always extracted. A value of
+	// 0 disables the threshold.
+	//
+	// Default is 0.
+	DecompressionMinSize *string `hcl:"decompression_min_size"`
+
+	// MaxFilesPerDir is the maximum number of entries a single directory of
+	// an extracted archive may hold. Archives with a directory exceeding it
+	// are not extracted. A value of 0 disables the limit.
+	//
+	// Default is 100000 entries.
+	MaxFilesPerDir *int `hcl:"max_files_per_dir"`
+
+	// MaxParallelChunks is the maximum number of chunks of a single artifact,
+	// such as the parts listed by a manifest, downloaded at the same time,
+	// unless the artifact sets its own max_parallel.
+	//
+	// Default is 4 chunks.
+	MaxParallelChunks *int `hcl:"max_parallel_chunks"`
+
+	// DecompressorOverrides maps archive extensions to the name of the
+	// decompressor forced for them, instead of the registered decompressor
+	// with the highest priority for the extension.
+	DecompressorOverrides map[string]string `hcl:"decompressor_overrides"`
+
 	// DisableArtifactInspection will turn off artifact inspection which checks
 	// the artifact contents for potential sandbox escapes. If the platform supports
 	// filesystem isolation, and it is not disabled, the check will not be run
@@ -72,6 +103,85 @@ type ArtifactConfig struct {
 	// variable names to inherit from the Nomad Client and set in the artifact
 	// download sandbox process.
 	SetEnvironmentVariables *string `hcl:"set_environment_variables"`
+
+	// CacheDir is the directory in which prefetched artifacts are stored,
+	// addressed by their checksum. Artifacts with a matching checksum are
+	// copied from the cache instead of being downloaded again.
+	//
+	// The cache is disabled when empty, which is the default.
+	CacheDir *string `hcl:"cache_dir"`
+
+	// CacheStaleIfError is the maximum age of a cached copy of an artifact
+	// which is served in place of the artifact when downloading it fails, such
+	// as when its source is unreachable. Artifacts are only cached for this
+	// purpose when the cache is enabled.
+	//
+	// Stale copies are never served when "0s", which is the default.
+	CacheStaleIfError *string `hcl:"cache_stale_if_error"`
+
+	// HTTPSizePreflight enables a HEAD request before downloading an http
+	// artifact, so that artifacts whose Content-Length exceeds HTTPMaxSize are
+	// rejected without downloading them.
+	HTTPSizePreflight *bool `hcl:"http_size_preflight"`
+
+	// MinTLSVersion is the minimum TLS version of the connections http
+	// artifacts are downloaded over. Servers which only negotiate a lower
+	// version are rejected. One of "tls10", "tls11", "tls12" or "tls13".
+	//
+	// Default is "tls12".
+	MinTLSVersion *string `hcl:"min_tls_version"`
+
+	// DisableAutoExtract stores artifacts verbatim unless they explicitly opt
+	// into extraction, with the "archive" option or in "dir" mode. Artifacts in
+	// "any" mode which would otherwise be extracted because of their file
+	// extension are rejected.
+	DisableAutoExtract *bool `hcl:"disable_auto_extract"`
+
+	// SymlinkRewriteRoots is a list of absolute install roots. Absolute
+	// symlinks in artifacts that target a path under one of these roots are
+	// rewritten to relative symlinks pointing at the same path under the task
+	// directory, instead of being rejected as escaping the sandbox.
+	SymlinkRewriteRoots []string `hcl:"symlink_rewrite_roots"`
+
+	// DisallowSymlinks prevents artifacts from creating any symlink in the
+	// task directory, including those which resolve within the sandbox. When
+	// "skip", symlinks are left out of the artifact. When "fail", artifacts
+	// with a symlink are rejected.
+	//
+	// Symlinks are allowed when empty, which is the default.
+	DisallowSymlinks *string `hcl:"disallow_symlinks"`
+
+	// StripSetuid removes the setuid, setgid, and sticky bits from the files
+	// and directories of artifacts, so that a crafted archive cannot place a
+	// setuid binary in the task directory. When false, artifacts holding such
+	// files are rejected instead.
+	//
+	// Defaults to true.
+	StripSetuid *bool `hcl:"strip_setuid"`
+
+	// UnixSockets maps virtual HTTP host names to the unix:// addresses of
+	// the Unix domain sockets that http artifacts from those hosts are
+	// downloaded over, instead of connecting to the host over TCP.
+	UnixSockets map[string]string `hcl:"unix_sockets"`
+
+	// DefaultHeaders maps the http and https schemes to headers which are
+	// sent with every artifact download from a source with that scheme. The
+	// headers of an artifact take precedence over the defaults.
+	DefaultHeaders map[string]map[string]string `hcl:"default_headers"`
+
+	// PostCmdAllowlist is the absolute paths of the binaries artifacts may run
+	// as their post command, once they are in place. Post commands are
+	// rejected when empty, which is the default.
+	PostCmdAllowlist []string `hcl:"post_cmd_allowlist"`
+
+	// PolicyURL is the URL of an Open Policy Agent decision which every
+	// artifact is evaluated against before it is fetched, such as
+	// "http://127.0.0.1:8181/v1/data/nomad/artifact". Artifacts are denied if
+	// the decision does not allow them or cannot be evaluated.
+	//
+	// Artifacts are not evaluated against a policy when empty, which is the
+	// default.
+	PolicyURL *string `hcl:"policy_url"`
 }
 
 func (a *ArtifactConfig) Copy() *ArtifactConfig {
@@ -87,11 +197,38 @@ func (a *ArtifactConfig) Copy() *ArtifactConfig {
 		S3Timeout:                     pointer.Copy(a.S3Timeout),
 		DecompressionFileCountLimit:   pointer.Copy(a.DecompressionFileCountLimit),
 		DecompressionSizeLimit:        pointer.Copy(a.DecompressionSizeLimit),
+		DecompressionMinSize:          pointer.Copy(a.DecompressionMinSize),
+		MaxFilesPerDir:                pointer.Copy(a.MaxFilesPerDir),
+		MaxParallelChunks:             pointer.Copy(a.MaxParallelChunks),
+		DecompressorOverrides:         maps.Clone(a.DecompressorOverrides),
 		DisableArtifactInspection:     pointer.Copy(a.DisableArtifactInspection),
 		DisableFilesystemIsolation:    pointer.Copy(a.DisableFilesystemIsolation),
 		FilesystemIsolationExtraPaths: slices.Clone(a.FilesystemIsolationExtraPaths),
 		SetEnvironmentVariables:       pointer.Copy(a.SetEnvironmentVariables),
+		CacheDir:                      pointer.Copy(a.CacheDir),
+		CacheStaleIfError:             pointer.Copy(a.CacheStaleIfError),
+		HTTPSizePreflight:             pointer.Copy(a.HTTPSizePreflight),
+		MinTLSVersion:                 pointer.Copy(a.MinTLSVersion),
+		DisableAutoExtract:            pointer.Copy(a.DisableAutoExtract),
+		SymlinkRewriteRoots:           slices.Clone(a.SymlinkRewriteRoots),
+		DisallowSymlinks:              pointer.Copy(a.DisallowSymlinks),
+		StripSetuid:                   pointer.Copy(a.StripSetuid),
+		UnixSockets:                   maps.Clone(a.UnixSockets),
+		DefaultHeaders:                copyDefaultHeaders(a.DefaultHeaders),
+		PostCmdAllowlist:              slices.Clone(a.PostCmdAllowlist),
+		PolicyURL:                     pointer.Copy(a.PolicyURL),
+	}
+}
+
+func copyDefaultHeaders(headers map[string]map[string]string) map[string]map[string]string {
+	if headers == nil {
+		return nil
 	}
+	c := make(map[string]map[string]string, len(headers))
+	for scheme, h := range headers {
+		c[scheme] = maps.Clone(h)
+	}
+	return c
 }
 
 func (a *ArtifactConfig) Merge(o *ArtifactConfig) *ArtifactConfig {
@@ -110,9 +247,20 @@ func (a *ArtifactConfig) Merge(o *ArtifactConfig) *ArtifactConfig {
 			S3Timeout:                   pointer.Merge(a.S3Timeout, o.S3Timeout),
 			DecompressionFileCountLimit: pointer.Merge(a.DecompressionFileCountLimit, o.DecompressionFileCountLimit),
 			DecompressionSizeLimit:      pointer.Merge(a.DecompressionSizeLimit, o.DecompressionSizeLimit),
+			DecompressionMinSize:        pointer.Merge(a.DecompressionMinSize, o.DecompressionMinSize),
+			MaxFilesPerDir:              pointer.Merge(a.MaxFilesPerDir, o.MaxFilesPerDir),
+			MaxParallelChunks:           pointer.Merge(a.MaxParallelChunks, o.MaxParallelChunks),
 			DisableArtifactInspection:   pointer.Merge(a.DisableArtifactInspection, o.DisableArtifactInspection),
 			DisableFilesystemIsolation:  pointer.Merge(a.DisableFilesystemIsolation, o.DisableFilesystemIsolation),
 			SetEnvironmentVariables:     pointer.Merge(a.SetEnvironmentVariables, o.SetEnvironmentVariables),
+			CacheDir:                    pointer.Merge(a.CacheDir, o.CacheDir),
+			CacheStaleIfError:           pointer.Merge(a.CacheStaleIfError, o.CacheStaleIfError),
+			HTTPSizePreflight:           pointer.Merge(a.HTTPSizePreflight, o.HTTPSizePreflight),
+			MinTLSVersion:               pointer.Merge(a.MinTLSVersion, o.MinTLSVersion),
+			DisableAutoExtract:          pointer.Merge(a.DisableAutoExtract, o.DisableAutoExtract),
+			DisallowSymlinks:            pointer.Merge(a.DisallowSymlinks, o.DisallowSymlinks),
+			PolicyURL:                   pointer.Merge(a.PolicyURL, o.PolicyURL),
+			StripSetuid:                 pointer.Merge(a.StripSetuid, o.StripSetuid),
 		}
 
 		if o.FilesystemIsolationExtraPaths != nil {
@@ -121,6 +269,36 @@ func (a *ArtifactConfig) Merge(o *ArtifactConfig) *ArtifactConfig {
 			result.FilesystemIsolationExtraPaths = slices.Clone(a.FilesystemIsolationExtraPaths)
 		}
 
+		if o.SymlinkRewriteRoots != nil {
+			result.SymlinkRewriteRoots = slices.Clone(o.SymlinkRewriteRoots)
+		} else {
+			result.SymlinkRewriteRoots = slices.Clone(a.SymlinkRewriteRoots)
+		}
+
+		if o.DecompressorOverrides != nil {
+			result.DecompressorOverrides = maps.Clone(o.DecompressorOverrides)
+		} else {
+			result.DecompressorOverrides = maps.Clone(a.DecompressorOverrides)
+		}
+
+		if o.UnixSockets != nil {
+			result.UnixSockets = maps.Clone(o.UnixSockets)
+		} else {
+			result.UnixSockets = maps.Clone(a.UnixSockets)
+		}
+
+		if o.DefaultHeaders != nil {
+			result.DefaultHeaders = copyDefaultHeaders(o.DefaultHeaders)
+		} else {
+			result.DefaultHeaders = copyDefaultHeaders(a.DefaultHeaders)
+		}
+
+		if o.PostCmdAllowlist != nil {
+			result.PostCmdAllowlist = slices.Clone(o.PostCmdAllowlist)
+		} else {
+			result.PostCmdAllowlist = slices.Clone(a.PostCmdAllowlist)
+		}
+
 		return result
 	}
 }
@@ -146,6 +324,14 @@ func (a *ArtifactConfig) Equal(o *ArtifactConfig) bool {
 		return false
 	case !pointer.Eq(a.DecompressionSizeLimit, o.DecompressionSizeLimit):
 		return false
+	case !pointer.Eq(a.DecompressionMinSize, o.DecompressionMinSize):
+		return false
+	case !pointer.Eq(a.MaxFilesPerDir, o.MaxFilesPerDir):
+		return false
+	case !pointer.Eq(a.MaxParallelChunks, o.MaxParallelChunks):
+		return false
+	case !maps.Equal(a.DecompressorOverrides, o.DecompressorOverrides):
+		return false
 	case !pointer.Eq(a.DisableArtifactInspection, o.DisableArtifactInspection):
 		return false
 	case !pointer.Eq(a.DisableFilesystemIsolation, o.DisableFilesystemIsolation):
@@ -154,6 +340,30 @@ func (a *ArtifactConfig) Equal(o *ArtifactConfig) bool {
 		return false
 	case !pointer.Eq(a.SetEnvironmentVariables, o.SetEnvironmentVariables):
 		return false
+	case !pointer.Eq(a.CacheDir, o.CacheDir):
+		return false
+	case !pointer.Eq(a.CacheStaleIfError, o.CacheStaleIfError):
+		return false
+	case !pointer.Eq(a.HTTPSizePreflight, o.HTTPSizePreflight):
+		return false
+	case !pointer.Eq(a.MinTLSVersion, o.MinTLSVersion):
+		return false
+	case !pointer.Eq(a.DisableAutoExtract, o.DisableAutoExtract):
+		return false
+	case !helper.SliceSetEq(a.SymlinkRewriteRoots, o.SymlinkRewriteRoots):
+		return false
+	case !pointer.Eq(a.DisallowSymlinks, o.DisallowSymlinks):
+		return false
+	case !pointer.Eq(a.StripSetuid, o.StripSetuid):
+		return false
+	case !maps.Equal(a.UnixSockets, o.UnixSockets):
+		return false
+	case !maps.EqualFunc(a.DefaultHeaders, o.DefaultHeaders, maps.Equal[map[string]string]):
+		return false
+	case !helper.SliceSetEq(a.PostCmdAllowlist, o.PostCmdAllowlist):
+		return false
+	case !pointer.Eq(a.PolicyURL, o.PolicyURL):
+		return false
 	}
 	return true
 }
@@ -233,6 +443,38 @@ func (a *ArtifactConfig) Validate() error {
 		return fmt.Errorf("decompression_size_limit must be < %d but found %d", int64(math.MaxInt64), v)
 	}
 
+	if a.DecompressionMinSize == nil {
+		return fmt.Errorf("decompression_min_size must not be nil")
+	}
+	if v, err := humanize.ParseBytes(*a.DecompressionMinSize); err != nil {
+		return fmt.Errorf("decompression_min_size is not a valid size: %w", err)
+	} else if v > math.MaxInt64 {
+		return fmt.Errorf("decompression_min_size must be < %d but found %d", int64(math.MaxInt64), v)
+	}
+
+	if a.MaxFilesPerDir == nil {
+		return fmt.Errorf("max_files_per_dir must not be nil")
+	}
+	if v := *a.MaxFilesPerDir; v < 0 {
+		return fmt.Errorf("max_files_per_dir must be >= 0 but found %d", v)
+	}
+
+	if a.MaxParallelChunks == nil {
+		return fmt.Errorf("max_parallel_chunks must not be nil")
+	}
+	if v := *a.MaxParallelChunks; v < 1 {
+		return fmt.Errorf("max_parallel_chunks must be > 0 but found %d", v)
+	}
+
+	for ext, name := range a.DecompressorOverrides {
+		if ext == "" {
+			return fmt.Errorf("decompressor_overrides must not contain an empty extension")
+		}
+		if name == "" {
+			return fmt.Errorf("decompressor_overrides for %q must not be empty", ext)
+		}
+	}
+
 	if a.DisableArtifactInspection == nil {
 		return fmt.Errorf("disable_artifact_inspection must be set")
 	}
@@ -251,6 +493,96 @@ func (a *ArtifactConfig) Validate() error {
 		return fmt.Errorf("set_environment_variables must be set")
 	}
 
+	if a.CacheDir == nil {
+		return fmt.Errorf("cache_dir must be set")
+	}
+	if v := *a.CacheDir; v != "" && !filepath.IsAbs(v) {
+		return fmt.Errorf("cache_dir must be an absolute path but found %q", v)
+	}
+
+	if a.CacheStaleIfError == nil {
+		return fmt.Errorf("cache_stale_if_error must be set")
+	}
+	if v, err := time.ParseDuration(*a.CacheStaleIfError); err != nil {
+		return fmt.Errorf("cache_stale_if_error not a valid duration: %w", err)
+	} else if v < 0 {
+		return fmt.Errorf("cache_stale_if_error must be >= 0")
+	} else if v > 0 && *a.CacheDir == "" {
+		return fmt.Errorf("cache_stale_if_error requires cache_dir to be set")
+	}
+
+	if a.HTTPSizePreflight == nil {
+		return fmt.Errorf("http_size_preflight must be set")
+	}
+
+	if a.MinTLSVersion == nil {
+		return fmt.Errorf("min_tls_version must be set")
+	}
+	switch v := *a.MinTLSVersion; v {
+	case "tls10", "tls11", "tls12", "tls13":
+	default:
+		return fmt.Errorf("min_tls_version must be one of tls10, tls11, tls12 or tls13 but found %q", v)
+	}
+
+	if a.DisableAutoExtract == nil {
+		return fmt.Errorf("disable_auto_extract must be set")
+	}
+
+	for _, root := range a.SymlinkRewriteRoots {
+		if !filepath.IsAbs(root) || filepath.Clean(root) == "/" {
+			return fmt.Errorf("symlink_rewrite_roots must contain absolute paths other than / but found %q", root)
+		}
+	}
+
+	if a.DisallowSymlinks == nil {
+		return fmt.Errorf("disallow_symlinks must be set")
+	}
+	switch v := *a.DisallowSymlinks; v {
+	case "", "skip", "fail":
+	default:
+		return fmt.Errorf("disallow_symlinks must be empty, skip or fail but found %q", v)
+	}
+
+	if a.StripSetuid == nil {
+		return fmt.Errorf("strip_setuid must be set")
+	}
+
+	for host, addr := range a.UnixSockets {
+		if host == "" {
+			return fmt.Errorf("unix_sockets must not contain an empty host")
+		}
+		if path, ok := strings.CutPrefix(addr, "unix://"); !ok || !filepath.IsAbs(path) {
+			return fmt.Errorf("unix_sockets address for %q must be unix:// followed by an absolute path but found %q", host, addr)
+		}
+	}
+
+	for scheme, headers := range a.DefaultHeaders {
+		if scheme != "http" && scheme != "https" {
+			return fmt.Errorf("default_headers scheme must be http or https but found %q", scheme)
+		}
+		for name := range headers {
+			if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+				return fmt.Errorf("default_headers for %q contains invalid header name %q", scheme, name)
+			}
+		}
+	}
+
+	for _, bin := range a.PostCmdAllowlist {
+		if !filepath.IsAbs(bin) || filepath.Clean(bin) != bin {
+			return fmt.Errorf("post_cmd_allowlist must contain clean absolute paths but found %q", bin)
+		}
+	}
+
+	if a.PolicyURL == nil {
+		return fmt.Errorf("policy_url must be set")
+	}
+	if v := *a.PolicyURL; v != "" {
+		u, err := url.Parse(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("policy_url must be an http or https URL but found %q", v)
+		}
+	}
+
 	return nil
 }
 
@@ -289,6 +621,20 @@ func DefaultArtifactConfig() *ArtifactConfig {
 		// a single artifact. Must be large enough to accommodate large payloads.
 		DecompressionSizeLimit: pointer.Of("100GB"),
 
+		// DecompressionMinSize extracts archives of any size, as go-getter
+		// does.
+		DecompressionMinSize: pointer.Of("0"),
+
+		// MaxFilesPerDir limits the number of entries of any one directory
+		// of an extracted archive. Must be large enough for the largest
+		// directories of typical payloads, such as node_modules or vendor
+		// trees, while still refusing directories of millions of entries.
+		MaxFilesPerDir: pointer.Of(100000),
+
+		// MaxParallelChunks limits the number of connections a single
+		// chunked artifact download opens at the same time.
+		MaxParallelChunks: pointer.Of(4),
+
 		// Toggle for disabling artifact inspection
 		DisableArtifactInspection: pointer.Of(false),
 
@@ -300,5 +646,52 @@ func DefaultArtifactConfig() *ArtifactConfig {
 
 		// No environment variables are inherited from Client by default.
 		SetEnvironmentVariables: pointer.Of(""),
+
+		// The artifact cache is disabled by default.
+		CacheDir: pointer.Of(""),
+
+		// Stale cached artifacts are never served by default.
+		CacheStaleIfError: pointer.Of("0s"),
+
+		// Size preflight HEAD requests are disabled by default.
+		HTTPSizePreflight: pointer.Of(false),
+
+		// Artifacts are only downloaded over TLS 1.2 or later by default.
+		MinTLSVersion: pointer.Of("tls12"),
+
+		// Artifacts are extracted automatically by default.
+		DisableAutoExtract: pointer.Of(false),
+
+		// No absolute symlinks are rewritten by default.
+		SymlinkRewriteRoots: nil,
+
+		// Artifacts may create symlinks within the sandbox by default.
+		DisallowSymlinks: pointer.Of(""),
+
+		// The setuid, setgid, and sticky bits of artifacts are stripped by
+		// default.
+		StripSetuid: pointer.Of(true),
+
+		// Artifacts cannot run post commands by default.
+		PostCmdAllowlist: nil,
+
+		// Artifacts are not evaluated against a policy by default.
+		PolicyURL: pointer.Of(""),
+	}
+}
+
+// IsSensitiveHeader returns whether the value of the HTTP header name may
+// contain credentials, and so must not be logged or returned by the API.
+func IsSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	for _, word := range []string{"token", "secret", "password", "key"} {
+		if strings.Contains(name, word) {
+			return true
+		}
 	}
+	return false
 }
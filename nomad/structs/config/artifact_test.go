@@ -20,6 +20,9 @@ func TestArtifactConfig_Copy(t *testing.T) {
 		"d:rx:/opt/bin",
 		"d:r:/tmp/stash",
 	}
+	a.DefaultHeaders = map[string]map[string]string{"https": {"X-Org": "acme"}}
+	a.DecompressorOverrides = map[string]string{"tar.gz": "go-getter"}
+	a.PostCmdAllowlist = []string{"/usr/bin/chmod"}
 	b := a.Copy()
 	must.Equal(t, a, b)
 	must.Equal(t, b, a)
@@ -30,11 +33,26 @@ func TestArtifactConfig_Copy(t *testing.T) {
 	b.HgTimeout = pointer.Of("2m")
 	b.DecompressionFileCountLimit = pointer.Of(7)
 	b.DecompressionSizeLimit = pointer.Of("2GB")
+	b.DecompressionMinSize = pointer.Of("1KB")
+	b.MaxFilesPerDir = pointer.Of(8)
+	b.MaxParallelChunks = pointer.Of(2)
 	must.NotEqual(t, a, b)
 
 	b = a.Copy()
 	b.FilesystemIsolationExtraPaths[1] = "f:rx:/opt/bin/runme"
 	must.NotEqual(t, a, b)
+
+	b = a.Copy()
+	b.DefaultHeaders["https"]["X-Org"] = "other"
+	must.NotEqual(t, a, b)
+
+	b = a.Copy()
+	b.DecompressorOverrides["tar.gz"] = "sparse"
+	must.NotEqual(t, a, b)
+
+	b = a.Copy()
+	b.PostCmdAllowlist[0] = "/bin/chmod"
+	must.NotEqual(t, a, b)
 }
 
 func TestArtifactConfig_Merge(t *testing.T) {
@@ -57,6 +75,9 @@ func TestArtifactConfig_Merge(t *testing.T) {
 				S3Timeout:                   pointer.Of("30m"),
 				DecompressionFileCountLimit: pointer.Of(4096),
 				DecompressionSizeLimit:      pointer.Of("100GB"),
+				DecompressionMinSize:        pointer.Of("0"),
+				MaxFilesPerDir:              pointer.Of(100000),
+				MaxParallelChunks:           pointer.Of(4),
 				DisableFilesystemIsolation:  pointer.Of(false),
 				FilesystemIsolationExtraPaths: []string{
 					"f:r:/dev/urandom",
@@ -64,6 +85,11 @@ func TestArtifactConfig_Merge(t *testing.T) {
 					"d:r:/tmp/stash",
 				},
 				SetEnvironmentVariables: pointer.Of(""),
+				CacheDir:                pointer.Of(""),
+				CacheStaleIfError:       pointer.Of("0s"),
+				HTTPSizePreflight:       pointer.Of(false),
+				MinTLSVersion:           pointer.Of("tls12"),
+				DisableAutoExtract:      pointer.Of(false),
 			},
 			other: &ArtifactConfig{
 				HTTPReadTimeout:             pointer.Of("5m"),
@@ -74,12 +100,28 @@ func TestArtifactConfig_Merge(t *testing.T) {
 				S3Timeout:                   pointer.Of("4m"),
 				DecompressionFileCountLimit: pointer.Of(100),
 				DecompressionSizeLimit:      pointer.Of("8GB"),
+				DecompressionMinSize:        pointer.Of("1KB"),
+				MaxFilesPerDir:              pointer.Of(1000),
+				MaxParallelChunks:           pointer.Of(8),
+				DecompressorOverrides:       map[string]string{"tar.gz": "go-getter"},
 				DisableFilesystemIsolation:  pointer.Of(true),
 				FilesystemIsolationExtraPaths: []string{
 					"d:rw:/opt/certs",
 					"f:rx:/opt/bin/runme",
 				},
 				SetEnvironmentVariables: pointer.Of("FOO,BAR"),
+				CacheDir:                pointer.Of("/var/cache/nomad"),
+				CacheStaleIfError:       pointer.Of("1h"),
+				HTTPSizePreflight:       pointer.Of(true),
+				MinTLSVersion:           pointer.Of("tls13"),
+				DisableAutoExtract:      pointer.Of(true),
+				SymlinkRewriteRoots:     []string{"/opt/app"},
+				DisallowSymlinks:        pointer.Of("fail"),
+				StripSetuid:             pointer.Of(false),
+				UnixSockets:             map[string]string{"artifacts.local": "unix:///run/artifacts.sock"},
+				DefaultHeaders:          map[string]map[string]string{"https": {"X-Org": "acme"}},
+				PostCmdAllowlist:        []string{"/usr/bin/chmod"},
+				PolicyURL:               pointer.Of("http://127.0.0.1:8181/v1/data/nomad/artifact"),
 			},
 			expected: &ArtifactConfig{
 				HTTPReadTimeout:             pointer.Of("5m"),
@@ -90,12 +132,28 @@ func TestArtifactConfig_Merge(t *testing.T) {
 				S3Timeout:                   pointer.Of("4m"),
 				DecompressionFileCountLimit: pointer.Of(100),
 				DecompressionSizeLimit:      pointer.Of("8GB"),
+				DecompressionMinSize:        pointer.Of("1KB"),
+				MaxFilesPerDir:              pointer.Of(1000),
+				MaxParallelChunks:           pointer.Of(8),
+				DecompressorOverrides:       map[string]string{"tar.gz": "go-getter"},
 				DisableFilesystemIsolation:  pointer.Of(true),
 				FilesystemIsolationExtraPaths: []string{
 					"d:rw:/opt/certs",
 					"f:rx:/opt/bin/runme",
 				},
 				SetEnvironmentVariables: pointer.Of("FOO,BAR"),
+				CacheDir:                pointer.Of("/var/cache/nomad"),
+				CacheStaleIfError:       pointer.Of("1h"),
+				HTTPSizePreflight:       pointer.Of(true),
+				MinTLSVersion:           pointer.Of("tls13"),
+				DisableAutoExtract:      pointer.Of(true),
+				SymlinkRewriteRoots:     []string{"/opt/app"},
+				DisallowSymlinks:        pointer.Of("fail"),
+				StripSetuid:             pointer.Of(false),
+				UnixSockets:             map[string]string{"artifacts.local": "unix:///run/artifacts.sock"},
+				DefaultHeaders:          map[string]map[string]string{"https": {"X-Org": "acme"}},
+				PostCmdAllowlist:        []string{"/usr/bin/chmod"},
+				PolicyURL:               pointer.Of("http://127.0.0.1:8181/v1/data/nomad/artifact"),
 			},
 		},
 		{
@@ -475,6 +533,76 @@ func TestArtifactConfig_Validate(t *testing.T) {
 			},
 			expErr: "decompression_size_limit is not a valid size",
 		},
+		{
+			name: "decompression min size is nil",
+			config: func(a *ArtifactConfig) {
+				a.DecompressionMinSize = nil
+			},
+			expErr: "decompression_min_size must not be nil",
+		},
+		{
+			name: "decompression min size is negative",
+			config: func(a *ArtifactConfig) {
+				a.DecompressionMinSize = pointer.Of("-1KB")
+			},
+			expErr: "decompression_min_size is not a valid size",
+		},
+		{
+			name: "max files per dir is nil",
+			config: func(a *ArtifactConfig) {
+				a.MaxFilesPerDir = nil
+			},
+			expErr: "max_files_per_dir must not be nil",
+		},
+		{
+			name: "max files per dir is negative",
+			config: func(a *ArtifactConfig) {
+				a.MaxFilesPerDir = pointer.Of(-1)
+			},
+			expErr: "max_files_per_dir must be >= 0 but found -1",
+		},
+		{
+			name: "max parallel chunks is nil",
+			config: func(a *ArtifactConfig) {
+				a.MaxParallelChunks = nil
+			},
+			expErr: "max_parallel_chunks must not be nil",
+		},
+		{
+			name: "max parallel chunks is zero",
+			config: func(a *ArtifactConfig) {
+				a.MaxParallelChunks = pointer.Of(0)
+			},
+			expErr: "max_parallel_chunks must be > 0 but found 0",
+		},
+		{
+			name: "max files per dir is zero",
+			config: func(a *ArtifactConfig) {
+				a.MaxFilesPerDir = pointer.Of(0)
+			},
+			expErr: "",
+		},
+		{
+			name: "decompressor overrides empty extension",
+			config: func(a *ArtifactConfig) {
+				a.DecompressorOverrides = map[string]string{"": "go-getter"}
+			},
+			expErr: "decompressor_overrides must not contain an empty extension",
+		},
+		{
+			name: "decompressor overrides empty name",
+			config: func(a *ArtifactConfig) {
+				a.DecompressorOverrides = map[string]string{"tar.gz": ""}
+			},
+			expErr: `decompressor_overrides for "tar.gz" must not be empty`,
+		},
+		{
+			name: "decompressor overrides ok",
+			config: func(a *ArtifactConfig) {
+				a.DecompressorOverrides = map[string]string{"tar.gz": "go-getter"}
+			},
+			expErr: "",
+		},
 		{
 			name: "fs isolation not set",
 			config: func(a *ArtifactConfig) {
@@ -499,6 +627,221 @@ func TestArtifactConfig_Validate(t *testing.T) {
 			},
 			expErr: "set_environment_variables must be set",
 		},
+		{
+			name: "cache dir not set",
+			config: func(a *ArtifactConfig) {
+				a.CacheDir = nil
+			},
+			expErr: "cache_dir must be set",
+		},
+		{
+			name: "cache dir is relative",
+			config: func(a *ArtifactConfig) {
+				a.CacheDir = pointer.Of("cache")
+			},
+			expErr: `cache_dir must be an absolute path but found "cache"`,
+		},
+		{
+			name: "cache stale if error not set",
+			config: func(a *ArtifactConfig) {
+				a.CacheStaleIfError = nil
+			},
+			expErr: "cache_stale_if_error must be set",
+		},
+		{
+			name: "cache stale if error invalid",
+			config: func(a *ArtifactConfig) {
+				a.CacheStaleIfError = pointer.Of("bad")
+			},
+			expErr: "cache_stale_if_error not a valid duration",
+		},
+		{
+			name: "cache stale if error negative",
+			config: func(a *ArtifactConfig) {
+				a.CacheStaleIfError = pointer.Of("-1h")
+				a.CacheDir = pointer.Of("/var/cache/nomad")
+			},
+			expErr: "cache_stale_if_error must be >= 0",
+		},
+		{
+			name: "cache stale if error without cache dir",
+			config: func(a *ArtifactConfig) {
+				a.CacheStaleIfError = pointer.Of("1h")
+			},
+			expErr: "cache_stale_if_error requires cache_dir to be set",
+		},
+		{
+			name: "cache stale if error with cache dir",
+			config: func(a *ArtifactConfig) {
+				a.CacheStaleIfError = pointer.Of("1h")
+				a.CacheDir = pointer.Of("/var/cache/nomad")
+			},
+			expErr: "",
+		},
+		{
+			name: "http size preflight not set",
+			config: func(a *ArtifactConfig) {
+				a.HTTPSizePreflight = nil
+			},
+			expErr: "http_size_preflight must be set",
+		},
+		{
+			name: "min tls version not set",
+			config: func(a *ArtifactConfig) {
+				a.MinTLSVersion = nil
+			},
+			expErr: "min_tls_version must be set",
+		},
+		{
+			name: "min tls version invalid",
+			config: func(a *ArtifactConfig) {
+				a.MinTLSVersion = pointer.Of("tls1.2")
+			},
+			expErr: "min_tls_version must be one of tls10, tls11, tls12 or tls13",
+		},
+		{
+			name: "disable auto extract not set",
+			config: func(a *ArtifactConfig) {
+				a.DisableAutoExtract = nil
+			},
+			expErr: "disable_auto_extract must be set",
+		},
+		{
+			name: "symlink rewrite root is relative",
+			config: func(a *ArtifactConfig) {
+				a.SymlinkRewriteRoots = []string{"/opt/app", "opt/lib"}
+			},
+			expErr: `symlink_rewrite_roots must contain absolute paths other than / but found "opt/lib"`,
+		},
+		{
+			name: "symlink rewrite root is filesystem root",
+			config: func(a *ArtifactConfig) {
+				a.SymlinkRewriteRoots = []string{"/"}
+			},
+			expErr: `symlink_rewrite_roots must contain absolute paths other than / but found "/"`,
+		},
+		{
+			name: "disallow symlinks not set",
+			config: func(a *ArtifactConfig) {
+				a.DisallowSymlinks = nil
+			},
+			expErr: "disallow_symlinks must be set",
+		},
+		{
+			name: "disallow symlinks invalid",
+			config: func(a *ArtifactConfig) {
+				a.DisallowSymlinks = pointer.Of("remove")
+			},
+			expErr: `disallow_symlinks must be empty, skip or fail but found "remove"`,
+		},
+		{
+			name: "disallow symlinks skip",
+			config: func(a *ArtifactConfig) {
+				a.DisallowSymlinks = pointer.Of("skip")
+			},
+			expErr: "",
+		},
+		{
+			name: "strip setuid not set",
+			config: func(a *ArtifactConfig) {
+				a.StripSetuid = nil
+			},
+			expErr: "strip_setuid must be set",
+		},
+		{
+			name: "unix socket address without scheme",
+			config: func(a *ArtifactConfig) {
+				a.UnixSockets = map[string]string{"artifacts.local": "/run/artifacts.sock"}
+			},
+			expErr: `unix_sockets address for "artifacts.local" must be unix:// followed by an absolute path but found "/run/artifacts.sock"`,
+		},
+		{
+			name: "unix socket address is relative",
+			config: func(a *ArtifactConfig) {
+				a.UnixSockets = map[string]string{"artifacts.local": "unix://run/artifacts.sock"}
+			},
+			expErr: `must be unix:// followed by an absolute path`,
+		},
+		{
+			name: "unix socket address is valid",
+			config: func(a *ArtifactConfig) {
+				a.UnixSockets = map[string]string{"artifacts.local": "unix:///run/artifacts.sock"}
+			},
+			expErr: "",
+		},
+		{
+			name: "default headers scheme is not http",
+			config: func(a *ArtifactConfig) {
+				a.DefaultHeaders = map[string]map[string]string{"s3": {"X-Org": "acme"}}
+			},
+			expErr: `default_headers scheme must be http or https but found "s3"`,
+		},
+		{
+			name: "default headers name is invalid",
+			config: func(a *ArtifactConfig) {
+				a.DefaultHeaders = map[string]map[string]string{"https": {"X Org": "acme"}}
+			},
+			expErr: `default_headers for "https" contains invalid header name "X Org"`,
+		},
+		{
+			name: "default headers are valid",
+			config: func(a *ArtifactConfig) {
+				a.DefaultHeaders = map[string]map[string]string{
+					"http":  {"X-Org": "acme"},
+					"https": {"Accept": "application/octet-stream", "X-Org": "acme"},
+				}
+			},
+			expErr: "",
+		},
+		{
+			name: "post cmd allowlist path is relative",
+			config: func(a *ArtifactConfig) {
+				a.PostCmdAllowlist = []string{"/usr/bin/chmod", "bin/unpack"}
+			},
+			expErr: `post_cmd_allowlist must contain clean absolute paths but found "bin/unpack"`,
+		},
+		{
+			name: "post cmd allowlist path is not clean",
+			config: func(a *ArtifactConfig) {
+				a.PostCmdAllowlist = []string{"/usr/bin/../bin/chmod"}
+			},
+			expErr: `post_cmd_allowlist must contain clean absolute paths`,
+		},
+		{
+			name: "post cmd allowlist is valid",
+			config: func(a *ArtifactConfig) {
+				a.PostCmdAllowlist = []string{"/usr/bin/chmod", "/opt/bin/unpack"}
+			},
+			expErr: "",
+		},
+		{
+			name: "policy url not set",
+			config: func(a *ArtifactConfig) {
+				a.PolicyURL = nil
+			},
+			expErr: "policy_url must be set",
+		},
+		{
+			name: "policy url is not http",
+			config: func(a *ArtifactConfig) {
+				a.PolicyURL = pointer.Of("unix:///run/opa.sock")
+			},
+			expErr: `policy_url must be an http or https URL but found "unix:///run/opa.sock"`,
+		},
+		{
+			name: "policy url is valid",
+			config: func(a *ArtifactConfig) {
+				a.PolicyURL = pointer.Of("https://opa.internal:8181/v1/data/nomad/artifact")
+			},
+			expErr: "",
+		},
+		{
+			name: "cache dir is absolute",
+			config: func(a *ArtifactConfig) {
+				a.CacheDir = pointer.Of("/var/cache/nomad")
+			},
+			expErr: "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -517,3 +860,19 @@ func TestArtifactConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSensitiveHeader(t *testing.T) {
+	ci.Parallel(t)
+
+	for name, exp := range map[string]bool{
+		"Authorization":       true,
+		"proxy-authorization": true,
+		"Cookie":              true,
+		"X-Vault-Token":       true,
+		"X-Api-Key":           true,
+		"Accept":              false,
+		"X-Org":               false,
+	} {
+		must.Eq(t, exp, IsSensitiveHeader(name), must.Sprint(name))
+	}
+}
@@ -33,6 +33,19 @@ type AutopilotConfig struct {
 	// before autopilot can prune dead servers.
 	MinQuorum int `hcl:"min_quorum"`
 
+	// MinDiskFree is the minimum free space of the volume holding a server's
+	// Raft data before the server is considered unhealthy, such as "5GB".
+	MinDiskFree string `hcl:"min_disk_free"`
+
+	// MaxLastLogAge is how far behind the leader's, in time, the last log
+	// applied by a server can be before the server is considered unhealthy.
+	MaxLastLogAge    time.Duration
+	MaxLastLogAgeHCL string `hcl:"max_last_log_age" json:"-"`
+
+	// MaxAppliedIndexLag is the number of logs a server can trail the
+	// leader's applied index by before being considered unhealthy.
+	MaxAppliedIndexLag int `hcl:"max_applied_index_lag"`
+
 	// (Enterprise-only) EnableRedundancyZones specifies whether to enable redundancy zones.
 	EnableRedundancyZones *bool `hcl:"enable_redundancy_zones"`
 
@@ -83,6 +96,18 @@ func (a *AutopilotConfig) Merge(b *AutopilotConfig) *AutopilotConfig {
 	if b.MinQuorum != 0 {
 		result.MinQuorum = b.MinQuorum
 	}
+	if b.MinDiskFree != "" {
+		result.MinDiskFree = b.MinDiskFree
+	}
+	if b.MaxLastLogAge != 0 {
+		result.MaxLastLogAge = b.MaxLastLogAge
+	}
+	if b.MaxLastLogAgeHCL != "" {
+		result.MaxLastLogAgeHCL = b.MaxLastLogAgeHCL
+	}
+	if b.MaxAppliedIndexLag != 0 {
+		result.MaxAppliedIndexLag = b.MaxAppliedIndexLag
+	}
 	if b.EnableRedundancyZones != nil {
 		result.EnableRedundancyZones = b.EnableRedundancyZones
 	}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"slices"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+)
+
+// EnvFromNodeMetaConfig describes which node metadata is exported into the
+// environment of every task on a client.
+type EnvFromNodeMetaConfig struct {
+	// AllowedPrefixes are the prefixes of the node metadata keys exported.
+	AllowedPrefixes []string `hcl:"allowed_prefixes"`
+
+	// DeniedPrefixes are the prefixes of the node metadata keys never
+	// exported, even if they match an allowed prefix.
+	DeniedPrefixes []string `hcl:"denied_prefixes"`
+
+	// EnvPrefix is prepended to the node metadata keys after NOMAD_META_ to
+	// form the names of the environment variables. Defaults to "NODE_".
+	EnvPrefix *string `hcl:"env_prefix"`
+}
+
+func (e *EnvFromNodeMetaConfig) Copy() *EnvFromNodeMetaConfig {
+	if e == nil {
+		return nil
+	}
+
+	ne := new(EnvFromNodeMetaConfig)
+	*ne = *e
+	ne.AllowedPrefixes = slices.Clone(e.AllowedPrefixes)
+	ne.DeniedPrefixes = slices.Clone(e.DeniedPrefixes)
+	ne.EnvPrefix = pointer.Copy(e.EnvPrefix)
+	return ne
+}
+
+// Merge returns a copy of e with the options set in o. The prefix lists of o
+// replace those of e when set.
+func (e *EnvFromNodeMetaConfig) Merge(o *EnvFromNodeMetaConfig) *EnvFromNodeMetaConfig {
+	switch {
+	case e == nil:
+		return o.Copy()
+	case o == nil:
+		return e.Copy()
+	default:
+		ne := e.Copy()
+		if o.AllowedPrefixes != nil {
+			ne.AllowedPrefixes = slices.Clone(o.AllowedPrefixes)
+		}
+		if o.DeniedPrefixes != nil {
+			ne.DeniedPrefixes = slices.Clone(o.DeniedPrefixes)
+		}
+		ne.EnvPrefix = pointer.Merge(e.EnvPrefix, o.EnvPrefix)
+		return ne
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestEnvFromNodeMetaConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	a := &EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack", "zone"},
+		DeniedPrefixes:  []string{"zone_secret"},
+	}
+	b := &EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack"},
+		EnvPrefix:       pointer.Of("HOST_"),
+	}
+
+	result := a.Merge(b)
+	must.Eq(t, &EnvFromNodeMetaConfig{
+		AllowedPrefixes: []string{"rack"},
+		DeniedPrefixes:  []string{"zone_secret"},
+		EnvPrefix:       pointer.Of("HOST_"),
+	}, result)
+
+	// the originals are unmodified
+	must.Eq(t, []string{"rack", "zone"}, a.AllowedPrefixes)
+	must.Nil(t, a.EnvPrefix)
+
+	must.Eq(t, a, a.Merge(nil))
+	must.Eq(t, b, (*EnvFromNodeMetaConfig)(nil).Merge(b))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"slices"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pointer"
+)
+
+// ClientGCConfig describes how a client garbage collects terminal allocations
+// in addition to the top level gc_* client options.
+type ClientGCConfig struct {
+	// DiskUsageLowThreshold is the disk usage given as a percent that garbage
+	// collection started by exceeding gc_disk_usage_threshold continues until
+	// usage is below. Defaults to gc_disk_usage_threshold.
+	DiskUsageLowThreshold *float64 `hcl:"disk_usage_low_threshold"`
+
+	// Policies are the garbage collection policies for allocations of each
+	// job type.
+	Policies []*ClientGCPolicy `hcl:"policies"`
+}
+
+// ClientGCPolicy describes when terminal allocations of a job type are
+// garbage collected regardless of disk usage.
+type ClientGCPolicy struct {
+	// JobType is the type of job the policy applies to.
+	JobType string `hcl:"job_type"`
+
+	// MaxAge is the duration after an allocation becomes terminal that it
+	// is garbage collected.
+	MaxAge string `hcl:"max_age"`
+}
+
+func (p *ClientGCPolicy) Copy() *ClientGCPolicy {
+	if p == nil {
+		return nil
+	}
+
+	np := new(ClientGCPolicy)
+	*np = *p
+	return np
+}
+
+func (g *ClientGCConfig) Copy() *ClientGCConfig {
+	if g == nil {
+		return nil
+	}
+
+	ng := new(ClientGCConfig)
+	*ng = *g
+	ng.DiskUsageLowThreshold = pointer.Copy(g.DiskUsageLowThreshold)
+	ng.Policies = helper.CopySlice(g.Policies)
+	return ng
+}
+
+// Merge returns a copy of g with the options set in o. Policies in o replace
+// the policies in g for the same job type.
+func (g *ClientGCConfig) Merge(o *ClientGCConfig) *ClientGCConfig {
+	switch {
+	case g == nil:
+		return o.Copy()
+	case o == nil:
+		return g.Copy()
+	default:
+		ng := g.Copy()
+		if o.DiskUsageLowThreshold != nil {
+			ng.DiskUsageLowThreshold = pointer.Copy(o.DiskUsageLowThreshold)
+		}
+		for _, p := range o.Policies {
+			ng.Policies = slices.DeleteFunc(ng.Policies, func(np *ClientGCPolicy) bool {
+				return np.JobType == p.JobType
+			})
+			ng.Policies = append(ng.Policies, p.Copy())
+		}
+		return ng
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestClientGCConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	a := &ClientGCConfig{
+		DiskUsageLowThreshold: pointer.Of(60.0),
+		Policies: []*ClientGCPolicy{
+			{JobType: "batch", MaxAge: "1h"},
+			{JobType: "service", MaxAge: "24h"},
+		},
+	}
+	b := &ClientGCConfig{
+		Policies: []*ClientGCPolicy{
+			{JobType: "batch", MaxAge: "2h"},
+			{JobType: "sysbatch", MaxAge: "30m"},
+		},
+	}
+
+	result := a.Merge(b)
+	must.Eq(t, &ClientGCConfig{
+		DiskUsageLowThreshold: pointer.Of(60.0),
+		Policies: []*ClientGCPolicy{
+			{JobType: "service", MaxAge: "24h"},
+			{JobType: "batch", MaxAge: "2h"},
+			{JobType: "sysbatch", MaxAge: "30m"},
+		},
+	}, result)
+
+	// the originals are unmodified
+	must.Eq(t, "1h", a.Policies[0].MaxAge)
+	must.Len(t, 2, a.Policies)
+
+	must.Eq(t, a, a.Merge(nil))
+	must.Eq(t, b, (*ClientGCConfig)(nil).Merge(b))
+}
@@ -262,6 +262,20 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		}
 	}
 
+	// MaxRunTime diff
+	if oldPrimitiveFlat != nil && newPrimitiveFlat != nil {
+		if tg.MaxRunTime == nil {
+			oldPrimitiveFlat["MaxRunTime"] = ""
+		} else {
+			oldPrimitiveFlat["MaxRunTime"] = fmt.Sprintf("%d", *tg.MaxRunTime)
+		}
+		if other.MaxRunTime == nil {
+			newPrimitiveFlat["MaxRunTime"] = ""
+		} else {
+			newPrimitiveFlat["MaxRunTime"] = fmt.Sprintf("%d", *other.MaxRunTime)
+		}
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, false)
 
@@ -515,13 +529,18 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, dDiff)
 	}
 
+	// Node payload diff
+	if nDiff := nodePayloadDiff(t.NodePayload, other.NodePayload, contextual); nDiff != nil {
+		diff.Objects = append(diff.Objects, nDiff)
+	}
+
+	// Service discovery diff
+	if sDiff := serviceDiscoveryDiff(t.ServiceDiscovery, other.ServiceDiscovery, contextual); sDiff != nil {
+		diff.Objects = append(diff.Objects, sDiff)
+	}
+
 	// Artifacts diff
-	diffs := primitiveObjectSetDiff(
-		interfaceSlice(t.Artifacts),
-		interfaceSlice(other.Artifacts),
-		nil,
-		"Artifact",
-		contextual)
+	diffs := artifactDiffs(t.Artifacts, other.Artifacts, contextual)
 	if diffs != nil {
 		diff.Objects = append(diff.Objects, diffs...)
 	}
@@ -537,6 +556,11 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, vDiff)
 	}
 
+	// Additional Vault blocks diff
+	if vDiffs := vaultsDiffs(t.Vaults, other.Vaults, contextual); vDiffs != nil {
+		diff.Objects = append(diff.Objects, vDiffs...)
+	}
+
 	secDiffs := secretsDiffs(t.Secrets, other.Secrets, contextual)
 	if secDiffs != nil {
 		diff.Objects = append(diff.Objects, secDiffs...)
@@ -880,13 +904,23 @@ func serviceDiff(old, new *Service, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, conDiffs)
 	}
 
+	// Consul namespace and partition diffs
+	if consulDiff := primitiveObjectDiff(old.Consul, new.Consul, nil, "Consul", contextual); consulDiff != nil {
+		diff.Objects = append(diff.Objects, consulDiff)
+	}
+
 	// Workload Identity diffs
 	if wiDiffs := idDiff(old.Identity, new.Identity, contextual); wiDiffs != nil {
 		diff.Objects = append(diff.Objects, wiDiffs)
 	}
 
 	// Weights diffs
-	if weightsDiffs := weightsDiff(old.Weights, new.Weights, contextual); weightsDiffs != nil {
+	if weightsDiffs := weightsDiff(old.Weights, new.Weights, "Weights", contextual); weightsDiffs != nil {
+		diff.Objects = append(diff.Objects, weightsDiffs)
+	}
+
+	// Canary weights diffs
+	if weightsDiffs := weightsDiff(old.CanaryWeights, new.CanaryWeights, "CanaryWeights", contextual); weightsDiffs != nil {
 		diff.Objects = append(diff.Objects, weightsDiffs)
 	}
 
@@ -1581,6 +1615,78 @@ func connectGatewayIngressServiceDiff(prev, next *ConsulIngressService, contextu
 	return diff
 }
 
+// nodePayloadDiff returns the diff of two node payload configurations, or nil
+// if they are equal.
+func nodePayloadDiff(prev, next *NodePayloadConfig, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "NodePayload"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(prev, next) {
+		return nil
+	} else if prev == nil {
+		prev = new(NodePayloadConfig)
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	} else if next == nil {
+		next = new(NodePayloadConfig)
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Diff the keys.
+	if setDiff := stringSetDiff(prev.MetaKeys, next.MetaKeys, "MetaKeys", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+	if setDiff := stringSetDiff(prev.AttrKeys, next.AttrKeys, "AttrKeys", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
+	return diff
+}
+
+// serviceDiscoveryDiff returns the diff of two service discovery
+// configurations, or nil if they are equal. Upstreams are matched by name.
+func serviceDiscoveryDiff(prev, next *ServiceDiscovery, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "ServiceDiscovery"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(prev, next) {
+		return nil
+	} else if prev == nil {
+		prev = new(ServiceDiscovery)
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	} else if next == nil {
+		next = new(ServiceDiscovery)
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Diff the upstreams.
+	if uDiffs := primitiveObjectSetDiff(
+		interfaceSlice(prev.Upstreams),
+		interfaceSlice(next.Upstreams),
+		nil, "Upstream", contextual); uDiffs != nil {
+		diff.Objects = append(diff.Objects, uDiffs...)
+	}
+
+	return diff
+}
+
 func connectGatewayHTTPHeaderModifiersDiff(prev, next *ConsulHTTPHeaderModifiers, name string, contextual bool) *ObjectDiff {
 	diff := &ObjectDiff{Type: DiffTypeNone, Name: name}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
@@ -1893,6 +1999,11 @@ func consulProxyUpstreamDiff(prev, next ConsulUpstream, contextual bool) *Object
 		diff.Objects = append(diff.Objects, mDiff)
 	}
 
+	// diff the passive health check primitive object
+	if pDiff := primitiveObjectDiff(prev.PassiveHealthCheck, next.PassiveHealthCheck, nil, "PassiveHealthCheck", contextual); pDiff != nil {
+		diff.Objects = append(diff.Objects, pDiff)
+	}
+
 	return diff
 }
 
@@ -2041,6 +2152,38 @@ func vaultDiff(old, new *Vault, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// vaultsDiffs diffs a set of Vault blocks, matching blocks by their cluster.
+// If contextual diff is enabled, all fields of edited blocks will be
+// returned, even if no diff occurred.
+func vaultsDiffs(old, new []*Vault, contextual bool) []*ObjectDiff {
+	var diffs []*ObjectDiff
+
+	oldMap := make(map[string]*Vault, len(old))
+	newMap := make(map[string]*Vault, len(new))
+	for _, o := range old {
+		oldMap[o.ClusterName()] = o
+	}
+	for _, n := range new {
+		newMap[n.ClusterName()] = n
+	}
+
+	for k, v := range oldMap {
+		if diff := vaultDiff(v, newMap[k], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for k, v := range newMap {
+		if _, ok := oldMap[k]; !ok {
+			if diff := vaultDiff(nil, v, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
 // waitConfigDiff returns the diff of two WaitConfig objects. If contextual diff is
 // enabled, all fields will be returned, even if no diff occurred.
 func waitConfigDiff(old, new *WaitConfig, contextual bool) *ObjectDiff {
@@ -2676,6 +2819,12 @@ func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectD
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"_struct", "Device", "CIDR", "IP", "MBits"}
 
+	// Only diff PreferIPv6 when it is set, so that the networks of jobs which
+	// don't use it aren't shown with a false value
+	if (n == nil || !n.PreferIPv6) && (other == nil || !other.PreferIPv6) {
+		filter = append(filter, "PreferIPv6")
+	}
+
 	if reflect.DeepEqual(n, other) {
 		return nil
 	} else if n == nil {
@@ -3032,7 +3181,7 @@ func idSliceDiffs(old, new []*WorkloadIdentity, contextual bool) []*ObjectDiff {
 	return diffs
 }
 
-func weightsDiff(oldWeights *ServiceWeights, newWeights *ServiceWeights, contextual bool) *ObjectDiff {
+func weightsDiff(oldWeights *ServiceWeights, newWeights *ServiceWeights, name string, contextual bool) *ObjectDiff {
 	if reflect.DeepEqual(oldWeights, newWeights) {
 		return nil
 	}
@@ -3048,7 +3197,7 @@ func weightsDiff(oldWeights *ServiceWeights, newWeights *ServiceWeights, context
 		return m
 	}
 
-	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Weights"}
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: name}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	if oldWeights == nil {
 		diff.Type = DiffTypeAdded
@@ -3349,6 +3498,10 @@ func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, setDiff)
 	}
 
+	if setDiff := stringSetDiff(old.Exclusions, new.Exclusions, "Exclusions", contextual); setDiff != nil && setDiff.Type != DiffTypeNone {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	sort.Sort(FieldDiffs(diff.Fields))
 	return diff
 }
@@ -3393,6 +3546,130 @@ Loop:
 	return diff
 }
 
+// artifactDiffs diffs a set of artifacts, matched by their DiffID.
+func artifactDiffs(old, new []*TaskArtifact, contextual bool) []*ObjectDiff {
+	makeSet := func(artifacts []*TaskArtifact) map[string]*TaskArtifact {
+		set := make(map[string]*TaskArtifact, len(artifacts))
+		for _, artifact := range artifacts {
+			key := artifact.DiffID()
+			if key == "" {
+				hash, err := hashstructure.Hash(artifact, nil)
+				if err != nil {
+					panic(err)
+				}
+				key = fmt.Sprintf("%d", hash)
+			}
+			set[key] = artifact
+		}
+		return set
+	}
+
+	oldSet := makeSet(old)
+	newSet := makeSet(new)
+
+	var diffs []*ObjectDiff
+	for k, oldArtifact := range oldSet {
+		if diff := artifactDiff(oldArtifact, newSet[k], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for k, newArtifact := range newSet {
+		// Added
+		if _, ok := oldSet[k]; !ok {
+			diffs = append(diffs, artifactDiff(nil, newArtifact, contextual))
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// artifactDiff returns the diff of two artifacts, including their Vault PKI,
+// Vault AWS, pre-auth and post command blocks. If there is no difference, nil is
+// returned.
+func artifactDiff(old, new *TaskArtifact, contextual bool) *ObjectDiff {
+	diff := primitiveObjectDiff(old, new, nil, "Artifact", contextual)
+
+	var oldPKI, newPKI *ArtifactVaultPKI
+	var oldAWS, newAWS *ArtifactVaultAWS
+	var oldPreAuth, newPreAuth *ArtifactPreAuth
+	var oldPostCmd, newPostCmd *ArtifactPostCmd
+	if old != nil {
+		oldPKI = old.GetterVaultPKI
+		oldAWS = old.GetterVaultAWS
+		oldPreAuth = old.GetterPreAuth
+		oldPostCmd = old.GetterPostCmd
+	}
+	if new != nil {
+		newPKI = new.GetterVaultPKI
+		newAWS = new.GetterVaultAWS
+		newPreAuth = new.GetterPreAuth
+		newPostCmd = new.GetterPostCmd
+	}
+
+	var objects []*ObjectDiff
+	if pkiDiff := primitiveObjectDiff(oldPKI, newPKI, nil, "VaultPKI", contextual); pkiDiff != nil {
+		objects = append(objects, pkiDiff)
+	}
+	if awsDiff := primitiveObjectDiff(oldAWS, newAWS, nil, "VaultAWS", contextual); awsDiff != nil {
+		objects = append(objects, awsDiff)
+	}
+	if preAuthDiff := primitiveObjectDiff(oldPreAuth, newPreAuth, nil, "PreAuth", contextual); preAuthDiff != nil {
+		objects = append(objects, preAuthDiff)
+	}
+	if postCmdDiff := artifactPostCmdDiff(oldPostCmd, newPostCmd, contextual); postCmdDiff != nil {
+		objects = append(objects, postCmdDiff)
+	}
+	if len(objects) == 0 {
+		return diff
+	}
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Artifact"}
+		if contextual {
+			diff.Fields = fieldDiffs(
+				flatmap.Flatten(old, nil, true),
+				flatmap.Flatten(new, nil, true),
+				contextual)
+		}
+	}
+	diff.Objects = append(diff.Objects, objects...)
+	return diff
+}
+
+// artifactPostCmdDiff returns the diff of two artifact post commands. If
+// contextual diff is enabled, all fields will be returned, even if no diff
+// occurred.
+func artifactPostCmdDiff(old, new *ArtifactPostCmd, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "PostCmd"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &ArtifactPostCmd{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, []string{"Args"}, true)
+	} else if new == nil {
+		new = &ArtifactPostCmd{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, []string{"Args"}, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, []string{"Args"}, true)
+		newPrimitiveFlat = flatmap.Flatten(new, []string{"Args"}, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Args diffs
+	if setDiff := stringSetDiff(old.Args, new.Args, "Args", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
+	return diff
+}
+
 // primitiveObjectSetDiff does a set difference of the old and new sets. The
 // filter parameter can be used to filter a set of primitive fields in the
 // passed structs. The name corresponds to the name of the passed objects. If
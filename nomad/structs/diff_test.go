@@ -201,6 +201,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "batch",
 						New:  "",
 					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "VersionRetention",
+						Old:  "0",
+						New:  "",
+					},
 				},
 			},
 		},
@@ -270,6 +276,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "",
 						New:  "batch",
 					},
+					{
+						Type: DiffTypeAdded,
+						Name: "VersionRetention",
+						Old:  "",
+						New:  "0",
+					},
 				},
 			},
 		},
@@ -534,6 +546,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxMissedLaunches",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -588,6 +606,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxMissedLaunches",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -656,6 +680,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxMissedLaunches",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "ProhibitOverlap",
@@ -1033,6 +1063,24 @@ func TestJobDiff(t *testing.T) {
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ExclusionCalendar",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxMissedLaunches",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "MissedLaunchPolicy",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "ProhibitOverlap",
@@ -2466,6 +2514,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "15000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Jitter",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "MaxDelay",
@@ -2527,6 +2581,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "15000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Jitter",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "MaxDelay",
@@ -2654,6 +2714,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "2000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Jitter",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MaxDelay",
@@ -3322,6 +3388,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Class",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Migrate",
@@ -3840,6 +3912,12 @@ func TestTaskGroupDiff(t *testing.T) {
 														Type: DiffTypeAdded,
 														Name: "ConsulUpstreams",
 														Fields: []*FieldDiff{
+															{
+																Type: DiffTypeAdded,
+																Name: "ConnectTimeout",
+																Old:  "",
+																New:  "0",
+															},
 															{
 																Type: DiffTypeAdded,
 																Name: "Datacenter",
@@ -4696,6 +4774,33 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "TaskGroup max_run_time edited",
+			Old: &TaskGroup{
+				MaxRunTime: pointer.Of(time.Hour),
+			},
+			New: &TaskGroup{
+				MaxRunTime: pointer.Of(2 * time.Hour),
+				OnTimeout:  TaskGroupOnTimeoutComplete,
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Fields: []*FieldDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "MaxRunTime",
+						Old:  "3600000000000",
+						New:  "7200000000000",
+					},
+					{
+						Type: DiffTypeAdded,
+						Name: "OnTimeout",
+						Old:  "",
+						New:  "complete",
+					},
+				},
+			},
+		},
 
 		{
 			TestCase: "TaskGroup volumes added",
@@ -6001,18 +6106,42 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "GetterKeepArchive",
+								Old:  "",
+								New:  "false",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "GetterMaxParallel",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "GetterMode",
 								Old:  "",
 								New:  "file",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "GetterOptional",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "GetterOptions[bam]",
 								Old:  "",
 								New:  "baz",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "GetterOverlay",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "GetterSource",
@@ -6049,18 +6178,42 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "GetterKeepArchive",
+								Old:  "false",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "GetterMaxParallel",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "GetterMode",
 								Old:  "dir",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "GetterOptional",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "GetterOptions[bar]",
 								Old:  "baz",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "GetterOverlay",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "GetterSource",
@@ -6078,6 +6231,217 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Artifact vault_pki edited",
+			Old: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+						GetterVaultPKI: &ArtifactVaultPKI{
+							Path: "pki/issue/foo",
+						},
+					},
+				},
+			},
+			New: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+						GetterVaultPKI: &ArtifactVaultPKI{
+							Path: "pki/issue/foo",
+							TTL:  time.Minute,
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Artifact",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "VaultPKI",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "TTL",
+										Old:  "0",
+										New:  "60000000000",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Artifact pre_auth added",
+			Old: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+					},
+				},
+			},
+			New: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+						GetterPreAuth: &ArtifactPreAuth{
+							URL: "https://example.com/login",
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Artifact",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "PreAuth",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "URL",
+										Old:  "",
+										New:  "https://example.com/login",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Artifact post_cmd added",
+			Old: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+					},
+				},
+			},
+			New: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+						GetterPostCmd: &ArtifactPostCmd{
+							Command: "chmod",
+							Args:    []string{"+x", "app"},
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Artifact",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "PostCmd",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Command",
+										Old:  "",
+										New:  "chmod",
+									},
+								},
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Args",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeAdded,
+												Name: "Args",
+												Old:  "",
+												New:  "+x",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Args",
+												Old:  "",
+												New:  "app",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Artifact vault_aws added",
+			Old: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+					},
+				},
+			},
+			New: &Task{
+				Artifacts: []*TaskArtifact{
+					{
+						GetterSource: "foo",
+						RelativeDest: "foo",
+						GetterVaultAWS: &ArtifactVaultAWS{
+							Path: "aws/sts/foo",
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Artifact",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "VaultAWS",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Path",
+										Old:  "",
+										New:  "aws/sts/foo",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "TTL",
+										Old:  "",
+										New:  "0",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "Resources edited (no networks)",
 			Old: &Task{
@@ -10556,6 +10920,118 @@ func TestServicesDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:       "SidecarService upstream connect timeout and passive health check",
+			Contextual: false,
+			Old: []*Service{
+				{
+					Name:      "webapp",
+					Provider:  "consul",
+					PortLabel: "http",
+					Connect: &ConsulConnect{
+						SidecarService: &ConsulSidecarService{
+							Port: "http",
+							Proxy: &ConsulProxy{
+								Upstreams: []ConsulUpstream{
+									{
+										DestinationName: "count-api",
+										LocalBindPort:   8080,
+										ConnectTimeout:  time.Second,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			New: []*Service{
+				{
+					Name:      "webapp",
+					Provider:  "consul",
+					PortLabel: "http",
+					Connect: &ConsulConnect{
+						SidecarService: &ConsulSidecarService{
+							Port: "http",
+							Proxy: &ConsulProxy{
+								Upstreams: []ConsulUpstream{
+									{
+										DestinationName: "count-api",
+										LocalBindPort:   8080,
+										ConnectTimeout:  5 * time.Second,
+										PassiveHealthCheck: ConsulPassiveHealthCheck{
+											MaxFailures: 3,
+											Interval:    10 * time.Second,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Expected: []*ObjectDiff{
+				{
+					Type: DiffTypeEdited,
+					Name: "Service",
+					Objects: []*ObjectDiff{
+						{
+							Type: DiffTypeEdited,
+							Name: "ConsulConnect",
+							Objects: []*ObjectDiff{
+								{
+									Type: DiffTypeEdited,
+									Name: "SidecarService",
+									Objects: []*ObjectDiff{
+										{
+											Type: DiffTypeEdited,
+											Name: "ConsulProxy",
+											Objects: []*ObjectDiff{
+												{
+													Type: DiffTypeEdited,
+													Name: "ConsulUpstreams",
+													Fields: []*FieldDiff{
+														{
+															Type: DiffTypeEdited,
+															Name: "ConnectTimeout",
+															Old:  "1000000000",
+															New:  "5000000000",
+														},
+													},
+													Objects: []*ObjectDiff{
+														{
+															Type: DiffTypeEdited,
+															Name: "PassiveHealthCheck",
+															Fields: []*FieldDiff{
+																{
+																	Type: DiffTypeEdited,
+																	Name: "Interval",
+																	Old:  "0",
+																	New:  "10000000000",
+																},
+																{
+																	Type: DiffTypeEdited,
+																	Name: "MaxFailures",
+																	Old:  "0",
+																	New:  "3",
+																},
+															},
+														},
+													},
+												},
+												{
+													Type: DiffTypeNone,
+													Name: "TransparentProxy",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:       "SidecarService with different meta",
 			Contextual: false,
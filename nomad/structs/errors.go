@@ -13,6 +13,7 @@ import (
 const (
 	errNoLeader                   = "No cluster leader"
 	errNotReadyForConsistentReads = "Not ready to serve consistent reads"
+	errMaxStaleExceeded           = "Stale read exceeds max stale duration and no leader to forward to"
 	errNoRegionPath               = "No path to region"
 	errTokenNotFound              = "ACL token not found"
 	errTokenExpired               = "ACL token expired"
@@ -51,6 +52,7 @@ const (
 var (
 	ErrNoLeader                   = errors.New(errNoLeader)
 	ErrNotReadyForConsistentReads = errors.New(errNotReadyForConsistentReads)
+	ErrMaxStaleExceeded           = errors.New(errMaxStaleExceeded)
 	ErrNoRegionPath               = errors.New(errNoRegionPath)
 	ErrTokenNotFound              = errors.New(errTokenNotFound)
 	ErrTokenExpired               = errors.New(errTokenExpired)
@@ -89,6 +91,12 @@ func IsErrNoLeader(err error) bool {
 	return err != nil && strings.Contains(err.Error(), errNoLeader)
 }
 
+// IsErrMaxStaleExceeded returns whether the error is due to a stale read
+// exceeding its max stale duration without a leader to forward it to.
+func IsErrMaxStaleExceeded(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errMaxStaleExceeded)
+}
+
 // IsErrNoRegionPath returns whether the error is due to there being no path to
 // the given region.
 func IsErrNoRegionPath(err error) bool {
@@ -224,6 +224,11 @@ type Evaluation struct {
 	// the SnapshotIndex being less than the CreateIndex.
 	SnapshotIndex uint64
 
+	// TraceParent is the W3C traceparent of the span which created the
+	// evaluation, if it is traced, so that the spans of processing the
+	// evaluation join the same trace.
+	TraceParent string
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -42,6 +42,7 @@ const (
 	TypeNodeEligibilityUpdate         = "NodeEligibility"
 	TypeNodeDrain                     = "NodeDrain"
 	TypeNodeEvent                     = "NodeStreamEvent"
+	TypeNodeEventAdded                = "NodeEventAdded"
 	TypeNodePoolUpserted              = "NodePoolUpserted"
 	TypeNodePoolDeleted               = "NodePoolDeleted"
 	TypeDeploymentUpdate              = "DeploymentStatusUpdate"
@@ -73,6 +74,7 @@ const (
 	TypeCSIVolumeDeregistered         = "CSIVolumeDeregistered"
 	TypeCSIVolumeClaim                = "CSIVolumeClaim"
 	TypeUtilizationSnapshotUpserted   = "UtilizationSnapshotUpserted"
+	TypeServerHealthUpdated           = "ServerHealthUpdated"
 )
 
 // Event represents a change in Nomads state.
@@ -146,6 +148,12 @@ type NodeStreamEvent struct {
 	Node *Node
 }
 
+// NodeEventStreamEvent holds a NodeEvent newly added to a Node
+type NodeEventStreamEvent struct {
+	NodeID    string
+	NodeEvent *NodeEvent
+}
+
 // NodePoolEvent holds a newly updated NodePool.
 type NodePoolEvent struct {
 	NodePool *NodePool
@@ -216,3 +224,10 @@ type CSIVolumeEvent struct {
 type CSIPluginEvent struct {
 	Plugin *CSIPlugin
 }
+
+// ServerHealthEvent holds the health of a server which started or stopped
+// failing the health check thresholds of the Autopilot config, to be used as
+// an event in the event stream.
+type ServerHealthEvent struct {
+	Server *ServerHealth
+}
@@ -153,6 +153,10 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 	hostVolumeClaims := map[string]int{}
 	exclusiveHostVolumeClaims := []string{}
 
+	// diskClassUsage is the ephemeral disk placed in each host disk class,
+	// which does not count against the node's default disk
+	diskClassUsage := map[string]int64{}
+
 	// For each alloc, add the resources
 	for _, alloc := range allocs {
 		// Do not consider the resource impact of terminal allocations
@@ -163,6 +167,11 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 		cr := alloc.AllocatedResources.Comparable()
 		used.Add(cr)
 
+		if cr != nil && cr.Shared.DiskClass != "" {
+			diskClassUsage[cr.Shared.DiskClass] += cr.Shared.DiskMB
+			used.Shared.DiskMB -= cr.Shared.DiskMB
+		}
+
 		// Adding the comparable resource unions reserved core sets, need to check if reserved cores overlap
 		for _, core := range cr.Flattened.Cpu.ReservedCores {
 			if _, ok := reservedCores[core]; ok {
@@ -197,6 +206,12 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 		return false, dimension, used, nil
 	}
 
+	for class, diskMB := range diskClassUsage {
+		if node.NodeResources.Disk.Classes[class] < diskMB {
+			return false, "disk class " + class, used, nil
+		}
+	}
+
 	// Create the network index if missing
 	if netIdx == nil {
 		netIdx = NewNetworkIndex()
@@ -361,12 +376,14 @@ func CopySliceNodeScoreMeta(s []*NodeScoreMeta) []*NodeScoreMeta {
 
 // VaultNamespaceSet takes the structure returned by job.Vault() and returns a
 // set of required namespaces.
-func VaultNamespaceSet(blocks map[string]map[string]*Vault) []string {
+func VaultNamespaceSet(blocks map[string]map[string][]*Vault) []string {
 	s := set.New[string](10)
 	for _, taskGroupVault := range blocks {
-		for _, taskVault := range taskGroupVault {
-			if taskVault != nil && taskVault.Namespace != "" {
-				s.Insert(taskVault.Namespace)
+		for _, taskVaults := range taskGroupVault {
+			for _, taskVault := range taskVaults {
+				if taskVault != nil && taskVault.Namespace != "" {
+					s.Insert(taskVault.Namespace)
+				}
 			}
 		}
 	}
@@ -372,6 +372,52 @@ func TestAllocsFit_TerminalAlloc(t *testing.T) {
 	must.Eq(t, 1024, used.Flattened.Memory.MemoryMB)
 }
 
+func TestAllocsFit_DiskClass(t *testing.T) {
+	ci.Parallel(t)
+
+	n := node2k()
+	n.NodeResources.Disk.Classes = map[string]int64{"fast": 1000}
+
+	a1 := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu: AllocatedCpuResources{
+						CpuShares: 100,
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB: 100,
+					},
+				},
+			},
+			Shared: AllocatedSharedResources{
+				DiskMB:    600,
+				DiskClass: "fast",
+			},
+		},
+	}
+
+	// Should fit one allocation, without using the default disk
+	fit, dim, used, err := AllocsFit(n, []*Allocation{a1}, nil, false)
+	must.NoError(t, err)
+	must.True(t, fit, must.Sprintf("bad dimension: %q", dim))
+	must.Eq(t, 0, used.Shared.DiskMB)
+
+	// Should not fit a second allocation in the same class
+	fit, dim, _, err = AllocsFit(n, []*Allocation{a1, a1.Copy()}, nil, false)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, "disk class fast", dim)
+
+	// Should not fit an allocation in a class the node doesn't have
+	a2 := a1.Copy()
+	a2.AllocatedResources.Shared.DiskClass = "slow"
+	fit, dim, _, err = AllocsFit(n, []*Allocation{a2}, nil, false)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, "disk class slow", dim)
+}
+
 // TestAllocsFit_ClientTerminalAlloc asserts that allocs which have a terminal
 // ClientStatus *do not* have their resources counted as in-use.
 func TestAllocsFit_ClientTerminalAlloc(t *testing.T) {
@@ -924,38 +970,41 @@ func TestGenerateMigrateToken(t *testing.T) {
 }
 
 func TestVaultNamespaceSet(t *testing.T) {
-	input := map[string]map[string]*Vault{
+	input := map[string]map[string][]*Vault{
 		"tg1": {
-			"task1": {
+			"task1": {{
 				Namespace: "ns1-1",
-			},
-			"task2": {
+			}},
+			"task2": {{
 				Namespace: "ns1-2",
-			},
+			}},
 		},
 		"tg2": {
-			"task1": {
+			"task1": {{
 				Namespace: "ns2",
-			},
-			"task2": {
+			}},
+			"task2": {{
 				Namespace: "ns2",
-			},
+			}},
 		},
 		"tg3": {
-			"task1": {
+			"task1": {{
 				Namespace: "ns3-1",
-			},
+			}, {
+				Cluster:   "other",
+				Namespace: "ns3-2",
+			}},
 		},
 		"tg4": {
 			"task1": nil,
 		},
 		"tg5": {
-			"task1": {
+			"task1": {{
 				Namespace: "ns2",
-			},
+			}},
 		},
 		"tg6": {
-			"task1": {},
+			"task1": {{}},
 		},
 	}
 	expected := []string{
@@ -963,6 +1012,7 @@ func TestVaultNamespaceSet(t *testing.T) {
 		"ns1-2",
 		"ns2",
 		"ns3-1",
+		"ns3-2",
 	}
 	got := VaultNamespaceSet(input)
 	require.ElementsMatch(t, expected, got)
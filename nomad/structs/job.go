@@ -250,6 +250,18 @@ func (j *Job) RequiredNUMA() set.Collection[string] {
 	return result
 }
 
+// RequiredReplicatePer identifies which task groups, if any, within the job
+// are replicated per distinct value of a node attribute.
+func (j *Job) RequiredReplicatePer() set.Collection[string] {
+	result := set.New[string](len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		if tg.ReplicatePer != "" {
+			result.Insert(tg.Name)
+		}
+	}
+	return result
+}
+
 // RequiredBridgeNetwork identifies which task groups, if any, within the job
 // contain networks requesting bridge networking.
 func (j *Job) RequiredBridgeNetwork() set.Collection[string] {
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var (
+	// validMaintenanceWindowName is the rule used to validate a maintenance
+	// window name.
+	validMaintenanceWindowName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+)
+
+// MaintenanceWindow is a period of planned maintenance, such as network work,
+// during which the nodes it selects are given a longer grace before their
+// disconnected allocations are considered lost and replaced.
+type MaintenanceWindow struct {
+	// Name is the maintenance window name. It must be unique.
+	Name string
+
+	// Description is the human-friendly description of the maintenance
+	// window.
+	Description string
+
+	// Start is the time at which the maintenance window begins.
+	Start time.Time
+
+	// Duration is how long the maintenance window lasts. The window is
+	// removed once it ends.
+	Duration time.Duration
+
+	// Grace is added to the disconnect lost_after of allocations on nodes
+	// selected by the window while it is active.
+	Grace time.Duration
+
+	// NodePool selects the nodes in the node pool. It may be combined with
+	// Datacenter, in which case nodes must match both.
+	NodePool string
+
+	// Datacenter selects the nodes in datacenters matching the glob.
+	Datacenter string
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// GetID implements the IDGetter interface required for pagination.
+func (w *MaintenanceWindow) GetID() string {
+	return w.Name
+}
+
+// Copy returns a copy of the maintenance window.
+func (w *MaintenanceWindow) Copy() *MaintenanceWindow {
+	if w == nil {
+		return nil
+	}
+	wc := new(MaintenanceWindow)
+	*wc = *w
+	return wc
+}
+
+// Validate returns an error if the maintenance window is invalid.
+func (w *MaintenanceWindow) Validate() error {
+	var mErr *multierror.Error
+
+	if !validMaintenanceWindowName.MatchString(w.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q, must match regex %s", w.Name, validMaintenanceWindowName))
+	}
+	if w.Start.IsZero() {
+		mErr = multierror.Append(mErr, errors.New("missing start time"))
+	}
+	if w.Duration <= 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("duration must be positive (got %v)", w.Duration))
+	}
+	if w.Grace <= 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("grace must be positive (got %v)", w.Grace))
+	}
+	if w.NodePool == "" && w.Datacenter == "" {
+		mErr = multierror.Append(mErr, errors.New("must select nodes by node pool or datacenter"))
+	}
+	if w.NodePool != "" {
+		if err := ValidateNodePoolName(w.NodePool); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid node pool: %w", err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// End returns the time at which the maintenance window ends.
+func (w *MaintenanceWindow) End() time.Time {
+	return w.Start.Add(w.Duration)
+}
+
+// Active returns true if now is within the maintenance window.
+func (w *MaintenanceWindow) Active(now time.Time) bool {
+	return !now.Before(w.Start) && now.Before(w.End())
+}
+
+// Expired returns true if the maintenance window has ended by now.
+func (w *MaintenanceWindow) Expired(now time.Time) bool {
+	return !now.Before(w.End())
+}
+
+// Matches returns true if the node is selected by the maintenance window.
+func (w *MaintenanceWindow) Matches(node *Node) bool {
+	if node == nil {
+		return false
+	}
+	if w.NodePool != "" && !node.IsInPool(w.NodePool) {
+		return false
+	}
+	if w.Datacenter != "" && !node.IsInAnyDC([]string{w.Datacenter}) {
+		return false
+	}
+	return true
+}
+
+// ActiveMaintenanceWindow returns the window with the longest grace among the
+// windows that are active at now and select the node, or nil if the node is
+// not in a maintenance window.
+func ActiveMaintenanceWindow(windows []*MaintenanceWindow, node *Node, now time.Time) *MaintenanceWindow {
+	var active *MaintenanceWindow
+	for _, w := range windows {
+		if !w.Active(now) || !w.Matches(node) {
+			continue
+		}
+		if active == nil || w.Grace > active.Grace {
+			active = w
+		}
+	}
+	return active
+}
+
+// MaintenanceWindowListRequest is used to list maintenance windows.
+type MaintenanceWindowListRequest struct {
+	QueryOptions
+}
+
+// MaintenanceWindowListResponse is the response to a maintenance windows list
+// request.
+type MaintenanceWindowListResponse struct {
+	MaintenanceWindows []*MaintenanceWindow
+	QueryMeta
+}
+
+// MaintenanceWindowSpecificRequest is used to make a request for a specific
+// maintenance window.
+type MaintenanceWindowSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleMaintenanceWindowResponse is the response to a specific maintenance
+// window request.
+type SingleMaintenanceWindowResponse struct {
+	MaintenanceWindow *MaintenanceWindow
+	QueryMeta
+}
+
+// MaintenanceWindowUpsertRequest is used to make a request to insert or update
+// maintenance windows.
+type MaintenanceWindowUpsertRequest struct {
+	MaintenanceWindows []*MaintenanceWindow
+	WriteRequest
+}
+
+// MaintenanceWindowDeleteRequest is used to make a request to delete
+// maintenance windows.
+type MaintenanceWindowDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
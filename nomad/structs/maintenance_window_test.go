@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		window      *MaintenanceWindow
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			window: &MaintenanceWindow{
+				Name:     "network",
+				Start:    time.Now(),
+				Duration: time.Hour,
+				Grace:    30 * time.Minute,
+				NodePool: "edge",
+			},
+		},
+		{
+			name: "invalid name",
+			window: &MaintenanceWindow{
+				Name:       "not valid",
+				Start:      time.Now(),
+				Duration:   time.Hour,
+				Grace:      30 * time.Minute,
+				Datacenter: "dc1",
+			},
+			expectedErr: "invalid name",
+		},
+		{
+			name: "missing start",
+			window: &MaintenanceWindow{
+				Name:       "network",
+				Duration:   time.Hour,
+				Grace:      30 * time.Minute,
+				Datacenter: "dc1",
+			},
+			expectedErr: "missing start time",
+		},
+		{
+			name: "missing duration and grace",
+			window: &MaintenanceWindow{
+				Name:       "network",
+				Start:      time.Now(),
+				Datacenter: "dc1",
+			},
+			expectedErr: "2 errors occurred",
+		},
+		{
+			name: "missing selector",
+			window: &MaintenanceWindow{
+				Name:     "network",
+				Start:    time.Now(),
+				Duration: time.Hour,
+				Grace:    30 * time.Minute,
+			},
+			expectedErr: "must select nodes by node pool or datacenter",
+		},
+		{
+			name: "invalid node pool",
+			window: &MaintenanceWindow{
+				Name:     "network",
+				Start:    time.Now(),
+				Duration: time.Hour,
+				Grace:    30 * time.Minute,
+				NodePool: "not valid",
+			},
+			expectedErr: "invalid node pool",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.window.Validate()
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindow_Active(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	window := &MaintenanceWindow{
+		Start:    now,
+		Duration: time.Hour,
+	}
+
+	must.False(t, window.Active(now.Add(-time.Second)))
+	must.False(t, window.Expired(now.Add(-time.Second)))
+
+	must.True(t, window.Active(now))
+	must.True(t, window.Active(now.Add(59*time.Minute)))
+	must.False(t, window.Expired(now.Add(59*time.Minute)))
+
+	must.False(t, window.Active(now.Add(time.Hour)))
+	must.True(t, window.Expired(now.Add(time.Hour)))
+}
+
+func TestMaintenanceWindow_Matches(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &Node{NodePool: "edge", Datacenter: "us-east-1a"}
+
+	testCases := []struct {
+		name     string
+		window   *MaintenanceWindow
+		expected bool
+	}{
+		{
+			name:     "node pool",
+			window:   &MaintenanceWindow{NodePool: "edge"},
+			expected: true,
+		},
+		{
+			name:     "all node pool",
+			window:   &MaintenanceWindow{NodePool: NodePoolAll},
+			expected: true,
+		},
+		{
+			name:     "other node pool",
+			window:   &MaintenanceWindow{NodePool: "default"},
+			expected: false,
+		},
+		{
+			name:     "datacenter glob",
+			window:   &MaintenanceWindow{Datacenter: "us-east-*"},
+			expected: true,
+		},
+		{
+			name:     "node pool and other datacenter",
+			window:   &MaintenanceWindow{NodePool: "edge", Datacenter: "us-west-*"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.window.Matches(node))
+		})
+	}
+}
+
+func TestActiveMaintenanceWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	node := &Node{NodePool: "edge", Datacenter: "dc1"}
+
+	short := &MaintenanceWindow{Name: "short", NodePool: "edge",
+		Start: now.Add(-time.Minute), Duration: time.Hour, Grace: time.Minute}
+	long := &MaintenanceWindow{Name: "long", Datacenter: "dc1",
+		Start: now.Add(-time.Minute), Duration: time.Hour, Grace: time.Hour}
+	future := &MaintenanceWindow{Name: "future", Datacenter: "dc1",
+		Start: now.Add(time.Hour), Duration: time.Hour, Grace: 2 * time.Hour}
+	other := &MaintenanceWindow{Name: "other", Datacenter: "dc2",
+		Start: now.Add(-time.Minute), Duration: time.Hour, Grace: 2 * time.Hour}
+
+	must.Eq(t, long, ActiveMaintenanceWindow(
+		[]*MaintenanceWindow{short, long, future, other}, node, now))
+	must.Eq(t, short, ActiveMaintenanceWindow(
+		[]*MaintenanceWindow{short, future, other}, node, now))
+	must.Nil(t, ActiveMaintenanceWindow(
+		[]*MaintenanceWindow{future, other}, node, now))
+}
@@ -431,6 +431,71 @@ func (idx *NetworkIndex) AddReservedPortsForIP(ports []uint64, ip string) (colli
 	return
 }
 
+// PortCollisionError is returned when a reserved port of a network ask is
+// already in use on the host IP it would be assigned to.
+type PortCollisionError struct {
+	Label  string
+	Port   int
+	HostIP string
+}
+
+func (e *PortCollisionError) Error() string {
+	return fmt.Sprintf("reserved port collision %s=%d", e.Label, e.Port)
+}
+
+// AllocUsingPort returns the first non-terminal allocation which holds port on
+// the host IP, or nil if none of the allocations do.
+func AllocUsingPort(allocs []*Allocation, ip string, port int) *Allocation {
+	usesPort := func(n *NetworkResource) bool {
+		if n == nil || n.IP != ip {
+			return false
+		}
+		for _, ports := range [][]Port{n.ReservedPorts, n.DynamicPorts} {
+			for _, p := range ports {
+				if p.Value == port {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, alloc := range allocs {
+		if alloc.ClientTerminalStatus() {
+			continue
+		}
+
+		if alloc.AllocatedResources != nil {
+			for _, p := range alloc.AllocatedResources.Shared.Ports {
+				if p.HostIP == ip && p.Value == port {
+					return alloc
+				}
+			}
+			for _, n := range alloc.AllocatedResources.Shared.Networks {
+				if usesPort(n) {
+					return alloc
+				}
+			}
+			for _, resources := range alloc.AllocatedResources.Tasks {
+				for _, n := range resources.Networks {
+					if usesPort(n) {
+						return alloc
+					}
+				}
+			}
+		} else {
+			for _, resources := range alloc.TaskResources {
+				for _, n := range resources.Networks {
+					if usesPort(n) {
+						return alloc
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // yieldIP is used to iteratively invoke the callback with
 // an available IP
 func (idx *NetworkIndex) yieldIP(cb func(net *NetworkResource, offerIP net.IP) bool) {
@@ -481,7 +546,7 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 		// if allocPort is still nil after the loop, the port wasn't available for reservation
 		var allocPort *AllocatedPortMapping
 		var addrErr error
-		for _, addr := range idx.HostNetworks[port.HostNetwork] {
+		for _, addr := range idx.hostNetworkAddrs(port.HostNetwork, ask.PreferIPv6) {
 			// Guard against invalid port
 			if port.Value < 0 || port.Value >= MaxValidPort {
 				return nil, fmt.Errorf("invalid port %d (out of range)", port.Value)
@@ -491,7 +556,7 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 			if !port.IgnoreCollision {
 				used := idx.getUsedPortsFor(addr.Address)
 				if used != nil && used.Check(uint(port.Value)) {
-					addrErr = fmt.Errorf("reserved port collision %s=%d", port.Label, port.Value)
+					addrErr = &PortCollisionError{Label: port.Label, Port: port.Value, HostIP: addr.Address}
 					continue
 				}
 			}
@@ -521,7 +586,7 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 	for _, port := range ask.DynamicPorts {
 		var allocPort *AllocatedPortMapping
 		var addrErr error
-		for _, addr := range idx.HostNetworks[port.HostNetwork] {
+		for _, addr := range idx.hostNetworkAddrs(port.HostNetwork, ask.PreferIPv6) {
 			used := idx.getUsedPortsFor(addr.Address)
 			// Try to stochastically pick the dynamic ports as it is faster and
 			// lower memory usage.
@@ -566,6 +631,29 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 	return offer, nil
 }
 
+// hostNetworkAddrs returns the addresses of the host network with the given
+// alias in the order ports are assigned on them. When preferIPv6 is set, the
+// IPv6 addresses come before the IPv4 addresses.
+func (idx *NetworkIndex) hostNetworkAddrs(alias string, preferIPv6 bool) []NodeNetworkAddress {
+	addrs := idx.HostNetworks[alias]
+	if !preferIPv6 {
+		return addrs
+	}
+
+	sorted := make([]NodeNetworkAddress, 0, len(addrs))
+	for _, addr := range addrs {
+		if addr.isIPv6() {
+			sorted = append(sorted, addr)
+		}
+	}
+	for _, addr := range addrs {
+		if !addr.isIPv6() {
+			sorted = append(sorted, addr)
+		}
+	}
+	return sorted
+}
+
 // AssignTaskNetwork is used to offer network resources given a
 // task.resources.network ask.  If the ask cannot be satisfied, returns nil
 //
@@ -599,7 +687,7 @@ func (idx *NetworkIndex) AssignTaskNetwork(ask *NetworkResource) (out *NetworkRe
 
 			// Check if in use
 			if used != nil && used.Check(uint(port.Value)) {
-				err = fmt.Errorf("reserved port collision %s=%d", port.Label, port.Value)
+				err = &PortCollisionError{Label: port.Label, Port: port.Value, HostIP: offerIPStr}
 				return
 			}
 		}
@@ -767,6 +855,14 @@ func AllocatedPortsToNetworkResouce(ask *NetworkResource, ports AllocatedPorts,
 		for _, nw := range node.NodeNetworks {
 			if nw.Mode == "host" {
 				out.IP = nw.Addresses[0].Address
+				if ask.PreferIPv6 {
+					for _, addr := range nw.Addresses {
+						if addr.isIPv6() {
+							out.IP = addr.Address
+							break
+						}
+					}
+				}
 				break
 			}
 		}
@@ -530,6 +530,71 @@ func TestNetworkIndex_AssignPorts_TwoIp(t *testing.T) {
 
 }
 
+func TestNetworkIndex_AssignPorts_PreferIPv6(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			NodeNetworks: []*NodeNetworkResource{
+				{
+					Mode:   "host",
+					Device: "eth0",
+					Speed:  1000,
+					Addresses: []NodeNetworkAddress{
+						{
+							Alias:   "default",
+							Address: "192.168.0.100",
+							Family:  NodeNetworkAF_IPv4,
+						},
+						{
+							Alias:   "default",
+							Address: "fd00::100",
+							Family:  NodeNetworkAF_IPv6,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	idx := NewNetworkIndex()
+	idx.SetNode(n)
+
+	ask := &NetworkResource{
+		ReservedPorts: []Port{{Label: "static", Value: 7000, HostNetwork: "default"}},
+		DynamicPorts:  []Port{{Label: "dynamic", HostNetwork: "default"}},
+	}
+
+	// without the preference, the ports are assigned on the first address
+	offer, err := idx.AssignPorts(ask)
+	must.NoError(t, err)
+	for _, label := range []string{"static", "dynamic"} {
+		port, ok := offer.Get(label)
+		must.True(t, ok)
+		must.Eq(t, "192.168.0.100", port.HostIP)
+	}
+
+	ask.PreferIPv6 = true
+	offer, err = idx.AssignPorts(ask)
+	must.NoError(t, err)
+	for _, label := range []string{"static", "dynamic"} {
+		port, ok := offer.Get(label)
+		must.True(t, ok)
+		must.Eq(t, "fd00::100", port.HostIP)
+	}
+
+	// the IPv4 address is used once the port is taken on the IPv6 address
+	idx.AddReservedPorts(AllocatedPorts{{Label: "static", Value: 7000, HostIP: "fd00::100"}})
+	offer, err = idx.AssignPorts(ask)
+	must.NoError(t, err)
+	port, ok := offer.Get("static")
+	must.True(t, ok)
+	must.Eq(t, "192.168.0.100", port.HostIP)
+
+	nwRes := AllocatedPortsToNetworkResouce(ask, offer, n.NodeResources)
+	must.Eq(t, "fd00::100", nwRes.IP)
+}
+
 // TestNetworkIndex_IgnorePortCollision tests Port.IgnoreCollision.
 func TestNetworkIndex_IgnorePortCollision(t *testing.T) {
 	ci.Parallel(t)
@@ -599,6 +664,10 @@ func TestNetworkIndex_IgnorePortCollision(t *testing.T) {
 		must.ErrorContains(t, err, "reserved port collision test-port=10")
 		must.Nil(t, allocated, must.Sprint("expect no ports on AssignPorts error"))
 
+		var collisionErr *PortCollisionError
+		must.ErrorAs(t, err, &collisionErr)
+		must.Eq(t, &PortCollisionError{Label: "test-port", Port: 10, HostIP: ip}, collisionErr)
+
 		ask = &NetworkResource{ReservedPorts: nonCollidingPorts}
 		allocated, err = idx.AssignPorts(ask)
 		must.NoError(t, err)
@@ -606,6 +675,50 @@ func TestNetworkIndex_IgnorePortCollision(t *testing.T) {
 	})
 }
 
+func TestAllocUsingPort(t *testing.T) {
+	ci.Parallel(t)
+
+	groupAlloc := &Allocation{
+		ID:    "group",
+		JobID: "group-job",
+		AllocatedResources: &AllocatedResources{
+			Shared: AllocatedSharedResources{
+				Ports: AllocatedPorts{{Label: "http", Value: 8080, HostIP: "10.0.0.5"}},
+			},
+		},
+	}
+	taskAlloc := &Allocation{
+		ID:    "task",
+		JobID: "task-job",
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Networks: Networks{{
+						IP:           "10.0.0.5",
+						DynamicPorts: []Port{{Label: "admin", Value: 25000}},
+					}},
+				},
+			},
+		},
+	}
+	stoppedAlloc := &Allocation{
+		ID:           "stopped",
+		JobID:        "stopped-job",
+		ClientStatus: AllocClientStatusComplete,
+		AllocatedResources: &AllocatedResources{
+			Shared: AllocatedSharedResources{
+				Ports: AllocatedPorts{{Label: "http", Value: 9090, HostIP: "10.0.0.5"}},
+			},
+		},
+	}
+	allocs := []*Allocation{stoppedAlloc, groupAlloc, taskAlloc}
+
+	must.Eq(t, groupAlloc, AllocUsingPort(allocs, "10.0.0.5", 8080))
+	must.Eq(t, taskAlloc, AllocUsingPort(allocs, "10.0.0.5", 25000))
+	must.Nil(t, AllocUsingPort(allocs, "10.0.0.6", 8080))
+	must.Nil(t, AllocUsingPort(allocs, "10.0.0.5", 9090))
+}
+
 func TestNetworkIndex_AssignTaskNetwork(t *testing.T) {
 	ci.Parallel(t)
 	idx := NewNetworkIndex()
@@ -494,6 +494,10 @@ type NodeMetaApplyRequest struct {
 	// Meta is the new Node metadata being applied and differs slightly
 	// from Node.Meta as nil values are used to unset Node.Meta keys.
 	Meta map[string]*string
+
+	// CheckIndex, if set, makes the update a check-and-set operation which
+	// is only applied if the node metadata's ModifyIndex equals it.
+	CheckIndex *uint64
 }
 
 func (n *NodeMetaApplyRequest) Validate() error {
@@ -528,8 +532,39 @@ type NodeMetaResponse struct {
 
 	// Static is the static Node metadata (set via agent configuration)
 	Static map[string]string
+
+	// ModifyIndex is the index of the merged Node metadata, which is
+	// incremented whenever it changes. It is local to the Client agent.
+	ModifyIndex uint64
 }
 
+// ArtifactPrefetchRequest is used to download an artifact into the artifact
+// cache of a Client agent before any allocation needs it.
+type ArtifactPrefetchRequest struct {
+	QueryOptions // Client RPCs must use QueryOptions to set AllowStale=true
+
+	// NodeID is the node being targeted by this request (or the node
+	// receiving this request if NodeID is empty).
+	NodeID string
+
+	// Artifact is the artifact to download. It must declare a checksum so
+	// that it can be stored in the cache by content.
+	Artifact *TaskArtifact
+}
+
+func (r *ArtifactPrefetchRequest) Validate() error {
+	if r.Artifact == nil {
+		return fmt.Errorf("missing required Artifact object")
+	}
+	if r.Artifact.GetterOptions["checksum"] == "" {
+		return fmt.Errorf("artifact must specify a checksum to be prefetched")
+	}
+	return r.Artifact.Validate()
+}
+
+// ArtifactPrefetchResponse is the response to an ArtifactPrefetchRequest.
+type ArtifactPrefetchResponse struct{}
+
 // NodeIdentityClaims represents the claims for a Nomad node identity JWT.
 type NodeIdentityClaims struct {
 	NodeID         string `json:"nomad_node_id,omitempty"`
@@ -68,6 +68,10 @@ type NodePool struct {
 	// NodeIdentityTTL is the time-to-live for node identities in the pool.
 	NodeIdentityTTL time.Duration
 
+	// RescheduleOverrides adjusts the reschedule delay of allocations which
+	// failed on nodes in the pool.
+	RescheduleOverrides *NodePoolRescheduleOverrides
+
 	// Hash is the hash of the node pool which is used to efficiently diff when
 	// we replicate pools across regions.
 	Hash []byte
@@ -94,6 +98,10 @@ func (n *NodePool) Validate() error {
 
 	mErr = multierror.Append(mErr, n.SchedulerConfiguration.Validate())
 
+	if err := n.RescheduleOverrides.Validate(); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid reschedule overrides: %w", err))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -119,6 +127,7 @@ func (n *NodePool) Copy() *NodePool {
 	*nc = *n
 	nc.Meta = maps.Clone(nc.Meta)
 	nc.SchedulerConfiguration = nc.SchedulerConfiguration.Copy()
+	nc.RescheduleOverrides = nc.RescheduleOverrides.Copy()
 
 	nc.Hash = make([]byte, len(n.Hash))
 	copy(nc.Hash, n.Hash)
@@ -188,6 +197,14 @@ func (n *NodePool) SetHash() []byte {
 		}
 	}
 
+	if o := n.RescheduleOverrides; o != nil {
+		_, _ = hash.Write([]byte("reschedule_overrides"))
+		_, _ = hash.Write([]byte(o.Delay.String()))
+		_, _ = hash.Write([]byte(o.DelayFunction))
+		_, _ = hash.Write([]byte(o.MaxDelay.String()))
+		_, _ = hash.Write([]byte(o.Jitter.String()))
+	}
+
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
 	for k := range n.Meta {
@@ -270,6 +287,89 @@ func (n *NodePoolSchedulerConfiguration) Copy() *NodePoolSchedulerConfiguration
 	return nc
 }
 
+// NodePoolRescheduleOverrides overrides the reschedule policy of allocations
+// which failed on nodes in a node pool, such as to wait longer before
+// rescheduling allocations in a pool where node churn is expected. Fields
+// left unset keep the value of the task group's reschedule policy.
+type NodePoolRescheduleOverrides struct {
+	// Delay overrides the minimum duration to wait between reschedule
+	// attempts.
+	Delay time.Duration
+
+	// DelayFunction overrides how the delay progressively changes on
+	// subsequent reschedule attempts.
+	DelayFunction string
+
+	// MaxDelay overrides the upper bound on the delay.
+	MaxDelay time.Duration
+
+	// Jitter overrides the upper bound of the random duration added to the
+	// delay.
+	Jitter time.Duration
+}
+
+// Copy returns a copy of the node pool reschedule overrides.
+func (o *NodePoolRescheduleOverrides) Copy() *NodePoolRescheduleOverrides {
+	if o == nil {
+		return nil
+	}
+	oc := new(NodePoolRescheduleOverrides)
+	*oc = *o
+	return oc
+}
+
+// Validate returns an error if the node pool reschedule overrides are
+// invalid.
+func (o *NodePoolRescheduleOverrides) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if o.Delay != 0 && o.Delay < ReschedulePolicyMinDelay {
+		mErr = multierror.Append(mErr, fmt.Errorf("delay cannot be less than %v (got %v)", ReschedulePolicyMinDelay, o.Delay))
+	}
+	if o.DelayFunction != "" && !isValidDelayFunction(o.DelayFunction) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid delay function %q, must be one of %q", o.DelayFunction, RescheduleDelayFunctions))
+	}
+	if o.MaxDelay != 0 && o.MaxDelay < ReschedulePolicyMinDelay {
+		mErr = multierror.Append(mErr, fmt.Errorf("max delay cannot be less than %v (got %v)", ReschedulePolicyMinDelay, o.MaxDelay))
+	}
+	if o.Delay != 0 && o.MaxDelay != 0 && o.MaxDelay < o.Delay {
+		mErr = multierror.Append(mErr, fmt.Errorf("max delay cannot be less than delay %v (got %v)", o.Delay, o.MaxDelay))
+	}
+	if o.Jitter < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("jitter cannot be negative (got %v)", o.Jitter))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Apply returns a copy of policy with the overrides applied. A max delay is
+// raised to the delay if the overridden delay exceeds it.
+func (o *NodePoolRescheduleOverrides) Apply(policy *ReschedulePolicy) *ReschedulePolicy {
+	policy = policy.Copy()
+	if o == nil || policy == nil {
+		return policy
+	}
+
+	if o.Delay != 0 {
+		policy.Delay = o.Delay
+	}
+	if o.DelayFunction != "" {
+		policy.DelayFunction = o.DelayFunction
+	}
+	if o.MaxDelay != 0 {
+		policy.MaxDelay = o.MaxDelay
+	}
+	if o.Jitter != 0 {
+		policy.Jitter = o.Jitter
+	}
+	if policy.MaxDelay != 0 && policy.MaxDelay < policy.Delay {
+		policy.MaxDelay = policy.Delay
+	}
+	return policy
+}
+
 // NodePoolListRequest is used to list node pools.
 type NodePoolListRequest struct {
 	QueryOptions
@@ -108,6 +108,49 @@ func TestNodePool_Validate(t *testing.T) {
 			},
 			expectedErr: "description longer",
 		},
+		{
+			name: "valid reschedule overrides",
+			pool: &NodePool{
+				Name: "valid",
+				RescheduleOverrides: &NodePoolRescheduleOverrides{
+					Delay:         time.Minute,
+					DelayFunction: "exponential",
+					MaxDelay:      time.Hour,
+					Jitter:        30 * time.Second,
+				},
+			},
+		},
+		{
+			name: "invalid reschedule overrides delay function",
+			pool: &NodePool{
+				Name: "valid",
+				RescheduleOverrides: &NodePoolRescheduleOverrides{
+					DelayFunction: "linear",
+				},
+			},
+			expectedErr: "invalid reschedule overrides: 1 error occurred:\n\t* invalid delay function",
+		},
+		{
+			name: "invalid reschedule overrides max delay",
+			pool: &NodePool{
+				Name: "valid",
+				RescheduleOverrides: &NodePoolRescheduleOverrides{
+					Delay:    time.Hour,
+					MaxDelay: time.Minute,
+				},
+			},
+			expectedErr: "max delay cannot be less than delay",
+		},
+		{
+			name: "invalid reschedule overrides jitter",
+			pool: &NodePool{
+				Name: "valid",
+				RescheduleOverrides: &NodePoolRescheduleOverrides{
+					Jitter: -time.Second,
+				},
+			},
+			expectedErr: "jitter cannot be negative",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -123,6 +166,40 @@ func TestNodePool_Validate(t *testing.T) {
 	}
 }
 
+func TestNodePoolRescheduleOverrides_Apply(t *testing.T) {
+	ci.Parallel(t)
+
+	policy := &ReschedulePolicy{
+		Attempts:      0,
+		Delay:         30 * time.Second,
+		DelayFunction: "exponential",
+		MaxDelay:      5 * time.Minute,
+		Unlimited:     true,
+	}
+
+	var nilOverrides *NodePoolRescheduleOverrides
+	must.Eq(t, policy, nilOverrides.Apply(policy))
+
+	overrides := &NodePoolRescheduleOverrides{
+		Delay:         10 * time.Minute,
+		DelayFunction: "constant",
+		Jitter:        time.Minute,
+	}
+	got := overrides.Apply(policy)
+	must.Eq(t, &ReschedulePolicy{
+		Attempts:      0,
+		Delay:         10 * time.Minute,
+		DelayFunction: "constant",
+		MaxDelay:      10 * time.Minute,
+		Jitter:        time.Minute,
+		Unlimited:     true,
+	}, got)
+
+	// the original policy is not modified
+	must.Eq(t, 30*time.Second, policy.Delay)
+	must.Eq(t, 5*time.Minute, policy.MaxDelay)
+}
+
 func TestNodePool_IsBuiltIn(t *testing.T) {
 	ci.Parallel(t)
 
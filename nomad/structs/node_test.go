@@ -1141,3 +1141,36 @@ func TestNodeIntroductionIdentityClaims_string(t *testing.T) {
 		must.Eq(t, "custom-pool:node-name-1", claims.String())
 	})
 }
+
+func TestNodeEvent_SetIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("named token", func(t *testing.T) {
+		token := &ACLToken{AccessorID: "accessor", Name: "operator"}
+		event := NewNodeEvent().SetIdentity(&AuthenticatedIdentity{ACLToken: token})
+		must.Eq(t, "operator", event.Actor)
+		must.Eq(t, "accessor", event.AccessorID)
+	})
+
+	t.Run("unnamed token", func(t *testing.T) {
+		token := &ACLToken{AccessorID: "accessor"}
+		event := NewNodeEvent().SetIdentity(&AuthenticatedIdentity{ACLToken: token})
+		must.Eq(t, "accessor", event.Actor)
+		must.Eq(t, "accessor", event.AccessorID)
+	})
+
+	t.Run("anonymous token", func(t *testing.T) {
+		event := NewNodeEvent().SetIdentity(&AuthenticatedIdentity{
+			ACLToken: AnonymousACLToken,
+			TLSName:  "cli",
+		})
+		must.Eq(t, "Anonymous Token", event.Actor)
+		must.Eq(t, "", event.AccessorID)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		event := NewNodeEvent().SetIdentity(nil)
+		must.Eq(t, "unauthenticated", event.Actor)
+		must.Eq(t, "", event.AccessorID)
+	})
+}
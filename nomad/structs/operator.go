@@ -50,6 +50,69 @@ type RaftConfigurationResponse struct {
 	Index uint64
 }
 
+// RaftPeerStatus is the replication status of a server in the Raft
+// configuration, as known by the leader.
+type RaftPeerStatus struct {
+	// ID is the unique ID for the server.
+	ID raft.ServerID
+
+	// Node is the node name of the server, as known by Nomad, or this
+	// will be set to "(unknown)" otherwise.
+	Node string
+
+	// Address is the IP:port of the server, used for Raft communications.
+	Address raft.ServerAddress
+
+	// Leader is true if this server is the current cluster leader.
+	Leader bool
+
+	// Voter is true if this server has a vote in the cluster.
+	Voter bool
+
+	// State is the replication state of the server: "leader" for the
+	// leader, "failing" while the leader fails to heartbeat to it,
+	// "replicating" otherwise, or "unknown" if autopilot has not fetched its
+	// stats yet.
+	State string
+
+	// LastContact is the time since the server last heard from the leader.
+	LastContact time.Duration
+
+	// MatchIndex is the index of the last log stored by the server.
+	MatchIndex uint64
+
+	// TrailingLogs is the number of logs the server trails the leader by.
+	TrailingLogs uint64
+
+	// ReplicationFailures is the number of consecutive heartbeats from the
+	// leader to the server which have failed.
+	ReplicationFailures uint64
+}
+
+const (
+	RaftPeerStateLeader      = "leader"
+	RaftPeerStateReplicating = "replicating"
+	RaftPeerStateFailing     = "failing"
+	RaftPeerStateUnknown     = "unknown"
+)
+
+// RaftStatusResponse is returned when querying for the replication status of
+// the servers in the Raft configuration.
+type RaftStatusResponse struct {
+	// Servers has the replication status of each server in the Raft
+	// configuration.
+	Servers []*RaftPeerStatus
+
+	// Term is the current Raft term of the leader.
+	Term uint64
+
+	// LastIndex is the index of the last log stored by the leader.
+	LastIndex uint64
+
+	// CommitIndex is the index of the last log committed by the cluster.
+	CommitIndex uint64
+}
+
 // RaftPeerByAddressRequest is used by the Operator endpoint to apply a Raft
 // operation on a specific Raft peer by address in the form of "IP:port".
 //
@@ -170,6 +233,21 @@ type AutopilotConfig struct {
 	// before autopilot can prune dead servers.
 	MinQuorum uint
 
+	// MinDiskFree is the minimum free space, in bytes, of the volume holding a
+	// server's Raft data before the server is considered unhealthy. Zero
+	// disables the check.
+	MinDiskFree uint64
+
+	// MaxLastLogAge is how far behind the leader's, in time, the last log
+	// applied by a server can be before the server is considered unhealthy.
+	// Zero disables the check.
+	MaxLastLogAge time.Duration
+
+	// MaxAppliedIndexLag is the number of logs a server can trail the
+	// leader's applied index by before being considered unhealthy. Zero
+	// disables the check.
+	MaxAppliedIndexLag uint64
+
 	// (Enterprise-only) EnableRedundancyZones specifies whether to enable redundancy zones.
 	EnableRedundancyZones bool
 
@@ -0,0 +1,386 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// PeriodicExclusionCalendarItem is the item of the variable referenced
+	// by the exclusion_calendar of a periodic job which holds the calendar.
+	PeriodicExclusionCalendarItem = "calendar"
+
+	// PeriodicStatusLaunches is the number of upcoming launches returned
+	// by the status of a periodic job.
+	PeriodicStatusLaunches = 3
+
+	// maxPeriodicExcludedDays bounds the number of excluded days skipped
+	// when computing the next launch of a periodic job, so that exclusions
+	// covering every date do not keep the dispatcher searching forever.
+	maxPeriodicExcludedDays = 3660
+
+	// maxPeriodicCalendarEventDays bounds the number of days a single event
+	// of an exclusion calendar may span.
+	maxPeriodicCalendarEventDays = 366
+
+	// maxPeriodicMissedLaunchScan bounds the number of launches enumerated
+	// in a single window when looking for the missed launches of a periodic
+	// job, so that a schedule with bursts of launches does not delay
+	// leadership establishment.
+	maxPeriodicMissedLaunchScan = 100000
+
+	// minPeriodicMissedLaunchWindow is the first window before now in which
+	// the missed launches of a periodic job are looked for.
+	minPeriodicMissedLaunchWindow = time.Minute
+)
+
+// periodicDate is a date on which a periodic job is not launched. A zero
+// year, month, or day matches any.
+type periodicDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func (d periodicDate) matches(year int, month time.Month, day int) bool {
+	return (d.year == 0 || d.year == year) &&
+		(d.month == 0 || d.month == month) &&
+		(d.day == 0 || d.day == day)
+}
+
+// PeriodicCalendar is a set of dates on which a periodic job is not launched.
+// The nil calendar excludes no dates.
+type PeriodicCalendar struct {
+	dates []periodicDate
+}
+
+// Excludes returns whether the date of t, in the location of t, is excluded
+// by the calendar.
+func (c *PeriodicCalendar) Excludes(t time.Time) bool {
+	if c == nil {
+		return false
+	}
+	year, month, day := t.Date()
+	for _, d := range c.dates {
+		if d.matches(year, month, day) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePeriodicExclusions parses the exclusions of a periodic job. Each
+// exclusion is a date in the YYYY-MM-DD format, any part of which may be "*"
+// to match every year, month, or day, such as "*-12-25" for every Christmas.
+func ParsePeriodicExclusions(exclusions []string) (*PeriodicCalendar, error) {
+	if len(exclusions) == 0 {
+		return nil, nil
+	}
+
+	c := &PeriodicCalendar{dates: make([]periodicDate, 0, len(exclusions))}
+	for _, exclusion := range exclusions {
+		d, err := parsePeriodicExclusion(exclusion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclusion %q: %v", exclusion, err)
+		}
+		c.dates = append(c.dates, d)
+	}
+	return c, nil
+}
+
+func parsePeriodicExclusion(exclusion string) (periodicDate, error) {
+	parts := strings.Split(exclusion, "-")
+	if len(parts) != 3 {
+		return periodicDate{}, fmt.Errorf("must be a date in the YYYY-MM-DD format")
+	}
+
+	var values [3]int
+	limits := [3][2]int{{1, 9999}, {1, 12}, {1, 31}}
+	for i, part := range parts {
+		if part == "*" {
+			continue
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil || v < limits[i][0] || v > limits[i][1] {
+			return periodicDate{}, fmt.Errorf("must be a date in the YYYY-MM-DD format")
+		}
+		values[i] = v
+	}
+	if values == [3]int{} {
+		return periodicDate{}, fmt.Errorf("must not exclude every date")
+	}
+
+	return periodicDate{year: values[0], month: time.Month(values[1]), day: values[2]}, nil
+}
+
+// ParsePeriodicCalendar parses an exclusion calendar in the iCalendar format.
+// The dates of each event are excluded, from its DTSTART up to but not
+// including its DTEND, as for all-day events. Events recurring every year
+// with an RRULE of FREQ=YEARLY are excluded on the same dates every year.
+// Other properties and components are ignored, and dates are taken as
+// written, in the time zone of the periodic job.
+func ParsePeriodicCalendar(ics string) (*PeriodicCalendar, error) {
+	c := new(PeriodicCalendar)
+
+	var (
+		inEvent    bool
+		start, end time.Time
+		yearly     bool
+	)
+	for i, line := range unfoldCalendarLines(ics) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		var err error
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			inEvent, start, end, yearly = true, time.Time{}, time.Time{}, false
+		case !inEvent:
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			inEvent = false
+			err = c.addEvent(start, end, yearly)
+		case name == "DTSTART":
+			start, err = parseCalendarDate(value)
+		case name == "DTEND":
+			end, err = parseCalendarDate(value)
+		case name == "RRULE":
+			yearly, err = parseCalendarRule(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+	}
+	if inEvent {
+		return nil, fmt.Errorf("unterminated event")
+	}
+	return c, nil
+}
+
+// addEvent excludes the dates of an event of an exclusion calendar.
+func (c *PeriodicCalendar) addEvent(start, end time.Time, yearly bool) error {
+	if start.IsZero() {
+		return fmt.Errorf("event is missing DTSTART")
+	}
+	if !end.After(start) {
+		end = start.AddDate(0, 0, 1)
+	}
+
+	for days := 0; start.Before(end); days++ {
+		if days == maxPeriodicCalendarEventDays {
+			return fmt.Errorf("event spans more than %d days", maxPeriodicCalendarEventDays)
+		}
+		d := periodicDate{year: start.Year(), month: start.Month(), day: start.Day()}
+		if yearly {
+			d.year = 0
+		}
+		c.dates = append(c.dates, d)
+		start = start.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+// unfoldCalendarLines returns the lines of an iCalendar, joining the lines
+// which continue the previous one by starting with whitespace.
+func unfoldCalendarLines(ics string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(ics))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseCalendarDate parses the date of a DATE or DATE-TIME value, ignoring its
+// time.
+func parseCalendarDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	t, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return t, nil
+}
+
+// parseCalendarRule returns whether the recurrence rule of an event repeats it
+// every year, which is the only recurrence supported.
+func parseCalendarRule(value string) (bool, error) {
+	for _, part := range strings.Split(strings.ToUpper(value), ";") {
+		if part == "FREQ=YEARLY" {
+			continue
+		}
+		return false, fmt.Errorf("unsupported recurrence rule %q, only FREQ=YEARLY is supported", value)
+	}
+	return true, nil
+}
+
+// NextExcluding returns the closest time instant matching the spec that is
+// after the passed time, like Next, skipping the dates excluded by the
+// exclusions of the periodic configuration or by calendar, which may be nil.
+// If every date is excluded for years to come, the zero value of time.Time is
+// returned.
+func (p *PeriodicConfig) NextExcluding(fromTime time.Time, calendar *PeriodicCalendar) (time.Time, error) {
+	exclusions, err := ParsePeriodicExclusions(p.Exclusions)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for i := 0; i < maxPeriodicExcludedDays; i++ {
+		next, err := p.Next(fromTime)
+		if err != nil || next.IsZero() {
+			return next, err
+		}
+		if !exclusions.Excludes(next) && !calendar.Excludes(next) {
+			return next, nil
+		}
+
+		// skip the remainder of the excluded date
+		year, month, day := next.Date()
+		fromTime = time.Date(year, month, day+1, 0, 0, 0, 0, next.Location()).Add(-time.Second)
+	}
+	return time.Time{}, nil
+}
+
+// NextLaunches returns the next n launch times after fromTime, skipping the
+// dates excluded by the exclusions of the periodic configuration or by
+// calendar, which may be nil.
+func (p *PeriodicConfig) NextLaunches(fromTime time.Time, calendar *PeriodicCalendar, n int) ([]time.Time, error) {
+	launches := make([]time.Time, 0, n)
+	for len(launches) < n {
+		next, err := p.NextExcluding(fromTime, calendar)
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() {
+			break
+		}
+		launches = append(launches, next)
+		fromTime = next
+	}
+	return launches, nil
+}
+
+// MissedLaunches returns the launches of the periodic configuration after
+// lastLaunch and before now which were missed, skipping the dates excluded by
+// its exclusions or by calendar, which may be nil. The launches to make up for
+// are returned oldest first according to the missed launch policy: none for
+// PeriodicMissedLaunchSkip, the most recent one for PeriodicMissedLaunchRunOne,
+// and up to MaxMissedLaunches of the most recent ones for
+// PeriodicMissedLaunchRunAll, or only the most recent one if the job prohibits
+// overlap.
+//
+// The schedule is followed on the wall clock of the location of lastLaunch,
+// so that daylight saving time transitions neither make up for a launch twice
+// when the clock falls back, nor miss a launch whose time the clock skips
+// when it springs forward. Such a launch is made up for at the time it would
+// have had without the transition.
+//
+// The launches are looked for in a window ending at now, which doubles until
+// it holds as many launches as are made up for or reaches back to lastLaunch,
+// so that the work done depends on the number of launches made up for rather
+// than on how many were missed.
+func (p *PeriodicConfig) MissedLaunches(lastLaunch, now time.Time, calendar *PeriodicCalendar) ([]time.Time, error) {
+	var keep int
+	switch p.MissedLaunchPolicy {
+	case PeriodicMissedLaunchSkip:
+		return nil, nil
+	case PeriodicMissedLaunchRunAll:
+		keep = p.MaxMissedLaunches
+		if p.ProhibitOverlap {
+			keep = 1
+		}
+	default:
+		keep = 1
+	}
+	if keep < 1 {
+		return nil, nil
+	}
+
+	// the window is doubled at most up to the time since lastLaunch, which
+	// saturates rather than overflows
+	since := now.Sub(lastLaunch)
+	for window := minPeriodicMissedLaunchWindow; ; window *= 2 {
+		from := now.Add(-window).In(lastLaunch.Location())
+		all := window > since/2
+		if all {
+			from = lastLaunch
+		}
+
+		missed, err := p.launchesBetween(from, lastLaunch, now, calendar, keep)
+		if err != nil || all || len(missed) == keep {
+			return missed, err
+		}
+	}
+}
+
+// launchesBetween returns up to keep of the most recent launches after from
+// and lastLaunch and before now, oldest first, following the schedule on the
+// wall clock of the location of lastLaunch.
+func (p *PeriodicConfig) launchesBetween(from, lastLaunch, now time.Time, calendar *PeriodicCalendar, keep int) ([]time.Time, error) {
+	loc := lastLaunch.Location()
+	var missed []time.Time
+	fromTime := wallClock(from)
+	for i := 0; i < maxPeriodicMissedLaunchScan; i++ {
+		next, err := p.NextExcluding(fromTime, calendar)
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() || !next.After(fromTime) {
+			break
+		}
+		fromTime = next
+
+		launch := fromWallClock(next, loc)
+		if !launch.Before(now) {
+			break
+		}
+		if !launch.After(lastLaunch) {
+			continue
+		}
+		if len(missed) == keep {
+			missed = missed[1:]
+		}
+		missed = append(missed, launch)
+	}
+	return missed, nil
+}
+
+// wallClock returns the time in UTC showing the same wall clock as t.
+func wallClock(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+	return time.Date(year, month, day, hour, minute, sec, t.Nanosecond(), time.UTC)
+}
+
+// fromWallClock returns the time in loc showing the same wall clock as w, in
+// UTC. A wall clock repeated by a daylight saving time transition is its first
+// occurrence, and one skipped by a transition is moved forward by the length
+// of the transition.
+func fromWallClock(w time.Time, loc *time.Location) time.Time {
+	t := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc)
+	return t.Add(w.Sub(wallClock(t)))
+}
+
+// MissedLaunchReason returns the reason recorded in the child of a periodic job
+// launched at launchTime to make up for the launch scheduled at scheduled.
+func MissedLaunchReason(scheduled, launchTime time.Time) string {
+	const layout = "2006-01-02 15:04 MST"
+	return fmt.Sprintf("missed: scheduled %s, launched %s",
+		scheduled.Format(layout), launchTime.In(scheduled.Location()).Format(layout))
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParsePeriodicExclusions(t *testing.T) {
+	ci.Parallel(t)
+
+	c, err := ParsePeriodicExclusions([]string{"2024-07-04", "*-12-25", "2025-*-01"})
+	must.NoError(t, err)
+
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+	}
+	must.True(t, c.Excludes(day(2024, time.July, 4)))
+	must.False(t, c.Excludes(day(2025, time.July, 4)))
+	must.True(t, c.Excludes(day(2031, time.December, 25)))
+	must.True(t, c.Excludes(day(2025, time.March, 1)))
+	must.False(t, c.Excludes(day(2025, time.March, 2)))
+
+	// the nil calendar excludes nothing
+	c, err = ParsePeriodicExclusions(nil)
+	must.NoError(t, err)
+	must.False(t, c.Excludes(day(2025, time.March, 1)))
+
+	for _, invalid := range []string{"", "2024-13-01", "2024-1", "24-*-x", "*-*-*"} {
+		_, err := ParsePeriodicExclusions([]string{invalid})
+		must.Error(t, err, must.Sprint(invalid))
+	}
+}
+
+func TestParsePeriodicCalendar(t *testing.T) {
+	ci.Parallel(t)
+
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:New Year's Day\r\n" +
+		"DTSTART;VALUE=DATE:20240101\r\n" +
+		"RRULE:FREQ=YEARLY\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Company\r\n" +
+		"  offsite\r\n" +
+		"DTSTART;VALUE=DATE:20240610\r\n" +
+		"DTEND;VALUE=DATE:20240613\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20240704T090000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	c, err := ParsePeriodicCalendar(ics)
+	must.NoError(t, err)
+
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+	}
+	must.True(t, c.Excludes(day(2024, time.January, 1)))
+	must.True(t, c.Excludes(day(2030, time.January, 1)))
+	must.False(t, c.Excludes(day(2024, time.June, 9)))
+	must.True(t, c.Excludes(day(2024, time.June, 10)))
+	must.True(t, c.Excludes(day(2024, time.June, 12)))
+	must.False(t, c.Excludes(day(2024, time.June, 13)))
+	must.True(t, c.Excludes(day(2024, time.July, 4)))
+	must.False(t, c.Excludes(day(2025, time.July, 4)))
+
+	_, err = ParsePeriodicCalendar("BEGIN:VEVENT\nDTSTART:20240101\nRRULE:FREQ=WEEKLY\nEND:VEVENT\n")
+	must.ErrorContains(t, err, "only FREQ=YEARLY is supported")
+
+	_, err = ParsePeriodicCalendar("BEGIN:VEVENT\nSUMMARY:no date\nEND:VEVENT\n")
+	must.ErrorContains(t, err, "missing DTSTART")
+
+	_, err = ParsePeriodicCalendar("BEGIN:VEVENT\nDTSTART:20240101\n")
+	must.ErrorContains(t, err, "unterminated event")
+}
+
+func TestPeriodicConfig_NextLaunches(t *testing.T) {
+	ci.Parallel(t)
+
+	// every 15 minutes on weekdays, hourly on weekends
+	p := &PeriodicConfig{
+		Enabled:    true,
+		SpecType:   PeriodicSpecCron,
+		Specs:      []string{"*/15 * * * MON-FRI", "0 * * * SAT,SUN"},
+		Exclusions: []string{"*-12-25"},
+	}
+	p.Canonicalize()
+
+	calendar, err := ParsePeriodicCalendar("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20241226\nEND:VEVENT\n")
+	must.NoError(t, err)
+
+	// Tuesday Dec 24 2024, the 25th and 26th are excluded
+	from := time.Date(2024, time.December, 24, 23, 40, 0, 0, time.UTC)
+	launches, err := p.NextLaunches(from, calendar, 3)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{
+		time.Date(2024, time.December, 24, 23, 45, 0, 0, time.UTC),
+		time.Date(2024, time.December, 27, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 27, 0, 15, 0, 0, time.UTC),
+	}, launches)
+
+	// Saturday Dec 28 2024 launches hourly
+	from = time.Date(2024, time.December, 27, 23, 50, 0, 0, time.UTC)
+	launches, err = p.NextLaunches(from, nil, 2)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{
+		time.Date(2024, time.December, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 28, 1, 0, 0, 0, time.UTC),
+	}, launches)
+
+	// a job whose every launch is excluded is not launched
+	p.Specs = []string{"0 0 25 12 *"}
+	next, err := p.NextExcluding(from, nil)
+	must.NoError(t, err)
+	must.True(t, next.IsZero())
+}
+
+func TestPeriodicConfig_MissedLaunches(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &PeriodicConfig{
+		Enabled:           true,
+		SpecType:          PeriodicSpecCron,
+		Spec:              "0 * * * *",
+		MaxMissedLaunches: 2,
+	}
+	p.Canonicalize()
+
+	last := time.Date(2024, time.December, 24, 1, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.December, 24, 4, 13, 0, 0, time.UTC)
+	hour := func(h int) time.Time {
+		return time.Date(2024, time.December, 24, h, 0, 0, 0, time.UTC)
+	}
+
+	// the default policy makes up for the most recent launch only
+	missed, err := p.MissedLaunches(last, now, nil)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{hour(4)}, missed)
+
+	p.MissedLaunchPolicy = PeriodicMissedLaunchSkip
+	missed, err = p.MissedLaunches(last, now, nil)
+	must.NoError(t, err)
+	must.SliceEmpty(t, missed)
+
+	// run_all is capped to the most recent launches
+	p.MissedLaunchPolicy = PeriodicMissedLaunchRunAll
+	missed, err = p.MissedLaunches(last, now, nil)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{hour(3), hour(4)}, missed)
+
+	p.MaxMissedLaunches = 10
+	missed, err = p.MissedLaunches(last, now, nil)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{hour(2), hour(3), hour(4)}, missed)
+
+	// overlapping children are not launched
+	p.ProhibitOverlap = true
+	missed, err = p.MissedLaunches(last, now, nil)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{hour(4)}, missed)
+
+	// nothing was missed since the last launch
+	missed, err = p.MissedLaunches(hour(4), now, nil)
+	must.NoError(t, err)
+	must.SliceEmpty(t, missed)
+
+	// the most recent launches of a frequent job missed for longer than a
+	// single scan enumerates are made up for
+	p.Spec = "* * * * *"
+	p.ProhibitOverlap = false
+	p.MaxMissedLaunches = 2
+	missed, err = p.MissedLaunches(last.AddDate(-1, 0, 0), now, nil)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{now.Add(-2 * time.Minute), now.Add(-time.Minute)}, missed)
+
+	// the window reaches back to the last launch when it holds fewer
+	// launches than are made up for
+	missed, err = p.MissedLaunches(now.Add(-90*time.Second), now.Add(time.Minute), nil)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{now.Add(-time.Minute), now}, missed)
+}
+
+func TestPeriodicConfig_MissedLaunches_DST(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &PeriodicConfig{
+		Enabled:            true,
+		SpecType:           PeriodicSpecCron,
+		TimeZone:           "America/New_York",
+		MissedLaunchPolicy: PeriodicMissedLaunchRunOne,
+	}
+	p.Canonicalize()
+	loc := p.GetLocation()
+
+	// On Sun, Nov 2 2025, 2:00 am EDT the clock falls back to 1:00 am EST,
+	// so the launch at 1:30 am EDT is not made up for at 1:30 am EST.
+	p.Spec = "30 1 * * *"
+	last := time.Date(2025, time.November, 2, 1, 30, 0, 0, loc)
+	now := last.Add(75 * time.Minute)
+	must.Eq(t, "EST", now.Format("MST"))
+	missed, err := p.MissedLaunches(last, now, nil)
+	must.NoError(t, err)
+	must.SliceEmpty(t, missed)
+
+	p.MissedLaunchPolicy = PeriodicMissedLaunchRunAll
+	p.MaxMissedLaunches = 10
+	missed, err = p.MissedLaunches(last.AddDate(0, 0, -1), now, nil)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{last}, missed)
+
+	// On Sun, Mar 9 2025, 2:00 am EST the clock springs forward to 3:00 am
+	// EDT, so the launch at 2:30 am is made up for at 3:30 am EDT.
+	p.Spec = "30 2 * * *"
+	p.MissedLaunchPolicy = PeriodicMissedLaunchRunOne
+	last = time.Date(2025, time.March, 8, 2, 30, 0, 0, loc)
+	now = time.Date(2025, time.March, 9, 5, 0, 0, 0, loc)
+	missed, err = p.MissedLaunches(last, now, nil)
+	must.NoError(t, err)
+	must.Len(t, 1, missed)
+	must.Eq(t, time.Date(2025, time.March, 9, 7, 30, 0, 0, time.UTC), missed[0].UTC())
+}
+
+func TestMissedLaunchReason(t *testing.T) {
+	ci.Parallel(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	must.NoError(t, err)
+
+	scheduled := time.Date(2025, time.March, 10, 2, 0, 0, 0, loc)
+	launched := time.Date(2025, time.March, 10, 8, 13, 0, 0, time.UTC)
+	must.Eq(t, "missed: scheduled 2025-03-10 02:00 EDT, launched 2025-03-10 04:13 EDT",
+		MissedLaunchReason(scheduled, launched))
+}
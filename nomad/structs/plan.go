@@ -62,10 +62,21 @@ type Plan struct {
 	// as evicted.
 	NodePreemptions map[string][]*Allocation
 
+	// ScalingEvents maps task groups whose count the scheduler computed, such
+	// as task groups replicated per node attribute, to the scaling event
+	// explaining a change of their count. They are recorded when the plan is
+	// applied.
+	ScalingEvents map[string]*ScalingEvent
+
 	// SnapshotIndex is the Raft index of the snapshot used to create the
 	// Plan. The leader will wait to evaluate the plan until its StateStore
 	// has reached at least this index.
 	SnapshotIndex uint64
+
+	// TraceParent is the W3C traceparent of the span which submitted the
+	// plan, if it is traced, so that the span of the leader applying the
+	// plan joins the same trace.
+	TraceParent string
 }
 
 func (p *Plan) GoString() string {
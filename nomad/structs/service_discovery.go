@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+)
+
+const (
+	// ServiceUpstreamStrategyAll sets the addresses of every instance of an
+	// upstream service, separated by commas.
+	ServiceUpstreamStrategyAll = "all"
+
+	// ServiceUpstreamStrategyOne sets the address of a single instance of an
+	// upstream service, which is chosen consistently for the allocation.
+	ServiceUpstreamStrategyOne = "one"
+
+	// ServiceUpstreamChangeModeNoop takes no action when the instances of an
+	// upstream service change, other than rewriting the hosts file.
+	ServiceUpstreamChangeModeNoop = "noop"
+
+	// ServiceUpstreamChangeModeSignal signals the task when the instances of
+	// an upstream service change.
+	ServiceUpstreamChangeModeSignal = "signal"
+
+	// ServiceUpstreamChangeModeRestart restarts the task when the instances
+	// of an upstream service change, so that its environment is updated.
+	ServiceUpstreamChangeModeRestart = "restart"
+)
+
+// validServiceUpstreamEnv matches the names of the environment variables the
+// addresses of upstream services may be set in.
+var validServiceUpstreamEnv = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ServiceDiscovery configures the Nomad services whose addresses a task
+// discovers before it starts, without templates or Consul.
+type ServiceDiscovery struct {
+	// HostsFile is the path, relative to the task directory, of a file
+	// written in the hosts format with a line for each address of each
+	// upstream, named after the upstream.
+	HostsFile string
+
+	// Upstreams are the services the task discovers.
+	Upstreams []*ServiceUpstream
+}
+
+// ServiceUpstream is a Nomad service discovered by a task.
+type ServiceUpstream struct {
+	// Name is the label of the upstream block, which names the upstream in
+	// the hosts file.
+	Name string
+
+	// Service is the name of the Nomad service discovered, in the namespace
+	// of the job.
+	Service string
+
+	// Env is the name of the environment variable the addresses of the
+	// service are set in, as host:port.
+	Env string
+
+	// Strategy is how the addresses of multiple instances are set, either
+	// ServiceUpstreamStrategyAll or ServiceUpstreamStrategyOne.
+	Strategy string
+
+	// WaitForHealthy only discovers the instances of the service whose
+	// allocation is running and, if it reports its health, healthy.
+	WaitForHealthy bool
+
+	// WaitTimeout is how long the task waits for an instance of the service
+	// before it fails to start.
+	WaitTimeout time.Duration
+
+	// ChangeMode is what happens to the task when the instances of the
+	// service change: ServiceUpstreamChangeModeNoop,
+	// ServiceUpstreamChangeModeSignal, or ServiceUpstreamChangeModeRestart.
+	ChangeMode string
+
+	// ChangeSignal is the signal sent to the task when ChangeMode is
+	// ServiceUpstreamChangeModeSignal.
+	ChangeSignal string
+}
+
+func (s *ServiceDiscovery) Copy() *ServiceDiscovery {
+	if s == nil {
+		return nil
+	}
+	ns := new(ServiceDiscovery)
+	*ns = *s
+	ns.Upstreams = helper.CopySlice(s.Upstreams)
+	return ns
+}
+
+func (s *ServiceDiscovery) Validate() error {
+	var mErr multierror.Error
+	if len(s.Upstreams) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("at least one upstream must be set"))
+	}
+
+	if s.HostsFile != "" {
+		escaped, err := escapingfs.PathEscapesAllocViaRelative("task", s.HostsFile)
+		if err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid hosts_file path: %v", err))
+		} else if escaped {
+			mErr.Errors = append(mErr.Errors, errors.New("hosts_file escapes allocation directory"))
+		}
+	}
+
+	seen := make(map[string]struct{}, len(s.Upstreams))
+	for _, u := range s.Upstreams {
+		if _, ok := seen[u.Name]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("duplicate upstream %q", u.Name))
+		}
+		seen[u.Name] = struct{}{}
+
+		if u.Env == "" && s.HostsFile == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("upstream %q must set env unless hosts_file is set", u.Name))
+		}
+		if err := u.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, multierror.Prefix(err, fmt.Sprintf("upstream %q:", u.Name)))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+func (u *ServiceUpstream) Copy() *ServiceUpstream {
+	if u == nil {
+		return nil
+	}
+	nu := new(ServiceUpstream)
+	*nu = *u
+	return nu
+}
+
+// DiffID fulfills the DiffableWithID interface, matching upstreams by name.
+func (u *ServiceUpstream) DiffID() string {
+	return u.Name
+}
+
+func (u *ServiceUpstream) Validate() error {
+	var mErr multierror.Error
+	if u.Name == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing name"))
+	}
+	if u.Service == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing service"))
+	}
+	if u.Env != "" && !validServiceUpstreamEnv.MatchString(u.Env) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid env %q", u.Env))
+	}
+
+	switch u.Strategy {
+	case ServiceUpstreamStrategyAll, ServiceUpstreamStrategyOne:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid strategy %q; must be one of: %s, %s",
+			u.Strategy, ServiceUpstreamStrategyAll, ServiceUpstreamStrategyOne))
+	}
+
+	if u.WaitTimeout < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("wait_timeout must not be negative"))
+	}
+
+	switch u.ChangeMode {
+	case ServiceUpstreamChangeModeNoop, ServiceUpstreamChangeModeRestart:
+		if u.ChangeSignal != "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("change_signal requires change_mode %q", ServiceUpstreamChangeModeSignal))
+		}
+	case ServiceUpstreamChangeModeSignal:
+		if u.ChangeSignal == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("must specify a change_signal with change_mode %q", ServiceUpstreamChangeModeSignal))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid change_mode %q; must be one of: %s, %s, %s", u.ChangeMode,
+			ServiceUpstreamChangeModeNoop, ServiceUpstreamChangeModeSignal, ServiceUpstreamChangeModeRestart))
+	}
+
+	return mErr.ErrorOrNil()
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func testServiceUpstream() *ServiceUpstream {
+	return &ServiceUpstream{
+		Name:        "db",
+		Service:     "postgres",
+		Env:         "DB_ADDR",
+		Strategy:    ServiceUpstreamStrategyAll,
+		WaitTimeout: time.Minute,
+		ChangeMode:  ServiceUpstreamChangeModeRestart,
+	}
+}
+
+func TestServiceDiscovery_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		modifyFn    func(*ServiceDiscovery)
+		expectedErr string
+	}{
+		{
+			name:     "valid",
+			modifyFn: func(*ServiceDiscovery) {},
+		},
+		{
+			name: "no upstreams",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams = nil
+			},
+			expectedErr: "at least one upstream must be set",
+		},
+		{
+			name: "hosts file escapes",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.HostsFile = "../../../etc/hosts"
+			},
+			expectedErr: "hosts_file escapes allocation directory",
+		},
+		{
+			name: "duplicate upstream",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams = append(s.Upstreams, testServiceUpstream())
+			},
+			expectedErr: `duplicate upstream "db"`,
+		},
+		{
+			name: "missing env",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].Env = ""
+			},
+			expectedErr: `upstream "db" must set env unless hosts_file is set`,
+		},
+		{
+			name: "hosts file without env",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.HostsFile = "local/hosts"
+				s.Upstreams[0].Env = ""
+			},
+		},
+		{
+			name: "invalid env",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].Env = "DB-ADDR"
+			},
+			expectedErr: `upstream "db": invalid env "DB-ADDR"`,
+		},
+		{
+			name: "missing service",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].Service = ""
+			},
+			expectedErr: "missing service",
+		},
+		{
+			name: "invalid strategy",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].Strategy = "random"
+			},
+			expectedErr: `invalid strategy "random"`,
+		},
+		{
+			name: "negative wait timeout",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].WaitTimeout = -time.Second
+			},
+			expectedErr: "wait_timeout must not be negative",
+		},
+		{
+			name: "signal without change signal",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].ChangeMode = ServiceUpstreamChangeModeSignal
+			},
+			expectedErr: `must specify a change_signal with change_mode "signal"`,
+		},
+		{
+			name: "change signal without signal",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].ChangeSignal = "SIGHUP"
+			},
+			expectedErr: `change_signal requires change_mode "signal"`,
+		},
+		{
+			name: "invalid change mode",
+			modifyFn: func(s *ServiceDiscovery) {
+				s.Upstreams[0].ChangeMode = "reload"
+			},
+			expectedErr: `invalid change_mode "reload"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &ServiceDiscovery{Upstreams: []*ServiceUpstream{testServiceUpstream()}}
+			tc.modifyFn(s)
+
+			err := s.Validate()
+			if tc.expectedErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestServiceDiscovery_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, (*ServiceDiscovery)(nil).Copy())
+
+	s := &ServiceDiscovery{
+		HostsFile: "local/hosts",
+		Upstreams: []*ServiceUpstream{testServiceUpstream()},
+	}
+	c := s.Copy()
+	must.Eq(t, s, c)
+
+	c.Upstreams[0].Service = "mysql"
+	must.Eq(t, "postgres", s.Upstreams[0].Service)
+}
@@ -91,6 +91,11 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Weights are determined from either Service.Weights or
+	// Service.CanaryWeights and allow consumers to implement weighted
+	// selection of the instances of a service, based on their health.
+	Weights *ServiceWeights
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -105,6 +110,7 @@ func (s *ServiceRegistration) Copy() *ServiceRegistration {
 	ns := new(ServiceRegistration)
 	*ns = *s
 	ns.Tags = slices.Clone(ns.Tags)
+	ns.Weights = s.Weights.Copy()
 
 	return ns
 }
@@ -145,6 +151,9 @@ func (s *ServiceRegistration) Equal(o *ServiceRegistration) bool {
 	if !helper.SliceSetEq(s.Tags, o.Tags) {
 		return false
 	}
+	if !s.Weights.Equal(o.Weights) {
+		return false
+	}
 	return true
 }
 
@@ -276,6 +285,11 @@ type ServiceRegistrationStub struct {
 type ServiceRegistrationByNameRequest struct {
 	ServiceName string
 	Choose      string // stable selection of n services
+
+	// Healthy only returns the registrations whose allocation is running
+	// and, if it reports its health, healthy.
+	Healthy bool
+
 	QueryOptions
 }
 
@@ -376,6 +376,36 @@ func TestServiceRegistration_Equal(t *testing.T) {
 			expectedOutput: true,
 			name:           "both equal",
 		},
+		{
+			serviceReg1: &ServiceRegistration{
+				ID:          "_nomad-task-2873cf75-42e5-7c45-ca1c-415f3e18be3d-group-cache-example-cache-db",
+				ServiceName: "example-cache",
+				Namespace:   "default",
+				NodeID:      "17a6d1c0-811e-2ca9-ded0-3d5d6a54904c",
+				Datacenter:  "dc1",
+				JobID:       "example",
+				AllocID:     "2873cf75-42e5-7c45-ca1c-415f3e18be3d",
+				Tags:        []string{"foo"},
+				Address:     "192.168.13.13",
+				Port:        23813,
+				Weights:     &ServiceWeights{Passing: 10, Warning: 1},
+			},
+			serviceReg2: &ServiceRegistration{
+				ID:          "_nomad-task-2873cf75-42e5-7c45-ca1c-415f3e18be3d-group-cache-example-cache-db",
+				ServiceName: "example-cache",
+				Namespace:   "default",
+				NodeID:      "17a6d1c0-811e-2ca9-ded0-3d5d6a54904c",
+				Datacenter:  "dc1",
+				JobID:       "example",
+				AllocID:     "2873cf75-42e5-7c45-ca1c-415f3e18be3d",
+				Tags:        []string{"foo"},
+				Address:     "192.168.13.13",
+				Port:        23813,
+				Weights:     &ServiceWeights{Passing: 1, Warning: 1},
+			},
+			expectedOutput: false,
+			name:           "weights not equal",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -622,6 +622,10 @@ type Service struct {
 	CanaryMeta map[string]string // Consul service meta when it is a canary
 	Weights    *ServiceWeights   // Service weights for DNS SRV request
 
+	// CanaryWeights are the weights of the service when it is a canary,
+	// overriding Weights.
+	CanaryWeights *ServiceWeights
+
 	// The values to set for tagged_addresses in Consul service registration.
 	// Does not affect Nomad networking, these are for Consul service discovery.
 	TaggedAddresses map[string]string
@@ -644,6 +648,11 @@ type Service struct {
 	// Consul Cluster (by name) to send API requests to
 	Cluster string
 
+	// Consul overrides the Consul namespace and admin partition of the
+	// enclosing group or task for this service. The cluster is set with the
+	// Cluster field instead. Only valid when provider is consul.
+	Consul *Consul
+
 	// Identity is a field populated automatically by the job mutating hook.
 	// Its name will be `consul-service/${service_name}`, and its contents will
 	// match the server's `consul.service_identity` configuration block.
@@ -679,7 +688,9 @@ func (s *Service) Copy() *Service {
 	ns.TaggedAddresses = maps.Clone(s.TaggedAddresses)
 
 	ns.Weights = s.Weights.Copy()
+	ns.CanaryWeights = s.CanaryWeights.Copy()
 	ns.Identity = s.Identity.Copy()
+	ns.Consul = s.Consul.Copy()
 
 	ns.Kind = s.Kind
 
@@ -880,6 +891,11 @@ func (s *Service) validateConsulService(mErr *multierror.Error) {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Service %s kind must be one of consul service kind or empty", s.Name))
 	}
 
+	// the cluster of a service is set by its cluster field
+	if s.Consul != nil && s.Consul.Cluster != "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Service %s consul block cannot set a cluster; use the service cluster instead", s.Name))
+	}
+
 	// check connect
 	if s.Connect != nil {
 		if err := s.Connect.Validate(); err != nil {
@@ -920,6 +936,10 @@ func (s *Service) validateNomadService(mErr *multierror.Error) {
 	if s.Connect != nil {
 		mErr.Errors = append(mErr.Errors, errors.New("Service with provider nomad cannot include Connect blocks"))
 	}
+
+	if s.Consul != nil {
+		mErr.Errors = append(mErr.Errors, errors.New("Service with provider nomad cannot include a consul block"))
+	}
 }
 
 // validateIdentity performs validation on workload identity field populated by
@@ -974,8 +994,10 @@ func (s *Service) Hash(allocID, taskName string, canary bool) string {
 	hashConnect(h, s.Connect)
 	hashString(h, s.OnUpdate)
 	hashString(h, s.Namespace)
+	hashServiceConsul(h, s.Consul)
 	hashIdentity(h, s.Identity)
 	hashWeights(h, s.Weights)
+	hashCanaryWeights(h, s.CanaryWeights)
 	hashString(h, s.Kind)
 
 	// Don't hash the provider parameter, so we don't cause churn of all
@@ -1011,6 +1033,9 @@ func hashConnect(h hash.Hash, connect *ConsulConnect) {
 				hashString(h, upstream.LocalBindSocketPath)
 				hashString(h, upstream.LocalBindSocketMode)
 				hashConfig(h, upstream.Config)
+				hashIntIfNonZero(h, "ConnectTimeout", int(upstream.ConnectTimeout))
+				hashIntIfNonZero(h, "MaxFailures", upstream.PassiveHealthCheck.MaxFailures)
+				hashIntIfNonZero(h, "Interval", int(upstream.PassiveHealthCheck.Interval))
 			}
 		}
 	}
@@ -1023,6 +1048,22 @@ func hashWeights(h hash.Hash, weights *ServiceWeights) {
 	}
 }
 
+// hashCanaryWeights hashes the canary weights apart from the weights, without
+// changing the hash of services which do not set them.
+func hashCanaryWeights(h hash.Hash, weights *ServiceWeights) {
+	if weights != nil {
+		hashString(h, "CanaryWeights")
+		hashWeights(h, weights)
+	}
+}
+
+func hashServiceConsul(h hash.Hash, consul *Consul) {
+	if consul != nil {
+		hashStringIfNonEmpty(h, consul.Namespace)
+		hashStringIfNonEmpty(h, consul.Partition)
+	}
+}
+
 func hashIdentity(h hash.Hash, identity *WorkloadIdentity) {
 	if identity != nil {
 		hashString(h, identity.Name)
@@ -1097,6 +1138,10 @@ func (s *Service) Equal(o *Service) bool {
 		return false
 	}
 
+	if !s.Consul.Equal(o.Consul) {
+		return false
+	}
+
 	if s.AddressMode != o.AddressMode {
 		return false
 	}
@@ -1157,6 +1202,10 @@ func (s *Service) Equal(o *Service) bool {
 		return false
 	}
 
+	if !s.CanaryWeights.Equal(o.CanaryWeights) {
+		return false
+	}
+
 	if s.Kind != o.Kind {
 		return false
 	}
@@ -1168,6 +1217,16 @@ func (s *Service) IsConsul() bool {
 	return s.Provider == ServiceProviderConsul || s.Provider == ""
 }
 
+// ConsulNamespace returns the Consul namespace in which the service is
+// registered, which is namespace unless overridden by the service's consul
+// block.
+func (s *Service) ConsulNamespace(namespace string) string {
+	if s.Consul != nil && s.Consul.Namespace != "" {
+		return s.Consul.Namespace
+	}
+	return namespace
+}
+
 // ServiceWeights represents the weights for a service block.
 type ServiceWeights struct {
 	Passing int
@@ -1320,6 +1379,13 @@ func (c *ConsulConnect) Validate() error {
 				return err
 			}
 		}
+		if proxy := c.SidecarService.Proxy; proxy != nil {
+			for _, upstream := range proxy.Upstreams {
+				if err := upstream.Validate(); err != nil {
+					return err
+				}
+			}
+		}
 		count++
 	}
 
@@ -1803,6 +1869,108 @@ type ConsulUpstream struct {
 	// Config is an upstream configuration. It is opaque to Nomad and passed
 	// directly to Consul.
 	Config map[string]any
+
+	// ConnectTimeout is the timeout for new network connections from the
+	// proxy to the upstream. Zero leaves the Consul default in place.
+	ConnectTimeout time.Duration
+
+	// PassiveHealthCheck configures outlier detection of the upstream's
+	// instances by the proxy.
+	PassiveHealthCheck ConsulPassiveHealthCheck
+}
+
+// ConsulPassiveHealthCheck represents a Consul Connect upstream
+// passive_health_check jobspec block. A zero value leaves passive health
+// checking to the Consul defaults.
+type ConsulPassiveHealthCheck struct {
+	// MaxFailures is the number of consecutive failures after which an
+	// upstream instance is ejected from the load balancing pool.
+	MaxFailures int
+
+	// Interval is the time between ejection sweeps of the upstream instances.
+	Interval time.Duration
+}
+
+// IsZero returns true if no passive health check fields are set.
+func (c ConsulPassiveHealthCheck) IsZero() bool {
+	return c == ConsulPassiveHealthCheck{}
+}
+
+// Validate returns an error if the upstream's first class proxy fields are
+// invalid, or conflict with the opaque upstream configuration.
+func (u *ConsulUpstream) Validate() error {
+	if u.ConnectTimeout < 0 {
+		return fmt.Errorf("Consul Connect upstream %q connect_timeout must not be negative", u.DestinationName)
+	}
+	if u.ConnectTimeout > 0 {
+		if _, exists := u.Config[upstreamConfigConnectTimeout]; exists {
+			return fmt.Errorf("Consul Connect upstream %q cannot set both connect_timeout and config.%s",
+				u.DestinationName, upstreamConfigConnectTimeout)
+		}
+	}
+
+	phc := u.PassiveHealthCheck
+	if phc.MaxFailures < 0 {
+		return fmt.Errorf("Consul Connect upstream %q passive_health_check max_failures must not be negative", u.DestinationName)
+	}
+	if phc.Interval < 0 {
+		return fmt.Errorf("Consul Connect upstream %q passive_health_check interval must not be negative", u.DestinationName)
+	}
+	if !phc.IsZero() {
+		if _, exists := u.Config[upstreamConfigPassiveHealthCheck]; exists {
+			return fmt.Errorf("Consul Connect upstream %q cannot set both passive_health_check and config.%s",
+				u.DestinationName, upstreamConfigPassiveHealthCheck)
+		}
+	}
+
+	// Envoy ignores the connect timeout and outlier detection Consul derives
+	// from the upstream config when the cluster is replaced wholesale.
+	if u.ConnectTimeout > 0 || !phc.IsZero() {
+		if _, exists := u.Config[upstreamConfigEnvoyCluster]; exists {
+			return fmt.Errorf("Consul Connect upstream %q cannot set connect_timeout or passive_health_check with config.%s",
+				u.DestinationName, upstreamConfigEnvoyCluster)
+		}
+	}
+	return nil
+}
+
+const (
+	// upstreamConfigConnectTimeout and upstreamConfigPassiveHealthCheck are
+	// the keys of the Consul upstream configuration set by the first class
+	// ConnectTimeout and PassiveHealthCheck upstream fields.
+	upstreamConfigConnectTimeout     = "connect_timeout_ms"
+	upstreamConfigPassiveHealthCheck = "passive_health_check"
+
+	// upstreamConfigEnvoyCluster is the Consul escape hatch which replaces the
+	// upstream's Envoy cluster, including the settings above.
+	upstreamConfigEnvoyCluster = "envoy_cluster_json"
+)
+
+// ProxyConfig returns the opaque upstream configuration with the first class
+// upstream fields applied, as registered with Consul.
+func (u *ConsulUpstream) ProxyConfig() map[string]any {
+	if u.ConnectTimeout == 0 && u.PassiveHealthCheck.IsZero() {
+		return maps.Clone(u.Config)
+	}
+
+	config := maps.Clone(u.Config)
+	if config == nil {
+		config = make(map[string]any)
+	}
+	if u.ConnectTimeout > 0 {
+		config[upstreamConfigConnectTimeout] = u.ConnectTimeout.Milliseconds()
+	}
+	if phc := u.PassiveHealthCheck; !phc.IsZero() {
+		check := make(map[string]any)
+		if phc.MaxFailures > 0 {
+			check["max_failures"] = phc.MaxFailures
+		}
+		if phc.Interval > 0 {
+			check["interval"] = phc.Interval.String()
+		}
+		config[upstreamConfigPassiveHealthCheck] = check
+	}
+	return config
 }
 
 // Equal returns true if the structs are recursively equal.
@@ -1836,6 +2004,10 @@ func (u *ConsulUpstream) Equal(o *ConsulUpstream) bool {
 	case !reflect.DeepEqual(u.Config, o.Config):
 		// envoy config, use reflect
 		return false
+	case u.ConnectTimeout != o.ConnectTimeout:
+		return false
+	case u.PassiveHealthCheck != o.PassiveHealthCheck:
+		return false
 	}
 	return true
 }
@@ -508,6 +508,10 @@ func TestService_Hash(t *testing.T) {
 		try(t, func(s *svc) { s.EnableTagOverride = true })
 	})
 
+	t.Run("mod canary weights", func(t *testing.T) {
+		try(t, func(s *svc) { s.CanaryWeights = &ServiceWeights{Passing: 1, Warning: 1} })
+	})
+
 	t.Run("mod connect sidecar tags", func(t *testing.T) {
 		try(t, func(s *svc) { s.Connect.SidecarService.Tags = []string{"new", "tags"} })
 	})
@@ -915,6 +919,26 @@ func TestConsulUpstream_upstreamEqual(t *testing.T) {
 		must.False(t, upstreamsEquals(a, b))
 	})
 
+	t.Run("different connect timeout", func(t *testing.T) {
+		a := []ConsulUpstream{up("foo", 8000)}
+		a[0].ConnectTimeout = time.Second
+
+		b := []ConsulUpstream{up("foo", 8000)}
+		b[0].ConnectTimeout = 2 * time.Second
+
+		must.False(t, upstreamsEquals(a, b))
+	})
+
+	t.Run("different passive health check", func(t *testing.T) {
+		a := []ConsulUpstream{up("foo", 8000)}
+		a[0].PassiveHealthCheck = ConsulPassiveHealthCheck{MaxFailures: 3}
+
+		b := []ConsulUpstream{up("foo", 8000)}
+		b[0].PassiveHealthCheck = ConsulPassiveHealthCheck{MaxFailures: 5}
+
+		must.False(t, upstreamsEquals(a, b))
+	})
+
 	t.Run("identical", func(t *testing.T) {
 		a := []ConsulUpstream{up("foo", 8000), up("bar", 9000)}
 		b := []ConsulUpstream{up("foo", 8000), up("bar", 9000)}
@@ -938,6 +962,149 @@ func TestConsulUpstream_upstreamEqual(t *testing.T) {
 	})
 }
 
+func TestConsulUpstream_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		upstream *ConsulUpstream
+		expErr   string
+	}{
+		{
+			name:     "empty",
+			upstream: &ConsulUpstream{DestinationName: "api"},
+		},
+		{
+			name: "valid",
+			upstream: &ConsulUpstream{
+				DestinationName: "api",
+				ConnectTimeout:  5 * time.Second,
+				PassiveHealthCheck: ConsulPassiveHealthCheck{
+					MaxFailures: 3,
+					Interval:    10 * time.Second,
+				},
+				Config: map[string]any{"protocol": "http"},
+			},
+		},
+		{
+			name: "negative connect timeout",
+			upstream: &ConsulUpstream{
+				DestinationName: "api",
+				ConnectTimeout:  -time.Second,
+			},
+			expErr: "connect_timeout must not be negative",
+		},
+		{
+			name: "negative max failures",
+			upstream: &ConsulUpstream{
+				DestinationName:    "api",
+				PassiveHealthCheck: ConsulPassiveHealthCheck{MaxFailures: -1},
+			},
+			expErr: "max_failures must not be negative",
+		},
+		{
+			name: "negative interval",
+			upstream: &ConsulUpstream{
+				DestinationName:    "api",
+				PassiveHealthCheck: ConsulPassiveHealthCheck{Interval: -time.Second},
+			},
+			expErr: "interval must not be negative",
+		},
+		{
+			name: "connect timeout in config",
+			upstream: &ConsulUpstream{
+				DestinationName: "api",
+				ConnectTimeout:  5 * time.Second,
+				Config:          map[string]any{"connect_timeout_ms": 1000},
+			},
+			expErr: "cannot set both connect_timeout and config.connect_timeout_ms",
+		},
+		{
+			name: "passive health check in config",
+			upstream: &ConsulUpstream{
+				DestinationName:    "api",
+				PassiveHealthCheck: ConsulPassiveHealthCheck{MaxFailures: 3},
+				Config:             map[string]any{"passive_health_check": map[string]any{}},
+			},
+			expErr: "cannot set both passive_health_check and config.passive_health_check",
+		},
+		{
+			name: "envoy cluster override",
+			upstream: &ConsulUpstream{
+				DestinationName: "api",
+				ConnectTimeout:  5 * time.Second,
+				Config:          map[string]any{"envoy_cluster_json": "{}"},
+			},
+			expErr: "with config.envoy_cluster_json",
+		},
+		{
+			name: "envoy cluster override without fields",
+			upstream: &ConsulUpstream{
+				DestinationName: "api",
+				Config:          map[string]any{"envoy_cluster_json": "{}"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.upstream.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+
+	t.Run("connect", func(t *testing.T) {
+		c := &ConsulConnect{
+			SidecarService: &ConsulSidecarService{
+				Proxy: &ConsulProxy{
+					Upstreams: []ConsulUpstream{{
+						DestinationName: "api",
+						ConnectTimeout:  -time.Second,
+					}},
+				},
+			},
+		}
+		must.ErrorContains(t, c.Validate(), "connect_timeout must not be negative")
+	})
+}
+
+func TestConsulUpstream_ProxyConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("unset", func(t *testing.T) {
+		u := &ConsulUpstream{Config: map[string]any{"protocol": "http"}}
+		must.Eq(t, map[string]any{"protocol": "http"}, u.ProxyConfig())
+
+		must.Nil(t, new(ConsulUpstream).ProxyConfig())
+	})
+
+	t.Run("set", func(t *testing.T) {
+		u := &ConsulUpstream{
+			Config:         map[string]any{"protocol": "http"},
+			ConnectTimeout: 5 * time.Second,
+			PassiveHealthCheck: ConsulPassiveHealthCheck{
+				MaxFailures: 3,
+				Interval:    10 * time.Second,
+			},
+		}
+		must.Eq(t, map[string]any{
+			"protocol":           "http",
+			"connect_timeout_ms": int64(5000),
+			"passive_health_check": map[string]any{
+				"max_failures": 3,
+				"interval":     "10s",
+			},
+		}, u.ProxyConfig())
+
+		// the opaque config is not modified
+		must.Eq(t, map[string]any{"protocol": "http"}, u.Config)
+	})
+}
+
 func TestConsulExposePath_exposePathsEqual(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2077,6 +2244,35 @@ func TestService_Validate(t *testing.T) {
 			expErr:    true,
 			expErrStr: "Service testservice kind must be one of consul service kind or empty",
 		},
+		{
+			name: "provider consul with consul namespace",
+			input: &Service{
+				Name:     "testservice",
+				Provider: "consul",
+				Consul:   &Consul{Namespace: "shared", Partition: "default"},
+			},
+			expErr: false,
+		},
+		{
+			name: "provider consul with consul cluster",
+			input: &Service{
+				Name:     "testservice",
+				Provider: "consul",
+				Consul:   &Consul{Cluster: "other"},
+			},
+			expErr:    true,
+			expErrStr: "Service testservice consul block cannot set a cluster",
+		},
+		{
+			name: "provider nomad with consul block",
+			input: &Service{
+				Name:     "testservice",
+				Provider: "nomad",
+				Consul:   &Consul{Namespace: "shared"},
+			},
+			expErr:    true,
+			expErrStr: "Service with provider nomad cannot include a consul block",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -31,3 +31,32 @@ func (t *Task) GetVaultClusterName() string {
 	}
 	return VaultDefaultCluster
 }
+
+// AllVaults returns the primary Vault block of the task followed by its
+// additional Vault blocks, or nil if the task does not use Vault.
+func (t *Task) AllVaults() []*Vault {
+	if t.Vault == nil {
+		return nil
+	}
+	return append([]*Vault{t.Vault}, t.Vaults...)
+}
+
+// LookupVault returns the Vault block of the task for the given cluster, or
+// nil if there is none.
+func (t *Task) LookupVault(cluster string) *Vault {
+	for _, v := range t.AllVaults() {
+		if v.ClusterName() == cluster {
+			return v
+		}
+	}
+	return nil
+}
+
+// ClusterName returns the name of the Vault cluster of the block, which is
+// the default cluster if none is set.
+func (v *Vault) ClusterName() string {
+	if v.Cluster != "" {
+		return v.Cluster
+	}
+	return VaultDefaultCluster
+}
@@ -90,6 +90,7 @@ type WorkloadIdentityClaimsBuilder struct {
 	tg          *TaskGroup
 	task        *Task
 	serviceName string
+	service     *Service
 	consul      *Consul
 	vault       *Vault
 	node        *Node
@@ -128,13 +129,19 @@ func (b *WorkloadIdentityClaimsBuilder) WithTask(task *Task) *WorkloadIdentityCl
 	return b
 }
 
-// WithVault adds the task's vault block to the builder context. This should
-// only be called after WithTask.
+// WithVault adds the task's vault block for the cluster of the identity to the
+// builder context. This should only be called after WithTask.
 func (b *WorkloadIdentityClaimsBuilder) WithVault(extraClaims map[string]string) *WorkloadIdentityClaimsBuilder {
 	if !b.wid.IsVault() || b.task == nil {
 		return b
 	}
 	b.vault = b.task.Vault
+	for _, vault := range b.task.Vaults {
+		if WorkloadIdentityVaultPrefix+vault.ClusterName() == b.wid.Name {
+			b.vault = vault
+			break
+		}
+	}
 	for k, v := range extraClaims {
 		b.extras[k] = v
 	}
@@ -167,6 +174,7 @@ func (b *WorkloadIdentityClaimsBuilder) WithService(service *Service) *WorkloadI
 		serviceName = b.wihandle.InterpolatedWorkloadIdentifier
 	}
 	b.serviceName = serviceName
+	b.service = service
 	return b
 }
 
@@ -204,6 +212,10 @@ func (b *WorkloadIdentityClaimsBuilder) Build(now time.Time) *IdentityClaims {
 	if b.consul != nil {
 		claims.ConsulNamespace = b.consul.Namespace
 	}
+	if b.service != nil && b.wid.IsConsul() {
+		// the service's own consul block overrides the group or task namespace
+		claims.ConsulNamespace = b.service.ConsulNamespace(claims.ConsulNamespace)
+	}
 	if b.vault != nil {
 		claims.VaultNamespace = b.vault.Namespace
 		claims.VaultRole = b.vault.Role
@@ -108,6 +108,15 @@ func TestNewIdentityClaims(t *testing.T) {
 					Identity: &WorkloadIdentity{
 						Audience: []string{"group-service.consul.io"},
 					},
+				}, {
+					Name:      "shared-service",
+					PortLabel: "http",
+					Consul: &Consul{
+						Namespace: "shared-consul-namespace",
+					},
+					Identity: &WorkloadIdentity{
+						Audience: []string{"group-service.consul.io"},
+					},
 				}},
 				Tasks: []*Task{
 					{
@@ -335,6 +344,21 @@ func TestNewIdentityClaims(t *testing.T) {
 			},
 		},
 		// group: with consul.
+		// Use service-level Consul namespace for services which set one.
+		"job/consul-group/services/shared-service": {
+			WorkloadIdentityClaims: &WorkloadIdentityClaims{
+				ConsulNamespace: "shared-consul-namespace",
+				Namespace:       "default",
+				JobID:           "parentJob",
+				ServiceName:     "shared-service",
+				ExtraClaims:     map[string]string{},
+			},
+			Claims: jwt.Claims{
+				Subject:  "global:default:parentJob:consul-group:shared-service:consul-service_shared-service-http",
+				Audience: jwt.Audience{"group-service.consul.io"},
+			},
+		},
+		// group: with consul.
 		// task:  no consul, no vault.
 		"job/consul-group/task/default-identity": {
 			WorkloadIdentityClaims: &WorkloadIdentityClaims{
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -23,8 +24,19 @@ func NewSystemEndpoint(srv *Server, ctx *RPCContext) *System {
 }
 
 // GarbageCollect is used to trigger the system to immediately garbage collect nodes, evals
-// and jobs.
-func (s *System) GarbageCollect(args *structs.GenericRequest, reply *structs.GenericResponse) error {
+// and jobs, or only the types of objects targeted by the request. A dry run
+// instead reports the number of objects of each type eligible for garbage
+// collection.
+func (s *System) GarbageCollect(args *structs.GarbageCollectRequest, reply *structs.GarbageCollectResponse) error {
+
+	// The request is checked before it is forwarded, as a leader which does
+	// not support it would run a full garbage collection instead
+	if (args.DryRun || len(args.Types) > 0) && !s.srv.peersCache.ServersMeetMinimumVersion(
+		args.RequestRegion(), minVersionTargetedGC, true) {
+		return fmt.Errorf(
+			"all servers must be running version %v or later to target or dry run garbage collection",
+			minVersionTargetedGC)
+	}
 
 	authErr := s.srv.Authenticate(s.ctx, args)
 	if done, err := s.srv.forward("System.GarbageCollect", args, args, reply); done {
@@ -42,13 +54,42 @@ func (s *System) GarbageCollect(args *structs.GenericRequest, reply *structs.Gen
 		return structs.ErrPermissionDenied
 	}
 
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(400, err.Error())
+	}
+
+	if args.DryRun {
+		snap, err := s.srv.fsm.State().Snapshot()
+		if err != nil {
+			return err
+		}
+		types := args.Types
+		if len(types) == 0 {
+			types = structs.GCTypes
+		}
+
+		core := NewCoreScheduler(s.srv, snap).(*CoreScheduler)
+		reply.Classes, err = core.gcStats(types)
+		if err != nil {
+			return err
+		}
+		reply.Index, err = snap.LatestIndex()
+		return err
+	}
+
 	// Get the states current index
 	snapshotIndex, err := s.srv.fsm.State().LatestIndex()
 	if err != nil {
 		return fmt.Errorf("failed to determine state store's index: %v", err)
 	}
 
-	s.srv.evalBroker.Enqueue(s.srv.coreJobEval(structs.CoreJobForceGC, snapshotIndex))
+	// the targeted types are smuggled in the JobID of the core job
+	coreJob := structs.CoreJobForceGC
+	if len(args.Types) > 0 {
+		coreJob += ":" + strings.Join(args.Types, ",")
+	}
+
+	s.srv.evalBroker.Enqueue(s.srv.coreJobEval(coreJob, snapshotIndex))
 	return nil
 }
 
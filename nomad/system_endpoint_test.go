@@ -69,6 +69,156 @@ func TestSystemEndpoint_GarbageCollect(t *testing.T) {
 	}), wait.Timeout(3*time.Second)))
 }
 
+func TestSystemEndpoint_GarbageCollect_Types(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Insert a job that can be GC'd
+	state := s1.fsm.State()
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.Stop = true
+	job.SubmitTime = time.Now().Add(-10 * time.Millisecond).UnixNano()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	eval := mock.Eval()
+	eval.Status = structs.EvalStatusComplete
+	eval.JobID = job.ID
+	eval.JobModifyIndex = job.ModifyIndex
+	eval.ModifyTime = time.Now().Add(-10 * time.Millisecond).UnixNano()
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1001, []*structs.Evaluation{eval}))
+
+	// Insert a deployment that can be GC'd
+	d := mock.Deployment()
+	d.Status = structs.DeploymentStatusSuccessful
+	d.ModifyTime = time.Now().Add(-10 * time.Millisecond).UnixNano()
+	must.NoError(t, state.UpsertDeployment(1002, d))
+
+	// Invalid types are rejected
+	req := &structs.GarbageCollectRequest{
+		Types: []string{"allocs"},
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var resp structs.GarbageCollectResponse
+	err := msgpackrpc.CallWithCodec(codec, "System.GarbageCollect", req, &resp)
+	must.ErrorContains(t, err, `invalid garbage collection type "allocs"`)
+
+	// Only GC the deployments
+	req.Types = []string{structs.GCTypeDeployments}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "System.GarbageCollect", req, &resp))
+
+	must.Wait(t, wait.InitialSuccess(wait.ErrorFunc(func() error {
+		exist, err := state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			return fmt.Errorf("deployment %s wasn't garbage collected", d.ID)
+		}
+		return nil
+	}), wait.Timeout(3*time.Second)))
+
+	// Jobs are collected before deployments, so the job would be gone by
+	// now if it were targeted
+	exist, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, exist)
+}
+
+func TestSystemEndpoint_GarbageCollect_DryRun(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Insert a job that can only be GC'd by a forced GC
+	state := s1.fsm.State()
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.Stop = true
+	job.SubmitTime = time.Now().Add(-10 * time.Millisecond).UnixNano()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	eval := mock.Eval()
+	eval.Status = structs.EvalStatusComplete
+	eval.JobID = job.ID
+	eval.JobModifyIndex = job.ModifyIndex
+	eval.ModifyTime = time.Now().Add(-10 * time.Millisecond).UnixNano()
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1001, []*structs.Evaluation{eval}))
+
+	req := &structs.GarbageCollectRequest{
+		DryRun: true,
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var resp structs.GarbageCollectResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "System.GarbageCollect", req, &resp))
+	must.MapLen(t, len(structs.GCTypes), resp.Classes)
+	must.Eq(t, &structs.GCClassStats{
+		Eligible: 0,
+		Forced:   1,
+		Thresholds: map[string]time.Duration{
+			"job_gc_threshold": s1.config.JobGCThreshold,
+		},
+	}, resp.Classes[structs.GCTypeJobs])
+	must.Eq(t, s1.config.BatchEvalGCThreshold,
+		resp.Classes[structs.GCTypeEvals].Thresholds["batch_eval_gc_threshold"])
+	must.Positive(t, resp.Index)
+
+	// Only the targeted types are reported
+	req.Types = []string{structs.GCTypeNodes}
+	resp = structs.GarbageCollectResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "System.GarbageCollect", req, &resp))
+	must.MapLen(t, 1, resp.Classes)
+	must.MapContainsKey(t, resp.Classes, structs.GCTypeNodes)
+
+	// Nothing was collected
+	exist, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, exist)
+}
+
+func TestSystemEndpoint_GarbageCollect_MinVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.Build = "1.11.0"
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Servers which would run a full garbage collection instead reject a
+	// dry run or targeted one
+	req := &structs.GarbageCollectRequest{
+		DryRun: true,
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var resp structs.GarbageCollectResponse
+	err := msgpackrpc.CallWithCodec(codec, "System.GarbageCollect", req, &resp)
+	must.ErrorContains(t, err, "all servers must be running version 1.11.1 or later")
+
+	req.DryRun = false
+	req.Types = []string{structs.GCTypeNodes}
+	err = msgpackrpc.CallWithCodec(codec, "System.GarbageCollect", req, &resp)
+	must.ErrorContains(t, err, "all servers must be running version 1.11.1 or later")
+
+	// A full garbage collection is unaffected
+	req.Types = nil
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "System.GarbageCollect", req, &resp))
+}
+
 func TestSystemEndpoint_GarbageCollect_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/tracing"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the spans recorded by servers.
+const tracerName = "github.com/hashicorp/nomad/nomad"
+
+// newTracer returns the tracer of provider, or a tracer recording nothing if
+// provider is nil.
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// startSpan starts the span name as a child of the span of the W3C
+// traceParent, or as the root of a new trace if traceParent is empty. The
+// attributes of spans must only identify the objects involved, such as by
+// their IDs, and never include job specifications, payloads or tokens.
+func (s *Server) startSpan(traceParent, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return s.tracer.Start(tracing.Context(traceParent), name, opts...)
+}
+
+// jobRegisterSpanAttributes returns the attributes identifying the job
+// registered by args.
+func jobRegisterSpanAttributes(args *structs.JobRegisterRequest) trace.SpanStartOption {
+	attrs := []attribute.KeyValue{attribute.String("nomad.namespace", args.RequestNamespace())}
+	if args.Job != nil {
+		attrs = append(attrs, attribute.String("nomad.job_id", args.Job.ID))
+	}
+	return trace.WithAttributes(attrs...)
+}
+
+// evalSpanAttributes returns the attributes identifying eval and its job.
+func evalSpanAttributes(eval *structs.Evaluation) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attribute.String("nomad.eval_id", eval.ID),
+		attribute.String("nomad.namespace", eval.Namespace),
+		attribute.String("nomad.job_id", eval.JobID),
+		attribute.String("nomad.eval_type", eval.Type),
+		attribute.String("nomad.triggered_by", eval.TriggeredBy),
+	)
+}
+
+// planSpanAttributes returns the attributes identifying plan, its evaluation
+// and its job.
+func planSpanAttributes(plan *structs.Plan) trace.SpanStartOption {
+	attrs := []attribute.KeyValue{attribute.String("nomad.eval_id", plan.EvalID)}
+	if plan.Job != nil {
+		attrs = append(attrs,
+			attribute.String("nomad.namespace", plan.Job.Namespace),
+			attribute.String("nomad.job_id", plan.Job.ID),
+		)
+	}
+	return trace.WithAttributes(attrs...)
+}
+
+// planResultSpanAttributes returns the attributes counting the allocations
+// placed, stopped and preempted by the evaluated plan result.
+func planResultSpanAttributes(result *structs.PlanResult) []attribute.KeyValue {
+	count := func(allocs map[string][]*structs.Allocation) int {
+		n := 0
+		for _, nodeAllocs := range allocs {
+			n += len(nodeAllocs)
+		}
+		return n
+	}
+	return []attribute.KeyValue{
+		attribute.Int("nomad.allocs_placed", count(result.NodeAllocation)),
+		attribute.Int("nomad.allocs_stopped", count(result.NodeUpdate)),
+		attribute.Int("nomad.allocs_preempted", count(result.NodePreemptions)),
+		attribute.Bool("nomad.plan_partial", result.RefreshIndex != 0),
+	}
+}
+
+// evalQueuedSince returns the time eval has waited in the eval broker since,
+// which is the time it was last written.
+func evalQueuedSince(eval *structs.Evaluation) time.Time {
+	if eval.ModifyTime == 0 {
+		return time.Now()
+	}
+	return time.Unix(0, eval.ModifyTime)
+}
+
+// endSpan ends span, recording err as its status if it is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
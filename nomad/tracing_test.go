@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_JobRegister(t *testing.T) {
+	ci.Parallel(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 1
+		c.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	must.NoError(t, s1.State().UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			InternalRpcInfo: structs.InternalRpcInfo{
+				TraceParent: traceParent,
+			},
+		},
+	}
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// spans are exported as they end, and the plan is applied last
+	spanNames := []string{"Job.Register", "EvalBroker.Dequeue", "Scheduler.Process", "Plan.Submit", "Plan.Apply"}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			found := map[string]bool{}
+			for _, span := range exporter.GetSpans() {
+				found[span.Name] = true
+			}
+			for _, name := range spanNames {
+				if !found[name] {
+					return false
+				}
+			}
+			return true
+		}),
+		wait.Timeout(10*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+
+	for _, span := range exporter.GetSpans() {
+		must.Eq(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String(),
+			must.Sprintf("expected span %s in trace of request", span.Name))
+
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, attr := range span.Attributes {
+			attrs[attr.Key] = attr.Value
+		}
+		must.Eq(t, job.ID, attrs["nomad.job_id"].AsString(),
+			must.Sprintf("expected span %s to identify job", span.Name))
+		must.Eq(t, resp.EvalID, attrs["nomad.eval_id"].AsString(),
+			must.Sprintf("expected span %s to identify eval", span.Name))
+
+		if span.Name == "Plan.Apply" {
+			must.Eq(t, 1, attrs["nomad.allocs_placed"].AsInt64())
+		}
+	}
+}
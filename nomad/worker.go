@@ -17,11 +17,13 @@ import (
 	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/tracing"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler"
 	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -118,6 +120,11 @@ type Worker struct {
 	failureBackoff time.Duration
 	evalToken      string
 
+	// traceParent is the W3C traceparent of the span of the scheduler
+	// processing the current evaluation, which the spans of submitting its
+	// plans are children of.
+	traceParent string
+
 	// snapshotIndex is the index of the snapshot in which the scheduler was
 	// first invoked. It is used to mark the SnapshotIndex of evaluations
 	// Created, Updated or Reblocked.
@@ -414,6 +421,11 @@ func (w *Worker) run(raftSyncLimit time.Duration) {
 			return
 		}
 
+		// Trace the time the evaluation waited in the eval broker
+		_, span := w.srv.startSpan(eval.TraceParent, "EvalBroker.Dequeue",
+			evalSpanAttributes(eval), trace.WithTimestamp(evalQueuedSince(eval)))
+		span.End()
+
 		// since dequeue takes time, we could have shutdown the server after
 		// getting an eval that needs to be nacked before we exit. Explicitly
 		// check the server whether to allow this eval to be processed.
@@ -608,13 +620,17 @@ func (w *Worker) snapshotMinIndex(waitIndex uint64, timeout time.Duration) (*sta
 }
 
 // invokeScheduler is used to invoke the business logic of the scheduler
-func (w *Worker) invokeScheduler(snap *state.StateSnapshot, eval *structs.Evaluation, token string) error {
+func (w *Worker) invokeScheduler(snap *state.StateSnapshot, eval *structs.Evaluation, token string) (err error) {
 	defer metrics.MeasureSince([]string{"nomad", "worker", "invoke_scheduler", eval.Type}, time.Now())
 	// Store the evaluation token
 	w.evalToken = token
 
+	// Trace the scheduler, and store the span for the plans it submits
+	ctx, span := w.srv.startSpan(eval.TraceParent, "Scheduler.Process", evalSpanAttributes(eval))
+	defer func() { endSpan(span, err) }()
+	w.traceParent = tracing.TraceParent(ctx)
+
 	// Store the snapshot's index
-	var err error
 	w.snapshotIndex, err = snap.LatestIndex()
 	if err != nil {
 		return fmt.Errorf("failed to determine snapshot's index: %v", err)
@@ -648,7 +664,7 @@ func (w *Worker) ServersMeetMinimumVersion(minVersion *version.Version, checkFai
 
 // SubmitPlan is used to submit a plan for consideration. This allows
 // the worker to act as the planner for the scheduler.
-func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, sstructs.State, error) {
+func (w *Worker) SubmitPlan(plan *structs.Plan) (_ *structs.PlanResult, _ sstructs.State, err error) {
 	// Check for a shutdown before plan submission. Checking server state rather than
 	// worker state to allow work in flight to complete before stopping.
 	if w.srv.IsShutdown() {
@@ -656,6 +672,12 @@ func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, sstructs.S
 	}
 	defer metrics.MeasureSince([]string{"nomad", "worker", "submit_plan"}, time.Now())
 
+	// Trace the submission as a child of the scheduler's span, and add the
+	// span to the plan so that the leader applying it joins the same trace
+	ctx, span := w.srv.startSpan(w.traceParent, "Plan.Submit", planSpanAttributes(plan))
+	defer func() { endSpan(span, err) }()
+	plan.TraceParent = tracing.TraceParent(ctx)
+
 	// Add the evaluation token to the plan
 	plan.EvalToken = w.evalToken
 
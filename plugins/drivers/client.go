@@ -201,9 +201,7 @@ func (d *driverPluginClient) handleWaitTask(ctx context.Context, id string, ch c
 	if err != nil {
 		result.Err = grpcutils.HandleReqCtxGrpcErr(err, ctx, d.doneCtx)
 	} else {
-		result.ExitCode = int(resp.Result.ExitCode)
-		result.Signal = int(resp.Result.Signal)
-		result.OOMKilled = resp.Result.OomKilled
+		result = *exitResultFromProto(resp.Result)
 		if len(resp.Err) > 0 {
 			result.Err = errors.New(resp.Err)
 		}
@@ -482,6 +482,17 @@ type ExitResult struct {
 	Signal    int
 	OOMKilled bool
 	Err       error
+
+	// CoreDumped is true if the signal which terminated the task produced a
+	// core dump.
+	CoreDumped bool
+
+	// MaxRSS is the maximum resident set size of the task in bytes, and
+	// UserCPUTime and SystemCPUTime the CPU time it spent in user and kernel
+	// mode. They are left zero by drivers which cannot obtain them.
+	MaxRSS        uint64
+	UserCPUTime   time.Duration
+	SystemCPUTime time.Duration
 }
 
 func (r *ExitResult) Successful() bool {
@@ -3112,10 +3112,19 @@ type ExitResult struct {
 	// Signal is set if a signal was sent to the task
 	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
 	// OomKilled is true if the task exited as a result of the OOM Killer
-	OomKilled            bool     `protobuf:"varint,3,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	OomKilled bool `protobuf:"varint,3,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	// CoreDumped is true if the signal which terminated the task produced a
+	// core dump
+	CoreDumped bool `protobuf:"varint,4,opt,name=core_dumped,json=coreDumped,proto3" json:"core_dumped,omitempty"`
+	// MaxRssBytes is the maximum resident set size of the task
+	MaxRssBytes uint64 `protobuf:"varint,5,opt,name=max_rss_bytes,json=maxRssBytes,proto3" json:"max_rss_bytes,omitempty"`
+	// UserCpuTime is the CPU time the task spent in user mode
+	UserCpuTime *duration.Duration `protobuf:"bytes,6,opt,name=user_cpu_time,json=userCpuTime,proto3" json:"user_cpu_time,omitempty"`
+	// SystemCpuTime is the CPU time the task spent in kernel mode
+	SystemCpuTime        *duration.Duration `protobuf:"bytes,7,opt,name=system_cpu_time,json=systemCpuTime,proto3" json:"system_cpu_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ExitResult) Reset()         { *m = ExitResult{} }
@@ -3164,6 +3173,34 @@ func (m *ExitResult) GetOomKilled() bool {
 	return false
 }
 
+func (m *ExitResult) GetCoreDumped() bool {
+	if m != nil {
+		return m.CoreDumped
+	}
+	return false
+}
+
+func (m *ExitResult) GetMaxRssBytes() uint64 {
+	if m != nil {
+		return m.MaxRssBytes
+	}
+	return 0
+}
+
+func (m *ExitResult) GetUserCpuTime() *duration.Duration {
+	if m != nil {
+		return m.UserCpuTime
+	}
+	return nil
+}
+
+func (m *ExitResult) GetSystemCpuTime() *duration.Duration {
+	if m != nil {
+		return m.SystemCpuTime
+	}
+	return nil
+}
+
 // TaskStatus includes information of a specific task
 type TaskStatus struct {
 	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -3758,254 +3795,257 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3938 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
-	0x76, 0x17, 0xf8, 0x4f, 0xe4, 0x23, 0x45, 0x41, 0x2d, 0xc9, 0xa6, 0x39, 0x9b, 0x8c, 0x07, 0x5b,
-	0x93, 0x52, 0x76, 0x67, 0xe8, 0x59, 0x6d, 0x32, 0x1e, 0x7b, 0x3d, 0xeb, 0xe1, 0x50, 0xb4, 0x45,
-	0x5b, 0xa2, 0x94, 0x26, 0x15, 0xaf, 0xe3, 0x64, 0x10, 0x08, 0x68, 0x53, 0xb0, 0x48, 0x00, 0x83,
-	0x06, 0x65, 0x69, 0x53, 0xa9, 0xa4, 0x36, 0x55, 0xa9, 0x4d, 0x55, 0x52, 0xc9, 0x65, 0xb2, 0x97,
-	0x9c, 0xb6, 0x2a, 0xa7, 0x54, 0xee, 0xa9, 0x4d, 0xed, 0x29, 0x87, 0x7c, 0x89, 0x1c, 0x92, 0x5b,
-	0xae, 0xf9, 0x04, 0xd9, 0xea, 0x3f, 0x00, 0x01, 0x92, 0x1e, 0x83, 0x94, 0x4f, 0xe4, 0x7b, 0xdd,
-	0xfd, 0xeb, 0x87, 0xf7, 0x5e, 0xbf, 0x7e, 0xdd, 0xfd, 0x40, 0xf3, 0x86, 0xe3, 0x81, 0xed, 0xd0,
-	0x3b, 0x96, 0x6f, 0x5f, 0x10, 0x9f, 0xde, 0xf1, 0x7c, 0x37, 0x70, 0x25, 0xd5, 0xe0, 0x04, 0xfa,
-	0xf0, 0xcc, 0xa0, 0x67, 0xb6, 0xe9, 0xfa, 0x5e, 0xc3, 0x71, 0x47, 0x86, 0xd5, 0x90, 0x63, 0x1a,
-	0x72, 0x8c, 0xe8, 0x56, 0xff, 0xed, 0x81, 0xeb, 0x0e, 0x86, 0x44, 0x20, 0x9c, 0x8e, 0x5f, 0xde,
-	0xb1, 0xc6, 0xbe, 0x11, 0xd8, 0xae, 0x23, 0xdb, 0xdf, 0x9f, 0x6e, 0x0f, 0xec, 0x11, 0xa1, 0x81,
-	0x31, 0xf2, 0x64, 0x87, 0x0f, 0x43, 0x59, 0xe8, 0x99, 0xe1, 0x13, 0xeb, 0xce, 0x99, 0x39, 0xa4,
-	0x1e, 0x31, 0xd9, 0xaf, 0xce, 0xfe, 0xc8, 0x6e, 0x1f, 0x4d, 0x75, 0xa3, 0x81, 0x3f, 0x36, 0x83,
-	0x50, 0x72, 0x23, 0x08, 0x7c, 0xfb, 0x74, 0x1c, 0x10, 0xd1, 0x5b, 0xbb, 0x05, 0x37, 0xfb, 0x06,
-	0x3d, 0x6f, 0xb9, 0xce, 0x4b, 0x7b, 0xd0, 0x33, 0xcf, 0xc8, 0xc8, 0xc0, 0xe4, 0xeb, 0x31, 0xa1,
-	0x81, 0xf6, 0xc7, 0x50, 0x9b, 0x6d, 0xa2, 0x9e, 0xeb, 0x50, 0x82, 0xbe, 0x80, 0x1c, 0x9b, 0xb2,
-	0xa6, 0xdc, 0x56, 0x76, 0xca, 0xbb, 0x1f, 0x35, 0xde, 0xa4, 0x02, 0x21, 0x43, 0x43, 0x8a, 0xda,
-	0xe8, 0x79, 0xc4, 0xc4, 0x7c, 0xa4, 0xb6, 0x0d, 0x9b, 0x2d, 0xc3, 0x33, 0x4e, 0xed, 0xa1, 0x1d,
-	0xd8, 0x84, 0x86, 0x93, 0x8e, 0x61, 0x2b, 0xc9, 0x96, 0x13, 0xfe, 0x09, 0x54, 0xcc, 0x18, 0x5f,
-	0x4e, 0x7c, 0xaf, 0x91, 0x4a, 0xf7, 0x8d, 0x3d, 0x4e, 0x25, 0x80, 0x13, 0x70, 0xda, 0x16, 0xa0,
-	0x47, 0xb6, 0x33, 0x20, 0xbe, 0xe7, 0xdb, 0x4e, 0x10, 0x0a, 0xf3, 0xeb, 0x2c, 0x6c, 0x26, 0xd8,
-	0x52, 0x98, 0x57, 0x00, 0x91, 0x1e, 0x99, 0x28, 0xd9, 0x9d, 0xf2, 0xee, 0x93, 0x94, 0xa2, 0xcc,
-	0xc1, 0x6b, 0x34, 0x23, 0xb0, 0xb6, 0x13, 0xf8, 0x57, 0x38, 0x86, 0x8e, 0xbe, 0x82, 0xc2, 0x19,
-	0x31, 0x86, 0xc1, 0x59, 0x2d, 0x73, 0x5b, 0xd9, 0xa9, 0xee, 0x3e, 0xba, 0xc6, 0x3c, 0xfb, 0x1c,
-	0xa8, 0x17, 0x18, 0x01, 0xc1, 0x12, 0x15, 0x7d, 0x0c, 0x48, 0xfc, 0xd3, 0x2d, 0x42, 0x4d, 0xdf,
-	0xf6, 0x98, 0x4b, 0xd6, 0xb2, 0xb7, 0x95, 0x9d, 0x12, 0xde, 0x10, 0x2d, 0x7b, 0x93, 0x86, 0xba,
-	0x07, 0xeb, 0x53, 0xd2, 0x22, 0x15, 0xb2, 0xe7, 0xe4, 0x8a, 0x5b, 0xa4, 0x84, 0xd9, 0x5f, 0xf4,
-	0x18, 0xf2, 0x17, 0xc6, 0x70, 0x4c, 0xb8, 0xc8, 0xe5, 0xdd, 0x1f, 0xbc, 0xcd, 0x3d, 0xa4, 0x8b,
-	0x4e, 0xf4, 0x80, 0xc5, 0xf8, 0xfb, 0x99, 0xcf, 0x14, 0xed, 0x1e, 0x94, 0x63, 0x72, 0xa3, 0x2a,
-	0xc0, 0x49, 0x77, 0xaf, 0xdd, 0x6f, 0xb7, 0xfa, 0xed, 0x3d, 0x75, 0x05, 0xad, 0x41, 0xe9, 0xa4,
-	0xbb, 0xdf, 0x6e, 0x1e, 0xf4, 0xf7, 0x9f, 0xab, 0x0a, 0x2a, 0xc3, 0x6a, 0x48, 0x64, 0xb4, 0x4b,
-	0x40, 0x98, 0x98, 0xee, 0x05, 0xf1, 0x99, 0x23, 0x4b, 0xab, 0xa2, 0x9b, 0xb0, 0x1a, 0x18, 0xf4,
-	0x5c, 0xb7, 0x2d, 0x29, 0x73, 0x81, 0x91, 0x1d, 0x0b, 0x75, 0xa0, 0x70, 0x66, 0x38, 0xd6, 0xf0,
-	0xed, 0x72, 0x27, 0x55, 0xcd, 0xc0, 0xf7, 0xf9, 0x40, 0x2c, 0x01, 0x98, 0x77, 0x27, 0x66, 0x16,
-	0x06, 0xd0, 0x9e, 0x83, 0xda, 0x0b, 0x0c, 0x3f, 0x88, 0x8b, 0xd3, 0x86, 0x1c, 0x9b, 0x5f, 0x7a,
-	0xf4, 0x22, 0x73, 0x8a, 0x95, 0x89, 0xf9, 0x70, 0xed, 0xff, 0x32, 0xb0, 0x11, 0xc3, 0x96, 0x9e,
-	0xfa, 0x0c, 0x0a, 0x3e, 0xa1, 0xe3, 0x61, 0xc0, 0xe1, 0xab, 0xbb, 0x0f, 0x53, 0xc2, 0xcf, 0x20,
-	0x35, 0x30, 0x87, 0xc1, 0x12, 0x0e, 0xed, 0x80, 0x2a, 0x46, 0xe8, 0xc4, 0xf7, 0x5d, 0x5f, 0x1f,
-	0xd1, 0x01, 0xd7, 0x5a, 0x09, 0x57, 0x05, 0xbf, 0xcd, 0xd8, 0x87, 0x74, 0x10, 0xd3, 0x6a, 0xf6,
-	0x9a, 0x5a, 0x45, 0x06, 0xa8, 0x0e, 0x09, 0x5e, 0xbb, 0xfe, 0xb9, 0xce, 0x54, 0xeb, 0xdb, 0x16,
-	0xa9, 0xe5, 0x38, 0xe8, 0xa7, 0x29, 0x41, 0xbb, 0x62, 0xf8, 0x91, 0x1c, 0x8d, 0xd7, 0x9d, 0x24,
-	0x43, 0xfb, 0x3e, 0x14, 0xc4, 0x97, 0x32, 0x4f, 0xea, 0x9d, 0xb4, 0x5a, 0xed, 0x5e, 0x4f, 0x5d,
-	0x41, 0x25, 0xc8, 0xe3, 0x76, 0x1f, 0x33, 0x0f, 0x2b, 0x41, 0xfe, 0x51, 0xb3, 0xdf, 0x3c, 0x50,
-	0x33, 0xda, 0xf7, 0x60, 0xfd, 0x99, 0x61, 0x07, 0x69, 0x9c, 0x4b, 0x73, 0x41, 0x9d, 0xf4, 0x95,
-	0xd6, 0xe9, 0x24, 0xac, 0x93, 0x5e, 0x35, 0xed, 0x4b, 0x3b, 0x98, 0xb2, 0x87, 0x0a, 0x59, 0xe2,
-	0xfb, 0xd2, 0x04, 0xec, 0xaf, 0xf6, 0x1a, 0xd6, 0x7b, 0x81, 0xeb, 0xa5, 0xf2, 0xfc, 0x1f, 0xc2,
-	0x2a, 0xdb, 0x6d, 0xdc, 0x71, 0x20, 0x5d, 0xff, 0x56, 0x43, 0xec, 0x46, 0x8d, 0x70, 0x37, 0x6a,
-	0xec, 0xc9, 0xdd, 0x0a, 0x87, 0x3d, 0xd1, 0x0d, 0x28, 0x50, 0x7b, 0xe0, 0x18, 0x43, 0x19, 0x2d,
-	0x24, 0xa5, 0x21, 0xe6, 0xe4, 0xe1, 0xc4, 0xd2, 0xf1, 0x5b, 0x80, 0xf6, 0x08, 0x0d, 0x7c, 0xf7,
-	0x2a, 0x95, 0x3c, 0x5b, 0x90, 0x7f, 0xe9, 0xfa, 0xa6, 0x58, 0x88, 0x45, 0x2c, 0x08, 0xb6, 0xa8,
-	0x12, 0x20, 0x12, 0xfb, 0x63, 0x40, 0x1d, 0x87, 0xed, 0x29, 0xe9, 0x0c, 0xf1, 0x0f, 0x19, 0xd8,
-	0x4c, 0xf4, 0x97, 0xc6, 0x58, 0x7e, 0x1d, 0xb2, 0xc0, 0x34, 0xa6, 0x62, 0x1d, 0xa2, 0x23, 0x28,
-	0x88, 0x1e, 0x52, 0x93, 0x77, 0x17, 0x00, 0x12, 0xdb, 0x94, 0x84, 0x93, 0x30, 0x73, 0x9d, 0x3e,
-	0xfb, 0x6e, 0x9d, 0xfe, 0x35, 0xa8, 0xe1, 0x77, 0xd0, 0xb7, 0xda, 0xe6, 0x09, 0x6c, 0x9a, 0xee,
-	0x70, 0x48, 0x4c, 0xe6, 0x0d, 0xba, 0xed, 0x04, 0xc4, 0xbf, 0x30, 0x86, 0x6f, 0xf7, 0x1b, 0x34,
-	0x19, 0xd5, 0x91, 0x83, 0xb4, 0x17, 0xb0, 0x11, 0x9b, 0x58, 0x1a, 0xe2, 0x11, 0xe4, 0x29, 0x63,
-	0x48, 0x4b, 0x7c, 0xb2, 0xa0, 0x25, 0x28, 0x16, 0xc3, 0xb5, 0x4d, 0x01, 0xde, 0xbe, 0x20, 0x4e,
-	0xf4, 0x59, 0xda, 0x1e, 0x6c, 0xf4, 0xb8, 0x9b, 0xa6, 0xf2, 0xc3, 0x89, 0x8b, 0x67, 0x12, 0x2e,
-	0xbe, 0x05, 0x28, 0x8e, 0x22, 0x1d, 0xf1, 0x0a, 0xd6, 0xdb, 0x97, 0xc4, 0x4c, 0x85, 0x5c, 0x83,
-	0x55, 0xd3, 0x1d, 0x8d, 0x0c, 0xc7, 0xaa, 0x65, 0x6e, 0x67, 0x77, 0x4a, 0x38, 0x24, 0xe3, 0x6b,
-	0x31, 0x9b, 0x76, 0x2d, 0x6a, 0x7f, 0xa7, 0x80, 0x3a, 0x99, 0x5b, 0x2a, 0x92, 0x49, 0x1f, 0x58,
-	0x0c, 0x88, 0xcd, 0x5d, 0xc1, 0x92, 0x92, 0xfc, 0x30, 0x5c, 0x08, 0x3e, 0xf1, 0xfd, 0x58, 0x38,
-	0xca, 0x5e, 0x33, 0x1c, 0x69, 0xfb, 0xf0, 0x9d, 0x50, 0x9c, 0x5e, 0xe0, 0x13, 0x63, 0x64, 0x3b,
-	0x83, 0xce, 0xd1, 0x91, 0x47, 0x84, 0xe0, 0x08, 0x41, 0xce, 0x32, 0x02, 0x43, 0x0a, 0xc6, 0xff,
-	0xb3, 0x45, 0x6f, 0x0e, 0x5d, 0x1a, 0x2d, 0x7a, 0x4e, 0x68, 0xff, 0x99, 0x85, 0xda, 0x0c, 0x54,
-	0xa8, 0xde, 0x17, 0x90, 0xa7, 0x24, 0x18, 0x7b, 0xd2, 0x55, 0xda, 0xa9, 0x05, 0x9e, 0x8f, 0xd7,
-	0xe8, 0x31, 0x30, 0x2c, 0x30, 0xd1, 0x00, 0x8a, 0x41, 0x70, 0xa5, 0x53, 0xfb, 0xa7, 0x61, 0x42,
-	0x70, 0x70, 0x5d, 0xfc, 0x3e, 0xf1, 0x47, 0xb6, 0x63, 0x0c, 0x7b, 0xf6, 0x4f, 0x09, 0x5e, 0x0d,
-	0x82, 0x2b, 0xf6, 0x07, 0x3d, 0x67, 0x0e, 0x6f, 0xd9, 0x8e, 0x54, 0x7b, 0x6b, 0xd9, 0x59, 0x62,
-	0x0a, 0xc6, 0x02, 0xb1, 0x7e, 0x00, 0x79, 0xfe, 0x4d, 0xcb, 0x38, 0xa2, 0x0a, 0xd9, 0x20, 0xb8,
-	0xe2, 0x42, 0x15, 0x31, 0xfb, 0x5b, 0x7f, 0x00, 0x95, 0xf8, 0x17, 0x30, 0x47, 0x3a, 0x23, 0xf6,
-	0xe0, 0x4c, 0x38, 0x58, 0x1e, 0x4b, 0x8a, 0x59, 0xf2, 0xb5, 0x6d, 0xc9, 0x94, 0x35, 0x8f, 0x05,
-	0xa1, 0xfd, 0x5b, 0x06, 0x6e, 0xcd, 0xd1, 0x8c, 0x74, 0xd6, 0x17, 0x09, 0x67, 0x7d, 0x47, 0x5a,
-	0x08, 0x3d, 0xfe, 0x45, 0xc2, 0xe3, 0xdf, 0x21, 0x38, 0x5b, 0x36, 0x37, 0xa0, 0x40, 0x2e, 0xed,
-	0x80, 0x58, 0x52, 0x55, 0x92, 0x8a, 0x2d, 0xa7, 0xdc, 0x75, 0x97, 0xd3, 0x21, 0x6c, 0xb5, 0x7c,
-	0x62, 0x04, 0x44, 0x86, 0xf2, 0xd0, 0xff, 0x6f, 0x41, 0xd1, 0x18, 0x0e, 0x5d, 0x73, 0x62, 0xd6,
-	0x55, 0x4e, 0x77, 0x2c, 0x54, 0x87, 0xe2, 0x99, 0x4b, 0x03, 0xc7, 0x18, 0x11, 0x19, 0xbc, 0x22,
-	0x5a, 0xfb, 0x46, 0x81, 0xed, 0x29, 0x3c, 0x69, 0x85, 0x53, 0xa8, 0xda, 0xd4, 0x1d, 0xf2, 0x0f,
-	0xd4, 0x63, 0x27, 0xbc, 0x1f, 0x2d, 0xb6, 0xd5, 0x74, 0x42, 0x0c, 0x7e, 0xe0, 0x5b, 0xb3, 0xe3,
-	0x24, 0xf7, 0x38, 0x3e, 0xb9, 0x25, 0x57, 0x7a, 0x48, 0x6a, 0xff, 0xa8, 0xc0, 0xb6, 0xdc, 0xe1,
-	0xd3, 0x7f, 0xe8, 0xac, 0xc8, 0x99, 0x77, 0x2d, 0xb2, 0x56, 0x83, 0x1b, 0xd3, 0x72, 0xc9, 0x98,
-	0xff, 0xdf, 0x79, 0x40, 0xb3, 0xa7, 0x4b, 0xf4, 0x01, 0x54, 0x28, 0x71, 0x2c, 0x5d, 0xec, 0x17,
-	0x62, 0x2b, 0x2b, 0xe2, 0x32, 0xe3, 0x89, 0x8d, 0x83, 0xb2, 0x10, 0x48, 0x2e, 0xa5, 0xb4, 0x45,
-	0xcc, 0xff, 0xa3, 0x33, 0xa8, 0xbc, 0xa4, 0x7a, 0x34, 0x37, 0x77, 0xa8, 0x6a, 0xea, 0xb0, 0x36,
-	0x2b, 0x47, 0xe3, 0x51, 0x2f, 0xfa, 0x2e, 0x5c, 0x7e, 0x49, 0x23, 0x02, 0xfd, 0x5c, 0x81, 0x9b,
-	0x61, 0x5a, 0x31, 0x51, 0xdf, 0xc8, 0xb5, 0x08, 0xad, 0xe5, 0x6e, 0x67, 0x77, 0xaa, 0xbb, 0xc7,
-	0xd7, 0xd0, 0xdf, 0x0c, 0xf3, 0xd0, 0xb5, 0x08, 0xde, 0x76, 0xe6, 0x70, 0x29, 0x6a, 0xc0, 0xe6,
-	0x68, 0x4c, 0x03, 0x5d, 0x78, 0x81, 0x2e, 0x3b, 0xd5, 0xf2, 0x5c, 0x2f, 0x1b, 0xac, 0x29, 0xe1,
-	0xab, 0xe8, 0x1c, 0xd6, 0x46, 0xee, 0xd8, 0x09, 0x74, 0x93, 0x9f, 0x7f, 0x68, 0xad, 0xb0, 0xd0,
-	0xc1, 0x78, 0x8e, 0x96, 0x0e, 0x19, 0x9c, 0x38, 0x4d, 0x51, 0x5c, 0x19, 0xc5, 0x28, 0xf4, 0x7b,
-	0x70, 0xc3, 0xb2, 0xa9, 0x71, 0x3a, 0x24, 0xfa, 0xd0, 0x1d, 0xe8, 0x93, 0x1c, 0xa6, 0x56, 0xe4,
-	0xf2, 0x6d, 0xc9, 0xd6, 0x03, 0x77, 0xd0, 0x8a, 0xda, 0xf8, 0xa8, 0x2b, 0xc7, 0x18, 0xd9, 0xa6,
-	0xce, 0x44, 0x1e, 0xba, 0x86, 0xa5, 0x8f, 0x29, 0xf1, 0x69, 0xad, 0x24, 0x47, 0x89, 0xd6, 0x67,
-	0xb2, 0xf1, 0x84, 0xb5, 0x69, 0xf7, 0xa1, 0x1c, 0xb3, 0x17, 0x2a, 0x42, 0xae, 0x7b, 0xd4, 0x6d,
-	0xab, 0x2b, 0x08, 0xa0, 0xd0, 0xda, 0xc7, 0x47, 0x47, 0x7d, 0x71, 0xfc, 0xe8, 0x1c, 0x36, 0x1f,
-	0xb7, 0xd5, 0x0c, 0x63, 0x9f, 0x74, 0xff, 0xb0, 0xdd, 0x39, 0x50, 0xb3, 0x5a, 0x1b, 0x2a, 0xf1,
-	0xaf, 0x40, 0x08, 0xaa, 0x27, 0xdd, 0xa7, 0xdd, 0xa3, 0x67, 0x5d, 0xfd, 0xf0, 0xe8, 0xa4, 0xdb,
-	0x67, 0x87, 0x98, 0x2a, 0x40, 0xb3, 0xfb, 0x7c, 0x42, 0xaf, 0x41, 0xa9, 0x7b, 0x14, 0x92, 0x4a,
-	0x3d, 0xa3, 0x2a, 0x4f, 0x72, 0xc5, 0x55, 0xb5, 0x88, 0x2b, 0x3e, 0x19, 0xb9, 0x01, 0xd1, 0xd9,
-	0x16, 0x41, 0xb5, 0xff, 0xc8, 0xc2, 0xd6, 0x3c, 0x23, 0x23, 0x0b, 0x72, 0xcc, 0x61, 0xe4, 0xd1,
-	0xf2, 0xdd, 0xfb, 0x0b, 0x47, 0x67, 0xeb, 0xc4, 0x33, 0xe4, 0x5e, 0x52, 0xc2, 0xfc, 0x3f, 0xd2,
-	0xa1, 0x30, 0x34, 0x4e, 0xc9, 0x90, 0xd6, 0xb2, 0xfc, 0xf2, 0xe5, 0xf1, 0x75, 0xe6, 0x3e, 0xe0,
-	0x48, 0xe2, 0xe6, 0x45, 0xc2, 0xa2, 0x3e, 0x94, 0x59, 0xb4, 0xa4, 0x42, 0x9d, 0x32, 0x80, 0xef,
-	0xa6, 0x9c, 0x65, 0x7f, 0x32, 0x12, 0xc7, 0x61, 0xea, 0xf7, 0xa0, 0x1c, 0x9b, 0x6c, 0xce, 0xc5,
-	0xc9, 0x56, 0xfc, 0xe2, 0xa4, 0x14, 0xbf, 0x05, 0x79, 0x38, 0x6b, 0x03, 0xa6, 0x23, 0xe6, 0x24,
-	0xfb, 0x47, 0xbd, 0xbe, 0x38, 0xa2, 0x3e, 0xc6, 0x47, 0x27, 0xc7, 0xaa, 0xc2, 0x98, 0xfd, 0x66,
-	0xef, 0xa9, 0x9a, 0x89, 0x7c, 0x28, 0xab, 0xb5, 0xa0, 0x1c, 0x93, 0x2b, 0xb1, 0x3d, 0x28, 0xc9,
-	0xed, 0x81, 0x05, 0x68, 0xc3, 0xb2, 0x7c, 0x42, 0xa9, 0x94, 0x23, 0x24, 0xb5, 0x17, 0x50, 0xda,
-	0xeb, 0xf6, 0x24, 0x44, 0x0d, 0x56, 0x29, 0xf1, 0xd9, 0x77, 0xf3, 0x2b, 0xb0, 0x12, 0x0e, 0x49,
-	0x06, 0x4e, 0x89, 0xe1, 0x9b, 0x67, 0x84, 0xca, 0xa4, 0x22, 0xa2, 0xd9, 0x28, 0x97, 0x5f, 0x25,
-	0x09, 0xdb, 0x95, 0x70, 0x48, 0x6a, 0xff, 0x5f, 0x04, 0x98, 0x5c, 0x6b, 0xa0, 0x2a, 0x64, 0xa2,
-	0x60, 0x9f, 0xb1, 0x2d, 0xe6, 0x07, 0xb1, 0xcd, 0x8c, 0xff, 0x47, 0xbb, 0xb0, 0x3d, 0xa2, 0x03,
-	0xcf, 0x30, 0xcf, 0x75, 0x79, 0x1b, 0x21, 0x62, 0x02, 0x0f, 0x9c, 0x15, 0xbc, 0x29, 0x1b, 0xe5,
-	0x92, 0x17, 0xb8, 0x07, 0x90, 0x25, 0xce, 0x05, 0x0f, 0x72, 0xe5, 0xdd, 0xfb, 0x0b, 0x5f, 0xb7,
-	0x34, 0xda, 0xce, 0x85, 0xf0, 0x15, 0x06, 0x83, 0x74, 0x00, 0x8b, 0x5c, 0xd8, 0x26, 0xd1, 0x19,
-	0x68, 0x9e, 0x83, 0x7e, 0xb1, 0x38, 0xe8, 0x1e, 0xc7, 0x88, 0xa0, 0x4b, 0x56, 0x48, 0xa3, 0x2e,
-	0x94, 0x7c, 0x42, 0xdd, 0xb1, 0x6f, 0x12, 0x11, 0xe9, 0xd2, 0x9f, 0x88, 0x70, 0x38, 0x0e, 0x4f,
-	0x20, 0xd0, 0x1e, 0x14, 0x78, 0x80, 0xa3, 0xb5, 0x55, 0x2e, 0xec, 0x47, 0x29, 0xc1, 0x78, 0x74,
-	0xc1, 0x72, 0x2c, 0x7a, 0x0c, 0xab, 0x42, 0x44, 0x5a, 0x2b, 0x72, 0x98, 0x8f, 0xd3, 0x46, 0x5f,
-	0x3e, 0x0a, 0x87, 0xa3, 0x99, 0x55, 0x59, 0x60, 0xe4, 0x71, 0xb1, 0x84, 0xf9, 0x7f, 0xf4, 0x1e,
-	0x94, 0xc4, 0x66, 0x6f, 0xd9, 0x7e, 0x0d, 0x84, 0x73, 0x72, 0xc6, 0x9e, 0xed, 0xa3, 0xf7, 0xa1,
-	0x2c, 0x92, 0x3a, 0x9d, 0x47, 0x85, 0x32, 0x6f, 0x06, 0xc1, 0x3a, 0x66, 0xb1, 0x41, 0x74, 0x20,
-	0xbe, 0x2f, 0x3a, 0x54, 0xa2, 0x0e, 0xc4, 0xf7, 0x79, 0x87, 0xdf, 0x81, 0x75, 0x9e, 0x0a, 0x0f,
-	0x7c, 0x77, 0xec, 0xe9, 0xdc, 0xa7, 0xd6, 0x78, 0xa7, 0x35, 0xc6, 0x7e, 0xcc, 0xb8, 0x5d, 0xe6,
-	0x5c, 0xb7, 0xa0, 0xf8, 0xca, 0x3d, 0x15, 0x1d, 0xaa, 0x62, 0x1d, 0xbc, 0x72, 0x4f, 0xc3, 0xa6,
-	0x28, 0x1d, 0x59, 0x4f, 0xa6, 0x23, 0x5f, 0xc3, 0x8d, 0xd9, 0x7d, 0x95, 0xa7, 0x25, 0xea, 0xf5,
-	0xd3, 0x92, 0x2d, 0x67, 0x5e, 0x1c, 0xfe, 0x12, 0xb2, 0x96, 0x43, 0x6b, 0x1b, 0x0b, 0x39, 0x47,
-	0xb4, 0x8e, 0x31, 0x1b, 0x8c, 0xb6, 0xa1, 0xc0, 0x3e, 0xd6, 0xb6, 0x6a, 0x48, 0x84, 0x9e, 0x57,
-	0xee, 0x69, 0xc7, 0x42, 0xdf, 0x81, 0x12, 0xfb, 0x7e, 0xea, 0x19, 0x26, 0xa9, 0x6d, 0xf2, 0x96,
-	0x09, 0x83, 0x19, 0xca, 0x71, 0x2d, 0x22, 0x54, 0xb4, 0x25, 0x0c, 0xc5, 0x18, 0x5c, 0x47, 0x37,
-	0x61, 0x95, 0x37, 0xda, 0x56, 0x6d, 0x5b, 0x9c, 0x38, 0x18, 0xd9, 0xb1, 0x90, 0x06, 0x6b, 0x9e,
-	0xe1, 0x13, 0x27, 0xd0, 0xe5, 0x8c, 0x37, 0x78, 0x73, 0x59, 0x30, 0x9f, 0xb0, 0x79, 0xeb, 0x9f,
-	0x42, 0x31, 0x5c, 0x0c, 0x8b, 0x84, 0xc9, 0xfa, 0x03, 0xa8, 0x26, 0x97, 0xd2, 0x42, 0x41, 0xf6,
-	0x9f, 0x33, 0x50, 0x8a, 0x16, 0x0d, 0x72, 0x60, 0x93, 0x1b, 0x95, 0xa5, 0xa6, 0xfa, 0x64, 0x0d,
-	0x8a, 0x84, 0xf8, 0xf3, 0x94, 0x6a, 0x6e, 0x86, 0x08, 0xf2, 0x64, 0x2e, 0x17, 0x24, 0x8a, 0x90,
-	0x27, 0xf3, 0x7d, 0x05, 0xeb, 0x43, 0xdb, 0x19, 0x5f, 0xc6, 0xe6, 0x12, 0x99, 0xec, 0xef, 0xa7,
-	0x9c, 0xeb, 0x80, 0x8d, 0x9e, 0xcc, 0x51, 0x1d, 0x26, 0x68, 0xb4, 0x0f, 0x79, 0xcf, 0xf5, 0x83,
-	0x70, 0xcf, 0x4c, 0xbb, 0x9b, 0x1d, 0xbb, 0x7e, 0x70, 0x68, 0x78, 0x1e, 0x3b, 0xac, 0x09, 0x00,
-	0xed, 0x9b, 0x0c, 0xdc, 0x98, 0xff, 0x61, 0xa8, 0x0b, 0x59, 0xd3, 0x1b, 0x4b, 0x25, 0x3d, 0x58,
-	0x54, 0x49, 0x2d, 0x6f, 0x3c, 0x91, 0x9f, 0x01, 0xa1, 0x67, 0x50, 0x18, 0x91, 0x91, 0xeb, 0x5f,
-	0x49, 0x5d, 0x3c, 0x5c, 0x14, 0xf2, 0x90, 0x8f, 0x9e, 0xa0, 0x4a, 0x38, 0x84, 0xa1, 0x28, 0x17,
-	0x13, 0x95, 0x61, 0x7b, 0xc1, 0xeb, 0xb4, 0x10, 0x12, 0x47, 0x38, 0xda, 0xa7, 0xb0, 0x3d, 0xf7,
-	0x53, 0xd0, 0x6f, 0x01, 0x98, 0xde, 0x58, 0xe7, 0xcf, 0x1d, 0xc2, 0x83, 0xb2, 0xb8, 0x64, 0x7a,
-	0xe3, 0x1e, 0x67, 0x68, 0x2f, 0xa0, 0xf6, 0x26, 0x79, 0xd9, 0x1a, 0x13, 0x12, 0xeb, 0xa3, 0x53,
-	0xae, 0x83, 0x2c, 0x2e, 0x0a, 0xc6, 0xe1, 0x29, 0x5b, 0x4a, 0x61, 0xa3, 0x71, 0xc9, 0x3a, 0x64,
-	0x79, 0x87, 0xb2, 0xec, 0x60, 0x5c, 0x1e, 0x9e, 0x6a, 0xbf, 0xc8, 0xc0, 0xfa, 0x94, 0xc8, 0xec,
-	0xc8, 0x2a, 0x02, 0x70, 0x78, 0x19, 0x20, 0x28, 0x16, 0x8d, 0x4d, 0xdb, 0x0a, 0xaf, 0x91, 0xf9,
-	0x7f, 0xbe, 0x0f, 0x7b, 0xf2, 0x8a, 0x37, 0x63, 0x7b, 0x6c, 0xf9, 0x8c, 0x4e, 0xed, 0x80, 0xf2,
-	0xa4, 0x28, 0x8f, 0x05, 0x81, 0x9e, 0x43, 0xd5, 0x27, 0x7c, 0xff, 0xb7, 0x74, 0xe1, 0x65, 0xf9,
-	0x85, 0xbc, 0x4c, 0x4a, 0xc8, 0x9c, 0x0d, 0xaf, 0x85, 0x48, 0x8c, 0xa2, 0xe8, 0x19, 0xac, 0x85,
-	0xc9, 0xb4, 0x40, 0x2e, 0x2c, 0x8d, 0x5c, 0x91, 0x40, 0x1c, 0x58, 0xbb, 0x07, 0xe5, 0x58, 0x23,
-	0xfb, 0x30, 0x9e, 0xfd, 0x49, 0x9d, 0x08, 0x22, 0x19, 0x2d, 0xf2, 0x32, 0x5a, 0x68, 0xa7, 0x50,
-	0x8e, 0xad, 0x8b, 0x45, 0x86, 0x32, 0x7d, 0x06, 0x2e, 0xd7, 0x67, 0x1e, 0x67, 0x02, 0x97, 0xc5,
-	0x49, 0x96, 0x79, 0xe9, 0xb6, 0xc7, 0x35, 0x5a, 0xc2, 0x05, 0x46, 0x76, 0x3c, 0xed, 0x57, 0x19,
-	0xa8, 0x26, 0x97, 0x74, 0xe8, 0x47, 0x1e, 0xf1, 0x6d, 0xd7, 0x8a, 0xf9, 0xd1, 0x31, 0x67, 0x30,
-	0x5f, 0x61, 0xcd, 0x5f, 0x8f, 0xdd, 0xc0, 0x08, 0x7d, 0xc5, 0xf4, 0xc6, 0x7f, 0xc0, 0xe8, 0x29,
-	0x1f, 0xcc, 0x4e, 0xf9, 0x20, 0xfa, 0x08, 0x90, 0x74, 0xa5, 0xa1, 0x3d, 0xb2, 0x03, 0xfd, 0xf4,
-	0x2a, 0x20, 0xc2, 0xc6, 0x59, 0xac, 0x8a, 0x96, 0x03, 0xd6, 0xf0, 0x25, 0xe3, 0x33, 0xc7, 0x73,
-	0xdd, 0x91, 0x4e, 0x4d, 0xd7, 0x27, 0xba, 0x61, 0xbd, 0xe2, 0xa7, 0xb5, 0x2c, 0x2e, 0xbb, 0xee,
-	0xa8, 0xc7, 0x78, 0x4d, 0xeb, 0x15, 0xdb, 0x88, 0x4d, 0x6f, 0x4c, 0x49, 0xa0, 0xb3, 0x1f, 0x9e,
-	0xbb, 0x94, 0x30, 0x08, 0x56, 0xcb, 0x1b, 0x53, 0xf4, 0x5d, 0x58, 0x0b, 0x3b, 0xf0, 0xbd, 0x58,
-	0x26, 0x01, 0x15, 0xd9, 0x85, 0xf3, 0x90, 0x06, 0x95, 0x63, 0xe2, 0x9b, 0xc4, 0x09, 0xfa, 0xb6,
-	0x79, 0x4e, 0xf9, 0xb1, 0x4b, 0xc1, 0x09, 0x9e, 0x3c, 0xb5, 0x84, 0xb3, 0x8d, 0xc8, 0x88, 0x6a,
-	0xff, 0xaa, 0x40, 0x9e, 0xa7, 0x2c, 0x4c, 0x29, 0x7c, 0xbb, 0xe7, 0xd9, 0x80, 0x4c, 0x75, 0x19,
-	0x83, 0xe7, 0x02, 0xef, 0x41, 0x89, 0x2b, 0x3f, 0x76, 0xc2, 0xe0, 0x79, 0x30, 0x6f, 0xac, 0x43,
-	0xd1, 0x27, 0x86, 0xe5, 0x3a, 0xc3, 0xf0, 0x16, 0x2c, 0xa2, 0xd1, 0xef, 0x82, 0xea, 0xf9, 0xae,
-	0x67, 0x0c, 0x26, 0x07, 0x67, 0x69, 0xbe, 0xf5, 0x18, 0x9f, 0xa7, 0xe8, 0xdf, 0x85, 0x35, 0x4a,
-	0x44, 0x64, 0x17, 0x4e, 0x92, 0x17, 0x9f, 0x29, 0x99, 0xfc, 0x44, 0xa0, 0x7d, 0x0d, 0x05, 0xb1,
-	0x71, 0x5d, 0x43, 0xde, 0x8f, 0x01, 0x09, 0x45, 0x32, 0x07, 0x19, 0xd9, 0x94, 0xca, 0x2c, 0x9b,
-	0x3f, 0xe5, 0x8a, 0x96, 0xe3, 0x49, 0x83, 0xf6, 0x5f, 0x8a, 0xc8, 0xb7, 0xc5, 0x23, 0x1b, 0x4b,
-	0xcc, 0xd9, 0xaa, 0x61, 0x47, 0x5b, 0x71, 0x9b, 0x17, 0x92, 0xa8, 0x03, 0x05, 0x99, 0x56, 0x67,
-	0x96, 0x7d, 0xa3, 0x94, 0x00, 0xe1, 0xdd, 0x3e, 0x91, 0x37, 0x1b, 0x8b, 0xde, 0xed, 0x13, 0x71,
-	0xb7, 0x4f, 0xd0, 0x07, 0x50, 0x91, 0x09, 0xbf, 0x80, 0xcb, 0xf1, 0x7c, 0xbf, 0x6c, 0x45, 0x0f,
-	0x28, 0x44, 0xfb, 0x5f, 0x25, 0x8a, 0x7b, 0xe1, 0x43, 0x07, 0xfa, 0x0a, 0x8a, 0x2c, 0x84, 0xe8,
-	0x23, 0xc3, 0x93, 0xcf, 0xf6, 0xad, 0xe5, 0xde, 0x50, 0xc2, 0x5d, 0x51, 0xa4, 0xeb, 0xab, 0x9e,
-	0xa0, 0x58, 0xfc, 0x64, 0x47, 0xa5, 0x30, 0x7e, 0xb2, 0xff, 0xe8, 0x43, 0xa8, 0x1a, 0xe3, 0xc0,
-	0xd5, 0x0d, 0xeb, 0x82, 0xf8, 0x81, 0x4d, 0x89, 0xf4, 0xa5, 0x35, 0xc6, 0x6d, 0x86, 0xcc, 0xfa,
-	0x7d, 0xa8, 0xc4, 0x31, 0xdf, 0x96, 0xb7, 0xe4, 0xe3, 0x79, 0xcb, 0x9f, 0x02, 0x4c, 0x2e, 0x0d,
-	0x99, 0x8f, 0x90, 0x4b, 0x3b, 0xd0, 0xcd, 0xf0, 0x6c, 0x9e, 0xc7, 0x45, 0xc6, 0x68, 0x31, 0x67,
-	0x4c, 0xbe, 0x68, 0xe4, 0xc3, 0x17, 0x0d, 0x16, 0x1d, 0xd8, 0x82, 0x3e, 0xb7, 0x87, 0xc3, 0xe8,
-	0x22, 0xb3, 0xe4, 0xba, 0xa3, 0xa7, 0x9c, 0xa1, 0xfd, 0x3a, 0x23, 0x7c, 0x45, 0xbc, 0x4d, 0xa5,
-	0x3a, 0x9b, 0xbd, 0x2b, 0x53, 0xdf, 0x03, 0xa0, 0x81, 0xe1, 0xb3, 0x24, 0xcc, 0x08, 0xaf, 0x52,
-	0xeb, 0x33, 0x4f, 0x22, 0xfd, 0xb0, 0x58, 0x06, 0x97, 0x64, 0xef, 0x66, 0x80, 0x3e, 0x87, 0x8a,
-	0xe9, 0x8e, 0xbc, 0x21, 0x91, 0x83, 0xf3, 0x6f, 0x1d, 0x5c, 0x8e, 0xfa, 0x37, 0x83, 0xd8, 0x05,
-	0x6e, 0xe1, 0xba, 0x17, 0xb8, 0xbf, 0x52, 0xc4, 0x13, 0x5b, 0xfc, 0x85, 0x0f, 0x0d, 0xe6, 0x94,
-	0x91, 0x3c, 0x5e, 0xf2, 0xb9, 0xf0, 0xdb, 0x6a, 0x48, 0xea, 0x9f, 0xa7, 0x29, 0xda, 0x78, 0x73,
-	0x5a, 0xfc, 0xef, 0x59, 0x28, 0x45, 0xaf, 0x6b, 0x33, 0xb6, 0xff, 0x0c, 0x4a, 0x51, 0xa5, 0x92,
-	0x0c, 0x10, 0xdf, 0x6a, 0x9e, 0xa8, 0x33, 0x7a, 0x09, 0xc8, 0x18, 0x0c, 0xa2, 0x74, 0x57, 0x1f,
-	0x53, 0x63, 0x10, 0xbe, 0x6d, 0x7e, 0xb6, 0x80, 0x1e, 0xc2, 0xfd, 0xf1, 0x84, 0x8d, 0xc7, 0xaa,
-	0x31, 0x18, 0x24, 0x38, 0xe8, 0xcf, 0x60, 0x3b, 0x39, 0x87, 0x7e, 0x7a, 0xa5, 0x7b, 0xb6, 0x25,
-	0xef, 0x00, 0xf6, 0x17, 0x7d, 0x60, 0x6c, 0x24, 0xe0, 0xbf, 0xbc, 0x3a, 0xb6, 0x2d, 0xa1, 0x73,
-	0xe4, 0xcf, 0x34, 0xd4, 0xff, 0x02, 0x6e, 0xbe, 0xa1, 0xfb, 0x1c, 0x1b, 0x74, 0x93, 0x85, 0x33,
-	0xcb, 0x2b, 0x21, 0x66, 0xbd, 0x5f, 0x2a, 0xe2, 0x1d, 0x34, 0xa9, 0x93, 0x66, 0x3c, 0x4f, 0xbf,
-	0x93, 0x72, 0x9e, 0xd6, 0xf1, 0x89, 0x80, 0xe7, 0xa9, 0xf9, 0x93, 0xa9, 0xd4, 0x3c, 0x6d, 0x42,
-	0x26, 0x32, 0x5c, 0x01, 0x24, 0x11, 0xb4, 0x7f, 0xc9, 0x42, 0x31, 0x44, 0xe7, 0x27, 0xf8, 0x2b,
-	0x1a, 0x90, 0x91, 0x1e, 0x5d, 0x2f, 0x2a, 0x18, 0x04, 0x8b, 0xef, 0xa8, 0xef, 0x41, 0x69, 0x4c,
-	0x89, 0x2f, 0x9a, 0x33, 0xbc, 0xb9, 0xc8, 0x18, 0xbc, 0xf1, 0x7d, 0x28, 0x07, 0x6e, 0x60, 0x0c,
-	0xf5, 0x80, 0xe7, 0x0b, 0x59, 0x31, 0x9a, 0xb3, 0x78, 0xb6, 0x80, 0xbe, 0x0f, 0x1b, 0xc1, 0x99,
-	0xef, 0x06, 0xc1, 0x90, 0xe5, 0xaa, 0x3c, 0x73, 0x12, 0x89, 0x4e, 0x0e, 0xab, 0x51, 0x83, 0xc8,
-	0xa8, 0x28, 0x8b, 0xde, 0x93, 0xce, 0xcc, 0x75, 0x79, 0x10, 0xc9, 0xe1, 0xb5, 0x88, 0xcb, 0x5c,
-	0x9b, 0x6d, 0x9e, 0x9e, 0xc8, 0x48, 0x78, 0xac, 0x50, 0x70, 0x48, 0x22, 0x1d, 0xd6, 0x47, 0xc4,
-	0xa0, 0x63, 0x9f, 0x58, 0xfa, 0x4b, 0x9b, 0x0c, 0x2d, 0x71, 0xf1, 0x52, 0x4d, 0x7d, 0xdc, 0x08,
-	0xd5, 0xd2, 0x78, 0xc4, 0x47, 0xe3, 0x6a, 0x08, 0x27, 0x68, 0x96, 0x39, 0x88, 0x7f, 0x68, 0x1d,
-	0xca, 0xbd, 0xe7, 0xbd, 0x7e, 0xfb, 0x50, 0x3f, 0x3c, 0xda, 0x6b, 0xcb, 0xda, 0xa8, 0x5e, 0x1b,
-	0x0b, 0x52, 0x61, 0xed, 0xfd, 0xa3, 0x7e, 0xf3, 0x40, 0xef, 0x77, 0x5a, 0x4f, 0x7b, 0x6a, 0x06,
-	0x6d, 0xc3, 0x46, 0x7f, 0x1f, 0x1f, 0xf5, 0xfb, 0x07, 0xed, 0x3d, 0xfd, 0xb8, 0x8d, 0x3b, 0x47,
-	0x7b, 0x3d, 0x35, 0x8b, 0x10, 0x54, 0x27, 0xec, 0x7e, 0xe7, 0xb0, 0xad, 0xe6, 0x50, 0x19, 0x56,
-	0x8f, 0xdb, 0xb8, 0xd5, 0xee, 0xf6, 0xd5, 0xbc, 0xf6, 0x8b, 0x2c, 0x94, 0x63, 0x56, 0x64, 0x8e,
-	0xec, 0x53, 0x71, 0xae, 0xc9, 0x61, 0xf6, 0x97, 0xbf, 0xe5, 0x1a, 0xe6, 0x99, 0xb0, 0x4e, 0x0e,
-	0x0b, 0x82, 0x9f, 0x65, 0x8c, 0xcb, 0xd8, 0x3a, 0xcf, 0xe1, 0xe2, 0xc8, 0xb8, 0x14, 0x20, 0x1f,
-	0x40, 0xe5, 0x9c, 0xf8, 0x0e, 0x19, 0xca, 0x76, 0x61, 0x91, 0xb2, 0xe0, 0x89, 0x2e, 0x3b, 0xa0,
-	0xca, 0x2e, 0x13, 0x18, 0x61, 0x8e, 0xaa, 0xe0, 0x1f, 0x86, 0x60, 0x5b, 0x90, 0x17, 0xcd, 0xab,
-	0x62, 0x7e, 0x4e, 0xb0, 0x6d, 0x8a, 0xbe, 0x36, 0x3c, 0x9e, 0x43, 0xe6, 0x30, 0xff, 0x8f, 0x4e,
-	0x67, 0xed, 0x53, 0xe0, 0xf6, 0xb9, 0xb7, 0xb8, 0x3b, 0xbf, 0xc9, 0x44, 0x67, 0x91, 0x89, 0x56,
-	0x21, 0x8b, 0xc3, 0x82, 0xa2, 0x56, 0xb3, 0xb5, 0xcf, 0xcc, 0xb2, 0x06, 0xa5, 0xc3, 0xe6, 0x4f,
-	0xf4, 0x93, 0x9e, 0xb8, 0xd5, 0x57, 0xa1, 0xf2, 0xb4, 0x8d, 0xbb, 0xed, 0x03, 0xc9, 0xc9, 0xa2,
-	0x2d, 0x50, 0x25, 0x67, 0xd2, 0x2f, 0xc7, 0x10, 0xc4, 0xdf, 0x3c, 0x2a, 0x42, 0xae, 0xf7, 0xac,
-	0x79, 0xac, 0x16, 0xb4, 0xff, 0xc9, 0xc0, 0xba, 0xd8, 0x16, 0xa2, 0xd2, 0x87, 0x37, 0x3f, 0xfd,
-	0xc6, 0x6f, 0xb1, 0x32, 0xc9, 0x5b, 0xac, 0x30, 0x09, 0xe5, 0xbb, 0x7a, 0x76, 0x92, 0x84, 0xf2,
-	0x9b, 0x9d, 0x44, 0xc4, 0xcf, 0x2d, 0x12, 0xf1, 0x6b, 0xb0, 0x3a, 0x22, 0x34, 0xb2, 0x5b, 0x09,
-	0x87, 0x24, 0xb2, 0xa1, 0x6c, 0x38, 0x8e, 0x1b, 0x18, 0xe2, 0x6a, 0xb8, 0xb0, 0xd0, 0x66, 0x38,
-	0xf5, 0xc5, 0x8d, 0xe6, 0x04, 0x49, 0x04, 0xe6, 0x38, 0x76, 0xfd, 0xc7, 0xa0, 0x4e, 0x77, 0x58,
-	0x64, 0x3b, 0xfc, 0xde, 0x0f, 0x26, 0xbb, 0x21, 0x61, 0xeb, 0x42, 0xbe, 0xb3, 0xa8, 0x2b, 0x8c,
-	0xc0, 0x27, 0xdd, 0x6e, 0xa7, 0xfb, 0x58, 0x55, 0x10, 0x40, 0xa1, 0xfd, 0x93, 0x4e, 0xbf, 0xbd,
-	0xa7, 0x66, 0x76, 0x7f, 0xb9, 0x01, 0x05, 0x21, 0x24, 0xfa, 0x46, 0x66, 0x02, 0xf1, 0xb2, 0x5a,
-	0xf4, 0xe3, 0x85, 0x33, 0xea, 0x44, 0xa9, 0x6e, 0xfd, 0xe1, 0xd2, 0xe3, 0xe5, 0x33, 0xe6, 0x0a,
-	0xfa, 0x1b, 0x05, 0x2a, 0x89, 0x27, 0xcc, 0xb4, 0x57, 0xe3, 0x73, 0xaa, 0x78, 0xeb, 0x3f, 0x5a,
-	0x6a, 0x6c, 0x24, 0xcb, 0xcf, 0x15, 0x28, 0xc7, 0xea, 0x57, 0xd1, 0xbd, 0x65, 0x6a, 0x5e, 0x85,
-	0x24, 0xf7, 0x97, 0x2f, 0x97, 0xd5, 0x56, 0x3e, 0x51, 0xd0, 0x5f, 0x2b, 0x50, 0x8e, 0x55, 0x72,
-	0xa6, 0x16, 0x65, 0xb6, 0xee, 0x34, 0xb5, 0x28, 0xf3, 0x0a, 0x47, 0x57, 0xd0, 0x5f, 0x2a, 0x50,
-	0x8a, 0xaa, 0x32, 0xd1, 0xdd, 0xc5, 0xeb, 0x38, 0x85, 0x10, 0x9f, 0x2d, 0x5b, 0x00, 0xaa, 0xad,
-	0xa0, 0x3f, 0x87, 0x62, 0x58, 0xc2, 0x88, 0xd2, 0xee, 0x5e, 0x53, 0xf5, 0x91, 0xf5, 0xbb, 0x0b,
-	0x8f, 0x8b, 0x4f, 0x1f, 0xd6, 0x15, 0xa6, 0x9e, 0x7e, 0xaa, 0x02, 0xb2, 0x7e, 0x77, 0xe1, 0x71,
-	0xd1, 0xf4, 0xcc, 0x13, 0x62, 0xe5, 0x87, 0xa9, 0x3d, 0x61, 0xb6, 0xee, 0x31, 0xb5, 0x27, 0xcc,
-	0xab, 0x76, 0x14, 0x82, 0xc4, 0x0a, 0x18, 0x53, 0x0b, 0x32, 0x5b, 0x24, 0x99, 0x5a, 0x90, 0x39,
-	0xf5, 0x92, 0xda, 0x0a, 0xfa, 0x99, 0x12, 0x3f, 0x17, 0xdc, 0x5d, 0xb8, 0x4e, 0x6f, 0x41, 0x97,
-	0x9c, 0xa9, 0x14, 0xe4, 0x0b, 0xf4, 0x67, 0xf2, 0x16, 0x43, 0x94, 0xf9, 0xa1, 0x45, 0xc0, 0x12,
-	0x95, 0x81, 0xf5, 0x4f, 0x97, 0xdb, 0x6c, 0xb8, 0x10, 0x7f, 0xa5, 0x00, 0x4c, 0x0a, 0x02, 0x53,
-	0x0b, 0x31, 0x53, 0x89, 0x58, 0xbf, 0xb7, 0xc4, 0xc8, 0xf8, 0x02, 0x09, 0x0b, 0x96, 0x52, 0x2f,
-	0x90, 0xa9, 0x82, 0xc5, 0xd4, 0x0b, 0x64, 0xba, 0xd8, 0x50, 0x5b, 0x41, 0xff, 0xa4, 0xc0, 0xc6,
-	0x4c, 0xc1, 0x14, 0x7a, 0x78, 0xcd, 0x9a, 0xb9, 0xfa, 0x17, 0xcb, 0x03, 0x84, 0xa2, 0xed, 0x28,
-	0x9f, 0x28, 0xe8, 0x6f, 0x15, 0x58, 0x4b, 0x16, 0x92, 0xa4, 0xde, 0xa5, 0xe6, 0x94, 0x5e, 0xd5,
-	0x1f, 0x2c, 0x37, 0x38, 0xd2, 0xd6, 0xdf, 0x2b, 0x50, 0x4d, 0xd6, 0x14, 0xa1, 0x07, 0x8b, 0x85,
-	0x85, 0x29, 0x81, 0x3e, 0x5f, 0x72, 0x74, 0x28, 0xd1, 0x97, 0xab, 0x7f, 0x94, 0x17, 0xd9, 0x5b,
-	0x81, 0xff, 0xfc, 0xf0, 0x37, 0x01, 0x00, 0x00, 0xff, 0xff, 0x4f, 0xda, 0xef, 0xe8, 0xfd, 0x34,
-	0x00, 0x00,
+	// 3999 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x73, 0x1b, 0xc9,
+	0x75, 0xd7, 0xe0, 0x1b, 0x0f, 0x20, 0x38, 0x6c, 0x91, 0x12, 0x84, 0x75, 0xb2, 0xf2, 0xb8, 0x36,
+	0xa5, 0xd8, 0xbb, 0xd0, 0x9a, 0x4e, 0x56, 0x2b, 0x59, 0xb2, 0x16, 0x0b, 0x42, 0x22, 0x24, 0x12,
+	0x64, 0x1a, 0x60, 0x64, 0x45, 0xc9, 0x4e, 0x0d, 0x67, 0x5a, 0xe0, 0x88, 0x98, 0x8f, 0x9d, 0x1e,
+	0x50, 0xa4, 0x53, 0xa9, 0xa4, 0x9c, 0xaa, 0x94, 0x53, 0x95, 0x54, 0x72, 0xd9, 0xf8, 0x92, 0x93,
+	0xab, 0x52, 0x39, 0xa4, 0x72, 0x4f, 0x39, 0xe5, 0x53, 0x0e, 0xf9, 0x27, 0x72, 0x48, 0x6e, 0xb9,
+	0xe6, 0x2f, 0x88, 0xab, 0x3f, 0x66, 0x30, 0x43, 0x50, 0xe6, 0x00, 0xd4, 0x09, 0x78, 0xaf, 0xbb,
+	0x7f, 0xfd, 0xe6, 0xf5, 0xeb, 0xd7, 0xaf, 0x5f, 0x3f, 0xd0, 0xfc, 0xc9, 0x74, 0x6c, 0xbb, 0xf4,
+	0xae, 0x15, 0xd8, 0x27, 0x24, 0xa0, 0x77, 0xfd, 0xc0, 0x0b, 0x3d, 0x49, 0xb5, 0x39, 0x81, 0x3e,
+	0x3a, 0x32, 0xe8, 0x91, 0x6d, 0x7a, 0x81, 0xdf, 0x76, 0x3d, 0xc7, 0xb0, 0xda, 0x72, 0x4c, 0x5b,
+	0x8e, 0x11, 0xdd, 0x5a, 0xbf, 0x3d, 0xf6, 0xbc, 0xf1, 0x84, 0x08, 0x84, 0xc3, 0xe9, 0xeb, 0xbb,
+	0xd6, 0x34, 0x30, 0x42, 0xdb, 0x73, 0x65, 0xfb, 0x87, 0xe7, 0xdb, 0x43, 0xdb, 0x21, 0x34, 0x34,
+	0x1c, 0x5f, 0x76, 0xf8, 0x28, 0x92, 0x85, 0x1e, 0x19, 0x01, 0xb1, 0xee, 0x1e, 0x99, 0x13, 0xea,
+	0x13, 0x93, 0xfd, 0xea, 0xec, 0x8f, 0xec, 0xf6, 0xf1, 0xb9, 0x6e, 0x34, 0x0c, 0xa6, 0x66, 0x18,
+	0x49, 0x6e, 0x84, 0x61, 0x60, 0x1f, 0x4e, 0x43, 0x22, 0x7a, 0x6b, 0xb7, 0xe0, 0xe6, 0xc8, 0xa0,
+	0xc7, 0x5d, 0xcf, 0x7d, 0x6d, 0x8f, 0x87, 0xe6, 0x11, 0x71, 0x0c, 0x4c, 0xbe, 0x9e, 0x12, 0x1a,
+	0x6a, 0x7f, 0x0c, 0xcd, 0xf9, 0x26, 0xea, 0x7b, 0x2e, 0x25, 0xe8, 0x0b, 0x28, 0xb0, 0x29, 0x9b,
+	0xca, 0x6d, 0xe5, 0x4e, 0x6d, 0xf3, 0xe3, 0xf6, 0xbb, 0x54, 0x20, 0x64, 0x68, 0x4b, 0x51, 0xdb,
+	0x43, 0x9f, 0x98, 0x98, 0x8f, 0xd4, 0x36, 0xe0, 0x7a, 0xd7, 0xf0, 0x8d, 0x43, 0x7b, 0x62, 0x87,
+	0x36, 0xa1, 0xd1, 0xa4, 0x53, 0x58, 0x4f, 0xb3, 0xe5, 0x84, 0x7f, 0x02, 0x75, 0x33, 0xc1, 0x97,
+	0x13, 0xdf, 0x6f, 0x67, 0xd2, 0x7d, 0x7b, 0x8b, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xb6, 0x0e, 0xe8,
+	0x89, 0xed, 0x8e, 0x49, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xc2, 0xfc, 0x2a, 0x0f, 0xd7, 0x53, 0x6c,
+	0x29, 0xcc, 0x1b, 0x80, 0x58, 0x8f, 0x4c, 0x94, 0xfc, 0x9d, 0xda, 0xe6, 0xb3, 0x8c, 0xa2, 0x5c,
+	0x80, 0xd7, 0xee, 0xc4, 0x60, 0x3d, 0x37, 0x0c, 0xce, 0x70, 0x02, 0x1d, 0x7d, 0x05, 0xa5, 0x23,
+	0x62, 0x4c, 0xc2, 0xa3, 0x66, 0xee, 0xb6, 0x72, 0xa7, 0xb1, 0xf9, 0xe4, 0x0a, 0xf3, 0x6c, 0x73,
+	0xa0, 0x61, 0x68, 0x84, 0x04, 0x4b, 0x54, 0xf4, 0x09, 0x20, 0xf1, 0x4f, 0xb7, 0x08, 0x35, 0x03,
+	0xdb, 0x67, 0x26, 0xd9, 0xcc, 0xdf, 0x56, 0xee, 0x54, 0xf1, 0x9a, 0x68, 0xd9, 0x9a, 0x35, 0xb4,
+	0x7c, 0x58, 0x3d, 0x27, 0x2d, 0x52, 0x21, 0x7f, 0x4c, 0xce, 0xf8, 0x8a, 0x54, 0x31, 0xfb, 0x8b,
+	0x9e, 0x42, 0xf1, 0xc4, 0x98, 0x4c, 0x09, 0x17, 0xb9, 0xb6, 0xf9, 0xfd, 0xcb, 0xcc, 0x43, 0x9a,
+	0xe8, 0x4c, 0x0f, 0x58, 0x8c, 0x7f, 0x90, 0xfb, 0x5c, 0xd1, 0xee, 0x43, 0x2d, 0x21, 0x37, 0x6a,
+	0x00, 0x1c, 0x0c, 0xb6, 0x7a, 0xa3, 0x5e, 0x77, 0xd4, 0xdb, 0x52, 0xaf, 0xa1, 0x15, 0xa8, 0x1e,
+	0x0c, 0xb6, 0x7b, 0x9d, 0x9d, 0xd1, 0xf6, 0x4b, 0x55, 0x41, 0x35, 0x28, 0x47, 0x44, 0x4e, 0x3b,
+	0x05, 0x84, 0x89, 0xe9, 0x9d, 0x90, 0x80, 0x19, 0xb2, 0x5c, 0x55, 0x74, 0x13, 0xca, 0xa1, 0x41,
+	0x8f, 0x75, 0xdb, 0x92, 0x32, 0x97, 0x18, 0xd9, 0xb7, 0x50, 0x1f, 0x4a, 0x47, 0x86, 0x6b, 0x4d,
+	0x2e, 0x97, 0x3b, 0xad, 0x6a, 0x06, 0xbe, 0xcd, 0x07, 0x62, 0x09, 0xc0, 0xac, 0x3b, 0x35, 0xb3,
+	0x58, 0x00, 0xed, 0x25, 0xa8, 0xc3, 0xd0, 0x08, 0xc2, 0xa4, 0x38, 0x3d, 0x28, 0xb0, 0xf9, 0x9b,
+	0xca, 0xc2, 0x73, 0x8a, 0x9d, 0x89, 0xf9, 0x70, 0xed, 0xff, 0x72, 0xb0, 0x96, 0xc0, 0x96, 0x96,
+	0xfa, 0x02, 0x4a, 0x01, 0xa1, 0xd3, 0x49, 0xc8, 0xe1, 0x1b, 0x9b, 0x8f, 0x33, 0xc2, 0xcf, 0x21,
+	0xb5, 0x31, 0x87, 0xc1, 0x12, 0x0e, 0xdd, 0x01, 0x55, 0x8c, 0xd0, 0x49, 0x10, 0x78, 0x81, 0xee,
+	0xd0, 0x31, 0xd7, 0x5a, 0x15, 0x37, 0x04, 0xbf, 0xc7, 0xd8, 0xbb, 0x74, 0x9c, 0xd0, 0x6a, 0xfe,
+	0x8a, 0x5a, 0x45, 0x06, 0xa8, 0x2e, 0x09, 0xdf, 0x7a, 0xc1, 0xb1, 0xce, 0x54, 0x1b, 0xd8, 0x16,
+	0x69, 0x16, 0x38, 0xe8, 0x67, 0x19, 0x41, 0x07, 0x62, 0xf8, 0x9e, 0x1c, 0x8d, 0x57, 0xdd, 0x34,
+	0x43, 0xfb, 0x1e, 0x94, 0xc4, 0x97, 0x32, 0x4b, 0x1a, 0x1e, 0x74, 0xbb, 0xbd, 0xe1, 0x50, 0xbd,
+	0x86, 0xaa, 0x50, 0xc4, 0xbd, 0x11, 0x66, 0x16, 0x56, 0x85, 0xe2, 0x93, 0xce, 0xa8, 0xb3, 0xa3,
+	0xe6, 0xb4, 0xef, 0xc2, 0xea, 0x0b, 0xc3, 0x0e, 0xb3, 0x18, 0x97, 0xe6, 0x81, 0x3a, 0xeb, 0x2b,
+	0x57, 0xa7, 0x9f, 0x5a, 0x9d, 0xec, 0xaa, 0xe9, 0x9d, 0xda, 0xe1, 0xb9, 0xf5, 0x50, 0x21, 0x4f,
+	0x82, 0x40, 0x2e, 0x01, 0xfb, 0xab, 0xbd, 0x85, 0xd5, 0x61, 0xe8, 0xf9, 0x99, 0x2c, 0xff, 0x07,
+	0x50, 0x66, 0xa7, 0x8d, 0x37, 0x0d, 0xa5, 0xe9, 0xdf, 0x6a, 0x8b, 0xd3, 0xa8, 0x1d, 0x9d, 0x46,
+	0xed, 0x2d, 0x79, 0x5a, 0xe1, 0xa8, 0x27, 0xba, 0x01, 0x25, 0x6a, 0x8f, 0x5d, 0x63, 0x22, 0xbd,
+	0x85, 0xa4, 0x34, 0x04, 0xea, 0x6c, 0x62, 0x69, 0xf8, 0x5d, 0x40, 0x5b, 0x84, 0x86, 0x81, 0x77,
+	0x96, 0x49, 0x9e, 0x75, 0x28, 0xbe, 0xf6, 0x02, 0x53, 0x6c, 0xc4, 0x0a, 0x16, 0x04, 0xdb, 0x54,
+	0x29, 0x10, 0x89, 0xfd, 0x09, 0xa0, 0xbe, 0xcb, 0xce, 0x94, 0x6c, 0x0b, 0xf1, 0xf7, 0x39, 0xb8,
+	0x9e, 0xea, 0x2f, 0x17, 0x63, 0xf9, 0x7d, 0xc8, 0x1c, 0xd3, 0x94, 0x8a, 0x7d, 0x88, 0xf6, 0xa0,
+	0x24, 0x7a, 0x48, 0x4d, 0xde, 0x5b, 0x00, 0x48, 0x1c, 0x53, 0x12, 0x4e, 0xc2, 0x5c, 0x68, 0xf4,
+	0xf9, 0xf7, 0x6b, 0xf4, 0x6f, 0x41, 0x8d, 0xbe, 0x83, 0x5e, 0xba, 0x36, 0xcf, 0xe0, 0xba, 0xe9,
+	0x4d, 0x26, 0xc4, 0x64, 0xd6, 0xa0, 0xdb, 0x6e, 0x48, 0x82, 0x13, 0x63, 0x72, 0xb9, 0xdd, 0xa0,
+	0xd9, 0xa8, 0xbe, 0x1c, 0xa4, 0xbd, 0x82, 0xb5, 0xc4, 0xc4, 0x72, 0x21, 0x9e, 0x40, 0x91, 0x32,
+	0x86, 0x5c, 0x89, 0x4f, 0x17, 0x5c, 0x09, 0x8a, 0xc5, 0x70, 0xed, 0xba, 0x00, 0xef, 0x9d, 0x10,
+	0x37, 0xfe, 0x2c, 0x6d, 0x0b, 0xd6, 0x86, 0xdc, 0x4c, 0x33, 0xd9, 0xe1, 0xcc, 0xc4, 0x73, 0x29,
+	0x13, 0x5f, 0x07, 0x94, 0x44, 0x91, 0x86, 0x78, 0x06, 0xab, 0xbd, 0x53, 0x62, 0x66, 0x42, 0x6e,
+	0x42, 0xd9, 0xf4, 0x1c, 0xc7, 0x70, 0xad, 0x66, 0xee, 0x76, 0xfe, 0x4e, 0x15, 0x47, 0x64, 0x72,
+	0x2f, 0xe6, 0xb3, 0xee, 0x45, 0xed, 0x6f, 0x15, 0x50, 0x67, 0x73, 0x4b, 0x45, 0x32, 0xe9, 0x43,
+	0x8b, 0x01, 0xb1, 0xb9, 0xeb, 0x58, 0x52, 0x92, 0x1f, 0xb9, 0x0b, 0xc1, 0x27, 0x41, 0x90, 0x70,
+	0x47, 0xf9, 0x2b, 0xba, 0x23, 0x6d, 0x1b, 0xbe, 0x15, 0x89, 0x33, 0x0c, 0x03, 0x62, 0x38, 0xb6,
+	0x3b, 0xee, 0xef, 0xed, 0xf9, 0x44, 0x08, 0x8e, 0x10, 0x14, 0x2c, 0x23, 0x34, 0xa4, 0x60, 0xfc,
+	0x3f, 0xdb, 0xf4, 0xe6, 0xc4, 0xa3, 0xf1, 0xa6, 0xe7, 0x84, 0xf6, 0x9f, 0x79, 0x68, 0xce, 0x41,
+	0x45, 0xea, 0x7d, 0x05, 0x45, 0x4a, 0xc2, 0xa9, 0x2f, 0x4d, 0xa5, 0x97, 0x59, 0xe0, 0x8b, 0xf1,
+	0xda, 0x43, 0x06, 0x86, 0x05, 0x26, 0x1a, 0x43, 0x25, 0x0c, 0xcf, 0x74, 0x6a, 0xff, 0x24, 0x0a,
+	0x08, 0x76, 0xae, 0x8a, 0x3f, 0x22, 0x81, 0x63, 0xbb, 0xc6, 0x64, 0x68, 0xff, 0x84, 0xe0, 0x72,
+	0x18, 0x9e, 0xb1, 0x3f, 0xe8, 0x25, 0x33, 0x78, 0xcb, 0x76, 0xa5, 0xda, 0xbb, 0xcb, 0xce, 0x92,
+	0x50, 0x30, 0x16, 0x88, 0xad, 0x1d, 0x28, 0xf2, 0x6f, 0x5a, 0xc6, 0x10, 0x55, 0xc8, 0x87, 0xe1,
+	0x19, 0x17, 0xaa, 0x82, 0xd9, 0xdf, 0xd6, 0x43, 0xa8, 0x27, 0xbf, 0x80, 0x19, 0xd2, 0x11, 0xb1,
+	0xc7, 0x47, 0xc2, 0xc0, 0x8a, 0x58, 0x52, 0x6c, 0x25, 0xdf, 0xda, 0x96, 0x0c, 0x59, 0x8b, 0x58,
+	0x10, 0xda, 0xbf, 0xe5, 0xe0, 0xd6, 0x05, 0x9a, 0x91, 0xc6, 0xfa, 0x2a, 0x65, 0xac, 0xef, 0x49,
+	0x0b, 0x91, 0xc5, 0xbf, 0x4a, 0x59, 0xfc, 0x7b, 0x04, 0x67, 0xdb, 0xe6, 0x06, 0x94, 0xc8, 0xa9,
+	0x1d, 0x12, 0x4b, 0xaa, 0x4a, 0x52, 0x89, 0xed, 0x54, 0xb8, 0xea, 0x76, 0xda, 0x85, 0xf5, 0x6e,
+	0x40, 0x8c, 0x90, 0x48, 0x57, 0x1e, 0xd9, 0xff, 0x2d, 0xa8, 0x18, 0x93, 0x89, 0x67, 0xce, 0x96,
+	0xb5, 0xcc, 0xe9, 0xbe, 0x85, 0x5a, 0x50, 0x39, 0xf2, 0x68, 0xe8, 0x1a, 0x0e, 0x91, 0xce, 0x2b,
+	0xa6, 0xb5, 0x6f, 0x14, 0xd8, 0x38, 0x87, 0x27, 0x57, 0xe1, 0x10, 0x1a, 0x36, 0xf5, 0x26, 0xfc,
+	0x03, 0xf5, 0xc4, 0x0d, 0xef, 0x87, 0x8b, 0x1d, 0x35, 0xfd, 0x08, 0x83, 0x5f, 0xf8, 0x56, 0xec,
+	0x24, 0xc9, 0x2d, 0x8e, 0x4f, 0x6e, 0xc9, 0x9d, 0x1e, 0x91, 0xda, 0x3f, 0x28, 0xb0, 0x21, 0x4f,
+	0xf8, 0xec, 0x1f, 0x3a, 0x2f, 0x72, 0xee, 0x7d, 0x8b, 0xac, 0x35, 0xe1, 0xc6, 0x79, 0xb9, 0xa4,
+	0xcf, 0xff, 0xef, 0x22, 0xa0, 0xf9, 0xdb, 0x25, 0xfa, 0x36, 0xd4, 0x29, 0x71, 0x2d, 0x5d, 0x9c,
+	0x17, 0xe2, 0x28, 0xab, 0xe0, 0x1a, 0xe3, 0x89, 0x83, 0x83, 0x32, 0x17, 0x48, 0x4e, 0xa5, 0xb4,
+	0x15, 0xcc, 0xff, 0xa3, 0x23, 0xa8, 0xbf, 0xa6, 0x7a, 0x3c, 0x37, 0x37, 0xa8, 0x46, 0x66, 0xb7,
+	0x36, 0x2f, 0x47, 0xfb, 0xc9, 0x30, 0xfe, 0x2e, 0x5c, 0x7b, 0x4d, 0x63, 0x02, 0xfd, 0x4c, 0x81,
+	0x9b, 0x51, 0x58, 0x31, 0x53, 0x9f, 0xe3, 0x59, 0x84, 0x36, 0x0b, 0xb7, 0xf3, 0x77, 0x1a, 0x9b,
+	0xfb, 0x57, 0xd0, 0xdf, 0x1c, 0x73, 0xd7, 0xb3, 0x08, 0xde, 0x70, 0x2f, 0xe0, 0x52, 0xd4, 0x86,
+	0xeb, 0xce, 0x94, 0x86, 0xba, 0xb0, 0x02, 0x5d, 0x76, 0x6a, 0x16, 0xb9, 0x5e, 0xd6, 0x58, 0x53,
+	0xca, 0x56, 0xd1, 0x31, 0xac, 0x38, 0xde, 0xd4, 0x0d, 0x75, 0x93, 0xdf, 0x7f, 0x68, 0xb3, 0xb4,
+	0xd0, 0xc5, 0xf8, 0x02, 0x2d, 0xed, 0x32, 0x38, 0x71, 0x9b, 0xa2, 0xb8, 0xee, 0x24, 0x28, 0xf4,
+	0x7b, 0x70, 0xc3, 0xb2, 0xa9, 0x71, 0x38, 0x21, 0xfa, 0xc4, 0x1b, 0xeb, 0xb3, 0x18, 0xa6, 0x59,
+	0xe1, 0xf2, 0xad, 0xcb, 0xd6, 0x1d, 0x6f, 0xdc, 0x8d, 0xdb, 0xf8, 0xa8, 0x33, 0xd7, 0x70, 0x6c,
+	0x53, 0x67, 0x22, 0x4f, 0x3c, 0xc3, 0xd2, 0xa7, 0x94, 0x04, 0xb4, 0x59, 0x95, 0xa3, 0x44, 0xeb,
+	0x0b, 0xd9, 0x78, 0xc0, 0xda, 0xb4, 0x07, 0x50, 0x4b, 0xac, 0x17, 0xaa, 0x40, 0x61, 0xb0, 0x37,
+	0xe8, 0xa9, 0xd7, 0x10, 0x40, 0xa9, 0xbb, 0x8d, 0xf7, 0xf6, 0x46, 0xe2, 0xfa, 0xd1, 0xdf, 0xed,
+	0x3c, 0xed, 0xa9, 0x39, 0xc6, 0x3e, 0x18, 0xfc, 0x61, 0xaf, 0xbf, 0xa3, 0xe6, 0xb5, 0x1e, 0xd4,
+	0x93, 0x5f, 0x81, 0x10, 0x34, 0x0e, 0x06, 0xcf, 0x07, 0x7b, 0x2f, 0x06, 0xfa, 0xee, 0xde, 0xc1,
+	0x60, 0xc4, 0x2e, 0x31, 0x0d, 0x80, 0xce, 0xe0, 0xe5, 0x8c, 0x5e, 0x81, 0xea, 0x60, 0x2f, 0x22,
+	0x95, 0x56, 0x4e, 0x55, 0x9e, 0x15, 0x2a, 0x65, 0xb5, 0x82, 0xeb, 0x01, 0x71, 0xbc, 0x90, 0xe8,
+	0xec, 0x88, 0xa0, 0xda, 0x7f, 0xe4, 0x61, 0xfd, 0xa2, 0x45, 0x46, 0x16, 0x14, 0x98, 0xc1, 0xc8,
+	0xab, 0xe5, 0xfb, 0xb7, 0x17, 0x8e, 0xce, 0xf6, 0x89, 0x6f, 0xc8, 0xb3, 0xa4, 0x8a, 0xf9, 0x7f,
+	0xa4, 0x43, 0x69, 0x62, 0x1c, 0x92, 0x09, 0x6d, 0xe6, 0x79, 0xf2, 0xe5, 0xe9, 0x55, 0xe6, 0xde,
+	0xe1, 0x48, 0x22, 0xf3, 0x22, 0x61, 0xd1, 0x08, 0x6a, 0xcc, 0x5b, 0x52, 0xa1, 0x4e, 0xe9, 0xc0,
+	0x37, 0x33, 0xce, 0xb2, 0x3d, 0x1b, 0x89, 0x93, 0x30, 0xad, 0xfb, 0x50, 0x4b, 0x4c, 0x76, 0x41,
+	0xe2, 0x64, 0x3d, 0x99, 0x38, 0xa9, 0x26, 0xb3, 0x20, 0x8f, 0x61, 0xfd, 0x22, 0x1d, 0x31, 0x23,
+	0xd9, 0xde, 0x1b, 0x8e, 0xc4, 0x15, 0xf5, 0x29, 0xde, 0x3b, 0xd8, 0x57, 0x15, 0xc6, 0x1c, 0x75,
+	0x86, 0xcf, 0xd5, 0x5c, 0x6c, 0x43, 0x79, 0xad, 0x0b, 0xb5, 0x84, 0x5c, 0xa9, 0xe3, 0x41, 0x49,
+	0x1f, 0x0f, 0xcc, 0x41, 0x1b, 0x96, 0x15, 0x10, 0x4a, 0xa5, 0x1c, 0x11, 0xa9, 0xbd, 0x82, 0xea,
+	0xd6, 0x60, 0x28, 0x21, 0x9a, 0x50, 0xa6, 0x24, 0x60, 0xdf, 0xcd, 0x53, 0x60, 0x55, 0x1c, 0x91,
+	0x0c, 0x9c, 0x12, 0x23, 0x30, 0x8f, 0x08, 0x95, 0x41, 0x45, 0x4c, 0xb3, 0x51, 0x1e, 0x4f, 0x25,
+	0x89, 0xb5, 0xab, 0xe2, 0x88, 0xd4, 0xfe, 0xbf, 0x02, 0x30, 0x4b, 0x6b, 0xa0, 0x06, 0xe4, 0x62,
+	0x67, 0x9f, 0xb3, 0x2d, 0x66, 0x07, 0x89, 0xc3, 0x8c, 0xff, 0x47, 0x9b, 0xb0, 0xe1, 0xd0, 0xb1,
+	0x6f, 0x98, 0xc7, 0xba, 0xcc, 0x46, 0x08, 0x9f, 0xc0, 0x1d, 0x67, 0x1d, 0x5f, 0x97, 0x8d, 0x72,
+	0xcb, 0x0b, 0xdc, 0x1d, 0xc8, 0x13, 0xf7, 0x84, 0x3b, 0xb9, 0xda, 0xe6, 0x83, 0x85, 0xd3, 0x2d,
+	0xed, 0x9e, 0x7b, 0x22, 0x6c, 0x85, 0xc1, 0x20, 0x1d, 0xc0, 0x22, 0x27, 0xb6, 0x49, 0x74, 0x06,
+	0x5a, 0xe4, 0xa0, 0x5f, 0x2c, 0x0e, 0xba, 0xc5, 0x31, 0x62, 0xe8, 0xaa, 0x15, 0xd1, 0x68, 0x00,
+	0xd5, 0x80, 0x50, 0x6f, 0x1a, 0x98, 0x44, 0x78, 0xba, 0xec, 0x37, 0x22, 0x1c, 0x8d, 0xc3, 0x33,
+	0x08, 0xb4, 0x05, 0x25, 0xee, 0xe0, 0x68, 0xb3, 0x7c, 0x3b, 0xff, 0x1b, 0x73, 0xb7, 0x69, 0x30,
+	0xee, 0x5d, 0xb0, 0x1c, 0x8b, 0x9e, 0x42, 0x59, 0x88, 0x48, 0x9b, 0x15, 0x0e, 0xf3, 0x49, 0x56,
+	0xef, 0xcb, 0x47, 0xe1, 0x68, 0x34, 0x5b, 0x55, 0xe6, 0x18, 0xb9, 0x5f, 0xac, 0x62, 0xfe, 0x1f,
+	0x7d, 0x00, 0x55, 0x71, 0xd8, 0x5b, 0x76, 0xd0, 0x04, 0x61, 0x9c, 0x9c, 0xb1, 0x65, 0x07, 0xe8,
+	0x43, 0xa8, 0x89, 0xa0, 0x4e, 0xe7, 0x5e, 0xa1, 0xc6, 0x9b, 0x41, 0xb0, 0xf6, 0x99, 0x6f, 0x10,
+	0x1d, 0x48, 0x10, 0x88, 0x0e, 0xf5, 0xb8, 0x03, 0x09, 0x02, 0xde, 0xe1, 0x77, 0x60, 0x95, 0x87,
+	0xc2, 0xe3, 0xc0, 0x9b, 0xfa, 0x3a, 0xb7, 0xa9, 0x15, 0xde, 0x69, 0x85, 0xb1, 0x9f, 0x32, 0xee,
+	0x80, 0x19, 0xd7, 0x2d, 0xa8, 0xbc, 0xf1, 0x0e, 0x45, 0x87, 0x86, 0xd8, 0x07, 0x6f, 0xbc, 0xc3,
+	0xa8, 0x29, 0x0e, 0x47, 0x56, 0xd3, 0xe1, 0xc8, 0xd7, 0x70, 0x63, 0xfe, 0x5c, 0xe5, 0x61, 0x89,
+	0x7a, 0xf5, 0xb0, 0x64, 0xdd, 0xbd, 0x80, 0x8b, 0xbe, 0x84, 0xbc, 0xe5, 0xd2, 0xe6, 0xda, 0x42,
+	0xc6, 0x11, 0xef, 0x63, 0xcc, 0x06, 0xa3, 0x0d, 0x28, 0xb1, 0x8f, 0xb5, 0xad, 0x26, 0x12, 0xae,
+	0xe7, 0x8d, 0x77, 0xd8, 0xb7, 0xd0, 0xb7, 0xa0, 0xca, 0xbe, 0x9f, 0xfa, 0x86, 0x49, 0x9a, 0xd7,
+	0x79, 0xcb, 0x8c, 0xc1, 0x16, 0xca, 0xf5, 0x2c, 0x22, 0x54, 0xb4, 0x2e, 0x16, 0x8a, 0x31, 0xb8,
+	0x8e, 0x6e, 0x42, 0x99, 0x37, 0xda, 0x56, 0x73, 0x83, 0x37, 0x95, 0x18, 0xd9, 0xb7, 0x90, 0x06,
+	0x2b, 0xbe, 0x11, 0x10, 0x37, 0xd4, 0xe5, 0x8c, 0x37, 0x78, 0x73, 0x4d, 0x30, 0x9f, 0xb1, 0x79,
+	0x5b, 0x9f, 0x41, 0x25, 0xda, 0x0c, 0x8b, 0xb8, 0xc9, 0xd6, 0x43, 0x68, 0xa4, 0xb7, 0xd2, 0x42,
+	0x4e, 0xf6, 0x9f, 0x72, 0x50, 0x8d, 0x37, 0x0d, 0x72, 0xe1, 0x3a, 0x5f, 0x54, 0x23, 0x24, 0x96,
+	0x3e, 0xdb, 0x83, 0x22, 0x20, 0x7e, 0x94, 0x51, 0xcd, 0x9d, 0x08, 0x41, 0xde, 0xcc, 0xe5, 0x86,
+	0x44, 0x31, 0xf2, 0x6c, 0xbe, 0xaf, 0x60, 0x75, 0x62, 0xbb, 0xd3, 0xd3, 0xc4, 0x5c, 0x22, 0x92,
+	0xfd, 0xfd, 0x8c, 0x73, 0xed, 0xb0, 0xd1, 0xb3, 0x39, 0x1a, 0x93, 0x14, 0x8d, 0xb6, 0xa1, 0xe8,
+	0x7b, 0x41, 0x18, 0x9d, 0x99, 0x59, 0x4f, 0xb3, 0x7d, 0x2f, 0x08, 0x77, 0x0d, 0xdf, 0x67, 0x97,
+	0x35, 0x01, 0xa0, 0x7d, 0x93, 0x83, 0x1b, 0x17, 0x7f, 0x18, 0x1a, 0x40, 0xde, 0xf4, 0xa7, 0x52,
+	0x49, 0x0f, 0x17, 0x55, 0x52, 0xd7, 0x9f, 0xce, 0xe4, 0x67, 0x40, 0x2c, 0x81, 0xed, 0x10, 0xc7,
+	0x0b, 0xce, 0xa4, 0x2e, 0x1e, 0x2f, 0x0a, 0xb9, 0xcb, 0x47, 0xcf, 0x50, 0x25, 0x1c, 0xc2, 0x50,
+	0x91, 0x9b, 0x89, 0x4a, 0xb7, 0xbd, 0x60, 0x3a, 0x2d, 0x82, 0xc4, 0x31, 0x8e, 0xf6, 0x19, 0x6c,
+	0x5c, 0xf8, 0x29, 0xe8, 0xb7, 0x00, 0x4c, 0x7f, 0xaa, 0xf3, 0xe7, 0x0e, 0x61, 0x41, 0x79, 0x5c,
+	0x35, 0xfd, 0xe9, 0x90, 0x33, 0xb4, 0x57, 0xd0, 0x7c, 0x97, 0xbc, 0x6c, 0x8f, 0x09, 0x89, 0x75,
+	0xe7, 0x90, 0xeb, 0x20, 0x8f, 0x2b, 0x82, 0xb1, 0x7b, 0xc8, 0xb6, 0x52, 0xd4, 0x68, 0x9c, 0xb2,
+	0x0e, 0x79, 0xde, 0xa1, 0x26, 0x3b, 0x18, 0xa7, 0xbb, 0x87, 0xda, 0xcf, 0x73, 0xb0, 0x7a, 0x4e,
+	0x64, 0x76, 0x65, 0x15, 0x0e, 0x38, 0x4a, 0x06, 0x08, 0x8a, 0x79, 0x63, 0xd3, 0xb6, 0xa2, 0x34,
+	0x32, 0xff, 0xcf, 0xcf, 0x61, 0x5f, 0xa6, 0x78, 0x73, 0xb6, 0xcf, 0xb6, 0x8f, 0x73, 0x68, 0x87,
+	0x94, 0x07, 0x45, 0x45, 0x2c, 0x08, 0xf4, 0x12, 0x1a, 0x01, 0xe1, 0xe7, 0xbf, 0xa5, 0x0b, 0x2b,
+	0x2b, 0x2e, 0x64, 0x65, 0x52, 0x42, 0x66, 0x6c, 0x78, 0x25, 0x42, 0x62, 0x14, 0x45, 0x2f, 0x60,
+	0x25, 0x0a, 0xa6, 0x05, 0x72, 0x69, 0x69, 0xe4, 0xba, 0x04, 0xe2, 0xc0, 0xec, 0x65, 0x29, 0xd1,
+	0xc8, 0x3e, 0x8c, 0x47, 0x7f, 0x52, 0x27, 0x82, 0x48, 0x7b, 0x8b, 0xa2, 0xf4, 0x16, 0xda, 0x21,
+	0xd4, 0x12, 0xfb, 0x62, 0x91, 0xa1, 0x4c, 0x9f, 0xa1, 0xc7, 0xf5, 0x59, 0xc4, 0xb9, 0xd0, 0x63,
+	0x7e, 0x92, 0x45, 0x5e, 0xba, 0xed, 0x73, 0x8d, 0x56, 0x71, 0x89, 0x91, 0x7d, 0x5f, 0xfb, 0x65,
+	0x0e, 0x1a, 0xe9, 0x2d, 0x1d, 0xd9, 0x91, 0x4f, 0x02, 0xdb, 0xb3, 0x12, 0x76, 0xb4, 0xcf, 0x19,
+	0xcc, 0x56, 0x58, 0xf3, 0xd7, 0x53, 0x2f, 0x34, 0x22, 0x5b, 0x31, 0xfd, 0xe9, 0x1f, 0x30, 0xfa,
+	0x9c, 0x0d, 0xe6, 0xcf, 0xd9, 0x20, 0xfa, 0x18, 0x90, 0x34, 0xa5, 0x89, 0xed, 0xd8, 0xa1, 0x7e,
+	0x78, 0x16, 0x12, 0xb1, 0xc6, 0x79, 0xac, 0x8a, 0x96, 0x1d, 0xd6, 0xf0, 0x25, 0xe3, 0x33, 0xc3,
+	0xf3, 0x3c, 0x47, 0xa7, 0xa6, 0x17, 0x10, 0xdd, 0xb0, 0xde, 0xf0, 0xdb, 0x5a, 0x1e, 0xd7, 0x3c,
+	0xcf, 0x19, 0x32, 0x5e, 0xc7, 0x7a, 0xc3, 0x0e, 0x62, 0xd3, 0x9f, 0x52, 0x12, 0xea, 0xec, 0x87,
+	0xc7, 0x2e, 0x55, 0x0c, 0x82, 0xd5, 0xf5, 0xa7, 0x14, 0x7d, 0x07, 0x56, 0xa2, 0x0e, 0xfc, 0x2c,
+	0x96, 0x41, 0x40, 0x5d, 0x76, 0xe1, 0x3c, 0xa4, 0x41, 0x7d, 0x9f, 0x04, 0x26, 0x71, 0xc3, 0x91,
+	0x6d, 0x1e, 0x53, 0x7e, 0xed, 0x52, 0x70, 0x8a, 0x27, 0x6f, 0x2d, 0xd1, 0x6c, 0x0e, 0x71, 0xa8,
+	0xf6, 0xaf, 0x0a, 0x14, 0x79, 0xc8, 0xc2, 0x94, 0xc2, 0x8f, 0x7b, 0x1e, 0x0d, 0xc8, 0x50, 0x97,
+	0x31, 0x78, 0x2c, 0xf0, 0x01, 0x54, 0xb9, 0xf2, 0x13, 0x37, 0x0c, 0x1e, 0x07, 0xf3, 0xc6, 0x16,
+	0x54, 0x02, 0x62, 0x58, 0x9e, 0x3b, 0x89, 0xb2, 0x60, 0x31, 0x8d, 0x7e, 0x17, 0x54, 0x3f, 0xf0,
+	0x7c, 0x63, 0x3c, 0xbb, 0x38, 0xcb, 0xe5, 0x5b, 0x4d, 0xf0, 0x79, 0x88, 0xfe, 0x1d, 0x58, 0xa1,
+	0x44, 0x78, 0x76, 0x61, 0x24, 0x45, 0xf1, 0x99, 0x92, 0xc9, 0x6f, 0x04, 0xda, 0xd7, 0x50, 0x12,
+	0x07, 0xd7, 0x15, 0xe4, 0xfd, 0x04, 0x90, 0x50, 0x24, 0x33, 0x10, 0xc7, 0xa6, 0x54, 0x46, 0xd9,
+	0xfc, 0x29, 0x57, 0xb4, 0xec, 0xcf, 0x1a, 0xb4, 0xff, 0x52, 0x00, 0x66, 0x8f, 0x6c, 0x2c, 0x30,
+	0x67, 0xbb, 0x86, 0x5d, 0x6d, 0x45, 0x36, 0x2f, 0x22, 0x59, 0x22, 0x4b, 0x86, 0xd5, 0xb9, 0x65,
+	0xdf, 0x28, 0x25, 0x40, 0x94, 0xdb, 0x27, 0x32, 0xb3, 0xb1, 0x68, 0x6e, 0x9f, 0x88, 0xdc, 0x3e,
+	0x61, 0xf9, 0x15, 0x19, 0xf0, 0x0b, 0xb8, 0x02, 0x8f, 0xf7, 0x6b, 0x56, 0xfc, 0x80, 0x42, 0xb4,
+	0xff, 0x55, 0x62, 0xbf, 0x17, 0x3d, 0x74, 0xa0, 0xaf, 0xa0, 0xc2, 0x5c, 0x88, 0xee, 0x18, 0xbe,
+	0x7c, 0xb6, 0xef, 0x2e, 0xf7, 0x86, 0x12, 0x9d, 0x8a, 0x22, 0x5c, 0x2f, 0xfb, 0x82, 0x62, 0xfe,
+	0x93, 0x5d, 0x95, 0x22, 0xff, 0xc9, 0xfe, 0xa3, 0x8f, 0xa0, 0x61, 0x4c, 0x43, 0x4f, 0x37, 0xac,
+	0x13, 0x12, 0x84, 0x36, 0x25, 0xd2, 0x96, 0x56, 0x18, 0xb7, 0x13, 0x31, 0x5b, 0x0f, 0xa0, 0x9e,
+	0xc4, 0xbc, 0x2c, 0x6e, 0x29, 0x26, 0xe3, 0x96, 0x7f, 0xce, 0x01, 0xcc, 0xb2, 0x86, 0xcc, 0x48,
+	0x58, 0x0a, 0x52, 0x37, 0xa3, 0xcb, 0x79, 0x11, 0x57, 0x18, 0xa3, 0xcb, 0xac, 0x31, 0xfd, 0xa4,
+	0x51, 0x8c, 0x9e, 0x34, 0x98, 0x7b, 0x60, 0x3b, 0xfa, 0xd8, 0x9e, 0x4c, 0xe2, 0x4c, 0x66, 0xd5,
+	0xf3, 0x9c, 0xe7, 0x9c, 0xc1, 0x37, 0x33, 0xdb, 0xeb, 0xd6, 0xd4, 0xf1, 0x89, 0xc5, 0xf5, 0x5d,
+	0xc1, 0xc0, 0x58, 0x5b, 0x9c, 0xc3, 0x8f, 0x22, 0xe3, 0x54, 0x0f, 0x28, 0x95, 0xae, 0x83, 0x59,
+	0x79, 0x01, 0xd7, 0x1c, 0xe3, 0x14, 0x53, 0x2a, 0xbc, 0xc6, 0x23, 0x58, 0x99, 0x52, 0x76, 0x49,
+	0xf3, 0xa7, 0x3a, 0x7b, 0xb9, 0x68, 0x96, 0x2e, 0x7b, 0xe0, 0xa8, 0xb1, 0xfe, 0x5d, 0x7f, 0x3a,
+	0xb2, 0x1d, 0x82, 0x3a, 0xb0, 0x4a, 0xcf, 0x68, 0x48, 0x9c, 0x19, 0x40, 0xf9, 0x32, 0x80, 0x15,
+	0x31, 0x42, 0x42, 0x68, 0xbf, 0xca, 0x09, 0x9b, 0x17, 0x6f, 0x6c, 0x99, 0xee, 0x98, 0xef, 0xcb,
+	0x64, 0xef, 0x03, 0xd0, 0xd0, 0x08, 0x58, 0x30, 0x69, 0x44, 0x29, 0xe1, 0xd6, 0x9c, 0xe0, 0xa3,
+	0xa8, 0xe8, 0x07, 0x57, 0x65, 0xef, 0x4e, 0x88, 0x1e, 0x41, 0xdd, 0xf4, 0x1c, 0x7f, 0x42, 0xe4,
+	0xe0, 0xe2, 0xa5, 0x83, 0x6b, 0x71, 0xff, 0x4e, 0x98, 0x48, 0x44, 0x97, 0xae, 0x9a, 0x88, 0xfe,
+	0xa5, 0x22, 0x9e, 0x0a, 0x93, 0x2f, 0x95, 0x68, 0x7c, 0x41, 0x39, 0xcc, 0xd3, 0x25, 0x9f, 0x3d,
+	0x7f, 0x53, 0x2d, 0x4c, 0xeb, 0x51, 0x96, 0xe2, 0x93, 0x77, 0x87, 0xf7, 0xff, 0x9e, 0x87, 0x6a,
+	0xb4, 0x2c, 0xf3, 0x6b, 0xff, 0x39, 0x54, 0xe3, 0x8a, 0xab, 0x66, 0xee, 0x52, 0x0d, 0xcf, 0x3a,
+	0xa3, 0xd7, 0x80, 0x8c, 0xf1, 0x38, 0x0e, 0xdb, 0xf5, 0x29, 0x35, 0xc6, 0xd1, 0x1b, 0xed, 0xe7,
+	0x0b, 0xe8, 0x21, 0x3a, 0xe7, 0x0f, 0xd8, 0x78, 0xac, 0x1a, 0xe3, 0x71, 0x8a, 0x83, 0xfe, 0x14,
+	0x36, 0xd2, 0x73, 0xe8, 0x87, 0x67, 0xba, 0x6f, 0x5b, 0x32, 0x97, 0xb1, 0xbd, 0xe8, 0x43, 0x69,
+	0x3b, 0x05, 0xff, 0xe5, 0xd9, 0xbe, 0x6d, 0x09, 0x9d, 0xa3, 0x60, 0xae, 0xa1, 0xf5, 0xe7, 0x70,
+	0xf3, 0x1d, 0xdd, 0x2f, 0x58, 0x83, 0x41, 0xba, 0x00, 0x68, 0x79, 0x25, 0x24, 0x56, 0xef, 0x17,
+	0x0a, 0xac, 0xcd, 0x75, 0x40, 0x9d, 0xe4, 0x7d, 0xe3, 0x6e, 0xc6, 0x79, 0xba, 0xfb, 0x07, 0x02,
+	0x9e, 0x8d, 0x45, 0xcf, 0xce, 0x5d, 0x31, 0xb2, 0x06, 0x96, 0x22, 0x52, 0x17, 0x40, 0x12, 0x41,
+	0xfb, 0x97, 0x3c, 0x54, 0x22, 0x74, 0x9e, 0x89, 0x10, 0xfe, 0x2a, 0x4e, 0x93, 0x2a, 0x18, 0x04,
+	0x8b, 0x47, 0x06, 0x1f, 0x40, 0x95, 0xfb, 0x43, 0xde, 0x9c, 0xe3, 0xcd, 0x15, 0xc6, 0xe0, 0x8d,
+	0x1f, 0x42, 0x2d, 0xf4, 0x42, 0x63, 0xa2, 0x87, 0x3c, 0xee, 0xc9, 0x8b, 0xd1, 0x9c, 0xc5, 0xa3,
+	0x1e, 0xf4, 0x3d, 0x58, 0x0b, 0x8f, 0x02, 0x2f, 0x0c, 0x27, 0x2c, 0xe6, 0xe6, 0x11, 0xa0, 0x08,
+	0xd8, 0x0a, 0x58, 0x8d, 0x1b, 0x44, 0x64, 0x48, 0xd9, 0x29, 0x34, 0xeb, 0xcc, 0x5d, 0xa7, 0xf0,
+	0xcf, 0x2b, 0x31, 0x97, 0xbb, 0xd8, 0x26, 0x94, 0x7d, 0x11, 0x59, 0x71, 0x5f, 0xa1, 0xe0, 0x88,
+	0x44, 0x3a, 0xac, 0x3a, 0xc4, 0xa0, 0xd3, 0x80, 0x58, 0xfa, 0x6b, 0x9b, 0x4c, 0x2c, 0x91, 0x40,
+	0x6a, 0x64, 0xbe, 0x36, 0x45, 0x6a, 0x69, 0x3f, 0xe1, 0xa3, 0x71, 0x23, 0x82, 0x13, 0x34, 0x8b,
+	0x80, 0xc4, 0x3f, 0xb4, 0x0a, 0xb5, 0xe1, 0xcb, 0xe1, 0xa8, 0xb7, 0xab, 0xef, 0xee, 0x6d, 0xf5,
+	0x64, 0x8d, 0xd7, 0xb0, 0x87, 0x05, 0xa9, 0xb0, 0xf6, 0xd1, 0xde, 0xa8, 0xb3, 0xa3, 0x8f, 0xfa,
+	0xdd, 0xe7, 0x43, 0x35, 0x87, 0x36, 0x60, 0x6d, 0xb4, 0x8d, 0xf7, 0x46, 0xa3, 0x9d, 0xde, 0x96,
+	0xbe, 0xdf, 0xc3, 0xfd, 0xbd, 0xad, 0xa1, 0x9a, 0x67, 0x39, 0xf0, 0x19, 0x7b, 0xd4, 0xdf, 0xed,
+	0xa9, 0x05, 0x56, 0xd5, 0xb3, 0xdf, 0xc3, 0xdd, 0xde, 0x60, 0xa4, 0x16, 0xb5, 0x9f, 0xe7, 0xa1,
+	0x96, 0x58, 0x45, 0x66, 0xc8, 0x01, 0x15, 0xf7, 0xb3, 0x02, 0x66, 0x7f, 0xf9, 0x9b, 0xb4, 0x61,
+	0x1e, 0x89, 0xd5, 0x29, 0x60, 0x41, 0xf0, 0x3b, 0x99, 0x71, 0x9a, 0xd8, 0xe7, 0x05, 0x5c, 0x71,
+	0x8c, 0x53, 0x01, 0xf2, 0x6d, 0xa8, 0x1f, 0x93, 0xc0, 0x25, 0x13, 0xd9, 0x2e, 0x56, 0xa4, 0x26,
+	0x78, 0xa2, 0xcb, 0x1d, 0x50, 0x65, 0x97, 0x19, 0x8c, 0x58, 0x8e, 0x86, 0xe0, 0xef, 0x46, 0x60,
+	0xeb, 0x50, 0x14, 0xcd, 0x65, 0x31, 0x3f, 0x27, 0xd8, 0x31, 0x45, 0xdf, 0x1a, 0x3e, 0x8f, 0x85,
+	0x0b, 0x98, 0xff, 0x47, 0x87, 0xf3, 0xeb, 0x53, 0xe2, 0xeb, 0x73, 0x7f, 0x71, 0x73, 0x7e, 0xd7,
+	0x12, 0x1d, 0xc5, 0x4b, 0x54, 0x86, 0x3c, 0x8e, 0x0a, 0xa3, 0xba, 0x9d, 0xee, 0x36, 0x5b, 0x96,
+	0x15, 0xa8, 0xee, 0x76, 0x7e, 0xac, 0x1f, 0x0c, 0xc5, 0xeb, 0x84, 0x0a, 0xf5, 0xe7, 0x3d, 0x3c,
+	0xe8, 0xed, 0x48, 0x4e, 0x1e, 0xad, 0x83, 0x2a, 0x39, 0xb3, 0x7e, 0x05, 0x86, 0x20, 0xfe, 0x16,
+	0x59, 0xb6, 0x7a, 0xf8, 0xa2, 0xb3, 0xaf, 0x96, 0xb4, 0xff, 0xc9, 0xc1, 0xaa, 0x38, 0x16, 0xe2,
+	0x12, 0x8e, 0x77, 0x3f, 0x61, 0x27, 0xb3, 0x71, 0xb9, 0x74, 0x36, 0x2e, 0x0a, 0xa6, 0xf9, 0xa9,
+	0x9e, 0x9f, 0x05, 0xd3, 0x3c, 0x43, 0x95, 0xf2, 0xf8, 0x85, 0x45, 0x3c, 0x7e, 0x13, 0xca, 0x0e,
+	0xa1, 0xf1, 0xba, 0x55, 0x71, 0x44, 0x22, 0x1b, 0x6a, 0x86, 0xeb, 0x7a, 0xa1, 0x21, 0x52, 0xdc,
+	0xa5, 0x85, 0x0e, 0xc3, 0x73, 0x5f, 0xdc, 0xee, 0xcc, 0x90, 0x84, 0x63, 0x4e, 0x62, 0xb7, 0x7e,
+	0x04, 0xea, 0xf9, 0x0e, 0x8b, 0x1c, 0x87, 0xdf, 0xfd, 0xfe, 0xec, 0x34, 0x24, 0x6c, 0x5f, 0xc8,
+	0xf7, 0x22, 0xf5, 0x1a, 0x23, 0xf0, 0xc1, 0x60, 0xd0, 0x1f, 0x3c, 0x55, 0x15, 0xf6, 0xca, 0xd4,
+	0xfb, 0x71, 0x9f, 0x15, 0x5b, 0xe6, 0x36, 0x7f, 0xb1, 0x06, 0x25, 0x21, 0x24, 0xfa, 0x46, 0x46,
+	0x02, 0xc9, 0xf2, 0x60, 0xf4, 0xa3, 0x85, 0x6f, 0x06, 0xa9, 0x92, 0xe3, 0xd6, 0xe3, 0xa5, 0xc7,
+	0xcb, 0xe7, 0xd8, 0x6b, 0xe8, 0xaf, 0x15, 0xa8, 0xa7, 0x9e, 0x62, 0xb3, 0xa6, 0xf8, 0x2f, 0xa8,
+	0x46, 0x6e, 0xfd, 0x70, 0xa9, 0xb1, 0xb1, 0x2c, 0x3f, 0x53, 0xa0, 0x96, 0xa8, 0xc3, 0x45, 0xf7,
+	0x97, 0xa9, 0xdd, 0x15, 0x92, 0x3c, 0x58, 0xbe, 0xec, 0x57, 0xbb, 0xf6, 0xa9, 0x82, 0xfe, 0x4a,
+	0x81, 0x5a, 0xa2, 0x22, 0x35, 0xb3, 0x28, 0xf3, 0xf5, 0xb3, 0xad, 0x07, 0xcb, 0x0c, 0x8d, 0x75,
+	0xf2, 0x17, 0x0a, 0x54, 0xe3, 0xea, 0x52, 0x74, 0x6f, 0xf1, 0x7a, 0x54, 0x21, 0xc4, 0xe7, 0xcb,
+	0x16, 0xb2, 0x6a, 0xd7, 0xd0, 0x9f, 0x41, 0x25, 0x2a, 0xc5, 0x44, 0x59, 0x4f, 0xaf, 0x73, 0x75,
+	0x9e, 0xad, 0x7b, 0x0b, 0x8f, 0x4b, 0x4e, 0x1f, 0xd5, 0x47, 0x66, 0x9e, 0xfe, 0x5c, 0x25, 0x67,
+	0xeb, 0xde, 0xc2, 0xe3, 0xe2, 0xe9, 0x99, 0x25, 0x24, 0xca, 0x28, 0x33, 0x5b, 0xc2, 0x7c, 0xfd,
+	0x66, 0xeb, 0xc1, 0x32, 0x43, 0x53, 0x82, 0x24, 0x0a, 0x31, 0x33, 0x0b, 0x32, 0x5f, 0xec, 0xd9,
+	0x7a, 0xb0, 0xcc, 0xd0, 0x58, 0x90, 0x9f, 0x2a, 0xc9, 0x7b, 0xc1, 0xbd, 0x85, 0xeb, 0x0d, 0x17,
+	0x34, 0xc9, 0xb9, 0x8a, 0x47, 0xbe, 0x41, 0x7f, 0x2a, 0xb3, 0x31, 0xa2, 0x5c, 0x11, 0x2d, 0x02,
+	0x96, 0xaa, 0x70, 0x6c, 0x7d, 0xb6, 0xdc, 0x61, 0xc3, 0x85, 0xf8, 0x4b, 0x05, 0x60, 0x56, 0xd8,
+	0x98, 0x59, 0x88, 0xb9, 0x8a, 0xca, 0xd6, 0xfd, 0x25, 0x46, 0x26, 0x37, 0x48, 0x54, 0x78, 0x95,
+	0x79, 0x83, 0x9c, 0x2b, 0xbc, 0x6c, 0xdd, 0x5b, 0x78, 0x5c, 0x3c, 0xfd, 0x3f, 0x2a, 0xb0, 0x36,
+	0x57, 0xf8, 0x85, 0x1e, 0x5f, 0xb1, 0xf6, 0xaf, 0xf5, 0xc5, 0xf2, 0x00, 0x91, 0x68, 0x77, 0x94,
+	0x4f, 0x15, 0xf4, 0x37, 0x0a, 0xac, 0xa4, 0x0b, 0x62, 0x32, 0x9f, 0x52, 0x17, 0x94, 0x90, 0xb5,
+	0x1e, 0x2e, 0x37, 0x38, 0xd6, 0xd6, 0xdf, 0x29, 0xd0, 0x90, 0xfb, 0x3b, 0x92, 0xe7, 0xe1, 0x62,
+	0x6e, 0xe1, 0x9c, 0x40, 0x8f, 0x96, 0x1c, 0x1d, 0x49, 0xf4, 0x65, 0xf9, 0x8f, 0x8a, 0x22, 0x7a,
+	0x2b, 0xf1, 0x9f, 0x1f, 0xfc, 0x7a, 0x00, 0x91, 0x8e, 0x40, 0xcc, 0xc5, 0x35, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -173,12 +173,8 @@ func (b *driverPluginServer) WaitTask(ctx context.Context, req *proto.WaitTaskRe
 	}
 
 	resp := &proto.WaitTaskResponse{
-		Err: errStr,
-		Result: &proto.ExitResult{
-			ExitCode:  int32(result.ExitCode),
-			Signal:    int32(result.Signal),
-			OomKilled: result.OOMKilled,
-		},
+		Err:    errStr,
+		Result: exitResultToProto(result),
 	}
 
 	return resp, nil
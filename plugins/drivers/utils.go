@@ -376,17 +376,29 @@ func exitResultToProto(result *ExitResult) *proto.ExitResult {
 		return &proto.ExitResult{}
 	}
 	return &proto.ExitResult{
-		ExitCode:  int32(result.ExitCode),
-		Signal:    int32(result.Signal),
-		OomKilled: result.OOMKilled,
+		ExitCode:      int32(result.ExitCode),
+		Signal:        int32(result.Signal),
+		OomKilled:     result.OOMKilled,
+		CoreDumped:    result.CoreDumped,
+		MaxRssBytes:   result.MaxRSS,
+		UserCpuTime:   ptypes.DurationProto(result.UserCPUTime),
+		SystemCpuTime: ptypes.DurationProto(result.SystemCPUTime),
 	}
 }
 
 func exitResultFromProto(pb *proto.ExitResult) *ExitResult {
+	// drivers built before the CPU times were added leave them unset
+	userCPUTime, _ := ptypes.Duration(pb.UserCpuTime)
+	systemCPUTime, _ := ptypes.Duration(pb.SystemCpuTime)
+
 	return &ExitResult{
-		ExitCode:  int(pb.ExitCode),
-		Signal:    int(pb.Signal),
-		OOMKilled: pb.OomKilled,
+		ExitCode:      int(pb.ExitCode),
+		Signal:        int(pb.Signal),
+		OOMKilled:     pb.OomKilled,
+		CoreDumped:    pb.CoreDumped,
+		MaxRSS:        pb.MaxRssBytes,
+		UserCPUTime:   userCPUTime,
+		SystemCPUTime: systemCPUTime,
 	}
 }
 
@@ -5,6 +5,7 @@ package drivers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -38,6 +39,24 @@ func TestResourceUsageRoundTrip(t *testing.T) {
 	must.Eq(t, parsed, input)
 }
 
+func TestExitResultRoundTrip(t *testing.T) {
+	input := &ExitResult{
+		ExitCode:      134,
+		Signal:        6,
+		CoreDumped:    true,
+		MaxRSS:        25681920,
+		UserCPUTime:   1500 * time.Millisecond,
+		SystemCPUTime: 200 * time.Millisecond,
+	}
+
+	parsed := exitResultFromProto(exitResultToProto(input))
+	must.Eq(t, input, parsed)
+
+	// results of drivers which do not report the usage are left zero
+	parsed = exitResultFromProto(&proto.ExitResult{ExitCode: 1})
+	must.Eq(t, &ExitResult{ExitCode: 1}, parsed)
+}
+
 func TestTaskConfigRoundTrip(t *testing.T) {
 
 	input := &TaskConfig{
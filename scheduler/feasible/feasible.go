@@ -37,6 +37,7 @@ const (
 	FilterConstraintDevices                        = "missing devices"
 	FilterConstraintSecrets                        = "missing secrets provider"
 	FilterConstraintsCSIPluginTopology             = "did not meet topology requirement"
+	FilterConstraintUnparseableVersionTemplate     = "unparseable version for %s"
 )
 
 var (
@@ -971,13 +972,29 @@ func (c *ConstraintChecker) Feasible(option *structs.Node) bool {
 	// Use this node if possible
 	for _, constraint := range c.constraints {
 		if !c.meetsConstraint(constraint, option) {
-			c.ctx.Metrics().FilterNode(option, constraint.String())
+			c.ctx.Metrics().FilterNode(option, filterReason(constraint, option))
 			return false
 		}
 	}
 	return true
 }
 
+// filterReason returns the reason a node which does not meet constraint is
+// filtered. Nodes whose version cannot be parsed for a version constraint are
+// attributed separately from those whose version is out of range, so that
+// misreported versions can be told apart from outdated ones.
+func filterReason(constraint *structs.Constraint, option *structs.Node) string {
+	switch constraint.Operand {
+	case structs.ConstraintVersion, structs.ConstraintSemver:
+		if lVal, ok := resolveTarget(constraint.LTarget, option); ok {
+			if _, err := parseVersion(lVal); err != nil {
+				return fmt.Sprintf(FilterConstraintUnparseableVersionTemplate, constraint)
+			}
+		}
+	}
+	return constraint.String()
+}
+
 func (c *ConstraintChecker) meetsConstraint(constraint *structs.Constraint, option *structs.Node) bool {
 	// Resolve the targets. Targets that are not present are treated as `nil`.
 	// This is to allow for matching constraints where a target is not present.
@@ -1155,7 +1172,7 @@ func checkVersionMatch(parse verConstraintParser, lVal, rVal interface{}) bool {
 	}
 
 	// Parse the version
-	vers, err := version.NewVersion(versionStr)
+	vers, err := parseVersion(versionStr)
 	if err != nil {
 		return false
 	}
@@ -1176,6 +1193,19 @@ func checkVersionMatch(parse verConstraintParser, lVal, rVal interface{}) bool {
 	return c.Check(vers)
 }
 
+// parseVersion parses a version found on a node, such as an attribute or
+// metadata value. Surrounding whitespace, a leading "v" or "V", and build
+// metadata in any format are tolerated, as build metadata does not affect the
+// precedence of versions.
+func parseVersion(versionStr string) (*version.Version, error) {
+	versionStr = strings.TrimSpace(versionStr)
+	if len(versionStr) > 0 && (versionStr[0] == 'v' || versionStr[0] == 'V') {
+		versionStr = versionStr[1:]
+	}
+	versionStr, _, _ = strings.Cut(versionStr, "+")
+	return version.NewVersion(versionStr)
+}
+
 // checkAttributeVersionMatch is used to compare a version on the
 // left hand side with a set of constraints on the right hand side
 func checkAttributeVersionMatch(parse verConstraintParser, lVal, rVal *psstructs.Attribute) bool {
@@ -1190,7 +1220,7 @@ func checkAttributeVersionMatch(parse verConstraintParser, lVal, rVal *psstructs
 	}
 
 	// Parse the version
-	vers, err := version.NewVersion(versionStr)
+	vers, err := parseVersion(versionStr)
 	if err != nil {
 		return false
 	}
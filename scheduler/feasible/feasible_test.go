@@ -1404,6 +1404,40 @@ func TestConstraintChecker(t *testing.T) {
 	}
 }
 
+func TestConstraintChecker_UnparseableVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := MockContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[0].Meta["firmware"] = "v2.4.0+build.7"
+	nodes[1].Meta["firmware"] = "3.1.0"
+	nodes[2].Meta["firmware"] = "unknown"
+
+	constraint := &structs.Constraint{
+		Operand: structs.ConstraintSemver,
+		LTarget: "${meta.firmware}",
+		RTarget: ">= 2.3.0, < 3",
+	}
+	checker := NewConstraintChecker(ctx, []*structs.Constraint{constraint})
+
+	must.True(t, checker.Feasible(nodes[0]))
+	must.False(t, checker.Feasible(nodes[1]))
+	must.False(t, checker.Feasible(nodes[2]))
+	must.False(t, checker.Feasible(nodes[3]))
+
+	// nodes with an unparseable version are attributed separately from those
+	// with an out of range or missing version
+	metrics := ctx.Metrics().ConstraintFiltered
+	must.MapLen(t, 2, metrics)
+	must.Eq(t, 2, metrics["${meta.firmware} semver >= 2.3.0, < 3"])
+	must.Eq(t, 1, metrics["unparseable version for ${meta.firmware} semver >= 2.3.0, < 3"])
+}
+
 func TestResolveConstraintTarget(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1802,6 +1836,31 @@ func TestCheckSemverConstraint(t *testing.T) {
 			lVal: "1.3.0-beta1+ent", rVal: "= 1.3.0-beta1",
 			result: true,
 		},
+		{
+			name: "Leading v is tolerated",
+			lVal: "v2.3.1", rVal: ">= 2.3.0, < 3",
+			result: true,
+		},
+		{
+			name: "Leading V and surrounding whitespace are tolerated",
+			lVal: " V2.3.1 ", rVal: ">= 2.3.0, < 3",
+			result: true,
+		},
+		{
+			name: "Meta in any format is ignored",
+			lVal: "2.3.1+build_5/x86", rVal: ">= 2.3.0, < 3",
+			result: true,
+		},
+		{
+			name: "3.0.0 does not satisfy >= 2.3.0, < 3",
+			lVal: "v3.0.0", rVal: ">= 2.3.0, < 3",
+			result: false,
+		},
+		{
+			name: "Unparseable versions are infeasible",
+			lVal: "firmware-two", rVal: ">= 2.3.0, < 3",
+			result: false,
+		},
 	}
 
 	for _, tc := range cases {
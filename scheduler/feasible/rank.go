@@ -4,6 +4,7 @@
 package feasible
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"slices"
@@ -269,7 +270,8 @@ NEXTNODE:
 			TaskLifecycles: make(map[string]*structs.TaskLifecycleConfig,
 				len(iter.taskGroup.Tasks)),
 			Shared: structs.AllocatedSharedResources{
-				DiskMB: int64(iter.taskGroup.EphemeralDisk.SizeMB),
+				DiskMB:    int64(iter.taskGroup.EphemeralDisk.SizeMB),
+				DiskClass: iter.taskGroup.EphemeralDisk.Class,
 			},
 		}
 
@@ -290,6 +292,9 @@ NEXTNODE:
 		// Check if we need task group network resource
 		if len(iter.taskGroup.Networks) > 0 {
 			ask := iter.taskGroup.Networks[0].Copy()
+			if iter.taskGroup.AdvertiseMode == structs.AdvertiseModeIPv6 {
+				ask.PreferIPv6 = true
+			}
 			for i, port := range ask.DynamicPorts {
 				if port.HostNetwork != "" {
 					if hostNetworkValue, hostNetworkOk := resolveTarget(port.HostNetwork, option.Node); hostNetworkOk {
@@ -316,8 +321,7 @@ NEXTNODE:
 			if err != nil {
 				// If eviction is not enabled, mark this node as exhausted and continue
 				if !iter.evict {
-					iter.ctx.Metrics().ExhaustedNode(option.Node,
-						fmt.Sprintf("network: %s", err))
+					iter.exhaustedNetwork(option.Node, proposed, err)
 					netIdx.Release()
 					continue NEXTNODE
 				}
@@ -328,8 +332,7 @@ NEXTNODE:
 				netPreemptions := preemptor.PreemptForNetwork(ask, netIdx)
 				if netPreemptions == nil {
 					iter.ctx.Logger().Named("binpack").Debug("preemption not possible ", "network_resource", ask)
-					iter.ctx.Metrics().ExhaustedNode(option.Node,
-						fmt.Sprintf("network: %s", err))
+					iter.exhaustedNetwork(option.Node, proposed, err)
 					netIdx.Release()
 					continue NEXTNODE
 				}
@@ -347,8 +350,7 @@ NEXTNODE:
 				offer, err = netIdx.AssignPorts(ask)
 				if err != nil {
 					iter.ctx.Logger().Named("binpack").Debug("unexpected error, unable to create network offer after considering preemption", "error", err)
-					iter.ctx.Metrics().ExhaustedNode(option.Node,
-						fmt.Sprintf("network: %s", err))
+					iter.exhaustedNetwork(option.Node, proposed, err)
 					netIdx.Release()
 					continue NEXTNODE
 				}
@@ -362,9 +364,10 @@ NEXTNODE:
 			total.Shared.Networks = []*structs.NetworkResource{nwRes}
 			total.Shared.Ports = offer
 			option.AllocResources = &structs.AllocatedSharedResources{
-				Networks: []*structs.NetworkResource{nwRes},
-				DiskMB:   int64(iter.taskGroup.EphemeralDisk.SizeMB),
-				Ports:    offer,
+				Networks:  []*structs.NetworkResource{nwRes},
+				DiskMB:    int64(iter.taskGroup.EphemeralDisk.SizeMB),
+				Ports:     offer,
+				DiskClass: iter.taskGroup.EphemeralDisk.Class,
 			}
 
 		}
@@ -392,8 +395,7 @@ NEXTNODE:
 				if offer == nil {
 					// If eviction is not enabled, mark this node as exhausted and continue
 					if !iter.evict {
-						iter.ctx.Metrics().ExhaustedNode(option.Node,
-							fmt.Sprintf("network: %s", err))
+						iter.exhaustedNetwork(option.Node, proposed, err)
 						netIdx.Release()
 						continue NEXTNODE
 					}
@@ -404,8 +406,7 @@ NEXTNODE:
 					netPreemptions := preemptor.PreemptForNetwork(ask, netIdx)
 					if netPreemptions == nil {
 						iter.ctx.Logger().Named("binpack").Debug("preemption not possible ", "network_resource", ask)
-						iter.ctx.Metrics().ExhaustedNode(option.Node,
-							fmt.Sprintf("network: %s", err))
+						iter.exhaustedNetwork(option.Node, proposed, err)
 						netIdx.Release()
 						continue NEXTNODE
 					}
@@ -423,8 +424,7 @@ NEXTNODE:
 					offer, err = netIdx.AssignTaskNetwork(ask)
 					if offer == nil {
 						iter.ctx.Logger().Named("binpack").Debug("unexpected error, unable to create network offer after considering preemption", "error", err)
-						iter.ctx.Metrics().ExhaustedNode(option.Node,
-							fmt.Sprintf("network: %s", err))
+						iter.exhaustedNetwork(option.Node, proposed, err)
 						netIdx.Release()
 						continue NEXTNODE
 					}
@@ -786,6 +786,31 @@ func (iter *BinPackIterator) Reset() {
 	iter.source.Reset()
 }
 
+// exhaustedNetwork marks the node as exhausted because the network ask could
+// not be satisfied. If the failure was a static port collision, the port and
+// the allocation holding it are recorded as well.
+func (iter *BinPackIterator) exhaustedNetwork(node *structs.Node, proposed []*structs.Allocation, err error) {
+	metrics := iter.ctx.Metrics()
+	metrics.ExhaustedNode(node, fmt.Sprintf("network: %s", err))
+
+	var collisionErr *structs.PortCollisionError
+	if !errors.As(err, &collisionErr) {
+		return
+	}
+
+	collision := &structs.PortCollision{
+		NodeID: node.ID,
+		HostIP: collisionErr.HostIP,
+		Port:   collisionErr.Port,
+		Label:  collisionErr.Label,
+	}
+	if holder := structs.AllocUsingPort(proposed, collisionErr.HostIP, collisionErr.Port); holder != nil {
+		collision.AllocID = holder.ID
+		collision.JobID = holder.JobID
+	}
+	metrics.ExhaustedPort(collision)
+}
+
 // JobAntiAffinityIterator is used to apply an anti-affinity to allocating
 // along side other allocations from this job. This is used to help distribute
 // load across the cluster.
@@ -804,6 +804,108 @@ func TestBinPackIterator_Network_PortCollision_Alloc(t *testing.T) {
 	must.Eq(t, 1, ctx.metrics.DimensionExhausted["network: port collision"])
 }
 
+func TestBinPackIterator_Network_ReservedPortCollision(t *testing.T) {
+	state, ctx := MockContext(t)
+
+	nodes := []*RankedNode{
+		{
+			Node: &structs.Node{
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Processors: processorResources2048,
+					Cpu:        legacyCpuResources2048,
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 2048,
+					},
+					NodeNetworks: []*structs.NodeNetworkResource{
+						{
+							Mode:   "host",
+							Device: "eth0",
+							Addresses: []structs.NodeNetworkAddress{
+								{
+									Alias:   "default",
+									Address: "192.168.0.100",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	// Add an allocation holding the static port
+	j := mock.Job()
+	j.ID = "api-gateway"
+	alloc := &structs.Allocation{
+		Namespace: structs.DefaultNamespace,
+		ID:        uuid.Generate(),
+		EvalID:    uuid.Generate(),
+		NodeID:    nodes[0].Node.ID,
+		JobID:     j.ID,
+		Job:       j,
+		AllocatedResources: &structs.AllocatedResources{
+			Tasks: map[string]*structs.AllocatedTaskResources{
+				"web": {
+					Cpu: structs.AllocatedCpuResources{
+						CpuShares: 512,
+					},
+					Memory: structs.AllocatedMemoryResources{
+						MemoryMB: 512,
+					},
+				},
+			},
+			Shared: structs.AllocatedSharedResources{
+				Ports: structs.AllocatedPorts{
+					{Label: "http", Value: 8080, HostIP: "192.168.0.100"},
+				},
+			},
+		},
+		DesiredStatus: structs.AllocDesiredStatusRun,
+		ClientStatus:  structs.AllocClientStatusRunning,
+		TaskGroup:     "web",
+	}
+	must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:      512,
+					MemoryMB: 512,
+				},
+			},
+		},
+		Networks: []*structs.NetworkResource{
+			{
+				ReservedPorts: []structs.Port{{Label: "http", Value: 8080, HostNetwork: "default"}},
+			},
+		},
+	}
+	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp.SetTaskGroup(taskGroup)
+	binp.SetSchedulerConfiguration(testSchedulerConfig)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
+	out := collectRanked(scoreNorm)
+
+	// We expect a placement failure naming the port and the alloc holding it
+	must.Len(t, 0, out)
+	must.Eq(t, 1, ctx.metrics.DimensionExhausted["network: reserved port collision http=8080"])
+	must.Eq(t, []*structs.PortCollision{{
+		NodeID:  nodes[0].Node.ID,
+		HostIP:  "192.168.0.100",
+		Port:    8080,
+		Label:   "http",
+		AllocID: alloc.ID,
+		JobID:   "api-gateway",
+	}}, ctx.metrics.PortCollisions)
+}
+
 // Tests bin packing iterator with host network interpolation of task group level ports configuration
 func TestBinPackIterator_Network_Interpolation_Success(t *testing.T) {
 	_, ctx := MockContext(t)
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ReplicateValue returns the value on node of the node attribute the task
+// group is replicated per, if the node has the attribute.
+func ReplicateValue(tg *structs.TaskGroup, node *structs.Node) (string, bool) {
+	if tg.ReplicatePer == "" {
+		return "", false
+	}
+	return resolveTarget(tg.ReplicatePer, node)
+}
+
+// ReplicateValues returns the distinct values of the node attribute the task
+// group is replicated per among the nodes which meet the constraints of the
+// job, the task group, and its tasks. Whether the nodes have the resources for
+// an allocation of the task group is not considered.
+func ReplicateValues(ctx ConstraintContext, job *structs.Job, tg *structs.TaskGroup, nodes []*structs.Node) map[string]struct{} {
+	constraints := append([]*structs.Constraint{}, job.Constraints...)
+	constraints = append(constraints, tg.Constraints...)
+	for _, task := range tg.Tasks {
+		constraints = append(constraints, task.Constraints...)
+	}
+	checker := NewConstraintChecker(ctx, constraints)
+
+	values := make(map[string]struct{})
+	for _, node := range nodes {
+		value, ok := ReplicateValue(tg, node)
+		if !ok || !checker.Feasible(node) {
+			continue
+		}
+		values[value] = struct{}{}
+	}
+	return values
+}
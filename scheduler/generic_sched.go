@@ -74,6 +74,10 @@ type GenericScheduler struct {
 
 	deployment *structs.Deployment
 
+	// rescheduleNodePools are the node pools with reschedule overrides of
+	// the nodes with failed allocs, keyed by node ID.
+	rescheduleNodePools map[string]*structs.NodePool
+
 	blocked         *structs.Evaluation
 	failedTGAllocs  map[string]*structs.AllocMetric
 	queuedAllocs    map[string]int
@@ -343,10 +347,33 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	// nodes to lost, but only if the scheduler has already marked them
 	updateNonTerminalAllocsToLost(s.plan, tainted, allocs)
 
+	// Determine the reschedule overrides of the node pools of failed allocs
+	s.rescheduleNodePools, err = rescheduleNodePools(s.state, allocs, tainted)
+	if err != nil {
+		return fmt.Errorf("failed to get node pools for job '%s': %v",
+			s.eval.JobID, err)
+	}
+
+	// Determine the grace of disconnected nodes in maintenance windows
+	now := time.Now().UTC()
+	grace, err := maintenanceGrace(s.state, tainted, now)
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance windows for job '%s': %v",
+			s.eval.JobID, err)
+	}
+
+	// Determine the counts of task groups replicated per node attribute
+	job, scalingEvents, err := replicatedJob(s.ctx, s.job, allocs, tainted)
+	if err != nil {
+		return fmt.Errorf("failed to get replicated task groups for job '%s': %v",
+			s.eval.JobID, err)
+	}
+	s.plan.ScalingEvents = scalingEvents
+
 	r := reconciler.NewAllocReconciler(s.logger,
 		genericAllocUpdateFn(s.ctx, s.stack, s.eval.ID),
 		reconciler.ReconcilerState{
-			Job:               s.job,
+			Job:               job,
 			JobID:             s.eval.JobID,
 			JobIsBatch:        s.batch,
 			DeploymentCurrent: s.deployment,
@@ -357,7 +384,9 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		reconciler.ClusterState{
 			TaintedNodes:                tainted,
 			SupportsDisconnectedClients: s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true),
-			Now:                         time.Now().UTC(),
+			Now:                         now,
+			RescheduleNodePools:         s.rescheduleNodePools,
+			MaintenanceGrace:            grace,
 		})
 	result := r.Compute()
 	if s.logger.IsDebug() {
@@ -604,7 +633,8 @@ func (s *GenericScheduler) computePlacements(
 					Tasks:          option.TaskResources,
 					TaskLifecycles: option.TaskLifecycles,
 					Shared: structs.AllocatedSharedResources{
-						DiskMB: int64(tg.EphemeralDisk.SizeMB),
+						DiskMB:    int64(tg.EphemeralDisk.SizeMB),
+						DiskClass: tg.EphemeralDisk.Class,
 					},
 				}
 				if option.AllocResources != nil {
@@ -666,7 +696,7 @@ func (s *GenericScheduler) computePlacements(
 						original := prevAllocation
 						prevAllocation = prevAllocation.Copy()
 						missing.SetPreviousAllocation(prevAllocation)
-						UpdateRescheduleTracker(alloc, prevAllocation, now)
+						UpdateRescheduleTracker(alloc, prevAllocation, now, s.rescheduleNodePools[prevAllocation.NodeID])
 						swapAllocInPlan(s.plan, original, prevAllocation)
 					}
 				}
@@ -815,8 +845,9 @@ func annotateRescheduleTracker(prev *structs.Allocation, note structs.Reschedule
 // UpdateRescheduleTracker carries over previous restart attempts and adds the
 // most recent restart. This mutates both allocations; "alloc" is a new
 // allocation so this is safe, but "prev" is coming from the state store and
-// must be copied first.
-func UpdateRescheduleTracker(alloc *structs.Allocation, prev *structs.Allocation, now time.Time) {
+// must be copied first. The reschedule overrides of pool, the node pool of the
+// node "prev" failed on, are applied to the delay if it is not nil.
+func UpdateRescheduleTracker(alloc *structs.Allocation, prev *structs.Allocation, now time.Time, pool *structs.NodePool) {
 	reschedPolicy := prev.ReschedulePolicy()
 	var rescheduleEvents []*structs.RescheduleEvent
 	if prev.RescheduleTracker != nil {
@@ -846,8 +877,10 @@ func UpdateRescheduleTracker(alloc *structs.Allocation, prev *structs.Allocation
 			}
 		}
 	}
-	nextDelay := prev.NextDelay()
+	nextDelay, jitter, source := prev.RescheduleDelay(pool)
 	rescheduleEvent := structs.NewRescheduleEvent(now.UnixNano(), prev.ID, prev.NodeID, nextDelay)
+	rescheduleEvent.Jitter = jitter
+	rescheduleEvent.DelaySource = source
 	rescheduleEvents = append(rescheduleEvents, rescheduleEvent)
 	alloc.RescheduleTracker = &structs.RescheduleTracker{
 		Events:         rescheduleEvents,
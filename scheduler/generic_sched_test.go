@@ -736,6 +736,196 @@ func TestServiceSched_JobRegister_DistinctProperty(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_ReplicatePer(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	// Create two nodes in each of three racks, and a node without a rack
+	var nodes []*structs.Node
+	for i := 0; i < 6; i++ {
+		node := mock.Node()
+		node.Meta["rack"] = fmt.Sprintf("r%d", i%3+1)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodes = append(nodes, node)
+	}
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), mock.Node()))
+
+	// Create a job replicated per rack, with the constraint implied by
+	// replicate_per
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].ReplicatePer = "${meta.rack}"
+	job.TaskGroups[0].Constraints = append(job.TaskGroups[0].Constraints,
+		&structs.Constraint{
+			Operand: structs.ConstraintDistinctProperty,
+			LTarget: "${meta.rack}",
+			RTarget: "1",
+		})
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	process := func(t *testing.T, triggeredBy string) *structs.Plan {
+		t.Helper()
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: triggeredBy,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		plans := len(h.Plans)
+		must.NoError(t, h.Process(NewServiceScheduler, eval))
+		must.Len(t, plans+1, h.Plans)
+		return h.Plans[plans]
+	}
+
+	racks := func(t *testing.T) []string {
+		t.Helper()
+		allocs, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+		must.NoError(t, err)
+		var out []string
+		for _, alloc := range allocs {
+			if alloc.ServerTerminalStatus() {
+				continue
+			}
+			node, err := h.State.NodeByID(nil, alloc.NodeID)
+			must.NoError(t, err)
+			out = append(out, node.Meta["rack"])
+		}
+		slices.Sort(out)
+		return out
+	}
+
+	// one allocation is placed per rack
+	plan := process(t, structs.EvalTriggerJobRegister)
+	must.Eq(t, []string{"r1", "r2", "r3"}, racks(t))
+	event := plan.ScalingEvents["web"]
+	must.NotNil(t, event)
+	must.Eq(t, "rack r1 added, rack r2 added, rack r3 added", event.Message)
+	must.Eq(t, 3, *event.Count)
+	must.Eq(t, 0, event.PreviousCount)
+
+	// a node in a new rack joins
+	node := mock.Node()
+	node.Meta["rack"] = "r4"
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	plan = process(t, structs.EvalTriggerNodeUpdate)
+	must.Eq(t, []string{"r1", "r2", "r3", "r4"}, racks(t))
+	must.Eq(t, "rack r4 added", plan.ScalingEvents["web"].Message)
+	must.Eq(t, 3, plan.ScalingEvents["web"].PreviousCount)
+
+	// the only node in the new rack goes down, so the rack is gone
+	node = node.Copy()
+	node.Status = structs.NodeStatusDown
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	plan = process(t, structs.EvalTriggerNodeUpdate)
+	must.Eq(t, []string{"r1", "r2", "r3"}, racks(t))
+	must.MapEmpty(t, plan.NodeAllocation)
+	must.Eq(t, "rack r4 removed", plan.ScalingEvents["web"].Message)
+
+	// the scaling events are recorded with the plans
+	events, _, err := h.State.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 3, events["web"])
+	must.Eq(t, "rack r4 removed", events["web"][0].Message)
+
+	// nothing changes while the racks remain
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerNodeUpdate,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	plans := len(h.Plans)
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+	must.Len(t, plans, h.Plans)
+}
+
+func TestServiceSched_JobModify_ReplicatePer_Canary(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		node.Meta["rack"] = fmt.Sprintf("r%d", i+1)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a job replicated per rack with canary updates
+	job := mock.Job()
+	job.TaskGroups[0].ReplicatePer = "${meta.rack}"
+	job.TaskGroups[0].Update = &structs.UpdateStrategy{
+		MaxParallel:     1,
+		Canary:          1,
+		AutoPromote:     true,
+		HealthCheck:     structs.UpdateStrategyHealthCheck_Checks,
+		MinHealthyTime:  10 * time.Second,
+		HealthyDeadline: 10 * time.Minute,
+	}
+	job.TaskGroups[0].Constraints = append(job.TaskGroups[0].Constraints,
+		&structs.Constraint{
+			Operand: structs.ConstraintDistinctProperty,
+			LTarget: "${meta.rack}",
+			RTarget: "1",
+		})
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	process := func(t *testing.T) *structs.Plan {
+		t.Helper()
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		plans := len(h.Plans)
+		must.NoError(t, h.Process(NewServiceScheduler, eval))
+		must.Len(t, plans+1, h.Plans)
+		return h.Plans[plans]
+	}
+
+	plan := process(t)
+	var placed int
+	for _, allocs := range plan.NodeAllocation {
+		placed += len(allocs)
+	}
+	must.Eq(t, 3, placed)
+
+	// A destructive update replaces the allocation of one rack rather than
+	// placing a canary next to it
+	job = job.Copy()
+	job.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	plan = process(t)
+	var stopped []*structs.Allocation
+	for _, allocs := range plan.NodeUpdate {
+		stopped = append(stopped, allocs...)
+	}
+	var replacements []*structs.Allocation
+	for _, allocs := range plan.NodeAllocation {
+		replacements = append(replacements, allocs...)
+	}
+	must.Len(t, 1, stopped)
+	must.Len(t, 1, replacements)
+	must.Eq(t, stopped[0].NodeID, replacements[0].NodeID)
+	must.False(t, replacements[0].DeploymentStatus.IsCanary())
+
+	must.NotNil(t, plan.Deployment)
+	state := plan.Deployment.TaskGroups["web"]
+	must.Eq(t, 0, state.DesiredCanaries)
+	must.Eq(t, 3, state.DesiredTotal)
+}
+
 func TestServiceSched_JobRegister_DistinctProperty_TaskGroup(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6570,6 +6760,7 @@ func Test_updateRescheduleTracker(t *testing.T) {
 					PrevAllocID:    prevAlloc.ID,
 					PrevNodeID:     prevAlloc.NodeID,
 					Delay:          5 * time.Second,
+					DelaySource:    structs.RescheduleDelaySourceJob,
 				},
 			},
 		},
@@ -6594,6 +6785,7 @@ func Test_updateRescheduleTracker(t *testing.T) {
 					PrevAllocID:    prevAlloc.ID,
 					PrevNodeID:     prevAlloc.NodeID,
 					Delay:          5 * time.Second,
+					DelaySource:    structs.RescheduleDelaySourceJob,
 				},
 			},
 		},
@@ -6618,6 +6810,7 @@ func Test_updateRescheduleTracker(t *testing.T) {
 					PrevAllocID:    prevAlloc.ID,
 					PrevNodeID:     prevAlloc.NodeID,
 					Delay:          5 * time.Second,
+					DelaySource:    structs.RescheduleDelaySourceJob,
 				},
 			},
 		},
@@ -6711,6 +6904,7 @@ func Test_updateRescheduleTracker(t *testing.T) {
 					PrevAllocID:    prevAlloc.ID,
 					PrevNodeID:     prevAlloc.NodeID,
 					Delay:          170 * time.Second,
+					DelaySource:    structs.RescheduleDelaySourceJob,
 				},
 			},
 		},
@@ -6762,6 +6956,7 @@ func Test_updateRescheduleTracker(t *testing.T) {
 					PrevAllocID:    prevAlloc.ID,
 					PrevNodeID:     prevAlloc.NodeID,
 					Delay:          80 * time.Second,
+					DelaySource:    structs.RescheduleDelaySourceJob,
 				},
 			},
 		},
@@ -6771,7 +6966,7 @@ func Test_updateRescheduleTracker(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			prevAlloc.RescheduleTracker = &structs.RescheduleTracker{Events: tc.prevAllocEvents}
 			prevAlloc.Job.LookupTaskGroup(prevAlloc.TaskGroup).ReschedulePolicy = tc.reschedPolicy
-			UpdateRescheduleTracker(alloc, prevAlloc, tc.reschedTime)
+			UpdateRescheduleTracker(alloc, prevAlloc, tc.reschedTime, nil)
 			must.Eq(t, tc.expectedRescheduleEvents, alloc.RescheduleTracker.Events)
 		})
 	}
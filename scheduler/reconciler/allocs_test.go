@@ -139,7 +139,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 			}{ // These two cases test that we maintain parity with pre-disconnected-clients behavior.
 				{
 					name:            "lost-client",
-					state:           ClusterState{nodes, false, time.Now()},
+					state:           ClusterState{nodes, false, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"untainted1": {
@@ -241,7 +241,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:  "lost-client-only-tainted-nodes",
-					state: ClusterState{nodes, false, time.Now()},
+					state: ClusterState{nodes, false, time.Now(), nil, nil},
 					// The logic associated with this test case can only trigger if there
 					// is a tainted node. Therefore, testing with a nil node set produces
 					// false failures, so don't perform that test if in this case.
@@ -287,7 +287,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-disconnect-unset-max-disconnect",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: true,
 					all: allocSet{
 						// Non-terminal allocs on disconnected nodes w/o max-disconnect are lost
@@ -322,7 +322,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				// Everything below this line tests the disconnected client mode.
 				{
 					name:            "disco-client-untainted-reconnect-failed-and-replaced",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"running-replacement": {
@@ -381,7 +381,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-reconnecting-running-no-replacement",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						// Running allocs on reconnected nodes with no replacement are reconnecting.
@@ -419,7 +419,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-terminal",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						// Allocs on reconnected nodes that are complete need to be updated to stop
@@ -567,7 +567,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-disconnect",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: true,
 					all: allocSet{
 						// Non-terminal allocs on disconnected nodes are disconnecting
@@ -707,9 +707,67 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 						},
 					},
 				},
+				{
+					// Unknown allocs on nodes in a maintenance window don't
+					// expire until the window's grace has passed too.
+					name:            "disco-client-disconnect-maintenance-window",
+					state:           ClusterState{nodes, true, time.Now(), nil, map[string]time.Duration{"disconnected": 2 * time.Minute}},
+					skipNilNodeTest: true,
+					all: allocSet{
+						"untainted-unknown": {
+							ID:            "untainted-unknown",
+							Name:          "untainted-unknown",
+							ClientStatus:  structs.AllocClientStatusUnknown,
+							DesiredStatus: structs.AllocDesiredStatusRun,
+							Job:           testJob,
+							NodeID:        "disconnected",
+							TaskGroup:     "web",
+							AllocStates:   expiredAllocState,
+						},
+						"expiring-expired": {
+							ID:            "expiring-expired",
+							Name:          "expiring-expired",
+							ClientStatus:  structs.AllocClientStatusUnknown,
+							DesiredStatus: structs.AllocDesiredStatusRun,
+							Job:           testJob,
+							NodeID:        "normal",
+							TaskGroup:     "web",
+							AllocStates:   expiredAllocState,
+						},
+					},
+					untainted: allocSet{
+						"untainted-unknown": {
+							ID:            "untainted-unknown",
+							Name:          "untainted-unknown",
+							ClientStatus:  structs.AllocClientStatusUnknown,
+							DesiredStatus: structs.AllocDesiredStatusRun,
+							Job:           testJob,
+							NodeID:        "disconnected",
+							TaskGroup:     "web",
+							AllocStates:   expiredAllocState,
+						},
+					},
+					migrate:       allocSet{},
+					disconnecting: allocSet{},
+					reconnecting:  allocSet{},
+					ignore:        allocSet{},
+					lost:          allocSet{},
+					expiring: allocSet{
+						"expiring-expired": {
+							ID:            "expiring-expired",
+							Name:          "expiring-expired",
+							ClientStatus:  structs.AllocClientStatusUnknown,
+							DesiredStatus: structs.AllocDesiredStatusRun,
+							Job:           testJob,
+							NodeID:        "normal",
+							TaskGroup:     "web",
+							AllocStates:   expiredAllocState,
+						},
+					},
+				},
 				{
 					name:            "disco-client-reconnect",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						// Expired allocs on reconnected clients are lost
@@ -745,7 +803,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-running-reconnecting-and-replacement-untainted",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"running-replacement": {
@@ -805,7 +863,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 					// "untainted" instead of "reconnecting" to allow changes such as
 					// job updates to be applied properly.
 					name:            "disco-client-reconnected-alloc-untainted",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"running-reconnected": {
@@ -841,7 +899,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				// Everything below this line tests the single instance on lost mode.
 				{
 					name:            "lost-client-single-instance-on",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"untainted1": {
@@ -943,7 +1001,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:  "lost-client-only-tainted-nodes-single-instance-on",
-					state: ClusterState{nodes, false, time.Now()},
+					state: ClusterState{nodes, false, time.Now(), nil, nil},
 					// The logic associated with this test case can only trigger if there
 					// is a tainted node. Therefore, testing with a nil node set produces
 					// false failures, so don't perform that test if in this case.
@@ -989,7 +1047,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-disconnect-unset-max-disconnect-single-instance-on",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: true,
 					all: allocSet{
 						// Non-terminal allocs on disconnected nodes w/o max-disconnect are lost
@@ -1021,7 +1079,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-untainted-reconnect-failed-and-replaced-single-instance-on",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"running-replacement": {
@@ -1080,7 +1138,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-reconnect-single-instance-on",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						// Expired allocs on reconnected clients are lost
@@ -1116,7 +1174,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-running-reconnecting-and-replacement-untainted-single-instance-on",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"running-replacement": {
@@ -1176,7 +1234,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 					// "untainted" instead of "reconnecting" to allow changes such as
 					// job updates to be applied properly.
 					name:            "disco-client-reconnected-alloc-untainted",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: false,
 					all: allocSet{
 						"running-reconnected": {
@@ -1211,7 +1269,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 				},
 				{
 					name:            "disco-client-reconnected-alloc-untainted-single-instance-on",
-					state:           ClusterState{nodes, true, time.Now()},
+					state:           ClusterState{nodes, true, time.Now(), nil, nil},
 					skipNilNodeTest: true,
 					all: allocSet{
 						"untainted-unknown": {
@@ -2082,7 +2140,7 @@ func TestAllocSet_filterByRescheduleable(t *testing.T) {
 				testJob.Type = structs.JobTypeService
 			}
 			untainted, resNow, resLater := tc.all.filterByRescheduleable(tc.isBatch,
-				tc.isDisconnecting, now, "evailID", tc.deployment)
+				tc.isDisconnecting, now, "evailID", tc.deployment, nil)
 			must.Eq(t, tc.untainted, untainted, must.Sprintf("with-nodes: untainted"))
 			must.Eq(t, tc.resNow, resNow, must.Sprintf("with-nodes: reschedule-now"))
 			must.Eq(t, tc.resLater, resLater, must.Sprintf("with-nodes: rescheduleLater"))
@@ -203,7 +203,7 @@ func (set allocSet) filterByTainted(state ClusterState) (untainted, migrate, los
 			continue
 		}
 
-		if supportsDisconnectedClients && alloc.Expired(state.Now) {
+		if supportsDisconnectedClients && alloc.ExpiredWithGrace(state.Now, state.MaintenanceGrace[alloc.NodeID]) {
 			expiring[alloc.ID] = alloc
 			continue
 		}
@@ -232,7 +232,7 @@ func (set allocSet) filterByTainted(state ClusterState) (untainted, migrate, los
 				// Expired unknown allocs should be processed depending on the max client disconnect
 				// and/or avoid reschedule on lost configurations, they are both treated as
 				// expiring.
-				if alloc.Expired(state.Now) {
+				if alloc.ExpiredWithGrace(state.Now, state.MaintenanceGrace[alloc.NodeID]) {
 					expiring[alloc.ID] = alloc
 					continue
 				}
@@ -302,14 +302,50 @@ func (set allocSet) filterByClientStatus(clientStatus string) allocSet {
 	return allocs
 }
 
+// filterByMaxRunTimeExceeded splits the set into the allocations on
+// disconnected nodes which exceeded the max run time of their task group and
+// the remaining ones. The clients of these allocations cannot enforce the max
+// run time, so the scheduler fails them instead.
+func (set allocSet) filterByMaxRunTimeExceeded(now time.Time) (remaining, exceeded allocSet) {
+	remaining = make(allocSet)
+	exceeded = make(allocSet)
+	for id, alloc := range set {
+		if alloc.ClientStatus == structs.AllocClientStatusUnknown &&
+			alloc.DesiredStatus == structs.AllocDesiredStatusRun {
+			expiry := maxRunTimeExpiry(alloc)
+			if !expiry.IsZero() && !expiry.After(now) {
+				exceeded[id] = alloc
+				continue
+			}
+		}
+		remaining[id] = alloc
+	}
+	return remaining, exceeded
+}
+
+// maxRunTimeExpiry returns the time after which the scheduler fails an
+// allocation on a disconnected node for exceeding the max run time of its task
+// group, or the zero time if it never does. The allocations of task groups
+// which complete on timeout are left to their client, since the scheduler
+// cannot tell whether their tasks ran successfully.
+func maxRunTimeExpiry(alloc *structs.Allocation) time.Time {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || !tg.FailsOnTimeout() {
+		return time.Time{}
+	}
+	return alloc.MaxRunTimeExpiry()
+}
+
 // filterByRescheduleable filters the allocation set to return the set of
 // allocations that are either untainted or a set of allocations that must
 // be rescheduled now. Allocations that can be rescheduled at a future time
 // are also returned so that we can create follow up evaluations for them.
 // Allocs are skipped or considered untainted according to logic defined in
-// shouldFilter method.
+// shouldFilter method. The reschedule overrides of the node pools in nodePools,
+// keyed by node ID, are applied to allocs on those nodes.
 func (set allocSet) filterByRescheduleable(isBatch, isDisconnecting bool,
 	now time.Time, evalID string, deployment *structs.Deployment,
+	nodePools map[string]*structs.NodePool,
 ) (
 	untainted, rescheduleNow allocSet, rescheduleLater []*delayedRescheduleInfo,
 ) {
@@ -344,7 +380,7 @@ func (set allocSet) filterByRescheduleable(isBatch, isDisconnecting bool,
 			continue
 		}
 
-		eligibleNow, eligibleLater, rescheduleTime = updateByReschedulable(alloc, now, evalID, deployment, isDisconnecting)
+		eligibleNow, eligibleLater, rescheduleTime = updateByReschedulable(alloc, now, evalID, deployment, isDisconnecting, nodePools[alloc.NodeID])
 		if eligibleNow {
 			rescheduleNow[alloc.ID] = alloc
 			continue
@@ -428,7 +464,7 @@ func shouldFilter(alloc *structs.Allocation, isBatch bool) (untainted, ignore bo
 
 // updateByReschedulable is a helper method that encapsulates logic for whether a failed allocation
 // should be rescheduled now, later or left in the untainted set
-func updateByReschedulable(alloc *structs.Allocation, now time.Time, evalID string, d *structs.Deployment, isDisconnecting bool) (rescheduleNow, rescheduleLater bool, rescheduleTime time.Time) {
+func updateByReschedulable(alloc *structs.Allocation, now time.Time, evalID string, d *structs.Deployment, isDisconnecting bool, pool *structs.NodePool) (rescheduleNow, rescheduleLater bool, rescheduleTime time.Time) {
 	// If the allocation is part of an ongoing active deployment, we only allow it to reschedule
 	// if it has been marked eligible
 	if d != nil && alloc.DeploymentID == d.ID && d.Active() && !alloc.DesiredTransition.ShouldReschedule() {
@@ -444,14 +480,14 @@ func updateByReschedulable(alloc *structs.Allocation, now time.Time, evalID stri
 	var eligible bool
 	switch {
 	case isDisconnecting:
-		rescheduleTime, eligible = alloc.RescheduleTimeOnDisconnect(now)
+		rescheduleTime, eligible = alloc.RescheduleTimeOnDisconnect(now, pool)
 
 	case alloc.ClientStatus == structs.AllocClientStatusUnknown && alloc.FollowupEvalID == evalID:
 		lastDisconnectTime := alloc.LastUnknown()
-		rescheduleTime, eligible = alloc.NextRescheduleTimeByTime(lastDisconnectTime)
+		rescheduleTime, eligible = alloc.NextRescheduleTimeByTime(lastDisconnectTime, pool)
 
 	default:
-		rescheduleTime, eligible = alloc.NextRescheduleTime()
+		rescheduleTime, eligible = alloc.NextRescheduleTime(pool)
 	}
 
 	if eligible && (alloc.FollowupEvalID == evalID || rescheduleTime.Sub(now) <= rescheduleWindowSize) {
@@ -489,8 +525,9 @@ func (set allocSet) delayByStopAfter() (later []*delayedRescheduleInfo) {
 }
 
 // delayByLostAfter returns a delay for any unknown allocation
-// that has disconnect.lost_after configured
-func (set allocSet) delayByLostAfter(now time.Time) ([]*delayedRescheduleInfo, error) {
+// that has disconnect.lost_after configured, extended by the maintenance
+// window grace of its node
+func (set allocSet) delayByLostAfter(now time.Time, grace map[string]time.Duration) ([]*delayedRescheduleInfo, error) {
 	var later []*delayedRescheduleInfo
 
 	for _, alloc := range set {
@@ -499,10 +536,17 @@ func (set allocSet) delayByLostAfter(now time.Time) ([]*delayedRescheduleInfo, e
 			return nil, errors.New("unable to computing disconnecting timeouts")
 		}
 
+		// Evaluate the allocation earlier if it exceeds the max run time of
+		// its task group before being lost, so that it is failed then.
+		rescheduleTime := timeout.Add(grace[alloc.NodeID])
+		if expiry := maxRunTimeExpiry(alloc); !expiry.IsZero() && expiry.Before(rescheduleTime) {
+			rescheduleTime = expiry
+		}
+
 		later = append(later, &delayedRescheduleInfo{
 			allocID:        alloc.ID,
 			alloc:          alloc,
-			rescheduleTime: timeout,
+			rescheduleTime: rescheduleTime,
 		})
 	}
 
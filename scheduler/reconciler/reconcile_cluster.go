@@ -247,6 +247,14 @@ type ClusterState struct {
 	TaintedNodes                map[string]*structs.Node
 	SupportsDisconnectedClients bool
 	Now                         time.Time
+
+	// RescheduleNodePools are the node pools with reschedule overrides,
+	// keyed by the ID of the nodes in the pool which have failed allocs.
+	RescheduleNodePools map[string]*structs.NodePool
+
+	// MaintenanceGrace is the grace added to the disconnect lost_after of
+	// allocations on nodes in an active maintenance window, keyed by node ID.
+	MaintenanceGrace map[string]time.Duration
 }
 
 // NewAllocReconciler creates a new reconciler that should be used to determine
@@ -419,13 +427,13 @@ func (a *AllocReconciler) computeGroup(group string, all allocSet) (*ReconcileRe
 	// Determine what set of terminal allocations need to be rescheduled
 	untainted, rescheduleNow, rescheduleLater := untainted.filterByRescheduleable(
 		a.jobState.JobIsBatch, false, a.clusterState.Now,
-		a.jobState.EvalID, a.jobState.DeploymentCurrent)
+		a.jobState.EvalID, a.jobState.DeploymentCurrent, a.clusterState.RescheduleNodePools)
 
 	// Determine what set of migrating allocations need to be rescheduled. These
 	// will be batch job allocations that were stopped using the `stop alloc` command.
 	_, migrateRescheduleNow, migrateRescheduleLater := migrate.filterByRescheduleable(
 		a.jobState.JobIsBatch, false, a.clusterState.Now,
-		a.jobState.EvalID, a.jobState.DeploymentCurrent)
+		a.jobState.EvalID, a.jobState.DeploymentCurrent, a.clusterState.RescheduleNodePools)
 
 	rescheduleNow = rescheduleNow.union(migrateRescheduleNow)
 	rescheduleLater = append(rescheduleLater, migrateRescheduleLater...)
@@ -446,6 +454,15 @@ func (a *AllocReconciler) computeGroup(group string, all allocSet) (*ReconcileRe
 		}
 	}
 
+	// Fail the allocations on disconnected nodes which exceeded the max run
+	// time of the task group, since their client cannot enforce it.
+	untainted, exceeded := untainted.filterByMaxRunTimeExceeded(a.clusterState.Now)
+	if len(exceeded) > 0 {
+		result.Stop = append(result.Stop,
+			markStop(exceeded, structs.AllocClientStatusFailed, sstructs.StatusAllocMaxRunTimeExceeded)...)
+		result.DesiredTGUpdates[group].Stop += uint64(len(exceeded))
+	}
+
 	result.DesiredFollowupEvals = map[string][]*structs.Evaluation{}
 	result.DisconnectUpdates = make(allocSet)
 
@@ -1558,7 +1575,7 @@ func (a *AllocReconciler) createTimeoutLaterEvals(disconnecting allocSet, tgName
 		return map[string]string{}, nil
 	}
 
-	timeoutDelays, err := disconnecting.delayByLostAfter(a.clusterState.Now)
+	timeoutDelays, err := disconnecting.delayByLostAfter(a.clusterState.Now, a.clusterState.MaintenanceGrace)
 	if err != nil {
 		a.logger.Error("error for task_group", "task_group", tgName, "error", err)
 		return map[string]string{}, nil
@@ -1635,7 +1652,8 @@ func (a *AllocReconciler) computeDisconnecting(
 
 	if tg.GetDisconnectLostTimeout() != 0 {
 		untaintedDisconnecting, rescheduleDisconnecting, laterDisconnecting := disconnecting.filterByRescheduleable(
-			a.jobState.JobIsBatch, true, a.clusterState.Now, a.jobState.EvalID, a.jobState.DeploymentCurrent)
+			a.jobState.JobIsBatch, true, a.clusterState.Now, a.jobState.EvalID, a.jobState.DeploymentCurrent,
+			a.clusterState.RescheduleNodePools)
 
 		*rescheduleNow = rescheduleNow.union(rescheduleDisconnecting)
 		*untainted = untainted.union(untaintedDisconnecting)
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
 	"github.com/kr/pretty"
 	"github.com/shoenig/test"
 	"github.com/shoenig/test/must"
@@ -2499,6 +2500,59 @@ func TestReconciler_RescheduleLater_Service(t *testing.T) {
 	must.Eq(t, evals[0].ID, annotated.FollowupEvalID)
 }
 
+// Tests that the reschedule overrides of the node pool of a failed
+// allocation's node are used to compute its reschedule time.
+func TestReconciler_RescheduleLater_NodePoolOverrides(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	tgName := job.TaskGroups[0].Name
+	now := time.Now()
+
+	job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{
+		Attempts: 1, Interval: 24 * time.Hour, Delay: 15 * time.Second, MaxDelay: 1 * time.Hour}
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, tgName, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	allocs[0].TaskStates = map[string]*structs.TaskState{tgName: {State: "start",
+		StartedAt:  now.Add(-1 * time.Hour),
+		FinishedAt: now}}
+	allocs[0].ClientStatus = structs.AllocClientStatusFailed
+
+	pool := mock.NodePool()
+	pool.RescheduleOverrides = &structs.NodePoolRescheduleOverrides{
+		Delay: 10 * time.Minute,
+	}
+
+	reconciler := NewAllocReconciler(
+		testlog.HCLogger(t), allocUpdateFnIgnore, ReconcilerState{
+			JobIsBatch:     false,
+			JobID:          job.ID,
+			Job:            job,
+			ExistingAllocs: allocs,
+			EvalID:         uuid.Generate(),
+			EvalPriority:   50,
+		}, ClusterState{
+			SupportsDisconnectedClients: true,
+			Now:                         time.Now().UTC(),
+			RescheduleNodePools:         map[string]*structs.NodePool{allocs[0].NodeID: pool},
+		})
+	r := reconciler.Compute()
+
+	evals := r.DesiredFollowupEvals[tgName]
+	must.SliceLen(t, 1, evals)
+	must.Eq(t, now.Add(10*time.Minute), evals[0].WaitUntil)
+}
+
 // Tests service allocations with client status complete
 func TestReconciler_Service_ClientStatusComplete(t *testing.T) {
 	ci.Parallel(t)
@@ -6901,6 +6955,119 @@ func TestReconciler_Node_Disconnect_Updates_Alloc_To_Unknown(t *testing.T) {
 	})
 }
 
+func TestReconciler_Node_Disconnect_MaintenanceWindowGrace(t *testing.T) {
+	ci.Parallel(t)
+
+	job, allocs := buildResumableAllocations(3, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+	nodes := buildDisconnectedNodes(allocs, 2)
+
+	grace := map[string]time.Duration{}
+	for id := range nodes {
+		grace[id] = 10 * time.Minute
+	}
+
+	now := time.Now().UTC()
+	reconciler := NewAllocReconciler(
+		testlog.HCLogger(t), allocUpdateFnIgnore, ReconcilerState{
+			JobIsBatch:        false,
+			JobID:             job.ID,
+			Job:               job,
+			DeploymentCurrent: nil,
+			ExistingAllocs:    allocs,
+			EvalPriority:      50,
+		}, ClusterState{
+			TaintedNodes:                nodes,
+			SupportsDisconnectedClients: true,
+			Now:                         now,
+			MaintenanceGrace:            grace,
+		})
+	results := reconciler.Compute()
+
+	// The follow up eval waits for the lost_after and the maintenance window
+	// grace of the disconnected nodes.
+	evals := results.DesiredFollowupEvals[job.TaskGroups[0].Name]
+	must.SliceLen(t, 1, evals)
+	must.Eq(t, now.Add(15*time.Minute), evals[0].WaitUntil)
+}
+
+func TestReconciler_Disconnect_MaxRunTime(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+	buildBatchAllocs := func(clientStatus string, startedAt time.Time) (*structs.Job, []*structs.Allocation) {
+		job := mock.BatchJob()
+		tg := job.TaskGroups[0]
+		tg.Count = 2
+		tg.MaxRunTime = pointer.Of(30 * time.Minute)
+		tg.Disconnect = &structs.DisconnectStrategy{LostAfter: time.Hour}
+
+		allocs := buildAllocations(job, 2, clientStatus, structs.AllocDesiredStatusRun, 2)
+		for _, alloc := range allocs {
+			alloc.TaskStates = map[string]*structs.TaskState{
+				tg.Tasks[0].Name: {State: structs.TaskStateRunning, StartedAt: startedAt},
+			}
+			if clientStatus == structs.AllocClientStatusUnknown {
+				alloc.AllocStates = []*structs.AllocState{{
+					Field: structs.AllocStateFieldClientStatus,
+					Value: structs.AllocClientStatusUnknown,
+					Time:  now.Add(-10 * time.Minute),
+				}}
+			}
+		}
+		return job, allocs
+	}
+
+	reconcile := func(job *structs.Job, allocs []*structs.Allocation) *ReconcileResults {
+		reconciler := NewAllocReconciler(
+			testlog.HCLogger(t), allocUpdateFnIgnore, ReconcilerState{
+				JobIsBatch:     true,
+				JobID:          job.ID,
+				Job:            job,
+				ExistingAllocs: allocs,
+				EvalPriority:   50,
+			}, ClusterState{
+				TaintedNodes:                buildDisconnectedNodes(allocs, 2),
+				SupportsDisconnectedClients: true,
+				Now:                         now,
+			})
+		return reconciler.Compute()
+	}
+
+	t.Run("disconnecting", func(t *testing.T) {
+		job, allocs := buildBatchAllocs(structs.AllocClientStatusRunning, now.Add(-10*time.Minute))
+		results := reconcile(job, allocs)
+
+		// The follow up eval is created for the max run time expiry, which
+		// is before the allocs are lost.
+		evals := results.DesiredFollowupEvals[job.TaskGroups[0].Name]
+		must.SliceLen(t, 1, evals)
+		must.Eq(t, allocs[0].MaxRunTimeExpiry(), evals[0].WaitUntil)
+		must.MapLen(t, 2, results.DisconnectUpdates)
+		must.SliceEmpty(t, results.Stop)
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		job, allocs := buildBatchAllocs(structs.AllocClientStatusUnknown, now.Add(-2*time.Hour))
+		results := reconcile(job, allocs)
+
+		must.SliceLen(t, 2, results.Stop)
+		for _, stop := range results.Stop {
+			must.Eq(t, structs.AllocClientStatusFailed, stop.ClientStatus)
+			must.Eq(t, sstructs.StatusAllocMaxRunTimeExceeded, stop.StatusDescription)
+		}
+		must.SliceLen(t, 2, results.Place)
+	})
+
+	t.Run("complete on timeout", func(t *testing.T) {
+		job, allocs := buildBatchAllocs(structs.AllocClientStatusUnknown, now.Add(-2*time.Hour))
+		job.TaskGroups[0].OnTimeout = structs.TaskGroupOnTimeoutComplete
+		results := reconcile(job, allocs)
+
+		must.SliceEmpty(t, results.Stop)
+		must.SliceEmpty(t, results.Place)
+	})
+}
+
 func TestReconciler_Disconnect_UpdateJobAfterReconnect(t *testing.T) {
 	ci.Parallel(t)
 
@@ -17,6 +17,10 @@ type NodeReconciler struct {
 	DeploymentCurrent *structs.Deployment
 	DeploymentUpdates []*structs.DeploymentStatusUpdate
 
+	// MaintenanceGrace is the grace added to the disconnect lost_after of
+	// allocs on disconnected nodes in a maintenance window, by node ID.
+	MaintenanceGrace map[string]time.Duration
+
 	// COMPAT(1.14.0):
 	// compatHasSameVersionAllocs indicates that the reconciler found some
 	// allocations that were for the version being deployed
@@ -162,7 +166,7 @@ func (nr *NodeReconciler) computeForNode(
 				alloc.ClientStatus == structs.AllocClientStatusRunning) {
 			reconnect = alloc.NeedsToReconnect()
 			if reconnect {
-				expired = alloc.Expired(time.Now())
+				expired = alloc.ExpiredWithGrace(time.Now(), nr.MaintenanceGrace[alloc.NodeID])
 			}
 		}
 
@@ -438,6 +438,7 @@ func TestDiffSystemAllocsForNode_DisconnectedNode(t *testing.T) {
 		name    string
 		node    *structs.Node
 		allocFn func(*structs.Allocation)
+		grace   time.Duration
 		expect  diffResultCount
 	}{
 		{
@@ -495,6 +496,20 @@ func TestDiffSystemAllocsForNode_DisconnectedNode(t *testing.T) {
 			},
 			expect: diffResultCount{lost: 1},
 		},
+		{
+			name: "disconnected alloc is not lost within maintenance grace",
+			node: disconnectedNode,
+			allocFn: func(alloc *structs.Allocation) {
+				alloc.ClientStatus = structs.AllocClientStatusUnknown
+				alloc.AllocStates = []*structs.AllocState{{
+					Field: structs.AllocStateFieldClientStatus,
+					Value: structs.AllocClientStatusUnknown,
+					Time:  time.Now().Add(-2 * time.Hour),
+				}}
+			},
+			grace:  2 * time.Hour,
+			expect: diffResultCount{ignore: 1},
+		},
 		{
 			name: "disconnected allocs are ignored",
 			node: disconnectedNode,
@@ -522,6 +537,7 @@ func TestDiffSystemAllocsForNode_DisconnectedNode(t *testing.T) {
 			}
 
 			nr := NewNodeReconciler(nil)
+			nr.MaintenanceGrace = map[string]time.Duration{tc.node.ID: tc.grace}
 			got := nr.computeForNode(
 				job, tc.node.ID, eligibleNodes, nil, taintedNodes,
 				required, []*structs.Allocation{alloc}, terminal, true,
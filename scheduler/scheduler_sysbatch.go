@@ -6,6 +6,7 @@ package scheduler
 import (
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
@@ -230,8 +231,15 @@ func (s *SysBatchScheduler) computeJobAllocs() error {
 	// Split out terminal allocations
 	live, term := structs.SplitTerminalAllocs(allocs)
 
+	// Determine the grace of disconnected nodes in maintenance windows
+	grace, err := maintenanceGrace(s.state, tainted, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance windows for job '%s': %v", s.eval.JobID, err)
+	}
+
 	// Diff the required and existing allocations
 	nr := reconciler.NewNodeReconciler(nil)
+	nr.MaintenanceGrace = grace
 	r := nr.Compute(s.job, s.nodes, s.notReadyNodes, tainted, live, term,
 		s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true))
 	if s.logger.IsDebug() {
@@ -409,7 +417,8 @@ func (s *SysBatchScheduler) computePlacements(place []reconciler.AllocTuple, exi
 			Tasks:          option.TaskResources,
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
-				DiskMB: int64(missing.TaskGroup.EphemeralDisk.SizeMB),
+				DiskMB:    int64(missing.TaskGroup.EphemeralDisk.SizeMB),
+				DiskClass: missing.TaskGroup.EphemeralDisk.Class,
 			},
 		}
 
@@ -9,6 +9,7 @@ import (
 	"math"
 	"runtime/debug"
 	"slices"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
@@ -247,8 +248,15 @@ func (s *SystemScheduler) computeJobAllocs() error {
 	// Split out terminal allocations
 	live, term := structs.SplitTerminalAllocs(allocs)
 
+	// Determine the grace of disconnected nodes in maintenance windows
+	grace, err := maintenanceGrace(s.state, tainted, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance windows for job '%s': %v", s.eval.JobID, err)
+	}
+
 	// Diff the required and existing allocations
 	nr := reconciler.NewNodeReconciler(s.deployment)
+	nr.MaintenanceGrace = grace
 	reconciliationResult := nr.Compute(s.job, s.nodes, s.notReadyNodes, tainted,
 		live, term, s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true))
 	if s.logger.IsDebug() {
@@ -574,7 +582,8 @@ func (s *SystemScheduler) computePlacements(
 			Tasks:          option.TaskResources,
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
-				DiskMB: int64(missing.TaskGroup.EphemeralDisk.SizeMB),
+				DiskMB:    int64(missing.TaskGroup.EphemeralDisk.SizeMB),
+				DiskClass: missing.TaskGroup.EphemeralDisk.Class,
 			},
 		}
 
@@ -35,6 +35,11 @@ const (
 	// rescheduled
 	StatusAllocRescheduled = "alloc was rescheduled because it failed"
 
+	// StatusAllocMaxRunTimeExceeded is the status used when an allocation on a
+	// disconnected node is failed because it exceeded the max run time of its
+	// task group
+	StatusAllocMaxRunTimeExceeded = "alloc exceeded the max run time of its task group"
+
 	// DescBlockedEvalMaxPlan is the description used for blocked evals that are
 	// a result of hitting the max number of plan attempts
 	DescBlockedEvalMaxPlan = "created due to placement conflicts"
@@ -52,6 +52,10 @@ type State interface {
 	// NodePoolByName is used to lookup a node by ID.
 	NodePoolByName(ws memdb.WatchSet, poolName string) (*structs.NodePool, error)
 
+	// MaintenanceWindows returns an iterator over all maintenance windows.
+	// The type of each result is *structs.MaintenanceWindow
+	MaintenanceWindows(ws memdb.WatchSet) (memdb.ResultIterator, error)
+
 	// AllocsByJob returns the allocations by JobID
 	AllocsByJob(ws memdb.WatchSet, namespace, jobID string, all bool) ([]*structs.Allocation, error)
 
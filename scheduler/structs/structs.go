@@ -128,6 +128,7 @@ func (p *PlanBuilder) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, State
 		Deployment:        plan.Deployment,
 		DeploymentUpdates: plan.DeploymentUpdates,
 		EvalID:            plan.EvalID,
+		ScalingEvents:     plan.ScalingEvents,
 	}
 
 	var allocs []*structs.Allocation
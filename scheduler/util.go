@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strings"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler/feasible"
 	"github.com/hashicorp/nomad/scheduler/reconciler"
@@ -133,6 +136,189 @@ func taintedNodes(state sstructs.State, allocs []*structs.Allocation) (map[strin
 	return out, nil
 }
 
+// rescheduleNodePools returns the node pools with reschedule overrides of the
+// nodes with failed, lost or unknown allocs, keyed by node ID. The allocs on
+// tainted nodes which are down or disconnected are included too, as they are
+// about to be lost or unknown.
+func rescheduleNodePools(state sstructs.State, allocs []*structs.Allocation, tainted map[string]*structs.Node) (map[string]*structs.NodePool, error) {
+	out := make(map[string]*structs.NodePool)
+	pools := make(map[string]*structs.NodePool)
+	seen := make(map[string]struct{})
+	for _, alloc := range allocs {
+		switch alloc.ClientStatus {
+		case structs.AllocClientStatusFailed, structs.AllocClientStatusLost, structs.AllocClientStatusUnknown:
+		default:
+			node := tainted[alloc.NodeID]
+			if alloc.TerminalStatus() || node == nil ||
+				(node.Status != structs.NodeStatusDown && node.Status != structs.NodeStatusDisconnected) {
+				continue
+			}
+		}
+		if _, ok := seen[alloc.NodeID]; ok {
+			continue
+		}
+		seen[alloc.NodeID] = struct{}{}
+
+		node, err := state.NodeByID(nil, alloc.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			continue
+		}
+
+		pool, ok := pools[node.NodePool]
+		if !ok {
+			pool, err = state.NodePoolByName(nil, node.NodePool)
+			if err != nil {
+				return nil, err
+			}
+			pools[node.NodePool] = pool
+		}
+		if pool != nil && pool.RescheduleOverrides != nil {
+			out[alloc.NodeID] = pool
+		}
+	}
+
+	return out, nil
+}
+
+// maintenanceGrace returns the grace of the active maintenance windows which
+// select the disconnected tainted nodes, keyed by node ID.
+func maintenanceGrace(state sstructs.State, tainted map[string]*structs.Node, now time.Time) (map[string]time.Duration, error) {
+	iter, err := state.MaintenanceWindows(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []*structs.MaintenanceWindow
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		windows = append(windows, raw.(*structs.MaintenanceWindow))
+	}
+	if len(windows) == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string]time.Duration)
+	for nodeID, node := range tainted {
+		if node == nil || node.Status != structs.NodeStatusDisconnected {
+			continue
+		}
+		if window := structs.ActiveMaintenanceWindow(windows, node, now); window != nil {
+			out[nodeID] = window.Grace
+		}
+	}
+
+	return out, nil
+}
+
+// maxReplicateChanges is the number of value changes of a task group
+// replicated per node attribute listed in the message of its scaling event.
+const maxReplicateChanges = 10
+
+// replicatedJob returns a copy of job in which the count of every task group
+// replicated per node attribute is the number of distinct values of the
+// attribute, along with the scaling events of the task groups whose values
+// changed. The values are those of the feasible nodes, and those of the nodes
+// of allocs which are still running and not tainted, so that a value only
+// disappears once no node has it and the allocation for it is lost or
+// migrated. Canaries are not placed for a replicated task group, as they would
+// be second allocations for the same values, so its allocations are replaced
+// per value instead, up to max_parallel at a time. The job is returned
+// unchanged if no task group is replicated.
+func replicatedJob(ctx feasible.Context, job *structs.Job, allocs []*structs.Allocation,
+	tainted map[string]*structs.Node) (*structs.Job, map[string]*structs.ScalingEvent, error) {
+	if job == nil || job.Stopped() || job.RequiredReplicatePer().Empty() {
+		return job, nil, nil
+	}
+
+	nodes, _, _, err := readyNodesInDCsAndPool(ctx.State(), job.Datacenters, job.NodePool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job = job.Copy()
+	var events map[string]*structs.ScalingEvent
+	for _, tg := range job.TaskGroups {
+		if tg.ReplicatePer == "" {
+			continue
+		}
+
+		values := feasible.ReplicateValues(ctx, job, tg, nodes)
+		previous := make(map[string]struct{})
+		for _, alloc := range allocs {
+			if alloc.TaskGroup != tg.Name || alloc.ServerTerminalStatus() {
+				continue
+			}
+			node, err := ctx.State().NodeByID(nil, alloc.NodeID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if node == nil {
+				continue
+			}
+			value, ok := feasible.ReplicateValue(tg, node)
+			if !ok {
+				continue
+			}
+			previous[value] = struct{}{}
+			if _, isTainted := tainted[alloc.NodeID]; !isTainted && !alloc.ClientTerminalStatus() {
+				values[value] = struct{}{}
+			}
+		}
+
+		if event := replicateScalingEvent(tg, previous, values); event != nil {
+			if events == nil {
+				events = make(map[string]*structs.ScalingEvent)
+			}
+			events[tg.Name] = event
+		}
+		tg.Count = len(values)
+
+		if tg.Update != nil && tg.Update.Canary > 0 {
+			tg.Update.Canary = 0
+			tg.Update.AutoPromote = false
+		}
+	}
+
+	return job, events, nil
+}
+
+// replicateScalingEvent returns the scaling event explaining the count change
+// of a task group replicated per node attribute whose values changed from
+// previous to current, such as "rack r12 added", or nil if they are the same.
+func replicateScalingEvent(tg *structs.TaskGroup, previous, current map[string]struct{}) *structs.ScalingEvent {
+	name := strings.TrimSuffix(strings.TrimPrefix(tg.ReplicatePer, "${"), "}")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	var changes []string
+	for _, value := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := previous[value]; !ok {
+			changes = append(changes, fmt.Sprintf("%s %s added", name, value))
+		}
+	}
+	for _, value := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := current[value]; !ok {
+			changes = append(changes, fmt.Sprintf("%s %s removed", name, value))
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if len(changes) > maxReplicateChanges {
+		more := len(changes) - maxReplicateChanges
+		changes = append(changes[:maxReplicateChanges], fmt.Sprintf("and %d more", more))
+	}
+
+	event := structs.NewScalingEvent(strings.Join(changes, ", "))
+	event.Count = pointer.Of(int64(len(current)))
+	event.PreviousCount = int64(len(previous))
+	event.Meta = map[string]any{"replicate_per": tg.ReplicatePer}
+	return event
+}
+
 // comparison records the _first_ detected difference between two groups during
 // a comparison in tasksUpdated
 //
@@ -183,6 +369,12 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 		return c
 	}
 
+	// Check that the advertised address family hasn't changed, as it is used
+	// to assign the ports and to build the environment of the tasks
+	if a.AdvertiseMode != b.AdvertiseMode {
+		return difference("advertise mode", a.AdvertiseMode, b.AdvertiseMode)
+	}
+
 	// Check consul updated
 	if c := consulUpdated(a.Consul, b.Consul); c.modified {
 		return c
@@ -230,6 +422,9 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 		if !at.Vault.Equal(bt.Vault) {
 			return difference("task vault", at.Vault, bt.Vault)
 		}
+		if !slices.EqualFunc(at.Vaults, bt.Vaults, func(a, b *structs.Vault) bool { return a.Equal(b) }) {
+			return difference("task vaults", at.Vaults, bt.Vaults)
+		}
 		if !slices.EqualFunc(at.Secrets, bt.Secrets, func(a, b *structs.Secret) bool { return a.Equal(b) }) {
 			return difference("task secrets", at.Secrets, bt.Secrets)
 		}
@@ -483,6 +678,10 @@ func networkUpdated(netA, netB []*structs.NetworkResource) comparison {
 			return difference("network hostname", an.Hostname, bn.Hostname)
 		}
 
+		if an.PreferIPv6 != bn.PreferIPv6 {
+			return difference("network prefer ipv6", an.PreferIPv6, bn.PreferIPv6)
+		}
+
 		if !an.DNS.Equal(bn.DNS) {
 			return difference("network dns", an.DNS, bn.DNS)
 		}
@@ -678,9 +877,10 @@ func inplaceUpdate(ctx feasible.Context, eval *structs.Evaluation, job *structs.
 			Tasks:          option.TaskResources,
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
-				DiskMB:   int64(update.TaskGroup.EphemeralDisk.SizeMB),
-				Ports:    update.Alloc.AllocatedResources.Shared.Ports,
-				Networks: update.Alloc.AllocatedResources.Shared.Networks.Copy(),
+				DiskMB:    int64(update.TaskGroup.EphemeralDisk.SizeMB),
+				Ports:     update.Alloc.AllocatedResources.Shared.Ports,
+				Networks:  update.Alloc.AllocatedResources.Shared.Networks.Copy(),
+				DiskClass: update.TaskGroup.EphemeralDisk.Class,
 			},
 		}
 		newAlloc.Metrics = ctx.Metrics()
@@ -900,7 +1100,8 @@ func genericAllocUpdateFn(ctx feasible.Context, stack feasible.Stack, evalID str
 			Tasks:          option.TaskResources,
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
-				DiskMB: int64(newTG.EphemeralDisk.SizeMB),
+				DiskMB:    int64(newTG.EphemeralDisk.SizeMB),
+				DiskClass: newTG.EphemeralDisk.Class,
 			},
 		}
 
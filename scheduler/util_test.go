@@ -4,6 +4,7 @@
 package scheduler
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -196,6 +197,101 @@ func TestTaintedNodes(t *testing.T) {
 	must.Nil(t, tainted["12345678-abcd-efab-cdef-123456789abc"])
 }
 
+func TestRescheduleNodePools(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+
+	pool := mock.NodePool()
+	pool.RescheduleOverrides = &structs.NodePoolRescheduleOverrides{
+		Delay: 5 * time.Minute,
+	}
+	must.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 999,
+		[]*structs.NodePool{pool}))
+
+	node1 := mock.Node()
+	node1.NodePool = pool.Name
+	node2 := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node1))
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2))
+
+	allocs := []*structs.Allocation{
+		{NodeID: node1.ID, ClientStatus: structs.AllocClientStatusFailed},
+		{NodeID: node1.ID, ClientStatus: structs.AllocClientStatusLost},
+		{NodeID: node2.ID, ClientStatus: structs.AllocClientStatusFailed},
+		{NodeID: "12345678-abcd-efab-cdef-123456789abc", ClientStatus: structs.AllocClientStatusFailed},
+	}
+	pools, err := rescheduleNodePools(state, allocs, nil)
+	must.NoError(t, err)
+	must.MapLen(t, 1, pools)
+	must.Eq(t, pool.Name, pools[node1.ID].Name)
+
+	// allocs that won't be rescheduled don't need their node pool
+	allocs[0].ClientStatus = structs.AllocClientStatusRunning
+	allocs[1].ClientStatus = structs.AllocClientStatusComplete
+	pools, err = rescheduleNodePools(state, allocs, nil)
+	must.NoError(t, err)
+	must.MapEmpty(t, pools)
+
+	// unless they are on a node which is disconnecting or down
+	disconnected := node1.Copy()
+	disconnected.Status = structs.NodeStatusDisconnected
+	tainted := map[string]*structs.Node{node1.ID: disconnected}
+	pools, err = rescheduleNodePools(state, allocs, tainted)
+	must.NoError(t, err)
+	must.MapLen(t, 1, pools)
+	must.Eq(t, pool.Name, pools[node1.ID].Name)
+
+	// but not if they are already terminal, or the node is only draining
+	allocs[0].ClientStatus = structs.AllocClientStatusComplete
+	pools, err = rescheduleNodePools(state, allocs, tainted)
+	must.NoError(t, err)
+	must.MapEmpty(t, pools)
+
+	allocs[0].ClientStatus = structs.AllocClientStatusRunning
+	pools, err = rescheduleNodePools(state, allocs, map[string]*structs.Node{node1.ID: mock.DrainNode()})
+	must.NoError(t, err)
+	must.MapEmpty(t, pools)
+}
+
+func TestMaintenanceGrace(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+	now := time.Now()
+
+	node1 := mock.Node()
+	node1.Status = structs.NodeStatusDisconnected
+	node2 := mock.Node()
+	node2.Status = structs.NodeStatusDisconnected
+	node2.Datacenter = "dc2"
+	node3 := mock.DrainNode()
+	tainted := map[string]*structs.Node{
+		node1.ID:                               node1,
+		node2.ID:                               node2,
+		node3.ID:                               node3,
+		"12345678-abcd-efab-cdef-123456789abc": nil,
+	}
+
+	grace, err := maintenanceGrace(state, tainted, now)
+	must.NoError(t, err)
+	must.MapEmpty(t, grace)
+
+	window := mock.MaintenanceWindow()
+	window.Datacenter = "dc1"
+	must.NoError(t, state.UpsertMaintenanceWindows(structs.MsgTypeTestSetup, 1000,
+		[]*structs.MaintenanceWindow{window}))
+
+	// Only disconnected nodes selected by the window are given a grace.
+	grace, err = maintenanceGrace(state, tainted, now)
+	must.NoError(t, err)
+	must.Eq(t, map[string]time.Duration{node1.ID: window.Grace}, grace)
+
+	grace, err = maintenanceGrace(state, tainted, window.End())
+	must.NoError(t, err)
+	must.MapEmpty(t, grace)
+}
+
 func TestShuffleNodes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -456,6 +552,10 @@ func TestTasksUpdated(t *testing.T) {
 
 	must.True(t, tasksUpdated(j32, j33, name).modified)
 
+	// Change the advertise mode
+	j34 := j33.Copy()
+	j34.TaskGroups[0].AdvertiseMode = structs.AdvertiseModeIPv6
+	must.True(t, tasksUpdated(j33, j34, name).modified)
 }
 
 func TestTasksUpdated_connectServiceUpdated(t *testing.T) {
@@ -596,6 +696,16 @@ func TestNetworkUpdated(t *testing.T) {
 			},
 			updated: true,
 		},
+		{
+			name: "prefer_ipv6 updated",
+			a: []*structs.NetworkResource{
+				{Mode: "bridge"},
+			},
+			b: []*structs.NetworkResource{
+				{Mode: "bridge", PreferIPv6: true},
+			},
+			updated: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1346,6 +1456,37 @@ func TestUtil_UpdateNonTerminalAllocsToLost(t *testing.T) {
 	must.Eq(t, allocsLost, expected, must.Sprintf("actual: %v, expected: %v", allocsLost, expected))
 }
 
+func TestUtil_ReplicateScalingEvent(t *testing.T) {
+	ci.Parallel(t)
+
+	tg := &structs.TaskGroup{Name: "web", ReplicatePer: "${attr.platform.aws.placement.availability-zone}"}
+	values := func(vs ...string) map[string]struct{} {
+		out := make(map[string]struct{}, len(vs))
+		for _, v := range vs {
+			out[v] = struct{}{}
+		}
+		return out
+	}
+
+	must.Nil(t, replicateScalingEvent(tg, values("a", "b"), values("b", "a")))
+
+	event := replicateScalingEvent(tg, values("a", "b"), values("b", "c"))
+	must.NotNil(t, event)
+	must.Eq(t, "availability-zone c added, availability-zone a removed", event.Message)
+	must.Eq(t, 2, event.PreviousCount)
+	must.Eq(t, 2, *event.Count)
+	must.Eq(t, "${attr.platform.aws.placement.availability-zone}", event.Meta["replicate_per"])
+
+	// long lists of changes are truncated
+	var many []string
+	for i := 0; i < maxReplicateChanges+3; i++ {
+		many = append(many, fmt.Sprintf("v%02d", i))
+	}
+	event = replicateScalingEvent(tg, nil, values(many...))
+	must.StrHasSuffix(t, "availability-zone v09 added, and 3 more", event.Message)
+	must.Eq(t, maxReplicateChanges+3, *event.Count)
+}
+
 func TestTaskGroupUpdated_Restart(t *testing.T) {
 	ci.Parallel(t)
 